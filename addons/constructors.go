@@ -1,10 +1,43 @@
 package addons
 
 import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/rocket-pool/smartnode/addons/external"
 	"github.com/rocket-pool/smartnode/addons/graffiti_wall_writer"
+	"github.com/rocket-pool/smartnode/addons/rescue_node"
 	"github.com/rocket-pool/smartnode/shared/types/addons"
 )
 
+// The folder in the Smartnode directory that community addons are installed in
+const ExternalAddonsFolder string = "addons"
+
 func NewGraffitiWallWriter() addons.SmartnodeAddon {
 	return graffiti_wall_writer.NewGraffitiWallWriter()
 }
+
+func NewRescueNode() addons.SmartnodeAddon {
+	return rescue_node.NewRescueNode()
+}
+
+// Check if an addon ID belongs to one of the addons built into the Smartnode
+func IsBuiltinAddon(id string) bool {
+	return id == string(graffiti_wall_writer.ContainerID_GraffitiWallWriter) ||
+		id == rescue_node.AddonID
+}
+
+// Get the community addons installed in the provided Smartnode directory.
+// Addons that can't be loaded, or that clash with a built-in addon, are returned as errors.
+func NewExternalAddons(rpDir string) ([]addons.SmartnodeAddon, []error) {
+	externalAddons, errs := external.LoadAddons(filepath.Join(rpDir, ExternalAddonsFolder))
+	result := []addons.SmartnodeAddon{}
+	for _, addon := range externalAddons {
+		if IsBuiltinAddon(addon.GetID()) {
+			errs = append(errs, fmt.Errorf("addon [%s] has the same ID as a built-in addon", addon.GetID()))
+			continue
+		}
+		result = append(result, addon)
+	}
+	return result, errs
+}
@@ -0,0 +1,227 @@
+package external
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/types/addons"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Settings
+const (
+	enabledParameterID      string = "enabled"
+	containerTagParameterID string = "containerTag"
+	enabledEnvSuffix        string = "ENABLED"
+	containerTagEnvSuffix   string = "CONTAINER_TAG"
+	hookTimeout                    = 10 * time.Second
+)
+
+// A community addon described by a manifest instead of being built into the Smartnode
+type Addon struct {
+	manifest *Manifest
+	dir      string
+	cfg      *Config
+}
+
+// The settings of a community addon
+type Config struct {
+	Title        string
+	Enabled      *cfgtypes.Parameter
+	ContainerTag *cfgtypes.Parameter
+	Parameters   []*cfgtypes.Parameter
+}
+
+// Create an addon from its manifest and the folder it's installed in
+func NewAddon(manifest *Manifest, dir string) (*Addon, error) {
+	containerID := cfgtypes.ContainerID(manifest.ID)
+	envPrefix := getEnvPrefix(manifest.ID)
+
+	cfg := &Config{
+		Title: fmt.Sprintf("%s Settings", manifest.Name),
+		Enabled: &cfgtypes.Parameter{
+			ID:                   enabledParameterID,
+			Name:                 "Enabled",
+			Description:          fmt.Sprintf("Enable the %s addon", manifest.Name),
+			Type:                 cfgtypes.ParameterType_Bool,
+			Default:              map[cfgtypes.Network]interface{}{cfgtypes.Network_All: false},
+			AffectsContainers:    []cfgtypes.ContainerID{containerID},
+			EnvironmentVariables: []string{envPrefix + enabledEnvSuffix},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+		ContainerTag: &cfgtypes.Parameter{
+			ID:                   containerTagParameterID,
+			Name:                 "Container Tag",
+			Description:          "The tag name of the container you want to use on Docker Hub.",
+			Type:                 cfgtypes.ParameterType_String,
+			Default:              map[cfgtypes.Network]interface{}{cfgtypes.Network_All: manifest.ContainerTag},
+			AffectsContainers:    []cfgtypes.ContainerID{containerID},
+			EnvironmentVariables: []string{envPrefix + containerTagEnvSuffix},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   true,
+		},
+		Parameters: []*cfgtypes.Parameter{},
+	}
+
+	for _, manifestParam := range manifest.Parameters {
+		envVar := manifestParam.getEnvironmentVariable(envPrefix)
+		param := &cfgtypes.Parameter{
+			ID:                   manifestParam.ID,
+			Name:                 manifestParam.Name,
+			Description:          manifestParam.Description,
+			Type:                 cfgtypes.ParameterType(manifestParam.Type),
+			Advanced:             manifestParam.Advanced,
+			AffectsContainers:    []cfgtypes.ContainerID{containerID},
+			EnvironmentVariables: []string{envVar},
+			CanBeBlank:           manifestParam.CanBeBlank,
+			OverwriteOnUpgrade:   false,
+		}
+		switch param.Type {
+		case cfgtypes.ParameterType_String, cfgtypes.ParameterType_Bool, cfgtypes.ParameterType_Int, cfgtypes.ParameterType_Uint, cfgtypes.ParameterType_Float:
+		default:
+			return nil, fmt.Errorf("parameter [%s] of addon [%s] has unsupported type [%s]", param.ID, manifest.ID, param.Type)
+		}
+
+		// Parse the default the same way a saved setting is parsed, so it has the right type
+		param.Default = map[cfgtypes.Network]interface{}{cfgtypes.Network_All: ""}
+		err := param.Deserialize(map[string]string{param.ID: manifestParam.Default}, cfgtypes.Network_All)
+		if err != nil {
+			return nil, fmt.Errorf("invalid default for parameter [%s] of addon [%s]: %w", param.ID, manifest.ID, err)
+		}
+		param.Default[cfgtypes.Network_All] = param.Value
+		cfg.Parameters = append(cfg.Parameters, param)
+	}
+
+	return &Addon{
+		manifest: manifest,
+		dir:      dir,
+		cfg:      cfg,
+	}, nil
+}
+
+// Get the parameters for this config
+func (cfg *Config) GetParameters() []*cfgtypes.Parameter {
+	return append([]*cfgtypes.Parameter{cfg.Enabled, cfg.ContainerTag}, cfg.Parameters...)
+}
+
+// The the title for the config
+func (cfg *Config) GetConfigTitle() string {
+	return cfg.Title
+}
+
+func (a *Addon) GetID() string {
+	return a.manifest.ID
+}
+
+func (a *Addon) GetName() string {
+	return a.manifest.Name
+}
+
+func (a *Addon) GetDescription() string {
+	description := a.manifest.Description
+	if a.manifest.Version != "" {
+		description = fmt.Sprintf("%s\n\nVersion %s", description, a.manifest.Version)
+	}
+	return description + "\n\nThis is a community addon that isn't maintained by the Rocket Pool team."
+}
+
+func (a *Addon) GetConfig() cfgtypes.Config {
+	return a.cfg
+}
+
+func (a *Addon) GetContainerName() string {
+	return a.manifest.ID
+}
+
+func (a *Addon) GetContainerTag() string {
+	return a.manifest.ContainerTag
+}
+
+func (a *Addon) GetEnabledParameter() *cfgtypes.Parameter {
+	return a.cfg.Enabled
+}
+
+func (a *Addon) UpdateEnvVars(envVars map[string]string) error {
+	if a.cfg.Enabled.Value == true {
+		cfgtypes.AddParametersToEnvVars(a.cfg.GetParameters(), envVars)
+	}
+	return nil
+}
+
+// Get the manifest the addon was created from
+func (a *Addon) GetManifest() *Manifest {
+	return a.manifest
+}
+
+// Get the folder the addon is installed in
+func (a *Addon) GetFolder() string {
+	return a.dir
+}
+
+// Community addons ship their container template in their own folder
+func (a *Addon) GetTemplatePath() string {
+	template := a.manifest.Template
+	if template == "" {
+		template = DefaultTemplateFilename
+	}
+	return filepath.Join(a.dir, template)
+}
+
+// Send the node's details to the addon's task endpoint, if it has one
+func (a *Addon) RunTask(context addons.TaskContext) error {
+	if a.manifest.TaskUrl == "" {
+		return nil
+	}
+	body, err := json.Marshal(context)
+	if err != nil {
+		return fmt.Errorf("error serializing task context: %w", err)
+	}
+	httpClient := &http.Client{Timeout: hookTimeout}
+	response, err := httpClient.Post(a.manifest.TaskUrl, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("error calling the task endpoint of addon [%s]: %w", a.manifest.ID, err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		message, _ := io.ReadAll(response.Body)
+		return fmt.Errorf("task endpoint of addon [%s] failed (%s): %s", a.manifest.ID, response.Status, strings.TrimSpace(string(message)))
+	}
+	return nil
+}
+
+// Get the addon's status from its status endpoint; addons without one report nothing
+func (a *Addon) GetStatus() (addons.Status, error) {
+	if a.manifest.StatusUrl == "" {
+		return addons.Status{}, nil
+	}
+	httpClient := &http.Client{Timeout: hookTimeout}
+	response, err := httpClient.Get(a.manifest.StatusUrl)
+	if err != nil {
+		return addons.Status{}, fmt.Errorf("error calling the status endpoint of addon [%s]: %w", a.manifest.ID, err)
+	}
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return addons.Status{}, fmt.Errorf("error reading the status of addon [%s]: %w", a.manifest.ID, err)
+	}
+	if response.StatusCode != http.StatusOK {
+		return addons.Status{}, fmt.Errorf("status endpoint of addon [%s] failed (%s): %s", a.manifest.ID, response.Status, strings.TrimSpace(string(body)))
+	}
+	var status addons.Status
+	if err := json.Unmarshal(body, &status); err != nil {
+		return addons.Status{}, fmt.Errorf("error parsing the status of addon [%s]: %w", a.manifest.ID, err)
+	}
+	switch status.Level {
+	case addons.StatusLevel_Ok, addons.StatusLevel_Warning, addons.StatusLevel_Error:
+	default:
+		return addons.Status{}, fmt.Errorf("addon [%s] reported unknown status level [%s]", a.manifest.ID, status.Level)
+	}
+	return status, nil
+}
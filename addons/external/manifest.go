@@ -0,0 +1,176 @@
+package external
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Settings
+const (
+	ManifestFilename        string = "addon.yml"
+	DefaultTemplateFilename string = "addon.tmpl"
+)
+
+// Addon IDs are used in folder, section, environment variable and container names, so they're kept simple
+var idRegex = regexp.MustCompile("^[a-z][a-z0-9-]{0,31}$")
+
+// The characters allowed in the names of the environment variables of an addon's settings
+var envVarRegex = regexp.MustCompile("^[A-Z0-9_]+$")
+
+// The description of a community addon, read from the addon.yml file in its folder
+type Manifest struct {
+	ID           string              `yaml:"id"`
+	Name         string              `yaml:"name"`
+	Description  string              `yaml:"description"`
+	Version      string              `yaml:"version"`
+	ContainerTag string              `yaml:"containerTag"`
+	Template     string              `yaml:"template,omitempty"`
+	Parameters   []ManifestParameter `yaml:"parameters,omitempty"`
+
+	// Endpoints on the addon's container that the node daemon calls
+	TaskUrl   string `yaml:"taskUrl,omitempty"`
+	StatusUrl string `yaml:"statusUrl,omitempty"`
+}
+
+// A setting of a community addon
+type ManifestParameter struct {
+	ID          string `yaml:"id"`
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+	Type        string `yaml:"type"`
+	Default     string `yaml:"default"`
+	CanBeBlank  bool   `yaml:"canBeBlank,omitempty"`
+	Advanced    bool   `yaml:"advanced,omitempty"`
+
+	// Defaults to ADDON_<ID>_<PARAMETER ID>; a custom name must start with the same ADDON_<ID>_ prefix
+	EnvironmentVariable string `yaml:"environmentVariable,omitempty"`
+}
+
+// Read the manifest of the addon in the provided folder
+func LoadManifest(dir string) (*Manifest, error) {
+	path := filepath.Join(dir, ManifestFilename)
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading addon manifest [%s]: %w", path, err)
+	}
+	manifest := new(Manifest)
+	if err := yaml.Unmarshal(bytes, manifest); err != nil {
+		return nil, fmt.Errorf("error parsing addon manifest [%s]: %w", path, err)
+	}
+	if err := manifest.validate(); err != nil {
+		return nil, fmt.Errorf("invalid addon manifest [%s]: %w", path, err)
+	}
+	return manifest, nil
+}
+
+// Check that the manifest has everything the Smartnode needs to run the addon
+func (m *Manifest) validate() error {
+	if !idRegex.MatchString(m.ID) {
+		return fmt.Errorf("the ID [%s] must start with a letter and only contain lowercase letters, numbers and dashes (up to 32 characters)", m.ID)
+	}
+	if m.Name == "" {
+		return fmt.Errorf("the addon doesn't have a name")
+	}
+	if m.ContainerTag == "" {
+		return fmt.Errorf("the addon doesn't have a container tag")
+	}
+
+	// The template is rendered into the compose project, so it has to come from the addon's own folder
+	if m.Template != "" {
+		if filepath.IsAbs(m.Template) {
+			return fmt.Errorf("the template [%s] must be a path relative to the addon's folder", m.Template)
+		}
+		template, err := filepath.Rel(".", filepath.Clean(m.Template))
+		if err != nil || template == "." || template == ".." || strings.HasPrefix(template, ".."+string(filepath.Separator)) {
+			return fmt.Errorf("the template [%s] must be a file inside the addon's folder", m.Template)
+		}
+	}
+
+	ids := map[string]bool{enabledParameterID: true, containerTagParameterID: true}
+	envPrefix := getEnvPrefix(m.ID)
+	envVars := map[string]bool{envPrefix + enabledEnvSuffix: true, envPrefix + containerTagEnvSuffix: true}
+	for _, param := range m.Parameters {
+		if param.ID == "" {
+			return fmt.Errorf("a parameter doesn't have an ID")
+		}
+		if ids[param.ID] {
+			return fmt.Errorf("the parameter ID [%s] is used more than once or is reserved", param.ID)
+		}
+		ids[param.ID] = true
+
+		// Addon settings are applied after the Smartnode's own, so they're kept to the addon's prefix to stop them from overriding
+		// the variables every container shares, such as the client endpoints or the fee recipient
+		envVar := param.getEnvironmentVariable(envPrefix)
+		if param.EnvironmentVariable != "" && (!strings.HasPrefix(envVar, envPrefix) || envVar == envPrefix || !envVarRegex.MatchString(envVar)) {
+			return fmt.Errorf("the environment variable [%s] of parameter [%s] must start with [%s] and only contain uppercase letters, numbers and underscores", envVar, param.ID, envPrefix)
+		}
+		if envVars[envVar] {
+			return fmt.Errorf("the environment variable [%s] of parameter [%s] is used more than once or is reserved", envVar, param.ID)
+		}
+		envVars[envVar] = true
+	}
+	return nil
+}
+
+// Get the prefix of the environment variables of an addon's settings
+func getEnvPrefix(id string) string {
+	return "ADDON_" + strings.ToUpper(strings.ReplaceAll(id, "-", "_")) + "_"
+}
+
+// Get the environment variable a parameter is passed to the addon's container in
+func (p ManifestParameter) getEnvironmentVariable(envPrefix string) string {
+	if p.EnvironmentVariable != "" {
+		return p.EnvironmentVariable
+	}
+	return envPrefix + strings.ToUpper(p.ID)
+}
+
+// Get the community addons installed in the provided folder.
+// Folders without a manifest are skipped; the errors for ones with a broken manifest are returned alongside the addons that could be loaded.
+func LoadAddons(dir string) ([]*Addon, []error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return []*Addon{}, nil
+	}
+	if err != nil {
+		return []*Addon{}, []error{fmt.Errorf("error reading addons folder [%s]: %w", dir, err)}
+	}
+
+	addons := []*Addon{}
+	errs := []error{}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		addonDir := filepath.Join(dir, entry.Name())
+		if _, err := os.Stat(filepath.Join(addonDir, ManifestFilename)); err != nil {
+			continue
+		}
+		manifest, err := LoadManifest(addonDir)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if manifest.ID != entry.Name() {
+			errs = append(errs, fmt.Errorf("addon [%s] must be installed in a folder named [%s]", manifest.ID, manifest.ID))
+			continue
+		}
+		addon, err := NewAddon(manifest, addonDir)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		addons = append(addons, addon)
+	}
+	sort.Slice(addons, func(i, j int) bool {
+		return addons[i].manifest.ID < addons[j].manifest.ID
+	})
+	return addons, errs
+}
@@ -22,6 +22,10 @@ func NewGraffitiWallWriter() addons.SmartnodeAddon {
 	}
 }
 
+func (gww *GraffitiWallWriter) GetID() string {
+	return fmt.Sprint(ContainerID_GraffitiWallWriter)
+}
+
 func (gww *GraffitiWallWriter) GetName() string {
 	return "Graffiti Wall Writer"
 }
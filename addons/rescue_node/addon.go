@@ -0,0 +1,140 @@
+package rescue_node
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/types/addons"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+const (
+	AddonID string = "rescue-node"
+
+	// How long before the end of the delegation the status starts warning about it
+	expiryWarningPeriod time.Duration = 24 * time.Hour
+)
+
+type RescueNode struct {
+	cfg *RescueNodeConfig `yaml:"config,omitempty"`
+}
+
+func NewRescueNode() addons.SmartnodeAddon {
+	return &RescueNode{
+		cfg: NewConfig(),
+	}
+}
+
+func (rn *RescueNode) GetID() string {
+	return AddonID
+}
+
+func (rn *RescueNode) GetName() string {
+	return "Rescue Node"
+}
+
+func (rn *RescueNode) GetDescription() string {
+	return "This addon lets your Validator client temporarily fall back to a rescue node run by a third party while your own Beacon node is offline or resyncing, so your validators keep attesting in the meantime. The rescue node only accepts your node's credentials until they expire, and your Validator client goes back to your own Beacon node as soon as it's healthy again.\n\nThe addon doesn't run a container of its own; it configures your Validator client's fallback Beacon node."
+}
+
+func (rn *RescueNode) GetConfig() cfgtypes.Config {
+	return rn.cfg
+}
+
+// The rescue node runs elsewhere, so the addon doesn't have a container
+func (rn *RescueNode) GetContainerName() string {
+	return ""
+}
+
+func (rn *RescueNode) GetEnabledParameter() *cfgtypes.Parameter {
+	return &rn.cfg.Enabled
+}
+
+func (rn *RescueNode) GetContainerTag() string {
+	return ""
+}
+
+// Point the Validator client's fallback Beacon node at the rescue node while the delegation is active.
+// This replaces any fallback clients the node has configured until the delegation ends.
+func (rn *RescueNode) UpdateEnvVars(envVars map[string]string) error {
+	if !rn.IsActive(time.Now()) {
+		return nil
+	}
+	beaconUrl, err := rn.GetBeaconUrl()
+	if err != nil {
+		return err
+	}
+	envVars["FALLBACK_CC_API_ENDPOINT"] = beaconUrl
+	if envVars["CC_CLIENT"] == string(cfgtypes.ConsensusClient_Prysm) {
+		envVars["FALLBACK_CC_RPC_ENDPOINT"] = rn.cfg.PrysmUrl.Value.(string)
+	}
+	return nil
+}
+
+// Report how much of the delegation period is left
+func (rn *RescueNode) GetStatus() (addons.Status, error) {
+	end := rn.GetDelegationEnd()
+	remaining := time.Until(end)
+	if remaining <= 0 {
+		return addons.Status{
+			Level:   addons.StatusLevel_Error,
+			Message: fmt.Sprintf("The delegation to the rescue node ended on %s. Your Validator client keeps it as its fallback until you run `rocketpool node rescue-node stop` and `rocketpool service start`, or request new credentials.", end.Format(time.RFC1123)),
+		}, nil
+	}
+	level := addons.StatusLevel_Ok
+	if remaining < expiryWarningPeriod {
+		level = addons.StatusLevel_Warning
+	}
+	return addons.Status{
+		Level:   level,
+		Message: fmt.Sprintf("Delegating to the rescue node for another %s (until %s).", remaining.Round(time.Minute), end.Format(time.RFC1123)),
+	}, nil
+}
+
+// Get the time the delegation ends
+func (rn *RescueNode) GetDelegationEnd() time.Time {
+	return time.Unix(int64(rn.cfg.DelegationEnd.Value.(uint64)), 0)
+}
+
+// Check if the Validator client should be using the rescue node as its fallback at the provided time
+func (rn *RescueNode) IsActive(now time.Time) bool {
+	if rn.cfg.Enabled.Value != true {
+		return false
+	}
+	if rn.cfg.Username.Value.(string) == "" || rn.cfg.Password.Value.(string) == "" {
+		return false
+	}
+	return now.Before(rn.GetDelegationEnd())
+}
+
+// Get the rescue node's Beacon API URL with the node's credentials in it
+func (rn *RescueNode) GetBeaconUrl() (string, error) {
+	beaconUrl, err := url.Parse(rn.cfg.BeaconUrl.Value.(string))
+	if err != nil {
+		return "", fmt.Errorf("error parsing rescue node URL [%s]: %w", rn.cfg.BeaconUrl.Value.(string), err)
+	}
+	beaconUrl.User = url.UserPassword(rn.cfg.Username.Value.(string), rn.cfg.Password.Value.(string))
+	return beaconUrl.String(), nil
+}
+
+// Use a different rescue node than the default one
+func (rn *RescueNode) SetBeaconUrl(beaconUrl string) {
+	rn.cfg.BeaconUrl.Value = beaconUrl
+}
+
+// Start delegating to the rescue node with the provided credentials for the provided period
+func (rn *RescueNode) Start(username string, password string, period time.Duration, now time.Time) {
+	rn.cfg.Enabled.Value = true
+	rn.cfg.Username.Value = username
+	rn.cfg.Password.Value = password
+	rn.cfg.DelegationEnd.Value = uint64(now.Add(period).Unix())
+}
+
+// Stop delegating to the rescue node and forget the credentials
+func (rn *RescueNode) Stop() {
+	rn.cfg.Enabled.Value = false
+	rn.cfg.Username.Value = ""
+	rn.cfg.Password.Value = ""
+	rn.cfg.DelegationEnd.Value = uint64(0)
+}
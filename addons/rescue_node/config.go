@@ -0,0 +1,130 @@
+package rescue_node
+
+import (
+	"github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Constants
+const (
+	defaultBeaconUrl string = "https://rescue.rocketpool.net"
+	defaultPrysmUrl  string = "rescue.rocketpool.net:443"
+
+	// The length of a delegation in days if the node operator doesn't pick one
+	DefaultPeriodDays uint64 = 10
+)
+
+// Configuration for the Rescue Node
+type RescueNodeConfig struct {
+	Title string `yaml:"-"`
+
+	Enabled config.Parameter `yaml:"enabled,omitempty"`
+
+	// The Beacon API URL of the rescue node
+	BeaconUrl config.Parameter `yaml:"beaconUrl,omitempty"`
+
+	// The gRPC endpoint of the rescue node, used by Prysm
+	PrysmUrl config.Parameter `yaml:"prysmUrl,omitempty"`
+
+	// The credentials issued by the rescue node's operator
+	Username config.Parameter `yaml:"username,omitempty"`
+	Password config.Parameter `yaml:"password,omitempty"`
+
+	// The end of the delegation period, as a Unix timestamp
+	DelegationEnd config.Parameter `yaml:"delegationEnd,omitempty"`
+}
+
+// Creates a new configuration instance
+func NewConfig() *RescueNodeConfig {
+	return &RescueNodeConfig{
+		Title: "Rescue Node Settings",
+
+		Enabled: config.Parameter{
+			ID:                   "enabled",
+			Name:                 "Enabled",
+			Description:          "Enable this to delegate your validator duties to a rescue node while your own Beacon node is down or resyncing. Your Validator client keeps using your own Beacon node whenever it's healthy, and only switches to the rescue node when it isn't.\n\nUse `rocketpool node rescue-node start` to set this up instead of enabling it here.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Validator},
+			EnvironmentVariables: []string{"ADDON_RESCUE_NODE_ENABLED"},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		BeaconUrl: config.Parameter{
+			ID:                   "beaconUrl",
+			Name:                 "Beacon API URL",
+			Description:          "The URL of the rescue node's Beacon API.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: defaultBeaconUrl},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Validator},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		PrysmUrl: config.Parameter{
+			ID:                   "prysmUrl",
+			Name:                 "Prysm gRPC URL",
+			Description:          "The address of the rescue node's gRPC endpoint, which Prysm's Validator client connects to instead of the Beacon API.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: defaultPrysmUrl},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Validator},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		Username: config.Parameter{
+			ID:                   "username",
+			Name:                 "Username",
+			Description:          "The username the rescue node's operator gave you for your node's credentials.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Validator},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		Password: config.Parameter{
+			ID:                   "password",
+			Name:                 "Password",
+			Description:          "The password the rescue node's operator gave you for your node's credentials.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Validator},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		DelegationEnd: config.Parameter{
+			ID:                   "delegationEnd",
+			Name:                 "Delegation End",
+			Description:          "The time the delegation to the rescue node ends, as a Unix timestamp. After this, your Validator client only uses your own Beacon node again. This is set by `rocketpool node rescue-node start`.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(0)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Validator},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+	}
+}
+
+// Get the parameters for this config
+func (cfg *RescueNodeConfig) GetParameters() []*config.Parameter {
+	return []*config.Parameter{
+		&cfg.Enabled,
+		&cfg.BeaconUrl,
+		&cfg.PrysmUrl,
+		&cfg.Username,
+		&cfg.Password,
+		&cfg.DelegationEnd,
+	}
+}
+
+// The the title for the config
+func (cfg *RescueNodeConfig) GetConfigTitle() string {
+	return cfg.Title
+}
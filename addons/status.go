@@ -0,0 +1,65 @@
+package addons
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/types/addons"
+)
+
+// The status of the enabled addons, as last seen by the node daemon
+type StatusReport struct {
+	Time   time.Time     `json:"time"`
+	Addons []AddonStatus `json:"addons"`
+}
+
+// The status of a single addon
+type AddonStatus struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+
+	// What the addon reported, if it reports a status
+	Status *addons.Status `json:"status,omitempty"`
+	Error  string         `json:"error,omitempty"`
+
+	// The error from the addon's last daemon task, if it has one
+	TaskError string `json:"taskError,omitempty"`
+}
+
+// Save the report to the provided path
+func (r *StatusReport) Save(path string) error {
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return fmt.Errorf("error creating addon status directory: %w", err)
+	}
+	bytes, err := json.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("error serializing addon status: %w", err)
+	}
+	err = os.WriteFile(path, bytes, 0644)
+	if err != nil {
+		return fmt.Errorf("error writing addon status file [%s]: %w", path, err)
+	}
+	return nil
+}
+
+// Load the report from the provided path. Returns nil if the node daemon hasn't recorded one yet.
+func LoadStatusReport(path string) (*StatusReport, error) {
+	bytes, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading addon status file [%s]: %w", path, err)
+	}
+	var report StatusReport
+	err = json.Unmarshal(bytes, &report)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing addon status file [%s]: %w", path, err)
+	}
+	return &report, nil
+}
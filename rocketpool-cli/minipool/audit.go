@@ -0,0 +1,72 @@
+package minipool
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+const colorGreen string = "\033[32m"
+
+func auditMinipools(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Run the audit
+	fmt.Println("Checking your minipools against the deposit contract and the Beacon Chain. This can take a while for older nodes...")
+	response, err := rp.AuditMinipools()
+	if err != nil {
+		return err
+	}
+	if len(response.Minipools) == 0 {
+		fmt.Println("The node does not have any minipools with deposits to check.")
+		return nil
+	}
+
+	// Print the results
+	warnings := 0
+	criticals := 0
+	fmt.Printf("\nAudited %d minipools (deposits since block %d):\n\n", len(response.Minipools), response.StartBlock)
+	for _, minipool := range response.Minipools {
+		if len(minipool.Issues) == 0 {
+			if c.Bool("all") {
+				fmt.Printf("%s%s: OK%s (%s, %d deposits)\n", colorGreen, minipool.Address.Hex(), colorReset, minipool.MinipoolStatus.String(), minipool.DepositCount)
+			}
+			continue
+		}
+
+		fmt.Printf("%s (%s, pubkey %s):\n", minipool.Address.Hex(), minipool.MinipoolStatus.String(), minipool.ValidatorPubkey.Hex())
+		for _, issue := range minipool.Issues {
+			color := colorYellow
+			if issue.Severity == "critical" {
+				color = colorRed
+				criticals++
+			} else {
+				warnings++
+			}
+			fmt.Printf("\t%s[%s] %s%s\n", color, issue.Type, issue.Message, colorReset)
+		}
+		fmt.Println()
+	}
+
+	// Summarize
+	if warnings == 0 && criticals == 0 {
+		fmt.Printf("%sNo problems were found with any of your minipools.%s\n", colorGreen, colorReset)
+		return nil
+	}
+	if criticals > 0 {
+		fmt.Printf("%sFound %d critical problems. Minipools with the wrong withdrawal credentials or missing deposits will be scrubbed or can't be staked; please contact the Rocket Pool team on Discord for help.%s\n", colorRed, criticals, colorReset)
+	}
+	if warnings > 0 {
+		fmt.Printf("%sFound %d warnings. These don't put your minipools at risk of being scrubbed, but are worth looking into.%s\n", colorYellow, warnings, colorReset)
+	}
+	return nil
+
+}
@@ -1,6 +1,8 @@
 package minipool
 
 import (
+	"fmt"
+
 	"github.com/urfave/cli"
 
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
@@ -38,6 +40,29 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "audit",
+				Usage:     "Check every minipool's deposits and withdrawal credentials against the deposit contract and the Beacon Chain",
+				UsageText: "rocketpool minipool audit [options]",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "all, a",
+						Usage: "List minipools without any problems too",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return auditMinipools(c)
+
+				},
+			},
+
 			{
 				Name:      "stake",
 				Aliases:   []string{"t"},
@@ -393,6 +418,153 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:  "delegate",
+				Usage: "Check your minipools' delegate contracts and upgrade them in stages",
+				Subcommands: []cli.Command{
+
+					{
+						Name:      "status",
+						Aliases:   []string{"s"},
+						Usage:     "Show each minipool's delegate version compared to the latest one, and what upgrading changes",
+						UsageText: "rocketpool minipool delegate status",
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 0); err != nil {
+								return err
+							}
+
+							// Run
+							return getDelegateStatus(c)
+
+						},
+					},
+
+					{
+						Name:      "upgrade",
+						Aliases:   []string{"u"},
+						Usage:     "Upgrade minipools to the latest delegate one transaction at a time, pausing at the first failure",
+						UsageText: "rocketpool minipool delegate upgrade [options]",
+						Flags: []cli.Flag{
+							cli.StringFlag{
+								Name:  "minipool, m",
+								Usage: "The address of a single minipool to upgrade",
+							},
+							cli.BoolFlag{
+								Name:  "all, a",
+								Usage: "Upgrade all of the minipools that aren't using the latest delegate",
+							},
+							cli.Uint64Flag{
+								Name:  "rolling, r",
+								Usage: "Upgrade all eligible minipools in batches of this size, checking each batch before starting the next",
+							},
+							cli.BoolFlag{
+								Name:  "yes, y",
+								Usage: "Automatically confirm each upgrade and batch",
+							},
+						},
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 0); err != nil {
+								return err
+							}
+
+							// Validate flags
+							if c.String("minipool") != "" {
+								if _, err := cliutils.ValidateAddress("minipool address", c.String("minipool")); err != nil {
+									return err
+								}
+								if c.Bool("all") || c.Uint64("rolling") > 0 {
+									return fmt.Errorf("--minipool can't be used with --all or --rolling")
+								}
+							}
+
+							// Run
+							return upgradeDelegates(c)
+
+						},
+					},
+
+					{
+						Name:      "enable-latest",
+						Usage:     "Make minipools always use the latest delegate, without having to upgrade them",
+						UsageText: "rocketpool minipool delegate enable-latest [options]",
+						Flags: []cli.Flag{
+							cli.StringFlag{
+								Name:  "minipool, m",
+								Usage: "The address of a single minipool to change the setting for",
+							},
+							cli.BoolFlag{
+								Name:  "all, a",
+								Usage: "Change the setting for all of the node's minipools that it can be changed for",
+							},
+							cli.BoolFlag{
+								Name:  "yes, y",
+								Usage: "Automatically confirm the change",
+							},
+						},
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 0); err != nil {
+								return err
+							}
+
+							// Validate flags
+							if c.String("minipool") != "" {
+								if _, err := cliutils.ValidateAddress("minipool address", c.String("minipool")); err != nil {
+									return err
+								}
+							}
+
+							// Run
+							return setUseLatestDelegates(c, true)
+
+						},
+					},
+
+					{
+						Name:      "disable-latest",
+						Usage:     "Make minipools keep their current delegate until you upgrade them",
+						UsageText: "rocketpool minipool delegate disable-latest [options]",
+						Flags: []cli.Flag{
+							cli.StringFlag{
+								Name:  "minipool, m",
+								Usage: "The address of a single minipool to change the setting for",
+							},
+							cli.BoolFlag{
+								Name:  "all, a",
+								Usage: "Change the setting for all of the node's minipools that it can be changed for",
+							},
+							cli.BoolFlag{
+								Name:  "yes, y",
+								Usage: "Automatically confirm the change",
+							},
+						},
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 0); err != nil {
+								return err
+							}
+
+							// Validate flags
+							if c.String("minipool") != "" {
+								if _, err := cliutils.ValidateAddress("minipool address", c.String("minipool")); err != nil {
+									return err
+								}
+							}
+
+							// Run
+							return setUseLatestDelegates(c, false)
+
+						},
+					},
+				},
+			},
+
 			{
 				Name:      "delegate-upgrade",
 				Aliases:   []string{"u"},
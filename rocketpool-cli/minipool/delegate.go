@@ -363,3 +363,351 @@ func setUseLatestDelegateMinipools(c *cli.Context, setting bool) error {
 	return nil
 
 }
+
+// What changed in each version of the minipool delegate, relative to the one before it
+var delegateVersionChanges = map[uint8]string{
+	1: "The original delegate. Rewards can only be withdrawn by exiting the validator and closing the minipool.",
+	2: "Added distributing the minipool's balance, so skimmed rewards can be paid out to the node and rETH holders without exiting, and finalizing minipools after they exit.",
+	3: "Added bond reduction, vacant minipools for migrating solo validators, and changes to how balances are split that bond reduction relies on.",
+}
+
+func getDelegateStatus(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the delegates
+	status, err := rp.MinipoolDelegateStatus()
+	if err != nil {
+		return err
+	}
+	if len(status.Minipools) == 0 {
+		fmt.Println("The node does not have any minipools yet.")
+		return nil
+	}
+	fmt.Printf("The latest minipool delegate is %s (version %d).\n\n", status.LatestDelegate.Hex(), status.LatestDelegateVersion)
+
+	// Show each minipool's delegate
+	outdatedVersions := map[uint8]bool{}
+	upgradeable := 0
+	for _, mp := range status.Minipools {
+		var state string
+		switch {
+		case mp.Finalised:
+			state = "finalized, can't be upgraded"
+		case mp.UseLatestDelegate:
+			state = fmt.Sprintf("%suses the latest delegate automatically%s", colorGreen, colorReset)
+		case mp.Delegate == status.LatestDelegate:
+			state = fmt.Sprintf("%sup to date%s", colorGreen, colorReset)
+		default:
+			state = fmt.Sprintf("%supgrade available%s", colorYellow, colorReset)
+			outdatedVersions[mp.DelegateVersion] = true
+			upgradeable++
+		}
+		useLatest := "off"
+		if mp.UseLatestDelegate {
+			useLatest = "on"
+		}
+		fmt.Printf("%s: version %d (%s)\n", mp.Address.Hex(), mp.EffectiveDelegateVersion, state)
+		fmt.Printf("    use-latest-delegate: %s\n", useLatest)
+		if mp.PreviousDelegate != (common.Address{}) && !mp.Finalised {
+			fmt.Printf("    can roll back to version %d (%s)\n", mp.PreviousDelegateVersion, mp.PreviousDelegate.Hex())
+		}
+	}
+	if upgradeable == 0 {
+		fmt.Println("\nAll of your minipools that can be upgraded are using the latest delegate.")
+		return nil
+	}
+
+	// Explain what upgrading changes for the versions that are still in use
+	fmt.Printf("\n%d minipool(s) can be upgraded. Upgrading to version %d changes the following:\n", upgradeable, status.LatestDelegateVersion)
+	oldest := status.LatestDelegateVersion
+	for version := range outdatedVersions {
+		if version < oldest {
+			oldest = version
+		}
+	}
+	for version := oldest + 1; version <= status.LatestDelegateVersion; version++ {
+		change, exists := delegateVersionChanges[version]
+		if !exists {
+			change = "See the release notes of the Rocket Pool upgrade that introduced it."
+		}
+		fmt.Printf("    Version %d: %s\n", version, change)
+	}
+	fmt.Println("\nRun `rocketpool minipool delegate upgrade` to upgrade them, or `rocketpool minipool delegate enable-latest` to have them follow new delegates automatically. You can roll an upgraded minipool back to its previous delegate with `rocketpool minipool delegate-rollback`.")
+	return nil
+
+}
+
+func upgradeDelegates(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the minipools that can be upgraded
+	status, err := rp.MinipoolDelegateStatus()
+	if err != nil {
+		return err
+	}
+	minipools := []api.MinipoolDelegateDetails{}
+	for _, mp := range status.Minipools {
+		if !mp.Finalised && !mp.UseLatestDelegate && mp.Delegate != status.LatestDelegate {
+			minipools = append(minipools, mp)
+		}
+	}
+	if len(minipools) == 0 {
+		fmt.Println("No minipools are eligible for delegate upgrades.")
+		return nil
+	}
+
+	// Get selected minipools
+	selectedMinipools := []api.MinipoolDelegateDetails{}
+	if c.String("minipool") != "" {
+		selectedAddress := common.HexToAddress(c.String("minipool"))
+		for _, mp := range minipools {
+			if mp.Address == selectedAddress {
+				selectedMinipools = append(selectedMinipools, mp)
+				break
+			}
+		}
+		if len(selectedMinipools) == 0 {
+			return fmt.Errorf("Minipool %s is not eligible for a delegate upgrade.", selectedAddress.Hex())
+		}
+	} else if c.Bool("all") || c.Uint64("rolling") > 0 {
+		selectedMinipools = minipools
+	} else {
+		options := make([]string, len(minipools)+1)
+		options[0] = "All available minipools"
+		for mi, mp := range minipools {
+			options[mi+1] = fmt.Sprintf("%s (using delegate version %d)", mp.Address.Hex(), mp.DelegateVersion)
+		}
+		selected, _ := cliutils.Select("Please select a minipool to upgrade:", options)
+		if selected == 0 {
+			selectedMinipools = minipools
+		} else {
+			selectedMinipools = []api.MinipoolDelegateDetails{minipools[selected-1]}
+		}
+	}
+
+	// Stage the upgrades in batches; without a rolling batch size they're all done in one
+	batchSize := int(c.Uint64("rolling"))
+	if batchSize == 0 || batchSize > len(selectedMinipools) {
+		batchSize = len(selectedMinipools)
+	}
+
+	// Get the total gas limit estimate
+	var totalGas uint64 = 0
+	var totalSafeGas uint64 = 0
+	var gasInfo rocketpoolapi.GasInfo
+	for _, mp := range selectedMinipools {
+		canResponse, err := rp.CanDelegateUpgradeMinipool(mp.Address)
+		if err != nil {
+			return fmt.Errorf("error checking if minipool %s could be upgraded: %w", mp.Address.Hex(), err)
+		}
+		gasInfo = canResponse.GasInfo
+		totalGas += canResponse.GasInfo.EstGasLimit
+		totalSafeGas += canResponse.GasInfo.SafeGasLimit
+	}
+	gasInfo.EstGasLimit = totalGas
+	gasInfo.SafeGasLimit = totalSafeGas
+
+	// Assign max fees
+	err = gas.AssignMaxFeeAndLimit(gasInfo, rp, c.Bool("yes"))
+	if err != nil {
+		return err
+	}
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to upgrade %d minipools to delegate version %d, %d at a time?", len(selectedMinipools), status.LatestDelegateVersion, batchSize))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Upgrade the minipools one at a time, stopping at the first one that fails so the rest can be checked before retrying
+	upgraded := 0
+	for upgraded < len(selectedMinipools) {
+		end := upgraded + batchSize
+		if end > len(selectedMinipools) {
+			end = len(selectedMinipools)
+		}
+		for _, mp := range selectedMinipools[upgraded:end] {
+			if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Upgrade minipool %s from delegate version %d to %d?", mp.Address.Hex(), mp.DelegateVersion, status.LatestDelegateVersion))) {
+				fmt.Printf("Stopped before minipool %s. %d of %d minipools were upgraded.\n", mp.Address.Hex(), upgraded, len(selectedMinipools))
+				return nil
+			}
+			response, err := rp.DelegateUpgradeMinipool(mp.Address)
+			if err != nil {
+				pauseDelegateUpgrades(mp.Address, upgraded, len(selectedMinipools), err)
+				return nil
+			}
+			fmt.Printf("Upgrading minipool %s...\n", mp.Address.Hex())
+			cliutils.PrintTransactionHash(rp, response.TxHash)
+			if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
+				pauseDelegateUpgrades(mp.Address, upgraded, len(selectedMinipools), err)
+				return nil
+			}
+			fmt.Printf("Successfully upgraded minipool %s.\n", mp.Address.Hex())
+			upgraded++
+		}
+
+		// Check the batch actually took effect before moving on to the next one
+		if upgraded == len(selectedMinipools) {
+			break
+		}
+		batchStatus, err := rp.MinipoolDelegateStatus()
+		if err != nil {
+			return err
+		}
+		for _, mp := range batchStatus.Minipools {
+			for _, done := range selectedMinipools[:upgraded] {
+				if mp.Address == done.Address && mp.Delegate != batchStatus.LatestDelegate {
+					pauseDelegateUpgrades(mp.Address, upgraded, len(selectedMinipools), fmt.Errorf("its delegate is still %s", mp.Delegate.Hex()))
+					return nil
+				}
+			}
+		}
+		fmt.Printf("\n%d of %d minipools have been upgraded.\n", upgraded, len(selectedMinipools))
+		if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Continue with the next %d?", batchSize))) {
+			fmt.Println("Stopped. Run this command again to upgrade the rest.")
+			return nil
+		}
+	}
+
+	// Return
+	fmt.Printf("All %d minipools have been upgraded.\n", upgraded)
+	return nil
+
+}
+
+// Report the upgrade that failed and how far the staged upgrade got
+func pauseDelegateUpgrades(address common.Address, upgraded int, total int, err error) {
+	fmt.Printf("%sCould not upgrade minipool %s: %s.%s\n", colorRed, address.Hex(), err.Error(), colorReset)
+	fmt.Printf("The remaining upgrades have been paused; %d of %d minipools were upgraded. Run this command again once you've looked into the failure.\n", upgraded, total)
+}
+
+// Explain what the use-latest-delegate setting trusts before it's changed
+func printUseLatestDelegateTradeoff(setting bool) {
+	if setting {
+		fmt.Printf("%sWith use-latest-delegate enabled, a minipool always runs whichever delegate the Rocket Pool protocol DAO has most recently released, as soon as it's released.\n"+
+			"You won't have to upgrade it yourself, but you also won't get to review a new delegate or wait for others to try it first, and you can't roll back to the previous one while the setting is on.%s\n\n", colorYellow, colorReset)
+	} else {
+		fmt.Printf("%sWith use-latest-delegate disabled, a minipool keeps running the delegate it's upgraded to until you upgrade it again.\n"+
+			"You decide when to trust a new delegate, but you have to upgrade it yourself (or enable automatic upgrades in the Smartnode settings) to get new features and fixes.%s\n\n", colorYellow, colorReset)
+	}
+}
+
+func setUseLatestDelegates(c *cli.Context, setting bool) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the minipools the setting can be changed for
+	canResponse, err := rp.CanSetUseLatestDelegateMinipools(setting)
+	if err != nil {
+		return err
+	}
+	minipools := []api.MinipoolUseLatestDelegateDetails{}
+	for _, mp := range canResponse.Minipools {
+		if mp.CanChange {
+			minipools = append(minipools, mp)
+		}
+	}
+
+	// Get selected minipools
+	selectedMinipools := []api.MinipoolUseLatestDelegateDetails{}
+	if c.String("minipool") != "" {
+		selectedAddress := common.HexToAddress(c.String("minipool"))
+		for _, mp := range canResponse.Minipools {
+			if mp.Address != selectedAddress {
+				continue
+			}
+			if !mp.CanChange {
+				return fmt.Errorf("Minipool %s can't have its use-latest-delegate setting changed: %s.", selectedAddress.Hex(), mp.Reason)
+			}
+			selectedMinipools = append(selectedMinipools, mp)
+		}
+		if len(selectedMinipools) == 0 {
+			return fmt.Errorf("Minipool %s doesn't belong to this node.", selectedAddress.Hex())
+		}
+	} else {
+		if len(minipools) == 0 {
+			fmt.Printf("No minipools can have their use-latest-delegate setting changed to %t.\n", setting)
+			return nil
+		}
+		if c.Bool("all") {
+			selectedMinipools = minipools
+		} else {
+			options := make([]string, len(minipools)+1)
+			options[0] = "All available minipools"
+			for mi, mp := range minipools {
+				options[mi+1] = mp.Address.Hex()
+			}
+			selected, _ := cliutils.Select("Please select a minipool to change the setting for:", options)
+			if selected == 0 {
+				selectedMinipools = minipools
+			} else {
+				selectedMinipools = []api.MinipoolUseLatestDelegateDetails{minipools[selected-1]}
+			}
+		}
+	}
+
+	// Explain the trade-off before anything is changed
+	printUseLatestDelegateTradeoff(setting)
+
+	// Get the total gas limit estimate
+	var totalGas uint64 = 0
+	var totalSafeGas uint64 = 0
+	var gasInfo rocketpoolapi.GasInfo
+	for _, mp := range selectedMinipools {
+		gasInfo = mp.GasInfo
+		totalGas += mp.GasInfo.EstGasLimit
+		totalSafeGas += mp.GasInfo.SafeGasLimit
+	}
+	gasInfo.EstGasLimit = totalGas
+	gasInfo.SafeGasLimit = totalSafeGas
+
+	// Assign max fees
+	err = gas.AssignMaxFeeAndLimit(gasInfo, rp, c.Bool("yes"))
+	if err != nil {
+		return err
+	}
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to set use-latest-delegate to %t for %d minipools?", setting, len(selectedMinipools)))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Update minipools
+	for _, mp := range selectedMinipools {
+		response, err := rp.SetUseLatestDelegateMinipool(mp.Address, setting)
+		if err != nil {
+			fmt.Printf("Could not update the use-latest-delegate setting for minipool %s: %s.\n", mp.Address.Hex(), err)
+			continue
+		}
+
+		fmt.Printf("Updating the use-latest-delegate setting for minipool %s...\n", mp.Address.Hex())
+		cliutils.PrintTransactionHash(rp, response.TxHash)
+		if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
+			fmt.Printf("Could not update the use-latest-delegate setting for minipool %s: %s.\n", mp.Address.Hex(), err)
+		} else {
+			fmt.Printf("Successfully updated the setting for minipool %s.\n", mp.Address.Hex())
+		}
+	}
+
+	// Return
+	return nil
+
+}
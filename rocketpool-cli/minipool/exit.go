@@ -98,6 +98,19 @@ func exitMinipools(c *cli.Context) error {
 		return nil
 	}
 
+	// Check the confirmation policy
+	invocations := make([][]string, len(selectedMinipools))
+	for i, minipool := range selectedMinipools {
+		invocations[i] = []string{minipool.Address.Hex()}
+	}
+	ready, err := cliutils.ConfirmProtectedCommand(rp, "minipool/exit", invocations...)
+	if err != nil {
+		return err
+	}
+	if !ready {
+		return nil
+	}
+
 	// Exit minipools
 	for _, minipool := range selectedMinipools {
 		if _, err := rp.ExitMinipool(minipool.Address); err != nil {
@@ -14,7 +14,6 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
-	"github.com/rocket-pool/smartnode/shared/utils/math"
 	"github.com/urfave/cli"
 )
 
@@ -143,19 +142,7 @@ func beginReduceBondAmount(c *cli.Context) error {
 			return fmt.Errorf("couldn't check if minipool %s could have its bond reduced: %s)", minipool.Address.Hex(), err.Error())
 		} else {
 			if !canResponse.CanReduce {
-				fmt.Printf("Cannot reduce bond for minipool %s:\n", minipool.Address.Hex())
-				if canResponse.BondReductionDisabled {
-					fmt.Println("Bond reductions are currently disabled.")
-				}
-				if canResponse.MinipoolVersionTooLow {
-					fmt.Println("The minipool version is too low. It must be upgraded first using `rocketpool minipool delegate-upgrade`.")
-				}
-				if canResponse.BalanceTooLow {
-					fmt.Printf("The minipool's validator balance on the Beacon Chain is too low (must be 32 ETH or higher, currently %.6f ETH).\n", math.RoundDown(float64(canResponse.Balance)/1e9, 6))
-				}
-				if canResponse.InvalidBeaconState {
-					fmt.Printf("The minipool's validator is not in a legal state on the Beacon Chain. It must be pending or active (current state: %s)\n", canResponse.BeaconState)
-				}
+				cliutils.PrintPreflightResult(fmt.Sprintf("Cannot reduce bond for minipool %s:", minipool.Address.Hex()), canResponse.Preflight)
 				return nil
 			}
 			gasInfo = canResponse.GasInfo
@@ -34,6 +34,7 @@ func getStatus(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	fiat := cliutils.NewFiatFormatter(rp)
 
 	// Get minipools by status
 	statusMinipools := map[string][]api.MinipoolDetails{}
@@ -91,7 +92,7 @@ func getStatus(c *cli.Context) error {
 		// Minipools
 		for _, minipool := range minipools {
 			if !minipool.Finalised || c.Bool("include-finalized") {
-				printMinipoolDetails(minipool, status.LatestDelegate)
+				printMinipoolDetails(minipool, status.LatestDelegate, fiat)
 			}
 		}
 
@@ -105,7 +106,7 @@ func getStatus(c *cli.Context) error {
 
 		// Minipools
 		for _, minipool := range finalisedMinipools {
-			printMinipoolDetails(minipool, status.LatestDelegate)
+			printMinipoolDetails(minipool, status.LatestDelegate, fiat)
 		}
 	} else {
 		fmt.Printf("%d finalized minipool(s) (hidden)\n", len(finalisedMinipools))
@@ -135,7 +136,7 @@ func getStatus(c *cli.Context) error {
 
 }
 
-func printMinipoolDetails(minipool api.MinipoolDetails, latestDelegate common.Address) {
+func printMinipoolDetails(minipool api.MinipoolDetails, latestDelegate common.Address, fiat *cliutils.FiatFormatter) {
 
 	fmt.Printf("--------------------\n")
 	fmt.Printf("\n")
@@ -151,7 +152,7 @@ func printMinipoolDetails(minipool api.MinipoolDetails, latestDelegate common.Ad
 	}
 	fmt.Printf("Status updated:        %s\n", minipool.Status.StatusTime.Format(TimeFormat))
 	fmt.Printf("Node fee:              %f%%\n", minipool.Node.Fee*100)
-	fmt.Printf("Node deposit:          %.6f ETH\n", math.RoundDown(eth.WeiToEth(minipool.Node.DepositBalance), 6))
+	fmt.Printf("Node deposit:          %.6f ETH%s\n", math.RoundDown(eth.WeiToEth(minipool.Node.DepositBalance), 6), fiat.Eth(eth.WeiToEth(minipool.Node.DepositBalance)))
 
 	// Queue position
 	if minipool.Queue.Position != 0 {
@@ -167,10 +168,10 @@ func printMinipoolDetails(minipool api.MinipoolDetails, latestDelegate common.Ad
 		} else {
 			fmt.Printf("RP ETH assigned:       no\n")
 		}
-		fmt.Printf("Minipool Balance (EL): %.6f ETH\n", math.RoundDown(eth.WeiToEth(minipool.Balances.ETH), 6))
-		fmt.Printf("Your portion:          %.6f ETH\n", math.RoundDown(eth.WeiToEth(minipool.NodeShareOfETHBalance), 6))
-		fmt.Printf("Available refund:      %.6f ETH\n", math.RoundDown(eth.WeiToEth(minipool.Node.RefundBalance), 6))
-		fmt.Printf("Total EL rewards:      %.6f ETH\n", math.RoundDown(eth.WeiToEth(totalRewards), 6))
+		fmt.Printf("Minipool Balance (EL): %.6f ETH%s\n", math.RoundDown(eth.WeiToEth(minipool.Balances.ETH), 6), fiat.Eth(eth.WeiToEth(minipool.Balances.ETH)))
+		fmt.Printf("Your portion:          %.6f ETH%s\n", math.RoundDown(eth.WeiToEth(minipool.NodeShareOfETHBalance), 6), fiat.Eth(eth.WeiToEth(minipool.NodeShareOfETHBalance)))
+		fmt.Printf("Available refund:      %.6f ETH%s\n", math.RoundDown(eth.WeiToEth(minipool.Node.RefundBalance), 6), fiat.Eth(eth.WeiToEth(minipool.Node.RefundBalance)))
+		fmt.Printf("Total EL rewards:      %.6f ETH%s\n", math.RoundDown(eth.WeiToEth(totalRewards), 6), fiat.Eth(eth.WeiToEth(totalRewards)))
 	}
 
 	// Validator details - prelaunch and staking minipools
@@ -184,8 +185,8 @@ func printMinipoolDetails(minipool api.MinipoolDetails, latestDelegate common.Ad
 			} else {
 				fmt.Printf("Validator active:      no\n")
 			}
-			fmt.Printf("Beacon balance (CL):   %.6f ETH\n", math.RoundDown(eth.WeiToEth(minipool.Validator.Balance), 6))
-			fmt.Printf("Your portion:          %.6f ETH\n", math.RoundDown(eth.WeiToEth(minipool.Validator.NodeBalance), 6))
+			fmt.Printf("Beacon balance (CL):   %.6f ETH%s\n", math.RoundDown(eth.WeiToEth(minipool.Validator.Balance), 6), fiat.Eth(eth.WeiToEth(minipool.Validator.Balance)))
+			fmt.Printf("Your portion:          %.6f ETH%s\n", math.RoundDown(eth.WeiToEth(minipool.Validator.NodeBalance), 6), fiat.Eth(eth.WeiToEth(minipool.Validator.NodeBalance)))
 		} else {
 			fmt.Printf("Validator seen:        no\n")
 		}
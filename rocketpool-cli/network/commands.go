@@ -18,7 +18,13 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				Name:      "stats",
 				Aliases:   []string{"s"},
 				Usage:     "Get stats about the Rocket Pool network and its tokens",
-				UsageText: "rocketpool network stats",
+				UsageText: "rocketpool network stats [options]",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "apr, a",
+						Usage: "Also show the trailing rETH APR and an estimate of the node's APR, which takes longer to calculate",
+					},
+				},
 				Action: func(c *cli.Context) error {
 
 					// Validate args
@@ -32,6 +38,46 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "export-stats",
+				Usage:     "Export a snapshot of network-wide stats, signed by the node wallet, for publishing to community dashboards",
+				UsageText: "rocketpool network export-stats [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "output, o",
+						Usage: "The file to save the snapshot to (prints it if not set)",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return exportStats(c)
+
+				},
+			},
+
+			{
+				Name:      "verify-stats",
+				Usage:     "Verify the signature of an exported network stats snapshot",
+				UsageText: "rocketpool network verify-stats file",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					// Run
+					return verifyStats(c, c.Args().Get(0))
+
+				},
+			},
+
 			{
 				Name:      "timezone-map",
 				Aliases:   []string{"t"},
@@ -54,7 +100,18 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				Name:      "node-fee",
 				Aliases:   []string{"f"},
 				Usage:     "Get the current network node commission rate",
-				UsageText: "rocketpool network node-fee",
+				UsageText: "rocketpool network node-fee [options]",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "history",
+						Usage: "Show the commission curve and how the commission of new minipools has moved",
+					},
+					cli.Uint64Flag{
+						Name:  "days, d",
+						Usage: "The number of days of history to show",
+						Value: 30,
+					},
+				},
 				Action: func(c *cli.Context) error {
 
 					// Validate args
@@ -135,6 +192,24 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 
 				},
 			},
+
+			{
+				Name:      "contracts",
+				Aliases:   []string{"c"},
+				Usage:     "List the current address and version of each Rocket Pool contract",
+				UsageText: "rocketpool network contracts",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getContracts(c)
+
+				},
+			},
 		},
 	})
 }
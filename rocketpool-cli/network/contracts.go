@@ -0,0 +1,37 @@
+package network
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+func getContracts(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the contracts
+	response, err := rp.NetworkContracts()
+	if err != nil {
+		return err
+	}
+
+	// Print & return
+	fmt.Printf("%-40s %-44s %-8s %s\n", "Contract", "Address", "Version", "Last Upgraded")
+	for _, contract := range response.Contracts {
+		upgraded := "never"
+		if contract.UpgradedBlock > 0 {
+			upgraded = fmt.Sprintf("block %d (%d previous addresses)", contract.UpgradedBlock, len(contract.PreviousAddresses))
+		}
+		fmt.Printf("%-40s %-44s %-8d %s\n", contract.Name, contract.Address.Hex(), contract.Version, upgraded)
+	}
+	return nil
+
+}
@@ -3,7 +3,9 @@ package network
 import (
 	"fmt"
 	"strconv"
+	"time"
 
+	"github.com/rocket-pool/smartnode/shared/services/progress"
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 	"github.com/urfave/cli"
@@ -13,6 +15,8 @@ const (
 	colorReset  string = "\033[0m"
 	colorGreen  string = "\033[32m"
 	colorYellow string = "\033[33m"
+
+	rewardsTreeProgressInterval = 2 * time.Second
 )
 
 func generateRewardsTree(c *cli.Context) error {
@@ -78,6 +82,7 @@ func generateRewardsTree(c *cli.Context) error {
 	fmt.Printf("Your request to generate the rewards tree for interval %d has been applied, and your `watchtower` container will begin the process during its next duty check (typically 5 minutes).\nYou can follow its progress with %s`rocketpool service logs watchtower`%s.\n\n", index, colorGreen, colorReset)
 
 	if c.Bool("yes") || cliutils.Confirm("Would you like to restart the watchtower container now, so it starts generating the file immediately?") {
+		restartTime := time.Now()
 		container := fmt.Sprintf("%s_watchtower", cfg.Smartnode.ProjectName.Value.(string))
 		response, err := rp.RestartContainer(container)
 		if err != nil {
@@ -88,8 +93,50 @@ func generateRewardsTree(c *cli.Context) error {
 		}
 
 		fmt.Println("Done!")
+
+		// Follow the generation on terminals, since it can take a long time
+		bar := progress.NewBar(c.GlobalBool("quiet"))
+		if bar.Enabled() {
+			return followRewardsTreeGeneration(rp, bar, index, restartTime)
+		}
 	}
 
 	return nil
 
 }
+
+// Show the watchtower's progress on the tree until it's done
+func followRewardsTreeGeneration(rp *rocketpool.Client, bar *progress.Bar, index uint64, requestTime time.Time) error {
+
+	fmt.Println("Following the generation... you can press Ctrl+C to stop following it, and the watchtower will keep going in the background.")
+	defer bar.Clear()
+
+	waiting := progress.Update{
+		Phase:   "Waiting for the watchtower to start",
+		Started: requestTime,
+	}
+	for {
+		response, err := rp.GetRewardsTreeProgress()
+		if err != nil {
+			return err
+		}
+
+		// Anything saved before the request is from an earlier run
+		update := response.Progress
+		if update == nil || update.Started.Before(requestTime) {
+			bar.Update(waiting)
+		} else if update.Done {
+			bar.Clear()
+			if update.Error != "" {
+				return fmt.Errorf("Generating the rewards tree for interval %d failed: %s", index, update.Error)
+			}
+			fmt.Printf("The rewards tree for interval %d has been generated. Check %s`rocketpool service logs watchtower`%s to see if its root matches the canonical one.\n", index, colorGreen, colorReset)
+			return nil
+		} else {
+			bar.Update(*update)
+		}
+
+		time.Sleep(rewardsTreeProgressInterval)
+	}
+
+}
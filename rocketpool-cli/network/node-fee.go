@@ -3,6 +3,7 @@ package network
 import (
 	"fmt"
 
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
@@ -17,6 +18,10 @@ func getNodeFee(c *cli.Context) error {
 	}
 	defer rp.Close()
 
+	if c.Bool("history") {
+		return getNodeFeeHistory(rp, c.Uint64("days"))
+	}
+
 	// Get node fee
 	response, err := rp.NodeFee()
 	if err != nil {
@@ -31,3 +36,41 @@ func getNodeFee(c *cli.Context) error {
 	return nil
 
 }
+
+func getNodeFeeHistory(rp *rocketpool.Client, days uint64) error {
+
+	// Get the fee history
+	if days == 0 {
+		return fmt.Errorf("The number of days must be greater than 0.")
+	}
+	response, err := rp.NodeFeeHistory(days)
+	if err != nil {
+		return err
+	}
+
+	// Print the curve
+	fmt.Printf("%s=== Commission Curve ===%s\n", colorGreen, colorReset)
+	fmt.Printf("The commission moves between %.2f%% and %.2f%% with the demand for node deposits (the ETH in the deposit pool minus the ETH needed by the queue), reaching the target of %.2f%% when demand is zero.\n", response.MinNodeFee*100, response.MaxNodeFee*100, response.TargetNodeFee*100)
+	fmt.Printf("%-20s %12s\n", "Demand (ETH)", "Commission")
+	for _, point := range response.Curve {
+		fmt.Printf("%-20.2f %11.2f%%\n", eth.WeiToEth(point.Demand), point.Fee*100)
+	}
+	fmt.Println()
+
+	// Print the history
+	fmt.Printf("%s=== New Minipools (last %d days) ===%s\n", colorGreen, response.Days, colorReset)
+	if len(response.History) == 0 {
+		fmt.Println("No minipools were created in this period.")
+	} else {
+		fmt.Printf("%-12s %10s %10s %10s %10s\n", "Date", "Minipools", "Min", "Average", "Max")
+		for _, day := range response.History {
+			fmt.Printf("%-12s %10d %9.2f%% %9.2f%% %9.2f%%\n", day.Date.Format("2006-01-02"), day.Minipools, day.MinFee*100, day.AverageFee*100, day.MaxFee*100)
+		}
+	}
+	fmt.Println()
+
+	// Print the current fee
+	fmt.Printf("Current demand is %.2f ETH, so a minipool created now would lock in a commission of %s%.2f%%%s for its lifetime.\n", eth.WeiToEth(response.NodeDemand), colorGreen, response.NodeFee*100, colorReset)
+	return nil
+
+}
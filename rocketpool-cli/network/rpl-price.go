@@ -7,6 +7,7 @@ import (
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 	"github.com/rocket-pool/smartnode/shared/utils/math"
 )
 
@@ -24,9 +25,10 @@ func getRplPrice(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	fiat := cliutils.NewFiatFormatter(rp)
 
 	// Print & return
-	fmt.Printf("The current network RPL price is %.6f ETH.\n", math.RoundDown(eth.WeiToEth(response.RplPrice), 6))
+	fmt.Printf("The current network RPL price is %.6f ETH%s.\n", math.RoundDown(eth.WeiToEth(response.RplPrice), 6), fiat.Eth(eth.WeiToEth(response.RplPrice)))
 	fmt.Printf("Prices last updated at block: %d\n", response.RplPriceBlock)
 	return nil
 
@@ -0,0 +1,74 @@
+package network
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/netstats"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+func exportStats(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the snapshot
+	response, err := rp.NetworkStatsSnapshot()
+	if err != nil {
+		return err
+	}
+	bytes, err := json.MarshalIndent(response.Snapshot, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error serializing snapshot: %w", err)
+	}
+
+	// Print it or write it to the output file
+	output := c.String("output")
+	if output == "" {
+		fmt.Println(string(bytes))
+		return nil
+	}
+	if err := os.WriteFile(output, bytes, 0644); err != nil {
+		return fmt.Errorf("error writing snapshot to %s: %w", output, err)
+	}
+	if response.Snapshot.Signature == "" {
+		fmt.Printf("Saved an unsigned snapshot to %s; initialize the node wallet to sign snapshots.\n", output)
+	} else {
+		fmt.Printf("Saved a snapshot signed by %s to %s.\n", response.Snapshot.Signer.Hex(), output)
+	}
+	return nil
+
+}
+
+func verifyStats(c *cli.Context, path string) error {
+
+	// Load the snapshot
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("error reading snapshot file %s: %w", path, err)
+	}
+	var signed netstats.SignedSnapshot
+	if err := json.Unmarshal(bytes, &signed); err != nil {
+		return fmt.Errorf("error deserializing snapshot file %s: %w", path, err)
+	}
+
+	// Check the signature
+	snapshot, err := netstats.VerifySnapshot(&signed)
+	if err != nil {
+		return fmt.Errorf("snapshot could not be verified: %w", err)
+	}
+	fmt.Printf("The snapshot of %s at block %d was signed by %s.\n", snapshot.Network, snapshot.BlockNumber, signed.Signer.Hex())
+	if snapshot.SchemaVersion != netstats.SchemaVersion {
+		fmt.Printf("NOTE: it uses schema version %d, but this version of the Smartnode uses schema version %d.\n", snapshot.SchemaVersion, netstats.SchemaVersion)
+	}
+	return nil
+
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 )
 
 const (
@@ -26,6 +27,7 @@ func getStats(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	fiat := cliutils.NewFiatFormatter(rp)
 	activeMinipools := response.InitializedMinipoolCount +
 		response.PrelaunchMinipoolCount +
 		response.StakingMinipoolCount +
@@ -34,7 +36,7 @@ func getStats(c *cli.Context) error {
 
 	// Print & return
 	fmt.Printf("%s========== General Stats ==========%s\n", colorGreen, colorReset)
-	fmt.Printf("Total Value Locked:      %f ETH\n", response.TotalValueLocked)
+	fmt.Printf("Total Value Locked:      %f ETH%s\n", response.TotalValueLocked, fiat.Eth(response.TotalValueLocked))
 	fmt.Printf("Staking Pool Balance:    %f ETH\n", response.DepositPoolBalance)
 	fmt.Printf("Minipool Queue Demand:   %f ETH\n", response.MinipoolCapacity)
 	fmt.Printf("Staking Pool ETH Used:   %f%%\n\n", response.StakerUtilization*100)
@@ -56,11 +58,30 @@ func getStats(c *cli.Context) error {
 	fmt.Printf("Pending Balance:         %f\n\n", response.SmoothingPoolBalance)
 
 	fmt.Printf("%s============== Tokens =============%s\n", colorGreen, colorReset)
-	fmt.Printf("rETH Price (ETH / rETH): %f ETH\n", response.RethPrice)
-	fmt.Printf("RPL Price (ETH / RPL):   %f ETH\n", response.RplPrice)
-	fmt.Printf("Total RPL staked:        %f RPL\n", response.TotalRplStaked)
+	fmt.Printf("rETH Price (ETH / rETH): %f ETH%s\n", response.RethPrice, fiat.Eth(response.RethPrice))
+	fmt.Printf("RPL Price (ETH / RPL):   %f ETH%s\n", response.RplPrice, fiat.Eth(response.RplPrice))
+	fmt.Printf("Total RPL staked:        %f RPL%s\n", response.TotalRplStaked, fiat.Rpl(response.TotalRplStaked))
 	fmt.Printf("Effective RPL staked:    %f RPL\n", response.EffectiveRplStaked)
 
+	// The APR scans the rETH balance history and builds the node's state, so it's only shown on request
+	if !c.Bool("apr") {
+		return nil
+	}
+	fmt.Printf("\n%s=============== APR ===============%s\n", colorGreen, colorReset)
+	aprResponse, err := rp.NetworkApr()
+	if err != nil {
+		fmt.Printf("%sThe APR is unavailable: %s%s\n", colorYellow, err.Error(), colorReset)
+		return nil
+	}
+	fmt.Printf("rETH APR (7-day):        %.2f%%\n", aprResponse.RethApr)
+	fmt.Printf("Solo Validator APR:      %.2f%%\n", aprResponse.ValidatorApr)
+	if aprResponse.NodeAprAvailable {
+		fmt.Printf("Your Node (estimated):\n")
+		fmt.Printf("    Bonded ETH APR:      %.2f%%\n", aprResponse.NodeEthApr)
+		fmt.Printf("    Staked RPL APR:      %.2f%%\n", aprResponse.NodeRplApr)
+		fmt.Printf("    Total Capital APR:   %.2f%%\n", aprResponse.NodeTotalApr)
+	}
+
 	return nil
 
 }
@@ -0,0 +1,62 @@
+package node
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/activity"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// The colors each category of activity is shown in
+var activityCategoryColors = map[activity.Category]string{
+	activity.Category_Deposit:     colorGreen,
+	activity.Category_Stake:       colorGreen,
+	activity.Category_Claim:       colorBlue,
+	activity.Category_Penalty:     colorRed,
+	activity.Category_Credentials: colorYellow,
+}
+
+func getActivity(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the category filter
+	var category activity.Category
+	if c.String("category") != "" {
+		category, err = cliutils.ValidateActivityCategory("category", c.String("category"))
+		if err != nil {
+			return err
+		}
+	}
+
+	// Get the activity
+	response, err := rp.NodeActivity(category, c.Uint64("limit"))
+	if err != nil {
+		return err
+	}
+	if len(response.Events) == 0 {
+		fmt.Printf("No activity was found for your node up to block %d.\n", response.ScannedBlock)
+		return nil
+	}
+
+	// Print the timeline, oldest first so the most recent activity is closest to the prompt
+	fmt.Printf("Your node's Rocket Pool activity (up to block %d):\n\n", response.ScannedBlock)
+	for i := len(response.Events) - 1; i >= 0; i-- {
+		event := response.Events[i]
+		color := activityCategoryColors[event.Category]
+		fmt.Printf("%s  %s%-11s %s%s\n", event.Time.Local().Format("2006-01-02 15:04"), color, event.Category, event.Summary(), colorReset)
+		if c.Bool("verbose") {
+			fmt.Printf("\t\tBlock %d, transaction %s (%s.%s)\n", event.Block, event.TxHash.Hex(), event.Contract, event.Event)
+		}
+	}
+	return nil
+
+}
@@ -0,0 +1,153 @@
+package node
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/math"
+)
+
+// The display names of the tokens allowances can be granted for
+var approvalTokenNames = map[string]string{
+	"rpl":   "RPL",
+	"fsrpl": "fsRPL",
+	"reth":  "rETH",
+}
+
+func getApprovals(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the allowances
+	response, err := rp.NodeApprovals()
+	if err != nil {
+		return err
+	}
+
+	// Print the current contracts' allowances
+	fmt.Printf("%sAllowances for current Rocket Pool contracts:%s\n", colorGreen, colorReset)
+	stale := 0
+	for _, approval := range response.Approvals {
+		if !approval.Current {
+			stale++
+			continue
+		}
+		fmt.Printf("%-6s %-28s %s  %s\n", approvalTokenNames[approval.Token], approval.Spender, approval.SpenderAddress.Hex(), formatAllowance(approval.Allowance))
+	}
+	fmt.Println()
+
+	// Print allowances left behind on contracts that have since been upgraded
+	if stale == 0 {
+		fmt.Println("None of your tokens can be spent by previous versions of Rocket Pool's contracts.")
+		return nil
+	}
+	fmt.Printf("%sAllowances for previous versions of Rocket Pool contracts:%s\n", colorYellow, colorReset)
+	for _, approval := range response.Approvals {
+		if approval.Current {
+			continue
+		}
+		fmt.Printf("%-6s %-28s %s  %s\n", approvalTokenNames[approval.Token], approval.Spender, approval.SpenderAddress.Hex(), formatAllowance(approval.Allowance))
+	}
+	fmt.Println()
+	fmt.Println("These contracts were replaced by protocol upgrades and no longer need to spend your tokens.")
+	fmt.Println("You can remove them with `rocketpool node approvals revoke token spender-address`.")
+	return nil
+
+}
+
+func setApproval(c *cli.Context, token string, spender common.Address, amountWei *big.Int) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check the allowance can be set
+	canSet, err := rp.CanNodeSetApproval(token, spender, amountWei)
+	if err != nil {
+		return err
+	}
+	if !canSet.CanSet {
+		fmt.Println("Cannot set the allowance:")
+		if canSet.UnknownSpender {
+			fmt.Printf("%s is not a current or previous Rocket Pool contract. Only allowances for Rocket Pool's contracts can be managed with this command.\n", spender.Hex())
+		}
+		return nil
+	}
+
+	// Assign max fees
+	err = gas.AssignMaxFeeAndLimit(canSet.GasInfo, rp, c.Bool("yes"))
+	if err != nil {
+		return err
+	}
+
+	// Prompt for confirmation
+	description := fmt.Sprintf("set the %s allowance of %s to %s", approvalTokenNames[token], spender.Hex(), formatAllowance(amountWei))
+	if amountWei.Sign() == 0 {
+		description = fmt.Sprintf("revoke the %s allowance of %s", approvalTokenNames[token], spender.Hex())
+	}
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to %s?", description))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Set the allowance
+	response, err := rp.NodeSetApproval(token, spender, amountWei)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Setting the allowance...\n")
+	cliutils.PrintTransactionHash(rp, response.TxHash)
+	if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
+		return err
+	}
+
+	// Log & return
+	if amountWei.Sign() == 0 {
+		fmt.Printf("Successfully revoked the %s allowance of %s.\n", approvalTokenNames[token], spender.Hex())
+	} else {
+		fmt.Printf("Successfully set the %s allowance of %s to %s.\n", approvalTokenNames[token], spender.Hex(), formatAllowance(amountWei))
+	}
+	return nil
+
+}
+
+// Get the allowance to set from the command's amount argument, which is in whole tokens unless --wei is set
+func getApprovalAmount(c *cli.Context, value string) (*big.Int, error) {
+	if c.Bool("wei") {
+		return cliutils.ValidatePositiveOrZeroWeiAmount("allowance", value)
+	}
+	amount, err := cliutils.ValidateEthAmount("allowance", value)
+	if err != nil {
+		return nil, err
+	}
+	if amount < 0 {
+		return nil, fmt.Errorf("Invalid allowance '%s' - must be greater or equal to 0", value)
+	}
+	return eth.EthToWei(amount), nil
+}
+
+// Format an allowance for display, calling out unlimited ones
+func formatAllowance(allowance *big.Int) string {
+	// Anything over half the uint256 range is treated as an unlimited approval
+	unlimited := new(big.Int).Lsh(big.NewInt(1), 255)
+	if allowance.Cmp(unlimited) >= 0 {
+		return "unlimited"
+	}
+	return fmt.Sprintf("%.6f", math.RoundDown(eth.WeiToEth(allowance), 6))
+}
@@ -0,0 +1,137 @@
+package node
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// The --mode value for going back to the node's MEV-Boost setting
+const blockBuildingModeDefault string = "default"
+
+func getBlockBuilding(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Get the preferences
+	response, err := rp.GetBlockBuilding()
+	if err != nil {
+		return err
+	}
+
+	if response.MevBoostEnabled {
+		fmt.Println("MEV-Boost is enabled, so your minipools use its builders unless they've opted out.")
+	} else {
+		fmt.Println("MEV-Boost is disabled, so your minipools build their blocks locally.")
+	}
+	if !response.KeymanagerEnabled {
+		fmt.Printf("%sThe Keymanager API isn't enabled, so these preferences can't be pushed to your validator client. Please enable it in `rocketpool service config`.%s\n", colorYellow, colorReset)
+	} else if !response.GasLimitSupported {
+		fmt.Printf("%sYour validator client can't take these preferences over the Keymanager API.%s\n", colorYellow, colorReset)
+	}
+	if len(response.Minipools) == 0 {
+		fmt.Println("None of your minipools have their own block building preferences.")
+		return nil
+	}
+
+	fmt.Println()
+	fmt.Println("Minipool block building preferences:")
+	for _, minipool := range response.Minipools {
+		building := "local building"
+		if minipool.UsesBuilder {
+			building = "MEV-Boost builders"
+		}
+		if minipool.Mode == cfgtypes.BlockBuildingMode_Default {
+			building += " (node default)"
+		}
+		gasLimit := "validator client default"
+		if minipool.GasLimit != 0 {
+			gasLimit = fmt.Sprint(minipool.GasLimit)
+		}
+		fmt.Printf("%s: %s, gas limit: %s\n", minipool.Address.Hex(), building, gasLimit)
+	}
+	if response.KeymanagerEnabled && response.GasLimitSupported && !response.BuilderSupported {
+		fmt.Printf("\n%sYour validator client can only take the gas limit over the Keymanager API, so these minipools follow the node's MEV-Boost setting. Only Lighthouse lets individual validators opt out of MEV-Boost.%s\n", colorYellow, colorReset)
+	}
+	return nil
+
+}
+
+func setBlockBuilding(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	if !c.Bool("clear") && !c.IsSet("mode") && !c.IsSet("gas-limit") {
+		return fmt.Errorf("Please set --mode or --gas-limit, or use --clear.")
+	}
+
+	// Start from the minipool's current preference, so only the provided flags change it
+	minipoolAddress := common.HexToAddress(c.String("minipool"))
+	mode := cfgtypes.BlockBuildingMode_Default
+	gasLimit := uint64(0)
+	if !c.Bool("clear") {
+		response, err := rp.GetBlockBuilding()
+		if err != nil {
+			return err
+		}
+		for _, minipool := range response.Minipools {
+			if minipool.Address == minipoolAddress {
+				mode = minipool.Mode
+				gasLimit = minipool.GasLimit
+				break
+			}
+		}
+
+		if c.IsSet("mode") {
+			switch c.String("mode") {
+			case blockBuildingModeDefault:
+				mode = cfgtypes.BlockBuildingMode_Default
+			case string(cfgtypes.BlockBuildingMode_Builder):
+				mode = cfgtypes.BlockBuildingMode_Builder
+			case string(cfgtypes.BlockBuildingMode_Local):
+				mode = cfgtypes.BlockBuildingMode_Local
+			default:
+				return fmt.Errorf("Invalid mode '%s'; it must be 'builder', 'local' or 'default'.", c.String("mode"))
+			}
+		}
+		if c.IsSet("gas-limit") {
+			gasLimit = c.Uint64("gas-limit")
+		}
+	}
+
+	// Set it
+	response, err := rp.SetMinipoolBlockBuilding(minipoolAddress, mode, gasLimit)
+	if err != nil {
+		return err
+	}
+	if mode == cfgtypes.BlockBuildingMode_Default && gasLimit == 0 {
+		fmt.Printf("Minipool %s will use the node's block building settings again.\n", minipoolAddress.Hex())
+	} else {
+		building := "build its blocks locally"
+		if response.UsesBuilder {
+			building = "use MEV-Boost's builders"
+		}
+		gasLimitString := "the validator client's default gas limit"
+		if gasLimit != 0 {
+			gasLimitString = fmt.Sprintf("a gas limit of %d", gasLimit)
+		}
+		fmt.Printf("Minipool %s will %s, with %s.\n", minipoolAddress.Hex(), building, gasLimitString)
+	}
+	if response.Applied {
+		fmt.Println("Your validator client has been updated.")
+	} else if mode == cfgtypes.BlockBuildingMode_Default && gasLimit == 0 {
+		fmt.Printf("%sYour validator client couldn't be updated (%s); please run this again once it's running, or it may keep using the old preference.%s\n", colorYellow, response.ApplyError, colorReset)
+	} else {
+		fmt.Printf("%sYour validator client couldn't be updated yet (%s); the node daemon will keep trying once it has loaded the minipool's validator key.%s\n", colorYellow, response.ApplyError, colorReset)
+	}
+	return nil
+
+}
@@ -30,13 +30,7 @@ func nodeBurn(c *cli.Context, amount float64, token string) error {
 		return err
 	}
 	if !canBurn.CanBurn {
-		fmt.Println("Cannot burn tokens:")
-		if canBurn.InsufficientBalance {
-			fmt.Printf("The node's %s balance is insufficient.\n", token)
-		}
-		if canBurn.InsufficientCollateral {
-			fmt.Printf("There is insufficient ETH collateral to trade %s for.\n", token)
-		}
+		cliutils.PrintPreflightResult("Cannot burn tokens:", canBurn.Preflight)
 		return nil
 	}
 
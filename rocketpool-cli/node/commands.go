@@ -1,8 +1,15 @@
 package node
 
 import (
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
 	"github.com/urfave/cli"
 
+	"github.com/rocket-pool/smartnode/addons/rescue_node"
+	"github.com/rocket-pool/smartnode/shared/services/activity"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 )
 
@@ -50,6 +57,83 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "dashboard",
+				Aliases:   []string{"db"},
+				Usage:     "Show a live view of the node's status, client sync, minipools, pending transactions and recent alerts",
+				UsageText: "rocketpool node dashboard [options]",
+				Flags: []cli.Flag{
+					cli.Uint64Flag{
+						Name:  "interval, i",
+						Usage: "The number of seconds between refreshes",
+						Value: 15,
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return runDashboard(c)
+
+				},
+			},
+
+			{
+				Name:      "history",
+				Usage:     "Chart the trends of the node's key metrics, as recorded by the node daemon",
+				UsageText: "rocketpool node history [options]",
+				Flags: []cli.Flag{
+					cli.Uint64Flag{
+						Name:  "days, d",
+						Usage: "The number of days of history to show",
+						Value: 7,
+					},
+					cli.StringFlag{
+						Name:  "metric, m",
+						Usage: "Only show metrics whose name contains this text (e.g. 'stake' or 'apr')",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getHistory(c)
+
+				},
+			},
+
+			{
+				Name:      "uptime",
+				Usage:     "Show the cumulative downtime of the node's validators per month and the estimated revenue lost",
+				UsageText: "rocketpool node uptime [options]",
+				Flags: []cli.Flag{
+					cli.Uint64Flag{
+						Name:  "months, m",
+						Usage: "The number of months to show, including the current one",
+						Value: 3,
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getUptime(c)
+
+				},
+			},
+
 			{
 				Name:      "register",
 				Aliases:   []string{"r"},
@@ -76,7 +160,7 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 					}
 
 					// Run
-					return registerNode(c)
+					return RegisterNode(c)
 
 				},
 			},
@@ -102,7 +186,7 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 			{
 				Name:      "set-withdrawal-address",
 				Aliases:   []string{"w"},
-				Usage:     "Set the node's withdrawal address",
+				Usage:     "Set the node's withdrawal address. ENS names and address book names supported.",
 				UsageText: "rocketpool node set-withdrawal-address [options] address",
 				Flags: []cli.Flag{
 					cli.BoolFlag{
@@ -482,7 +566,7 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 			{
 				Name:      "send",
 				Aliases:   []string{"n"},
-				Usage:     "Send ETH or tokens from the node account to an address. ENS names supported. <token> can be 'rpl', 'eth', 'fsrpl' (for the old RPL v1 token), 'reth', or the address of an arbitrary token you want to send (including the 0x prefix).",
+				Usage:     "Send ETH or tokens from the node account to an address. ENS names and address book names supported. <token> can be 'rpl', 'eth', 'fsrpl' (for the old RPL v1 token), 'reth', or the address of an arbitrary token you want to send (including the 0x prefix).",
 				UsageText: "rocketpool node send [options] amount token to",
 				Flags: []cli.Flag{
 					cli.BoolFlag{
@@ -674,7 +758,7 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 
 			{
 				Name:      "send-message",
-				Usage:     "Send a zero-ETH transaction to the target address (or ENS) with the provided hex-encoded message as the data payload",
+				Usage:     "Send a zero-ETH transaction to the target address (or ENS or address book name) with the provided hex-encoded message as the data payload",
 				UsageText: "rocketpool node send-message [-y] to-address hex-message",
 				Flags: []cli.Flag{
 					cli.BoolFlag{
@@ -698,6 +782,538 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 
 				},
 			},
+
+			{
+				Name:      "mev-status",
+				Usage:     "Show whether your MEV-Boost relays are live and which of your validators are registered with them",
+				UsageText: "rocketpool node mev-status [options]",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "validators, v",
+						Usage: "List the relays each validator is registered with",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getMevStatus(c)
+
+				},
+			},
+
+			{
+				Name:      "proposals",
+				Usage:     "Show your validators' recent proposals, whether their blocks came from MEV-Boost or were built locally, and what they earned",
+				UsageText: "rocketpool node proposals [options]",
+				Flags: []cli.Flag{
+					cli.UintFlag{
+						Name:  "limit, l",
+						Usage: "The number of proposals to show",
+						Value: 20,
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getProposals(c)
+
+				},
+			},
+
+			{
+				Name:      "dvt-status",
+				Usage:     "Show the health of your DVT cluster and the minipools it operates",
+				UsageText: "rocketpool node dvt-status",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getDvtStatus(c)
+
+				},
+			},
+
+			{
+				Name:      "graffiti",
+				Usage:     "Show the graffiti your node and minipools put in the blocks they propose",
+				UsageText: "rocketpool node graffiti",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getGraffiti(c)
+
+				},
+			},
+
+			{
+				Name:      "set-graffiti",
+				Usage:     "Set the graffiti template or rotation list for your node, or for one of its minipools",
+				UsageText: "rocketpool node set-graffiti [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "template, t",
+						Usage: "The graffiti template to use, which can include placeholders like {rp} and {custom}",
+					},
+					cli.StringFlag{
+						Name:  "minipool, m",
+						Usage: "The address of the minipool to set the graffiti for, instead of the node",
+					},
+					cli.StringFlag{
+						Name:  "rotation, r",
+						Usage: "A list of graffiti templates separated by `;` to cycle through instead of a single template",
+					},
+					cli.BoolFlag{
+						Name:  "clear, c",
+						Usage: "Go back to the standard graffiti, or to the node's graffiti for a minipool",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Validate flags
+					if c.String("minipool") != "" {
+						if _, err := cliutils.ValidateAddress("minipool address", c.String("minipool")); err != nil {
+							return err
+						}
+					}
+
+					// Run
+					return setGraffiti(c)
+
+				},
+			},
+
+			{
+				Name:      "block-building",
+				Usage:     "Show which of your minipools have their own block building mode or gas limit",
+				UsageText: "rocketpool node block-building",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getBlockBuilding(c)
+
+				},
+			},
+
+			{
+				Name:      "set-block-building",
+				Usage:     "Opt one of your minipools in or out of MEV-Boost's builders, or set the gas limit it uses",
+				UsageText: "rocketpool node set-block-building --minipool address [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "minipool, m",
+						Usage: "The address of the minipool to set the block building preference for",
+					},
+					cli.StringFlag{
+						Name:  "mode",
+						Usage: "Use MEV-Boost's builders ('builder'), always build blocks locally ('local'), or follow the node's MEV-Boost setting ('default')",
+					},
+					cli.Uint64Flag{
+						Name:  "gas-limit, g",
+						Usage: "The gas limit to register with the builders and to target for locally built blocks, or 0 for the validator client's default",
+					},
+					cli.BoolFlag{
+						Name:  "clear, c",
+						Usage: "Go back to the node's block building settings",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Validate flags
+					if _, err := cliutils.ValidateAddress("minipool address", c.String("minipool")); err != nil {
+						return err
+					}
+
+					// Run
+					return setBlockBuilding(c)
+
+				},
+			},
+
+			{
+				Name:  "rescue-node",
+				Usage: "Temporarily delegate your validators to a rescue node's Beacon node while your own is offline or resyncing",
+				Subcommands: []cli.Command{
+
+					{
+						Name:      "credentials",
+						Aliases:   []string{"c"},
+						Usage:     "Sign a request for rescue node credentials with the node wallet",
+						UsageText: "rocketpool node rescue-node credentials",
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 0); err != nil {
+								return err
+							}
+
+							// Run
+							return getRescueNodeCredentials(c)
+
+						},
+					},
+
+					{
+						Name:      "start",
+						Usage:     "Use a rescue node as your Validator client's fallback Beacon node for a number of days",
+						UsageText: "rocketpool node rescue-node start [options]",
+						Flags: []cli.Flag{
+							cli.StringFlag{
+								Name:  "username, u",
+								Usage: "The username the rescue node's operator gave you",
+							},
+							cli.StringFlag{
+								Name:  "password, p",
+								Usage: "The password the rescue node's operator gave you",
+							},
+							cli.Uint64Flag{
+								Name:  "days, d",
+								Usage: "How many days to delegate to the rescue node for",
+								Value: rescue_node.DefaultPeriodDays,
+							},
+							cli.StringFlag{
+								Name:  "url",
+								Usage: "The Beacon API URL of the rescue node, if you aren't using the default one",
+							},
+						},
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 0); err != nil {
+								return err
+							}
+
+							// Run
+							return startRescueNode(c)
+
+						},
+					},
+
+					{
+						Name:      "status",
+						Aliases:   []string{"s"},
+						Usage:     "Show how long your node is still delegating to the rescue node for",
+						UsageText: "rocketpool node rescue-node status",
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 0); err != nil {
+								return err
+							}
+
+							// Run
+							return getRescueNodeStatus(c)
+
+						},
+					},
+
+					{
+						Name:      "stop",
+						Usage:     "Stop using the rescue node and forget its credentials",
+						UsageText: "rocketpool node rescue-node stop",
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 0); err != nil {
+								return err
+							}
+
+							// Run
+							return stopRescueNode(c)
+
+						},
+					},
+				},
+			},
+
+			{
+				Name:  "approvals",
+				Usage: "Manage the token allowances your node has granted to Rocket Pool's contracts",
+				Subcommands: []cli.Command{
+
+					{
+						Name:      "list",
+						Aliases:   []string{"l"},
+						Usage:     "List your node's RPL, fsRPL and rETH allowances for current and previous Rocket Pool contracts",
+						UsageText: "rocketpool node approvals list",
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 0); err != nil {
+								return err
+							}
+
+							// Run
+							return getApprovals(c)
+
+						},
+					},
+
+					{
+						Name:      "set",
+						Aliases:   []string{"s"},
+						Usage:     "Set your node's allowance of a token for a Rocket Pool contract to an exact amount",
+						UsageText: "rocketpool node approvals set [options] token spender-address amount",
+						Flags: []cli.Flag{
+							cli.BoolFlag{
+								Name:  "wei",
+								Usage: "Treat the amount as a number of wei instead of whole tokens",
+							},
+							cli.BoolFlag{
+								Name:  "yes, y",
+								Usage: "Automatically confirm the new allowance",
+							},
+						},
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 3); err != nil {
+								return err
+							}
+							token, err := cliutils.ValidateApprovalTokenType("token type", c.Args().Get(0))
+							if err != nil {
+								return err
+							}
+							spender, err := cliutils.ValidateAddress("spender address", c.Args().Get(1))
+							if err != nil {
+								return err
+							}
+							amountWei, err := getApprovalAmount(c, c.Args().Get(2))
+							if err != nil {
+								return err
+							}
+
+							// Run
+							return setApproval(c, token, spender, amountWei)
+
+						},
+					},
+
+					{
+						Name:      "revoke",
+						Aliases:   []string{"r"},
+						Usage:     "Remove your node's allowance of a token for a Rocket Pool contract",
+						UsageText: "rocketpool node approvals revoke [options] token spender-address",
+						Flags: []cli.Flag{
+							cli.BoolFlag{
+								Name:  "yes, y",
+								Usage: "Automatically confirm removing the allowance",
+							},
+						},
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 2); err != nil {
+								return err
+							}
+							token, err := cliutils.ValidateApprovalTokenType("token type", c.Args().Get(0))
+							if err != nil {
+								return err
+							}
+							spender, err := cliutils.ValidateAddress("spender address", c.Args().Get(1))
+							if err != nil {
+								return err
+							}
+
+							// Run
+							return setApproval(c, token, spender, big.NewInt(0))
+
+						},
+					},
+				},
+			},
+
+			{
+				Name:      "stranded-assets",
+				Usage:     "List your node's RPL and ETH held by previous versions of Rocket Pool's contracts after protocol upgrades",
+				UsageText: "rocketpool node stranded-assets",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getStrandedAssets(c)
+
+				},
+			},
+
+			{
+				Name:      "claim-stranded-assets",
+				Usage:     "Recover your node's RPL and ETH held by previous versions of Rocket Pool's contracts",
+				UsageText: "rocketpool node claim-stranded-assets [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "asset, a",
+						Usage: "The address of the asset to recover (or 'all')",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm recovering the assets",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Validate flags
+					if c.String("asset") != "" && c.String("asset") != "all" {
+						if _, err := cliutils.ValidateAddress("asset address", c.String("asset")); err != nil {
+							return err
+						}
+					}
+
+					// Run
+					return claimStrandedAssets(c)
+
+				},
+			},
+
+			{
+				Name:      "activity",
+				Usage:     "Show a timeline of your node's Rocket Pool activity, such as deposits, RPL staking, claims, penalties and withdrawal address changes",
+				UsageText: "rocketpool node activity [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "category, c",
+						Usage: fmt.Sprintf("Only show activity in this category %v", activity.Categories),
+					},
+					cli.Uint64Flag{
+						Name:  "limit, l",
+						Usage: "The number of most recent events to show (0 for all of them)",
+						Value: 50,
+					},
+					cli.BoolFlag{
+						Name:  "verbose, v",
+						Usage: "Show the block, transaction and contract event of each entry",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getActivity(c)
+
+				},
+			},
+
+			{
+				Name:      "export-income",
+				Usage:     "Export your node's income in a calendar year with its fiat value, for tax reporting",
+				UsageText: "rocketpool node export-income --year year [options]",
+				Flags: []cli.Flag{
+					cli.IntFlag{
+						Name:  "year, y",
+						Usage: "The calendar year to export (in UTC)",
+						Value: time.Now().UTC().Year() - 1,
+					},
+					cli.StringFlag{
+						Name:  "format, f",
+						Usage: "The format of the export: 'csv' or 'koinly' (Koinly's universal import format)",
+						Value: incomeFormat_Csv,
+					},
+					cli.StringFlag{
+						Name:  "output, o",
+						Usage: "The file to write the export to (defaults to rocketpool-income-<year>-<format>.csv in the current directory)",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Validate flags
+					year := c.Int("year")
+					if year < 2021 || year > time.Now().UTC().Year() {
+						return fmt.Errorf("Invalid year %d - it must be between 2021 (when Rocket Pool launched) and %d", year, time.Now().UTC().Year())
+					}
+					format := strings.ToLower(c.String("format"))
+					if format != incomeFormat_Csv && format != incomeFormat_Koinly {
+						return fmt.Errorf("Invalid format '%s' - valid formats are '%s' and '%s'", c.String("format"), incomeFormat_Csv, incomeFormat_Koinly)
+					}
+
+					// Run
+					return exportIncome(c, year, format)
+
+				},
+			},
+
+			{
+				Name:      "estimate-deposit",
+				Usage:     "Project the returns of a new minipool from the network's current commission, APRs, RPL price and queue",
+				UsageText: "rocketpool node estimate-deposit [options]",
+				Flags: []cli.Flag{
+					cli.Float64Flag{
+						Name:  "bond, b",
+						Usage: "The bond of the new minipool in ETH (8 or 16)",
+						Value: 8,
+					},
+					cli.Float64Flag{
+						Name:  "rpl, r",
+						Usage: "The amount of RPL you'd stake for the minipool (defaults to the minimum)",
+					},
+					cli.StringFlag{
+						Name:  "days, d",
+						Usage: "A comma-separated list of the numbers of days to project returns over",
+						Value: defaultEstimateHorizons,
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return estimateDeposit(c)
+
+				},
+			},
 		},
 	})
 }
@@ -160,19 +160,16 @@ func createVacantMinipool(c *cli.Context, pubkey types.ValidatorPubkey) error {
 		return err
 	}
 	if !canDeposit.CanDeposit {
-		fmt.Println("Cannot create a vacant minipool for migration:")
-		if canDeposit.InsufficientRplStake {
-			fmt.Printf("The node has not staked enough RPL to collateralize a new minipool with a bond of %d ETH.\n", int(amount))
-		}
-		if canDeposit.InvalidAmount {
-			fmt.Println("The deposit amount is invalid.")
-		}
-		if canDeposit.DepositDisabled {
-			fmt.Println("Vacant minipool deposits are currently disabled.")
-		}
+		cliutils.PrintPreflightResult("Cannot create a vacant minipool for migration:", canDeposit.Preflight)
 		return nil
 	}
 
+	// Explain what an ETH-only node gives up before it commits to a new minipool
+	if canDeposit.EthOnly {
+		printEthOnlyForfeits()
+		fmt.Println()
+	}
+
 	if c.String("salt") != "" {
 		fmt.Printf("Using custom salt %s, your minipool address will be %s.\n\n", c.String("salt"), canDeposit.MinipoolAddress.Hex())
 	}
@@ -0,0 +1,299 @@
+package node
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/math"
+)
+
+// Settings
+const (
+	dashboardAlertCount uint64 = 10
+	dashboardTimeFormat string = "2006-01-02 15:04:05"
+)
+
+// Everything the dashboard shows, fetched from the same API routes as the individual commands
+type dashboardData struct {
+	status     api.NodeStatusResponse
+	statusErr  error
+	sync       api.NodeSyncProgressResponse
+	syncErr    error
+	pending    api.NodePendingTransactionsResponse
+	pendingErr error
+	alerts     api.NodeRecentAlertsResponse
+	alertsErr  error
+	time       time.Time
+}
+
+// The panels of the dashboard
+type dashboard struct {
+	app       *tview.Application
+	node      *tview.TextView
+	sync      *tview.TextView
+	minipools *tview.TextView
+	pending   *tview.TextView
+	alerts    *tview.TextView
+	footer    *tview.TextView
+	interval  time.Duration
+}
+
+// Show a live view of the node's status until the user quits
+func runDashboard(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	interval := time.Duration(c.Uint64("interval")) * time.Second
+	if interval < time.Second {
+		return fmt.Errorf("The refresh interval must be at least 1 second.")
+	}
+
+	// Build the layout
+	d := &dashboard{
+		app:       tview.NewApplication(),
+		node:      newDashboardPanel("Node"),
+		sync:      newDashboardPanel("Clients"),
+		minipools: newDashboardPanel("Minipools"),
+		pending:   newDashboardPanel("Pending Transactions"),
+		alerts:    newDashboardPanel("Recent Alerts"),
+		footer:    tview.NewTextView().SetDynamicColors(true),
+		interval:  interval,
+	}
+	grid := tview.NewGrid().
+		SetRows(0, 0, 0, 1).
+		SetColumns(0, 0).
+		AddItem(d.node, 0, 0, 1, 1, 0, 0, false).
+		AddItem(d.sync, 0, 1, 1, 1, 0, 0, false).
+		AddItem(d.minipools, 1, 0, 1, 1, 0, 0, false).
+		AddItem(d.pending, 1, 1, 1, 1, 0, 0, false).
+		AddItem(d.alerts, 2, 0, 1, 2, 0, 0, false).
+		AddItem(d.footer, 3, 0, 1, 2, 0, 0, false)
+	d.footer.SetText("Loading...")
+
+	// Refresh on a timer or when the user asks for it
+	refresh := make(chan bool, 1)
+	d.app.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch {
+		case event.Key() == tcell.KeyEsc, event.Rune() == 'q':
+			d.app.Stop()
+			return nil
+		case event.Rune() == 'r':
+			select {
+			case refresh <- true:
+			default:
+			}
+			return nil
+		}
+		return event
+	})
+	go func() {
+		for {
+			data := fetchDashboardData(rp)
+			d.app.QueueUpdateDraw(func() {
+				d.render(data)
+			})
+			select {
+			case <-refresh:
+			case <-time.After(interval):
+			}
+		}
+	}()
+
+	return d.app.SetRoot(grid, true).Run()
+
+}
+
+// Create a bordered panel for one section of the dashboard
+func newDashboardPanel(title string) *tview.TextView {
+	panel := tview.NewTextView().SetDynamicColors(true).SetWrap(true)
+	panel.SetBorder(true).SetTitle(" " + title + " ")
+	return panel
+}
+
+// Get the latest data for every panel; an error in one section doesn't stop the others from updating
+func fetchDashboardData(rp *rocketpool.Client) dashboardData {
+	data := dashboardData{}
+	data.sync, data.syncErr = rp.NodeSync()
+	data.status, data.statusErr = rp.NodeStatus()
+	data.pending, data.pendingErr = rp.NodePendingTransactions()
+	data.alerts, data.alertsErr = rp.NodeRecentAlerts(dashboardAlertCount)
+	data.time = time.Now()
+	return data
+}
+
+// Draw the latest data into the panels
+func (d *dashboard) render(data dashboardData) {
+	d.node.SetText(renderDashboardNode(data))
+	d.sync.SetText(renderDashboardSync(data))
+	d.minipools.SetText(renderDashboardMinipools(data))
+	d.pending.SetText(renderDashboardPending(data))
+	d.alerts.SetText(renderDashboardAlerts(data))
+	d.footer.SetText(fmt.Sprintf("Updated %s, refreshing every %s. Press [::b]r[::-] to refresh now or [::b]q[::-] to quit.", data.time.Format(dashboardTimeFormat), d.interval))
+}
+
+func renderDashboardNode(data dashboardData) string {
+	if data.statusErr != nil {
+		return renderDashboardError(data.statusErr)
+	}
+	status := data.status
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Address:    [blue]%s[-]\n", tview.Escape(status.AccountAddressFormatted))
+	if status.Registered {
+		fmt.Fprintf(&sb, "Registered: [green]yes[-] (%s)\n", tview.Escape(status.TimezoneLocation))
+	} else {
+		fmt.Fprintf(&sb, "Registered: [yellow]no[-]\n")
+	}
+	if status.Trusted {
+		fmt.Fprintf(&sb, "Oracle DAO: [green]member[-]\n")
+	}
+	fmt.Fprintf(&sb, "Balance:    %.6f ETH, %.6f RPL\n", math.RoundDown(eth.WeiToEth(status.AccountBalances.ETH), 6), math.RoundDown(eth.WeiToEth(status.AccountBalances.RPL), 6))
+	if !status.Registered {
+		return sb.String()
+	}
+	fmt.Fprintf(&sb, "RPL staked: %.6f RPL (%.6f effective)\n", math.RoundDown(eth.WeiToEth(status.RplStake), 6), math.RoundDown(eth.WeiToEth(status.EffectiveRplStake), 6))
+	if status.EthOnlyPermitted && status.RplStake.Sign() == 0 {
+		fmt.Fprintf(&sb, "Collateral: [yellow]none (ETH-only, forfeiting RPL rewards)[-]\n")
+	} else if status.MinipoolCounts.Total > 0 {
+		color := "green"
+		if status.RplStake.Cmp(status.MinimumRplStake) < 0 {
+			color = "yellow"
+		}
+		fmt.Fprintf(&sb, "Collateral: [%s]%.2f%%[-] of borrowed ETH\n", color, status.BorrowedCollateralRatio*100)
+	}
+	fmt.Fprintf(&sb, "Credit:     %.6f ETH\n", math.RoundDown(eth.WeiToEth(status.CreditBalance), 6))
+	if status.FeeRecipientInfo.IsInSmoothingPool {
+		fmt.Fprintf(&sb, "Smoothing pool: [green]opted in[-]\n")
+	}
+	if len(status.PenalizedMinipools) > 0 {
+		fmt.Fprintf(&sb, "[red]%d minipool(s) have been penalized[-]\n", len(status.PenalizedMinipools))
+	}
+	return sb.String()
+}
+
+func renderDashboardSync(data dashboardData) string {
+	if data.syncErr != nil {
+		return renderDashboardError(data.syncErr)
+	}
+	var sb strings.Builder
+	renderDashboardClientManager(&sb, "Execution", data.sync.EcStatus)
+	sb.WriteString("\n")
+	renderDashboardClientManager(&sb, "Consensus", data.sync.BcStatus)
+	return sb.String()
+}
+
+func renderDashboardClientManager(sb *strings.Builder, name string, status api.ClientManagerStatus) {
+	fmt.Fprintf(sb, "%s (primary):  %s\n", name, renderDashboardClient(status.PrimaryClientStatus))
+	if status.FallbackEnabled {
+		fmt.Fprintf(sb, "%s (fallback): %s\n", name, renderDashboardClient(status.FallbackClientStatus))
+	}
+}
+
+func renderDashboardClient(status api.ClientStatus) string {
+	if status.Error != "" {
+		return fmt.Sprintf("[red]unavailable[-] (%s)", tview.Escape(status.Error))
+	}
+	if status.IsSynced {
+		return "[green]synced[-]"
+	}
+	progress := rocketpool.SyncRatioToPercent(status.SyncProgress)
+	return fmt.Sprintf("[yellow]syncing[-] %s %.2f%%", renderDashboardBar(progress/100, 20), progress)
+}
+
+func renderDashboardMinipools(data dashboardData) string {
+	if data.statusErr != nil {
+		return renderDashboardError(data.statusErr)
+	}
+	counts := data.status.MinipoolCounts
+	if counts.Total == 0 {
+		return "The node doesn't have any minipools."
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Total:        %d\n", counts.Total)
+	rows := []struct {
+		name  string
+		count int
+		color string
+	}{
+		{"Initialized", counts.Initialized, "yellow"},
+		{"Prelaunch", counts.Prelaunch, "yellow"},
+		{"Staking", counts.Staking, "green"},
+		{"Withdrawable", counts.Withdrawable, "blue"},
+		{"Dissolved", counts.Dissolved, "red"},
+		{"Finalized", counts.Finalised, "white"},
+	}
+	for _, row := range rows {
+		if row.count > 0 {
+			fmt.Fprintf(&sb, "%-13s [%s]%d[-]\n", row.name+":", row.color, row.count)
+		}
+	}
+
+	// Things the operator can do something about
+	if counts.RefundAvailable > 0 {
+		fmt.Fprintf(&sb, "[yellow]%d minipool(s) have a refund available[-]\n", counts.RefundAvailable)
+	}
+	if counts.CloseAvailable > 0 {
+		fmt.Fprintf(&sb, "[yellow]%d minipool(s) can be closed[-]\n", counts.CloseAvailable)
+	}
+	return sb.String()
+}
+
+func renderDashboardPending(data dashboardData) string {
+	if data.pendingErr != nil {
+		return renderDashboardError(data.pendingErr)
+	}
+	pending := data.pending
+	if pending.PendingCount == 0 {
+		return fmt.Sprintf("[green]None[-]\nNext nonce: %d", pending.LatestNonce)
+	}
+	return fmt.Sprintf("[yellow]%d transaction(s) waiting to be mined[-]\nNonces %d to %d are pending.", pending.PendingCount, pending.LatestNonce, pending.PendingNonce-1)
+}
+
+func renderDashboardAlerts(data dashboardData) string {
+	if data.alertsErr != nil {
+		return renderDashboardError(data.alertsErr)
+	}
+	if len(data.alerts.Alerts) == 0 {
+		return "[green]No alerts have been sent.[-]"
+	}
+	var sb strings.Builder
+	for _, entry := range data.alerts.Alerts {
+		color := "yellow"
+		switch {
+		case entry.Alert.Resolved:
+			color = "green"
+		case entry.Alert.Severity == alerting.Severity_Critical:
+			color = "red"
+		case entry.Alert.Severity == alerting.Severity_Info:
+			color = "white"
+		}
+		fmt.Fprintf(&sb, "%s [%s]%s[-]: %s\n", entry.Time.Local().Format(dashboardTimeFormat), color, tview.Escape(entry.Alert.Summary()), tview.Escape(entry.Alert.Message))
+	}
+	return sb.String()
+}
+
+// Draw a simple progress bar for a value between 0 and 1
+func renderDashboardBar(ratio float64, width int) string {
+	filled := int(ratio * float64(width))
+	if filled > width {
+		filled = width
+	}
+	if filled < 0 {
+		filled = 0
+	}
+	return tview.Escape("[" + strings.Repeat("#", filled) + strings.Repeat("-", width-filled) + "]")
+}
+
+func renderDashboardError(err error) string {
+	return fmt.Sprintf("[red]%s[-]", tview.Escape(err.Error()))
+}
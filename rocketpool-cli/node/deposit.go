@@ -154,31 +154,16 @@ func nodeDeposit(c *cli.Context) error {
 		return err
 	}
 	if !canDeposit.CanDeposit {
-		fmt.Println("Cannot make node deposit:")
-		if canDeposit.InsufficientBalanceWithoutCredit {
-			nodeBalance := eth.WeiToEth(canDeposit.NodeBalance)
-			fmt.Printf("There is not enough ETH in the staking pool to use your credit balance (it needs at least 1 ETH but only has %.2f ETH) and you don't have enough ETH in your wallet (%.6f ETH) to cover the deposit amount yourself. If you want to continue creating a minipool, you will either need to wait for the staking pool to have more ETH deposited or add more ETH to your node wallet.", eth.WeiToEth(canDeposit.DepositBalance), nodeBalance)
-		}
-		if canDeposit.InsufficientBalance {
-			nodeBalance := eth.WeiToEth(canDeposit.NodeBalance)
-			creditBalance := eth.WeiToEth(canDeposit.CreditBalance)
-			fmt.Printf("The node's balance of %.6f ETH and credit balance of %.6f ETH are not enough to create a minipool with a %.1f ETH bond.", nodeBalance, creditBalance, amount)
-		}
-		if canDeposit.InsufficientRplStake {
-			fmt.Printf("The node has not staked enough RPL to collateralize a new minipool with a bond of %d ETH (this also includes the RPL required to support any pending bond reductions).\n", int(amount))
-		}
-		if canDeposit.InvalidAmount {
-			fmt.Println("The deposit amount is invalid.")
-		}
-		if canDeposit.UnbondedMinipoolsAtMax {
-			fmt.Println("The node cannot create any more unbonded minipools.")
-		}
-		if canDeposit.DepositDisabled {
-			fmt.Println("Node deposits are currently disabled.")
-		}
+		cliutils.PrintPreflightResult("Cannot make node deposit:", canDeposit.Preflight)
 		return nil
 	}
 
+	// Explain what an ETH-only node gives up before it commits to a new minipool
+	if canDeposit.EthOnly {
+		printEthOnlyForfeits()
+		fmt.Println()
+	}
+
 	useCreditBalance := false
 	fmt.Printf("You currently have %.2f ETH in your credit balance.\n", eth.WeiToEth(canDeposit.CreditBalance))
 	if canDeposit.CreditBalance.Cmp(big.NewInt(0)) > 0 {
@@ -234,6 +219,15 @@ func nodeDeposit(c *cli.Context) error {
 		return err
 	}
 
+	// Load the config to check for DVT mode
+	cfg, _, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if cfg.Dvt.Enabled.Value == true {
+		fmt.Printf("%sNOTE: DVT mode is enabled, so this minipool's validator key will be handed off to your DVT cluster instead of being loaded into your Validator client.%s\n\n", colorYellow, colorReset)
+	}
+
 	// Prompt for confirmation
 	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf(
 		"You are about to deposit %.6f ETH to create a minipool with a minimum possible commission rate of %f%%.\n"+
@@ -264,6 +258,11 @@ func nodeDeposit(c *cli.Context) error {
 	fmt.Printf("The node deposit of %.6f ETH was made successfully!\n", math.RoundDown(eth.WeiToEth(amountWei), 6))
 	fmt.Printf("Your new minipool's address is: %s\n", response.MinipoolAddress)
 	fmt.Printf("The validator pubkey is: %s\n\n", response.ValidatorPubkey.Hex())
+	if response.DvtHandoffKeystore != "" {
+		if err := printDvtHandoffInstructions(cfg, response.DvtHandoffKeystore); err != nil {
+			return err
+		}
+	}
 
 	fmt.Println("Your minipool is now in Initialized status.")
 	fmt.Println("Once the remaining ETH has been assigned to your minipool from the staking pool, it will move to Prelaunch status.")
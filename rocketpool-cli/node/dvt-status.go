@@ -0,0 +1,120 @@
+package node
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+func getDvtStatus(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Load the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode first.")
+	}
+
+	// Get the DVT status
+	response, err := rp.DvtStatus()
+	if err != nil {
+		return err
+	}
+	if !response.Enabled {
+		fmt.Println("DVT mode is disabled, so new minipools' validators run in your own Validator client. You can enable it in the DVT section of `rocketpool service config`.")
+	}
+	if len(response.Minipools) == 0 {
+		fmt.Println("None of your minipools are operated by a DVT cluster.")
+		return nil
+	}
+	if response.Enabled {
+		fmt.Printf("DVT mode is enabled (%s).\n", cfg.Dvt.Provider.Value)
+	}
+	fmt.Println()
+
+	// Print the cluster health
+	status := response.ClusterStatus
+	if status == nil {
+		fmt.Println("The node daemon hasn't checked your DVT cluster yet. It checks it every 5 minutes while DVT mode is enabled.")
+	} else {
+		fmt.Printf("Cluster health (last checked %s ago):\n", time.Since(status.Time).Round(time.Second))
+		if status.Healthy {
+			fmt.Printf("    %sHEALTHY%s, %dms\n", colorGreen, colorReset, status.Latency)
+		} else {
+			fmt.Printf("    %sUNHEALTHY%s\n", colorRed, colorReset)
+		}
+		fmt.Printf("    %s\n", status.Url)
+		for component, state := range status.Components {
+			fmt.Printf("    %s: %s\n", component, state)
+		}
+		if status.Error != "" {
+			fmt.Printf("    %s%s%s\n", colorYellow, status.Error, colorReset)
+		}
+	}
+	fmt.Println()
+
+	// Print the minipools
+	handoffPath, err := getDvtHandoffHostPath(cfg)
+	if err != nil {
+		return err
+	}
+	pendingHandoffs := 0
+	fmt.Println("DVT minipools:")
+	for _, minipool := range response.Minipools {
+		fmt.Printf("%s (%s, created %s)\n", minipool.Address.Hex(), minipool.Provider, minipool.HandoffTime.Format(time.RFC822))
+		fmt.Printf("    Validator: 0x%s\n", minipool.Pubkey.Hex())
+		if minipool.HandoffKeystore != "" {
+			fmt.Printf("    %sKeystore waiting to be split: %s%s\n", colorYellow, filepath.Join(handoffPath, minipool.HandoffKeystore), colorReset)
+			pendingHandoffs++
+		}
+	}
+	if pendingHandoffs > 0 {
+		fmt.Printf("\n%d validator keystores are still in the handoff folder. Once you've split them into key shares for your cluster and backed them up, delete them (and their .txt password files) so the full keys aren't left on this machine.\n", pendingHandoffs)
+	}
+	return nil
+
+}
+
+// Get the host path of the folder that DVT validator keystores are handed off in
+func getDvtHandoffHostPath(cfg *config.RocketPoolConfig) (string, error) {
+	dataPath, err := homedir.Expand(cfg.Smartnode.DataPath.Value.(string))
+	if err != nil {
+		return "", fmt.Errorf("error expanding data directory: %w", err)
+	}
+	return filepath.Join(dataPath, config.DvtHandoffFolder), nil
+}
+
+// Print the steps for handing a new DVT minipool's validator keystore to the cluster
+func printDvtHandoffInstructions(cfg *config.RocketPoolConfig, keystoreFilename string) error {
+	handoffPath, err := getDvtHandoffHostPath(cfg)
+	if err != nil {
+		return err
+	}
+	keystorePath := filepath.Join(handoffPath, keystoreFilename)
+
+	fmt.Printf("%sThis minipool is in DVT mode, so its validator key has NOT been loaded into your Validator client.%s\n", colorYellow, colorReset)
+	fmt.Printf("Its keystore has been written to %s, with its password in the matching .txt file.\n", keystorePath)
+	switch cfg.Dvt.Provider.Value.(cfgtypes.DvtProvider) {
+	case cfgtypes.DvtProvider_Obol:
+		fmt.Printf("Split it into key shares for your cluster with `charon create cluster --split-existing-keys --split-keys-dir %s`, and distribute them to your operators.\n", handoffPath)
+	case cfgtypes.DvtProvider_Ssv:
+		fmt.Println("Split it into key shares with the SSV key splitting tool, and register the validator with your operators on the SSV Network.")
+	}
+	fmt.Println("The cluster must be running the validator before your minipool moves to Staking status.")
+	fmt.Println("Once the key shares are backed up, delete the keystore and its password from the handoff folder. You can check on it with `rocketpool node dvt-status`.")
+	fmt.Println()
+	return nil
+}
@@ -0,0 +1,114 @@
+package node
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// The default horizons to project returns over, in days
+const defaultEstimateHorizons string = "30,180,365,730"
+
+func estimateDeposit(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the horizons
+	horizons := []uint64{}
+	for _, value := range strings.Split(c.String("days"), ",") {
+		days, err := strconv.ParseUint(strings.TrimSpace(value), 10, 64)
+		if err != nil || days == 0 {
+			return fmt.Errorf("Invalid number of days '%s'", value)
+		}
+		horizons = append(horizons, days)
+	}
+
+	// Get the estimates
+	response, err := rp.EstimateNodeDeposit()
+	if err != nil {
+		return err
+	}
+	var estimate *api.DepositEstimate
+	for i := range response.Estimates {
+		if response.Estimates[i].BondAmount == c.Float64("bond") {
+			estimate = &response.Estimates[i]
+		}
+	}
+	if estimate == nil {
+		return fmt.Errorf("Invalid bond amount %.1f ETH - new minipools can have a bond of 8 or 16 ETH", c.Float64("bond"))
+	}
+	rplStake := estimate.MinimumRplStake
+	if c.IsSet("rpl") {
+		rplStake = c.Float64("rpl")
+	}
+
+	// Print the network conditions the estimate is based on
+	fmt.Printf("%s=== Network Conditions ===%s\n", colorGreen, colorReset)
+	fmt.Printf("Commission:         %.2f%%\n", response.Commission*100)
+	fmt.Printf("rETH APR:           %.2f%% (trailing 7 days)\n", response.RethApr)
+	fmt.Printf("Validator APR:      %.2f%% (implied by the rETH APR)\n", response.ValidatorApr)
+	fmt.Printf("RPL price:          %.6f ETH\n", response.RplPrice)
+	fmt.Printf("RPL rewards APR:    %.2f%% (on effective stake)\n", response.YearlyRplRewardsPerRpl*100)
+	fmt.Printf("Minipool queue:     %d minipools needing %.2f ETH, with %.2f ETH in the deposit pool\n\n", response.QueueLength, response.QueueEthRequired, response.DepositPoolBalance)
+
+	// Print the projection for the selected bond
+	fmt.Printf("%s=== %.0f ETH Bond ===%s\n", colorGreen, estimate.BondAmount, colorReset)
+	fmt.Printf("Borrowed ETH:       %.0f ETH\n", estimate.BorrowedAmount)
+	fmt.Printf("RPL stake:          %.2f RPL (minimum %.2f, rewarded up to %.2f)\n", rplStake, estimate.MinimumRplStake, estimate.MaximumRplStake)
+	if rplStake < estimate.MinimumRplStake {
+		fmt.Printf("%sThat isn't enough RPL to create the minipool, and it wouldn't earn RPL rewards.%s\n", colorRed, colorReset)
+	} else if rplStake == 0 {
+		fmt.Printf("%sWith no RPL staked, this would be an ETH-only node: it wouldn't earn RPL rewards or have any voting power in the protocol DAO.%s\n", colorYellow, colorReset)
+	} else if rplStake > estimate.MaximumRplStake {
+		fmt.Printf("%sRPL staked above %.2f RPL doesn't earn rewards for this minipool.%s\n", colorYellow, estimate.MaximumRplStake, colorReset)
+	}
+	if estimate.WaitsInQueue {
+		fmt.Printf("%sThe deposit pool doesn't have enough ETH to match this minipool yet, so it will wait in the queue and only start earning once it's staking. The projection starts from then.%s\n", colorYellow, colorReset)
+	}
+	fmt.Println()
+	capital := estimate.BondAmount + rplStake*response.RplPrice
+	yearlyRpl := getEstimatedYearlyRpl(*estimate, rplStake, response.YearlyRplRewardsPerRpl)
+	fmt.Printf("%-10s %14s %14s %16s %10s\n", "Horizon", "ETH rewards", "RPL rewards", "Total (ETH)", "Return")
+	for _, days := range horizons {
+		fraction := float64(days) / 365
+		ethRewards := estimate.YearlyEthRewards * fraction
+		rplRewards := yearlyRpl * fraction
+		total := ethRewards + rplRewards*response.RplPrice
+		fmt.Printf("%-10s %14.6f %14.6f %16.6f %9.2f%%\n", fmt.Sprintf("%d days", days), ethRewards, rplRewards, total, total/capital*100)
+	}
+	fmt.Println()
+
+	// Compare it with the other bond sizes at their minimum RPL stake
+	fmt.Printf("%s=== Bond Comparison (minimum RPL stake) ===%s\n", colorGreen, colorReset)
+	for _, other := range response.Estimates {
+		otherCapital := other.BondAmount + other.MinimumRplStake*response.RplPrice
+		otherYearly := other.YearlyEthRewards + getEstimatedYearlyRpl(other, other.MinimumRplStake, response.YearlyRplRewardsPerRpl)*response.RplPrice
+		fmt.Printf("%2.0f ETH bond: %.2f ETH + %.2f RPL of capital, about %.6f ETH per year (%.2f%% APR)\n", other.BondAmount, other.BondAmount, other.MinimumRplStake, otherYearly, otherYearly/otherCapital*100)
+	}
+	fmt.Println()
+
+	fmt.Println("These are estimates that assume today's APRs, commission, RPL price and RPL inflation stay the same. They don't include gas costs, the time spent in the queue, or any penalties, so your actual returns will differ.")
+	return nil
+
+}
+
+// Get the yearly RPL rewards of a minipool's RPL stake; stake below the minimum earns nothing, and stake above the maximum isn't counted
+func getEstimatedYearlyRpl(estimate api.DepositEstimate, rplStake float64, rewardsPerRpl float64) float64 {
+	if rplStake < estimate.MinimumRplStake {
+		return 0
+	}
+	if rplStake > estimate.MaximumRplStake {
+		rplStake = estimate.MaximumRplStake
+	}
+	return rplStake * rewardsPerRpl
+}
@@ -0,0 +1,157 @@
+package node
+
+import (
+	"encoding/csv"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Export formats
+const (
+	incomeFormat_Csv    string = "csv"
+	incomeFormat_Koinly string = "koinly"
+)
+
+// The label Koinly uses for staking income
+const koinlyRewardLabel string = "reward"
+
+func exportIncome(c *cli.Context, year int, format string) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the income
+	fmt.Printf("Compiling your node's income for %d. Prices are looked up for every day you were paid, which can take a few minutes on the free price API...\n", year)
+	response, err := rp.NodeIncome(year)
+	if err != nil {
+		return err
+	}
+	if len(response.Entries) == 0 {
+		fmt.Printf("Your node didn't receive any income in %d (indexed up to block %d).\n", year, response.ScannedBlock)
+		return nil
+	}
+
+	// Write the file
+	path := c.String("output")
+	if path == "" {
+		path = fmt.Sprintf("rocketpool-income-%d-%s.csv", year, format)
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("error creating %s: %w", path, err)
+	}
+	defer file.Close()
+	writer := csv.NewWriter(file)
+	if format == incomeFormat_Koinly {
+		err = writeKoinlyIncome(writer, response)
+	} else {
+		err = writeCsvIncome(writer, response)
+	}
+	if err != nil {
+		return fmt.Errorf("error writing %s: %w", path, err)
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("error writing %s: %w", path, err)
+	}
+
+	// Print a summary
+	totals := map[string]*big.Int{}
+	fiatTotal := float64(0)
+	for _, entry := range response.Entries {
+		if _, exists := totals[entry.Token]; !exists {
+			totals[entry.Token] = big.NewInt(0)
+		}
+		totals[entry.Token].Add(totals[entry.Token], entry.Amount)
+		fiatTotal += entry.FiatValue
+	}
+	currency := strings.ToUpper(response.Currency)
+	fmt.Printf("Exported %d payouts to %s:\n", len(response.Entries), path)
+	for _, token := range []string{"ETH", "RPL"} {
+		if total, exists := totals[token]; exists {
+			fmt.Printf("\t%s %s\n", formatWei(total), token)
+		}
+	}
+	fmt.Printf("\tTotal value: %.2f %s\n", fiatTotal, currency)
+	if len(response.SkippedWithdrawals) > 0 {
+		fmt.Printf("\n%sNOTE: %d full minipool withdrawals were not included, because they return your bond along with any rewards. Please work out the rewards in these transactions yourself:%s\n", colorYellow, len(response.SkippedWithdrawals), colorReset)
+		for _, txHash := range response.SkippedWithdrawals {
+			fmt.Printf("\t%s\n", txHash.Hex())
+		}
+	}
+	fmt.Println("\nThis export is provided for convenience and isn't tax advice; please check it against your own records.")
+	return nil
+
+}
+
+// Write the income as a plain CSV file
+func writeCsvIncome(writer *csv.Writer, response api.NodeIncomeResponse) error {
+	currency := strings.ToUpper(response.Currency)
+	err := writer.Write([]string{"Date (UTC)", "Type", "Description", "Token", "Amount", fmt.Sprintf("Price (%s)", currency), fmt.Sprintf("Value (%s)", currency), "Transaction"})
+	if err != nil {
+		return err
+	}
+	for _, entry := range response.Entries {
+		err := writer.Write([]string{
+			entry.Time.UTC().Format("2006-01-02 15:04:05"),
+			entry.Type,
+			entry.Description,
+			entry.Token,
+			formatWei(entry.Amount),
+			fmt.Sprintf("%.6f", entry.FiatPrice),
+			fmt.Sprintf("%.2f", entry.FiatValue),
+			entry.TxHash.Hex(),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Write the income in Koinly's universal import format
+func writeKoinlyIncome(writer *csv.Writer, response api.NodeIncomeResponse) error {
+	currency := strings.ToUpper(response.Currency)
+	err := writer.Write([]string{"Date", "Sent Amount", "Sent Currency", "Received Amount", "Received Currency", "Fee Amount", "Fee Currency", "Net Worth Amount", "Net Worth Currency", "Label", "Description", "TxHash"})
+	if err != nil {
+		return err
+	}
+	for _, entry := range response.Entries {
+		err := writer.Write([]string{
+			entry.Time.UTC().Format("2006-01-02 15:04:05 UTC"),
+			"",
+			"",
+			formatWei(entry.Amount),
+			entry.Token,
+			"",
+			"",
+			fmt.Sprintf("%.2f", entry.FiatValue),
+			currency,
+			koinlyRewardLabel,
+			entry.Description,
+			entry.TxHash.Hex(),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Format a wei amount as a full-precision decimal token amount
+func formatWei(wei *big.Int) string {
+	amount := new(big.Rat).SetFrac(wei, big.NewInt(1e18)).FloatString(18)
+	amount = strings.TrimRight(amount, "0")
+	return strings.TrimSuffix(amount, ".")
+}
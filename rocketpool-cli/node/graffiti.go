@@ -0,0 +1,115 @@
+package node
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+func getGraffiti(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Get the graffiti
+	response, err := rp.GetGraffiti()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Node graffiti: %s\n", response.NodeGraffiti)
+	if !response.FileSupported {
+		fmt.Printf("%sYour validator client can't read its graffiti from a file, so it uses the node's graffiti from when the Smartnode was last started, and rotation and minipool graffiti aren't available.%s\n", colorYellow, colorReset)
+	}
+	if len(response.Minipools) == 0 {
+		return nil
+	}
+
+	fmt.Println()
+	fmt.Println("Minipool graffiti:")
+	for _, minipool := range response.Minipools {
+		if minipool.Error != "" {
+			fmt.Printf("%s: %s%s%s\n", minipool.Address.Hex(), colorRed, minipool.Error, colorReset)
+			continue
+		}
+		fmt.Printf("%s: %s\n", minipool.Address.Hex(), minipool.Graffiti)
+		if len(minipool.Rotation) > 0 {
+			fmt.Printf("    rotating through: %s\n", strings.Join(minipool.Rotation, config.GraffitiRotationSeparator+" "))
+		}
+	}
+	if response.FileSupported && !response.PerValidatorSupport {
+		fmt.Printf("\n%sYour validator client can't use different graffiti for each validator, so these minipools will use the node's graffiti.%s\n", colorYellow, colorReset)
+	}
+	return nil
+
+}
+
+func setGraffiti(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Load the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode first.")
+	}
+
+	// Get the new template and rotation list
+	template := ""
+	rotation := ""
+	if !c.Bool("clear") {
+		template = c.String("template")
+		rotation = c.String("rotation")
+		if template == "" && rotation == "" {
+			template = cliutils.Prompt(fmt.Sprintf("Please enter the graffiti template to use. It can use the placeholders %s, %s, %s, %s, %s, %s and %s:", config.GraffitiPlaceholderRp, config.GraffitiPlaceholderVersion, config.GraffitiPlaceholderEc, config.GraffitiPlaceholderCc, config.GraffitiPlaceholderEcInitial, config.GraffitiPlaceholderCcInitial, config.GraffitiPlaceholderCustom), "^.+$", "Invalid template")
+		}
+	}
+
+	// Set the graffiti for a minipool
+	if c.String("minipool") != "" {
+		minipoolAddress := common.HexToAddress(c.String("minipool"))
+		response, err := rp.SetMinipoolGraffiti(minipoolAddress, template, rotation)
+		if err != nil {
+			return err
+		}
+		if template == "" && rotation == "" {
+			fmt.Printf("Minipool %s will use the node's graffiti again: %s\n", minipoolAddress.Hex(), response.Graffiti)
+		} else {
+			fmt.Printf("Minipool %s's graffiti is now: %s\n", minipoolAddress.Hex(), response.Graffiti)
+		}
+		fmt.Println("The node daemon will update your validator client's graffiti within a few minutes.")
+		return nil
+	}
+
+	// Set the graffiti for the node
+	if err := cfg.Graffiti.ValidateGraffiti(template, config.ParseGraffitiRotation(rotation)); err != nil {
+		return err
+	}
+	cfg.Graffiti.Template.Value = template
+	cfg.Graffiti.Rotation.Value = rotation
+	graffiti, err := cfg.Graffiti.GetNodeGraffiti(time.Now())
+	if err != nil {
+		return err
+	}
+	if err := rp.SaveConfig(cfg); err != nil {
+		return fmt.Errorf("error saving graffiti settings: %w", err)
+	}
+
+	fmt.Printf("Your node's graffiti is now: %s\n", graffiti)
+	fmt.Printf("%sPlease run `rocketpool service start` to apply it.%s\n", colorYellow, colorReset)
+	return nil
+
+}
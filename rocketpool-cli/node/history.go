@@ -0,0 +1,137 @@
+package node
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/history"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+// The maximum width of a trend chart, in characters
+const historyChartWidth int = 48
+
+// The characters used to draw trend charts, from lowest to highest
+var historyChartLevels = []rune("▁▂▃▄▅▆▇█")
+
+// A metric that can be charted from the node history
+type historyMetric struct {
+	name   string
+	format string
+	value  func(sample history.NodeSample) float64
+}
+
+var historyMetrics = []historyMetric{
+	{"ETH balance", "%.6f ETH", func(s history.NodeSample) float64 { return s.EthBalance }},
+	{"RPL balance", "%.6f RPL", func(s history.NodeSample) float64 { return s.RplBalance }},
+	{"rETH balance", "%.6f rETH", func(s history.NodeSample) float64 { return s.RethBalance }},
+	{"RPL stake", "%.6f RPL", func(s history.NodeSample) float64 { return s.RplStake }},
+	{"Effective RPL stake", "%.6f RPL", func(s history.NodeSample) float64 { return s.EffectiveRplStake }},
+	{"Collateral ratio", "%.2f%%", func(s history.NodeSample) float64 { return s.CollateralRatio * 100 }},
+	{"Beacon balance", "%.6f ETH", func(s history.NodeSample) float64 { return s.BeaconBalance }},
+	{"Active minipools", "%.0f", func(s history.NodeSample) float64 { return float64(s.ActiveMinipools) }},
+	{"RPL price", "%.6f ETH", func(s history.NodeSample) float64 { return s.RplPrice }},
+	{"rETH exchange rate", "%.6f ETH", func(s history.NodeSample) float64 { return s.RethExchangeRate }},
+	{"Estimated RPL APR", "%.2f%%", func(s history.NodeSample) float64 { return s.RplApr }},
+	{"Attestation effectiveness", "%.2f%%", func(s history.NodeSample) float64 { return s.Effectiveness * 100 }},
+}
+
+func getHistory(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Get the history
+	days := c.Uint64("days")
+	response, err := rp.NodeHistory(days * 24)
+	if err != nil {
+		return err
+	}
+	samples := response.Samples
+	if len(samples) == 0 {
+		fmt.Printf("The node daemon hasn't recorded any history in the last %d days yet. Samples are recorded once per hour while the node daemon is running.\n", days)
+		return nil
+	}
+
+	// Filter the metrics
+	metrics := historyMetrics
+	if filter := strings.ToLower(c.String("metric")); filter != "" {
+		metrics = []historyMetric{}
+		for _, metric := range historyMetrics {
+			if strings.Contains(strings.ToLower(metric.name), filter) {
+				metrics = append(metrics, metric)
+			}
+		}
+		if len(metrics) == 0 {
+			return fmt.Errorf("no metrics match '%s'", c.String("metric"))
+		}
+	}
+
+	// Print the charts
+	first := samples[0]
+	last := samples[len(samples)-1]
+	fmt.Printf("Node history from %s to %s (%d samples):\n\n", first.Time.Local().Format("2006-01-02 15:04"), last.Time.Local().Format("2006-01-02 15:04"), len(samples))
+	for _, metric := range metrics {
+		values := make([]float64, len(samples))
+		for i, sample := range samples {
+			values[i] = metric.value(sample)
+		}
+		minValue, maxValue := values[0], values[0]
+		for _, value := range values {
+			minValue = math.Min(minValue, value)
+			maxValue = math.Max(maxValue, value)
+		}
+
+		change := values[len(values)-1] - values[0]
+		changeColor := colorReset
+		if change > 0 {
+			changeColor = colorGreen
+		} else if change < 0 {
+			changeColor = colorRed
+		}
+
+		fmt.Printf("%s\n", metric.name)
+		fmt.Printf("\t%s\n", renderTrendChart(values))
+		fmt.Printf("\tLatest: "+metric.format+"   Min: "+metric.format+"   Max: "+metric.format+"   Change: %s%+g%s\n\n", values[len(values)-1], minValue, maxValue, changeColor, change, colorReset)
+	}
+
+	return nil
+
+}
+
+// Render a series of values as a single-line chart, averaging them into buckets if there are more values than characters
+func renderTrendChart(values []float64) string {
+	buckets := values
+	if len(values) > historyChartWidth {
+		buckets = make([]float64, historyChartWidth)
+		for i := range buckets {
+			start := i * len(values) / historyChartWidth
+			end := (i + 1) * len(values) / historyChartWidth
+			sum := float64(0)
+			for _, value := range values[start:end] {
+				sum += value
+			}
+			buckets[i] = sum / float64(end-start)
+		}
+	}
+
+	minValue, maxValue := buckets[0], buckets[0]
+	for _, value := range buckets {
+		minValue = math.Min(minValue, value)
+		maxValue = math.Max(maxValue, value)
+	}
+
+	var builder strings.Builder
+	for _, value := range buckets {
+		level := 0
+		if maxValue > minValue {
+			level = int((value - minValue) / (maxValue - minValue) * float64(len(historyChartLevels)-1))
+		}
+		builder.WriteRune(historyChartLevels[level])
+	}
+	return builder.String()
+}
@@ -0,0 +1,108 @@
+package node
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+func getMevStatus(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Load the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode first.")
+	}
+	if cfg.IsNativeMode || cfg.EnableMevBoost.Value == false {
+		fmt.Println("MEV-Boost isn't enabled, so there are no relays to check.")
+		return nil
+	}
+	if cfg.MevBoost.Mode.Value.(cfgtypes.Mode) != cfgtypes.Mode_Local {
+		fmt.Printf("Your MEV-Boost client is externally managed (%s), so its relays can't be checked by the Smartnode.\n", cfg.MevBoost.ExternalUrl.Value)
+		return nil
+	}
+	if cfg.MevBoost.EnableRelayMonitoring.Value == false {
+		fmt.Println("Relay monitoring is disabled. You can enable it in the MEV-Boost section of `rocketpool service config`.")
+		return nil
+	}
+
+	// Get the relay report
+	response, err := rp.MevStatus()
+	if err != nil {
+		return err
+	}
+	report := response.RelayReport
+	if report == nil {
+		fmt.Println("The node daemon hasn't checked your relays yet. It checks them every 15 minutes while your clients are synced.")
+		return nil
+	}
+
+	fmt.Printf("Last checked %s ago, for %d validators.\n\n", time.Since(report.Time).Round(time.Second), len(report.Validators))
+	if len(report.Relays) == 0 {
+		fmt.Printf("%sYou don't have any relays enabled.%s\n", colorYellow, colorReset)
+		return nil
+	}
+
+	// Print the relays
+	for _, relay := range report.Relays {
+		regulation := "unregulated"
+		if relay.Regulated {
+			regulation = "regulated"
+		}
+		if relay.Live {
+			fmt.Printf("%s%s%s (%s): live, %dms\n", colorGreen, relay.Name, colorReset, regulation, relay.Latency)
+			fmt.Printf("    %d of %d validators registered\n", len(relay.RegisteredValidators), len(report.Validators))
+		} else {
+			fmt.Printf("%s%s%s (%s): %sDOWN%s\n", colorRed, relay.Name, colorReset, regulation, colorRed, colorReset)
+		}
+		fmt.Printf("    %s\n", relay.Url)
+		if relay.Error != "" {
+			fmt.Printf("    %s%s%s\n", colorYellow, relay.Error, colorReset)
+		}
+	}
+	fmt.Println()
+
+	// Print the registrations by validator
+	if c.Bool("validators") {
+		for _, pubkey := range report.Validators {
+			relays := []string{}
+			for _, relay := range report.Relays {
+				for _, registered := range relay.RegisteredValidators {
+					if registered == pubkey {
+						relays = append(relays, relay.Name)
+						break
+					}
+				}
+			}
+			if len(relays) == 0 {
+				fmt.Printf("%s0x%s: not registered with any relay%s\n", colorYellow, pubkey.Hex(), colorReset)
+			} else {
+				fmt.Printf("0x%s: %v\n", pubkey.Hex(), relays)
+			}
+		}
+		fmt.Println()
+	}
+
+	unregistered := report.GetUnregisteredValidators()
+	if len(unregistered) > 0 {
+		fmt.Printf("%s%d validators aren't registered with any of your live relays, so their proposals won't get MEV-Boost blocks. Make sure your Validator client is running with MEV-Boost enabled.%s\n", colorYellow, len(unregistered), colorReset)
+		if !c.Bool("validators") {
+			fmt.Println("Run `rocketpool node mev-status --validators` to see which ones.")
+		}
+	} else if len(report.Validators) > 0 && len(report.GetDownRelays()) < len(report.Relays) {
+		fmt.Printf("%sAll of your validators are registered with at least one live relay.%s\n", colorGreen, colorReset)
+	}
+	return nil
+
+}
@@ -0,0 +1,71 @@
+package node
+
+import (
+	"fmt"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/mevboost"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+func getProposals(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Get the proposals
+	response, err := rp.Proposals()
+	if err != nil {
+		return err
+	}
+	proposals := response.Proposals
+	if len(proposals) == 0 {
+		fmt.Println("The node daemon hasn't recorded any proposals from your validators yet.")
+		return nil
+	}
+	limit := int(c.Uint("limit"))
+	if limit > 0 && len(proposals) > limit {
+		proposals = proposals[len(proposals)-limit:]
+	}
+
+	// Print them, newest first
+	fallbacks := 0
+	missed := 0
+	for i := len(proposals) - 1; i >= 0; i-- {
+		proposal := proposals[i]
+		fmt.Printf("Slot %d (%s), validator 0x%s\n", proposal.Slot, proposal.Time.Local().Format("2006-01-02 15:04:05"), proposal.Pubkey.Hex())
+		switch proposal.Source {
+		case mevboost.ProposalSource_Missed:
+			missed++
+			fmt.Printf("    %sMissed%s\n", colorRed, colorReset)
+			continue
+		case mevboost.ProposalSource_Relay:
+			fmt.Printf("    Block %d from the %s relay, bid %.6f ETH\n", proposal.BlockNumber, proposal.Relay, eth.WeiToEth(proposal.BidValue))
+		case mevboost.ProposalSource_Local:
+			if proposal.ExpectedBuilder {
+				fallbacks++
+				fmt.Printf("    %sBlock %d built locally instead of by MEV-Boost%s\n", colorYellow, proposal.BlockNumber, colorReset)
+			} else {
+				fmt.Printf("    Block %d built locally\n", proposal.BlockNumber)
+			}
+		}
+		if proposal.Reward != nil {
+			fmt.Printf("    Fee recipient %s received %.6f ETH\n", proposal.FeeRecipient.Hex(), eth.WeiToEth(proposal.Reward))
+		} else {
+			fmt.Printf("    Fee recipient %s, reward unknown\n", proposal.FeeRecipient.Hex())
+		}
+	}
+	fmt.Println()
+
+	if fallbacks > 0 {
+		fmt.Printf("%s%d of these proposals fell back to locally built blocks even though they were supposed to use MEV-Boost. Check your relays with `rocketpool node mev-status`.%s\n", colorYellow, fallbacks, colorReset)
+	}
+	if missed > 0 {
+		fmt.Printf("%s%d of these proposals were missed.%s\n", colorRed, missed, colorReset)
+	}
+	return nil
+
+}
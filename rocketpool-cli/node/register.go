@@ -10,7 +10,7 @@ import (
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 )
 
-func registerNode(c *cli.Context) error {
+func RegisterNode(c *cli.Context) error {
 
 	// Get RP client
 	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
@@ -33,13 +33,7 @@ func registerNode(c *cli.Context) error {
 		return err
 	}
 	if !canRegister.CanRegister {
-		fmt.Println("The node cannot be registered:")
-		if canRegister.AlreadyRegistered {
-			fmt.Println("The node is already registered with Rocket Pool.")
-		}
-		if canRegister.RegistrationDisabled {
-			fmt.Println("Node registrations are currently disabled.")
-		}
+		cliutils.PrintPreflightResult("The node cannot be registered:", canRegister.Preflight)
 		return nil
 	}
 
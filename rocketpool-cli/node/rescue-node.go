@@ -0,0 +1,202 @@
+package node
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/addons/rescue_node"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// The message the rescue node's operator expects node operators to sign when they request credentials
+const rescueNodeMessageFormat string = "Rescue Node %d"
+
+// Load the config and get the rescue node addon from it
+func loadRescueNode(rp *rocketpool.Client) (*config.RocketPoolConfig, *rescue_node.RescueNode, error) {
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+	if isNew {
+		return nil, nil, fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode first.")
+	}
+	rescueNode, ok := cfg.RescueNode.(*rescue_node.RescueNode)
+	if !ok {
+		return nil, nil, fmt.Errorf("the rescue node addon isn't available")
+	}
+	return cfg, rescueNode, nil
+}
+
+func getRescueNodeCredentials(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Get & check wallet status
+	status, err := rp.WalletStatus()
+	if err != nil {
+		return err
+	}
+	if !status.WalletInitialized {
+		fmt.Println("The node wallet is not initialized.")
+		return nil
+	}
+
+	// Sign the request with the node wallet, which proves to the rescue node's operator that it comes from a Rocket Pool node
+	message := fmt.Sprintf(rescueNodeMessageFormat, time.Now().Unix())
+	response, err := rp.SignMessage(message)
+	if err != nil {
+		return err
+	}
+	bytes, err := json.MarshalIndent(PersonalSignature{
+		Address:   status.AccountAddress,
+		Message:   message,
+		Signature: response.SignedData,
+		Version:   fmt.Sprint(signatureVersion),
+	}, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Signed credentials request:\n\n%s\n\n", string(bytes))
+	fmt.Println("Submit this to the rescue node's operator to get a username and password for your node, then run `rocketpool node rescue-node start` with them.")
+	fmt.Println("You don't need to hand over any keys or exit messages: your validator keys stay in your own Validator client, which only uses the rescue node as its Beacon node.")
+	return nil
+
+}
+
+func startRescueNode(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Load the config
+	cfg, rescueNode, err := loadRescueNode(rp)
+	if err != nil {
+		return err
+	}
+
+	// Get the credentials
+	username := c.String("username")
+	if username == "" {
+		username = cliutils.Prompt("Please enter the username the rescue node's operator gave you:", "^.+$", "Invalid username")
+	}
+	password := c.String("password")
+	if password == "" {
+		password = cliutils.PromptPassword("Please enter the password the rescue node's operator gave you:", "^.+$", "Invalid password")
+	}
+	days := c.Uint64("days")
+	if days == 0 {
+		return fmt.Errorf("the delegation period must be at least one day")
+	}
+
+	// Start the delegation
+	if c.String("url") != "" {
+		rescueNode.SetBeaconUrl(c.String("url"))
+	}
+	rescueNode.Start(username, password, time.Duration(days)*24*time.Hour, time.Now())
+	if _, err := rescueNode.GetBeaconUrl(); err != nil {
+		return err
+	}
+	if err := rp.SaveConfig(cfg); err != nil {
+		return fmt.Errorf("error saving rescue node settings: %w", err)
+	}
+
+	fmt.Printf("Your Validator client will fall back to the rescue node until %s.\n", rescueNode.GetDelegationEnd().Format(time.RFC1123))
+	fmt.Println("It keeps using your own Beacon node whenever that's healthy. The rescue node stays configured until you run `rocketpool node rescue-node stop` and `rocketpool service start`; the node daemon will alert you when the delegation ends or your own Beacon node is synced again.")
+	fmt.Println("The rescue node only accepts your validators' Rocket Pool fee recipient; the node daemon keeps your Validator client's fee recipient set to it, so you don't need to change anything.")
+	if cfg.UseFallbackClients.Value == true {
+		fmt.Printf("%sThe rescue node replaces your fallback Beacon node until the delegation ends.%s\n", colorYellow, colorReset)
+	}
+	fmt.Printf("%sPlease run `rocketpool service start` to apply it.%s\n", colorYellow, colorReset)
+	return nil
+
+}
+
+func getRescueNodeStatus(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Load the config
+	_, rescueNode, err := loadRescueNode(rp)
+	if err != nil {
+		return err
+	}
+	if rescueNode.GetEnabledParameter().Value != true {
+		fmt.Println("Your node isn't delegating to a rescue node.")
+		return nil
+	}
+
+	// Show the countdown
+	end := rescueNode.GetDelegationEnd()
+	remaining := time.Until(end)
+	if remaining <= 0 {
+		fmt.Printf("%sThe delegation to the rescue node ended on %s.%s\n", colorRed, end.Format(time.RFC1123), colorReset)
+		fmt.Println("Your Validator client still has the rescue node as its fallback Beacon node with the expired credentials. Run `rocketpool node rescue-node stop` and then `rocketpool service start` to remove it.")
+		return nil
+	}
+	color := colorGreen
+	if remaining < 24*time.Hour {
+		color = colorYellow
+	}
+	fmt.Printf("Your Validator client can fall back to the rescue node for another %s%s%s (until %s).\n", color, remaining.Round(time.Minute), colorReset, end.Format(time.RFC1123))
+
+	// Show what the node daemon last saw, which tells whether the settings have been applied
+	response, err := rp.GetAddonStatus()
+	if err != nil {
+		fmt.Printf("%sCouldn't get the rescue node status from the node daemon: %s%s\n", colorYellow, err.Error(), colorReset)
+		return nil
+	}
+	if response.Report == nil {
+		return nil
+	}
+	for _, status := range response.Report.Addons {
+		if status.ID != rescue_node.AddonID {
+			continue
+		}
+		if status.Error != "" {
+			fmt.Printf("%sThe node daemon couldn't check the rescue node: %s%s\n", colorRed, status.Error, colorReset)
+		}
+		return nil
+	}
+	fmt.Printf("%sThe node daemon hasn't picked up the delegation yet. Please run `rocketpool service start` if you haven't already.%s\n", colorYellow, colorReset)
+	return nil
+
+}
+
+func stopRescueNode(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Load the config
+	cfg, rescueNode, err := loadRescueNode(rp)
+	if err != nil {
+		return err
+	}
+	if rescueNode.GetEnabledParameter().Value != true {
+		fmt.Println("Your node isn't delegating to a rescue node.")
+		return nil
+	}
+
+	// Stop the delegation
+	rescueNode.Stop()
+	if err := rp.SaveConfig(cfg); err != nil {
+		return fmt.Errorf("error saving rescue node settings: %w", err)
+	}
+
+	fmt.Println("Your Validator client will only use your own Beacon node from now on.")
+	fmt.Printf("%sPlease run `rocketpool service start` to apply it.%s\n", colorYellow, colorReset)
+	return nil
+
+}
@@ -84,42 +84,52 @@ func getRewards(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	fiat := cliutils.NewFiatFormatter(rp)
 
 	fmt.Printf("%sNOTE: Legacy rewards from pre-Redstone are temporarily not being included in the below figures. They will be added back in a future release. We apologize for the inconvenience!%s\n\n", colorYellow, colorReset)
 
 	fmt.Println("=== ETH ===")
-	fmt.Printf("You have earned %.4f ETH from the Beacon Chain (including your commissions) so far.\n", rewards.BeaconRewards)
-	fmt.Printf("You have claimed %.4f ETH from the Smoothing Pool.\n", rewards.CumulativeEthRewards)
-	fmt.Printf("You still have %.4f ETH in unclaimed Smoothing Pool rewards.\n", rewards.UnclaimedEthRewards)
+	fmt.Printf("You have earned %.4f ETH%s from the Beacon Chain (including your commissions) so far.\n", rewards.BeaconRewards, fiat.Eth(rewards.BeaconRewards))
+	fmt.Printf("You have claimed %.4f ETH%s from the Smoothing Pool.\n", rewards.CumulativeEthRewards, fiat.Eth(rewards.CumulativeEthRewards))
+	fmt.Printf("You still have %.4f ETH%s in unclaimed Smoothing Pool rewards.\n", rewards.UnclaimedEthRewards, fiat.Eth(rewards.UnclaimedEthRewards))
 
 	nextRewardsTime := rewards.LastCheckpoint.Add(rewards.RewardsInterval)
 	nextRewardsTimeString := cliutils.GetDateTimeString(uint64(nextRewardsTime.Unix()))
 	timeToCheckpointString := time.Until(nextRewardsTime).Round(time.Second).String()
 
-	// Assume 365 days in a year, 24 hours per day
-	rplApr := rewards.EstimatedRewards / rewards.TotalRplStake / rewards.RewardsInterval.Hours() * (24 * 365) * 100
-
 	fmt.Println("\n=== RPL ===")
 	fmt.Printf("The current rewards cycle started on %s.\n", cliutils.GetDateTimeString(uint64(rewards.LastCheckpoint.Unix())))
 	fmt.Printf("It will end on %s (%s from now).\n", nextRewardsTimeString, timeToCheckpointString)
 
 	if rewards.UnclaimedRplRewards > 0 {
-		fmt.Printf("You currently have %f unclaimed RPL from staking rewards.\n", rewards.UnclaimedRplRewards)
+		fmt.Printf("You currently have %f unclaimed RPL%s from staking rewards.\n", rewards.UnclaimedRplRewards, fiat.Rpl(rewards.UnclaimedRplRewards))
 	}
 	if rewards.UnclaimedTrustedRplRewards > 0 {
-		fmt.Printf("You currently have %f unclaimed RPL from Oracle DAO duties.\n", rewards.UnclaimedTrustedRplRewards)
+		fmt.Printf("You currently have %f unclaimed RPL%s from Oracle DAO duties.\n", rewards.UnclaimedTrustedRplRewards, fiat.Rpl(rewards.UnclaimedTrustedRplRewards))
 	}
 
 	fmt.Println()
-	fmt.Printf("Your estimated RPL staking rewards for this cycle: %f RPL (this may change based on network activity).\n", rewards.EstimatedRewards)
-	fmt.Printf("Based on your current total stake of %f RPL, this is approximately %.2f%% APR.\n", rewards.TotalRplStake, rplApr)
-	fmt.Printf("Your node has received %f RPL staking rewards in total.\n", rewards.CumulativeRplRewards)
+	if rewards.TotalRplStake == 0 {
+		// Without any RPL staked there's nothing to earn RPL staking rewards on
+		if rewards.EthOnlyPermitted {
+			printEthOnlyForfeits()
+		} else {
+			fmt.Println("Your node doesn't have any RPL staked, so it won't earn RPL staking rewards this cycle.")
+		}
+	} else {
+		// Assume 365 days in a year, 24 hours per day
+		rplApr := rewards.EstimatedRewards / rewards.TotalRplStake / rewards.RewardsInterval.Hours() * (24 * 365) * 100
+
+		fmt.Printf("Your estimated RPL staking rewards for this cycle: %f RPL%s (this may change based on network activity).\n", rewards.EstimatedRewards, fiat.Rpl(rewards.EstimatedRewards))
+		fmt.Printf("Based on your current total stake of %f RPL, this is approximately %.2f%% APR.\n", rewards.TotalRplStake, rplApr)
+	}
+	fmt.Printf("Your node has received %f RPL%s staking rewards in total.\n", rewards.CumulativeRplRewards, fiat.Rpl(rewards.CumulativeRplRewards))
 
 	if rewards.Trusted {
 		rplTrustedApr := rewards.EstimatedTrustedRplRewards / rewards.TrustedRplBond / rewards.RewardsInterval.Hours() * (24 * 365) * 100
 
 		fmt.Println()
-		fmt.Printf("You will receive an estimated %f RPL in rewards for Oracle DAO duties (this may change based on network activity).\n", rewards.EstimatedTrustedRplRewards)
+		fmt.Printf("You will receive an estimated %f RPL%s in rewards for Oracle DAO duties (this may change based on network activity).\n", rewards.EstimatedTrustedRplRewards, fiat.Rpl(rewards.EstimatedTrustedRplRewards))
 		fmt.Printf("Based on your bond of %f RPL, this is approximately %.2f%% APR.\n", rewards.TrustedRplBond, rplTrustedApr)
 		fmt.Printf("Your node has received %f RPL Oracle DAO rewards in total.\n", rewards.CumulativeTrustedRplRewards)
 	}
@@ -2,9 +2,7 @@ package node
 
 import (
 	"fmt"
-	"strings"
 
-	"github.com/ethereum/go-ethereum/common"
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/services/gas"
@@ -21,21 +19,9 @@ func addAddressToStakeRplWhitelist(c *cli.Context, addressOrENS string) error {
 	}
 	defer rp.Close()
 
-	var address common.Address
-	var addressString string
-	if strings.Contains(addressOrENS, ".") {
-		response, err := rp.ResolveEnsName(addressOrENS)
-		if err != nil {
-			return err
-		}
-		address = response.Address
-		addressString = fmt.Sprintf("%s (%s)", addressOrENS, address.Hex())
-	} else {
-		address, err = cliutils.ValidateAddress("address", addressOrENS)
-		if err != nil {
-			return err
-		}
-		addressString = address.Hex()
+	address, addressString, err := cliutils.ResolveAddress(rp, "address", addressOrENS)
+	if err != nil {
+		return err
 	}
 
 	// Get the gas estimate
@@ -82,21 +68,9 @@ func removeAddressFromStakeRplWhitelist(c *cli.Context, addressOrENS string) err
 	}
 	defer rp.Close()
 
-	var address common.Address
-	var addressString string
-	if strings.Contains(addressOrENS, ".") {
-		response, err := rp.ResolveEnsName(addressOrENS)
-		if err != nil {
-			return err
-		}
-		address = response.Address
-		addressString = fmt.Sprintf("%s (%s)", addressOrENS, address.Hex())
-	} else {
-		address, err = cliutils.ValidateAddress("address", addressOrENS)
-		if err != nil {
-			return err
-		}
-		addressString = address.Hex()
+	address, addressString, err := cliutils.ResolveAddress(rp, "address", addressOrENS)
+	if err != nil {
+		return err
 	}
 
 	// Get the gas estimate
@@ -311,10 +311,7 @@ func nodeStakeRpl(c *cli.Context) error {
 		return err
 	}
 	if !canStake.CanStake {
-		fmt.Println("Cannot stake RPL:")
-		if canStake.InsufficientBalance {
-			fmt.Println("The node's RPL balance is insufficient.")
-		}
+		cliutils.PrintPreflightResult("Cannot stake RPL:", canStake.Preflight)
 		return nil
 	}
 
@@ -46,22 +46,26 @@ func getStatus(c *cli.Context) error {
 	if err != nil {
 		return fmt.Errorf("Error loading configuration: %w", err)
 	}
+	fiat := cliutils.NewFiatFormatter(rp)
 
 	// Account address & balances
 	fmt.Printf("%s=== Account and Balances ===%s\n", colorGreen, colorReset)
 	fmt.Printf(
-		"The node %s%s%s has a balance of %.6f ETH and %.6f RPL.\n",
+		"The node %s%s%s has a balance of %.6f ETH%s and %.6f RPL%s.\n",
 		colorBlue,
 		status.AccountAddressFormatted,
 		colorReset,
 		math.RoundDown(eth.WeiToEth(status.AccountBalances.ETH), 6),
-		math.RoundDown(eth.WeiToEth(status.AccountBalances.RPL), 6))
+		fiat.Eth(eth.WeiToEth(status.AccountBalances.ETH)),
+		math.RoundDown(eth.WeiToEth(status.AccountBalances.RPL), 6),
+		fiat.Rpl(eth.WeiToEth(status.AccountBalances.RPL)))
 	if status.AccountBalances.FixedSupplyRPL.Cmp(big.NewInt(0)) > 0 {
 		fmt.Printf("The node has a balance of %.6f old RPL which can be swapped for new RPL.\n", math.RoundDown(eth.WeiToEth(status.AccountBalances.FixedSupplyRPL), 6))
 	}
 	fmt.Printf(
-		"The node has %.6f ETH in its credit balance, which can be used to make new minipools.\n",
+		"The node has %.6f ETH%s in its credit balance, which can be used to make new minipools.\n",
 		math.RoundDown(eth.WeiToEth(status.CreditBalance), 6),
+		fiat.Eth(eth.WeiToEth(status.CreditBalance)),
 	)
 
 	// Registered node details
@@ -148,12 +152,14 @@ func getStatus(c *cli.Context) error {
 		fmt.Printf("%s=== Withdrawal Address ===%s\n", colorGreen, colorReset)
 		if !bytes.Equal(status.AccountAddress.Bytes(), status.WithdrawalAddress.Bytes()) {
 			fmt.Printf(
-				"The node's withdrawal address %s%s%s has a balance of %.6f ETH and %.6f RPL.\n",
+				"The node's withdrawal address %s%s%s has a balance of %.6f ETH%s and %.6f RPL%s.\n",
 				colorBlue,
 				status.WithdrawalAddressFormatted,
 				colorReset,
 				math.RoundDown(eth.WeiToEth(status.WithdrawalBalances.ETH), 6),
-				math.RoundDown(eth.WeiToEth(status.WithdrawalBalances.RPL), 6))
+				fiat.Eth(eth.WeiToEth(status.WithdrawalBalances.ETH)),
+				math.RoundDown(eth.WeiToEth(status.WithdrawalBalances.RPL), 6),
+				fiat.Rpl(eth.WeiToEth(status.WithdrawalBalances.RPL)))
 		} else {
 			fmt.Printf("%sThe node's withdrawal address has not been changed, so rewards and withdrawals will be sent to the node itself.\n", colorYellow)
 			fmt.Printf("Consider changing this to a cold wallet address that you control using the `set-withdrawal-address` command.\n%s", colorReset)
@@ -190,7 +196,7 @@ func getStatus(c *cli.Context) error {
 			fmt.Printf("The node is not opted into the Smoothing Pool.\nTo learn more about the Smoothing Pool, please visit %s.\n", smoothingPoolLink)
 		}
 
-		fmt.Printf("The node's fee distributor %s%s%s has a balance of %.6f ETH.\n", colorBlue, status.FeeRecipientInfo.FeeDistributorAddress.Hex(), colorReset, math.RoundDown(eth.WeiToEth(status.FeeDistributorBalance), 6))
+		fmt.Printf("The node's fee distributor %s%s%s has a balance of %.6f ETH%s.\n", colorBlue, status.FeeRecipientInfo.FeeDistributorAddress.Hex(), colorReset, math.RoundDown(eth.WeiToEth(status.FeeDistributorBalance), 6), fiat.Eth(eth.WeiToEth(status.FeeDistributorBalance)))
 		if cfg.IsNativeMode && !status.FeeRecipientInfo.IsInSmoothingPool && !status.FeeRecipientInfo.IsInOptOutCooldown {
 			fmt.Printf("%sNOTE: You are in Native Mode; you MUST ensure that your Validator Client is using this address as its fee recipient!%s\n", colorYellow, colorReset)
 		}
@@ -203,11 +209,17 @@ func getStatus(c *cli.Context) error {
 		// RPL stake details
 		fmt.Printf("%s=== RPL Stake ===%s\n", colorGreen, colorReset)
 		fmt.Println("NOTE: The following figures take *any pending bond reductions* into account.\n")
-		fmt.Printf(
-			"The node has a total stake of %.6f RPL and an effective stake of %.6f RPL.\n",
-			math.RoundDown(eth.WeiToEth(status.RplStake), 6),
-			math.RoundDown(eth.WeiToEth(status.EffectiveRplStake), 6))
-		if status.BorrowedCollateralRatio > 0 {
+		ethOnly := status.EthOnlyPermitted && status.RplStake.Sign() == 0
+		if ethOnly {
+			printEthOnlyForfeits()
+		} else {
+			fmt.Printf(
+				"The node has a total stake of %.6f RPL%s and an effective stake of %.6f RPL.\n",
+				math.RoundDown(eth.WeiToEth(status.RplStake), 6),
+				fiat.Rpl(eth.WeiToEth(status.RplStake)),
+				math.RoundDown(eth.WeiToEth(status.EffectiveRplStake), 6))
+		}
+		if status.BorrowedCollateralRatio > 0 && !ethOnly {
 			rplTooLow := (status.RplStake.Cmp(status.MinimumRplStake) < 0)
 			if rplTooLow {
 				fmt.Printf(
@@ -234,18 +246,22 @@ func getStatus(c *cli.Context) error {
 			fmt.Println()
 		}
 
-		remainingAmount := big.NewInt(0).Sub(status.EthMatchedLimit, status.EthMatched)
-		remainingAmount.Sub(remainingAmount, status.PendingMatchAmount)
-		remainingAmountEth := int(eth.WeiToEth(remainingAmount))
-		remainingFor8EB := remainingAmountEth / 24
-		if remainingFor8EB < 0 {
-			remainingFor8EB = 0
-		}
-		remainingFor16EB := remainingAmountEth / 16
-		if remainingFor16EB < 0 {
-			remainingFor16EB = 0
+		if status.EthOnlyPermitted {
+			fmt.Print("The protocol doesn't require RPL collateral, so the node's RPL stake doesn't limit how many minipools it can make.\n\n")
+		} else {
+			remainingAmount := big.NewInt(0).Sub(status.EthMatchedLimit, status.EthMatched)
+			remainingAmount.Sub(remainingAmount, status.PendingMatchAmount)
+			remainingAmountEth := int(eth.WeiToEth(remainingAmount))
+			remainingFor8EB := remainingAmountEth / 24
+			if remainingFor8EB < 0 {
+				remainingFor8EB = 0
+			}
+			remainingFor16EB := remainingAmountEth / 16
+			if remainingFor16EB < 0 {
+				remainingFor16EB = 0
+			}
+			fmt.Printf("The node has enough RPL staked to make %d more 8-ETH minipools (or %d more 16-ETH minipools).\n\n", remainingFor8EB, remainingFor16EB)
 		}
-		fmt.Printf("The node has enough RPL staked to make %d more 8-ETH minipools (or %d more 16-ETH minipools).\n\n", remainingFor8EB, remainingFor16EB)
 
 		// Minipool details
 		fmt.Printf("%s=== Minipools ===%s\n", colorGreen, colorReset)
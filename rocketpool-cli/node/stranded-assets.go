@@ -0,0 +1,161 @@
+package node
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	rocketpoolapi "github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/services/upgrades"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/math"
+)
+
+// The display names of the tokens stranded assets can be in
+var strandedAssetTokenNames = map[string]string{
+	"rpl": "RPL",
+	"eth": "ETH",
+}
+
+func getStrandedAssets(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the assets
+	response, err := rp.NodeStrandedAssets()
+	if err != nil {
+		return err
+	}
+	if len(response.Assets) == 0 {
+		fmt.Println("None of your node's assets are held by previous versions of Rocket Pool's contracts.")
+		return nil
+	}
+
+	// Print them
+	fmt.Printf("%sYour node has %d assets held by previous versions of Rocket Pool's contracts:%s\n", colorYellow, len(response.Assets), colorReset)
+	for _, asset := range response.Assets {
+		fmt.Printf("%.6f %s: %s\n", math.RoundDown(eth.WeiToEth(asset.Amount), 6), strandedAssetTokenNames[asset.Token], asset.Description())
+	}
+	fmt.Println()
+	fmt.Println("Run `rocketpool node claim-stranded-assets` to recover them.")
+	return nil
+
+}
+
+func claimStrandedAssets(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the assets
+	response, err := rp.NodeStrandedAssets()
+	if err != nil {
+		return err
+	}
+	if len(response.Assets) == 0 {
+		fmt.Println("None of your node's assets are held by previous versions of Rocket Pool's contracts.")
+		return nil
+	}
+
+	// Get selected assets
+	var selectedAssets []upgrades.StrandedAsset
+	if c.String("asset") == "" {
+
+		// Prompt for asset selection
+		options := make([]string, len(response.Assets)+1)
+		options[0] = "All available assets"
+		for ai, asset := range response.Assets {
+			options[ai+1] = fmt.Sprintf("%.6f %s: %s", math.RoundDown(eth.WeiToEth(asset.Amount), 6), strandedAssetTokenNames[asset.Token], asset.Description())
+		}
+		selected, _ := cliutils.Select("Please select an asset to recover:", options)
+
+		// Get assets
+		if selected == 0 {
+			selectedAssets = response.Assets
+		} else {
+			selectedAssets = []upgrades.StrandedAsset{response.Assets[selected-1]}
+		}
+
+	} else {
+
+		// Get matching assets
+		if c.String("asset") == "all" {
+			selectedAssets = response.Assets
+		} else {
+			selectedAddress := common.HexToAddress(c.String("asset"))
+			for _, asset := range response.Assets {
+				if asset.Address == selectedAddress {
+					selectedAssets = append(selectedAssets, asset)
+				}
+			}
+			if selectedAssets == nil {
+				return fmt.Errorf("Your node doesn't have any assets at %s.", selectedAddress.Hex())
+			}
+		}
+
+	}
+
+	// Get the total gas limit estimate
+	var totalGas uint64 = 0
+	var totalSafeGas uint64 = 0
+	var gasInfo rocketpoolapi.GasInfo
+	for _, asset := range selectedAssets {
+		canResponse, err := rp.CanNodeClaimStrandedAsset(asset.Type, asset.Address)
+		if err != nil {
+			fmt.Printf("WARNING: Couldn't get gas price for claim transaction (%s)", err)
+			break
+		} else {
+			gasInfo = canResponse.GasInfo
+			totalGas += canResponse.GasInfo.EstGasLimit
+			totalSafeGas += canResponse.GasInfo.SafeGasLimit
+		}
+	}
+	gasInfo.EstGasLimit = totalGas
+	gasInfo.SafeGasLimit = totalSafeGas
+
+	// Assign max fees
+	err = gas.AssignMaxFeeAndLimit(gasInfo, rp, c.Bool("yes"))
+	if err != nil {
+		return err
+	}
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to recover %d assets?", len(selectedAssets)))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Claim assets
+	for _, asset := range selectedAssets {
+		response, err := rp.NodeClaimStrandedAsset(asset.Type, asset.Address)
+		if err != nil {
+			fmt.Printf("Could not recover %s: %s.\n", asset.Description(), err)
+			continue
+		}
+
+		fmt.Printf("Recovering %s...\n", asset.Description())
+		cliutils.PrintTransactionHash(rp, response.TxHash)
+		if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
+			fmt.Printf("Could not recover %s: %s.\n", asset.Description(), err)
+		} else {
+			fmt.Printf("Successfully recovered %.6f %s.\n", math.RoundDown(eth.WeiToEth(asset.Amount), 6), strandedAssetTokenNames[asset.Token])
+		}
+	}
+
+	// Return
+	return nil
+
+}
@@ -17,6 +17,10 @@ var ethClientRecentBlockThreshold, _ = time.ParseDuration("5m")
 
 func printClientStatus(status *api.ClientStatus, name string) {
 
+	if status.Provider != "" {
+		defer fmt.Printf("\tIt's hosted by %s; the Smartnode keeps its requests within that provider's known limits.\n", status.Provider)
+	}
+
 	if status.Error != "" {
 		fmt.Printf("Your %s is unavailable (%s).\n", name, status.Error)
 		return
@@ -0,0 +1,58 @@
+package node
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+func getUptime(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Get the uptime report
+	months := c.Uint64("months")
+	if months == 0 {
+		return fmt.Errorf("Invalid months '0' - must be greater than 0")
+	}
+	response, err := rp.NodeUptime(months)
+	if err != nil {
+		return err
+	}
+	if len(response.Months) == 0 {
+		fmt.Printf("The node daemon hasn't recorded the uptime of any validators in the last %d months yet. It checks your validators' attestations once per epoch while the node daemon is running.\n", months)
+		return nil
+	}
+
+	fmt.Printf("Estimated revenue lost is based on a validator APR of %.2f%%, doubled to account for inactivity penalties.\n", response.ValidatorApr)
+	fmt.Printf("Epochs that weren't checked (e.g. while the node daemon was offline) aren't counted.\n\n")
+
+	for _, month := range response.Months {
+		fmt.Printf("%s=== %s ===%s\n", colorGreen, month.Month, colorReset)
+		var totalDowntime time.Duration
+		var totalMissed uint64
+		var totalLost float64
+		for _, validator := range month.Validators {
+			uptimePercent := 100.0
+			if validator.TrackedTime > 0 {
+				uptimePercent = 100 * (1 - validator.Downtime.Seconds()/validator.TrackedTime.Seconds())
+			}
+			color := colorReset
+			if validator.Downtime > 0 {
+				color = colorYellow
+			}
+			fmt.Printf("%sValidator %s: %.3f%% uptime, %s offline (%d missed epochs), ~%.6f ETH lost%s\n", color, validator.Index, uptimePercent, validator.Downtime.Round(time.Second), validator.MissedEpochs, validator.EstimatedEthLost, colorReset)
+			totalDowntime += validator.Downtime
+			totalMissed += validator.MissedEpochs
+			totalLost += validator.EstimatedEthLost
+		}
+		fmt.Printf("Total: %s offline across %d validators (%d missed epochs), ~%.6f ETH lost\n\n", totalDowntime.Round(time.Second), len(month.Validators), totalMissed, totalLost)
+	}
+	return nil
+
+}
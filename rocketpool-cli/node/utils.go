@@ -386,3 +386,11 @@ func promptForSoloKeyPassword(rp *rocketpool.Client, cfg *config.RocketPoolConfi
 	return passwordFile, nil
 
 }
+
+// Explain what a node gives up by staking without RPL
+func printEthOnlyForfeits() {
+	fmt.Printf("%sYour node doesn't have any RPL staked. The protocol allows this, but an ETH-only node forfeits:\n", colorYellow)
+	fmt.Println("- RPL staking rewards, which are only paid on staked RPL")
+	fmt.Println("- Voting power in the protocol DAO, which also comes from staked RPL")
+	fmt.Printf("It still earns its commission and Smoothing Pool rewards on its minipools. You can stake RPL at any time with `rocketpool node stake-rpl`.%s\n", colorReset)
+}
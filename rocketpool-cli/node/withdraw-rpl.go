@@ -90,16 +90,7 @@ func nodeWithdrawRpl(c *cli.Context) error {
 		return err
 	}
 	if !canWithdraw.CanWithdraw {
-		fmt.Println("Cannot withdraw staked RPL:")
-		if canWithdraw.InsufficientBalance {
-			fmt.Println("The node's staked RPL balance is insufficient.")
-		}
-		if canWithdraw.MinipoolsUndercollateralized {
-			fmt.Println("Remaining staked RPL is not enough to collateralize the node's minipools.")
-		}
-		if canWithdraw.WithdrawalDelayActive {
-			fmt.Println("The withdrawal delay period has not passed.")
-		}
+		cliutils.PrintPreflightResult("Cannot withdraw staked RPL:", canWithdraw.Preflight)
 		return nil
 	}
 
@@ -3,9 +3,7 @@ package node
 import (
 	"fmt"
 	"strconv"
-	"strings"
 
-	"github.com/ethereum/go-ethereum/common"
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
@@ -23,21 +21,9 @@ func setWithdrawalAddress(c *cli.Context, withdrawalAddressOrENS string) error {
 	}
 	defer rp.Close()
 
-	var withdrawalAddress common.Address
-	var withdrawalAddressString string
-	if strings.Contains(withdrawalAddressOrENS, ".") {
-		response, err := rp.ResolveEnsName(withdrawalAddressOrENS)
-		if err != nil {
-			return err
-		}
-		withdrawalAddress = response.Address
-		withdrawalAddressString = fmt.Sprintf("%s (%s)", withdrawalAddressOrENS, withdrawalAddress.Hex())
-	} else {
-		withdrawalAddress, err = cliutils.ValidateAddress("withdrawal address", withdrawalAddressOrENS)
-		if err != nil {
-			return err
-		}
-		withdrawalAddressString = withdrawalAddress.Hex()
+	withdrawalAddress, withdrawalAddressString, err := cliutils.ResolveAddress(rp, "withdrawal address", withdrawalAddressOrENS)
+	if err != nil {
+		return err
 	}
 
 	// Print the "pending" disclaimer
@@ -116,6 +102,15 @@ func setWithdrawalAddress(c *cli.Context, withdrawalAddressOrENS string) error {
 		return nil
 	}
 
+	// Check the confirmation policy
+	ready, err := cliutils.ConfirmProtectedCommand(rp, "node/set-withdrawal-address", []string{withdrawalAddress.Hex(), strconv.FormatBool(confirm)})
+	if err != nil {
+		return err
+	}
+	if !ready {
+		return nil
+	}
+
 	// Set node's withdrawal address
 	response, err := rp.SetNodeWithdrawalAddress(withdrawalAddress, confirm)
 	if err != nil {
@@ -176,6 +171,15 @@ func confirmWithdrawalAddress(c *cli.Context) error {
 		return nil
 	}
 
+	// Check the confirmation policy
+	ready, err := cliutils.ConfirmProtectedCommand(rp, "node/confirm-withdrawal-address", []string{})
+	if err != nil {
+		return err
+	}
+	if !ready {
+		return nil
+	}
+
 	// Confirm node's withdrawal address
 	response, err := rp.ConfirmNodeWithdrawalAddress()
 	if err != nil {
@@ -0,0 +1,36 @@
+package odao
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+func approveRplPrice(c *cli.Context, blockNumber uint64) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Your watchtower will submit the RPL price it calculated for block %d even though it diverges from the reference prices. Have you checked that the price is correct?", blockNumber))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Approve the price
+	if _, err := rp.ApproveTNDAORplPrice(blockNumber); err != nil {
+		return err
+	}
+
+	// Log & return
+	fmt.Printf("The RPL price for block %d has been approved. Your watchtower will submit it during its next duty check; you can follow it with `rocketpool service logs watchtower`.\n", blockNumber)
+	return nil
+
+}
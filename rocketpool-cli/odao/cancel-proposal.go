@@ -77,7 +77,7 @@ func cancelProposal(c *cli.Context) error {
 		// Prompt for proposal selection
 		options := make([]string, len(cancelableProposals))
 		for pi, proposal := range cancelableProposals {
-			options[pi] = fmt.Sprintf("proposal %d (message: '%s', payload: %s)", proposal.ID, proposal.Message, proposal.PayloadStr)
+			options[pi] = fmt.Sprintf("proposal %d (message: '%s', payload: %s)", proposal.ID, proposal.Message, getPayloadDescription(proposals, proposal))
 		}
 		selected, _ := cliutils.Select("Please select a proposal to cancel:", options)
 		selectedProposal = cancelableProposals[selected]
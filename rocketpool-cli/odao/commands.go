@@ -1,8 +1,13 @@
 package odao
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/urfave/cli"
 
+	"github.com/rocket-pool/smartnode/shared/services/addressbook"
+	"github.com/rocket-pool/smartnode/shared/types/api"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 )
 
@@ -625,6 +630,71 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "submissions",
+				Usage:     "Show the latest oracle DAO submissions, which members voted for which values, and when consensus was reached",
+				UsageText: "rocketpool odao submissions [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "type, t",
+						Usage: "The type of submissions to show ('prices', 'balances', or 'rewards')",
+						Value: string(api.TNDAOSubmissionType_Balances),
+					},
+					cli.Uint64Flag{
+						Name:  "last, l",
+						Usage: "The number of rounds to show",
+						Value: 5,
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Validate flags
+					submissionType, err := cliutils.ValidateTNDAOSubmissionType("submission type", c.String("type"))
+					if err != nil {
+						return err
+					}
+					if c.Uint64("last") == 0 {
+						return fmt.Errorf("Invalid round count '0' - must be greater than 0")
+					}
+
+					// Run
+					return getSubmissions(c, submissionType, c.Uint64("last"))
+
+				},
+			},
+
+			{
+				Name:      "approve-rpl-price",
+				Usage:     "Let the watchtower submit the RPL price for a block even though it diverges from the reference prices",
+				UsageText: "rocketpool odao approve-rpl-price [options] block",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm the approval",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					blockNumber, err := cliutils.ValidateUint("block number", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					return approveRplPrice(c, blockNumber)
+
+				},
+			},
+
 			{
 				Name:      "leave",
 				Aliases:   []string{"l"},
@@ -633,7 +703,7 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				Flags: []cli.Flag{
 					cli.StringFlag{
 						Name:  "refund-address, r",
-						Usage: "The address to refund the node's RPL bond to (or 'node')",
+						Usage: "The address, ENS name or address book name to refund the node's RPL bond to (or 'node')",
 					},
 					cli.BoolFlag{
 						Name:  "yes, y",
@@ -648,7 +718,7 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 					}
 
 					// Validate flags
-					if c.String("refund-address") != "" && c.String("refund-address") != "node" {
+					if c.String("refund-address") != "" && c.String("refund-address") != "node" && !strings.Contains(c.String("refund-address"), ".") && !addressbook.IsValidName(c.String("refund-address")) {
 						if _, err := cliutils.ValidateAddress("bond refund address", c.String("refund-address")); err != nil {
 							return err
 						}
@@ -77,7 +77,7 @@ func executeProposal(c *cli.Context) error {
 		options := make([]string, len(executableProposals)+1)
 		options[0] = "All available proposals"
 		for pi, proposal := range executableProposals {
-			options[pi+1] = fmt.Sprintf("proposal %d (message: '%s', payload: %s)", proposal.ID, proposal.Message, proposal.PayloadStr)
+			options[pi+1] = fmt.Sprintf("proposal %d (message: '%s', payload: %s)", proposal.ID, proposal.Message, getPayloadDescription(proposals, proposal))
 		}
 		selected, _ := cliutils.Select("Please select a proposal to execute:", options)
 
@@ -22,6 +22,7 @@ func leave(c *cli.Context) error {
 
 	// Get the RPL bond refund address
 	var bondRefundAddress common.Address
+	var bondRefundAddressString string
 	if c.String("refund-address") == "node" {
 
 		// Set bond refund address to node address
@@ -30,11 +31,15 @@ func leave(c *cli.Context) error {
 			return err
 		}
 		bondRefundAddress = wallet.AccountAddress
+		bondRefundAddressString = bondRefundAddress.Hex()
 
 	} else if c.String("refund-address") != "" {
 
 		// Parse bond refund address
-		bondRefundAddress = common.HexToAddress(c.String("refund-address"))
+		bondRefundAddress, bondRefundAddressString, err = cliutils.ResolveAddress(rp, "bond refund address", c.String("refund-address"))
+		if err != nil {
+			return err
+		}
 
 	} else {
 
@@ -47,11 +52,15 @@ func leave(c *cli.Context) error {
 		// Prompt for node address
 		if cliutils.Confirm(fmt.Sprintf("Would you like to refund your RPL bond to your node account (%s)?", wallet.AccountAddress.Hex())) {
 			bondRefundAddress = wallet.AccountAddress
+			bondRefundAddressString = bondRefundAddress.Hex()
 		} else {
 
 			// Prompt for custom address
-			inputAddress := cliutils.Prompt("Please enter the address to refund your RPL bond to:", "^0x[0-9a-fA-F]{40}$", "Invalid address")
-			bondRefundAddress = common.HexToAddress(inputAddress)
+			inputAddress := cliutils.Prompt("Please enter the address, ENS name or address book name to refund your RPL bond to:", "^\\S+$", "Invalid address")
+			bondRefundAddress, bondRefundAddressString, err = cliutils.ResolveAddress(rp, "bond refund address", inputAddress)
+			if err != nil {
+				return err
+			}
 
 		}
 
@@ -80,7 +89,7 @@ func leave(c *cli.Context) error {
 	}
 
 	// Prompt for confirmation
-	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to leave the oracle DAO and refund your RPL bond to %s? This action cannot be undone!", bondRefundAddress.Hex()))) {
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to leave the oracle DAO and refund your RPL bond to %s? This action cannot be undone!", bondRefundAddressString))) {
 		fmt.Println("Cancelled.")
 		return nil
 	}
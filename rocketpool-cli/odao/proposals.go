@@ -11,6 +11,7 @@ import (
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 )
 
@@ -89,6 +90,7 @@ func getProposals(c *cli.Context, stateFilter string) error {
 			for _, member := range allMembers.Members {
 				if bytes.Equal(proposal.ProposerAddress.Bytes(), member.Address.Bytes()) {
 					fmt.Printf("%d: %s - Proposed by: %s (%s)\n", proposal.ID, proposal.Message, member.ID, proposal.ProposerAddress)
+					fmt.Printf("    %s\n", getPayloadDescription(allProposals, proposal))
 				}
 			}
 		}
@@ -150,7 +152,8 @@ func getProposal(c *cli.Context, id uint64) error {
 	// Main details
 	fmt.Printf("Proposal ID:          %d\n", proposal.ID)
 	fmt.Printf("Message:              %s\n", proposal.Message)
-	fmt.Printf("Payload:              %s\n", proposal.PayloadStr)
+	fmt.Printf("Payload:              %s\n", getPayloadDescription(allProposals, *proposal))
+	fmt.Printf("Payload (call):       %s\n", proposal.PayloadStr)
 	fmt.Printf("Payload (bytes):      %s\n", hex.EncodeToString(proposal.Payload))
 	fmt.Printf("Proposed by:          %s (%s)\n", memberID, proposal.ProposerAddress.Hex())
 	fmt.Printf("Created at:           %s\n", cliutils.GetDateTimeString(proposal.CreatedTime))
@@ -186,3 +189,11 @@ func getProposal(c *cli.Context, id uint64) error {
 
 	return nil
 }
+
+// Get the description of a proposal's payload, or its raw payload string if it couldn't be decoded
+func getPayloadDescription(proposals api.TNDAOProposalsResponse, proposal dao.ProposalDetails) string {
+	if description, exists := proposals.PayloadDescriptions[proposal.ID]; exists {
+		return description
+	}
+	return proposal.PayloadStr
+}
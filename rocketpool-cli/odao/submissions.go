@@ -0,0 +1,98 @@
+package odao
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+const (
+	colorReset  string = "\033[0m"
+	colorRed    string = "\033[31m"
+	colorGreen  string = "\033[32m"
+	colorYellow string = "\033[33m"
+)
+
+func getSubmissions(c *cli.Context, submissionType api.TNDAOSubmissionType, last uint64) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the submissions
+	fmt.Printf("Searching the %s submission events, which can take a while...\n\n", submissionType)
+	response, err := rp.TNDAOSubmissions(submissionType, last)
+	if err != nil {
+		return err
+	}
+	if len(response.Rounds) == 0 {
+		fmt.Printf("The oracle DAO hasn't made any %s submissions yet.\n", submissionType)
+		return nil
+	}
+
+	// Rounds without consensus that are newer than the latest one with it are still being voted on; older ones were superseded
+	latestConsensus := uint64(0)
+	for _, round := range response.Rounds {
+		if round.ConsensusReached && round.Key > latestConsensus {
+			latestConsensus = round.Key
+		}
+	}
+
+	for _, round := range response.Rounds {
+		if submissionType == api.TNDAOSubmissionType_Rewards {
+			fmt.Printf("%s=== Rewards interval %d (execution block %d) ===%s\n", colorGreen, round.Key, round.ReferenceBlock, colorReset)
+		} else {
+			fmt.Printf("%s=== %s for block %d ===%s\n", colorGreen, getSubmissionTypeTitle(submissionType), round.Key, colorReset)
+		}
+
+		agreeing := 0
+		for _, vote := range round.Votes {
+			if vote.MatchesConsensus {
+				agreeing++
+			}
+		}
+		if round.ConsensusReached {
+			fmt.Printf("Consensus reached in block %d at %s with %d of %d submissions in agreement:\n\t%s\n", round.ConsensusBlock, cliutils.GetDateTimeString(uint64(round.ConsensusTime.Unix())), agreeing, len(round.Votes), round.ConsensusValue)
+		} else if round.Key > latestConsensus {
+			fmt.Printf("%sNo consensus yet; %d of the %d current members have submitted.%s\n", colorYellow, len(round.Votes), response.MemberCount, colorReset)
+		} else {
+			fmt.Printf("%sConsensus was never reached; a later round superseded this one.%s\n", colorYellow, colorReset)
+		}
+
+		for _, vote := range round.Votes {
+			member := vote.Member.Hex()
+			if vote.MemberID != "" {
+				member = fmt.Sprintf("%s (%s)", vote.MemberID, vote.Member.Hex())
+			}
+			color := ""
+			if round.ConsensusReached {
+				color = colorRed
+				if vote.MatchesConsensus {
+					color = colorGreen
+				}
+			}
+			fmt.Printf("\t%s%s%s\n\t\tBlock %d at %s: %s\n", color, member, colorReset, vote.Block, cliutils.GetDateTimeString(uint64(vote.Time.Unix())), vote.Value)
+		}
+		fmt.Println()
+	}
+	return nil
+
+}
+
+// Get the title of a submission type
+func getSubmissionTypeTitle(submissionType api.TNDAOSubmissionType) string {
+	switch submissionType {
+	case api.TNDAOSubmissionType_Prices:
+		return "RPL price"
+	case api.TNDAOSubmissionType_Balances:
+		return "Network balances"
+	}
+	return string(submissionType)
+}
@@ -87,7 +87,7 @@ func voteOnProposal(c *cli.Context) error {
 				"proposal %d (message: '%s', payload: %s, end time: %s, votes required: %.2f, votes for: %.2f, votes against: %.2f, proposed by: %s (%s))",
 				proposal.ID,
 				proposal.Message,
-				proposal.PayloadStr,
+				getPayloadDescription(proposals, proposal),
 				cliutils.GetDateTimeString(proposal.EndTime),
 				proposal.VotesRequired,
 				proposal.VotesFor,
@@ -2,6 +2,7 @@ package queue
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/urfave/cli"
@@ -28,6 +29,38 @@ func getStatus(c *cli.Context) error {
 	// Print & return
 	fmt.Printf("The staking pool has a balance of %.6f ETH.\n", math.RoundDown(eth.WeiToEth(status.DepositPoolBalance), 6))
 	fmt.Printf("There are %d available minipools with a total capacity of %.6f ETH.\n", status.MinipoolQueueLength, math.RoundDown(eth.WeiToEth(status.MinipoolQueueCapacity), 6))
+	spareEth := eth.WeiToEth(status.DepositPoolBalance) - eth.WeiToEth(status.MinipoolQueueCapacity)
+	if spareEth > 0 {
+		fmt.Printf("After matching the whole queue, the staking pool would have %.6f ETH left for new minipools.\n", math.RoundDown(spareEth, 6))
+	} else {
+		fmt.Printf("The queue needs %.6f ETH more than the staking pool has before new minipools can be matched.\n", math.RoundDown(-spareEth, 6))
+	}
+	fmt.Printf("Over the last %d days, an average of %.2f ETH per day was deposited into the staking pool.\n", status.DepositRateDays, status.DepositRate)
+
+	// Print the node's minipools in the queue
+	if len(status.NodeMinipools) > 0 {
+		fmt.Printf("\nYour node has %d minipool(s) in the queue:\n", len(status.NodeMinipools))
+		for _, minipool := range status.NodeMinipools {
+			wait := "unknown (no recent deposits)"
+			if minipool.WaitKnown {
+				wait = formatWait(minipool.EstimatedWait)
+			}
+			fmt.Printf("%s: position %d, estimated wait %s\n", minipool.Address.Hex(), minipool.Position, wait)
+		}
+		fmt.Println("Wait estimates assume deposits keep coming in at the recent rate.")
+	}
 	return nil
 
 }
+
+// Format a wait estimate in days and hours
+func formatWait(wait time.Duration) string {
+	hours := int64(wait.Hours())
+	if hours == 0 {
+		return "less than an hour"
+	}
+	if hours < 24 {
+		return fmt.Sprintf("%d hours", hours)
+	}
+	return fmt.Sprintf("%d days, %d hours", hours/24, hours%24)
+}
@@ -0,0 +1,34 @@
+package quickstart
+
+import (
+	"github.com/urfave/cli"
+
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Register commands
+func RegisterCommands(app *cli.App, name string, aliases []string) {
+	app.Commands = append(app.Commands, cli.Command{
+		Name:      name,
+		Aliases:   aliases,
+		Usage:     "Set up a new node step by step: install the Smartnode, choose your clients, wait for them to sync, create a wallet, fund it and register the node",
+		UsageText: "rocketpool quickstart [options]",
+		Flags: []cli.Flag{
+			cli.BoolFlag{
+				Name:  "restart, r",
+				Usage: "Forget the progress of a previous run and check every step again",
+			},
+		},
+		Action: func(c *cli.Context) error {
+
+			// Validate args
+			if err := cliutils.ValidateArgCount(c, 0); err != nil {
+				return err
+			}
+
+			// Run
+			return runQuickstart(c)
+
+		},
+	})
+}
@@ -0,0 +1,453 @@
+package quickstart
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/rocketpool-cli/node"
+	"github.com/rocket-pool/smartnode/rocketpool-cli/service"
+	"github.com/rocket-pool/smartnode/rocketpool-cli/wallet"
+	"github.com/rocket-pool/smartnode/shared"
+	"github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Settings
+const (
+	progressFilename     string        = "quickstart.json"
+	pollInterval         time.Duration = 15 * time.Second
+	registrationTimezone string        = "Etc/UTC"
+	progressBarWidth     int           = 20
+	colorReset           string        = "\033[0m"
+	colorRed             string        = "\033[31m"
+	colorGreen           string        = "\033[32m"
+	colorYellow          string        = "\033[33m"
+	colorLightBlue       string        = "\033[36m"
+)
+
+// Step IDs, as saved in the progress file
+const (
+	stepInstall  string = "install"
+	stepConfig   string = "config"
+	stepStart    string = "start"
+	stepSync     string = "sync"
+	stepWallet   string = "wallet"
+	stepFunding  string = "funding"
+	stepRegister string = "register"
+)
+
+// The steps that have been completed, saved in the Rocket Pool directory so the quickstart can be resumed
+type progress struct {
+	path      string
+	Completed map[string]time.Time `json:"completed"`
+}
+
+// One step of the quickstart
+type step struct {
+	id    string
+	title string
+
+	// Checks whether the step is already done
+	check func(q *quickstart) (bool, error)
+
+	// Once finished, trust the saved progress instead of checking again
+	once bool
+
+	// Runs the step; returns false if the user stopped before it was finished
+	run func(q *quickstart) (bool, error)
+}
+
+// The state shared by the quickstart steps
+type quickstart struct {
+	c          *cli.Context
+	configPath string
+	progress   *progress
+}
+
+// The steps in the order they're run
+var steps = []step{
+	{id: stepInstall, title: "Install the Smartnode", check: isInstalled, run: install},
+	{id: stepConfig, title: "Choose your clients and settings", check: isConfigured, run: configure},
+	{id: stepStart, title: "Start the Smartnode", check: isRunning, run: start},
+	{id: stepSync, title: "Sync your clients", check: isSynced, run: waitForSync, once: true},
+	{id: stepWallet, title: "Create or recover the node wallet", check: hasWallet, run: createWallet},
+	{id: stepFunding, title: "Fund the node wallet", check: isFunded, run: waitForFunding, once: true},
+	{id: stepRegister, title: "Register the node", check: isRegistered, run: register},
+}
+
+// Walk through setting up a new node, skipping the steps that are already done
+func runQuickstart(c *cli.Context) error {
+
+	// Load the progress of a previous run
+	configPath, err := homedir.Expand(c.GlobalString("config-path"))
+	if err != nil {
+		return fmt.Errorf("error expanding config path [%s]: %w", c.GlobalString("config-path"), err)
+	}
+	progressPath := filepath.Join(configPath, progressFilename)
+	if c.Bool("restart") {
+		if err := os.Remove(progressPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("error removing quickstart progress file [%s]: %w", progressPath, err)
+		}
+	}
+	prog, err := loadProgress(progressPath)
+	if err != nil {
+		return err
+	}
+	q := &quickstart{
+		c:          c,
+		configPath: configPath,
+		progress:   prog,
+	}
+
+	fmt.Printf("%s=== Rocket Pool Quickstart ===%s\n", colorGreen, colorReset)
+	fmt.Println("This will take you through setting up a new node. Each step is checked before it runs, so you can stop at any time and run `rocketpool quickstart` again to continue.")
+	fmt.Println()
+
+	for i, s := range steps {
+		fmt.Printf("%sStep %d of %d: %s%s\n", colorLightBlue, i+1, len(steps), s.title, colorReset)
+
+		// Skip the step if it's already done
+		completedTime, completed := q.progress.Completed[s.id]
+		if s.once && completed {
+			fmt.Printf("%sDone on %s.%s\n\n", colorGreen, completedTime.Local().Format(time.RFC1123), colorReset)
+			continue
+		}
+		done, err := s.check(q)
+		if err != nil {
+			return fmt.Errorf("error checking the %s step: %w", s.id, err)
+		}
+		if done {
+			fmt.Printf("%sDone.%s\n\n", colorGreen, colorReset)
+			if err := q.complete(s.id); err != nil {
+				return err
+			}
+			continue
+		}
+
+		// Run it
+		finished, err := s.run(q)
+		if err != nil {
+			return err
+		}
+		if !finished {
+			fmt.Println()
+			fmt.Println("Run `rocketpool quickstart` again to pick up where you left off.")
+			return nil
+		}
+		if err := q.complete(s.id); err != nil {
+			return err
+		}
+		fmt.Println()
+	}
+
+	// Print the next steps
+	fmt.Printf("%sYour node is set up and registered with Rocket Pool!%s\n\n", colorGreen, colorReset)
+	fmt.Printf("%s=== Next Steps ===%s\n", colorLightBlue, colorReset)
+	fmt.Println("- Set your withdrawal address with `rocketpool node set-withdrawal-address`.")
+	fmt.Println("- Stake RPL with `rocketpool node stake-rpl` if you want to earn RPL rewards.")
+	fmt.Println("- Create a minipool with `rocketpool node deposit`.")
+	fmt.Println("- Keep an eye on your node with `rocketpool node dashboard`.")
+	return nil
+
+}
+
+// Load the quickstart progress, or start with none if there isn't any saved yet
+func loadProgress(path string) (*progress, error) {
+	prog := &progress{
+		path:      path,
+		Completed: map[string]time.Time{},
+	}
+	bytes, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return prog, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading quickstart progress file [%s]: %w", path, err)
+	}
+	if err := json.Unmarshal(bytes, prog); err != nil {
+		return nil, fmt.Errorf("error deserializing quickstart progress file [%s]: %w", path, err)
+	}
+	if prog.Completed == nil {
+		prog.Completed = map[string]time.Time{}
+	}
+	return prog, nil
+}
+
+// Record a step as completed
+func (q *quickstart) complete(id string) error {
+	if _, exists := q.progress.Completed[id]; exists {
+		return nil
+	}
+	q.progress.Completed[id] = time.Now().UTC()
+
+	// The progress lives in the Rocket Pool directory, which doesn't exist until the Smartnode is installed
+	if _, err := os.Stat(q.configPath); err != nil {
+		return nil
+	}
+	bytes, err := json.Marshal(q.progress)
+	if err != nil {
+		return fmt.Errorf("error serializing quickstart progress: %w", err)
+	}
+	if err := os.WriteFile(q.progress.path, bytes, 0644); err != nil {
+		return fmt.Errorf("error writing quickstart progress file [%s]: %w", q.progress.path, err)
+	}
+	return nil
+}
+
+// Create a context for running another command's action, with only the provided options set.
+// The quickstart's own flags must not leak into them; `service config` runs headless when it sees any flags.
+func (q *quickstart) newStepContext(defaults map[string]string) *cli.Context {
+	set := flag.NewFlagSet("quickstart", flag.ContinueOnError)
+	for name, value := range defaults {
+		set.String(name, value, "")
+	}
+	return cli.NewContext(q.c.App, set, q.c)
+}
+
+// Get a client for the step's checks
+func (q *quickstart) newClient() *rocketpool.Client {
+	return rocketpool.NewClientFromCtx(q.c)
+}
+
+func isInstalled(q *quickstart) (bool, error) {
+	_, err := os.Stat(q.configPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("error checking the Rocket Pool directory [%s]: %w", q.configPath, err)
+	}
+	return true, nil
+}
+
+func install(q *quickstart) (bool, error) {
+	err := service.InstallService(q.newStepContext(map[string]string{
+		"version": fmt.Sprintf("v%s", shared.RocketPoolVersion),
+	}))
+	if err != nil {
+		return false, err
+	}
+	installed, err := isInstalled(q)
+	if err != nil || !installed {
+		return false, err
+	}
+
+	// Docker permissions for a new install only apply to new shell sessions, so the rest has to wait
+	if err := q.complete(stepInstall); err != nil {
+		return false, err
+	}
+	fmt.Println()
+	fmt.Printf("%sLog out and back in (or restart the machine) before continuing, so your account can use Docker.%s\n", colorYellow, colorReset)
+	return false, nil
+}
+
+func isConfigured(q *quickstart) (bool, error) {
+	rp := q.newClient()
+	defer rp.Close()
+	_, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return false, err
+	}
+	return !isNew, nil
+}
+
+func configure(q *quickstart) (bool, error) {
+	fmt.Println("The configuration screen will open so you can choose your network, Execution and Consensus clients, and other settings. Save your changes when you're done.")
+	if !cliutils.Confirm("Ready to continue?") {
+		return false, nil
+	}
+	if err := service.ConfigureService(q.newStepContext(nil)); err != nil {
+		return false, err
+	}
+	return isConfigured(q)
+}
+
+// The API only answers once the Smartnode is running
+func isRunning(q *quickstart) (bool, error) {
+	rp := q.newClient()
+	defer rp.Close()
+	_, err := rp.WalletStatus()
+	return err == nil, nil
+}
+
+func start(q *quickstart) (bool, error) {
+	if err := service.StartService(q.newStepContext(nil), true); err != nil {
+		return false, err
+	}
+	return isRunning(q)
+}
+
+func isSynced(q *quickstart) (bool, error) {
+	rp := q.newClient()
+	defer rp.Close()
+	status, err := rp.NodeSync()
+	if err != nil {
+		return false, err
+	}
+	return status.EcStatus.PrimaryClientStatus.IsSynced && status.BcStatus.PrimaryClientStatus.IsSynced, nil
+}
+
+func waitForSync(q *quickstart) (bool, error) {
+	rp := q.newClient()
+	defer rp.Close()
+
+	fmt.Println("Your clients are syncing. This can take anywhere from a few hours to a few days, depending on your clients and hardware.")
+	fmt.Println("You can leave this running, or press Ctrl+C and run `rocketpool quickstart` again later.")
+	for {
+		status, err := rp.NodeSync()
+		if err != nil {
+			return false, err
+		}
+		ec := status.EcStatus.PrimaryClientStatus
+		bc := status.BcStatus.PrimaryClientStatus
+		fmt.Printf("\rExecution: %s   Consensus: %s", formatSyncStatus(ec), formatSyncStatus(bc))
+		if ec.IsSynced && bc.IsSynced {
+			fmt.Println()
+			return true, nil
+		}
+		time.Sleep(pollInterval)
+	}
+}
+
+// Format a client's sync progress for the single line the sync step keeps updating
+func formatSyncStatus(status api.ClientStatus) string {
+	if status.Error != "" {
+		return fmt.Sprintf("%sunavailable%s                ", colorRed, colorReset)
+	}
+	if status.IsSynced {
+		return fmt.Sprintf("%ssynced%s                     ", colorGreen, colorReset)
+	}
+	percent := rocketpool.SyncRatioToPercent(status.SyncProgress)
+	filled := int(percent / 100 * float64(progressBarWidth))
+	if filled > progressBarWidth {
+		filled = progressBarWidth
+	}
+	if filled < 0 {
+		filled = 0
+	}
+	return fmt.Sprintf("[%s%s] %6.2f%%", strings.Repeat("#", filled), strings.Repeat("-", progressBarWidth-filled), percent)
+}
+
+func hasWallet(q *quickstart) (bool, error) {
+	rp := q.newClient()
+	defer rp.Close()
+	status, err := rp.WalletStatus()
+	if err != nil {
+		return false, err
+	}
+	return status.WalletInitialized, nil
+}
+
+func createWallet(q *quickstart) (bool, error) {
+	options := []string{
+		"Create a new wallet",
+		"Recover an existing wallet from its mnemonic",
+	}
+	selection, _ := cliutils.Select("Your node needs a wallet to send transactions and hold its ETH and RPL.", options)
+	var err error
+	if selection == 0 {
+		err = wallet.InitWallet(q.newStepContext(nil))
+	} else {
+		err = wallet.RecoverWallet(q.newStepContext(nil))
+	}
+	if err != nil {
+		return false, err
+	}
+	return hasWallet(q)
+}
+
+// A registered node doesn't need funding for registration anymore
+func isFunded(q *quickstart) (bool, error) {
+	registered, err := isRegistered(q)
+	if err != nil || registered {
+		return registered, err
+	}
+	balance, required, err := getRegistrationFunding(q)
+	if err != nil {
+		return false, err
+	}
+	return balance.Sign() > 0 && balance.Cmp(required) >= 0, nil
+}
+
+func waitForFunding(q *quickstart) (bool, error) {
+	rp := q.newClient()
+	defer rp.Close()
+	status, err := rp.WalletStatus()
+	if err != nil {
+		return false, err
+	}
+
+	balance, required, err := getRegistrationFunding(q)
+	if err != nil {
+		return false, err
+	}
+	if required.Sign() > 0 {
+		fmt.Printf("Registering your node costs about %.6f ETH in gas at the current gas price.\n", eth.WeiToEth(required))
+	}
+	fmt.Printf("Please send ETH to your node address: %s%s%s\n", colorGreen, status.AccountAddress.Hex(), colorReset)
+	fmt.Println("Waiting for it to arrive; you can press Ctrl+C and run `rocketpool quickstart` again later.")
+	for balance.Sign() == 0 || balance.Cmp(required) < 0 {
+		fmt.Printf("\rBalance: %.6f ETH", eth.WeiToEth(balance))
+		time.Sleep(pollInterval)
+		response, err := rp.GetEthBalance()
+		if err != nil {
+			return false, err
+		}
+		balance = response.Balance
+	}
+	fmt.Printf("\rBalance: %.6f ETH\n", eth.WeiToEth(balance))
+	return true, nil
+}
+
+// Get the node's ETH balance and the most registration could cost in gas; if there's no gas price suggestion available, any balance is accepted
+func getRegistrationFunding(q *quickstart) (*big.Int, *big.Int, error) {
+	rp := q.newClient()
+	defer rp.Close()
+
+	response, err := rp.GetEthBalance()
+	if err != nil {
+		return nil, nil, err
+	}
+	canRegister, err := rp.CanRegisterNode(registrationTimezone)
+	if err != nil {
+		return nil, nil, err
+	}
+	required := big.NewInt(0)
+	maxFee, err := gas.GetHeadlessMaxFeeWei()
+	if err != nil {
+		fmt.Printf("%sWARNING: couldn't estimate the cost of registering your node (%s).%s\n", colorYellow, err.Error(), colorReset)
+	} else {
+		required.Mul(maxFee, big.NewInt(int64(canRegister.GasInfo.SafeGasLimit)))
+	}
+	return response.Balance, required, nil
+}
+
+func isRegistered(q *quickstart) (bool, error) {
+	rp := q.newClient()
+	defer rp.Close()
+	status, err := rp.NodeStatus()
+	if err != nil {
+		return false, err
+	}
+	return status.Registered, nil
+}
+
+func register(q *quickstart) (bool, error) {
+	if err := node.RegisterNode(q.newStepContext(nil)); err != nil {
+		return false, err
+	}
+	return isRegistered(q)
+}
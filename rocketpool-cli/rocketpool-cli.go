@@ -15,6 +15,7 @@ import (
 	"github.com/rocket-pool/smartnode/rocketpool-cli/node"
 	"github.com/rocket-pool/smartnode/rocketpool-cli/odao"
 	"github.com/rocket-pool/smartnode/rocketpool-cli/queue"
+	"github.com/rocket-pool/smartnode/rocketpool-cli/quickstart"
 	"github.com/rocket-pool/smartnode/rocketpool-cli/service"
 	"github.com/rocket-pool/smartnode/rocketpool-cli/wallet"
 	"github.com/rocket-pool/smartnode/shared"
@@ -98,10 +99,18 @@ ______           _        _    ______           _
 			Name:  "nonce",
 			Usage: "Use this flag to explicitly specify the nonce that this transaction should use, so it can override an existing 'stuck' transaction",
 		},
+		cli.StringFlag{
+			Name:  "idempotency-key",
+			Usage: "A unique `key` for the transactions this command sends; running the command again with the same key returns the original transactions instead of sending new ones. Requires the node API server.",
+		},
 		cli.BoolFlag{
 			Name:  "debug",
 			Usage: "Enable debug printing of API commands",
 		},
+		cli.BoolFlag{
+			Name:  "quiet, q",
+			Usage: "Don't show progress bars for long-running commands, for use in scripts",
+		},
 		cli.BoolFlag{
 			Name: "secure-session, s",
 			Usage: "Some commands may print sensitive information to your terminal. " +
@@ -151,6 +160,7 @@ ______           _        _    ______           _
 	node.RegisterCommands(app, "node", []string{"n"})
 	odao.RegisterCommands(app, "odao", []string{"o"})
 	queue.RegisterCommands(app, "queue", []string{"q"})
+	quickstart.RegisterCommands(app, "quickstart", []string{"qs"})
 	service.RegisterCommands(app, "service", []string{"s"})
 	wallet.RegisterCommands(app, "wallet", []string{"w"})
 
@@ -0,0 +1,222 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/addons"
+	"github.com/rocket-pool/smartnode/addons/external"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	addontypes "github.com/rocket-pool/smartnode/shared/types/addons"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// List the built-in and installed community addons along with their status
+func listAddons(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Load the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode first.")
+	}
+
+	// Get the status the node daemon last recorded; it isn't available if the Smartnode isn't running
+	statuses := map[string]addons.AddonStatus{}
+	response, err := rp.GetAddonStatus()
+	if err != nil {
+		fmt.Printf("%sCouldn't get the status of your addons from the node daemon: %s%s\n\n", colorYellow, err.Error(), colorReset)
+	} else if response.Report != nil {
+		for _, status := range response.Report.Addons {
+			statuses[status.ID] = status
+		}
+	}
+
+	for _, addon := range cfg.GetAddons() {
+		source := "built-in"
+		if !addons.IsBuiltinAddon(addon.GetID()) {
+			source = "community"
+		}
+		fmt.Printf("%s%s%s (%s, %s)\n", colorGreen, addon.GetName(), colorReset, addon.GetID(), source)
+		if addon.GetEnabledParameter().Value != true {
+			fmt.Println("    Disabled")
+			continue
+		}
+		fmt.Println("    Enabled")
+		status, exists := statuses[addon.GetID()]
+		if !exists {
+			continue
+		}
+		if status.Status != nil {
+			fmt.Printf("    Status: %s\n", formatAddonStatus(*status.Status))
+		}
+		if status.Error != "" {
+			fmt.Printf("    Status: %s%s%s\n", colorRed, status.Error, colorReset)
+		}
+		if status.TaskError != "" {
+			fmt.Printf("    Task:   %s%s%s\n", colorRed, status.TaskError, colorReset)
+		}
+	}
+
+	// Show the addons that couldn't be loaded
+	for _, err := range cfg.ExternalAddonErrors {
+		fmt.Printf("%sWARNING: %s%s\n", colorYellow, err.Error(), colorReset)
+	}
+	return nil
+
+}
+
+// Install a community addon from a folder with its manifest and container template
+func installAddon(c *cli.Context, path string) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Load the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode first.")
+	}
+
+	// Check the addon
+	path, err = homedir.Expand(path)
+	if err != nil {
+		return fmt.Errorf("error expanding addon path: %w", err)
+	}
+	manifest, err := external.LoadManifest(path)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s%s%s (%s)\n", colorGreen, manifest.Name, colorReset, manifest.ID)
+	if manifest.Version != "" {
+		fmt.Printf("Version:   %s\n", manifest.Version)
+	}
+	fmt.Printf("Container: %s\n", manifest.ContainerTag)
+	fmt.Printf("%s\n\n", manifest.Description)
+
+	// Confirm
+	fmt.Printf("%sCommunity addons aren't reviewed by the Rocket Pool team. They run as containers next to your node and the node daemon shares your node address with them, so only install addons from people you trust.%s\n\n", colorYellow, colorReset)
+	if cfg.GetAddon(manifest.ID) != nil {
+		fmt.Printf("This addon is already installed; installing it again replaces it but keeps its settings.\n\n")
+	}
+	if !(c.Bool("yes") || cliutils.Confirm("Would you like to install this addon?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Install it
+	if _, err := rp.InstallAddon(path); err != nil {
+		return err
+	}
+	fmt.Printf("%sInstalled %s (%s).%s\n", colorGreen, manifest.Name, manifest.ID, colorReset)
+	fmt.Printf("Enable it with `rocketpool service addons enable %s` or in the Addons section of `rocketpool service config`.\n", manifest.ID)
+	return nil
+
+}
+
+// Uninstall a community addon
+func uninstallAddon(c *cli.Context, id string) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Load the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode first.")
+	}
+	addon := cfg.GetAddon(id)
+	if addon != nil && addon.GetEnabledParameter().Value == true {
+		return fmt.Errorf("Addon [%s] is still enabled. Please disable it with `rocketpool service addons disable %s` and restart the Smartnode with `rocketpool service start` first.", id, id)
+	}
+
+	// Confirm
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to uninstall addon [%s]?", id))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Uninstall it
+	if err := rp.UninstallAddon(id); err != nil {
+		return err
+	}
+	fmt.Printf("Uninstalled addon [%s].\n", id)
+	return nil
+
+}
+
+// Enable or disable an addon
+func setAddonEnabled(c *cli.Context, id string, enabled bool) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Load the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode first.")
+	}
+	addon := cfg.GetAddon(id)
+	if addon == nil {
+		return fmt.Errorf("Addon [%s] isn't installed. Run `rocketpool service addons list` to see the available addons.", id)
+	}
+
+	// Update the setting
+	enabledParam := addon.GetEnabledParameter()
+	if enabledParam.Value == enabled {
+		if enabled {
+			fmt.Printf("%s is already enabled.\n", addon.GetName())
+		} else {
+			fmt.Printf("%s is already disabled.\n", addon.GetName())
+		}
+		return nil
+	}
+	enabledParam.Value = enabled
+	if err := rp.SaveConfig(cfg); err != nil {
+		return err
+	}
+
+	if enabled {
+		fmt.Printf("%sEnabled %s.%s You can change its settings in the Addons section of `rocketpool service config`.\n", colorGreen, addon.GetName(), colorReset)
+	} else {
+		fmt.Printf("Disabled %s.\n", addon.GetName())
+	}
+	fmt.Println("Please restart the Smartnode with `rocketpool service start` to apply the change.")
+	return nil
+
+}
+
+// Format an addon's status with the color for its level
+func formatAddonStatus(status addontypes.Status) string {
+	color := colorGreen
+	switch status.Level {
+	case addontypes.StatusLevel_Warning:
+		color = colorYellow
+	case addontypes.StatusLevel_Error:
+		color = colorRed
+	}
+	if status.Message == "" {
+		return fmt.Sprintf("%s%s%s", color, status.Level, colorReset)
+	}
+	return fmt.Sprintf("%s%s%s (%s)", color, status.Level, colorReset, status.Message)
+}
@@ -0,0 +1,183 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// List the keys for the node daemon's API server
+func listApiKeys(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Load the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode first.")
+	}
+	if cfg.ApiServer.Enabled.Value != true {
+		fmt.Printf("%sThe node API server isn't enabled, so these keys can't be used yet. You can enable it in the `Node API Server` section of `rocketpool service config`.%s\n\n", colorYellow, colorReset)
+	}
+
+	// Get the keys
+	keys, err := rp.LoadApiKeys(cfg)
+	if err != nil {
+		return err
+	}
+	if len(keys.Keys) == 0 {
+		fmt.Println("You don't have any API keys yet. Create one with `rocketpool service api-keys add <name> --role <role>`.")
+		return nil
+	}
+	for _, key := range keys.Keys {
+		fmt.Printf("%s%s%s\n", colorGreen, key.Name, colorReset)
+		fmt.Printf("    Role:    %s\n", key.Role)
+		fmt.Printf("    Created: %s\n", key.Created.Local().Format("2006-01-02 15:04:05"))
+		if !key.Rotated.IsZero() {
+			fmt.Printf("    Rotated: %s\n", key.Rotated.Local().Format("2006-01-02 15:04:05"))
+		}
+	}
+	return nil
+
+}
+
+// Create a new key for the node daemon's API server
+func addApiKey(c *cli.Context, name string) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Check the role
+	role, err := config.ParseApiRole(c.String("role"))
+	if err != nil {
+		return err
+	}
+	if role == config.ApiRole_Admin && !(c.Bool("yes") || cliutils.Confirm("Admin keys can do everything the CLI can, including exporting your node wallet and sending its funds anywhere. Are you sure you want to create one?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Load the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode first.")
+	}
+
+	// Add the key
+	keys, err := rp.LoadApiKeys(cfg)
+	if err != nil {
+		return err
+	}
+	token, err := keys.Add(name, role)
+	if err != nil {
+		return err
+	}
+	if err := rp.SaveApiKeys(cfg, keys); err != nil {
+		return err
+	}
+
+	fmt.Printf("Created the %s key '%s'. Send it as a bearer token in the Authorization header of your requests:\n\n", role, name)
+	fmt.Printf("    %s%s%s\n\n", colorGreen, token, colorReset)
+	fmt.Println("Save it somewhere safe now; it can't be shown again. If you lose it, rotate the key to get a new one.")
+	if cfg.ApiServer.Enabled.Value != true {
+		fmt.Printf("\n%sThe node API server isn't enabled yet. You can enable it in the `Node API Server` section of `rocketpool service config`.%s\n", colorYellow, colorReset)
+	}
+	return nil
+
+}
+
+// Replace a key's token with a new one
+func rotateApiKey(c *cli.Context, name string) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Load the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode first.")
+	}
+
+	// Rotate the key
+	keys, err := rp.LoadApiKeys(cfg)
+	if err != nil {
+		return err
+	}
+	if _, exists := keys.Get(name); !exists {
+		return fmt.Errorf("There is no API key named '%s'.", name)
+	}
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("The current token for '%s' will stop working immediately. Are you sure you want to rotate it?", name))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+	token, err := keys.Rotate(name)
+	if err != nil {
+		return err
+	}
+	if err := rp.SaveApiKeys(cfg, keys); err != nil {
+		return err
+	}
+
+	fmt.Printf("The new token for '%s' is:\n\n", name)
+	fmt.Printf("    %s%s%s\n\n", colorGreen, token, colorReset)
+	fmt.Println("Save it somewhere safe now; it can't be shown again.")
+	return nil
+
+}
+
+// Delete a key
+func removeApiKey(c *cli.Context, name string) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Load the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode first.")
+	}
+
+	// Remove the key
+	keys, err := rp.LoadApiKeys(cfg)
+	if err != nil {
+		return err
+	}
+	if _, exists := keys.Get(name); !exists {
+		return fmt.Errorf("There is no API key named '%s'.", name)
+	}
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to remove the API key '%s'? Anything using it will lose access immediately.", name))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+	if err := keys.Remove(name); err != nil {
+		return err
+	}
+	if err := rp.SaveApiKeys(cfg, keys); err != nil {
+		return err
+	}
+
+	fmt.Printf("Removed the API key '%s'.\n", name)
+	return nil
+
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+// Show the most recent entries in the audit log of state-changing commands
+func showAuditLog(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Get the log
+	response, err := rp.GetAuditLog(c.Uint64("limit"))
+	if err != nil {
+		return err
+	}
+	if response.Problem != "" {
+		fmt.Printf("%sWARNING: the audit log has been tampered with or corrupted (%s). Entries from that point on can't be trusted.%s\n\n", colorYellow, response.Problem, colorReset)
+	}
+
+	// Print the entries
+	route := c.String("route")
+	shown := 0
+	for _, entry := range response.Entries {
+		if !strings.HasPrefix(entry.Route, route) {
+			continue
+		}
+		shown++
+
+		source := "node CLI"
+		if entry.KeyName != "" {
+			source = fmt.Sprintf("API key \"%s\"", entry.KeyName)
+		}
+		fmt.Printf("%s%s%s  %s %s\n", colorGreen, entry.Time.Local().Format(time.RFC1123), colorReset, entry.Route, strings.Join(entry.Args, " "))
+		fmt.Printf("    Run by: %s\n", source)
+		if entry.Error != "" {
+			fmt.Printf("    Status: %s%s: %s%s\n", colorRed, entry.Status, entry.Error, colorReset)
+		} else {
+			fmt.Printf("    Status: %s\n", entry.Status)
+		}
+		for _, hash := range entry.TxHashes {
+			fmt.Printf("    Transaction: %s\n", hash)
+		}
+	}
+	if shown == 0 {
+		fmt.Println("No matching commands have been recorded.")
+		return nil
+	}
+	fmt.Printf("\nShowing %d of the %d recorded commands.\n", shown, response.TotalEntries)
+	return nil
+
+}
@@ -0,0 +1,278 @@
+package service
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/backup"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// The shortest backup passphrase allowed
+const minBackupPassphraseLength int = 12
+
+// Write an encrypted backup of the settings, wallet, validator keys and records
+func backupService(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Load the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode first.")
+	}
+	dataPath, err := homedir.Expand(cfg.Smartnode.DataPath.Value.(string))
+	if err != nil {
+		return fmt.Errorf("error expanding data directory: %w", err)
+	}
+
+	fmt.Printf("%sThe backup will contain your node wallet, its password and all of your validator keys. Anyone with the backup file and its passphrase will have full control of your node.\nKeep it somewhere offline and secure, and use a strong passphrase you won't forget - it can't be recovered.%s\n\n", colorYellow, colorReset)
+	passphrase := promptBackupPassphrase()
+
+	// The validator clients' slashing protection databases are backed up too, so stop the validator client to make sure they're consistent
+	validatorContainerName := cfg.Smartnode.ProjectName.Value.(string) + ValidatorContainerSuffix
+	restartValidator := false
+	if !cfg.IsNativeMode {
+		status, err := rp.GetDockerStatus(validatorContainerName)
+		if err == nil && status == "running" {
+			if c.Bool("yes") || cliutils.Confirm("Your validator client is running. It should be stopped while the backup is made so its slashing protection database is saved consistently; it will be restarted afterwards. Stop it now?") {
+				fmt.Printf("Stopping %s...\n", validatorContainerName)
+				if _, err := rp.StopContainer(validatorContainerName); err != nil {
+					return fmt.Errorf("error stopping %s: %w", validatorContainerName, err)
+				}
+				restartValidator = true
+			} else {
+				fmt.Printf("%sNOTE: the validator client will keep running, so the slashing protection database in the backup may be incomplete.%s\n", colorYellow, colorReset)
+			}
+		}
+	}
+
+	// Include a portable copy of the slashing protection database, so it can be imported into a different validator client after restoring
+	if !cfg.IsNativeMode {
+		status, err := rp.GetDockerStatus(validatorContainerName)
+		if err == nil && status != "running" {
+			fmt.Println("Exporting the slashing protection database...")
+			if err := rp.ExportSlashingProtection(cfg, slashingProtectionExportFilename); err != nil {
+				fmt.Printf("%sWARNING: couldn't export the slashing protection database: %s\nThe backup will still contain the validator client's own database.%s\n", colorYellow, err.Error(), colorReset)
+			}
+		}
+	}
+
+	// Make the backup
+	fmt.Println("Creating the backup, this may take a moment...")
+	response, backupErr := rp.CreateBackup(passphrase)
+	if restartValidator {
+		fmt.Printf("Restarting %s...\n", validatorContainerName)
+		if _, err := rp.StartContainer(validatorContainerName); err != nil {
+			fmt.Printf("%sWARNING: error restarting %s: %s\nPlease run `rocketpool service start` to restart it.%s\n", colorRed, validatorContainerName, err.Error(), colorReset)
+		}
+	}
+	if backupErr != nil {
+		return backupErr
+	}
+
+	fmt.Printf("\n%sCreated a backup of %d files:%s\n%s\n\n", colorGreen, len(response.Manifest.Files), colorReset, filepath.Join(dataPath, response.Filename))
+	fmt.Println("Copy it off of this machine, then delete it from the data folder. You can restore it on a new machine with `rocketpool service restore`.")
+	return nil
+
+}
+
+// Restore the settings, wallet, validator keys and records from a backup, guiding the user through the steps needed to avoid double-signing
+func restoreService(c *cli.Context, backupPath string) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Load the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` and `rocketpool service start` to install the Smartnode on this machine first.")
+	}
+	dataPath, err := homedir.Expand(cfg.Smartnode.DataPath.Value.(string))
+	if err != nil {
+		return fmt.Errorf("error expanding data directory: %w", err)
+	}
+
+	// The API can only read backups from the data folder, so copy it there if it isn't already
+	filename, err := copyBackupToDataFolder(backupPath, dataPath)
+	if err != nil {
+		return err
+	}
+
+	// Check the backup
+	passphrase := cliutils.PromptPassword("Please enter the backup's passphrase:", "^.+$", "")
+	fmt.Println("Checking the backup, this may take a moment...")
+	check, err := rp.CheckBackup(filename, passphrase)
+	if err != nil {
+		return err
+	}
+	manifest := check.Manifest
+	fmt.Printf("\n%sThe backup is intact.%s\n", colorGreen, colorReset)
+	fmt.Printf("Created:           %s (%s ago)\n", manifest.Time.Local().Format(time.RFC1123), time.Since(manifest.Time).Round(time.Minute))
+	fmt.Printf("Smartnode version: v%s\n", manifest.SmartnodeVersion)
+	fmt.Printf("Network:           %s\n", manifest.Network)
+	if manifest.NodeAddress != "" {
+		fmt.Printf("Node address:      %s\n", manifest.NodeAddress)
+	}
+	fmt.Printf("Files:             %d\n\n", len(manifest.Files))
+
+	if check.WalletExists && !strings.EqualFold(check.WalletAddress, manifest.NodeAddress) {
+		return fmt.Errorf("This machine already has a node wallet for %s, which isn't the node in the backup. Restoring it would overwrite that wallet, so the restore has been cancelled.", check.WalletAddress)
+	}
+
+	// Make sure the validator keys can't be running anywhere else
+	if manifest.HasValidatorKeys() {
+		fmt.Printf("%sThe backup contains validator keys. If they are ever active on two machines at the same time, your validators WILL BE SLASHED - you will lose ETH and RPL, and the validators will be forcibly exited.\nPlease confirm each of the following before continuing.%s\n\n", colorRed, colorReset)
+		checklist := []string{
+			"The validator client on the old machine has been stopped.",
+			"The old machine can never start its validator client again: it has been wiped or permanently powered off, or its validator keys have been deleted.",
+			"No other machine (including any fallback or failover node) has a copy of these validator keys loaded.",
+			"At least 15 minutes (more than 2 epochs) have passed since the old validator client stopped.",
+		}
+		for i, item := range checklist {
+			if !cliutils.Confirm(fmt.Sprintf("%d/%d: %s", i+1, len(checklist), item)) {
+				fmt.Println("Cancelled. Please complete this step before restoring the backup.")
+				return nil
+			}
+		}
+		fmt.Println()
+	}
+	if !cliutils.Confirm("Restoring will replace this machine's Smartnode settings and the contents of its data folder with the ones in the backup. Are you sure you want to continue?") {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Make sure the validator client on this machine doesn't load the keys until the restore is finished
+	if cfg.IsNativeMode {
+		fmt.Printf("%sPlease make sure your validator client service is stopped before continuing.%s\n", colorYellow, colorReset)
+		if !cliutils.Confirm("Is your validator client stopped?") {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+	} else {
+		validatorContainerName := cfg.Smartnode.ProjectName.Value.(string) + ValidatorContainerSuffix
+		status, err := rp.GetDockerStatus(validatorContainerName)
+		if err == nil && status == "running" {
+			fmt.Printf("Stopping %s...\n", validatorContainerName)
+			if _, err := rp.StopContainer(validatorContainerName); err != nil {
+				return fmt.Errorf("error stopping %s: %w", validatorContainerName, err)
+			}
+		}
+	}
+
+	// Restore it
+	fmt.Println("Restoring the backup...")
+	if _, err := rp.RestoreBackup(filename, passphrase); err != nil {
+		return err
+	}
+
+	// Keep this machine's data path, and turn on doppelganger detection as a last line of defense
+	restoredCfg, _, err := rp.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("error loading the restored settings: %w", err)
+	}
+	restoredCfg.Smartnode.DataPath.Value = cfg.Smartnode.DataPath.Value
+	if !restoredCfg.IsNativeMode {
+		enableDoppelgangerDetection(restoredCfg)
+	}
+	if err := rp.SaveConfig(restoredCfg); err != nil {
+		return fmt.Errorf("error saving the restored settings: %w", err)
+	}
+
+	fmt.Printf("\n%sThe backup has been restored.%s\n\n", colorGreen, colorReset)
+	if !restoredCfg.IsNativeMode {
+		fmt.Println("Doppelganger detection has been enabled, so your validator client will wait 2-3 epochs to make sure your validators aren't active anywhere else before it starts attesting. It is normal to miss a few attestations while it does.")
+	}
+	fmt.Println("Next steps:")
+	fmt.Println("1. Review the restored settings with `rocketpool service config`.")
+	fmt.Println("2. Start the Smartnode with `rocketpool service start`.")
+	fmt.Println("3. Check `rocketpool service logs validator` to make sure your validators are loaded and attesting once your clients have synced.")
+	fmt.Printf("4. Delete %s from the data folder once you've stored it somewhere safe.\n", filename)
+	if manifest.HasValidatorKeys() {
+		fmt.Printf("\nIf you change to a different validator client, import the slashing protection file from the backup into it first with `rocketpool service slashing-protection import %s`.\n", filepath.Join(dataPath, "validators", slashingProtectionExportFilename))
+	}
+	return nil
+
+}
+
+// Prompt for a new backup passphrase
+func promptBackupPassphrase() string {
+	for {
+		passphrase := cliutils.PromptPassword(
+			"Please enter a passphrase to encrypt the backup with:",
+			fmt.Sprintf("^.{%d,}$", minBackupPassphraseLength),
+			fmt.Sprintf("The passphrase must be at least %d characters long. Please try again:", minBackupPassphraseLength),
+		)
+		confirmation := cliutils.PromptPassword("Please confirm the passphrase:", "^.*$", "")
+		if passphrase == confirmation {
+			return passphrase
+		}
+		fmt.Println("Passphrase confirmation does not match.")
+		fmt.Println("")
+	}
+}
+
+// Copy a backup file into the data folder if it isn't there already, returning its name
+func copyBackupToDataFolder(backupPath string, dataPath string) (string, error) {
+	backupPath, err := homedir.Expand(backupPath)
+	if err != nil {
+		return "", fmt.Errorf("error expanding backup path: %w", err)
+	}
+	backupPath, err = filepath.Abs(backupPath)
+	if err != nil {
+		return "", fmt.Errorf("error getting backup path: %w", err)
+	}
+	filename := filepath.Base(backupPath)
+	if !strings.HasSuffix(filename, backup.FileExtension) {
+		return "", fmt.Errorf("%s is not a Smartnode backup file (it should end in %s)", backupPath, backup.FileExtension)
+	}
+	target := filepath.Join(dataPath, filename)
+	if backupPath == target {
+		return filename, nil
+	}
+
+	source, err := os.Open(backupPath)
+	if err != nil {
+		return "", fmt.Errorf("error opening backup file: %w", err)
+	}
+	defer source.Close()
+	if err := os.MkdirAll(dataPath, 0700); err != nil {
+		return "", fmt.Errorf("error creating data folder: %w", err)
+	}
+	destination, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return "", fmt.Errorf("error copying backup file into the data folder: %w", err)
+	}
+	defer destination.Close()
+	if _, err := io.Copy(destination, source); err != nil {
+		return "", fmt.Errorf("error copying backup file into the data folder: %w", err)
+	}
+	return filename, nil
+}
+
+// Enable doppelganger detection for whichever consensus client is in use
+func enableDoppelgangerDetection(cfg *config.RocketPoolConfig) {
+	cfg.ConsensusCommon.DoppelgangerDetection.Value = true
+	cfg.ExternalLighthouse.DoppelgangerDetection.Value = true
+	cfg.ExternalLodestar.DoppelgangerDetection.Value = true
+	cfg.ExternalNimbus.DoppelgangerDetection.Value = true
+	cfg.ExternalPrysm.DoppelgangerDetection.Value = true
+}
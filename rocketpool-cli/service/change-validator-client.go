@@ -0,0 +1,217 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// The shortest safety delay allowed between stopping the old validator client and starting the new one; the same as the `service start` slash timer
+const minValidatorClientChangeDelay time.Duration = 15 * time.Minute
+
+// Change the validator client, moving its keys and slashing protection to the new one and waiting out the safety delay before it starts
+func changeValidatorClient(c *cli.Context, clientName string) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Load the config
+	cfg, err := loadSlashingProtectionConfig(rp)
+	if err != nil {
+		return err
+	}
+
+	// Check the new client
+	newClient := cfgtypes.ConsensusClient(clientName)
+	validClient := false
+	for _, option := range cfg.ConsensusClient.Options {
+		if option.Value == newClient {
+			validClient = true
+			break
+		}
+	}
+	if !validClient {
+		return fmt.Errorf("[%s] is not a supported validator client", clientName)
+	}
+	oldClient, mode := cfg.GetSelectedConsensusClient()
+	if newClient == oldClient {
+		fmt.Printf("You're already using %s.\n", oldClient)
+		return nil
+	}
+	delay := c.Duration("delay")
+	if delay < minValidatorClientChangeDelay {
+		return fmt.Errorf("The safety delay can't be shorter than %s.", minValidatorClientChangeDelay)
+	}
+	skipSlashingProtection := c.Bool("no-slashing-protection")
+
+	// Explain what's about to happen
+	steps := []string{"Stop the current validator client."}
+	if !skipSlashingProtection {
+		steps = append(steps, "Export its slashing protection database and check that it covers all of your validators.")
+	}
+	steps = append(steps, "Switch your settings to the new client and regenerate your validator keys in its format.")
+	if !skipSlashingProtection {
+		steps = append(steps, "Import the slashing protection database into the new client.")
+	}
+	steps = append(steps, fmt.Sprintf("Wait until %s has passed since the old client stopped, then start the Smartnode with the new client.", delay))
+	fmt.Printf("This will change your validator client from %s to %s:\n", oldClient, newClient)
+	for i, step := range steps {
+		fmt.Printf("%d. %s\n", i+1, step)
+	}
+	fmt.Println()
+	if mode == cfgtypes.Mode_Local {
+		fmt.Printf("%sNOTE: your Consensus client is locally managed, so this also replaces your Beacon node with %s. It will have to sync from scratch (or with checkpoint sync if you've set it up), and your validators can't attest until it has.%s\n\n", colorYellow, newClient, colorReset)
+	} else {
+		fmt.Printf("%sNOTE: your Consensus client is externally managed, so make sure your external Beacon node is compatible with the %s validator client before continuing.%s\n\n", colorYellow, newClient, colorReset)
+	}
+	if skipSlashingProtection {
+		fmt.Printf("%sThe slashing protection database won't be moved to the new client, so it will only be protected by the safety delay.%s\n\n", colorRed, colorReset)
+	}
+	if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to change your validator client?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Stop the old validator client
+	validatorContainerName := cfg.Smartnode.ProjectName.Value.(string) + ValidatorContainerSuffix
+	stopTime := time.Now()
+	status, err := rp.GetDockerStatus(validatorContainerName)
+	if err != nil {
+		return fmt.Errorf("error getting the status of %s: %w", validatorContainerName, err)
+	}
+	if status == "running" {
+		fmt.Printf("Stopping %s...\n", validatorContainerName)
+		if _, err := rp.StopContainer(validatorContainerName); err != nil {
+			return fmt.Errorf("error stopping %s: %w", validatorContainerName, err)
+		}
+		stopTime = time.Now()
+	} else {
+		stopTime, err = rp.GetDockerContainerShutdownTime(validatorContainerName)
+		if err != nil {
+			return fmt.Errorf("error getting the time %s stopped: %w", validatorContainerName, err)
+		}
+	}
+
+	// Until the settings change, anything going wrong just puts the old client back
+	restartOldValidator := func() {
+		if status != "running" {
+			return
+		}
+		fmt.Printf("Restarting %s...\n", validatorContainerName)
+		if _, err := rp.StartContainer(validatorContainerName); err != nil {
+			fmt.Printf("%sWARNING: error restarting %s: %s\nPlease run `rocketpool service start` to restart it.%s\n", colorRed, validatorContainerName, err.Error(), colorReset)
+		}
+	}
+
+	// Export the old client's slashing protection and make sure it covers every validator
+	if !skipSlashingProtection {
+		fmt.Printf("Exporting the %s slashing protection database...\n", oldClient)
+		if err := rp.ExportSlashingProtection(cfg, slashingProtectionExportFilename); err != nil {
+			restartOldValidator()
+			return fmt.Errorf("error exporting slashing protection: %w\nYour validator client hasn't been changed. If %s can't export its slashing protection, you can use --no-slashing-protection to rely on the safety delay instead.", err, oldClient)
+		}
+		check, err := rp.CheckSlashingProtection(slashingProtectionExportFilename)
+		if err != nil {
+			restartOldValidator()
+			return err
+		}
+		if !check.CoversAllKeys && !c.Bool("allow-missing") {
+			fmt.Printf("%sThe exported slashing protection doesn't have any records for these validators:%s\n", colorYellow, colorReset)
+			for _, pubkey := range check.MissingPubkeys {
+				fmt.Printf("\t0x%s\n", pubkey.Hex())
+			}
+			restartOldValidator()
+			fmt.Printf("%sYour validator client hasn't been changed. If these validators have never been run by %s or anywhere else, use --allow-missing to continue.%s\n", colorRed, oldClient, colorReset)
+			return nil
+		}
+	}
+
+	// Switch the settings to the new client
+	if mode == cfgtypes.Mode_Local {
+		cfg.ConsensusClient.Value = newClient
+	} else {
+		cfg.ExternalConsensusClient.Value = newClient
+		copyExternalConsensusUrl(cfg, oldClient, newClient)
+	}
+	if errors := cfg.Validate(); len(errors) > 0 {
+		restartOldValidator()
+		fmt.Printf("%sYour settings aren't valid with %s, so your validator client hasn't been changed:\n\n", colorRed, newClient)
+		for _, err := range errors {
+			fmt.Printf("%s\n\n", err)
+		}
+		fmt.Printf("Please select %s in `rocketpool service config` instead.%s\n", newClient, colorReset)
+		return nil
+	}
+	if err := rp.SaveConfig(cfg); err != nil {
+		restartOldValidator()
+		return fmt.Errorf("error saving settings: %w", err)
+	}
+	fmt.Printf("Switched your settings to %s.\n", newClient)
+
+	// From here on the old client is left stopped if something goes wrong, so it can't run alongside the new one
+	leftStopped := fmt.Sprintf("Your settings now use %s, and the old validator client has been left stopped. Please fix the problem, then run `rocketpool service start` no sooner than %s.", newClient, stopTime.Add(delay).Format(time.Kitchen))
+
+	// Regenerate the validator keys, which writes them for every client
+	fmt.Println("Regenerating your validator keys...")
+	if _, err := rp.RebuildWallet(); err != nil {
+		return fmt.Errorf("error regenerating validator keys: %w\n%s", err, leftStopped)
+	}
+
+	// Import the slashing protection into the new client
+	if !skipSlashingProtection {
+		fmt.Printf("Importing the slashing protection database into %s...\n", newClient)
+		if err := rp.ImportSlashingProtection(cfg, slashingProtectionExportFilename); err != nil {
+			return fmt.Errorf("error importing slashing protection: %w\n%s", err, leftStopped)
+		}
+	}
+
+	// Wait out the safety delay
+	safeStartTime := stopTime.Add(delay)
+	remainingTime := time.Until(safeStartTime)
+	if remainingTime > 0 {
+		fmt.Printf("\n%sWaiting until %s has passed since the old validator client stopped before starting %s. Don't start any validator client with these keys in the meantime.%s\n", colorYellow, delay, newClient, colorReset)
+		for remainingTime > 0 {
+			fmt.Printf("Remaining time: %s", remainingTime.Round(time.Second))
+			time.Sleep(1 * time.Second)
+			remainingTime = time.Until(safeStartTime)
+			fmt.Printf("%s\r", clearLine)
+		}
+		fmt.Println()
+	}
+
+	// Start the new client
+	fmt.Printf("%sThe safety delay has passed, starting the Smartnode with %s.%s\n\n", colorGreen, newClient, colorReset)
+	return StartService(c, true)
+
+}
+
+// Use the old external Consensus client's URL for the new one if it doesn't have one yet
+func copyExternalConsensusUrl(cfg *config.RocketPoolConfig, oldClient cfgtypes.ConsensusClient, newClient cfgtypes.ConsensusClient) {
+	getUrlParam := func(client cfgtypes.ConsensusClient) *cfgtypes.Parameter {
+		switch client {
+		case cfgtypes.ConsensusClient_Lighthouse:
+			return &cfg.ExternalLighthouse.HttpUrl
+		case cfgtypes.ConsensusClient_Lodestar:
+			return &cfg.ExternalLodestar.HttpUrl
+		case cfgtypes.ConsensusClient_Nimbus:
+			return &cfg.ExternalNimbus.HttpUrl
+		case cfgtypes.ConsensusClient_Prysm:
+			return &cfg.ExternalPrysm.HttpUrl
+		case cfgtypes.ConsensusClient_Teku:
+			return &cfg.ExternalTeku.HttpUrl
+		}
+		return nil
+	}
+	oldUrl := getUrlParam(oldClient)
+	newUrl := getUrlParam(newClient)
+	if oldUrl != nil && newUrl != nil && newUrl.Value.(string) == "" {
+		newUrl.Value = oldUrl.Value
+	}
+}
@@ -3,11 +3,13 @@ package service
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/tasks"
 	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 )
@@ -137,6 +139,10 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						Usage: "The smart node package version to install",
 						Value: fmt.Sprintf("v%s", shared.RocketPoolVersion),
 					},
+					cli.StringFlag{
+						Name:  "installer",
+						Usage: "A local copy of the installation script to run instead of downloading it",
+					},
 				},
 				Action: func(c *cli.Context) error {
 
@@ -146,7 +152,7 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 					}
 
 					// Run command
-					return installService(c)
+					return InstallService(c)
 
 				},
 			},
@@ -155,8 +161,29 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				Name:      "config",
 				Aliases:   []string{"c"},
 				Usage:     "Configure the Rocket Pool service",
-				UsageText: "rocketpool service config",
-				Flags:     configFlags,
+				UsageText: "rocketpool service config [options]",
+				Flags: append([]cli.Flag{
+					cli.StringFlag{
+						Name:  "file",
+						Usage: fmt.Sprintf("Apply a declarative YAML config document instead of using the interactive UI; it uses the same sections and parameter IDs as the user settings file, along with `schemaVersion: %d`", config.DeclarativeSchemaVersion),
+					},
+					cli.BoolFlag{
+						Name:  "check",
+						Usage: "Check the settings file for unknown settings, invalid combinations and port conflicts without changing anything",
+					},
+					cli.BoolFlag{
+						Name:  "diff",
+						Usage: "Show which container environment variables and compose files would change the next time the service is started, without changing anything",
+					},
+					cli.BoolFlag{
+						Name:  "rollback",
+						Usage: "Restore the settings from before the last Smartnode upgrade",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm the rollback",
+					},
+				}, configFlags...),
 				Action: func(c *cli.Context) error {
 
 					// Validate args
@@ -165,7 +192,7 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 					}
 
 					// Run command
-					return configureService(c)
+					return ConfigureService(c)
 
 				},
 			},
@@ -198,6 +225,10 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						Name:  "ignore-slash-timer",
 						Usage: "Bypass the safety timer that forces a delay when switching to a new consensus client",
 					},
+					cli.BoolFlag{
+						Name:  "ignore-preflight",
+						Usage: "Start even if the port conflict pre-flight checks fail",
+					},
 					cli.BoolFlag{
 						Name:  "yes, y",
 						Usage: "Ignore service config prompt after upgrading",
@@ -211,11 +242,889 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 					}
 
 					// Run command
-					return startService(c, false)
+					return StartService(c, false)
+
+				},
+			},
+
+			{
+				Name:      "update",
+				Usage:     "Check for new Smartnode and client releases and install them, rolling back if the services don't come back up",
+				UsageText: "rocketpool service update [options]",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "clients-only",
+						Usage: "Only update the clients",
+					},
+					cli.BoolFlag{
+						Name:  "smartnode-only",
+						Usage: "Only update the Smartnode",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm the update",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run command
+					return updateService(c)
+
+				},
+			},
+
+			{
+				Name:      "backup",
+				Usage:     "Create an encrypted backup of your settings, node wallet, validator keys, slashing protection databases and rewards records",
+				UsageText: "rocketpool service backup [options]",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically stop the validator client while the backup is made",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run command
+					return backupService(c)
+
+				},
+			},
+
+			{
+				Name:      "restore",
+				Usage:     "Restore a backup made with `rocketpool service backup`, such as when moving your node to new hardware",
+				UsageText: "rocketpool service restore backup-file",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					// Run command
+					return restoreService(c, c.Args().Get(0))
 
 				},
 			},
 
+			{
+				Name:      "profile",
+				Usage:     "Manage the saved settings profiles for different networks",
+				UsageText: "rocketpool service profile command [options]",
+				Subcommands: []cli.Command{
+					{
+						Name:      "list",
+						Aliases:   []string{"l"},
+						Usage:     "List the saved network profiles",
+						UsageText: "rocketpool service profile list",
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 0); err != nil {
+								return err
+							}
+
+							// Run command
+							return listProfiles(c)
+
+						},
+					},
+
+					{
+						Name:      "save",
+						Aliases:   []string{"s"},
+						Usage:     "Save the current settings as a network profile",
+						UsageText: "rocketpool service profile save name [options]",
+						Flags: []cli.Flag{
+							cli.BoolFlag{
+								Name:  "yes, y",
+								Usage: "Automatically confirm replacing an existing profile",
+							},
+						},
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 1); err != nil {
+								return err
+							}
+
+							// Run command
+							return saveProfile(c, c.Args().Get(0))
+
+						},
+					},
+
+					{
+						Name:      "create",
+						Aliases:   []string{"c"},
+						Usage:     "Create a profile for another network from the current settings, with its own containers and data folder",
+						UsageText: "rocketpool service profile create name network",
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 2); err != nil {
+								return err
+							}
+
+							// Run command
+							return createProfile(c, c.Args().Get(0), c.Args().Get(1))
+
+						},
+					},
+
+					{
+						Name:      "delete",
+						Aliases:   []string{"d"},
+						Usage:     "Delete a saved network profile; its data folder and chain data are kept",
+						UsageText: "rocketpool service profile delete name [options]",
+						Flags: []cli.Flag{
+							cli.BoolFlag{
+								Name:  "yes, y",
+								Usage: "Automatically confirm deleting the profile",
+							},
+						},
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 1); err != nil {
+								return err
+							}
+
+							// Run command
+							return deleteProfile(c, c.Args().Get(0))
+
+						},
+					},
+				},
+			},
+
+			{
+				Name:      "switch-network",
+				Usage:     "Stop the Smartnode and restart it with a different network profile's settings, data folder and containers",
+				UsageText: "rocketpool service switch-network profile [options]",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm the switch",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					// Run command
+					return switchNetwork(c, c.Args().Get(0))
+
+				},
+			},
+
+			{
+				Name:      "custom-network",
+				Usage:     "Manage the custom network definition used to run on a private devnet or test deployment",
+				UsageText: "rocketpool service custom-network command [options]",
+				Subcommands: []cli.Command{
+					{
+						Name:      "import",
+						Aliases:   []string{"i"},
+						Usage:     "Check a network definition file and copy it into the data folder",
+						UsageText: "rocketpool service custom-network import file [options]",
+						Flags: []cli.Flag{
+							cli.BoolFlag{
+								Name:  "yes, y",
+								Usage: "Automatically confirm replacing the current network definition",
+							},
+						},
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 1); err != nil {
+								return err
+							}
+
+							// Run command
+							return importCustomNetwork(c, c.Args().Get(0))
+
+						},
+					},
+
+					{
+						Name:      "show",
+						Aliases:   []string{"s"},
+						Usage:     "Show the custom network definition in use",
+						UsageText: "rocketpool service custom-network show",
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 0); err != nil {
+								return err
+							}
+
+							// Run command
+							return showCustomNetwork(c)
+
+						},
+					},
+
+					{
+						Name:      "remove",
+						Aliases:   []string{"r"},
+						Usage:     "Remove the custom network definition and go back to the built-in network settings",
+						UsageText: "rocketpool service custom-network remove [options]",
+						Flags: []cli.Flag{
+							cli.BoolFlag{
+								Name:  "yes, y",
+								Usage: "Automatically confirm removing the network definition",
+							},
+						},
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 0); err != nil {
+								return err
+							}
+
+							// Run command
+							return removeCustomNetwork(c)
+
+						},
+					},
+				},
+			},
+
+			{
+				Name:      "data-layout",
+				Usage:     "View where each part of the Smartnode's data (chain data, rewards trees, wallet and logs) is kept",
+				UsageText: "rocketpool service data-layout",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run command
+					return getDataLayout(c)
+
+				},
+			},
+
+			{
+				Name:      "migrate-data",
+				Usage:     "Move one part of the Smartnode's data to another folder or volume, and switch the Smartnode over to it",
+				UsageText: "rocketpool service migrate-data component folder [options]\n   component can be execution-data, consensus-data, rewards-trees, wallet or logs",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "force",
+						Usage: "Bypass the free space check on the target folder",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm the move",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+
+					// Run command
+					return migrateData(c, c.Args().Get(0), c.Args().Get(1))
+
+				},
+			},
+
+			{
+				Name:      "change-validator-client",
+				Usage:     "Change to a different validator client, moving your keys and slashing protection to it and waiting out a safety delay before it starts",
+				UsageText: "rocketpool service change-validator-client client [options]",
+				Flags: []cli.Flag{
+					cli.DurationFlag{
+						Name:  "delay, d",
+						Usage: "How long to wait after the old validator client stops before starting the new one (at least 15m)",
+						Value: 15 * time.Minute,
+					},
+					cli.BoolFlag{
+						Name:  "allow-missing",
+						Usage: "Continue even if the exported slashing protection doesn't have records for some of the node's validators",
+					},
+					cli.BoolFlag{
+						Name:  "no-slashing-protection",
+						Usage: "Don't move the slashing protection database to the new client, and rely on the safety delay instead",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm the change",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					// Run command
+					return changeValidatorClient(c, c.Args().Get(0))
+
+				},
+			},
+
+			{
+				Name:      "slashing-protection",
+				Usage:     "Export or import the validator client's slashing protection database as an EIP-3076 interchange file",
+				UsageText: "rocketpool service slashing-protection command [options]",
+				Subcommands: []cli.Command{
+					{
+						Name:      "export",
+						Aliases:   []string{"e"},
+						Usage:     "Export the validator client's slashing protection database to the validators folder",
+						UsageText: "rocketpool service slashing-protection export [options]",
+						Flags: []cli.Flag{
+							cli.StringFlag{
+								Name:  "output, o",
+								Usage: "Also copy the exported file to this path",
+							},
+							cli.BoolFlag{
+								Name:  "yes, y",
+								Usage: "Automatically confirm stopping the validator client during the export",
+							},
+						},
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 0); err != nil {
+								return err
+							}
+
+							// Run command
+							return exportSlashingProtection(c)
+
+						},
+					},
+
+					{
+						Name:      "import",
+						Aliases:   []string{"i"},
+						Usage:     "Import a slashing protection file into the validator client, once it has been checked to cover all of the node's validators",
+						UsageText: "rocketpool service slashing-protection import file [options]",
+						Flags: []cli.Flag{
+							cli.BoolFlag{
+								Name:  "allow-missing",
+								Usage: "Import the file even if it doesn't have records for some of the node's validators",
+							},
+							cli.BoolFlag{
+								Name:  "yes, y",
+								Usage: "Automatically confirm stopping the validator client during the import",
+							},
+						},
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 1); err != nil {
+								return err
+							}
+
+							// Run command
+							return importSlashingProtection(c, c.Args().Get(0))
+
+						},
+					},
+				},
+			},
+
+			{
+				Name:      "api-keys",
+				Usage:     "Manage the keys other tools can use with the node daemon's API server",
+				UsageText: "rocketpool service api-keys command [options]",
+				Subcommands: []cli.Command{
+					{
+						Name:      "list",
+						Aliases:   []string{"l"},
+						Usage:     "List the API keys and their roles",
+						UsageText: "rocketpool service api-keys list",
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 0); err != nil {
+								return err
+							}
+
+							// Run command
+							return listApiKeys(c)
+
+						},
+					},
+
+					{
+						Name:      "add",
+						Aliases:   []string{"a"},
+						Usage:     "Create an API key; read-only keys can only query the node, operator keys can also send its routine transactions, and admin keys can do everything",
+						UsageText: "rocketpool service api-keys add name --role role [options]",
+						Flags: []cli.Flag{
+							cli.StringFlag{
+								Name:  "role, r",
+								Usage: "The key's role: 'read-only', 'operator' or 'admin'",
+								Value: "read-only",
+							},
+							cli.BoolFlag{
+								Name:  "yes, y",
+								Usage: "Automatically confirm creating an admin key",
+							},
+						},
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 1); err != nil {
+								return err
+							}
+
+							// Run command
+							return addApiKey(c, c.Args().Get(0))
+
+						},
+					},
+
+					{
+						Name:      "rotate",
+						Aliases:   []string{"r"},
+						Usage:     "Replace an API key's token with a new one",
+						UsageText: "rocketpool service api-keys rotate name [options]",
+						Flags: []cli.Flag{
+							cli.BoolFlag{
+								Name:  "yes, y",
+								Usage: "Automatically confirm rotating the key",
+							},
+						},
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 1); err != nil {
+								return err
+							}
+
+							// Run command
+							return rotateApiKey(c, c.Args().Get(0))
+
+						},
+					},
+
+					{
+						Name:      "remove",
+						Aliases:   []string{"d"},
+						Usage:     "Remove an API key",
+						UsageText: "rocketpool service api-keys remove name [options]",
+						Flags: []cli.Flag{
+							cli.BoolFlag{
+								Name:  "yes, y",
+								Usage: "Automatically confirm removing the key",
+							},
+						},
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 1); err != nil {
+								return err
+							}
+
+							// Run command
+							return removeApiKey(c, c.Args().Get(0))
+
+						},
+					},
+				},
+			},
+
+			{
+				Name:      "confirm-command",
+				Usage:     "Request and confirm a run of a command that's protected by a confirmation policy, with the arguments its API command will be given, so it can be run once",
+				UsageText: "rocketpool service confirm-command route [args...]",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateMinArgCount(c, 1); err != nil {
+						return err
+					}
+
+					// Run command
+					return confirmCommand(c, c.Args().Get(0), c.Args().Tail())
+
+				},
+			},
+
+			{
+				Name:      "approve-command",
+				Usage:     "Approve a pending confirmation request from this device; only works from a CLI using one of the Confirmation Devices' API keys",
+				UsageText: "rocketpool service approve-command id [options]",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm approving the request",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					// Run command
+					return approveCommand(c, c.Args().Get(0))
+
+				},
+			},
+
+			{
+				Name:      "confirmation-requests",
+				Usage:     "List the pending requests for commands protected by a confirmation policy",
+				UsageText: "rocketpool service confirmation-requests",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run command
+					return listConfirmationRequests(c)
+
+				},
+			},
+
+			{
+				Name:      "set-confirmation-passphrase",
+				Usage:     "Set the passphrase for commands whose confirmation policy has the passphrase safeguard",
+				UsageText: "rocketpool service set-confirmation-passphrase",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run command
+					return setConfirmationPassphrase(c)
+
+				},
+			},
+
+			{
+				Name:      "audit-log",
+				Usage:     "Show the state-changing commands the node has run, and who ran them",
+				UsageText: "rocketpool service audit-log [options]",
+				Flags: []cli.Flag{
+					cli.Uint64Flag{
+						Name:  "limit, l",
+						Usage: "The number of most recent entries to check",
+						Value: 50,
+					},
+					cli.StringFlag{
+						Name:  "route, r",
+						Usage: "Only show commands whose route starts with this, such as node/ or minipool/exit",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run command
+					return showAuditLog(c)
+
+				},
+			},
+
+			{
+				Name:      "migrate-secrets",
+				Usage:     "Move the wallet password and API server token out of the data folder and into the configured secret store",
+				UsageText: "rocketpool service migrate-secrets [options]",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm moving the secrets",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run command
+					return migrateSecrets(c)
+
+				},
+			},
+
+			{
+				Name:      "encrypt-validator-keys",
+				Usage:     "Encrypt the validator keystore directory with a key from the configured secret store",
+				UsageText: "rocketpool service encrypt-validator-keys [options]",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm encrypting the validator keys",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run command
+					return encryptValidatorKeys(c)
+
+				},
+			},
+
+			{
+				Name:      "decrypt-validator-keys",
+				Usage:     "Decrypt the validator keystore directory back into the data folder",
+				UsageText: "rocketpool service decrypt-validator-keys [options]",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm decrypting the validator keys",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run command
+					return decryptValidatorKeys(c)
+
+				},
+			},
+
+			{
+				Name:      "fork",
+				Usage:     "Control a local Anvil or Hardhat fork used as the primary Execution client, for end-to-end testing",
+				UsageText: "rocketpool service fork command [options]",
+				Subcommands: []cli.Command{
+					{
+						Name:      "status",
+						Aliases:   []string{"s"},
+						Usage:     "Check if the primary Execution client is a local fork",
+						UsageText: "rocketpool service fork status",
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 0); err != nil {
+								return err
+							}
+
+							// Run command
+							return forkStatus(c)
+
+						},
+					},
+
+					{
+						Name:      "warp",
+						Aliases:   []string{"w"},
+						Usage:     "Move the fork's clock forward by a duration (e.g. 24h) and mine a block at the new time",
+						UsageText: "rocketpool service fork warp duration",
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 1); err != nil {
+								return err
+							}
+							duration, err := time.ParseDuration(c.Args().Get(0))
+							if err != nil {
+								return fmt.Errorf("Invalid duration '%s': %w", c.Args().Get(0), err)
+							}
+
+							// Run command
+							return forkWarp(c, duration)
+
+						},
+					},
+
+					{
+						Name:      "mine",
+						Aliases:   []string{"m"},
+						Usage:     "Mine blocks on the fork",
+						UsageText: "rocketpool service fork mine blocks",
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 1); err != nil {
+								return err
+							}
+							blocks, err := cliutils.ValidatePositiveUint("block count", c.Args().Get(0))
+							if err != nil {
+								return err
+							}
+
+							// Run command
+							return forkMine(c, blocks)
+
+						},
+					},
+
+					{
+						Name:      "set-balance",
+						Aliases:   []string{"b"},
+						Usage:     "Set the ETH balance of an address on the fork, such as to fund the node wallet",
+						UsageText: "rocketpool service fork set-balance address amount",
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 2); err != nil {
+								return err
+							}
+							address, err := cliutils.ValidateAddress("address", c.Args().Get(0))
+							if err != nil {
+								return err
+							}
+							amount, err := cliutils.ValidateEthAmount("amount", c.Args().Get(1))
+							if err != nil {
+								return err
+							}
+
+							// Run command
+							return forkSetBalance(c, address, amount)
+
+						},
+					},
+				},
+			},
+
+			{
+				Name:      "addons",
+				Usage:     "Manage the addons that run alongside the Smartnode, including community addons",
+				UsageText: "rocketpool service addons command [options]",
+				Subcommands: []cli.Command{
+					{
+						Name:      "list",
+						Aliases:   []string{"l"},
+						Usage:     "List the built-in and installed community addons and their status",
+						UsageText: "rocketpool service addons list",
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 0); err != nil {
+								return err
+							}
+
+							// Run command
+							return listAddons(c)
+
+						},
+					},
+
+					{
+						Name:      "install",
+						Aliases:   []string{"i"},
+						Usage:     "Install a community addon from a folder containing its addon.yml manifest and container template",
+						UsageText: "rocketpool service addons install folder [options]",
+						Flags: []cli.Flag{
+							cli.BoolFlag{
+								Name:  "yes, y",
+								Usage: "Automatically confirm installing the addon",
+							},
+						},
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 1); err != nil {
+								return err
+							}
+
+							// Run command
+							return installAddon(c, c.Args().Get(0))
+
+						},
+					},
+
+					{
+						Name:      "uninstall",
+						Aliases:   []string{"u"},
+						Usage:     "Uninstall a community addon",
+						UsageText: "rocketpool service addons uninstall id [options]",
+						Flags: []cli.Flag{
+							cli.BoolFlag{
+								Name:  "yes, y",
+								Usage: "Automatically confirm uninstalling the addon",
+							},
+						},
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 1); err != nil {
+								return err
+							}
+
+							// Run command
+							return uninstallAddon(c, c.Args().Get(0))
+
+						},
+					},
+
+					{
+						Name:      "enable",
+						Aliases:   []string{"e"},
+						Usage:     "Enable an addon",
+						UsageText: "rocketpool service addons enable id",
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 1); err != nil {
+								return err
+							}
+
+							// Run command
+							return setAddonEnabled(c, c.Args().Get(0), true)
+
+						},
+					},
+
+					{
+						Name:      "disable",
+						Aliases:   []string{"d"},
+						Usage:     "Disable an addon",
+						UsageText: "rocketpool service addons disable id",
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 1); err != nil {
+								return err
+							}
+
+							// Run command
+							return setAddonEnabled(c, c.Args().Get(0), false)
+
+						},
+					},
+				},
+			},
+
 			{
 				Name:      "pause",
 				Aliases:   []string{"p"},
@@ -301,6 +1210,139 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "task-status",
+				Usage:     "View the recent runs of each node and watchtower daemon task",
+				UsageText: "rocketpool service task-status [options]",
+				Flags: []cli.Flag{
+					cli.UintFlag{
+						Name:  "runs, n",
+						Usage: "The number of recent runs to show for each task",
+						Value: uint(tasks.DefaultRunHistorySize),
+					},
+					cli.Float64Flag{
+						Name:  "budget, b",
+						Usage: "The percentage of recent runs a task can fail before it's highlighted as flapping",
+						Value: tasks.DefaultFailureBudget * 100,
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run command
+					return getTaskStatus(c)
+
+				},
+			},
+
+			{
+				Name:      "rpc-usage",
+				Usage:     "View the RPC requests each node and watchtower daemon task has made to your clients, to see what's using up a metered provider's quota",
+				UsageText: "rocketpool service rpc-usage [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "endpoint, e",
+						Usage: "Only show the usage of one endpoint (\"primary EC\", \"fallback EC\", \"primary CC\" or \"fallback CC\")",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run command
+					return getRpcUsage(c)
+
+				},
+			},
+
+			{
+				Name:      "profile",
+				Usage:     "Collect a CPU, heap or goroutine profile from the running node or watchtower daemon and save it to the data folder (requires profiling to be enabled)",
+				UsageText: "rocketpool service profile [--cpu duration | --heap | --goroutine] [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "cpu",
+						Usage: "Record a CPU profile for the provided duration, such as 30s",
+					},
+					cli.BoolFlag{
+						Name:  "heap",
+						Usage: "Take a snapshot of the daemon's memory usage",
+					},
+					cli.BoolFlag{
+						Name:  "goroutine",
+						Usage: "Take a snapshot of the daemon's goroutines",
+					},
+					cli.StringFlag{
+						Name:  "daemon, d",
+						Usage: "The daemon to profile (node or watchtower)",
+						Value: "node",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run command
+					return collectProfile(c)
+
+				},
+			},
+
+			{
+				Name:      "system-status",
+				Usage:     "View the disk, chain data and memory usage and the clock skew of this machine, and get advice on pruning if the disk is running low",
+				UsageText: "rocketpool service system-status",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run command
+					return getSystemStatus(c)
+
+				},
+			},
+
+			{
+				Name:      "report",
+				Usage:     "Create an archive of your settings (with secrets removed), recent logs, client versions and node status to attach to a support request",
+				UsageText: "rocketpool service report [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "output, o",
+						Usage: "The path to save the report to (defaults to a timestamped file in the current directory)",
+					},
+					cli.Uint64Flag{
+						Name:  "lines, n",
+						Usage: "The number of recent log lines to include from each service",
+						Value: 1000,
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run command
+					return createReport(c)
+
+				},
+			},
+
 			{
 				Name:      "compose",
 				Usage:     "View the Rocket Pool service docker compose config",
@@ -475,7 +1517,21 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 			{
 				Name:      "resync-eth1",
 				Usage:     fmt.Sprintf("%sDeletes the main execution client's chain data and resyncs it from scratch. Only use this as a last resort!%s", colorRed, colorReset),
-				UsageText: "rocketpool service resync-eth1",
+				UsageText: "rocketpool service resync-eth1 [options]",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm the resync",
+					},
+					cli.StringFlag{
+						Name:  "snapshot, s",
+						Usage: "A folder to save a copy of the chain data to before it's deleted",
+					},
+					cli.BoolFlag{
+						Name:  "no-monitor",
+						Usage: "Don't wait and show the sync progress after the client restarts",
+					},
+				},
 				Action: func(c *cli.Context) error {
 
 					// Validate args
@@ -492,7 +1548,25 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 			{
 				Name:      "resync-eth2",
 				Usage:     fmt.Sprintf("%sDeletes the consensus client's chain data and resyncs it from scratch. Only use this as a last resort!%s", colorRed, colorReset),
-				UsageText: "rocketpool service resync-eth2",
+				UsageText: "rocketpool service resync-eth2 [options]",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm the resync",
+					},
+					cli.StringFlag{
+						Name:  "snapshot, s",
+						Usage: "A folder to save a copy of the chain data to before it's deleted",
+					},
+					cli.StringFlag{
+						Name:  "checkpoint-sync-url",
+						Usage: "The checkpoint sync provider to use if one isn't configured yet",
+					},
+					cli.BoolFlag{
+						Name:  "no-monitor",
+						Usage: "Don't wait and show the sync progress after the client restarts",
+					},
+				},
 				Action: func(c *cli.Context) error {
 
 					// Validate args
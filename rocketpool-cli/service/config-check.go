@@ -0,0 +1,95 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+// Check the settings file against the config schema and the client constraints without changing anything
+func checkConfig(rp *rocketpool.Client, cfg *config.RocketPoolConfig, isNew bool) error {
+
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode first.")
+	}
+
+	// Check the raw settings for unknown entries
+	settings, err := rp.LoadConfigSettings()
+	if err != nil {
+		return err
+	}
+	problems := cfg.GetUnknownSettings(settings)
+
+	// Check the config as a whole
+	problems = append(problems, cfg.Validate()...)
+	problems = append(problems, cfg.GetPortConflicts()...)
+
+	if len(cfg.Migrations) > 0 {
+		fmt.Printf("Settings upgrades applied: %s\n", strings.Join(cfg.Migrations, " -> "))
+	}
+	if len(problems) == 0 {
+		fmt.Printf("%sYour configuration is valid.%s\n", colorGreen, colorReset)
+		return nil
+	}
+	for _, problem := range problems {
+		fmt.Printf("%s%s%s\n", colorRed, problem, colorReset)
+	}
+	return fmt.Errorf("found %d problem(s) with your configuration", len(problems))
+
+}
+
+// Show which container environment variables and compose files would change the next time the service is started
+func diffConfig(rp *rocketpool.Client, cfg *config.RocketPoolConfig, isNew bool) error {
+
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode first.")
+	}
+
+	diff, err := rp.GetComposeDiff(cfg)
+	if err != nil {
+		return err
+	}
+
+	// Print the environment variable changes
+	if !diff.HasDeployedSettings {
+		fmt.Printf("%sThe environment variables of the current deployment weren't recorded, so they can't be compared; they will be recorded the next time you run `rocketpool service start`.%s\n\n", colorYellow, colorReset)
+	} else if len(diff.EnvChanges) > 0 {
+		fmt.Printf("%s=== Environment Variables ===%s\n", colorGreen, colorReset)
+		for _, change := range diff.EnvChanges {
+			switch {
+			case change.OldValue == "":
+				fmt.Printf("+ %s=%s\n", change.Name, change.NewValue)
+			case change.NewValue == "":
+				fmt.Printf("- %s=%s\n", change.Name, change.OldValue)
+			default:
+				fmt.Printf("~ %s: %s => %s\n", change.Name, change.OldValue, change.NewValue)
+			}
+		}
+		fmt.Println()
+	}
+
+	// Print the compose file changes
+	if len(diff.AddedFiles)+len(diff.RemovedFiles)+len(diff.ChangedFiles) > 0 {
+		fmt.Printf("%s=== Compose Files ===%s\n", colorGreen, colorReset)
+		for _, file := range diff.AddedFiles {
+			fmt.Printf("+ %s\n", file)
+		}
+		for _, file := range diff.RemovedFiles {
+			fmt.Printf("- %s\n", file)
+		}
+		for _, file := range diff.ChangedFiles {
+			fmt.Printf("~ %s\n", file)
+		}
+		fmt.Println()
+	}
+
+	if len(diff.EnvChanges)+len(diff.AddedFiles)+len(diff.RemovedFiles)+len(diff.ChangedFiles) == 0 {
+		fmt.Println("Your running configuration matches your settings; nothing would change.")
+		return nil
+	}
+	fmt.Println("Nothing has been changed yet; run `rocketpool service start` to apply these changes.")
+	return nil
+
+}
@@ -0,0 +1,38 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/rocket-pool/smartnode/shared"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Restore the settings file from before the last Smartnode upgrade
+func rollbackConfig(rp *rocketpool.Client, yes bool) error {
+
+	backupCfg, err := rp.LoadBackupConfig()
+	if err != nil {
+		return fmt.Errorf("error loading backup settings: %w", err)
+	}
+	if backupCfg == nil {
+		return fmt.Errorf("there is no backup of your settings from a previous Smartnode version to roll back to")
+	}
+
+	fmt.Printf("%sThis will replace your current settings with the backup taken before your last upgrade (from Smartnode %s).\nYour current settings will become the backup, so you can undo this by running `rocketpool service config --rollback` again.%s\n\n", colorYellow, backupCfg.Version, colorReset)
+	if !(yes || cliutils.Confirm("Are you sure you want to roll back your settings?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	version, err := rp.RollbackConfig()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Your settings have been rolled back to the ones from Smartnode %s.\n", version)
+	if version != fmt.Sprintf("v%s", shared.RocketPoolVersion) {
+		fmt.Printf("This Smartnode (v%s) will upgrade them again the next time they're loaded, so please reinstall Smartnode %s before starting the service.\n", shared.RocketPoolVersion, version)
+	}
+	return nil
+
+}
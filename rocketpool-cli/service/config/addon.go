@@ -10,8 +10,8 @@ import (
 	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 )
 
-// The page wrapper for the Graffiti Wall Writer addon config
-type AddonGwwPage struct {
+// The page wrapper for an addon's config
+type AddonPage struct {
 	addonsPage   *AddonsPage
 	page         *page
 	layout       *standardLayout
@@ -21,10 +21,10 @@ type AddonGwwPage struct {
 	otherParams  []*parameterizedFormItem
 }
 
-// Creates a new page for the Graffiti Wall Writer addon settings
-func NewAddonGwwPage(addonsPage *AddonsPage, addon addons.SmartnodeAddon) *AddonGwwPage {
+// Creates a new page for an addon's settings
+func NewAddonPage(addonsPage *AddonsPage, addon addons.SmartnodeAddon) *AddonPage {
 
-	configPage := &AddonGwwPage{
+	configPage := &AddonPage{
 		addonsPage:   addonsPage,
 		masterConfig: addonsPage.home.md.Config,
 		addon:        addon,
@@ -33,7 +33,7 @@ func NewAddonGwwPage(addonsPage *AddonsPage, addon addons.SmartnodeAddon) *Addon
 
 	configPage.page = newPage(
 		addonsPage.page,
-		"settings-addon-gww",
+		fmt.Sprintf("settings-addon-%s", addon.GetID()),
 		addon.GetName(),
 		addon.GetDescription(),
 		configPage.layout.grid,
@@ -44,12 +44,12 @@ func NewAddonGwwPage(addonsPage *AddonsPage, addon addons.SmartnodeAddon) *Addon
 }
 
 // Get the underlying page
-func (configPage *AddonGwwPage) getPage() *page {
+func (configPage *AddonPage) getPage() *page {
 	return configPage.page
 }
 
-// Creates the content for the GWW settings page
-func (configPage *AddonGwwPage) createContent() {
+// Creates the content for the addon settings page
+func (configPage *AddonPage) createContent() {
 
 	// Create the layout
 	configPage.layout = newStandardLayout()
@@ -107,7 +107,7 @@ func (configPage *AddonGwwPage) createContent() {
 }
 
 // Handle all of the form changes when the Use Fallback EC box has changed
-func (configPage *AddonGwwPage) handleEnableChanged() {
+func (configPage *AddonPage) handleEnableChanged() {
 	configPage.layout.form.Clear(true)
 	configPage.layout.form.AddFormItem(configPage.enabledBox.item)
 
@@ -120,6 +120,6 @@ func (configPage *AddonGwwPage) handleEnableChanged() {
 }
 
 // Handle a bulk redraw request
-func (configPage *AddonGwwPage) handleLayoutChanged() {
+func (configPage *AddonPage) handleLayoutChanged() {
 	configPage.handleEnableChanged()
 }
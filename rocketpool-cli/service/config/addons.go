@@ -15,8 +15,6 @@ type AddonsPage struct {
 	page          *page
 	layout        *standardLayout
 	masterConfig  *config.RocketPoolConfig
-	gwwPage       *AddonGwwPage
-	gwwButton     *parameterizedFormItem
 	categoryList  *tview.List
 	addonSubpages []settingsPage
 	content       tview.Primitive
@@ -38,9 +36,9 @@ func NewAddonsPage(home *settingsHome) *AddonsPage {
 	)
 
 	// Create the addon subpages
-	addonsPage.gwwPage = NewAddonGwwPage(addonsPage, home.md.Config.GraffitiWallWriter)
-	addonSubpages := []settingsPage{
-		addonsPage.gwwPage,
+	addonSubpages := []settingsPage{}
+	for _, addon := range home.md.Config.GetAddons() {
+		addonSubpages = append(addonSubpages, NewAddonPage(addonsPage, addon))
 	}
 	addonsPage.addonSubpages = addonSubpages
 
@@ -0,0 +1,102 @@
+package config
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// The page wrapper for the alerting config
+type AlertingConfigPage struct {
+	home              *settingsHome
+	page              *page
+	layout            *standardLayout
+	masterConfig      *config.RocketPoolConfig
+	enableAlertingBox *parameterizedFormItem
+	alertingItems     []*parameterizedFormItem
+}
+
+// Creates a new page for the alerting settings
+func NewAlertingConfigPage(home *settingsHome) *AlertingConfigPage {
+
+	configPage := &AlertingConfigPage{
+		home:         home,
+		masterConfig: home.md.Config,
+	}
+	configPage.createContent()
+
+	configPage.page = newPage(
+		home.homePage,
+		"settings-alerting",
+		"Alerting",
+		"Select this to configure the alerts the Smartnode sends when something goes wrong with your node, and the channels (such as Discord, Telegram, Pushover, or PagerDuty) it sends them to.",
+		configPage.layout.grid,
+	)
+
+	return configPage
+
+}
+
+// Get the underlying page
+func (configPage *AlertingConfigPage) getPage() *page {
+	return configPage.page
+}
+
+// Creates the content for the alerting settings page
+func (configPage *AlertingConfigPage) createContent() {
+
+	// Create the layout
+	configPage.layout = newStandardLayout()
+	configPage.layout.createForm(&configPage.masterConfig.Smartnode.Network, "Alerting Settings")
+
+	// Return to the home page after pressing Escape
+	configPage.layout.form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		// Return to the home page
+		if event.Key() == tcell.KeyEsc {
+			// Close all dropdowns and break if one was open
+			for _, param := range configPage.layout.parameters {
+				dropDown, ok := param.item.(*DropDown)
+				if ok && dropDown.open {
+					dropDown.CloseList(configPage.home.md.app)
+					return nil
+				}
+			}
+
+			configPage.home.md.setPage(configPage.home.homePage)
+			return nil
+		}
+		return event
+	})
+
+	// Set up the form items
+	configPage.enableAlertingBox = createParameterizedCheckbox(&configPage.masterConfig.EnableAlerting)
+	configPage.alertingItems = createParameterizedFormItems(configPage.masterConfig.Alerting.GetParameters(), configPage.layout.descriptionBox)
+
+	// Map the parameters to the form items in the layout
+	configPage.layout.mapParameterizedFormItems(configPage.enableAlertingBox)
+	configPage.layout.mapParameterizedFormItems(configPage.alertingItems...)
+
+	// Set up the setting callbacks
+	configPage.enableAlertingBox.item.(*tview.Checkbox).SetChangedFunc(func(checked bool) {
+		if configPage.masterConfig.EnableAlerting.Value == checked {
+			return
+		}
+		configPage.masterConfig.EnableAlerting.Value = checked
+		configPage.handleLayoutChanged()
+	})
+
+	// Do the initial draw
+	configPage.handleLayoutChanged()
+}
+
+// Handle all of the form changes when the Enable Alerting box has changed
+func (configPage *AlertingConfigPage) handleLayoutChanged() {
+	configPage.layout.form.Clear(true)
+	configPage.layout.form.AddFormItem(configPage.enableAlertingBox.item)
+
+	if configPage.masterConfig.EnableAlerting.Value == true {
+		configPage.layout.addFormItems(configPage.alertingItems)
+	}
+
+	configPage.layout.refresh()
+}
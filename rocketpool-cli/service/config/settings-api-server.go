@@ -0,0 +1,72 @@
+package config
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// The page wrapper for the node API server config
+type ApiServerConfigPage struct {
+	home           *settingsHome
+	page           *page
+	layout         *standardLayout
+	masterConfig   *config.RocketPoolConfig
+	apiServerItems []*parameterizedFormItem
+}
+
+// Creates a new page for the node API server settings
+func NewApiServerConfigPage(home *settingsHome) *ApiServerConfigPage {
+
+	configPage := &ApiServerConfigPage{
+		home:         home,
+		masterConfig: home.md.Config,
+	}
+	configPage.createContent()
+
+	configPage.page = newPage(
+		home.homePage,
+		"settings-api-server",
+		"Node API Server",
+		"Select this to serve the Smartnode's API commands over HTTP from the node daemon, for the CLI and for other tools.",
+		configPage.layout.grid,
+	)
+
+	return configPage
+
+}
+
+// Get the underlying page
+func (configPage *ApiServerConfigPage) getPage() *page {
+	return configPage.page
+}
+
+// Creates the content for the node API server settings page
+func (configPage *ApiServerConfigPage) createContent() {
+
+	// Create the layout
+	configPage.layout = newStandardLayout()
+	configPage.layout.createForm(&configPage.masterConfig.Smartnode.Network, "Node API Server Settings")
+
+	// Return to the home page after pressing Escape
+	configPage.layout.form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEsc {
+			configPage.home.md.setPage(configPage.home.homePage)
+			return nil
+		}
+		return event
+	})
+
+	// Set up the form items
+	configPage.apiServerItems = createParameterizedFormItems(configPage.masterConfig.ApiServer.GetParameters(), configPage.layout.descriptionBox)
+	configPage.layout.mapParameterizedFormItems(configPage.apiServerItems...)
+
+	// Do the initial draw
+	configPage.handleLayoutChanged()
+}
+
+// Handle all of the form changes when the layout has changed
+func (configPage *ApiServerConfigPage) handleLayoutChanged() {
+	configPage.layout.form.Clear(true)
+	configPage.layout.addFormItems(configPage.apiServerItems)
+	configPage.layout.refresh()
+}
@@ -0,0 +1,72 @@
+package config
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// The page wrapper for the container override config
+type ContainerOverridesConfigPage struct {
+	home          *settingsHome
+	page          *page
+	layout        *standardLayout
+	masterConfig  *config.RocketPoolConfig
+	overrideItems []*parameterizedFormItem
+}
+
+// Creates a new page for the container override settings
+func NewContainerOverridesConfigPage(home *settingsHome) *ContainerOverridesConfigPage {
+
+	configPage := &ContainerOverridesConfigPage{
+		home:         home,
+		masterConfig: home.md.Config,
+	}
+	configPage.createContent()
+
+	configPage.page = newPage(
+		home.homePage,
+		"settings-container-overrides",
+		"Container Overrides",
+		"Select this to set extra environment variables for the client containers. Container tags and extra command line flags can be changed on each client's own page.",
+		configPage.layout.grid,
+	)
+
+	return configPage
+
+}
+
+// Get the underlying page
+func (configPage *ContainerOverridesConfigPage) getPage() *page {
+	return configPage.page
+}
+
+// Creates the content for the container override settings page
+func (configPage *ContainerOverridesConfigPage) createContent() {
+
+	// Create the layout
+	configPage.layout = newStandardLayout()
+	configPage.layout.createForm(&configPage.masterConfig.Smartnode.Network, "Container Override Settings")
+
+	// Return to the home page after pressing Escape
+	configPage.layout.form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEsc {
+			configPage.home.md.setPage(configPage.home.homePage)
+			return nil
+		}
+		return event
+	})
+
+	// Set up the form items
+	configPage.overrideItems = createParameterizedFormItems(configPage.masterConfig.ContainerOverrides.GetParameters(), configPage.layout.descriptionBox)
+	configPage.layout.mapParameterizedFormItems(configPage.overrideItems...)
+
+	// Do the initial draw
+	configPage.handleLayoutChanged()
+}
+
+// Handle all of the form changes when the layout has changed
+func (configPage *ContainerOverridesConfigPage) handleLayoutChanged() {
+	configPage.layout.form.Clear(true)
+	configPage.layout.addFormItems(configPage.overrideItems)
+	configPage.layout.refresh()
+}
@@ -0,0 +1,72 @@
+package config
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// The page wrapper for the container resources config
+type ContainerResourcesConfigPage struct {
+	home          *settingsHome
+	page          *page
+	layout        *standardLayout
+	masterConfig  *config.RocketPoolConfig
+	resourceItems []*parameterizedFormItem
+}
+
+// Creates a new page for the container resource settings
+func NewContainerResourcesConfigPage(home *settingsHome) *ContainerResourcesConfigPage {
+
+	configPage := &ContainerResourcesConfigPage{
+		home:         home,
+		masterConfig: home.md.Config,
+	}
+	configPage.createContent()
+
+	configPage.page = newPage(
+		home.homePage,
+		"settings-container-resources",
+		"Container Resources",
+		"Select this to limit the CPU and memory each container can use, change their priorities, and choose when Docker restarts them.",
+		configPage.layout.grid,
+	)
+
+	return configPage
+
+}
+
+// Get the underlying page
+func (configPage *ContainerResourcesConfigPage) getPage() *page {
+	return configPage.page
+}
+
+// Creates the content for the container resource settings page
+func (configPage *ContainerResourcesConfigPage) createContent() {
+
+	// Create the layout
+	configPage.layout = newStandardLayout()
+	configPage.layout.createForm(&configPage.masterConfig.Smartnode.Network, "Container Resource Settings")
+
+	// Return to the home page after pressing Escape
+	configPage.layout.form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEsc {
+			configPage.home.md.setPage(configPage.home.homePage)
+			return nil
+		}
+		return event
+	})
+
+	// Set up the form items
+	configPage.resourceItems = createParameterizedFormItems(configPage.masterConfig.ContainerResources.GetParameters(), configPage.layout.descriptionBox)
+	configPage.layout.mapParameterizedFormItems(configPage.resourceItems...)
+
+	// Do the initial draw
+	configPage.handleLayoutChanged()
+}
+
+// Handle all of the form changes when the layout has changed
+func (configPage *ContainerResourcesConfigPage) handleLayoutChanged() {
+	configPage.layout.form.Clear(true)
+	configPage.layout.addFormItems(configPage.resourceItems)
+	configPage.layout.refresh()
+}
@@ -0,0 +1,72 @@
+package config
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// The page wrapper for the data layout config
+type DataLayoutConfigPage struct {
+	home         *settingsHome
+	page         *page
+	layout       *standardLayout
+	masterConfig *config.RocketPoolConfig
+	layoutItems  []*parameterizedFormItem
+}
+
+// Creates a new page for the data layout settings
+func NewDataLayoutConfigPage(home *settingsHome) *DataLayoutConfigPage {
+
+	configPage := &DataLayoutConfigPage{
+		home:         home,
+		masterConfig: home.md.Config,
+	}
+	configPage.createContent()
+
+	configPage.page = newPage(
+		home.homePage,
+		"settings-data-layout",
+		"Data Layout",
+		"Select this to keep the chain data, rewards trees, wallet or logs in separate folders or volumes.",
+		configPage.layout.grid,
+	)
+
+	return configPage
+
+}
+
+// Get the underlying page
+func (configPage *DataLayoutConfigPage) getPage() *page {
+	return configPage.page
+}
+
+// Creates the content for the data layout settings page
+func (configPage *DataLayoutConfigPage) createContent() {
+
+	// Create the layout
+	configPage.layout = newStandardLayout()
+	configPage.layout.createForm(&configPage.masterConfig.Smartnode.Network, "Data Layout Settings")
+
+	// Return to the home page after pressing Escape
+	configPage.layout.form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEsc {
+			configPage.home.md.setPage(configPage.home.homePage)
+			return nil
+		}
+		return event
+	})
+
+	// Set up the form items
+	configPage.layoutItems = createParameterizedFormItems(configPage.masterConfig.DataLayout.GetParameters(), configPage.layout.descriptionBox)
+	configPage.layout.mapParameterizedFormItems(configPage.layoutItems...)
+
+	// Do the initial draw
+	configPage.handleLayoutChanged()
+}
+
+// Handle all of the form changes when the layout has changed
+func (configPage *DataLayoutConfigPage) handleLayoutChanged() {
+	configPage.layout.form.Clear(true)
+	configPage.layout.addFormItems(configPage.layoutItems)
+	configPage.layout.refresh()
+}
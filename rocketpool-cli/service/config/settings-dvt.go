@@ -0,0 +1,72 @@
+package config
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// The page wrapper for the DVT config
+type DvtConfigPage struct {
+	home         *settingsHome
+	page         *page
+	layout       *standardLayout
+	masterConfig *config.RocketPoolConfig
+	dvtItems     []*parameterizedFormItem
+}
+
+// Creates a new page for the DVT settings
+func NewDvtConfigPage(home *settingsHome) *DvtConfigPage {
+
+	configPage := &DvtConfigPage{
+		home:         home,
+		masterConfig: home.md.Config,
+	}
+	configPage.createContent()
+
+	configPage.page = newPage(
+		home.homePage,
+		"settings-dvt",
+		"Distributed Validators",
+		"Select this to have new minipools' validators operated by a distributed validator (DVT) cluster, such as Obol or SSV, instead of your own Validator client.",
+		configPage.layout.grid,
+	)
+
+	return configPage
+
+}
+
+// Get the underlying page
+func (configPage *DvtConfigPage) getPage() *page {
+	return configPage.page
+}
+
+// Creates the content for the DVT settings page
+func (configPage *DvtConfigPage) createContent() {
+
+	// Create the layout
+	configPage.layout = newStandardLayout()
+	configPage.layout.createForm(&configPage.masterConfig.Smartnode.Network, "Distributed Validator Settings")
+
+	// Return to the home page after pressing Escape
+	configPage.layout.form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEsc {
+			configPage.home.md.setPage(configPage.home.homePage)
+			return nil
+		}
+		return event
+	})
+
+	// Set up the form items
+	configPage.dvtItems = createParameterizedFormItems(configPage.masterConfig.Dvt.GetParameters(), configPage.layout.descriptionBox)
+	configPage.layout.mapParameterizedFormItems(configPage.dvtItems...)
+
+	// Do the initial draw
+	configPage.handleLayoutChanged()
+}
+
+// Handle all of the form changes when the layout has changed
+func (configPage *DvtConfigPage) handleLayoutChanged() {
+	configPage.layout.form.Clear(true)
+	configPage.layout.addFormItems(configPage.dvtItems)
+	configPage.layout.refresh()
+}
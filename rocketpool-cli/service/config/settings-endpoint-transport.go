@@ -0,0 +1,72 @@
+package config
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// The page wrapper for the endpoint transport config
+type EndpointTransportConfigPage struct {
+	home           *settingsHome
+	page           *page
+	layout         *standardLayout
+	masterConfig   *config.RocketPoolConfig
+	transportItems []*parameterizedFormItem
+}
+
+// Creates a new page for the endpoint transport settings
+func NewEndpointTransportConfigPage(home *settingsHome) *EndpointTransportConfigPage {
+
+	configPage := &EndpointTransportConfigPage{
+		home:         home,
+		masterConfig: home.md.Config,
+	}
+	configPage.createContent()
+
+	configPage.page = newPage(
+		home.homePage,
+		"settings-endpoint-transport",
+		"External Endpoint Transport",
+		"Select this to set up authentication, client TLS certificates or a proxy for externally managed and fallback clients.",
+		configPage.layout.grid,
+	)
+
+	return configPage
+
+}
+
+// Get the underlying page
+func (configPage *EndpointTransportConfigPage) getPage() *page {
+	return configPage.page
+}
+
+// Creates the content for the endpoint transport settings page
+func (configPage *EndpointTransportConfigPage) createContent() {
+
+	// Create the layout
+	configPage.layout = newStandardLayout()
+	configPage.layout.createForm(&configPage.masterConfig.Smartnode.Network, "External Endpoint Transport Settings")
+
+	// Return to the home page after pressing Escape
+	configPage.layout.form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEsc {
+			configPage.home.md.setPage(configPage.home.homePage)
+			return nil
+		}
+		return event
+	})
+
+	// Set up the form items
+	configPage.transportItems = createParameterizedFormItems(configPage.masterConfig.EndpointTransport.GetParameters(), configPage.layout.descriptionBox)
+	configPage.layout.mapParameterizedFormItems(configPage.transportItems...)
+
+	// Do the initial draw
+	configPage.handleLayoutChanged()
+}
+
+// Handle all of the form changes when the layout has changed
+func (configPage *EndpointTransportConfigPage) handleLayoutChanged() {
+	configPage.layout.form.Clear(true)
+	configPage.layout.addFormItems(configPage.transportItems)
+	configPage.layout.refresh()
+}
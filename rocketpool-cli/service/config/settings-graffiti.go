@@ -0,0 +1,72 @@
+package config
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// The page wrapper for the graffiti config
+type GraffitiConfigPage struct {
+	home          *settingsHome
+	page          *page
+	layout        *standardLayout
+	masterConfig  *config.RocketPoolConfig
+	graffitiItems []*parameterizedFormItem
+}
+
+// Creates a new page for the graffiti settings
+func NewGraffitiConfigPage(home *settingsHome) *GraffitiConfigPage {
+
+	configPage := &GraffitiConfigPage{
+		home:         home,
+		masterConfig: home.md.Config,
+	}
+	configPage.createContent()
+
+	configPage.page = newPage(
+		home.homePage,
+		"settings-graffiti",
+		"Graffiti",
+		"Select this to set up the graffiti your validators put in the blocks they propose, using templates or a rotating list.",
+		configPage.layout.grid,
+	)
+
+	return configPage
+
+}
+
+// Get the underlying page
+func (configPage *GraffitiConfigPage) getPage() *page {
+	return configPage.page
+}
+
+// Creates the content for the graffiti settings page
+func (configPage *GraffitiConfigPage) createContent() {
+
+	// Create the layout
+	configPage.layout = newStandardLayout()
+	configPage.layout.createForm(&configPage.masterConfig.Smartnode.Network, "Graffiti Settings")
+
+	// Return to the home page after pressing Escape
+	configPage.layout.form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEsc {
+			configPage.home.md.setPage(configPage.home.homePage)
+			return nil
+		}
+		return event
+	})
+
+	// Set up the form items
+	configPage.graffitiItems = createParameterizedFormItems(configPage.masterConfig.Graffiti.GetParameters(), configPage.layout.descriptionBox)
+	configPage.layout.mapParameterizedFormItems(configPage.graffitiItems...)
+
+	// Do the initial draw
+	configPage.handleLayoutChanged()
+}
+
+// Handle all of the form changes when the layout has changed
+func (configPage *GraffitiConfigPage) handleLayoutChanged() {
+	configPage.layout.form.Clear(true)
+	configPage.layout.addFormItems(configPage.graffitiItems)
+	configPage.layout.refresh()
+}
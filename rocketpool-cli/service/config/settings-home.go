@@ -20,6 +20,16 @@ type settingsHome struct {
 	ccPage           *ConsensusConfigPage
 	mevBoostPage     *MevBoostConfigPage
 	metricsPage      *MetricsConfigPage
+	alertingPage     *AlertingConfigPage
+	overridesPage    *ContainerOverridesConfigPage
+	resourcesPage    *ContainerResourcesConfigPage
+	dataLayoutPage   *DataLayoutConfigPage
+	transportPage    *EndpointTransportConfigPage
+	graffitiPage     *GraffitiConfigPage
+	dvtPage          *DvtConfigPage
+	keymanagerPage   *KeymanagerConfigPage
+	apiServerPage    *ApiServerConfigPage
+	secretsPage      *SecretsConfigPage
 	addonsPage       *AddonsPage
 	categoryList     *tview.List
 	settingsSubpages []settingsPage
@@ -45,6 +55,16 @@ func newSettingsHome(md *mainDisplay) *settingsHome {
 	home.fallbackPage = NewFallbackConfigPage(home)
 	home.mevBoostPage = NewMevBoostConfigPage(home)
 	home.metricsPage = NewMetricsConfigPage(home)
+	home.alertingPage = NewAlertingConfigPage(home)
+	home.overridesPage = NewContainerOverridesConfigPage(home)
+	home.resourcesPage = NewContainerResourcesConfigPage(home)
+	home.dataLayoutPage = NewDataLayoutConfigPage(home)
+	home.transportPage = NewEndpointTransportConfigPage(home)
+	home.graffitiPage = NewGraffitiConfigPage(home)
+	home.dvtPage = NewDvtConfigPage(home)
+	home.keymanagerPage = NewKeymanagerConfigPage(home)
+	home.apiServerPage = NewApiServerConfigPage(home)
+	home.secretsPage = NewSecretsConfigPage(home)
 	home.addonsPage = NewAddonsPage(home)
 	settingsSubpages := []settingsPage{
 		home.smartnodePage,
@@ -53,6 +73,16 @@ func newSettingsHome(md *mainDisplay) *settingsHome {
 		home.fallbackPage,
 		home.mevBoostPage,
 		home.metricsPage,
+		home.alertingPage,
+		home.overridesPage,
+		home.resourcesPage,
+		home.dataLayoutPage,
+		home.transportPage,
+		home.graffitiPage,
+		home.dvtPage,
+		home.keymanagerPage,
+		home.apiServerPage,
+		home.secretsPage,
 		home.addonsPage,
 	}
 	home.settingsSubpages = settingsSubpages
@@ -0,0 +1,72 @@
+package config
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// The page wrapper for the Keymanager API config
+type KeymanagerConfigPage struct {
+	home            *settingsHome
+	page            *page
+	layout          *standardLayout
+	masterConfig    *config.RocketPoolConfig
+	keymanagerItems []*parameterizedFormItem
+}
+
+// Creates a new page for the Keymanager API settings
+func NewKeymanagerConfigPage(home *settingsHome) *KeymanagerConfigPage {
+
+	configPage := &KeymanagerConfigPage{
+		home:         home,
+		masterConfig: home.md.Config,
+	}
+	configPage.createContent()
+
+	configPage.page = newPage(
+		home.homePage,
+		"settings-keymanager",
+		"Keymanager API",
+		"Select this to let the node daemon manage your Validator client's keys and fee recipients over its Keymanager API instead of restarting it.",
+		configPage.layout.grid,
+	)
+
+	return configPage
+
+}
+
+// Get the underlying page
+func (configPage *KeymanagerConfigPage) getPage() *page {
+	return configPage.page
+}
+
+// Creates the content for the Keymanager API settings page
+func (configPage *KeymanagerConfigPage) createContent() {
+
+	// Create the layout
+	configPage.layout = newStandardLayout()
+	configPage.layout.createForm(&configPage.masterConfig.Smartnode.Network, "Keymanager API Settings")
+
+	// Return to the home page after pressing Escape
+	configPage.layout.form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEsc {
+			configPage.home.md.setPage(configPage.home.homePage)
+			return nil
+		}
+		return event
+	})
+
+	// Set up the form items
+	configPage.keymanagerItems = createParameterizedFormItems(configPage.masterConfig.Keymanager.GetParameters(), configPage.layout.descriptionBox)
+	configPage.layout.mapParameterizedFormItems(configPage.keymanagerItems...)
+
+	// Do the initial draw
+	configPage.handleLayoutChanged()
+}
+
+// Handle all of the form changes when the layout has changed
+func (configPage *KeymanagerConfigPage) handleLayoutChanged() {
+	configPage.layout.form.Clear(true)
+	configPage.layout.addFormItems(configPage.keymanagerItems)
+	configPage.layout.refresh()
+}
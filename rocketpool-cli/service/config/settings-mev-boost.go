@@ -91,6 +91,7 @@ func (configPage *MevBoostConfigPage) createContent() {
 		&configPage.masterConfig.MevBoost.OpenRpcPort,
 		&configPage.masterConfig.MevBoost.ContainerTag,
 		&configPage.masterConfig.MevBoost.AdditionalFlags,
+		&configPage.masterConfig.MevBoost.EnableRelayMonitoring,
 	}
 	externalParams := []*cfgtypes.Parameter{&configPage.masterConfig.MevBoost.ExternalUrl}
 
@@ -150,8 +151,8 @@ func (configPage *MevBoostConfigPage) handleModeChanged() {
 		case cfgtypes.Mode_Local:
 			configPage.handleSelectionModeChanged()
 		case cfgtypes.Mode_External:
-			if configPage.masterConfig.ExecutionClientMode.Value.(cfgtypes.Mode) == cfgtypes.Mode_Local {
-				// Only show these to Docker users, not Hybrid users
+			if configPage.masterConfig.ConsensusClientMode.Value.(cfgtypes.Mode) == cfgtypes.Mode_Local {
+				// Only show these to users with a locally managed Consensus client, since an external one connects to MEV-Boost itself
 				configPage.layout.addFormItems(configPage.externalItems)
 			}
 		}
@@ -0,0 +1,72 @@
+package config
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// The page wrapper for the secret storage config
+type SecretsConfigPage struct {
+	home         *settingsHome
+	page         *page
+	layout       *standardLayout
+	masterConfig *config.RocketPoolConfig
+	secretsItems []*parameterizedFormItem
+}
+
+// Creates a new page for the secret storage settings
+func NewSecretsConfigPage(home *settingsHome) *SecretsConfigPage {
+
+	configPage := &SecretsConfigPage{
+		home:         home,
+		masterConfig: home.md.Config,
+	}
+	configPage.createContent()
+
+	configPage.page = newPage(
+		home.homePage,
+		"settings-secrets",
+		"Secret Storage",
+		"Select this to keep the node wallet's password and the API server's token in a secret manager instead of plaintext files in your data folder, and to keep your validator keys encrypted on disk.",
+		configPage.layout.grid,
+	)
+
+	return configPage
+
+}
+
+// Get the underlying page
+func (configPage *SecretsConfigPage) getPage() *page {
+	return configPage.page
+}
+
+// Creates the content for the secret storage settings page
+func (configPage *SecretsConfigPage) createContent() {
+
+	// Create the layout
+	configPage.layout = newStandardLayout()
+	configPage.layout.createForm(&configPage.masterConfig.Smartnode.Network, "Secret Storage Settings")
+
+	// Return to the home page after pressing Escape
+	configPage.layout.form.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEsc {
+			configPage.home.md.setPage(configPage.home.homePage)
+			return nil
+		}
+		return event
+	})
+
+	// Set up the form items
+	configPage.secretsItems = createParameterizedFormItems(configPage.masterConfig.Secrets.GetParameters(), configPage.layout.descriptionBox)
+	configPage.layout.mapParameterizedFormItems(configPage.secretsItems...)
+
+	// Do the initial draw
+	configPage.handleLayoutChanged()
+}
+
+// Handle all of the form changes when the layout has changed
+func (configPage *SecretsConfigPage) handleLayoutChanged() {
+	configPage.layout.form.Clear(true)
+	configPage.layout.addFormItems(configPage.secretsItems)
+	configPage.layout.refresh()
+}
@@ -37,7 +37,7 @@ func createMevModeStep(wiz *wizard, currentStep int, totalSteps int) *choiceWiza
 		case cfgtypes.Mode_Local:
 			wiz.localMevModal.show()
 		case cfgtypes.Mode_External:
-			switch wiz.md.Config.ExecutionClientMode.Value {
+			switch wiz.md.Config.ConsensusClientMode.Value {
 			case cfgtypes.Mode_Local:
 				wiz.externalMevModal.show()
 			case cfgtypes.Mode_External:
@@ -45,7 +45,7 @@ func createMevModeStep(wiz *wizard, currentStep int, totalSteps int) *choiceWiza
 				wiz.md.Config.MevBoost.Mode.Value = cfgtypes.Mode_External
 				wiz.finishedModal.show()
 			default:
-				panic(fmt.Sprintf("Unknown EC mode %s during MEV mode selection", wiz.md.Config.ExecutionClientMode.Value))
+				panic(fmt.Sprintf("Unknown CC mode %s during MEV mode selection", wiz.md.Config.ConsensusClientMode.Value))
 			}
 		default:
 			panic(fmt.Sprintf("Unknown MEV mode %s", modes[buttonIndex].Value))
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Request and confirm a run of a command protected by a confirmation policy with the provided API arguments, so it can be run afterwards
+func confirmCommand(c *cli.Context, route string, args []string) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Check the status first so unprotected commands aren't requested
+	status, err := rp.GetConfirmationStatus(route, nil)
+	if err != nil {
+		return err
+	}
+	if !status.Protected {
+		fmt.Printf("[%s] isn't protected by a confirmation policy, so it doesn't need to be confirmed.\n", route)
+		return nil
+	}
+
+	// Confirm it
+	ready, err := cliutils.ConfirmProtectedCommand(rp, route, args)
+	if err != nil {
+		return err
+	}
+	if ready {
+		fmt.Printf("[%s] has been confirmed and can be run once with these arguments now.\n", route)
+	}
+	return nil
+
+}
+
+// Approve a pending confirmation request from this device
+func approveCommand(c *cli.Context, id string) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Find the request
+	requests, err := rp.GetConfirmationRequests()
+	if err != nil {
+		return err
+	}
+	found := false
+	for _, request := range requests.Requests {
+		if request.ID == id {
+			cliutils.PrintConfirmationRequest(request)
+			fmt.Println()
+			found = true
+			break
+		}
+	}
+	if !found {
+		return fmt.Errorf("There is no pending confirmation request with ID %s; it may have expired.", id)
+	}
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to approve this request?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Approve it
+	if _, err := rp.ApproveCommand(id); err != nil {
+		return err
+	}
+	fmt.Printf("Approved request %s.\n", id)
+	return nil
+
+}
+
+// List the pending confirmation requests
+func listConfirmationRequests(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Get the requests
+	response, err := rp.GetConfirmationRequests()
+	if err != nil {
+		return err
+	}
+	if len(response.Requests) == 0 {
+		fmt.Println("There are no pending confirmation requests.")
+		return nil
+	}
+	for _, request := range response.Requests {
+		cliutils.PrintConfirmationRequest(request)
+	}
+	return nil
+
+}
+
+// Set the passphrase used to confirm commands with the passphrase safeguard
+func setConfirmationPassphrase(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Check if there's a current passphrase
+	status, err := rp.GetConfirmationStatus("", nil)
+	if err != nil {
+		return err
+	}
+	currentPassphrase := ""
+	if status.PassphraseSet {
+		currentPassphrase = cliutils.PromptPassword("Please enter the current confirmation passphrase:", "^.+$", "")
+	}
+
+	// Get the new passphrase
+	fmt.Println("The confirmation passphrase is separate from the node wallet's password; use a different one so knowing one isn't enough to get past the other.")
+	var newPassphrase string
+	for {
+		newPassphrase = cliutils.PromptPassword("Please enter the new confirmation passphrase:", "^.{8,}$", "The passphrase must be at least 8 characters long")
+		confirmation := cliutils.PromptPassword("Please enter it again to confirm:", "^.*$", "")
+		if newPassphrase == confirmation {
+			break
+		}
+		fmt.Println("The passphrases don't match, please try again.")
+	}
+
+	// Set it
+	if _, err := rp.SetConfirmationPassphrase(currentPassphrase, newPassphrase); err != nil {
+		return err
+	}
+	fmt.Println("The confirmation passphrase has been set.")
+	return nil
+
+}
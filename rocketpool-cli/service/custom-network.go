@@ -0,0 +1,184 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Check a network definition file and copy it into the data folder
+func importCustomNetwork(c *cli.Context, path string) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Load the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode first.")
+	}
+
+	// Check the definition
+	path, err = homedir.Expand(path)
+	if err != nil {
+		return fmt.Errorf("error expanding network definition path: %w", err)
+	}
+	definition, err := config.LoadNetworkDefinition(path)
+	if err != nil {
+		return err
+	}
+	printNetworkDefinition(definition)
+
+	// Get the target path
+	targetPath, err := getCustomNetworkHostPath(cfg)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(targetPath); err == nil {
+		if !(c.Bool("yes") || cliutils.Confirm("You already have a custom network definition. Would you like to replace it with this one?")) {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+	}
+
+	// Copy it into the data folder
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("error reading network definition: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(targetPath), 0775); err != nil {
+		return fmt.Errorf("error creating data folder: %w", err)
+	}
+	if err := os.WriteFile(targetPath, contents, 0644); err != nil {
+		return fmt.Errorf("error saving network definition: %w", err)
+	}
+
+	fmt.Printf("\n%sImported the [%s] network definition.%s\n", colorGreen, definition.Name, colorReset)
+	currentNetwork := cfg.Smartnode.Network.Value.(cfgtypes.Network)
+	if currentNetwork != definition.Network {
+		fmt.Printf("%sIt is based on %v, but your Smartnode is on %v, so it won't be used until you select %v in `rocketpool service config`.%s\n", colorYellow, definition.Network, currentNetwork, definition.Network, colorReset)
+	}
+	fmt.Println("Please restart the Smartnode with `rocketpool service start` to use it.")
+	return nil
+
+}
+
+// Show the custom network definition in use
+func showCustomNetwork(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Load the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode first.")
+	}
+
+	targetPath, err := getCustomNetworkHostPath(cfg)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(targetPath); os.IsNotExist(err) {
+		fmt.Println("You don't have a custom network definition. Import one with `rocketpool service custom-network import <file>`.")
+		return nil
+	}
+	definition, err := config.LoadNetworkDefinition(targetPath)
+	if err != nil {
+		return err
+	}
+	printNetworkDefinition(definition)
+
+	currentNetwork := cfg.Smartnode.Network.Value.(cfgtypes.Network)
+	if currentNetwork != definition.Network {
+		fmt.Printf("\n%sThis definition isn't in use because your Smartnode is on %v.%s\n", colorYellow, currentNetwork, colorReset)
+	}
+	return nil
+
+}
+
+// Remove the custom network definition
+func removeCustomNetwork(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Load the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode first.")
+	}
+
+	targetPath, err := getCustomNetworkHostPath(cfg)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(targetPath); os.IsNotExist(err) {
+		fmt.Println("You don't have a custom network definition.")
+		return nil
+	}
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to remove your custom network definition? The Smartnode will go back to the built-in %v settings.", cfg.Smartnode.Network.Value))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+	if err := os.Remove(targetPath); err != nil {
+		return fmt.Errorf("error removing network definition: %w", err)
+	}
+
+	fmt.Println("Removed the custom network definition. Please restart the Smartnode with `rocketpool service start` to go back to the built-in network settings.")
+	return nil
+
+}
+
+// Get the path of the custom network definition in the data folder on the host
+func getCustomNetworkHostPath(cfg *config.RocketPoolConfig) (string, error) {
+	dataPath, err := homedir.Expand(cfg.Smartnode.DataPath.Value.(string))
+	if err != nil {
+		return "", fmt.Errorf("error expanding data directory: %w", err)
+	}
+	return filepath.Join(dataPath, config.CustomNetworkFilename), nil
+}
+
+// Print the details of a network definition
+func printNetworkDefinition(definition *config.NetworkDefinition) {
+	fmt.Printf("Name:               %s\n", definition.Name)
+	fmt.Printf("Based on:           %v\n", definition.Network)
+	fmt.Printf("Chain ID:           %d\n", definition.ChainID)
+	fmt.Printf("RocketStorage:      %s\n", definition.RocketStorageAddress)
+	fmt.Printf("Multicall:          %s\n", definition.MulticallAddress)
+	if definition.BalanceBatcherAddress != "" {
+		fmt.Printf("Balance batcher:    %s\n", definition.BalanceBatcherAddress)
+	}
+	if definition.RplTokenAddress != "" {
+		fmt.Printf("RPL token:          %s\n", definition.RplTokenAddress)
+	}
+	if definition.RethAddress != "" {
+		fmt.Printf("rETH:               %s\n", definition.RethAddress)
+	}
+	if definition.Genesis.ForkVersion != "" {
+		fmt.Printf("Genesis fork:       %s\n", definition.Genesis.ForkVersion)
+	}
+	if definition.Genesis.Time != 0 {
+		fmt.Printf("Genesis time:       %d\n", definition.Genesis.Time)
+	}
+}
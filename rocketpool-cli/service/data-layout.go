@@ -0,0 +1,347 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+	"github.com/mitchellh/go-homedir"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/auditlog"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// View where each part of the Smartnode's data is kept
+func getDataLayout(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Load the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode.")
+	}
+
+	fmt.Printf("%s=== Data Layout ===%s\n", colorGreen, colorReset)
+	for _, component := range config.GetDataComponents() {
+		location, relocated, err := getDataLocation(rp, cfg, component)
+		if err != nil {
+			location = fmt.Sprintf("unknown (%s)", err.Error())
+		}
+		if relocated {
+			fmt.Printf("%-16s %s%s%s", component, colorLightBlue, location, colorReset)
+		} else {
+			fmt.Printf("%-16s %s (default)", component, location)
+		}
+		if !isChainData(component) || relocated {
+			if free, err := getPartitionFreeSpace(rp, location); err == nil {
+				fmt.Printf(", %s free", humanize.IBytes(free))
+			}
+		}
+		fmt.Println()
+	}
+	fmt.Println()
+	fmt.Println("Use `rocketpool service migrate-data <component> <folder>` to move a component to another folder or volume.")
+	return nil
+
+}
+
+// Move one part of the Smartnode's data to another folder, and point the Smartnode at it
+func migrateData(c *cli.Context, componentName string, targetDir string) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Load the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode.")
+	}
+	if cfg.IsNativeMode {
+		return fmt.Errorf("This command isn't available in Native Mode. Stop your services, move the files yourself, and set the new folder in `rocketpool service config`.")
+	}
+
+	// Check the component and the target folder
+	component := config.DataComponent(componentName)
+	param := cfg.DataLayout.GetParameter(component)
+	if param == nil {
+		names := []string{}
+		for _, component := range config.GetDataComponents() {
+			names = append(names, string(component))
+		}
+		return fmt.Errorf("[%s] isn't a data component that can be moved; please choose one of: %s", componentName, strings.Join(names, ", "))
+	}
+	targetDir, err = filepath.Abs(targetDir)
+	if err != nil {
+		return fmt.Errorf("Error converting to absolute path: %w", err)
+	}
+	source, relocated, err := getDataLocation(rp, cfg, component)
+	if err != nil {
+		return err
+	}
+	if relocated && source == targetDir {
+		fmt.Printf("Your %s is already kept in %s.\n", component, targetDir)
+		return nil
+	}
+	entries, err := os.ReadDir(targetDir)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("Error reading target dir: %w", err)
+	}
+	if len(entries) > 0 {
+		return fmt.Errorf("Target directory [%s] isn't empty. Please choose an empty or new folder so nothing in it is overwritten.", targetDir)
+	}
+
+	// Make sure the new settings are valid before anything is stopped
+	previousValue := param.Value
+	param.Value = targetDir
+	if problems := cfg.DataLayout.GetProblems(); len(problems) > 0 {
+		return errors.New(strings.Join(problems, "\n"))
+	}
+
+	// Get the containers that use the component
+	prefix := cfg.Smartnode.ProjectName.Value.(string)
+	var containers []string
+	switch component {
+	case config.DataComponent_ExecutionData:
+		containers = []string{prefix + ExecutionContainerSuffix}
+	case config.DataComponent_ConsensusData:
+		containers = []string{prefix + BeaconContainerSuffix}
+	default:
+		containers = []string{prefix + ApiContainerSuffix, prefix + NodeContainerSuffix, prefix + WatchtowerContainerSuffix}
+	}
+
+	// Check there's enough room for chain data, which can be huge
+	if isChainData(component) && !c.Bool("force") {
+		var sourceBytes uint64
+		if relocated {
+			sourceBytes, err = rp.GetDirSizeViaEcMigrator(prefix+EcMigratorContainerSuffix, source, cfg.Smartnode.GetEcMigratorContainerTag())
+		} else {
+			sourceBytes, err = getVolumeSpaceUsed(rp, source)
+		}
+		if err != nil {
+			fmt.Printf("%sWARNING: Couldn't check the size of the chain data: %s\nPlease verify you have enough free space in the target folder before proceeding!%s\n\n", colorRed, err.Error(), colorReset)
+		} else {
+			targetFree, err := getPartitionFreeSpace(rp, targetDir)
+			if err != nil {
+				fmt.Printf("%sWARNING: Couldn't get the free space available in the target folder: %s\nPlease verify you have enough free space in the target folder before proceeding!%s\n\n", colorRed, err.Error(), colorReset)
+			} else {
+				fmt.Printf("%sChain data size:       %s%s\n", colorLightBlue, humanize.IBytes(sourceBytes), colorReset)
+				fmt.Printf("%sTarget dir free space: %s%s\n\n", colorLightBlue, humanize.IBytes(targetFree), colorReset)
+				if targetFree < sourceBytes {
+					return fmt.Errorf("%sYour target directory does not have enough space to hold the chain data. Please free up more space and try again or use the --force flag to ignore this check.%s", colorRed, colorReset)
+				}
+			}
+		}
+	}
+
+	// Explain what's about to happen
+	fmt.Printf("This will move your %s from %s to %s:\n", component, source, targetDir)
+	fmt.Printf("1. Stop %s.\n", strings.Join(containers, ", "))
+	fmt.Println("2. Copy the data to the new folder.")
+	fmt.Println("3. Switch your settings to the new folder and restart the Smartnode.")
+	fmt.Printf("\nThe old copy is left where it is, so you can go back if something goes wrong; you can delete it once you've checked that everything works.\n\n")
+	if isChainData(component) {
+		fmt.Printf("%sNOTE: Once started, the copy *will not stop* until it is complete - even if you exit the command with Ctrl+C.\nPlease do not exit until it finishes so you can watch its progress.%s\n\n", colorYellow, colorReset)
+	}
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to move your %s?", component))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Stop the containers that use it
+	stopped := []string{}
+	restartStopped := func() {
+		for _, container := range stopped {
+			fmt.Printf("Restarting %s...\n", container)
+			if _, err := rp.StartContainer(container); err != nil {
+				fmt.Printf("%sWARNING: error restarting %s: %s\nPlease run `rocketpool service start` to restart it.%s\n", colorRed, container, err.Error(), colorReset)
+			}
+		}
+	}
+	for _, container := range containers {
+		status, err := rp.GetDockerStatus(container)
+		if err != nil || status != "running" {
+			continue
+		}
+		fmt.Printf("Stopping %s...\n", container)
+		if _, err := rp.StopContainer(container); err != nil {
+			restartStopped()
+			return fmt.Errorf("error stopping %s: %w", container, err)
+		}
+		stopped = append(stopped, container)
+	}
+
+	// Copy the data
+	fmt.Printf("Copying your %s to %s...\n", component, targetDir)
+	if isChainData(component) {
+		err = os.MkdirAll(targetDir, 0755)
+		if err == nil {
+			err = rp.RunEcMigrator(prefix+EcMigratorContainerSuffix, source, targetDir, "export", cfg.Smartnode.GetEcMigratorContainerTag())
+		}
+	} else {
+		err = copyDataComponent(component, source, targetDir)
+	}
+	if err != nil {
+		restartStopped()
+		return fmt.Errorf("error copying your %s: %w\nYour settings haven't been changed, and your %s is still in %s.", component, err, component, source)
+	}
+
+	// Switch the settings to the new folder
+	if err := rp.SaveConfig(cfg); err != nil {
+		param.Value = previousValue
+		restartStopped()
+		return fmt.Errorf("error saving settings: %w\nYour %s is still in %s.", err, component, source)
+	}
+	fmt.Printf("%sYour %s is now kept in %s.%s\n", colorGreen, component, targetDir, colorReset)
+	if relocated {
+		fmt.Printf("The old copy in %s can be deleted once you've checked that everything works.\n\n", source)
+	} else if isChainData(component) {
+		fmt.Printf("The old copy in the %s Docker volume can be deleted with `docker volume rm %s` once you've checked that everything works.\n\n", source, source)
+	} else {
+		fmt.Printf("The old copy in %s can be deleted once you've checked that everything works.\n\n", source)
+	}
+
+	// Restart with the new mounts
+	return StartService(c, true)
+
+}
+
+// Get where a component is kept: its folder on the host, or the name of its Docker volume for chain data that hasn't been moved
+func getDataLocation(rp *rocketpool.Client, cfg *config.RocketPoolConfig, component config.DataComponent) (string, bool, error) {
+	if path := cfg.DataLayout.GetPath(component); path != "" {
+		return path, true, nil
+	}
+
+	prefix := cfg.Smartnode.ProjectName.Value.(string)
+	switch component {
+	case config.DataComponent_ExecutionData:
+		volume, err := rp.GetClientVolumeName(prefix+ExecutionContainerSuffix, clientDataVolumeName)
+		if err != nil {
+			return "", false, fmt.Errorf("error getting execution client volume name: %w", err)
+		}
+		return volume, false, nil
+	case config.DataComponent_ConsensusData:
+		volume, err := rp.GetClientVolumeName(prefix+BeaconContainerSuffix, clientDataVolumeName)
+		if err != nil {
+			return "", false, fmt.Errorf("error getting consensus client volume name: %w", err)
+		}
+		return volume, false, nil
+	}
+
+	dataPath, err := homedir.Expand(cfg.Smartnode.DataPath.Value.(string))
+	if err != nil {
+		return "", false, fmt.Errorf("error expanding data path: %w", err)
+	}
+	if component == config.DataComponent_RewardsTrees {
+		return filepath.Join(dataPath, config.RewardsTreesFolder), false, nil
+	}
+	return dataPath, false, nil
+}
+
+// Check if a component is a client's chain data, which lives in a Docker volume by default
+func isChainData(component config.DataComponent) bool {
+	return component == config.DataComponent_ExecutionData || component == config.DataComponent_ConsensusData
+}
+
+// Copy the files of one of the daemon's data components from the folder it's in to the new folder
+func copyDataComponent(component config.DataComponent, sourceDir string, targetDir string) error {
+	mode := os.FileMode(0755)
+	if component == config.DataComponent_Wallet {
+		mode = 0700
+	}
+	if err := os.MkdirAll(targetDir, mode); err != nil {
+		return fmt.Errorf("error creating [%s]: %w", targetDir, err)
+	}
+
+	// The rewards trees have a folder of their own; the others share the data folder, so only their own files are copied
+	var names []string
+	switch component {
+	case config.DataComponent_RewardsTrees:
+		return copyFolder(sourceDir, targetDir)
+	case config.DataComponent_Wallet:
+		names = []string{config.WalletFilename, config.PasswordFilename}
+	case config.DataComponent_Logs:
+		names = append(auditlog.GetFilenames(config.AuditLogFilename), config.CrashReportsFolder)
+	}
+	for _, name := range names {
+		source := filepath.Join(sourceDir, name)
+		info, err := os.Stat(source)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("error reading [%s]: %w", source, err)
+		}
+		if info.IsDir() {
+			err = copyFolder(source, filepath.Join(targetDir, name))
+		} else {
+			err = copyFile(source, filepath.Join(targetDir, name), info.Mode())
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Copy a folder and everything in it, keeping the file permissions
+func copyFolder(sourceDir string, targetDir string) error {
+	return filepath.Walk(sourceDir, func(path string, info os.FileInfo, err error) error {
+		if errors.Is(err, os.ErrNotExist) && path == sourceDir {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("error reading [%s]: %w", path, err)
+		}
+		relativePath, err := filepath.Rel(sourceDir, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(targetDir, relativePath)
+		if info.IsDir() {
+			if err := os.MkdirAll(target, info.Mode().Perm()); err != nil {
+				return fmt.Errorf("error creating [%s]: %w", target, err)
+			}
+			return nil
+		}
+		return copyFile(path, target, info.Mode())
+	})
+}
+
+// Copy a file, keeping its permissions
+func copyFile(source string, target string, mode os.FileMode) error {
+	sourceFile, err := os.Open(source)
+	if err != nil {
+		return fmt.Errorf("error opening [%s]: %w", source, err)
+	}
+	defer sourceFile.Close()
+	targetFile, err := os.OpenFile(target, os.O_CREATE|os.O_EXCL|os.O_WRONLY, mode.Perm())
+	if err != nil {
+		return fmt.Errorf("error creating [%s]: %w", target, err)
+	}
+	if _, err := io.Copy(targetFile, sourceFile); err != nil {
+		targetFile.Close()
+		return fmt.Errorf("error copying [%s] to [%s]: %w", source, target, err)
+	}
+	if err := targetFile.Sync(); err != nil {
+		targetFile.Close()
+		return fmt.Errorf("error writing [%s]: %w", target, err)
+	}
+	return targetFile.Close()
+}
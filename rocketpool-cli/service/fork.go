@@ -0,0 +1,89 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/utils/math"
+)
+
+// Print whether the primary Execution client is a local fork
+func forkStatus(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Get the fork status
+	response, err := rp.GetForkStatus()
+	if err != nil {
+		return err
+	}
+	if !response.IsFork {
+		fmt.Println("Your primary Execution client is not an Anvil or Hardhat fork.")
+		return nil
+	}
+	fmt.Printf("Your primary Execution client is a local %s fork of chain %d.\n", response.ForkClient, response.ChainID)
+	fmt.Printf("Its latest block is %d, at %s.\n", response.BlockNumber, time.Unix(int64(response.BlockTime), 0).UTC().Format(time.RFC1123))
+	return nil
+
+}
+
+// Move a local fork's clock forward
+func forkWarp(c *cli.Context, duration time.Duration) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Warp
+	seconds := uint64(duration.Seconds())
+	if seconds == 0 {
+		return fmt.Errorf("the duration must be at least one second")
+	}
+	response, err := rp.ForkWarp(seconds)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Moved the fork forward by %s. Block %d is now the latest, at %s.\n", duration, response.BlockNumber, time.Unix(int64(response.BlockTime), 0).UTC().Format(time.RFC1123))
+	return nil
+
+}
+
+// Mine blocks on a local fork
+func forkMine(c *cli.Context, blocks uint64) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Mine
+	response, err := rp.ForkMine(blocks)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Mined %d block(s). Block %d is now the latest.\n", blocks, response.BlockNumber)
+	return nil
+
+}
+
+// Set the ETH balance of an address on a local fork
+func forkSetBalance(c *cli.Context, address common.Address, amount float64) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Set the balance
+	if _, err := rp.ForkSetBalance(address, eth.EthToWei(amount)); err != nil {
+		return err
+	}
+	fmt.Printf("Set the balance of %s to %.6f ETH.\n", address.Hex(), math.RoundDown(amount, 6))
+	return nil
+
+}
@@ -0,0 +1,42 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Move the wallet password and API server token out of the data folder and into the configured secret store
+func migrateSecrets(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Prompt for confirmation
+	fmt.Println("This will move the node wallet's password and the API server's token out of your data folder and into the secret store you chose in `rocketpool service config`, then delete the files.")
+	fmt.Printf("%sMake sure you have a backup of your wallet password first; if you lose access to the secret store, you'll need it (or your mnemonic) to use your node wallet again.%s\n\n", colorYellow, colorReset)
+	if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to move your secrets?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Move them
+	response, err := rp.MigrateSecrets()
+	if err != nil {
+		return err
+	}
+	if len(response.Migrated) == 0 {
+		fmt.Printf("There were no secrets left in your data folder; they're already in %s.\n", response.Store)
+		return nil
+	}
+	for _, name := range response.Migrated {
+		fmt.Printf("Moved %s to %s.\n", name, response.Store)
+	}
+	fmt.Println("\nRestart the Smartnode service with `rocketpool service start` so the daemons read their secrets from the new store.")
+	return nil
+
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	netutils "github.com/rocket-pool/smartnode/shared/utils/net"
+)
+
+// How long to wait for the router to answer a UPnP search
+var gatewayDiscoveryTimeout, _ = time.ParseDuration("3s")
+
+// Check that the ports the services publish are free and that the P2P ports can be reached from the internet.
+// Returns the problems that will stop the clients from starting; reachability problems are only printed as warnings.
+func runPreflightChecks(rp *rocketpool.Client, cfg *config.RocketPoolConfig) []string {
+
+	// Ports that more than one service is configured to use
+	problems := cfg.GetPortConflicts()
+
+	// Ports that another program on this machine is already using
+	hostPorts := cfg.GetHostPorts()
+	prefix := cfg.Smartnode.ProjectName.Value.(string)
+	running := map[string]bool{}
+	for _, port := range hostPorts {
+		isRunning, checked := running[port.Container]
+		if !checked {
+			status, err := rp.GetDockerStatus(prefix + "_" + port.Container)
+			isRunning = err == nil && status == "running"
+			running[port.Container] = isRunning
+		}
+		if isRunning {
+			// The port is held by the service's own container
+			continue
+		}
+		if err := probePort(port.Port, port.Protocol); err != nil {
+			problems = append(problems, fmt.Sprintf("%s port %d/%s is already in use by another program on this machine (%s).\nStop that program (`sudo ss -tulpn | grep :%d` will show which one it is) or choose a different port with `rocketpool service config`.", port.Name, port.Port, port.Protocol, err.Error(), port.Port))
+		}
+	}
+
+	for _, warning := range checkP2pReachability(hostPorts) {
+		fmt.Printf("%sWARNING: %s%s\n\n", colorYellow, warning, colorReset)
+	}
+	return problems
+
+}
+
+// Check if a port is free by briefly listening on it
+func probePort(port uint16, protocol string) error {
+	address := fmt.Sprintf(":%d", port)
+	if protocol == "udp" {
+		conn, err := net.ListenPacket("udp", address)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	}
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
+	return listener.Close()
+}
+
+// Check if the P2P ports will be reachable from the internet, returning warnings for any that may not be
+func checkP2pReachability(hostPorts []config.HostPort) []string {
+	p2pPorts := []config.HostPort{}
+	for _, port := range hostPorts {
+		if port.IsP2p {
+			p2pPorts = append(p2pPorts, port)
+		}
+	}
+	if len(p2pPorts) == 0 {
+		return []string{}
+	}
+	portList := make([]string, len(p2pPorts))
+	for i, port := range p2pPorts {
+		portList[i] = fmt.Sprintf("%d/%s", port.Port, port.Protocol)
+	}
+
+	// If the external address belongs to this machine, there's no NAT to get through
+	externalIP, err := rocketpool.GetExternalIP()
+	if err != nil {
+		return []string{fmt.Sprintf("Couldn't get your external IP address, so P2P port reachability wasn't checked: %s", err.Error())}
+	}
+	isLocal, err := netutils.IsLocalAddress(externalIP)
+	if err != nil {
+		return []string{err.Error()}
+	}
+	if isLocal {
+		return []string{}
+	}
+
+	// Behind NAT, so check the router's port mappings
+	gateway, err := netutils.DiscoverGateway(gatewayDiscoveryTimeout)
+	if err != nil {
+		return []string{fmt.Sprintf("Couldn't search for a UPnP router, so P2P port reachability wasn't checked: %s", err.Error())}
+	}
+	if gateway == nil {
+		return []string{fmt.Sprintf("This machine is behind NAT and your router doesn't support UPnP, so its port forwarding couldn't be checked.\nMake sure your router forwards these P2P ports to this machine, or your clients will struggle to find peers: %s", strings.Join(portList, ", "))}
+	}
+	warnings := []string{}
+	for _, port := range p2pPorts {
+		mapping, err := gateway.GetPortMapping(port.Port, port.Protocol)
+		switch {
+		case err != nil:
+			warnings = append(warnings, fmt.Sprintf("Couldn't check your router's forwarding for %s port %d/%s: %s", port.Name, port.Port, port.Protocol, err.Error()))
+		case mapping == nil || !mapping.Enabled:
+			warnings = append(warnings, fmt.Sprintf("Your router doesn't forward %s port %d/%s to this machine. Add a port forwarding rule for it to %s, or your clients will struggle to find peers.", port.Name, port.Port, port.Protocol, gateway.LocalIP.String()))
+		case mapping.InternalClient != gateway.LocalIP.String():
+			warnings = append(warnings, fmt.Sprintf("Your router forwards %s port %d/%s to %s instead of this machine (%s).", port.Name, port.Port, port.Protocol, mapping.InternalClient, gateway.LocalIP.String()))
+		}
+	}
+	return warnings
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/profiling"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+// Collect a runtime profile from one of the daemons
+func collectProfile(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Get the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode.")
+	}
+	if cfg.Smartnode.EnableProfiling.Value != true {
+		fmt.Println("Profiling isn't enabled. Enable it in the Smartnode section of `rocketpool service config`, then restart the service with `rocketpool service start`.")
+		return nil
+	}
+
+	// Get the kind of profile
+	kinds := 0
+	var kind profiling.ProfileKind
+	var duration time.Duration
+	if c.IsSet("cpu") {
+		kinds++
+		kind = profiling.ProfileKind_Cpu
+		duration, err = time.ParseDuration(c.String("cpu"))
+		if err != nil {
+			return fmt.Errorf("Invalid CPU profile duration '%s': %w", c.String("cpu"), err)
+		}
+		if duration < time.Second {
+			return fmt.Errorf("The CPU profile duration must be at least 1 second.")
+		}
+	}
+	if c.Bool("heap") {
+		kinds++
+		kind = profiling.ProfileKind_Heap
+	}
+	if c.Bool("goroutine") {
+		kinds++
+		kind = profiling.ProfileKind_Goroutine
+	}
+	if kinds != 1 {
+		return fmt.Errorf("Please specify exactly one of --cpu, --heap or --goroutine.")
+	}
+
+	// Collect it
+	daemon := c.String("daemon")
+	if kind == profiling.ProfileKind_Cpu {
+		fmt.Printf("Recording a %s CPU profile of the %s daemon...\n", duration, daemon)
+	}
+	response, err := rp.CollectProfile(daemon, string(kind), uint64(duration.Seconds()))
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(cfg.Smartnode.GetProfilesPath(false), response.Filename)
+	fmt.Printf("Saved the %s profile of the %s daemon (%d bytes) to %s.\n", response.Kind, response.Daemon, response.Size, path)
+	fmt.Printf("You can explore it with %sgo tool pprof -http=localhost:8080 %s%s\n", colorGreen, path, colorReset)
+	return nil
+
+}
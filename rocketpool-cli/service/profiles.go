@@ -0,0 +1,318 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// List the saved network profiles
+func listProfiles(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Get the profiles
+	names, err := rp.GetProfiles()
+	if err != nil {
+		return err
+	}
+	active, err := rp.GetActiveProfile()
+	if err != nil {
+		return err
+	}
+	if len(names) == 0 {
+		fmt.Println("You don't have any network profiles yet. Save your current settings as one with `rocketpool service profile save <name>`.")
+		return nil
+	}
+
+	for _, name := range names {
+		profile, err := rp.LoadProfile(name)
+		if err != nil {
+			fmt.Printf("%s%s: %s%s\n", colorRed, name, err.Error(), colorReset)
+			continue
+		}
+		marker := " "
+		if name == active {
+			marker = "*"
+		}
+		fmt.Printf("%s %s%s%s\n", marker, colorGreen, name, colorReset)
+		fmt.Printf("    Network:      %v\n", profile.Smartnode.Network.Value)
+		fmt.Printf("    Project name: %s\n", profile.Smartnode.ProjectName.Value)
+		fmt.Printf("    Data path:    %s\n", profile.Smartnode.DataPath.Value)
+	}
+	if active != "" {
+		fmt.Println("\n* = the profile in use")
+	}
+	return nil
+
+}
+
+// Save the current settings as a network profile
+func saveProfile(c *cli.Context, name string) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Load the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode first.")
+	}
+
+	// Don't overwrite a different profile by accident
+	active, err := rp.GetActiveProfile()
+	if err != nil {
+		return err
+	}
+	existing, err := rp.LoadProfile(name)
+	if err != nil {
+		return err
+	}
+	if existing != nil && name != active && !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("There is already a profile named [%s]. Would you like to replace it with your current settings?", name))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Save it
+	if err := rp.SaveProfile(name, cfg); err != nil {
+		return err
+	}
+	if err := rp.SetActiveProfile(name); err != nil {
+		return err
+	}
+	fmt.Printf("Saved your current settings as the [%s] profile.\n", name)
+	return nil
+
+}
+
+// Create a network profile for a different network based on the current settings
+func createProfile(c *cli.Context, name string, network string) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Load the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode first.")
+	}
+	if err := rocketpool.ValidateProfileName(name); err != nil {
+		return err
+	}
+	existing, err := rp.LoadProfile(name)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return fmt.Errorf("There is already a profile named [%s].", name)
+	}
+
+	// Get the network
+	var newNetwork cfgtypes.Network
+	networkNames := []string{}
+	for _, option := range cfg.Smartnode.Network.Options {
+		optionNetwork := option.Value.(cfgtypes.Network)
+		networkNames = append(networkNames, string(optionNetwork))
+		if string(optionNetwork) == network {
+			newNetwork = optionNetwork
+		}
+	}
+	if newNetwork == cfgtypes.Network_Unknown {
+		return fmt.Errorf("Unknown network [%s]; the available networks are %s.", network, strings.Join(networkNames, ", "))
+	}
+
+	// Start from the current settings on the new network, with its own containers, chain data and data folder
+	profile := cfg.CreateCopy()
+	profile.ChangeNetwork(newNetwork)
+	profile.Smartnode.ProjectName.Value = fmt.Sprintf("%s-%s", cfg.Smartnode.ProjectName.Value, name)
+	profile.Smartnode.DataPath.Value = fmt.Sprintf("%s-%s", strings.TrimRight(cfg.Smartnode.DataPath.Value.(string), "/"), name)
+	profile.ConsensusCommon.CheckpointSyncProvider.Value = ""
+	if err := rp.SaveProfile(name, profile); err != nil {
+		return err
+	}
+
+	fmt.Printf("Created the [%s] profile for %s.\n", name, newNetwork)
+	fmt.Printf("It will use the Docker project name [%s] and the data folder %s, so its chain data, wallet and validator keys are kept separate from your other profiles.\n", profile.Smartnode.ProjectName.Value, profile.Smartnode.DataPath.Value)
+	fmt.Printf("If you used a Checkpoint Sync URL, it has been cleared because it won't work on the new network. Switch to the profile with `rocketpool service switch-network %s`, then review its settings with `rocketpool service config`.\n", name)
+	return nil
+
+}
+
+// Delete a network profile
+func deleteProfile(c *cli.Context, name string) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	active, err := rp.GetActiveProfile()
+	if err != nil {
+		return err
+	}
+	if name == active {
+		return fmt.Errorf("[%s] is the profile in use, so it can't be deleted. Switch to a different one first.", name)
+	}
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to delete the [%s] profile? Its data folder and chain data will not be removed.", name))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+	if err := rp.DeleteProfile(name); err != nil {
+		return err
+	}
+	fmt.Printf("Deleted the [%s] profile.\n", name)
+	return nil
+
+}
+
+// Switch to a different network profile, swapping the settings, data folder and containers
+func switchNetwork(c *cli.Context, name string) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Load the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode first.")
+	}
+	if cfg.IsNativeMode {
+		return fmt.Errorf("Network switching isn't available in Native Mode; please reconfigure and restart your services manually.")
+	}
+
+	// Get the profiles
+	active, err := rp.GetActiveProfile()
+	if err != nil {
+		return err
+	}
+	if name == active {
+		fmt.Printf("You're already using the [%s] profile.\n", name)
+		return nil
+	}
+	target, err := rp.LoadProfile(name)
+	if err != nil {
+		return err
+	}
+	if target == nil {
+		return fmt.Errorf("There is no profile named [%s]. Create one with `rocketpool service profile create %s <network>`, or see your profiles with `rocketpool service profile list`.", name, name)
+	}
+
+	// The current settings have to be saved as a profile so they can be switched back to
+	if active == "" {
+		active = string(cfg.Smartnode.Network.Value.(cfgtypes.Network))
+		existing, err := rp.LoadProfile(active)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			return fmt.Errorf("Your current settings haven't been saved as a profile, and there is already a profile named [%s]. Please save them with `rocketpool service profile save <name>` first.", active)
+		}
+	}
+
+	// Sharing a data folder or Docker project between networks would mix up wallets, keys and chain data
+	if target.Smartnode.DataPath.Value == cfg.Smartnode.DataPath.Value {
+		return fmt.Errorf("The [%s] profile uses the same data folder as your current settings (%s). Each profile needs its own data folder so its wallet and validator keys are kept separate.", name, cfg.Smartnode.DataPath.Value)
+	}
+	if target.Smartnode.ProjectName.Value == cfg.Smartnode.ProjectName.Value {
+		return fmt.Errorf("The [%s] profile uses the same Docker project name as your current settings (%s). Each profile needs its own project name so its containers and chain data are kept separate.", name, cfg.Smartnode.ProjectName.Value)
+	}
+
+	// Never leave validators offline
+	fmt.Println("Checking for active validators...")
+	if err := checkNoActiveValidators(rp); err != nil {
+		return err
+	}
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Your Smartnode will be stopped and restarted on %v using the [%s] profile. Your current settings will be saved as the [%s] profile. Are you sure you want to continue?", target.Smartnode.Network.Value, name, active))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Save the current settings
+	if err := rp.SaveProfile(active, cfg); err != nil {
+		return err
+	}
+	if err := rp.SetActiveProfile(active); err != nil {
+		return err
+	}
+
+	// Stop the current containers; they're kept so switching back doesn't have to recreate them
+	fmt.Print("Stopping containers... ")
+	if err := rp.PauseService(getComposeFiles(c)); err != nil {
+		return fmt.Errorf("error stopping service: %w", err)
+	}
+	fmt.Println("done")
+
+	// Make sure the new data folder exists
+	dataPath, err := homedir.Expand(target.Smartnode.DataPath.Value.(string))
+	if err != nil {
+		return fmt.Errorf("error expanding data directory: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Join(dataPath, "validators"), 0775); err != nil {
+		return fmt.Errorf("error creating data folder: %w", err)
+	}
+
+	// Swap the settings
+	if err := rp.SaveConfig(target); err != nil {
+		return fmt.Errorf("error saving the [%s] profile's settings: %w", name, err)
+	}
+	if err := rp.SetActiveProfile(name); err != nil {
+		return err
+	}
+	fmt.Printf("Switched to the [%s] profile.\n\n", name)
+
+	// Start the new containers
+	if err := StartService(c, true); err != nil {
+		return fmt.Errorf("%w\nYour settings have been switched to the [%s] profile, but the Smartnode couldn't be started. Please fix the problem and run `rocketpool service start`, or switch back with `rocketpool service switch-network %s`.", err, name, active)
+	}
+	return nil
+
+}
+
+// Check that none of the node's validators are active, so they won't miss duties while the Smartnode is on another network
+func checkNoActiveValidators(rp *rocketpool.Client) error {
+	wallet, err := rp.WalletStatus()
+	if err != nil {
+		return fmt.Errorf("error checking the node wallet: %w", err)
+	}
+	if !wallet.WalletInitialized {
+		return nil
+	}
+
+	status, err := rp.MinipoolStatus()
+	if err != nil {
+		return fmt.Errorf("couldn't check whether your validators are active, so the network wasn't switched: %w", err)
+	}
+	active := []string{}
+	for _, minipool := range status.Minipools {
+		if minipool.Validator.Active {
+			active = append(active, minipool.Address.Hex())
+		}
+	}
+	if len(active) > 0 {
+		return fmt.Errorf("You have %d active validators (%s). They would stop attesting and proposing while the Smartnode is on another network, so the network wasn't switched.\nPlease exit them first, or keep this network on its own machine.", len(active), strings.Join(active, ", "))
+	}
+	return nil
+}
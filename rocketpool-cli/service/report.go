@@ -0,0 +1,215 @@
+package service
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/report"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// The services whose logs are included in a report
+var reportLogServices = []cfgtypes.ContainerID{
+	cfgtypes.ContainerID_Node,
+	cfgtypes.ContainerID_Watchtower,
+	cfgtypes.ContainerID_Eth1,
+	cfgtypes.ContainerID_Eth2,
+	cfgtypes.ContainerID_Validator,
+	cfgtypes.ContainerID_MevBoost,
+}
+
+// The services whose logs are searched for failed transactions
+var reportTransactionServices = []cfgtypes.ContainerID{
+	cfgtypes.ContainerID_Node,
+	cfgtypes.ContainerID_Watchtower,
+}
+
+// Collect the settings, logs and status of the Smartnode into an archive that can be attached to a support request
+func createReport(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Load the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode first.")
+	}
+
+	// Get the report path
+	outputPath := c.String("output")
+	if outputPath == "" {
+		outputPath = fmt.Sprintf("%s%s%s", report.FilePrefix, time.Now().Format("20060102-150405"), report.FileExtension)
+	}
+	outputPath, err = homedir.Expand(outputPath)
+	if err != nil {
+		return fmt.Errorf("error expanding report path: %w", err)
+	}
+	outputPath, err = filepath.Abs(outputPath)
+	if err != nil {
+		return fmt.Errorf("error getting the full report path: %w", err)
+	}
+	lines := c.Uint64("lines")
+	if lines == 0 {
+		return fmt.Errorf("The number of log lines must be at least 1.")
+	}
+
+	// Secrets found in the settings are redacted from everything else too, so the settings go first
+	redactor := report.NewRedactor()
+	settings := redactor.SanitizeSettings(cfg.Serialize())
+	files := map[string][]byte{}
+	problems := []string{}
+	addJson := func(name string, value interface{}, err error) {
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %s", name, err.Error()))
+			return
+		}
+		bytes, err := json.MarshalIndent(value, "", "  ")
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: error serializing: %s", name, err.Error()))
+			return
+		}
+		files[name] = bytes
+	}
+	addJson("settings.json", settings, nil)
+
+	// Versions and the environment
+	fmt.Println("Collecting versions and status...")
+	var summary strings.Builder
+	fmt.Fprintf(&summary, "Report created: %s\n", time.Now().UTC().Format(time.RFC1123))
+	fmt.Fprintf(&summary, "Rocket Pool client version: v%s\n", shared.RocketPoolVersion)
+	serviceVersion, err := rp.GetServiceVersion()
+	if err != nil {
+		problems = append(problems, fmt.Sprintf("service version: %s", err.Error()))
+	} else {
+		fmt.Fprintf(&summary, "Rocket Pool service version: %s\n", serviceVersion)
+	}
+	fmt.Fprintf(&summary, "Operating system: %s/%s (%d CPUs)\n", runtime.GOOS, runtime.GOARCH, runtime.NumCPU())
+	fmt.Fprintf(&summary, "Network: %v\n", cfg.Smartnode.Network.Value)
+	ecDescription, ccDescription := cfg.GetClientModeDescriptions()
+	fmt.Fprintf(&summary, "Execution client: %s\n", ecDescription)
+	fmt.Fprintf(&summary, "Consensus client: %s\n", ccDescription)
+	fmt.Fprintf(&summary, "Orchestrator: %v\n", cfg.Smartnode.Orchestrator.Value)
+	if !cfg.IsNativeMode {
+		prefix := cfg.Smartnode.ProjectName.Value.(string)
+		summary.WriteString("\nContainer images:\n")
+		for _, service := range reportLogServices {
+			container := fmt.Sprintf("%s_%s", prefix, service)
+			image, err := rp.GetDockerImage(container)
+			if err != nil {
+				continue
+			}
+			status, _ := rp.GetDockerStatus(container)
+			fmt.Fprintf(&summary, "\t%s: %s (%s)\n", container, image, status)
+		}
+	}
+	files["summary.txt"] = []byte(summary.String())
+
+	// The node daemon's view of the node; these fail if the Smartnode isn't running, which is worth knowing too
+	sync, err := rp.NodeSync()
+	addJson("sync-status.json", sync, err)
+	systemStatus, err := rp.GetSystemStatus()
+	addJson("system-status.json", systemStatus, err)
+	taskStatus, err := rp.GetTaskStatus()
+	addJson("task-status.json", taskStatus, err)
+	rpcUsage, err := rp.GetRpcUsage()
+	addJson("rpc-usage.json", rpcUsage, err)
+	pending, err := rp.NodePendingTransactions()
+	addJson("pending-transactions.json", pending, err)
+	alerts, err := rp.NodeRecentAlerts(uint64(alerting.DefaultHistorySize))
+	addJson("recent-alerts.json", alerts, err)
+
+	// Logs
+	if cfg.IsNativeMode {
+		problems = append(problems, "logs: not collected in Native mode")
+	} else {
+		fmt.Printf("Collecting the last %d lines of each service's logs...\n", lines)
+		failedTransactions := []string{}
+		for _, service := range reportLogServices {
+			logs, err := rp.GetServiceLogs(getComposeFiles(c), fmt.Sprint(lines), string(service))
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("%s logs: %s", service, err.Error()))
+				continue
+			}
+			files[fmt.Sprintf("logs/%s.log", service)] = logs
+			for _, txService := range reportTransactionServices {
+				if service == txService {
+					failedTransactions = append(failedTransactions, findFailedTransactions(string(logs))...)
+				}
+			}
+		}
+		if len(failedTransactions) > 0 {
+			files["failed-transactions.log"] = []byte(strings.Join(failedTransactions, "\n") + "\n")
+		}
+	}
+
+	if len(problems) > 0 {
+		files["problems.txt"] = []byte(strings.Join(problems, "\n") + "\n")
+	}
+
+	// Write the archive
+	archive, err := report.NewArchive(outputPath)
+	if err != nil {
+		return err
+	}
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := archive.AddFile(name, []byte(redactor.Redact(string(files[name])))); err != nil {
+			archive.Close()
+			os.Remove(outputPath)
+			return err
+		}
+	}
+	if err := archive.Close(); err != nil {
+		os.Remove(outputPath)
+		return err
+	}
+
+	fmt.Printf("\n%sCreated a report with %d files:%s\n%s\n\n", colorGreen, len(files), colorReset, outputPath)
+	if len(problems) > 0 {
+		fmt.Printf("%sSome information couldn't be collected; see problems.txt in the report for details.%s\n", colorYellow, colorReset)
+	}
+	fmt.Println("Passwords, API keys and tokens in your settings have been removed, and URLs have been shortened to their hosts.")
+	fmt.Println("The report still contains your node address and your clients' logs, so please look through it before sharing it.")
+	return nil
+
+}
+
+// Get the log lines that mention a transaction failing
+func findFailedTransactions(logs string) []string {
+	failed := []string{}
+	scanner := bufio.NewScanner(strings.NewReader(logs))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		lower := strings.ToLower(line)
+		if !strings.Contains(lower, "transaction") {
+			continue
+		}
+		if strings.Contains(lower, "fail") || strings.Contains(lower, "error") || strings.Contains(lower, "revert") {
+			failed = append(failed, line)
+		}
+	}
+	return failed
+}
@@ -0,0 +1,150 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// How often the sync progress is checked while monitoring a resync
+const resyncMonitorInterval time.Duration = 30 * time.Second
+
+// Get the folder to save a copy of a client's chain data to before it's deleted, or an empty string to skip the copy
+func getResyncSnapshotDir(c *cli.Context, clientName string) (string, error) {
+	snapshotDir := c.String("snapshot")
+	if snapshotDir == "" && !c.Bool("yes") {
+		if cliutils.Confirm(fmt.Sprintf("Would you like to save a copy of your %s chain data to another folder (such as a portable drive) before it's deleted?", clientName)) {
+			snapshotDir = cliutils.Prompt("Please enter the folder to save the copy in:", "^.+$", "Please enter a folder.")
+		}
+	}
+	if snapshotDir == "" {
+		return "", nil
+	}
+
+	snapshotDir, err := filepath.Abs(snapshotDir)
+	if err != nil {
+		return "", fmt.Errorf("Error converting to absolute path: %w", err)
+	}
+	info, err := os.Stat(snapshotDir)
+	if os.IsNotExist(err) {
+		return "", fmt.Errorf("Snapshot directory [%s] does not exist.", snapshotDir)
+	} else if err != nil {
+		return "", fmt.Errorf("Error reading snapshot dir: %w", err)
+	}
+	if !info.IsDir() {
+		return "", fmt.Errorf("Snapshot directory [%s] is not a directory.", snapshotDir)
+	}
+	return snapshotDir, nil
+}
+
+// Copy a stopped client's chain data volume to a folder before it's deleted
+func snapshotChainData(rp *rocketpool.Client, cfg *config.RocketPoolConfig, prefix string, volume string, snapshotDir string) error {
+
+	// Make sure the copy will fit; the chain data is gone once the volume is deleted, so this isn't skippable
+	volumeBytes, err := getVolumeSpaceUsed(rp, volume)
+	if err != nil {
+		return fmt.Errorf("Error getting the size of volume %s: %w", volume, err)
+	}
+	targetFree, err := getPartitionFreeSpace(rp, snapshotDir)
+	if err != nil {
+		return fmt.Errorf("Error getting the free space available in %s: %w", snapshotDir, err)
+	}
+	fmt.Printf("%sChain data size:            %s%s\n", colorLightBlue, humanize.IBytes(volumeBytes), colorReset)
+	fmt.Printf("%sSnapshot folder free space: %s%s\n", colorLightBlue, humanize.IBytes(targetFree), colorReset)
+	if targetFree < volumeBytes {
+		return fmt.Errorf("The snapshot folder does not have enough space to hold the chain data. Please free up more space or choose a different folder.")
+	}
+
+	fmt.Printf("Copying data from volume %s to %s...\n", volume, snapshotDir)
+	fmt.Printf("%sNOTE: This process *will not stop* until the copy is complete - even if you exit the command with Ctrl+C.%s\n", colorYellow, colorReset)
+	err = rp.RunEcMigrator(prefix+EcMigratorContainerSuffix, volume, snapshotDir, "export", cfg.Smartnode.GetEcMigratorContainerTag())
+	if err != nil {
+		return fmt.Errorf("Error copying the chain data: %w", err)
+	}
+	fmt.Println("The chain data was copied successfully.")
+	return nil
+
+}
+
+// Make sure a checkpoint sync provider is set before the consensus client is rebuilt, so it syncs instantly
+func enableCheckpointSync(c *cli.Context, rp *rocketpool.Client, cfg *config.RocketPoolConfig) error {
+	checkpointSyncUrl := cfg.ConsensusCommon.CheckpointSyncProvider.Value.(string)
+	if checkpointSyncUrl != "" {
+		fmt.Printf("You have a checkpoint sync provider configured (%s).\nYour consensus client will use it to sync to the head of the Beacon Chain instantly after being rebuilt.\n\n", checkpointSyncUrl)
+		return nil
+	}
+
+	checkpointSyncUrl = c.String("checkpoint-sync-url")
+	if checkpointSyncUrl == "" && !c.Bool("yes") {
+		fmt.Printf("%sYou do not have a checkpoint sync provider configured.\nIf you have active validators, they %swill be considered offline and will lose ETH%s%s until your consensus client finishes syncing.%s\n", colorRed, colorBold, colorReset, colorRed, colorReset)
+		fmt.Println("A checkpoint sync provider lets your consensus client sync to the head of the Beacon Chain in a few minutes. See https://eth-clients.github.io/checkpoint-sync-endpoints/ for a list of public providers.")
+		checkpointSyncUrl = cliutils.Prompt("Please enter a checkpoint sync provider URL, or leave it blank to sync from scratch:", "^(https?://.+)?$", "Please enter a URL starting with http:// or https://, or leave it blank.")
+	}
+	if checkpointSyncUrl == "" {
+		fmt.Printf("%sYour consensus client will sync from scratch, which can take several days.%s\n\n", colorYellow, colorReset)
+		return nil
+	}
+
+	cfg.ConsensusCommon.CheckpointSyncProvider.Value = checkpointSyncUrl
+	if err := rp.SaveConfig(cfg); err != nil {
+		return fmt.Errorf("Error saving the checkpoint sync provider: %w", err)
+	}
+	fmt.Printf("Your consensus client will use %s to sync after being rebuilt.\n\n", checkpointSyncUrl)
+	return nil
+}
+
+// Follow a resyncing client's progress until it's synced, estimating how long is left
+func monitorResync(c *cli.Context, rp *rocketpool.Client, name string, getStatus func(api.NodeSyncProgressResponse) api.ClientStatus) error {
+	if c.Bool("no-monitor") {
+		return nil
+	}
+
+	fmt.Printf("\nMonitoring the %s's sync progress. You can press Ctrl+C at any time; the client will keep syncing.\n", name)
+	var startProgress float64
+	var startTime time.Time
+	for {
+		response, err := rp.NodeSync()
+		if err != nil {
+			// The API may not be ready yet right after a restart
+			fmt.Printf("\r%sWaiting for the Smartnode to respond...%s", colorYellow, colorReset)
+			time.Sleep(resyncMonitorInterval)
+			continue
+		}
+		status := getStatus(response)
+
+		switch {
+		case status.IsSynced:
+			fmt.Printf("\r%sYour %s is fully synced!%s                                        \n", colorGreen, name, colorReset)
+			return nil
+
+		case status.Error != "":
+			fmt.Printf("\r%sYour %s is unavailable (%s).%s", colorYellow, name, status.Error, colorReset)
+
+		default:
+			// The estimate is based on the progress made since monitoring started
+			now := time.Now()
+			if startTime.IsZero() {
+				startProgress = status.SyncProgress
+				startTime = now
+			}
+			eta := "calculating..."
+			progressMade := status.SyncProgress - startProgress
+			if progressMade > 0 {
+				rate := progressMade / now.Sub(startTime).Seconds()
+				remaining := time.Duration((1-status.SyncProgress)/rate) * time.Second
+				eta = fmt.Sprintf("about %s remaining", remaining.Round(time.Minute))
+			}
+			fmt.Printf("\rYour %s is syncing: %0.2f%% (%s)          ", name, rocketpool.SyncRatioToPercent(status.SyncProgress), eta)
+		}
+		time.Sleep(resyncMonitorInterval)
+	}
+}
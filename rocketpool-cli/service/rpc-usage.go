@@ -0,0 +1,94 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/services/rpcusage"
+)
+
+// View the RPC requests each daemon task has made to the clients, so the tasks using up a metered provider's quota can be found
+func getRpcUsage(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Get the RPC usage
+	response, err := rp.GetRpcUsage()
+	if err != nil {
+		return err
+	}
+	if len(response.Daemons) == 0 {
+		fmt.Println("The node and watchtower daemons haven't recorded any RPC usage yet. It's saved after each run of their task loops.")
+		return nil
+	}
+
+	endpointFilter := c.String("endpoint")
+	for _, daemon := range response.Daemons {
+		elapsed := daemon.Updated.Sub(daemon.Since)
+		fmt.Printf("%s=== %s (since %s, updated %s) ===%s\n", colorGreen, daemon.Daemon, daemon.Since.Local().Format(time.RFC1123), daemon.Updated.Local().Format(time.RFC1123), colorReset)
+
+		// Group the usage by endpoint
+		byEndpoint := map[string][]rpcusage.Usage{}
+		endpoints := []string{}
+		for _, usage := range daemon.Usage {
+			if endpointFilter != "" && usage.Endpoint != endpointFilter {
+				continue
+			}
+			if _, exists := byEndpoint[usage.Endpoint]; !exists {
+				endpoints = append(endpoints, usage.Endpoint)
+			}
+			byEndpoint[usage.Endpoint] = append(byEndpoint[usage.Endpoint], usage)
+		}
+		if len(endpoints) == 0 {
+			fmt.Println("No RPC usage recorded.")
+			fmt.Println()
+			continue
+		}
+		sort.Strings(endpoints)
+
+		for _, endpoint := range endpoints {
+			usages := byEndpoint[endpoint]
+			var total rpcusage.Usage
+			for _, usage := range usages {
+				total.Requests += usage.Requests
+				total.Errors += usage.Errors
+				total.RequestBytes += usage.RequestBytes
+				total.ResponseBytes += usage.ResponseBytes
+			}
+			fmt.Printf("%s%s%s: %d requests (%s per hour), %s sent, %s received, %d failed\n", colorYellow, endpoint, colorReset, total.Requests, formatHourlyRate(total.Requests, elapsed), humanize.IBytes(total.RequestBytes), humanize.IBytes(total.ResponseBytes), total.Errors)
+
+			// Show the heaviest tasks first
+			sort.SliceStable(usages, func(i, j int) bool {
+				return usages[i].Requests > usages[j].Requests
+			})
+			for _, usage := range usages {
+				share := float64(usage.Requests) / float64(total.Requests) * 100
+				fmt.Printf("\t%-30s %8d requests (%5.1f%%)  %10s sent  %10s received", usage.Task, usage.Requests, share, humanize.IBytes(usage.RequestBytes), humanize.IBytes(usage.ResponseBytes))
+				if usage.Errors > 0 {
+					fmt.Printf("  %s%d failed%s", colorRed, usage.Errors, colorReset)
+				}
+				fmt.Println()
+			}
+		}
+		fmt.Println()
+	}
+
+	fmt.Printf("Requests are counted per HTTP request, so a JSON-RPC batch counts once; requests made outside of a task (such as for CLI commands) are listed as \"%s\".\n", rpcusage.OtherTask)
+	return nil
+
+}
+
+// Format a count as a rate per hour over the provided time
+func formatHourlyRate(count uint64, elapsed time.Duration) string {
+	if elapsed < time.Minute {
+		return "n/a"
+	}
+	return fmt.Sprintf("%.0f", float64(count)/elapsed.Hours())
+}
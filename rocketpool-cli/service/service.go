@@ -6,6 +6,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 
@@ -20,6 +21,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
 	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 	"github.com/rocket-pool/smartnode/shared/utils/sys"
@@ -52,7 +54,7 @@ const (
 )
 
 // Install the Rocket Pool service
-func installService(c *cli.Context) error {
+func InstallService(c *cli.Context) error {
 	dataPath := ""
 
 	if c.String("network") != "" {
@@ -86,7 +88,7 @@ func installService(c *cli.Context) error {
 	}
 
 	// Install service
-	err = rp.InstallService(c.Bool("verbose"), c.Bool("no-deps"), c.String("network"), c.String("version"), c.String("path"), dataPath)
+	err = rp.InstallService(c.Bool("verbose"), c.Bool("no-deps"), c.String("network"), c.String("version"), c.String("path"), dataPath, c.String("installer"))
 	if err != nil {
 		return err
 	}
@@ -98,11 +100,20 @@ func installService(c *cli.Context) error {
 	printPatchNotes(c)
 
 	// Reload the config after installation
-	_, isNew, err = rp.LoadConfig()
+	newCfg, isNew, err := rp.LoadConfig()
 	if err != nil {
 		return fmt.Errorf("error loading new configuration: %w", err)
 	}
 
+	// Update the provisioned Grafana dashboards and alert rules
+	if !isNew && newCfg.EnableMetrics.Value.(bool) {
+		err = rp.UpdateGrafanaProvisioning(newCfg)
+		if err != nil {
+			return err
+		}
+		fmt.Println("Updated the provisioned Grafana dashboards and alert rules.")
+	}
+
 	// Report next steps
 	fmt.Printf("%s\n=== Next Steps ===\n", colorLightBlue)
 	fmt.Printf("Run 'rocketpool service config' to review the settings changes for this update, or to continue setting up your node.%s\n", colorReset)
@@ -189,13 +200,41 @@ func serviceStatus(c *cli.Context) error {
 		return err
 	}
 
+	// Print how each client is managed
+	cfg, isNew, err := rp.LoadConfig()
+	if err == nil && !isNew {
+		ecDescription, ccDescription := cfg.GetClientModeDescriptions()
+		fmt.Printf("Execution client: %s\n", ecDescription)
+		fmt.Printf("Consensus client: %s\n\n", ccDescription)
+	}
+
 	// Print service status
-	return rp.PrintServiceStatus(getComposeFiles(c))
+	err = rp.PrintServiceStatus(getComposeFiles(c))
+	if err != nil {
+		return err
+	}
+
+	// Print any resource warnings from the node daemon; this is best-effort since the API container may not be running
+	systemStatus, err := rp.GetSystemStatus()
+	if err == nil {
+		printSystemWarnings(systemStatus)
+	}
+
+	// Print any new releases found by the node daemon
+	updateStatus, err := rp.GetUpdateStatus()
+	if err == nil && updateStatus.UpdateStatus != nil {
+		for _, release := range updateStatus.UpdateStatus.GetAvailableUpdates(time.Now()) {
+			if release.Verified {
+				fmt.Printf("%sUpdate available: %s %s (you have %s). Run `rocketpool service update` to install it.%s\n", colorYellow, release.Name, release.LatestVersion, release.CurrentVersion, colorReset)
+			}
+		}
+	}
+	return nil
 
 }
 
 // Configure the service
-func configureService(c *cli.Context) error {
+func ConfigureService(c *cli.Context) error {
 
 	// Make sure the config directory exists first
 	configPath := c.GlobalString("config-path")
@@ -213,6 +252,11 @@ func configureService(c *cli.Context) error {
 	rp := rocketpool.NewClientFromCtx(c)
 	defer rp.Close()
 
+	// Roll back to the settings from before the last upgrade if requested
+	if c.Bool("rollback") {
+		return rollbackConfig(rp, c.Bool("yes"))
+	}
+
 	// Load the config, checking to see if it's new (hasn't been installed before)
 	var oldCfg *config.RocketPoolConfig
 	cfg, isNew, err := rp.LoadConfig()
@@ -228,6 +272,10 @@ func configureService(c *cli.Context) error {
 
 	// For upgrades, move the config to the old one and create a new upgraded copy
 	if isUpdate {
+		err = rp.BackupConfig()
+		if err != nil {
+			return fmt.Errorf("error backing up settings before the upgrade: %w", err)
+		}
 		oldCfg = cfg
 		cfg = cfg.CreateCopy()
 		err = cfg.UpdateDefaults()
@@ -236,6 +284,19 @@ func configureService(c *cli.Context) error {
 		}
 	}
 
+	// Check or compare the current settings without changing anything
+	if c.Bool("check") {
+		return checkConfig(rp, cfg, isNew)
+	}
+	if c.Bool("diff") {
+		return diffConfig(rp, cfg, isNew)
+	}
+
+	// Apply a declarative config document if one was provided
+	if c.String("file") != "" {
+		return configureFromFile(c, rp, cfg, isNew)
+	}
+
 	// Save the config and exit in headless mode
 	if c.NumFlags() > 0 {
 		err := configureHeadless(c, cfg)
@@ -300,7 +361,7 @@ func configureService(c *cli.Context) error {
 				fmt.Println("Please run `rocketpool service start` when you are ready to launch.")
 				return nil
 			}
-			return startService(c, true)
+			return StartService(c, true)
 		}
 
 		// Query for service start if this is old and there are containers to change
@@ -324,7 +385,7 @@ func configureService(c *cli.Context) error {
 
 			fmt.Println()
 			fmt.Println("Applying changes and restarting containers...")
-			return startService(c, true)
+			return StartService(c, true)
 		}
 	} else {
 		fmt.Println("Your changes have not been saved. Your Smartnode configuration is the same as it was before.")
@@ -334,6 +395,66 @@ func configureService(c *cli.Context) error {
 	return err
 }
 
+// Updates a configuration from a declarative config document instead of the interactive UI
+func configureFromFile(c *cli.Context, rp *rocketpool.Client, cfg *config.RocketPoolConfig, isNew bool) error {
+
+	// Read the document
+	path := c.String("file")
+	document, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("error reading config document [%s]: %w", path, err)
+	}
+
+	// Apply it to a copy of the config so the changes can be reviewed
+	newCfg := cfg.CreateCopy()
+	err = newCfg.ApplyDeclarativeConfig(document)
+	if err != nil {
+		return err
+	}
+	changedSettings, affectedContainers, changeNetworks := newCfg.GetChanges(cfg)
+	if changeNetworks && !isNew {
+		return fmt.Errorf("the config document changes the network from %v to %v; changing networks deletes your chain data, node wallet and validator keys, so please do it with the interactive `rocketpool service config` instead", cfg.Smartnode.Network.Value, newCfg.Smartnode.Network.Value)
+	}
+
+	// Print the changes
+	totalChanges := 0
+	sectionNames := make([]string, 0, len(changedSettings))
+	for sectionName, settings := range changedSettings {
+		if len(settings) > 0 {
+			sectionNames = append(sectionNames, sectionName)
+			totalChanges += len(settings)
+		}
+	}
+	if totalChanges == 0 && !isNew {
+		fmt.Println("Your Smartnode configuration already matches the config document; nothing to change.")
+		return nil
+	}
+	sort.Strings(sectionNames)
+	for _, sectionName := range sectionNames {
+		fmt.Printf("%s%s%s\n", colorGreen, sectionName, colorReset)
+		for _, setting := range changedSettings[sectionName] {
+			fmt.Printf("\t%s: %s => %s\n", setting.Name, setting.OldValue, setting.NewValue)
+		}
+	}
+
+	// Save the config
+	err = rp.SaveConfig(newCfg)
+	if err != nil {
+		return fmt.Errorf("error saving config: %w", err)
+	}
+	fmt.Printf("Applied %d changes from %s.\n", totalChanges, path)
+	if len(affectedContainers) > 0 && !isNew {
+		prefix := fmt.Sprint(newCfg.Smartnode.ProjectName.Value)
+		fmt.Println("The following containers must be restarted for the changes to take effect:")
+		for container := range affectedContainers {
+			fmt.Printf("\t%s_%s\n", prefix, container)
+		}
+	}
+	fmt.Println("Please run `rocketpool service start` to apply the changes.")
+	return nil
+
+}
+
 // Updates a configuration from the provided CLI arguments headlessly
 func configureHeadless(c *cli.Context, cfg *config.RocketPoolConfig) error {
 
@@ -473,7 +594,7 @@ func changeNetworks(c *cli.Context, rp *rocketpool.Client, apiContainerName stri
 }
 
 // Start the Rocket Pool service
-func startService(c *cli.Context, ignoreConfigSuggestion bool) error {
+func StartService(c *cli.Context, ignoreConfigSuggestion bool) error {
 
 	// Get RP client
 	rp := rocketpool.NewClientFromCtx(c)
@@ -498,11 +619,13 @@ func startService(c *cli.Context, ignoreConfigSuggestion bool) error {
 		}
 	}
 
-	// Force all Docker or all Hybrid
-	if cfg.ExecutionClientMode.Value.(cfgtypes.Mode) == cfgtypes.Mode_Local && cfg.ConsensusClientMode.Value.(cfgtypes.Mode) == cfgtypes.Mode_External {
-		fmt.Printf("%sYou are using a locally-managed Execution client and an externally-managed Consensus client.\nThis configuration is not compatible with The Merge; please select either locally-managed or externally-managed for both the EC and CC.%s\n", colorRed, colorReset)
-	} else if cfg.ExecutionClientMode.Value.(cfgtypes.Mode) == cfgtypes.Mode_External && cfg.ConsensusClientMode.Value.(cfgtypes.Mode) == cfgtypes.Mode_Local {
-		fmt.Printf("%sYou are using an externally-managed Execution client and a locally-managed Consensus client.\nThis configuration is not compatible with The Merge; please select either locally-managed or externally-managed for both the EC and CC.%s\n", colorRed, colorReset)
+	// Tell the user how to connect an external Consensus client to the local Execution client
+	if cfg.IsEngineApiPublished() {
+		jwtSecretPath, err := homedir.Expand(cfg.GetJwtSecretPath())
+		if err != nil {
+			jwtSecretPath = cfg.GetJwtSecretPath()
+		}
+		fmt.Printf("%sNOTE: Your Execution client is locally managed but your Consensus client isn't, so its Engine API will be published on port %d.\nConfigure your external Consensus client to use http://<this machine's IP address>:%d as its Execution endpoint, with a copy of the JWT secret in %s.\nMake sure your firewall only lets your Consensus client's machine reach this port.%s\n\n", colorYellow, cfg.ExecutionCommon.EnginePort.Value, cfg.ExecutionCommon.EnginePort.Value, jwtSecretPath, colorReset)
 	}
 
 	if isNew {
@@ -516,6 +639,10 @@ func startService(c *cli.Context, ignoreConfigSuggestion bool) error {
 	}
 	if isUpdate && !ignoreConfigSuggestion {
 		if c.Bool("yes") || cliutils.Confirm("Smartnode upgrade detected - starting will overwrite certain settings with the latest defaults (such as container versions).\nYou may want to run `service config` first to see what's changed.\n\nWould you like to continue starting the service?") {
+			err = rp.BackupConfig()
+			if err != nil {
+				return fmt.Errorf("error backing up configuration: %w", err)
+			}
 			err = cfg.UpdateDefaults()
 			if err != nil {
 				return fmt.Errorf("error upgrading configuration with the latest parameters: %w", err)
@@ -535,6 +662,10 @@ func startService(c *cli.Context, ignoreConfigSuggestion bool) error {
 		if err != nil {
 			return err
 		}
+		err = rp.UpdateGrafanaProvisioning(cfg)
+		if err != nil {
+			return err
+		}
 	}
 
 	// Validate the config
@@ -548,6 +679,29 @@ func startService(c *cli.Context, ignoreConfigSuggestion bool) error {
 		return nil
 	}
 
+	// Make sure the Validator client has a Keymanager API token to start with
+	if err := rp.EnsureKeymanagerToken(cfg); err != nil {
+		return err
+	}
+
+	// Make sure the node daemon's API server has a token to start with
+	if err := rp.EnsureApiServerToken(cfg); err != nil {
+		return err
+	}
+
+	// Check the host for port conflicts before the clients fail to bind them
+	if !c.Bool("ignore-preflight") {
+		problems := runPreflightChecks(rp, cfg)
+		if len(problems) > 0 {
+			fmt.Printf("%sThe pre-flight checks found problems that will stop your clients from starting:\n\n", colorRed)
+			for _, problem := range problems {
+				fmt.Printf("%s\n\n", problem)
+			}
+			fmt.Printf("Fix them and start again, or use --ignore-preflight to start anyway.%s\n", colorReset)
+			return nil
+		}
+	}
+
 	if !c.Bool("ignore-slash-timer") {
 		// Do the client swap check
 		err := checkForValidatorChange(rp, cfg)
@@ -1324,7 +1478,7 @@ func resyncEth1(c *cli.Context) error {
 	defer rp.Close()
 
 	// Get the config
-	_, isNew, err := rp.LoadConfig()
+	cfg, isNew, err := rp.LoadConfig()
 	if err != nil {
 		return err
 	}
@@ -1341,6 +1495,12 @@ func resyncEth1(c *cli.Context) error {
 		return fmt.Errorf("Error getting container prefix: %w", err)
 	}
 
+	// Check if the chain data should be copied first
+	snapshotDir, err := getResyncSnapshotDir(c, "execution client")
+	if err != nil {
+		return err
+	}
+
 	// Prompt for confirmation
 	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("%sAre you SURE you want to delete and resync your main execution client from scratch? This cannot be undone!%s", colorRed, colorReset))) {
 		fmt.Println("Cancelled.")
@@ -1352,6 +1512,9 @@ func resyncEth1(c *cli.Context) error {
 	fmt.Printf("Stopping %s...\n", executionContainerName)
 	result, err := rp.StopContainer(executionContainerName)
 	if err != nil {
+		if snapshotDir != "" {
+			return fmt.Errorf("Error stopping main ETH1 container, so its chain data can't be copied: %w", err)
+		}
 		fmt.Printf("%sWARNING: Stopping main ETH1 container failed: %s%s\n", colorYellow, err.Error(), colorReset)
 	}
 	if result != executionContainerName {
@@ -1364,6 +1527,14 @@ func resyncEth1(c *cli.Context) error {
 		return fmt.Errorf("Error getting ETH1 volume name: %w", err)
 	}
 
+	// Copy the chain data
+	if snapshotDir != "" {
+		err = snapshotChainData(rp, cfg, prefix, volume, snapshotDir)
+		if err != nil {
+			return fmt.Errorf("%w\nYour execution client's chain data has not been deleted. Run `rocketpool service start` to restart it.", err)
+		}
+	}
+
 	// Remove ETH1
 	fmt.Printf("Deleting %s...\n", executionContainerName)
 	result, err = rp.RemoveContainer(executionContainerName)
@@ -1386,14 +1557,16 @@ func resyncEth1(c *cli.Context) error {
 
 	// Restart Rocket Pool
 	fmt.Printf("Rebuilding %s and restarting Rocket Pool...\n", executionContainerName)
-	err = startService(c, true)
+	err = StartService(c, true)
 	if err != nil {
 		return fmt.Errorf("Error starting Rocket Pool: %s", err)
 	}
 
 	fmt.Printf("\nDone! Your main execution client is now resyncing. You can follow its progress with `rocketpool service logs eth1`.\n")
 
-	return nil
+	return monitorResync(c, rp, "execution client", func(response api.NodeSyncProgressResponse) api.ClientStatus {
+		return response.EcStatus.PrimaryClientStatus
+	})
 
 }
 
@@ -1446,14 +1619,6 @@ func resyncEth2(c *cli.Context) error {
 	}
 	if !supportsCheckpointSync {
 		fmt.Printf("%sYour consensus client (%s) does not support checkpoint sync.\nIf you have active validators, they %swill be considered offline and will leak ETH%s%s while the client is syncing.%s\n\n", colorRed, clientName, colorBold, colorReset, colorRed, colorReset)
-	} else {
-		// Get the current checkpoint sync URL
-		checkpointSyncUrl := cfg.ConsensusCommon.CheckpointSyncProvider.Value.(string)
-		if checkpointSyncUrl == "" {
-			fmt.Printf("%sYou do not have a checkpoint sync provider configured.\nIf you have active validators, they %swill be considered offline and will lose ETH%s%s until your consensus client finishes syncing.\nWe strongly recommend you configure a checkpoint sync provider with `rocketpool service config` so it syncs instantly before running this.%s\n\n", colorRed, colorBold, colorReset, colorRed, colorReset)
-		} else {
-			fmt.Printf("You have a checkpoint sync provider configured (%s).\nYour consensus client will use it to sync to the head of the Beacon Chain instantly after being rebuilt.\n\n", checkpointSyncUrl)
-		}
 	}
 
 	// Get the container prefix
@@ -1462,17 +1627,33 @@ func resyncEth2(c *cli.Context) error {
 		return fmt.Errorf("Error getting container prefix: %w", err)
 	}
 
+	// Check if the chain data should be copied first
+	snapshotDir, err := getResyncSnapshotDir(c, "consensus client")
+	if err != nil {
+		return err
+	}
+
 	// Prompt for confirmation
 	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("%sAre you SURE you want to delete and resync your main consensus client from scratch? This cannot be undone!%s", colorRed, colorReset))) {
 		fmt.Println("Cancelled.")
 		return nil
 	}
 
+	// Set up checkpoint sync so the rebuilt client syncs instantly
+	if supportsCheckpointSync {
+		if err := enableCheckpointSync(c, rp, cfg); err != nil {
+			return err
+		}
+	}
+
 	// Stop ETH2
 	beaconContainerName := prefix + BeaconContainerSuffix
 	fmt.Printf("Stopping %s...\n", beaconContainerName)
 	result, err := rp.StopContainer(beaconContainerName)
 	if err != nil {
+		if snapshotDir != "" {
+			return fmt.Errorf("Error stopping ETH2 container, so its chain data can't be copied: %w", err)
+		}
 		fmt.Printf("%sWARNING: Stopping ETH2 container failed: %s%s\n", colorYellow, err.Error(), colorReset)
 	}
 	if result != beaconContainerName {
@@ -1485,6 +1666,14 @@ func resyncEth2(c *cli.Context) error {
 		return fmt.Errorf("Error getting ETH2 volume name: %w", err)
 	}
 
+	// Copy the chain data
+	if snapshotDir != "" {
+		err = snapshotChainData(rp, cfg, prefix, volume, snapshotDir)
+		if err != nil {
+			return fmt.Errorf("%w\nYour consensus client's chain data has not been deleted. Run `rocketpool service start` to restart it.", err)
+		}
+	}
+
 	// Remove ETH2
 	fmt.Printf("Deleting %s...\n", beaconContainerName)
 	result, err = rp.RemoveContainer(beaconContainerName)
@@ -1507,14 +1696,16 @@ func resyncEth2(c *cli.Context) error {
 
 	// Restart Rocket Pool
 	fmt.Printf("Rebuilding %s and restarting Rocket Pool...\n", beaconContainerName)
-	err = startService(c, true)
+	err = StartService(c, true)
 	if err != nil {
 		return fmt.Errorf("Error starting Rocket Pool: %s", err)
 	}
 
 	fmt.Printf("\nDone! Your consensus client is now resyncing. You can follow its progress with `rocketpool service logs eth2`.\n")
 
-	return nil
+	return monitorResync(c, rp, "consensus client", func(response api.NodeSyncProgressResponse) api.ClientStatus {
+		return response.BcStatus.PrimaryClientStatus
+	})
 
 }
 
@@ -0,0 +1,210 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Slashing protection files in the validators folder
+const (
+	// Prysm always exports to this name, so it's used for every client
+	slashingProtectionExportFilename string = "slashing_protection.json"
+	slashingProtectionImportFilename string = "slashing_protection_import.json"
+)
+
+// Export the validator client's slashing protection database to an EIP-3076 interchange file
+func exportSlashingProtection(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Load the config
+	cfg, err := loadSlashingProtectionConfig(rp)
+	if err != nil {
+		return err
+	}
+	validatorsPath, err := getValidatorsHostPath(cfg)
+	if err != nil {
+		return err
+	}
+
+	// The validator client has to be stopped so its database doesn't change during the export
+	validatorContainerName := cfg.Smartnode.ProjectName.Value.(string) + ValidatorContainerSuffix
+	restartValidator := false
+	status, err := rp.GetDockerStatus(validatorContainerName)
+	if err == nil && status == "running" {
+		if !(c.Bool("yes") || cliutils.Confirm("Your validator client has to be stopped while its slashing protection database is exported; it will be restarted afterwards. Stop it now?")) {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+		fmt.Printf("Stopping %s...\n", validatorContainerName)
+		if _, err := rp.StopContainer(validatorContainerName); err != nil {
+			return fmt.Errorf("error stopping %s: %w", validatorContainerName, err)
+		}
+		restartValidator = true
+	}
+
+	// Export it
+	fmt.Println("Exporting the slashing protection database...")
+	exportErr := rp.ExportSlashingProtection(cfg, slashingProtectionExportFilename)
+	if restartValidator {
+		fmt.Printf("Restarting %s...\n", validatorContainerName)
+		if _, err := rp.StartContainer(validatorContainerName); err != nil {
+			fmt.Printf("%sWARNING: error restarting %s: %s\nPlease run `rocketpool service start` to restart it.%s\n", colorRed, validatorContainerName, err.Error(), colorReset)
+		}
+	}
+	if exportErr != nil {
+		return fmt.Errorf("error exporting slashing protection: %w", exportErr)
+	}
+
+	exportPath := filepath.Join(validatorsPath, slashingProtectionExportFilename)
+	fmt.Printf("\n%sExported the slashing protection database to %s.%s\n", colorGreen, exportPath, colorReset)
+	if c.String("output") != "" {
+		outputPath, err := homedir.Expand(c.String("output"))
+		if err != nil {
+			return fmt.Errorf("error expanding output path: %w", err)
+		}
+		if err := copySlashingProtectionFile(exportPath, outputPath); err != nil {
+			return err
+		}
+		fmt.Printf("Copied it to %s.\n", outputPath)
+	}
+	fmt.Println("You can import it into another Smartnode's validator client with `rocketpool service slashing-protection import`.")
+	return nil
+
+}
+
+// Import an EIP-3076 interchange file into the validator client's slashing protection database, making sure it covers all of the node's validators first
+func importSlashingProtection(c *cli.Context, path string) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Load the config
+	cfg, err := loadSlashingProtectionConfig(rp)
+	if err != nil {
+		return err
+	}
+	validatorsPath, err := getValidatorsHostPath(cfg)
+	if err != nil {
+		return err
+	}
+
+	// The API can only read files in the data folder, so copy it into the validators folder
+	path, err = homedir.Expand(path)
+	if err != nil {
+		return fmt.Errorf("error expanding slashing protection file path: %w", err)
+	}
+	importPath := filepath.Join(validatorsPath, slashingProtectionImportFilename)
+	if err := copySlashingProtectionFile(path, importPath); err != nil {
+		return err
+	}
+	defer func() {
+		_ = os.Remove(importPath)
+	}()
+
+	// Check that it's for this chain and has records for every validator
+	fmt.Println("Checking the slashing protection file...")
+	check, err := rp.CheckSlashingProtection(slashingProtectionImportFilename)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("The file has records for %d validators; your node has %d validating minipools.\n", check.ValidatorCount, len(check.NodeValidators))
+	if !check.CoversAllKeys {
+		fmt.Printf("%sThe file doesn't have any records for these validators:%s\n", colorYellow, colorReset)
+		for _, pubkey := range check.MissingPubkeys {
+			fmt.Printf("\t0x%s\n", pubkey.Hex())
+		}
+		if !c.Bool("allow-missing") {
+			fmt.Printf("\n%sYour validator client won't be protected from signing conflicting duties for them, so the import has been cancelled.\nPlease export the slashing protection from every validator client that has run these keys, or use --allow-missing if you're sure they have never been run anywhere else.%s\n", colorRed, colorReset)
+			return nil
+		}
+		fmt.Printf("%sContinuing because --allow-missing was set.%s\n", colorYellow, colorReset)
+	}
+
+	// Stop the validator client; it's only started again once the import has succeeded
+	validatorContainerName := cfg.Smartnode.ProjectName.Value.(string) + ValidatorContainerSuffix
+	status, err := rp.GetDockerStatus(validatorContainerName)
+	validatorExists := (err == nil)
+	if validatorExists && status == "running" {
+		if !(c.Bool("yes") || cliutils.Confirm("Your validator client has to be stopped while the slashing protection is imported; it will be restarted once the import succeeds. Stop it now?")) {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+		fmt.Printf("Stopping %s...\n", validatorContainerName)
+		if _, err := rp.StopContainer(validatorContainerName); err != nil {
+			return fmt.Errorf("error stopping %s: %w", validatorContainerName, err)
+		}
+	}
+
+	// Import it
+	fmt.Println("Importing the slashing protection file...")
+	if err := rp.ImportSlashingProtection(cfg, slashingProtectionImportFilename); err != nil {
+		fmt.Printf("%sThe import failed, so your validator client has been left stopped. Please fix the problem and import the file again before starting it.%s\n", colorRed, colorReset)
+		return fmt.Errorf("error importing slashing protection: %w", err)
+	}
+	fmt.Printf("\n%sImported the slashing protection file.%s\n", colorGreen, colorReset)
+
+	if validatorExists {
+		fmt.Printf("Starting %s...\n", validatorContainerName)
+		if _, err := rp.StartContainer(validatorContainerName); err != nil {
+			fmt.Printf("%sWARNING: error starting %s: %s\nPlease run `rocketpool service start` to start it.%s\n", colorRed, validatorContainerName, err.Error(), colorReset)
+		}
+	} else {
+		fmt.Println("Your validator client will use it when you start the Smartnode with `rocketpool service start`.")
+	}
+	return nil
+
+}
+
+// Load the config, making sure the Smartnode manages the validator client
+func loadSlashingProtectionConfig(rp *rocketpool.Client) (*config.RocketPoolConfig, error) {
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	if isNew {
+		return nil, fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode first.")
+	}
+	if cfg.IsNativeMode {
+		return nil, fmt.Errorf("The Smartnode doesn't manage your validator client in native mode. Please use its own slashing protection commands.")
+	}
+	return cfg, nil
+}
+
+// Get the path of the validators folder on the host
+func getValidatorsHostPath(cfg *config.RocketPoolConfig) (string, error) {
+	dataPath, err := homedir.Expand(cfg.Smartnode.DataPath.Value.(string))
+	if err != nil {
+		return "", fmt.Errorf("error expanding data directory: %w", err)
+	}
+	return filepath.Join(dataPath, "validators"), nil
+}
+
+// Copy a slashing protection file
+func copySlashingProtectionFile(source string, destination string) error {
+	if source == destination {
+		return nil
+	}
+	contents, err := os.ReadFile(source)
+	if err != nil {
+		return fmt.Errorf("error reading slashing protection file: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(destination), 0775); err != nil {
+		return fmt.Errorf("error creating folder for the slashing protection file: %w", err)
+	}
+	if err := os.WriteFile(destination, contents, 0644); err != nil {
+		return fmt.Errorf("error writing slashing protection file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// View the disk, chain data and memory usage and the clock skew, and offer to prune the Execution client if the disk is running low
+func getSystemStatus(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Get the system status
+	response, err := rp.GetSystemStatus()
+	if err != nil {
+		return err
+	}
+	status := response.SystemStatus
+	if status == nil {
+		fmt.Println("The node daemon hasn't recorded the system status yet. It is checked every few minutes while the node daemon is running.")
+		return nil
+	}
+
+	fmt.Printf("%s=== System Status (as of %s) ===%s\n", colorGreen, status.Time.Local().Format(time.RFC1123), colorReset)
+	fmt.Printf("Disk (%s):  %s free of %s\n", status.DiskPath, humanize.IBytes(status.DiskFree), humanize.IBytes(status.DiskTotal))
+	if status.DaysUntilFull >= 0 {
+		fmt.Printf("Disk usage growth: %s per day (full in about %.0f days)\n", humanize.IBytes(uint64(status.DiskUsagePerDay)), status.DaysUntilFull)
+	}
+	for _, chainData := range status.ChainData {
+		fmt.Printf("%s chain data: %s\n", chainData.Client, humanize.IBytes(chainData.Size))
+	}
+	fmt.Printf("Memory: %.1f%% used (%s available of %s)\n", status.MemoryUsedPercent, humanize.IBytes(status.MemoryAvailable), humanize.IBytes(status.MemoryTotal))
+	if status.NtpOffset != nil {
+		fmt.Printf("Clock offset from %s: %s\n", status.NtpServer, formatClockOffset(*status.NtpOffset))
+	}
+	if status.BeaconOffset != nil {
+		fmt.Printf("Clock offset from Beacon slot times: %s\n", formatClockOffset(*status.BeaconOffset))
+	}
+	fmt.Println()
+
+	if !printSystemWarnings(response) {
+		fmt.Printf("%sNo resource or clock problems detected.%s\n", colorGreen, colorReset)
+		return nil
+	}
+
+	// Offer to prune the Execution client
+	if status.PruneAdvice != "" && response.CanPrune {
+		if cliutils.Confirm("Would you like to prune your Execution client now?") {
+			return pruneExecutionClient(c)
+		}
+	}
+	return nil
+
+}
+
+// Format how far the system clock is from a time source
+func formatClockOffset(offset time.Duration) string {
+	if offset < 0 {
+		return fmt.Sprintf("%s behind", (-offset).Round(time.Millisecond))
+	}
+	return fmt.Sprintf("%s ahead", offset.Round(time.Millisecond))
+}
+
+// Print any resource warnings from the system status, returning true if there were any
+func printSystemWarnings(response api.SystemStatusResponse) bool {
+	status := response.SystemStatus
+	if status == nil || len(status.Warnings) == 0 {
+		return false
+	}
+	for _, warning := range status.Warnings {
+		fmt.Printf("%sWARNING: %s%s\n", colorYellow, warning, colorReset)
+	}
+	if status.PruneAdvice != "" {
+		fmt.Printf("%s\n", status.PruneAdvice)
+	}
+	fmt.Println()
+	return true
+}
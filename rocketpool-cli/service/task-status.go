@@ -0,0 +1,73 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+// View the recent runs of each daemon task
+func getTaskStatus(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Get the task status
+	response, err := rp.GetTaskStatus()
+	if err != nil {
+		return err
+	}
+	if len(response.Daemons) == 0 {
+		fmt.Println("The node and watchtower daemons haven't recorded any task runs yet.")
+		return nil
+	}
+
+	runCount := int(c.Uint("runs"))
+	budget := c.Float64("budget") / 100
+	for _, daemon := range response.Daemons {
+		fmt.Printf("%s=== %s (updated %s) ===%s\n", colorGreen, daemon.Daemon, daemon.Updated.Local().Format(time.RFC1123), colorReset)
+		for _, task := range daemon.Tasks {
+			failureRate := task.GetFailureRate()
+			statusColor := colorGreen
+			if task.IsOverBudget(budget) {
+				statusColor = colorRed
+			} else if failureRate > 0 {
+				statusColor = colorYellow
+			}
+
+			// Draw the recent runs, oldest first
+			runs := task.Runs
+			if len(runs) > runCount {
+				runs = runs[len(runs)-runCount:]
+			}
+			history := ""
+			for _, run := range runs {
+				if run.Error == "" {
+					history += colorGreen + "✓" + colorReset
+				} else {
+					history += colorRed + "✗" + colorReset
+				}
+			}
+
+			fmt.Printf("%s%-30s%s %s  %.0f%% failed (%d errors in %d runs)\n", statusColor, task.Task, colorReset, history, failureRate*100, task.TotalErrors, task.TotalRuns)
+			if len(task.Runs) > 0 {
+				latest := task.Runs[len(task.Runs)-1]
+				fmt.Printf("\tLast run: %s (took %s)\n", latest.Start.Local().Format(time.RFC1123), latest.Duration.Round(time.Millisecond))
+				if latest.Error != "" {
+					fmt.Printf("\t%sLast error: %s%s\n", colorRed, latest.Error, colorReset)
+				}
+			}
+			if !task.LastSuccess.IsZero() {
+				fmt.Printf("\tLast success: %s\n", task.LastSuccess.Local().Format(time.RFC1123))
+			}
+		}
+		fmt.Println()
+	}
+
+	return nil
+
+}
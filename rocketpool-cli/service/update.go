@@ -0,0 +1,337 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/services/updates"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Health check settings for updates
+var (
+	// How long the services have to come up after an update before it's rolled back
+	updateHealthTimeout, _ = time.ParseDuration("5m")
+
+	// How long the services have to stay up before the update is considered successful
+	updateHealthSettleTime, _ = time.ParseDuration("1m")
+
+	updateHealthPollInterval, _ = time.ParseDuration("10s")
+)
+
+// Check for new Smartnode and client releases and install them, rolling back if the services don't come back up
+func updateService(c *cli.Context) error {
+
+	if c.Bool("clients-only") && c.Bool("smartnode-only") {
+		return fmt.Errorf("--clients-only and --smartnode-only can't be used together")
+	}
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Load the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode first.")
+	}
+	if cfg.IsNativeMode {
+		return fmt.Errorf("Automatic updates aren't available in Native Mode; please update the Smartnode and your clients manually.")
+	}
+
+	// Check for new releases; the staged rollout doesn't apply when the user asks for an update
+	fmt.Println("Checking for new releases...")
+	status := updates.CheckForUpdates(cfg, nil)
+	for _, err := range status.Errors {
+		fmt.Printf("%sWARNING: %s%s\n", colorYellow, err, colorReset)
+	}
+	smartnodeRelease := status.Smartnode
+	clientReleases := status.Clients
+	if c.Bool("clients-only") {
+		smartnodeRelease = nil
+	}
+	if c.Bool("smartnode-only") {
+		clientReleases = nil
+	}
+	if smartnodeRelease == nil && len(clientReleases) == 0 {
+		fmt.Printf("%sEverything is up to date.%s\n", colorGreen, colorReset)
+		return nil
+	}
+
+	// The Smartnode goes first, since it comes with its own default client versions
+	if smartnodeRelease != nil {
+		fmt.Printf("Smartnode: %s => %s (%s)\n", smartnodeRelease.CurrentVersion, smartnodeRelease.LatestVersion, smartnodeRelease.Url)
+		if !smartnodeRelease.Verified {
+			return fmt.Errorf("Smartnode %s failed verification and won't be installed: %s", smartnodeRelease.LatestVersion, smartnodeRelease.VerificationError)
+		}
+		if len(clientReleases) > 0 {
+			fmt.Println("Client updates will be checked again after the Smartnode has been updated; run `rocketpool service update --clients-only` afterwards.")
+		}
+		if !(c.Bool("yes") || cliutils.Confirm("Would you like to update the Smartnode? Your services will be restarted.")) {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+		return updateSmartnode(c, rp, cfg, *smartnodeRelease)
+	}
+
+	verifiedReleases := []updates.Release{}
+	for _, release := range clientReleases {
+		fmt.Printf("%s: %s => %s (%s)\n", release.Name, release.CurrentVersion, release.LatestVersion, release.Url)
+		if release.Verified {
+			verifiedReleases = append(verifiedReleases, release)
+			continue
+		}
+		reason := release.VerificationError
+		if reason == "" {
+			reason = "its container tag couldn't be updated automatically"
+		}
+		fmt.Printf("%sWARNING: %s %s is unverified: %s%s\n", colorYellow, release.Name, release.LatestVersion, reason, colorReset)
+	}
+
+	// Unverified releases are only installed if the user explicitly accepts them
+	if len(verifiedReleases) < len(clientReleases) {
+		if c.Bool("yes") {
+			fmt.Println("Unverified releases will be skipped.")
+			clientReleases = verifiedReleases
+		} else if !cliutils.Confirm("Would you like to install the unverified releases anyway?") {
+			clientReleases = verifiedReleases
+		}
+		if len(clientReleases) == 0 {
+			fmt.Println("There are no verified client updates to install.")
+			return nil
+		}
+	}
+	if !(c.Bool("yes") || cliutils.Confirm("Would you like to update these clients? Their containers will be restarted.")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+	return updateClients(c, rp, cfg, clientReleases)
+
+}
+
+// Change the client container tags to their new releases and restart the services, restoring the old tags if they don't come back up
+func updateClients(c *cli.Context, rp *rocketpool.Client, cfg *config.RocketPoolConfig, releases []updates.Release) error {
+
+	oldTags := updates.UpdateClientTags(cfg, releases)
+	if len(oldTags) == 0 {
+		fmt.Println("None of your container tags could be updated automatically; please set them with `rocketpool service config`.")
+		return nil
+	}
+	for param, oldTag := range oldTags {
+		fmt.Printf("%s => %s\n", oldTag, param.Value)
+	}
+	err := rp.SaveConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("error saving config: %w", err)
+	}
+
+	err = StartService(c, true)
+	if err == nil {
+		err = waitForHealthyServices(rp, cfg)
+	}
+	if err == nil {
+		fmt.Printf("%sYour clients were updated successfully.%s\n", colorGreen, colorReset)
+		return nil
+	}
+
+	// Roll back
+	fmt.Printf("%sThe update failed: %s\nRolling back to the previous client versions...%s\n", colorRed, err.Error(), colorReset)
+	for param, oldTag := range oldTags {
+		param.Value = oldTag
+	}
+	if saveErr := rp.SaveConfig(cfg); saveErr != nil {
+		return fmt.Errorf("error restoring the previous client versions: %w", saveErr)
+	}
+	if startErr := StartService(c, true); startErr != nil {
+		return fmt.Errorf("error restarting the previous client versions: %w", startErr)
+	}
+	return fmt.Errorf("the client update was rolled back: %w", err)
+
+}
+
+// Replace the CLI with the new release, install its service files and restart the services with it, which migrates the
+// settings. If the services don't come back up, the previous CLI, service files and settings are restored.
+func updateSmartnode(c *cli.Context, rp *rocketpool.Client, cfg *config.RocketPoolConfig, release updates.Release) error {
+
+	// Download and verify the new CLI
+	latest, err := updates.GetLatestRelease(updates.SmartnodeRepo)
+	if err != nil {
+		return err
+	}
+	if latest.TagName != release.LatestVersion {
+		return fmt.Errorf("the latest Smartnode release changed from %s to %s while updating; please try again", release.LatestVersion, latest.TagName)
+	}
+	assetName := fmt.Sprintf("rocketpool-cli-%s-%s", runtime.GOOS, runtime.GOARCH)
+	fmt.Printf("Downloading %s %s...\n", assetName, latest.TagName)
+	binary, signed, err := latest.DownloadVerifiedAsset(assetName, filepath.Join(cfg.RocketPoolDirectory, updates.ReleaseKeyFile))
+	if err != nil {
+		return err
+	}
+	if !signed {
+		fmt.Printf("%sThe download matched its published checksum, but its signature couldn't be checked because there's no release signing key at %s.%s\n", colorYellow, filepath.Join(cfg.RocketPoolDirectory, updates.ReleaseKeyFile), colorReset)
+	}
+
+	// Download and verify the installers for the new version and the current one, so a rollback doesn't run an unchecked script
+	installerPath, err := saveVerifiedInstaller(cfg, latest.TagName)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(installerPath)
+	rollbackVersion := fmt.Sprintf("v%s", shared.RocketPoolVersion)
+	rollbackInstallerPath, err := saveVerifiedInstaller(cfg, rollbackVersion)
+	if err != nil {
+		return fmt.Errorf("couldn't verify the installer of your current version for rollbacks: %w", err)
+	}
+	defer os.Remove(rollbackInstallerPath)
+
+	// Replace the CLI, keeping the old one for rollbacks
+	exePath, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("error getting the CLI's path: %w", err)
+	}
+	exePath, err = filepath.EvalSymlinks(exePath)
+	if err != nil {
+		return fmt.Errorf("error resolving the CLI's path: %w", err)
+	}
+	backupPath := exePath + ".bak"
+	err = os.Rename(exePath, backupPath)
+	if errors.Is(err, fs.ErrPermission) {
+		return fmt.Errorf("you don't have permission to replace %s; please run this command with sudo", exePath)
+	}
+	if err != nil {
+		return fmt.Errorf("error backing up the CLI to %s: %w", backupPath, err)
+	}
+	err = os.WriteFile(exePath, binary, 0755)
+	if err != nil {
+		if restoreErr := os.Rename(backupPath, exePath); restoreErr != nil {
+			return fmt.Errorf("error writing the new CLI (%s) and restoring the old one from %s (%s)", err.Error(), backupPath, restoreErr.Error())
+		}
+		return fmt.Errorf("error writing the new CLI to %s: %w", exePath, err)
+	}
+
+	// Install the new service files and restart the services with the new CLI
+	err = runCli(c, exePath, "service", "install", "--yes", "--no-deps", "--version", latest.TagName, "--installer", installerPath)
+	if err == nil {
+		err = runCli(c, exePath, "service", "start", "--yes")
+	}
+	if err == nil {
+		err = waitForHealthyServices(rp, cfg)
+	}
+	if err == nil {
+		fmt.Printf("%sThe Smartnode was updated to %s successfully.%s\n", colorGreen, latest.TagName, colorReset)
+		return nil
+	}
+
+	// Roll back
+	fmt.Printf("%sThe update failed: %s\nRolling back to Smartnode v%s...%s\n", colorRed, err.Error(), shared.RocketPoolVersion, colorReset)
+	if restoreErr := os.Rename(backupPath, exePath); restoreErr != nil {
+		return fmt.Errorf("error restoring the previous CLI from %s: %w", backupPath, restoreErr)
+	}
+	if installErr := runCli(c, exePath, "service", "install", "--yes", "--no-deps", "--version", rollbackVersion, "--installer", rollbackInstallerPath); installErr != nil {
+		return fmt.Errorf("error reinstalling the previous service files: %w", installErr)
+	}
+	if _, rollbackErr := rp.RollbackConfig(); rollbackErr != nil {
+		fmt.Printf("%sWARNING: couldn't restore your previous settings: %s%s\n", colorYellow, rollbackErr.Error(), colorReset)
+	}
+	if startErr := runCli(c, exePath, "service", "start", "--yes"); startErr != nil {
+		return fmt.Errorf("error restarting the previous Smartnode version: %w", startErr)
+	}
+	return fmt.Errorf("the Smartnode update was rolled back: %w", err)
+
+}
+
+// Download the installer of a Smartnode release, verify it and save it to a temporary file, returning the file's path
+func saveVerifiedInstaller(cfg *config.RocketPoolConfig, version string) (string, error) {
+	keyPath := filepath.Join(cfg.RocketPoolDirectory, updates.ReleaseKeyFile)
+	installer, _, err := updates.DownloadVerifiedInstaller(version, keyPath)
+	if err != nil {
+		return "", fmt.Errorf("error verifying the installer of Smartnode %s: %w", version, err)
+	}
+	file, err := os.CreateTemp("", "rocketpool-install-*.sh")
+	if err != nil {
+		return "", fmt.Errorf("error creating a temporary file for the installer: %w", err)
+	}
+	defer file.Close()
+	if _, err := file.Write(installer); err != nil {
+		os.Remove(file.Name())
+		return "", fmt.Errorf("error saving the installer to %s: %w", file.Name(), err)
+	}
+	return file.Name(), nil
+}
+
+// Run a command with the given CLI binary, passing along the config path
+func runCli(c *cli.Context, exePath string, args ...string) error {
+	cmd := exec.Command(exePath, append([]string{"--config-path", c.GlobalString("config-path")}, args...)...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// Wait for all of the Smartnode's containers to be running, and to stay running for a while
+func waitForHealthyServices(rp *rocketpool.Client, cfg *config.RocketPoolConfig) error {
+
+	// Get the containers
+	prefix := cfg.Smartnode.ProjectName.Value.(string)
+	containers := []string{
+		prefix + "_" + config.ApiContainerName,
+		prefix + "_" + config.NodeContainerName,
+		prefix + "_" + config.WatchtowerContainerName,
+		prefix + "_" + config.ValidatorContainerName,
+	}
+	if cfg.ExecutionClientMode.Value.(cfgtypes.Mode) == cfgtypes.Mode_Local {
+		containers = append(containers, prefix+"_"+config.Eth1ContainerName)
+	}
+	if cfg.ConsensusClientMode.Value.(cfgtypes.Mode) == cfgtypes.Mode_Local {
+		containers = append(containers, prefix+"_"+config.Eth2ContainerName)
+	}
+	if cfg.EnableMevBoost.Value == true && cfg.MevBoost.Mode.Value.(cfgtypes.Mode) == cfgtypes.Mode_Local {
+		containers = append(containers, prefix+"_"+config.MevBoostContainerName)
+	}
+
+	fmt.Println("Waiting for the services to come up...")
+	deadline := time.Now().Add(updateHealthTimeout)
+	var runningSince time.Time
+	for {
+		stopped := ""
+		for _, container := range containers {
+			status, err := rp.GetDockerStatus(container)
+			if err != nil || status != "running" {
+				stopped = fmt.Sprintf("%s is %s", container, status)
+				if err != nil {
+					stopped = fmt.Sprintf("couldn't get the status of %s: %s", container, err.Error())
+				}
+				break
+			}
+		}
+
+		switch {
+		case stopped == "" && runningSince.IsZero():
+			runningSince = time.Now()
+		case stopped == "" && time.Since(runningSince) >= updateHealthSettleTime:
+			return nil
+		case stopped != "":
+			runningSince = time.Time{}
+			if time.Now().After(deadline) {
+				return fmt.Errorf("the services didn't come up within %s (%s)", updateHealthTimeout, stopped)
+			}
+		}
+		time.Sleep(updateHealthPollInterval)
+	}
+
+}
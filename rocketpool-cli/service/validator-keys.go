@@ -0,0 +1,77 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Encrypt the validator keystore directory with a key from the configured secret store
+func encryptValidatorKeys(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Prompt for confirmation
+	fmt.Println("This will encrypt your validator keystore directory with a key kept in the secret store you chose in `rocketpool service config`, then delete the unencrypted directory.")
+	fmt.Println("The node daemon will decrypt it into memory-backed storage for your Validator Client whenever the node starts, so the daemon must be running for the Validator Client to have its keys.")
+	fmt.Println("Your Validator Client will be stopped while this happens, and its slashing protection database will be moved to a separate folder in your data folder so it always stays on disk.")
+	fmt.Printf("%sIf you lose access to the secret store, you'll need to rebuild your validator keys from your mnemonic with `rocketpool wallet rebuild`. The deleted files may still be recoverable from the disk until they're overwritten.%s\n\n", colorYellow, colorReset)
+	if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to encrypt your validator keys?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Encrypt them
+	response, err := rp.EncryptValidatorKeys()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Your validator keys have been encrypted to %s with a key kept in %s.\n", response.SealedPath, response.Store)
+	fmt.Printf("Your Validator Client's slashing protection database is now kept in %s.\n", response.SlashingProtectionPath)
+	if !response.RestartRequired {
+		fmt.Printf("Your Validator Client has been restarted and is now reading them from %s.\n", response.UnsealedPath)
+		return nil
+	}
+
+	// Restart with the tmpfs volume mounted over the validators folder
+	fmt.Println("Restarting the Smartnode so your Validator Client reads them from a tmpfs volume...")
+	return StartService(c, true)
+
+}
+
+// Decrypt the validator keystore directory back into the data folder
+func decryptValidatorKeys(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Prompt for confirmation
+	fmt.Println("This will decrypt your validator keystore directory back into your data folder and delete its encrypted copy.")
+	if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to decrypt your validator keys?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Decrypt them
+	response, err := rp.DecryptValidatorKeys()
+	if err != nil {
+		return err
+	}
+	if !response.RestartRequired {
+		fmt.Printf("Your validator keys have been decrypted into %s and your Validator Client has been restarted.\n", response.ValidatorsPath)
+		fmt.Println("You can now disable validator key encryption in `rocketpool service config`.")
+		return nil
+	}
+
+	// Restart without the tmpfs volume, so the node daemon can move the keys into the validators folder
+	fmt.Println("Your validator keys have been decrypted; you can now disable validator key encryption in `rocketpool service config`.")
+	fmt.Printf("Restarting the Smartnode so the node daemon can move them into %s...\n", response.ValidatorsPath)
+	return StartService(c, true)
+
+}
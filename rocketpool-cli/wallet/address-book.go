@@ -0,0 +1,144 @@
+package wallet
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/addressbook"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// The shortest address book passphrase allowed
+const minAddressBookPassphraseLength int = 8
+
+func addAddressBookEntry(c *cli.Context, name string, addressString string) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Validate the entry before loading the book, so a typo doesn't cost a passphrase prompt
+	if !addressbook.IsValidName(name) {
+		return fmt.Errorf("Invalid name '%s'; names can only contain letters, numbers, dashes and underscores.", name)
+	}
+	address, hasChecksum, err := addressbook.ValidateChecksum(addressString)
+	if err != nil {
+		return err
+	}
+	if !hasChecksum {
+		// Without a checksum, entering the address a second time is the only way to catch a typo
+		fmt.Printf("%sThis address doesn't have a checksum (it isn't in mixed case), so typos in it can't be detected automatically.%s\n", colorYellow, colorReset)
+		confirmation := cliutils.Prompt("Please enter the address again to confirm it:", "^0[xX][0-9a-fA-F]{40}$", "Invalid address, try again:")
+		if !strings.EqualFold(confirmation, addressString) {
+			return fmt.Errorf("The addresses don't match; the entry was not added.")
+		}
+	}
+
+	// Load the address book
+	book, path, err := cliutils.LoadAddressBook(rp)
+	if err != nil {
+		return err
+	}
+	if err := book.Add(name, address); err != nil {
+		return err
+	}
+
+	// Encrypt it if requested
+	if c.Bool("encrypt") && !book.IsEncrypted() {
+		book.SetPassphrase(promptAddressBookPassphrase())
+	}
+
+	// Save it
+	if err := book.Save(path); err != nil {
+		return err
+	}
+	fmt.Printf("Added %s (%s) to the address book. You can now use '%s' in place of the address in commands that take one.\n", name, address.Hex(), name)
+	return nil
+
+}
+
+func listAddressBook(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Load the address book
+	book, _, err := cliutils.LoadAddressBook(rp)
+	if err != nil {
+		return err
+	}
+	if len(book.Entries) == 0 {
+		fmt.Println("The address book is empty. You can add addresses to it with `rocketpool wallet address-book add`.")
+		return nil
+	}
+
+	// Print the entries
+	nameWidth := 0
+	for _, entry := range book.Entries {
+		if len(entry.Name) > nameWidth {
+			nameWidth = len(entry.Name)
+		}
+	}
+	for _, entry := range book.Entries {
+		fmt.Printf("%-*s  %s\n", nameWidth, entry.Name, entry.Address.Hex())
+	}
+	if book.IsEncrypted() {
+		fmt.Println("\nThe address book is encrypted.")
+	}
+	return nil
+
+}
+
+func removeAddressBookEntry(c *cli.Context, name string) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Load the address book
+	book, path, err := cliutils.LoadAddressBook(rp)
+	if err != nil {
+		return err
+	}
+	address, exists := book.Get(name)
+	if !exists {
+		return fmt.Errorf("The address book doesn't have an entry named '%s'.", name)
+	}
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to remove %s (%s) from the address book?", name, address.Hex()))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Remove the entry
+	if err := book.Remove(name); err != nil {
+		return err
+	}
+	if err := book.Save(path); err != nil {
+		return err
+	}
+	fmt.Printf("Removed %s from the address book.\n", name)
+	return nil
+
+}
+
+// Prompt for a new address book passphrase
+func promptAddressBookPassphrase() string {
+	for {
+		passphrase := cliutils.PromptPassword(
+			"Please enter a passphrase to encrypt the address book with:",
+			fmt.Sprintf("^.{%d,}$", minAddressBookPassphraseLength),
+			fmt.Sprintf("The passphrase must be at least %d characters long. Please try again:", minAddressBookPassphraseLength),
+		)
+		confirmation := cliutils.PromptPassword("Please confirm the passphrase:", "^.*$", "")
+		if passphrase == confirmation {
+			return passphrase
+		}
+		fmt.Println("Passphrase confirmation does not match.")
+	}
+}
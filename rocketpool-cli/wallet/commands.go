@@ -68,7 +68,58 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 					}
 
 					// Run
-					return initWallet(c)
+					return InitWallet(c)
+
+				},
+			},
+
+			{
+				Name:      "unlock",
+				Usage:     "Unlock the node wallet for a limited time, after which the node clears its keys from memory",
+				UsageText: "rocketpool wallet unlock [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "for, f",
+						Usage: "How long to unlock the wallet for, such as 30m or 8h",
+						Value: "1h",
+					},
+					cli.BoolFlag{
+						Name:  "permanent",
+						Usage: "Save the wallet's password to disk again, so it stays unlocked",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return unlockWallet(c)
+
+				},
+			},
+
+			{
+				Name:      "lock",
+				Usage:     "Lock the node wallet, deleting its saved password so it has to be unlocked for a limited time before each use",
+				UsageText: "rocketpool wallet lock [options]",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm locking the wallet",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return lockWallet(c)
 
 				},
 			},
@@ -125,7 +176,7 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 					}
 
 					// Run
-					return recoverWallet(c)
+					return RecoverWallet(c)
 
 				},
 			},
@@ -231,6 +282,80 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:    "address-book",
+				Aliases: []string{"ab"},
+				Usage:   "Manage the local address book of external addresses, which can be used by name in commands that take an address",
+				Subcommands: []cli.Command{
+
+					{
+						Name:      "add",
+						Aliases:   []string{"a"},
+						Usage:     "Add an address to the address book. Mixed-case addresses have their checksums validated; other addresses must be entered twice.",
+						UsageText: "rocketpool wallet address-book add [options] name address",
+						Flags: []cli.Flag{
+							cli.BoolFlag{
+								Name:  "encrypt, e",
+								Usage: "Encrypt the address book with a passphrase, if it isn't encrypted already",
+							},
+						},
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 2); err != nil {
+								return err
+							}
+
+							// Run
+							return addAddressBookEntry(c, c.Args().Get(0), c.Args().Get(1))
+
+						},
+					},
+
+					{
+						Name:      "list",
+						Aliases:   []string{"l"},
+						Usage:     "List the addresses in the address book",
+						UsageText: "rocketpool wallet address-book list",
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 0); err != nil {
+								return err
+							}
+
+							// Run
+							return listAddressBook(c)
+
+						},
+					},
+
+					{
+						Name:      "remove",
+						Aliases:   []string{"r"},
+						Usage:     "Remove an address from the address book",
+						UsageText: "rocketpool wallet address-book remove [options] name",
+						Flags: []cli.Flag{
+							cli.BoolFlag{
+								Name:  "yes, y",
+								Usage: "Automatically confirm removing the address",
+							},
+						},
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 1); err != nil {
+								return err
+							}
+
+							// Run
+							return removeAddressBookEntry(c, c.Args().Get(0))
+
+						},
+					},
+				},
+			},
+
 			{
 				Name:      "purge",
 				Usage:     fmt.Sprintf("%sDeletes your node wallet, your validator keys, and restarts your Validator Client while preserving your chain data. WARNING: Only use this if you want to stop validating with this machine!%s", colorRed, colorReset),
@@ -10,7 +10,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/utils/term"
 )
 
-func initWallet(c *cli.Context) error {
+func InitWallet(c *cli.Context) error {
 
 	// Get RP client
 	rp := rocketpool.NewClientFromCtx(c)
@@ -25,6 +25,10 @@ func initWallet(c *cli.Context) error {
 		fmt.Println("The node wallet is already initialized.")
 		return nil
 	}
+	if status.WalletLocked {
+		fmt.Println("The node wallet is already initialized, but it's locked. Run `rocketpool wallet unlock` to use it.")
+		return nil
+	}
 
 	// Prompt for user confirmation before printing sensitive information
 	if !(c.GlobalBool("secure-session") ||
@@ -0,0 +1,107 @@
+package wallet
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+func unlockWallet(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Get & check wallet status
+	status, err := rp.WalletStatus()
+	if err != nil {
+		return err
+	}
+	if !status.WalletLocked && !status.WalletInitialized {
+		fmt.Println("The node wallet has not been initialized.")
+		return nil
+	}
+	if status.PasswordSaved {
+		fmt.Println("The node wallet's password is saved on disk, so it's always unlocked. Run `rocketpool wallet lock` to use unlock sessions instead.")
+		return nil
+	}
+
+	// Get the session duration
+	var duration time.Duration
+	if !c.Bool("permanent") {
+		duration, err = time.ParseDuration(c.String("for"))
+		if err != nil {
+			return fmt.Errorf("Invalid duration '%s': %w", c.String("for"), err)
+		}
+		if duration < time.Minute {
+			return errors.New("The wallet must be unlocked for at least a minute.")
+		}
+	}
+
+	// Get the password
+	password := cliutils.PromptPassword("Please enter the node wallet's password:", "^.+$", "")
+
+	// Save the password for good
+	if c.Bool("permanent") {
+		if _, err := rp.SetPassword(password); err != nil {
+			return err
+		}
+		fmt.Println("The node wallet's password has been saved to disk, so the wallet will stay unlocked.")
+		return nil
+	}
+
+	// Start the session
+	response, err := rp.UnlockWallet(password, duration)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("The node wallet is unlocked until %s. After that the node will clear its keys from memory and stop sending transactions until it's unlocked again.\n", response.SessionExpires.Local().Format(time.RFC1123))
+	return nil
+
+}
+
+func lockWallet(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Get & check wallet status
+	status, err := rp.WalletStatus()
+	if err != nil {
+		return err
+	}
+	if status.WalletLocked {
+		fmt.Println("The node wallet is already locked.")
+		return nil
+	}
+	if !status.WalletInitialized {
+		fmt.Println("The node wallet has not been initialized.")
+		return nil
+	}
+
+	// Prompt for confirmation
+	if status.PasswordSaved {
+		fmt.Printf("%sThis will delete the node wallet's password from disk. From then on, the wallet has to be unlocked with `rocketpool wallet unlock` for a limited time before the node can send transactions, including automatic ones such as staking minipools and distributing balances.%s\n\n", colorYellow, colorReset)
+	}
+	if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to lock the node wallet?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Get the password; it's checked so the wallet can't be locked by someone who couldn't unlock it again
+	password := cliutils.PromptPassword("Please enter the node wallet's password:", "^.+$", "")
+
+	// Lock it
+	if _, err := rp.LockWallet(password); err != nil {
+		return err
+	}
+	fmt.Println("The node wallet has been locked.")
+	return nil
+
+}
@@ -11,7 +11,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
 )
 
-func recoverWallet(c *cli.Context) error {
+func RecoverWallet(c *cli.Context) error {
 
 	// Get RP client
 	rp, ready, err := rocketpool.NewClientFromCtx(c).WithStatus()
@@ -35,6 +35,10 @@ func recoverWallet(c *cli.Context) error {
 		fmt.Println("The node wallet is already initialized.")
 		return nil
 	}
+	if status.WalletLocked {
+		fmt.Println("The node wallet is already initialized, but it's locked. Run `rocketpool wallet unlock` to use it.")
+		return nil
+	}
 
 	// Prompt a notice about test recovery
 	fmt.Printf("%sNOTE:\nThis command will fully regenerate your node wallet's private key and (unless explicitly disabled) the validator keys for your minipools.\nIf you just want to test recovery to ensure it works without actually regenerating the files, please use `rocketpool wallet test-recovery` instead.%s\n\n", colorYellow, colorReset)
@@ -2,6 +2,7 @@ package wallet
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/urfave/cli"
 
@@ -28,9 +29,16 @@ func getStatus(c *cli.Context) error {
 	}
 
 	// Print status & return
+	if status.WalletLocked {
+		fmt.Println("The node wallet is initialized, but it's locked. Run `rocketpool wallet unlock` to use it.")
+		return nil
+	}
 	if status.WalletInitialized {
 		fmt.Println("The node wallet is initialized.")
 		fmt.Printf("Node account: %s\n", status.AccountAddress.Hex())
+		if status.SessionActive && !status.PasswordSaved {
+			fmt.Printf("The wallet is unlocked until %s, after which it will lock itself again.\n", status.SessionExpires.Local().Format(time.RFC1123))
+		}
 	} else {
 		fmt.Println("The node wallet has not been initialized.")
 	}
@@ -1,7 +1,13 @@
 package api
 
 import (
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/smartnode/rocketpool/api/debug"
@@ -15,9 +21,15 @@ import (
 	"github.com/rocket-pool/smartnode/rocketpool/api/node"
 	"github.com/rocket-pool/smartnode/rocketpool/api/odao"
 	"github.com/rocket-pool/smartnode/rocketpool/api/queue"
+	"github.com/rocket-pool/smartnode/rocketpool/api/roles"
 	apiservice "github.com/rocket-pool/smartnode/rocketpool/api/service"
 	"github.com/rocket-pool/smartnode/rocketpool/api/wallet"
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/auditlog"
+	"github.com/rocket-pool/smartnode/shared/services/cmdqueue"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/confirmations"
+	"github.com/rocket-pool/smartnode/shared/services/progress"
 	apitypes "github.com/rocket-pool/smartnode/shared/types/api"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
@@ -47,6 +59,241 @@ func waitForTransaction(c *cli.Context, hash common.Hash) (*apitypes.APIResponse
 
 }
 
+// The commands that change the node wallet's files, which must not run concurrently or they could derive the same keys or overwrite each other's changes.
+// Transactions take their turn separately when their nonce is assigned.
+var walletFileRoutes = map[string]bool{
+	"wallet/init":               true,
+	"wallet/recover":            true,
+	"wallet/search-and-recover": true,
+	"wallet/rebuild":            true,
+	"wallet/set-password":       true,
+	"wallet/lock":               true,
+	"wallet/unlock":             true,
+	"node/deposit":              true,
+}
+
+// Make the commands that change the node wallet's files wait for their turn before they run, and show the commands that send transactions how many are ahead of them
+func queueCommands(commands []cli.Command, prefix string) {
+	for i := range commands {
+		command := &commands[i]
+		route := prefix + command.Name
+		if len(command.Subcommands) > 0 {
+			queueCommands(command.Subcommands, route+"/")
+			continue
+		}
+		if roles.GetRequiredRole(route) == config.ApiRole_ReadOnly {
+			continue
+		}
+		action, isAction := command.Action.(func(*cli.Context) error)
+		if !isAction {
+			continue
+		}
+		changesWalletFiles := walletFileRoutes[route]
+		command.Action = func(c *cli.Context) error {
+			cmdqueue.SetWaitReporter(progress.NewStderrReporter("Waiting for the node wallet"))
+			if !changesWalletFiles {
+				return action(c)
+			}
+			cfg, err := services.GetConfig(c)
+			if err != nil {
+				return err
+			}
+			queuePath := filepath.Join(cfg.Smartnode.GetCommandQueuePath(), cmdqueue.WalletFilesFolder)
+			lock, err := cmdqueue.Acquire(queuePath, cmdqueue.DefaultTimeout, progress.NewStderrReporter("Waiting for the node wallet's files"))
+			if err != nil {
+				return err
+			}
+			defer lock.Release()
+			return action(c)
+		}
+	}
+}
+
+// The read-only commands that read the network state, which the API server runs in the node daemon's own process instead of a new one.
+// They share the daemon's network state manager, so they reuse the snapshot its tasks took at the current slot.
+var daemonCommands = map[string]func(c *cli.Context) []byte{
+	"node/estimate-deposit": func(c *cli.Context) []byte { return api.MarshalResponse(node.EstimateDeposit(c)) },
+	"network/apr":           func(c *cli.Context) []byte { return api.MarshalResponse(network.GetApr(c)) },
+}
+
+// Get the function that runs a command in the node daemon's process, if it's one that should be run there
+func GetDaemonCommand(route string) (func(c *cli.Context) []byte, bool) {
+	command, exists := daemonCommands[route]
+	return command, exists
+}
+
+// The commands that manage confirmation requests, which can't be protected by a policy themselves or nothing could be confirmed
+var confirmationRoutes = map[string]bool{
+	"service/request-confirmation": true,
+	"service/confirm-command":      true,
+	"service/approve-command":      true,
+}
+
+// Make the commands that have a confirmation policy check that their request has been confirmed before they run
+func protectCommands(commands []cli.Command, prefix string) {
+	for i := range commands {
+		command := &commands[i]
+		route := prefix + command.Name
+		if len(command.Subcommands) > 0 {
+			protectCommands(command.Subcommands, route+"/")
+			continue
+		}
+		if roles.GetRequiredRole(route) == config.ApiRole_ReadOnly || confirmationRoutes[route] {
+			continue
+		}
+		action, isAction := command.Action.(func(*cli.Context) error)
+		if !isAction {
+			continue
+		}
+		command.Action = func(c *cli.Context) error {
+			cfg, err := services.GetConfig(c)
+			if err != nil {
+				return err
+			}
+			policies, err := confirmations.GetPolicies(cfg)
+			if err != nil {
+				api.PrintErrorResponse(err)
+				return nil
+			}
+			policy, protected := policies[route]
+			if !protected {
+				return action(c)
+			}
+			if err := useConfirmation(cfg.Smartnode.GetConfirmationsPath(), policy, c.Args()); err != nil {
+				api.PrintErrorResponse(err)
+				return nil
+			}
+			return action(c)
+		}
+	}
+}
+
+// Use the confirmation for a run of a protected command, holding the store's lock until the use is saved so it can only be used once
+func useConfirmation(path string, policy confirmations.Policy, args []string) error {
+	unlock, err := confirmations.LockStore(path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	store, err := confirmations.LoadStore(path)
+	if err != nil {
+		return err
+	}
+	if err := store.Use(policy, args); err != nil {
+		return err
+	}
+	return store.Save()
+}
+
+// The arguments that are never written to the audit log
+var secretArgs = map[string]bool{
+	"password": true,
+	"mnemonic": true,
+}
+
+// Record the commands that aren't read-only in the audit log, along with their outcome and any transactions they sent
+func auditCommands(commands []cli.Command, prefix string) {
+	for i := range commands {
+		command := &commands[i]
+		route := prefix + command.Name
+		if len(command.Subcommands) > 0 {
+			auditCommands(command.Subcommands, route+"/")
+			continue
+		}
+		if roles.GetRequiredRole(route) == config.ApiRole_ReadOnly {
+			continue
+		}
+		action, isAction := command.Action.(func(*cli.Context) error)
+		if !isAction {
+			continue
+		}
+
+		// Get the names of the arguments from the usage text, so the secret ones can be redacted
+		argNames := []string{}
+		usage := strings.Fields(command.UsageText)
+		for j, word := range usage {
+			if word == command.Name {
+				argNames = usage[j+1:]
+				break
+			}
+		}
+
+		command.Action = func(c *cli.Context) error {
+			cfg, err := services.GetConfig(c)
+			if err != nil {
+				return err
+			}
+			entry := auditlog.Entry{
+				Time:    time.Now().UTC(),
+				Route:   route,
+				Args:    []string{},
+				KeyName: os.Getenv(confirmations.ApiKeyNameEnvVar),
+			}
+			for j, arg := range c.Args() {
+				if j < len(argNames) && secretArgs[argNames[j]] {
+					arg = "<redacted>"
+				}
+				entry.Args = append(entry.Args, arg)
+			}
+
+			// Run the command, capturing its response
+			api.SetResponseListener(func(response interface{}) {
+				entry.Status, entry.Error, entry.TxHashes = getAuditOutcome(response)
+			})
+			err = action(c)
+			api.SetResponseListener(nil)
+			if err != nil {
+				entry.Status = "error"
+				entry.Error = err.Error()
+			}
+
+			// Record it
+			if auditErr := auditlog.NewLog(cfg.Smartnode.GetAuditLogPath()).Append(entry); auditErr != nil {
+				fmt.Fprintf(os.Stderr, "WARNING: couldn't write to the audit log: %s\n", auditErr.Error())
+			}
+			return err
+		}
+	}
+}
+
+// Get the status, error and transaction hashes of a command's response
+func getAuditOutcome(response interface{}) (string, string, []string) {
+	bytes, err := json.Marshal(response)
+	if err != nil {
+		return "", "", nil
+	}
+	fields := map[string]interface{}{}
+	if err := json.Unmarshal(bytes, &fields); err != nil {
+		return "", "", nil
+	}
+	status, _ := fields["status"].(string)
+	responseError, _ := fields["error"].(string)
+	txHashes := []string{}
+	for name, value := range fields {
+		if !strings.Contains(strings.ToLower(name), "txhash") {
+			continue
+		}
+		switch value := value.(type) {
+		case string:
+			if isSentTxHash(value) {
+				txHashes = append(txHashes, value)
+			}
+		case []interface{}:
+			for _, hash := range value {
+				if hash, ok := hash.(string); ok && isSentTxHash(hash) {
+					txHashes = append(txHashes, hash)
+				}
+			}
+		}
+	}
+	return status, responseError, txHashes
+}
+
+// Check if a transaction hash in a response belongs to a transaction that was sent, rather than being left blank
+func isSentTxHash(hash string) bool {
+	return hash != "" && hash != (common.Hash{}).Hex()
+}
+
 // Register commands
 func RegisterCommands(app *cli.App, name string, aliases []string) {
 
@@ -97,6 +344,15 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 		},
 	})
 
+	// Commands with a confirmation policy check it, inside their turn with the node wallet so requests can't be used concurrently
+	protectCommands(command.Subcommands, "")
+
+	// Commands that change something are recorded in the audit log, during their turn with the node wallet so the log is written in order
+	auditCommands(command.Subcommands, "")
+
+	// Commands that change something take turns with the node wallet
+	queueCommands(command.Subcommands, "")
+
 	// Register CLI command
 	app.Commands = append(app.Commands, command)
 
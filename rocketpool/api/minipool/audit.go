@@ -0,0 +1,291 @@
+package minipool
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prysmaticlabs/prysm/v3/beacon-chain/core/signing"
+	prdeposit "github.com/prysmaticlabs/prysm/v3/contracts/deposit"
+	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/node"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/types"
+	rputils "github.com/rocket-pool/rocketpool-go/utils"
+	"github.com/urfave/cli"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/progress"
+	"github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// The total a validator is expected to have been deposited once its minipool is staking
+const auditFullDepositGwei uint64 = 32e9
+
+// Audit issue severities
+const (
+	auditSeverity_Warning  string = "warning"
+	auditSeverity_Critical string = "critical"
+)
+
+// Audit issue types
+const (
+	auditIssue_WrongBeaconCredentials  string = "wrong-beacon-credentials"
+	auditIssue_WrongDepositCredentials string = "wrong-deposit-credentials"
+	auditIssue_MissingDeposit          string = "missing-deposit"
+	auditIssue_MissingValidator        string = "missing-validator"
+	auditIssue_UnexpectedTopUp         string = "unexpected-top-up"
+	auditIssue_InvalidDeposit          string = "invalid-deposit"
+)
+
+func auditMinipools(c *cli.Context) (*api.MinipoolAuditResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireBeaconClientSynced(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.MinipoolAuditResponse{
+		Minipools: []api.MinipoolAuditDetails{},
+	}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the minipools that have deposits to check; initialized minipools haven't deposited yet and dissolved ones are gone
+	reporter := progress.NewStderrReporter("Auditing minipools")
+	addresses, err := minipool.GetNodeMinipoolAddresses(rp, nodeAccount.Address, nil)
+	if err != nil {
+		reporter.Finish(err)
+		return nil, err
+	}
+	reporter.SetPhase("Loading minipools", uint64(len(addresses)))
+	pubkeys := map[types.ValidatorPubkey]bool{}
+	pubkeyList := []types.ValidatorPubkey{}
+	for i, address := range addresses {
+		details, err := getMinipoolAuditDetails(rp, address)
+		if err != nil {
+			reporter.Finish(err)
+			return nil, err
+		}
+		reporter.SetProgress(uint64(i + 1))
+		if details == nil {
+			continue
+		}
+		response.Minipools = append(response.Minipools, *details)
+		pubkeys[details.ValidatorPubkey] = true
+		pubkeyList = append(pubkeyList, details.ValidatorPubkey)
+	}
+	if len(response.Minipools) == 0 {
+		reporter.Finish(nil)
+		return &response, nil
+	}
+
+	// Get the validators' state on the Beacon Chain
+	reporter.SetPhase("Checking the Beacon Chain", 0)
+	statuses, err := bc.GetValidatorStatuses(pubkeyList, nil)
+	if err != nil {
+		reporter.Finish(err)
+		return nil, fmt.Errorf("Error getting validator statuses: %w", err)
+	}
+	eth2Config, err := bc.GetEth2Config()
+	if err != nil {
+		reporter.Finish(err)
+		return nil, err
+	}
+	depositDomain, err := signing.ComputeDomain(eth2types.DomainDeposit, eth2Config.GenesisForkVersion, eth2types.ZeroGenesisValidatorsRoot)
+	if err != nil {
+		reporter.Finish(err)
+		return nil, fmt.Errorf("Error computing deposit domain: %w", err)
+	}
+
+	// Get the deposits; none of the node's minipools can have been deposited for before it registered
+	reporter.SetPhase("Scanning the deposit contract", 0)
+	registrationTime, err := node.GetNodeRegistrationTime(rp, nodeAccount.Address, nil)
+	if err != nil {
+		reporter.Finish(err)
+		return nil, err
+	}
+	startHeader, err := rewards.GetELBlockHeaderForTime(registrationTime, rp)
+	if err != nil {
+		reporter.Finish(err)
+		return nil, err
+	}
+	response.StartBlock = startHeader.Number.Uint64()
+	eventLogInterval, err := cfg.GetEventLogInterval()
+	if err != nil {
+		reporter.Finish(err)
+		return nil, err
+	}
+	depositMap, err := rputils.GetDeposits(rp, pubkeys, startHeader.Number, big.NewInt(int64(eventLogInterval)), nil)
+	if err != nil {
+		reporter.Finish(err)
+		return nil, fmt.Errorf("Error getting deposits: %w", err)
+	}
+	reporter.Finish(nil)
+
+	// Check each minipool
+	for i := range response.Minipools {
+		details := &response.Minipools[i]
+		status, exists := statuses[details.ValidatorPubkey]
+		details.ValidatorExists = exists && status.Exists
+		if details.ValidatorExists {
+			details.BeaconWithdrawalCredentials = status.WithdrawalCredentials
+		}
+		auditDeposits(details, depositMap[details.ValidatorPubkey], depositDomain)
+		auditBeaconState(details)
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Get the details of a minipool to audit, or nil if it doesn't have any deposits to check
+func getMinipoolAuditDetails(rp *rocketpool.RocketPool, address common.Address) (*api.MinipoolAuditDetails, error) {
+	mp, err := minipool.NewMinipool(rp, address, nil)
+	if err != nil {
+		return nil, err
+	}
+	status, err := mp.GetStatus(nil)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting minipool %s status: %w", address.Hex(), err)
+	}
+	if status == types.Initialized || status == types.Dissolved {
+		return nil, nil
+	}
+	finalised, err := mp.GetFinalised(nil)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting minipool %s finalized status: %w", address.Hex(), err)
+	}
+	if finalised {
+		return nil, nil
+	}
+
+	pubkey, err := minipool.GetMinipoolPubkey(rp, address, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting minipool %s pubkey: %w", address.Hex(), err)
+	}
+	credentials, err := minipool.GetMinipoolWithdrawalCredentials(rp, address, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting minipool %s withdrawal credentials: %w", address.Hex(), err)
+	}
+	vacant := false
+	if mp.GetVersion() >= 3 {
+		if err := mp.GetContract().Call(nil, &vacant, "getVacant"); err != nil {
+			return nil, fmt.Errorf("Error getting minipool %s vacancy: %w", address.Hex(), err)
+		}
+	}
+
+	return &api.MinipoolAuditDetails{
+		Address:                       address,
+		ValidatorPubkey:               pubkey,
+		MinipoolStatus:                status,
+		Vacant:                        vacant,
+		ExpectedWithdrawalCredentials: credentials,
+		Issues:                        []api.MinipoolAuditIssue{},
+	}, nil
+}
+
+// Check a minipool's deposits against the deposits it should have
+func auditDeposits(details *api.MinipoolAuditDetails, deposits []rputils.DepositData, depositDomain []byte) {
+
+	// Vacant minipools are solo validators migrating in; they were deposited for outside of Rocket Pool
+	if details.Vacant {
+		return
+	}
+
+	// Only deposits with valid signatures count; the Beacon Chain ignores the rest
+	validDeposits := []rputils.DepositData{}
+	for _, deposit := range deposits {
+		depositData := new(ethpb.Deposit_Data)
+		depositData.Amount = deposit.Amount
+		depositData.PublicKey = deposit.Pubkey.Bytes()
+		depositData.WithdrawalCredentials = deposit.WithdrawalCredentials.Bytes()
+		depositData.Signature = deposit.Signature.Bytes()
+		if err := prdeposit.VerifyDepositSignature(depositData, depositDomain); err != nil {
+			addAuditIssue(details, auditSeverity_Warning, auditIssue_InvalidDeposit, "Deposit of %.6f ETH in transaction %s has an invalid signature, so it was ignored by the Beacon Chain.", gweiToEth(deposit.Amount), deposit.TxHash.Hex())
+			continue
+		}
+		validDeposits = append(validDeposits, deposit)
+		details.DepositedGwei += deposit.Amount
+	}
+	details.DepositCount = len(validDeposits)
+
+	if len(validDeposits) == 0 {
+		addAuditIssue(details, auditSeverity_Critical, auditIssue_MissingDeposit, "No valid deposit was found for this minipool's validator in the deposit contract.")
+		return
+	}
+
+	// The first deposit sets the withdrawal credentials; if it's wrong, the minipool will be scrubbed
+	first := validDeposits[0]
+	if first.WithdrawalCredentials != details.ExpectedWithdrawalCredentials {
+		addAuditIssue(details, auditSeverity_Critical, auditIssue_WrongDepositCredentials, "The first deposit (transaction %s) used withdrawal credentials %s instead of the minipool's %s.", first.TxHash.Hex(), first.WithdrawalCredentials.Hex(), details.ExpectedWithdrawalCredentials.Hex())
+	}
+
+	// Anything beyond the prelaunch deposit before staking, or beyond 32 ETH after, didn't come from Rocket Pool
+	switch details.MinipoolStatus {
+	case types.Prelaunch:
+		for _, deposit := range validDeposits[1:] {
+			addAuditIssue(details, auditSeverity_Warning, auditIssue_UnexpectedTopUp, "An extra deposit of %.6f ETH was made in transaction %s before the minipool was staked.", gweiToEth(deposit.Amount), deposit.TxHash.Hex())
+		}
+	default:
+		if details.DepositedGwei > auditFullDepositGwei {
+			addAuditIssue(details, auditSeverity_Warning, auditIssue_UnexpectedTopUp, "A total of %.6f ETH was deposited for this validator, which is %.6f ETH more than expected.", gweiToEth(details.DepositedGwei), gweiToEth(details.DepositedGwei-auditFullDepositGwei))
+		}
+	}
+
+}
+
+// Check a minipool's validator on the Beacon Chain
+func auditBeaconState(details *api.MinipoolAuditDetails) {
+	if !details.ValidatorExists {
+		if details.MinipoolStatus == types.Staking && details.DepositCount > 0 {
+			addAuditIssue(details, auditSeverity_Warning, auditIssue_MissingValidator, "The minipool is staking but its validator isn't on the Beacon Chain yet. This is normal for several hours after staking.")
+		}
+		return
+	}
+	if details.BeaconWithdrawalCredentials != details.ExpectedWithdrawalCredentials {
+		addAuditIssue(details, auditSeverity_Critical, auditIssue_WrongBeaconCredentials, "The validator's withdrawal credentials on the Beacon Chain are %s instead of the minipool's %s.", details.BeaconWithdrawalCredentials.Hex(), details.ExpectedWithdrawalCredentials.Hex())
+	}
+}
+
+// Add an issue to a minipool's audit
+func addAuditIssue(details *api.MinipoolAuditDetails, severity string, issueType string, format string, args ...interface{}) {
+	details.Issues = append(details.Issues, api.MinipoolAuditIssue{
+		Severity: severity,
+		Type:     issueType,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// Convert a gwei amount to ETH for display
+func gweiToEth(gwei uint64) float64 {
+	return float64(gwei) / 1e9
+}
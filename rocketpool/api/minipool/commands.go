@@ -34,6 +34,24 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "audit",
+				Usage:     "Cross-check the node's minipools' deposits and withdrawal credentials against the deposit contract and the Beacon Chain",
+				UsageText: "rocketpool api minipool audit",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(auditMinipools(c))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "can-stake",
 				Usage:     "Check whether the minipool is ready to be staked, moving from prelaunch to staking status",
@@ -404,6 +422,28 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 
 				},
 			},
+			{
+				Name:      "can-set-use-latest-delegates",
+				Usage:     "Check which of the node's minipools can have the 'always use latest delegate' toggle changed to the provided setting",
+				UsageText: "rocketpool api minipool can-set-use-latest-delegates setting",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					setting, err := cliutils.ValidateBool("setting", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(canSetUseLatestDelegates(c, setting))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "set-use-latest-delegate",
 				Usage:     "Set whether or not to ignore the minipool's current delegate, and always use the latest delegate instead",
@@ -518,6 +558,24 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "get-delegate-status",
+				Usage:     "Get the delegate contracts and their versions for each of the node's minipools",
+				UsageText: "rocketpool api minipool get-delegate-status",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getDelegateStatus(c))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "get-vanity-artifacts",
 				Aliases:   []string{"v"},
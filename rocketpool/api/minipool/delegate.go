@@ -246,30 +246,13 @@ func canSetUseLatestDelegate(c *cli.Context, minipoolAddress common.Address, set
 		return nil, err
 	}
 
-	if !setting {
-		// Get the version and deposit type
-		depositType, err := minipool.GetMinipoolDepositType(rp, minipoolAddress, nil)
-		if err != nil {
-			return nil, fmt.Errorf("error getting minipool %s deposit type: %w", minipoolAddress.Hex(), err)
-		}
-		version := mp.GetVersion()
-		if depositType == rptypes.Variable && version == 3 {
-			// Get the previous delegate
-			oldDelegate, err := mp.GetDelegate(nil)
-			if err != nil {
-				return nil, fmt.Errorf("error getting old delegate for minipool %s: %w", minipoolAddress.Hex(), err)
-			}
-
-			// Get the version
-			oldDelegateVersion, err := rocketpool.GetContractVersion(rp, oldDelegate, nil)
-			if err != nil {
-				return nil, fmt.Errorf("error getting version of old delegate %s for minipool %s: %w", oldDelegate.Hex(), minipoolAddress.Hex(), err)
-			}
-
-			if oldDelegateVersion == 2 {
-				return nil, fmt.Errorf("you cannot unset 'use-latest-delegate' for minipool %s after reducing your ETH bond, as this would revert to the Redstone delegate and render your minipool unable to distribute its balance; please upgrade your minipool's delegate first before unsetting this flag", minipoolAddress.Hex())
-			}
-		}
+	// Check that the setting can be changed
+	reason, err := getUseLatestDelegateBlocker(rp, mp, setting)
+	if err != nil {
+		return nil, err
+	}
+	if reason != "" {
+		return nil, fmt.Errorf("%s", reason)
 	}
 
 	// Get gas estimate
@@ -311,30 +294,13 @@ func setUseLatestDelegate(c *cli.Context, minipoolAddress common.Address, settin
 		return nil, err
 	}
 
-	if !setting {
-		// Get the version and deposit type
-		depositType, err := minipool.GetMinipoolDepositType(rp, minipoolAddress, nil)
-		if err != nil {
-			return nil, fmt.Errorf("error getting minipool %s deposit type: %w", minipoolAddress.Hex(), err)
-		}
-		version := mp.GetVersion()
-		if depositType == rptypes.Variable && version == 3 {
-			// Get the previous delegate
-			oldDelegate, err := mp.GetDelegate(nil)
-			if err != nil {
-				return nil, fmt.Errorf("error getting old delegate for minipool %s: %w", minipoolAddress.Hex(), err)
-			}
-
-			// Get the version
-			oldDelegateVersion, err := rocketpool.GetContractVersion(rp, oldDelegate, nil)
-			if err != nil {
-				return nil, fmt.Errorf("error getting version of old delegate %s for minipool %s: %w", oldDelegate.Hex(), minipoolAddress.Hex(), err)
-			}
-
-			if oldDelegateVersion == 2 {
-				return nil, fmt.Errorf("you cannot unset 'use-latest-delegate' for minipool %s after reducing your ETH bond, as this would revert to the Redstone delegate and render your minipool unable to distribute its balance; please upgrade your minipool's delegate first before unsetting this flag", minipoolAddress.Hex())
-			}
-		}
+	// Check that the setting can be changed
+	reason, err := getUseLatestDelegateBlocker(rp, mp, setting)
+	if err != nil {
+		return nil, err
+	}
+	if reason != "" {
+		return nil, fmt.Errorf("%s", reason)
 	}
 
 	// Get transactor
@@ -488,3 +454,212 @@ func getEffectiveDelegate(c *cli.Context, minipoolAddress common.Address) (*api.
 	return &response, nil
 
 }
+
+func getDelegateStatus(c *cli.Context) (*api.MinipoolDelegateStatusResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.MinipoolDelegateStatusResponse{
+		Minipools: []api.MinipoolDelegateDetails{},
+	}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the latest delegate
+	latestDelegateAddress, err := rp.GetAddress("rocketMinipoolDelegate", nil)
+	if err != nil {
+		return nil, err
+	}
+	response.LatestDelegate = *latestDelegateAddress
+
+	// Delegates are shared by many minipools, so each one's version is only looked up once
+	versions := map[common.Address]uint8{}
+	getVersion := func(address common.Address) (uint8, error) {
+		if address == (common.Address{}) {
+			return 0, nil
+		}
+		if version, exists := versions[address]; exists {
+			return version, nil
+		}
+		version, err := rocketpool.GetContractVersion(rp, address, nil)
+		if err != nil {
+			return 0, fmt.Errorf("Error getting version of delegate %s: %w", address.Hex(), err)
+		}
+		versions[address] = version
+		return version, nil
+	}
+	response.LatestDelegateVersion, err = getVersion(response.LatestDelegate)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the delegates of each minipool
+	addresses, err := minipool.GetNodeMinipoolAddresses(rp, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, address := range addresses {
+		mp, err := minipool.NewMinipool(rp, address, nil)
+		if err != nil {
+			return nil, err
+		}
+		details := api.MinipoolDelegateDetails{
+			Address: address,
+		}
+		if details.Finalised, err = mp.GetFinalised(nil); err != nil {
+			return nil, fmt.Errorf("Error getting finalized status of minipool %s: %w", address.Hex(), err)
+		}
+		if details.UseLatestDelegate, err = mp.GetUseLatestDelegate(nil); err != nil {
+			return nil, fmt.Errorf("Error getting use latest delegate of minipool %s: %w", address.Hex(), err)
+		}
+		if details.Delegate, err = mp.GetDelegate(nil); err != nil {
+			return nil, fmt.Errorf("Error getting delegate of minipool %s: %w", address.Hex(), err)
+		}
+		if details.PreviousDelegate, err = mp.GetPreviousDelegate(nil); err != nil {
+			return nil, fmt.Errorf("Error getting previous delegate of minipool %s: %w", address.Hex(), err)
+		}
+		if details.EffectiveDelegate, err = mp.GetEffectiveDelegate(nil); err != nil {
+			return nil, fmt.Errorf("Error getting effective delegate of minipool %s: %w", address.Hex(), err)
+		}
+		if details.DelegateVersion, err = getVersion(details.Delegate); err != nil {
+			return nil, err
+		}
+		if details.PreviousDelegateVersion, err = getVersion(details.PreviousDelegate); err != nil {
+			return nil, err
+		}
+		if details.EffectiveDelegateVersion, err = getVersion(details.EffectiveDelegate); err != nil {
+			return nil, err
+		}
+		response.Minipools = append(response.Minipools, details)
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+func canSetUseLatestDelegates(c *cli.Context, setting bool) (*api.CanSetUseLatestDelegatesResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.CanSetUseLatestDelegatesResponse{
+		Setting:   setting,
+		Minipools: []api.MinipoolUseLatestDelegateDetails{},
+	}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+
+	// Check each of the node's minipools
+	addresses, err := minipool.GetNodeMinipoolAddresses(rp, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, address := range addresses {
+		mp, err := minipool.NewMinipool(rp, address, nil)
+		if err != nil {
+			return nil, err
+		}
+		details := api.MinipoolUseLatestDelegateDetails{
+			Address: address,
+		}
+		if details.UseLatestDelegate, err = mp.GetUseLatestDelegate(nil); err != nil {
+			return nil, fmt.Errorf("Error getting use latest delegate of minipool %s: %w", address.Hex(), err)
+		}
+		finalised, err := mp.GetFinalised(nil)
+		if err != nil {
+			return nil, fmt.Errorf("Error getting finalized status of minipool %s: %w", address.Hex(), err)
+		}
+		switch {
+		case finalised:
+			details.Reason = "the minipool has been finalized"
+		case details.UseLatestDelegate == setting:
+			details.Reason = fmt.Sprintf("the setting is already %t", setting)
+		default:
+			details.Reason, err = getUseLatestDelegateBlocker(rp, mp, setting)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if details.Reason == "" {
+			details.CanChange = true
+			gasInfo, err := mp.EstimateSetUseLatestDelegateGas(setting, opts)
+			if err == nil {
+				details.GasInfo = gasInfo
+			}
+		}
+		response.Minipools = append(response.Minipools, details)
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Get the reason a minipool's use-latest-delegate setting can't be changed, or an empty string if it can
+func getUseLatestDelegateBlocker(rp *rocketpool.RocketPool, mp minipool.Minipool, setting bool) (string, error) {
+	if setting {
+		return "", nil
+	}
+
+	// Get the version and deposit type
+	minipoolAddress := mp.GetAddress()
+	depositType, err := minipool.GetMinipoolDepositType(rp, minipoolAddress, nil)
+	if err != nil {
+		return "", fmt.Errorf("error getting minipool %s deposit type: %w", minipoolAddress.Hex(), err)
+	}
+	if depositType != rptypes.Variable || mp.GetVersion() != 3 {
+		return "", nil
+	}
+
+	// Get the previous delegate
+	oldDelegate, err := mp.GetDelegate(nil)
+	if err != nil {
+		return "", fmt.Errorf("error getting old delegate for minipool %s: %w", minipoolAddress.Hex(), err)
+	}
+
+	// Get the version
+	oldDelegateVersion, err := rocketpool.GetContractVersion(rp, oldDelegate, nil)
+	if err != nil {
+		return "", fmt.Errorf("error getting version of old delegate %s for minipool %s: %w", oldDelegate.Hex(), minipoolAddress.Hex(), err)
+	}
+	if oldDelegateVersion == 2 {
+		return fmt.Sprintf("you cannot unset 'use-latest-delegate' for minipool %s after reducing your ETH bond, as this would revert to the Redstone delegate and render your minipool unable to distribute its balance; please upgrade your minipool's delegate first before unsetting this flag", minipoolAddress.Hex()), nil
+	}
+	return "", nil
+}
@@ -11,6 +11,7 @@ import (
 	eth2types "github.com/wealdtech/go-eth2-types/v2"
 
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	"github.com/rocket-pool/smartnode/shared/utils/validator"
 )
@@ -24,6 +25,10 @@ func importKey(c *cli.Context, minipoolAddress common.Address, mnemonic string)
 	if err := services.RequireBeaconClientSynced(c); err != nil {
 		return nil, err
 	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
 	w, err := services.GetWallet(c)
 	if err != nil {
 		return nil, err
@@ -61,6 +66,15 @@ func importKey(c *cli.Context, minipoolAddress common.Address, mnemonic string)
 		return nil, fmt.Errorf("minipool %s does not have a validator pubkey associated with it", minipoolAddress.Hex())
 	}
 
+	// DVT minipools are operated by the cluster, so their keys can't be loaded into the Validator client
+	dvtSettings, err := config.LoadDvtSettings(cfg.Smartnode.GetDvtSettingsPath())
+	if err != nil {
+		return nil, err
+	}
+	if _, isDvt := dvtSettings.Minipools[minipoolAddress]; isDvt {
+		return nil, fmt.Errorf("minipool %s is operated by your DVT cluster; importing its key into your Validator client could get it slashed", minipoolAddress.Hex())
+	}
+
 	// Get the index for this validator based on the mnemonic
 	index := uint(0)
 	validatorKeyPath := validator.ValidatorKeyPath
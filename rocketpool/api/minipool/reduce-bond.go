@@ -12,8 +12,8 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	"github.com/rocket-pool/smartnode/shared/utils/eth1"
+	"github.com/rocket-pool/smartnode/shared/utils/math"
 	"github.com/urfave/cli"
-	"golang.org/x/sync/errgroup"
 )
 
 func canBeginReduceBondAmount(c *cli.Context, minipoolAddress common.Address, newBondAmountWei *big.Int) (*api.CanBeginReduceBondAmountResponse, error) {
@@ -38,31 +38,31 @@ func canBeginReduceBondAmount(c *cli.Context, minipoolAddress common.Address, ne
 	response := api.CanBeginReduceBondAmountResponse{}
 
 	// Data
-	var wg errgroup.Group
+	preflight := services.NewPreflightRunner()
 	var nodeDepositAmount *big.Int
 
 	// Check if bond reduction is enabled
-	wg.Go(func() error {
+	preflight.Check("Bond reductions enabled", "Bond reductions are currently disabled.", func() (bool, error) {
 		bondReductionEnabled, err := protocol.GetBondReductionEnabled(rp, nil)
 		if err != nil {
-			return fmt.Errorf("error checking if bond reduction is enabled: %w", err)
+			return false, fmt.Errorf("error checking if bond reduction is enabled: %w", err)
 		}
 		response.BondReductionDisabled = !bondReductionEnabled
-		return nil
+		return bondReductionEnabled, nil
 	})
 
 	// Check the minipool version
-	wg.Go(func() error {
+	preflight.Check("Minipool version", "The minipool version is too low. It must be upgraded first using `rocketpool minipool delegate-upgrade`.", func() (bool, error) {
 		version, err := rocketpool.GetContractVersion(rp, minipoolAddress, nil)
 		if err != nil {
-			return fmt.Errorf("error getting minipool %s contract version: %w", minipoolAddress.Hex(), err)
+			return false, fmt.Errorf("error getting minipool %s contract version: %w", minipoolAddress.Hex(), err)
 		}
 		response.MinipoolVersionTooLow = (version < 3)
-		return nil
+		return !response.MinipoolVersionTooLow, nil
 	})
 
 	// Check the balance and status on Beacon
-	wg.Go(func() error {
+	preflight.Load(func() error {
 		var err error
 		pubkey, err := minipool.GetMinipoolPubkey(rp, minipoolAddress, nil)
 		if err != nil {
@@ -78,7 +78,7 @@ func canBeginReduceBondAmount(c *cli.Context, minipoolAddress common.Address, ne
 	})
 
 	// Get match request info
-	wg.Go(func() error {
+	preflight.Load(func() error {
 		mp, err := minipool.NewMinipool(rp, minipoolAddress, nil)
 		if err != nil {
 			return fmt.Errorf("error creating binding for minipool %s: %w", minipoolAddress.Hex(), err)
@@ -92,21 +92,27 @@ func canBeginReduceBondAmount(c *cli.Context, minipoolAddress common.Address, ne
 		return nil
 	})
 
-	// Wait for data
-	if err := wg.Wait(); err != nil {
-		return nil, err
-	}
-
 	// Check the beacon state
-	response.InvalidBeaconState = !(response.BeaconState == beacon.ValidatorState_PendingInitialized ||
-		response.BeaconState == beacon.ValidatorState_PendingQueued ||
-		response.BeaconState == beacon.ValidatorState_ActiveOngoing)
+	preflight.CheckWithReason("Beacon Chain state", func() (bool, string, error) {
+		response.InvalidBeaconState = !(response.BeaconState == beacon.ValidatorState_PendingInitialized ||
+			response.BeaconState == beacon.ValidatorState_PendingQueued ||
+			response.BeaconState == beacon.ValidatorState_ActiveOngoing)
+		return !response.InvalidBeaconState, fmt.Sprintf("The minipool's validator is not in a legal state on the Beacon Chain. It must be pending or active (current state: %s).", response.BeaconState), nil
+	})
 
 	// Make sure the balance is high enough
-	threshold := uint64(32000000000)
-	response.BalanceTooLow = response.Balance < threshold
+	preflight.CheckWithReason("Beacon Chain balance", func() (bool, string, error) {
+		threshold := uint64(32000000000)
+		response.BalanceTooLow = response.Balance < threshold
+		return !response.BalanceTooLow, fmt.Sprintf("The minipool's validator balance on the Beacon Chain is too low (must be 32 ETH or higher, currently %.6f ETH).", math.RoundDown(float64(response.Balance)/1e9, 6)), nil
+	})
 
-	response.CanReduce = !(response.BondReductionDisabled || response.MinipoolVersionTooLow || response.BalanceTooLow || response.InvalidBeaconState)
+	// Wait for data
+	response.Preflight, err = preflight.Run()
+	if err != nil {
+		return nil, err
+	}
+	response.CanReduce = response.Preflight.Passed
 
 	// Get gas estimate
 	opts, err := w.GetNodeAccountTransactor()
@@ -16,7 +16,9 @@ import (
 
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/services/contracts"
+	"github.com/rocket-pool/smartnode/shared/services/dvt"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	"github.com/rocket-pool/smartnode/shared/utils/eth1"
@@ -29,6 +31,10 @@ func getMinipoolRescueDissolvedDetailsForNode(c *cli.Context) (*api.GetMinipoolR
 	if err := services.RequireNodeRegistered(c); err != nil {
 		return nil, err
 	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
 	w, err := services.GetWallet(c)
 	if err != nil {
 		return nil, err
@@ -73,7 +79,7 @@ func getMinipoolRescueDissolvedDetailsForNode(c *cli.Context) (*api.GetMinipoolR
 			mi := mi
 			wg.Go(func() error {
 				address := addresses[mi]
-				mpDetails, err := getMinipoolRescueDissolvedDetails(rp, w, bc, address, nodeAccount.Address)
+				mpDetails, err := getMinipoolRescueDissolvedDetails(cfg, rp, w, bc, address, nodeAccount.Address)
 				if err == nil {
 					details[mi] = mpDetails
 				}
@@ -91,7 +97,7 @@ func getMinipoolRescueDissolvedDetailsForNode(c *cli.Context) (*api.GetMinipoolR
 
 }
 
-func getMinipoolRescueDissolvedDetails(rp *rocketpool.RocketPool, w *wallet.Wallet, bc beacon.Client, minipoolAddress common.Address, nodeAddress common.Address) (api.MinipoolRescueDissolvedDetails, error) {
+func getMinipoolRescueDissolvedDetails(cfg *config.RocketPoolConfig, rp *rocketpool.RocketPool, w *wallet.Wallet, bc beacon.Client, minipoolAddress common.Address, nodeAddress common.Address) (api.MinipoolRescueDissolvedDetails, error) {
 
 	// Create minipool
 	mp, err := minipool.NewMinipool(rp, minipoolAddress, nil)
@@ -193,7 +199,7 @@ func getMinipoolRescueDissolvedDetails(rp *rocketpool.RocketPool, w *wallet.Wall
 	opts.GasLimit = 0
 
 	// Get the gas info for depositing
-	tx, err := getDepositTx(rp, w, bc, minipoolAddress, one, opts)
+	tx, err := getDepositTx(cfg, rp, w, bc, minipoolAddress, one, opts)
 	if err != nil {
 		return api.MinipoolRescueDissolvedDetails{}, fmt.Errorf("error estimating gas for rescue deposit on minipool %s: %w", minipoolAddress.Hex(), err)
 	}
@@ -216,7 +222,7 @@ func getMinipoolRescueDissolvedDetails(rp *rocketpool.RocketPool, w *wallet.Wall
 }
 
 // Create a transaction for submitting a rescue deposit, optionally simulating it only for gas estimation
-func getDepositTx(rp *rocketpool.RocketPool, w *wallet.Wallet, bc beacon.Client, minipoolAddress common.Address, amount *big.Int, opts *bind.TransactOpts) (*types.Transaction, error) {
+func getDepositTx(cfg *config.RocketPoolConfig, rp *rocketpool.RocketPool, w *wallet.Wallet, bc beacon.Client, minipoolAddress common.Address, amount *big.Int, opts *bind.TransactOpts) (*types.Transaction, error) {
 
 	blankAddress := common.Address{}
 	casperAddress, err := rp.GetAddress("casperDeposit", nil)
@@ -255,7 +261,7 @@ func getDepositTx(rp *rocketpool.RocketPool, w *wallet.Wallet, bc beacon.Client,
 	if err != nil {
 		return nil, err
 	}
-	validatorKey, err := w.GetValidatorKeyByPubkey(validatorPubkey)
+	validatorKey, err := dvt.GetValidatorKey(cfg, w, validatorPubkey)
 	if err != nil {
 		return nil, err
 	}
@@ -287,6 +293,10 @@ func rescueDissolvedMinipool(c *cli.Context, minipoolAddress common.Address, amo
 	if err := services.RequireNodeRegistered(c); err != nil {
 		return nil, err
 	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
 	w, err := services.GetWallet(c)
 	if err != nil {
 		return nil, err
@@ -317,7 +327,7 @@ func rescueDissolvedMinipool(c *cli.Context, minipoolAddress common.Address, amo
 	}
 
 	// Submit the rescue deposit
-	tx, err := getDepositTx(rp, w, bc, minipoolAddress, amount, opts)
+	tx, err := getDepositTx(cfg, rp, w, bc, minipoolAddress, amount, opts)
 	if err != nil {
 		return nil, fmt.Errorf("error submitting rescue deposit: %w", err)
 	}
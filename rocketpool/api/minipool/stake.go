@@ -12,6 +12,7 @@ import (
 
 	rptypes "github.com/rocket-pool/rocketpool-go/types"
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/dvt"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	"github.com/rocket-pool/smartnode/shared/utils/eth1"
 	"github.com/rocket-pool/smartnode/shared/utils/validator"
@@ -23,6 +24,10 @@ func canStakeMinipool(c *cli.Context, minipoolAddress common.Address) (*api.CanS
 	if err := services.RequireNodeRegistered(c); err != nil {
 		return nil, err
 	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
 	w, err := services.GetWallet(c)
 	if err != nil {
 		return nil, err
@@ -103,7 +108,7 @@ func canStakeMinipool(c *cli.Context, minipoolAddress common.Address) (*api.CanS
 		if err != nil {
 			return nil, err
 		}
-		validatorKey, err := w.GetValidatorKeyByPubkey(validatorPubkey)
+		validatorKey, err := dvt.GetValidatorKey(cfg, w, validatorPubkey)
 		if err != nil {
 			return nil, err
 		}
@@ -155,6 +160,10 @@ func stakeMinipool(c *cli.Context, minipoolAddress common.Address) (*api.StakeMi
 	if err := services.RequireNodeRegistered(c); err != nil {
 		return nil, err
 	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
 	w, err := services.GetWallet(c)
 	if err != nil {
 		return nil, err
@@ -206,7 +215,7 @@ func stakeMinipool(c *cli.Context, minipoolAddress common.Address) (*api.StakeMi
 	if err != nil {
 		return nil, err
 	}
-	validatorKey, err := w.GetValidatorKeyByPubkey(validatorPubkey)
+	validatorKey, err := dvt.GetValidatorKey(cfg, w, validatorPubkey)
 	if err != nil {
 		return nil, err
 	}
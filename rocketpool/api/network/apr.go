@@ -0,0 +1,126 @@
+package network
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/rocket-pool/rocketpool-go/network"
+	"github.com/urfave/cli"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+// The number of days used for the trailing rETH APR
+const rethAprDays uint64 = 7
+
+// Get the trailing rETH APR and an estimate of the node's APR
+func GetApr(c *cli.Context) (*api.NetworkAprResponse, error) {
+
+	// Get services
+	if err := services.RequireRocketStorage(c); err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NetworkAprResponse{}
+
+	// Sync
+	var wg errgroup.Group
+	var stakerUtilization float64
+	var nodeFee float64
+
+	// Get the ETH utilization rate
+	wg.Go(func() error {
+		var err error
+		stakerUtilization, err = network.GetETHUtilizationRate(rp, nil)
+		return err
+	})
+
+	// Get node fee
+	wg.Go(func() error {
+		var err error
+		nodeFee, err = network.GetNodeFee(rp, nil)
+		return err
+	})
+
+	// Get the trailing rETH APR
+	wg.Go(func() error {
+		eventLogInterval, err := cfg.GetEventLogInterval()
+		if err != nil {
+			return err
+		}
+		rethApr, _, err := rputils.GetTrailingRethApr(rp, rethAprDays, big.NewInt(int64(eventLogInterval)))
+		if err == nil {
+			response.RethApr = rethApr
+		}
+		return err
+	})
+
+	// Wait for data
+	if err := wg.Wait(); err != nil {
+		return nil, err
+	}
+
+	// Get the implied validator APR and the node's APR estimate
+	response.ValidatorApr = rputils.GetValidatorAprFromRethApr(response.RethApr, stakerUtilization, nodeFee)
+	if err := getNodeApr(c, &response); err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Estimate the APR of the node if the wallet has been initialized
+func getNodeApr(c *cli.Context, response *api.NetworkAprResponse) error {
+
+	// Get services
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return err
+	}
+	if !w.IsInitialized() {
+		return nil
+	}
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil
+	}
+	mgr, err := services.GetNetworkStateManager(c)
+	if err != nil {
+		return err
+	}
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+
+	// Get the node's state
+	networkState, totalEffectiveStake, err := mgr.GetHeadStateForNode(nodeAccount.Address, true)
+	if err != nil {
+		return fmt.Errorf("error getting network state: %w", err)
+	}
+
+	// Estimate the APR
+	nodeApr, err := rputils.EstimateNodeApr(networkState, nodeAccount.Address, totalEffectiveStake, response.ValidatorApr)
+	if err != nil {
+		return err
+	}
+	response.NodeAprAvailable = true
+	response.NodeEthApr = nodeApr.EthApr
+	response.NodeRplApr = nodeApr.RplApr
+	response.NodeTotalApr = nodeApr.TotalApr
+	return nil
+
+}
@@ -34,6 +34,64 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "get-gas-fee-history",
+				Usage:     "Get the base fees and priority fees paid in the latest blocks",
+				UsageText: "rocketpool api network get-gas-fee-history",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getGasFeeHistory(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "get-fiat-prices",
+				Usage:     "Get the current prices of ETH and RPL in the configured fiat currency",
+				UsageText: "rocketpool api network get-fiat-prices",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getFiatPrices(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "get-node-fee-history",
+				Usage:     "Get the node commission curve and the commission of minipools created over the last few days",
+				UsageText: "rocketpool api network get-node-fee-history days",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					days, err := cliutils.ValidatePositiveUint("days", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getNodeFeeHistory(c, days))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "rpl-price",
 				Aliases:   []string{"p"},
@@ -72,6 +130,42 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "apr",
+				Usage:     "Get the trailing rETH APR and an estimate of the node's APR",
+				UsageText: "rocketpool api network apr",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(GetApr(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "stats-snapshot",
+				Usage:     "Get a signed snapshot of network-wide stats for publishing",
+				UsageText: "rocketpool api network stats-snapshot",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getStatsSnapshot(c))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "timezone-map",
 				Aliases:   []string{"t"},
@@ -137,6 +231,46 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "decode-proposal-payload",
+				Usage:     "Decode the payload of an oracle DAO or protocol DAO proposal into what it will do",
+				UsageText: "rocketpool api network decode-proposal-payload dao payload",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					payload, err := cliutils.ValidateByteArray("payload", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(decodeProposalPayload(c, c.Args().Get(0), payload))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "get-rewards-tree-progress",
+				Usage:     "Get the progress of the rewards tree the watchtower is generating, or the last one it generated",
+				UsageText: "rocketpool api network get-rewards-tree-progress",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getRewardsTreeProgress(c))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "dao-proposals",
 				Aliases:   []string{"d"},
@@ -216,6 +350,24 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 
 				},
 			},
+
+			{
+				Name:      "contracts",
+				Usage:     "Get the current address and version of each Rocket Pool contract.",
+				UsageText: "rocketpool api network contracts",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getContracts(c))
+					return nil
+
+				},
+			},
 		},
 	})
 }
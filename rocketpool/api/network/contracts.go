@@ -0,0 +1,38 @@
+package network
+
+import (
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/progress"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Get the current address and version of each Rocket Pool contract
+func getContracts(c *cli.Context) (*api.NetworkContractsResponse, error) {
+
+	// Get services
+	if err := services.RequireRocketStorage(c); err != nil {
+		return nil, err
+	}
+	registry, err := services.GetContractRegistry(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NetworkContractsResponse{}
+
+	// Load the contracts, catching up on any upgrades since the history was last updated
+	reporter := progress.NewStderrReporter("Loading contracts")
+	_, err = registry.Refresh(reporter)
+	reporter.Finish(err)
+	if err != nil {
+		return nil, err
+	}
+	response.Contracts = registry.GetContracts()
+
+	// Return response
+	return &response, nil
+
+}
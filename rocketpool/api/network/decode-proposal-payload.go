@@ -0,0 +1,38 @@
+package network
+
+import (
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/daopayload"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func decodeProposalPayload(c *cli.Context, daoName string, payload []byte) (*api.DecodeProposalPayloadResponse, error) {
+
+	// Get services
+	if err := services.RequireRocketStorage(c); err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.DecodeProposalPayloadResponse{}
+
+	// Decode the payload with the DAO's proposals contract
+	contractName, err := daopayload.GetProposalsContractName(daoName)
+	if err != nil {
+		return nil, err
+	}
+	response.Payload, err = daopayload.Decode(rp, contractName, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
@@ -0,0 +1,41 @@
+package network
+
+import (
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/prices"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// How long fetched prices are reused before they're refreshed
+const fiatPriceTtl time.Duration = 5 * time.Minute
+
+func getFiatPrices(c *cli.Context) (*api.FiatPricesResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.FiatPricesResponse{}
+
+	// Get the prices
+	priceClient := prices.NewClient(cfg.Smartnode.PriceApiUrl.Value.(string), cfg.Smartnode.FiatCurrency.Value.(string))
+	currentPrices, err := priceClient.GetCachedCurrentPrices(cfg.Smartnode.GetFiatPriceCachePath(), fiatPriceTtl)
+	if err != nil {
+		return nil, err
+	}
+	response.Currency = currentPrices.Currency
+	response.EthPrice = currentPrices.Eth
+	response.RplPrice = currentPrices.Rpl
+	response.PriceTime = currentPrices.Time
+
+	// Return response
+	return &response, nil
+
+}
@@ -0,0 +1,54 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// How many recent blocks the gas price suggestions are based on
+const gasFeeHistoryBlocks uint64 = 20
+
+func getGasFeeHistory(c *cli.Context) (*api.GasFeeHistoryResponse, error) {
+
+	// Get services
+	if err := services.RequireEthClientSynced(c); err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.GasFeeHistoryResponse{
+		Percentiles: api.GasFeeHistoryPercentiles,
+	}
+
+	// Get the fee history of the latest blocks
+	history, err := ec.FeeHistory(context.Background(), gasFeeHistoryBlocks, nil, api.GasFeeHistoryPercentiles)
+	if err != nil {
+		return nil, fmt.Errorf("error getting the fee history: %w", err)
+	}
+	if len(history.BaseFee) == 0 {
+		return nil, fmt.Errorf("the execution client didn't return any fee history")
+	}
+
+	// The base fees include the one for the next block
+	response.BaseFees = history.BaseFee[:len(history.BaseFee)-1]
+	response.NextBaseFee = history.BaseFee[len(history.BaseFee)-1]
+	response.PriorityFees = history.Reward
+	if response.PriorityFees == nil {
+		response.PriorityFees = [][]*big.Int{}
+	}
+	response.GasUsedRatios = history.GasUsedRatio
+
+	// Return response
+	return &response, nil
+
+}
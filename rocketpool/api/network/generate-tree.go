@@ -7,6 +7,7 @@ import (
 	"github.com/fatih/color"
 	"github.com/rocket-pool/rocketpool-go/rewards"
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/progress"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	"github.com/urfave/cli"
 )
@@ -75,3 +76,24 @@ func generateRewardsTree(c *cli.Context, index uint64) (*api.NetworkGenerateRewa
 	return &response, nil
 
 }
+
+func getRewardsTreeProgress(c *cli.Context) (*api.NetworkRewardsTreeProgressResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NetworkRewardsTreeProgressResponse{}
+
+	// Load the progress saved by the watchtower
+	response.Progress, err = progress.Load(cfg.Smartnode.GetRewardsTreeProgressPath(true))
+	if err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+
+}
@@ -0,0 +1,225 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/network"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/settings/protocol"
+	"github.com/urfave/cli"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/logscan"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Settings
+const (
+	nodeFeeHistoryBlocksPerDay uint64 = 7200
+	nodeFeeHistoryThreadLimit  int    = 6
+	nodeFeeCurveDivisor        int64  = 4
+)
+
+// The points the fee curve is shown at, in quarters of the demand range
+var nodeFeeCurvePoints = []int64{-4, -2, -1, 0, 1, 2, 4}
+
+func getNodeFeeHistory(c *cli.Context, days uint64) (*api.NodeFeeHistoryResponse, error) {
+
+	// Get services
+	if err := services.RequireRocketStorage(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeFeeHistoryResponse{
+		Days:    days,
+		Curve:   []api.NodeFeeCurvePoint{},
+		History: []api.NodeFeeHistoryDay{},
+	}
+
+	// Sync
+	var wg errgroup.Group
+
+	// Get the current fee and the curve parameters
+	wg.Go(func() error {
+		var err error
+		response.NodeFee, err = network.GetNodeFee(rp, nil)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		response.NodeDemand, err = network.GetNodeDemand(rp, nil)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		response.DemandRange, err = protocol.GetNodeFeeDemandRange(rp, nil)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		response.MinNodeFee, err = protocol.GetMinimumNodeFee(rp, nil)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		response.TargetNodeFee, err = protocol.GetTargetNodeFee(rp, nil)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		response.MaxNodeFee, err = protocol.GetMaximumNodeFee(rp, nil)
+		return err
+	})
+
+	// Wait for data
+	if err := wg.Wait(); err != nil {
+		return nil, err
+	}
+
+	// Get the fee at points along the demand range
+	for _, point := range nodeFeeCurvePoints {
+		demand := big.NewInt(point)
+		demand.Mul(demand, response.DemandRange)
+		demand.Div(demand, big.NewInt(nodeFeeCurveDivisor))
+		fee, err := network.GetNodeFeeByDemand(rp, demand, nil)
+		if err != nil {
+			return nil, err
+		}
+		response.Curve = append(response.Curve, api.NodeFeeCurvePoint{
+			Demand: demand,
+			Fee:    fee,
+		})
+	}
+
+	// Get the minipools created in the window; each one keeps the fee it was created with
+	eventLogInterval, err := cfg.GetEventLogInterval()
+	if err != nil {
+		return nil, err
+	}
+	minipools, err := getCreatedMinipools(rp, days, big.NewInt(int64(eventLogInterval)))
+	if err != nil {
+		return nil, err
+	}
+	fees := make([]float64, len(minipools))
+	var feeWg errgroup.Group
+	feeWg.SetLimit(nodeFeeHistoryThreadLimit)
+	for i, created := range minipools {
+		i := i
+		address := created.address
+		feeWg.Go(func() error {
+			mp, err := minipool.NewMinipool(rp, address, nil)
+			if err != nil {
+				return fmt.Errorf("error creating binding for minipool %s: %w", address.Hex(), err)
+			}
+			fees[i], err = mp.GetNodeFee(nil)
+			if err != nil {
+				return fmt.Errorf("error getting the commission of minipool %s: %w", address.Hex(), err)
+			}
+			return nil
+		})
+	}
+	if err := feeWg.Wait(); err != nil {
+		return nil, err
+	}
+
+	// Summarize the fees by day
+	daysByDate := map[time.Time]*api.NodeFeeHistoryDay{}
+	for i, created := range minipools {
+		date := created.time.UTC().Truncate(24 * time.Hour)
+		day, exists := daysByDate[date]
+		if !exists {
+			day = &api.NodeFeeHistoryDay{
+				Date:   date,
+				MinFee: fees[i],
+				MaxFee: fees[i],
+			}
+			daysByDate[date] = day
+		}
+		if fees[i] < day.MinFee {
+			day.MinFee = fees[i]
+		}
+		if fees[i] > day.MaxFee {
+			day.MaxFee = fees[i]
+		}
+		day.AverageFee = (day.AverageFee*float64(day.Minipools) + fees[i]) / float64(day.Minipools+1)
+		day.Minipools++
+	}
+	for _, day := range daysByDate {
+		response.History = append(response.History, *day)
+	}
+	sort.Slice(response.History, func(i, j int) bool {
+		return response.History[i].Date.Before(response.History[j].Date)
+	})
+
+	// Return response
+	return &response, nil
+
+}
+
+// A minipool found in the minipool manager's creation events
+type createdMinipool struct {
+	address common.Address
+	time    time.Time
+}
+
+// Get the minipools created over the last few days
+func getCreatedMinipools(rp *rocketpool.RocketPool, days uint64, intervalSize *big.Int) ([]createdMinipool, error) {
+	rocketMinipoolManager, err := rp.GetContract("rocketMinipoolManager", nil)
+	if err != nil {
+		return nil, err
+	}
+	minipoolCreated, exists := rocketMinipoolManager.ABI.Events["MinipoolCreated"]
+	if !exists {
+		return nil, fmt.Errorf("rocketMinipoolManager does not have a MinipoolCreated event")
+	}
+
+	// Get the logs for the window
+	currentBlock, err := rp.Client.BlockNumber(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("error getting latest block number: %w", err)
+	}
+	fromBlock := uint64(0)
+	if currentBlock > days*nodeFeeHistoryBlocksPerDay {
+		fromBlock = currentBlock - days*nodeFeeHistoryBlocksPerDay
+	}
+	logs, err := logscan.GetLogs(rp, []common.Address{*rocketMinipoolManager.Address}, [][]common.Hash{{minipoolCreated.ID}}, intervalSize, big.NewInt(0).SetUint64(fromBlock), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting minipool creation events: %w", err)
+	}
+
+	minipools := []createdMinipool{}
+	for _, log := range logs {
+		if len(log.Topics) < 2 {
+			continue
+		}
+		values := make(map[string]interface{})
+		if err := minipoolCreated.Inputs.UnpackIntoMap(values, log.Data); err != nil {
+			return nil, fmt.Errorf("error unpacking minipool creation in block %d: %w", log.BlockNumber, err)
+		}
+		timestamp, ok := values["time"].(*big.Int)
+		if !ok {
+			continue
+		}
+		minipools = append(minipools, createdMinipool{
+			address: common.BytesToAddress(log.Topics[1].Bytes()),
+			time:    time.Unix(timestamp.Int64(), 0),
+		})
+	}
+	return minipools, nil
+}
@@ -0,0 +1,69 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/netstats"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+func getStatsSnapshot(c *cli.Context) (*api.NetworkStatsSnapshotResponse, error) {
+
+	// Get services
+	if err := services.RequireRocketStorage(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NetworkStatsSnapshotResponse{}
+
+	// Take the snapshot at the latest block
+	header, err := rp.Client.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting latest block header: %w", err)
+	}
+	networkName := string(cfg.Smartnode.Network.Value.(cfgtypes.Network))
+	snapshot, err := netstats.CreateSnapshot(rp, networkName, header.Number.Uint64(), time.Unix(int64(header.Time), 0))
+	if err != nil {
+		return nil, err
+	}
+
+	// Sign it with the node wallet if there is one
+	var signer common.Address
+	var sign func(message string) ([]byte, error)
+	if w.IsInitialized() {
+		nodeAccount, err := w.GetNodeAccount()
+		if err != nil {
+			return nil, err
+		}
+		signer = nodeAccount.Address
+		sign = w.SignMessage
+	}
+	response.Snapshot, err = netstats.SignSnapshot(snapshot, signer, sign)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
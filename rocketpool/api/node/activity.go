@@ -0,0 +1,79 @@
+package node
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/activity"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/progress"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getActivity(c *cli.Context, category activity.Category, limit uint64) (*api.NodeActivityResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeActivityResponse{}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Bring the index up to date; the daemon usually keeps it current, so this only covers the last few blocks
+	reporter := progress.NewStderrReporter("Indexing node activity")
+	db, err := updateActivityDatabase(cfg, rp, nodeAccount.Address, reporter)
+	reporter.Finish(err)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	// Get the events
+	_, response.ScannedBlock, err = db.GetScanState()
+	if err != nil {
+		return nil, err
+	}
+	response.Events, err = db.GetEvents(activity.Filter{
+		Category: category,
+		Limit:    limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Bring the node's activity database up to date and open it
+func updateActivityDatabase(cfg *config.RocketPoolConfig, rp *rocketpool.RocketPool, nodeAddress common.Address, reporter *progress.Reporter) (*activity.Database, error) {
+	eventLogInterval, err := cfg.GetEventLogInterval()
+	if err != nil {
+		return nil, err
+	}
+	return activity.UpdateDatabase(rp, cfg.Smartnode.GetActivityDatabasePath(), cfg.Smartnode.GetUpgradeHistoryPath(), nodeAddress, big.NewInt(int64(eventLogInterval)), cfg.Smartnode.ConfirmationDepth.Value.(uint64), reporter)
+}
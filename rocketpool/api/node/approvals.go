@@ -0,0 +1,341 @@
+package node
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/tokens"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/progress"
+	"github.com/rocket-pool/smartnode/shared/services/upgrades"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/eth1"
+)
+
+// The tokens the node can grant allowances for, and the contract each one lives at
+var approvalTokenContracts = map[string]string{
+	"rpl":   "rocketTokenRPL",
+	"fsrpl": "rocketTokenRPLFixedSupply",
+	"reth":  "rocketTokenRETH",
+}
+
+// The Rocket Pool contracts the node may have granted allowances to
+var approvalSpenderContracts = []string{
+	"rocketNodeStaking",
+	"rocketDAONodeTrustedActions",
+	"rocketTokenRPL",
+	"rocketDepositPool",
+	"rocketNodeDeposit",
+	"rocketVault",
+}
+
+// The allowances the Smartnode sets itself, which are always listed even when they're empty
+var expectedApprovals = map[string][]string{
+	"rpl":   {"rocketNodeStaking", "rocketDAONodeTrustedActions"},
+	"fsrpl": {"rocketTokenRPL"},
+}
+
+// A Rocket Pool contract address that can be granted an allowance
+type approvalSpender struct {
+	name    string
+	address common.Address
+	current bool
+}
+
+func getApprovals(c *cli.Context) (*api.NodeApprovalsResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireRocketStorage(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeApprovalsResponse{
+		Approvals: []api.TokenApproval{},
+	}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the current and previous addresses of every spender
+	reporter := progress.NewStderrReporter("Loading approvals")
+	spenders, err := getApprovalSpenders(rp, cfg, reporter)
+	if err != nil {
+		reporter.Finish(err)
+		return nil, err
+	}
+
+	// Get the allowance of every token for every spender
+	reporter.SetPhase("Checking allowances", uint64(len(approvalTokenContracts)*len(spenders)))
+	checked := uint64(0)
+	for _, token := range []string{"rpl", "fsrpl", "reth"} {
+		tokenAddress, err := rp.GetAddress(approvalTokenContracts[token], nil)
+		if err != nil {
+			reporter.Finish(err)
+			return nil, err
+		}
+		for _, spender := range spenders {
+			allowance, err := getTokenAllowance(rp, token, nodeAccount.Address, spender.address)
+			if err != nil {
+				reporter.Finish(err)
+				return nil, fmt.Errorf("Error getting the %s allowance of %s (%s): %w", token, spender.name, spender.address.Hex(), err)
+			}
+			checked++
+			reporter.SetProgress(checked)
+			if allowance.Sign() == 0 && !(spender.current && isExpectedApproval(token, spender.name)) {
+				continue
+			}
+			response.Approvals = append(response.Approvals, api.TokenApproval{
+				Token:          token,
+				TokenAddress:   *tokenAddress,
+				Spender:        spender.name,
+				SpenderAddress: spender.address,
+				Current:        spender.current,
+				Allowance:      allowance,
+			})
+		}
+	}
+	reporter.Finish(nil)
+
+	// Return response
+	return &response, nil
+
+}
+
+func canSetApproval(c *cli.Context, token string, spenderAddress common.Address, amountWei *big.Int) (*api.CanNodeSetApprovalResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireRocketStorage(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.CanNodeSetApprovalResponse{}
+
+	// Only allowances for Rocket Pool contracts can be managed here
+	spenders, err := getApprovalSpenders(rp, cfg, nil)
+	if err != nil {
+		return nil, err
+	}
+	response.UnknownSpender = !isApprovalSpender(spenders, spenderAddress)
+	response.CanSet = !response.UnknownSpender
+	if !response.CanSet {
+		return &response, nil
+	}
+
+	// Get gas estimates
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+	var gasInfo rocketpool.GasInfo
+	switch token {
+	case "rpl":
+		gasInfo, err = tokens.EstimateApproveRPLGas(rp, spenderAddress, amountWei, opts)
+	case "fsrpl":
+		gasInfo, err = tokens.EstimateApproveFixedSupplyRPLGas(rp, spenderAddress, amountWei, opts)
+	case "reth":
+		gasInfo, err = tokens.EstimateApproveRETHGas(rp, spenderAddress, amountWei, opts)
+	default:
+		err = fmt.Errorf("Unknown token type '%s'", token)
+	}
+	if err != nil {
+		return nil, err
+	}
+	response.GasInfo = gasInfo
+
+	// Return response
+	return &response, nil
+
+}
+
+func setApproval(c *cli.Context, token string, spenderAddress common.Address, amountWei *big.Int) (*api.NodeSetApprovalResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireRocketStorage(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeSetApprovalResponse{}
+
+	// Only allowances for Rocket Pool contracts can be managed here
+	spenders, err := getApprovalSpenders(rp, cfg, nil)
+	if err != nil {
+		return nil, err
+	}
+	if !isApprovalSpender(spenders, spenderAddress) {
+		return nil, fmt.Errorf("%s is not a current or previous Rocket Pool contract.", spenderAddress.Hex())
+	}
+
+	// Get transactor
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+
+	// Override the provided pending TX if requested
+	err = eth1.CheckForNonceOverride(c, opts)
+	if err != nil {
+		return nil, fmt.Errorf("Error checking for nonce override: %w", err)
+	}
+
+	// Set the allowance
+	var hash common.Hash
+	switch token {
+	case "rpl":
+		hash, err = tokens.ApproveRPL(rp, spenderAddress, amountWei, opts)
+	case "fsrpl":
+		hash, err = tokens.ApproveFixedSupplyRPL(rp, spenderAddress, amountWei, opts)
+	case "reth":
+		hash, err = tokens.ApproveRETH(rp, spenderAddress, amountWei, opts)
+	default:
+		err = fmt.Errorf("Unknown token type '%s'", token)
+	}
+	if err != nil {
+		return nil, err
+	}
+	response.TxHash = hash
+
+	// Return response
+	return &response, nil
+
+}
+
+// Get the current address of every spender, along with the addresses it had before protocol upgrades replaced it
+func getApprovalSpenders(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, reporter *progress.Reporter) ([]approvalSpender, error) {
+
+	// Bring the record of contract upgrades up to date
+	eventLogInterval, err := cfg.GetEventLogInterval()
+	if err != nil {
+		return nil, err
+	}
+	history, err := upgrades.UpdateHistory(rp, cfg.Smartnode.GetUpgradeHistoryPath(), big.NewInt(int64(eventLogInterval)), cfg.Smartnode.ConfirmationDepth.Value.(uint64), reporter)
+	if err != nil {
+		return nil, err
+	}
+
+	// Contracts replaced by upgrade contracts didn't emit upgrade events, so they're tracked in the config instead
+	legacyAddresses := map[string][]common.Address{
+		"rocketNodeStaking": {cfg.Smartnode.GetV110NodeStakingAddress()},
+		"rocketNodeDeposit": {cfg.Smartnode.GetV110NodeDepositAddress()},
+	}
+
+	spenders := []approvalSpender{}
+	for _, name := range approvalSpenderContracts {
+		address, err := rp.GetAddress(name, nil)
+		if err != nil {
+			return nil, err
+		}
+		spenders = append(spenders, approvalSpender{
+			name:    name,
+			address: *address,
+			current: true,
+		})
+
+		seen := map[common.Address]bool{
+			*address: true,
+		}
+		previousAddresses := append(legacyAddresses[name], history.GetPreviousAddresses(name)...)
+		for _, previousAddress := range previousAddresses {
+			if previousAddress == (common.Address{}) || seen[previousAddress] {
+				continue
+			}
+			seen[previousAddress] = true
+			spenders = append(spenders, approvalSpender{
+				name:    name,
+				address: previousAddress,
+				current: false,
+			})
+		}
+	}
+	return spenders, nil
+
+}
+
+// Get the node's allowance of a token for a spender
+func getTokenAllowance(rp *rocketpool.RocketPool, token string, owner common.Address, spender common.Address) (*big.Int, error) {
+	switch token {
+	case "rpl":
+		return tokens.GetRPLAllowance(rp, owner, spender, nil)
+	case "fsrpl":
+		return tokens.GetFixedSupplyRPLAllowance(rp, owner, spender, nil)
+	case "reth":
+		return tokens.GetRETHAllowance(rp, owner, spender, nil)
+	default:
+		return nil, fmt.Errorf("Unknown token type '%s'", token)
+	}
+}
+
+// Check if the Smartnode sets an allowance of the token for the spender itself
+func isExpectedApproval(token string, spenderName string) bool {
+	for _, name := range expectedApprovals[token] {
+		if name == spenderName {
+			return true
+		}
+	}
+	return false
+}
+
+// Check if an address is a current or previous Rocket Pool contract that can be granted an allowance
+func isApprovalSpender(spenders []approvalSpender, address common.Address) bool {
+	for _, spender := range spenders {
+		if spender.address == address {
+			return true
+		}
+	}
+	return false
+}
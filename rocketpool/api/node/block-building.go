@@ -0,0 +1,155 @@
+package node
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/keymanager"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Gets the block building preferences of each minipool that has its own
+func getBlockBuilding(c *cli.Context) (*api.NodeGetBlockBuildingResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeGetBlockBuildingResponse{
+		MevBoostEnabled:   (cfg.EnableMevBoost.Value == true),
+		KeymanagerEnabled: cfg.Keymanager.IsEnabled(),
+		Minipools:         []api.MinipoolBlockBuilding{},
+	}
+	cc, _ := cfg.GetSelectedConsensusClient()
+	response.GasLimitSupported, response.BuilderSupported = config.GetBlockBuildingSupport(cc)
+
+	// Get the minipool preferences
+	settings, err := config.LoadBlockBuildingSettings(cfg.Smartnode.GetBlockBuildingSettingsPath())
+	if err != nil {
+		return nil, err
+	}
+	for address, preference := range settings.Minipools {
+		response.Minipools = append(response.Minipools, api.MinipoolBlockBuilding{
+			Address:     address,
+			Mode:        preference.Mode,
+			GasLimit:    preference.GasLimit,
+			UsesBuilder: cfg.UsesBuilder(preference),
+		})
+	}
+	sort.Slice(response.Minipools, func(i, j int) bool {
+		return bytes.Compare(response.Minipools[i].Address.Bytes(), response.Minipools[j].Address.Bytes()) < 0
+	})
+
+	// Return response
+	return &response, nil
+
+}
+
+// Sets the block building preference of one of the node's minipools, or clears it if the mode is the default and the gas limit is 0
+func setMinipoolBlockBuilding(c *cli.Context, minipoolAddress common.Address, mode string, gasLimit uint64) (*api.SetMinipoolBlockBuildingResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.SetMinipoolBlockBuildingResponse{}
+
+	// Check the preference
+	if !cfg.Keymanager.IsEnabled() {
+		return nil, fmt.Errorf("Block building preferences are pushed to the Validator client over its Keymanager API, which isn't enabled. Please enable it in the Keymanager API section of `rocketpool service config` first.")
+	}
+	preference := config.BlockBuildingPreference{
+		Mode:     cfgtypes.BlockBuildingMode(mode),
+		GasLimit: gasLimit,
+	}
+	if err := cfg.ValidateBlockBuildingPreference(preference); err != nil {
+		return nil, err
+	}
+	response.UsesBuilder = cfg.UsesBuilder(preference)
+
+	// Make sure the minipool belongs to the node
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	mp, err := minipool.NewMinipool(rp, minipoolAddress, nil)
+	if err != nil {
+		return nil, err
+	}
+	owner, err := mp.GetNodeAddress(nil)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(owner.Bytes(), nodeAccount.Address.Bytes()) {
+		return nil, fmt.Errorf("Minipool %s does not belong to the node", minipoolAddress.Hex())
+	}
+
+	// DVT minipools aren't run by the Smartnode's Validator client
+	dvtSettings, err := config.LoadDvtSettings(cfg.Smartnode.GetDvtSettingsPath())
+	if err != nil {
+		return nil, err
+	}
+	if _, exists := dvtSettings.Minipools[minipoolAddress]; exists {
+		return nil, fmt.Errorf("Minipool %s is operated by your DVT cluster, so its block building has to be set up there.", minipoolAddress.Hex())
+	}
+
+	// Save it
+	path := cfg.Smartnode.GetBlockBuildingSettingsPath()
+	settings, err := config.LoadBlockBuildingSettings(path)
+	if err != nil {
+		return nil, err
+	}
+	if preference.IsDefault() {
+		delete(settings.Minipools, minipoolAddress)
+	} else {
+		settings.Minipools[minipoolAddress] = preference
+	}
+	err = settings.Save(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Push it to the Validator client; if that doesn't work yet, the node daemon will try again
+	pubkey, err := minipool.GetMinipoolPubkey(rp, minipoolAddress, nil)
+	if err != nil {
+		return nil, err
+	}
+	km, err := keymanager.NewClient(cfg)
+	if err == nil {
+		err = km.ApplyBlockBuildingPreference(cfg, pubkey, preference)
+	}
+	if err != nil {
+		response.ApplyError = err.Error()
+	} else {
+		response.Applied = true
+	}
+
+	// Return response
+	return &response, nil
+
+}
@@ -6,7 +6,6 @@ import (
 
 	"github.com/rocket-pool/rocketpool-go/tokens"
 	"github.com/urfave/cli"
-	"golang.org/x/sync/errgroup"
 
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/types/api"
@@ -40,43 +39,43 @@ func canNodeBurn(c *cli.Context, amountWei *big.Int, token string) (*api.CanNode
 		return nil, err
 	}
 
-	// Sync
-	var wg errgroup.Group
+	// Run pre-flight checks
+	preflight := services.NewPreflightRunner()
 
 	// Check node balance
-	wg.Go(func() error {
+	preflight.Check("Sufficient balance", fmt.Sprintf("The node's %s balance is insufficient.", token), func() (bool, error) {
 		switch token {
 		case "reth":
 
 			// Check node rETH balance
 			rethBalanceWei, err := tokens.GetRETHBalance(rp, nodeAccount.Address, nil)
 			if err != nil {
-				return err
+				return false, err
 			}
 			response.InsufficientBalance = (amountWei.Cmp(rethBalanceWei) > 0)
 
 		}
-		return nil
+		return !response.InsufficientBalance, nil
 	})
 
 	// Check token contract collateral
-	wg.Go(func() error {
+	preflight.Check("Sufficient collateral", fmt.Sprintf("There is insufficient ETH collateral to trade %s for.", token), func() (bool, error) {
 		switch token {
 		case "reth":
 
 			// Check rETH collateral
 			rethTotalCollateral, err := tokens.GetRETHTotalCollateral(rp, nil)
 			if err != nil {
-				return err
+				return false, err
 			}
 			response.InsufficientCollateral = (amountWei.Cmp(rethTotalCollateral) > 0)
 
 		}
-		return nil
+		return !response.InsufficientCollateral, nil
 	})
 
 	// Get gas estimate
-	wg.Go(func() error {
+	preflight.Load(func() error {
 		opts, err := w.GetNodeAccountTransactor()
 		if err != nil {
 			return err
@@ -93,12 +92,13 @@ func canNodeBurn(c *cli.Context, amountWei *big.Int, token string) (*api.CanNode
 	})
 
 	// Wait for data
-	if err := wg.Wait(); err != nil {
+	response.Preflight, err = preflight.Run()
+	if err != nil {
 		return nil, err
 	}
 
 	// Update & return response
-	response.CanBurn = !(response.InsufficientBalance || response.InsufficientCollateral)
+	response.CanBurn = response.Preflight.Passed
 	return &response, nil
 
 }
@@ -18,6 +18,7 @@ import (
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/progress"
 	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	"github.com/rocket-pool/smartnode/shared/utils/eth1"
@@ -71,11 +72,14 @@ func getRewardsInfo(c *cli.Context) (*api.NodeGetRewardsInfoResponse, error) {
 	response.ClaimedIntervals = claimed
 
 	// Get the info for each unclaimed interval
-	for _, unclaimedInterval := range unclaimed {
+	reporter := progress.NewStderrReporter("Loading rewards")
+	reporter.SetPhase("Scanning rewards intervals", uint64(len(unclaimed)))
+	for i, unclaimedInterval := range unclaimed {
 		intervalInfo, err := rprewards.GetIntervalInfo(rp, cfg, nodeAccount.Address, unclaimedInterval, nil)
 		if err != nil {
 			return nil, err
 		}
+		reporter.SetProgress(uint64(i + 1))
 		if !intervalInfo.TreeFileExists || !intervalInfo.MerkleRootValid {
 			response.InvalidIntervals = append(response.InvalidIntervals, intervalInfo)
 			continue
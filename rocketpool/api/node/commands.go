@@ -3,6 +3,7 @@ package node
 import (
 	"github.com/urfave/cli"
 
+	"github.com/rocket-pool/smartnode/shared/services/activity"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 )
@@ -868,6 +869,90 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "history",
+				Usage:     "Get the node's recorded metric history",
+				UsageText: "rocketpool api node history hours",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					hours, err := cliutils.ValidatePositiveUint("hours", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getHistory(c, hours))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "uptime",
+				Usage:     "Get the monthly uptime report for the node's validators",
+				UsageText: "rocketpool api node uptime months",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					months, err := cliutils.ValidatePositiveUint("months", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getUptime(c, months))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "get-pending-transactions",
+				Usage:     "Get the number of transactions from the node wallet that haven't been mined yet",
+				UsageText: "rocketpool api node get-pending-transactions",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getPendingTransactions(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "get-recent-alerts",
+				Usage:     "Get the most recent alerts sent by the node daemon, newest first",
+				UsageText: "rocketpool api node get-recent-alerts count",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					count, err := cliutils.ValidatePositiveUint("count", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getRecentAlerts(c, count))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "sign-message",
 				Usage:     "Signs an arbitrary message with the node's private key.",
@@ -1467,6 +1552,359 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 
 				},
 			},
+
+			{
+				Name:      "mev-status",
+				Usage:     "Get the MEV relay health and validator registrations found by the node daemon",
+				UsageText: "rocketpool api node mev-status",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getMevStatus(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "proposals",
+				Usage:     "Get the node's recent proposals recorded by the node daemon",
+				UsageText: "rocketpool api node proposals",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getProposals(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "dvt-status",
+				Usage:     "Get the node's DVT minipools and the DVT cluster health found by the node daemon",
+				UsageText: "rocketpool api node dvt-status",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getDvtStatus(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "get-graffiti",
+				Usage:     "Get the node's graffiti and the graffiti of each minipool that has its own",
+				UsageText: "rocketpool api node get-graffiti",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getGraffiti(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "set-minipool-graffiti",
+				Usage:     "Set the graffiti template and rotation list of one of the node's minipools, or clear them if both are blank",
+				UsageText: "rocketpool api node set-minipool-graffiti minipool-address template rotation",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 3); err != nil {
+						return err
+					}
+					minipoolAddress, err := cliutils.ValidateAddress("minipool address", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					template := c.Args().Get(1)
+					rotation := c.Args().Get(2)
+
+					// Run
+					api.PrintResponse(setMinipoolGraffiti(c, minipoolAddress, template, rotation))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "get-block-building",
+				Usage:     "Get the block building preferences of each minipool that has its own",
+				UsageText: "rocketpool api node get-block-building",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getBlockBuilding(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "set-minipool-block-building",
+				Usage:     "Set the block building mode and gas limit of one of the node's minipools, or clear them if the mode is blank and the gas limit is 0",
+				UsageText: "rocketpool api node set-minipool-block-building minipool-address mode gas-limit",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 3); err != nil {
+						return err
+					}
+					minipoolAddress, err := cliutils.ValidateAddress("minipool address", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					mode := c.Args().Get(1)
+					gasLimit, err := cliutils.ValidateUint("gas limit", c.Args().Get(2))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(setMinipoolBlockBuilding(c, minipoolAddress, mode, gasLimit))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "get-approvals",
+				Usage:     "Get the node's token allowances for current and previous Rocket Pool contracts",
+				UsageText: "rocketpool api node get-approvals",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getApprovals(c))
+					return nil
+
+				},
+			},
+			{
+				Name:      "can-set-approval",
+				Usage:     "Check whether the node can set its allowance of a token for a Rocket Pool contract",
+				UsageText: "rocketpool api node can-set-approval token spender amount",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 3); err != nil {
+						return err
+					}
+					token, err := cliutils.ValidateApprovalTokenType("token type", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					spender, err := cliutils.ValidateAddress("spender address", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+					amountWei, err := cliutils.ValidatePositiveOrZeroWeiAmount("allowance", c.Args().Get(2))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(canSetApproval(c, token, spender, amountWei))
+					return nil
+
+				},
+			},
+			{
+				Name:      "set-approval",
+				Usage:     "Set the node's allowance of a token for a Rocket Pool contract to an exact amount",
+				UsageText: "rocketpool api node set-approval token spender amount",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 3); err != nil {
+						return err
+					}
+					token, err := cliutils.ValidateApprovalTokenType("token type", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					spender, err := cliutils.ValidateAddress("spender address", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+					amountWei, err := cliutils.ValidatePositiveOrZeroWeiAmount("allowance", c.Args().Get(2))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(setApproval(c, token, spender, amountWei))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "get-stranded-assets",
+				Usage:     "Get the node's assets held by previous versions of Rocket Pool's contracts",
+				UsageText: "rocketpool api node get-stranded-assets",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getStrandedAssets(c))
+					return nil
+
+				},
+			},
+			{
+				Name:      "can-claim-stranded-asset",
+				Usage:     "Check whether the node can claim an asset held by a previous version of a Rocket Pool contract",
+				UsageText: "rocketpool api node can-claim-stranded-asset type address",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					assetType, err := cliutils.ValidateStrandedAssetType("asset type", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					address, err := cliutils.ValidateAddress("asset address", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(canClaimStrandedAsset(c, assetType, address))
+					return nil
+
+				},
+			},
+			{
+				Name:      "claim-stranded-asset",
+				Usage:     "Claim an asset held by a previous version of a Rocket Pool contract",
+				UsageText: "rocketpool api node claim-stranded-asset type address",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					assetType, err := cliutils.ValidateStrandedAssetType("asset type", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					address, err := cliutils.ValidateAddress("asset address", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(claimStrandedAsset(c, assetType, address))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "get-activity",
+				Usage:     "Get the node's Rocket Pool activity, newest first. Use 'all' for every category and 0 for no limit.",
+				UsageText: "rocketpool api node get-activity category limit",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					var category activity.Category
+					if c.Args().Get(0) != "all" {
+						var err error
+						category, err = cliutils.ValidateActivityCategory("category", c.Args().Get(0))
+						if err != nil {
+							return err
+						}
+					}
+					limit, err := cliutils.ValidateUint("limit", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getActivity(c, category, limit))
+					return nil
+
+				},
+			},
+			{
+				Name:      "get-income",
+				Usage:     "Get the node's income in a calendar year, valued in the configured fiat currency",
+				UsageText: "rocketpool api node get-income year",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					year, err := cliutils.ValidatePositiveUint("year", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getIncome(c, int(year)))
+					return nil
+
+				},
+			},
+			{
+				Name:      "estimate-deposit",
+				Usage:     "Estimate the returns of a new minipool with each bond size from the network's current state",
+				UsageText: "rocketpool api node estimate-deposit",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(EstimateDeposit(c))
+					return nil
+
+				},
+			},
 		},
 	})
 }
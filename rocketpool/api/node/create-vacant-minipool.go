@@ -17,8 +17,8 @@ import (
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 	"github.com/rocket-pool/smartnode/shared/utils/eth1"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
 	"github.com/urfave/cli"
-	"golang.org/x/sync/errgroup"
 )
 
 func canCreateVacantMinipool(c *cli.Context, amountWei *big.Int, minNodeFee float64, salt *big.Int, pubkey rptypes.ValidatorPubkey) (*api.CanCreateVacantMinipoolResponse, error) {
@@ -67,52 +67,63 @@ func canCreateVacantMinipool(c *cli.Context, amountWei *big.Int, minNodeFee floa
 	}
 
 	// Data
-	var wg1 errgroup.Group
+	preflight := services.NewPreflightRunner()
 	var ethMatched *big.Int
 	var ethMatchedLimit *big.Int
 
-	// Check node deposits are enabled
-	wg1.Go(func() error {
-		depositEnabled, err := protocol.GetVacantMinipoolsEnabled(rp, nil)
-		if err == nil {
-			response.DepositDisabled = !depositEnabled
-		}
+	// Get node staking information
+	preflight.Load(func() error {
+		var err error
+		ethMatched, err = node.GetNodeEthMatched(rp, nodeAccount.Address, nil)
+		return err
+	})
+	preflight.Load(func() error {
+		var err error
+		ethMatchedLimit, err = rputils.GetNodeEthMatchedLimit(rp, nodeAccount.Address, nil)
 		return err
 	})
 
-	// Get node staking information
-	wg1.Go(func() error {
+	// Check if the node is staking without RPL
+	preflight.Load(func() error {
 		var err error
-		ethMatched, err = node.GetNodeEthMatched(rp, nodeAccount.Address, nil)
+		response.EthOnly, err = rputils.IsEthOnlyNode(rp, nodeAccount.Address, nil)
 		return err
 	})
-	wg1.Go(func() error {
+
+	// Get the next minipool address
+	preflight.Load(func() error {
 		var err error
-		ethMatchedLimit, err = node.GetNodeEthMatchedLimit(rp, nodeAccount.Address, nil)
+		response.MinipoolAddress, err = minipool.GetExpectedAddress(rp, nodeAccount.Address, salt, nil)
 		return err
 	})
 
-	// Wait for data
-	if err := wg1.Wait(); err != nil {
-		return nil, err
-	}
+	// Check vacant minipool deposits are enabled
+	preflight.Check("Vacant minipool deposits enabled", "Vacant minipool deposits are currently disabled.", func() (bool, error) {
+		depositEnabled, err := protocol.GetVacantMinipoolsEnabled(rp, nil)
+		if err != nil {
+			return false, err
+		}
+		response.DepositDisabled = !depositEnabled
+		return depositEnabled, nil
+	})
 
-	// Get the next minipool address and withdrawal credentials
-	minipoolAddress, err := minipool.GetExpectedAddress(rp, nodeAccount.Address, salt, nil)
+	// Check the RPL collateral
+	preflight.CheckWithReason("Sufficient RPL stake", func() (bool, string, error) {
+		validatorEthWei := eth.EthToWei(ValidatorEth)
+		matchRequest := big.NewInt(0).Sub(validatorEthWei, amountWei)
+		availableToMatch := big.NewInt(0).Sub(ethMatchedLimit, ethMatched)
+		response.InsufficientRplStake = (availableToMatch.Cmp(matchRequest) == -1)
+		return !response.InsufficientRplStake, fmt.Sprintf("The node has not staked enough RPL to collateralize a new minipool with a bond of %.0f ETH.", eth.WeiToEth(amountWei)), nil
+	})
+
+	// Wait for data
+	response.Preflight, err = preflight.Run()
 	if err != nil {
 		return nil, err
 	}
 
-	// Check data
-	validatorEthWei := eth.EthToWei(ValidatorEth)
-	matchRequest := big.NewInt(0).Sub(validatorEthWei, amountWei)
-	availableToMatch := big.NewInt(0).Sub(ethMatchedLimit, ethMatched)
-
-	response.InsufficientRplStake = (availableToMatch.Cmp(matchRequest) == -1)
-	response.MinipoolAddress = minipoolAddress
-
 	// Update response
-	response.CanDeposit = !(response.InsufficientRplStake || response.InvalidAmount || response.DepositDisabled)
+	response.CanDeposit = response.Preflight.Passed
 	if !response.CanDeposit {
 		return &response, nil
 	}
@@ -143,7 +154,7 @@ func canCreateVacantMinipool(c *cli.Context, amountWei *big.Int, minNodeFee floa
 	balanceWei.Mul(balanceWei, big.NewInt(1e9))
 
 	// Run the deposit gas estimator
-	gasInfo, err := node.EstimateCreateVacantMinipoolGas(rp, amountWei, minNodeFee, pubkey, salt, minipoolAddress, balanceWei, opts)
+	gasInfo, err := node.EstimateCreateVacantMinipoolGas(rp, amountWei, minNodeFee, pubkey, salt, response.MinipoolAddress, balanceWei, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -263,7 +274,7 @@ func createVacantMinipool(c *cli.Context, amountWei *big.Int, minNodeFee float64
 	}
 
 	// Create the minipool
-	tx, err := node.CreateVacantMinipool(rp, amountWei, minNodeFee, pubkey, salt, minipoolAddress, balanceWei, opts)
+	tx, err := node.CreateVacantMinipool(rp, amountWei, minNodeFee, pubkey, salt, response.MinipoolAddress, balanceWei, opts)
 	if err != nil {
 		return nil, err
 	}
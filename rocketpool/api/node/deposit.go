@@ -18,13 +18,15 @@ import (
 	rptypes "github.com/rocket-pool/rocketpool-go/types"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/urfave/cli"
-	"golang.org/x/sync/errgroup"
 
 	prdeposit "github.com/prysmaticlabs/prysm/v3/contracts/deposit"
 	ethpb "github.com/prysmaticlabs/prysm/v3/proto/prysm/v1alpha1"
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/dvt"
 	"github.com/rocket-pool/smartnode/shared/types/api"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 	"github.com/rocket-pool/smartnode/shared/utils/eth1"
 	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
 	"github.com/rocket-pool/smartnode/shared/utils/validator"
@@ -84,15 +86,14 @@ func canNodeDeposit(c *cli.Context, amountWei *big.Int, minNodeFee float64, salt
 	}
 
 	// Data
-	var wg1 errgroup.Group
+	preflight := services.NewPreflightRunner()
 	var ethMatched *big.Int
 	var ethMatchedLimit *big.Int
 	var pendingMatchAmount *big.Int
 	var minipoolAddress common.Address
-	var depositPoolBalance *big.Int
 
 	// Check credit balance
-	wg1.Go(func() error {
+	preflight.Load(func() error {
 		ethBalanceWei, err := node.GetNodeDepositCredit(rp, nodeAccount.Address, nil)
 		if err == nil {
 			response.CreditBalance = ethBalanceWei
@@ -101,7 +102,7 @@ func canNodeDeposit(c *cli.Context, amountWei *big.Int, minNodeFee float64, salt
 	})
 
 	// Check node balance
-	wg1.Go(func() error {
+	preflight.Load(func() error {
 		ethBalanceWei, err := ec.BalanceAt(context.Background(), nodeAccount.Address, nil)
 		if err == nil {
 			response.NodeBalance = ethBalanceWei
@@ -109,17 +110,9 @@ func canNodeDeposit(c *cli.Context, amountWei *big.Int, minNodeFee float64, salt
 		return err
 	})
 
-	// Check node deposits are enabled
-	wg1.Go(func() error {
-		depositEnabled, err := protocol.GetNodeDepositEnabled(rp, nil)
-		if err == nil {
-			response.DepositDisabled = !depositEnabled
-		}
-		return err
-	})
-
 	// Get node staking information
-	wg1.Go(func() error {
+	preflight.Load(func() error {
+		var err error
 		ethMatched, ethMatchedLimit, pendingMatchAmount, err = rputils.CheckCollateral(rp, nodeAccount.Address, nil)
 		if err != nil {
 			return fmt.Errorf("error checking collateral for node %s: %w", nodeAccount.Address.Hex(), err)
@@ -127,46 +120,65 @@ func canNodeDeposit(c *cli.Context, amountWei *big.Int, minNodeFee float64, salt
 		return nil
 	})
 
-	// Get deposit pool balance
-	wg1.Go(func() error {
+	// Check if the node is staking without RPL
+	preflight.Load(func() error {
 		var err error
-		depositPoolBalance, err = deposit.GetBalance(rp, nil)
+		response.EthOnly, err = rputils.IsEthOnlyNode(rp, nodeAccount.Address, nil)
 		return err
 	})
 
-	// Wait for data
-	if err := wg1.Wait(); err != nil {
-		return nil, err
-	}
+	// Get deposit pool balance and check if the credit balance can be used
+	preflight.Load(func() error {
+		depositPoolBalance, err := deposit.GetBalance(rp, nil)
+		if err == nil {
+			response.DepositBalance = depositPoolBalance
+			response.CanUseCredit = (depositPoolBalance.Cmp(eth.EthToWei(1)) >= 0)
+		}
+		return err
+	})
+
+	// Check node deposits are enabled
+	preflight.Check("Node deposits enabled", "Node deposits are currently disabled.", func() (bool, error) {
+		depositEnabled, err := protocol.GetNodeDepositEnabled(rp, nil)
+		if err != nil {
+			return false, err
+		}
+		response.DepositDisabled = !depositEnabled
+		return depositEnabled, nil
+	})
 
 	// Check for insufficient balance
-	totalBalance := big.NewInt(0).Add(response.NodeBalance, response.CreditBalance)
-	response.InsufficientBalance = (amountWei.Cmp(totalBalance) > 0)
-
-	// Check if the credit balance can be used
-	response.DepositBalance = depositPoolBalance
-	response.CanUseCredit = (depositPoolBalance.Cmp(eth.EthToWei(1)) >= 0)
-
-	// Check data
-	validatorEthWei := eth.EthToWei(ValidatorEth)
-	matchRequest := big.NewInt(0).Sub(validatorEthWei, amountWei)
-	availableToMatch := big.NewInt(0).Sub(ethMatchedLimit, ethMatched)
-	availableToMatch.Sub(availableToMatch, pendingMatchAmount)
-	response.InsufficientRplStake = (availableToMatch.Cmp(matchRequest) == -1)
-
-	// Update response
-	response.CanDeposit = !(response.InsufficientBalance || response.InsufficientRplStake || response.InvalidAmount || response.DepositDisabled)
-	if !response.CanDeposit {
-		return &response, nil
-	}
+	preflight.CheckWithReason("Sufficient balance", func() (bool, string, error) {
+		totalBalance := big.NewInt(0).Add(response.NodeBalance, response.CreditBalance)
+		response.InsufficientBalance = (amountWei.Cmp(totalBalance) > 0)
+		return !response.InsufficientBalance, fmt.Sprintf("The node's balance of %.6f ETH and credit balance of %.6f ETH are not enough to create a minipool with a %.1f ETH bond.", eth.WeiToEth(response.NodeBalance), eth.WeiToEth(response.CreditBalance), eth.WeiToEth(amountWei)), nil
+	})
+
+	// Check if the node wallet can cover the deposit when the credit balance can't be used
+	preflight.CheckWithReason("Credit balance usable", func() (bool, string, error) {
+		totalBalance := big.NewInt(0).Add(response.NodeBalance, response.CreditBalance)
+		response.InsufficientBalanceWithoutCredit = !response.CanUseCredit && amountWei.Cmp(totalBalance) <= 0 && response.NodeBalance.Cmp(amountWei) < 0
+		return !response.InsufficientBalanceWithoutCredit, fmt.Sprintf("There is not enough ETH in the staking pool to use your credit balance (it needs at least 1 ETH but only has %.2f ETH) and you don't have enough ETH in your wallet (%.6f ETH) to cover the deposit amount yourself. If you want to continue creating a minipool, you will either need to wait for the staking pool to have more ETH deposited or add more ETH to your node wallet.", eth.WeiToEth(response.DepositBalance), eth.WeiToEth(response.NodeBalance)), nil
+	})
 
-	if response.CanDeposit && !response.CanUseCredit && response.NodeBalance.Cmp(amountWei) < 0 {
-		// Can't use credit and there's not enough ETH in the node wallet to deposit so error out
-		response.InsufficientBalanceWithoutCredit = true
-		response.CanDeposit = false
+	// Check the RPL collateral
+	preflight.CheckWithReason("Sufficient RPL stake", func() (bool, string, error) {
+		validatorEthWei := eth.EthToWei(ValidatorEth)
+		matchRequest := big.NewInt(0).Sub(validatorEthWei, amountWei)
+		availableToMatch := big.NewInt(0).Sub(ethMatchedLimit, ethMatched)
+		availableToMatch.Sub(availableToMatch, pendingMatchAmount)
+		response.InsufficientRplStake = (availableToMatch.Cmp(matchRequest) == -1)
+		return !response.InsufficientRplStake, fmt.Sprintf("The node has not staked enough RPL to collateralize a new minipool with a bond of %.0f ETH (this also includes the RPL required to support any pending bond reductions).", eth.WeiToEth(amountWei)), nil
+	})
+
+	// Wait for data
+	response.Preflight, err = preflight.Run()
+	if err != nil {
+		return nil, err
 	}
 
 	// Break before the gas estimator if depositing won't work
+	response.CanDeposit = response.Preflight.Passed
 	if !response.CanDeposit {
 		return &response, nil
 	}
@@ -263,6 +275,10 @@ func nodeDeposit(c *cli.Context, amountWei *big.Int, minNodeFee float64, salt *b
 	if err := services.RequireNodeRegistered(c); err != nil {
 		return nil, err
 	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
 	w, err := services.GetWallet(c)
 	if err != nil {
 		return nil, err
@@ -349,10 +365,25 @@ func nodeDeposit(c *cli.Context, amountWei *big.Int, minNodeFee float64, salt *b
 		opts.Value = amountWei
 	}
 
-	// Create and save a new validator key
-	validatorKey, err := w.CreateValidatorKey()
-	if err != nil {
-		return nil, err
+	// Create and save a new validator key; in DVT mode, it's handed off to the cluster instead of the Validator client
+	isDvt := (cfg.Dvt.Enabled.Value == true)
+	var validatorKey *eth2types.BLSPrivateKey
+	var walletIndex uint
+	if isDvt {
+		var derivationPath string
+		validatorKey, derivationPath, walletIndex, err = w.ReserveValidatorKey()
+		if err != nil {
+			return nil, err
+		}
+		response.DvtHandoffKeystore, err = dvt.WriteHandoffKeystore(cfg.Smartnode.GetDvtHandoffPath(), validatorKey, derivationPath)
+		if err != nil {
+			return nil, fmt.Errorf("error handing the validator key off to the DVT cluster: %w", err)
+		}
+	} else {
+		validatorKey, err = w.CreateValidatorKey()
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// Get the next minipool address and withdrawal credentials
@@ -388,6 +419,28 @@ func nodeDeposit(c *cli.Context, amountWei *big.Int, minNodeFee float64, salt *b
 			"***************\n", minipoolAddress.Hex(), pubKey.Hex(), status.Index)
 	}
 
+	// Make sure nobody has front-run the deposit for this pubkey with different withdrawal credentials; the Beacon Chain
+	// doesn't show deposits until they've been processed, so the deposit contract has to be checked for recent ones
+	eventLogInterval, err := cfg.GetEventLogInterval()
+	if err != nil {
+		return nil, err
+	}
+	pendingDeposits, err := validator.GetPendingDeposits(rp, pubKey, big.NewInt(int64(eventLogInterval)))
+	if err != nil {
+		return nil, fmt.Errorf("Error checking for existing deposits: %w\nYour funds have not been deposited for your own safety.", err)
+	}
+	for _, pendingDeposit := range pendingDeposits {
+		if pendingDeposit.WithdrawalCredentials != withdrawalCredentials {
+			return nil, fmt.Errorf("**** ALERT ****\n"+
+				"Your minipool %s has the following as a validator pubkey:\n\t%s\n"+
+				"Transaction %s in block %d already deposited %d gwei for this key with different withdrawal credentials:\n\t%s\n"+
+				"This can be an attempt to front-run your deposit and take control of the validator's funds.\n"+
+				"Rocket Pool will not allow you to deposit this validator for your own safety.\n"+
+				"PLEASE REPORT THIS TO THE ROCKET POOL DEVELOPERS.\n"+
+				"***************\n", minipoolAddress.Hex(), pubKey.Hex(), pendingDeposit.TxHash.Hex(), pendingDeposit.BlockNumber, pendingDeposit.AmountGwei, pendingDeposit.WithdrawalCredentials.Hex())
+		}
+	}
+
 	// Do a final sanity check
 	err = validateDepositInfo(eth2Config, depositAmount, pubKey, withdrawalCredentials, signature)
 	if err != nil {
@@ -437,6 +490,24 @@ func nodeDeposit(c *cli.Context, amountWei *big.Int, minNodeFee float64, salt *b
 		return nil, err
 	}
 
+	// Record the DVT minipool so its key is kept out of the Validator client
+	if isDvt {
+		settingsPath := cfg.Smartnode.GetDvtSettingsPath()
+		settings, err := config.LoadDvtSettings(settingsPath)
+		if err != nil {
+			return nil, err
+		}
+		settings.Minipools[minipoolAddress] = config.DvtMinipool{
+			Pubkey:      pubKey,
+			WalletIndex: walletIndex,
+			Provider:    cfg.Dvt.Provider.Value.(cfgtypes.DvtProvider),
+			HandoffTime: time.Now(),
+		}
+		if err := settings.Save(settingsPath); err != nil {
+			return nil, err
+		}
+	}
+
 	// Print transaction if requested
 	if !submit {
 		b, err := tx.MarshalBinary()
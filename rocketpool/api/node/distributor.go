@@ -7,7 +7,6 @@ import (
 	"github.com/rocket-pool/rocketpool-go/node"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/urfave/cli"
-	"golang.org/x/sync/errgroup"
 
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/types/api"
@@ -174,17 +173,17 @@ func canDistribute(c *cli.Context) (*api.NodeCanDistributeResponse, error) {
 	}
 
 	// Sync
-	var wg errgroup.Group
+	preflight := services.NewPreflightRunner()
 
 	// Get the contract's balance
-	wg.Go(func() error {
+	preflight.Load(func() error {
 		var err error
 		response.Balance, err = rp.Client.BalanceAt(context.Background(), distributorAddress, nil)
 		return err
 	})
 
 	// Get the node share of the balance
-	wg.Go(func() error {
+	preflight.Load(func() error {
 		nodeShareRaw, err := distributor.GetNodeShare(nil)
 		if err != nil {
 			return fmt.Errorf("error getting node share for distributor %s: %w", distributorAddress.Hex(), err)
@@ -194,7 +193,7 @@ func canDistribute(c *cli.Context) (*api.NodeCanDistributeResponse, error) {
 	})
 
 	// Get gas estimates
-	wg.Go(func() error {
+	preflight.Load(func() error {
 		var err error
 		opts, err := w.GetNodeAccountTransactor()
 		if err != nil {
@@ -206,7 +205,7 @@ func canDistribute(c *cli.Context) (*api.NodeCanDistributeResponse, error) {
 	})
 
 	// Wait for data
-	if err := wg.Wait(); err != nil {
+	if _, err := preflight.Run(); err != nil {
 		return nil, err
 	}
 
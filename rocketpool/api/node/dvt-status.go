@@ -0,0 +1,67 @@
+package node
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/dvt"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Gets the node's DVT minipools and the cluster health found by the node daemon
+func getDvtStatus(c *cli.Context) (*api.NodeDvtStatusResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeDvtStatusResponse{
+		Enabled:   (cfg.Dvt.Enabled.Value == true),
+		Minipools: []api.DvtMinipool{},
+	}
+
+	// Get the DVT minipools
+	settings, err := config.LoadDvtSettings(cfg.Smartnode.GetDvtSettingsPath())
+	if err != nil {
+		return nil, err
+	}
+	handoffPath := cfg.Smartnode.GetDvtHandoffPath()
+	for address, minipool := range settings.Minipools {
+		details := api.DvtMinipool{
+			Address:     address,
+			Pubkey:      minipool.Pubkey,
+			Provider:    minipool.Provider,
+			HandoffTime: minipool.HandoffTime,
+		}
+		keystoreFilename := dvt.GetHandoffKeystoreFilename(minipool.Pubkey)
+		_, err := os.Stat(filepath.Join(handoffPath, keystoreFilename))
+		if err == nil {
+			details.HandoffKeystore = keystoreFilename
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+		response.Minipools = append(response.Minipools, details)
+	}
+	sort.Slice(response.Minipools, func(i, j int) bool {
+		return response.Minipools[i].HandoffTime.Before(response.Minipools[j].HandoffTime)
+	})
+
+	// Load the cluster status
+	response.ClusterStatus, err = dvt.LoadClusterStatus(cfg.Smartnode.GetDvtStatusPath())
+	if err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
@@ -7,16 +7,15 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	"github.com/urfave/cli"
-	ens "github.com/wealdtech/go-ens/v3"
 )
 
 func resolveEnsName(c *cli.Context, name string) (*api.ResolveEnsNameResponse, error) {
-	rp, err := services.GetRocketPool(c)
+	resolver, err := services.GetEnsResolver(c)
 	if err != nil {
 		return nil, err
 	}
 
-	address, err := ens.Resolve(rp.Client, name)
+	address, err := resolver.Resolve(name)
 	if err != nil {
 		return nil, err
 	}
@@ -28,15 +27,18 @@ func resolveEnsName(c *cli.Context, name string) (*api.ResolveEnsNameResponse, e
 }
 
 func reverseResolveEnsName(c *cli.Context, address common.Address) (*api.ResolveEnsNameResponse, error) {
-	rp, err := services.GetRocketPool(c)
+	resolver, err := services.GetEnsResolver(c)
 	if err != nil {
 		return nil, err
 	}
 
-	name, err := ens.ReverseResolve(rp.Client, address)
+	name, err := resolver.ReverseResolve(address)
 	if err != nil {
 		return nil, err
 	}
+	if name == "" {
+		return nil, fmt.Errorf("%s does not have a primary ENS name", address.Hex())
+	}
 	response := api.ResolveEnsNameResponse{
 		Address: address,
 		EnsName: name,
@@ -45,14 +47,9 @@ func reverseResolveEnsName(c *cli.Context, address common.Address) (*api.Resolve
 }
 
 func formatResolvedAddress(c *cli.Context, address common.Address) string {
-	rp, err := services.GetRocketPool(c)
-	if err != nil {
-		return address.Hex()
-	}
-
-	name, err := ens.ReverseResolve(rp.Client, address)
+	resolver, err := services.GetEnsResolver(c)
 	if err != nil {
 		return address.Hex()
 	}
-	return fmt.Sprintf("%s (%s)", name, address.Hex())
+	return resolver.FormatAddress(address)
 }
@@ -0,0 +1,101 @@
+package node
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+// Settings
+const estimateRethAprDays uint64 = 7
+
+// The bond sizes a new minipool can be created with
+var estimateBondAmounts = []float64{8, 16}
+
+// Estimate the returns of a new minipool with each bond size from the network's current state
+func EstimateDeposit(c *cli.Context) (*api.NodeEstimateDepositResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	mgr, err := services.GetNetworkStateManager(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeEstimateDepositResponse{
+		Estimates: []api.DepositEstimate{},
+	}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the network's state, including the total effective RPL stake that RPL rewards are shared across
+	networkState, totalEffectiveStake, err := mgr.GetHeadStateForNode(nodeAccount.Address, true)
+	if err != nil {
+		return nil, fmt.Errorf("error getting network state: %w", err)
+	}
+	details := networkState.NetworkDetails
+	response.Commission = details.NodeFee
+	response.RplPrice = eth.WeiToEth(details.RplPrice)
+	response.YearlyRplRewardsPerRpl = rputils.GetYearlyRplRewardsPerRpl(details, totalEffectiveStake)
+	response.DepositPoolBalance = eth.WeiToEth(details.DepositPoolBalance)
+	response.QueueLength = details.QueueLength.Uint64()
+	response.QueueEthRequired = eth.WeiToEth(details.QueueCapacity.Total)
+
+	// Estimate the validator APR from the trailing rETH APR
+	eventLogInterval, err := cfg.GetEventLogInterval()
+	if err != nil {
+		return nil, err
+	}
+	response.RethApr, _, err = rputils.GetTrailingRethApr(rp, estimateRethAprDays, big.NewInt(int64(eventLogInterval)))
+	if err != nil {
+		return nil, err
+	}
+	response.ValidatorApr = rputils.GetValidatorAprFromRethApr(response.RethApr, details.ETHUtilizationRate, details.NodeFee)
+
+	// Estimate the returns of each bond size; the node earns the full APR on its bond plus the commission on the borrowed ETH
+	minCollateralFraction := eth.WeiToEth(details.MinCollateralFraction)
+	maxCollateralFraction := eth.WeiToEth(details.MaxCollateralFraction)
+	for _, bond := range estimateBondAmounts {
+		borrowed := validatorStakedEth - bond
+		estimate := api.DepositEstimate{
+			BondAmount:       bond,
+			BorrowedAmount:   borrowed,
+			YearlyEthRewards: (bond + borrowed*response.Commission) * response.ValidatorApr / 100,
+			WaitsInQueue:     response.DepositPoolBalance < response.QueueEthRequired+borrowed,
+		}
+		if response.RplPrice > 0 {
+			estimate.MinimumRplStake = borrowed * minCollateralFraction / response.RplPrice
+			estimate.MaximumRplStake = bond * maxCollateralFraction / response.RplPrice
+		}
+		response.Estimates = append(response.Estimates, estimate)
+	}
+
+	// Return response
+	return &response, nil
+
+}
@@ -0,0 +1,139 @@
+package node
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Gets the node's graffiti and the graffiti of each minipool that has its own
+func getGraffiti(c *cli.Context) (*api.NodeGetGraffitiResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeGetGraffitiResponse{
+		Minipools: []api.MinipoolGraffiti{},
+	}
+	response.FileSupported, _, response.PerValidatorSupport = config.GetGraffitiFileSupport(cfg.GetGraffitiConsensusClient())
+
+	// Render the node's graffiti
+	now := time.Now()
+	response.NodeGraffiti, err = cfg.Graffiti.GetNodeGraffiti(now)
+	if err != nil {
+		return nil, err
+	}
+
+	// Render the minipool graffiti
+	settings, err := config.LoadGraffitiSettings(cfg.Smartnode.GetGraffitiSettingsPath())
+	if err != nil {
+		return nil, err
+	}
+	for address, override := range settings.Minipools {
+		minipoolGraffiti := api.MinipoolGraffiti{
+			Address:  address,
+			Template: override.Template,
+			Rotation: override.Rotation,
+		}
+		minipoolGraffiti.Graffiti, err = cfg.Graffiti.GetMinipoolGraffiti(override, now)
+		if err != nil {
+			minipoolGraffiti.Error = err.Error()
+		}
+		response.Minipools = append(response.Minipools, minipoolGraffiti)
+	}
+	sort.Slice(response.Minipools, func(i, j int) bool {
+		return bytes.Compare(response.Minipools[i].Address.Bytes(), response.Minipools[j].Address.Bytes()) < 0
+	})
+
+	// Return response
+	return &response, nil
+
+}
+
+// Sets the graffiti template and rotation list of one of the node's minipools, or clears them if both are empty
+func setMinipoolGraffiti(c *cli.Context, minipoolAddress common.Address, template string, rotationList string) (*api.SetMinipoolGraffitiResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.SetMinipoolGraffitiResponse{}
+
+	// Make sure the minipool belongs to the node
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	mp, err := minipool.NewMinipool(rp, minipoolAddress, nil)
+	if err != nil {
+		return nil, err
+	}
+	owner, err := mp.GetNodeAddress(nil)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(owner.Bytes(), nodeAccount.Address.Bytes()) {
+		return nil, fmt.Errorf("Minipool %s does not belong to the node", minipoolAddress.Hex())
+	}
+
+	// Check the new graffiti
+	rotation := config.ParseGraffitiRotation(rotationList)
+	override := config.GraffitiOverride{
+		Template: template,
+		Rotation: rotation,
+	}
+	if err := cfg.Graffiti.ValidateGraffiti(template, rotation); err != nil {
+		return nil, err
+	}
+	response.Graffiti, err = cfg.Graffiti.GetMinipoolGraffiti(override, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	// Save it; the node daemon renders it into the validator client's graffiti file
+	path := cfg.Smartnode.GetGraffitiSettingsPath()
+	settings, err := config.LoadGraffitiSettings(path)
+	if err != nil {
+		return nil, err
+	}
+	if template == "" && len(rotation) == 0 {
+		delete(settings.Minipools, minipoolAddress)
+	} else {
+		settings.Minipools[minipoolAddress] = override
+	}
+	err = settings.Save(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
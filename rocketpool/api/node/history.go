@@ -0,0 +1,35 @@
+package node
+
+import (
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/history"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getHistory(c *cli.Context, hours uint64) (*api.NodeHistoryResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeHistoryResponse{}
+
+	// Load the samples in the requested window
+	since := time.Now().Add(-time.Duration(hours) * time.Hour)
+	samples, err := history.NewStore(cfg.Smartnode.GetNodeHistoryPath()).Load(since)
+	if err != nil {
+		return nil, err
+	}
+	response.Samples = samples
+
+	// Return response
+	return &response, nil
+
+}
@@ -0,0 +1,169 @@
+package node
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/activity"
+	"github.com/rocket-pool/smartnode/shared/services/prices"
+	"github.com/rocket-pool/smartnode/shared/services/progress"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Income types
+const (
+	incomeType_RplRewards     string = "rpl-rewards"
+	incomeType_SmoothingPool  string = "smoothing-pool"
+	incomeType_FeeDistributor string = "fee-distributor"
+	incomeType_Skim           string = "skimmed-rewards"
+)
+
+// Minipool balances distributed below this are rewards skimmed from the Beacon Chain; anything larger is a full withdrawal that includes the bond
+var skimBalanceLimit = eth.EthToWei(8)
+
+func getIncome(c *cli.Context, year int) (*api.NodeIncomeResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	priceClient := prices.NewClient(cfg.Smartnode.PriceApiUrl.Value.(string), cfg.Smartnode.FiatCurrency.Value.(string))
+	response := api.NodeIncomeResponse{
+		Year:               year,
+		Currency:           priceClient.Currency(),
+		Entries:            []api.NodeIncomeEntry{},
+		SkippedWithdrawals: []common.Hash{},
+	}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Bring the activity index up to date
+	reporter := progress.NewStderrReporter("Compiling node income")
+	db, err := updateActivityDatabase(cfg, rp, nodeAccount.Address, reporter)
+	if err != nil {
+		reporter.Finish(err)
+		return nil, err
+	}
+	defer db.Close()
+	_, response.ScannedBlock, err = db.GetScanState()
+	if err != nil {
+		reporter.Finish(err)
+		return nil, err
+	}
+
+	// Get the payouts made during the year
+	start := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	events, err := db.GetEvents(activity.Filter{
+		Category: activity.Category_Claim,
+		Since:    start,
+		Until:    start.AddDate(1, 0, 0),
+	})
+	if err != nil {
+		reporter.Finish(err)
+		return nil, err
+	}
+	for i := len(events) - 1; i >= 0; i-- {
+		event := events[i]
+		switch event.Event {
+		case "RewardsClaimed":
+			intervals := getEventList(event, "rewardIndex")
+			rplAmounts := getEventList(event, "amountRPL")
+			ethAmounts := getEventList(event, "amountETH")
+			for j, interval := range intervals {
+				if j < len(rplAmounts) {
+					addIncome(&response, event, incomeType_RplRewards, "RPL", rplAmounts[j], fmt.Sprintf("RPL rewards for interval %s", interval))
+				}
+				if j < len(ethAmounts) {
+					addIncome(&response, event, incomeType_SmoothingPool, "ETH", ethAmounts[j], fmt.Sprintf("Smoothing Pool rewards for interval %s", interval))
+				}
+			}
+		case "RPLTokensClaimed":
+			addIncome(&response, event, incomeType_RplRewards, "RPL", getEventDetail(event, "amount"), "RPL rewards")
+		case "FeesDistributed":
+			addIncome(&response, event, incomeType_FeeDistributor, "ETH", getEventDetail(event, "nodeAmount"), "Priority fees and MEV distributed from the fee distributor")
+		case "EtherWithdrawalProcessed":
+			totalBalance, ok := new(big.Int).SetString(getEventDetail(event, "totalBalance"), 10)
+			if !ok || totalBalance.Cmp(skimBalanceLimit) >= 0 {
+				response.SkippedWithdrawals = append(response.SkippedWithdrawals, event.TxHash)
+				continue
+			}
+			addIncome(&response, event, incomeType_Skim, "ETH", getEventDetail(event, "nodeAmount"), fmt.Sprintf("Beacon Chain rewards skimmed from minipool %s", event.Minipool.Hex()))
+		}
+	}
+
+	// Value each payout on the day it was received
+	reporter.SetPhase("Getting prices", uint64(len(response.Entries)))
+	for i := range response.Entries {
+		entry := &response.Entries[i]
+		coinID := prices.CoinID_Eth
+		if entry.Token == "RPL" {
+			coinID = prices.CoinID_Rpl
+		}
+		entry.FiatPrice, err = priceClient.GetPrice(coinID, entry.Time)
+		if err != nil {
+			reporter.Finish(err)
+			return nil, err
+		}
+		entry.FiatValue = entry.FiatPrice * eth.WeiToEth(entry.Amount)
+		reporter.SetProgress(uint64(i + 1))
+	}
+	reporter.Finish(nil)
+
+	// Return response
+	return &response, nil
+
+}
+
+// Add a payout to the income, ignoring empty ones
+func addIncome(response *api.NodeIncomeResponse, event activity.Event, incomeType string, token string, amount string, description string) {
+	value, ok := new(big.Int).SetString(amount, 10)
+	if !ok || value.Sign() == 0 {
+		return
+	}
+	response.Entries = append(response.Entries, api.NodeIncomeEntry{
+		Time:        event.Time,
+		Type:        incomeType,
+		Token:       token,
+		Amount:      value,
+		TxHash:      event.TxHash,
+		Description: description,
+	})
+}
+
+// Get an argument of an event; some contracts prefix their event argument names with an underscore
+func getEventDetail(event activity.Event, name string) string {
+	if value, exists := event.Details[name]; exists {
+		return value
+	}
+	return event.Details["_"+name]
+}
+
+// Get an array argument of an event
+func getEventList(event activity.Event, name string) []string {
+	return strings.Fields(strings.Trim(getEventDetail(event, name), "[]"))
+}
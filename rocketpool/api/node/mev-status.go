@@ -0,0 +1,33 @@
+package node
+
+import (
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/mevboost"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Gets the MEV relay health and validator registrations found by the node daemon
+func getMevStatus(c *cli.Context) (*api.NodeMevStatusResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeMevStatusResponse{}
+
+	// Load the report
+	report, err := mevboost.LoadRelayReport(cfg.Smartnode.GetMevRelayStatusPath())
+	if err != nil {
+		return nil, err
+	}
+	response.RelayReport = report
+
+	// Return response
+	return &response, nil
+
+}
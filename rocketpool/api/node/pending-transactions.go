@@ -0,0 +1,50 @@
+package node
+
+import (
+	"context"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getPendingTransactions(c *cli.Context) (*api.NodePendingTransactionsResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodePendingTransactionsResponse{}
+
+	// Every nonce between the latest block's and the mempool's belongs to a transaction that hasn't been mined yet
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	response.LatestNonce, err = ec.NonceAt(context.Background(), nodeAccount.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+	response.PendingNonce, err = ec.PendingNonceAt(context.Background(), nodeAccount.Address)
+	if err != nil {
+		return nil, err
+	}
+	if response.PendingNonce > response.LatestNonce {
+		response.PendingCount = response.PendingNonce - response.LatestNonce
+	}
+
+	// Return response
+	return &response, nil
+
+}
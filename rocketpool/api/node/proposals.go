@@ -0,0 +1,33 @@
+package node
+
+import (
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/mevboost"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Gets the node's recent proposals recorded by the node daemon
+func getProposals(c *cli.Context) (*api.NodeProposalsResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeProposalsResponse{}
+
+	// Load the history
+	history, err := mevboost.LoadProposalHistory(cfg.Smartnode.GetProposalHistoryPath())
+	if err != nil {
+		return nil, err
+	}
+	response.Proposals = history.Proposals
+
+	// Return response
+	return &response, nil
+
+}
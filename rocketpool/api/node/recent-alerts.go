@@ -0,0 +1,35 @@
+package node
+
+import (
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getRecentAlerts(c *cli.Context, count uint64) (*api.NodeRecentAlertsResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeRecentAlertsResponse{}
+
+	// Get the newest alerts first
+	history, err := alerting.LoadHistory(cfg.Smartnode.GetAlertHistoryPath())
+	if err != nil {
+		return nil, err
+	}
+	response.Alerts = []alerting.HistoryEntry{}
+	for i := len(history) - 1; i >= 0 && uint64(len(response.Alerts)) < count; i-- {
+		response.Alerts = append(response.Alerts, history[i])
+	}
+
+	// Return response
+	return &response, nil
+
+}
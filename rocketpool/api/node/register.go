@@ -6,7 +6,6 @@ import (
 	"github.com/rocket-pool/rocketpool-go/node"
 	"github.com/rocket-pool/rocketpool-go/settings/protocol"
 	"github.com/urfave/cli"
-	"golang.org/x/sync/errgroup"
 
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/types/api"
@@ -34,34 +33,35 @@ func canRegisterNode(c *cli.Context, timezoneLocation string) (*api.CanRegisterN
 	// Response
 	response := api.CanRegisterNodeResponse{}
 
-	// Sync
-	var wg errgroup.Group
+	// Run pre-flight checks
+	preflight := services.NewPreflightRunner()
 
 	// Check node is not already registered
-	wg.Go(func() error {
+	preflight.Check("Node not registered", "The node is already registered with Rocket Pool.", func() (bool, error) {
 		nodeAccount, err := w.GetNodeAccount()
 		if err != nil {
-			return err
+			return false, err
 		}
 		exists, err := node.GetNodeExists(rp, nodeAccount.Address, nil)
 		if err != nil {
-			return err
+			return false, err
 		}
 		response.AlreadyRegistered = exists
-		return nil
+		return !exists, nil
 	})
 
 	// Check node registrations are enabled
-	wg.Go(func() error {
+	preflight.Check("Registrations enabled", "Node registrations are currently disabled.", func() (bool, error) {
 		registrationEnabled, err := protocol.GetNodeRegistrationEnabled(rp, nil)
-		if err == nil {
-			response.RegistrationDisabled = !registrationEnabled
+		if err != nil {
+			return false, err
 		}
-		return err
+		response.RegistrationDisabled = !registrationEnabled
+		return registrationEnabled, nil
 	})
 
 	// Get gas estimate
-	wg.Go(func() error {
+	preflight.Load(func() error {
 		opts, err := w.GetNodeAccountTransactor()
 		if err != nil {
 			return err
@@ -74,12 +74,13 @@ func canRegisterNode(c *cli.Context, timezoneLocation string) (*api.CanRegisterN
 	})
 
 	// Wait for data
-	if err := wg.Wait(); err != nil {
+	response.Preflight, err = preflight.Run()
+	if err != nil {
 		return nil, err
 	}
 
 	// Update & return response
-	response.CanRegister = !(response.AlreadyRegistered || response.RegistrationDisabled)
+	response.CanRegister = response.Preflight.Passed
 	return &response, nil
 
 }
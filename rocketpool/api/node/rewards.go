@@ -19,9 +19,11 @@ import (
 
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/progress"
 	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	"github.com/rocket-pool/smartnode/shared/utils/eth2"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
 )
 
 func getRewards(c *cli.Context) (*api.NodeRewardsResponse, error) {
@@ -134,6 +136,11 @@ func getRewards(c *cli.Context) (*api.NodeRewardsResponse, error) {
 			return err
 		}
 
+		// Each interval needs its snapshot event from the logs, which can take a while on nodes that have been running for a long time
+		reporter := progress.NewStderrReporter("Loading rewards")
+		reporter.SetPhase("Scanning rewards intervals", uint64(len(claimed)+len(unclaimed)))
+		scanned := uint64(0)
+
 		// Get the info for each claimed interval
 		for _, claimedInterval := range claimed {
 			intervalInfo, err := rprewards.GetIntervalInfo(rp, cfg, nodeAccount.Address, claimedInterval, nil)
@@ -145,6 +152,8 @@ func getRewards(c *cli.Context) (*api.NodeRewardsResponse, error) {
 			}
 			rplRewards.Add(rplRewards, &intervalInfo.CollateralRplAmount.Int)
 			ethRewards.Add(ethRewards, &intervalInfo.SmoothingPoolEthAmount.Int)
+			scanned++
+			reporter.SetProgress(scanned)
 		}
 
 		// Get the unclaimed rewards
@@ -153,6 +162,8 @@ func getRewards(c *cli.Context) (*api.NodeRewardsResponse, error) {
 			if err != nil {
 				return err
 			}
+			scanned++
+			reporter.SetProgress(scanned)
 			if !intervalInfo.TreeFileExists {
 				return fmt.Errorf("Error calculating lifetime node rewards: rewards file %s doesn't exist and interval %d is unclaimed", intervalInfo.TreeFilePath, unclaimedInterval)
 			}
@@ -207,6 +218,13 @@ func getRewards(c *cli.Context) (*api.NodeRewardsResponse, error) {
 		return err
 	})
 
+	// Check if the node can stake without RPL
+	wg.Go(func() error {
+		var err error
+		response.EthOnlyPermitted, err = rputils.IsEthOnlyStakingPermitted(rp, nil)
+		return err
+	})
+
 	// Get the total network effective stake
 	wg.Go(func() error {
 		multicallerAddress := common.HexToAddress(cfg.Smartnode.GetMulticallAddress())
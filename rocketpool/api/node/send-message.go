@@ -78,7 +78,9 @@ func sendMessage(c *cli.Context, address common.Address, message []byte) (*api.N
 	}
 
 	// Send the message
-	hash, err := eth.SendTransaction(ec, address, w.GetChainID(), message, true, opts)
+	hash, err := sendInTurn(c, func() (common.Hash, error) {
+		return eth.SendTransaction(ec, address, w.GetChainID(), message, true, opts)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("error sending message: %w", err)
 	}
@@ -12,6 +12,8 @@ import (
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/cmdqueue"
+	"github.com/rocket-pool/smartnode/shared/services/progress"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	"github.com/rocket-pool/smartnode/shared/utils/eth1"
 )
@@ -250,7 +252,9 @@ func nodeSend(c *cli.Context, amountWei *big.Int, token string, to common.Addres
 
 			// Transfer ETH
 			opts.Value = amountWei
-			hash, err := eth.SendTransaction(ec, to, w.GetChainID(), nil, false, opts)
+			hash, err := sendInTurn(c, func() (common.Hash, error) {
+				return eth.SendTransaction(ec, to, w.GetChainID(), nil, false, opts)
+			})
 			if err != nil {
 				return nil, err
 			}
@@ -302,3 +306,17 @@ func nodeSend(c *cli.Context, amountWei *big.Int, token string, to common.Addres
 	return &response, nil
 
 }
+
+// Send a raw transaction while holding the command queue, since it assigns its own nonce outside of the node wallet's transactor
+func sendInTurn(c *cli.Context, send func() (common.Hash, error)) (common.Hash, error) {
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	lock, err := cmdqueue.Acquire(cfg.Smartnode.GetCommandQueuePath(), cmdqueue.DefaultTimeout, progress.NewStderrReporter("Waiting for the node wallet"))
+	if err != nil {
+		return common.Hash{}, err
+	}
+	defer lock.Release()
+	return send()
+}
@@ -39,26 +39,40 @@ func canNodeStakeRpl(c *cli.Context, amountWei *big.Int) (*api.CanNodeStakeRplRe
 		return nil, err
 	}
 
+	// Run pre-flight checks
+	preflight := services.NewPreflightRunner()
+
 	// Check RPL balance
-	rplBalance, err := tokens.GetRPLBalance(rp, nodeAccount.Address, nil)
-	if err != nil {
-		return nil, err
-	}
-	response.InsufficientBalance = (amountWei.Cmp(rplBalance) > 0)
+	preflight.Check("Sufficient RPL balance", "The node's RPL balance is insufficient.", func() (bool, error) {
+		rplBalance, err := tokens.GetRPLBalance(rp, nodeAccount.Address, nil)
+		if err != nil {
+			return false, err
+		}
+		response.InsufficientBalance = (amountWei.Cmp(rplBalance) > 0)
+		return !response.InsufficientBalance, nil
+	})
 
 	// Get gas estimates
-	opts, err := w.GetNodeAccountTransactor()
+	preflight.Load(func() error {
+		opts, err := w.GetNodeAccountTransactor()
+		if err != nil {
+			return err
+		}
+		gasInfo, err := node.EstimateStakeGas(rp, amountWei, opts)
+		if err == nil {
+			response.GasInfo = gasInfo
+		}
+		return err
+	})
+
+	// Wait for data
+	response.Preflight, err = preflight.Run()
 	if err != nil {
 		return nil, err
 	}
-	gasInfo, err := node.EstimateStakeGas(rp, amountWei, opts)
-	if err != nil {
-		return nil, err
-	}
-	response.GasInfo = gasInfo
 
 	// Update & return response
-	response.CanStake = !(response.InsufficientBalance)
+	response.CanStake = response.Preflight.Passed
 	return &response, nil
 
 }
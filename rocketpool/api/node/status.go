@@ -128,6 +128,11 @@ func getStatus(c *cli.Context) (*api.NodeStatusResponse, error) {
 		response.EthMatched, response.EthMatchedLimit, response.PendingMatchAmount, err = rputils.CheckCollateral(rp, nodeAccount.Address, nil)
 		return err
 	})
+	wg.Go(func() error {
+		var err error
+		response.EthOnlyPermitted, err = rputils.IsEthOnlyStakingPermitted(rp, nil)
+		return err
+	})
 
 	wg.Go(func() error {
 		var err error
@@ -0,0 +1,237 @@
+package node
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/legacy/v1.0.0/rewards"
+	"github.com/rocket-pool/rocketpool-go/node"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/progress"
+	"github.com/rocket-pool/smartnode/shared/services/upgrades"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/eth1"
+)
+
+func getStrandedAssets(c *cli.Context) (*api.NodeStrandedAssetsResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireRocketStorage(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeStrandedAssetsResponse{}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Find the assets
+	reporter := progress.NewStderrReporter("Checking previous contracts")
+	assets, err := findStrandedAssets(rp, cfg, nodeAccount.Address, reporter)
+	reporter.Finish(err)
+	if err != nil {
+		return nil, err
+	}
+	response.Assets = assets
+
+	// Return response
+	return &response, nil
+
+}
+
+func canClaimStrandedAsset(c *cli.Context, assetType upgrades.AssetType, address common.Address) (*api.CanNodeClaimStrandedAssetResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireRocketStorage(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.CanNodeClaimStrandedAssetResponse{}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Make sure the asset is still there
+	asset, err := getStrandedAsset(rp, cfg, nodeAccount.Address, assetType, address)
+	if err != nil {
+		return nil, err
+	}
+	response.NotFound = (asset == nil)
+	response.CanClaim = !response.NotFound
+	if !response.CanClaim {
+		return &response, nil
+	}
+
+	// Get gas estimates
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+	var gasInfo rocketpool.GasInfo
+	switch asset.Type {
+	case upgrades.AssetType_RplClaim:
+		gasInfo, err = rewards.EstimateClaimNodeRewardsGas(rp, opts, &asset.Contract)
+	case upgrades.AssetType_TrustedRplClaim:
+		gasInfo, err = rewards.EstimateClaimTrustedNodeRewardsGas(rp, opts, &asset.Contract)
+	case upgrades.AssetType_FeeDistributor:
+		var distributor *node.Distributor
+		distributor, err = node.NewDistributor(rp, asset.Address, nil)
+		if err == nil {
+			gasInfo, err = distributor.EstimateDistributeGas(opts)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	response.GasInfo = gasInfo
+
+	// Return response
+	return &response, nil
+
+}
+
+func claimStrandedAsset(c *cli.Context, assetType upgrades.AssetType, address common.Address) (*api.NodeClaimStrandedAssetResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireRocketStorage(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeClaimStrandedAssetResponse{}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Make sure the asset is still there
+	asset, err := getStrandedAsset(rp, cfg, nodeAccount.Address, assetType, address)
+	if err != nil {
+		return nil, err
+	}
+	if asset == nil {
+		return nil, fmt.Errorf("The node doesn't have a %s at %s.", assetType, address.Hex())
+	}
+
+	// Get transactor
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+
+	// Override the provided pending TX if requested
+	err = eth1.CheckForNonceOverride(c, opts)
+	if err != nil {
+		return nil, fmt.Errorf("Error checking for nonce override: %w", err)
+	}
+
+	// Claim the asset
+	var hash common.Hash
+	switch asset.Type {
+	case upgrades.AssetType_RplClaim:
+		hash, err = rewards.ClaimNodeRewards(rp, opts, &asset.Contract)
+	case upgrades.AssetType_TrustedRplClaim:
+		hash, err = rewards.ClaimTrustedNodeRewards(rp, opts, &asset.Contract)
+	case upgrades.AssetType_FeeDistributor:
+		var distributor *node.Distributor
+		distributor, err = node.NewDistributor(rp, asset.Address, nil)
+		if err == nil {
+			hash, err = distributor.Distribute(opts)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	response.TxHash = hash
+
+	// Return response
+	return &response, nil
+
+}
+
+// Bring the upgrade history up to date and find the node's assets in the previous contracts it lists
+func findStrandedAssets(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, nodeAddress common.Address, reporter *progress.Reporter) ([]upgrades.StrandedAsset, error) {
+	eventLogInterval, err := cfg.GetEventLogInterval()
+	if err != nil {
+		return nil, err
+	}
+	history, err := upgrades.UpdateHistory(rp, cfg.Smartnode.GetUpgradeHistoryPath(), big.NewInt(int64(eventLogInterval)), cfg.Smartnode.ConfirmationDepth.Value.(uint64), reporter)
+	if err != nil {
+		return nil, err
+	}
+	return upgrades.FindStrandedAssets(rp, cfg, history, nodeAddress)
+}
+
+// Get one of the node's stranded assets, or nil if it isn't there anymore
+func getStrandedAsset(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, nodeAddress common.Address, assetType upgrades.AssetType, address common.Address) (*upgrades.StrandedAsset, error) {
+	assets, err := findStrandedAssets(rp, cfg, nodeAddress, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, asset := range assets {
+		if asset.Type == assetType && asset.Address == address {
+			return &asset, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,81 @@
+package node
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/network"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/uptime"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+// Settings
+const (
+	uptimeRethAprDays  uint64  = 30
+	secondsPerYear     float64 = 365 * 24 * 60 * 60
+	validatorStakedEth float64 = 32
+)
+
+func getUptime(c *cli.Context, months uint64) (*api.NodeUptimeResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeUptimeResponse{}
+
+	// Load the report for the requested months
+	store, err := uptime.LoadStore(cfg.Smartnode.GetValidatorUptimePath())
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	since := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, -int(months-1), 0)
+	response.Months = store.GetMonthlyReport(since)
+
+	// Estimate the validator APR from the trailing rETH APR
+	eventLogInterval, err := cfg.GetEventLogInterval()
+	if err != nil {
+		return nil, err
+	}
+	rethApr, _, err := rputils.GetTrailingRethApr(rp, uptimeRethAprDays, big.NewInt(int64(eventLogInterval)))
+	if err != nil {
+		return nil, err
+	}
+	utilization, err := network.GetETHUtilizationRate(rp, nil)
+	if err != nil {
+		return nil, err
+	}
+	nodeFee, err := network.GetNodeFee(rp, nil)
+	if err != nil {
+		return nil, err
+	}
+	response.ValidatorApr = rputils.GetValidatorAprFromRethApr(rethApr, utilization, nodeFee)
+
+	// Estimate the revenue lost while offline: the missed rewards, plus inactivity penalties of roughly the same size
+	ethPerSecond := validatorStakedEth * response.ValidatorApr / 100 / secondsPerYear
+	for i := range response.Months {
+		for j := range response.Months[i].Validators {
+			validator := &response.Months[i].Validators[j]
+			validator.EstimatedEthLost = 2 * validator.Downtime.Seconds() * ethPerSecond
+		}
+	}
+
+	// Return response
+	return &response, nil
+
+}
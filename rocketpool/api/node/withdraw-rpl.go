@@ -8,7 +8,6 @@ import (
 	"github.com/rocket-pool/rocketpool-go/node"
 	"github.com/rocket-pool/rocketpool-go/settings/protocol"
 	"github.com/urfave/cli"
-	"golang.org/x/sync/errgroup"
 
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/types/api"
@@ -44,7 +43,7 @@ func canNodeWithdrawRpl(c *cli.Context, amountWei *big.Int) (*api.CanNodeWithdra
 	}
 
 	// Data
-	var wg errgroup.Group
+	preflight := services.NewPreflightRunner()
 	var rplStake *big.Int
 	var minimumRplStake *big.Int
 	var currentTime uint64
@@ -52,21 +51,21 @@ func canNodeWithdrawRpl(c *cli.Context, amountWei *big.Int) (*api.CanNodeWithdra
 	var withdrawalDelay uint64
 
 	// Get RPL stake
-	wg.Go(func() error {
+	preflight.Load(func() error {
 		var err error
 		rplStake, err = node.GetNodeRPLStake(rp, nodeAccount.Address, nil)
 		return err
 	})
 
 	// Get minimum RPL stake
-	wg.Go(func() error {
+	preflight.Load(func() error {
 		var err error
 		minimumRplStake, err = node.GetNodeMinimumRPLStake(rp, nodeAccount.Address, nil)
 		return err
 	})
 
 	// Get current block
-	wg.Go(func() error {
+	preflight.Load(func() error {
 		header, err := ec.HeaderByNumber(context.Background(), nil)
 		if err == nil {
 			currentTime = header.Time
@@ -75,21 +74,21 @@ func canNodeWithdrawRpl(c *cli.Context, amountWei *big.Int) (*api.CanNodeWithdra
 	})
 
 	// Get RPL staked time
-	wg.Go(func() error {
+	preflight.Load(func() error {
 		var err error
 		rplStakedTime, err = node.GetNodeRPLStakedTime(rp, nodeAccount.Address, nil)
 		return err
 	})
 
 	// Get withdrawal delay
-	wg.Go(func() error {
+	preflight.Load(func() error {
 		var err error
 		withdrawalDelay, err = protocol.GetRewardsClaimIntervalTime(rp, nil)
 		return err
 	})
 
 	// Get gas estimate
-	wg.Go(func() error {
+	preflight.Load(func() error {
 		opts, err := w.GetNodeAccountTransactor()
 		if err != nil {
 			return err
@@ -101,20 +100,30 @@ func canNodeWithdrawRpl(c *cli.Context, amountWei *big.Int) (*api.CanNodeWithdra
 		return err
 	})
 
+	// Check data
+	preflight.Check("Sufficient staked RPL", "The node's staked RPL balance is insufficient.", func() (bool, error) {
+		response.InsufficientBalance = (amountWei.Cmp(rplStake) > 0)
+		return !response.InsufficientBalance, nil
+	})
+	preflight.Check("Minipools collateralized", "Remaining staked RPL is not enough to collateralize the node's minipools.", func() (bool, error) {
+		var remainingRplStake big.Int
+		remainingRplStake.Sub(rplStake, amountWei)
+		response.MinipoolsUndercollateralized = (remainingRplStake.Cmp(minimumRplStake) < 0)
+		return !response.MinipoolsUndercollateralized, nil
+	})
+	preflight.Check("Withdrawal delay passed", "The withdrawal delay period has not passed.", func() (bool, error) {
+		response.WithdrawalDelayActive = ((currentTime - rplStakedTime) < withdrawalDelay)
+		return !response.WithdrawalDelayActive, nil
+	})
+
 	// Wait for data
-	if err := wg.Wait(); err != nil {
+	response.Preflight, err = preflight.Run()
+	if err != nil {
 		return nil, err
 	}
 
-	// Check data
-	var remainingRplStake big.Int
-	remainingRplStake.Sub(rplStake, amountWei)
-	response.InsufficientBalance = (amountWei.Cmp(rplStake) > 0)
-	response.MinipoolsUndercollateralized = (remainingRplStake.Cmp(minimumRplStake) < 0)
-	response.WithdrawalDelayActive = ((currentTime - rplStakedTime) < withdrawalDelay)
-
 	// Update & return response
-	response.CanWithdraw = !(response.InsufficientBalance || response.MinipoolsUndercollateralized || response.WithdrawalDelayActive)
+	response.CanWithdraw = response.Preflight.Passed
 	return &response, nil
 
 }
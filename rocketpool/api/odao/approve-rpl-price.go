@@ -0,0 +1,54 @@
+package odao
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/rocket-pool/rocketpool-go/network"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Set a marker for the watchtower to submit the RPL price for the given block even though it diverges from the reference prices
+func approveRplPrice(c *cli.Context, blockNumber uint64) (*api.ApproveTNDAORplPriceResponse, error) {
+
+	// Get services
+	if err := services.RequireRocketStorage(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.ApproveTNDAORplPriceResponse{}
+
+	// Make sure the price for the block hasn't been agreed on already
+	response.PricesBlock, err = network.GetPricesBlock(rp, nil)
+	if err != nil {
+		return nil, err
+	}
+	if blockNumber <= response.PricesBlock {
+		return nil, fmt.Errorf("The RPL price has already been submitted for block %d.", response.PricesBlock)
+	}
+
+	// Create the approval marker
+	approvalPath := cfg.Smartnode.GetRplPriceApprovalPath(blockNumber, true)
+	approvalFile, err := os.Create(approvalPath)
+	if approvalFile != nil {
+		approvalFile.Close()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Error creating approval marker: %w", err)
+	}
+
+	return &response, nil
+
+}
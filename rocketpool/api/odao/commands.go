@@ -1029,6 +1029,54 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "submissions",
+				Usage:     "Get the latest Oracle DAO submissions of a type, reconstructed from their events",
+				UsageText: "rocketpool api odao submissions type last",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					submissionType, err := cliutils.ValidateTNDAOSubmissionType("submission type", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					last, err := cliutils.ValidatePositiveUint("round count", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getSubmissions(c, submissionType, last))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "approve-rpl-price",
+				Usage:     "Set a marker for the watchtower to submit the RPL price for the given block even though it diverges from the reference prices",
+				UsageText: "rocketpool api odao approve-rpl-price block",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					blockNumber, err := cliutils.ValidateUint("block number", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(approveRplPrice(c, blockNumber))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "get-member-settings",
 				Usage:     "Get the ODAO settings related to ODAO members",
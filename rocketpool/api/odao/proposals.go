@@ -5,6 +5,7 @@ import (
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/daopayload"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 )
 
@@ -43,6 +44,19 @@ func getProposals(c *cli.Context) (*api.TNDAOProposalsResponse, error) {
 
 	response.Proposals = proposals
 
+	// Describe the proposal payloads; the raw payload strings are still there for the ones that can't be decoded
+	response.PayloadDescriptions = map[uint64]string{}
+	proposalsAbi, err := rp.GetABI(daopayload.OracleDaoProposalsContractName, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, proposal := range proposals {
+		decoded, err := daopayload.DecodeWithAbi(proposalsAbi, proposal.Payload)
+		if err == nil {
+			response.PayloadDescriptions[proposal.ID] = decoded.Description
+		}
+	}
+
 	// Return response
 	return &response, nil
 
@@ -83,6 +97,12 @@ func getProposal(c *cli.Context, id uint64) (*api.TNDAOProposalResponse, error)
 
 	response.Proposals = proposal
 
+	// Describe the proposal payload
+	decoded, err := daopayload.Decode(rp, daopayload.OracleDaoProposalsContractName, proposal.Payload)
+	if err == nil {
+		response.PayloadDescription = decoded.Description
+	}
+
 	// Return response
 	return &response, nil
 
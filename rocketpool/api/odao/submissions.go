@@ -0,0 +1,305 @@
+package odao
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rocket-pool/rocketpool-go/dao/trustednode"
+	"github.com/rocket-pool/rocketpool-go/rewards"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/logscan"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// The number of blocks to search for submissions at a time, going back from the head (about a week)
+const submissionsScanWindow uint64 = 7200 * 7
+
+// A submission or consensus event, decoded
+type submissionEvent struct {
+	key            uint64
+	referenceBlock uint64
+	member         common.Address
+	block          uint64
+	time           time.Time
+	rawValue       string
+	value          string
+}
+
+// A round of submissions, with the raw values used to compare the votes with the consensus
+type submissionRound struct {
+	round        api.TNDAOSubmissionRound
+	consensusRaw string
+	voteRaws     []string
+}
+
+func getSubmissions(c *cli.Context, submissionType api.TNDAOSubmissionType, last uint64) (*api.TNDAOSubmissionsResponse, error) {
+
+	// Get services
+	if err := services.RequireRocketStorage(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.TNDAOSubmissionsResponse{
+		Type:   submissionType,
+		Rounds: []api.TNDAOSubmissionRound{},
+	}
+
+	// Get the contract and the events for the submission type
+	var contractName, submittedName, updatedName string
+	switch submissionType {
+	case api.TNDAOSubmissionType_Prices:
+		contractName, submittedName, updatedName = "rocketNetworkPrices", "PricesSubmitted", "PricesUpdated"
+	case api.TNDAOSubmissionType_Balances:
+		contractName, submittedName, updatedName = "rocketNetworkBalances", "BalancesSubmitted", "BalancesUpdated"
+	case api.TNDAOSubmissionType_Rewards:
+		contractName, submittedName, updatedName = "rocketRewardsPool", "RewardSnapshotSubmitted", "RewardSnapshot"
+	default:
+		return nil, fmt.Errorf("unknown submission type [%s]", submissionType)
+	}
+	contract, err := rp.GetContract(contractName, nil)
+	if err != nil {
+		return nil, err
+	}
+	submittedEvent, exists := contract.ABI.Events[submittedName]
+	if !exists {
+		return nil, fmt.Errorf("%s does not have a %s event", contractName, submittedName)
+	}
+	updatedEvent, exists := contract.ABI.Events[updatedName]
+	if !exists {
+		return nil, fmt.Errorf("%s does not have a %s event", contractName, updatedName)
+	}
+	eventLogInterval, err := cfg.GetEventLogInterval()
+	if err != nil {
+		return nil, err
+	}
+	intervalSize := big.NewInt(int64(eventLogInterval))
+
+	// Get the current member count
+	response.MemberCount, err = trustednode.GetMemberCount(rp, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Search back from the head until the requested number of rounds has been found, along with all of their submissions;
+	// a round's submissions can't be made before the block its values were calculated at
+	currentBlock, err := rp.Client.BlockNumber(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("error getting latest block number: %w", err)
+	}
+	rounds := map[uint64]*submissionRound{}
+	topicFilter := [][]common.Hash{{submittedEvent.ID, updatedEvent.ID}}
+	toBlock := currentBlock
+	for {
+		fromBlock := uint64(0)
+		if toBlock > submissionsScanWindow {
+			fromBlock = toBlock - submissionsScanWindow + 1
+		}
+		logs, err := logscan.GetLogs(rp, []common.Address{*contract.Address}, topicFilter, intervalSize, new(big.Int).SetUint64(fromBlock), new(big.Int).SetUint64(toBlock))
+		if err != nil {
+			return nil, fmt.Errorf("error getting %s submission events: %w", submissionType, err)
+		}
+
+		for _, log := range logs {
+			isConsensus := log.Topics[0] == updatedEvent.ID
+			event := submittedEvent
+			if isConsensus {
+				event = updatedEvent
+			}
+			decoded, err := decodeSubmissionEvent(submissionType, event, log, isConsensus)
+			if err != nil {
+				return nil, err
+			}
+
+			round, exists := rounds[decoded.key]
+			if !exists {
+				round = &submissionRound{
+					round: api.TNDAOSubmissionRound{
+						Key:            decoded.key,
+						ReferenceBlock: decoded.referenceBlock,
+						Votes:          []api.TNDAOSubmissionVote{},
+					},
+				}
+				rounds[decoded.key] = round
+			}
+			if isConsensus {
+				round.round.ConsensusReached = true
+				round.round.ConsensusBlock = decoded.block
+				round.round.ConsensusTime = decoded.time
+				round.round.ConsensusValue = decoded.value
+				round.consensusRaw = decoded.rawValue
+				continue
+			}
+			round.round.Votes = append(round.round.Votes, api.TNDAOSubmissionVote{
+				Member: decoded.member,
+				Block:  decoded.block,
+				Time:   decoded.time,
+				Value:  decoded.value,
+			})
+			round.voteRaws = append(round.voteRaws, decoded.rawValue)
+		}
+
+		if fromBlock == 0 || hasCompleteRounds(rounds, last, fromBlock) {
+			break
+		}
+		toBlock = fromBlock - 1
+	}
+
+	// Keep the latest rounds
+	keys := make([]uint64, 0, len(rounds))
+	for key := range rounds {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i] > keys[j]
+	})
+	if uint64(len(keys)) > last {
+		keys = keys[:last]
+	}
+
+	// Compare the votes with the consensus and name the members
+	memberIDs := map[common.Address]string{}
+	for _, key := range keys {
+		round := rounds[key]
+		for i := range round.round.Votes {
+			vote := &round.round.Votes[i]
+			vote.MatchesConsensus = round.round.ConsensusReached && round.voteRaws[i] == round.consensusRaw
+			id, exists := memberIDs[vote.Member]
+			if !exists {
+				id, err = trustednode.GetMemberID(rp, vote.Member, nil)
+				if err != nil {
+					return nil, fmt.Errorf("error getting the ID of member %s: %w", vote.Member.Hex(), err)
+				}
+				memberIDs[vote.Member] = id
+			}
+			vote.MemberID = id
+		}
+		sort.SliceStable(round.round.Votes, func(i, j int) bool {
+			return round.round.Votes[i].Block < round.round.Votes[j].Block
+		})
+		response.Rounds = append(response.Rounds, round.round)
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Check if the latest rounds that were found have been searched back to the blocks their values were calculated at
+func hasCompleteRounds(rounds map[uint64]*submissionRound, last uint64, fromBlock uint64) bool {
+	if uint64(len(rounds)) < last {
+		return false
+	}
+	keys := make([]uint64, 0, len(rounds))
+	for key := range rounds {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i] > keys[j]
+	})
+	for _, key := range keys[:last] {
+		if rounds[key].round.ReferenceBlock < fromBlock {
+			return false
+		}
+	}
+	return true
+}
+
+// Decode a submission or consensus event
+func decodeSubmissionEvent(submissionType api.TNDAOSubmissionType, event abi.Event, log types.Log, isConsensus bool) (submissionEvent, error) {
+	decoded := submissionEvent{
+		block: log.BlockNumber,
+	}
+	values := make(map[string]interface{})
+	if err := event.Inputs.UnpackIntoMap(values, log.Data); err != nil {
+		return decoded, fmt.Errorf("error unpacking %s event in block %d: %w", event.Name, log.BlockNumber, err)
+	}
+	timestamp, exists := values["blockTimestamp"].(*big.Int)
+	if !exists {
+		// Older versions of the contracts call it "time"
+		timestamp, _ = values["time"].(*big.Int)
+	}
+	if timestamp != nil {
+		decoded.time = time.Unix(timestamp.Int64(), 0)
+	}
+
+	// The submitting member is the first indexed argument of the submission events
+	if !isConsensus {
+		if len(log.Topics) < 2 {
+			return decoded, fmt.Errorf("%s event in block %d is missing its member", event.Name, log.BlockNumber)
+		}
+		decoded.member = common.BytesToAddress(log.Topics[1].Bytes())
+	}
+
+	switch submissionType {
+	case api.TNDAOSubmissionType_Prices:
+		block, _ := values["block"].(*big.Int)
+		rplPrice, _ := values["rplPrice"].(*big.Int)
+		if block == nil || rplPrice == nil {
+			return decoded, fmt.Errorf("%s event in block %d is missing its values", event.Name, log.BlockNumber)
+		}
+		decoded.key = block.Uint64()
+		decoded.referenceBlock = decoded.key
+		decoded.rawValue = rplPrice.String()
+		decoded.value = fmt.Sprintf("%.9f ETH per RPL", eth.WeiToEth(rplPrice))
+
+	case api.TNDAOSubmissionType_Balances:
+		block, _ := values["block"].(*big.Int)
+		totalEth, _ := values["totalEth"].(*big.Int)
+		stakingEth, _ := values["stakingEth"].(*big.Int)
+		rethSupply, _ := values["rethSupply"].(*big.Int)
+		if block == nil || totalEth == nil || stakingEth == nil || rethSupply == nil {
+			return decoded, fmt.Errorf("%s event in block %d is missing its values", event.Name, log.BlockNumber)
+		}
+		decoded.key = block.Uint64()
+		decoded.referenceBlock = decoded.key
+		decoded.rawValue = fmt.Sprintf("%s/%s/%s", totalEth.String(), stakingEth.String(), rethSupply.String())
+		rate := 0.0
+		if rethSupply.Sign() > 0 {
+			rate = eth.WeiToEth(totalEth) / eth.WeiToEth(rethSupply)
+		}
+		decoded.value = fmt.Sprintf("%.6f ETH total, %.6f ETH staking, %.6f rETH supply (rate %.8f)", eth.WeiToEth(totalEth), eth.WeiToEth(stakingEth), eth.WeiToEth(rethSupply), rate)
+
+	case api.TNDAOSubmissionType_Rewards:
+		// The interval is indexed after the member in the submission events, and on its own in the consensus event
+		indexTopic := 2
+		if isConsensus {
+			indexTopic = 1
+		}
+		if len(log.Topics) <= indexTopic {
+			return decoded, fmt.Errorf("%s event in block %d is missing its interval", event.Name, log.BlockNumber)
+		}
+		decoded.key = new(big.Int).SetBytes(log.Topics[indexTopic].Bytes()).Uint64()
+		submissionValue, exists := values["submission"]
+		if !exists {
+			return decoded, fmt.Errorf("%s event in block %d is missing its submission", event.Name, log.BlockNumber)
+		}
+		submission := reflect.ValueOf(submissionValue).Convert(reflect.TypeOf(rewards.RewardSubmission{})).Interface().(rewards.RewardSubmission)
+		if submission.ExecutionBlock != nil {
+			decoded.referenceBlock = submission.ExecutionBlock.Uint64()
+		}
+		root := common.BytesToHash(submission.MerkleRoot[:]).Hex()
+		decoded.rawValue = fmt.Sprintf("%s/%s", root, submission.MerkleTreeCID)
+		decoded.value = fmt.Sprintf("root %s (CID %s)", root, submission.MerkleTreeCID)
+	}
+
+	return decoded, nil
+}
@@ -1,6 +1,8 @@
 package queue
 
 import (
+	"math/big"
+
 	"github.com/rocket-pool/rocketpool-go/deposit"
 	"github.com/rocket-pool/rocketpool-go/minipool"
 	"github.com/urfave/cli"
@@ -8,21 +10,32 @@ import (
 
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/types/api"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
 )
 
+// The number of days the deposit rate is averaged over
+const depositRateDays uint64 = 7
+
 func getStatus(c *cli.Context) (*api.QueueStatusResponse, error) {
 
 	// Get services
 	if err := services.RequireRocketStorage(c); err != nil {
 		return nil, err
 	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
 	rp, err := services.GetRocketPool(c)
 	if err != nil {
 		return nil, err
 	}
 
 	// Response
-	response := api.QueueStatusResponse{}
+	response := api.QueueStatusResponse{
+		DepositRateDays: depositRateDays,
+		NodeMinipools:   []api.QueuedMinipool{},
+	}
 
 	// Sync
 	var wg errgroup.Group
@@ -48,12 +61,86 @@ func getStatus(c *cli.Context) (*api.QueueStatusResponse, error) {
 		return err
 	})
 
+	// Get the deposit rate
+	wg.Go(func() error {
+		eventLogInterval, err := cfg.GetEventLogInterval()
+		if err != nil {
+			return err
+		}
+		response.DepositRate, err = rputils.GetTrailingDepositRate(rp, depositRateDays, big.NewInt(int64(eventLogInterval)))
+		return err
+	})
+
 	// Wait for data
 	if err := wg.Wait(); err != nil {
 		return nil, err
 	}
 
+	// Estimate the wait of the node's minipools in the queue, if there's a registered node
+	if err := addNodeQueueStatus(c, &response); err != nil {
+		return nil, err
+	}
+
 	// Return response
 	return &response, nil
 
 }
+
+// Add the node's queued minipools to the queue status
+func addNodeQueueStatus(c *cli.Context, response *api.QueueStatusResponse) error {
+
+	// Get services
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return err
+	}
+	if !w.IsInitialized() {
+		return nil
+	}
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return err
+	}
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+
+	// Get the queue position of each minipool
+	addresses, err := minipool.GetNodeMinipoolAddresses(rp, nodeAccount.Address, nil)
+	if err != nil {
+		return err
+	}
+	positions := make([]int64, len(addresses))
+	var wg errgroup.Group
+	for i, address := range addresses {
+		i := i
+		address := address
+		wg.Go(func() error {
+			var err error
+			positions[i], err = minipool.GetQueuePositionOfMinipool(rp, address, nil)
+			return err
+		})
+	}
+	if err := wg.Wait(); err != nil {
+		return err
+	}
+
+	// Estimate how long each queued minipool will wait
+	for i, address := range addresses {
+		if positions[i] <= 0 {
+			continue
+		}
+		queued := api.QueuedMinipool{
+			Address:  address,
+			Position: uint64(positions[i]),
+		}
+		queued.EstimatedWait, queued.WaitKnown = rputils.EstimateQueueWait(queued.Position, response.MinipoolQueueLength, response.MinipoolQueueCapacity, response.DepositPoolBalance, response.DepositRate)
+		response.NodeMinipools = append(response.NodeMinipools, queued)
+	}
+	return nil
+
+}
@@ -0,0 +1,288 @@
+package roles
+
+import (
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// Commands that only admin keys can run, because they expose the node wallet or its keys, move funds out of the node, change who owns it,
+// stop it from validating or manage the node itself
+var adminRoutes = map[string]bool{
+	"debug/export-validators":             true,
+	"minipool/change-withdrawal-creds":    true,
+	"minipool/close":                      true,
+	"minipool/exit":                       true,
+	"minipool/import-key":                 true,
+	"node/burn":                           true,
+	"node/confirm-withdrawal-address":     true,
+	"node/send":                           true,
+	"node/send-message":                   true,
+	"node/set-stake-rpl-for-allowed":      true,
+	"node/set-withdrawal-address":         true,
+	"node/sign":                           true,
+	"node/sign-message":                   true,
+	"service/approve-command":             true,
+	"service/check-backup":                true,
+	"service/confirm-command":             true,
+	"service/create-backup":               true,
+	"service/decrypt-validator-keys":      true,
+	"service/encrypt-validator-keys":      true,
+	"service/fork-mine":                   true,
+	"service/fork-set-balance":            true,
+	"service/fork-warp":                   true,
+	"service/migrate-secrets":             true,
+	"service/restart-vc":                  true,
+	"service/restore-backup":              true,
+	"service/set-confirmation-passphrase": true,
+	"service/terminate-data-folder":       true,
+	"wallet/export":                       true,
+	"wallet/init":                         true,
+	"wallet/lock":                         true,
+	"wallet/rebuild":                      true,
+	"wallet/recover":                      true,
+	"wallet/search-and-recover":           true,
+	"wallet/set-password":                 true,
+	"wallet/test-recovery":                true,
+	"wallet/test-search-and-recover":      true,
+	"wallet/unlock":                       true,
+}
+
+// Commands that send transactions or change the node's files in the course of running it, which operators can do
+var operatorRoutes = map[string]bool{
+	"auction/bid-lot":                            true,
+	"auction/claim-lot":                          true,
+	"auction/create-lot":                         true,
+	"auction/recover-lot":                        true,
+	"faucet/withdraw-rpl":                        true,
+	"minipool/begin-reduce-bond-amount":          true,
+	"minipool/delegate-rollback":                 true,
+	"minipool/delegate-upgrade":                  true,
+	"minipool/dissolve":                          true,
+	"minipool/distribute-balance":                true,
+	"minipool/promote":                           true,
+	"minipool/reduce-bond-amount":                true,
+	"minipool/refund":                            true,
+	"minipool/rescue-dissolved":                  true,
+	"minipool/set-use-latest-delegate":           true,
+	"minipool/stake":                             true,
+	"network/download-rewards-file":              true,
+	"network/generate-rewards-tree":              true,
+	"node/claim-and-stake-rewards":               true,
+	"node/claim-rewards":                         true,
+	"node/claim-rpl-rewards":                     true,
+	"node/claim-stranded-asset":                  true,
+	"node/clear-snapshot-delegate":               true,
+	"node/create-vacant-minipool":                true,
+	"node/deposit":                               true,
+	"node/distribute":                            true,
+	"node/initialize-fee-distributor":            true,
+	"node/register":                              true,
+	"node/set-approval":                          true,
+	"node/set-minipool-block-building":           true,
+	"node/set-minipool-graffiti":                 true,
+	"node/set-smoothing-pool-status":             true,
+	"node/set-snapshot-delegate":                 true,
+	"node/set-timezone":                          true,
+	"node/stake-rpl":                             true,
+	"node/stake-rpl-approve-rpl":                 true,
+	"node/swap-rpl":                              true,
+	"node/swap-rpl-approve-rpl":                  true,
+	"node/wait-and-stake-rpl":                    true,
+	"node/wait-and-swap-rpl":                     true,
+	"node/withdraw-rpl":                          true,
+	"odao/approve-rpl-price":                     true,
+	"odao/cancel-proposal":                       true,
+	"odao/execute-proposal":                      true,
+	"odao/join":                                  true,
+	"odao/join-approve-rpl":                      true,
+	"odao/leave":                                 true,
+	"odao/propose-bond-reduction-window-length":  true,
+	"odao/propose-bond-reduction-window-start":   true,
+	"odao/propose-invite":                        true,
+	"odao/propose-kick":                          true,
+	"odao/propose-leave":                         true,
+	"odao/propose-members-minipool-unbonded-max": true,
+	"odao/propose-members-quorum":                true,
+	"odao/propose-members-rplbond":               true,
+	"odao/propose-promotion-scrub-period":        true,
+	"odao/propose-proposal-action-timespan":      true,
+	"odao/propose-proposal-cooldown":             true,
+	"odao/propose-proposal-execute-timespan":     true,
+	"odao/propose-proposal-vote-delay-timespan":  true,
+	"odao/propose-proposal-vote-timespan":        true,
+	"odao/propose-replace":                       true,
+	"odao/propose-scrub-penalty-enabled":         true,
+	"odao/propose-scrub-period":                  true,
+	"odao/replace":                               true,
+	"odao/vote-proposal":                         true,
+	"queue/process":                              true,
+	"service/request-confirmation":               true,
+	"wallet/set-ens-name":                        true,
+}
+
+// Commands that only report or check something
+var readOnlyRoutes = map[string]bool{
+	"wait":   true,
+	"events": true,
+	"routes": true,
+
+	"auction/can-bid-lot":              true,
+	"auction/can-claim-lot":            true,
+	"auction/can-create-lot":           true,
+	"auction/can-recover-lot":          true,
+	"auction/lots":                     true,
+	"auction/status":                   true,
+	"faucet/can-withdraw-rpl":          true,
+	"faucet/status":                    true,
+	"queue/can-process":                true,
+	"queue/status":                     true,
+	"wallet/estimate-gas-set-ens-name": true,
+	"wallet/status":                    true,
+
+	"minipool/audit":                                 true,
+	"minipool/can-begin-reduce-bond-amount":          true,
+	"minipool/can-change-withdrawal-creds":           true,
+	"minipool/can-delegate-rollback":                 true,
+	"minipool/can-delegate-upgrade":                  true,
+	"minipool/can-dissolve":                          true,
+	"minipool/can-exit":                              true,
+	"minipool/can-promote":                           true,
+	"minipool/can-reduce-bond-amount":                true,
+	"minipool/can-refund":                            true,
+	"minipool/can-set-use-latest-delegate":           true,
+	"minipool/can-set-use-latest-delegates":          true,
+	"minipool/can-stake":                             true,
+	"minipool/get-delegate-status":                   true,
+	"minipool/get-distribute-balance-details":        true,
+	"minipool/get-minipool-close-details-for-node":   true,
+	"minipool/get-rescue-dissolved-details-for-node": true,
+	"minipool/get-vanity-artifacts":                  true,
+	"minipool/status":                                true,
+
+	"network/apr":                       true,
+	"network/can-generate-rewards-tree": true,
+	"network/contracts":                 true,
+	"network/dao-proposals":             true,
+	"network/decode-proposal-payload":   true,
+	"network/get-fiat-prices":           true,
+	"network/get-gas-fee-history":       true,
+	"network/get-node-fee-history":      true,
+	"network/get-rewards-tree-progress": true,
+	"network/is-atlas-deployed":         true,
+	"network/latest-delegate":           true,
+	"network/node-fee":                  true,
+	"network/rpl-price":                 true,
+	"network/stats":                     true,
+	"network/stats-snapshot":            true,
+	"network/timezone-map":              true,
+
+	"node/can-burn":                               true,
+	"node/can-claim-and-stake-rewards":            true,
+	"node/can-claim-rewards":                      true,
+	"node/can-claim-rpl-rewards":                  true,
+	"node/can-claim-stranded-asset":               true,
+	"node/can-confirm-withdrawal-address":         true,
+	"node/can-create-vacant-minipool":             true,
+	"node/can-deposit":                            true,
+	"node/can-distribute":                         true,
+	"node/can-register":                           true,
+	"node/can-send":                               true,
+	"node/can-send-message":                       true,
+	"node/can-set-approval":                       true,
+	"node/can-set-smoothing-pool-status":          true,
+	"node/can-set-stake-rpl-for-allowed":          true,
+	"node/can-set-timezone":                       true,
+	"node/can-set-withdrawal-address":             true,
+	"node/can-stake-rpl":                          true,
+	"node/can-swap-rpl":                           true,
+	"node/can-withdraw-rpl":                       true,
+	"node/check-collateral":                       true,
+	"node/deposit-contract-info":                  true,
+	"node/dvt-status":                             true,
+	"node/estimate-clear-snapshot-delegate-gas":   true,
+	"node/estimate-deposit":                       true,
+	"node/estimate-set-snapshot-delegate-gas":     true,
+	"node/get-activity":                           true,
+	"node/get-approvals":                          true,
+	"node/get-block-building":                     true,
+	"node/get-eth-balance":                        true,
+	"node/get-graffiti":                           true,
+	"node/get-income":                             true,
+	"node/get-initialize-fee-distributor-gas":     true,
+	"node/get-pending-transactions":               true,
+	"node/get-recent-alerts":                      true,
+	"node/get-rewards-info":                       true,
+	"node/get-smoothing-pool-registration-status": true,
+	"node/get-stake-rpl-approval-gas":             true,
+	"node/get-stranded-assets":                    true,
+	"node/get-swap-rpl-approval-gas":              true,
+	"node/history":                                true,
+	"node/is-fee-distributor-initialized":         true,
+	"node/mev-status":                             true,
+	"node/proposals":                              true,
+	"node/resolve-ens-name":                       true,
+	"node/reverse-resolve-ens-name":               true,
+	"node/rewards":                                true,
+	"node/stake-rpl-allowance":                    true,
+	"node/status":                                 true,
+	"node/swap-rpl-allowance":                     true,
+	"node/sync":                                   true,
+	"node/uptime":                                 true,
+
+	"odao/can-cancel-proposal":                       true,
+	"odao/can-execute-proposal":                      true,
+	"odao/can-join":                                  true,
+	"odao/can-leave":                                 true,
+	"odao/can-propose-bond-reduction-window-length":  true,
+	"odao/can-propose-bond-reduction-window-start":   true,
+	"odao/can-propose-invite":                        true,
+	"odao/can-propose-kick":                          true,
+	"odao/can-propose-leave":                         true,
+	"odao/can-propose-members-minipool-unbonded-max": true,
+	"odao/can-propose-members-quorum":                true,
+	"odao/can-propose-members-rplbond":               true,
+	"odao/can-propose-promotion-scrub-period":        true,
+	"odao/can-propose-proposal-action-timespan":      true,
+	"odao/can-propose-proposal-cooldown":             true,
+	"odao/can-propose-proposal-execute-timespan":     true,
+	"odao/can-propose-proposal-vote-delay-timespan":  true,
+	"odao/can-propose-proposal-vote-timespan":        true,
+	"odao/can-propose-replace":                       true,
+	"odao/can-propose-scrub-penalty-enabled":         true,
+	"odao/can-propose-scrub-period":                  true,
+	"odao/can-propose-setting":                       true,
+	"odao/can-replace":                               true,
+	"odao/can-vote-proposal":                         true,
+	"odao/get-member-settings":                       true,
+	"odao/get-minipool-settings":                     true,
+	"odao/get-proposal-settings":                     true,
+	"odao/members":                                   true,
+	"odao/proposal-details":                          true,
+	"odao/proposals":                                 true,
+	"odao/status":                                    true,
+	"odao/submissions":                               true,
+
+	"service/check-slashing-protection": true,
+	"service/fork-status":               true,
+	"service/get-addon-status":          true,
+	"service/get-audit-log":             true,
+	"service/get-client-status":         true,
+	"service/get-confirmation-requests": true,
+	"service/get-confirmation-status":   true,
+	"service/profile":                   true,
+	"service/rpc-usage":                 true,
+	"service/system-status":             true,
+	"service/task-status":               true,
+	"service/update-status":             true,
+}
+
+// Get the role a key needs to run a command.
+// Commands that haven't been classified need an admin key, so a new command is never exposed to a weaker key by mistake.
+func GetRequiredRole(route string) config.ApiRole {
+	if readOnlyRoutes[route] {
+		return config.ApiRole_ReadOnly
+	}
+	if operatorRoutes[route] {
+		return config.ApiRole_Operator
+	}
+	return config.ApiRole_Admin
+}
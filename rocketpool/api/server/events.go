@@ -0,0 +1,126 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rocket-pool/rocketpool-go/utils"
+
+	"github.com/rocket-pool/smartnode/shared/services/events"
+)
+
+// Settings
+const (
+	// The route that streams the daemon's events
+	eventsRoute string = "events"
+
+	// How often a comment is sent on idle event streams so proxies don't close them
+	eventKeepaliveInterval = 15 * time.Second
+)
+
+// The data of a transaction-confirmed or transaction-failed event
+type transactionEvent struct {
+	Hash        common.Hash `json:"hash"`
+	Command     string      `json:"command"`
+	BlockNumber uint64      `json:"blockNumber,omitempty"`
+	Error       string      `json:"error,omitempty"`
+}
+
+// Stream the daemon's events to the client as Server-Sent Events.
+// Clients that reconnect with the Last-Event-ID header get the recent events they missed.
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, fmt.Errorf("event streaming isn't supported"))
+		return
+	}
+	var lastID uint64
+	if header := r.Header.Get("Last-Event-ID"); header != "" {
+		id, err := strconv.ParseUint(header, 10, 64)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid Last-Event-ID [%s]", header))
+			return
+		}
+		lastID = id
+	}
+
+	subscriber := s.events.Subscribe(lastID)
+	defer s.events.Unsubscribe(subscriber)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	keepalive := time.NewTicker(eventKeepaliveInterval)
+	defer keepalive.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-s.stop:
+			return
+		case <-keepalive.C:
+			fmt.Fprint(w, ": keepalive\n\n")
+			flusher.Flush()
+		case event, open := <-subscriber:
+			if !open {
+				// The client fell too far behind; it can reconnect and catch up from the history
+				return
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				s.log.Warnf("WARNING: Error serializing event %d: %s", event.ID, err.Error())
+				continue
+			}
+			fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data)
+			flusher.Flush()
+		}
+	}
+}
+
+// Publish an event once each transaction in a command's response has been mined
+func (s *Server) watchTransactions(route string, output []byte) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(output, &fields); err != nil {
+		return
+	}
+	for name, value := range fields {
+		if name != "txHash" && !strings.HasSuffix(name, "TxHash") {
+			continue
+		}
+		var hash common.Hash
+		if err := json.Unmarshal(value, &hash); err != nil || hash == (common.Hash{}) {
+			continue
+		}
+		go s.watchTransaction(route, hash)
+	}
+}
+
+// Wait for a transaction and publish whether it succeeded
+func (s *Server) watchTransaction(route string, hash common.Hash) {
+	event := transactionEvent{
+		Hash:    hash,
+		Command: route,
+	}
+	receipt, err := utils.WaitForTransaction(s.ec, hash)
+	if err != nil {
+		event.Error = err.Error()
+		s.events.Publish(events.EventType_TransactionFailed, event)
+		return
+	}
+	event.BlockNumber = receipt.BlockNumber.Uint64()
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		event.Error = "the transaction reverted"
+		s.events.Publish(events.EventType_TransactionFailed, event)
+		return
+	}
+	s.events.Publish(events.EventType_TransactionConfirmed, event)
+}
@@ -0,0 +1,66 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+
+	"github.com/rocket-pool/smartnode/rocketpool/api/roles"
+	"github.com/rocket-pool/smartnode/shared"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/types/api/schema"
+)
+
+// Build the OpenAPI document for the server's routes
+func buildSchema(routes map[string]string) ([]byte, error) {
+	schemaRoutes := []schema.Route{
+		{
+			Path:    RoutePrefix + routesRoute,
+			Method:  http.MethodGet,
+			Role:    string(roles.GetRequiredRole(routesRoute)),
+			Summary: "List the routes and the role each one needs",
+		},
+		{
+			Path:    RoutePrefix + eventsRoute,
+			Method:  http.MethodGet,
+			Role:    string(roles.GetRequiredRole(eventsRoute)),
+			Summary: "Stream the daemon's events as Server-Sent Events",
+		},
+	}
+	for route, usage := range routes {
+		schemaRoutes = append(schemaRoutes, schema.Route{
+			Path:       RoutePrefix + route,
+			Command:    route,
+			Method:     http.MethodPost,
+			Role:       string(roles.GetRequiredRole(route)),
+			Summary:    usage,
+			Idempotent: roles.GetRequiredRole(route) != config.ApiRole_ReadOnly,
+		})
+	}
+	sort.Slice(schemaRoutes, func(i, j int) bool {
+		return schemaRoutes[i].Path < schemaRoutes[j].Path
+	})
+	return schema.Build(schemaRoutes, shared.RocketPoolVersion)
+}
+
+// Serve the OpenAPI document to any valid key, so integrators can generate clients for the routes
+func (s *Server) handleSchema(w http.ResponseWriter, r *http.Request) {
+	role, _, err := s.getRole(r)
+	if err != nil {
+		s.log.Warnf("WARNING: Error checking API key: %s", err.Error())
+		writeError(w, http.StatusInternalServerError, errors.New("error checking API key"))
+		return
+	}
+	if role == "" {
+		writeError(w, http.StatusUnauthorized, errors.New("missing or invalid API token"))
+		return
+	}
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, errors.New(SchemaPath+" only supports GET"))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(s.schema)
+}
@@ -0,0 +1,508 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/rocketpool/api"
+	"github.com/rocket-pool/smartnode/rocketpool/api/roles"
+	"github.com/rocket-pool/smartnode/shared"
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/backup"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/confirmations"
+	"github.com/rocket-pool/smartnode/shared/services/events"
+	"github.com/rocket-pool/smartnode/shared/services/idempotency"
+	"github.com/rocket-pool/smartnode/shared/services/progress"
+	"github.com/rocket-pool/smartnode/shared/services/secrets"
+	apitypes "github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Settings
+const (
+	// The prefix of every route; the version changes if the routes or the request format stop being backwards compatible
+	RoutePrefix string = "/api/v1/"
+
+	// The route that lists the other routes
+	routesRoute string = "routes"
+
+	// The path of the OpenAPI document describing the routes
+	SchemaPath string = "/api/schema"
+
+	maxRequestSize      int64  = 1 << 20
+	readHeaderTimeout          = 10 * time.Second
+	authorizationPrefix string = "Bearer "
+
+	// The name logged for the CLI's own token
+	cliKeyName string = "cli"
+
+	// The content type of responses that stream the command's progress ahead of its output
+	progressContentType string = "text/plain; charset=utf-8"
+
+	// The header clients can send an idempotency key in instead of the request body, and the one that marks replayed responses
+	idempotencyKeyHeader     string = "Idempotency-Key"
+	idempotentReplayedHeader string = "Idempotent-Replayed"
+)
+
+// The environment variables requests can pass secrets to the api commands in
+var requestEnvVars = map[string]bool{
+	confirmations.PassphraseEnvVar:    true,
+	confirmations.NewPassphraseEnvVar: true,
+	backup.PassphraseEnvVar:           true,
+}
+
+// Serves the api commands over HTTP from the node daemon.
+// Each request runs the matching command in a new process, exactly like the CLI does over docker exec, so commands can't interfere with each other or with the daemon.
+// The read-only commands that use the network state are the exception, and run in the daemon to share its state manager.
+type Server struct {
+	c       *cli.Context
+	cfg     *config.RocketPoolConfig
+	log     log.ColorLogger
+	token   []byte
+	binPath string
+	routes  map[string]string
+	schema  []byte
+	events  *events.Broker
+	ec      *services.ExecutionClientManager
+	keys    *idempotency.Store
+	server  *http.Server
+	stop    chan struct{}
+}
+
+// Create the API server
+func NewServer(c *cli.Context, cfg *config.RocketPoolConfig, broker *events.Broker, logger log.ColorLogger) (*Server, error) {
+
+	// The CLI creates the token when the service starts, so it can read it without access to the daemon's files
+	token, err := loadToken(c, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+	binPath, err := os.Executable()
+	if err != nil {
+		return nil, fmt.Errorf("error getting daemon binary path: %w", err)
+	}
+
+	// Mirror the api command tree
+	app := cli.NewApp()
+	api.RegisterCommands(app, "api", []string{})
+	routes := map[string]string{}
+	for _, command := range app.Commands {
+		addRoutes(routes, "", command.Subcommands)
+	}
+	schema, err := buildSchema(routes)
+	if err != nil {
+		return nil, fmt.Errorf("error building API schema: %w", err)
+	}
+	keys, err := idempotency.LoadStore(cfg.Smartnode.GetApiIdempotencyPath())
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Server{
+		c:       c,
+		cfg:     cfg,
+		log:     logger,
+		token:   token,
+		binPath: binPath,
+		routes:  routes,
+		schema:  schema,
+		events:  broker,
+		ec:      ec,
+		keys:    keys,
+		stop:    make(chan struct{}),
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc(RoutePrefix, s.handle)
+	mux.HandleFunc(SchemaPath, s.handleSchema)
+	s.server = &http.Server{
+		Addr:              fmt.Sprintf("%s:%d", cfg.ApiServer.GetListenAddress(), cfg.ApiServer.Port.Value.(uint16)),
+		Handler:           mux,
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+	s.server.RegisterOnShutdown(func() {
+		close(s.stop)
+	})
+	return s, nil
+
+}
+
+// Load the API server's token from the secret store, or from the data folder if there isn't one
+func loadToken(c *cli.Context, cfg *config.RocketPoolConfig) ([]byte, error) {
+	store, err := services.GetSecretStore(c)
+	if err != nil {
+		return nil, err
+	}
+	if store != nil {
+		token, exists, err := store.Get(secrets.ApiServerToken)
+		if err != nil {
+			return nil, fmt.Errorf("error reading API server token: %w", err)
+		}
+		if !exists || token == "" {
+			return nil, fmt.Errorf("there is no API server token in %s", store.String())
+		}
+		return []byte(token), nil
+	}
+
+	tokenPath := cfg.Smartnode.GetApiServerTokenPath()
+	token, err := os.ReadFile(tokenPath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading API server token [%s]: %w", tokenPath, err)
+	}
+	token = bytes.TrimSpace(token)
+	if len(token) == 0 {
+		return nil, fmt.Errorf("API server token [%s] is empty", tokenPath)
+	}
+	return token, nil
+}
+
+// Add a route for every command in the tree that can be run, along with its usage
+func addRoutes(routes map[string]string, prefix string, commands []cli.Command) {
+	for _, command := range commands {
+		route := prefix + command.Name
+		if len(command.Subcommands) > 0 {
+			addRoutes(routes, route+"/", command.Subcommands)
+		} else {
+			routes[route] = command.Usage
+		}
+	}
+}
+
+// Serve the API until it fails or is shut down
+func (s *Server) Serve() error {
+	s.log.Printlnf("Serving the API on %s.", s.server.Addr)
+	if s.cfg.ApiServer.IsOpenToExternalHosts() {
+		s.log.Warnf("WARNING: The API is open to external hosts over plain HTTP, so API keys, tokens and passphrases sent to it can be read by anyone on your network. Reach it through a VPN or a reverse proxy that adds TLS, or only open it to localhost.")
+	}
+	err := s.server.ListenAndServe()
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("error running API server: %w", err)
+	}
+	return nil
+}
+
+// Stop accepting requests and wait until the commands that are running have returned their responses, or the context expires.
+// The event streams are ended, since they'd never finish on their own.
+func (s *Server) Shutdown(ctx context.Context) error {
+	err := s.server.Shutdown(ctx)
+	if err != nil {
+		return fmt.Errorf("error shutting down API server: %w", err)
+	}
+	return nil
+}
+
+// Handle a request to one of the routes
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	role, keyName, err := s.getRole(r)
+	if err != nil {
+		s.log.Warnf("WARNING: Error checking API key: %s", err.Error())
+		writeError(w, http.StatusInternalServerError, errors.New("error checking API key"))
+		return
+	}
+	if role == "" {
+		writeError(w, http.StatusUnauthorized, errors.New("missing or invalid API token"))
+		return
+	}
+
+	route := strings.TrimPrefix(r.URL.Path, RoutePrefix)
+	if route == routesRoute {
+		if r.Method != http.MethodGet {
+			writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("%s only supports GET", r.URL.Path))
+			return
+		}
+		s.writeRoutes(w, role)
+		return
+	}
+	if route == eventsRoute {
+		if r.Method != http.MethodGet {
+			writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("%s only supports GET", r.URL.Path))
+			return
+		}
+		s.handleEvents(w, r)
+		return
+	}
+	if _, exists := s.routes[route]; !exists {
+		writeError(w, http.StatusNotFound, fmt.Errorf("unknown API command [%s]", route))
+		return
+	}
+	if requiredRole := roles.GetRequiredRole(route); !role.Includes(requiredRole) {
+		s.log.Warnf("WARNING: API key '%s' (%s) tried to run [%s], which needs the %s role.", keyName, role, route, requiredRole)
+		writeError(w, http.StatusForbidden, fmt.Errorf("API command [%s] needs the %s role, but this key is %s", route, requiredRole, role))
+		return
+	}
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("%s only supports POST", r.URL.Path))
+		return
+	}
+
+	var request apitypes.ServerRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestSize)).Decode(&request); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("error decoding request: %w", err))
+		return
+	}
+	for name := range request.EnvVars {
+		if !requestEnvVars[name] {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("API commands can't be passed the environment variable %s", name))
+			return
+		}
+	}
+
+	// Commands that send transactions can be given an idempotency key, so retrying them returns the original response instead of sending them again
+	key := request.IdempotencyKey
+	if header := r.Header.Get(idempotencyKeyHeader); header != "" {
+		key = header
+	}
+	fingerprint := ""
+	if key != "" && roles.GetRequiredRole(route) != config.ApiRole_ReadOnly {
+		fingerprint = getRequestFingerprint(request)
+		record, err := s.keys.Begin(route, key, fingerprint)
+		if errors.Is(err, idempotency.ErrKeyInProgress) || errors.Is(err, idempotency.ErrKeyInterrupted) {
+			writeError(w, http.StatusConflict, err)
+			return
+		}
+		if errors.Is(err, idempotency.ErrKeyMismatch) {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		if record != nil {
+			s.log.Printlnf("Returning the original response to [%s] for a repeated idempotency key.", route)
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set(idempotentReplayedHeader, "true")
+			w.WriteHeader(http.StatusOK)
+			w.Write(record.Response)
+			return
+		}
+	} else {
+		key = ""
+	}
+
+	// Stream the command's progress ahead of its output if the client asked for it.
+	// The status can't change once the first line is sent, so errors after that are written as the command's response instead.
+	streaming := false
+	var onProgress func(progress.Update)
+	if request.Progress {
+		flusher, canFlush := w.(http.Flusher)
+		onProgress = func(update progress.Update) {
+			line, err := progress.FormatLine(update)
+			if err != nil {
+				return
+			}
+			if !streaming {
+				w.Header().Set("Content-Type", progressContentType)
+				w.WriteHeader(http.StatusOK)
+				streaming = true
+			}
+			w.Write(line)
+			if canFlush {
+				flusher.Flush()
+			}
+		}
+	}
+
+	output, started, err := s.runCommand(route, keyName, request, onProgress)
+	if key != "" {
+		s.finishIdempotentCommand(route, key, fingerprint, output, started, err)
+	}
+	if err != nil {
+		s.log.Warnf("WARNING: Error running API command [%s]: %s", route, err.Error())
+		if streaming {
+			json.NewEncoder(w).Encode(apitypes.APIResponse{
+				Status: "error",
+				Error:  err.Error(),
+			})
+			return
+		}
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	s.watchTransactions(route, output)
+
+	// The command's output is already a JSON response with its own status
+	if !streaming {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+	}
+	w.Write(output)
+}
+
+// Run an api command in a new daemon process and get its response, passing the progress it reports to the provided function if there is one.
+// The process isn't tied to the request, so a client that disconnects can't kill it halfway through sending its transactions.
+// Also returns whether the process was started, since a command that never started can't have sent anything.
+func (s *Server) runCommand(route string, keyName string, request apitypes.ServerRequest, onProgress func(progress.Update)) ([]byte, bool, error) {
+	// Commands that only read the network state run here, with the state manager the daemon's tasks share.
+	// The process-level flags can't be changed for them, so requests that set any still get a new process.
+	if command, exists := api.GetDaemonCommand(route); exists && len(request.Args) == 0 && !request.IgnoreSyncCheck && !request.ForceFallbacks {
+		return command(s.c), true, nil
+	}
+
+	args := []string{"--settings", s.c.GlobalString("settings")}
+	if request.IgnoreSyncCheck {
+		args = append(args, "--ignore-sync-check")
+	}
+	if request.ForceFallbacks {
+		args = append(args, "--force-fallbacks")
+	}
+	if request.MaxFee != 0 {
+		args = append(args, "--maxFee", strconv.FormatFloat(request.MaxFee, 'f', -1, 64))
+	}
+	if request.MaxPrioFee != 0 {
+		args = append(args, "--maxPrioFee", strconv.FormatFloat(request.MaxPrioFee, 'f', -1, 64))
+	}
+	if request.GasLimit != 0 {
+		args = append(args, "--gasLimit", strconv.FormatUint(request.GasLimit, 10))
+	}
+	if request.Nonce != "" {
+		args = append(args, "--nonce", request.Nonce)
+	}
+	args = append(args, "api")
+	args = append(args, strings.Split(route, "/")...)
+	args = append(args, request.Args...)
+
+	cmd := exec.Command(s.binPath, args...)
+	cmd.Env = os.Environ()
+
+	// Tell the command which key ran it so secondary devices can approve confirmation requests; the CLI's own token never counts as one
+	if keyName == cliKeyName {
+		keyName = ""
+	}
+	cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", confirmations.ApiKeyNameEnvVar, keyName))
+	for name, value := range request.EnvVars {
+		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", name, value))
+	}
+	filter := progress.NewLineFilter(onProgress)
+	cmd.Stderr = filter
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	if err := cmd.Start(); err != nil {
+		return nil, false, err
+	}
+	err := cmd.Wait()
+	output := stdout.Bytes()
+	if len(bytes.TrimSpace(output)) > 0 {
+		return output, true, nil
+	}
+	if err != nil {
+		if stderr := bytes.TrimSpace(filter.Other()); len(stderr) > 0 {
+			return nil, true, fmt.Errorf("%w: %s", err, string(stderr))
+		}
+		return nil, true, err
+	}
+	return nil, true, errors.New("the command didn't return a response")
+}
+
+// Record the response to a command that was sent with an idempotency key.
+// Once the command has started, whatever it returned is kept, errors included, so a retry can't send its transactions again;
+// the key is only released if the command never started.
+func (s *Server) finishIdempotentCommand(route string, key string, fingerprint string, output []byte, started bool, err error) {
+	if !started {
+		if err := s.keys.Release(route, key); err != nil {
+			s.log.Warnf("WARNING: Error releasing the idempotency key of [%s]: %s", route, err.Error())
+		}
+		return
+	}
+	response := output
+	if err != nil {
+		response, _ = json.Marshal(apitypes.APIResponse{
+			Status: "error",
+			Error:  err.Error(),
+		})
+	}
+	if err := s.keys.Finish(route, key, fingerprint, response); err != nil {
+		s.log.Warnf("WARNING: Error saving the response for idempotency key of [%s]: %s", route, err.Error())
+	}
+}
+
+// Get a fingerprint of what a request asks the command to do, so a key can't be reused for something else.
+// The gas settings are left out since a retry may reasonably change them.
+func getRequestFingerprint(request apitypes.ServerRequest) string {
+	hash := sha256.New()
+	for _, arg := range request.Args {
+		hash.Write([]byte(arg))
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// Get the role of the request's bearer token and the name of its key, or an empty role if it isn't valid.
+// The token the CLI uses is always an admin; the other keys are read on every request so they can be changed without restarting the daemon.
+func (s *Server) getRole(r *http.Request) (config.ApiRole, string, error) {
+	header := r.Header.Get("Authorization")
+	if !strings.HasPrefix(header, authorizationPrefix) {
+		return "", "", nil
+	}
+	token := strings.TrimPrefix(header, authorizationPrefix)
+	if subtle.ConstantTimeCompare([]byte(token), s.token) == 1 {
+		return config.ApiRole_Admin, cliKeyName, nil
+	}
+
+	keys, err := config.LoadApiKeys(s.cfg.Smartnode.GetApiKeysPath())
+	if err != nil {
+		return "", "", err
+	}
+	key, exists := keys.Find(token)
+	if !exists {
+		return "", "", nil
+	}
+	return key.Role, key.Name, nil
+}
+
+// Write the list of routes and the role each one needs
+func (s *Server) writeRoutes(w http.ResponseWriter, role config.ApiRole) {
+	routes := make([]apitypes.ServerRoute, 0, len(s.routes)+1)
+	routes = append(routes, apitypes.ServerRoute{
+		Path: RoutePrefix + eventsRoute,
+		Role: string(roles.GetRequiredRole(eventsRoute)),
+	})
+	for route := range s.routes {
+		routes = append(routes, apitypes.ServerRoute{
+			Path: RoutePrefix + route,
+			Role: string(roles.GetRequiredRole(route)),
+		})
+	}
+	sort.Slice(routes, func(i, j int) bool {
+		return routes[i].Path < routes[j].Path
+	})
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(apitypes.ServerRoutesResponse{
+		Status:  "success",
+		Version: shared.RocketPoolVersion,
+		Role:    string(role),
+		Routes:  routes,
+	})
+}
+
+// Write an error response with the provided status code
+func writeError(w http.ResponseWriter, statusCode int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(apitypes.APIResponse{
+		Status: "error",
+		Error:  err.Error(),
+	})
+}
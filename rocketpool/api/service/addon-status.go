@@ -0,0 +1,33 @@
+package service
+
+import (
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/addons"
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Gets the status of the enabled addons recorded by the node daemon
+func getAddonStatus(c *cli.Context) (*api.AddonStatusResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.AddonStatusResponse{}
+
+	// Load the report
+	report, err := addons.LoadStatusReport(cfg.Smartnode.GetAddonStatusPath())
+	if err != nil {
+		return nil, err
+	}
+	response.Report = report
+
+	// Return response
+	return &response, nil
+
+}
@@ -0,0 +1,38 @@
+package service
+
+import (
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/auditlog"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Get the most recent entries in the audit log, newest last
+func getAuditLog(c *cli.Context, limit uint64) (*api.GetAuditLogResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.GetAuditLogResponse{}
+
+	// Load the log
+	entries, problem, err := auditlog.NewLog(cfg.Smartnode.GetAuditLogPath()).Load()
+	if err != nil {
+		return nil, err
+	}
+	response.TotalEntries = len(entries)
+	response.Problem = problem
+	if uint64(len(entries)) > limit {
+		entries = entries[uint64(len(entries))-limit:]
+	}
+	response.Entries = entries
+
+	// Return response
+	return &response, nil
+
+}
@@ -0,0 +1,203 @@
+package service
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared"
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/backup"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Writes an encrypted backup of the settings and the data folder into the data folder
+func createBackup(c *cli.Context) (*api.CreateBackupResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	passphrase, err := getBackupPassphrase()
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.CreateBackupResponse{}
+
+	// Describe the backup
+	manifest := backup.Manifest{
+		SmartnodeVersion: shared.RocketPoolVersion,
+		Network:          string(cfg.Smartnode.Network.Value.(cfgtypes.Network)),
+		Time:             time.Now().UTC(),
+	}
+	if w.IsInitialized() {
+		nodeAccount, err := w.GetNodeAccount()
+		if err != nil {
+			return nil, err
+		}
+		manifest.NodeAddress = nodeAccount.Address.Hex()
+	}
+
+	// Write it to a temporary file first so an interrupted backup never looks complete
+	dataPath := cfg.Smartnode.GetDataFolderPath()
+	filename := fmt.Sprintf("rocketpool-backup-%s-%s%s", manifest.Network, manifest.Time.Format("20060102-150405"), backup.FileExtension)
+	backupPath := filepath.Join(dataPath, filename)
+	tempPath := backupPath + ".tmp"
+	file, err := os.OpenFile(tempPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("error creating backup file: %w", err)
+	}
+	err = backup.Create(file, passphrase, &manifest, c.GlobalString("settings"), dataPath)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(tempPath)
+		return nil, fmt.Errorf("error creating backup: %w", err)
+	}
+	if err := os.Rename(tempPath, backupPath); err != nil {
+		return nil, fmt.Errorf("error saving backup file: %w", err)
+	}
+
+	response.Filename = filename
+	response.Manifest = &manifest
+
+	// Return response
+	return &response, nil
+
+}
+
+// Decrypts and reads an entire backup in the data folder to check it before restoring it
+func checkBackup(c *cli.Context, filename string) (*api.CheckBackupResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	passphrase, err := getBackupPassphrase()
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.CheckBackupResponse{}
+
+	// Read the backup
+	manifest, err := readBackup(cfg.Smartnode.GetDataFolderPath(), filename, passphrase, backup.Verify)
+	if err != nil {
+		return nil, err
+	}
+	response.Manifest = manifest
+
+	// Check for an existing wallet it would replace
+	if w.IsInitialized() {
+		nodeAccount, err := w.GetNodeAccount()
+		if err != nil {
+			return nil, err
+		}
+		response.WalletExists = true
+		response.WalletAddress = nodeAccount.Address.Hex()
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Restores the settings and the data folder from a backup in the data folder
+func restoreBackup(c *cli.Context, filename string) (*api.RestoreBackupResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	passphrase, err := getBackupPassphrase()
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.RestoreBackupResponse{}
+
+	// Check the whole backup before overwriting anything
+	dataPath := cfg.Smartnode.GetDataFolderPath()
+	manifest, err := readBackup(dataPath, filename, passphrase, backup.Verify)
+	if err != nil {
+		return nil, err
+	}
+
+	// Never replace a different node's wallet
+	if w.IsInitialized() {
+		nodeAccount, err := w.GetNodeAccount()
+		if err != nil {
+			return nil, err
+		}
+		if !strings.EqualFold(nodeAccount.Address.Hex(), manifest.NodeAddress) {
+			return nil, fmt.Errorf("this node already has a wallet for %s, which doesn't match the backup's node address (%s); refusing to overwrite it", nodeAccount.Address.Hex(), manifest.NodeAddress)
+		}
+	}
+
+	// Restore it
+	manifest, err = readBackup(dataPath, filename, passphrase, func(file io.Reader, passphrase string) (*backup.Manifest, error) {
+		return backup.Restore(file, passphrase, c.GlobalString("settings"), dataPath)
+	})
+	if err != nil {
+		return nil, err
+	}
+	response.Manifest = manifest
+
+	// Return response
+	return &response, nil
+
+}
+
+// Get the backup passphrase passed by the CLI
+func getBackupPassphrase() (string, error) {
+	encoded := os.Getenv(backup.PassphraseEnvVar)
+	if encoded == "" {
+		return "", errors.New("the backup passphrase was not provided")
+	}
+	passphrase, err := hex.DecodeString(encoded)
+	if err != nil {
+		return "", fmt.Errorf("error decoding backup passphrase: %w", err)
+	}
+	return string(passphrase), nil
+}
+
+// Open a backup in the data folder and run a reader on it
+func readBackup(dataPath string, filename string, passphrase string, read func(io.Reader, string) (*backup.Manifest, error)) (*backup.Manifest, error) {
+	if filename != filepath.Base(filename) || !strings.HasSuffix(filename, backup.FileExtension) {
+		return nil, fmt.Errorf("%s is not the name of a backup file in the data folder", filename)
+	}
+	file, err := os.Open(filepath.Join(dataPath, filename))
+	if err != nil {
+		return nil, fmt.Errorf("error opening backup file: %w", err)
+	}
+	defer file.Close()
+	return read(file, passphrase)
+}
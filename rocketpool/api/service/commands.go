@@ -3,6 +3,7 @@ package service
 import (
 	"github.com/urfave/cli"
 
+	"github.com/rocket-pool/smartnode/shared/services/profiling"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 )
@@ -69,6 +70,462 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 
 				},
 			},
+
+			{
+				Name:      "task-status",
+				Usage:     "Gets the recent task runs recorded by the node and watchtower daemons",
+				UsageText: "rocketpool api service task-status",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getTaskStatus(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "rpc-usage",
+				Usage:     "Gets the RPC requests the node and watchtower daemons have made to their clients, by endpoint and by task",
+				UsageText: "rocketpool api service rpc-usage",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getRpcUsage(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "profile",
+				Usage:     "Collect a runtime profile from the node or watchtower daemon and save it to the data folder",
+				UsageText: "rocketpool api service profile daemon kind seconds",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 3); err != nil {
+						return err
+					}
+					seconds, err := cliutils.ValidateUint("seconds", c.Args().Get(2))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(collectProfile(c, c.Args().Get(0), profiling.ProfileKind(c.Args().Get(1)), seconds))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "get-addon-status",
+				Usage:     "Gets the status of the enabled addons recorded by the node daemon",
+				UsageText: "rocketpool api service get-addon-status",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getAddonStatus(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "system-status",
+				Usage:     "Gets the latest disk, chain data and memory usage recorded by the node daemon",
+				UsageText: "rocketpool api service system-status",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getSystemStatus(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "update-status",
+				Usage:     "Gets the new Smartnode and client releases found by the node daemon",
+				UsageText: "rocketpool api service update-status",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getUpdateStatus(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "create-backup",
+				Usage:     "Writes an encrypted backup of the settings, wallet, validator keys and records into the data folder; the passphrase is read from the environment",
+				UsageText: "rocketpool api service create-backup",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(createBackup(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "check-backup",
+				Usage:     "Decrypts and reads an entire backup in the data folder to check it before restoring it",
+				UsageText: "rocketpool api service check-backup filename",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(checkBackup(c, c.Args().Get(0)))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "restore-backup",
+				Usage:     "Restores the settings, wallet, validator keys and records from a backup in the data folder",
+				UsageText: "rocketpool api service restore-backup filename",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(restoreBackup(c, c.Args().Get(0)))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "check-slashing-protection",
+				Usage:     "Checks that a slashing protection interchange file in the validators folder is for this chain and covers all of the node's validators",
+				UsageText: "rocketpool api service check-slashing-protection filename",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(checkSlashingProtection(c, c.Args().Get(0)))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "get-confirmation-status",
+				Usage:     "Get the confirmation policy of a command and its pending confirmation request",
+				UsageText: "rocketpool api service get-confirmation-status route [args...]",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateMinArgCount(c, 1); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getConfirmationStatus(c, c.Args().Get(0), c.Args().Tail()))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "request-confirmation",
+				Usage:     "Request to run a command protected by a confirmation policy",
+				UsageText: "rocketpool api service request-confirmation route args [args...]",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateMinArgCount(c, 2); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(requestConfirmation(c, c.Args().Get(0), c.Args().Tail()))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "confirm-command",
+				Usage:     "Confirm a request to run a protected command with its phrase and the confirmation passphrase",
+				UsageText: "rocketpool api service confirm-command id phrase",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(confirmCommand(c, c.Args().Get(0), c.Args().Get(1)))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "approve-command",
+				Usage:     "Approve a request to run a protected command from an allow-listed device",
+				UsageText: "rocketpool api service approve-command id",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(approveCommand(c, c.Args().Get(0)))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "get-confirmation-requests",
+				Usage:     "Get the pending requests to run protected commands",
+				UsageText: "rocketpool api service get-confirmation-requests",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getConfirmationRequests(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "set-confirmation-passphrase",
+				Usage:     "Set the passphrase that confirms requests to run protected commands",
+				UsageText: "rocketpool api service set-confirmation-passphrase",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(setConfirmationPassphrase(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "get-audit-log",
+				Usage:     "Get the most recent entries in the audit log of state-changing commands",
+				UsageText: "rocketpool api service get-audit-log limit",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					limit, err := cliutils.ValidatePositiveUint("limit", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getAuditLog(c, limit))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "migrate-secrets",
+				Usage:     "Move the wallet password and API server token out of the data folder and into the configured secret store",
+				UsageText: "rocketpool api service migrate-secrets",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(migrateSecrets(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "encrypt-validator-keys",
+				Usage:     "Encrypt the validator keystore directory with a key from the configured secret store",
+				UsageText: "rocketpool api service encrypt-validator-keys",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(encryptValidatorKeys(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "decrypt-validator-keys",
+				Usage:     "Decrypt the validator keystore directory back into the data folder",
+				UsageText: "rocketpool api service decrypt-validator-keys",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(decryptValidatorKeys(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "fork-status",
+				Usage:     "Check if the primary Execution client is a local Anvil or Hardhat fork",
+				UsageText: "rocketpool api service fork-status",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getForkStatus(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "fork-warp",
+				Usage:     "Move a local fork's clock forward and mine a block at the new time",
+				UsageText: "rocketpool api service fork-warp seconds",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					seconds, err := cliutils.ValidatePositiveUint("seconds", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(forkWarp(c, seconds))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "fork-mine",
+				Usage:     "Mine blocks on a local fork",
+				UsageText: "rocketpool api service fork-mine blocks",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					blocks, err := cliutils.ValidatePositiveUint("block count", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(forkMine(c, blocks))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "fork-set-balance",
+				Usage:     "Set the ETH balance of an address on a local fork",
+				UsageText: "rocketpool api service fork-set-balance address amount",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					address, err := cliutils.ValidateAddress("address", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					balance, err := cliutils.ValidateWeiAmount("balance", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(forkSetBalance(c, address, balance))
+					return nil
+
+				},
+			},
 		},
 	})
 }
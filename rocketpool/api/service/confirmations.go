@@ -0,0 +1,251 @@
+package service
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/confirmations"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Get the confirmation policy of a command and its pending request, if it has one.
+// If runs of the command are provided, the request is only returned if it allows all of them.
+func getConfirmationStatus(c *cli.Context, route string, invocations []string) (*api.ConfirmationStatusResponse, error) {
+	runs, err := confirmations.ParseInvocations(invocations)
+	if err != nil {
+		return nil, err
+	}
+	return updateConfirmation(c, route, "", runs, nil)
+}
+
+// Request to run a protected command once with each of the provided arguments, replacing any request for it that's already pending
+func requestConfirmation(c *cli.Context, route string, invocations []string) (*api.ConfirmationStatusResponse, error) {
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	runs, err := confirmations.ParseInvocations(invocations)
+	if err != nil {
+		return nil, err
+	}
+	return updateConfirmation(c, route, "", nil, func(store *confirmations.Store, policy confirmations.Policy, request *confirmations.Request) (*confirmations.Request, error) {
+		return store.NewRequest(policy, confirmations.GetDelay(cfg), runs)
+	})
+}
+
+// Confirm a request with the typed phrase and the confirmation passphrase, whichever of them its policy needs
+func confirmCommand(c *cli.Context, id string, phrase string) (*api.ConfirmationStatusResponse, error) {
+	passphrase, err := getConfirmationPassphrase(confirmations.PassphraseEnvVar)
+	if err != nil {
+		return nil, err
+	}
+	return updateConfirmation(c, "", id, nil, func(store *confirmations.Store, policy confirmations.Policy, request *confirmations.Request) (*confirmations.Request, error) {
+		return request, store.Confirm(policy, request, phrase, passphrase)
+	})
+}
+
+// Approve a request from one of the allow-listed devices
+func approveCommand(c *cli.Context, id string) (*api.ConfirmationStatusResponse, error) {
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	return updateConfirmation(c, "", id, nil, func(store *confirmations.Store, policy confirmations.Policy, request *confirmations.Request) (*confirmations.Request, error) {
+		if !policy.Requires(confirmations.Safeguard_Device) {
+			return nil, fmt.Errorf("the confirmation policy for [%s] doesn't need a device approval", policy.Route)
+		}
+		return request, confirmations.Approve(request, os.Getenv(confirmations.ApiKeyNameEnvVar), cfg.Smartnode.ConfirmationDevices.Value.(string))
+	})
+}
+
+// Get all of the pending requests
+func getConfirmationRequests(c *cli.Context) (*api.ConfirmationRequestsResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	policies, err := confirmations.GetPolicies(cfg)
+	if err != nil {
+		return nil, err
+	}
+	unlock, err := confirmations.LockStore(cfg.Smartnode.GetConfirmationsPath())
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	store, err := confirmations.LoadStore(cfg.Smartnode.GetConfirmationsPath())
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.ConfirmationRequestsResponse{
+		Requests: []api.ConfirmationRequest{},
+	}
+	for _, policy := range policies {
+		if request, exists := store.GetRequest(policy.Route); exists {
+			response.Requests = append(response.Requests, getConfirmationRequest(policy, request))
+		}
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Set the confirmation passphrase. The current one is needed to change it, so the policies can't be weakened by replacing it.
+func setConfirmationPassphrase(c *cli.Context) (*api.SetConfirmationPassphraseResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	unlock, err := confirmations.LockStore(cfg.Smartnode.GetConfirmationsPath())
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	store, err := confirmations.LoadStore(cfg.Smartnode.GetConfirmationsPath())
+	if err != nil {
+		return nil, err
+	}
+	newPassphrase, err := getConfirmationPassphrase(confirmations.NewPassphraseEnvVar)
+	if err != nil {
+		return nil, err
+	}
+	if newPassphrase == "" {
+		return nil, errors.New("the new confirmation passphrase was not provided")
+	}
+
+	// Response
+	response := api.SetConfirmationPassphraseResponse{}
+
+	// Check the current passphrase and set the new one
+	if store.HasPassphrase() {
+		passphrase, err := getConfirmationPassphrase(confirmations.PassphraseEnvVar)
+		if err != nil {
+			return nil, err
+		}
+		if !store.CheckPassphrase(passphrase) {
+			return nil, errors.New("the current confirmation passphrase is incorrect")
+		}
+	}
+	if err := store.SetPassphrase(newPassphrase); err != nil {
+		return nil, err
+	}
+	if err := store.Save(); err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Load the policy and the request for a command by its route or its request ID, then update the request and save it if an update is provided.
+// When looking it up by route, the request is ignored unless it allows all of the provided runs of the command.
+func updateConfirmation(c *cli.Context, route string, id string, runs [][]string, update func(*confirmations.Store, confirmations.Policy, *confirmations.Request) (*confirmations.Request, error)) (*api.ConfirmationStatusResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	policies, err := confirmations.GetPolicies(cfg)
+	if err != nil {
+		return nil, err
+	}
+	unlock, err := confirmations.LockStore(cfg.Smartnode.GetConfirmationsPath())
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	store, err := confirmations.LoadStore(cfg.Smartnode.GetConfirmationsPath())
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the request
+	var request *confirmations.Request
+	if id != "" {
+		var exists bool
+		request, exists = store.FindRequest(id)
+		if !exists {
+			return nil, fmt.Errorf("there is no pending confirmation request with ID %s; it may have expired", id)
+		}
+		route = request.Route
+	} else {
+		request, _ = store.GetRequest(route)
+		if request != nil && !request.Covers(runs) {
+			request = nil
+		}
+	}
+
+	// Response
+	response := api.ConfirmationStatusResponse{
+		Route:         route,
+		Safeguards:    []string{},
+		PassphraseSet: store.HasPassphrase(),
+	}
+	policy, protected := policies[route]
+	if !protected {
+		return &response, nil
+	}
+	response.Protected = true
+	for _, safeguard := range policy.Safeguards {
+		response.Safeguards = append(response.Safeguards, string(safeguard))
+	}
+
+	// Update the request
+	if update != nil {
+		request, err = update(store, policy, request)
+		if err != nil {
+			return nil, err
+		}
+		if err := store.Save(); err != nil {
+			return nil, err
+		}
+	}
+	if request != nil {
+		status := getConfirmationRequest(policy, request)
+		response.Request = &status
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Get the status of a request for the response
+func getConfirmationRequest(policy confirmations.Policy, request *confirmations.Request) api.ConfirmationRequest {
+	status := api.ConfirmationRequest{
+		ID:         request.ID,
+		Route:      request.Route,
+		Phrase:     request.Phrase,
+		Requested:  request.Requested,
+		UsableAt:   request.UsableAt,
+		Expires:    request.Expires(),
+		ApprovedBy: request.ApprovedBy,
+		Missing:    []string{},
+	}
+	for _, safeguard := range request.Missing(policy) {
+		status.Missing = append(status.Missing, string(safeguard))
+	}
+	return status
+}
+
+func getConfirmationPassphrase(envVar string) (string, error) {
+	passphrase, err := hex.DecodeString(os.Getenv(envVar))
+	if err != nil {
+		return "", fmt.Errorf("error decoding confirmation passphrase: %w", err)
+	}
+	return string(passphrase), nil
+}
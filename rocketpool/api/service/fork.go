@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Get whether the primary Execution client is a local fork, and the state of its chain
+func getForkStatus(c *cli.Context) (*api.ForkStatusResponse, error) {
+
+	// Get services
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.ForkStatusResponse{}
+
+	// Get the fork client
+	forkClient, err := ec.GetForkClient(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	response.ForkClient = string(forkClient)
+	response.IsFork = (forkClient != services.ForkClient_None)
+
+	// Get the chain's state
+	chainID, err := ec.ChainID(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	response.ChainID = chainID.Uint64()
+	header, err := ec.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return nil, err
+	}
+	response.BlockNumber = header.Number.Uint64()
+	response.BlockTime = header.Time
+
+	// Return response
+	return &response, nil
+
+}
+
+// Move the fork's clock forward and mine a block at the new time
+func forkWarp(c *cli.Context, seconds uint64) (*api.ForkWarpResponse, error) {
+
+	// Get services
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.ForkWarpResponse{}
+
+	// Warp
+	if err := ec.IncreaseForkTime(context.Background(), seconds); err != nil {
+		return nil, err
+	}
+	header, err := ec.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return nil, err
+	}
+	response.BlockNumber = header.Number.Uint64()
+	response.BlockTime = header.Time
+
+	// Return response
+	return &response, nil
+
+}
+
+// Mine blocks on the fork
+func forkMine(c *cli.Context, blocks uint64) (*api.ForkMineResponse, error) {
+
+	// Get services
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.ForkMineResponse{}
+
+	// Mine
+	if blocks == 0 {
+		return nil, errors.New("the number of blocks must be greater than 0")
+	}
+	if err := ec.MineForkBlocks(context.Background(), blocks); err != nil {
+		return nil, err
+	}
+	header, err := ec.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return nil, err
+	}
+	response.BlockNumber = header.Number.Uint64()
+	response.BlockTime = header.Time
+
+	// Return response
+	return &response, nil
+
+}
+
+// Set the ETH balance of an address on the fork
+func forkSetBalance(c *cli.Context, address common.Address, balance *big.Int) (*api.ForkSetBalanceResponse, error) {
+
+	// Get services
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.ForkSetBalanceResponse{}
+
+	// Set the balance
+	if err := ec.SetForkBalance(context.Background(), address, balance); err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
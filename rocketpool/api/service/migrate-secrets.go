@@ -0,0 +1,78 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/secrets"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Move the secrets kept in plaintext files in the data folder into the configured secret store, deleting the files
+func migrateSecrets(c *cli.Context) (*api.MigrateSecretsResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	store, err := services.GetSecretStore(c)
+	if err != nil {
+		return nil, err
+	}
+	if store == nil {
+		return nil, errors.New("Secret storage is set to the data folder, so there's nowhere to move the secrets to. Choose a secret manager in `rocketpool service config` first.")
+	}
+
+	// Response
+	response := api.MigrateSecretsResponse{
+		Store:    store.String(),
+		Migrated: []string{},
+	}
+
+	// Move each secret, checking it was stored correctly before deleting its file
+	files := []struct {
+		name string
+		path string
+		trim bool
+	}{
+		{name: secrets.WalletPassword, path: os.ExpandEnv(cfg.Smartnode.GetPasswordPath())},
+		{name: secrets.ApiServerToken, path: cfg.Smartnode.GetApiServerTokenPath(), trim: true},
+	}
+	for _, file := range files {
+		bytes, err := os.ReadFile(file.path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reading %s [%s]: %w", file.name, file.path, err)
+		}
+		value := string(bytes)
+		if file.trim {
+			value = strings.TrimSpace(value)
+		}
+		if err := store.Set(file.name, value); err != nil {
+			return nil, err
+		}
+		stored, exists, err := store.Get(file.name)
+		if err != nil {
+			return nil, err
+		}
+		if !exists || stored != value {
+			return nil, fmt.Errorf("%s didn't match after saving it to %s, so [%s] has been kept", file.name, store.String(), file.path)
+		}
+		if err := os.Remove(file.path); err != nil {
+			return nil, fmt.Errorf("%s was saved to %s, but [%s] couldn't be deleted: %w", file.name, store.String(), file.path, err)
+		}
+		response.Migrated = append(response.Migrated, file.name)
+	}
+
+	// Return response
+	return &response, nil
+
+}
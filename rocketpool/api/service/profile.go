@@ -0,0 +1,68 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/profiling"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// The longest CPU profile that can be collected
+const maxCpuProfileDuration time.Duration = 5 * time.Minute
+
+// Collect a runtime profile from the node or watchtower daemon and save it to the data folder
+func collectProfile(c *cli.Context, daemon string, kind profiling.ProfileKind, seconds uint64) (*api.CollectProfileResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.CollectProfileResponse{
+		Daemon: daemon,
+		Kind:   string(kind),
+	}
+
+	// Check the request
+	if cfg.Smartnode.EnableProfiling.Value != true {
+		return nil, fmt.Errorf("profiling isn't enabled; enable it in the Smartnode section of `rocketpool service config` and restart the service first")
+	}
+	isDaemon := false
+	for _, taskDaemon := range taskDaemons {
+		if daemon == taskDaemon {
+			isDaemon = true
+			break
+		}
+	}
+	if !isDaemon {
+		return nil, fmt.Errorf("unknown daemon [%s]; profiles can be collected from %v", daemon, taskDaemons)
+	}
+	duration := time.Duration(seconds) * time.Second
+	if kind == profiling.ProfileKind_Cpu && (duration <= 0 || duration > maxCpuProfileDuration) {
+		return nil, fmt.Errorf("CPU profiles must be between 1 second and %s long", maxCpuProfileDuration)
+	}
+
+	// Collect the profile
+	response.Filename = fmt.Sprintf("%s-%s-%s.pprof", daemon, kind, time.Now().UTC().Format("20060102-150405"))
+	path := filepath.Join(cfg.Smartnode.GetProfilesPath(true), response.Filename)
+	if err := profiling.Collect(cfg.Smartnode.GetProfilingSocketPath(daemon), kind, duration, path); err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("error checking profile [%s]: %w", path, err)
+	}
+	response.Size = uint64(info.Size())
+
+	// Return response
+	return &response, nil
+
+}
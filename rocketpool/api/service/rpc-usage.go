@@ -0,0 +1,39 @@
+package service
+
+import (
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/rpcusage"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Gets the RPC usage recorded by the node and watchtower daemons
+func getRpcUsage(c *cli.Context) (*api.RpcUsageResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.RpcUsageResponse{
+		Daemons: []rpcusage.DaemonUsage{},
+	}
+
+	// Load the usage saved by each daemon
+	for _, daemon := range taskDaemons {
+		usage, err := rpcusage.LoadDaemonUsage(cfg.Smartnode.GetRpcUsagePath(daemon))
+		if err != nil {
+			return nil, err
+		}
+		if usage != nil {
+			response.Daemons = append(response.Daemons, *usage)
+		}
+	}
+
+	// Return response
+	return &response, nil
+
+}
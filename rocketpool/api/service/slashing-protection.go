@@ -0,0 +1,81 @@
+package service
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/slashing"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Checks that a slashing protection interchange file in the validators folder is for this chain and covers all of the node's validators
+func checkSlashingProtection(c *cli.Context, filename string) (*api.CheckSlashingProtectionResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.CheckSlashingProtectionResponse{}
+
+	// Load the file
+	if filepath.Base(filename) != filename {
+		return nil, fmt.Errorf("[%s] must be the name of a file in the validators folder", filename)
+	}
+	interchange, err := slashing.LoadInterchange(filepath.Join(cfg.Smartnode.GetDataFolderPath(), "validators", filename))
+	if err != nil {
+		return nil, err
+	}
+
+	// Make sure it's for this chain
+	eth2Config, err := bc.GetEth2Config()
+	if err != nil {
+		return nil, err
+	}
+	if err := interchange.Validate(eth2Config.GenesisValidatorsRoot); err != nil {
+		return nil, fmt.Errorf("The slashing protection file is invalid: %w", err)
+	}
+	response.ValidatorCount = len(interchange.Data)
+
+	// Check that it has records for all of the node's validators
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	pubkeys, err := minipool.GetNodeValidatingMinipoolPubkeys(rp, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+	if pubkeys == nil {
+		pubkeys = []types.ValidatorPubkey{}
+	}
+	response.NodeValidators = pubkeys
+	response.MissingPubkeys = interchange.GetMissingPubkeys(pubkeys)
+	response.CoversAllKeys = (len(response.MissingPubkeys) == 0)
+
+	// Return response
+	return &response, nil
+
+}
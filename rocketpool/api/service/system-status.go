@@ -0,0 +1,34 @@
+package service
+
+import (
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/sysmon"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Gets the latest disk, chain data and memory usage recorded by the node daemon
+func getSystemStatus(c *cli.Context) (*api.SystemStatusResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.SystemStatusResponse{}
+
+	// Load the status
+	status, err := sysmon.LoadSystemStatus(cfg.Smartnode.GetSystemStatusPath())
+	if err != nil {
+		return nil, err
+	}
+	response.SystemStatus = status
+	response.CanPrune = sysmon.CanPruneExecutionClient(cfg)
+
+	// Return response
+	return &response, nil
+
+}
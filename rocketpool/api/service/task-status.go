@@ -0,0 +1,42 @@
+package service
+
+import (
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/tasks"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// The daemons that record their task runs
+var taskDaemons = []string{"node", "watchtower"}
+
+// Gets the recent task runs recorded by the node and watchtower daemons
+func getTaskStatus(c *cli.Context) (*api.TaskStatusResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.TaskStatusResponse{
+		Daemons: []tasks.DaemonTaskStatus{},
+	}
+
+	// Load the status saved by each daemon
+	for _, daemon := range taskDaemons {
+		status, err := tasks.LoadDaemonTaskStatus(cfg.Smartnode.GetTaskStatusPath(daemon))
+		if err != nil {
+			return nil, err
+		}
+		if status != nil {
+			response.Daemons = append(response.Daemons, *status)
+		}
+	}
+
+	// Return response
+	return &response, nil
+
+}
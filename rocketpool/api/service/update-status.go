@@ -0,0 +1,33 @@
+package service
+
+import (
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/updates"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Gets the new Smartnode and client releases found by the node daemon
+func getUpdateStatus(c *cli.Context) (*api.UpdateStatusResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.UpdateStatusResponse{}
+
+	// Load the status
+	status, err := updates.LoadUpdateStatus(cfg.Smartnode.GetUpdateStatusPath())
+	if err != nil {
+		return nil, err
+	}
+	response.UpdateStatus = status
+
+	// Return response
+	return &response, nil
+
+}
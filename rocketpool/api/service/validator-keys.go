@@ -0,0 +1,261 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/keyseal"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/validator"
+)
+
+// Encrypt the validator keystore directory with an envelope key from the secret store. In Native mode the directory is replaced with a link to
+// a decrypted copy in memory-backed storage; in Docker mode it's emptied, and the service must be restarted to mount a tmpfs volume over it.
+// The slashing protection databases are moved to persistent storage rather than encrypted.
+func encryptValidatorKeys(c *cli.Context) (*api.EncryptValidatorKeysResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	if !cfg.Secrets.IsValidatorKeyEncryptionEnabled() {
+		return nil, errors.New("Validator key encryption is disabled. Enable it in the Secret Storage section of `rocketpool service config` first.")
+	}
+	if keyseal.IsSealed(cfg) {
+		return nil, errors.New("The validator keys are already encrypted.")
+	}
+	store, err := services.GetSecretStore(c)
+	if err != nil {
+		return nil, err
+	}
+	if store == nil {
+		return nil, errors.New("Secret storage is set to the data folder, so there's nowhere to keep the encryption key. Choose a secret manager in `rocketpool service config` first.")
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+	d, err := services.GetDocker(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	validatorsPath := os.ExpandEnv(cfg.Smartnode.GetValidatorKeychainPath())
+	sealedPath := os.ExpandEnv(cfg.Smartnode.GetSealedValidatorKeychainPath())
+	unsealedPath := os.ExpandEnv(cfg.Smartnode.GetUnsealedValidatorKeychainPath())
+	slashingProtectionPath := os.ExpandEnv(cfg.Smartnode.GetSlashingProtectionPath())
+	response := api.EncryptValidatorKeysResponse{
+		Store:                  store.String(),
+		SealedPath:             sealedPath,
+		UnsealedPath:           unsealedPath,
+		SlashingProtectionPath: slashingProtectionPath,
+		RestartRequired:        !cfg.IsNativeMode,
+	}
+	key, err := keyseal.GetEnvelopeKey(store, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// Stop the Validator Client while its slashing protection database is moved, and put everything back if encrypting fails
+	if err := validator.StopValidator(cfg, bc, nil, d); err != nil {
+		return nil, fmt.Errorf("error stopping validator client: %w", err)
+	}
+	succeeded := false
+	defer func() {
+		if succeeded {
+			return
+		}
+		if _, err := os.Stat(validatorsPath); err == nil {
+			keyseal.AttachSlashingProtection(validatorsPath, slashingProtectionPath)
+		}
+		os.Remove(sealedPath)
+		validator.RestartValidator(cfg, bc, nil, d)
+	}()
+	if err := keyseal.DetachSlashingProtection(validatorsPath, slashingProtectionPath); err != nil {
+		return nil, err
+	}
+
+	// Encrypt the directory, and check that it decrypts to the same contents
+	digest, err := keyseal.Seal(validatorsPath, sealedPath, key)
+	if err != nil {
+		return nil, err
+	}
+	sealedDigest, err := keyseal.GetSealedDigest(sealedPath, key)
+	if err != nil {
+		return nil, err
+	}
+	if sealedDigest != digest {
+		return nil, fmt.Errorf("the encrypted copy in [%s] doesn't match [%s], so it has been kept", sealedPath, validatorsPath)
+	}
+
+	if !cfg.IsNativeMode {
+		// Delete the unencrypted keys; the tmpfs volume will cover the folder once the service is restarted, and the node daemon will decrypt them into it
+		if err := keyseal.Clear(validatorsPath); err != nil {
+			return nil, err
+		}
+		if err := os.Remove(filepath.Join(validatorsPath, keyseal.SlashingProtectionLinkName)); err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("error removing the slashing protection link: %w", err)
+		}
+		succeeded = true
+		return &response, nil
+	}
+
+	// Decrypt it into memory-backed storage and replace the directory with a link to it
+	if err := os.MkdirAll(unsealedPath, 0700); err != nil {
+		return nil, fmt.Errorf("error creating [%s]: %w", unsealedPath, err)
+	}
+	if err := checkMemoryBacked(unsealedPath); err != nil {
+		return nil, err
+	}
+	unsealedDigest, err := keyseal.Unseal(sealedPath, unsealedPath, key)
+	if err != nil {
+		return nil, err
+	}
+	if unsealedDigest != digest {
+		return nil, fmt.Errorf("the decrypted copy in [%s] doesn't match [%s], so it has been kept", unsealedPath, validatorsPath)
+	}
+	if _, err := keyseal.LinkSlashingProtection(unsealedPath, slashingProtectionPath); err != nil {
+		return nil, err
+	}
+
+	// From here on the keys are safe in both copies, so the encrypted one is kept even if something fails
+	succeeded = true
+	if err := os.RemoveAll(validatorsPath); err != nil {
+		return nil, fmt.Errorf("error deleting [%s]: %w", validatorsPath, err)
+	}
+	if err := os.Symlink(unsealedPath, validatorsPath); err != nil {
+		return nil, fmt.Errorf("error linking [%s] to [%s]: %w", validatorsPath, unsealedPath, err)
+	}
+
+	// Restart the Validator Client so it loads the keys from their new location
+	if err := validator.RestartValidator(cfg, bc, nil, d); err != nil {
+		return nil, fmt.Errorf("error restarting validator client: %w", err)
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Decrypt the validator keystore directory back into the data folder, move the slashing protection databases back into it, and delete its encrypted copy.
+// In Docker mode the keys are decrypted next to the validators folder, and the node daemon moves them into place once the service has been restarted
+// without the tmpfs volume.
+func decryptValidatorKeys(c *cli.Context) (*api.DecryptValidatorKeysResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	if !keyseal.IsSealed(cfg) {
+		return nil, errors.New("The validator keys aren't encrypted.")
+	}
+	store, err := services.GetSecretStore(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+	d, err := services.GetDocker(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	validatorsPath := os.ExpandEnv(cfg.Smartnode.GetValidatorKeychainPath())
+	sealedPath := os.ExpandEnv(cfg.Smartnode.GetSealedValidatorKeychainPath())
+	unsealedPath := os.ExpandEnv(cfg.Smartnode.GetUnsealedValidatorKeychainPath())
+	slashingProtectionPath := os.ExpandEnv(cfg.Smartnode.GetSlashingProtectionPath())
+	targetPath := validatorsPath
+	if !cfg.IsNativeMode {
+		targetPath = os.ExpandEnv(cfg.Smartnode.GetDecryptedValidatorKeychainPath())
+	}
+	response := api.DecryptValidatorKeysResponse{
+		ValidatorsPath:  validatorsPath,
+		RestartRequired: !cfg.IsNativeMode,
+	}
+	key, err := keyseal.GetEnvelopeKey(store, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// Stop the Validator Client so nothing changes while the keys are moved
+	if err := validator.StopValidator(cfg, bc, nil, d); err != nil {
+		return nil, fmt.Errorf("error stopping validator client: %w", err)
+	}
+	err = decryptValidatorKeysTo(cfg, key, sealedPath, unsealedPath, validatorsPath, targetPath, slashingProtectionPath)
+	if err != nil || cfg.IsNativeMode {
+		if restartErr := validator.RestartValidator(cfg, bc, nil, d); restartErr != nil && err == nil {
+			err = fmt.Errorf("error restarting validator client: %w", restartErr)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Decrypt the validator keystore directory into the target folder and remove its encrypted copy
+func decryptValidatorKeysTo(cfg *config.RocketPoolConfig, key []byte, sealedPath string, unsealedPath string, validatorsPath string, targetPath string, slashingProtectionPath string) error {
+
+	// Bring the encrypted copy up to date with the decrypted one, if there is one
+	empty, err := keyseal.IsEmpty(unsealedPath)
+	if err != nil {
+		return err
+	}
+	if !empty {
+		if _, err := keyseal.Seal(unsealedPath, sealedPath, key); err != nil {
+			return err
+		}
+	}
+
+	// Decrypt it, and move the slashing protection databases back into it
+	if cfg.IsNativeMode {
+		if err := os.Remove(validatorsPath); err != nil {
+			return fmt.Errorf("error removing the link at [%s]: %w", validatorsPath, err)
+		}
+	}
+	if _, err := keyseal.Unseal(sealedPath, targetPath, key); err != nil {
+		return err
+	}
+	if err := keyseal.AttachSlashingProtection(targetPath, slashingProtectionPath); err != nil {
+		return err
+	}
+
+	// Remove the encrypted copy and, in Native mode, the decrypted one in memory-backed storage
+	if err := os.Remove(sealedPath); err != nil {
+		return fmt.Errorf("error deleting [%s]: %w", sealedPath, err)
+	}
+	if cfg.IsNativeMode {
+		if err := os.RemoveAll(unsealedPath); err != nil {
+			return fmt.Errorf("error deleting [%s]: %w", unsealedPath, err)
+		}
+	}
+	return nil
+
+}
+
+// Make sure the validator keys are only ever decrypted into memory-backed storage
+func checkMemoryBacked(dir string) error {
+	memoryBacked, err := keyseal.IsMemoryBacked(dir)
+	if err != nil {
+		return err
+	}
+	if !memoryBacked {
+		return fmt.Errorf("[%s] isn't on memory-backed storage, so the validator keys won't be decrypted into it", dir)
+	}
+	return nil
+}
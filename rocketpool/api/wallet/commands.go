@@ -1,6 +1,8 @@
 package wallet
 
 import (
+	"time"
+
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/utils/api"
@@ -57,6 +59,54 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "unlock",
+				Usage:     "Unlock the node wallet for a limited time without saving its password to disk",
+				UsageText: "rocketpool api wallet unlock password seconds",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					password, err := cliutils.ValidateNodePassword("wallet password", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					seconds, err := cliutils.ValidatePositiveUint("seconds", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(unlockWallet(c, password, time.Duration(seconds)*time.Second))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "lock",
+				Usage:     "Lock the node wallet, deleting its saved password and ending any unlock session",
+				UsageText: "rocketpool api wallet lock password",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					password, err := cliutils.ValidateNodePassword("wallet password", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(lockWallet(c, password))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "init",
 				Aliases:   []string{"i"},
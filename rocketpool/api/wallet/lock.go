@@ -0,0 +1,65 @@
+package wallet
+
+import (
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func unlockWallet(c *cli.Context, password string, duration time.Duration) (*api.UnlockWalletResponse, error) {
+
+	// Get services
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.UnlockWalletResponse{}
+
+	// Start the session, which checks the password
+	response.SessionExpires, err = w.StartSession(password, duration)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+func lockWallet(c *cli.Context, password string) (*api.LockWalletResponse, error) {
+
+	// Get services
+	pm, err := services.GetPasswordManager(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.LockWalletResponse{}
+
+	// Make sure the operator knows the password, since it's needed to unlock the wallet again
+	if err := w.VerifyPassword(password); err != nil {
+		return nil, err
+	}
+
+	// Forget the saved password and end the session
+	if err := pm.DeletePassword(); err != nil {
+		return nil, err
+	}
+	if err := w.EndSession(); err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/progress"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	walletutils "github.com/rocket-pool/smartnode/shared/utils/wallet"
 )
@@ -36,7 +37,9 @@ func rebuildWallet(c *cli.Context) (*api.RebuildWalletResponse, error) {
 	}
 
 	// Recover validator keys
-	response.ValidatorKeys, err = walletutils.RecoverMinipoolKeys(c, rp, nodeAccount.Address, w, false)
+	reporter := progress.NewStderrReporter("Recovering validator keys")
+	response.ValidatorKeys, err = walletutils.RecoverMinipoolKeys(c, rp, nodeAccount.Address, w, false, reporter)
+	reporter.Finish(err)
 	if err != nil {
 		return nil, err
 	}
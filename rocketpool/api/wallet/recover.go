@@ -9,6 +9,7 @@ import (
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/progress"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	walletutils "github.com/rocket-pool/smartnode/shared/utils/wallet"
@@ -74,7 +75,9 @@ func recoverWallet(c *cli.Context, mnemonic string) (*api.RecoverWalletResponse,
 	response.AccountAddress = nodeAccount.Address
 
 	if !c.Bool("skip-validator-key-recovery") {
-		response.ValidatorKeys, err = walletutils.RecoverMinipoolKeys(c, rp, nodeAccount.Address, w, false)
+		reporter := progress.NewStderrReporter("Recovering validator keys")
+		response.ValidatorKeys, err = walletutils.RecoverMinipoolKeys(c, rp, nodeAccount.Address, w, false, reporter)
+		reporter.Finish(err)
 		if err != nil {
 			return nil, err
 		}
@@ -125,7 +128,10 @@ func searchAndRecoverWallet(c *cli.Context, mnemonic string, address common.Addr
 		wallet.LedgerLiveNodeKeyPath,
 		wallet.MyEtherWalletNodeKeyPath,
 	}
+	searchReporter := progress.NewStderrReporter("Searching for wallet")
+	searchReporter.SetPhase("Checking derivation paths", uint64(findIterations))
 	for i := uint(0); i < findIterations; i++ {
+		searchReporter.SetProgress(uint64(i))
 		for j := 0; j < len(paths); j++ {
 			derivationPath := paths[j]
 			recoveredWallet, err := wallet.NewWallet("", uint(w.GetChainID().Uint64()), nil, nil, 0, nil)
@@ -154,6 +160,7 @@ func searchAndRecoverWallet(c *cli.Context, mnemonic string, address common.Addr
 			break
 		}
 	}
+	searchReporter.Finish(nil)
 
 	if !response.FoundWallet {
 		return nil, fmt.Errorf("exhausted all derivation paths and indices from 0 to %d, wallet not found", findIterations)
@@ -172,7 +179,9 @@ func searchAndRecoverWallet(c *cli.Context, mnemonic string, address common.Addr
 	response.AccountAddress = nodeAccount.Address
 
 	if !c.Bool("skip-validator-key-recovery") {
-		response.ValidatorKeys, err = walletutils.RecoverMinipoolKeys(c, rp, nodeAccount.Address, w, false)
+		reporter := progress.NewStderrReporter("Recovering validator keys")
+		response.ValidatorKeys, err = walletutils.RecoverMinipoolKeys(c, rp, nodeAccount.Address, w, false, reporter)
+		reporter.Finish(err)
 		if err != nil {
 			return nil, err
 		}
@@ -16,15 +16,26 @@ func setPassword(c *cli.Context, password string) (*api.SetPasswordResponse, err
 	if err != nil {
 		return nil, err
 	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
 
 	// Response
 	response := api.SetPasswordResponse{}
 
 	// Check if password is already set
-	if pm.IsPasswordSet() {
+	if pm.IsPasswordSaved() {
 		return nil, errors.New("The node password is already set")
 	}
 
+	// Make sure the password matches an existing wallet that's been locked
+	if w.IsLocked() || w.IsInitialized() {
+		if err := w.VerifyPassword(password); err != nil {
+			return nil, err
+		}
+	}
+
 	// Set password
 	if err := pm.SetPassword(password); err != nil {
 		return nil, err
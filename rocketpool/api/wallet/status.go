@@ -24,7 +24,10 @@ func getStatus(c *cli.Context) (*api.WalletStatusResponse, error) {
 
 	// Get wallet status
 	response.PasswordSet = pm.IsPasswordSet()
+	response.PasswordSaved = pm.IsPasswordSaved()
 	response.WalletInitialized = w.IsInitialized()
+	response.WalletLocked = w.IsLocked()
+	response.SessionExpires, response.SessionActive = pm.GetSessionExpiry()
 
 	// Get accounts if initialized
 	if response.WalletInitialized {
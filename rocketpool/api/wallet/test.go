@@ -8,6 +8,7 @@ import (
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/progress"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	walletutils "github.com/rocket-pool/smartnode/shared/utils/wallet"
@@ -68,7 +69,9 @@ func testRecoverWallet(c *cli.Context, mnemonic string) (*api.RecoverWalletRespo
 	response.AccountAddress = nodeAccount.Address
 
 	if !c.Bool("skip-validator-key-recovery") {
-		response.ValidatorKeys, err = walletutils.RecoverMinipoolKeys(c, rp, nodeAccount.Address, w, true)
+		reporter := progress.NewStderrReporter("Recovering validator keys")
+		response.ValidatorKeys, err = walletutils.RecoverMinipoolKeys(c, rp, nodeAccount.Address, w, true, reporter)
+		reporter.Finish(err)
 		if err != nil {
 			return nil, err
 		}
@@ -113,7 +116,10 @@ func testSearchAndRecoverWallet(c *cli.Context, mnemonic string, address common.
 		wallet.LedgerLiveNodeKeyPath,
 		wallet.MyEtherWalletNodeKeyPath,
 	}
+	searchReporter := progress.NewStderrReporter("Searching for wallet")
+	searchReporter.SetPhase("Checking derivation paths", uint64(findIterations))
 	for i := uint(0); i < findIterations; i++ {
+		searchReporter.SetProgress(uint64(i))
 		for j := 0; j < len(paths); j++ {
 			derivationPath := paths[j]
 			recoveredWallet, err := wallet.NewWallet("", uint(w.GetChainID().Uint64()), nil, nil, 0, nil)
@@ -142,6 +148,7 @@ func testSearchAndRecoverWallet(c *cli.Context, mnemonic string, address common.
 			break
 		}
 	}
+	searchReporter.Finish(nil)
 
 	if !response.FoundWallet {
 		return nil, fmt.Errorf("exhausted all derivation paths and indices from 0 to %d, wallet not found", findIterations)
@@ -160,7 +167,9 @@ func testSearchAndRecoverWallet(c *cli.Context, mnemonic string, address common.
 	response.AccountAddress = nodeAccount.Address
 
 	if !c.Bool("skip-validator-key-recovery") {
-		response.ValidatorKeys, err = walletutils.RecoverMinipoolKeys(c, rp, nodeAccount.Address, w, true)
+		reporter := progress.NewStderrReporter("Recovering validator keys")
+		response.ValidatorKeys, err = walletutils.RecoverMinipoolKeys(c, rp, nodeAccount.Address, w, true, reporter)
+		reporter.Finish(err)
 		if err != nil {
 			return nil, err
 		}
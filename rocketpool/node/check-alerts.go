@@ -0,0 +1,256 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+// How often to update the RPL price volatility; prices are only submitted a few times a day
+var priceVolatilityInterval, _ = time.ParseDuration("1h")
+
+// How many standard deviations of price movement the collateral forecast allows for, which covers about 97.7% of price drops
+const collateralForecastDeviations float64 = 2
+
+// Check alerts task
+type checkAlerts struct {
+	c                   *cli.Context
+	log                 log.ColorLogger
+	cfg                 *config.RocketPoolConfig
+	rp                  *rocketpool.RocketPool
+	alerts              *alerting.AlertManager
+	nodeAddress         common.Address
+	collateralThreshold float64
+	collateralLookback  uint64
+	stuckTxTimeout      time.Duration
+
+	// Tracking for the collateral forecast rule
+	priceVolatility     float64
+	priceUpdatesPerDay  float64
+	priceVolatilityTime time.Time
+
+	// Tracking for the stuck transaction rule
+	stuckNonce      uint64
+	stuckNonceSince time.Time
+}
+
+// Create check alerts task
+func newCheckAlerts(c *cli.Context, logger log.ColorLogger, alerts *alerting.AlertManager, nodeAddress common.Address) (*checkAlerts, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &checkAlerts{
+		c:                   c,
+		log:                 logger,
+		cfg:                 cfg,
+		rp:                  rp,
+		alerts:              alerts,
+		nodeAddress:         nodeAddress,
+		collateralThreshold: cfg.Alerting.CollateralThreshold.Value.(float64) / 100,
+		collateralLookback:  cfg.Alerting.CollateralLookback.Value.(uint64),
+		stuckTxTimeout:      time.Duration(cfg.Alerting.StuckTxTimeout.Value.(uint64)) * time.Minute,
+	}, nil
+
+}
+
+// Raise or clear the execution client alert
+func (t *checkAlerts) checkExecutionClient(syncErr error) {
+	message := "The execution client is back online."
+	if syncErr != nil {
+		message = fmt.Sprintf("The node can't use its execution client: %s", syncErr.Error())
+	}
+	t.alerts.Update(alerting.Alert{
+		Rule:     alerting.Rule_ExecutionClientDown,
+		Severity: alerting.Severity_Critical,
+		Title:    "Execution client unavailable",
+		Message:  message,
+	}, syncErr != nil)
+}
+
+// Raise or clear the Beacon client alert
+func (t *checkAlerts) checkBeaconClient(syncErr error) {
+	message := "The Beacon client is back online."
+	if syncErr != nil {
+		message = fmt.Sprintf("The node can't use its Beacon client: %s", syncErr.Error())
+	}
+	t.alerts.Update(alerting.Alert{
+		Rule:     alerting.Rule_BeaconClientDown,
+		Severity: alerting.Severity_Critical,
+		Title:    "Beacon client unavailable",
+		Message:  message,
+	}, syncErr != nil)
+}
+
+// Check the alert rules that depend on the network state
+func (t *checkAlerts) run(state *state.NetworkState) error {
+
+	// Check if alerting is enabled
+	if !t.alerts.IsEnabled() {
+		return nil
+	}
+
+	t.checkCollateral(state)
+	if err := t.checkCollateralForecast(state); err != nil {
+		return fmt.Errorf("error forecasting collateral: %w", err)
+	}
+	if err := t.checkStuckTransactions(); err != nil {
+		return fmt.Errorf("error checking for stuck transactions: %w", err)
+	}
+	return nil
+
+}
+
+// Raise an alert if the node's RPL collateral has fallen below the configured threshold
+func (t *checkAlerts) checkCollateral(state *state.NetworkState) {
+	nd, exists := state.NodeDetailsByAddress[t.nodeAddress]
+	if !exists || t.collateralThreshold == 0 {
+		return
+	}
+
+	// Get the amount of borrowed ETH across the node's active minipools
+	borrowedEth := t.getBorrowedEth(state)
+	if borrowedEth.Sign() == 0 {
+		return
+	}
+
+	// ETH-only nodes don't have any collateral to warn about; they've already given up RPL rewards by not staking
+	if state.NetworkDetails.MinCollateralFraction.Sign() == 0 && nd.RplStake.Sign() == 0 {
+		t.alerts.Resolve(alerting.Alert{Rule: alerting.Rule_LowCollateral})
+		return
+	}
+
+	// Get the value of the staked RPL in ETH
+	stakeValue := big.NewInt(0).Mul(nd.RplStake, state.NetworkDetails.RplPrice)
+	stakeValue.Div(stakeValue, eth.EthToWei(1))
+	ratio := eth.WeiToEth(stakeValue) / eth.WeiToEth(borrowedEth)
+
+	t.alerts.Update(alerting.Alert{
+		Rule:     alerting.Rule_LowCollateral,
+		Severity: alerting.Severity_Warning,
+		Title:    "Low RPL collateral",
+		Message:  fmt.Sprintf("The node's RPL stake is worth %.2f%% of its borrowed ETH, which is below the alert threshold of %.2f%%.", ratio*100, t.collateralThreshold*100),
+	}, ratio < t.collateralThreshold)
+}
+
+// Raise an alert if a plausible drop in the RPL price before the next rewards snapshot would take the node's collateral below
+// the minimum required for RPL rewards. The drop is projected from the volatility of the prices the Oracle DAO has submitted recently.
+func (t *checkAlerts) checkCollateralForecast(state *state.NetworkState) error {
+	nd, exists := state.NodeDetailsByAddress[t.nodeAddress]
+	if !exists || t.collateralLookback == 0 {
+		return nil
+	}
+
+	// ETH-only nodes don't have a minimum to stay above
+	details := state.NetworkDetails
+	minCollateral := eth.WeiToEth(details.MinCollateralFraction)
+	if minCollateral == 0 || nd.RplStake.Sign() == 0 {
+		return nil
+	}
+	borrowedEth := eth.WeiToEth(t.getBorrowedEth(state))
+	if borrowedEth == 0 {
+		return nil
+	}
+
+	// Update the price volatility
+	if time.Since(t.priceVolatilityTime) > priceVolatilityInterval {
+		eventLogInterval, err := t.cfg.GetEventLogInterval()
+		if err != nil {
+			return err
+		}
+		prices, err := rputils.GetSubmittedRplPrices(t.rp, t.collateralLookback, big.NewInt(int64(eventLogInterval)))
+		if err != nil {
+			return err
+		}
+		t.priceVolatility = rputils.GetRplPriceVolatility(prices)
+		t.priceUpdatesPerDay = float64(len(prices)) / float64(t.collateralLookback)
+		t.priceVolatilityTime = time.Now()
+	}
+
+	// Project the price at the snapshot, treating each price update until then as a random step
+	snapshotTime := details.IntervalStart.Add(details.IntervalDuration)
+	updates := time.Until(snapshotTime).Hours() / 24 * t.priceUpdatesPerDay
+	if updates < 0 {
+		updates = 0
+	}
+	priceDrop := 1 - math.Exp(-collateralForecastDeviations*t.priceVolatility*math.Sqrt(updates))
+	projectedPrice := eth.WeiToEth(details.RplPrice) * (1 - priceDrop)
+	if projectedPrice <= 0 {
+		return nil
+	}
+	rplStake := eth.WeiToEth(nd.RplStake)
+	projectedRatio := rplStake * projectedPrice / borrowedEth
+	topUp := borrowedEth*minCollateral/projectedPrice - rplStake
+
+	t.alerts.Update(alerting.Alert{
+		Rule:     alerting.Rule_CollateralForecast,
+		Severity: alerting.Severity_Warning,
+		Title:    "RPL collateral may fall below the minimum",
+		Message:  fmt.Sprintf("Based on the RPL price volatility over the last %d days, the RPL price could drop by %.2f%% before the rewards snapshot on %s. The node's RPL stake would then be worth %.2f%% of its borrowed ETH, which is below the minimum of %.2f%% required for RPL rewards. Staking another %.6f RPL would keep it above the minimum.", t.collateralLookback, priceDrop*100, snapshotTime.Format(time.RFC1123), projectedRatio*100, minCollateral*100, topUp),
+	}, projectedRatio < minCollateral)
+	return nil
+}
+
+// Get the amount of borrowed ETH across the node's active minipools
+func (t *checkAlerts) getBorrowedEth(state *state.NetworkState) *big.Int {
+	borrowedEth := big.NewInt(0)
+	for _, mpd := range state.MinipoolDetailsByNode[t.nodeAddress] {
+		if mpd.Finalised {
+			continue
+		}
+		borrowed := big.NewInt(0).Sub(eth.EthToWei(32), mpd.NodeDepositBalance)
+		borrowedEth.Add(borrowedEth, borrowed)
+	}
+	return borrowedEth
+}
+
+// Raise an alert if the node wallet has had a transaction pending for too long
+func (t *checkAlerts) checkStuckTransactions() error {
+	latestNonce, err := t.rp.Client.NonceAt(context.Background(), t.nodeAddress, nil)
+	if err != nil {
+		return err
+	}
+	pendingNonce, err := t.rp.Client.PendingNonceAt(context.Background(), t.nodeAddress)
+	if err != nil {
+		return err
+	}
+
+	// Track how long the oldest pending nonce has been waiting
+	if pendingNonce <= latestNonce {
+		t.stuckNonceSince = time.Time{}
+	} else if t.stuckNonceSince.IsZero() || t.stuckNonce != latestNonce {
+		t.stuckNonce = latestNonce
+		t.stuckNonceSince = time.Now()
+	}
+
+	stuck := !t.stuckNonceSince.IsZero() && time.Since(t.stuckNonceSince) > t.stuckTxTimeout
+	t.alerts.Update(alerting.Alert{
+		Rule:     alerting.Rule_StuckTransaction,
+		Severity: alerting.Severity_Warning,
+		Title:    "Stuck transaction",
+		Message:  fmt.Sprintf("The transaction with nonce %d from the node wallet has been pending for more than %s. You may need to resubmit it with a higher gas price.", t.stuckNonce, t.stuckTxTimeout),
+	}, stuck)
+	return nil
+}
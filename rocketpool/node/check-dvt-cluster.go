@@ -0,0 +1,88 @@
+package node
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/dvt"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// How often to check the DVT cluster's health
+var dvtClusterCheckInterval, _ = time.ParseDuration("5m")
+
+// Check DVT cluster task
+type checkDvtCluster struct {
+	c         *cli.Context
+	log       log.ColorLogger
+	cfg       *config.RocketPoolConfig
+	alerts    *alerting.AlertManager
+	lastCheck time.Time
+}
+
+// Create check DVT cluster task
+func newCheckDvtCluster(c *cli.Context, logger log.ColorLogger, alerts *alerting.AlertManager) (*checkDvtCluster, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &checkDvtCluster{
+		c:      c,
+		log:    logger,
+		cfg:    cfg,
+		alerts: alerts,
+	}, nil
+
+}
+
+// Check that the DVT cluster operating the node's DVT minipools is healthy
+func (t *checkDvtCluster) run() error {
+
+	// Only check the cluster if there are minipools for it to operate
+	if t.cfg.Dvt.Enabled.Value == false || t.cfg.Dvt.ApiUrl.Value.(string) == "" {
+		return nil
+	}
+	if time.Since(t.lastCheck) < dvtClusterCheckInterval {
+		return nil
+	}
+	t.lastCheck = time.Now()
+	settings, err := config.LoadDvtSettings(t.cfg.Smartnode.GetDvtSettingsPath())
+	if err != nil {
+		return err
+	}
+	if len(settings.Minipools) == 0 {
+		return nil
+	}
+	pubkeys := make([]types.ValidatorPubkey, 0, len(settings.Minipools))
+	for _, minipool := range settings.Minipools {
+		pubkeys = append(pubkeys, minipool.Pubkey)
+	}
+
+	// Check the cluster
+	status := dvt.CheckCluster(t.cfg, pubkeys)
+	if err := status.Save(t.cfg.Smartnode.GetDvtStatusPath()); err != nil {
+		return err
+	}
+	if !status.Healthy {
+		t.log.Warnf("WARNING: your DVT cluster isn't healthy: %s", status.Error)
+	}
+	t.alerts.Update(alerting.Alert{
+		Rule:     alerting.Rule_DvtClusterUnhealthy,
+		Severity: alerting.Severity_Critical,
+		Title:    "DVT cluster isn't healthy",
+		Message:  fmt.Sprintf("Your DVT client at %s reports a problem with the cluster operating %d of your minipools: %s. Their validators may be missing duties.", status.Url, len(pubkeys), status.Error),
+	}, !status.Healthy)
+
+	return nil
+
+}
@@ -0,0 +1,137 @@
+package node
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/mevboost"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// How often to check the relays; every validator's registration is looked up on every relay, so this is kept infrequent
+var mevRelayCheckInterval, _ = time.ParseDuration("15m")
+
+// Check MEV relays task
+type checkMevRelays struct {
+	c           *cli.Context
+	log         log.ColorLogger
+	cfg         *config.RocketPoolConfig
+	alerts      *alerting.AlertManager
+	nodeAddress common.Address
+	lastCheck   time.Time
+
+	// The relays that were down at the last check, so they can be resolved when they come back or are disabled
+	downRelays map[cfgtypes.MevRelayID]string
+}
+
+// Create check MEV relays task
+func newCheckMevRelays(c *cli.Context, logger log.ColorLogger, alerts *alerting.AlertManager, nodeAddress common.Address) (*checkMevRelays, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &checkMevRelays{
+		c:           c,
+		log:         logger,
+		cfg:         cfg,
+		alerts:      alerts,
+		nodeAddress: nodeAddress,
+		downRelays:  map[cfgtypes.MevRelayID]string{},
+	}, nil
+
+}
+
+// Check that the enabled relays are up and that the node's validators are registered with them
+func (t *checkMevRelays) run(state *state.NetworkState) error {
+
+	// Only check the relays the Smartnode's own MEV-Boost uses
+	if t.cfg.IsNativeMode ||
+		t.cfg.EnableMevBoost.Value == false ||
+		t.cfg.MevBoost.Mode.Value.(cfgtypes.Mode) != cfgtypes.Mode_Local ||
+		t.cfg.MevBoost.EnableRelayMonitoring.Value == false {
+		return nil
+	}
+	if time.Since(t.lastCheck) < mevRelayCheckInterval {
+		return nil
+	}
+	t.lastCheck = time.Now()
+
+	// Get the validators that should be registered
+	pubkeys := []types.ValidatorPubkey{}
+	for _, mpd := range state.MinipoolDetailsByNode[t.nodeAddress] {
+		validator, exists := state.ValidatorDetails[mpd.Pubkey]
+		if !exists || !validator.Exists {
+			continue
+		}
+		switch validator.Status {
+		case beacon.ValidatorState_PendingQueued, beacon.ValidatorState_ActiveOngoing:
+			pubkeys = append(pubkeys, mpd.Pubkey)
+		}
+	}
+
+	// Check the relays
+	report := mevboost.CheckRelays(t.cfg, pubkeys)
+	if err := report.Save(t.cfg.Smartnode.GetMevRelayStatusPath()); err != nil {
+		return err
+	}
+
+	// Alert on relays that are down
+	down := map[cfgtypes.MevRelayID]string{}
+	for _, relay := range report.GetDownRelays() {
+		down[relay.ID] = relay.Name
+		t.log.Warnf("WARNING: MEV relay %s is down: %s", relay.Name, relay.Error)
+		t.alerts.Raise(alerting.Alert{
+			Rule:     alerting.Rule_MevRelayDown,
+			Subject:  string(relay.ID),
+			Severity: alerting.Severity_Warning,
+			Title:    fmt.Sprintf("MEV relay %s is down", relay.Name),
+			Message:  fmt.Sprintf("The %s relay (%s) isn't responding: %s. Your proposals can still use your other relays, or fall back to locally built blocks.", relay.Name, relay.Url, relay.Error),
+		})
+	}
+	for id, name := range t.downRelays {
+		if _, stillDown := down[id]; !stillDown {
+			t.alerts.Resolve(alerting.Alert{
+				Rule:     alerting.Rule_MevRelayDown,
+				Subject:  string(id),
+				Severity: alerting.Severity_Warning,
+				Title:    fmt.Sprintf("MEV relay %s is down", name),
+				Message:  fmt.Sprintf("The %s relay is responding again, or has been disabled.", name),
+			})
+		}
+	}
+	t.downRelays = down
+
+	// Alert on validators that no live relay knows about
+	unregistered := report.GetUnregisteredValidators()
+	pubkeyStrings := make([]string, len(unregistered))
+	for i, pubkey := range unregistered {
+		pubkeyStrings[i] = "0x" + pubkey.Hex()
+	}
+	if len(unregistered) > 0 {
+		t.log.Warnf("WARNING: %d validators aren't registered with any of your MEV relays: %s", len(unregistered), strings.Join(pubkeyStrings, ", "))
+	}
+	t.alerts.Update(alerting.Alert{
+		Rule:     alerting.Rule_MevUnregistered,
+		Severity: alerting.Severity_Warning,
+		Title:    "Validators aren't registered with any MEV relay",
+		Message:  fmt.Sprintf("%d of your validators aren't registered with any of your MEV relays, so their proposals won't get MEV-Boost blocks: %s. Make sure your Validator client is running with MEV-Boost enabled.", len(unregistered), strings.Join(pubkeyStrings, ", ")),
+	}, len(unregistered) > 0)
+
+	return nil
+
+}
@@ -0,0 +1,101 @@
+package node
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/addons/rescue_node"
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// How often to check whether the rescue node is still needed
+var rescueNodeCheckInterval, _ = time.ParseDuration("5m")
+
+// Check rescue node task
+type checkRescueNode struct {
+	c         *cli.Context
+	log       log.ColorLogger
+	cfg       *config.RocketPoolConfig
+	bc        *services.BeaconClientManager
+	alerts    *alerting.AlertManager
+	lastCheck time.Time
+}
+
+// Create check rescue node task
+func newCheckRescueNode(c *cli.Context, logger log.ColorLogger, alerts *alerting.AlertManager) (*checkRescueNode, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &checkRescueNode{
+		c:      c,
+		log:    logger,
+		cfg:    cfg,
+		bc:     bc,
+		alerts: alerts,
+	}, nil
+
+}
+
+// The Validator client only picks up the rescue node settings when its container is recreated, so it keeps the rescue node
+// as its fallback until the user stops the delegation and restarts the services. Tell them when that's due: once the
+// delegation has ended, or once their own Beacon node is healthy again.
+func (t *checkRescueNode) run() error {
+
+	rescueNode, ok := t.cfg.RescueNode.(*rescue_node.RescueNode)
+	if !ok {
+		return nil
+	}
+	if time.Since(t.lastCheck) < rescueNodeCheckInterval {
+		return nil
+	}
+	t.lastCheck = time.Now()
+
+	enabled := rescueNode.GetEnabledParameter().Value == true
+	active := rescueNode.IsActive(t.lastCheck)
+	end := rescueNode.GetDelegationEnd().Format(time.RFC1123)
+
+	// Check the delegation period
+	expired := enabled && !active
+	if expired {
+		t.log.Warnf("WARNING: the delegation to the rescue node ended on %s, but your Validator client still uses it as its fallback Beacon node.", end)
+	}
+	t.alerts.Update(alerting.Alert{
+		Rule:     alerting.Rule_RescueNodeExpired,
+		Severity: alerting.Severity_Warning,
+		Title:    "Rescue node delegation has ended",
+		Message:  fmt.Sprintf("The delegation to the rescue node ended on %s, but your Validator client still has it as its fallback Beacon node with the expired credentials. Run `rocketpool node rescue-node stop` and then `rocketpool service start` to remove it.", end),
+	}, expired)
+
+	// Check the node's own Beacon node
+	recovered := false
+	if active {
+		status := t.bc.CheckStatus().PrimaryClientStatus
+		recovered = status.IsWorking && status.IsSynced
+		if recovered {
+			t.log.Println("Your Beacon node is synced again, so the rescue node is no longer needed.")
+		}
+	}
+	t.alerts.Update(alerting.Alert{
+		Rule:     alerting.Rule_RescueNodeUnneeded,
+		Severity: alerting.Severity_Info,
+		Title:    "Beacon node is synced again",
+		Message:  fmt.Sprintf("Your Beacon node is synced again, but your Validator client will keep the rescue node as its fallback until the delegation ends on %s. Run `rocketpool node rescue-node stop` and then `rocketpool service start` to stop using it now.", end),
+	}, recovered)
+
+	return nil
+
+}
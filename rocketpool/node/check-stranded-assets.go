@@ -0,0 +1,113 @@
+package node
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/upgrades"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// How often to check for assets left in previous contract versions; upgrades are rare, so this doesn't need to be often
+var strandedAssetCheckInterval, _ = time.ParseDuration("24h")
+
+// Check stranded assets task
+type checkStrandedAssets struct {
+	c           *cli.Context
+	log         log.ColorLogger
+	cfg         *config.RocketPoolConfig
+	rp          *rocketpool.RocketPool
+	alerts      *alerting.AlertManager
+	nodeAddress common.Address
+	lastCheck   time.Time
+
+	// The assets found by the previous check, so the alerts for ones that have been claimed can be cleared
+	found map[string]upgrades.StrandedAsset
+}
+
+// Create check stranded assets task
+func newCheckStrandedAssets(c *cli.Context, logger log.ColorLogger, alerts *alerting.AlertManager, nodeAddress common.Address) (*checkStrandedAssets, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &checkStrandedAssets{
+		c:           c,
+		log:         logger,
+		cfg:         cfg,
+		rp:          rp,
+		alerts:      alerts,
+		nodeAddress: nodeAddress,
+		found:       map[string]upgrades.StrandedAsset{},
+	}, nil
+
+}
+
+// Look for the node's assets in contracts replaced by protocol upgrades, and raise an alert for each one
+func (t *checkStrandedAssets) run() error {
+
+	if time.Since(t.lastCheck) < strandedAssetCheckInterval {
+		return nil
+	}
+
+	// Bring the upgrade history up to date; the first scan covers every block since Rocket Pool was deployed
+	t.log.Println("Checking for assets in previous Rocket Pool contracts...")
+	eventLogInterval, err := t.cfg.GetEventLogInterval()
+	if err != nil {
+		return err
+	}
+	history, err := upgrades.UpdateHistory(t.rp, t.cfg.Smartnode.GetUpgradeHistoryPath(), big.NewInt(int64(eventLogInterval)), t.cfg.Smartnode.ConfirmationDepth.Value.(uint64), nil)
+	if err != nil {
+		return fmt.Errorf("error updating the contract upgrade history: %w", err)
+	}
+	assets, err := upgrades.FindStrandedAssets(t.rp, t.cfg, history, t.nodeAddress)
+	if err != nil {
+		return fmt.Errorf("error checking for stranded assets: %w", err)
+	}
+	t.lastCheck = time.Now()
+
+	// Raise alerts for the assets that are still there and clear the ones that are gone
+	found := map[string]upgrades.StrandedAsset{}
+	for _, asset := range assets {
+		key := fmt.Sprintf("%s/%s", asset.Type, asset.Address.Hex())
+		found[key] = asset
+		t.log.Printlnf("Found %.6f %s: %s.", eth.WeiToEth(asset.Amount), asset.Token, asset.Description())
+		t.alerts.Raise(alerting.Alert{
+			Rule:     alerting.Rule_StrandedAsset,
+			Subject:  key,
+			Severity: alerting.Severity_Warning,
+			Title:    "Assets found in a previous Rocket Pool contract",
+			Message:  fmt.Sprintf("Your node has %.6f %s: %s. Run `rocketpool node stranded-assets` to see it and `rocketpool node claim-stranded-assets` to recover it.", eth.WeiToEth(asset.Amount), asset.Token, asset.Description()),
+		})
+	}
+	for key := range t.found {
+		if _, exists := found[key]; !exists {
+			t.alerts.Resolve(alerting.Alert{
+				Rule:    alerting.Rule_StrandedAsset,
+				Subject: key,
+				Title:   "Assets found in a previous Rocket Pool contract",
+			})
+		}
+	}
+	t.found = found
+
+	return nil
+
+}
@@ -0,0 +1,104 @@
+package node
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/updates"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// How often to check for new releases
+var updateCheckInterval, _ = time.ParseDuration("6h")
+
+// Check for updates task
+type checkUpdates struct {
+	c           *cli.Context
+	log         log.ColorLogger
+	cfg         *config.RocketPoolConfig
+	alerts      *alerting.AlertManager
+	nodeAddress common.Address
+	lastCheck   time.Time
+	status      *updates.UpdateStatus
+
+	// The releases that have already been announced
+	notified map[string]bool
+}
+
+// Create check for updates task
+func newCheckUpdates(c *cli.Context, logger log.ColorLogger, alerts *alerting.AlertManager, nodeAddress common.Address) (*checkUpdates, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &checkUpdates{
+		c:           c,
+		log:         logger,
+		cfg:         cfg,
+		alerts:      alerts,
+		nodeAddress: nodeAddress,
+		notified:    map[string]bool{},
+	}, nil
+
+}
+
+// Check for new Smartnode and client releases, and raise an alert for each one that has been rolled out to this node
+func (t *checkUpdates) run() error {
+
+	if t.cfg.Smartnode.EnableUpdateChecks.Value == false {
+		return nil
+	}
+
+	// Releases are rare, so only check GitHub occasionally
+	if time.Since(t.lastCheck) > updateCheckInterval {
+		t.lastCheck = time.Now()
+		t.status = updates.CheckForUpdates(t.cfg, t.nodeAddress.Bytes())
+		for _, err := range t.status.Errors {
+			t.log.Warnf("WARNING: couldn't check for updates: %s", err)
+		}
+		if t.status.Smartnode != nil && !t.status.Smartnode.Verified {
+			t.log.Warnf("WARNING: Smartnode %s failed verification: %s", t.status.Smartnode.LatestVersion, t.status.Smartnode.VerificationError)
+		}
+		for _, release := range t.status.Clients {
+			if !release.Verified {
+				t.log.Warnf("WARNING: %s %s couldn't be verified: %s", release.Name, release.LatestVersion, release.VerificationError)
+			}
+		}
+		err := t.status.Save(t.cfg.Smartnode.GetUpdateStatusPath())
+		if err != nil {
+			return err
+		}
+	}
+	if t.status == nil {
+		return nil
+	}
+
+	// Announce the releases that have reached this node in the rollout
+	for _, release := range t.status.GetAvailableUpdates(time.Now()) {
+		key := fmt.Sprintf("%s/%s", release.Name, release.LatestVersion)
+		if !release.Verified || t.notified[key] {
+			continue
+		}
+		t.notified[key] = true
+		t.log.Printlnf("%s %s is available (you have %s).", release.Name, release.LatestVersion, release.CurrentVersion)
+		t.alerts.Raise(alerting.Alert{
+			Rule:     alerting.Rule_UpdateAvailable,
+			Subject:  key,
+			Severity: alerting.Severity_Info,
+			Title:    fmt.Sprintf("%s %s is available", release.Name, release.LatestVersion),
+			Message:  fmt.Sprintf("Version %s of %s has been released (you have %s). See %s for the release notes, and run `rocketpool service update` to install it.", release.LatestVersion, release.Name, release.CurrentVersion, release.Url),
+		})
+	}
+	return nil
+
+}
@@ -0,0 +1,148 @@
+package collectors
+
+import (
+	"fmt"
+	"log"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+// The number of days used for the trailing rETH APR
+const rethAprDays uint64 = 7
+
+// How often to refresh the trailing rETH APR, since it requires scanning event logs
+const rethAprRefreshInterval time.Duration = time.Hour
+
+// Represents the collector for the rETH and node APR metrics
+type AprCollector struct {
+	// The trailing rETH APR
+	rethApr *prometheus.Desc
+
+	// The solo validator APR implied by the rETH APR
+	validatorApr *prometheus.Desc
+
+	// The estimated APR of the node, broken down by source
+	nodeApr *prometheus.Desc
+
+	// The Rocket Pool contract manager
+	rp *rocketpool.RocketPool
+
+	// The node's address
+	nodeAddress common.Address
+
+	// The event log interval for the current EC
+	eventLogInterval *big.Int
+
+	// The thread-safe locker for the network state
+	stateLocker *StateLocker
+
+	// The cached rETH APR and the time it was last refreshed
+	cachedRethApr   float64
+	lastRefreshTime time.Time
+	cacheLock       *sync.Mutex
+
+	// Prefix for logging
+	logPrefix string
+}
+
+// Create a new AprCollector instance
+func NewAprCollector(rp *rocketpool.RocketPool, nodeAddress common.Address, cfg *config.RocketPoolConfig, stateLocker *StateLocker) *AprCollector {
+
+	// Get the event log interval
+	eventLogInterval, err := cfg.GetEventLogInterval()
+	if err != nil {
+		log.Printf("Error getting event log interval: %s\n", err.Error())
+		return nil
+	}
+
+	subsystem := "apr"
+	return &AprCollector{
+		rethApr: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "reth_apr"),
+			fmt.Sprintf("The trailing %d-day rETH APR, in percent", rethAprDays),
+			nil, nil,
+		),
+		validatorApr: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "validator_apr"),
+			"The solo validator APR implied by the rETH APR, in percent",
+			nil, nil,
+		),
+		nodeApr: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "node_apr"),
+			"The estimated APR of the node, in percent",
+			[]string{"source"}, nil,
+		),
+		rp:               rp,
+		nodeAddress:      nodeAddress,
+		eventLogInterval: big.NewInt(int64(eventLogInterval)),
+		stateLocker:      stateLocker,
+		cacheLock:        &sync.Mutex{},
+		logPrefix:        "APR Collector",
+	}
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *AprCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.rethApr
+	channel <- collector.validatorApr
+	channel <- collector.nodeApr
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *AprCollector) Collect(channel chan<- prometheus.Metric) {
+	// Get the latest state
+	state := collector.stateLocker.GetState()
+	if state == nil {
+		return
+	}
+
+	rethApr, err := collector.getRethApr()
+	if err != nil {
+		collector.logError(err)
+		return
+	}
+	validatorApr := rputils.GetValidatorAprFromRethApr(rethApr, state.NetworkDetails.ETHUtilizationRate, state.NetworkDetails.NodeFee)
+
+	channel <- prometheus.MustNewConstMetric(
+		collector.rethApr, prometheus.GaugeValue, rethApr)
+	channel <- prometheus.MustNewConstMetric(
+		collector.validatorApr, prometheus.GaugeValue, validatorApr)
+
+	nodeApr, err := rputils.EstimateNodeApr(state, collector.nodeAddress, collector.stateLocker.GetTotalEffectiveRPLStake(), validatorApr)
+	if err != nil {
+		collector.logError(err)
+		return
+	}
+	channel <- prometheus.MustNewConstMetric(
+		collector.nodeApr, prometheus.GaugeValue, nodeApr.EthApr, "eth")
+	channel <- prometheus.MustNewConstMetric(
+		collector.nodeApr, prometheus.GaugeValue, nodeApr.RplApr, "rpl")
+	channel <- prometheus.MustNewConstMetric(
+		collector.nodeApr, prometheus.GaugeValue, nodeApr.TotalApr, "total")
+}
+
+// Get the trailing rETH APR, refreshing it if the cached value is stale
+func (collector *AprCollector) getRethApr() (float64, error) {
+	collector.cacheLock.Lock()
+	defer collector.cacheLock.Unlock()
+
+	if time.Since(collector.lastRefreshTime) < rethAprRefreshInterval {
+		return collector.cachedRethApr, nil
+	}
+	rethApr, _, err := rputils.GetTrailingRethApr(collector.rp, rethAprDays, collector.eventLogInterval)
+	if err != nil {
+		return 0, fmt.Errorf("error getting rETH APR: %w", err)
+	}
+	collector.cachedRethApr = rethApr
+	collector.lastRefreshTime = time.Now()
+	return rethApr, nil
+}
+
+// Log error messages
+func (collector *AprCollector) logError(err error) {
+	fmt.Printf("[%s] %s\n", collector.logPrefix, err.Error())
+}
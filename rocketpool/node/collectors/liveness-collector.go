@@ -0,0 +1,116 @@
+package collectors
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// The attestation statistics for a single validator
+type ValidatorLivenessStats struct {
+	// The number of epochs the validator was seen performing its duties in
+	LiveEpochs uint64
+
+	// The number of epochs the validator missed its duties in
+	MissedEpochs uint64
+
+	// The number of epochs in a row that the validator has missed its duties, up to the latest one
+	ConsecutiveMisses uint64
+
+	// The latest epoch that was checked
+	LastEpoch uint64
+}
+
+// Represents the collector for the validator liveness metrics
+type LivenessCollector struct {
+
+	// The number of epochs each validator was seen performing its duties in
+	liveEpochsDesc *prometheus.Desc
+
+	// The number of epochs each validator missed its duties in
+	missedEpochsDesc *prometheus.Desc
+
+	// The number of epochs in a row that each validator has missed its duties
+	consecutiveMissesDesc *prometheus.Desc
+
+	// The fraction of checked epochs each validator was live in
+	effectivenessDesc *prometheus.Desc
+
+	// The latest epoch that was checked
+	latestEpochDesc *prometheus.Desc
+
+	// The stats for each validator, keyed by validator index
+	Stats map[string]*ValidatorLivenessStats
+
+	// The latest epoch that was checked
+	LatestEpoch float64
+
+	// Mutex
+	UpdateLock *sync.Mutex
+}
+
+// Create a new LivenessCollector instance
+func NewLivenessCollector() *LivenessCollector {
+	subsystem := "liveness"
+	labels := []string{"validator"}
+	return &LivenessCollector{
+		liveEpochsDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "live_epochs"),
+			"The number of epochs each validator was seen performing its duties in since the daemon started",
+			labels, nil,
+		),
+		missedEpochsDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "missed_epochs"),
+			"The number of epochs each validator missed its duties in since the daemon started",
+			labels, nil,
+		),
+		consecutiveMissesDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "consecutive_misses"),
+			"The number of epochs in a row that each validator has missed its duties",
+			labels, nil,
+		),
+		effectivenessDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "effectiveness"),
+			"The fraction of checked epochs each validator was live in",
+			labels, nil,
+		),
+		latestEpochDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "latest_epoch"),
+			"The latest epoch that validator liveness was checked for",
+			nil, nil,
+		),
+		Stats:      map[string]*ValidatorLivenessStats{},
+		UpdateLock: &sync.Mutex{},
+	}
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *LivenessCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.liveEpochsDesc
+	channel <- collector.missedEpochsDesc
+	channel <- collector.consecutiveMissesDesc
+	channel <- collector.effectivenessDesc
+	channel <- collector.latestEpochDesc
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *LivenessCollector) Collect(channel chan<- prometheus.Metric) {
+
+	// Sync
+	collector.UpdateLock.Lock()
+	defer collector.UpdateLock.Unlock()
+
+	// Update all of the metrics
+	for index, stats := range collector.Stats {
+		effectiveness := float64(0)
+		total := stats.LiveEpochs + stats.MissedEpochs
+		if total > 0 {
+			effectiveness = float64(stats.LiveEpochs) / float64(total)
+		}
+		channel <- prometheus.MustNewConstMetric(
+			collector.liveEpochsDesc, prometheus.CounterValue, float64(stats.LiveEpochs), index)
+		channel <- prometheus.MustNewConstMetric(
+			collector.missedEpochsDesc, prometheus.CounterValue, float64(stats.MissedEpochs), index)
+		channel <- prometheus.MustNewConstMetric(
+			collector.consecutiveMissesDesc, prometheus.GaugeValue, float64(stats.ConsecutiveMisses), index)
+		channel <- prometheus.MustNewConstMetric(
+			collector.effectivenessDesc, prometheus.GaugeValue, effectiveness, index)
+	}
+	channel <- prometheus.MustNewConstMetric(
+		collector.latestEpochDesc, prometheus.GaugeValue, collector.LatestEpoch)
+}
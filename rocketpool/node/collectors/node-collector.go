@@ -12,6 +12,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/types"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
@@ -20,6 +21,9 @@ import (
 	"golang.org/x/sync/errgroup"
 )
 
+// Status label for minipools that have been finalised
+const finalisedStatus string = "Finalised"
+
 // Represents the collector for the user's node
 type NodeCollector struct {
 	// The total amount of RPL staked on the node
@@ -46,6 +50,15 @@ type NodeCollector struct {
 	// The number of active minipools owned by the node
 	activeMinipoolCount *prometheus.Desc
 
+	// The number of minipools owned by the node, by status
+	minipoolCounts *prometheus.Desc
+
+	// The ETH balance of the node's fee distributor
+	feeDistributorBalance *prometheus.Desc
+
+	// Whether or not the node is opted into the smoothing pool
+	smoothingPoolRegistered *prometheus.Desc
+
 	// The amount of ETH this node deposited into minipools
 	depositedEth *prometheus.Desc
 
@@ -157,6 +170,18 @@ func NewNodeCollector(rp *rocketpool.RocketPool, bc beacon.Client, nodeAddress c
 			"The number of active minipools owned by the node",
 			nil, nil,
 		),
+		minipoolCounts: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "minipool_count"),
+			"The number of minipools owned by the node, by status",
+			[]string{"status"}, nil,
+		),
+		feeDistributorBalance: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "fee_distributor_balance"),
+			"The ETH balance of the node's fee distributor",
+			nil, nil,
+		),
+		smoothingPoolRegistered: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "smoothing_pool_registered"),
+			"Whether or not the node is opted into the smoothing pool (1 if it is, 0 if not)",
+			nil, nil,
+		),
 		depositedEth: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "deposited_eth"),
 			"The amount of ETH this node deposited into minipools",
 			nil, nil,
@@ -222,6 +247,9 @@ func (collector *NodeCollector) Describe(channel chan<- *prometheus.Desc) {
 	channel <- collector.rplApr
 	channel <- collector.balances
 	channel <- collector.activeMinipoolCount
+	channel <- collector.minipoolCounts
+	channel <- collector.feeDistributorBalance
+	channel <- collector.smoothingPoolRegistered
 	channel <- collector.depositedEth
 	channel <- collector.beaconBalance
 	channel <- collector.beaconShare
@@ -259,7 +287,20 @@ func (collector *NodeCollector) Collect(channel chan<- prometheus.Metric) {
 	oldRplBalance := eth.WeiToEth(nd.BalanceOldRPL)
 	newRplBalance := eth.WeiToEth(nd.BalanceRPL)
 	rethBalance := eth.WeiToEth(nd.BalanceRETH)
+	feeDistributorBalance := float64(0)
+	if nd.DistributorBalance != nil {
+		feeDistributorBalance = eth.WeiToEth(nd.DistributorBalance)
+	}
+	smoothingPoolRegistered := float64(0)
+	if nd.SmoothingPoolRegistrationState {
+		smoothingPoolRegistered = 1
+	}
 	var activeMinipoolCount float64
+	minipoolCounts := map[string]float64{}
+	for _, status := range types.MinipoolStatuses {
+		minipoolCounts[status] = 0
+	}
+	minipoolCounts[finalisedStatus] = 0
 	rplPriceRaw := state.NetworkDetails.RplPrice
 	rplPrice := eth.WeiToEth(rplPriceRaw)
 	var beaconHead beacon.BeaconHead
@@ -345,6 +386,9 @@ func (collector *NodeCollector) Collect(channel chan<- prometheus.Metric) {
 		for _, mpd := range minipools {
 			if mpd.Finalised {
 				minipoolCount--
+				minipoolCounts[finalisedStatus]++
+			} else {
+				minipoolCounts[mpd.Status.String()]++
 			}
 		}
 		activeMinipoolCount = float64(minipoolCount)
@@ -523,6 +567,14 @@ func (collector *NodeCollector) Collect(channel chan<- prometheus.Metric) {
 		collector.balances, prometheus.GaugeValue, rethBalance, "rETH")
 	channel <- prometheus.MustNewConstMetric(
 		collector.activeMinipoolCount, prometheus.GaugeValue, activeMinipoolCount)
+	for status, count := range minipoolCounts {
+		channel <- prometheus.MustNewConstMetric(
+			collector.minipoolCounts, prometheus.GaugeValue, count, status)
+	}
+	channel <- prometheus.MustNewConstMetric(
+		collector.feeDistributorBalance, prometheus.GaugeValue, feeDistributorBalance)
+	channel <- prometheus.MustNewConstMetric(
+		collector.smoothingPoolRegistered, prometheus.GaugeValue, smoothingPoolRegistered)
 	channel <- prometheus.MustNewConstMetric(
 		collector.depositedEth, prometheus.GaugeValue, totalDepositBalance)
 	channel <- prometheus.MustNewConstMetric(
@@ -0,0 +1,110 @@
+package collectors
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/rocket-pool/smartnode/shared/services/sysmon"
+)
+
+// Represents the collector for the machine's resource usage
+type SystemCollector struct {
+	// The total size of the disk holding the Smartnode's data
+	diskTotalDesc *prometheus.Desc
+
+	// The free space on the disk holding the Smartnode's data
+	diskFreeDesc *prometheus.Desc
+
+	// The estimated number of days until the disk is full
+	daysUntilFullDesc *prometheus.Desc
+
+	// The size of each client's chain data
+	chainDataDesc *prometheus.Desc
+
+	// The fraction of RAM in use
+	memoryUsedDesc *prometheus.Desc
+
+	// How far ahead of each time source the system clock is
+	clockOffsetDesc *prometheus.Desc
+
+	// The latest system status
+	Status sysmon.SystemStatus
+
+	// Mutex
+	UpdateLock *sync.Mutex
+}
+
+// Create a new SystemCollector instance
+func NewSystemCollector() *SystemCollector {
+	subsystem := "system"
+	return &SystemCollector{
+		diskTotalDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "disk_total_bytes"),
+			"The total size of the disk holding the Smartnode's data",
+			nil, nil,
+		),
+		diskFreeDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "disk_free_bytes"),
+			"The free space on the disk holding the Smartnode's data",
+			nil, nil,
+		),
+		daysUntilFullDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "disk_days_until_full"),
+			"The estimated number of days until the disk is full, or -1 if it isn't filling up",
+			nil, nil,
+		),
+		chainDataDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "chain_data_bytes"),
+			"The size of each locally-managed client's chain data",
+			[]string{"client"}, nil,
+		),
+		memoryUsedDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "memory_used_ratio"),
+			"The fraction of the machine's RAM that is in use",
+			nil, nil,
+		),
+		clockOffsetDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "clock_offset_seconds"),
+			"How far ahead of the NTP server or the Beacon Chain's slot times the system clock is (negative if it's behind)",
+			[]string{"source"}, nil,
+		),
+		UpdateLock: &sync.Mutex{},
+	}
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *SystemCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.diskTotalDesc
+	channel <- collector.diskFreeDesc
+	channel <- collector.daysUntilFullDesc
+	channel <- collector.chainDataDesc
+	channel <- collector.memoryUsedDesc
+	channel <- collector.clockOffsetDesc
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *SystemCollector) Collect(channel chan<- prometheus.Metric) {
+	collector.UpdateLock.Lock()
+	defer collector.UpdateLock.Unlock()
+
+	status := collector.Status
+	if status.Time.IsZero() {
+		return
+	}
+
+	channel <- prometheus.MustNewConstMetric(
+		collector.diskTotalDesc, prometheus.GaugeValue, float64(status.DiskTotal))
+	channel <- prometheus.MustNewConstMetric(
+		collector.diskFreeDesc, prometheus.GaugeValue, float64(status.DiskFree))
+	channel <- prometheus.MustNewConstMetric(
+		collector.daysUntilFullDesc, prometheus.GaugeValue, status.DaysUntilFull)
+	for _, chainData := range status.ChainData {
+		channel <- prometheus.MustNewConstMetric(
+			collector.chainDataDesc, prometheus.GaugeValue, float64(chainData.Size), chainData.Client)
+	}
+	channel <- prometheus.MustNewConstMetric(
+		collector.memoryUsedDesc, prometheus.GaugeValue, status.MemoryUsedPercent/100)
+	if status.NtpOffset != nil {
+		channel <- prometheus.MustNewConstMetric(
+			collector.clockOffsetDesc, prometheus.GaugeValue, status.NtpOffset.Seconds(), "ntp")
+	}
+	if status.BeaconOffset != nil {
+		channel <- prometheus.MustNewConstMetric(
+			collector.clockOffsetDesc, prometheus.GaugeValue, status.BeaconOffset.Seconds(), "beacon")
+	}
+}
@@ -0,0 +1,81 @@
+package node
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/activity"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// How often to index new activity; `rocketpool node activity` catches up on anything newer itself
+var activityIndexInterval, _ = time.ParseDuration("15m")
+
+// Index node activity task
+type indexActivity struct {
+	c           *cli.Context
+	log         log.ColorLogger
+	cfg         *config.RocketPoolConfig
+	rp          *rocketpool.RocketPool
+	nodeAddress common.Address
+	lastIndex   time.Time
+}
+
+// Create index node activity task
+func newIndexActivity(c *cli.Context, logger log.ColorLogger, nodeAddress common.Address) (*indexActivity, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &indexActivity{
+		c:           c,
+		log:         logger,
+		cfg:         cfg,
+		rp:          rp,
+		nodeAddress: nodeAddress,
+	}, nil
+
+}
+
+// Add the node's Rocket Pool events since the last run to the activity database
+func (t *indexActivity) run() error {
+
+	if time.Since(t.lastIndex) < activityIndexInterval {
+		return nil
+	}
+
+	// The first run scans everything since the node registered, which can take a while
+	eventLogInterval, err := t.cfg.GetEventLogInterval()
+	if err != nil {
+		return err
+	}
+	db, err := activity.UpdateDatabase(t.rp, t.cfg.Smartnode.GetActivityDatabasePath(), t.cfg.Smartnode.GetUpgradeHistoryPath(), t.nodeAddress, big.NewInt(int64(eventLogInterval)), t.cfg.Smartnode.ConfirmationDepth.Value.(uint64), nil)
+	if err != nil {
+		return fmt.Errorf("error indexing node activity: %w", err)
+	}
+	_, scannedBlock, err := db.GetScanState()
+	db.Close()
+	if err != nil {
+		return err
+	}
+	t.log.Printlnf("Indexed node activity up to block %d.", scannedBlock)
+	t.lastIndex = time.Now()
+
+	return nil
+
+}
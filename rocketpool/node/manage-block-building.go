@@ -0,0 +1,129 @@
+package node
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/keymanager"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Manage block building task
+type manageBlockBuilding struct {
+	c           *cli.Context
+	log         log.ColorLogger
+	cfg         *config.RocketPoolConfig
+	nodeAddress common.Address
+
+	// The preferences pushed to the Validator client since the daemon started; Lighthouse's builder setting can't be read back, so this is how changes are found
+	applied map[types.ValidatorPubkey]config.BlockBuildingPreference
+}
+
+// Create manage block building task
+func newManageBlockBuilding(c *cli.Context, logger log.ColorLogger, nodeAddress common.Address) (*manageBlockBuilding, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &manageBlockBuilding{
+		c:           c,
+		log:         logger,
+		cfg:         cfg,
+		nodeAddress: nodeAddress,
+		applied:     map[types.ValidatorPubkey]config.BlockBuildingPreference{},
+	}, nil
+
+}
+
+// Push the minipools' block building preferences to the Validator client over its Keymanager API
+func (m *manageBlockBuilding) run(state *state.NetworkState) error {
+
+	// The preferences can only be pushed over the Keymanager API
+	if !m.cfg.Keymanager.IsEnabled() {
+		return nil
+	}
+
+	// Get the preference of each minipool that has one
+	settings, err := config.LoadBlockBuildingSettings(m.cfg.Smartnode.GetBlockBuildingSettingsPath())
+	if err != nil {
+		return err
+	}
+	preferences := map[types.ValidatorPubkey]config.BlockBuildingPreference{}
+	emptyPubkey := types.ValidatorPubkey{}
+	for _, mpd := range state.MinipoolDetailsByNode[m.nodeAddress] {
+		preference, exists := settings.Minipools[mpd.MinipoolAddress]
+		if !exists || mpd.Pubkey == emptyPubkey {
+			continue
+		}
+		preferences[mpd.Pubkey] = preference
+	}
+
+	// Validators whose preference was cleared go back to the Validator client's defaults
+	cleared := map[types.ValidatorPubkey]bool{}
+	for pubkey := range m.applied {
+		if _, exists := preferences[pubkey]; !exists {
+			preferences[pubkey] = config.BlockBuildingPreference{}
+			cleared[pubkey] = true
+		}
+	}
+	if len(preferences) == 0 {
+		return nil
+	}
+
+	// Only validators the Validator client has loaded can be changed; the others are picked up once they're loaded
+	km, err := keymanager.NewClient(m.cfg)
+	if err != nil {
+		return err
+	}
+	keystores, err := km.ListKeystores()
+	if err != nil {
+		return err
+	}
+	loaded := map[types.ValidatorPubkey]bool{}
+	for _, keystore := range keystores {
+		loaded[keystore.Pubkey] = true
+	}
+
+	for pubkey, preference := range preferences {
+		if !loaded[pubkey] {
+			continue
+		}
+
+		// Preferences that don't work with the node's current settings fall back to its default mode
+		validationErr := m.cfg.ValidateBlockBuildingPreference(preference)
+		if validationErr != nil {
+			preference.Mode = cfgtypes.BlockBuildingMode_Default
+		}
+		if applied, exists := m.applied[pubkey]; exists && applied == preference {
+			continue
+		}
+		if validationErr != nil {
+			m.log.Warnf("WARNING: The block building preference for validator %s can't be used (%s), so it will follow the node's MEV-Boost setting instead.", pubkey.Hex(), validationErr.Error())
+		}
+
+		err := km.ApplyBlockBuildingPreference(m.cfg, pubkey, preference)
+		if err != nil {
+			m.log.Warnf("WARNING: Couldn't update the block building preference for validator %s: %s", pubkey.Hex(), err.Error())
+			continue
+		}
+		if cleared[pubkey] {
+			delete(m.applied, pubkey)
+			m.log.Printlnf("Validator %s now uses the Validator client's default block building settings.", pubkey.Hex())
+		} else {
+			m.applied[pubkey] = preference
+			m.log.Printlnf("Updated the block building preference for validator %s (builders: %t, gas limit: %d).", pubkey.Hex(), m.cfg.UsesBuilder(preference), preference.GasLimit)
+		}
+	}
+
+	return nil
+
+}
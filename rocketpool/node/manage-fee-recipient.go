@@ -11,6 +11,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/keymanager"
 	rpsvc "github.com/rocket-pool/smartnode/shared/services/rocketpool"
 	"github.com/rocket-pool/smartnode/shared/services/state"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
@@ -104,27 +105,43 @@ func (m *manageFeeRecipient) run(state *state.NetworkState) error {
 	if err != nil {
 		return fmt.Errorf("error validating fee recipient files: %w", err)
 	}
+	filesCorrect := fileExists && correctAddress
 
 	if !fileExists {
 		m.log.Println("Fee recipient files don't all exist, regenerating...")
 	} else if !correctAddress {
-		m.log.Printlnf("WARNING: Fee recipient files did not contain the correct fee recipient of %s, regenerating...", correctFeeRecipient.Hex())
-	} else {
-		// Files are all correct, return.
-		return nil
+		m.log.Warnf("WARNING: Fee recipient files did not contain the correct fee recipient of %s, regenerating...", correctFeeRecipient.Hex())
 	}
 
 	// Regenerate the fee recipient files
-	err = rpsvc.UpdateFeeRecipientFile(correctFeeRecipient, m.cfg)
-	if err != nil {
-		m.log.Println("***ERROR***")
-		m.log.Printlnf("Error updating fee recipient files: %s", err.Error())
-		m.log.Println("Shutting down the validator client for safety to prevent you from being penalized...")
-
-		err = validator.StopValidator(m.cfg, m.bc, &m.log, m.d)
+	if !filesCorrect {
+		err = rpsvc.UpdateFeeRecipientFile(correctFeeRecipient, m.cfg)
 		if err != nil {
-			return fmt.Errorf("error stopping validator client: %w", err)
+			m.log.Println("***ERROR***")
+			m.log.Printlnf("Error updating fee recipient files: %s", err.Error())
+			m.log.Println("Shutting down the validator client for safety to prevent you from being penalized...")
+
+			err = validator.StopValidator(m.cfg, m.bc, &m.log, m.d)
+			if err != nil {
+				return fmt.Errorf("error stopping validator client: %w", err)
+			}
+			return nil
+		}
+	}
+
+	// Apply the fee recipient over the Keymanager API so the VC doesn't need a restart; this also catches validators it loaded since the last check
+	if m.cfg.Keymanager.IsEnabled() {
+		err = m.updateKeymanagerFeeRecipients(correctFeeRecipient)
+		if err == nil {
+			if !filesCorrect {
+				m.log.Println("Fee recipient files updated and applied over the Keymanager API, you are now validating safely.")
+			}
+			return nil
 		}
+		m.log.Warnf("WARNING: couldn't set the fee recipient over the Keymanager API: %s", err.Error())
+	}
+	if filesCorrect {
+		// Files are all correct, return.
 		return nil
 	}
 
@@ -140,3 +157,16 @@ func (m *manageFeeRecipient) run(state *state.NetworkState) error {
 	return nil
 
 }
+
+// Make sure every validator in the VC uses the correct fee recipient, using its Keymanager API
+func (m *manageFeeRecipient) updateKeymanagerFeeRecipients(feeRecipient common.Address) error {
+	client, err := keymanager.NewClient(m.cfg)
+	if err != nil {
+		return err
+	}
+	updated, err := client.SetFeeRecipientForAll(feeRecipient)
+	if updated > 0 {
+		m.log.Printlnf("Set the fee recipient of %d validators to %s over the Keymanager API.", updated, feeRecipient.Hex())
+	}
+	return err
+}
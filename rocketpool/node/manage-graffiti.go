@@ -0,0 +1,134 @@
+package node
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/docker/docker/client"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	rpsvc "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+	"github.com/rocket-pool/smartnode/shared/utils/validator"
+)
+
+// Manage graffiti task
+type manageGraffiti struct {
+	c           *cli.Context
+	log         log.ColorLogger
+	cfg         *config.RocketPoolConfig
+	d           *client.Client
+	bc          beacon.Client
+	nodeAddress common.Address
+
+	// Set once the user has been warned that their client can't use per-minipool graffiti, so it isn't repeated every cycle
+	warnedPerValidator bool
+}
+
+// Create manage graffiti task
+func newManageGraffiti(c *cli.Context, logger log.ColorLogger, nodeAddress common.Address) (*manageGraffiti, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	d, err := services.GetDocker(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &manageGraffiti{
+		c:           c,
+		log:         logger,
+		cfg:         cfg,
+		d:           d,
+		bc:          bc,
+		nodeAddress: nodeAddress,
+	}, nil
+
+}
+
+// Render the node's and minipools' graffiti into the validator client's graffiti file
+func (m *manageGraffiti) run(state *state.NetworkState) error {
+
+	// Only clients that read their graffiti from a file can be managed; the others use the graffiti from the config
+	supported, rereadsFile, perValidator := config.GetGraffitiFileSupport(m.cfg.GetGraffitiConsensusClient())
+	if !supported {
+		return nil
+	}
+	now := time.Now()
+
+	// Get the node's graffiti
+	defaultGraffiti, err := m.cfg.Graffiti.GetNodeGraffiti(now)
+	if err != nil {
+		return fmt.Errorf("error rendering node graffiti: %w", err)
+	}
+
+	// Get the graffiti for each of the minipools that have their own
+	settings, err := config.LoadGraffitiSettings(m.cfg.Smartnode.GetGraffitiSettingsPath())
+	if err != nil {
+		return err
+	}
+	validatorGraffiti := map[types.ValidatorPubkey]string{}
+	emptyPubkey := types.ValidatorPubkey{}
+	for _, mpd := range state.MinipoolDetailsByNode[m.nodeAddress] {
+		override, exists := settings.Minipools[mpd.MinipoolAddress]
+		if !exists || mpd.Pubkey == emptyPubkey {
+			continue
+		}
+		graffiti, err := m.cfg.Graffiti.GetMinipoolGraffiti(override, now)
+		if err != nil {
+			m.log.Warnf("WARNING: Couldn't render the graffiti for minipool %s, it will use the node's graffiti instead: %s", mpd.MinipoolAddress.Hex(), err.Error())
+			continue
+		}
+		validatorGraffiti[mpd.Pubkey] = graffiti
+	}
+	if len(validatorGraffiti) > 0 && !perValidator && !m.warnedPerValidator {
+		m.log.Warnf("WARNING: Your validator client can't use different graffiti for each validator, so every minipool will use the node's graffiti.")
+		m.warnedPerValidator = true
+	}
+
+	// Check if the file needs to change
+	contents, err := rpsvc.GetGraffitiFileContents(m.cfg, defaultGraffiti, validatorGraffiti)
+	if err != nil {
+		return err
+	}
+	_, correctContents, err := rpsvc.CheckGraffitiFile(contents, m.cfg)
+	if err != nil {
+		return err
+	}
+	if correctContents {
+		return nil
+	}
+
+	// Write the new graffiti
+	err = rpsvc.UpdateGraffitiFile(contents, m.cfg)
+	if err != nil {
+		return err
+	}
+	if rereadsFile {
+		m.log.Printlnf("Updated the graffiti file, your validator client will use it for its next proposal (node graffiti: `%s`).", defaultGraffiti)
+		return nil
+	}
+
+	// Restart the VC so it picks up the new file
+	m.log.Printlnf("Updated the graffiti file (node graffiti: `%s`), restarting validator client...", defaultGraffiti)
+	err = validator.RestartValidator(m.cfg, m.bc, &m.log, m.d)
+	if err != nil {
+		return fmt.Errorf("error restarting validator client: %w", err)
+	}
+	return nil
+
+}
@@ -0,0 +1,81 @@
+package node
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/health"
+	"github.com/rocket-pool/smartnode/shared/services/passwords"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Manage wallet lock task
+type manageWalletLock struct {
+	c             *cli.Context
+	log           log.ColorLogger
+	pm            *passwords.PasswordManager
+	w             *wallet.Wallet
+	healthChecker *health.Checker
+}
+
+// Create manage wallet lock task
+func newManageWalletLock(c *cli.Context, logger log.ColorLogger, healthChecker *health.Checker) (*manageWalletLock, error) {
+
+	// Get services
+	pm, err := services.GetPasswordManager(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &manageWalletLock{
+		c:             c,
+		log:           logger,
+		pm:            pm,
+		w:             w,
+		healthChecker: healthChecker,
+	}, nil
+
+}
+
+// Drop the wallet's keys once its unlock session has ended, and load them again once it's been unlocked.
+// The wallet refuses to use its keys as soon as the session ends; this clears them from the daemon's memory.
+func (t *manageWalletLock) run() error {
+
+	// Lock the wallet if its password is no longer available
+	if !t.pm.IsPasswordSet() {
+		if t.w.IsInitialized() {
+			t.w.Lock()
+			if err := t.w.EndSession(); err != nil {
+				t.log.Warnf("WARNING: %s", err.Error())
+			}
+			t.log.Println("The wallet's unlock session has ended, so its keys have been cleared from memory. Run `rocketpool wallet unlock` to resume transactions.")
+		}
+		t.healthChecker.SetStatus(health.Component_Wallet, wallet.ErrWalletLocked)
+		return nil
+	}
+
+	// Reload it once it's been unlocked
+	if !t.w.IsInitialized() {
+		if err := t.w.Reload(); err != nil {
+			t.healthChecker.SetStatus(health.Component_Wallet, err)
+			return fmt.Errorf("error reloading the unlocked wallet: %w", err)
+		}
+		if expiry, active := t.pm.GetSessionExpiry(); active {
+			t.log.Printlnf("The wallet has been unlocked until %s.", expiry.Format(time.RFC1123))
+		} else {
+			t.log.Println("The wallet has been unlocked.")
+		}
+	}
+	t.healthChecker.SetStatus(health.Component_Wallet, nil)
+	return nil
+
+}
@@ -10,11 +10,13 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/rocket-pool/smartnode/rocketpool/node/collectors"
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/rpcusage"
+	"github.com/rocket-pool/smartnode/shared/services/tasks"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
 	"github.com/urfave/cli"
 )
 
-func runMetricsServer(c *cli.Context, logger log.ColorLogger, stateLocker *collectors.StateLocker) error {
+func runMetricsServer(c *cli.Context, logger log.ColorLogger, stateLocker *collectors.StateLocker, taskRecorder *tasks.Recorder, livenessCollector *collectors.LivenessCollector, systemCollector *collectors.SystemCollector) error {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -66,6 +68,7 @@ func runMetricsServer(c *cli.Context, logger log.ColorLogger, stateLocker *colle
 	trustedNodeCollector := collectors.NewTrustedNodeCollector(rp, bc, nodeAccount.Address, cfg, stateLocker)
 	beaconCollector := collectors.NewBeaconCollector(rp, bc, ec, nodeAccount.Address, stateLocker)
 	smoothingPoolCollector := collectors.NewSmoothingPoolCollector(rp, ec, stateLocker)
+	aprCollector := collectors.NewAprCollector(rp, nodeAccount.Address, cfg, stateLocker)
 
 	// Set up Prometheus
 	registry := prometheus.NewRegistry()
@@ -78,6 +81,11 @@ func runMetricsServer(c *cli.Context, logger log.ColorLogger, stateLocker *colle
 	registry.MustRegister(trustedNodeCollector)
 	registry.MustRegister(beaconCollector)
 	registry.MustRegister(smoothingPoolCollector)
+	registry.MustRegister(aprCollector)
+	registry.MustRegister(tasks.NewTaskCollector(taskRecorder))
+	registry.MustRegister(rpcusage.NewUsageCollector())
+	registry.MustRegister(livenessCollector)
+	registry.MustRegister(systemCollector)
 
 	// Set up snapshot checking if enabled
 	votingId := cfg.Smartnode.GetVotingSnapshotID()
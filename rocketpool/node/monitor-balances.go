@@ -0,0 +1,195 @@
+package node
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/history"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// How often to rebuild the model of typical balance changes from the node history, which is only sampled hourly
+var balanceModelInterval, _ = time.ParseDuration("1h")
+
+// How much of the node history the model of typical balance changes is built from
+var balanceModelLookback, _ = time.ParseDuration("720h")
+
+// How many standard deviations above the typical hourly outflow a drop has to be to count as an anomaly
+const balanceAnomalyDeviations float64 = 4
+
+// RPL outflows smaller than this are ignored, so rounding in the balances doesn't raise alerts
+const rplAnomalyDust float64 = 0.001
+
+// The node's balances at one point, along with what explains changes in them
+type balanceObservation struct {
+	block      uint64
+	ethBalance float64
+	rplBalance float64
+
+	// The ETH bonded to the node's minipools, which goes up when a deposit spends ETH from the wallet
+	bondedEth float64
+
+	// The node's staked RPL, which goes up when staking spends RPL from the wallet
+	rplStake float64
+}
+
+// Monitor node balances task
+type monitorBalances struct {
+	c            *cli.Context
+	log          log.ColorLogger
+	alerts       *alerting.AlertManager
+	store        *history.Store
+	nodeAddress  common.Address
+	ethThreshold float64
+
+	// The model of typical hourly outflows, built from the node history
+	typicalEthOutflow float64
+	typicalRplOutflow float64
+	modelTime         time.Time
+
+	// The balances seen on the previous run
+	previous *balanceObservation
+}
+
+// Create monitor node balances task
+func newMonitorBalances(c *cli.Context, logger log.ColorLogger, alerts *alerting.AlertManager, nodeAddress common.Address) (*monitorBalances, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &monitorBalances{
+		c:            c,
+		log:          logger,
+		alerts:       alerts,
+		store:        history.NewStore(cfg.Smartnode.GetNodeHistoryPath()),
+		nodeAddress:  nodeAddress,
+		ethThreshold: cfg.Alerting.BalanceAnomalyThreshold.Value.(float64),
+	}, nil
+
+}
+
+// Compare the node's balances with the previous run, and raise an alert if more ETH or RPL left the node wallet than usual
+// without a deposit or RPL stake to explain it
+func (t *monitorBalances) run(state *state.NetworkState) error {
+
+	// Check if the alert is enabled
+	if !t.alerts.IsEnabled() || t.ethThreshold == 0 {
+		return nil
+	}
+
+	// Get the current balances
+	nd, exists := state.NodeDetailsByAddress[t.nodeAddress]
+	if !exists {
+		return fmt.Errorf("node %s was not found in the network state", t.nodeAddress.Hex())
+	}
+	bondedEth := big.NewInt(0)
+	for _, mpd := range state.MinipoolDetailsByNode[t.nodeAddress] {
+		if !mpd.Finalised {
+			bondedEth.Add(bondedEth, mpd.NodeDepositBalance)
+		}
+	}
+	current := &balanceObservation{
+		block:      state.ElBlockNumber,
+		ethBalance: eth.WeiToEth(nd.BalanceETH),
+		rplBalance: eth.WeiToEth(nd.BalanceRPL),
+		bondedEth:  eth.WeiToEth(bondedEth),
+		rplStake:   eth.WeiToEth(nd.RplStake),
+	}
+	previous := t.previous
+	t.previous = current
+	if previous == nil || current.block <= previous.block {
+		return nil
+	}
+
+	// Rebuild the model of typical outflows
+	if time.Since(t.modelTime) >= balanceModelInterval {
+		if err := t.updateModel(); err != nil {
+			return err
+		}
+	}
+
+	// Get the outflows that aren't explained by deposits or staking
+	ethOutflow := previous.ethBalance - current.ethBalance - math.Max(current.bondedEth-previous.bondedEth, 0)
+	rplOutflow := previous.rplBalance - current.rplBalance - math.Max(current.rplStake-previous.rplStake, 0)
+
+	// Check them against the model
+	ethLimit := math.Max(t.typicalEthOutflow, t.ethThreshold)
+	if ethOutflow > ethLimit {
+		t.alerts.Raise(alerting.Alert{
+			Rule:     alerting.Rule_BalanceAnomaly,
+			Subject:  "eth",
+			Severity: alerting.Severity_Critical,
+			Title:    "Unexpected ETH outflow from the node wallet",
+			Message:  fmt.Sprintf("%.6f ETH left the node wallet between blocks %d and %d, which is more than the %.6f ETH it usually spends in an hour and isn't explained by a minipool deposit. If you or one of your automations didn't send it, your node wallet's key may be compromised.", ethOutflow, previous.block, current.block, ethLimit),
+		})
+	}
+	rplLimit := math.Max(t.typicalRplOutflow, rplAnomalyDust)
+	if rplOutflow > rplLimit {
+		t.alerts.Raise(alerting.Alert{
+			Rule:     alerting.Rule_BalanceAnomaly,
+			Subject:  "rpl",
+			Severity: alerting.Severity_Critical,
+			Title:    "Unexpected RPL transfer from the node wallet",
+			Message:  fmt.Sprintf("%.6f RPL left the node wallet between blocks %d and %d without being staked. If you or one of your automations didn't send it, your node wallet's key may be compromised.", rplOutflow, previous.block, current.block),
+		})
+	}
+	return nil
+
+}
+
+// Model the typical hourly outflows of ETH and RPL from the node history, as a number of standard deviations above their mean.
+// Hours where the node made a deposit are left out since their outflows are explained, and the ETH model also covers gas.
+func (t *monitorBalances) updateModel() error {
+	samples, err := t.store.Load(time.Now().Add(-balanceModelLookback))
+	if err != nil {
+		return fmt.Errorf("error loading node history: %w", err)
+	}
+	ethOutflows := []float64{}
+	rplOutflows := []float64{}
+	for i := 1; i < len(samples); i++ {
+		previous := samples[i-1]
+		current := samples[i]
+		hours := math.Max(current.Time.Sub(previous.Time).Hours(), 1)
+		if current.ActiveMinipools <= previous.ActiveMinipools {
+			ethOutflows = append(ethOutflows, math.Max(previous.EthBalance-current.EthBalance, 0)/hours)
+		}
+		rplOutflow := previous.RplBalance - current.RplBalance - math.Max(current.RplStake-previous.RplStake, 0)
+		rplOutflows = append(rplOutflows, math.Max(rplOutflow, 0)/hours)
+	}
+	t.typicalEthOutflow = getTypicalOutflow(ethOutflows)
+	t.typicalRplOutflow = getTypicalOutflow(rplOutflows)
+	t.modelTime = time.Now()
+	t.log.Printlnf("Typical hourly outflows from the node wallet are up to %.6f ETH and %.6f RPL.", t.typicalEthOutflow, t.typicalRplOutflow)
+	return nil
+}
+
+// Get the largest outflow that's still typical for a set of hourly outflows
+func getTypicalOutflow(outflows []float64) float64 {
+	if len(outflows) < 2 {
+		return 0
+	}
+	mean := 0.0
+	for _, outflow := range outflows {
+		mean += outflow
+	}
+	mean /= float64(len(outflows))
+	variance := 0.0
+	for _, outflow := range outflows {
+		variance += (outflow - mean) * (outflow - mean)
+	}
+	variance /= float64(len(outflows) - 1)
+	return mean + balanceAnomalyDeviations*math.Sqrt(variance)
+}
@@ -0,0 +1,223 @@
+package node
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/rocketpool/node/collectors"
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/tasks"
+	"github.com/rocket-pool/smartnode/shared/services/uptime"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Config
+var livenessCheckInterval, _ = time.ParseDuration("1m")
+
+// Monitor validator liveness task
+type monitorLiveness struct {
+	c           *cli.Context
+	log         log.ColorLogger
+	errLog      log.ColorLogger
+	bc          beacon.Client
+	eth2Config  beacon.Eth2Config
+	uptime      *uptime.Store
+	stateLocker *collectors.StateLocker
+	alerts      *alerting.AlertManager
+	coll        *collectors.LivenessCollector
+	nodeAddress common.Address
+	threshold   uint64
+	lastEpoch   uint64
+}
+
+// Create monitor validator liveness task
+func newMonitorLiveness(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, stateLocker *collectors.StateLocker, alerts *alerting.AlertManager, coll *collectors.LivenessCollector, nodeAddress common.Address) (*monitorLiveness, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+	eth2Config, err := bc.GetEth2Config()
+	if err != nil {
+		return nil, fmt.Errorf("error getting Beacon config: %w", err)
+	}
+	uptimeStore, err := uptime.LoadStore(cfg.Smartnode.GetValidatorUptimePath())
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &monitorLiveness{
+		c:           c,
+		log:         logger,
+		errLog:      errorLogger,
+		bc:          bc,
+		eth2Config:  eth2Config,
+		uptime:      uptimeStore,
+		stateLocker: stateLocker,
+		alerts:      alerts,
+		coll:        coll,
+		nodeAddress: nodeAddress,
+		threshold:   cfg.Alerting.MissedAttestations.Value.(uint64),
+	}, nil
+
+}
+
+// Check the liveness of the node's validators once per epoch in the background.
+// Beacon nodes only keep liveness data for the current and previous epochs, so this can't wait for the main task loop.
+func (t *monitorLiveness) start(guard *tasks.CrashGuard) {
+	go func() {
+		for {
+			if err := guard.Run("monitor-liveness", t.run); err != nil {
+				t.errLog.Println(err)
+			}
+			time.Sleep(livenessCheckInterval)
+		}
+	}()
+}
+
+// An alert to raise or clear once the liveness stats have been updated
+type livenessAlert struct {
+	alert  alerting.Alert
+	raised bool
+}
+
+// Check the liveness of the node's validators in every completed epoch since the last one that was checked
+func (t *monitorLiveness) run() error {
+
+	// Wait for the first network state
+	state := t.stateLocker.GetState()
+	if state == nil {
+		return nil
+	}
+
+	// Get the latest completed epoch
+	head, err := t.bc.GetBeaconHead()
+	if err != nil {
+		return fmt.Errorf("error getting Beacon head: %w", err)
+	}
+	if head.Epoch == 0 {
+		return nil
+	}
+	latestEpoch := head.Epoch - 1
+	if latestEpoch <= t.lastEpoch {
+		return nil
+	}
+	startEpoch := t.lastEpoch + 1
+	if t.lastEpoch == 0 {
+		startEpoch = latestEpoch
+	}
+
+	// Check each epoch that hasn't been checked yet
+	for epoch := startEpoch; epoch <= latestEpoch; epoch++ {
+		alerts, err := t.checkEpoch(state, epoch)
+		if err != nil {
+			if epoch == latestEpoch {
+				return err
+			}
+			// Beacon nodes may have pruned the liveness data of older epochs, so don't let them block the newer ones
+			t.log.Warnf("WARNING: couldn't check the liveness of epoch %d, skipping it: %s", epoch, err.Error())
+			t.lastEpoch = epoch
+			continue
+		}
+		t.lastEpoch = epoch
+
+		// Send the alerts without holding the collector lock, since notifiers can take a while
+		for _, alert := range alerts {
+			t.alerts.Update(alert.alert, alert.raised)
+		}
+	}
+
+	// Save the uptime spans
+	if err := t.uptime.Save(); err != nil {
+		return fmt.Errorf("error saving validator uptime: %w", err)
+	}
+	return nil
+
+}
+
+// Check the liveness of the node's validators in an epoch and update their stats, returning the alerts to raise or clear
+func (t *monitorLiveness) checkEpoch(networkState *state.NetworkState, epoch uint64) ([]livenessAlert, error) {
+
+	// Get the node's active validators
+	pubkeys := map[string]types.ValidatorPubkey{}
+	indices := []string{}
+	for _, mpd := range networkState.MinipoolDetailsByNode[t.nodeAddress] {
+		validator, exists := networkState.ValidatorDetails[mpd.Pubkey]
+		if !exists || validator.ActivationEpoch > epoch || validator.ExitEpoch <= epoch {
+			continue
+		}
+		pubkeys[validator.Index] = mpd.Pubkey
+		indices = append(indices, validator.Index)
+	}
+	if len(indices) == 0 {
+		return nil, nil
+	}
+
+	// Get the liveness of each validator
+	liveness, err := t.bc.GetValidatorLiveness(indices, epoch)
+	if err != nil {
+		return nil, fmt.Errorf("error getting validator liveness for epoch %d: %w", epoch, err)
+	}
+
+	// Get the time span of the epoch
+	epochStart := time.Unix(int64(t.eth2Config.GenesisTime+epoch*t.eth2Config.SecondsPerEpoch), 0)
+	epochEnd := epochStart.Add(time.Duration(t.eth2Config.SecondsPerEpoch) * time.Second)
+
+	// Update the stats
+	alerts := []livenessAlert{}
+	t.coll.UpdateLock.Lock()
+	defer t.coll.UpdateLock.Unlock()
+	for _, index := range indices {
+		t.uptime.RecordEpoch(index, pubkeys[index].Hex(), epoch, epochStart, epochEnd, liveness[index])
+
+		stats, exists := t.coll.Stats[index]
+		if !exists {
+			stats = &collectors.ValidatorLivenessStats{}
+			t.coll.Stats[index] = stats
+		}
+
+		if liveness[index] {
+			stats.LiveEpochs++
+			stats.ConsecutiveMisses = 0
+		} else {
+			stats.MissedEpochs++
+			stats.ConsecutiveMisses++
+			t.log.Printlnf("Validator %s (%s) missed its attestation in epoch %d (%d in a row).", index, pubkeys[index].Hex(), epoch, stats.ConsecutiveMisses)
+		}
+		stats.LastEpoch = epoch
+
+		// Raise or clear the alert
+		if t.threshold > 0 {
+			message := fmt.Sprintf("Validator %s (%s) has missed its attestations for %d epochs in a row as of epoch %d.", index, pubkeys[index].Hex(), stats.ConsecutiveMisses, epoch)
+			if stats.ConsecutiveMisses == 0 {
+				message = fmt.Sprintf("Validator %s (%s) is attesting again as of epoch %d.", index, pubkeys[index].Hex(), epoch)
+			}
+			alerts = append(alerts, livenessAlert{
+				alert: alerting.Alert{
+					Rule:     alerting.Rule_ValidatorOffline,
+					Subject:  pubkeys[index].Hex(),
+					Severity: alerting.Severity_Warning,
+					Title:    fmt.Sprintf("Validator %s offline", index),
+					Message:  message,
+				},
+				raised: stats.ConsecutiveMisses >= t.threshold,
+			})
+		}
+	}
+	t.coll.LatestEpoch = float64(epoch)
+	return alerts, nil
+
+}
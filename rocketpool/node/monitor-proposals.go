@@ -0,0 +1,314 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/rocketpool/node/collectors"
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/mevboost"
+	"github.com/rocket-pool/smartnode/shared/services/schedule"
+	"github.com/rocket-pool/smartnode/shared/services/tasks"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Config
+const (
+	// How many slots to wait after a proposal before inspecting it, so the block has had time to arrive
+	proposalCheckDelay uint64 = 2
+
+	// How many slots to keep retrying a proposal that couldn't be inspected before giving up on it
+	proposalRetryLimit uint64 = 64
+)
+
+var proposalCheckInterval, _ = time.ParseDuration("1m")
+
+// A proposal duty of one of the node's validators that hasn't been inspected yet
+type proposalDuty struct {
+	index    string
+	pubkey   types.ValidatorPubkey
+	minipool common.Address
+}
+
+// Monitor proposals task
+type monitorProposals struct {
+	c           *cli.Context
+	log         log.ColorLogger
+	errLog      log.ColorLogger
+	cfg         *config.RocketPoolConfig
+	bc          beacon.Client
+	ec          *services.ExecutionClientManager
+	eth2Config  beacon.Eth2Config
+	stateLocker *collectors.StateLocker
+	alerts      *alerting.AlertManager
+	nodeAddress common.Address
+	history     *mevboost.ProposalHistory
+	scheduler   *schedule.Scheduler
+
+	// Proposer duties are only available for the current epoch, so they're collected as the epochs go by and inspected once their slots pass
+	duties    map[uint64]proposalDuty
+	nextEpoch uint64
+}
+
+// Create monitor proposals task
+func newMonitorProposals(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, stateLocker *collectors.StateLocker, alerts *alerting.AlertManager, scheduler *schedule.Scheduler, nodeAddress common.Address) (*monitorProposals, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+	eth2Config, err := bc.GetEth2Config()
+	if err != nil {
+		return nil, fmt.Errorf("error getting Beacon config: %w", err)
+	}
+	history, err := mevboost.LoadProposalHistory(cfg.Smartnode.GetProposalHistoryPath())
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &monitorProposals{
+		c:           c,
+		log:         logger,
+		errLog:      errorLogger,
+		cfg:         cfg,
+		bc:          bc,
+		ec:          ec,
+		eth2Config:  eth2Config,
+		stateLocker: stateLocker,
+		alerts:      alerts,
+		nodeAddress: nodeAddress,
+		history:     history,
+		scheduler:   scheduler,
+		duties:      map[uint64]proposalDuty{},
+	}, nil
+
+}
+
+// Check the node's proposals in the background.
+// Proposer duties have to be collected every epoch, which is more often than the main task loop runs.
+func (t *monitorProposals) start(guard *tasks.CrashGuard) {
+	go func() {
+		for {
+			if err := guard.Run("monitor-proposals", t.run); err != nil {
+				t.errLog.Println(err)
+			}
+			time.Sleep(proposalCheckInterval)
+		}
+	}()
+}
+
+// Collect the node's proposer duties for the current epoch and inspect the proposals whose slots have passed
+func (t *monitorProposals) run() error {
+
+	// Wait for the first network state
+	state := t.stateLocker.GetState()
+	if state == nil {
+		return nil
+	}
+	now := uint64(time.Now().Unix())
+	if now < t.eth2Config.GenesisTime {
+		return nil
+	}
+	currentSlot := (now - t.eth2Config.GenesisTime) / t.eth2Config.SecondsPerSlot
+	currentEpoch := currentSlot / t.eth2Config.SlotsPerEpoch
+
+	// Collect the duties for the current epoch
+	if currentEpoch >= t.nextEpoch {
+		validators := map[string]proposalDuty{}
+		indices := []string{}
+		for _, mpd := range state.MinipoolDetailsByNode[t.nodeAddress] {
+			validator, exists := state.ValidatorDetails[mpd.Pubkey]
+			if !exists || !validator.Exists || validator.ActivationEpoch > currentEpoch || validator.ExitEpoch <= currentEpoch {
+				continue
+			}
+			validators[validator.Index] = proposalDuty{
+				index:    validator.Index,
+				pubkey:   mpd.Pubkey,
+				minipool: mpd.MinipoolAddress,
+			}
+			indices = append(indices, validator.Index)
+		}
+		if len(indices) > 0 {
+			slots, err := t.bc.GetValidatorProposerSlots(indices, currentEpoch)
+			if err != nil {
+				return fmt.Errorf("error getting proposer duties for epoch %d: %w", currentEpoch, err)
+			}
+			for slot, index := range slots {
+				t.duties[slot] = validators[index]
+				t.log.Printlnf("Validator %s will propose in slot %d.", validators[index].pubkey.Hex(), slot)
+				t.scheduler.SetDeadline(fmt.Sprintf("the proposal in slot %d", slot), time.Unix(int64(t.eth2Config.GenesisTime+slot*t.eth2Config.SecondsPerSlot), 0))
+			}
+		}
+		t.nextEpoch = currentEpoch + 1
+	}
+
+	// Inspect the proposals whose slots have passed
+	for slot, duty := range t.duties {
+		if slot+proposalCheckDelay > currentSlot {
+			continue
+		}
+		proposal, err := t.inspectProposal(slot, duty)
+		if err != nil {
+			if slot+proposalRetryLimit < currentSlot {
+				t.log.Warnf("WARNING: Giving up on the proposal in slot %d: %s", slot, err.Error())
+				delete(t.duties, slot)
+			} else {
+				t.log.Warnf("WARNING: Couldn't check the proposal in slot %d, will try again: %s", slot, err.Error())
+			}
+			continue
+		}
+		delete(t.duties, slot)
+		if proposal == nil {
+			continue
+		}
+
+		t.history.Add(*proposal)
+		if err := t.history.Save(t.cfg.Smartnode.GetProposalHistoryPath()); err != nil {
+			return err
+		}
+		t.updateAlerts(*proposal)
+	}
+
+	return nil
+
+}
+
+// Find where the block for one of the node's proposals came from and what it earned.
+// Returns nil if the slot turned out to belong to a different validator.
+func (t *monitorProposals) inspectProposal(slot uint64, duty proposalDuty) (*mevboost.Proposal, error) {
+	proposal := &mevboost.Proposal{
+		Slot:            slot,
+		Time:            time.Unix(int64(t.eth2Config.GenesisTime+slot*t.eth2Config.SecondsPerSlot), 0),
+		Pubkey:          duty.pubkey,
+		Minipool:        duty.minipool,
+		ExpectedBuilder: t.expectsBuilder(duty.minipool),
+	}
+
+	// Get the block
+	block, exists, err := t.bc.GetBeaconBlock(strconv.FormatUint(slot, 10))
+	if err != nil {
+		return nil, fmt.Errorf("error getting Beacon block: %w", err)
+	}
+	if !exists {
+		proposal.Source = mevboost.ProposalSource_Missed
+		t.log.Warnf("WARNING: Validator %s missed its proposal in slot %d.", duty.pubkey.Hex(), slot)
+		return proposal, nil
+	}
+	if block.ProposerIndex != duty.index {
+		t.log.Printlnf("The block in slot %d was proposed by validator %s instead of %s, so it won't be recorded.", slot, block.ProposerIndex, duty.index)
+		return nil, nil
+	}
+	if !block.HasExecutionPayload {
+		return nil, nil
+	}
+	proposal.BlockNumber = block.ExecutionBlockNumber
+	proposal.BlockHash = block.ExecutionBlockHash
+	proposal.FeeRecipient = block.FeeRecipient
+
+	// Check if a relay delivered it
+	payload, err := mevboost.FindDeliveredPayload(t.cfg, slot, block.ExecutionBlockHash)
+	if err != nil {
+		return nil, err
+	}
+	if payload != nil {
+		proposal.Source = mevboost.ProposalSource_Relay
+		proposal.Relay = payload.Relay.Name
+		proposal.BidValue = payload.Value
+	} else {
+		proposal.Source = mevboost.ProposalSource_Local
+	}
+
+	// The realized reward is what the fee recipient received in the block
+	proposal.Reward, err = t.getFeeRecipientIncrease(block.FeeRecipient, block.ExecutionBlockNumber)
+	if err != nil {
+		t.log.Warnf("WARNING: Couldn't get the reward for the proposal in slot %d: %s", slot, err.Error())
+	}
+
+	t.log.Printlnf("Validator %s proposed block %d in slot %d (source: %s).", duty.pubkey.Hex(), block.ExecutionBlockNumber, slot, proposal.Source)
+	return proposal, nil
+}
+
+// Check if a minipool is supposed to get its blocks from MEV-Boost's builders
+func (t *monitorProposals) expectsBuilder(minipoolAddress common.Address) bool {
+	if t.cfg.EnableMevBoost.Value != true {
+		return false
+	}
+	settings, err := config.LoadBlockBuildingSettings(t.cfg.Smartnode.GetBlockBuildingSettingsPath())
+	if err != nil {
+		t.log.Warnf("WARNING: Couldn't load the block building settings: %s", err.Error())
+		return true
+	}
+	return t.cfg.UsesBuilder(settings.Minipools[minipoolAddress])
+}
+
+// Get how much an address's balance went up in a block
+func (t *monitorProposals) getFeeRecipientIncrease(feeRecipient common.Address, blockNumber uint64) (*big.Int, error) {
+	if blockNumber == 0 {
+		return nil, fmt.Errorf("the block doesn't have a parent")
+	}
+	after, err := t.ec.BalanceAt(context.Background(), feeRecipient, big.NewInt(int64(blockNumber)))
+	if err != nil {
+		return nil, fmt.Errorf("error getting fee recipient balance at block %d: %w", blockNumber, err)
+	}
+	before, err := t.ec.BalanceAt(context.Background(), feeRecipient, big.NewInt(int64(blockNumber-1)))
+	if err != nil {
+		return nil, fmt.Errorf("error getting fee recipient balance at block %d: %w", blockNumber-1, err)
+	}
+	return big.NewInt(0).Sub(after, before), nil
+}
+
+// Alert when MEV-Boost fails to provide a block, so the validator falls back to building one locally or misses the proposal
+func (t *monitorProposals) updateAlerts(proposal mevboost.Proposal) {
+	fallbackAlert := alerting.Alert{
+		Rule:     alerting.Rule_MevLocalFallback,
+		Severity: alerting.Severity_Warning,
+		Title:    "A proposal fell back to a locally built block",
+		Message:  fmt.Sprintf("Validator 0x%s (minipool %s) was supposed to get its block for slot %d from MEV-Boost, but built it locally instead. Check that MEV-Boost is running and that your relays are up with `rocketpool node mev-status`.", proposal.Pubkey.Hex(), proposal.Minipool.Hex(), proposal.Slot),
+	}
+	missedAlert := alerting.Alert{
+		Rule:     alerting.Rule_ProposalMissed,
+		Severity: alerting.Severity_Critical,
+		Title:    "A proposal was missed",
+		Message:  fmt.Sprintf("Validator 0x%s (minipool %s) didn't produce a block for slot %d.", proposal.Pubkey.Hex(), proposal.Minipool.Hex(), proposal.Slot),
+	}
+	if proposal.ExpectedBuilder {
+		missedAlert.Message += " It was supposed to get the block from MEV-Boost, so your Validator client may be stuck waiting for MEV-Boost instead of falling back to building the block locally."
+	}
+
+	switch proposal.Source {
+	case mevboost.ProposalSource_Missed:
+		t.alerts.Raise(missedAlert)
+
+	case mevboost.ProposalSource_Local:
+		t.alerts.Resolve(missedAlert)
+		if proposal.ExpectedBuilder {
+			t.log.Warnf("WARNING: Validator %s built its block for slot %d locally instead of getting it from MEV-Boost.", proposal.Pubkey.Hex(), proposal.Slot)
+			t.alerts.Raise(fallbackAlert)
+		}
+
+	case mevboost.ProposalSource_Relay:
+		t.alerts.Resolve(missedAlert)
+		fallbackAlert.Message = fmt.Sprintf("Validator 0x%s got its block for slot %d from the %s relay.", proposal.Pubkey.Hex(), proposal.Slot, proposal.Relay)
+		t.alerts.Resolve(fallbackAlert)
+	}
+}
@@ -0,0 +1,206 @@
+package node
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+// Settings
+const queueDepositRateDays uint64 = 7
+
+// How often to recalculate the rate of deposits into the deposit pool, which needs a scan of the deposit events
+var queueDepositRateInterval, _ = time.ParseDuration("1h")
+
+// Monitor queue task
+type monitorQueue struct {
+	c                    *cli.Context
+	log                  log.ColorLogger
+	cfg                  *config.RocketPoolConfig
+	rp                   *rocketpool.RocketPool
+	alerts               *alerting.AlertManager
+	nodeAddress          common.Address
+	depositPoolThreshold float64
+	queueWaitChange      float64
+
+	// The node's minipools that were in the queue on the last run
+	queued map[common.Address]bool
+
+	// The estimated wait of each queued minipool when it was last alerted about
+	notifiedWaits map[common.Address]time.Duration
+
+	depositRate     float64
+	depositRateTime time.Time
+}
+
+// Create monitor queue task
+func newMonitorQueue(c *cli.Context, logger log.ColorLogger, alerts *alerting.AlertManager, nodeAddress common.Address) (*monitorQueue, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &monitorQueue{
+		c:                    c,
+		log:                  logger,
+		cfg:                  cfg,
+		rp:                   rp,
+		alerts:               alerts,
+		nodeAddress:          nodeAddress,
+		depositPoolThreshold: cfg.Alerting.DepositPoolThreshold.Value.(float64),
+		queueWaitChange:      cfg.Alerting.QueueWaitChange.Value.(float64) / 100,
+		notifiedWaits:        map[common.Address]time.Duration{},
+	}, nil
+
+}
+
+// Check the deposit pool and the node's minipools in the queue
+func (t *monitorQueue) run(state *state.NetworkState) error {
+
+	// Check if alerting is enabled
+	if !t.alerts.IsEnabled() {
+		return nil
+	}
+
+	t.checkDepositPool(state)
+	t.checkAssignments(state)
+	if err := t.checkQueueWaits(state); err != nil {
+		return fmt.Errorf("error estimating queue waits: %w", err)
+	}
+	return nil
+
+}
+
+// Raise an alert while the deposit pool has enough spare ETH for a new minipool to skip the queue
+func (t *monitorQueue) checkDepositPool(state *state.NetworkState) {
+	if t.depositPoolThreshold == 0 {
+		return
+	}
+	details := state.NetworkDetails
+	spareEth := eth.WeiToEth(details.DepositPoolBalance) - eth.WeiToEth(details.QueueCapacity.Total)
+	t.alerts.Update(alerting.Alert{
+		Rule:     alerting.Rule_DepositPoolCapacity,
+		Severity: alerting.Severity_Info,
+		Title:    "Deposit pool has room for new minipools",
+		Message:  fmt.Sprintf("The deposit pool has %.2f ETH left over after matching every minipool in the queue, which is above your threshold of %.2f ETH. A new minipool could be matched without waiting in the queue.", spareEth, t.depositPoolThreshold),
+	}, spareEth >= t.depositPoolThreshold)
+}
+
+// Send an alert for each of the node's minipools that left the queue since the last run
+func (t *monitorQueue) checkAssignments(state *state.NetworkState) {
+	queued := map[common.Address]bool{}
+	for _, mpd := range state.MinipoolDetailsByNode[t.nodeAddress] {
+		if mpd.Status == types.Initialized {
+			queued[mpd.MinipoolAddress] = true
+			continue
+		}
+		if t.queued[mpd.MinipoolAddress] && mpd.Status == types.Prelaunch {
+			t.alerts.Raise(alerting.Alert{
+				Rule:     alerting.Rule_MinipoolAssigned,
+				Subject:  mpd.MinipoolAddress.Hex(),
+				Severity: alerting.Severity_Info,
+				Title:    "Minipool assigned",
+				Message:  fmt.Sprintf("Minipool %s has been matched with ETH from the deposit pool and left the queue. The node will stake it once the scrub check has passed.", mpd.MinipoolAddress.Hex()),
+			})
+		}
+	}
+	if t.queued == nil {
+		t.log.Printlnf("The node has %d minipool(s) in the queue.", len(queued))
+	}
+	t.queued = queued
+	for address := range t.notifiedWaits {
+		if !queued[address] {
+			delete(t.notifiedWaits, address)
+		}
+	}
+}
+
+// Send an alert when the estimated wait of one of the node's queued minipools changes significantly
+func (t *monitorQueue) checkQueueWaits(state *state.NetworkState) error {
+	if t.queueWaitChange == 0 || len(t.queued) == 0 {
+		return nil
+	}
+
+	// Update the deposit rate
+	if time.Since(t.depositRateTime) > queueDepositRateInterval {
+		eventLogInterval, err := t.cfg.GetEventLogInterval()
+		if err != nil {
+			return err
+		}
+		t.depositRate, err = rputils.GetTrailingDepositRate(t.rp, queueDepositRateDays, big.NewInt(int64(eventLogInterval)))
+		if err != nil {
+			return err
+		}
+		t.depositRateTime = time.Now()
+	}
+
+	details := state.NetworkDetails
+	opts := &bind.CallOpts{
+		BlockNumber: big.NewInt(0).SetUint64(state.ElBlockNumber),
+	}
+	for address := range t.queued {
+		position, err := minipool.GetQueuePositionOfMinipool(t.rp, address, opts)
+		if err != nil {
+			return err
+		}
+		if position <= 0 {
+			continue
+		}
+		wait, ok := rputils.EstimateQueueWait(uint64(position), details.QueueLength.Uint64(), details.QueueCapacity.Total, details.DepositPoolBalance, t.depositRate)
+		if !ok {
+			continue
+		}
+
+		// The first estimate is only recorded; alerts are for changes to it
+		lastWait, exists := t.notifiedWaits[address]
+		if !exists {
+			t.notifiedWaits[address] = wait
+			continue
+		}
+		change := math.Abs(wait.Hours()-lastWait.Hours()) / math.Max(lastWait.Hours(), 1)
+		if change < t.queueWaitChange {
+			continue
+		}
+		t.notifiedWaits[address] = wait
+		t.alerts.Raise(alerting.Alert{
+			Rule:     alerting.Rule_QueueWaitChanged,
+			Subject:  fmt.Sprintf("%s/%d", address.Hex(), int64(wait.Hours())),
+			Severity: alerting.Severity_Info,
+			Title:    "Queue wait estimate changed",
+			Message:  fmt.Sprintf("Minipool %s is at position %d in the queue. Its estimated wait changed from %s to %s, based on the last %d days of deposits (%.2f ETH per day).", address.Hex(), position, formatQueueWait(lastWait), formatQueueWait(wait), queueDepositRateDays, t.depositRate),
+		})
+	}
+	return nil
+}
+
+// Format a queue wait estimate in days and hours
+func formatQueueWait(wait time.Duration) string {
+	hours := int64(wait.Hours())
+	if hours < 24 {
+		return fmt.Sprintf("%d hours", hours)
+	}
+	return fmt.Sprintf("%d days, %d hours", hours/24, hours%24)
+}
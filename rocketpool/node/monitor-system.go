@@ -0,0 +1,406 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/client"
+	"github.com/shirou/gopsutil/v3/disk"
+	"github.com/shirou/gopsutil/v3/mem"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/rocketpool/node/collectors"
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/sysmon"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Settings
+const (
+	chainDataMountPath string = "/ethclient"
+	bytesPerGB         uint64 = 1024 * 1024 * 1024
+)
+
+// How far back to look when estimating how quickly the disk is filling up
+var diskGrowthWindow, _ = time.ParseDuration("72h")
+
+// How often to measure the chain data volumes, since Docker has to walk them to get their size
+var chainDataInterval, _ = time.ParseDuration("1h")
+
+// How long to wait for the NTP server
+var ntpTimeout, _ = time.ParseDuration("5s")
+
+// How many head block ages to keep for the Beacon clock check; the freshest of them is the closest to the slot's real start
+const headAgeSamples int = 12
+
+// A free disk space sample used for the growth estimate
+type diskSample struct {
+	time time.Time
+	free uint64
+}
+
+// Monitor system resources task
+type monitorSystem struct {
+	c         *cli.Context
+	log       log.ColorLogger
+	cfg       *config.RocketPoolConfig
+	d         *client.Client
+	alerts    *alerting.AlertManager
+	collector *collectors.SystemCollector
+	samples   []diskSample
+	bc        beacon.Client
+
+	// How long after their slot started the recent head blocks were seen, by the system clock
+	headAges   []time.Duration
+	eth2Config *beacon.Eth2Config
+
+	// The latest chain data sizes
+	chainData     []sysmon.ChainDataUsage
+	chainDataTime time.Time
+}
+
+// Create monitor system resources task
+func newMonitorSystem(c *cli.Context, logger log.ColorLogger, alerts *alerting.AlertManager, collector *collectors.SystemCollector) (*monitorSystem, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+	var d *client.Client
+	if !cfg.IsNativeMode {
+		d, err = services.GetDocker(c)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Return task
+	return &monitorSystem{
+		c:         c,
+		log:       logger,
+		cfg:       cfg,
+		d:         d,
+		alerts:    alerts,
+		collector: collector,
+		samples:   []diskSample{},
+		bc:        bc,
+		headAges:  []time.Duration{},
+		chainData: []sysmon.ChainDataUsage{},
+	}, nil
+
+}
+
+// Record the disk, chain data and memory usage and the clock skew, and raise alerts if they cross the configured thresholds
+func (t *monitorSystem) run() error {
+
+	status := sysmon.SystemStatus{
+		Time:          time.Now(),
+		DiskPath:      t.getDataPath(),
+		DaysUntilFull: -1,
+		ChainData:     []sysmon.ChainDataUsage{},
+		Warnings:      []string{},
+	}
+
+	// Get the disk usage
+	usage, err := disk.Usage(status.DiskPath)
+	if err != nil {
+		return fmt.Errorf("error getting disk usage for %s: %w", status.DiskPath, err)
+	}
+	status.DiskTotal = usage.Total
+	status.DiskFree = usage.Free
+	t.estimateDiskGrowth(&status)
+
+	// Get the chain data sizes
+	if t.d != nil && time.Since(t.chainDataTime) > chainDataInterval {
+		chainData, err := t.getChainDataUsage()
+		if err != nil {
+			t.log.Warnf("WARNING: couldn't get chain data sizes: %s", err.Error())
+		} else {
+			t.chainData = chainData
+		}
+		t.chainDataTime = time.Now()
+	}
+	status.ChainData = t.chainData
+
+	// Get the memory usage
+	memory, err := mem.VirtualMemory()
+	if err != nil {
+		return fmt.Errorf("error getting memory usage: %w", err)
+	}
+	status.MemoryTotal = memory.Total
+	status.MemoryAvailable = memory.Available
+	status.MemoryUsedPercent = memory.UsedPercent
+
+	// Check the thresholds
+	t.checkDisk(&status)
+	t.checkMemory(&status)
+	t.checkClock(&status)
+	for _, warning := range status.Warnings {
+		t.log.Warnf("WARNING: %s", warning)
+	}
+
+	// Update the metrics and save the status for the CLI
+	t.collector.UpdateLock.Lock()
+	t.collector.Status = status
+	t.collector.UpdateLock.Unlock()
+	return status.Save(t.cfg.Smartnode.GetSystemStatusPath())
+
+}
+
+// Get the path of the Smartnode's data folder, which lives on the same disk as the chain data in standard setups
+func (t *monitorSystem) getDataPath() string {
+	if t.cfg.IsNativeMode {
+		return t.cfg.Smartnode.DataPath.Value.(string)
+	}
+	return config.DaemonDataPath
+}
+
+// Estimate how quickly the disk is filling up from the free space samples in the growth window
+func (t *monitorSystem) estimateDiskGrowth(status *sysmon.SystemStatus) {
+	t.samples = append(t.samples, diskSample{
+		time: status.Time,
+		free: status.DiskFree,
+	})
+	for len(t.samples) > 1 && status.Time.Sub(t.samples[0].time) > diskGrowthWindow {
+		t.samples = t.samples[1:]
+	}
+
+	oldest := t.samples[0]
+	elapsedDays := status.Time.Sub(oldest.time).Hours() / 24
+	if elapsedDays < 1.0/24 {
+		return
+	}
+	status.DiskUsagePerDay = (float64(oldest.free) - float64(status.DiskFree)) / elapsedDays
+	if status.DiskUsagePerDay > 0 {
+		status.DaysUntilFull = float64(status.DiskFree) / status.DiskUsagePerDay
+	}
+}
+
+// Get the size of the chain data volume of each locally-managed client
+func (t *monitorSystem) getChainDataUsage() ([]sysmon.ChainDataUsage, error) {
+	prefix := t.cfg.Smartnode.ProjectName.Value.(string)
+	clients := map[string]string{}
+	if t.cfg.ExecutionClientMode.Value.(cfgtypes.Mode) == cfgtypes.Mode_Local {
+		clients[prefix+"_"+config.Eth1ContainerName] = string(t.cfg.ExecutionClient.Value.(cfgtypes.ExecutionClient))
+	}
+	if t.cfg.ConsensusClientMode.Value.(cfgtypes.Mode) == cfgtypes.Mode_Local {
+		clients[prefix+"_"+config.Eth2ContainerName] = string(t.cfg.ConsensusClient.Value.(cfgtypes.ConsensusClient))
+	}
+	if len(clients) == 0 {
+		return []sysmon.ChainDataUsage{}, nil
+	}
+
+	// Get the volume mounted as the chain data folder in each client container
+	volumes := map[string]string{}
+	for containerName, clientName := range clients {
+		container, err := t.d.ContainerInspect(context.Background(), containerName)
+		if err != nil {
+			return nil, fmt.Errorf("error inspecting container %s: %w", containerName, err)
+		}
+		for _, mount := range container.Mounts {
+			if mount.Destination == chainDataMountPath && mount.Name != "" {
+				volumes[mount.Name] = clientName
+			}
+		}
+	}
+
+	// Get the volume sizes
+	diskUsage, err := t.d.DiskUsage(context.Background(), types.DiskUsageOptions{
+		Types: []types.DiskUsageObject{types.VolumeObject},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error getting Docker volume sizes: %w", err)
+	}
+	chainData := []sysmon.ChainDataUsage{}
+	for _, volume := range diskUsage.Volumes {
+		clientName, exists := volumes[volume.Name]
+		if !exists || volume.UsageData == nil || volume.UsageData.Size < 0 {
+			continue
+		}
+		chainData = append(chainData, sysmon.ChainDataUsage{
+			Client: clientName,
+			Volume: volume.Name,
+			Size:   uint64(volume.UsageData.Size),
+		})
+	}
+	return chainData, nil
+}
+
+// Raise an alert if the disk is low on space or is projected to fill up soon
+func (t *monitorSystem) checkDisk(status *sysmon.SystemStatus) {
+	freeThreshold := t.cfg.Alerting.DiskSpaceThreshold.Value.(uint64) * bytesPerGB
+	daysThreshold := float64(t.cfg.Alerting.DiskExhaustionDays.Value.(uint64))
+
+	lowSpace := status.DiskFree < freeThreshold
+	fillingUp := status.DaysUntilFull >= 0 && status.DaysUntilFull < daysThreshold
+	if lowSpace {
+		status.Warnings = append(status.Warnings, fmt.Sprintf("Only %.1f GB of disk space is left (below the %d GB threshold).", float64(status.DiskFree)/float64(bytesPerGB), freeThreshold/bytesPerGB))
+	}
+	if fillingUp {
+		status.Warnings = append(status.Warnings, fmt.Sprintf("At its current rate of %.1f GB per day, your disk will be full in %.1f days.", status.DiskUsagePerDay/float64(bytesPerGB), status.DaysUntilFull))
+	}
+
+	message := "Your disk has enough free space again."
+	if lowSpace || fillingUp {
+		status.PruneAdvice = sysmon.GetPruneAdvice(t.cfg)
+		message = fmt.Sprintf("%.1f GB of disk space is left. %s", float64(status.DiskFree)/float64(bytesPerGB), status.PruneAdvice)
+	}
+	t.alerts.Update(alerting.Alert{
+		Rule:     alerting.Rule_LowDiskSpace,
+		Severity: alerting.Severity_Warning,
+		Title:    "Low disk space",
+		Message:  message,
+	}, lowSpace || fillingUp)
+}
+
+// Raise an alert if the machine is running low on RAM
+func (t *monitorSystem) checkMemory(status *sysmon.SystemStatus) {
+	threshold := t.cfg.Alerting.MemoryThreshold.Value.(float64)
+	highUsage := status.MemoryUsedPercent > threshold
+	message := "Memory usage is back to normal."
+	if highUsage {
+		warning := fmt.Sprintf("%.1f%% of RAM is in use (above the %.0f%% threshold); your clients may be killed if the machine runs out of memory.", status.MemoryUsedPercent, threshold)
+		status.Warnings = append(status.Warnings, warning)
+		message = warning
+	}
+	t.alerts.Update(alerting.Alert{
+		Rule:     alerting.Rule_MemoryPressure,
+		Severity: alerting.Severity_Warning,
+		Title:    "High memory usage",
+		Message:  message,
+	}, highUsage)
+}
+
+// Raise an alert if the system clock has drifted from the NTP server or the Beacon Chain's slot times
+func (t *monitorSystem) checkClock(status *sysmon.SystemStatus) {
+	threshold := time.Duration(t.cfg.Alerting.ClockSkewThreshold.Value.(uint64)) * time.Millisecond
+	if threshold == 0 {
+		return
+	}
+
+	// Compare against the NTP server
+	server := t.cfg.Alerting.NtpServer.Value.(string)
+	if server != "" {
+		offset, err := sysmon.GetNtpOffset(server, ntpTimeout)
+		if err != nil {
+			t.log.Warnf("WARNING: couldn't check the system clock against NTP: %s", err.Error())
+		} else {
+			status.NtpServer = server
+			status.NtpOffset = &offset
+		}
+	}
+
+	// Compare against the Beacon Chain
+	offset, err := t.getBeaconOffset()
+	if err != nil {
+		t.log.Warnf("WARNING: couldn't check the system clock against the Beacon Chain: %s", err.Error())
+	} else {
+		status.BeaconOffset = offset
+	}
+
+	// Keep the alert as it is if the clock couldn't be checked at all
+	if status.NtpOffset == nil && status.BeaconOffset == nil {
+		return
+	}
+	warnings := []string{}
+	if status.NtpOffset != nil && absDuration(*status.NtpOffset) > threshold {
+		warnings = append(warnings, fmt.Sprintf("Your system clock is %s %s the NTP server %s (above the %s threshold).", absDuration(*status.NtpOffset).Round(time.Millisecond), describeOffset(*status.NtpOffset), server, threshold))
+	}
+	if status.BeaconOffset != nil && absDuration(*status.BeaconOffset) > threshold {
+		warnings = append(warnings, fmt.Sprintf("Your system clock is at least %s %s the Beacon Chain's slot times (above the %s threshold).", absDuration(*status.BeaconOffset).Round(time.Millisecond), describeOffset(*status.BeaconOffset), threshold))
+	}
+	status.Warnings = append(status.Warnings, warnings...)
+
+	message := "Your system clock is in sync again."
+	if len(warnings) > 0 {
+		message = strings.Join(warnings, " ") + " Validators with a skewed clock attest late or on the wrong data; make sure a time sync service such as chrony or systemd-timesyncd is running."
+	}
+	t.alerts.Update(alerting.Alert{
+		Rule:     alerting.Rule_ClockSkew,
+		Severity: alerting.Severity_Warning,
+		Title:    "System clock skew",
+		Message:  message,
+	}, len(warnings) > 0)
+}
+
+// Estimate how far ahead of the Beacon Chain's slot times the system clock is, from how long after its slot started each recent head block was seen.
+// A head block can't be seen before its slot starts, so a negative age means the clock is behind. Blocks normally arrive within the slot, so if even
+// the freshest recent head was more than a slot old, the clock is ahead. Returns nil if there aren't enough samples yet to tell.
+func (t *monitorSystem) getBeaconOffset() (*time.Duration, error) {
+	if t.eth2Config == nil {
+		eth2Config, err := t.bc.GetEth2Config()
+		if err != nil {
+			return nil, fmt.Errorf("error getting Beacon config: %w", err)
+		}
+		t.eth2Config = &eth2Config
+	}
+
+	// Heads from a client that's catching up don't say anything about the clock
+	syncStatus, err := t.bc.GetSyncStatus()
+	if err != nil {
+		return nil, fmt.Errorf("error getting Beacon client sync status: %w", err)
+	}
+	if syncStatus.Syncing {
+		return nil, nil
+	}
+	head, exists, err := t.bc.GetBeaconBlock("head")
+	if err != nil {
+		return nil, fmt.Errorf("error getting Beacon head block: %w", err)
+	}
+	if !exists {
+		return nil, nil
+	}
+	slotStart := time.Unix(int64(t.eth2Config.GenesisTime+head.Slot*t.eth2Config.SecondsPerSlot), 0)
+	t.headAges = append(t.headAges, time.Since(slotStart))
+	if len(t.headAges) > headAgeSamples {
+		t.headAges = t.headAges[1:]
+	}
+
+	freshest := t.headAges[0]
+	for _, age := range t.headAges {
+		if age < freshest {
+			freshest = age
+		}
+	}
+	slotTime := time.Duration(t.eth2Config.SecondsPerSlot) * time.Second
+	var offset time.Duration
+	switch {
+	case freshest < 0:
+		offset = freshest
+	case freshest > slotTime:
+		// Missed slots make heads look old too, so wait for a full set of samples before blaming the clock
+		if len(t.headAges) < headAgeSamples {
+			return nil, nil
+		}
+		offset = freshest - slotTime
+	}
+	return &offset, nil
+}
+
+// Get the absolute value of a duration
+func absDuration(duration time.Duration) time.Duration {
+	if duration < 0 {
+		return -duration
+	}
+	return duration
+}
+
+// Describe which way a clock offset goes
+func describeOffset(offset time.Duration) string {
+	if offset < 0 {
+		return "behind"
+	}
+	return "ahead of"
+}
@@ -1,6 +1,8 @@
 package node
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"math/big"
 	"net/http"
@@ -11,12 +13,23 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/fatih/color"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
 	"github.com/urfave/cli"
 
+	"github.com/rocket-pool/smartnode/rocketpool/api/server"
 	"github.com/rocket-pool/smartnode/rocketpool/node/collectors"
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/cmdqueue"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/events"
+	"github.com/rocket-pool/smartnode/shared/services/health"
+	"github.com/rocket-pool/smartnode/shared/services/profiling"
+	"github.com/rocket-pool/smartnode/shared/services/rpcusage"
+	"github.com/rocket-pool/smartnode/shared/services/schedule"
+	"github.com/rocket-pool/smartnode/shared/services/shutdown"
 	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/tasks"
 	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore/lighthouse"
 	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore/nimbus"
 	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore/prysm"
@@ -25,9 +38,12 @@ import (
 )
 
 // Config
-var tasksInterval, _ = time.ParseDuration("5m")
+var tasksInterval, _ = time.ParseDuration("10m")
+var activeTasksInterval, _ = time.ParseDuration("1m")
+var activeTasksWindow, _ = time.ParseDuration("10m")
 var taskCooldown, _ = time.ParseDuration("10s")
 var totalEffectiveStakeCooldown, _ = time.ParseDuration("1h")
+var maxTaskLoopAge, _ = time.ParseDuration("30m")
 
 const (
 	MaxConcurrentEth1Requests = 200
@@ -39,6 +55,31 @@ const (
 	PromoteMinipoolsColor        = color.FgMagenta
 	ReduceBondAmountColor        = color.FgHiBlue
 	DistributeMinipoolsColor     = color.FgHiGreen
+	CheckAlertsColor             = color.FgHiRed
+	MonitorLivenessColor         = color.FgCyan
+	RecordHistoryColor           = color.FgHiMagenta
+	MonitorSystemColor           = color.FgHiCyan
+	CheckUpdatesColor            = color.FgHiBlue
+	CheckMevRelaysColor          = color.FgHiMagenta
+	CheckDvtClusterColor         = color.FgHiYellow
+	CheckRescueNodeColor         = color.FgHiRed
+	ManageGraffitiColor          = color.FgHiGreen
+	ManageBlockBuildingColor     = color.FgWhite
+	MonitorProposalsColor        = color.FgHiMagenta
+	ApiServerColor               = color.FgHiBlue
+	PublishEventsColor           = color.FgBlue
+	RunAddonTasksColor           = color.FgHiCyan
+	CheckStrandedAssetsColor     = color.FgHiYellow
+	IndexActivityColor           = color.FgHiBlue
+	WatchContractUpgradesColor   = color.FgHiWhite
+	MonitorQueueColor            = color.FgGreen
+	PublishHeartbeatColor        = color.FgHiGreen
+	UpgradeDelegatesColor        = color.FgMagenta
+	MonitorBalancesColor         = color.FgHiRed
+	WatchAssignmentsColor        = color.FgHiCyan
+	ManageWalletLockColor        = color.FgHiYellow
+	SealValidatorKeysColor       = color.FgHiGreen
+	ShutdownColor                = color.FgHiWhite
 	ErrorColor                   = color.FgRed
 	WarningColor                 = color.FgYellow
 	UpdateColor                  = color.FgHiWhite
@@ -74,26 +115,51 @@ func run(c *cli.Context) error {
 	// Configure
 	configureHTTP()
 
-	// Wait until node is registered
-	if err := services.WaitNodeRegistered(c, true); err != nil {
-		return err
-	}
-
 	// Get services
 	cfg, err := services.GetConfig(c)
 	if err != nil {
 		return err
 	}
-	rp, err := services.GetRocketPool(c)
+
+	// Keep track of how the tasks run, and keep the daemon running if one of them panics
+	taskRecorder := tasks.NewRecorder("node", cfg.Smartnode.GetTaskStatusPath("node"), tasks.DefaultRunHistorySize)
+	crashGuard := tasks.NewCrashGuard("node", cfg.Smartnode.GetCrashReportsPath(), taskRecorder)
+
+	// Let the transactions in flight finish and be recorded before the daemon exits
+	coordinator := shutdown.NewCoordinator("node", log.NewModuleLogger("node.shutdown", log.LevelInfo, ShutdownColor))
+	coordinator.AddDrain("the command queue", func(ctx context.Context) error {
+		deadline, _ := ctx.Deadline()
+		return cmdqueue.Close(cfg.Smartnode.GetCommandQueuePath(), time.Until(deadline))
+	})
+	rpcUsagePath := cfg.Smartnode.GetRpcUsagePath("node")
+	coordinator.AddFlusher("RPC usage", func() error { return rpcusage.GetTracker().Save("node", rpcUsagePath) })
+	coordinator.HandleSignals(shutdown.DefaultTimeout)
+
+	// Decrypt the validator keys for the Validator Client if they're kept encrypted, without waiting for the node to be ready
+	sealValidatorKeys, err := newSealValidatorKeys(c, log.NewModuleLogger("node.seal-validator-keys", log.LevelInfo, SealValidatorKeysColor), log.NewModuleLogger("node", log.LevelError, ErrorColor))
 	if err != nil {
 		return err
 	}
+	sealValidatorKeys.start(crashGuard)
+
+	// Wait until node is registered
+	if err := services.WaitNodeRegistered(c, true); err != nil {
+		return err
+	}
+
+	// Make sure the Execution clients are on the network the node is configured for once they're ready
+	if err := services.WaitChainIDPinned(c, true); err != nil {
+		return err
+	}
+
+	// Get services
 	w, err := services.GetWallet(c)
 	if err != nil {
 		return err
 	}
-	bc, err := services.GetBeaconClient(c)
-	if err != nil {
+
+	// Configure logging
+	if err := services.ConfigureLogging(cfg); err != nil {
 		return err
 	}
 
@@ -104,47 +170,209 @@ func run(c *cli.Context) error {
 		fmt.Println("Starting node daemon in Docker Mode.")
 	}
 
+	// Track the daemon's health
+	healthChecker := health.NewChecker(maxTaskLoopAge, health.Component_ExecutionClient, health.Component_BeaconClient, health.Component_Wallet, health.Component_Duties)
+
 	nodeAccount, err := w.GetNodeAccount()
 	if err != nil {
 		return fmt.Errorf("error getting node account: %w", err)
 	}
+	healthChecker.SetStatus(health.Component_Wallet, nil)
 
 	// Initialize loggers
-	errorLog := log.NewColorLogger(ErrorColor)
-	updateLog := log.NewColorLogger(UpdateColor)
+	errorLog := log.NewModuleLogger("node", log.LevelError, ErrorColor)
+	updateLog := log.NewModuleLogger("node.state", log.LevelDebug, UpdateColor)
 
-	// Create the state manager
-	m, err := state.NewNetworkStateManager(rp, cfg, rp.Client, bc, &updateLog)
+	// Get the state manager shared by the daemon's tasks and the API server's in-process commands
+	m, err := services.GetNetworkStateManager(c)
 	if err != nil {
 		return err
 	}
+	m = m.WithLogger(&updateLog)
 	stateLocker := collectors.NewStateLocker()
+	livenessCollector := collectors.NewLivenessCollector()
+	systemCollector := collectors.NewSystemCollector()
+	alerts := alerting.NewAlertManager(cfg, log.NewModuleLogger("node.alerts", log.LevelWarn, CheckAlertsColor))
+	broker := events.NewBroker()
+	alertHistory := alerting.NewHistory(cfg.Smartnode.GetAlertHistoryPath(), alerting.DefaultHistorySize)
+	alerts.AddListener(func(alert alerting.Alert) {
+		if err := alertHistory.Record(alert); err != nil {
+			errorLog.Println(err)
+		}
+		if alert.Resolved {
+			broker.Publish(events.EventType_AlertResolved, alert)
+		} else {
+			broker.Publish(events.EventType_AlertRaised, alert)
+		}
+	})
+	crashGuard.AddListener(func(report tasks.CrashReport, path string) {
+		alerts.Raise(alerting.NewTaskCrashAlert(report.Daemon, report.Task, report.Panic, path))
+	})
 
 	// Initialize tasks
-	manageFeeRecipient, err := newManageFeeRecipient(c, log.NewColorLogger(ManageFeeRecipientColor))
+	manageFeeRecipient, err := newManageFeeRecipient(c, log.NewModuleLogger("node.manage-fee-recipient", log.LevelInfo, ManageFeeRecipientColor))
+	if err != nil {
+		return err
+	}
+	distributeMinipools, err := newDistributeMinipools(c, log.NewModuleLogger("node.distribute-minipools", log.LevelInfo, DistributeMinipoolsColor))
+	if err != nil {
+		return err
+	}
+	stakePrelaunchMinipools, err := newStakePrelaunchMinipools(c, log.NewModuleLogger("node.stake-prelaunch-minipools", log.LevelInfo, StakePrelaunchMinipoolsColor), coordinator, crashGuard)
+	if err != nil {
+		return err
+	}
+	promoteMinipools, err := newPromoteMinipools(c, log.NewModuleLogger("node.promote-minipools", log.LevelInfo, PromoteMinipoolsColor))
+	if err != nil {
+		return err
+	}
+	downloadRewardsTrees, err := newDownloadRewardsTrees(c, log.NewModuleLogger("node.download-reward-trees", log.LevelInfo, DownloadRewardsTreesColor))
 	if err != nil {
 		return err
 	}
-	distributeMinipools, err := newDistributeMinipools(c, log.NewColorLogger(DistributeMinipoolsColor))
+	reduceBonds, err := newReduceBonds(c, log.NewModuleLogger("node.reduce-bonds", log.LevelInfo, ReduceBondAmountColor))
 	if err != nil {
 		return err
 	}
-	stakePrelaunchMinipools, err := newStakePrelaunchMinipools(c, log.NewColorLogger(StakePrelaunchMinipoolsColor))
+	upgradeDelegates, err := newUpgradeDelegates(c, log.NewModuleLogger("node.upgrade-delegates", log.LevelInfo, UpgradeDelegatesColor), alerts, nodeAccount.Address)
 	if err != nil {
 		return err
 	}
-	promoteMinipools, err := newPromoteMinipools(c, log.NewColorLogger(PromoteMinipoolsColor))
+	checkAlerts, err := newCheckAlerts(c, log.NewModuleLogger("node.check-alerts", log.LevelWarn, CheckAlertsColor), alerts, nodeAccount.Address)
 	if err != nil {
 		return err
 	}
-	downloadRewardsTrees, err := newDownloadRewardsTrees(c, log.NewColorLogger(DownloadRewardsTreesColor))
+	monitorQueue, err := newMonitorQueue(c, log.NewModuleLogger("node.monitor-queue", log.LevelInfo, MonitorQueueColor), alerts, nodeAccount.Address)
 	if err != nil {
 		return err
 	}
-	reduceBonds, err := newReduceBonds(c, log.NewColorLogger(ReduceBondAmountColor))
+	monitorLiveness, err := newMonitorLiveness(c, log.NewModuleLogger("node.monitor-liveness", log.LevelInfo, MonitorLivenessColor), errorLog, stateLocker, alerts, livenessCollector, nodeAccount.Address)
 	if err != nil {
 		return err
 	}
+	// Poll faster around the times the tasks have to act on, and slower the rest of the time
+	scheduler := schedule.NewScheduler(activeTasksInterval, activeTasksWindow)
+
+	monitorProposals, err := newMonitorProposals(c, log.NewModuleLogger("node.monitor-proposals", log.LevelInfo, MonitorProposalsColor), errorLog, stateLocker, alerts, scheduler, nodeAccount.Address)
+	if err != nil {
+		return err
+	}
+	watchAssignments, err := newWatchAssignments(c, log.NewModuleLogger("node.watch-assignments", log.LevelInfo, WatchAssignmentsColor), errorLog, stateLocker, alerts, nodeAccount.Address)
+	if err != nil {
+		return err
+	}
+	monitorSystem, err := newMonitorSystem(c, log.NewModuleLogger("node.monitor-system", log.LevelInfo, MonitorSystemColor), alerts, systemCollector)
+	if err != nil {
+		return err
+	}
+	checkUpdates, err := newCheckUpdates(c, log.NewModuleLogger("node.check-updates", log.LevelInfo, CheckUpdatesColor), alerts, nodeAccount.Address)
+	if err != nil {
+		return err
+	}
+	checkMevRelays, err := newCheckMevRelays(c, log.NewModuleLogger("node.check-mev-relays", log.LevelInfo, CheckMevRelaysColor), alerts, nodeAccount.Address)
+	if err != nil {
+		return err
+	}
+	manageGraffiti, err := newManageGraffiti(c, log.NewModuleLogger("node.manage-graffiti", log.LevelInfo, ManageGraffitiColor), nodeAccount.Address)
+	if err != nil {
+		return err
+	}
+	manageBlockBuilding, err := newManageBlockBuilding(c, log.NewModuleLogger("node.manage-block-building", log.LevelInfo, ManageBlockBuildingColor), nodeAccount.Address)
+	if err != nil {
+		return err
+	}
+	checkDvtCluster, err := newCheckDvtCluster(c, log.NewModuleLogger("node.check-dvt-cluster", log.LevelInfo, CheckDvtClusterColor), alerts)
+	if err != nil {
+		return err
+	}
+	checkRescueNode, err := newCheckRescueNode(c, log.NewModuleLogger("node.check-rescue-node", log.LevelInfo, CheckRescueNodeColor), alerts)
+	if err != nil {
+		return err
+	}
+	checkStrandedAssets, err := newCheckStrandedAssets(c, log.NewModuleLogger("node.check-stranded-assets", log.LevelInfo, CheckStrandedAssetsColor), alerts, nodeAccount.Address)
+	if err != nil {
+		return err
+	}
+	watchContractUpgrades, err := newWatchContractUpgrades(c, log.NewModuleLogger("node.watch-contract-upgrades", log.LevelInfo, WatchContractUpgradesColor))
+	if err != nil {
+		return err
+	}
+	indexActivity, err := newIndexActivity(c, log.NewModuleLogger("node.index-activity", log.LevelInfo, IndexActivityColor), nodeAccount.Address)
+	if err != nil {
+		return err
+	}
+	publishEvents, err := newPublishEvents(c, log.NewModuleLogger("node.publish-events", log.LevelInfo, PublishEventsColor), broker, nodeAccount.Address)
+	if err != nil {
+		return err
+	}
+	runAddonTasks, err := newRunAddonTasks(c, log.NewModuleLogger("node.run-addon-tasks", log.LevelInfo, RunAddonTasksColor), nodeAccount.Address)
+	if err != nil {
+		return err
+	}
+	publishHeartbeat, err := newPublishHeartbeat(c, log.NewModuleLogger("node.publish-heartbeat", log.LevelInfo, PublishHeartbeatColor), errorLog, healthChecker, nodeAccount.Address)
+	if err != nil {
+		return err
+	}
+	monitorBalances, err := newMonitorBalances(c, log.NewModuleLogger("node.monitor-balances", log.LevelInfo, MonitorBalancesColor), alerts, nodeAccount.Address)
+	if err != nil {
+		return err
+	}
+	recordHistory, err := newRecordHistory(c, log.NewModuleLogger("node.record-history", log.LevelDebug, RecordHistoryColor), stateLocker, livenessCollector, nodeAccount.Address)
+	if err != nil {
+		return err
+	}
+	manageWalletLock, err := newManageWalletLock(c, log.NewModuleLogger("node.manage-wallet-lock", log.LevelInfo, ManageWalletLockColor), healthChecker)
+	if err != nil {
+		return err
+	}
+
+	// Start monitoring validator liveness and proposals
+	monitorLiveness.start(crashGuard)
+	coordinator.AddFlusher("validator uptime", monitorLiveness.uptime.Save)
+	monitorProposals.start(crashGuard)
+
+	// Start watching for the node's minipools to be assigned
+	watchAssignments.start(crashGuard)
+
+	// Start publishing heartbeats if they're enabled
+	publishHeartbeat.start(crashGuard)
+
+	// Start the health check server if it's enabled in the config, or its port was provided directly
+	healthPort := c.GlobalUint("healthPort")
+	if healthPort == 0 && cfg.Smartnode.EnableHealthCheck.Value == true {
+		healthPort = uint(cfg.Smartnode.NodeHealthPort.Value.(uint16))
+	}
+	if healthPort != 0 {
+		go func() {
+			if err := healthChecker.Serve(c.GlobalString("healthAddress"), healthPort); err != nil {
+				errorLog.Println(err)
+			}
+		}()
+	}
+
+	// Start the profiling server
+	if cfg.Smartnode.EnableProfiling.Value == true {
+		go func() {
+			if err := profiling.Serve(cfg.Smartnode.GetProfilingSocketPath("node")); err != nil {
+				errorLog.Println(err)
+			}
+		}()
+	}
+
+	// Start the API server
+	if cfg.ApiServer.Enabled.Value == true {
+		apiServer, err := server.NewServer(c, cfg, broker, log.NewModuleLogger("node.api-server", log.LevelInfo, ApiServerColor))
+		if err != nil {
+			errorLog.Println(err)
+		} else {
+			go func() {
+				if err := apiServer.Serve(); err != nil {
+					errorLog.Println(err)
+				}
+			}()
+			coordinator.AddDrain("the API server", apiServer.Shutdown)
+		}
+	}
 
 	// Wait group to handle the various threads
 	wg := new(sync.WaitGroup)
@@ -156,79 +384,228 @@ func run(c *cli.Context) error {
 	// Run task loop
 	go func() {
 		for {
+			passStart := time.Now()
+
+			// Check the disk and memory usage first, since running out of either can take the clients down
+			err := runTask(coordinator, crashGuard, taskRecorder, &errorLog, "monitor-system", monitorSystem.run)
+			if err != nil {
+				errorLog.Println(err)
+			}
+
+			// Drop or reload the wallet's keys if it's been locked or unlocked
+			err = runTask(coordinator, crashGuard, taskRecorder, &errorLog, "manage-wallet-lock", manageWalletLock.run)
+			if err != nil {
+				errorLog.Println(err)
+			}
+
+			// Check for new releases; this doesn't need the clients, so it runs even if they're down
+			err = runTask(coordinator, crashGuard, taskRecorder, &errorLog, "check-updates", checkUpdates.run)
+			if err != nil {
+				errorLog.Println(err)
+			}
+
+			// Check whether the rescue node is still needed; this has to run while the Beacon node is down too
+			err = runTask(coordinator, crashGuard, taskRecorder, &errorLog, "check-rescue-node", checkRescueNode.run)
+			if err != nil {
+				errorLog.Println(err)
+			}
+
 			// Check the EC status
-			err := services.WaitEthClientSynced(c, false) // Force refresh the primary / fallback EC status
+			err = services.WaitEthClientSynced(c, false) // Force refresh the primary / fallback EC status
+			checkAlerts.checkExecutionClient(err)
+			healthChecker.SetStatus(health.Component_ExecutionClient, err)
 			if err != nil {
 				errorLog.Println(err)
+				healthChecker.RecordProgress()
 				time.Sleep(taskCooldown)
 				continue
 			}
 
 			// Check the BC status
 			err = services.WaitBeaconClientSynced(c, false) // Force refresh the primary / fallback BC status
+			checkAlerts.checkBeaconClient(err)
+			healthChecker.SetStatus(health.Component_BeaconClient, err)
 			if err != nil {
 				errorLog.Println(err)
+				healthChecker.RecordProgress()
 				time.Sleep(taskCooldown)
 				continue
 			}
 
+			// Reload any contracts that were upgraded before they're used
+			err = runTask(coordinator, crashGuard, taskRecorder, &errorLog, "watch-contract-upgrades", watchContractUpgrades.run)
+			if err != nil {
+				errorLog.Println(err)
+			}
+
 			// Update the network state
 			updateTotalEffectiveStake := false
 			if time.Since(lastTotalEffectiveStakeTime) > totalEffectiveStakeCooldown {
 				updateTotalEffectiveStake = true
 				lastTotalEffectiveStakeTime = time.Now() // Even if the call below errors out, this will prevent contant errors related to this flag
 			}
-			state, totalEffectiveStake, err := updateNetworkState(m, &updateLog, nodeAccount.Address, updateTotalEffectiveStake)
+			var networkState *state.NetworkState
+			var totalEffectiveStake *big.Int
+			err = runTask(coordinator, crashGuard, taskRecorder, &errorLog, "update-network-state", func() error {
+				var err error
+				networkState, totalEffectiveStake, err = updateNetworkState(m, &updateLog, nodeAccount.Address, updateTotalEffectiveStake)
+				return err
+			})
 			if err != nil {
 				errorLog.Println(err)
+				healthChecker.RecordDuties(taskRecorder.GetFailuresSince(passStart))
 				time.Sleep(taskCooldown)
 				continue
 			}
+			state := networkState
 			stateLocker.UpdateState(state, totalEffectiveStake)
+			updateSchedule(scheduler, state, nodeAccount.Address)
+			crashGuard.SetInputs(map[string]string{
+				"slot":    fmt.Sprint(state.BeaconSlotNumber),
+				"elBlock": fmt.Sprint(state.ElBlockNumber),
+			})
+
+			// Publish what changed since the last run
+			err = runTask(coordinator, crashGuard, taskRecorder, &errorLog, "publish-events", func() error { return publishEvents.run(state) })
+			if err != nil {
+				errorLog.Println(err)
+			}
 
 			// Manage the fee recipient for the node
-			if err := manageFeeRecipient.run(state); err != nil {
+			err = runTask(coordinator, crashGuard, taskRecorder, &errorLog, "manage-fee-recipient", func() error { return manageFeeRecipient.run(state) })
+			if err != nil {
+				errorLog.Println(err)
+			}
+			time.Sleep(taskCooldown)
+
+			// Update the validator client's graffiti
+			err = runTask(coordinator, crashGuard, taskRecorder, &errorLog, "manage-graffiti", func() error { return manageGraffiti.run(state) })
+			if err != nil {
+				errorLog.Println(err)
+			}
+			time.Sleep(taskCooldown)
+
+			// Push the minipools' block building preferences to the validator client
+			err = runTask(coordinator, crashGuard, taskRecorder, &errorLog, "manage-block-building", func() error { return manageBlockBuilding.run(state) })
+			if err != nil {
 				errorLog.Println(err)
 			}
 			time.Sleep(taskCooldown)
 
 			// Run the rewards download check
-			if err := downloadRewardsTrees.run(state); err != nil {
+			err = runTask(coordinator, crashGuard, taskRecorder, &errorLog, "download-reward-trees", func() error { return downloadRewardsTrees.run(state) })
+			if err != nil {
 				errorLog.Println(err)
 			}
 			time.Sleep(taskCooldown)
 
 			// Run the minipool stake check
-			if err := stakePrelaunchMinipools.run(state); err != nil {
+			err = runTask(coordinator, crashGuard, taskRecorder, &errorLog, "stake-prelaunch-minipools", func() error { return stakePrelaunchMinipools.run(state) })
+			if err != nil {
 				errorLog.Println(err)
 			}
 			time.Sleep(taskCooldown)
 
 			// Run the balance distribution check
-			if err := distributeMinipools.run(state); err != nil {
+			err = runTask(coordinator, crashGuard, taskRecorder, &errorLog, "distribute-minipools", func() error { return distributeMinipools.run(state) })
+			if err != nil {
 				errorLog.Println(err)
 			}
 			time.Sleep(taskCooldown)
 
 			// Run the reduce bond check
-			if err := reduceBonds.run(state); err != nil {
+			err = runTask(coordinator, crashGuard, taskRecorder, &errorLog, "reduce-bonds", func() error { return reduceBonds.run(state) })
+			if err != nil {
+				errorLog.Println(err)
+			}
+			time.Sleep(taskCooldown)
+
+			// Run the delegate upgrade check
+			err = runTask(coordinator, crashGuard, taskRecorder, &errorLog, "upgrade-delegates", func() error { return upgradeDelegates.run(state) })
+			if err != nil {
 				errorLog.Println(err)
 			}
 			time.Sleep(taskCooldown)
 
 			// Run the minipool promotion check
-			if err := promoteMinipools.run(state); err != nil {
+			err = runTask(coordinator, crashGuard, taskRecorder, &errorLog, "promote-minipools", func() error { return promoteMinipools.run(state) })
+			if err != nil {
+				errorLog.Println(err)
+			}
+			time.Sleep(taskCooldown)
+
+			// Run the alert rules
+			err = runTask(coordinator, crashGuard, taskRecorder, &errorLog, "check-alerts", func() error { return checkAlerts.run(state) })
+			if err != nil {
+				errorLog.Println(err)
+			}
+
+			// Look for unexpected outflows from the node wallet
+			err = runTask(coordinator, crashGuard, taskRecorder, &errorLog, "monitor-balances", func() error { return monitorBalances.run(state) })
+			if err != nil {
+				errorLog.Println(err)
+			}
+
+			// Watch the deposit pool and the node's minipools in the queue
+			err = runTask(coordinator, crashGuard, taskRecorder, &errorLog, "monitor-queue", func() error { return monitorQueue.run(state) })
+			if err != nil {
+				errorLog.Println(err)
+			}
+
+			// Check the MEV relays
+			err = runTask(coordinator, crashGuard, taskRecorder, &errorLog, "check-mev-relays", func() error { return checkMevRelays.run(state) })
+			if err != nil {
+				errorLog.Println(err)
+			}
+
+			// Check the DVT cluster
+			err = runTask(coordinator, crashGuard, taskRecorder, &errorLog, "check-dvt-cluster", checkDvtCluster.run)
+			if err != nil {
+				errorLog.Println(err)
+			}
+
+			// Check for assets left in previous contract versions
+			err = runTask(coordinator, crashGuard, taskRecorder, &errorLog, "check-stranded-assets", checkStrandedAssets.run)
+			if err != nil {
 				errorLog.Println(err)
 			}
 
-			time.Sleep(tasksInterval)
+			// Index the node's Rocket Pool activity
+			err = runTask(coordinator, crashGuard, taskRecorder, &errorLog, "index-activity", indexActivity.run)
+			if err != nil {
+				errorLog.Println(err)
+			}
+
+			// Run the addons' tasks
+			err = runTask(coordinator, crashGuard, taskRecorder, &errorLog, "run-addon-tasks", func() error { return runAddonTasks.run(state) })
+			if err != nil {
+				errorLog.Println(err)
+			}
+
+			// Record the node's history
+			err = runTask(coordinator, crashGuard, taskRecorder, &errorLog, "record-history", func() error { return recordHistory.run(state) })
+			if err != nil {
+				errorLog.Println(err)
+			}
+			healthChecker.RecordDuties(taskRecorder.GetFailuresSince(passStart))
+
+			// Save the RPC usage so the CLI can report it
+			if err := rpcusage.GetTracker().Save("node", rpcUsagePath); err != nil {
+				errorLog.Println(err)
+			}
+
+			interval, reason := scheduler.GetInterval(tasksInterval)
+			if reason != "" {
+				updateLog.Printlnf("Checking again in %s because of %s.", interval.Round(time.Second), reason)
+			}
+			time.Sleep(interval)
 		}
 		wg.Done()
 	}()
 
 	// Run metrics loop
 	go func() {
-		err := runMetricsServer(c, log.NewColorLogger(MetricsColor), stateLocker)
+		err := runMetricsServer(c, log.NewModuleLogger("node.metrics", log.LevelInfo, MetricsColor), stateLocker, taskRecorder, livenessCollector, systemCollector)
 		if err != nil {
 			errorLog.Println(err)
 		}
@@ -241,6 +618,22 @@ func run(c *cli.Context) error {
 
 }
 
+// Run a task, recovering if it panics, and record its outcome, logging any errors saving it.
+// Tasks aren't started once the daemon is shutting down, and the ones running hold up the shutdown until they finish.
+func runTask(coordinator *shutdown.Coordinator, guard *tasks.CrashGuard, recorder *tasks.Recorder, errorLog *log.ColorLogger, task string, run func() error) error {
+	start := time.Now()
+	err := coordinator.Run(task, func() error {
+		return guard.Run(task, run)
+	})
+	if errors.Is(err, shutdown.ErrShuttingDown) {
+		return nil
+	}
+	if saveErr := recorder.Record(task, start, err); saveErr != nil {
+		errorLog.Println(saveErr)
+	}
+	return err
+}
+
 // Configure HTTP transport settings
 func configureHTTP() {
 
@@ -328,3 +721,24 @@ func updateNetworkState(m *state.NetworkStateManager, log *log.ColorLogger, node
 	}
 	return state, totalEffectiveStake, nil
 }
+
+// Set the deadlines the tasks have to act on from the latest network state
+func updateSchedule(scheduler *schedule.Scheduler, state *state.NetworkState, nodeAddress common.Address) {
+	// The rewards tree for the interval becomes available shortly after it ends
+	intervalEnd := state.NetworkDetails.IntervalStart.Add(state.NetworkDetails.IntervalDuration)
+	scheduler.SetDeadline("the end of the rewards interval", intervalEnd)
+
+	// Prelaunch minipools can be staked (and vacant ones promoted) once their scrub window ends
+	for _, mpd := range state.MinipoolDetailsByNode[nodeAddress] {
+		name := fmt.Sprintf("the end of minipool %s's scrub period", mpd.MinipoolAddress.Hex())
+		if mpd.Status != rptypes.Prelaunch {
+			scheduler.ClearDeadline(name)
+			continue
+		}
+		scrubPeriod := state.NetworkDetails.ScrubPeriod
+		if mpd.IsVacant {
+			scrubPeriod = state.NetworkDetails.PromotionScrubPeriod
+		}
+		scheduler.SetDeadline(name, time.Unix(mpd.StatusTime.Int64(), 0).Add(scrubPeriod))
+	}
+}
@@ -0,0 +1,129 @@
+package node
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/events"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// The data of a minipool-detected event
+type minipoolDetectedEvent struct {
+	Minipool common.Address        `json:"minipool"`
+	Pubkey   types.ValidatorPubkey `json:"pubkey"`
+}
+
+// The data of a client-failover or client-recovered event
+type clientFailoverEvent struct {
+	Client string `json:"client"`
+}
+
+// The data of a rewards-available event
+type rewardsAvailableEvent struct {
+	Interval uint64 `json:"interval"`
+}
+
+// Publish events task
+type publishEvents struct {
+	c           *cli.Context
+	log         log.ColorLogger
+	events      *events.Broker
+	ec          *services.ExecutionClientManager
+	bc          *services.BeaconClientManager
+	nodeAddress common.Address
+
+	// What the last run saw; nothing is published until the first run has recorded the starting point
+	initialized     bool
+	minipools       map[common.Address]bool
+	rewardIndex     uint64
+	ecUsingFallback bool
+	bcUsingFallback bool
+}
+
+// Create publish events task
+func newPublishEvents(c *cli.Context, logger log.ColorLogger, broker *events.Broker, nodeAddress common.Address) (*publishEvents, error) {
+
+	// Get services
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &publishEvents{
+		c:           c,
+		log:         logger,
+		events:      broker,
+		ec:          ec,
+		bc:          bc,
+		nodeAddress: nodeAddress,
+		minipools:   map[common.Address]bool{},
+	}, nil
+
+}
+
+// Publish the changes in the network state and the clients since the last run
+func (t *publishEvents) run(state *state.NetworkState) error {
+
+	// Fallback clients
+	ecUsingFallback := t.ec.IsUsingFallback()
+	if ecUsingFallback != t.ecUsingFallback {
+		t.publishFailover("execution", ecUsingFallback)
+		t.ecUsingFallback = ecUsingFallback
+	}
+	bcUsingFallback := t.bc.IsUsingFallback()
+	if bcUsingFallback != t.bcUsingFallback {
+		t.publishFailover("beacon", bcUsingFallback)
+		t.bcUsingFallback = bcUsingFallback
+	}
+
+	// New minipools
+	for _, mpd := range state.MinipoolDetailsByNode[t.nodeAddress] {
+		if t.minipools[mpd.MinipoolAddress] {
+			continue
+		}
+		t.minipools[mpd.MinipoolAddress] = true
+		if t.initialized {
+			t.log.Printlnf("Detected new minipool %s.", mpd.MinipoolAddress.Hex())
+			t.events.Publish(events.EventType_MinipoolDetected, minipoolDetectedEvent{
+				Minipool: mpd.MinipoolAddress,
+				Pubkey:   mpd.Pubkey,
+			})
+		}
+	}
+
+	// The reward index moves on once the previous interval's tree has been submitted and its rewards can be claimed
+	rewardIndex := state.NetworkDetails.RewardIndex
+	if t.initialized && rewardIndex > t.rewardIndex {
+		for interval := t.rewardIndex; interval < rewardIndex; interval++ {
+			t.log.Printlnf("Rewards for interval %d are available.", interval)
+			t.events.Publish(events.EventType_RewardsAvailable, rewardsAvailableEvent{
+				Interval: interval,
+			})
+		}
+	}
+	t.rewardIndex = rewardIndex
+
+	t.initialized = true
+	return nil
+
+}
+
+// Publish a switch to or from a fallback client
+func (t *publishEvents) publishFailover(client string, usingFallback bool) {
+	if usingFallback {
+		t.log.Printlnf("The %s client failed over to its fallback.", client)
+		t.events.Publish(events.EventType_ClientFailover, clientFailoverEvent{Client: client})
+	} else {
+		t.log.Printlnf("The primary %s client is back in use.", client)
+		t.events.Publish(events.EventType_ClientRecovered, clientFailoverEvent{Client: client})
+	}
+}
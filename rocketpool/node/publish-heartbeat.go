@@ -0,0 +1,169 @@
+package node
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared"
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/health"
+	"github.com/rocket-pool/smartnode/shared/services/tasks"
+	"github.com/rocket-pool/smartnode/shared/services/updates"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// How long to wait for the heartbeat endpoint to respond
+const heartbeatTimeout time.Duration = 15 * time.Second
+
+// The status of the node reported in each heartbeat
+type heartbeat struct {
+	NodeAddress      common.Address        `json:"nodeAddress"`
+	Timestamp        int64                 `json:"timestamp"`
+	SmartnodeVersion string                `json:"smartnodeVersion"`
+	Clients          []string              `json:"clients"`
+	Health           health.HealthResponse `json:"health"`
+}
+
+// A heartbeat signed by the node wallet. The signature is an EIP-191 personal message signature of the raw heartbeat JSON,
+// so the watchdog can recover the node address from it without having to reserialize the heartbeat.
+type signedHeartbeat struct {
+	Heartbeat json.RawMessage `json:"heartbeat"`
+	Signature string          `json:"signature"`
+}
+
+// Publish heartbeat task
+type publishHeartbeat struct {
+	c             *cli.Context
+	log           log.ColorLogger
+	errLog        log.ColorLogger
+	cfg           *config.RocketPoolConfig
+	w             *wallet.Wallet
+	healthChecker *health.Checker
+	client        *http.Client
+	nodeAddress   common.Address
+}
+
+// Create publish heartbeat task
+func newPublishHeartbeat(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, healthChecker *health.Checker, nodeAddress common.Address) (*publishHeartbeat, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &publishHeartbeat{
+		c:             c,
+		log:           logger,
+		errLog:        errorLogger,
+		cfg:           cfg,
+		w:             w,
+		healthChecker: healthChecker,
+		client:        &http.Client{Timeout: heartbeatTimeout},
+		nodeAddress:   nodeAddress,
+	}, nil
+
+}
+
+// Start publishing heartbeats in the background. They run separately from the task loop so a slow task doesn't make the node look silent,
+// while a task loop that has stalled still shows up in the heartbeat's health report.
+func (t *publishHeartbeat) start(guard *tasks.CrashGuard) {
+	if t.cfg.Alerting.HeartbeatUrl.Value.(string) == "" {
+		return
+	}
+	interval := time.Duration(t.cfg.Alerting.HeartbeatInterval.Value.(uint64)) * time.Minute
+	if interval == 0 {
+		interval = time.Minute
+	}
+	t.log.Printlnf("Publishing a heartbeat every %s.", interval)
+	go func() {
+		for {
+			if err := guard.Run("publish-heartbeat", t.run); err != nil {
+				t.errLog.Println(err)
+			}
+			time.Sleep(interval)
+		}
+	}()
+}
+
+// Sign and publish a heartbeat with the node's current status
+func (t *publishHeartbeat) run() error {
+
+	// Get the container tags of the clients the Smartnode manages, which include their versions
+	clients := []string{}
+	for _, image := range updates.GetClientImages(t.cfg) {
+		clients = append(clients, image.Tag.Value.(string))
+	}
+
+	// Serialize and sign the heartbeat
+	heartbeatBytes, err := json.Marshal(heartbeat{
+		NodeAddress:      t.nodeAddress,
+		Timestamp:        time.Now().Unix(),
+		SmartnodeVersion: shared.RocketPoolVersion,
+		Clients:          clients,
+		Health:           t.healthChecker.GetStatus(),
+	})
+	if err != nil {
+		return fmt.Errorf("error serializing heartbeat: %w", err)
+	}
+	signature, err := t.w.SignMessage(string(heartbeatBytes))
+	if err != nil {
+		return fmt.Errorf("error signing heartbeat: %w", err)
+	}
+	body, err := json.Marshal(signedHeartbeat{
+		Heartbeat: heartbeatBytes,
+		Signature: hexutil.Encode(signature),
+	})
+	if err != nil {
+		return fmt.Errorf("error serializing signed heartbeat: %w", err)
+	}
+
+	// Publish it
+	heartbeatUrl, err := t.getUrl()
+	if err != nil {
+		return err
+	}
+	response, err := t.client.Post(heartbeatUrl, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("error publishing heartbeat: %w", err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		responseBody, _ := io.ReadAll(response.Body)
+		return fmt.Errorf("error publishing heartbeat: request failed with code %d: %s", response.StatusCode, string(responseBody))
+	}
+	return nil
+
+}
+
+// Get the URL to publish heartbeats to, with the topic added to the end if there is one
+func (t *publishHeartbeat) getUrl() (string, error) {
+	heartbeatUrl := t.cfg.Alerting.HeartbeatUrl.Value.(string)
+	topic := t.cfg.Alerting.HeartbeatTopic.Value.(string)
+	if topic == "" {
+		return heartbeatUrl, nil
+	}
+	parsedUrl, err := url.Parse(heartbeatUrl)
+	if err != nil {
+		return "", fmt.Errorf("error parsing heartbeat URL [%s]: %w", heartbeatUrl, err)
+	}
+	parsedUrl.Path = strings.TrimSuffix(parsedUrl.Path, "/") + "/" + url.PathEscape(topic)
+	return parsedUrl.String(), nil
+}
@@ -0,0 +1,154 @@
+package node
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/rocketpool/node/collectors"
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/history"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Config
+var historySampleInterval, _ = time.ParseDuration("1h")
+
+// Record node history task
+type recordHistory struct {
+	c               *cli.Context
+	log             log.ColorLogger
+	store           *history.Store
+	stateLocker     *collectors.StateLocker
+	liveness        *collectors.LivenessCollector
+	nodeAddress     common.Address
+	lastSampleTime  time.Time
+	lastSampleKnown bool
+}
+
+// Create record node history task
+func newRecordHistory(c *cli.Context, logger log.ColorLogger, stateLocker *collectors.StateLocker, liveness *collectors.LivenessCollector, nodeAddress common.Address) (*recordHistory, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &recordHistory{
+		c:           c,
+		log:         logger,
+		store:       history.NewStore(cfg.Smartnode.GetNodeHistoryPath()),
+		stateLocker: stateLocker,
+		liveness:    liveness,
+		nodeAddress: nodeAddress,
+	}, nil
+
+}
+
+// Record a sample of the node's metrics if enough time has passed since the last one
+func (t *recordHistory) run(state *state.NetworkState) error {
+
+	// Get the time of the last sample on the first run
+	if !t.lastSampleKnown {
+		lastSampleTime, err := t.store.GetLatestTime()
+		if err != nil {
+			return err
+		}
+		t.lastSampleTime = lastSampleTime
+		t.lastSampleKnown = true
+	}
+	if time.Since(t.lastSampleTime) < historySampleInterval {
+		return nil
+	}
+
+	nd, exists := state.NodeDetailsByAddress[t.nodeAddress]
+	if !exists {
+		return fmt.Errorf("node %s was not found in the network state", t.nodeAddress.Hex())
+	}
+
+	// Get the minipool and validator totals
+	borrowedEth := big.NewInt(0)
+	beaconBalanceGwei := uint64(0)
+	activeMinipools := uint64(0)
+	for _, mpd := range state.MinipoolDetailsByNode[t.nodeAddress] {
+		if mpd.Finalised {
+			continue
+		}
+		activeMinipools++
+		borrowedEth.Add(borrowedEth, big.NewInt(0).Sub(eth.EthToWei(32), mpd.NodeDepositBalance))
+		if validator, exists := state.ValidatorDetails[mpd.Pubkey]; exists {
+			beaconBalanceGwei += validator.Balance
+		}
+	}
+
+	// Get the collateral ratio
+	rplPrice := eth.WeiToEth(state.NetworkDetails.RplPrice)
+	rplStake := eth.WeiToEth(nd.RplStake)
+	collateralRatio := float64(0)
+	if borrowedEth.Sign() > 0 {
+		collateralRatio = rplPrice * rplStake / eth.WeiToEth(borrowedEth)
+	}
+
+	// Estimate the RPL APR from the node's effective stake
+	effectiveStake := eth.WeiToEth(nd.EffectiveRPLStake)
+	rplApr := float64(0)
+	totalEffectiveStake := t.stateLocker.GetTotalEffectiveRPLStake()
+	if totalEffectiveStake != nil && totalEffectiveStake.Sign() > 0 && rplStake > 0 {
+		rewardsInterval := state.NetworkDetails.IntervalDuration
+		inflationPerDay := eth.WeiToEth(state.NetworkDetails.RPLInflationIntervalRate)
+		newRpl := (math.Pow(inflationPerDay, rewardsInterval.Hours()/24) - 1) * eth.WeiToEth(state.NetworkDetails.RPLTotalSupply)
+		estimatedRewards := effectiveStake / eth.WeiToEth(totalEffectiveStake) * newRpl * eth.WeiToEth(state.NetworkDetails.NodeOperatorRewardsPercent)
+		rplApr = estimatedRewards / rplStake / rewardsInterval.Hours() * (24 * 365) * 100
+	}
+
+	// Write the sample
+	now := time.Now()
+	sample := history.NodeSample{
+		Time:              now,
+		ElBlock:           state.ElBlockNumber,
+		EthBalance:        eth.WeiToEth(nd.BalanceETH),
+		RplBalance:        eth.WeiToEth(nd.BalanceRPL),
+		RethBalance:       eth.WeiToEth(nd.BalanceRETH),
+		RplStake:          rplStake,
+		EffectiveRplStake: effectiveStake,
+		CollateralRatio:   collateralRatio,
+		BeaconBalance:     float64(beaconBalanceGwei) / 1e9,
+		ActiveMinipools:   activeMinipools,
+		RplPrice:          rplPrice,
+		RethExchangeRate:  state.NetworkDetails.RETHExchangeRate,
+		RplApr:            rplApr,
+		Effectiveness:     t.getEffectiveness(),
+	}
+	if err := t.store.Append(sample); err != nil {
+		return err
+	}
+	t.lastSampleTime = now
+	t.log.Printlnf("Recorded node history sample at block %d.", state.ElBlockNumber)
+	return nil
+
+}
+
+// Get the average attestation effectiveness of the node's validators
+func (t *recordHistory) getEffectiveness() float64 {
+	t.liveness.UpdateLock.Lock()
+	defer t.liveness.UpdateLock.Unlock()
+
+	live := uint64(0)
+	total := uint64(0)
+	for _, stats := range t.liveness.Stats {
+		live += stats.LiveEpochs
+		total += stats.LiveEpochs + stats.MissedEpochs
+	}
+	if total == 0 {
+		return 0
+	}
+	return float64(live) / float64(total)
+}
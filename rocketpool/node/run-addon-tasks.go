@@ -0,0 +1,89 @@
+package node
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/addons"
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	addontypes "github.com/rocket-pool/smartnode/shared/types/addons"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Run addon tasks task
+type runAddonTasks struct {
+	c           *cli.Context
+	log         log.ColorLogger
+	cfg         *config.RocketPoolConfig
+	nodeAddress common.Address
+}
+
+// Create run addon tasks task
+func newRunAddonTasks(c *cli.Context, logger log.ColorLogger, nodeAddress common.Address) (*runAddonTasks, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	for _, err := range cfg.ExternalAddonErrors {
+		logger.Warnf("WARNING: %s", err.Error())
+	}
+
+	// Return task
+	return &runAddonTasks{
+		c:           c,
+		log:         logger,
+		cfg:         cfg,
+		nodeAddress: nodeAddress,
+	}, nil
+
+}
+
+// Run the daemon tasks of the enabled addons and record the status they report
+func (t *runAddonTasks) run(state *state.NetworkState) error {
+
+	context := addontypes.TaskContext{
+		Network:     fmt.Sprint(t.cfg.Smartnode.Network.Value),
+		NodeAddress: t.nodeAddress.Hex(),
+		BeaconSlot:  state.BeaconSlotNumber,
+	}
+	report := addons.StatusReport{
+		Time:   time.Now(),
+		Addons: []addons.AddonStatus{},
+	}
+	for _, addon := range t.cfg.GetAddons() {
+		if addon.GetEnabledParameter().Value != true {
+			continue
+		}
+		status := addons.AddonStatus{
+			ID:   addon.GetID(),
+			Name: addon.GetName(),
+		}
+
+		// A failing addon is reported, but never stops the other addons or the node's own tasks
+		if taskAddon, ok := addon.(addontypes.TaskAddon); ok {
+			if err := taskAddon.RunTask(context); err != nil {
+				t.log.Warnf("WARNING: %s", err.Error())
+				status.TaskError = err.Error()
+			}
+		}
+		if statusAddon, ok := addon.(addontypes.StatusAddon); ok {
+			addonStatus, err := statusAddon.GetStatus()
+			if err != nil {
+				status.Error = err.Error()
+			} else if addonStatus.Level != "" {
+				status.Status = &addonStatus
+			}
+		}
+		report.Addons = append(report.Addons, status)
+	}
+
+	return report.Save(t.cfg.Smartnode.GetAddonStatusPath())
+
+}
@@ -0,0 +1,240 @@
+package node
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/client"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/keyseal"
+	"github.com/rocket-pool/smartnode/shared/services/secrets"
+	"github.com/rocket-pool/smartnode/shared/services/tasks"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+	"github.com/rocket-pool/smartnode/shared/utils/validator"
+)
+
+// How often to check the decrypted validator keystore directory for changes. The slashing protection databases aren't sealed,
+// so this only bounds how long a new key can go without an encrypted copy.
+var sealValidatorKeysInterval, _ = time.ParseDuration("1m")
+
+// Seal validator keys task
+type sealValidatorKeys struct {
+	c      *cli.Context
+	log    log.ColorLogger
+	errLog log.ColorLogger
+	cfg    *config.RocketPoolConfig
+	bc     beacon.Client
+	d      *client.Client
+	store  secrets.Store
+
+	// The envelope key, once it's been loaded
+	key []byte
+
+	// The digest of the directory's contents the last time it was sealed
+	digest string
+
+	// Whether the node operator has been told to encrypt their existing keys
+	warned bool
+}
+
+// Create seal validator keys task
+func newSealValidatorKeys(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger) (*sealValidatorKeys, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+	d, err := services.GetDocker(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &sealValidatorKeys{
+		c:      c,
+		log:    logger,
+		errLog: errorLogger,
+		cfg:    cfg,
+		bc:     bc,
+		d:      d,
+	}, nil
+
+}
+
+// Start keeping the validator keystore directory decrypted for the Validator Client and its encrypted copy up to date.
+// This runs before the node is registered, so the Validator Client gets its keys back as soon as possible after a restart.
+func (t *sealValidatorKeys) start(guard *tasks.CrashGuard) {
+	go func() {
+		if err := guard.Run("finish-validator-key-decryption", t.finishDecryption); err != nil {
+			t.errLog.Printlnf("Error moving the decrypted validator keys into place: %s", err.Error())
+		}
+		for {
+			if err := guard.Run("seal-validator-keys", t.run); err != nil {
+				t.errLog.Printlnf("Error managing the encrypted validator keys: %s", err.Error())
+			}
+			time.Sleep(sealValidatorKeysInterval)
+		}
+	}()
+}
+
+// Decrypt the validator keystore directory if it's empty, such as after a reboot, and encrypt it again when it changes
+func (t *sealValidatorKeys) run() error {
+
+	// Only manage the directory once the existing keys have been encrypted, even if the setting has been turned off since,
+	// so the Validator Client doesn't lose its keys
+	sealedPath := os.ExpandEnv(t.cfg.Smartnode.GetSealedValidatorKeychainPath())
+	unsealedPath := os.ExpandEnv(t.cfg.Smartnode.GetUnsealedValidatorKeychainPath())
+	slashingProtectionPath := os.ExpandEnv(t.cfg.Smartnode.GetSlashingProtectionPath())
+	if !keyseal.IsSealed(t.cfg) {
+		if t.cfg.Secrets.IsValidatorKeyEncryptionEnabled() && !t.warned {
+			t.log.Println("Validator key encryption is enabled, but your existing keys haven't been encrypted yet. Run `rocketpool service encrypt-validator-keys` to encrypt them.")
+			t.warned = true
+		}
+		return nil
+	}
+
+	// Get the envelope key
+	if t.key == nil {
+		if t.store == nil {
+			store, err := services.GetSecretStore(t.c)
+			if err != nil {
+				return err
+			}
+			t.store = store
+		}
+		key, err := keyseal.GetEnvelopeKey(t.store, false)
+		if err != nil {
+			return err
+		}
+		t.key = key
+	}
+
+	// Decrypt the directory for the Validator Client if it's gone
+	empty, err := keyseal.IsEmpty(unsealedPath)
+	if err != nil {
+		return err
+	}
+	if empty {
+		if err := os.MkdirAll(unsealedPath, 0700); err != nil {
+			return fmt.Errorf("error creating [%s]: %w", unsealedPath, err)
+		}
+		memoryBacked, err := keyseal.IsMemoryBacked(unsealedPath)
+		if err != nil {
+			return err
+		}
+		if !memoryBacked {
+			return fmt.Errorf("[%s] isn't on memory-backed storage, so the validator keys won't be decrypted into it; run `rocketpool service start` so the service mounts a tmpfs volume there", unsealedPath)
+		}
+		digest, err := keyseal.Unseal(sealedPath, unsealedPath, t.key)
+		if err != nil {
+			return err
+		}
+		t.digest = digest
+		if _, err := keyseal.LinkSlashingProtection(unsealedPath, slashingProtectionPath); err != nil {
+			return err
+		}
+		t.log.Printlnf("Decrypted the validator keys into %s.", unsealedPath)
+		if err := validator.RestartValidator(t.cfg, t.bc, &t.log, t.d); err != nil {
+			return fmt.Errorf("error restarting validator client: %w", err)
+		}
+		return nil
+	}
+
+	// Link the slashing protection databases of any clients that have gotten keys since. If a Validator Client created its database before it was linked,
+	// it has to be stopped while the database is moved to persistent storage.
+	unlinked, err := keyseal.LinkSlashingProtection(unsealedPath, slashingProtectionPath)
+	if err != nil {
+		return err
+	}
+	if len(unlinked) > 0 {
+		t.log.Printlnf("The slashing protection database(s) %s are in memory-backed storage; moving them to %s.", strings.Join(unlinked, ", "), slashingProtectionPath)
+		if err := validator.StopValidator(t.cfg, t.bc, &t.log, t.d); err != nil {
+			return fmt.Errorf("error stopping validator client: %w", err)
+		}
+		detachErr := keyseal.DetachSlashingProtection(unsealedPath, slashingProtectionPath)
+		if err := validator.RestartValidator(t.cfg, t.bc, &t.log, t.d); err != nil {
+			return fmt.Errorf("error restarting validator client: %w", err)
+		}
+		if detachErr != nil {
+			return detachErr
+		}
+	}
+
+	// Encrypt it again if it's changed since it was last sealed
+	if t.digest == "" {
+		digest, err := keyseal.GetSealedDigest(sealedPath, t.key)
+		if err != nil {
+			return err
+		}
+		t.digest = digest
+	}
+	digest, err := keyseal.GetDigest(unsealedPath)
+	if err != nil {
+		return err
+	}
+	if digest != t.digest {
+		digest, err = keyseal.Seal(unsealedPath, sealedPath, t.key)
+		if err != nil {
+			return err
+		}
+		t.digest = digest
+	}
+	return nil
+
+}
+
+// In Docker mode, the validator keys are decrypted into a folder next to the validators folder, since the tmpfs volume covers it until the service
+// is restarted. Once it has been restarted without the volume, move them into place.
+func (t *sealValidatorKeys) finishDecryption() error {
+
+	decryptedPath := os.ExpandEnv(t.cfg.Smartnode.GetDecryptedValidatorKeychainPath())
+	validatorsPath := os.ExpandEnv(t.cfg.Smartnode.GetValidatorKeychainPath())
+	if t.cfg.IsNativeMode || keyseal.IsSealed(t.cfg) {
+		return nil
+	}
+	entries, err := os.ReadDir(decryptedPath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error reading [%s]: %w", decryptedPath, err)
+	}
+
+	// Wait until the service has been restarted without the tmpfs volume
+	memoryBacked, err := keyseal.IsMemoryBacked(validatorsPath)
+	if err != nil {
+		return err
+	}
+	if memoryBacked {
+		return fmt.Errorf("the validators folder is still on its tmpfs volume; run `rocketpool service start` to finish decrypting the validator keys")
+	}
+	if err := keyseal.Clear(validatorsPath); err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if err := os.Rename(filepath.Join(decryptedPath, entry.Name()), filepath.Join(validatorsPath, entry.Name())); err != nil {
+			return fmt.Errorf("error moving [%s] into [%s]: %w", entry.Name(), validatorsPath, err)
+		}
+	}
+	if err := os.Remove(decryptedPath); err != nil {
+		return fmt.Errorf("error removing [%s]: %w", decryptedPath, err)
+	}
+	t.log.Printlnf("Moved the decrypted validator keys into %s.", validatorsPath)
+	if err := validator.RestartValidator(t.cfg, t.bc, &t.log, t.d); err != nil {
+		return fmt.Errorf("error restarting validator client: %w", err)
+	}
+	return nil
+
+}
@@ -2,8 +2,10 @@ package node
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
+	"sync"
 	"time"
 
 	"github.com/docker/docker/client"
@@ -15,18 +17,26 @@ import (
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	rpstate "github.com/rocket-pool/rocketpool-go/utils/state"
 	"github.com/urfave/cli"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
 
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/dvt"
 	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/keymanager"
+	"github.com/rocket-pool/smartnode/shared/services/shutdown"
 	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/tasks"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
 	"github.com/rocket-pool/smartnode/shared/utils/validator"
 )
 
+// How long after a minipool's scrub check ends to stake it, so the check is over in the latest block too
+var scrubEndStakeMargin, _ = time.ParseDuration("24s")
+
 // Stake prelaunch minipools task
 type stakePrelaunchMinipools struct {
 	c              *cli.Context
@@ -40,10 +50,18 @@ type stakePrelaunchMinipools struct {
 	maxFee         *big.Int
 	maxPriorityFee *big.Int
 	gasLimit       uint64
+
+	// Staking minipools as soon as their scrub check ends, alongside the regular runs
+	stakeOnScrubEnd      bool
+	scrubEndGasThreshold float64
+	scheduled            map[common.Address]bool
+	lock                 sync.Mutex
+	coordinator          *shutdown.Coordinator
+	guard                *tasks.CrashGuard
 }
 
 // Create stake prelaunch minipools task
-func newStakePrelaunchMinipools(c *cli.Context, logger log.ColorLogger) (*stakePrelaunchMinipools, error) {
+func newStakePrelaunchMinipools(c *cli.Context, logger log.ColorLogger, coordinator *shutdown.Coordinator, guard *tasks.CrashGuard) (*stakePrelaunchMinipools, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -82,12 +100,18 @@ func newStakePrelaunchMinipools(c *cli.Context, logger log.ColorLogger) (*stakeP
 	priorityFeeGwei := cfg.Smartnode.PriorityFee.Value.(float64)
 	var priorityFee *big.Int
 	if priorityFeeGwei == 0 {
-		logger.Println("WARNING: priority fee was missing or 0, setting a default of 2.")
+		logger.Warnf("WARNING: priority fee was missing or 0, setting a default of 2.")
 		priorityFee = eth.GweiToWei(2)
 	} else {
 		priorityFee = eth.GweiToWei(priorityFeeGwei)
 	}
 
+	// Get the gas ceiling for staking when the scrub check ends
+	scrubEndGasThreshold := cfg.Smartnode.ScrubEndStakeGasThreshold.Value.(float64)
+	if scrubEndGasThreshold == 0 {
+		scrubEndGasThreshold = gasThreshold
+	}
+
 	// Return task
 	return &stakePrelaunchMinipools{
 		c:              c,
@@ -101,6 +125,12 @@ func newStakePrelaunchMinipools(c *cli.Context, logger log.ColorLogger) (*stakeP
 		maxFee:         maxFee,
 		maxPriorityFee: priorityFee,
 		gasLimit:       0,
+
+		stakeOnScrubEnd:      cfg.Smartnode.StakeOnScrubEnd.Value == true,
+		scrubEndGasThreshold: scrubEndGasThreshold,
+		scheduled:            map[common.Address]bool{},
+		coordinator:          coordinator,
+		guard:                guard,
 	}, nil
 
 }
@@ -108,6 +138,10 @@ func newStakePrelaunchMinipools(c *cli.Context, logger log.ColorLogger) (*stakeP
 // Stake prelaunch minipools
 func (t *stakePrelaunchMinipools) run(state *state.NetworkState) error {
 
+	// Take turns with the minipools being staked when their scrub check ends
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
 	// Reload the wallet (in case a call to `node deposit` changed it)
 	if err := t.w.Reload(); err != nil {
 		return err
@@ -141,25 +175,130 @@ func (t *stakePrelaunchMinipools) run(state *state.NetworkState) error {
 
 	// Stake minipools
 	successCount := 0
+	stakedPubkeys := []rptypes.ValidatorPubkey{}
 	for _, mpd := range minipools {
-		success, err := t.stakeMinipool(mpd, state, opts)
+		success, err := t.stakeMinipool(mpd, state, opts, t.gasThreshold)
 		if err != nil {
 			t.log.Println(fmt.Errorf("Could not stake minipool %s: %w", mpd.MinipoolAddress.Hex(), err))
 			return err
 		}
 		if success {
 			successCount++
+			stakedPubkeys = append(stakedPubkeys, mpd.Pubkey)
 		}
 	}
 
-	// Restart validator process if any minipools were staked successfully
+	// Load the new validators into the VC
 	if successCount > 0 {
-		if err := validator.RestartValidator(t.cfg, t.bc, &t.log, t.d); err != nil {
+		return t.loadValidators(stakedPubkeys)
+	}
+
+	// Return
+	return nil
+
+}
+
+// Load newly staked validators into the VC over the Keymanager API if possible, otherwise restart it
+func (t *stakePrelaunchMinipools) loadValidators(pubkeys []rptypes.ValidatorPubkey) error {
+	if t.cfg.Keymanager.IsEnabled() {
+		err := t.importValidatorKeys(pubkeys)
+		if err == nil {
+			return nil
+		}
+		t.log.Warnf("WARNING: couldn't load the new validator keys over the Keymanager API: %s", err.Error())
+		t.log.Println("Restarting the Validator client instead...")
+	}
+	return validator.RestartValidator(t.cfg, t.bc, &t.log, t.d)
+}
+
+// Schedule a minipool to be staked as soon as its scrub check ends, unless it already is
+func (t *stakePrelaunchMinipools) scheduleStake(mpd *rpstate.NativeMinipoolDetails, state *state.NetworkState, remainingTime time.Duration) {
+	if t.scheduled[mpd.MinipoolAddress] {
+		return
+	}
+	t.scheduled[mpd.MinipoolAddress] = true
+	time.AfterFunc(remainingTime+scrubEndStakeMargin, func() {
+		// Run it like the task loop does, so a panic doesn't take the daemon down and a shutdown waits for the transaction
+		err := t.coordinator.Run("stake-at-scrub-end", func() error {
+			return t.guard.Run("stake-at-scrub-end", func() error {
+				return t.stakeAtScrubEnd(mpd, state)
+			})
+		})
+		if errors.Is(err, shutdown.ErrShuttingDown) {
+			return
+		}
+		if err != nil {
+			t.log.Warnf("WARNING: couldn't stake minipool %s when its scrub check ended, it will be staked by the regular checks instead: %s", mpd.MinipoolAddress.Hex(), err.Error())
+		}
+	})
+	t.log.Printlnf("Minipool %s will be staked when its scrub check ends, in %s.", mpd.MinipoolAddress.Hex(), remainingTime.Round(time.Second))
+}
+
+// Stake a minipool whose scrub check has just ended, if it's still waiting to be staked and the gas price is below the ceiling
+func (t *stakePrelaunchMinipools) stakeAtScrubEnd(mpd *rpstate.NativeMinipoolDetails, state *state.NetworkState) error {
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	delete(t.scheduled, mpd.MinipoolAddress)
+
+	// Make sure a regular run hasn't staked or dissolved it already
+	mp, err := minipool.NewMinipoolFromVersion(t.rp, mpd.MinipoolAddress, mpd.Version, nil)
+	if err != nil {
+		return fmt.Errorf("cannot create binding for minipool %s: %w", mpd.MinipoolAddress.Hex(), err)
+	}
+	status, err := mp.GetStatus(nil)
+	if err != nil {
+		return fmt.Errorf("error getting minipool status: %w", err)
+	}
+	if status != rptypes.Prelaunch {
+		return nil
+	}
+
+	// Stake it
+	if err := t.w.Reload(); err != nil {
+		return err
+	}
+	success, err := t.stakeMinipool(mpd, state, nil, t.scrubEndGasThreshold)
+	if err != nil || !success {
+		return err
+	}
+	return t.loadValidators([]rptypes.ValidatorPubkey{mpd.Pubkey})
+
+}
+
+// Load the keys of newly staked validators into the VC over its Keymanager API
+func (t *stakePrelaunchMinipools) importValidatorKeys(pubkeys []rptypes.ValidatorPubkey) error {
+
+	// DVT validators are run by the cluster, so they're never loaded into the VC
+	dvtSettings, err := config.LoadDvtSettings(t.cfg.Smartnode.GetDvtSettingsPath())
+	if err != nil {
+		return err
+	}
+	dvtPubkeys := dvtSettings.GetPubkeys()
+	keys := []*eth2types.BLSPrivateKey{}
+	for _, pubkey := range pubkeys {
+		if dvtPubkeys[pubkey] {
+			continue
+		}
+		key, err := t.w.GetValidatorKeyByPubkey(pubkey)
+		if err != nil {
 			return err
 		}
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 {
+		return nil
 	}
 
-	// Return
+	// Import them
+	client, err := keymanager.NewClient(t.cfg)
+	if err != nil {
+		return err
+	}
+	if err := client.ImportValidatorKeys(keys); err != nil {
+		return err
+	}
+	t.log.Printlnf("Loaded %d new validator keys into the Validator client over its Keymanager API.", len(keys))
 	return nil
 
 }
@@ -189,6 +328,8 @@ func (t *stakePrelaunchMinipools) getPrelaunchMinipools(nodeAddress common.Addre
 			remainingTime := creationTime.Add(scrubPeriod).Sub(blockTime)
 			if remainingTime < 0 {
 				prelaunchMinipools = append(prelaunchMinipools, mpd)
+			} else if t.stakeOnScrubEnd {
+				t.scheduleStake(mpd, state, remainingTime)
 			} else {
 				t.log.Printlnf("Minipool %s has %s left until it can be staked.", mpd.MinipoolAddress.Hex(), remainingTime)
 			}
@@ -201,7 +342,7 @@ func (t *stakePrelaunchMinipools) getPrelaunchMinipools(nodeAddress common.Addre
 }
 
 // Stake a minipool
-func (t *stakePrelaunchMinipools) stakeMinipool(mpd *rpstate.NativeMinipoolDetails, state *state.NetworkState, callOpts *bind.CallOpts) (bool, error) {
+func (t *stakePrelaunchMinipools) stakeMinipool(mpd *rpstate.NativeMinipoolDetails, state *state.NetworkState, callOpts *bind.CallOpts, gasThreshold float64) (bool, error) {
 
 	// Log
 	t.log.Printlnf("Staking minipool %s...", mpd.MinipoolAddress.Hex())
@@ -216,7 +357,7 @@ func (t *stakePrelaunchMinipools) stakeMinipool(mpd *rpstate.NativeMinipoolDetai
 
 	// Get the validator key for the minipool
 	validatorPubkey := mpd.Pubkey
-	validatorKey, err := t.w.GetValidatorKeyByPubkey(validatorPubkey)
+	validatorKey, err := dvt.GetValidatorKey(t.cfg, t.w, validatorPubkey)
 	if err != nil {
 		return false, err
 	}
@@ -269,7 +410,7 @@ func (t *stakePrelaunchMinipools) stakeMinipool(mpd *rpstate.NativeMinipoolDetai
 	}
 
 	// Print the gas info
-	if !api.PrintAndCheckGasInfo(gasInfo, true, t.gasThreshold, &t.log, maxFee, t.gasLimit) {
+	if !api.PrintAndCheckGasInfo(gasInfo, true, gasThreshold, &t.log, maxFee, t.gasLimit) {
 		// Check for the timeout buffer
 		prelaunchTime := time.Unix(mpd.StatusTime.Int64(), 0)
 		isDue, timeUntilDue, err := api.IsTransactionDue(t.rp, prelaunchTime)
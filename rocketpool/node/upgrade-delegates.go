@@ -0,0 +1,299 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	rpstate "github.com/rocket-pool/rocketpool-go/utils/state"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/upgrades"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/utils/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// How often to check for a new delegate; they're only released with protocol upgrades
+var delegateUpgradeCheckInterval, _ = time.ParseDuration("1h")
+
+// Upgrade delegates task
+type upgradeDelegates struct {
+	c              *cli.Context
+	log            log.ColorLogger
+	cfg            *config.RocketPoolConfig
+	w              *wallet.Wallet
+	rp             *rocketpool.RocketPool
+	alerts         *alerting.AlertManager
+	nodeAddress    common.Address
+	gasThreshold   float64
+	delay          time.Duration
+	disabled       bool
+	maxFee         *big.Int
+	maxPriorityFee *big.Int
+	lastCheck      time.Time
+}
+
+// Create upgrade delegates task
+func newUpgradeDelegates(c *cli.Context, logger log.ColorLogger, alerts *alerting.AlertManager, nodeAddress common.Address) (*upgradeDelegates, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if auto-upgrading is disabled; it's opt-in, and respects the automatic transaction switch too
+	disabled := cfg.Smartnode.AutoUpgradeDelegates.Value != true
+	gasThreshold := cfg.Smartnode.DelegateUpgradeGasThreshold.Value.(float64)
+	if gasThreshold == 0 {
+		gasThreshold = cfg.Smartnode.AutoTxGasThreshold.Value.(float64)
+	}
+	if !disabled && gasThreshold == 0 {
+		logger.Println("Automatic tx gas threshold is 0, disabling auto-upgrading minipool delegates.")
+		disabled = true
+	}
+
+	// Get the user-requested max fee
+	maxFeeGwei := cfg.Smartnode.ManualMaxFee.Value.(float64)
+	var maxFee *big.Int
+	if maxFeeGwei == 0 {
+		maxFee = nil
+	} else {
+		maxFee = eth.GweiToWei(maxFeeGwei)
+	}
+
+	// Get the user-requested max fee
+	priorityFeeGwei := cfg.Smartnode.PriorityFee.Value.(float64)
+	var priorityFee *big.Int
+	if priorityFeeGwei == 0 {
+		priorityFee = eth.GweiToWei(2)
+	} else {
+		priorityFee = eth.GweiToWei(priorityFeeGwei)
+	}
+
+	// Return task
+	return &upgradeDelegates{
+		c:              c,
+		log:            logger,
+		cfg:            cfg,
+		w:              w,
+		rp:             rp,
+		alerts:         alerts,
+		nodeAddress:    nodeAddress,
+		gasThreshold:   gasThreshold,
+		delay:          time.Duration(cfg.Smartnode.DelegateUpgradeDelay.Value.(uint64)) * 24 * time.Hour,
+		disabled:       disabled,
+		maxFee:         maxFee,
+		maxPriorityFee: priorityFee,
+	}, nil
+
+}
+
+// Upgrade the node's minipools to the latest delegate once it has been out for long enough
+func (t *upgradeDelegates) run(state *state.NetworkState) error {
+
+	if t.disabled || time.Since(t.lastCheck) < delegateUpgradeCheckInterval {
+		return nil
+	}
+	t.lastCheck = time.Now()
+
+	// Get the minipools that aren't on the latest delegate
+	opts := &bind.CallOpts{
+		BlockNumber: big.NewInt(0).SetUint64(state.ElBlockNumber),
+	}
+	latestDelegate, err := t.rp.GetAddress("rocketMinipoolDelegate", opts)
+	if err != nil {
+		return fmt.Errorf("error getting the latest minipool delegate: %w", err)
+	}
+	minipools := []*rpstate.NativeMinipoolDetails{}
+	for _, mpd := range state.MinipoolDetailsByNode[t.nodeAddress] {
+		if mpd.Finalised || mpd.UseLatestDelegate || mpd.Delegate == *latestDelegate {
+			continue
+		}
+		minipools = append(minipools, mpd)
+	}
+	if len(minipools) == 0 {
+		return nil
+	}
+
+	// Wait until the delegate has been out for the waiting period
+	t.log.Printlnf("%d minipool(s) can be upgraded to delegate %s...", len(minipools), latestDelegate.Hex())
+	releaseTime, ready, err := t.getReleaseTime(*latestDelegate, state.ElBlockNumber)
+	if err != nil {
+		return err
+	}
+	if !ready {
+		t.log.Printlnf("The contract upgrade history hasn't caught up with block %d yet; waiting for it before checking when the delegate was released.", state.ElBlockNumber)
+		return nil
+	}
+	if wait := time.Until(releaseTime.Add(t.delay)); wait > 0 {
+		t.log.Printlnf("The delegate was released on %s; waiting another %s before upgrading to it.", releaseTime.Format(time.RFC1123), wait.Round(time.Minute))
+		return nil
+	}
+
+	// Upgrade the minipools, stopping at the first one that fails so a broken upgrade isn't repeated on all of them
+	upgraded := []string{}
+	var upgradeErr error
+	for _, mpd := range minipools {
+		success, err := t.upgradeDelegate(mpd, opts)
+		if err != nil {
+			upgradeErr = fmt.Errorf("error upgrading the delegate of minipool %s: %w", mpd.MinipoolAddress.Hex(), err)
+			break
+		}
+		if !success {
+			break
+		}
+		upgraded = append(upgraded, mpd.MinipoolAddress.Hex())
+	}
+
+	// Summarize what was upgraded
+	if len(upgraded) > 0 || upgradeErr != nil {
+		message := fmt.Sprintf("Upgraded %d of %d minipool(s) to delegate %s", len(upgraded), len(minipools), latestDelegate.Hex())
+		if len(upgraded) > 0 {
+			message += fmt.Sprintf(": %s", strings.Join(upgraded, ", "))
+		}
+		message += "."
+		severity := alerting.Severity_Info
+		if upgradeErr != nil {
+			severity = alerting.Severity_Warning
+			message += fmt.Sprintf(" The rest were not upgraded because of an error: %s. Run `rocketpool minipool delegate status` to check them.", upgradeErr.Error())
+		}
+		t.alerts.Raise(alerting.Alert{
+			Rule:     alerting.Rule_DelegateUpgraded,
+			Subject:  fmt.Sprintf("%s/%d", latestDelegate.Hex(), len(upgraded)),
+			Severity: severity,
+			Title:    "Minipool delegates upgraded",
+			Message:  message,
+		})
+	}
+	return upgradeErr
+
+}
+
+// Get the time the delegate was released. A delegate in the contract upgrade history was released in its upgrade block; any
+// other delegate, such as one from blocks the history hasn't scanned yet or one set without a ContractUpgraded event, counts as
+// released when this node first saw it, so the waiting period is never skipped. Returns false if the history doesn't cover the
+// state's block yet.
+func (t *upgradeDelegates) getReleaseTime(delegate common.Address, stateBlock uint64) (time.Time, bool, error) {
+
+	// Record the first sighting before checking the history, so a failed run doesn't push it back
+	sightingsPath := t.cfg.Smartnode.GetDelegateSightingsPath()
+	sightings, err := upgrades.LoadDelegateSightings(sightingsPath)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	firstSeen, isNew := sightings.Record(delegate, time.Now())
+	if isNew {
+		if err := sightings.Save(sightingsPath); err != nil {
+			return time.Time{}, false, err
+		}
+	}
+
+	// Update the history; it only scans blocks that are confirmationDepth deep, so it has caught up once it reaches that far behind the state
+	eventLogInterval, err := t.cfg.GetEventLogInterval()
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	confirmationDepth := t.cfg.Smartnode.ConfirmationDepth.Value.(uint64)
+	history, err := upgrades.UpdateHistory(t.rp, t.cfg.Smartnode.GetUpgradeHistoryPath(), big.NewInt(int64(eventLogInterval)), confirmationDepth, nil)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("error updating the contract upgrade history: %w", err)
+	}
+	if history.ScannedBlock+confirmationDepth < stateBlock {
+		return time.Time{}, false, nil
+	}
+
+	// Use the upgrade block if there is one
+	block, exists := history.GetUpgradeBlock("rocketMinipoolDelegate", delegate)
+	if !exists {
+		return firstSeen, true, nil
+	}
+	header, err := t.rp.Client.HeaderByNumber(context.Background(), big.NewInt(0).SetUint64(block))
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("error getting header for block %d: %w", block, err)
+	}
+	return time.Unix(int64(header.Time), 0), true, nil
+
+}
+
+// Upgrade a minipool's delegate. Returns false if the gas price is too high to do it now.
+func (t *upgradeDelegates) upgradeDelegate(mpd *rpstate.NativeMinipoolDetails, callOpts *bind.CallOpts) (bool, error) {
+
+	// Log
+	t.log.Printlnf("Upgrading the delegate of minipool %s...", mpd.MinipoolAddress.Hex())
+
+	mp, err := minipool.NewMinipoolFromVersion(t.rp, mpd.MinipoolAddress, mpd.Version, callOpts)
+	if err != nil {
+		return false, fmt.Errorf("cannot create binding for minipool %s: %w", mpd.MinipoolAddress.Hex(), err)
+	}
+
+	// Get transactor
+	opts, err := t.w.GetNodeAccountTransactor()
+	if err != nil {
+		return false, err
+	}
+
+	// Get the gas limit
+	gasInfo, err := mp.EstimateDelegateUpgradeGas(opts)
+	if err != nil {
+		return false, fmt.Errorf("Could not estimate the gas required to upgrade the delegate of minipool %s: %w", mpd.MinipoolAddress.Hex(), err)
+	}
+
+	// Get the max fee
+	maxFee := t.maxFee
+	if maxFee == nil || maxFee.Uint64() == 0 {
+		maxFee, err = rpgas.GetHeadlessMaxFeeWei()
+		if err != nil {
+			return false, err
+		}
+	}
+
+	// Print the gas info
+	if !api.PrintAndCheckGasInfo(gasInfo, true, t.gasThreshold, &t.log, maxFee, 0) {
+		return false, nil
+	}
+
+	opts.GasFeeCap = maxFee
+	opts.GasTipCap = t.maxPriorityFee
+	opts.GasLimit = gasInfo.SafeGasLimit
+
+	// Upgrade the delegate
+	hash, err := mp.DelegateUpgrade(opts)
+	if err != nil {
+		return false, err
+	}
+
+	// Print TX info and wait for it to be included in a block
+	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, &t.log)
+	if err != nil {
+		return false, err
+	}
+
+	// Log
+	t.log.Printlnf("Successfully upgraded the delegate of minipool %s.", mpd.MinipoolAddress.Hex())
+
+	// Return
+	return true, nil
+
+}
@@ -0,0 +1,212 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/rocketpool/node/collectors"
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/tasks"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// How long to wait before resubscribing after the websocket connection drops
+var assignmentResubscribeDelay, _ = time.ParseDuration("1m")
+
+// Watch minipool assignments task
+type watchAssignments struct {
+	c           *cli.Context
+	log         log.ColorLogger
+	errLog      log.ColorLogger
+	cfg         *config.RocketPoolConfig
+	rp          *rocketpool.RocketPool
+	w           *wallet.Wallet
+	stateLocker *collectors.StateLocker
+	alerts      *alerting.AlertManager
+	nodeAddress common.Address
+	wsUrl       string
+}
+
+// Create watch minipool assignments task
+func newWatchAssignments(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, stateLocker *collectors.StateLocker, alerts *alerting.AlertManager, nodeAddress common.Address) (*watchAssignments, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &watchAssignments{
+		c:           c,
+		log:         logger,
+		errLog:      errorLogger,
+		cfg:         cfg,
+		rp:          rp,
+		w:           w,
+		stateLocker: stateLocker,
+		alerts:      alerts,
+		nodeAddress: nodeAddress,
+		wsUrl:       getExecutionWebsocketUrl(cfg),
+	}, nil
+
+}
+
+// Get the URL of the execution client's websocket API, or an empty string if there isn't one
+func getExecutionWebsocketUrl(cfg *config.RocketPoolConfig) string {
+	if cfg.IsNativeMode {
+		return ""
+	}
+	if cfg.ExecutionClientMode.Value.(cfgtypes.Mode) == cfgtypes.Mode_Local {
+		return fmt.Sprintf("ws://%s:%d", config.Eth1ContainerName, cfg.ExecutionCommon.WsPort.Value)
+	}
+	return cfg.ExternalExecution.WsUrl.Value.(string)
+}
+
+// Start watching for deposit assignments in the background, resubscribing whenever the connection drops.
+// The monitor-queue task still notices assignments on its regular runs if the watcher isn't available.
+func (t *watchAssignments) start(guard *tasks.CrashGuard) {
+	if t.wsUrl == "" {
+		t.log.Println("The execution client has no websocket URL, so minipool assignments will only be noticed by the regular status checks.")
+		return
+	}
+	go func() {
+		for {
+			if err := guard.Run("watch-assignments", t.watch); err != nil {
+				t.errLog.Printlnf("Error watching for minipool assignments: %s", err.Error())
+			}
+			time.Sleep(assignmentResubscribeDelay)
+		}
+	}()
+}
+
+// Subscribe to the deposit pool's assignment events and handle them until the subscription fails
+func (t *watchAssignments) watch() error {
+
+	// Get the event to subscribe to
+	rocketDepositPool, err := t.rp.GetContract("rocketDepositPool", nil)
+	if err != nil {
+		return err
+	}
+	depositAssigned, exists := rocketDepositPool.ABI.Events["DepositAssigned"]
+	if !exists {
+		return fmt.Errorf("rocketDepositPool does not have a DepositAssigned event")
+	}
+
+	// Subscribe over the websocket API
+	client, err := ethclient.Dial(t.wsUrl)
+	if err != nil {
+		return fmt.Errorf("error connecting to the execution client's websocket API at [%s]: %w", t.wsUrl, err)
+	}
+	defer client.Close()
+	logs := make(chan ethtypes.Log)
+	query := ethereum.FilterQuery{
+		Addresses: []common.Address{*rocketDepositPool.Address},
+		Topics:    [][]common.Hash{{depositAssigned.ID}},
+	}
+	subscription, err := client.SubscribeFilterLogs(context.Background(), query, logs)
+	if err != nil {
+		return fmt.Errorf("error subscribing to deposit assignments: %w", err)
+	}
+	defer subscription.Unsubscribe()
+	t.log.Println("Watching for minipool assignments.")
+
+	// Handle the assignments
+	for {
+		select {
+		case err := <-subscription.Err():
+			return err
+		case log := <-logs:
+			if log.Removed || len(log.Topics) < 2 {
+				continue
+			}
+			minipoolAddress := common.BytesToAddress(log.Topics[1].Bytes())
+			values := make(map[string]interface{})
+			if err := depositAssigned.Inputs.UnpackIntoMap(values, log.Data); err != nil {
+				t.errLog.Printlnf("Error unpacking deposit assignment in block %d: %s", log.BlockNumber, err.Error())
+				continue
+			}
+			amount, _ := values["amount"].(*big.Int)
+			assignedTime, _ := values["time"].(*big.Int)
+			t.handleAssignment(minipoolAddress, amount, assignedTime, log.BlockNumber)
+		}
+	}
+
+}
+
+// Check if an assigned minipool is one of the node's queued minipools, and if so, check that it's ready for its second deposit
+// and notify the node operator right away
+func (t *watchAssignments) handleAssignment(minipoolAddress common.Address, amount *big.Int, assignedTime *big.Int, block uint64) {
+
+	// Find the minipool in the node's queue
+	state := t.stateLocker.GetState()
+	if state == nil {
+		return
+	}
+	var pubkey types.ValidatorPubkey
+	found := false
+	for _, mpd := range state.MinipoolDetailsByNode[t.nodeAddress] {
+		if mpd.MinipoolAddress == minipoolAddress && mpd.Status == types.Initialized {
+			pubkey = mpd.Pubkey
+			found = true
+			break
+		}
+	}
+	if !found {
+		return
+	}
+	t.log.Printlnf("Minipool %s was assigned from the deposit pool in block %d.", minipoolAddress.Hex(), block)
+
+	// Get when it can be staked
+	assigned := time.Now()
+	if assignedTime != nil {
+		assigned = time.Unix(assignedTime.Int64(), 0)
+	}
+	stakeTime := assigned.Add(state.NetworkDetails.ScrubPeriod)
+	message := fmt.Sprintf("Minipool %s has been matched with", minipoolAddress.Hex())
+	if amount != nil {
+		message += fmt.Sprintf(" %.2f ETH", eth.WeiToEth(amount))
+	} else {
+		message += " ETH"
+	}
+	message += fmt.Sprintf(" from the deposit pool and left the queue. The node will stake it once the scrub check has passed, at %s.", stakeTime.Format(time.RFC1123))
+
+	// Make sure the node will be able to stake it
+	severity := alerting.Severity_Info
+	if _, err := t.w.GetValidatorKeyByPubkey(pubkey); err != nil {
+		severity = alerting.Severity_Warning
+		message += fmt.Sprintf(" WARNING: the node wallet doesn't have the validator key for it (%s), so it can't be staked. Run `rocketpool wallet rebuild` to restore it before then.", err.Error())
+	}
+
+	t.alerts.Raise(alerting.Alert{
+		Rule:     alerting.Rule_MinipoolAssigned,
+		Subject:  minipoolAddress.Hex(),
+		Severity: severity,
+		Title:    "Minipool assigned",
+		Message:  message,
+	})
+
+}
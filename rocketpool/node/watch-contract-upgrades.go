@@ -0,0 +1,57 @@
+package node
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/registry"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Watch contract upgrades task
+type watchContractUpgrades struct {
+	c        *cli.Context
+	log      log.ColorLogger
+	registry *registry.Registry
+	loaded   bool
+}
+
+// Create watch contract upgrades task
+func newWatchContractUpgrades(c *cli.Context, logger log.ColorLogger) (*watchContractUpgrades, error) {
+
+	// Get services
+	registry, err := services.GetContractRegistry(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &watchContractUpgrades{
+		c:        c,
+		log:      logger,
+		registry: registry,
+	}, nil
+
+}
+
+// Reload the bindings of any contract upgraded since the last run, so the other tasks don't use the old ones
+func (t *watchContractUpgrades) run() error {
+
+	upgraded, err := t.registry.Refresh(nil)
+	if err != nil {
+		return fmt.Errorf("error checking for contract upgrades: %w", err)
+	}
+	if !t.loaded {
+		t.log.Printlnf("Loaded %d Rocket Pool contracts.", len(t.registry.GetContracts()))
+		t.loaded = true
+	}
+	if len(upgraded) > 0 {
+		t.log.Printlnf("Rocket Pool contracts were upgraded, reloaded %s.", strings.Join(upgraded, ", "))
+	}
+
+	return nil
+
+}
@@ -8,6 +8,8 @@ import (
 
 	"github.com/rocket-pool/smartnode/rocketpool/api"
 	"github.com/rocket-pool/smartnode/rocketpool/node"
+	"github.com/rocket-pool/smartnode/rocketpool/simulate"
+	"github.com/rocket-pool/smartnode/rocketpool/treefixture"
 	"github.com/rocket-pool/smartnode/rocketpool/watchtower"
 	"github.com/rocket-pool/smartnode/shared"
 	apiutils "github.com/rocket-pool/smartnode/shared/utils/api"
@@ -76,6 +78,16 @@ func main() {
 			Usage: "Port to serve metrics on if enabled",
 			Value: 9102,
 		},
+		cli.StringFlag{
+			Name:  "healthAddress",
+			Usage: "Address to serve the daemon health check endpoints (/healthz and /readyz) on",
+			Value: "0.0.0.0",
+		},
+		cli.UintFlag{
+			Name:  "healthPort",
+			Usage: "Port to serve the daemon health check endpoints (/healthz and /readyz) on, overriding the one in the config; leave it at 0 to use the config",
+			Value: 0,
+		},
 		cli.BoolFlag{
 			Name:  "ignore-sync-check",
 			Usage: "Set this to true if you already checked the sync status of the execution client(s) and don't need to re-check it for this command",
@@ -94,6 +106,8 @@ func main() {
 	api.RegisterCommands(app, "api", []string{"a"})
 	node.RegisterCommands(app, "node", []string{"n"})
 	watchtower.RegisterCommands(app, "watchtower", []string{"w"})
+	simulate.RegisterCommands(app, "simulate", []string{})
+	treefixture.RegisterCommands(app, "tree-fixture", []string{})
 
 	// Get command being run
 	var commandName string
@@ -0,0 +1,119 @@
+package simulate
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/fatih/color"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/simulator"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Register simulate command
+func RegisterCommands(app *cli.App, name string, aliases []string) {
+	app.Commands = append(app.Commands, cli.Command{
+		Name:    name,
+		Aliases: aliases,
+		Usage:   "Run simulated Execution and Beacon clients for developing the Smartnode without a synced node",
+		Hidden:  true,
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  "ec-address",
+				Usage: "The address the simulated Execution client listens on",
+				Value: "127.0.0.1:8545",
+			},
+			cli.StringFlag{
+				Name:  "fallback-ec-address",
+				Usage: "The address a second simulated Execution client listens on, for developing fallback support; leave blank to only run one",
+			},
+			cli.StringFlag{
+				Name:  "bc-address",
+				Usage: "The address the simulated Beacon node listens on",
+				Value: "127.0.0.1:5052",
+			},
+			cli.Uint64Flag{
+				Name:  "chain-id",
+				Usage: "The chain ID of the simulated network",
+				Value: 17000,
+			},
+			cli.StringFlag{
+				Name:  "deposit-contract",
+				Usage: "The address of the Beacon deposit contract the simulated Beacon node reports",
+				Value: "0x4242424242424242424242424242424242424242",
+			},
+			cli.BoolFlag{
+				Name:  "syncing",
+				Usage: "Report that the clients are still syncing",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			return run(c)
+		},
+	})
+}
+
+// Run the simulated clients until interrupted, adding a block and a slot every 12 seconds
+func run(c *cli.Context) error {
+
+	logger := log.NewColorLogger(color.FgHiCyan)
+	chainID := c.Uint64("chain-id")
+	genesisTime := time.Now()
+
+	// Create the clients
+	ecs := []*simulator.ExecutionServer{simulator.NewExecutionServer(c.String("ec-address"), chainID, genesisTime)}
+	if address := c.String("fallback-ec-address"); address != "" {
+		ecs = append(ecs, simulator.NewExecutionServer(address, chainID, genesisTime))
+	}
+	bc := simulator.NewBeaconServer(c.String("bc-address"), chainID, common.HexToAddress(c.String("deposit-contract")), genesisTime)
+	if c.Bool("syncing") {
+		for _, ec := range ecs {
+			ec.SetSyncing(&simulator.ExecutionSyncStatus{HighestBlock: 1})
+		}
+		bc.SetSyncing(true, 1)
+	}
+
+	// Start them
+	for _, ec := range ecs {
+		if err := ec.Start(); err != nil {
+			return err
+		}
+		logger.Printlnf("Simulated Execution client listening at %s (chain ID %d).", ec.Url(), chainID)
+	}
+	if err := bc.Start(); err != nil {
+		return err
+	}
+	logger.Printlnf("Simulated Beacon node listening at %s.", bc.Url())
+
+	// Advance the chain until interrupted
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, syscall.SIGINT, syscall.SIGTERM)
+	ticker := time.NewTicker(12 * time.Second)
+	defer ticker.Stop()
+	slot := uint64(0)
+	for {
+		select {
+		case <-ticker.C:
+			slot++
+			for _, ec := range ecs {
+				ec.Mine(1)
+			}
+			bc.SetHeadSlot(slot)
+		case <-interrupt:
+			for _, ec := range ecs {
+				_ = ec.Stop()
+			}
+			if err := bc.Stop(); err != nil {
+				return fmt.Errorf("error stopping simulated Beacon node: %w", err)
+			}
+			logger.Println("Stopped the simulated clients.")
+			return nil
+		}
+	}
+
+}
@@ -0,0 +1,176 @@
+package treefixture
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/fatih/color"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rewards/fixture"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Register tree-fixture command
+func RegisterCommands(app *cli.App, name string, aliases []string) {
+	app.Commands = append(app.Commands, cli.Command{
+		Name:    name,
+		Aliases: aliases,
+		Usage:   "Record the network state and client responses a rewards tree is generated from, and regenerate the tree from them without any clients",
+		Hidden:  true,
+		Subcommands: []cli.Command{
+			{
+				Name:      "record",
+				Usage:     "Generate the rewards tree for an interval and save its network state and every Execution and Beacon client response the generator used to a fixture",
+				UsageText: "rocketpool tree-fixture record --index index --ec-url url --bc-url url [options]",
+				Flags: []cli.Flag{
+					cli.Uint64Flag{
+						Name:  "index, i",
+						Usage: "The rewards interval to generate the tree for",
+					},
+					cli.StringFlag{
+						Name:  "network, n",
+						Usage: "The network the interval is on",
+						Value: string(cfgtypes.Network_Mainnet),
+					},
+					cli.StringFlag{
+						Name:  "ec-url, e",
+						Usage: "The URL of an archive Execution client",
+					},
+					cli.StringFlag{
+						Name:  "bc-url, b",
+						Usage: "The URL of a Beacon node with the states of the interval",
+					},
+					cli.Uint64Flag{
+						Name:  "ruleset, r",
+						Usage: "The ruleset to generate the tree with; leave unset to use the interval's own ruleset",
+					},
+					cli.StringFlag{
+						Name:  "output, o",
+						Usage: "The path to save the fixture to; defaults to rewards-<network>-<index>.fixture.gz in the current directory",
+					},
+				},
+				Action: func(c *cli.Context) error {
+					return record(c)
+				},
+			},
+
+			{
+				Name:      "replay",
+				Usage:     "Regenerate a rewards tree purely from a fixture and check that it matches the recorded one byte-for-byte",
+				UsageText: "rocketpool tree-fixture replay --fixture path [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "fixture, f",
+						Usage: "The path of the fixture to replay",
+					},
+					cli.Uint64Flag{
+						Name:  "ruleset, r",
+						Usage: "The ruleset to regenerate the tree with; leave unset to use the one the fixture was recorded with",
+					},
+					cli.StringFlag{
+						Name:  "output-dir, o",
+						Usage: "A directory to save the regenerated rewards file and minipool performance file to",
+					},
+				},
+				Action: func(c *cli.Context) error {
+					return replay(c)
+				},
+			},
+		},
+	})
+}
+
+// Generate the tree for an interval against live clients and record everything they were asked
+func record(c *cli.Context) error {
+
+	logger := log.NewColorLogger(color.FgHiCyan)
+	index := c.Uint64("index")
+	if !c.IsSet("index") {
+		return fmt.Errorf("the rewards interval index is required")
+	}
+	ecUrl := c.String("ec-url")
+	bcUrl := c.String("bc-url")
+	if ecUrl == "" || bcUrl == "" {
+		return fmt.Errorf("the Execution client and Beacon node URLs are required")
+	}
+	network := cfgtypes.Network(c.String("network"))
+	outputPath := c.String("output")
+	if outputPath == "" {
+		outputPath = fmt.Sprintf("rewards-%s-%d.fixture.gz", network, index)
+	}
+
+	// Get the interval and its state, then generate the tree with recording clients
+	interval, networkState, err := fixture.GetInterval(&logger, network, index, ecUrl, bcUrl)
+	if err != nil {
+		return err
+	}
+	treeFixture, files, err := fixture.Record(&logger, network, interval, networkState, c.Uint64("ruleset"), ecUrl, bcUrl)
+	if err != nil {
+		return err
+	}
+
+	// Save the fixture
+	if err := treeFixture.Save(outputPath); err != nil {
+		return err
+	}
+	logger.Printlnf("Saved the state and %d client responses for interval %d (ruleset v%d, Merkle root %s) to %s.", len(treeFixture.Exchanges), index, files.RulesetVersion, files.MerkleRoot, outputPath)
+	return nil
+
+}
+
+// Regenerate the tree from a fixture and compare it to the recorded one
+func replay(c *cli.Context) error {
+
+	logger := log.NewColorLogger(color.FgHiCyan)
+	fixturePath := c.String("fixture")
+	if fixturePath == "" {
+		return fmt.Errorf("the fixture path is required")
+	}
+	treeFixture, err := fixture.Load(fixturePath)
+	if err != nil {
+		return err
+	}
+	index := treeFixture.Interval.Index
+
+	// Regenerate the tree with replaying clients
+	files, err := treeFixture.Regenerate(&logger, c.Uint64("ruleset"))
+	if err != nil {
+		return err
+	}
+
+	// Save the regenerated files
+	if outputDir := c.String("output-dir"); outputDir != "" {
+		if err := os.MkdirAll(outputDir, 0755); err != nil {
+			return fmt.Errorf("error creating output directory [%s]: %w", outputDir, err)
+		}
+		rewardsPath := filepath.Join(outputDir, fmt.Sprintf(config.RewardsTreeFilenameFormat, string(treeFixture.Network), index))
+		if err := os.WriteFile(rewardsPath, files.RewardsFile, 0644); err != nil {
+			return fmt.Errorf("error saving rewards file to %s: %w", rewardsPath, err)
+		}
+		performancePath := filepath.Join(outputDir, fmt.Sprintf(config.MinipoolPerformanceFilenameFormat, string(treeFixture.Network), index))
+		if err := os.WriteFile(performancePath, files.MinipoolPerformanceFile, 0644); err != nil {
+			return fmt.Errorf("error saving minipool performance file to %s: %w", performancePath, err)
+		}
+		logger.Printlnf("Saved the regenerated files to %s.", outputDir)
+	}
+
+	// Compare them to the recorded ones
+	if files.RulesetVersion != treeFixture.RulesetVersion {
+		logger.Printlnf("Regenerated interval %d with ruleset v%d instead of the recorded v%d; Merkle root %s (recorded %s).", index, files.RulesetVersion, treeFixture.RulesetVersion, files.MerkleRoot, treeFixture.MerkleRoot)
+		return nil
+	}
+	mismatches := treeFixture.Compare(files)
+	if len(mismatches) > 0 {
+		for _, mismatch := range mismatches {
+			logger.Printlnf("MISMATCH: %s", mismatch)
+		}
+		return fmt.Errorf("the regenerated tree for interval %d doesn't match the fixture", index)
+	}
+	logger.Printlnf("The regenerated tree for interval %d matches the fixture byte-for-byte (ruleset v%d, Merkle root %s).", index, files.RulesetVersion, files.MerkleRoot)
+	return nil
+
+}
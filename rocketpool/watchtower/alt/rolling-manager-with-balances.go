@@ -895,7 +895,7 @@ func (r *RollingRecordManager_MergedDuties) LoadBestRecordFromDisk(startSlot uin
 		fullFilename := filepath.Join(recordsPath, filename)
 		record, err := r.loadRecordFromFile(fullFilename, checksum)
 		if err != nil {
-			r.log.Printlnf("%s WARNING: error loading record from file [%s]: %s... attempting previous file", r.logPrefix, fullFilename, err.Error())
+			r.log.Warnf("%s WARNING: error loading record from file [%s]: %s... attempting previous file", r.logPrefix, fullFilename, err.Error())
 			continue
 		}
 
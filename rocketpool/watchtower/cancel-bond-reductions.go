@@ -18,6 +18,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/tasks"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
@@ -40,10 +41,11 @@ type cancelBondReductions struct {
 	lock             *sync.Mutex
 	isRunning        bool
 	generationPrefix string
+	guard            *tasks.CrashGuard
 }
 
 // Create cancel bond reductions task
-func newCancelBondReductions(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, coll *collectors.BondReductionCollector) (*cancelBondReductions, error) {
+func newCancelBondReductions(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, coll *collectors.BondReductionCollector, guard *tasks.CrashGuard) (*cancelBondReductions, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -77,6 +79,7 @@ func newCancelBondReductions(c *cli.Context, logger log.ColorLogger, errorLogger
 		lock:             lock,
 		isRunning:        false,
 		generationPrefix: "[Bond Reduction]",
+		guard:            guard,
 	}, nil
 
 }
@@ -105,7 +108,7 @@ func (t *cancelBondReductions) run(state *state.NetworkState) error {
 	t.lock.Unlock()
 
 	// Run the check
-	go func() {
+	t.guard.Go("cancel-bond-reductions", func() {
 		t.lock.Lock()
 		t.isRunning = true
 		t.lock.Unlock()
@@ -120,7 +123,7 @@ func (t *cancelBondReductions) run(state *state.NetworkState) error {
 		t.lock.Lock()
 		t.isRunning = false
 		t.lock.Unlock()
-	}()
+	}, t.handleError)
 
 	// Return
 	return nil
@@ -0,0 +1,271 @@
+package watchtower
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+	"github.com/rocket-pool/rocketpool-go/dao/trustednode"
+	"github.com/rocket-pool/rocketpool-go/rewards"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/settings/protocol"
+
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/logscan"
+	"github.com/rocket-pool/smartnode/shared/services/reorg"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Settings
+const rewardsConsensusDiffFilenameFormat string = "rewards-consensus-%d.json"
+
+// Returned instead of submitting a rewards snapshot that was held because another root has the Oracle DAO's consensus
+var errSubmissionHeld = errors.New("the rewards submission was held because a different root has the Oracle DAO's consensus")
+
+// Checks the rewards roots the other Oracle DAO members have submitted for an interval before this node submits its own,
+// so a node that built a different tree than the rest of the DAO holds its vote instead of cementing a wrong one
+type rewardsConsensusCheck struct {
+	log    *log.ColorLogger
+	cfg    *config.RocketPoolConfig
+	rp     *rocketpool.RocketPool
+	alerts *alerting.AlertManager
+}
+
+// A rewards submission made by an Oracle DAO member
+type memberRewardsSubmission struct {
+	Member     common.Address           `json:"member"`
+	Block      uint64                   `json:"block"`
+	Submission rewards.RewardSubmission `json:"-"`
+}
+
+// The diagnostics saved when this node's rewards root disagrees with the rest of the Oracle DAO
+type rewardsConsensusDiff struct {
+	Index         uint64                    `json:"index"`
+	LocalRoot     common.Hash               `json:"localRoot"`
+	MajorityRoot  common.Hash               `json:"majorityRoot"`
+	Votes         map[common.Hash]int       `json:"votes"`
+	Submissions   []memberRewardsSubmission `json:"submissions"`
+	Local         map[string]string         `json:"local"`
+	Majority      map[string]string         `json:"majority"`
+	ChangedFields []string                  `json:"changedFields"`
+}
+
+// Create a rewards consensus check
+func newRewardsConsensusCheck(logger *log.ColorLogger, cfg *config.RocketPoolConfig, rp *rocketpool.RocketPool, alerts *alerting.AlertManager) *rewardsConsensusCheck {
+	return &rewardsConsensusCheck{
+		log:    logger,
+		cfg:    cfg,
+		rp:     rp,
+		alerts: alerts,
+	}
+}
+
+// Compare the node's submission with the ones the members have already made for the interval.
+// Returns false if a different root already has the Oracle DAO's consensus or is one vote away from it while outvoting the node's root,
+// in which case the submission should be held; a root that's merely ahead with a few early votes doesn't stop the node from voting.
+func (r *rewardsConsensusCheck) check(nodeAddress common.Address, submission rewards.RewardSubmission) (bool, error) {
+
+	index := submission.RewardIndex.Uint64()
+	localRoot := common.BytesToHash(submission.MerkleRoot[:])
+	submissions, err := r.getMemberSubmissions(submission.RewardIndex, submission.ExecutionBlock)
+	if err != nil {
+		return false, fmt.Errorf("error getting the rewards submissions of other Oracle DAO members: %w", err)
+	}
+	requiredVotes, err := r.getRequiredVotes()
+	if err != nil {
+		return false, err
+	}
+
+	// Count the votes for each root, including the one this node is about to make
+	votes := map[common.Hash]int{}
+	majoritySubmissions := map[common.Hash]rewards.RewardSubmission{}
+	others := []memberRewardsSubmission{}
+	for _, member := range submissions {
+		root := common.BytesToHash(member.Submission.MerkleRoot[:])
+		if member.Member == nodeAddress {
+			if root != localRoot {
+				votes[root]++
+			}
+			continue
+		}
+		votes[root]++
+		majoritySubmissions[root] = member.Submission
+		others = append(others, member)
+	}
+	votes[localRoot]++
+	majorityRoot := localRoot
+	for root, count := range votes {
+		if count > votes[majorityRoot] {
+			majorityRoot = root
+		}
+	}
+
+	// Submit unless another root outvotes this node's and has reached consensus or is one vote short of it
+	isOutlier := majorityRoot != localRoot && votes[majorityRoot] > votes[localRoot] && votes[majorityRoot]+1 >= requiredVotes
+	alert := alerting.Alert{
+		Rule:     alerting.Rule_RewardsRootOutlier,
+		Subject:  fmt.Sprint(index),
+		Severity: alerting.Severity_Critical,
+		Title:    fmt.Sprintf("Rewards root for interval %d disagrees with the Oracle DAO", index),
+	}
+	if !isOutlier {
+		if len(others) > 0 {
+			r.log.Printlnf("%d of %d other Oracle DAO members have submitted the same rewards root for interval %d (%d votes are needed for consensus).", votes[localRoot]-1, len(others), index, requiredVotes)
+		}
+		r.alerts.Update(alert, false)
+		return true, nil
+	}
+
+	// Save the diagnostics
+	diffPath, err := r.saveDiff(index, localRoot, majorityRoot, votes, others, submission, majoritySubmissions[majorityRoot])
+	if err != nil {
+		r.log.Warnf("WARNING: couldn't save the rewards consensus diagnostics: %s", err.Error())
+	}
+
+	r.log.Println("=== REWARDS ROOT DISAGREES WITH THE ORACLE DAO ===")
+	r.log.Printlnf("This node's root for interval %d is %s, which %d other member(s) agree with.", index, localRoot.Hex(), votes[localRoot]-1)
+	r.log.Printlnf("%d other member(s) submitted %s instead, and %d votes are needed for consensus.", votes[majorityRoot], majorityRoot.Hex(), requiredVotes)
+	r.log.Println("The submission will be held until the roots agree. Check the rewards tree this node generated before regenerating it.")
+	if diffPath != "" {
+		r.log.Printlnf("The submissions have been compared in %s.", diffPath)
+	}
+	alert.Message = fmt.Sprintf("This node's rewards root for interval %d (%s) has %d vote(s) from other Oracle DAO members, but %s has %d of the %d needed for consensus. The node is holding its submission instead of voting for it; check its rewards tree. Diagnostics were saved to %s.", index, localRoot.Hex(), votes[localRoot]-1, majorityRoot.Hex(), votes[majorityRoot], requiredVotes, diffPath)
+	r.alerts.Update(alert, true)
+	return false, nil
+
+}
+
+// Get the number of matching votes a rewards root needs to reach the Oracle DAO's consensus
+func (r *rewardsConsensusCheck) getRequiredVotes() (int, error) {
+	memberCount, err := trustednode.GetMemberCount(r.rp, nil)
+	if err != nil {
+		return 0, fmt.Errorf("error getting the Oracle DAO member count: %w", err)
+	}
+	threshold, err := protocol.GetNodeConsensusThreshold(r.rp, nil)
+	if err != nil {
+		return 0, fmt.Errorf("error getting the node consensus threshold: %w", err)
+	}
+	required := 1
+	for required < int(memberCount) && float64(required)/float64(memberCount) < threshold {
+		required++
+	}
+	return required, nil
+}
+
+// Get the rewards submissions made for an interval, keeping the latest one from each member
+func (r *rewardsConsensusCheck) getMemberSubmissions(index *big.Int, fromBlock *big.Int) ([]memberRewardsSubmission, error) {
+	rocketRewardsPool, err := r.rp.GetContract("rocketRewardsPool", nil)
+	if err != nil {
+		return nil, err
+	}
+	submittedEvent, exists := rocketRewardsPool.ABI.Events["RewardSnapshotSubmitted"]
+	if !exists {
+		return nil, fmt.Errorf("rocketRewardsPool does not have a RewardSnapshotSubmitted event")
+	}
+	eventLogInterval, err := r.cfg.GetEventLogInterval()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the logs for the interval, which can only be submitted after its execution block; submissions that aren't
+	// buried deep enough yet could still be reorged out, so they aren't counted
+	confirmedBlock, confirmed, err := reorg.GetConfirmedBlock(context.Background(), r.rp.Client, r.cfg.Smartnode.ConfirmationDepth.Value.(uint64))
+	if err != nil {
+		return nil, err
+	}
+	if !confirmed || confirmedBlock < fromBlock.Uint64() {
+		return []memberRewardsSubmission{}, nil
+	}
+	indexBytes := [32]byte{}
+	index.FillBytes(indexBytes[:])
+	topicFilter := [][]common.Hash{{submittedEvent.ID}, {}, {indexBytes}}
+	logs, err := logscan.GetLogs(r.rp, []common.Address{*rocketRewardsPool.Address}, topicFilter, big.NewInt(int64(eventLogInterval)), fromBlock, new(big.Int).SetUint64(confirmedBlock))
+	if err != nil {
+		return nil, err
+	}
+
+	submissionsByMember := map[common.Address]memberRewardsSubmission{}
+	submissionType := reflect.TypeOf(rewards.RewardSubmission{})
+	for _, log := range logs {
+		if len(log.Topics) < 2 {
+			continue
+		}
+		values := make(map[string]interface{})
+		if err := submittedEvent.Inputs.UnpackIntoMap(values, log.Data); err != nil {
+			return nil, fmt.Errorf("error unpacking rewards submission in block %d: %w", log.BlockNumber, err)
+		}
+		member := common.BytesToAddress(log.Topics[1].Bytes())
+		submissionsByMember[member] = memberRewardsSubmission{
+			Member:     member,
+			Block:      log.BlockNumber,
+			Submission: reflect.ValueOf(values["submission"]).Convert(submissionType).Interface().(rewards.RewardSubmission),
+		}
+	}
+
+	submissions := make([]memberRewardsSubmission, 0, len(submissionsByMember))
+	for _, submission := range submissionsByMember {
+		submissions = append(submissions, submission)
+	}
+	sort.Slice(submissions, func(i, j int) bool {
+		return submissions[i].Block < submissions[j].Block
+	})
+	return submissions, nil
+}
+
+// Save a comparison of the node's submission and the majority's to the watchtower folder, returning its path
+func (r *rewardsConsensusCheck) saveDiff(index uint64, localRoot common.Hash, majorityRoot common.Hash, votes map[common.Hash]int, submissions []memberRewardsSubmission, local rewards.RewardSubmission, majority rewards.RewardSubmission) (string, error) {
+	diff := rewardsConsensusDiff{
+		Index:         index,
+		LocalRoot:     localRoot,
+		MajorityRoot:  majorityRoot,
+		Votes:         votes,
+		Submissions:   submissions,
+		Local:         getRewardSubmissionFields(local),
+		Majority:      getRewardSubmissionFields(majority),
+		ChangedFields: []string{},
+	}
+	for field, value := range diff.Local {
+		if diff.Majority[field] != value {
+			diff.ChangedFields = append(diff.ChangedFields, field)
+		}
+	}
+	sort.Strings(diff.ChangedFields)
+
+	bytes, err := json.MarshalIndent(diff, "", "\t")
+	if err != nil {
+		return "", fmt.Errorf("error serializing rewards consensus diagnostics: %w", err)
+	}
+	folder := r.cfg.Smartnode.GetWatchtowerFolder(true)
+	if err := os.MkdirAll(folder, 0755); err != nil {
+		return "", fmt.Errorf("error creating watchtower folder: %w", err)
+	}
+	path := filepath.Join(folder, fmt.Sprintf(rewardsConsensusDiffFilenameFormat, index))
+	if err := os.WriteFile(path, bytes, 0644); err != nil {
+		return "", fmt.Errorf("error saving rewards consensus diagnostics: %w", err)
+	}
+	return path, nil
+}
+
+// Get the fields of a rewards submission as strings so they can be compared
+func getRewardSubmissionFields(submission rewards.RewardSubmission) map[string]string {
+	return map[string]string{
+		"executionBlock":  fmt.Sprint(submission.ExecutionBlock),
+		"consensusBlock":  fmt.Sprint(submission.ConsensusBlock),
+		"merkleRoot":      common.BytesToHash(submission.MerkleRoot[:]).Hex(),
+		"merkleTreeCID":   submission.MerkleTreeCID,
+		"intervalsPassed": fmt.Sprint(submission.IntervalsPassed),
+		"treasuryRPL":     fmt.Sprint(submission.TreasuryRPL),
+		"trustedNodeRPL":  fmt.Sprint(submission.TrustedNodeRPL),
+		"nodeRPL":         fmt.Sprint(submission.NodeRPL),
+		"nodeETH":         fmt.Sprint(submission.NodeETH),
+		"userETH":         fmt.Sprint(submission.UserETH),
+	}
+}
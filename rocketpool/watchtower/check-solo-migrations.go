@@ -18,6 +18,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/tasks"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
@@ -44,10 +45,11 @@ type checkSoloMigrations struct {
 	lock             *sync.Mutex
 	isRunning        bool
 	generationPrefix string
+	guard            *tasks.CrashGuard
 }
 
 // Create check solo migrations task
-func newCheckSoloMigrations(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, coll *collectors.SoloMigrationCollector) (*checkSoloMigrations, error) {
+func newCheckSoloMigrations(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, coll *collectors.SoloMigrationCollector, guard *tasks.CrashGuard) (*checkSoloMigrations, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -86,6 +88,7 @@ func newCheckSoloMigrations(c *cli.Context, logger log.ColorLogger, errorLogger
 		lock:             lock,
 		isRunning:        false,
 		generationPrefix: "[Solo Migration]",
+		guard:            guard,
 	}, nil
 
 }
@@ -114,7 +117,7 @@ func (t *checkSoloMigrations) run(state *state.NetworkState) error {
 	t.lock.Unlock()
 
 	// Run the check
-	go func() {
+	t.guard.Go("check-solo-migrations", func() {
 		t.lock.Lock()
 		t.isRunning = true
 		t.lock.Unlock()
@@ -129,7 +132,7 @@ func (t *checkSoloMigrations) run(state *state.NetworkState) error {
 		t.lock.Lock()
 		t.isRunning = false
 		t.lock.Unlock()
-	}()
+	}, t.handleError)
 
 	// Return
 	return nil
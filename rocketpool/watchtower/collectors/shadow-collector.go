@@ -0,0 +1,138 @@
+package collectors
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Represents the collector for the shadow mode submission metrics
+type ShadowCollector struct {
+
+	// The EL block of the latest network balances submission that was checked
+	balancesBlockDesc *prometheus.Desc
+
+	// The difference between the locally calculated total ETH balance and the Oracle DAO's, in ETH
+	totalEthDivergenceDesc *prometheus.Desc
+
+	// The difference between the locally calculated staking ETH balance and the Oracle DAO's, in ETH
+	stakingEthDivergenceDesc *prometheus.Desc
+
+	// The difference between the locally calculated rETH supply and the Oracle DAO's, in rETH
+	rethSupplyDivergenceDesc *prometheus.Desc
+
+	// The EL block of the latest RPL price submission that was checked
+	pricesBlockDesc *prometheus.Desc
+
+	// The difference between the locally calculated RPL price and the Oracle DAO's, in ETH
+	rplPriceDivergenceDesc *prometheus.Desc
+
+	// The latest rewards interval that was checked
+	rewardsIntervalDesc *prometheus.Desc
+
+	// Whether the local rewards tree root matched the canonical one
+	rewardsRootMatchDesc *prometheus.Desc
+
+	// The number of checks that have diverged from the Oracle DAO since the watchtower started
+	divergencesDesc *prometheus.Desc
+
+	// Values
+	BalancesBlock        float64
+	TotalEthDivergence   float64
+	StakingEthDivergence float64
+	RethSupplyDivergence float64
+	PricesBlock          float64
+	RplPriceDivergence   float64
+	RewardsInterval      float64
+	RewardsRootMatch     float64
+	Divergences          float64
+
+	// Mutex
+	UpdateLock *sync.Mutex
+}
+
+// Create a new ShadowCollector instance
+func NewShadowCollector() *ShadowCollector {
+	subsystem := "shadow"
+	return &ShadowCollector{
+		balancesBlockDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "balances_block"),
+			"The EL block of the latest network balances submission that was checked",
+			nil, nil,
+		),
+		totalEthDivergenceDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "total_eth_divergence"),
+			"The difference between the locally calculated total ETH balance and the Oracle DAO's, in ETH",
+			nil, nil,
+		),
+		stakingEthDivergenceDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "staking_eth_divergence"),
+			"The difference between the locally calculated staking ETH balance and the Oracle DAO's, in ETH",
+			nil, nil,
+		),
+		rethSupplyDivergenceDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "reth_supply_divergence"),
+			"The difference between the locally calculated rETH supply and the Oracle DAO's, in rETH",
+			nil, nil,
+		),
+		pricesBlockDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "prices_block"),
+			"The EL block of the latest RPL price submission that was checked",
+			nil, nil,
+		),
+		rplPriceDivergenceDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "rpl_price_divergence"),
+			"The difference between the locally calculated RPL price and the Oracle DAO's, in ETH",
+			nil, nil,
+		),
+		rewardsIntervalDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "rewards_interval"),
+			"The latest rewards interval that was checked",
+			nil, nil,
+		),
+		rewardsRootMatchDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "rewards_root_match"),
+			"Whether the local rewards tree root matched the canonical one (1 if it matched, 0 if not)",
+			nil, nil,
+		),
+		divergencesDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "divergences"),
+			"The number of checks that have diverged from the Oracle DAO since the watchtower started",
+			nil, nil,
+		),
+		UpdateLock: &sync.Mutex{},
+	}
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *ShadowCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.balancesBlockDesc
+	channel <- collector.totalEthDivergenceDesc
+	channel <- collector.stakingEthDivergenceDesc
+	channel <- collector.rethSupplyDivergenceDesc
+	channel <- collector.pricesBlockDesc
+	channel <- collector.rplPriceDivergenceDesc
+	channel <- collector.rewardsIntervalDesc
+	channel <- collector.rewardsRootMatchDesc
+	channel <- collector.divergencesDesc
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *ShadowCollector) Collect(channel chan<- prometheus.Metric) {
+
+	// Sync
+	collector.UpdateLock.Lock()
+	defer collector.UpdateLock.Unlock()
+
+	// Update all of the metrics
+	channel <- prometheus.MustNewConstMetric(
+		collector.balancesBlockDesc, prometheus.GaugeValue, collector.BalancesBlock)
+	channel <- prometheus.MustNewConstMetric(
+		collector.totalEthDivergenceDesc, prometheus.GaugeValue, collector.TotalEthDivergence)
+	channel <- prometheus.MustNewConstMetric(
+		collector.stakingEthDivergenceDesc, prometheus.GaugeValue, collector.StakingEthDivergence)
+	channel <- prometheus.MustNewConstMetric(
+		collector.rethSupplyDivergenceDesc, prometheus.GaugeValue, collector.RethSupplyDivergence)
+	channel <- prometheus.MustNewConstMetric(
+		collector.pricesBlockDesc, prometheus.GaugeValue, collector.PricesBlock)
+	channel <- prometheus.MustNewConstMetric(
+		collector.rplPriceDivergenceDesc, prometheus.GaugeValue, collector.RplPriceDivergence)
+	channel <- prometheus.MustNewConstMetric(
+		collector.rewardsIntervalDesc, prometheus.GaugeValue, collector.RewardsInterval)
+	channel <- prometheus.MustNewConstMetric(
+		collector.rewardsRootMatchDesc, prometheus.GaugeValue, collector.RewardsRootMatch)
+	channel <- prometheus.MustNewConstMetric(
+		collector.divergencesDesc, prometheus.CounterValue, collector.Divergences)
+
+}
@@ -0,0 +1,105 @@
+package watchtower
+
+import (
+	"sync"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/schedule"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Config
+var eventFallbackInterval, _ = time.ParseDuration("15m")
+var eventReconnectDelay, _ = time.ParseDuration("30s")
+
+// Wakes the watchtower task loop as soon as a new epoch is finalized, since that's when balance, price, and rewards duties become due.
+// Falls back to the regular polling interval whenever the Beacon node's event stream is unavailable.
+type eventTrigger struct {
+	bc        beacon.Client
+	log       *log.ColorLogger
+	wake      chan struct{}
+	lock      *sync.Mutex
+	connected bool
+}
+
+// Create a new event trigger
+func newEventTrigger(bc beacon.Client, logger log.ColorLogger) *eventTrigger {
+	return &eventTrigger{
+		bc:   bc,
+		log:  &logger,
+		wake: make(chan struct{}, 1),
+		lock: &sync.Mutex{},
+	}
+}
+
+// Start listening to the Beacon node's event stream in the background, reconnecting whenever it drops
+func (t *eventTrigger) start() {
+	go func() {
+		for {
+			events := make(chan beacon.ChainEvent)
+			stop := make(chan struct{})
+			done := make(chan error, 1)
+			go func() {
+				done <- t.bc.SubscribeChainEvents([]beacon.ChainEventTopic{beacon.ChainEventTopic_FinalizedCheckpoint}, events, stop)
+			}()
+
+			var err error
+		listen:
+			for {
+				select {
+				case event := <-events:
+					if !t.isConnected() {
+						t.log.Println("Subscribed to the Beacon node's event stream; duties will be triggered by finalized checkpoints.")
+						t.setConnected(true)
+					}
+					t.log.Printlnf("Epoch %d has been finalized, checking duties...", event.Epoch)
+					select {
+					case t.wake <- struct{}{}:
+					default:
+					}
+				case err = <-done:
+					break listen
+				}
+			}
+			close(stop)
+
+			if t.isConnected() {
+				t.log.Printlnf("Lost the Beacon node's event stream (%s), falling back to polling.", err)
+				t.setConnected(false)
+			}
+			time.Sleep(eventReconnectDelay)
+		}
+	}()
+}
+
+// Wait until the next finalized checkpoint arrives or the timeout elapses.
+// If the event stream is healthy, the timeout is extended so the loop stays idle between checkpoints,
+// but it's still shortened by the scheduler around the times the duties have to be done by.
+func (t *eventTrigger) wait(interval time.Duration, scheduler *schedule.Scheduler) {
+	if t.isConnected() {
+		interval = eventFallbackInterval
+	}
+	interval, reason := scheduler.GetInterval(interval)
+	if reason != "" {
+		t.log.Printlnf("Checking duties again in %s because of %s.", interval.Round(time.Second), reason)
+	}
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
+	select {
+	case <-t.wake:
+	case <-timer.C:
+	}
+}
+
+func (t *eventTrigger) isConnected() bool {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.connected
+}
+
+func (t *eventTrigger) setConnected(connected bool) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.connected = connected
+}
@@ -20,8 +20,10 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/progress"
 	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
 	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/tasks"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
 	"github.com/urfave/cli"
 )
@@ -38,10 +40,12 @@ type generateRewardsTree struct {
 	lock      *sync.Mutex
 	isRunning bool
 	m         *state.NetworkStateManager
+	progress  *progress.Reporter
+	guard     *tasks.CrashGuard
 }
 
 // Create generate rewards Merkle Tree task
-func newGenerateRewardsTree(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, m *state.NetworkStateManager) (*generateRewardsTree, error) {
+func newGenerateRewardsTree(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, m *state.NetworkStateManager, guard *tasks.CrashGuard) (*generateRewardsTree, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -52,7 +56,7 @@ func newGenerateRewardsTree(c *cli.Context, logger log.ColorLogger, errorLogger
 	if err != nil {
 		return nil, err
 	}
-	bc, err := services.GetBeaconClient(c)
+	bc, err := services.GetRewardsBeaconClient(c)
 	if err != nil {
 		return nil, err
 	}
@@ -73,6 +77,7 @@ func newGenerateRewardsTree(c *cli.Context, logger log.ColorLogger, errorLogger
 		lock:      lock,
 		isRunning: false,
 		m:         m,
+		guard:     guard,
 	}
 
 	return generator, nil
@@ -125,7 +130,15 @@ func (t *generateRewardsTree) run() error {
 			t.lock.Lock()
 			t.isRunning = true
 			t.lock.Unlock()
-			go t.generateRewardsTree(index)
+			go func() {
+				err := t.guard.Run("generate-rewards-tree", func() error {
+					t.generateRewardsTree(index)
+					return nil
+				})
+				if err != nil {
+					t.handleError(err)
+				}
+			}()
 
 			// Return after the first request, do others at other intervals
 			return nil
@@ -141,7 +154,11 @@ func (t *generateRewardsTree) generateRewardsTree(index uint64) {
 	generationPrefix := fmt.Sprintf("[Interval %d Tree]", index)
 	t.log.Printlnf("%s Starting generation of Merkle rewards tree for interval %d.", generationPrefix, index)
 
+	// Save the progress where the CLI can follow it
+	t.progress = progress.NewFileReporter(t.cfg.Smartnode.GetRewardsTreeProgressPath(true), fmt.Sprintf("Interval %d rewards tree", index))
+
 	// Find the event for this interval
+	t.progress.SetPhase("Finding the snapshot event", 0)
 	rewardsEvent, err := rprewards.GetRewardSnapshotEvent(t.rp, t.cfg, index, nil)
 	if err != nil {
 		t.handleError(fmt.Errorf("%s Error getting event for interval %d: %w", generationPrefix, index, err))
@@ -206,6 +223,7 @@ func (t *generateRewardsTree) generateRewardsTree(index uint64) {
 	}
 
 	// Get the state for the target slot
+	t.progress.SetPhase("Loading the network state", 0)
 	state, err := t.m.GetStateForSlot(rewardsEvent.ConsensusBlock.Uint64())
 	if err != nil {
 		t.handleError(fmt.Errorf("%s error getting state for beacon slot %d: %w", generationPrefix, rewardsEvent.ConsensusBlock.Uint64(), err))
@@ -220,6 +238,7 @@ func (t *generateRewardsTree) generateRewardsTree(index uint64) {
 func (t *generateRewardsTree) generateRewardsTreeImpl(rp *rocketpool.RocketPool, index uint64, generationPrefix string, rewardsEvent rewards.RewardsEvent, elBlockHeader *types.Header, state *state.NetworkState) {
 
 	// Generate the rewards file
+	t.progress.SetPhase("Generating the tree", 0)
 	start := time.Now()
 	treegen, err := rprewards.NewTreeGenerator(&t.log, generationPrefix, rp, t.cfg, t.bc, index, rewardsEvent.IntervalStartTime, rewardsEvent.IntervalEndTime, rewardsEvent.ConsensusBlock.Uint64(), elBlockHeader, rewardsEvent.IntervalsPassed.Uint64(), state, nil)
 	if err != nil {
@@ -233,14 +252,14 @@ func (t *generateRewardsTree) generateRewardsTreeImpl(rp *rocketpool.RocketPool,
 	}
 	header := rewardsFile.GetHeader()
 	for address, network := range header.InvalidNetworkNodes {
-		t.log.Printlnf("%s WARNING: Node %s has invalid network %d assigned! Using 0 (mainnet) instead.", generationPrefix, address.Hex(), network)
+		t.log.Warnf("%s WARNING: Node %s has invalid network %d assigned! Using 0 (mainnet) instead.", generationPrefix, address.Hex(), network)
 	}
 	t.log.Printlnf("%s Finished in %s", generationPrefix, time.Since(start).String())
 
 	// Validate the Merkle root
 	root := common.BytesToHash(header.MerkleTree.Root())
 	if root != rewardsEvent.MerkleRoot {
-		t.log.Printlnf("%s WARNING: your Merkle tree had a root of %s, but the canonical Merkle tree's root was %s. This file will not be usable for claiming rewards.", generationPrefix, root.Hex(), rewardsEvent.MerkleRoot.Hex())
+		t.log.Warnf("%s WARNING: your Merkle tree had a root of %s, but the canonical Merkle tree's root was %s. This file will not be usable for claiming rewards.", generationPrefix, root.Hex(), rewardsEvent.MerkleRoot.Hex())
 	} else {
 		t.log.Printlnf("%s Your Merkle tree's root of %s matches the canonical root! You will be able to use this file for claiming rewards.", generationPrefix, header.MerkleRoot)
 	}
@@ -260,6 +279,7 @@ func (t *generateRewardsTree) generateRewardsTreeImpl(rp *rocketpool.RocketPool,
 	}
 
 	// Write the files
+	t.progress.SetPhase("Saving the files", 2)
 	path := t.cfg.Smartnode.GetRewardsTreePath(index, true)
 	minipoolPerformancePath := t.cfg.Smartnode.GetMinipoolPerformancePath(index, true)
 	err = os.WriteFile(minipoolPerformancePath, minipoolPerformanceBytes, 0644)
@@ -267,13 +287,20 @@ func (t *generateRewardsTree) generateRewardsTreeImpl(rp *rocketpool.RocketPool,
 		t.handleError(fmt.Errorf("%s Error saving minipool performance file to %s: %w", generationPrefix, minipoolPerformancePath, err))
 		return
 	}
+	t.progress.SetProgress(1)
 	err = os.WriteFile(path, wrapperBytes, 0644)
 	if err != nil {
 		t.handleError(fmt.Errorf("%s Error saving rewards file to %s: %w", generationPrefix, path, err))
 		return
 	}
+	err = rprewards.SaveLocalRewardsRoot(t.cfg, index, common.HexToHash(header.MerkleRoot))
+	if err != nil {
+		t.handleError(fmt.Errorf("%s %w", generationPrefix, err))
+		return
+	}
 
 	t.log.Printlnf("%s Merkle tree generation complete!", generationPrefix)
+	t.progress.Finish(nil)
 	t.lock.Lock()
 	t.isRunning = false
 	t.lock.Unlock()
@@ -283,6 +310,7 @@ func (t *generateRewardsTree) generateRewardsTreeImpl(rp *rocketpool.RocketPool,
 func (t *generateRewardsTree) handleError(err error) {
 	t.errLog.Println(err)
 	t.errLog.Println("*** Rewards tree generation failed. ***")
+	t.progress.Finish(err)
 	t.lock.Lock()
 	t.isRunning = false
 	t.lock.Unlock()
@@ -23,6 +23,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
 	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/tasks"
 	"github.com/urfave/cli"
 	"gopkg.in/yaml.v2"
 
@@ -53,6 +54,7 @@ type processPenalties struct {
 	beaconConfig   beacon.Eth2Config
 	m              *state.NetworkStateManager
 	s              *state.NetworkState
+	guard          *tasks.CrashGuard
 }
 
 type penaltyState struct {
@@ -60,7 +62,7 @@ type penaltyState struct {
 }
 
 // Create process penalties task
-func newProcessPenalties(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, m *state.NetworkStateManager) (*processPenalties, error) {
+func newProcessPenalties(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, m *state.NetworkStateManager, guard *tasks.CrashGuard) (*processPenalties, error) {
 	// Get services
 	cfg, err := services.GetConfig(c)
 	if err != nil {
@@ -96,7 +98,7 @@ func newProcessPenalties(c *cli.Context, logger log.ColorLogger, errorLogger log
 	priorityFeeGwei := cfg.Smartnode.PriorityFee.Value.(float64)
 	var priorityFee *big.Int
 	if priorityFeeGwei == 0 {
-		logger.Println("WARNING: priority fee was missing or 0, setting a default of 2.")
+		logger.Warnf("WARNING: priority fee was missing or 0, setting a default of 2.")
 		priorityFee = eth.GweiToWei(2)
 	} else {
 		priorityFee = eth.GweiToWei(priorityFeeGwei)
@@ -126,6 +128,7 @@ func newProcessPenalties(c *cli.Context, logger log.ColorLogger, errorLogger log
 		gasLimit:       0,
 		beaconConfig:   beaconConfig,
 		m:              m,
+		guard:          guard,
 	}, nil
 }
 
@@ -215,7 +218,7 @@ func (t *processPenalties) run() error {
 	t.lock.Unlock()
 
 	// Run the check
-	go func() {
+	t.guard.Go("process-penalties", func() {
 		t.lock.Lock()
 		t.isRunning = true
 		t.lock.Unlock()
@@ -323,7 +326,7 @@ func (t *processPenalties) run() error {
 		t.lock.Lock()
 		t.isRunning = false
 		t.lock.Unlock()
-	}()
+	}, t.handleError)
 
 	// Return
 	return nil
@@ -399,7 +402,7 @@ func (t *processPenalties) processBlock(block *beacon.BeaconBlock, smoothingPool
 	}
 	isOptedIn, err := node.GetSmoothingPoolRegistrationState(t.rp, nodeAddress, &opts)
 	if err != nil {
-		t.log.Printlnf("*** WARNING: Couldn't check if node %s was opted into the smoothing pool for slot %d (execution block %d), skipping check... error: %w\n***", nodeAddress.Hex(), block.Slot, block.ExecutionBlockNumber, err)
+		t.log.Warnf("*** WARNING: Couldn't check if node %s was opted into the smoothing pool for slot %d (execution block %d), skipping check... error: %s\n***", nodeAddress.Hex(), block.Slot, block.ExecutionBlockNumber, err)
 		isOptedIn = false
 	}
 
@@ -422,7 +425,7 @@ func (t *processPenalties) processBlock(block *beacon.BeaconBlock, smoothingPool
 		// Get the opt out time
 		optOutTime, err := node.GetSmoothingPoolRegistrationChanged(t.rp, nodeAddress, &opts)
 		if err != nil {
-			t.log.Printlnf("*** WARNING: Couldn't check when node %s opted out of the smoothing pool for slot %d (execution block %d), skipping check... error: %w\n***", nodeAddress.Hex(), block.Slot, block.ExecutionBlockNumber, err)
+			t.log.Warnf("*** WARNING: Couldn't check when node %s opted out of the smoothing pool for slot %d (execution block %d), skipping check... error: %s\n***", nodeAddress.Hex(), block.Slot, block.ExecutionBlockNumber, err)
 		} else if optOutTime != time.Unix(0, 0) {
 			// Get the time of the epoch before this one
 			blockEpoch := block.Slot / t.beaconConfig.SlotsPerEpoch
@@ -0,0 +1,247 @@
+package watchtower
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+)
+
+// Settings
+const (
+	priceSource_CoinGecko string = "coingecko"
+	priceSource_Binance   string = "binance"
+
+	coinGeckoPriceUrl string = "https://api.coingecko.com/api/v3/coins/rocket-pool/market_chart/range?vs_currency=eth&from=%d&to=%d"
+	binancePriceUrl   string = "https://api.binance.com/api/v3/klines?symbol=%s&interval=1m&endTime=%d&limit=1"
+)
+
+// How long to wait for an exchange API to respond
+var priceReferenceTimeout, _ = time.ParseDuration("15s")
+
+// How far from the block's time an exchange price can be; CoinGecko's history is in 5 minute steps for short ranges
+var priceReferenceWindow, _ = time.ParseDuration("15m")
+
+// An RPL price from a source other than the network's TWAP pool
+type priceReference struct {
+	Source string
+	Price  float64
+}
+
+// Get the RPL price at the block from each of the configured reference sources; sources that fail are logged and skipped.
+// The exchanges' prices are taken at the block's time, since the submission can be for a block that's hours old if it's late or being retried.
+func (t *submitRplPrice) getReferencePrices(blockNumber uint64) []priceReference {
+	references := []priceReference{}
+	httpClient := &http.Client{Timeout: priceReferenceTimeout}
+
+	// Get the block's time, which the exchanges' prices are taken at
+	var blockTime time.Time
+	header, headerErr := t.ec.HeaderByNumber(context.Background(), big.NewInt(0).SetUint64(blockNumber))
+	if headerErr == nil {
+		blockTime = time.Unix(int64(header.Time), 0)
+	}
+
+	for _, source := range strings.Split(t.cfg.Smartnode.PriceReferenceSources.Value.(string), ",") {
+		source = strings.TrimSpace(source)
+		if source == "" {
+			continue
+		}
+		isExchange := strings.EqualFold(source, priceSource_CoinGecko) || strings.EqualFold(source, priceSource_Binance)
+
+		var price float64
+		var err error
+		switch {
+		case isExchange && headerErr != nil:
+			err = fmt.Errorf("error getting the time of block %d: %w", blockNumber, headerErr)
+		case strings.EqualFold(source, priceSource_CoinGecko):
+			price, err = getCoinGeckoPrice(httpClient, blockTime)
+		case strings.EqualFold(source, priceSource_Binance):
+			price, err = getBinancePrice(httpClient, blockTime)
+		case common.IsHexAddress(source):
+			var priceWei *big.Int
+			priceWei, err = t.getPoolTwap(source, blockNumber)
+			if err == nil {
+				price = eth.WeiToEth(priceWei)
+			}
+		default:
+			err = fmt.Errorf("unknown source; it must be %s, %s or the address of a Uniswap V3 pool", priceSource_CoinGecko, priceSource_Binance)
+		}
+		if err != nil {
+			t.log.Warnf("WARNING: couldn't get the reference RPL price from %s: %s", source, err.Error())
+			continue
+		}
+		references = append(references, priceReference{Source: source, Price: price})
+	}
+	return references
+}
+
+// Compare the RPL price with the reference sources and log the divergence from each of them.
+// Returns false if the price deviates from their median by more than the configured threshold and hasn't been approved, in which case it should be held.
+func (t *submitRplPrice) checkReferencePrices(blockNumber uint64, rplPrice *big.Int) bool {
+
+	references := t.getReferencePrices(blockNumber)
+	if len(references) == 0 {
+		return true
+	}
+
+	// Log the divergence from each source
+	price := eth.WeiToEth(rplPrice)
+	referencePrices := []float64{}
+	for _, reference := range references {
+		t.log.Printlnf("Reference RPL price from %s: %.6f ETH (%+.2f%%)", reference.Source, reference.Price, getDeviation(price, reference.Price))
+		referencePrices = append(referencePrices, reference.Price)
+	}
+	sort.Float64s(referencePrices)
+	median := referencePrices[len(referencePrices)/2]
+	if len(referencePrices)%2 == 0 {
+		median = (referencePrices[len(referencePrices)/2-1] + median) / 2
+	}
+	deviation := getDeviation(price, median)
+
+	maxDeviation := t.cfg.Smartnode.PriceMaxDeviation.Value.(float64)
+	alert := alerting.Alert{
+		Rule:     alerting.Rule_PriceDivergence,
+		Severity: alerting.Severity_Critical,
+		Title:    "RPL price diverges from the reference prices",
+	}
+	if maxDeviation <= 0 || (deviation <= maxDeviation && deviation >= -maxDeviation) {
+		t.alerts.Update(alert, false)
+		return true
+	}
+
+	// Submit it anyway if the price has been approved for this block
+	approvalPath := t.cfg.Smartnode.GetRplPriceApprovalPath(blockNumber, true)
+	_, err := os.Stat(approvalPath)
+	if err == nil {
+		t.log.Printlnf("The RPL price deviates by %+.2f%% from the median reference price, but it has been approved for block %d.", deviation, blockNumber)
+		t.alerts.Update(alert, false)
+		return true
+	}
+
+	t.log.Println("=== RPL PRICE DIVERGES FROM THE REFERENCE PRICES ===")
+	t.log.Printlnf("The RPL price for block %d is %.6f ETH, which deviates by %+.2f%% from the median reference price of %.6f ETH.", blockNumber, price, deviation, median)
+	t.log.Printlnf("The submission will be held; check the price and run `rocketpool odao approve-rpl-price %d` to submit it anyway.", blockNumber)
+	alert.Message = fmt.Sprintf("The RPL price calculated for block %d (%.6f ETH) deviates by %+.2f%% from the median of %d reference price(s) (%.6f ETH), which is more than the %.2f%% allowed in the Smartnode settings. The watchtower is holding the submission; check the price and run `rocketpool odao approve-rpl-price %d` to submit it anyway.", blockNumber, price, deviation, len(references), median, maxDeviation, blockNumber)
+	t.alerts.Update(alert, true)
+	return false
+
+}
+
+// Remove the approval for a block's price once it has been submitted
+func (t *submitRplPrice) clearPriceApproval(blockNumber uint64) {
+	approvalPath := t.cfg.Smartnode.GetRplPriceApprovalPath(blockNumber, true)
+	err := os.Remove(approvalPath)
+	if err != nil && !os.IsNotExist(err) {
+		t.log.Warnf("WARNING: couldn't remove the RPL price approval for block %d: %s", blockNumber, err.Error())
+	}
+}
+
+// Get the percentage a price deviates from a reference price by
+func getDeviation(price float64, reference float64) float64 {
+	if reference == 0 {
+		return 0
+	}
+	return (price - reference) / reference * 100
+}
+
+// Get the RPL / ETH price from CoinGecko at the provided time, from the closest point in its price history
+func getCoinGeckoPrice(httpClient *http.Client, priceTime time.Time) (float64, error) {
+	var response struct {
+		Prices [][2]float64 `json:"prices"`
+	}
+	from := priceTime.Add(-priceReferenceWindow).Unix()
+	to := priceTime.Add(priceReferenceWindow).Unix()
+	if err := getJson(httpClient, fmt.Sprintf(coinGeckoPriceUrl, from, to), &response); err != nil {
+		return 0, err
+	}
+
+	// Each point is a millisecond timestamp and a price
+	target := float64(priceTime.UnixMilli())
+	closest := -1
+	for i, point := range response.Prices {
+		if closest == -1 || math.Abs(point[0]-target) < math.Abs(response.Prices[closest][0]-target) {
+			closest = i
+		}
+	}
+	if closest == -1 {
+		return 0, fmt.Errorf("there were no RPL prices within %s of %s", priceReferenceWindow, priceTime.Format(time.RFC1123))
+	}
+	return response.Prices[closest][1], nil
+}
+
+// Get the RPL / ETH price from Binance at the provided time, through both tokens' USDT markets
+func getBinancePrice(httpClient *http.Client, priceTime time.Time) (float64, error) {
+	rplPrice, err := getBinanceKline(httpClient, "RPLUSDT", priceTime)
+	if err != nil {
+		return 0, err
+	}
+	ethPrice, err := getBinanceKline(httpClient, "ETHUSDT", priceTime)
+	if err != nil {
+		return 0, err
+	}
+	if ethPrice == 0 {
+		return 0, fmt.Errorf("the ETH price was 0")
+	}
+	return rplPrice / ethPrice, nil
+}
+
+// Get the opening price of a Binance market in the minute that contains the provided time
+func getBinanceKline(httpClient *http.Client, symbol string, priceTime time.Time) (float64, error) {
+	// Each kline is an array that starts with its opening time in milliseconds and its opening price
+	var response [][]json.RawMessage
+	if err := getJson(httpClient, fmt.Sprintf(binancePriceUrl, symbol, priceTime.UnixMilli()), &response); err != nil {
+		return 0, err
+	}
+	if len(response) == 0 || len(response[0]) < 2 {
+		return 0, fmt.Errorf("there was no %s price at %s", symbol, priceTime.Format(time.RFC1123))
+	}
+	var openTime int64
+	var openPrice string
+	if err := json.Unmarshal(response[0][0], &openTime); err != nil {
+		return 0, fmt.Errorf("error parsing the %s kline time: %w", symbol, err)
+	}
+	if err := json.Unmarshal(response[0][1], &openPrice); err != nil {
+		return 0, fmt.Errorf("error parsing the %s kline price: %w", symbol, err)
+	}
+	if gap := priceTime.Sub(time.UnixMilli(openTime)); gap < 0 || gap > priceReferenceWindow {
+		return 0, fmt.Errorf("there was no %s price within %s of %s", symbol, priceReferenceWindow, priceTime.Format(time.RFC1123))
+	}
+	price, err := strconv.ParseFloat(openPrice, 64)
+	if err != nil {
+		return 0, fmt.Errorf("error parsing the %s price [%s]: %w", symbol, openPrice, err)
+	}
+	return price, nil
+}
+
+// Get a JSON response from an exchange API
+func getJson(httpClient *http.Client, url string, result interface{}) error {
+	response, err := httpClient.Get(url)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("error reading response: %w", err)
+	}
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("the API responded with %s: %s", response.Status, strings.TrimSpace(string(body)))
+	}
+	if err := json.Unmarshal(body, result); err != nil {
+		return fmt.Errorf("error deserializing response: %w", err)
+	}
+	return nil
+}
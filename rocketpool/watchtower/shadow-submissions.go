@@ -0,0 +1,283 @@
+package watchtower
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rewards"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/rocketpool/watchtower/collectors"
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/tasks"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Shadow submissions task - computes the values the Oracle DAO is responsible for and compares them against the canonical ones
+type shadowSubmissions struct {
+	c                       *cli.Context
+	log                     *log.ColorLogger
+	errLog                  *log.ColorLogger
+	cfg                     *config.RocketPoolConfig
+	ec                      rocketpool.ExecutionClient
+	rp                      *rocketpool.RocketPool
+	balances                *submitNetworkBalances
+	prices                  *submitRplPrice
+	collector               *collectors.ShadowCollector
+	lastBalancesBlock       uint64
+	lastPricesBlock         uint64
+	lastRewardsIndex        uint64
+	lastMissingRewardsIndex uint64
+	lock                    *sync.Mutex
+	isRunning               bool
+	guard                   *tasks.CrashGuard
+}
+
+// Create shadow submissions task
+func newShadowSubmissions(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, balances *submitNetworkBalances, prices *submitRplPrice, collector *collectors.ShadowCollector, guard *tasks.CrashGuard) (*shadowSubmissions, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	lock := &sync.Mutex{}
+	return &shadowSubmissions{
+		c:         c,
+		log:       &logger,
+		errLog:    &errorLogger,
+		cfg:       cfg,
+		ec:        ec,
+		rp:        rp,
+		balances:  balances,
+		prices:    prices,
+		collector: collector,
+		lock:      lock,
+		isRunning: false,
+		guard:     guard,
+	}, nil
+
+}
+
+// Compare the latest Oracle DAO submissions against locally calculated values
+func (t *shadowSubmissions) run(state *state.NetworkState) error {
+
+	// Check if the previous comparison is still running
+	t.lock.Lock()
+	if t.isRunning {
+		t.log.Println("Shadow comparison is already running in the background.")
+		t.lock.Unlock()
+		return nil
+	}
+	t.isRunning = true
+	t.lock.Unlock()
+
+	t.guard.Go("shadow-submissions", func() {
+		logPrefix := "[Shadow]"
+
+		// Check the latest balances submission
+		if err := t.checkBalances(state); err != nil {
+			t.errLog.Println(fmt.Errorf("%s error checking network balances: %w", logPrefix, err))
+		}
+
+		// Check the latest price submission
+		if err := t.checkPrices(state); err != nil {
+			t.errLog.Println(fmt.Errorf("%s error checking RPL price: %w", logPrefix, err))
+		}
+
+		// Check the latest rewards tree submission
+		if err := t.checkRewardsRoot(state); err != nil {
+			t.errLog.Println(fmt.Errorf("%s error checking rewards tree root: %w", logPrefix, err))
+		}
+
+		t.lock.Lock()
+		t.isRunning = false
+		t.lock.Unlock()
+	}, t.handleError)
+
+	// Return
+	return nil
+
+}
+
+func (t *shadowSubmissions) handleError(err error) {
+	t.errLog.Println(err)
+	t.errLog.Println("*** Shadow comparison failed. ***")
+	t.lock.Lock()
+	t.isRunning = false
+	t.lock.Unlock()
+}
+
+// Compare the canonical network balances against locally calculated ones
+func (t *shadowSubmissions) checkBalances(state *state.NetworkState) error {
+
+	// Check if there's a new submission to compare against
+	blockNumberBig := state.NetworkDetails.BalancesBlock
+	blockNumber := blockNumberBig.Uint64()
+	if blockNumber == 0 || blockNumber <= t.lastBalancesBlock {
+		return nil
+	}
+
+	// Get the time of the block
+	header, err := t.ec.HeaderByNumber(context.Background(), blockNumberBig)
+	if err != nil {
+		return err
+	}
+	blockTime := time.Unix(int64(header.Time), 0)
+
+	// Get the Beacon block corresponding to this time
+	eth2Config := state.BeaconConfig
+	genesisTime := time.Unix(int64(eth2Config.GenesisTime), 0)
+	timeSinceGenesis := blockTime.Sub(genesisTime)
+	slotNumber := uint64(timeSinceGenesis.Seconds()) / eth2Config.SecondsPerSlot
+
+	// Calculate the balances
+	t.log.Printlnf("[Shadow] Calculating network balances for block %d...", blockNumber)
+	balances, err := t.balances.getNetworkBalances(header, blockNumberBig, slotNumber, blockTime)
+	if err != nil {
+		return err
+	}
+	totalEth := balances.getTotalEth()
+
+	// Compare against the canonical values
+	totalEthDelta := big.NewInt(0).Sub(totalEth, state.NetworkDetails.TotalETHBalance)
+	stakingEthDelta := big.NewInt(0).Sub(balances.MinipoolsStaking, state.NetworkDetails.StakingETHBalance)
+	rethSupplyDelta := big.NewInt(0).Sub(balances.RETHSupply, state.NetworkDetails.TotalRETHSupply)
+	matches := totalEthDelta.Sign() == 0 && stakingEthDelta.Sign() == 0 && rethSupplyDelta.Sign() == 0
+	if matches {
+		t.log.Printlnf("[Shadow] Network balances for block %d match the Oracle DAO's submission.", blockNumber)
+	} else {
+		t.errLog.Printlnf("[Shadow] Network balances for block %d DIVERGE from the Oracle DAO's submission!", blockNumber)
+		t.errLog.Printlnf("\tTotal ETH:   local %s, oDAO %s (delta %s wei)", totalEth.String(), state.NetworkDetails.TotalETHBalance.String(), totalEthDelta.String())
+		t.errLog.Printlnf("\tStaking ETH: local %s, oDAO %s (delta %s wei)", balances.MinipoolsStaking.String(), state.NetworkDetails.StakingETHBalance.String(), stakingEthDelta.String())
+		t.errLog.Printlnf("\trETH supply: local %s, oDAO %s (delta %s wei)", balances.RETHSupply.String(), state.NetworkDetails.TotalRETHSupply.String(), rethSupplyDelta.String())
+	}
+
+	// Update the metrics
+	t.collector.UpdateLock.Lock()
+	t.collector.BalancesBlock = float64(blockNumber)
+	t.collector.TotalEthDivergence = eth.WeiToEth(totalEthDelta)
+	t.collector.StakingEthDivergence = eth.WeiToEth(stakingEthDelta)
+	t.collector.RethSupplyDivergence = eth.WeiToEth(rethSupplyDelta)
+	if !matches {
+		t.collector.Divergences++
+	}
+	t.collector.UpdateLock.Unlock()
+
+	t.lastBalancesBlock = blockNumber
+	return nil
+
+}
+
+// Compare the canonical RPL price against a locally calculated one
+func (t *shadowSubmissions) checkPrices(state *state.NetworkState) error {
+
+	// Check if there's a new submission to compare against
+	blockNumber := state.NetworkDetails.PricesBlock
+	if blockNumber == 0 || blockNumber <= t.lastPricesBlock {
+		return nil
+	}
+
+	// Calculate the price
+	t.log.Printlnf("[Shadow] Calculating RPL price for block %d...", blockNumber)
+	rplPrice, err := t.prices.getRplTwap(blockNumber)
+	if err != nil {
+		return err
+	}
+
+	// Compare against the canonical value
+	delta := big.NewInt(0).Sub(rplPrice, state.NetworkDetails.RplPrice)
+	matches := delta.Sign() == 0
+	if matches {
+		t.log.Printlnf("[Shadow] RPL price for block %d matches the Oracle DAO's submission (%.6f ETH).", blockNumber, eth.WeiToEth(rplPrice))
+	} else {
+		t.errLog.Printlnf("[Shadow] RPL price for block %d DIVERGES from the Oracle DAO's submission: local %.6f ETH, oDAO %.6f ETH (delta %s wei)", blockNumber, eth.WeiToEth(rplPrice), eth.WeiToEth(state.NetworkDetails.RplPrice), delta.String())
+	}
+
+	// Update the metrics
+	t.collector.UpdateLock.Lock()
+	t.collector.PricesBlock = float64(blockNumber)
+	t.collector.RplPriceDivergence = eth.WeiToEth(delta)
+	if !matches {
+		t.collector.Divergences++
+	}
+	t.collector.UpdateLock.Unlock()
+
+	t.lastPricesBlock = blockNumber
+	return nil
+
+}
+
+// Compare the canonical Merkle root of the last rewards interval against the tree this node generated itself
+func (t *shadowSubmissions) checkRewardsRoot(state *state.NetworkState) error {
+
+	// Check if there's a new interval to compare against
+	currentIndex := state.NetworkDetails.RewardIndex
+	if currentIndex == 0 || currentIndex <= t.lastRewardsIndex {
+		return nil
+	}
+	interval := currentIndex - 1
+
+	// Get the root of the tree this node generated itself; the tree file can't be used because a downloaded tree is saved in the same place
+	localRoot, exists, err := rprewards.LoadLocalRewardsRoot(t.cfg, interval)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		// Check again later, since the tree may still be generating
+		if currentIndex != t.lastMissingRewardsIndex {
+			t.log.Printlnf("[Shadow] This node hasn't generated its own rewards tree for interval %d yet; set the Rewards Tree Mode to Generate to check it.", interval)
+			t.lastMissingRewardsIndex = currentIndex
+		}
+		return nil
+	}
+
+	// Get the canonical root
+	canonicalRootBytes, err := rewards.MerkleRoots(t.rp, big.NewInt(0).SetUint64(interval), nil)
+	if err != nil {
+		return fmt.Errorf("error getting canonical Merkle root for interval %d: %w", interval, err)
+	}
+	canonicalRoot := common.BytesToHash(canonicalRootBytes)
+
+	// Compare them
+	matches := localRoot == canonicalRoot
+	if matches {
+		t.log.Printlnf("[Shadow] Rewards tree root for interval %d matches the canonical root (%s).", interval, canonicalRoot.Hex())
+	} else {
+		t.errLog.Printlnf("[Shadow] Rewards tree root for interval %d DIVERGES from the canonical root: local %s, canonical %s", interval, localRoot.Hex(), canonicalRoot.Hex())
+	}
+
+	// Update the metrics
+	t.collector.UpdateLock.Lock()
+	t.collector.RewardsInterval = float64(interval)
+	if matches {
+		t.collector.RewardsRootMatch = 1
+	} else {
+		t.collector.RewardsRootMatch = 0
+		t.collector.Divergences++
+	}
+	t.collector.UpdateLock.Unlock()
+
+	t.lastRewardsIndex = currentIndex
+	return nil
+
+}
@@ -21,10 +21,12 @@ import (
 
 	"github.com/rocket-pool/smartnode/rocketpool/watchtower/utils"
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
 	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/tasks"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/eth1"
@@ -45,8 +47,11 @@ type submitNetworkBalances struct {
 	ec        rocketpool.ExecutionClient
 	rp        *rocketpool.RocketPool
 	bc        beacon.Client
+	m         *state.NetworkStateManager
+	alerts    *alerting.AlertManager
 	lock      *sync.Mutex
 	isRunning bool
+	guard     *tasks.CrashGuard
 }
 
 // Network balance info
@@ -67,7 +72,7 @@ type minipoolBalanceDetails struct {
 }
 
 // Create submit network balances task
-func newSubmitNetworkBalances(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger) (*submitNetworkBalances, error) {
+func newSubmitNetworkBalances(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, m *state.NetworkStateManager, alerts *alerting.AlertManager, guard *tasks.CrashGuard) (*submitNetworkBalances, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -102,8 +107,11 @@ func newSubmitNetworkBalances(c *cli.Context, logger log.ColorLogger, errorLogge
 		ec:        ec,
 		rp:        rp,
 		bc:        bc,
+		m:         m,
+		alerts:    alerts,
 		lock:      lock,
 		isRunning: false,
+		guard:     guard,
 	}, nil
 
 }
@@ -176,7 +184,7 @@ func (t *submitNetworkBalances) run(state *state.NetworkState) error {
 	}
 	t.lock.Unlock()
 
-	go func() {
+	t.guard.Go("submit-network-balances", func() {
 		t.lock.Lock()
 		t.isRunning = true
 		t.lock.Unlock()
@@ -226,6 +234,12 @@ func (t *submitNetworkBalances) run(state *state.NetworkState) error {
 			t.log.Printlnf("Have previously submitted out-of-date balances for block %d, trying again...", blockNumber)
 		}
 
+		// Make sure the balances don't move the rETH exchange rate further than expected
+		if err := t.checkRateBounds(state, balances); err != nil {
+			t.handleError(fmt.Errorf("%s %w", logPrefix, err))
+			return
+		}
+
 		// Log
 		t.log.Println("Submitting balances...")
 
@@ -240,7 +254,7 @@ func (t *submitNetworkBalances) run(state *state.NetworkState) error {
 		t.lock.Lock()
 		t.isRunning = false
 		t.lock.Unlock()
-	}()
+	}, t.handleError)
 
 	// Return
 	return nil
@@ -255,6 +269,47 @@ func (t *submitNetworkBalances) handleError(err error) {
 	t.lock.Unlock()
 }
 
+// Compare the rETH exchange rate implied by the balances with the one from the last submission, and refuse to submit them
+// if it has moved further than the configured bound
+func (t *submitNetworkBalances) checkRateBounds(state *state.NetworkState, balances networkBalances) error {
+
+	maxChange := t.cfg.Smartnode.BalancesMaxRateChange.Value.(float64)
+	if maxChange <= 0 {
+		return nil
+	}
+
+	// There's nothing to compare against until balances have been submitted with a nonzero supply
+	previousEth := state.NetworkDetails.TotalETHBalance
+	previousSupply := state.NetworkDetails.TotalRETHSupply
+	if previousEth == nil || previousSupply == nil || previousSupply.Sign() == 0 || balances.RETHSupply.Sign() == 0 {
+		return nil
+	}
+
+	// Get the old and new rates
+	previousRate := new(big.Float).Quo(new(big.Float).SetInt(previousEth), new(big.Float).SetInt(previousSupply))
+	newRate := new(big.Float).Quo(new(big.Float).SetInt(balances.getTotalEth()), new(big.Float).SetInt(balances.RETHSupply))
+	change, _ := new(big.Float).Quo(new(big.Float).Sub(newRate, previousRate), previousRate).Float64()
+	change *= 100
+	previousRateFloat, _ := previousRate.Float64()
+	newRateFloat, _ := newRate.Float64()
+	t.log.Printlnf("rETH exchange rate: %.8f (%+.4f%% since block %s)", newRateFloat, change, state.NetworkDetails.BalancesBlock.String())
+
+	alert := alerting.Alert{
+		Rule:     alerting.Rule_BalancesRateBound,
+		Severity: alerting.Severity_Critical,
+		Title:    "Network balances would move the rETH exchange rate out of bounds",
+	}
+	if change <= maxChange && change >= -maxChange {
+		t.alerts.Update(alert, false)
+		return nil
+	}
+
+	alert.Message = fmt.Sprintf("The network balances calculated for block %d would move the rETH exchange rate from %.8f to %.8f (%+.4f%%), which is more than the %.4f%% bound set in the Smartnode settings. The watchtower is holding the submission instead of voting for it; check the balances in its log for a calculation error, or raise the bound if the change is genuine.", balances.Block, previousRateFloat, newRateFloat, change, maxChange)
+	t.alerts.Update(alert, true)
+	return fmt.Errorf("the balances for block %d would change the rETH exchange rate by %+.4f%% (from %.8f to %.8f), more than the %.4f%% bound; refusing to submit them", balances.Block, change, previousRateFloat, newRateFloat, maxChange)
+
+}
+
 // Check whether balances for a block has already been submitted by the node
 func (t *submitNetworkBalances) hasSubmittedBlockBalances(nodeAddress common.Address, blockNumber uint64) (bool, error) {
 
@@ -268,13 +323,7 @@ func (t *submitNetworkBalances) hasSubmittedBlockBalances(nodeAddress common.Add
 func (t *submitNetworkBalances) hasSubmittedSpecificBlockBalances(nodeAddress common.Address, blockNumber uint64, balances networkBalances) (bool, error) {
 
 	// Calculate total ETH balance
-	totalEth := big.NewInt(0)
-	totalEth.Sub(totalEth, balances.NodeCreditBalance)
-	totalEth.Add(totalEth, balances.DepositPool)
-	totalEth.Add(totalEth, balances.MinipoolsTotal)
-	totalEth.Add(totalEth, balances.RETHContract)
-	totalEth.Add(totalEth, balances.DistributorShareTotal)
-	totalEth.Add(totalEth, balances.SmoothingPoolShare)
+	totalEth := balances.getTotalEth()
 
 	blockNumberBuf := make([]byte, 32)
 	big.NewInt(int64(blockNumber)).FillBytes(blockNumberBuf)
@@ -292,6 +341,18 @@ func (t *submitNetworkBalances) hasSubmittedSpecificBlockBalances(nodeAddress co
 
 }
 
+// Get the total ETH balance of the network, as reported in a balances submission
+func (b networkBalances) getTotalEth() *big.Int {
+	totalEth := big.NewInt(0)
+	totalEth.Sub(totalEth, b.NodeCreditBalance)
+	totalEth.Add(totalEth, b.DepositPool)
+	totalEth.Add(totalEth, b.MinipoolsTotal)
+	totalEth.Add(totalEth, b.RETHContract)
+	totalEth.Add(totalEth, b.DistributorShareTotal)
+	totalEth.Add(totalEth, b.SmoothingPoolShare)
+	return totalEth
+}
+
 // Prints a message to the log
 func (t *submitNetworkBalances) printMessage(message string) {
 	t.log.Println(message)
@@ -306,11 +367,8 @@ func (t *submitNetworkBalances) getNetworkBalances(elBlockHeader *types.Header,
 		return networkBalances{}, err
 	}
 
-	// Create a new state gen manager
-	mgr, err := state.NewNetworkStateManager(client, t.cfg, client.Client, t.bc, t.log)
-	if err != nil {
-		return networkBalances{}, fmt.Errorf("error creating network state manager for EL block %s, Beacon slot %d: %w", elBlock, beaconBlock, err)
-	}
+	// Use the daemon's state manager with that client, so the state is shared with the other tasks
+	mgr := t.m.WithClients(client, t.bc, t.log)
 
 	// Create a new state for the target block
 	state, err := mgr.GetStateForSlot(beaconBlock)
@@ -489,13 +547,7 @@ func (t *submitNetworkBalances) getMinipoolBalanceDetails(mpd *rpstate.NativeMin
 func (t *submitNetworkBalances) submitBalances(balances networkBalances) error {
 
 	// Calculate total ETH balance
-	totalEth := big.NewInt(0)
-	totalEth.Sub(totalEth, balances.NodeCreditBalance)
-	totalEth.Add(totalEth, balances.DepositPool)
-	totalEth.Add(totalEth, balances.MinipoolsTotal)
-	totalEth.Add(totalEth, balances.RETHContract)
-	totalEth.Add(totalEth, balances.DistributorShareTotal)
-	totalEth.Add(totalEth, balances.SmoothingPoolShare)
+	totalEth := balances.getTotalEth()
 
 	ratio := eth.WeiToEth(totalEth) / eth.WeiToEth(balances.RETHSupply)
 	t.log.Printlnf("Total ETH = %s\n", totalEth)
@@ -3,6 +3,7 @@ package watchtower
 import (
 	"context"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"math"
 	"math/big"
@@ -20,10 +21,12 @@ import (
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/rocket-pool/smartnode/rocketpool/watchtower/utils"
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
 	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/tasks"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/eth1"
@@ -46,14 +49,20 @@ type submitRewardsTree_Rolling struct {
 	genesisTime time.Time
 	recordMgr   *rprewards.RollingRecordManager
 	stateMgr    *state.NetworkStateManager
+	consensus   *rewardsConsensusCheck
 	logPrefix   string
 
+	// The number of epochs before the end of an interval to pre-generate its tree, and the last interval that was pre-generated
+	pregenerationEpochs uint64
+	pregeneratedIndex   uint64
+
 	lock      *sync.Mutex
 	isRunning bool
+	guard     *tasks.CrashGuard
 }
 
 // Create submit rewards tree with rolling record support
-func newSubmitRewardsTree_Rolling(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, stateMgr *state.NetworkStateManager) (*submitRewardsTree_Rolling, error) {
+func newSubmitRewardsTree_Rolling(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, stateMgr *state.NetworkStateManager, alerts *alerting.AlertManager, guard *tasks.CrashGuard) (*submitRewardsTree_Rolling, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -72,7 +81,7 @@ func newSubmitRewardsTree_Rolling(c *cli.Context, logger log.ColorLogger, errorL
 	if err != nil {
 		return nil, err
 	}
-	bc, err := services.GetBeaconClient(c)
+	bc, err := services.GetRewardsBeaconClient(c)
 	if err != nil {
 		return nil, err
 	}
@@ -128,7 +137,11 @@ func newSubmitRewardsTree_Rolling(c *cli.Context, logger log.ColorLogger, errorL
 		logPrefix:   logPrefix,
 		lock:        lock,
 		isRunning:   false,
+		guard:       guard,
+
+		pregenerationEpochs: cfg.Smartnode.RewardsTreePregenerationEpochs.Value.(uint64),
 	}
+	task.consensus = newRewardsConsensusCheck(&task.log, cfg, rp, alerts)
 
 	// Make a new rolling manager
 	recordMgr, err := rprewards.NewRollingRecordManager(&task.log, &task.errLog, cfg, rp, bc, stateMgr, startSlot, beaconCfg, currentIndex)
@@ -177,7 +190,7 @@ func (t *submitRewardsTree_Rolling) run(headState *state.NetworkState) error {
 	}
 	nodeAddress := nodeAccount.Address
 
-	go func() {
+	t.guard.Go("submit-rewards-tree", func() {
 		t.lock.Lock()
 		t.isRunning = true
 		t.lock.Unlock()
@@ -232,6 +245,14 @@ func (t *submitRewardsTree_Rolling) run(headState *state.NetworkState) error {
 				return
 			}
 
+			// Start generating the tree if the end of the interval is close
+			if t.pregenerationEpochs > 0 {
+				err = t.pregenerateTree(headState, latestFinalizedBlock.Slot)
+				if err != nil {
+					t.log.Warnf("%s WARNING: rewards tree pre-generation failed: %s", t.logPrefix, err.Error())
+				}
+			}
+
 			t.lock.Lock()
 			t.isRunning = false
 			t.lock.Unlock()
@@ -281,13 +302,8 @@ func (t *submitRewardsTree_Rolling) run(headState *state.NetworkState) error {
 				return
 			}
 
-			// Generate the rewards state
-			stateMgr, err := state.NewNetworkStateManager(client, t.cfg, client.Client, t.bc, &t.log)
-			if err != nil {
-				t.handleError(fmt.Errorf("error creating state manager for rewards slot: %w", err))
-				return
-			}
-			state, err := stateMgr.GetStateForSlot(rewardsSlot)
+			// Generate the rewards state with the daemon's state manager, using that client and the rewards Beacon client
+			state, err := t.stateMgr.WithClients(client, t.bc, &t.log).GetStateForSlot(rewardsSlot)
 			if err != nil {
 				t.handleError(fmt.Errorf("error getting state for rewards slot: %w", err))
 				return
@@ -302,12 +318,27 @@ func (t *submitRewardsTree_Rolling) run(headState *state.NetworkState) error {
 			}
 		} else {
 			t.log.Printlnf("%s Rewards submission for interval %d is due... waiting for epoch %d to be finalized (currently on epoch %d)", t.logPrefix, headState.NetworkDetails.RewardIndex, requiredRewardsEpoch, latestFinalizedEpoch)
+
+			// Keep the record up to date while waiting so only the last epoch of the interval is left for the report.
+			// The record can't go into that epoch, since the snapshot slot moves back if the last slots were missed.
+			lastPregenerationSlot := rewardsEpoch*headState.BeaconConfig.SlotsPerEpoch - 1
+			if t.pregenerationEpochs > 0 && t.recordMgr.Record.LastDutiesSlot < lastPregenerationSlot {
+				targetSlot := latestFinalizedBlock.Slot
+				if targetSlot > lastPregenerationSlot {
+					targetSlot = lastPregenerationSlot
+				}
+				err = t.recordMgr.UpdateRecordToState(headState, targetSlot)
+				if err != nil {
+					t.handleError(fmt.Errorf("error updating record: %w", err))
+					return
+				}
+			}
 		}
 
 		t.lock.Lock()
 		t.isRunning = false
 		t.lock.Unlock()
-	}()
+	}, t.handleError)
 
 	return nil
 }
@@ -326,6 +357,55 @@ func (t *submitRewardsTree_Rolling) handleError(err error) {
 	t.lock.Unlock()
 }
 
+// Generate a speculative tree for the current interval from the rolling record once its end is within the pre-generation window.
+// The record is saved first so the report at the end of the interval only has to process the epochs that come after it,
+// and the trial run catches problems with the tree generation before the real one is due.
+func (t *submitRewardsTree_Rolling) pregenerateTree(headState *state.NetworkState, latestFinalizedSlot uint64) error {
+	index := headState.NetworkDetails.RewardIndex
+	if t.pregeneratedIndex == index && index != 0 {
+		return nil
+	}
+
+	// Check if the end of the interval is close enough
+	beaconCfg := headState.BeaconConfig
+	endTime := headState.NetworkDetails.IntervalStart.Add(headState.NetworkDetails.IntervalDuration)
+	endEpoch := uint64(math.Ceil(endTime.Sub(t.genesisTime).Seconds()/float64(beaconCfg.SecondsPerSlot))) / beaconCfg.SlotsPerEpoch
+	finalizedEpoch := latestFinalizedSlot / beaconCfg.SlotsPerEpoch
+	if finalizedEpoch+t.pregenerationEpochs < endEpoch {
+		return nil
+	}
+	t.pregeneratedIndex = index
+	t.log.Printlnf("%s Interval %d ends in %d epochs, pre-generating its rewards tree from slot %d.", t.logPrefix, index, endEpoch-finalizedEpoch, latestFinalizedSlot)
+
+	// Save a checkpoint for the report to start from
+	err := t.recordMgr.SaveRecordToFile(t.recordMgr.Record)
+	if err != nil {
+		return fmt.Errorf("error saving record checkpoint: %w", err)
+	}
+
+	// Generate the tree as if the interval ended at the latest finalized slot
+	finalizedState, err := t.stateMgr.GetStateForSlot(latestFinalizedSlot)
+	if err != nil {
+		return fmt.Errorf("error getting state for slot %d: %w", latestFinalizedSlot, err)
+	}
+	elHeader, err := t.rp.Client.HeaderByNumber(context.Background(), big.NewInt(0).SetUint64(finalizedState.ElBlockNumber))
+	if err != nil {
+		return fmt.Errorf("error getting header for EL block %d: %w", finalizedState.ElBlockNumber, err)
+	}
+	snapshotTime := t.genesisTime.Add(time.Duration(beaconCfg.SecondsPerSlot*latestFinalizedSlot) * time.Second)
+	startTime := time.Now()
+	treegen, err := rprewards.NewTreeGenerator(&t.log, t.logPrefix+"[Pre-generation]", t.rp, t.cfg, t.bc, index, headState.NetworkDetails.IntervalStart, snapshotTime, latestFinalizedSlot, elHeader, 1, finalizedState, t.recordMgr.Record)
+	if err != nil {
+		return fmt.Errorf("error creating Merkle tree generator: %w", err)
+	}
+	rewardsFile, err := treegen.GenerateTree()
+	if err != nil {
+		return fmt.Errorf("error generating Merkle tree: %w", err)
+	}
+	t.log.Printlnf("%s Pre-generated the rewards tree for interval %d up to slot %d in %s (speculative root %s). The report will only need to process the remaining %d epochs.", t.logPrefix, index, latestFinalizedSlot, time.Since(startTime), rewardsFile.GetHeader().MerkleRoot, endEpoch-finalizedEpoch)
+	return nil
+}
+
 // Check if a rewards interval submission is required and if so, the slot number for the update
 func (t *submitRewardsTree_Rolling) isRewardsIntervalSubmissionRequired(state *state.NetworkState) (bool, uint64, uint64, time.Time, time.Time, error) {
 	// Check if a rewards interval has passed and needs to be calculated
@@ -382,7 +462,7 @@ func (t *submitRewardsTree_Rolling) isExistingFileValid(rewardsTreePath string,
 		return nil, nil, false, true
 	}
 	if err != nil {
-		t.log.Printlnf("%s WARNING: failed to check if [%s] exists: %s; regenerating file...\n", t.logPrefix, rewardsTreePath, err.Error())
+		t.log.Warnf("%s WARNING: failed to check if [%s] exists: %s; regenerating file...\n", t.logPrefix, rewardsTreePath, err.Error())
 		return nil, nil, false, true
 	}
 
@@ -390,14 +470,14 @@ func (t *submitRewardsTree_Rolling) isExistingFileValid(rewardsTreePath string,
 	filename := filepath.Base(rewardsTreePath)
 	fileBytes, err := os.ReadFile(rewardsTreePath)
 	if err != nil {
-		t.log.Printlnf("%s WARNING: failed to read %s: %s; regenerating file...\n", t.logPrefix, rewardsTreePath, err.Error())
+		t.log.Warnf("%s WARNING: failed to read %s: %s; regenerating file...\n", t.logPrefix, rewardsTreePath, err.Error())
 		return nil, nil, false, true
 	}
 
 	// Unmarshal it
 	proofWrapper, err := rprewards.DeserializeRewardsFile(fileBytes)
 	if err != nil {
-		t.log.Printlnf("%s WARNING: failed to deserialize %s: %s; regenerating file...\n", t.logPrefix, rewardsTreePath, err.Error())
+		t.log.Warnf("%s WARNING: failed to deserialize %s: %s; regenerating file...\n", t.logPrefix, rewardsTreePath, err.Error())
 		return nil, nil, false, true
 	}
 	header := proofWrapper.GetHeader()
@@ -406,7 +486,7 @@ func (t *submitRewardsTree_Rolling) isExistingFileValid(rewardsTreePath string,
 		// Get the CID for it
 		cid, err := rprewards.GetCidForRewardsFile(proofWrapper, filename)
 		if err != nil {
-			t.log.Printlnf("%s WARNING: failed to get CID for %s: %s; regenerating file...\n", t.logPrefix, rewardsTreePath, err.Error())
+			t.log.Warnf("%s WARNING: failed to get CID for %s: %s; regenerating file...\n", t.logPrefix, rewardsTreePath, err.Error())
 			return nil, nil, false, true
 		}
 
@@ -427,7 +507,7 @@ func (t *submitRewardsTree_Rolling) isExistingFileValid(rewardsTreePath string,
 
 		hasSubmitted, err := rewards.GetTrustedNodeSubmittedSpecificRewards(t.rp, nodeAddress, submission, nil)
 		if err != nil {
-			t.log.Printlnf("%s WARNING: could not check if node has previously submitted file %s: %s; regenerating file...\n", t.logPrefix, rewardsTreePath, err.Error())
+			t.log.Warnf("%s WARNING: could not check if node has previously submitted file %s: %s; regenerating file...\n", t.logPrefix, rewardsTreePath, err.Error())
 			return nil, nil, false, true
 		}
 		if !hasSubmitted {
@@ -497,6 +577,10 @@ func (t *submitRewardsTree_Rolling) runRewardsIntervalReport(client *rocketpool.
 
 		// Submit to the contracts
 		err = t.submitRewardsSnapshot(currentIndexBig, snapshotBeaconBlock, elBlockIndex, existingRewardsFile.GetHeader(), cid, big.NewInt(int64(intervalsPassed)))
+		if errors.Is(err, errSubmissionHeld) {
+			t.log.Printlnf("%s Held the rewards snapshot for interval %d since it disagrees with the Oracle DAO's consensus.", t.logPrefix, currentIndex)
+			return nil
+		}
 		if err != nil {
 			return fmt.Errorf("error submitting rewards snapshot: %w", err)
 		}
@@ -519,7 +603,7 @@ func (t *submitRewardsTree_Rolling) generateTree(rp *rocketpool.RocketPool, stat
 
 	// Log
 	if intervalsPassed > 1 {
-		t.log.Printlnf("WARNING: %d intervals have passed since the last rewards checkpoint was submitted! Rolling them into one...", intervalsPassed)
+		t.log.Warnf("WARNING: %d intervals have passed since the last rewards checkpoint was submitted! Rolling them into one...", intervalsPassed)
 	}
 	t.log.Printlnf("Rewards checkpoint has passed, starting Merkle tree generation for interval %d in the background.\n%s Snapshot Beacon block = %d, EL block = %d, running from %s to %s", currentIndex, t.logPrefix, snapshotBeaconBlock, elBlockIndex, startTime, endTime)
 
@@ -533,7 +617,7 @@ func (t *submitRewardsTree_Rolling) generateTree(rp *rocketpool.RocketPool, stat
 		return fmt.Errorf("Error generating Merkle tree: %w", err)
 	}
 	for address, network := range rewardsFile.GetHeader().InvalidNetworkNodes {
-		t.printMessage(fmt.Sprintf("WARNING: Node %s has invalid network %d assigned! Using 0 (mainnet) instead.", address.Hex(), network))
+		t.log.Warnf("%s WARNING: Node %s has invalid network %d assigned! Using 0 (mainnet) instead.", t.logPrefix, address.Hex(), network)
 	}
 
 	// Serialize the minipool performance file
@@ -575,6 +659,12 @@ func (t *submitRewardsTree_Rolling) generateTree(rp *rocketpool.RocketPool, stat
 		return fmt.Errorf("Error saving rewards tree file to %s: %w", rewardsTreePath, err)
 	}
 
+	// Record that this node built the tree itself
+	err = rprewards.SaveLocalRewardsRoot(t.cfg, currentIndex, common.HexToHash(rewardsFile.GetHeader().MerkleRoot))
+	if err != nil {
+		return err
+	}
+
 	// Only do the upload and submission process if this is an Oracle DAO node
 	if nodeTrusted {
 		// Upload the rewards tree file
@@ -587,11 +677,13 @@ func (t *submitRewardsTree_Rolling) generateTree(rp *rocketpool.RocketPool, stat
 
 		// Submit to the contracts
 		err = t.submitRewardsSnapshot(big.NewInt(int64(currentIndex)), snapshotBeaconBlock, elBlockIndex, rewardsFile.GetHeader(), cid, big.NewInt(int64(intervalsPassed)))
-		if err != nil {
+		if errors.Is(err, errSubmissionHeld) {
+			t.printMessage(fmt.Sprintf("Held the rewards snapshot for interval %d since it disagrees with the Oracle DAO's consensus.", currentIndex))
+		} else if err != nil {
 			return fmt.Errorf("Error submitting rewards snapshot: %w", err)
+		} else {
+			t.printMessage(fmt.Sprintf("Successfully submitted rewards snapshot for interval %d.", currentIndex))
 		}
-
-		t.printMessage(fmt.Sprintf("Successfully submitted rewards snapshot for interval %d.", currentIndex))
 	} else {
 		t.printMessage(fmt.Sprintf("Successfully generated rewards snapshot for interval %d.", currentIndex))
 	}
@@ -650,6 +742,15 @@ func (t *submitRewardsTree_Rolling) submitRewardsSnapshot(index *big.Int, consen
 		UserETH:         &rewardsFileHeader.TotalRewards.PoolStakerSmoothingPoolEth.Int,
 	}
 
+	// Hold the submission if the other Oracle DAO members have voted for a different root
+	canSubmit, err := t.consensus.check(opts.From, submission)
+	if err != nil {
+		return err
+	}
+	if !canSubmit {
+		return errSubmissionHeld
+	}
+
 	// Get the gas limit
 	gasInfo, err := rewards.EstimateSubmitRewardSnapshotGas(t.rp, submission, opts)
 	if err != nil {
@@ -3,6 +3,7 @@ package watchtower
 import (
 	"context"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"math"
 	"math/big"
@@ -20,10 +21,12 @@ import (
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/rocket-pool/smartnode/rocketpool/watchtower/utils"
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
 	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/tasks"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
@@ -48,10 +51,12 @@ type submitRewardsTree_Stateless struct {
 	isRunning        bool
 	generationPrefix string
 	m                *state.NetworkStateManager
+	consensus        *rewardsConsensusCheck
+	guard            *tasks.CrashGuard
 }
 
 // Create submit rewards Merkle Tree task
-func newSubmitRewardsTree_Stateless(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, m *state.NetworkStateManager) (*submitRewardsTree_Stateless, error) {
+func newSubmitRewardsTree_Stateless(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, m *state.NetworkStateManager, alerts *alerting.AlertManager, guard *tasks.CrashGuard) (*submitRewardsTree_Stateless, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -66,7 +71,7 @@ func newSubmitRewardsTree_Stateless(c *cli.Context, logger log.ColorLogger, erro
 	if err != nil {
 		return nil, err
 	}
-	bc, err := services.GetBeaconClient(c)
+	bc, err := services.GetRewardsBeaconClient(c)
 	if err != nil {
 		return nil, err
 	}
@@ -89,6 +94,8 @@ func newSubmitRewardsTree_Stateless(c *cli.Context, logger log.ColorLogger, erro
 		isRunning:        false,
 		generationPrefix: "[Merkle Tree]",
 		m:                m,
+		consensus:        newRewardsConsensusCheck(&logger, cfg, rp, alerts),
+		guard:            guard,
 	}
 
 	return generator, nil
@@ -212,6 +219,10 @@ func (t *submitRewardsTree_Stateless) Run(nodeTrusted bool, state *state.Network
 
 		// Submit to the contracts
 		err = t.submitRewardsSnapshot(currentIndexBig, snapshotBeaconBlock, elBlockIndex, proofWrapper.GetHeader(), cid, big.NewInt(int64(intervalsPassed)))
+		if errors.Is(err, errSubmissionHeld) {
+			t.log.Printlnf("Held the rewards snapshot for interval %d since it disagrees with the Oracle DAO's consensus.", currentIndex)
+			return nil
+		}
 		if err != nil {
 			return fmt.Errorf("Error submitting rewards snapshot: %w", err)
 		}
@@ -249,13 +260,13 @@ func (t *submitRewardsTree_Stateless) isExistingFileValid(rewardsTreePath string
 		// The file already exists, attempt to read it
 		fileBytes, err := os.ReadFile(rewardsTreePath)
 		if err != nil {
-			t.log.Printlnf("WARNING: failed to read %s: %s\nRegenerating file...\n", rewardsTreePath, err.Error())
+			t.log.Warnf("WARNING: failed to read %s: %s\nRegenerating file...\n", rewardsTreePath, err.Error())
 			return false
 		}
 
 		proofWrapper, err := rprewards.DeserializeRewardsFile(fileBytes)
 		if err != nil {
-			t.log.Printlnf("WARNING: failed to deserialize %s: %s\nRegenerating file...\n", rewardsTreePath, err.Error())
+			t.log.Warnf("WARNING: failed to deserialize %s: %s\nRegenerating file...\n", rewardsTreePath, err.Error())
 			return false
 		}
 
@@ -277,7 +288,7 @@ func (t *submitRewardsTree_Stateless) isExistingFileValid(rewardsTreePath string
 // Kick off the tree generation goroutine
 func (t *submitRewardsTree_Stateless) generateTree(intervalsPassed time.Duration, nodeTrusted bool, currentIndex uint64, snapshotBeaconBlock uint64, elBlockIndex uint64, startTime time.Time, endTime time.Time, snapshotElBlockHeader *types.Header, rewardsTreePath string, compressedRewardsTreePath string, minipoolPerformancePath string, compressedMinipoolPerformancePath string) {
 
-	go func() {
+	t.guard.Go("submit-rewards-tree", func() {
 		t.lock.Lock()
 		t.isRunning = true
 		t.lock.Unlock()
@@ -298,7 +309,7 @@ func (t *submitRewardsTree_Stateless) generateTree(intervalsPassed time.Duration
 		t.lock.Lock()
 		t.isRunning = false
 		t.lock.Unlock()
-	}()
+	}, t.handleError)
 
 }
 
@@ -307,15 +318,12 @@ func (t *submitRewardsTree_Stateless) generateTreeImpl(rp *rocketpool.RocketPool
 
 	// Log
 	if uint64(intervalsPassed) > 1 {
-		t.log.Printlnf("WARNING: %d intervals have passed since the last rewards checkpoint was submitted! Rolling them into one...", uint64(intervalsPassed))
+		t.log.Warnf("WARNING: %d intervals have passed since the last rewards checkpoint was submitted! Rolling them into one...", uint64(intervalsPassed))
 	}
 	t.log.Printlnf("Rewards checkpoint has passed, starting Merkle tree generation for interval %d in the background.\n%s Snapshot Beacon block = %d, EL block = %d, running from %s to %s", currentIndex, t.generationPrefix, snapshotBeaconBlock, elBlockIndex, startTime, endTime)
 
-	// Create a new state gen manager
-	mgr, err := state.NewNetworkStateManager(rp, t.cfg, rp.Client, t.bc, t.log)
-	if err != nil {
-		return fmt.Errorf("error creating network state manager for EL block %d, Beacon slot %d: %w", elBlockIndex, snapshotBeaconBlock, err)
-	}
+	// Use the daemon's state manager with the viable EC and the rewards Beacon client
+	mgr := t.m.WithClients(rp, t.bc, t.log)
 
 	// Create a new state for the target block
 	state, err := mgr.GetStateForSlot(snapshotBeaconBlock)
@@ -333,7 +341,7 @@ func (t *submitRewardsTree_Stateless) generateTreeImpl(rp *rocketpool.RocketPool
 		return fmt.Errorf("Error generating Merkle tree: %w", err)
 	}
 	for address, network := range rewardsFile.GetHeader().InvalidNetworkNodes {
-		t.printMessage(fmt.Sprintf("WARNING: Node %s has invalid network %d assigned! Using 0 (mainnet) instead.", address.Hex(), network))
+		t.log.Warnf("%s WARNING: Node %s has invalid network %d assigned! Using 0 (mainnet) instead.", t.generationPrefix, address.Hex(), network)
 	}
 
 	// Serialize the minipool performance file
@@ -375,6 +383,12 @@ func (t *submitRewardsTree_Stateless) generateTreeImpl(rp *rocketpool.RocketPool
 		return fmt.Errorf("Error saving rewards tree file to %s: %w", rewardsTreePath, err)
 	}
 
+	// Record that this node built the tree itself
+	err = rprewards.SaveLocalRewardsRoot(t.cfg, currentIndex, common.HexToHash(rewardsFile.GetHeader().MerkleRoot))
+	if err != nil {
+		return err
+	}
+
 	// Only do the upload and submission process if this is an Oracle DAO node
 	if nodeTrusted {
 		// Upload the rewards tree file
@@ -387,11 +401,13 @@ func (t *submitRewardsTree_Stateless) generateTreeImpl(rp *rocketpool.RocketPool
 
 		// Submit to the contracts
 		err = t.submitRewardsSnapshot(big.NewInt(int64(currentIndex)), snapshotBeaconBlock, elBlockIndex, rewardsFile.GetHeader(), cid, big.NewInt(int64(intervalsPassed)))
-		if err != nil {
+		if errors.Is(err, errSubmissionHeld) {
+			t.printMessage(fmt.Sprintf("Held the rewards snapshot for interval %d since it disagrees with the Oracle DAO's consensus.", currentIndex))
+		} else if err != nil {
 			return fmt.Errorf("Error submitting rewards snapshot: %w", err)
+		} else {
+			t.printMessage(fmt.Sprintf("Successfully submitted rewards snapshot for interval %d.", currentIndex))
 		}
-
-		t.printMessage(fmt.Sprintf("Successfully submitted rewards snapshot for interval %d.", currentIndex))
 	} else {
 		t.printMessage(fmt.Sprintf("Successfully generated rewards snapshot for interval %d.", currentIndex))
 	}
@@ -450,6 +466,15 @@ func (t *submitRewardsTree_Stateless) submitRewardsSnapshot(index *big.Int, cons
 		UserETH:         &rewardsFileHeader.TotalRewards.PoolStakerSmoothingPoolEth.Int,
 	}
 
+	// Hold the submission if the other Oracle DAO members have voted for a different root
+	canSubmit, err := t.consensus.check(opts.From, submission)
+	if err != nil {
+		return err
+	}
+	if !canSubmit {
+		return errSubmissionHeld
+	}
+
 	// Get the gas limit
 	gasInfo, err := rewards.EstimateSubmitRewardSnapshotGas(t.rp, submission, opts)
 	if err != nil {
@@ -23,10 +23,12 @@ import (
 
 	"github.com/rocket-pool/smartnode/rocketpool/watchtower/utils"
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
 	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/tasks"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/eth1"
@@ -200,12 +202,14 @@ type submitRplPrice struct {
 	w         *wallet.Wallet
 	rp        *rocketpool.RocketPool
 	bc        beacon.Client
+	alerts    *alerting.AlertManager
 	lock      *sync.Mutex
 	isRunning bool
+	guard     *tasks.CrashGuard
 }
 
 // Create submit RPL price task
-func newSubmitRplPrice(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger) (*submitRplPrice, error) {
+func newSubmitRplPrice(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, alerts *alerting.AlertManager, guard *tasks.CrashGuard) (*submitRplPrice, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -240,7 +244,9 @@ func newSubmitRplPrice(c *cli.Context, logger log.ColorLogger, errorLogger log.C
 		w:      w,
 		rp:     rp,
 		bc:     bc,
+		alerts: alerts,
 		lock:   lock,
+		guard:  guard,
 	}, nil
 
 }
@@ -345,7 +351,7 @@ func (t *submitRplPrice) run(state *state.NetworkState) error {
 	}
 	t.lock.Unlock()
 
-	go func() {
+	t.guard.Go("submit-rpl-price", func() {
 		t.lock.Lock()
 		t.isRunning = true
 		t.lock.Unlock()
@@ -388,6 +394,14 @@ func (t *submitRplPrice) run(state *state.NetworkState) error {
 			t.log.Printlnf("Have previously submitted out-of-date prices for block %d, trying again...", blockNumber)
 		}
 
+		// Cross-check the price with the reference sources
+		if !t.checkReferencePrices(blockNumber, rplPrice) {
+			t.lock.Lock()
+			t.isRunning = false
+			t.lock.Unlock()
+			return
+		}
+
 		// Log
 		t.log.Println("Submitting RPL price...")
 
@@ -396,13 +410,14 @@ func (t *submitRplPrice) run(state *state.NetworkState) error {
 			t.handleError(fmt.Errorf("%s could not submit RPL price: %w", logPrefix, err))
 			return
 		}
+		t.clearPriceApproval(blockNumber)
 
 		// Log and return
 		t.log.Printlnf("%s Price report complete.", logPrefix)
 		t.lock.Lock()
 		t.isRunning = false
 		t.lock.Unlock()
-	}()
+	}, t.handleError)
 
 	// Return
 	return nil
@@ -439,17 +454,21 @@ func (t *submitRplPrice) hasSubmittedSpecificBlockPrices(nodeAddress common.Addr
 
 // Get RPL price via TWAP at block
 func (t *submitRplPrice) getRplTwap(blockNumber uint64) (*big.Int, error) {
+	poolAddress := t.cfg.Smartnode.GetRplTwapPoolAddress()
+	if poolAddress == "" {
+		return nil, fmt.Errorf("RPL TWAP pool contract not deployed on this network")
+	}
+	return t.getPoolTwap(poolAddress, blockNumber)
+}
+
+// Get RPL price via the TWAP of a Uniswap V3 RPL / ETH pool at block
+func (t *submitRplPrice) getPoolTwap(poolAddress string, blockNumber uint64) (*big.Int, error) {
 
 	// Initialize call options
 	opts := &bind.CallOpts{
 		BlockNumber: big.NewInt(int64(blockNumber)),
 	}
 
-	poolAddress := t.cfg.Smartnode.GetRplTwapPoolAddress()
-	if poolAddress == "" {
-		return nil, fmt.Errorf("RPL TWAP pool contract not deployed on this network")
-	}
-
 	// Get a client with the block number available
 	client, err := eth1.GetBestApiClient(t.rp, t.cfg, t.printMessage, opts.BlockNumber)
 	if err != nil {
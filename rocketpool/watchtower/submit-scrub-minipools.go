@@ -25,7 +25,9 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/reorg"
 	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/tasks"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
@@ -52,6 +54,7 @@ type submitScrubMinipools struct {
 	coll      *collectors.ScrubCollector
 	lock      *sync.Mutex
 	isRunning bool
+	guard     *tasks.CrashGuard
 }
 
 type iterationData struct {
@@ -64,6 +67,7 @@ type iterationData struct {
 	badPrestakeCount      int
 	goodOnDepositContract int
 	badOnDepositContract  int
+	unconfirmedDeposits   int
 	unknownMinipools      int
 	safetyScrubs          int
 
@@ -83,7 +87,7 @@ type minipoolDetails struct {
 }
 
 // Create submit scrub minipools task
-func newSubmitScrubMinipools(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, coll *collectors.ScrubCollector) (*submitScrubMinipools, error) {
+func newSubmitScrubMinipools(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, coll *collectors.ScrubCollector, guard *tasks.CrashGuard) (*submitScrubMinipools, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -121,6 +125,7 @@ func newSubmitScrubMinipools(c *cli.Context, logger log.ColorLogger, errorLogger
 		coll:      coll,
 		lock:      lock,
 		isRunning: false,
+		guard:     guard,
 	}, nil
 
 }
@@ -149,7 +154,7 @@ func (t *submitScrubMinipools) run(state *state.NetworkState) error {
 	t.lock.Unlock()
 
 	// Run the check
-	go func() {
+	t.guard.Go("submit-scrub-minipools", func() {
 		t.lock.Lock()
 		t.isRunning = true
 		t.lock.Unlock()
@@ -243,7 +248,7 @@ func (t *submitScrubMinipools) run(state *state.NetworkState) error {
 		t.lock.Lock()
 		t.isRunning = false
 		t.lock.Unlock()
-	}()
+	}, t.handleError)
 
 	// Return
 	return nil
@@ -435,6 +440,12 @@ func (t *submitScrubMinipools) verifyDeposits() error {
 		return err
 	}
 
+	// Deposits that aren't buried deep enough yet could still be reorged out, so they're left for a later check
+	confirmedBlock, _, err := reorg.GetConfirmedBlock(context.Background(), t.rp.Client, t.cfg.Smartnode.ConfirmationDepth.Value.(uint64))
+	if err != nil {
+		return err
+	}
+
 	// Check each minipool's deposit data
 	for minipool, details := range t.it.minipools {
 
@@ -448,6 +459,13 @@ func (t *submitScrubMinipools) verifyDeposits() error {
 
 		// Go through each deposit for this minipool and find the first one that's valid
 		for depositIndex, deposit := range deposits {
+			if deposit.BlockNumber > confirmedBlock {
+				t.log.Printlnf("Minipool %s has a deposit in block %d that hasn't been confirmed yet, it will be checked again later.", minipool.GetAddress().Hex(), deposit.BlockNumber)
+				t.it.unconfirmedDeposits++
+				delete(t.it.minipools, minipool)
+				break
+			}
+
 			depositData := new(ethpb.Deposit_Data)
 			depositData.Amount = deposit.Amount
 			depositData.PublicKey = deposit.Pubkey.Bytes()
@@ -507,7 +525,7 @@ func (t *submitScrubMinipools) checkSafetyScrub(state *state.NetworkState) error
 	// Warn if there are any remaining minipools - this should never happen
 	remainingMinipools := len(t.it.minipools)
 	if remainingMinipools > 0 {
-		t.log.Printlnf("WARNING: %d minipools did not have deposit information", remainingMinipools)
+		t.log.Warnf("WARNING: %d minipools did not have deposit information", remainingMinipools)
 	} else {
 		return nil
 	}
@@ -527,7 +545,7 @@ func (t *submitScrubMinipools) checkSafetyScrub(state *state.NetworkState) error
 
 		// Verify this is actually a prelaunch minipool
 		if mpd.Status != types.Prelaunch {
-			t.log.Printlnf("\tMinipool %s is under review but is in %d status?", minipool.GetAddress().Hex(), types.MinipoolDepositTypes[mpd.Status])
+			t.log.Printlnf("\tMinipool %s is under review but is in %s status?", minipool.GetAddress().Hex(), types.MinipoolDepositTypes[mpd.Status])
 			continue
 		}
 
@@ -616,6 +634,7 @@ func (t *submitScrubMinipools) printFinalTally(prefix string) {
 	t.log.Printlnf("\tBeacon Chain scrubs: %d/%d", t.it.badOnBeaconCount, (t.it.badOnBeaconCount + t.it.goodOnBeaconCount))
 	t.log.Printlnf("\tPrestake scrubs: %d/%d", t.it.badPrestakeCount, (t.it.badPrestakeCount + t.it.goodPrestakeCount))
 	t.log.Printlnf("\tDeposit Contract scrubs: %d/%d", t.it.badOnDepositContract, (t.it.badOnDepositContract + t.it.goodOnDepositContract))
+	t.log.Printlnf("\tPools with unconfirmed deposits: %d", t.it.unconfirmedDeposits)
 	t.log.Printlnf("\tPools without deposits: %d", t.it.unknownMinipools)
 	t.log.Printlnf("\tRemaining uncovered minipools: %d", len(t.it.minipools))
 
@@ -1,6 +1,7 @@
 package watchtower
 
 import (
+	"errors"
 	"fmt"
 	"math/big"
 	"math/rand"
@@ -15,16 +16,27 @@ import (
 
 	"github.com/rocket-pool/rocketpool-go/dao/trustednode"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
 	"github.com/rocket-pool/smartnode/rocketpool/watchtower/collectors"
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/health"
+	"github.com/rocket-pool/smartnode/shared/services/profiling"
+	"github.com/rocket-pool/smartnode/shared/services/rpcusage"
+	"github.com/rocket-pool/smartnode/shared/services/schedule"
+	"github.com/rocket-pool/smartnode/shared/services/shutdown"
 	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/tasks"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
 )
 
 // Config
 var minTasksInterval, _ = time.ParseDuration("4m")
 var maxTasksInterval, _ = time.ParseDuration("6m")
+var activeTasksInterval, _ = time.ParseDuration("1m")
+var activeTasksWindow, _ = time.ParseDuration("10m")
+var maxTaskLoopAge, _ = time.ParseDuration("1h")
 var taskCooldown, _ = time.ParseDuration("5s")
 
 const (
@@ -43,7 +55,10 @@ const (
 	ProcessPenaltiesColor          = color.FgHiMagenta
 	CancelBondsColor               = color.FgGreen
 	CheckSoloMigrationsColor       = color.FgCyan
+	ShadowSubmissionsColor         = color.FgHiBlue
+	EventTriggerColor              = color.FgBlue
 	UpdateColor                    = color.FgHiWhite
+	ShutdownColor                  = color.FgHiWhite
 )
 
 // Register watchtower command
@@ -69,6 +84,11 @@ func run(c *cli.Context) error {
 		return err
 	}
 
+	// Make sure the Execution clients are on the network the node is configured for once they're ready
+	if err := services.WaitChainIDPinned(c, true); err != nil {
+		return err
+	}
+
 	// Get services
 	cfg, err := services.GetConfig(c)
 	if err != nil {
@@ -87,6 +107,11 @@ func run(c *cli.Context) error {
 		return err
 	}
 
+	// Configure logging
+	if err := services.ConfigureLogging(cfg); err != nil {
+		return err
+	}
+
 	// Print the current mode
 	if cfg.IsNativeMode {
 		fmt.Println("Starting watchtower daemon in Native Mode.")
@@ -100,81 +125,138 @@ func run(c *cli.Context) error {
 		fmt.Println("***NOTE: EXPERIMENTAL ROLLING RECORDS ARE ENABLED, BE ADVISED!***")
 	}
 
+	// Check if shadow mode is enabled
+	useShadowMode := cfg.Smartnode.WatchtowerShadowMode.Value.(bool)
+	if useShadowMode {
+		fmt.Println("Shadow mode is enabled; Oracle DAO submissions will be verified locally if this node is not an Oracle DAO member.")
+	}
+
 	// Initialize the metrics reporters
 	scrubCollector := collectors.NewScrubCollector()
 	bondReductionCollector := collectors.NewBondReductionCollector()
 	soloMigrationCollector := collectors.NewSoloMigrationCollector()
+	shadowCollector := collectors.NewShadowCollector()
 
 	// Initialize error logger
-	errorLog := log.NewColorLogger(ErrorColor)
-	updateLog := log.NewColorLogger(UpdateColor)
+	errorLog := log.NewModuleLogger("watchtower", log.LevelError, ErrorColor)
+	alerts := alerting.NewAlertManager(cfg, log.NewModuleLogger("watchtower.alerts", log.LevelWarn, ErrorColor))
+	updateLog := log.NewModuleLogger("watchtower.state", log.LevelDebug, UpdateColor)
+	taskRecorder := tasks.NewRecorder("watchtower", cfg.Smartnode.GetTaskStatusPath("watchtower"), tasks.DefaultRunHistorySize)
+	crashGuard := tasks.NewCrashGuard("watchtower", cfg.Smartnode.GetCrashReportsPath(), taskRecorder)
+	crashGuard.AddListener(func(report tasks.CrashReport, path string) {
+		alerts.Raise(alerting.NewTaskCrashAlert(report.Daemon, report.Task, report.Panic, path))
+	})
+
+	// Let the submissions in flight finish and be recorded before the daemon exits
+	coordinator := shutdown.NewCoordinator("watchtower", log.NewModuleLogger("watchtower.shutdown", log.LevelInfo, ShutdownColor))
+	rpcUsagePath := cfg.Smartnode.GetRpcUsagePath("watchtower")
+	coordinator.AddFlusher("RPC usage", func() error { return rpcusage.GetTracker().Save("watchtower", rpcUsagePath) })
+	coordinator.HandleSignals(shutdown.DefaultTimeout)
 
-	// Create the state manager
-	m, err := state.NewNetworkStateManager(rp, cfg, rp.Client, bc, &updateLog)
+	// Get the state manager shared by the daemon's tasks
+	m, err := services.GetNetworkStateManager(c)
 	if err != nil {
 		return err
 	}
+	m = m.WithLogger(&updateLog)
+
+	// Track the daemon's health
+	healthChecker := health.NewChecker(maxTaskLoopAge, health.Component_ExecutionClient, health.Component_BeaconClient, health.Component_Wallet, health.Component_Duties)
 
 	// Get the node address
 	nodeAccount, err := w.GetNodeAccount()
 	if err != nil {
 		return fmt.Errorf("error getting node account: %w", err)
 	}
+	healthChecker.SetStatus(health.Component_Wallet, nil)
 
 	// Initialize tasks
-	respondChallenges, err := newRespondChallenges(c, log.NewColorLogger(RespondChallengesColor), m)
+	respondChallenges, err := newRespondChallenges(c, log.NewModuleLogger("watchtower.respond-challenges", log.LevelInfo, RespondChallengesColor), m)
 	if err != nil {
 		return fmt.Errorf("error during respond-to-challenges check: %w", err)
 	}
-	submitRplPrice, err := newSubmitRplPrice(c, log.NewColorLogger(SubmitRplPriceColor), errorLog)
+	submitRplPrice, err := newSubmitRplPrice(c, log.NewModuleLogger("watchtower.submit-rpl-price", log.LevelInfo, SubmitRplPriceColor), errorLog, alerts, crashGuard)
 	if err != nil {
 		return fmt.Errorf("error during rpl price check: %w", err)
 	}
-	submitNetworkBalances, err := newSubmitNetworkBalances(c, log.NewColorLogger(SubmitNetworkBalancesColor), errorLog)
+	submitNetworkBalances, err := newSubmitNetworkBalances(c, log.NewModuleLogger("watchtower.submit-network-balances", log.LevelInfo, SubmitNetworkBalancesColor), errorLog, m, alerts, crashGuard)
 	if err != nil {
 		return fmt.Errorf("error during network balances check: %w", err)
 	}
-	dissolveTimedOutMinipools, err := newDissolveTimedOutMinipools(c, log.NewColorLogger(DissolveTimedOutMinipoolsColor))
+	dissolveTimedOutMinipools, err := newDissolveTimedOutMinipools(c, log.NewModuleLogger("watchtower.dissolve-timed-out-minipools", log.LevelInfo, DissolveTimedOutMinipoolsColor))
 	if err != nil {
 		return fmt.Errorf("error during timed-out minipools check: %w", err)
 	}
-	submitScrubMinipools, err := newSubmitScrubMinipools(c, log.NewColorLogger(SubmitScrubMinipoolsColor), errorLog, scrubCollector)
+	submitScrubMinipools, err := newSubmitScrubMinipools(c, log.NewModuleLogger("watchtower.submit-scrub-minipools", log.LevelInfo, SubmitScrubMinipoolsColor), errorLog, scrubCollector, crashGuard)
 	if err != nil {
 		return fmt.Errorf("error during scrub check: %w", err)
 	}
 	var submitRewardsTree_Stateless *submitRewardsTree_Stateless
 	var submitRewardsTree_Rolling *submitRewardsTree_Rolling
 	if !useRollingRecords {
-		submitRewardsTree_Stateless, err = newSubmitRewardsTree_Stateless(c, log.NewColorLogger(SubmitRewardsTreeColor), errorLog, m)
+		submitRewardsTree_Stateless, err = newSubmitRewardsTree_Stateless(c, log.NewModuleLogger("watchtower.submit-rewards-tree", log.LevelInfo, SubmitRewardsTreeColor), errorLog, m, alerts, crashGuard)
 		if err != nil {
 			return fmt.Errorf("error during stateless rewards tree check: %w", err)
 		}
 	} else {
-		submitRewardsTree_Rolling, err = newSubmitRewardsTree_Rolling(c, log.NewColorLogger(SubmitRewardsTreeColor), errorLog, m)
+		submitRewardsTree_Rolling, err = newSubmitRewardsTree_Rolling(c, log.NewModuleLogger("watchtower.submit-rewards-tree", log.LevelInfo, SubmitRewardsTreeColor), errorLog, m, alerts, crashGuard)
 		if err != nil {
 			return fmt.Errorf("error during rolling rewards tree check: %w", err)
 		}
 	}
-	/*processPenalties, err := newProcessPenalties(c, log.NewColorLogger(ProcessPenaltiesColor), errorLog)
+	/*processPenalties, err := newProcessPenalties(c, log.NewModuleLogger("watchtower.process-penalties", log.LevelInfo, ProcessPenaltiesColor), errorLog, m, crashGuard)
 	if err != nil {
 		return fmt.Errorf("error during penalties check: %w", err)
 	}*/
-	generateRewardsTree, err := newGenerateRewardsTree(c, log.NewColorLogger(SubmitRewardsTreeColor), errorLog, m)
+	generateRewardsTree, err := newGenerateRewardsTree(c, log.NewModuleLogger("watchtower.submit-rewards-tree", log.LevelInfo, SubmitRewardsTreeColor), errorLog, m, crashGuard)
 	if err != nil {
 		return fmt.Errorf("error during manual tree generation check: %w", err)
 	}
-	cancelBondReductions, err := newCancelBondReductions(c, log.NewColorLogger(CancelBondsColor), errorLog, bondReductionCollector)
+	cancelBondReductions, err := newCancelBondReductions(c, log.NewModuleLogger("watchtower.cancel-bond-reductions", log.LevelInfo, CancelBondsColor), errorLog, bondReductionCollector, crashGuard)
 	if err != nil {
 		return fmt.Errorf("error during bond reduction cancel check: %w", err)
 	}
-	checkSoloMigrations, err := newCheckSoloMigrations(c, log.NewColorLogger(CheckSoloMigrationsColor), errorLog, soloMigrationCollector)
+	checkSoloMigrations, err := newCheckSoloMigrations(c, log.NewModuleLogger("watchtower.check-solo-migrations", log.LevelInfo, CheckSoloMigrationsColor), errorLog, soloMigrationCollector, crashGuard)
 	if err != nil {
 		return fmt.Errorf("error during solo migration check: %w", err)
 	}
+	shadowSubmissions, err := newShadowSubmissions(c, log.NewModuleLogger("watchtower.shadow-submissions", log.LevelInfo, ShadowSubmissionsColor), errorLog, submitNetworkBalances, submitRplPrice, shadowCollector, crashGuard)
+	if err != nil {
+		return fmt.Errorf("error during shadow submissions check: %w", err)
+	}
 
 	intervalDelta := maxTasksInterval - minTasksInterval
 	secondsDelta := intervalDelta.Seconds()
 
+	// Trigger the task loop on finalized checkpoints instead of waiting for the full interval
+	trigger := newEventTrigger(bc, log.NewModuleLogger("watchtower.event-trigger", log.LevelDebug, EventTriggerColor))
+	trigger.start()
+
+	// Poll faster around the times the duties have to be done by, and slower the rest of the time
+	scheduler := schedule.NewScheduler(activeTasksInterval, activeTasksWindow)
+
+	// Start the health check server if it's enabled in the config, or its port was provided directly
+	healthPort := c.GlobalUint("healthPort")
+	if healthPort == 0 && cfg.Smartnode.EnableHealthCheck.Value == true {
+		healthPort = uint(cfg.Smartnode.WatchtowerHealthPort.Value.(uint16))
+	}
+	if healthPort != 0 {
+		go func() {
+			if err := healthChecker.Serve(c.GlobalString("healthAddress"), healthPort); err != nil {
+				errorLog.Println(err)
+			}
+		}()
+	}
+
+	// Start the profiling server
+	if cfg.Smartnode.EnableProfiling.Value == true {
+		go func() {
+			if err := profiling.Serve(cfg.Smartnode.GetProfilingSocketPath("watchtower")); err != nil {
+				errorLog.Println(err)
+			}
+		}()
+	}
+
 	// Wait group to handle the various threads
 	wg := new(sync.WaitGroup)
 	wg.Add(2)
@@ -182,22 +264,28 @@ func run(c *cli.Context) error {
 	// Run task loop
 	go func() {
 		for {
+			passStart := time.Now()
+
 			// Randomize the next interval
 			randomSeconds := rand.Intn(int(secondsDelta))
 			interval := time.Duration(randomSeconds)*time.Second + minTasksInterval
 
 			// Check the EC status
 			err := services.WaitEthClientSynced(c, false) // Force refresh the primary / fallback EC status
+			healthChecker.SetStatus(health.Component_ExecutionClient, err)
 			if err != nil {
 				errorLog.Println(err)
+				healthChecker.RecordProgress()
 				time.Sleep(taskCooldown)
 				continue
 			}
 
 			// Check the BC status
 			err = services.WaitBeaconClientSynced(c, false) // Force refresh the primary / fallback BC status
+			healthChecker.SetStatus(health.Component_BeaconClient, err)
 			if err != nil {
 				errorLog.Println(err)
+				healthChecker.RecordProgress()
 				time.Sleep(taskCooldown)
 				continue
 			}
@@ -207,87 +295,75 @@ func run(c *cli.Context) error {
 			latestBlock, err := m.GetLatestBeaconBlock()
 			if err != nil {
 				errorLog.Println(fmt.Errorf("error getting latest Beacon block: %w", err))
+				healthChecker.RecordDuties([]string{"get-latest-block"})
 				time.Sleep(taskCooldown)
 				continue
 			}
+			crashGuard.SetInputs(map[string]string{
+				"slot":    fmt.Sprint(latestBlock.Slot),
+				"elBlock": fmt.Sprint(latestBlock.ExecutionBlockNumber),
+			})
 
 			// Check if on the Oracle DAO
 			isOnOdao, err := isOnOracleDAO(rp, nodeAccount.Address, latestBlock)
 			if err != nil {
 				errorLog.Println(err)
+				healthChecker.RecordDuties([]string{"check-odao-membership"})
 				time.Sleep(taskCooldown)
 				continue
 			}
 
 			// Run the manual rewards tree generation
-			if err := generateRewardsTree.run(); err != nil {
-				errorLog.Println(err)
-			}
+			runTask(coordinator, crashGuard, taskRecorder, &errorLog, "generate-rewards-tree", generateRewardsTree.run)
 			time.Sleep(taskCooldown)
 
 			if isOnOdao {
 				// Run the challenge check
-				if err := respondChallenges.run(); err != nil {
-					errorLog.Println(err)
-				}
+				runDuty(alerts, coordinator, crashGuard, taskRecorder, &errorLog, "respond-challenges", respondChallenges.run)
 				time.Sleep(taskCooldown)
 
 				// Update the network state
 				state, err := updateNetworkState(m, &updateLog, latestBlock)
 				if err != nil {
 					errorLog.Println(err)
+					healthChecker.RecordDuties(append(taskRecorder.GetFailuresSince(passStart), "update-network-state"))
 					time.Sleep(taskCooldown)
 					continue
 				}
+				updateSchedule(scheduler, state)
 
 				// Run the network balance submission check
-				if err := submitNetworkBalances.run(state); err != nil {
-					errorLog.Println(err)
-				}
+				runDuty(alerts, coordinator, crashGuard, taskRecorder, &errorLog, "submit-network-balances", func() error { return submitNetworkBalances.run(state) })
 				time.Sleep(taskCooldown)
 
 				if !useRollingRecords {
 					// Run the rewards tree submission check
-					if err := submitRewardsTree_Stateless.Run(isOnOdao, state, latestBlock.Slot); err != nil {
-						errorLog.Println(err)
-					}
+					runDuty(alerts, coordinator, crashGuard, taskRecorder, &errorLog, "submit-rewards-tree", func() error { return submitRewardsTree_Stateless.Run(isOnOdao, state, latestBlock.Slot) })
 					time.Sleep(taskCooldown)
 				} else {
 					// Run the network balance and rewards tree submission check
-					if err := submitRewardsTree_Rolling.run(state); err != nil {
-						errorLog.Println(err)
-					}
+					runDuty(alerts, coordinator, crashGuard, taskRecorder, &errorLog, "submit-rewards-tree", func() error { return submitRewardsTree_Rolling.run(state) })
 					time.Sleep(taskCooldown)
 				}
 
 				// Run the price submission check
-				if err := submitRplPrice.run(state); err != nil {
-					errorLog.Println(err)
-				}
+				runDuty(alerts, coordinator, crashGuard, taskRecorder, &errorLog, "submit-rpl-price", func() error { return submitRplPrice.run(state) })
 				time.Sleep(taskCooldown)
 
 				// Run the minipool dissolve check
-				if err := dissolveTimedOutMinipools.run(state); err != nil {
-					errorLog.Println(err)
-				}
+				runDuty(alerts, coordinator, crashGuard, taskRecorder, &errorLog, "dissolve-timed-out-minipools", func() error { return dissolveTimedOutMinipools.run(state) })
 				time.Sleep(taskCooldown)
 
 				// Run the minipool scrub check
-				if err := submitScrubMinipools.run(state); err != nil {
-					errorLog.Println(err)
-				}
+				runDuty(alerts, coordinator, crashGuard, taskRecorder, &errorLog, "submit-scrub-minipools", func() error { return submitScrubMinipools.run(state) })
 				time.Sleep(taskCooldown)
 
 				// Run the bond cancel check
-				if err := cancelBondReductions.run(state); err != nil {
-					errorLog.Println(err)
-				}
+				runDuty(alerts, coordinator, crashGuard, taskRecorder, &errorLog, "cancel-bond-reductions", func() error { return cancelBondReductions.run(state) })
 				time.Sleep(taskCooldown)
 
 				// Run the solo migration check
-				if err := checkSoloMigrations.run(state); err != nil {
-					errorLog.Println(err)
-				}
+				runDuty(alerts, coordinator, crashGuard, taskRecorder, &errorLog, "check-solo-migrations", func() error { return checkSoloMigrations.run(state) })
 				/*time.Sleep(taskCooldown)
 
 				// Run the fee recipient penalty check
@@ -300,25 +376,43 @@ func run(c *cli.Context) error {
 				 */
 				if !useRollingRecords {
 					// Run the rewards tree submission check
-					if err := submitRewardsTree_Stateless.Run(isOnOdao, nil, latestBlock.Slot); err != nil {
-						errorLog.Println(err)
-					}
+					runTask(coordinator, crashGuard, taskRecorder, &errorLog, "submit-rewards-tree", func() error { return submitRewardsTree_Stateless.Run(isOnOdao, nil, latestBlock.Slot) })
 				} else {
 					// Run the network balance and rewards tree submission check
-					if err := submitRewardsTree_Rolling.run(nil); err != nil {
+					runTask(coordinator, crashGuard, taskRecorder, &errorLog, "submit-rewards-tree", func() error { return submitRewardsTree_Rolling.run(nil) })
+				}
+
+				if useShadowMode {
+					time.Sleep(taskCooldown)
+
+					// Update the network state
+					state, err := updateNetworkState(m, &updateLog, latestBlock)
+					if err != nil {
 						errorLog.Println(err)
+						healthChecker.RecordDuties(append(taskRecorder.GetFailuresSince(passStart), "update-network-state"))
+						time.Sleep(taskCooldown)
+						continue
 					}
+
+					// Run the shadow submissions check
+					runTask(coordinator, crashGuard, taskRecorder, &errorLog, "shadow-submissions", func() error { return shadowSubmissions.run(state) })
 				}
 			}
 
-			time.Sleep(interval)
+			healthChecker.RecordDuties(taskRecorder.GetFailuresSince(passStart))
+
+			// Save the RPC usage so the CLI can report it
+			if err := rpcusage.GetTracker().Save("watchtower", rpcUsagePath); err != nil {
+				errorLog.Println(err)
+			}
+			trigger.wait(interval, scheduler)
 		}
 		wg.Done()
 	}()
 
 	// Run metrics loop
 	go func() {
-		err := runMetricsServer(c, log.NewColorLogger(MetricsColor), scrubCollector, bondReductionCollector, soloMigrationCollector)
+		err := runMetricsServer(c, log.NewModuleLogger("watchtower.metrics", log.LevelInfo, MetricsColor), scrubCollector, bondReductionCollector, soloMigrationCollector, shadowCollector, taskRecorder)
 		if err != nil {
 			errorLog.Println(err)
 		}
@@ -351,6 +445,31 @@ func updateNetworkState(m *state.NetworkStateManager, log *log.ColorLogger, bloc
 	return state, nil
 }
 
+// Set the deadlines the duties have to be done by from the latest network state
+func updateSchedule(scheduler *schedule.Scheduler, state *state.NetworkState) {
+	// The rewards tree for the interval has to be generated and submitted once it ends
+	intervalEnd := state.NetworkDetails.IntervalStart.Add(state.NetworkDetails.IntervalDuration)
+	scheduler.SetDeadline("the end of the rewards interval", intervalEnd)
+
+	// Prelaunch minipools have to be scrubbed before their scrub period ends, so track the next one to end
+	var nextScrubEnd time.Time
+	now := time.Now()
+	for _, mpd := range state.MinipoolDetails {
+		if mpd.Status != rptypes.Prelaunch || mpd.IsVacant {
+			continue
+		}
+		scrubEnd := time.Unix(mpd.StatusTime.Int64(), 0).Add(state.NetworkDetails.ScrubPeriod)
+		if scrubEnd.After(now) && (nextScrubEnd.IsZero() || scrubEnd.Before(nextScrubEnd)) {
+			nextScrubEnd = scrubEnd
+		}
+	}
+	if nextScrubEnd.IsZero() {
+		scheduler.ClearDeadline("the end of the next scrub period")
+	} else {
+		scheduler.SetDeadline("the end of the next scrub period", nextScrubEnd)
+	}
+}
+
 // Check if this node is on the Oracle DAO
 func isOnOracleDAO(rp *rocketpool.RocketPool, nodeAddress common.Address, block beacon.BeaconBlock) (bool, error) {
 	opts := &bind.CallOpts{
@@ -363,3 +482,41 @@ func isOnOracleDAO(rp *rocketpool.RocketPool, nodeAddress common.Address, block
 	}
 	return nodeTrusted, nil
 }
+
+// Run a task, recovering if it panics, and record its outcome and log it if it failed.
+// Tasks aren't started once the daemon is shutting down, and the ones running hold up the shutdown until they finish.
+func runTask(coordinator *shutdown.Coordinator, guard *tasks.CrashGuard, recorder *tasks.Recorder, errorLog *log.ColorLogger, task string, run func() error) error {
+	start := time.Now()
+	err := coordinator.Run(task, func() error {
+		return guard.Run(task, run)
+	})
+	if errors.Is(err, shutdown.ErrShuttingDown) {
+		return err
+	}
+	if saveErr := recorder.Record(task, start, err); saveErr != nil {
+		errorLog.Println(saveErr)
+	}
+	if err != nil {
+		errorLog.Println(err)
+	}
+	return err
+}
+
+// Run an Oracle DAO duty and raise an alert if it failed, or clear the alert if it succeeded
+func runDuty(alerts *alerting.AlertManager, coordinator *shutdown.Coordinator, guard *tasks.CrashGuard, recorder *tasks.Recorder, errorLog *log.ColorLogger, duty string, run func() error) {
+	err := runTask(coordinator, guard, recorder, errorLog, duty, run)
+	if errors.Is(err, shutdown.ErrShuttingDown) {
+		return
+	}
+	message := fmt.Sprintf("The %s duty completed successfully.", duty)
+	if err != nil {
+		message = fmt.Sprintf("The %s duty failed: %s", duty, err.Error())
+	}
+	alerts.Update(alerting.Alert{
+		Rule:     alerting.Rule_WatchtowerDuty,
+		Subject:  duty,
+		Severity: alerting.Severity_Critical,
+		Title:    fmt.Sprintf("Watchtower duty %s failed", duty),
+		Message:  message,
+	}, err != nil)
+}
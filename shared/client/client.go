@@ -0,0 +1,214 @@
+// Go SDK for the node daemon's API server. It has a typed function for every API route, taking the same arguments as the rocketpool CLI's
+// API client and returning the response types from shared/types/api, so tools that don't run on the node can use the daemon with compile-time
+// checked calls. The functions are generated from the CLI's API client; the commands only ever run over HTTP on the server.
+package client
+
+//go:generate go run ./gen
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/rocket-pool/smartnode/shared/services/events"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Settings
+const (
+	routePrefix string = "/api/v1/"
+)
+
+// A client for the node daemon's API server.
+// It isn't safe for concurrent use, since the transaction settings apply to the calls that follow them.
+type Client struct {
+	url             string
+	token           string
+	httpClient      *http.Client
+	maxFee          float64
+	maxPrioFee      float64
+	gasLimit        uint64
+	customNonce     *big.Int
+	ignoreSyncCheck bool
+	forceFallbacks  bool
+	idempotencyKey  string
+
+	// Commands like signing a message skip the sync check for their own call, after which the configured setting applies again
+	defaultIgnoreSyncCheck bool
+}
+
+// Create a client for the API server at the provided base URL (such as http://127.0.0.1:8280), using a key created with `rocketpool service api-keys add`.
+// Commands the key's role doesn't allow return an error.
+func New(url string, token string) *Client {
+	return &Client{
+		url:        strings.TrimSuffix(url, "/"),
+		token:      token,
+		httpClient: http.DefaultClient,
+	}
+}
+
+// Use the provided HTTP client for requests instead of the default one, such as to set a timeout
+func (c *Client) SetHttpClient(httpClient *http.Client) {
+	c.httpClient = httpClient
+}
+
+// Set the max fee (in gwei), max priority fee (in gwei) and gas limit of the transactions the client submits; use 0 for the daemon's defaults
+func (c *Client) AssignGasSettings(maxFee float64, maxPrioFee float64, gasLimit uint64) {
+	c.maxFee = maxFee
+	c.maxPrioFee = maxPrioFee
+	c.gasLimit = gasLimit
+}
+
+// Set the nonce of the transactions the client submits; use nil for the next one
+func (c *Client) SetCustomNonce(nonce *big.Int) {
+	c.customNonce = nonce
+}
+
+// Set the key sent with every transaction the client submits until it's changed; use an empty key to stop sending one.
+// Submitting the same command with the same key again returns the original response instead of sending a new transaction.
+func (c *Client) SetIdempotencyKey(key string) {
+	c.idempotencyKey = key
+}
+
+// Set the flags for ignoring EC and CC sync checks and forcing the fallback clients
+func (c *Client) SetClientStatusFlags(ignoreSyncCheck bool, forceFallbacks bool) {
+	c.ignoreSyncCheck = ignoreSyncCheck
+	c.defaultIgnoreSyncCheck = ignoreSyncCheck
+	c.forceFallbacks = forceFallbacks
+}
+
+// Get the API server's routes and the role each one needs, along with the role of the client's key
+func (c *Client) Routes() (api.ServerRoutesResponse, error) {
+	request, err := http.NewRequest(http.MethodGet, c.url+routePrefix+"routes", nil)
+	if err != nil {
+		return api.ServerRoutesResponse{}, fmt.Errorf("Could not get API server routes: %w", err)
+	}
+	request.Header.Set("Authorization", "Bearer "+c.token)
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return api.ServerRoutesResponse{}, fmt.Errorf("Could not get API server routes: %w", err)
+	}
+	defer response.Body.Close()
+	var routes api.ServerRoutesResponse
+	if err := json.NewDecoder(response.Body).Decode(&routes); err != nil {
+		return api.ServerRoutesResponse{}, fmt.Errorf("Could not decode API server routes response: %w", err)
+	}
+	if routes.Error != "" {
+		return api.ServerRoutesResponse{}, fmt.Errorf("Could not get API server routes: %s", routes.Error)
+	}
+	return routes, nil
+}
+
+// Stream the node daemon's events, calling the handler with each one until the context is cancelled or the stream ends.
+// Pass the ID of the last event that was handled to catch up on the ones that were missed while disconnected, or 0 for only new events.
+func (c *Client) StreamEvents(ctx context.Context, lastEventID uint64, handler func(events.Event)) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url+routePrefix+"events", nil)
+	if err != nil {
+		return fmt.Errorf("Could not stream events: %w", err)
+	}
+	request.Header.Set("Authorization", "Bearer "+c.token)
+	request.Header.Set("Accept", "text/event-stream")
+	if lastEventID > 0 {
+		request.Header.Set("Last-Event-ID", strconv.FormatUint(lastEventID, 10))
+	}
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("Could not stream events: %w", err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(response.Body)
+		return fmt.Errorf("Could not stream events: HTTP %d: %s", response.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	// Every event's data line holds the whole event; the other fields and keepalive comments can be skipped
+	scanner := bufio.NewScanner(response.Body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "data: ") {
+			continue
+		}
+		var event events.Event
+		if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &event); err != nil {
+			return fmt.Errorf("Could not decode event: %w", err)
+		}
+		handler(event)
+	}
+	if err := scanner.Err(); err != nil && ctx.Err() == nil {
+		return fmt.Errorf("Could not stream events: %w", err)
+	}
+	return nil
+}
+
+// Run an API command on the server
+func (c *Client) callAPI(args string, otherArgs ...string) ([]byte, error) {
+	return c.callAPIWithEnvVars(nil, args, otherArgs...)
+}
+
+// Run an API command on the server, passing it secrets that it reads from its environment in the request body
+func (c *Client) callAPIWithEnvVars(envVars map[string]string, args string, otherArgs ...string) ([]byte, error) {
+	defer func() {
+		c.ignoreSyncCheck = c.defaultIgnoreSyncCheck
+	}()
+
+	// Every api command belongs to a group except for wait, so the route is made of the first one or two words
+	words := strings.Fields(args)
+	if len(words) == 0 {
+		return nil, fmt.Errorf("no API command provided")
+	}
+	routeLength := 2
+	if words[0] == "wait" || len(words) < 2 {
+		routeLength = 1
+	}
+	request := api.ServerRequest{
+		Args:            append(words[routeLength:], otherArgs...),
+		MaxFee:          c.maxFee,
+		MaxPrioFee:      c.maxPrioFee,
+		GasLimit:        c.gasLimit,
+		IgnoreSyncCheck: c.ignoreSyncCheck,
+		ForceFallbacks:  c.forceFallbacks,
+		IdempotencyKey:  c.idempotencyKey,
+		EnvVars:         envVars,
+	}
+	if c.customNonce != nil {
+		request.Nonce = c.customNonce.String()
+	}
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("error serializing API server request: %w", err)
+	}
+
+	url := c.url + routePrefix + strings.Join(words[:routeLength], "/")
+	httpRequest, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("error creating API server request: %w", err)
+	}
+	httpRequest.Header.Set("Content-Type", "application/json")
+	httpRequest.Header.Set("Authorization", "Bearer "+c.token)
+	response, err := c.httpClient.Do(httpRequest)
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to API server: %w", err)
+	}
+	defer response.Body.Close()
+	output, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading API server response: %w", err)
+	}
+
+	// Error responses from the server itself carry the same status and error fields as command responses
+	if response.StatusCode != http.StatusOK {
+		var errorResponse api.APIResponse
+		if err := json.Unmarshal(output, &errorResponse); err == nil && errorResponse.Error != "" {
+			return nil, fmt.Errorf("API server error (HTTP %d): %s", response.StatusCode, errorResponse.Error)
+		}
+		return nil, fmt.Errorf("API server error (HTTP %d): %s", response.StatusCode, string(output))
+	}
+	return output, nil
+}
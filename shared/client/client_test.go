@@ -0,0 +1,122 @@
+package client
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+const testToken string = "test-token"
+
+// A request the test server received
+type receivedRequest struct {
+	path    string
+	token   string
+	request api.ServerRequest
+}
+
+// Start a server that records each request and replies with the provided status and body
+func newTestServer(t *testing.T, status int, body string) (*Client, *[]receivedRequest) {
+	t.Helper()
+	received := []receivedRequest{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request api.ServerRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Errorf("error decoding request: %s", err.Error())
+		}
+		received = append(received, receivedRequest{
+			path:    r.URL.Path,
+			token:   strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "),
+			request: request,
+		})
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return New(server.URL+"/", testToken), &received
+}
+
+func TestCommandsRunOnTheirRoute(t *testing.T) {
+	client, received := newTestServer(t, http.StatusOK, `{"status":"success","canRegister":true}`)
+	client.AssignGasSettings(10, 2, 0)
+	client.SetIdempotencyKey("register-once")
+
+	response, err := client.CanRegisterNode("Europe/Berlin")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if !response.CanRegister {
+		t.Fatal("expected the response to be decoded")
+	}
+
+	request := (*received)[0]
+	if request.path != "/api/v1/node/can-register" {
+		t.Fatalf("expected the node/can-register route, got %s", request.path)
+	}
+	if request.token != testToken {
+		t.Fatalf("expected the client's token, got %s", request.token)
+	}
+	if len(request.request.Args) != 1 || request.request.Args[0] != "Europe/Berlin" {
+		t.Fatalf("expected the timezone as the only argument, got %v", request.request.Args)
+	}
+	if request.request.MaxFee != 10 || request.request.MaxPrioFee != 2 || request.request.IdempotencyKey != "register-once" {
+		t.Fatalf("expected the transaction settings to be sent, got %+v", request.request)
+	}
+}
+
+func TestSecretsAreSentInTheBody(t *testing.T) {
+	client, received := newTestServer(t, http.StatusOK, `{"status":"success"}`)
+	if _, err := client.CreateBackup("hunter2"); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	request := (*received)[0]
+	if request.path != "/api/v1/service/create-backup" {
+		t.Fatalf("expected the service/create-backup route, got %s", request.path)
+	}
+	if len(request.request.EnvVars) != 1 || len(request.request.Args) != 0 {
+		t.Fatalf("expected the passphrase in the environment variables only, got %+v", request.request)
+	}
+}
+
+func TestSyncCheckOverrideOnlyAppliesToItsCall(t *testing.T) {
+	client, received := newTestServer(t, http.StatusOK, `{"status":"success"}`)
+	if _, err := client.SignMessage("hello"); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if _, err := client.NodeStatus(); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if !(*received)[0].request.IgnoreSyncCheck {
+		t.Fatal("expected signing to skip the sync check")
+	}
+	if (*received)[1].request.IgnoreSyncCheck {
+		t.Fatal("expected the next command to use the configured sync check")
+	}
+}
+
+func TestErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		contains string
+	}{
+		{name: "server error", status: http.StatusForbidden, body: `{"status":"error","error":"this key can't run node/status"}`, contains: "HTTP 403): this key can't run node/status"},
+		{name: "server error without a body", status: http.StatusBadGateway, body: "bad gateway", contains: "HTTP 502): bad gateway"},
+		{name: "command error", status: http.StatusOK, body: `{"status":"error","error":"the node isn't registered"}`, contains: "the node isn't registered"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client, _ := newTestServer(t, test.status, test.body)
+			_, err := client.NodeStatus()
+			if err == nil || !strings.Contains(err.Error(), test.contains) {
+				t.Fatalf("expected an error containing \"%s\", got %v", test.contains, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,313 @@
+// Generates the SDK's typed function for every API route from the CLI's API client, so the two always take the same arguments and return the same responses.
+// Run it with `go generate ./shared/client` after adding or changing an API command.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	clientDir  string = "../services/rocketpool"
+	clientType string = "Client"
+	header     string = "// Code generated by gen/main.go; DO NOT EDIT.\n\n"
+)
+
+func main() {
+	fset := token.NewFileSet()
+	files, err := parseFiles(fset, clientDir)
+	if err != nil {
+		fail(err)
+	}
+
+	// Every API method is copied, along with the package-level helpers it uses
+	helpers := map[string]ast.Decl{}
+	topLevel := map[interface{}]bool{}
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			for _, name := range getDeclaredNames(decl) {
+				helpers[name] = decl
+			}
+			switch d := decl.(type) {
+			case *ast.FuncDecl:
+				topLevel[d] = true
+			case *ast.GenDecl:
+				for _, spec := range d.Specs {
+					topLevel[spec] = true
+				}
+			}
+		}
+	}
+	copied := map[ast.Decl]bool{}
+	pending := []ast.Decl{}
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv != nil && fn.Body != nil && callsAPI(fn) {
+				copied[decl] = true
+				pending = append(pending, decl)
+			}
+		}
+	}
+	for len(pending) > 0 {
+		decl := pending[0]
+		pending = pending[1:]
+		for _, name := range getPackageReferences(decl, topLevel) {
+			helper, exists := helpers[name]
+			if exists && !copied[helper] && isHelper(helper) {
+				copied[helper] = true
+				pending = append(pending, helper)
+			}
+		}
+	}
+
+	if err := removeGeneratedFiles(); err != nil {
+		fail(err)
+	}
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := generateFile(fset, name, files[name], copied); err != nil {
+			fail(err)
+		}
+	}
+}
+
+// Print an error and quit
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
+
+// Parse the CLI's API client, keyed by file name
+func parseFiles(fset *token.FileSet, dir string) (map[string]*ast.File, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	files := map[string]*ast.File{}
+	for _, path := range paths {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing %s: %w", path, err)
+		}
+		files[filepath.Base(path)] = file
+	}
+	return files, nil
+}
+
+// Clear out the previous output so removed commands don't linger
+func removeGeneratedFiles() error {
+	paths, err := filepath.Glob("*.go")
+	if err != nil {
+		return err
+	}
+	for _, path := range paths {
+		bytes, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if strings.HasPrefix(string(bytes), header) {
+			if err := os.Remove(path); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Write the declarations copied from one of the CLI client's files into a file of the same name
+func generateFile(fset *token.FileSet, name string, file *ast.File, copied map[ast.Decl]bool) error {
+	var decls bytes.Buffer
+	packages := map[string]bool{}
+	for _, decl := range file.Decls {
+		if !copied[decl] {
+			continue
+		}
+		if err := printer.Fprint(&decls, fset, &printer.CommentedNode{Node: decl, Comments: file.Comments}); err != nil {
+			return fmt.Errorf("error printing declaration in %s: %w", name, err)
+		}
+		decls.WriteString("\n\n")
+		ast.Inspect(decl, func(node ast.Node) bool {
+			if selector, ok := node.(*ast.SelectorExpr); ok {
+				if ident, ok := selector.X.(*ast.Ident); ok {
+					packages[ident.Name] = true
+				}
+			}
+			return true
+		})
+	}
+	if decls.Len() == 0 {
+		return nil
+	}
+
+	// Don't overwrite the SDK's own files
+	if bytes, err := os.ReadFile(name); err == nil && !strings.HasPrefix(string(bytes), header) {
+		return fmt.Errorf("%s would overwrite a file that isn't generated", name)
+	}
+
+	// Keep the imports the copied declarations use, with the standard library first
+	imports := []string{}
+	otherImports := []string{}
+	for _, spec := range file.Imports {
+		importPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			return err
+		}
+		packageName := getPackageName(importPath)
+		line := strconv.Quote(importPath)
+		if spec.Name != nil {
+			packageName = spec.Name.Name
+			line = packageName + " " + line
+		}
+		if !packages[packageName] {
+			continue
+		}
+		if strings.Contains(strings.Split(importPath, "/")[0], ".") {
+			otherImports = append(otherImports, line)
+		} else {
+			imports = append(imports, line)
+		}
+	}
+	if len(imports) > 0 && len(otherImports) > 0 {
+		imports = append(imports, "")
+	}
+	imports = append(imports, otherImports...)
+
+	var source bytes.Buffer
+	source.WriteString(header)
+	source.WriteString("package client\n\n")
+	source.WriteString("import (\n")
+	for _, line := range imports {
+		if line == "" {
+			source.WriteString("\n")
+			continue
+		}
+		source.WriteString("\t" + line + "\n")
+	}
+	source.WriteString(")\n\n")
+	source.Write(decls.Bytes())
+
+	formatted, err := format.Source(source.Bytes())
+	if err != nil {
+		return fmt.Errorf("error formatting generated source for %s: %w", name, err)
+	}
+	if err := os.WriteFile(name, formatted, 0644); err != nil {
+		return fmt.Errorf("error writing %s: %w", name, err)
+	}
+	return nil
+}
+
+// Check whether a method runs an API command
+func callsAPI(fn *ast.FuncDecl) bool {
+	found := false
+	ast.Inspect(fn.Body, func(node ast.Node) bool {
+		call, ok := node.(*ast.CallExpr)
+		if !ok {
+			return !found
+		}
+		if selector, ok := call.Fun.(*ast.SelectorExpr); ok {
+			if selector.Sel.Name == "callAPI" || selector.Sel.Name == "callAPIWithEnvVars" {
+				found = true
+			}
+		}
+		return !found
+	})
+	return found
+}
+
+// Get the names a declaration uses that aren't declared inside it, which are either package-level or built in
+func getPackageReferences(decl ast.Decl, topLevel map[interface{}]bool) []string {
+	names := []string{}
+	var inspect func(node ast.Node) bool
+	inspect = func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.SelectorExpr:
+			// Fields and methods are never package-level names
+			ast.Inspect(n.X, inspect)
+			return false
+		case *ast.KeyValueExpr:
+			// Struct literal keys are field names
+			if _, ok := n.Key.(*ast.Ident); !ok {
+				ast.Inspect(n.Key, inspect)
+			}
+			ast.Inspect(n.Value, inspect)
+			return false
+		case *ast.Ident:
+			// Names declared inside a function are resolved to their declaration by the parser
+			if n.Obj == nil || topLevel[n.Obj.Decl] {
+				names = append(names, n.Name)
+			}
+		}
+		return true
+	}
+	ast.Inspect(decl, inspect)
+	return names
+}
+
+// Check whether a declaration is a package-level helper rather than the client or one of its methods, which the SDK has its own of
+func isHelper(decl ast.Decl) bool {
+	if fn, ok := decl.(*ast.FuncDecl); ok {
+		return fn.Recv == nil
+	}
+	gen, ok := decl.(*ast.GenDecl)
+	if !ok || gen.Tok == token.IMPORT {
+		return false
+	}
+	for _, name := range getDeclaredNames(decl) {
+		if name == clientType {
+			return false
+		}
+	}
+	return true
+}
+
+// Get the package-level names a declaration adds
+func getDeclaredNames(decl ast.Decl) []string {
+	names := []string{}
+	switch d := decl.(type) {
+	case *ast.FuncDecl:
+		if d.Recv == nil {
+			names = append(names, d.Name.Name)
+		}
+	case *ast.GenDecl:
+		for _, spec := range d.Specs {
+			switch s := spec.(type) {
+			case *ast.ValueSpec:
+				for _, name := range s.Names {
+					names = append(names, name.Name)
+				}
+			case *ast.TypeSpec:
+				names = append(names, s.Name.Name)
+			}
+		}
+	}
+	return names
+}
+
+// Get the name a package is used by when it's imported without one
+func getPackageName(importPath string) string {
+	parts := strings.Split(importPath, "/")
+	name := parts[len(parts)-1]
+	if len(parts) > 1 && strings.HasPrefix(name, "v") {
+		if _, err := strconv.Atoi(name[1:]); err == nil {
+			name = parts[len(parts)-2]
+		}
+	}
+	return strings.TrimPrefix(name, "go-")
+}
@@ -0,0 +1,317 @@
+// Code generated by gen/main.go; DO NOT EDIT.
+
+package client
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	"github.com/goccy/go-json"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	utils "github.com/rocket-pool/smartnode/shared/utils/api"
+)
+
+// Get network node fee
+func (c *Client) NodeFee() (api.NodeFeeResponse, error) {
+	responseBytes, err := c.callAPI("network node-fee")
+	if err != nil {
+		return api.NodeFeeResponse{}, fmt.Errorf("Could not get network node fee: %w", err)
+	}
+	var response api.NodeFeeResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeFeeResponse{}, fmt.Errorf("Could not decode network node fee response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeFeeResponse{}, fmt.Errorf("Could not get network node fee: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the node fee curve and the fees locked in by minipools created over the last few days
+func (c *Client) NodeFeeHistory(days uint64) (api.NodeFeeHistoryResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("network get-node-fee-history %d", days))
+	if err != nil {
+		return api.NodeFeeHistoryResponse{}, fmt.Errorf("Could not get network node fee history: %w", err)
+	}
+	var response api.NodeFeeHistoryResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeFeeHistoryResponse{}, fmt.Errorf("Could not decode network node fee history response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeFeeHistoryResponse{}, fmt.Errorf("Could not get network node fee history: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the base fees and priority fees paid in the latest blocks
+func (c *Client) GasFeeHistory() (api.GasFeeHistoryResponse, error) {
+	responseBytes, err := c.callAPI("network get-gas-fee-history")
+	if err != nil {
+		return api.GasFeeHistoryResponse{}, fmt.Errorf("Could not get gas fee history: %w", err)
+	}
+	var response api.GasFeeHistoryResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.GasFeeHistoryResponse{}, fmt.Errorf("Could not decode gas fee history response: %w", err)
+	}
+	if response.Error != "" {
+		return api.GasFeeHistoryResponse{}, fmt.Errorf("Could not get gas fee history: %s", response.Error)
+	}
+	utils.ZeroIfNil(&response.NextBaseFee)
+	return response, nil
+}
+
+// Get the current prices of ETH and RPL in the configured fiat currency
+func (c *Client) FiatPrices() (api.FiatPricesResponse, error) {
+	responseBytes, err := c.callAPI("network get-fiat-prices")
+	if err != nil {
+		return api.FiatPricesResponse{}, fmt.Errorf("Could not get fiat prices: %w", err)
+	}
+	var response api.FiatPricesResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.FiatPricesResponse{}, fmt.Errorf("Could not decode fiat prices response: %w", err)
+	}
+	if response.Error != "" {
+		return api.FiatPricesResponse{}, fmt.Errorf("Could not get fiat prices: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get network RPL price
+func (c *Client) RplPrice() (api.RplPriceResponse, error) {
+	responseBytes, err := c.callAPI("network rpl-price")
+	if err != nil {
+		return api.RplPriceResponse{}, fmt.Errorf("Could not get network RPL price: %w", err)
+	}
+	var response api.RplPriceResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.RplPriceResponse{}, fmt.Errorf("Could not decode network RPL price response: %w", err)
+	}
+	if response.Error != "" {
+		return api.RplPriceResponse{}, fmt.Errorf("Could not get network RPL price: %s", response.Error)
+	}
+	if response.RplPrice == nil {
+		response.RplPrice = big.NewInt(0)
+	}
+	if response.MinPer8EthMinipoolRplStake == nil {
+		response.MinPer8EthMinipoolRplStake = big.NewInt(0)
+	}
+	if response.MaxPer8EthMinipoolRplStake == nil {
+		response.MaxPer8EthMinipoolRplStake = big.NewInt(0)
+	}
+	if response.MinPer16EthMinipoolRplStake == nil {
+		response.MinPer16EthMinipoolRplStake = big.NewInt(0)
+	}
+	if response.MaxPer16EthMinipoolRplStake == nil {
+		response.MaxPer16EthMinipoolRplStake = big.NewInt(0)
+	}
+	return response, nil
+}
+
+// Get network stats
+func (c *Client) NetworkStats() (api.NetworkStatsResponse, error) {
+	responseBytes, err := c.callAPI("network stats")
+	if err != nil {
+		return api.NetworkStatsResponse{}, fmt.Errorf("Could not get network stats: %w", err)
+	}
+	var response api.NetworkStatsResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NetworkStatsResponse{}, fmt.Errorf("Could not decode network stats response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NetworkStatsResponse{}, fmt.Errorf("Could not get network stats: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the trailing rETH APR and an estimate of the node's APR
+func (c *Client) NetworkApr() (api.NetworkAprResponse, error) {
+	responseBytes, err := c.callAPI("network apr")
+	if err != nil {
+		return api.NetworkAprResponse{}, fmt.Errorf("Could not get network APR: %w", err)
+	}
+	var response api.NetworkAprResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NetworkAprResponse{}, fmt.Errorf("Could not decode network APR response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NetworkAprResponse{}, fmt.Errorf("Could not get network APR: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get a signed snapshot of network-wide stats
+func (c *Client) NetworkStatsSnapshot() (api.NetworkStatsSnapshotResponse, error) {
+	responseBytes, err := c.callAPI("network stats-snapshot")
+	if err != nil {
+		return api.NetworkStatsSnapshotResponse{}, fmt.Errorf("Could not get network stats snapshot: %w", err)
+	}
+	var response api.NetworkStatsSnapshotResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NetworkStatsSnapshotResponse{}, fmt.Errorf("Could not decode network stats snapshot response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NetworkStatsSnapshotResponse{}, fmt.Errorf("Could not get network stats snapshot: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the timezone map
+func (c *Client) TimezoneMap() (api.NetworkTimezonesResponse, error) {
+	responseBytes, err := c.callAPI("network timezone-map")
+	if err != nil {
+		return api.NetworkTimezonesResponse{}, fmt.Errorf("Could not get network timezone map: %w", err)
+	}
+	var response api.NetworkTimezonesResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NetworkTimezonesResponse{}, fmt.Errorf("Could not decode network timezone map response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NetworkTimezonesResponse{}, fmt.Errorf("Could not get network timezone map: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Check if the rewards tree for the provided interval can be generated
+func (c *Client) CanGenerateRewardsTree(index uint64) (api.CanNetworkGenerateRewardsTreeResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("network can-generate-rewards-tree %d", index))
+	if err != nil {
+		return api.CanNetworkGenerateRewardsTreeResponse{}, fmt.Errorf("Could not check rewards tree generation status: %w", err)
+	}
+	var response api.CanNetworkGenerateRewardsTreeResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.CanNetworkGenerateRewardsTreeResponse{}, fmt.Errorf("Could not decode rewards tree generation status response: %w", err)
+	}
+	if response.Error != "" {
+		return api.CanNetworkGenerateRewardsTreeResponse{}, fmt.Errorf("Could not check rewards tree generation status: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Set a request marker for the watchtower to generate the rewards tree for the given interval
+func (c *Client) GenerateRewardsTree(index uint64) (api.NetworkGenerateRewardsTreeResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("network generate-rewards-tree %d", index))
+	if err != nil {
+		return api.NetworkGenerateRewardsTreeResponse{}, fmt.Errorf("Could not initialize rewards tree generation: %w", err)
+	}
+	var response api.NetworkGenerateRewardsTreeResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NetworkGenerateRewardsTreeResponse{}, fmt.Errorf("Could not decode rewards tree generation response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NetworkGenerateRewardsTreeResponse{}, fmt.Errorf("Could not initialize rewards tree generation: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the progress of the rewards tree the watchtower is generating, or the last one it generated
+func (c *Client) GetRewardsTreeProgress() (api.NetworkRewardsTreeProgressResponse, error) {
+	responseBytes, err := c.callAPI("network get-rewards-tree-progress")
+	if err != nil {
+		return api.NetworkRewardsTreeProgressResponse{}, fmt.Errorf("Could not get rewards tree generation progress: %w", err)
+	}
+	var response api.NetworkRewardsTreeProgressResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NetworkRewardsTreeProgressResponse{}, fmt.Errorf("Could not decode rewards tree generation progress response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NetworkRewardsTreeProgressResponse{}, fmt.Errorf("Could not get rewards tree generation progress: %s", response.Error)
+	}
+	return response, nil
+}
+
+// GetActiveDAOProposals fetches information about active DAO proposals
+func (c *Client) GetActiveDAOProposals() (api.NetworkDAOProposalsResponse, error) {
+	responseBytes, err := c.callAPI("network dao-proposals")
+	if err != nil {
+		return api.NetworkDAOProposalsResponse{}, fmt.Errorf("could not request active DAO proposals: %w", err)
+	}
+	var response api.NetworkDAOProposalsResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NetworkDAOProposalsResponse{}, fmt.Errorf("could not decode dao proposals response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NetworkDAOProposalsResponse{}, fmt.Errorf("error after requesting dao proposals: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Download a rewards info file from IPFS for the given interval
+func (c *Client) DownloadRewardsFile(interval uint64) (api.DownloadRewardsFileResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("network download-rewards-file %d", interval))
+	if err != nil {
+		return api.DownloadRewardsFileResponse{}, fmt.Errorf("could not download rewards file: %w", err)
+	}
+	var response api.DownloadRewardsFileResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.DownloadRewardsFileResponse{}, fmt.Errorf("could not decode download-rewards-file response: %w", err)
+	}
+	if response.Error != "" {
+		return api.DownloadRewardsFileResponse{}, fmt.Errorf("error after downloading rewards file: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Check if Atlas has been deployed yet
+func (c *Client) IsAtlasDeployed() (api.IsAtlasDeployedResponse, error) {
+	responseBytes, err := c.callAPI("network is-atlas-deployed")
+	if err != nil {
+		return api.IsAtlasDeployedResponse{}, fmt.Errorf("could not check if Atlas is deployed: %w", err)
+	}
+	var response api.IsAtlasDeployedResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.IsAtlasDeployedResponse{}, fmt.Errorf("could not decode is-atlas-deployed response: %w", err)
+	}
+	if response.Error != "" {
+		return api.IsAtlasDeployedResponse{}, fmt.Errorf("could not check if Atlas is deployed: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the address of the latest minipool delegate contract
+func (c *Client) GetLatestDelegate() (api.GetLatestDelegateResponse, error) {
+	responseBytes, err := c.callAPI("network latest-delegate")
+	if err != nil {
+		return api.GetLatestDelegateResponse{}, fmt.Errorf("could not get latest delegate: %w", err)
+	}
+	var response api.GetLatestDelegateResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.GetLatestDelegateResponse{}, fmt.Errorf("could not decode get-latest-delegate response: %w", err)
+	}
+	if response.Error != "" {
+		return api.GetLatestDelegateResponse{}, fmt.Errorf("could not get latest delegate: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the current address and version of each Rocket Pool contract
+func (c *Client) NetworkContracts() (api.NetworkContractsResponse, error) {
+	responseBytes, err := c.callAPI("network contracts")
+	if err != nil {
+		return api.NetworkContractsResponse{}, fmt.Errorf("Could not get network contracts: %w", err)
+	}
+	var response api.NetworkContractsResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NetworkContractsResponse{}, fmt.Errorf("Could not decode network contracts response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NetworkContractsResponse{}, fmt.Errorf("Could not get network contracts: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Decode the payload of an oracle DAO or protocol DAO proposal
+func (c *Client) DecodeProposalPayload(daoName string, payload []byte) (api.DecodeProposalPayloadResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("network decode-proposal-payload %s %s", daoName, hex.EncodeToString(payload)))
+	if err != nil {
+		return api.DecodeProposalPayloadResponse{}, fmt.Errorf("Could not decode proposal payload: %w", err)
+	}
+	var response api.DecodeProposalPayloadResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.DecodeProposalPayloadResponse{}, fmt.Errorf("Could not decode proposal payload response: %w", err)
+	}
+	if response.Error != "" {
+		return api.DecodeProposalPayloadResponse{}, fmt.Errorf("Could not decode proposal payload: %s", response.Error)
+	}
+	return response, nil
+}
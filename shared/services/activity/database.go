@@ -0,0 +1,348 @@
+package activity
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/rocket-pool/smartnode/shared/services/reorg"
+)
+
+// The version of the database schema; databases with a different version are rebuilt from scratch
+const schemaVersion = 1
+
+const schema = `
+CREATE TABLE IF NOT EXISTS metadata (
+	key   TEXT PRIMARY KEY,
+	value TEXT NOT NULL
+);
+CREATE TABLE IF NOT EXISTS events (
+	block     INTEGER NOT NULL,
+	log_index INTEGER NOT NULL,
+	tx_hash   TEXT NOT NULL,
+	time      INTEGER NOT NULL,
+	contract  TEXT NOT NULL,
+	address   TEXT NOT NULL,
+	event     TEXT NOT NULL,
+	category  TEXT NOT NULL,
+	minipool  TEXT NOT NULL,
+	details   TEXT NOT NULL,
+	PRIMARY KEY (block, log_index)
+);
+CREATE INDEX IF NOT EXISTS events_category ON events (category, block);
+CREATE INDEX IF NOT EXISTS events_minipool ON events (minipool, block);
+`
+
+// Metadata keys
+const (
+	metadataKey_Version      string = "version"
+	metadataKey_Node         string = "node"
+	metadataKey_ScannedBlock string = "scannedBlock"
+	metadataKey_Blocks       string = "blocks"
+)
+
+// A Rocket Pool contract event involving the node or one of its minipools
+type Event struct {
+	Block    uint64            `json:"block"`
+	LogIndex uint              `json:"logIndex"`
+	TxHash   common.Hash       `json:"txHash"`
+	Time     time.Time         `json:"time"`
+	Contract string            `json:"contract"`
+	Address  common.Address    `json:"address"`
+	Event    string            `json:"event"`
+	Category Category          `json:"category"`
+	Minipool common.Address    `json:"minipool"`
+	Details  map[string]string `json:"details"`
+}
+
+// A filter for the events to load from the database; zero values match everything
+type Filter struct {
+	Category Category
+	Minipool common.Address
+	Since    time.Time
+	Until    time.Time
+	Limit    uint64
+}
+
+// A SQLite database of the node's Rocket Pool activity
+type Database struct {
+	db *sql.DB
+}
+
+// Open the activity database at the provided path, creating it if it doesn't exist yet
+func Open(path string) (*Database, error) {
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return nil, fmt.Errorf("error creating activity database directory: %w", err)
+	}
+
+	// The daemon and the API both write to the database, so writers wait for each other instead of failing
+	db, err := sql.Open("sqlite3", fmt.Sprintf("file:%s?_busy_timeout=30000&_journal_mode=WAL", path))
+	if err != nil {
+		return nil, fmt.Errorf("error opening activity database [%s]: %w", path, err)
+	}
+	database := &Database{
+		db: db,
+	}
+	if err := database.migrate(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("error initializing activity database [%s]: %w", path, err)
+	}
+	return database, nil
+}
+
+// Close the database
+func (d *Database) Close() error {
+	return d.db.Close()
+}
+
+// Create the schema, dropping the old one if it's from a different version
+func (d *Database) migrate() error {
+	if _, err := d.db.Exec(schema); err != nil {
+		return err
+	}
+	version, err := d.getMetadata(d.db, metadataKey_Version)
+	if err != nil {
+		return err
+	}
+	if version == strconv.Itoa(schemaVersion) {
+		return nil
+	}
+	if _, err := d.db.Exec("DROP TABLE events; DROP TABLE metadata;"); err != nil {
+		return err
+	}
+	if _, err := d.db.Exec(schema); err != nil {
+		return err
+	}
+	return d.setMetadata(d.db, metadataKey_Version, strconv.Itoa(schemaVersion))
+}
+
+// Get the node the database was built for and the last block it was scanned up to
+func (d *Database) GetScanState() (common.Address, uint64, error) {
+	node, err := d.getMetadata(d.db, metadataKey_Node)
+	if err != nil {
+		return common.Address{}, 0, err
+	}
+	scannedBlock, err := d.getMetadata(d.db, metadataKey_ScannedBlock)
+	if err != nil {
+		return common.Address{}, 0, err
+	}
+	if scannedBlock == "" {
+		return common.HexToAddress(node), 0, nil
+	}
+	block, err := strconv.ParseUint(scannedBlock, 10, 64)
+	if err != nil {
+		return common.Address{}, 0, fmt.Errorf("error parsing scanned block [%s]: %w", scannedBlock, err)
+	}
+	return common.HexToAddress(node), block, nil
+}
+
+// Remove every event and start over for the provided node
+func (d *Database) Reset(nodeAddress common.Address) error {
+	tx, err := d.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if _, err := tx.Exec("DELETE FROM events"); err != nil {
+		return fmt.Errorf("error clearing activity events: %w", err)
+	}
+	if _, err := tx.Exec("DELETE FROM metadata WHERE key IN (?, ?)", metadataKey_ScannedBlock, metadataKey_Blocks); err != nil {
+		return fmt.Errorf("error clearing scanned block: %w", err)
+	}
+	if err := d.setMetadata(tx, metadataKey_Node, nodeAddress.Hex()); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Get the blocks the scan has reached, used to detect reorgs of blocks that have already been indexed
+func (d *Database) GetBlocks() (*reorg.Tracker, error) {
+	blocks, err := d.getMetadata(d.db, metadataKey_Blocks)
+	if err != nil {
+		return nil, err
+	}
+	tracker := &reorg.Tracker{
+		Checkpoints: []reorg.Checkpoint{},
+	}
+	if blocks == "" {
+		return tracker, nil
+	}
+	if err := json.Unmarshal([]byte(blocks), tracker); err != nil {
+		return nil, fmt.Errorf("error deserializing scanned blocks: %w", err)
+	}
+	return tracker, nil
+}
+
+// Remove the events after the provided block, which have been reorged out, so the blocks after it are scanned again
+func (d *Database) RollBack(block uint64, blocks *reorg.Tracker) error {
+	tx, err := d.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if _, err := tx.Exec("DELETE FROM events WHERE block > ?", block); err != nil {
+		return fmt.Errorf("error removing activity events after block %d: %w", block, err)
+	}
+	if err := d.setScanState(tx, block, blocks); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Save the events found in a range of blocks along with the new scanned block, so a scan interrupted partway can resume from there
+func (d *Database) SaveBatch(events []Event, scannedBlock uint64, blocks *reorg.Tracker) error {
+	tx, err := d.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// The same log can match several of the indexer's filters, so duplicates are ignored
+	stmt, err := tx.Prepare("INSERT OR IGNORE INTO events (block, log_index, tx_hash, time, contract, address, event, category, minipool, details) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)")
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+	for _, event := range events {
+		details, err := json.Marshal(event.Details)
+		if err != nil {
+			return fmt.Errorf("error serializing details of event %s: %w", event.Event, err)
+		}
+		_, err = stmt.Exec(event.Block, event.LogIndex, event.TxHash.Hex(), event.Time.Unix(), event.Contract, event.Address.Hex(), event.Event, string(event.Category), event.Minipool.Hex(), string(details))
+		if err != nil {
+			return fmt.Errorf("error saving event %s in transaction %s: %w", event.Event, event.TxHash.Hex(), err)
+		}
+	}
+
+	if err := d.setScanState(tx, scannedBlock, blocks); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Set the scanned block and the blocks the scan has reached
+func (d *Database) setScanState(q queryer, scannedBlock uint64, blocks *reorg.Tracker) error {
+	if err := d.setMetadata(q, metadataKey_ScannedBlock, strconv.FormatUint(scannedBlock, 10)); err != nil {
+		return err
+	}
+	bytes, err := json.Marshal(blocks)
+	if err != nil {
+		return fmt.Errorf("error serializing scanned blocks: %w", err)
+	}
+	return d.setMetadata(q, metadataKey_Blocks, string(bytes))
+}
+
+// Get the minipool addresses the indexer has seen created for the node
+func (d *Database) GetMinipools() ([]common.Address, error) {
+	rows, err := d.db.Query("SELECT DISTINCT minipool FROM events WHERE minipool != ?", common.Address{}.Hex())
+	if err != nil {
+		return nil, fmt.Errorf("error getting minipools: %w", err)
+	}
+	defer rows.Close()
+
+	minipools := []common.Address{}
+	for rows.Next() {
+		var minipool string
+		if err := rows.Scan(&minipool); err != nil {
+			return nil, err
+		}
+		minipools = append(minipools, common.HexToAddress(minipool))
+	}
+	return minipools, rows.Err()
+}
+
+// Load the events matching the filter, newest first
+func (d *Database) GetEvents(filter Filter) ([]Event, error) {
+	conditions := []string{}
+	args := []interface{}{}
+	if filter.Category != "" {
+		conditions = append(conditions, "category = ?")
+		args = append(args, string(filter.Category))
+	}
+	if filter.Minipool != (common.Address{}) {
+		conditions = append(conditions, "minipool = ?")
+		args = append(args, filter.Minipool.Hex())
+	}
+	if !filter.Since.IsZero() {
+		conditions = append(conditions, "time >= ?")
+		args = append(args, filter.Since.Unix())
+	}
+	if !filter.Until.IsZero() {
+		conditions = append(conditions, "time < ?")
+		args = append(args, filter.Until.Unix())
+	}
+	query := "SELECT block, log_index, tx_hash, time, contract, address, event, category, minipool, details FROM events"
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY block DESC, log_index DESC"
+	if filter.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, filter.Limit)
+	}
+
+	rows, err := d.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error getting activity events: %w", err)
+	}
+	defer rows.Close()
+
+	events := []Event{}
+	for rows.Next() {
+		var event Event
+		var txHash, address, category, minipool, details string
+		var timestamp int64
+		err := rows.Scan(&event.Block, &event.LogIndex, &txHash, &timestamp, &event.Contract, &address, &event.Event, &category, &minipool, &details)
+		if err != nil {
+			return nil, fmt.Errorf("error reading activity event: %w", err)
+		}
+		event.TxHash = common.HexToHash(txHash)
+		event.Time = time.Unix(timestamp, 0)
+		event.Address = common.HexToAddress(address)
+		event.Category = Category(category)
+		event.Minipool = common.HexToAddress(minipool)
+		if err := json.Unmarshal([]byte(details), &event.Details); err != nil {
+			return nil, fmt.Errorf("error deserializing details of event %s in transaction %s: %w", event.Event, txHash, err)
+		}
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
+
+// Something that can run queries, either the database itself or a transaction
+type queryer interface {
+	QueryRow(query string, args ...interface{}) *sql.Row
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}
+
+// Get a metadata value, or an empty string if it hasn't been set
+func (d *Database) getMetadata(q queryer, key string) (string, error) {
+	var value string
+	err := q.QueryRow("SELECT value FROM metadata WHERE key = ?", key).Scan(&value)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("error getting activity database %s: %w", key, err)
+	}
+	return value, nil
+}
+
+// Set a metadata value
+func (d *Database) setMetadata(q queryer, key string, value string) error {
+	_, err := q.Exec("INSERT OR REPLACE INTO metadata (key, value) VALUES (?, ?)", key, value)
+	if err != nil {
+		return fmt.Errorf("error setting activity database %s: %w", key, err)
+	}
+	return nil
+}
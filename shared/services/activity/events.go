@@ -0,0 +1,174 @@
+package activity
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+)
+
+// The kind of activity an event represents
+type Category string
+
+const (
+	Category_Deposit     Category = "deposit"
+	Category_Stake       Category = "stake"
+	Category_Claim       Category = "claim"
+	Category_Penalty     Category = "penalty"
+	Category_Credentials Category = "credentials"
+	Category_Minipool    Category = "minipool"
+	Category_Node        Category = "node"
+	Category_Other       Category = "other"
+)
+
+// Every category an event can be in
+var Categories = []Category{
+	Category_Deposit,
+	Category_Stake,
+	Category_Claim,
+	Category_Penalty,
+	Category_Credentials,
+	Category_Minipool,
+	Category_Node,
+	Category_Other,
+}
+
+// The category of each event the indexer knows about; anything else is Category_Other
+var eventCategories = map[string]Category{
+	// Node
+	"NodeRegistered":                Category_Node,
+	"NodeTimezoneLocationSet":       Category_Node,
+	"NodeRewardNetworkChanged":      Category_Node,
+	"NodeSmoothingPoolStateChanged": Category_Node,
+
+	// Deposits
+	"DepositReceived":   Category_Deposit,
+	"DepositFor":        Category_Deposit,
+	"MinipoolPrestaked": Category_Deposit,
+	"EtherDeposited":    Category_Deposit,
+
+	// RPL stake
+	"RPLStaked":          Category_Stake,
+	"RPLWithdrawn":       Category_Stake,
+	"StakeRPLForAllowed": Category_Stake,
+
+	// Rewards and withdrawals
+	"RewardsClaimed":           Category_Claim,
+	"RPLTokensClaimed":         Category_Claim,
+	"EtherWithdrawn":           Category_Claim,
+	"EtherWithdrawalProcessed": Category_Claim,
+	"FeesDistributed":          Category_Claim,
+	"Withdrawal":               Category_Claim,
+
+	// Penalties
+	"RPLSlashed":             Category_Penalty,
+	"MinipoolPenaltyUpdated": Category_Penalty,
+	"MinipoolScrubbed":       Category_Penalty,
+	"ScrubVoted":             Category_Penalty,
+
+	// Withdrawal credentials
+	"NodeWithdrawalAddressSet": Category_Credentials,
+
+	// Minipool lifecycle
+	"MinipoolCreated":         Category_Minipool,
+	"MinipoolDestroyed":       Category_Minipool,
+	"MinipoolEnqueued":        Category_Minipool,
+	"MinipoolDequeued":        Category_Minipool,
+	"MinipoolRemoved":         Category_Minipool,
+	"StatusUpdated":           Category_Minipool,
+	"MinipoolPromoted":        Category_Minipool,
+	"MinipoolVacancyPrepared": Category_Minipool,
+	"BeginBondReduction":      Category_Minipool,
+	"ReductionCancelled":      Category_Minipool,
+	"BondReduced":             Category_Minipool,
+	"CancelReductionVoted":    Category_Minipool,
+}
+
+// Get the category of the named event
+func getEventCategory(eventName string) Category {
+	if category, exists := eventCategories[eventName]; exists {
+		return category
+	}
+	return Category_Other
+}
+
+// Check if a category name is valid
+func IsValidCategory(category string) bool {
+	for _, c := range Categories {
+		if string(c) == category {
+			return true
+		}
+	}
+	return false
+}
+
+// Get a short, human-readable description of the event
+func (e Event) Summary() string {
+	switch e.Event {
+	case "NodeRegistered":
+		return "Registered the node"
+	case "DepositReceived":
+		if amount, ok := e.getEth("amount"); ok {
+			return fmt.Sprintf("Deposited %.6f ETH", amount)
+		}
+	case "MinipoolCreated":
+		return fmt.Sprintf("Created minipool %s", e.Minipool.Hex())
+	case "MinipoolDestroyed":
+		return fmt.Sprintf("Closed minipool %s", e.Minipool.Hex())
+	case "RPLStaked":
+		if amount, ok := e.getEth("amount"); ok {
+			return fmt.Sprintf("Staked %.6f RPL", amount)
+		}
+	case "RPLWithdrawn":
+		if amount, ok := e.getEth("amount"); ok {
+			return fmt.Sprintf("Withdrew %.6f RPL from the stake", amount)
+		}
+	case "RPLSlashed":
+		if amount, ok := e.getEth("amount"); ok {
+			return fmt.Sprintf("%.6f RPL of the stake was slashed", amount)
+		}
+	case "RewardsClaimed":
+		if intervals, ok := e.Details["rewardIndex"]; ok {
+			return fmt.Sprintf("Claimed rewards for intervals %s", strings.Trim(intervals, "[]"))
+		}
+	case "NodeWithdrawalAddressSet":
+		if address, ok := e.Details["withdrawalAddress"]; ok {
+			return fmt.Sprintf("Set the withdrawal address to %s", address)
+		}
+	case "StatusUpdated":
+		if status, err := strconv.ParseUint(e.Details["status"], 10, 8); err == nil {
+			return fmt.Sprintf("Minipool %s moved to %s", e.Minipool.Hex(), types.MinipoolStatus(status).String())
+		}
+	case "NodeSmoothingPoolStateChanged":
+		if e.Details["state"] == "true" {
+			return "Joined the Smoothing Pool"
+		} else if e.Details["state"] == "false" {
+			return "Left the Smoothing Pool"
+		}
+	}
+
+	// Fall back to the raw event
+	keys := make([]string, 0, len(e.Details))
+	for key := range e.Details {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	args := make([]string, len(keys))
+	for i, key := range keys {
+		args[i] = fmt.Sprintf("%s=%s", key, e.Details[key])
+	}
+	return fmt.Sprintf("%s(%s)", e.Event, strings.Join(args, ", "))
+}
+
+// Get a wei amount from the event's details in ETH (or any other 18-decimal token)
+func (e Event) getEth(key string) (float64, bool) {
+	value, ok := new(big.Int).SetString(e.Details[key], 10)
+	if !ok {
+		return 0, false
+	}
+	return eth.WeiToEth(value), true
+}
@@ -0,0 +1,412 @@
+package activity
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/node"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+
+	"github.com/rocket-pool/smartnode/shared/services/progress"
+	"github.com/rocket-pool/smartnode/shared/services/reorg"
+	"github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/services/upgrades"
+)
+
+// How many minipools to put in a single log filter, to stay within clients' filter size limits
+const minipoolFilterBatchSize int = 100
+
+// The Rocket Pool contracts that emit events about nodes
+var indexedContracts = []string{
+	"rocketNodeManager",
+	"rocketNodeDeposit",
+	"rocketNodeStaking",
+	"rocketMinipoolManager",
+	"rocketMinipoolQueue",
+	"rocketMinipoolBondReducer",
+	"rocketMinipoolPenalty",
+	"rocketMerkleDistributorMainnet",
+	"rocketRewardsPool",
+}
+
+// A contract the indexer decodes events from
+type eventSource struct {
+	name string
+	abi  *abi.ABI
+}
+
+// The state of a scan in progress
+type indexer struct {
+	rp          *rocketpool.RocketPool
+	nodeAddress common.Address
+	sources     map[common.Address]eventSource
+	contracts   []common.Address
+	minipools   map[common.Address]bool
+	minipoolAbi *abi.ABI
+	distributor common.Address
+	headers     map[uint64]time.Time
+}
+
+// Scan the blocks since the last update for events involving the node or its minipools, reporting the progress if a reporter is provided.
+// The contract upgrade history is used to include the events from previous versions of each contract.
+// Blocks closer to the head than confirmationDepth aren't indexed yet, and events from indexed blocks that have been reorged out are removed.
+func (d *Database) Update(rp *rocketpool.RocketPool, history *upgrades.History, nodeAddress common.Address, intervalSize *big.Int, confirmationDepth uint64, reporter *progress.Reporter) error {
+
+	// Start over if the node's wallet has changed
+	scannedNode, scannedBlock, err := d.GetScanState()
+	if err != nil {
+		return err
+	}
+	if scannedNode != nodeAddress {
+		if err := d.Reset(nodeAddress); err != nil {
+			return err
+		}
+		scannedBlock = 0
+	}
+
+	// Roll back to the last indexed block that's still canonical
+	blocks, err := d.GetBlocks()
+	if err != nil {
+		return err
+	}
+	if scannedBlock > 0 {
+		safeBlock, reorged, err := blocks.FindReorg(context.Background(), rp.Client)
+		if err != nil {
+			return fmt.Errorf("error checking the activity database for reorgs: %w", err)
+		}
+		if reorged {
+			if err := d.RollBack(safeBlock, blocks); err != nil {
+				return err
+			}
+			scannedBlock = safeBlock
+		}
+	}
+
+	// Nothing can involve the node before it registers, so the first scan starts there
+	fromBlock := scannedBlock + 1
+	if scannedBlock == 0 {
+		exists, err := node.GetNodeExists(rp, nodeAddress, nil)
+		if err != nil {
+			return fmt.Errorf("error checking if the node is registered: %w", err)
+		}
+		if !exists {
+			return nil
+		}
+		registrationTime, err := node.GetNodeRegistrationTime(rp, nodeAddress, nil)
+		if err != nil {
+			return fmt.Errorf("error getting the node's registration time: %w", err)
+		}
+		registrationHeader, err := rewards.GetELBlockHeaderForTime(registrationTime, rp)
+		if err != nil {
+			return fmt.Errorf("error getting the node's registration block: %w", err)
+		}
+		fromBlock = registrationHeader.Number.Uint64()
+	}
+	latestBlock, confirmed, err := reorg.GetConfirmedBlock(context.Background(), rp.Client, confirmationDepth)
+	if err != nil {
+		return err
+	}
+	if !confirmed || fromBlock > latestBlock {
+		return nil
+	}
+	interval := latestBlock - fromBlock + 1
+	if intervalSize != nil && intervalSize.Sign() > 0 {
+		interval = intervalSize.Uint64()
+	}
+
+	// Get the contracts and minipools to scan
+	reporter.SetPhase("Loading Rocket Pool contracts", 0)
+	idx, err := newIndexer(rp, d, history, nodeAddress)
+	if err != nil {
+		return err
+	}
+
+	// Scan in batches, saving each one so an interrupted scan can pick up where it left off
+	reporter.SetPhase("Scanning for node activity", (latestBlock-fromBlock)/interval+1)
+	batches := uint64(0)
+	for start := fromBlock; start <= latestBlock; start += interval {
+		end := start + interval - 1
+		if end > latestBlock {
+			end = latestBlock
+		}
+		events, err := idx.scan(new(big.Int).SetUint64(start), new(big.Int).SetUint64(end))
+		if err != nil {
+			return fmt.Errorf("error scanning for node activity between blocks %d and %d: %w", start, end, err)
+		}
+		if err := blocks.Add(context.Background(), rp.Client, end); err != nil {
+			return err
+		}
+		if err := d.SaveBatch(events, end, blocks); err != nil {
+			return err
+		}
+		batches++
+		reporter.SetProgress(batches)
+	}
+
+	return nil
+
+}
+
+// Create an indexer with every version of the indexed contracts and the node's known minipools
+func newIndexer(rp *rocketpool.RocketPool, d *Database, history *upgrades.History, nodeAddress common.Address) (*indexer, error) {
+	idx := &indexer{
+		rp:          rp,
+		nodeAddress: nodeAddress,
+		sources:     map[common.Address]eventSource{},
+		contracts:   []common.Address{},
+		minipools:   map[common.Address]bool{},
+		headers:     map[uint64]time.Time{},
+	}
+
+	// Previous versions are decoded with the current ABI; events whose signatures have changed since are kept as unknown events
+	idx.addSource("rocketStorage", *rp.RocketStorageContract.Address, rp.RocketStorageContract.ABI)
+	for _, contractName := range indexedContracts {
+		address, err := rp.GetAddress(contractName, nil)
+		if err != nil {
+			return nil, err
+		}
+		if *address == (common.Address{}) {
+			// Not deployed on this network
+			continue
+		}
+		contractAbi, err := rp.GetABI(contractName, nil)
+		if err != nil {
+			return nil, err
+		}
+		idx.addSource(contractName, *address, contractAbi)
+		for _, previousAddress := range history.GetPreviousAddresses(contractName) {
+			idx.addSource(contractName, previousAddress, contractAbi)
+		}
+	}
+
+	// Minipools and the fee distributor are scanned for all of their events
+	minipoolAbi, err := rp.GetABI("rocketMinipoolDelegate", nil)
+	if err != nil {
+		return nil, err
+	}
+	idx.minipoolAbi = minipoolAbi
+	knownMinipools, err := d.GetMinipools()
+	if err != nil {
+		return nil, err
+	}
+	currentMinipools, err := minipool.GetNodeMinipoolAddresses(rp, nodeAddress, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting the node's minipools: %w", err)
+	}
+	for _, address := range append(knownMinipools, currentMinipools...) {
+		idx.minipools[address] = true
+	}
+	distributorAbi, err := rp.GetABI("rocketNodeDistributorDelegate", nil)
+	if err != nil {
+		return nil, err
+	}
+	idx.distributor, err = node.GetDistributorAddress(rp, nodeAddress, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting the node's fee distributor address: %w", err)
+	}
+	idx.sources[idx.distributor] = eventSource{
+		name: "rocketNodeDistributor",
+		abi:  distributorAbi,
+	}
+
+	return idx, nil
+}
+
+// Add a contract to scan for events involving the node
+func (idx *indexer) addSource(name string, address common.Address, contractAbi *abi.ABI) {
+	if _, exists := idx.sources[address]; exists {
+		return
+	}
+	idx.sources[address] = eventSource{
+		name: name,
+		abi:  contractAbi,
+	}
+	idx.contracts = append(idx.contracts, address)
+}
+
+// Get the events involving the node in a range of blocks
+func (idx *indexer) scan(fromBlock *big.Int, toBlock *big.Int) ([]Event, error) {
+
+	// Events that index the node's address; these come first so minipools created in this range are scanned too
+	nodeTopic := common.BytesToHash(idx.nodeAddress.Bytes())
+	logs := []ethtypes.Log{}
+	for _, topicFilter := range [][][]common.Hash{{nil, {nodeTopic}}, {nil, nil, {nodeTopic}}} {
+		nodeLogs, err := eth.GetLogs(idx.rp, idx.contracts, topicFilter, nil, fromBlock, toBlock, nil)
+		if err != nil {
+			return nil, err
+		}
+		logs = append(logs, nodeLogs...)
+	}
+	events := []Event{}
+	for _, log := range logs {
+		event, err := idx.decode(log)
+		if err != nil {
+			return nil, err
+		}
+		if event.Event == "MinipoolCreated" && event.Minipool != (common.Address{}) {
+			idx.minipools[event.Minipool] = true
+		}
+		events = append(events, event)
+	}
+
+	// Events emitted by the node's minipools and fee distributor, and events that index one of its minipools
+	minipools := make([]common.Address, 0, len(idx.minipools))
+	for address := range idx.minipools {
+		minipools = append(minipools, address)
+	}
+	logs = []ethtypes.Log{}
+	distributorLogs, err := eth.GetLogs(idx.rp, []common.Address{idx.distributor}, nil, nil, fromBlock, toBlock, nil)
+	if err != nil {
+		return nil, err
+	}
+	logs = append(logs, distributorLogs...)
+	for start := 0; start < len(minipools); start += minipoolFilterBatchSize {
+		end := start + minipoolFilterBatchSize
+		if end > len(minipools) {
+			end = len(minipools)
+		}
+		batch := minipools[start:end]
+		minipoolTopics := make([]common.Hash, len(batch))
+		for i, address := range batch {
+			minipoolTopics[i] = common.BytesToHash(address.Bytes())
+		}
+		minipoolLogs, err := eth.GetLogs(idx.rp, batch, nil, nil, fromBlock, toBlock, nil)
+		if err != nil {
+			return nil, err
+		}
+		logs = append(logs, minipoolLogs...)
+		minipoolLogs, err = eth.GetLogs(idx.rp, idx.contracts, [][]common.Hash{nil, minipoolTopics}, nil, fromBlock, toBlock, nil)
+		if err != nil {
+			return nil, err
+		}
+		logs = append(logs, minipoolLogs...)
+	}
+	for _, log := range logs {
+		event, err := idx.decode(log)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	return events, nil
+
+}
+
+// Decode a log into an event
+func (idx *indexer) decode(log ethtypes.Log) (Event, error) {
+	event := Event{
+		Block:    log.BlockNumber,
+		LogIndex: log.Index,
+		TxHash:   log.TxHash,
+		Address:  log.Address,
+		Event:    "Unknown",
+		Details:  map[string]string{},
+	}
+
+	// Get the block time
+	blockTime, exists := idx.headers[log.BlockNumber]
+	if !exists {
+		header, err := idx.rp.Client.HeaderByNumber(context.Background(), new(big.Int).SetUint64(log.BlockNumber))
+		if err != nil {
+			return Event{}, fmt.Errorf("error getting header for block %d: %w", log.BlockNumber, err)
+		}
+		blockTime = time.Unix(int64(header.Time), 0)
+		idx.headers[log.BlockNumber] = blockTime
+	}
+	event.Time = blockTime
+
+	// Get the contract's ABI
+	var contractAbi *abi.ABI
+	if idx.minipools[log.Address] {
+		event.Contract = "rocketMinipool"
+		event.Minipool = log.Address
+		contractAbi = idx.minipoolAbi
+	} else if source, exists := idx.sources[log.Address]; exists {
+		event.Contract = source.name
+		contractAbi = source.abi
+	}
+
+	// Decode the arguments, keeping the raw log if it doesn't match the ABI
+	values := map[string]interface{}{}
+	decoded := false
+	if contractAbi != nil && len(log.Topics) > 0 {
+		if abiEvent, err := contractAbi.EventByID(log.Topics[0]); err == nil {
+			indexed := abi.Arguments{}
+			for _, input := range abiEvent.Inputs {
+				if input.Indexed {
+					indexed = append(indexed, input)
+				}
+			}
+			if abi.ParseTopicsIntoMap(values, indexed, log.Topics[1:]) == nil && abiEvent.Inputs.UnpackIntoMap(values, log.Data) == nil {
+				event.Event = abiEvent.Name
+				decoded = true
+			}
+		}
+	}
+	if !decoded {
+		event.Details["topics"] = fmt.Sprint(log.Topics)
+		event.Details["data"] = hexutil.Encode(log.Data)
+	}
+	for name, value := range values {
+		event.Details[name] = formatValue(value)
+	}
+	event.Category = getEventCategory(event.Event)
+
+	// Link the event to a minipool if it's about one
+	if event.Minipool == (common.Address{}) {
+		if minipoolAddress, ok := values["minipool"].(common.Address); ok {
+			event.Minipool = minipoolAddress
+		} else if len(log.Topics) > 1 {
+			topicAddress := common.BytesToAddress(log.Topics[1].Bytes())
+			if idx.minipools[topicAddress] {
+				event.Minipool = topicAddress
+			}
+		}
+	}
+
+	return event, nil
+}
+
+// Format a decoded event argument for storage
+func formatValue(value interface{}) string {
+	switch v := value.(type) {
+	case common.Address:
+		return v.Hex()
+	case common.Hash:
+		return v.Hex()
+	case [32]byte:
+		return common.Hash(v).Hex()
+	case []byte:
+		return hexutil.Encode(v)
+	case *big.Int:
+		return v.String()
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// Load the activity database, bring it and the contract upgrade history up to date, and return the database
+func UpdateDatabase(rp *rocketpool.RocketPool, databasePath string, historyPath string, nodeAddress common.Address, intervalSize *big.Int, confirmationDepth uint64, reporter *progress.Reporter) (*Database, error) {
+	history, err := upgrades.UpdateHistory(rp, historyPath, intervalSize, confirmationDepth, reporter)
+	if err != nil {
+		return nil, err
+	}
+	db, err := Open(databasePath)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.Update(rp, history, nodeAddress, intervalSize, confirmationDepth, reporter); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return db, nil
+}
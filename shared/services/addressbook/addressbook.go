@@ -0,0 +1,227 @@
+package addressbook
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"golang.org/x/crypto/scrypt"
+)
+
+// Encryption settings
+const (
+	fileMagic string = "RPADDRBOOK"
+	saltSize  int    = 32
+	keySize   int    = 32
+	scryptN   int    = 1 << 17
+	scryptR   int    = 8
+	scryptP   int    = 1
+)
+
+// Entry names can't contain dots so they can't be mistaken for ENS names
+var namePattern = regexp.MustCompile("^[a-zA-Z0-9_-]{1,64}$")
+
+// Returned when the passphrase doesn't decrypt the address book
+var ErrWrongPassphrase = errors.New("the passphrase is incorrect or the address book is corrupted")
+
+// A named address
+type Entry struct {
+	Name    string         `json:"name"`
+	Address common.Address `json:"address"`
+}
+
+// The node operator's saved external addresses, kept on the machine running the CLI
+type AddressBook struct {
+	Entries []Entry `json:"entries"`
+
+	// The passphrase the book is encrypted with, or an empty string if it's saved in plain text
+	passphrase string
+}
+
+// Check if the address book at the path is encrypted. A book that doesn't exist yet isn't.
+func IsEncrypted(path string) (bool, error) {
+	bytes, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("error reading address book: %w", err)
+	}
+	return strings.HasPrefix(string(bytes), fileMagic), nil
+}
+
+// Load the address book from the path, decrypting it with the passphrase if it's encrypted.
+// Returns an empty book if the file doesn't exist yet.
+func Load(path string, passphrase string) (*AddressBook, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &AddressBook{Entries: []Entry{}}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading address book: %w", err)
+	}
+
+	if bytes.HasPrefix(data, []byte(fileMagic)) {
+		data, err = decrypt(data[len(fileMagic):], passphrase)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		passphrase = ""
+	}
+
+	book := &AddressBook{}
+	if err := json.Unmarshal(data, book); err != nil {
+		return nil, fmt.Errorf("error deserializing address book: %w", err)
+	}
+	book.passphrase = passphrase
+	return book, nil
+}
+
+// Save the address book to the path, encrypting it if it has a passphrase
+func (b *AddressBook) Save(path string) error {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error serializing address book: %w", err)
+	}
+	if b.passphrase != "" {
+		data, err = encrypt(data, b.passphrase)
+		if err != nil {
+			return err
+		}
+		data = append([]byte(fileMagic), data...)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("error saving address book: %w", err)
+	}
+	return nil
+}
+
+// Check if the address book is encrypted
+func (b *AddressBook) IsEncrypted() bool {
+	return b.passphrase != ""
+}
+
+// Set the passphrase to encrypt the address book with when it's saved
+func (b *AddressBook) SetPassphrase(passphrase string) {
+	b.passphrase = passphrase
+}
+
+// Get the address saved under a name, ignoring case
+func (b *AddressBook) Get(name string) (common.Address, bool) {
+	for _, entry := range b.Entries {
+		if strings.EqualFold(entry.Name, name) {
+			return entry.Address, true
+		}
+	}
+	return common.Address{}, false
+}
+
+// Add an entry to the address book. The address must already have been checked with ValidateChecksum.
+func (b *AddressBook) Add(name string, address common.Address) error {
+	if !IsValidName(name) {
+		return fmt.Errorf("invalid name '%s'; names can only contain letters, numbers, dashes and underscores", name)
+	}
+	if _, exists := b.Get(name); exists {
+		return fmt.Errorf("the address book already has an entry named '%s'", name)
+	}
+	b.Entries = append(b.Entries, Entry{Name: name, Address: address})
+	sort.Slice(b.Entries, func(i, j int) bool {
+		return strings.ToLower(b.Entries[i].Name) < strings.ToLower(b.Entries[j].Name)
+	})
+	return nil
+}
+
+// Remove an entry from the address book, ignoring case
+func (b *AddressBook) Remove(name string) error {
+	for i, entry := range b.Entries {
+		if strings.EqualFold(entry.Name, name) {
+			b.Entries = append(b.Entries[:i], b.Entries[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("the address book doesn't have an entry named '%s'", name)
+}
+
+// Check if a string can be used as an entry name
+func IsValidName(name string) bool {
+	return namePattern.MatchString(name) && !common.IsHexAddress(name)
+}
+
+// Check whether an address has a valid EIP-55 checksum. Addresses that are all lowercase or all uppercase don't have one,
+// so they can't be checked; hasChecksum is false for those.
+func ValidateChecksum(value string) (address common.Address, hasChecksum bool, err error) {
+	if !common.IsHexAddress(value) {
+		return common.Address{}, false, fmt.Errorf("invalid address '%s'", value)
+	}
+	address = common.HexToAddress(value)
+	hex := strings.TrimPrefix(strings.TrimPrefix(value, "0x"), "0X")
+	if "0x"+hex == address.Hex() {
+		return address, true, nil
+	}
+	if hex == strings.ToLower(hex) || hex == strings.ToUpper(hex) {
+		return address, false, nil
+	}
+	return common.Address{}, true, fmt.Errorf("the checksum of address '%s' is invalid; it may have a typo", value)
+}
+
+// Encrypt the address book with the passphrase. The output is the salt, the nonce and the sealed data.
+func encrypt(data []byte, passphrase string) ([]byte, error) {
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	aead, err := deriveKey(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	output := append(salt, nonce...)
+	return aead.Seal(output, nonce, data, []byte(fileMagic)), nil
+}
+
+// Decrypt an address book encrypted with the passphrase
+func decrypt(data []byte, passphrase string) ([]byte, error) {
+	if len(data) < saltSize {
+		return nil, ErrWrongPassphrase
+	}
+	aead, err := deriveKey(passphrase, data[:saltSize])
+	if err != nil {
+		return nil, err
+	}
+	data = data[saltSize:]
+	if len(data) < aead.NonceSize() {
+		return nil, ErrWrongPassphrase
+	}
+	plaintext, err := aead.Open(nil, data[:aead.NonceSize()], data[aead.NonceSize():], []byte(fileMagic))
+	if err != nil {
+		return nil, ErrWrongPassphrase
+	}
+	return plaintext, nil
+}
+
+// Derive the encryption key from the passphrase
+func deriveKey(passphrase string, salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key([]byte(passphrase), salt, scryptN, scryptR, scryptP, keySize)
+	if err != nil {
+		return nil, fmt.Errorf("error deriving encryption key: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,93 @@
+package alerting
+
+import "fmt"
+
+// The severity of an alert
+type Severity string
+
+const (
+	Severity_Info     Severity = "info"
+	Severity_Warning  Severity = "warning"
+	Severity_Critical Severity = "critical"
+)
+
+// The rules that can raise alerts
+type Rule string
+
+const (
+	Rule_ExecutionClientDown Rule = "execution-client-down"
+	Rule_BeaconClientDown    Rule = "beacon-client-down"
+	Rule_ValidatorOffline    Rule = "validator-offline"
+	Rule_LowCollateral       Rule = "low-collateral"
+	Rule_StuckTransaction    Rule = "stuck-transaction"
+	Rule_WatchtowerDuty      Rule = "watchtower-duty-failed"
+	Rule_LowDiskSpace        Rule = "low-disk-space"
+	Rule_MemoryPressure      Rule = "memory-pressure"
+	Rule_UpdateAvailable     Rule = "update-available"
+	Rule_MevRelayDown        Rule = "mev-relay-down"
+	Rule_MevUnregistered     Rule = "mev-validator-unregistered"
+	Rule_DvtClusterUnhealthy Rule = "dvt-cluster-unhealthy"
+	Rule_MevLocalFallback    Rule = "mev-local-fallback"
+	Rule_ProposalMissed      Rule = "proposal-missed"
+	Rule_StrandedAsset       Rule = "stranded-asset"
+	Rule_MinipoolAssigned    Rule = "minipool-assigned"
+	Rule_DepositPoolCapacity Rule = "deposit-pool-capacity"
+	Rule_QueueWaitChanged    Rule = "queue-wait-changed"
+	Rule_RewardsRootOutlier  Rule = "rewards-root-outlier"
+	Rule_DelegateUpgraded    Rule = "delegate-upgraded"
+	Rule_CollateralForecast  Rule = "collateral-forecast"
+	Rule_BalanceAnomaly      Rule = "balance-anomaly"
+	Rule_TaskCrashed         Rule = "task-crashed"
+	Rule_ClockSkew           Rule = "clock-skew"
+	Rule_BalancesRateBound   Rule = "balances-rate-bound"
+	Rule_PriceDivergence     Rule = "price-divergence"
+	Rule_RescueNodeExpired   Rule = "rescue-node-expired"
+	Rule_RescueNodeUnneeded  Rule = "rescue-node-unneeded"
+)
+
+// An alert sent to the notification channels
+type Alert struct {
+	// The rule that raised the alert
+	Rule Rule `json:"rule"`
+
+	// The specific subject of the alert (such as a validator pubkey or a task name), if the rule can fire for more than one
+	Subject string `json:"subject,omitempty"`
+
+	Severity Severity `json:"severity"`
+	Title    string   `json:"title"`
+	Message  string   `json:"message"`
+
+	// True if this is a notification that a previously raised alert has cleared
+	Resolved bool `json:"resolved"`
+}
+
+// Get the unique key for this alert, used for deduplication
+func (a Alert) Key() string {
+	if a.Subject == "" {
+		return string(a.Rule)
+	}
+	return fmt.Sprintf("%s/%s", a.Rule, a.Subject)
+}
+
+// Get a short single-line summary of the alert
+func (a Alert) Summary() string {
+	if a.Resolved {
+		return fmt.Sprintf("[RESOLVED] %s", a.Title)
+	}
+	return fmt.Sprintf("[%s] %s", a.Severity, a.Title)
+}
+
+// Create the alert for a daemon task that panicked. The daemon keeps running its other tasks, so this is a warning.
+func NewTaskCrashAlert(daemon string, task string, panicMessage string, reportPath string) Alert {
+	message := fmt.Sprintf("The %s daemon's %s task panicked: %s. The daemon recovered and is still running its other tasks.", daemon, task, panicMessage)
+	if reportPath != "" {
+		message += fmt.Sprintf(" The crash report was saved to %s.", reportPath)
+	}
+	return Alert{
+		Rule:     Rule_TaskCrashed,
+		Subject:  fmt.Sprintf("%s/%s", daemon, task),
+		Severity: Severity_Warning,
+		Title:    fmt.Sprintf("The %s task crashed", task),
+		Message:  message,
+	}
+}
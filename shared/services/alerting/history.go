@@ -0,0 +1,88 @@
+package alerting
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// How many alerts the node daemon keeps in its history
+const DefaultHistorySize int = 50
+
+// An alert and when it was sent
+type HistoryEntry struct {
+	Time  time.Time `json:"time"`
+	Alert Alert     `json:"alert"`
+}
+
+// Saves the most recent alerts, raised or resolved, so they can be shown after they were sent
+type History struct {
+	path    string
+	size    int
+	entries []HistoryEntry
+	lock    *sync.Mutex
+}
+
+// Create a history that keeps the provided number of alerts, starting with the ones already saved at the path
+func NewHistory(path string, size int) *History {
+	entries, err := LoadHistory(path)
+	if err != nil {
+		// Start over rather than failing the daemon over a corrupt history
+		entries = []HistoryEntry{}
+	}
+	return &History{
+		path:    path,
+		size:    size,
+		entries: entries,
+		lock:    &sync.Mutex{},
+	}
+}
+
+// Add an alert to the history and save it
+func (h *History) Record(alert Alert) error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.entries = append(h.entries, HistoryEntry{
+		Time:  time.Now().UTC(),
+		Alert: alert,
+	})
+	if len(h.entries) > h.size {
+		h.entries = h.entries[len(h.entries)-h.size:]
+	}
+
+	err := os.MkdirAll(filepath.Dir(h.path), 0755)
+	if err != nil {
+		return fmt.Errorf("error creating alert history directory: %w", err)
+	}
+	bytes, err := json.Marshal(h.entries)
+	if err != nil {
+		return fmt.Errorf("error serializing alert history: %w", err)
+	}
+	err = os.WriteFile(h.path, bytes, 0644)
+	if err != nil {
+		return fmt.Errorf("error writing alert history file [%s]: %w", h.path, err)
+	}
+	return nil
+}
+
+// Load the alert history from the provided path, oldest first. Returns an empty history if the node daemon hasn't recorded one yet.
+func LoadHistory(path string) ([]HistoryEntry, error) {
+	bytes, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return []HistoryEntry{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading alert history file [%s]: %w", path, err)
+	}
+	var entries []HistoryEntry
+	err = json.Unmarshal(bytes, &entries)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing alert history file [%s]: %w", path, err)
+	}
+	return entries, nil
+}
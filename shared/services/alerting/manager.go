@@ -0,0 +1,109 @@
+package alerting
+
+import (
+	"sync"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Tracks which alerts are active and delivers them to the configured notifiers.
+// Alerts that stay active are only repeated after the cooldown, and a resolution notice is sent when they clear.
+type AlertManager struct {
+	notifiers []Notifier
+	cooldown  time.Duration
+	log       *log.ColorLogger
+	active    map[string]time.Time
+	listeners []func(Alert)
+	lock      *sync.Mutex
+}
+
+// Create a new alert manager from the Smartnode config.
+// If alerting is disabled, the manager will still track alerts but won't send anything.
+func NewAlertManager(cfg *config.RocketPoolConfig, logger log.ColorLogger) *AlertManager {
+	notifiers := []Notifier{}
+	if cfg.EnableAlerting.Value == true {
+		notifiers = NewNotifiers(cfg.Alerting)
+		if len(notifiers) == 0 {
+			logger.Warnf("WARNING: Alerting is enabled but no notification channels have been configured.")
+		}
+	}
+
+	return &AlertManager{
+		notifiers: notifiers,
+		cooldown:  time.Duration(cfg.Alerting.Cooldown.Value.(uint64)) * time.Minute,
+		log:       &logger,
+		active:    map[string]time.Time{},
+		lock:      &sync.Mutex{},
+	}
+}
+
+// Check if any notification channels are configured
+func (m *AlertManager) IsEnabled() bool {
+	return len(m.notifiers) > 0
+}
+
+// Call a function with every alert that's sent, whether or not any notification channels are configured
+func (m *AlertManager) AddListener(listener func(Alert)) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.listeners = append(m.listeners, listener)
+}
+
+// Raise an alert. It will be sent if it isn't already active or if the cooldown has elapsed since it was last sent.
+func (m *AlertManager) Raise(alert Alert) {
+	m.lock.Lock()
+	key := alert.Key()
+	lastSent, exists := m.active[key]
+	if exists && time.Since(lastSent) < m.cooldown {
+		m.lock.Unlock()
+		return
+	}
+	m.active[key] = time.Now()
+	m.lock.Unlock()
+
+	alert.Resolved = false
+	m.send(alert)
+}
+
+// Clear an alert. If it was active, a resolution notice is sent.
+func (m *AlertManager) Resolve(alert Alert) {
+	m.lock.Lock()
+	key := alert.Key()
+	_, exists := m.active[key]
+	if !exists {
+		m.lock.Unlock()
+		return
+	}
+	delete(m.active, key)
+	m.lock.Unlock()
+
+	alert.Resolved = true
+	m.send(alert)
+}
+
+// Raise or clear an alert depending on a condition
+func (m *AlertManager) Update(alert Alert, raised bool) {
+	if raised {
+		m.Raise(alert)
+	} else {
+		m.Resolve(alert)
+	}
+}
+
+// Deliver an alert to every notifier
+func (m *AlertManager) send(alert Alert) {
+	m.log.Printlnf("%s: %s", alert.Summary(), alert.Message)
+	m.lock.Lock()
+	listeners := m.listeners
+	m.lock.Unlock()
+	for _, listener := range listeners {
+		listener(alert)
+	}
+	for _, notifier := range m.notifiers {
+		if err := notifier.Send(alert); err != nil {
+			m.log.Warnf("WARNING: Couldn't send alert to %s: %s", notifier.GetName(), err.Error())
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package alerting
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// Config
+const notifierTimeout time.Duration = 15 * time.Second
+
+// A channel that alerts can be delivered through
+type Notifier interface {
+	// The name of the notification channel
+	GetName() string
+
+	// Deliver an alert
+	Send(alert Alert) error
+}
+
+// Create the notifiers that have been configured
+func NewNotifiers(cfg *config.AlertingConfig) []Notifier {
+	notifiers := []Notifier{}
+	client := &http.Client{Timeout: notifierTimeout}
+
+	if webhookUrl := cfg.DiscordWebhookUrl.Value.(string); webhookUrl != "" {
+		notifiers = append(notifiers, &DiscordNotifier{client: client, webhookUrl: webhookUrl})
+	}
+	botToken := cfg.TelegramBotToken.Value.(string)
+	chatID := cfg.TelegramChatID.Value.(string)
+	if botToken != "" && chatID != "" {
+		notifiers = append(notifiers, &TelegramNotifier{client: client, botToken: botToken, chatID: chatID})
+	}
+	appToken := cfg.PushoverAppToken.Value.(string)
+	userKey := cfg.PushoverUserKey.Value.(string)
+	if appToken != "" && userKey != "" {
+		notifiers = append(notifiers, &PushoverNotifier{client: client, appToken: appToken, userKey: userKey})
+	}
+	if routingKey := cfg.PagerDutyRoutingKey.Value.(string); routingKey != "" {
+		notifiers = append(notifiers, &PagerDutyNotifier{client: client, routingKey: routingKey})
+	}
+	if webhookUrl := cfg.WebhookUrl.Value.(string); webhookUrl != "" {
+		notifiers = append(notifiers, &WebhookNotifier{client: client, url: webhookUrl})
+	}
+
+	return notifiers
+}
+
+// Sends alerts to a Discord webhook
+type DiscordNotifier struct {
+	client     *http.Client
+	webhookUrl string
+}
+
+func (n *DiscordNotifier) GetName() string {
+	return "Discord"
+}
+
+func (n *DiscordNotifier) Send(alert Alert) error {
+	return postJson(n.client, n.webhookUrl, map[string]string{
+		"content": fmt.Sprintf("**%s**\n%s", alert.Summary(), alert.Message),
+	})
+}
+
+// Sends alerts to a Telegram chat through a bot
+type TelegramNotifier struct {
+	client   *http.Client
+	botToken string
+	chatID   string
+}
+
+func (n *TelegramNotifier) GetName() string {
+	return "Telegram"
+}
+
+func (n *TelegramNotifier) Send(alert Alert) error {
+	return postJson(n.client, fmt.Sprintf("https://api.telegram.org/bot%s/sendMessage", n.botToken), map[string]string{
+		"chat_id": n.chatID,
+		"text":    fmt.Sprintf("%s\n%s", alert.Summary(), alert.Message),
+	})
+}
+
+// Sends alerts through Pushover
+type PushoverNotifier struct {
+	client   *http.Client
+	appToken string
+	userKey  string
+}
+
+func (n *PushoverNotifier) GetName() string {
+	return "Pushover"
+}
+
+func (n *PushoverNotifier) Send(alert Alert) error {
+	priority := "0"
+	if alert.Severity == Severity_Critical && !alert.Resolved {
+		priority = "1"
+	}
+	response, err := n.client.PostForm("https://api.pushover.net/1/messages.json", url.Values{
+		"token":    {n.appToken},
+		"user":     {n.userKey},
+		"title":    {alert.Summary()},
+		"message":  {alert.Message},
+		"priority": {priority},
+	})
+	if err != nil {
+		return stripUrl(err)
+	}
+	return checkResponse(response)
+}
+
+// Sends alerts to PagerDuty through the Events API v2
+type PagerDutyNotifier struct {
+	client     *http.Client
+	routingKey string
+}
+
+func (n *PagerDutyNotifier) GetName() string {
+	return "PagerDuty"
+}
+
+func (n *PagerDutyNotifier) Send(alert Alert) error {
+	action := "trigger"
+	if alert.Resolved {
+		action = "resolve"
+	}
+	severity := string(alert.Severity)
+	return postJson(n.client, "https://events.pagerduty.com/v2/enqueue", map[string]interface{}{
+		"routing_key":  n.routingKey,
+		"event_action": action,
+		"dedup_key":    alert.Key(),
+		"payload": map[string]string{
+			"summary":  fmt.Sprintf("%s: %s", alert.Title, alert.Message),
+			"source":   "rocketpool-smartnode",
+			"severity": severity,
+		},
+	})
+}
+
+// Sends alerts as JSON to an arbitrary URL
+type WebhookNotifier struct {
+	client *http.Client
+	url    string
+}
+
+func (n *WebhookNotifier) GetName() string {
+	return "Webhook"
+}
+
+func (n *WebhookNotifier) Send(alert Alert) error {
+	return postJson(n.client, n.url, alert)
+}
+
+// Post a JSON body to a URL
+func postJson(client *http.Client, url string, body interface{}) error {
+	bodyBytes, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("error serializing alert: %w", err)
+	}
+	response, err := client.Post(url, "application/json", bytes.NewReader(bodyBytes))
+	if err != nil {
+		return stripUrl(err)
+	}
+	return checkResponse(response)
+}
+
+// Remove the request URL from a failed request's error, since Telegram bot tokens and Discord webhook secrets are part of it and the error gets logged
+func stripUrl(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return fmt.Errorf("error sending %s request: %w", urlErr.Op, urlErr.Err)
+	}
+	return err
+}
+
+// Make sure a notification request succeeded
+func checkResponse(response *http.Response) error {
+	defer response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		body, _ := io.ReadAll(response.Body)
+		return fmt.Errorf("request failed with code %d: %s", response.StatusCode, string(body))
+	}
+	return nil
+}
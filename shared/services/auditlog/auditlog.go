@@ -0,0 +1,354 @@
+package auditlog
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// The file mode of the audit log; it can contain addresses and amounts, so only the node's user can read it
+const fileMode = 0600
+
+// Settings
+const (
+	// The size the log can grow to before it's moved aside and a new file is started
+	maxFileSize int64 = 10 * 1024 * 1024
+
+	// The number of full files kept next to the current one, as <path>.1 (the newest) to <path>.5 (the oldest)
+	maxRotatedFiles int = 5
+
+	// The suffix of the file next to the log that records the hash of the last entry
+	headSuffix string = ".head"
+
+	// The suffix of the file next to the log that's locked while the log is written or read
+	lockSuffix string = ".lock"
+
+	// The longest line the log can contain
+	maxLineSize int = 1024 * 1024
+)
+
+// A state-changing API command that was run
+type Entry struct {
+	Time time.Time `json:"time"`
+
+	// The API route of the command, such as node/send
+	Route string `json:"route"`
+
+	// The command's arguments, with secrets such as passwords and mnemonics redacted
+	Args []string `json:"args"`
+
+	// The name of the API server key that ran the command, or empty if it came from the node's own CLI
+	KeyName string `json:"keyName"`
+
+	// The outcome of the command
+	Status   string   `json:"status"`
+	Error    string   `json:"error,omitempty"`
+	TxHashes []string `json:"txHashes,omitempty"`
+
+	// The hash of the previous entry and of this one, chaining the entries so edits and deletions can be detected
+	PreviousHash string `json:"previousHash"`
+	Hash         string `json:"hash"`
+}
+
+// An append-only log of the commands run by the API, saved as one JSON object per line
+type Log struct {
+	path string
+}
+
+// The hash of the last entry, along with the size of the log once it was written so an outdated record can be detected
+type head struct {
+	Hash string `json:"hash"`
+	Size int64  `json:"size"`
+}
+
+// Create a new audit log backed by the file at the provided path
+func NewLog(path string) *Log {
+	return &Log{
+		path: path,
+	}
+}
+
+// Get the hash of an entry, covering everything but the hash itself
+func (e Entry) getHash() (string, error) {
+	e.Hash = ""
+	bytes, err := json.Marshal(e)
+	if err != nil {
+		return "", fmt.Errorf("error serializing audit log entry: %w", err)
+	}
+	hash := sha256.Sum256(bytes)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// Append an entry to the log, chaining it to the last one. The chain isn't verified here; use Load for that.
+// The log is locked while the entry is written, so commands run by separate processes can append to it concurrently.
+func (l *Log) Append(entry Entry) error {
+	err := os.MkdirAll(filepath.Dir(l.path), 0755)
+	if err != nil {
+		return fmt.Errorf("error creating audit log directory: %w", err)
+	}
+	unlock, err := l.lock(syscall.LOCK_EX)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	// Get the hash of the last entry
+	size, err := getFileSize(l.path)
+	if err != nil {
+		return err
+	}
+	entry.PreviousHash, err = l.getLastHash(size)
+	if err != nil {
+		return err
+	}
+	entry.Hash, err = entry.getHash()
+	if err != nil {
+		return err
+	}
+	bytes, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("error serializing audit log entry: %w", err)
+	}
+	bytes = append(bytes, '\n')
+
+	// Start a new file once the current one is full
+	if size >= maxFileSize {
+		if err := l.rotate(); err != nil {
+			return err
+		}
+		size = 0
+	}
+
+	// Write the entry, then record it as the last one
+	file, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, fileMode)
+	if err != nil {
+		return fmt.Errorf("error opening audit log [%s]: %w", l.path, err)
+	}
+	defer file.Close()
+	_, err = file.Write(bytes)
+	if err != nil {
+		return fmt.Errorf("error writing to audit log [%s]: %w", l.path, err)
+	}
+	return l.saveHead(head{
+		Hash: entry.Hash,
+		Size: size + int64(len(bytes)),
+	})
+}
+
+// Load every entry in the order they were recorded, including the ones in rotated files, and check that the chain of hashes is intact.
+// Returns a description of the first problem with the chain, or an empty string if there isn't one.
+func (l *Log) Load() ([]Entry, string, error) {
+	entries := []Entry{}
+	problem := ""
+
+	// Keep entries from being appended and the files from being rotated while they're read
+	unlock, err := l.lock(syscall.LOCK_SH)
+	if errors.Is(err, os.ErrNotExist) {
+		return entries, problem, nil
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	defer unlock()
+
+	// Read the rotated files from the oldest to the newest, then the current one
+	paths := []string{}
+	for i := maxRotatedFiles; i >= 1; i-- {
+		paths = append(paths, getRotatedPath(l.path, i))
+	}
+	paths = append(paths, l.path)
+
+	for _, path := range paths {
+		file, err := os.Open(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, "", fmt.Errorf("error opening audit log [%s]: %w", path, err)
+		}
+
+		// The oldest file that's kept may follow one that was dropped by rotation, so its first entry can't be checked against it
+		isOldestKept := path == getRotatedPath(l.path, maxRotatedFiles)
+
+		line := 0
+		scanner := bufio.NewScanner(file)
+		scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+		for scanner.Scan() {
+			line++
+			var entry Entry
+			if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+				if problem == "" {
+					problem = fmt.Sprintf("line %d of %s can't be read: %s", line, filepath.Base(path), err.Error())
+				}
+				continue
+			}
+			if problem == "" {
+				hash, err := entry.getHash()
+				if err != nil {
+					file.Close()
+					return nil, "", err
+				}
+				previousHash := ""
+				if len(entries) > 0 {
+					previousHash = entries[len(entries)-1].Hash
+				} else if isOldestKept {
+					previousHash = entry.PreviousHash
+				}
+				if hash != entry.Hash {
+					problem = fmt.Sprintf("the entry on line %d of %s has been modified", line, filepath.Base(path))
+				} else if entry.PreviousHash != previousHash {
+					problem = fmt.Sprintf("the entry before line %d of %s has been modified or removed", line, filepath.Base(path))
+				}
+			}
+			entries = append(entries, entry)
+		}
+		err = scanner.Err()
+		file.Close()
+		if err != nil {
+			return nil, "", fmt.Errorf("error reading audit log [%s]: %w", path, err)
+		}
+	}
+	return entries, problem, nil
+}
+
+// Lock the log with the provided flock operation until the returned function is called
+func (l *Log) lock(operation int) (func(), error) {
+	path := l.path + lockSuffix
+	file, err := os.OpenFile(path, os.O_RDONLY|os.O_CREATE, fileMode)
+	if err != nil {
+		return nil, fmt.Errorf("error opening audit log lock [%s]: %w", path, err)
+	}
+	if err := syscall.Flock(int(file.Fd()), operation); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("error locking audit log [%s]: %w", path, err)
+	}
+	return func() {
+		syscall.Flock(int(file.Fd()), syscall.LOCK_UN)
+		file.Close()
+	}, nil
+}
+
+// Get the hash of the last entry in the log, given the current size of the log file.
+// The record next to the log is used if it matches the file; otherwise the last entry is read from the end of the log.
+func (l *Log) getLastHash(size int64) (string, error) {
+	bytes, err := os.ReadFile(l.path + headSuffix)
+	if err == nil {
+		var lastHead head
+		if json.Unmarshal(bytes, &lastHead) == nil && lastHead.Size == size {
+			return lastHead.Hash, nil
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("error reading audit log head [%s]: %w", l.path+headSuffix, err)
+	}
+
+	// Fall back to the last entry in the log, or in the newest rotated file if the log is empty
+	for _, path := range []string{l.path, getRotatedPath(l.path, 1)} {
+		hash, exists, err := readLastHash(path)
+		if err != nil {
+			return "", err
+		}
+		if exists {
+			return hash, nil
+		}
+	}
+	return "", nil
+}
+
+// Record the hash of the last entry next to the log
+func (l *Log) saveHead(lastHead head) error {
+	bytes, err := json.Marshal(lastHead)
+	if err != nil {
+		return fmt.Errorf("error serializing audit log head: %w", err)
+	}
+	path := l.path + headSuffix
+	err = os.WriteFile(path, bytes, fileMode)
+	if err != nil {
+		return fmt.Errorf("error writing audit log head [%s]: %w", path, err)
+	}
+	return nil
+}
+
+// Move the current file aside, dropping the oldest rotated file if there are too many
+func (l *Log) rotate() error {
+	for i := maxRotatedFiles - 1; i >= 1; i-- {
+		err := os.Rename(getRotatedPath(l.path, i), getRotatedPath(l.path, i+1))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("error rotating audit log: %w", err)
+		}
+	}
+	err := os.Rename(l.path, getRotatedPath(l.path, 1))
+	if err != nil {
+		return fmt.Errorf("error rotating audit log: %w", err)
+	}
+	return nil
+}
+
+// Get the names of every file that makes up the log with the provided filename: the log, the record of its last entry, and the rotated files
+func GetFilenames(filename string) []string {
+	names := []string{filename, filename + headSuffix}
+	for i := 1; i <= maxRotatedFiles; i++ {
+		names = append(names, getRotatedPath(filename, i))
+	}
+	return names
+}
+
+// Get the path of a rotated file, where 1 is the newest
+func getRotatedPath(path string, index int) string {
+	return fmt.Sprintf("%s.%d", path, index)
+}
+
+// Get the size of a file, or 0 if it doesn't exist
+func getFileSize(path string) (int64, error) {
+	info, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("error checking audit log [%s]: %w", path, err)
+	}
+	return info.Size(), nil
+}
+
+// Read the hash of the last entry in a log file from its end, returning false if the file doesn't have any entries
+func readLastHash(path string) (string, bool, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("error opening audit log [%s]: %w", path, err)
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return "", false, fmt.Errorf("error checking audit log [%s]: %w", path, err)
+	}
+
+	// Read the tail of the file, which holds at least the whole last line
+	offset := info.Size() - int64(maxLineSize) - 1
+	if offset < 0 {
+		offset = 0
+	}
+	tail := make([]byte, info.Size()-offset)
+	if _, err := file.ReadAt(tail, offset); err != nil {
+		return "", false, fmt.Errorf("error reading audit log [%s]: %w", path, err)
+	}
+	lines := strings.Split(strings.TrimRight(string(tail), "\n"), "\n")
+	lastLine := lines[len(lines)-1]
+	if lastLine == "" {
+		return "", false, nil
+	}
+	var entry Entry
+	if err := json.Unmarshal([]byte(lastLine), &entry); err != nil {
+		return "", false, fmt.Errorf("the last entry of the audit log [%s] can't be read: %w", path, err)
+	}
+	return entry.Hash, true, nil
+}
@@ -0,0 +1,175 @@
+package auditlog
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Create a log in a temporary folder
+func newTestLog(t *testing.T) (*Log, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "audit.log")
+	return NewLog(path), path
+}
+
+// Append an entry for the provided route, failing the test if it can't
+func appendEntry(t *testing.T, log *Log, route string) {
+	t.Helper()
+	err := log.Append(Entry{
+		Time:   time.Now(),
+		Route:  route,
+		Args:   []string{"1"},
+		Status: "success",
+	})
+	if err != nil {
+		t.Fatalf("error appending entry: %s", err.Error())
+	}
+}
+
+// Load the log, failing the test if it can't be read
+func loadEntries(t *testing.T, log *Log) ([]Entry, string) {
+	t.Helper()
+	entries, problem, err := log.Load()
+	if err != nil {
+		t.Fatalf("error loading log: %s", err.Error())
+	}
+	return entries, problem
+}
+
+func TestLoadMissingLog(t *testing.T) {
+	log := NewLog(filepath.Join(t.TempDir(), "missing", "audit.log"))
+	entries, problem := loadEntries(t, log)
+	if len(entries) != 0 || problem != "" {
+		t.Fatalf("expected an empty log with no problems, got %d entries and problem \"%s\"", len(entries), problem)
+	}
+}
+
+func TestAppendChainsEntries(t *testing.T) {
+	log, _ := newTestLog(t)
+	for i := 0; i < 3; i++ {
+		appendEntry(t, log, fmt.Sprintf("node/send-%d", i))
+	}
+
+	entries, problem := loadEntries(t, log)
+	if problem != "" {
+		t.Fatalf("expected an intact chain, got problem \"%s\"", problem)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 entries, got %d", len(entries))
+	}
+	if entries[0].PreviousHash != "" {
+		t.Fatalf("expected the first entry to have no previous hash, got %s", entries[0].PreviousHash)
+	}
+	for i := 1; i < len(entries); i++ {
+		if entries[i].PreviousHash != entries[i-1].Hash {
+			t.Fatalf("entry %d isn't chained to entry %d", i, i-1)
+		}
+	}
+}
+
+func TestConcurrentAppendsKeepTheChainIntact(t *testing.T) {
+	log, _ := newTestLog(t)
+	workers := 8
+	appendsPerWorker := 25
+
+	// Each worker uses its own Log like the separate API processes do
+	path := log.path
+	errs := make(chan error, workers*appendsPerWorker)
+	wg := &sync.WaitGroup{}
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			workerLog := NewLog(path)
+			for j := 0; j < appendsPerWorker; j++ {
+				errs <- workerLog.Append(Entry{
+					Time:   time.Now(),
+					Route:  fmt.Sprintf("node/send-%d-%d", worker, j),
+					Status: "success",
+				})
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("error appending entry: %s", err.Error())
+		}
+	}
+
+	entries, problem := loadEntries(t, log)
+	if problem != "" {
+		t.Fatalf("expected an intact chain, got problem \"%s\"", problem)
+	}
+	if len(entries) != workers*appendsPerWorker {
+		t.Fatalf("expected %d entries, got %d", workers*appendsPerWorker, len(entries))
+	}
+}
+
+func TestLoadDetectsModifiedEntry(t *testing.T) {
+	log, path := newTestLog(t)
+	appendEntry(t, log, "node/send")
+	appendEntry(t, log, "node/stake-rpl")
+
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	modified := strings.Replace(string(bytes), "node/stake-rpl", "node/swap-rpl", 1)
+	if err := os.WriteFile(path, []byte(modified), fileMode); err != nil {
+		t.Fatal(err)
+	}
+
+	_, problem := loadEntries(t, log)
+	if !strings.Contains(problem, "line 2") || !strings.Contains(problem, "modified") {
+		t.Fatalf("expected the modified second entry to be reported, got problem \"%s\"", problem)
+	}
+}
+
+func TestAppendRecoversFromOutdatedHead(t *testing.T) {
+	log, path := newTestLog(t)
+	appendEntry(t, log, "node/send")
+
+	// Record a stale head, as if the process was killed between writing the entry and the head
+	if err := os.WriteFile(path+headSuffix, []byte(`{"hash":"stale","size":1}`), fileMode); err != nil {
+		t.Fatal(err)
+	}
+	appendEntry(t, log, "node/stake-rpl")
+
+	_, problem := loadEntries(t, log)
+	if problem != "" {
+		t.Fatalf("expected an intact chain, got problem \"%s\"", problem)
+	}
+}
+
+func TestLoadFollowsTheChainAcrossRotatedFiles(t *testing.T) {
+	log, path := newTestLog(t)
+	appendEntry(t, log, "node/send")
+	if err := log.rotate(); err != nil {
+		t.Fatal(err)
+	}
+	appendEntry(t, log, "node/stake-rpl")
+
+	entries, problem := loadEntries(t, log)
+	if problem != "" {
+		t.Fatalf("expected an intact chain, got problem \"%s\"", problem)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+
+	// Removing the rotated file breaks the chain
+	if err := os.Remove(getRotatedPath(path, 1)); err != nil {
+		t.Fatal(err)
+	}
+	_, problem = loadEntries(t, log)
+	if problem == "" {
+		t.Fatal("expected the missing rotated file to be reported")
+	}
+}
@@ -0,0 +1,292 @@
+package backup
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Settings
+const (
+	SchemaVersion int    = 1
+	FileExtension string = ".rpbackup"
+
+	// The environment variable the CLI passes the passphrase to the API in, hex-encoded so it survives shell quoting
+	PassphraseEnvVar string = "RP_BACKUP_PASSPHRASE"
+
+	manifestName   string = "manifest.json"
+	settingsPrefix string = "settings"
+	dataPrefix     string = "data"
+)
+
+// The entries in the data folder that are backed up: the wallet and its password, the validator keys along with each
+// validator client's slashing protection database, and the rewards and history records
+var DataEntries = []string{
+	"wallet",
+	"password",
+	"validators",
+	"custom-keys",
+	"records",
+	"rewards-trees",
+	"history",
+}
+
+// A description of the backup's contents, stored unencrypted inside the archive ahead of the files
+type Manifest struct {
+	SchemaVersion    int       `json:"schemaVersion"`
+	SmartnodeVersion string    `json:"smartnodeVersion"`
+	Network          string    `json:"network"`
+	NodeAddress      string    `json:"nodeAddress,omitempty"`
+	Time             time.Time `json:"time"`
+	Files            []string  `json:"files"`
+}
+
+// Check if the backup includes validator keys, which can be slashed if they run on two machines at once
+func (m *Manifest) HasValidatorKeys() bool {
+	for _, file := range m.Files {
+		if strings.HasPrefix(file, path.Join(dataPrefix, "validators")+"/") || strings.HasPrefix(file, path.Join(dataPrefix, "custom-keys")+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// Write an encrypted backup of the settings file and the data folder
+func Create(out io.Writer, passphrase string, manifest *Manifest, settingsPath string, dataPath string) error {
+
+	// Find the files to back up
+	sources := map[string]string{
+		path.Join(settingsPrefix, filepath.Base(settingsPath)): settingsPath,
+	}
+	for _, entry := range DataEntries {
+		root := filepath.Join(dataPath, entry)
+		err := filepath.WalkDir(root, func(fsPath string, d fs.DirEntry, err error) error {
+			if errors.Is(err, fs.ErrNotExist) && fsPath == root {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if !d.Type().IsRegular() {
+				return nil
+			}
+			relPath, err := filepath.Rel(dataPath, fsPath)
+			if err != nil {
+				return err
+			}
+			sources[path.Join(dataPrefix, filepath.ToSlash(relPath))] = fsPath
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("error reading %s: %w", root, err)
+		}
+	}
+	manifest.SchemaVersion = SchemaVersion
+	manifest.Files = make([]string, 0, len(sources))
+	for archivePath := range sources {
+		manifest.Files = append(manifest.Files, archivePath)
+	}
+	sort.Strings(manifest.Files)
+
+	// Set up the stream
+	encrypter, err := newEncryptWriter(out, passphrase)
+	if err != nil {
+		return err
+	}
+	gzipWriter := gzip.NewWriter(encrypter)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	// Write the manifest first so it can be read without extracting everything
+	manifestBytes, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error serializing backup manifest: %w", err)
+	}
+	err = tarWriter.WriteHeader(&tar.Header{Name: manifestName, Mode: 0600, Size: int64(len(manifestBytes)), ModTime: manifest.Time})
+	if err != nil {
+		return err
+	}
+	if _, err := tarWriter.Write(manifestBytes); err != nil {
+		return err
+	}
+
+	// Write the files
+	for _, archivePath := range manifest.Files {
+		if err := addFile(tarWriter, archivePath, sources[archivePath]); err != nil {
+			return err
+		}
+	}
+
+	if err := tarWriter.Close(); err != nil {
+		return err
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return err
+	}
+	return encrypter.Close()
+
+}
+
+// Read the manifest of an encrypted backup without extracting it. This also checks the passphrase.
+func ReadManifest(in io.Reader, passphrase string) (*Manifest, error) {
+	tarReader, err := openArchive(in, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return readManifest(tarReader)
+}
+
+// Read an entire encrypted backup without extracting it, to check that it's complete and hasn't been tampered with
+func Verify(in io.Reader, passphrase string) (*Manifest, error) {
+	tarReader, err := openArchive(in, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	manifest, err := readManifest(tarReader)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		_, err := tarReader.Next()
+		if err == io.EOF {
+			return manifest, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reading backup: %w", err)
+		}
+		if _, err := io.Copy(io.Discard, tarReader); err != nil {
+			return nil, fmt.Errorf("error reading backup: %w", err)
+		}
+	}
+}
+
+// Extract an encrypted backup, restoring the settings file and the data folder
+func Restore(in io.Reader, passphrase string, settingsPath string, dataPath string) (*Manifest, error) {
+	tarReader, err := openArchive(in, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	manifest, err := readManifest(tarReader)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reading backup: %w", err)
+		}
+
+		// Work out where the file goes, refusing anything that would escape its folder
+		var target string
+		switch {
+		case header.Name == path.Join(settingsPrefix, filepath.Base(settingsPath)):
+			target = settingsPath
+		case strings.HasPrefix(header.Name, dataPrefix+"/"):
+			relPath := path.Clean(strings.TrimPrefix(header.Name, dataPrefix+"/"))
+			if relPath == "." || strings.HasPrefix(relPath, "../") || path.IsAbs(relPath) {
+				return nil, fmt.Errorf("the backup contains an invalid path: %s", header.Name)
+			}
+			target = filepath.Join(dataPath, filepath.FromSlash(relPath))
+		default:
+			return nil, fmt.Errorf("the backup contains an unexpected file: %s", header.Name)
+		}
+
+		if err := extractFile(tarReader, header, target); err != nil {
+			return nil, err
+		}
+	}
+	return manifest, nil
+}
+
+// Add a file to the archive
+func addFile(tarWriter *tar.Writer, archivePath string, fsPath string) error {
+	file, err := os.Open(fsPath)
+	if err != nil {
+		return fmt.Errorf("error opening %s: %w", fsPath, err)
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("error reading %s: %w", fsPath, err)
+	}
+	err = tarWriter.WriteHeader(&tar.Header{
+		Name:    archivePath,
+		Mode:    int64(info.Mode().Perm()),
+		Size:    info.Size(),
+		ModTime: info.ModTime(),
+	})
+	if err != nil {
+		return fmt.Errorf("error adding %s to the backup: %w", fsPath, err)
+	}
+	if _, err := io.Copy(tarWriter, file); err != nil {
+		return fmt.Errorf("error adding %s to the backup: %w", fsPath, err)
+	}
+	return nil
+}
+
+// Write a file from the archive
+func extractFile(tarReader *tar.Reader, header *tar.Header, target string) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0700); err != nil {
+		return fmt.Errorf("error creating folder for %s: %w", target, err)
+	}
+	file, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, fs.FileMode(header.Mode).Perm())
+	if err != nil {
+		return fmt.Errorf("error creating %s: %w", target, err)
+	}
+	defer file.Close()
+	if _, err := io.Copy(file, tarReader); err != nil {
+		return fmt.Errorf("error writing %s: %w", target, err)
+	}
+	return nil
+}
+
+// Decrypt and decompress a backup
+func openArchive(in io.Reader, passphrase string) (*tar.Reader, error) {
+	decrypter, err := newDecryptReader(in, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	gzipReader, err := gzip.NewReader(decrypter)
+	if err != nil {
+		if errors.Is(err, ErrWrongPassphrase) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("error decompressing backup: %w", err)
+	}
+	return tar.NewReader(gzipReader), nil
+}
+
+// Read the manifest, which is always the first entry
+func readManifest(tarReader *tar.Reader) (*Manifest, error) {
+	header, err := tarReader.Next()
+	if err != nil {
+		if errors.Is(err, ErrWrongPassphrase) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("error reading backup: %w", err)
+	}
+	if header.Name != manifestName {
+		return nil, fmt.Errorf("the backup doesn't start with a manifest")
+	}
+	var manifest Manifest
+	if err := json.NewDecoder(tarReader).Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("error decoding backup manifest: %w", err)
+	}
+	if manifest.SchemaVersion > SchemaVersion {
+		return nil, fmt.Errorf("the backup was made by a newer version of the Smartnode (schema version %d); please update before restoring it", manifest.SchemaVersion)
+	}
+	return &manifest, nil
+}
@@ -0,0 +1,197 @@
+package backup
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// Encryption settings
+const (
+	fileMagic  string = "RPBACKUP"
+	saltSize   int    = 32
+	keySize    int    = 32
+	chunkSize  int    = 1024 * 1024
+	scryptN    int    = 1 << 17
+	scryptR    int    = 8
+	scryptP    int    = 1
+	finalChunk byte   = 1
+	innerChunk byte   = 0
+)
+
+// Returned when the passphrase doesn't decrypt the backup
+var ErrWrongPassphrase = errors.New("the passphrase is incorrect or the backup is corrupted")
+
+// Derive the encryption key from the passphrase
+func deriveKey(passphrase string, salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key([]byte(passphrase), salt, scryptN, scryptR, scryptP, keySize)
+	if err != nil {
+		return nil, fmt.Errorf("error deriving encryption key: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// Get the nonce for a chunk, which is the base nonce with the chunk index mixed into its last 8 bytes
+func chunkNonce(base []byte, index uint64) []byte {
+	nonce := append([]byte{}, base...)
+	counter := binary.BigEndian.Uint64(nonce[len(nonce)-8:]) ^ index
+	binary.BigEndian.PutUint64(nonce[len(nonce)-8:], counter)
+	return nonce
+}
+
+// Encrypts a stream in authenticated chunks, so large backups don't have to fit in memory.
+// The last chunk is marked so a truncated file can't be mistaken for a complete one.
+type encryptWriter struct {
+	out    io.Writer
+	aead   cipher.AEAD
+	nonce  []byte
+	index  uint64
+	buffer []byte
+}
+
+// Create a writer that encrypts everything written to it with the passphrase. Close must be called to write the final chunk.
+func newEncryptWriter(out io.Writer, passphrase string) (*encryptWriter, error) {
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	aead, err := deriveKey(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	header := append([]byte(fileMagic), salt...)
+	header = append(header, nonce...)
+	if _, err := out.Write(header); err != nil {
+		return nil, err
+	}
+	return &encryptWriter{
+		out:    out,
+		aead:   aead,
+		nonce:  nonce,
+		buffer: make([]byte, 0, chunkSize),
+	}, nil
+}
+
+func (w *encryptWriter) Write(data []byte) (int, error) {
+	written := 0
+	for len(data) > 0 {
+		n := copy(w.buffer[len(w.buffer):cap(w.buffer)], data)
+		w.buffer = w.buffer[:len(w.buffer)+n]
+		data = data[n:]
+		written += n
+		if len(w.buffer) == cap(w.buffer) && len(data) > 0 {
+			if err := w.writeChunk(innerChunk); err != nil {
+				return written, err
+			}
+		}
+	}
+	return written, nil
+}
+
+func (w *encryptWriter) Close() error {
+	return w.writeChunk(finalChunk)
+}
+
+func (w *encryptWriter) writeChunk(flag byte) error {
+	sealed := w.aead.Seal(nil, chunkNonce(w.nonce, w.index), w.buffer, []byte{flag})
+	header := make([]byte, 5)
+	header[0] = flag
+	binary.BigEndian.PutUint32(header[1:], uint32(len(sealed)))
+	if _, err := w.out.Write(header); err != nil {
+		return err
+	}
+	if _, err := w.out.Write(sealed); err != nil {
+		return err
+	}
+	w.index++
+	w.buffer = w.buffer[:0]
+	return nil
+}
+
+// Decrypts a stream written by an encryptWriter
+type decryptReader struct {
+	in     io.Reader
+	aead   cipher.AEAD
+	nonce  []byte
+	index  uint64
+	buffer *bytes.Reader
+	done   bool
+}
+
+// Create a reader that decrypts a backup with the passphrase
+func newDecryptReader(in io.Reader, passphrase string) (*decryptReader, error) {
+	magic := make([]byte, len(fileMagic))
+	if _, err := io.ReadFull(in, magic); err != nil || string(magic) != fileMagic {
+		return nil, fmt.Errorf("this isn't a Smartnode backup file")
+	}
+	salt := make([]byte, saltSize)
+	if _, err := io.ReadFull(in, salt); err != nil {
+		return nil, fmt.Errorf("error reading backup header: %w", err)
+	}
+	aead, err := deriveKey(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(in, nonce); err != nil {
+		return nil, fmt.Errorf("error reading backup header: %w", err)
+	}
+	return &decryptReader{
+		in:     in,
+		aead:   aead,
+		nonce:  nonce,
+		buffer: bytes.NewReader(nil),
+	}, nil
+}
+
+func (r *decryptReader) Read(data []byte) (int, error) {
+	for r.buffer.Len() == 0 {
+		if r.done {
+			return 0, io.EOF
+		}
+		if err := r.readChunk(); err != nil {
+			return 0, err
+		}
+	}
+	return r.buffer.Read(data)
+}
+
+func (r *decryptReader) readChunk() error {
+	header := make([]byte, 5)
+	if _, err := io.ReadFull(r.in, header); err != nil {
+		return fmt.Errorf("the backup is truncated: %w", err)
+	}
+	flag := header[0]
+	length := binary.BigEndian.Uint32(header[1:])
+	if length > uint32(chunkSize+r.aead.Overhead()) {
+		return ErrWrongPassphrase
+	}
+	sealed := make([]byte, length)
+	if _, err := io.ReadFull(r.in, sealed); err != nil {
+		return fmt.Errorf("the backup is truncated: %w", err)
+	}
+	plaintext, err := r.aead.Open(nil, chunkNonce(r.nonce, r.index), sealed, []byte{flag})
+	if err != nil {
+		return ErrWrongPassphrase
+	}
+	r.index++
+	r.done = flag == finalChunk
+	r.buffer = bytes.NewReader(plaintext)
+	return nil
+}
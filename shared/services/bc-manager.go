@@ -1,6 +1,7 @@
 package services
 
 import (
+	"bytes"
 	"fmt"
 	"strings"
 
@@ -17,12 +18,16 @@ import (
 
 // This is a proxy for multiple Beacon clients, providing natural fallback support if one of them fails.
 type BeaconClientManager struct {
+	primaryProvider string
 	primaryBc       beacon.Client
 	fallbackBc      beacon.Client
 	logger          log.ColorLogger
 	primaryReady    bool
 	fallbackReady   bool
 	ignoreSyncCheck bool
+
+	// The custom network the clients should be on, if there is one
+	networkDefinition *config.NetworkDefinition
 }
 
 // This is a signature for a wrapped Beacon client function that only returns an error
@@ -72,23 +77,55 @@ func NewBeaconClientManager(cfg *config.RocketPoolConfig) (*BeaconClientManager,
 		}
 	}
 
+	// Custom network
+	networkDefinition, err := cfg.Smartnode.GetNetworkDefinition()
+	if err != nil {
+		return nil, fmt.Errorf("error loading custom network definition: %w", err)
+	}
+
+	// Get the HTTP clients for endpoints that need authentication, client certificates or a proxy
+	primaryTransport, err := cfg.EndpointTransport.GetConsensusClientSettings()
+	if err != nil {
+		return nil, fmt.Errorf("error getting primary CC transport settings: %w", err)
+	}
+	primaryHttpClient, err := newEndpointHttpClient("primary CC", primaryTransport)
+	if err != nil {
+		return nil, fmt.Errorf("error setting up the connection to the primary CC: %w", err)
+	}
+
 	var primaryBc beacon.Client
 	var fallbackBc beacon.Client
-	primaryBc = client.NewStandardHttpClient(primaryProvider)
+	primaryBc = client.NewStandardHttpClientWithHttpClient(primaryProvider, primaryHttpClient)
 	if fallbackProvider != "" {
-		fallbackBc = client.NewStandardHttpClient(fallbackProvider)
+		fallbackTransport, err := cfg.EndpointTransport.GetFallbackConsensusClientSettings()
+		if err != nil {
+			return nil, fmt.Errorf("error getting fallback CC transport settings: %w", err)
+		}
+		fallbackHttpClient, err := newEndpointHttpClient("fallback CC", fallbackTransport)
+		if err != nil {
+			return nil, fmt.Errorf("error setting up the connection to the fallback CC: %w", err)
+		}
+		fallbackBc = client.NewStandardHttpClientWithHttpClient(fallbackProvider, fallbackHttpClient)
 	}
 
 	return &BeaconClientManager{
-		primaryBc:     primaryBc,
-		fallbackBc:    fallbackBc,
-		logger:        log.NewColorLogger(color.FgHiBlue),
-		primaryReady:  true,
-		fallbackReady: fallbackBc != nil,
+		primaryProvider: primaryProvider,
+		primaryBc:       primaryBc,
+		fallbackBc:      fallbackBc,
+		logger:          log.NewColorLogger(color.FgHiBlue),
+		primaryReady:    true,
+		fallbackReady:   fallbackBc != nil,
+
+		networkDefinition: networkDefinition,
 	}, nil
 
 }
 
+// Get the URL of the primary client
+func (m *BeaconClientManager) GetPrimaryProvider() string {
+	return m.primaryProvider
+}
+
 /// ======================
 /// BeaconClient Functions
 /// ======================
@@ -225,6 +262,17 @@ func (m *BeaconClientManager) GetValidatorSyncDuties(indices []string, epoch uin
 	return result.(map[string]bool), nil
 }
 
+// Get whether or not validators were live during an epoch
+func (m *BeaconClientManager) GetValidatorLiveness(indices []string, epoch uint64) (map[string]bool, error) {
+	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
+		return client.GetValidatorLiveness(indices, epoch)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(map[string]bool), nil
+}
+
 // Get a validator's proposer duties
 func (m *BeaconClientManager) GetValidatorProposerDuties(indices []string, epoch uint64) (map[string]uint64, error) {
 	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
@@ -236,6 +284,17 @@ func (m *BeaconClientManager) GetValidatorProposerDuties(indices []string, epoch
 	return result.(map[string]uint64), nil
 }
 
+// Get the slots the provided validators are scheduled to propose in during an epoch
+func (m *BeaconClientManager) GetValidatorProposerSlots(indices []string, epoch uint64) (map[uint64]string, error) {
+	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
+		return client.GetValidatorProposerSlots(indices, epoch)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(map[uint64]string), nil
+}
+
 // Get the Beacon chain's domain data
 func (m *BeaconClientManager) GetDomainData(domainType []byte, epoch uint64, useGenesisFork bool) ([]byte, error) {
 	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
@@ -296,6 +355,24 @@ func (m *BeaconClientManager) ChangeWithdrawalCredentials(validatorIndex string,
 	return nil
 }
 
+// Subscribe to the event stream of whichever Beacon client is ready.
+// Streams end routinely, so they don't mark the client as disconnected; the caller is responsible for reconnecting,
+// and each new subscription goes to the primary client again as soon as it's ready.
+func (m *BeaconClientManager) SubscribeChainEvents(topics []beacon.ChainEventTopic, events chan<- beacon.ChainEvent, stop <-chan struct{}) error {
+	if m.primaryReady {
+		return m.primaryBc.SubscribeChainEvents(topics, events, stop)
+	}
+	if m.fallbackReady {
+		return m.fallbackBc.SubscribeChainEvents(topics, events, stop)
+	}
+	return fmt.Errorf("no Beacon clients were ready")
+}
+
+// True if the primary client is unavailable and requests are going to the fallback client instead
+func (m *BeaconClientManager) IsUsingFallback() bool {
+	return !m.primaryReady && m.fallbackReady
+}
+
 /// ==================
 /// Internal Functions
 /// ==================
@@ -318,11 +395,11 @@ func (m *BeaconClientManager) CheckStatus() *api.ClientManagerStatus {
 	}
 
 	// Get the primary BC status
-	status.PrimaryClientStatus = checkBcStatus(m.primaryBc)
+	status.PrimaryClientStatus = checkBcStatus(m.primaryBc, m.networkDefinition)
 
 	// Get the fallback BC status if applicable
 	if status.FallbackEnabled {
-		status.FallbackClientStatus = checkBcStatus(m.fallbackBc)
+		status.FallbackClientStatus = checkBcStatus(m.fallbackBc, m.networkDefinition)
 	}
 
 	// Flag the ready clients
@@ -334,7 +411,7 @@ func (m *BeaconClientManager) CheckStatus() *api.ClientManagerStatus {
 }
 
 // Check the client status
-func checkBcStatus(client beacon.Client) api.ClientStatus {
+func checkBcStatus(client beacon.Client, networkDefinition *config.NetworkDefinition) api.ClientStatus {
 
 	status := api.ClientStatus{}
 
@@ -357,10 +434,42 @@ func checkBcStatus(client beacon.Client) api.ClientStatus {
 		status.IsSynced = false
 		status.SyncProgress = syncStatus.Progress
 	}
+
+	// Make sure the client is on the custom network's chain
+	if networkDefinition != nil {
+		if err := checkBcGenesis(client, networkDefinition); err != nil {
+			status.Error = err.Error()
+			status.IsSynced = false
+			status.IsWorking = false
+		}
+	}
 	return status
 
 }
 
+// Check that the client's genesis matches the custom network definition
+func checkBcGenesis(client beacon.Client, networkDefinition *config.NetworkDefinition) error {
+	expectedForkVersion, err := networkDefinition.GetGenesisForkVersion()
+	if err != nil {
+		return err
+	}
+	if expectedForkVersion == nil && networkDefinition.Genesis.Time == 0 {
+		return nil
+	}
+
+	eth2Config, err := client.GetEth2Config()
+	if err != nil {
+		return fmt.Errorf("Genesis check failed with [%s]", err.Error())
+	}
+	if expectedForkVersion != nil && !bytes.Equal(eth2Config.GenesisForkVersion, expectedForkVersion) {
+		return fmt.Errorf("Client is on the wrong chain: its genesis fork version is 0x%x but the custom network [%s] expects 0x%x", eth2Config.GenesisForkVersion, networkDefinition.Name, expectedForkVersion)
+	}
+	if networkDefinition.Genesis.Time != 0 && eth2Config.GenesisTime != networkDefinition.Genesis.Time {
+		return fmt.Errorf("Client is on the wrong chain: its genesis time is %d but the custom network [%s] expects %d", eth2Config.GenesisTime, networkDefinition.Name, networkDefinition.Genesis.Time)
+	}
+	return nil
+}
+
 // Attempts to run a function progressively through each client until one succeeds or they all fail.
 func (m *BeaconClientManager) runFunction0(function bcFunction0) error {
 
@@ -371,7 +480,7 @@ func (m *BeaconClientManager) runFunction0(function bcFunction0) error {
 		if err != nil {
 			if m.isDisconnected(err) {
 				// If it's disconnected, log it and try the fallback
-				m.logger.Printlnf("WARNING: Primary Beacon client disconnected (%s), using fallback...", err.Error())
+				m.logger.Warnf("WARNING: Primary Beacon client disconnected (%s), using fallback...", err.Error())
 				m.primaryReady = false
 				return m.runFunction0(function)
 			}
@@ -388,7 +497,7 @@ func (m *BeaconClientManager) runFunction0(function bcFunction0) error {
 		if err != nil {
 			if m.isDisconnected(err) {
 				// If it's disconnected, log it and try the fallback
-				m.logger.Printlnf("WARNING: Fallback Beacon client disconnected (%s)", err.Error())
+				m.logger.Warnf("WARNING: Fallback Beacon client disconnected (%s)", err.Error())
 				m.fallbackReady = false
 				return fmt.Errorf("all Beacon clients failed")
 			}
@@ -413,7 +522,7 @@ func (m *BeaconClientManager) runFunction1(function bcFunction1) (interface{}, e
 		if err != nil {
 			if m.isDisconnected(err) {
 				// If it's disconnected, log it and try the fallback
-				m.logger.Printlnf("WARNING: Primary Beacon client disconnected (%s), using fallback...", err.Error())
+				m.logger.Warnf("WARNING: Primary Beacon client disconnected (%s), using fallback...", err.Error())
 				m.primaryReady = false
 				return m.runFunction1(function)
 			}
@@ -430,7 +539,7 @@ func (m *BeaconClientManager) runFunction1(function bcFunction1) (interface{}, e
 		if err != nil {
 			if m.isDisconnected(err) {
 				// If it's disconnected, log it and try the fallback
-				m.logger.Printlnf("WARNING: Fallback Beacon client disconnected (%s)", err.Error())
+				m.logger.Warnf("WARNING: Fallback Beacon client disconnected (%s)", err.Error())
 				m.fallbackReady = false
 				return nil, fmt.Errorf("all Beacon clients failed")
 			}
@@ -455,7 +564,7 @@ func (m *BeaconClientManager) runFunction2(function bcFunction2) (interface{}, i
 		if err != nil {
 			if m.isDisconnected(err) {
 				// If it's disconnected, log it and try the fallback
-				m.logger.Printlnf("WARNING: Primary Beacon client disconnected (%s), using fallback...", err.Error())
+				m.logger.Warnf("WARNING: Primary Beacon client disconnected (%s), using fallback...", err.Error())
 				m.primaryReady = false
 				return m.runFunction2(function)
 			}
@@ -472,7 +581,7 @@ func (m *BeaconClientManager) runFunction2(function bcFunction2) (interface{}, i
 		if err != nil {
 			if m.isDisconnected(err) {
 				// If it's disconnected, log it and try the fallback
-				m.logger.Printlnf("WARNING: Fallback Beacon client disconnected (%s)", err.Error())
+				m.logger.Warnf("WARNING: Fallback Beacon client disconnected (%s)", err.Error())
 				m.fallbackReady = false
 				return nil, nil, fmt.Errorf("all Beacon clients failed")
 			}
@@ -63,6 +63,7 @@ type BeaconBlock struct {
 	Attestations         []AttestationInfo
 	FeeRecipient         common.Address
 	ExecutionBlockNumber uint64
+	ExecutionBlockHash   common.Hash
 }
 
 // Committees is an interface as an optimization- since committees responses
@@ -88,6 +89,14 @@ type Committees interface {
 	Release()
 }
 
+// An event emitted by the Beacon node's event stream
+type ChainEvent struct {
+	Topic           ChainEventTopic
+	Slot            uint64
+	Epoch           uint64
+	EpochTransition bool
+}
+
 type AttestationInfo struct {
 	AggregationBits bitfield.Bitlist
 	SlotIndex       uint64
@@ -112,6 +121,13 @@ const (
 	Unknown
 )
 
+type ChainEventTopic string
+
+const (
+	ChainEventTopic_Head                ChainEventTopic = "head"
+	ChainEventTopic_FinalizedCheckpoint ChainEventTopic = "finalized_checkpoint"
+)
+
 type ValidatorState string
 
 const (
@@ -141,10 +157,13 @@ type Client interface {
 	GetValidatorIndex(pubkey types.ValidatorPubkey) (string, error)
 	GetValidatorSyncDuties(indices []string, epoch uint64) (map[string]bool, error)
 	GetValidatorProposerDuties(indices []string, epoch uint64) (map[string]uint64, error)
+	GetValidatorProposerSlots(indices []string, epoch uint64) (map[uint64]string, error)
+	GetValidatorLiveness(indices []string, epoch uint64) (map[string]bool, error)
 	GetDomainData(domainType []byte, epoch uint64, useGenesisFork bool) ([]byte, error)
 	ExitValidator(validatorIndex string, epoch uint64, signature types.ValidatorSignature) error
 	Close() error
 	GetEth1DataForEth2Block(blockId string) (Eth1Data, bool, error)
 	GetCommitteesForEpoch(epoch *uint64) (Committees, error)
 	ChangeWithdrawalCredentials(validatorIndex string, fromBlsPubkey types.ValidatorPubkey, toExecutionAddress common.Address, signature types.ValidatorSignature) error
+	SubscribeChainEvents(topics []ChainEventTopic, events chan<- ChainEvent, stop <-chan struct{}) error
 }
@@ -0,0 +1,400 @@
+package client
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/klauspost/compress/zstd"
+	"github.com/rocket-pool/rocketpool-go/types"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Settings
+const (
+	cacheFileExtension string  = ".cache"
+	cachePruneTarget   float64 = 0.9
+)
+
+// A Beacon client that keeps the finalized data used by rewards tree generation (committees, attestations, blocks and
+// validator statuses at a given slot) in a disk cache, so retries and reruns don't have to request it from the client again.
+// Everything else is passed straight through to the underlying client.
+type CachingClient struct {
+	beacon.Client
+	endpoint string
+	path     string
+	maxSize  uint64
+	log      *log.ColorLogger
+
+	encoder *zstd.Encoder
+	decoder *zstd.Decoder
+
+	slotsPerEpoch  uint64
+	finalizedEpoch uint64
+	totalSize      uint64
+	lock           sync.Mutex
+}
+
+// The cached form of a committees response, which doesn't use the pooled validator slices
+type cachedCommittee struct {
+	Index      uint64   `json:"index"`
+	Slot       uint64   `json:"slot"`
+	Validators []string `json:"validators"`
+}
+type cachedCommittees []cachedCommittee
+
+func (c cachedCommittees) Count() int                  { return len(c) }
+func (c cachedCommittees) Index(idx int) uint64        { return c[idx].Index }
+func (c cachedCommittees) Slot(idx int) uint64         { return c[idx].Slot }
+func (c cachedCommittees) Validators(idx int) []string { return c[idx].Validators }
+func (c cachedCommittees) Release()                    {}
+
+type cachedAttestations struct {
+	Attestations []beacon.AttestationInfo `json:"attestations"`
+	Found        bool                     `json:"found"`
+}
+type cachedBeaconBlock struct {
+	Block beacon.BeaconBlock `json:"block"`
+	Found bool               `json:"found"`
+}
+type cachedValidatorStatus struct {
+	Pubkey types.ValidatorPubkey  `json:"pubkey"`
+	Status beacon.ValidatorStatus `json:"status"`
+}
+
+// Create a caching client around the provided client, keeping up to maxSize bytes of responses from the endpoint in the provided folder
+func NewCachingClient(bc beacon.Client, endpoint string, path string, maxSize uint64, logger *log.ColorLogger) (*CachingClient, error) {
+	if err := os.MkdirAll(path, 0755); err != nil {
+		return nil, fmt.Errorf("error creating Beacon cache folder %s: %w", path, err)
+	}
+	encoder, err := zstd.NewWriter(nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating zstd compressor: %w", err)
+	}
+	decoder, err := zstd.NewReader(nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating zstd decompressor: %w", err)
+	}
+	client := &CachingClient{
+		Client:   bc,
+		endpoint: endpoint,
+		path:     path,
+		maxSize:  maxSize,
+		log:      logger,
+		encoder:  encoder,
+		decoder:  decoder,
+	}
+
+	// Get the size of the existing cache
+	entries, err := client.getEntries()
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		client.totalSize += uint64(entry.Size())
+	}
+	return client, nil
+}
+
+// Get the committees for an epoch
+func (c *CachingClient) GetCommitteesForEpoch(epoch *uint64) (beacon.Committees, error) {
+	if epoch == nil || !c.isFinalized(*epoch) {
+		return c.Client.GetCommitteesForEpoch(epoch)
+	}
+	key := c.getKey("committees", *epoch, "")
+	var committees cachedCommittees
+	if c.load(key, &committees) {
+		return committees, nil
+	}
+
+	response, err := c.Client.GetCommitteesForEpoch(epoch)
+	if err != nil {
+		return nil, err
+	}
+	committees = make(cachedCommittees, response.Count())
+	for i := range committees {
+		committees[i] = cachedCommittee{
+			Index:      response.Index(i),
+			Slot:       response.Slot(i),
+			Validators: append([]string{}, response.Validators(i)...),
+		}
+	}
+	response.Release()
+	c.save(key, committees)
+	return committees, nil
+}
+
+// Get the attestations in a Beacon chain block
+func (c *CachingClient) GetAttestations(blockId string) ([]beacon.AttestationInfo, bool, error) {
+	slot, err := strconv.ParseUint(blockId, 10, 64)
+	if err != nil || !c.isFinalized(c.getEpoch(slot)) {
+		return c.Client.GetAttestations(blockId)
+	}
+	key := c.getKey("attestations", c.getEpoch(slot), blockId)
+	var cached cachedAttestations
+	if c.load(key, &cached) {
+		return cached.Attestations, cached.Found, nil
+	}
+
+	attestations, found, err := c.Client.GetAttestations(blockId)
+	if err != nil {
+		return nil, false, err
+	}
+	c.save(key, cachedAttestations{Attestations: attestations, Found: found})
+	return attestations, found, nil
+}
+
+// Get a Beacon chain block
+func (c *CachingClient) GetBeaconBlock(blockId string) (beacon.BeaconBlock, bool, error) {
+	slot, err := strconv.ParseUint(blockId, 10, 64)
+	if err != nil || !c.isFinalized(c.getEpoch(slot)) {
+		return c.Client.GetBeaconBlock(blockId)
+	}
+	key := c.getKey("block", c.getEpoch(slot), blockId)
+	var cached cachedBeaconBlock
+	if c.load(key, &cached) {
+		return cached.Block, cached.Found, nil
+	}
+
+	block, found, err := c.Client.GetBeaconBlock(blockId)
+	if err != nil {
+		return beacon.BeaconBlock{}, false, err
+	}
+	c.save(key, cachedBeaconBlock{Block: block, Found: found})
+	return block, found, nil
+}
+
+// Get the statuses of multiple validators at a given slot or epoch
+func (c *CachingClient) GetValidatorStatuses(pubkeys []types.ValidatorPubkey, opts *beacon.ValidatorStatusOptions) (map[types.ValidatorPubkey]beacon.ValidatorStatus, error) {
+	if opts == nil || (opts.Slot == nil && opts.Epoch == nil) {
+		return c.Client.GetValidatorStatuses(pubkeys, opts)
+	}
+	var epoch uint64
+	var query string
+	if opts.Slot != nil {
+		epoch = c.getEpoch(*opts.Slot)
+		query = fmt.Sprintf("slot=%d", *opts.Slot)
+	} else {
+		epoch = *opts.Epoch
+		query = fmt.Sprintf("epoch=%d", *opts.Epoch)
+	}
+	if !c.isFinalized(epoch) {
+		return c.Client.GetValidatorStatuses(pubkeys, opts)
+	}
+
+	// The query includes the hash of the requested pubkeys
+	hasher := sha256.New()
+	for _, pubkey := range pubkeys {
+		hasher.Write(pubkey.Bytes())
+	}
+	key := c.getKey("validators", epoch, query+"&pubkeys="+hex.EncodeToString(hasher.Sum(nil)))
+	var cached []cachedValidatorStatus
+	if c.load(key, &cached) {
+		statuses := make(map[types.ValidatorPubkey]beacon.ValidatorStatus, len(cached))
+		for _, status := range cached {
+			statuses[status.Pubkey] = status.Status
+		}
+		return statuses, nil
+	}
+
+	statuses, err := c.Client.GetValidatorStatuses(pubkeys, opts)
+	if err != nil {
+		return nil, err
+	}
+	cached = make([]cachedValidatorStatus, 0, len(statuses))
+	for pubkey, status := range statuses {
+		cached = append(cached, cachedValidatorStatus{Pubkey: pubkey, Status: status})
+	}
+	c.save(key, cached)
+	return statuses, nil
+}
+
+// Get the epoch of a slot
+func (c *CachingClient) getEpoch(slot uint64) uint64 {
+	c.lock.Lock()
+	slotsPerEpoch := c.slotsPerEpoch
+	c.lock.Unlock()
+	if slotsPerEpoch == 0 {
+		config, err := c.Client.GetEth2Config()
+		if err != nil || config.SlotsPerEpoch == 0 {
+			// Without the config no epoch can be finalized, so nothing will be cached
+			return ^uint64(0)
+		}
+		slotsPerEpoch = config.SlotsPerEpoch
+		c.lock.Lock()
+		c.slotsPerEpoch = slotsPerEpoch
+		c.lock.Unlock()
+	}
+	return slot / slotsPerEpoch
+}
+
+// Check if an epoch has been finalized; only finalized data can be cached
+func (c *CachingClient) isFinalized(epoch uint64) bool {
+	c.lock.Lock()
+	finalizedEpoch := c.finalizedEpoch
+	c.lock.Unlock()
+	if epoch <= finalizedEpoch {
+		return true
+	}
+	head, err := c.Client.GetBeaconHead()
+	if err != nil {
+		return false
+	}
+	c.lock.Lock()
+	c.finalizedEpoch = head.FinalizedEpoch
+	c.lock.Unlock()
+	return epoch <= head.FinalizedEpoch
+}
+
+// Get the cache key for a query
+func (c *CachingClient) getKey(query string, epoch uint64, params string) string {
+	hash := sha256.Sum256([]byte(fmt.Sprintf("%s|%d|%s|%s", c.endpoint, epoch, query, params)))
+	return fmt.Sprintf("%s-%d-%s", query, epoch, hex.EncodeToString(hash[:8]))
+}
+
+// Load a cached response; entries that fail their integrity check are removed
+func (c *CachingClient) load(key string, value interface{}) bool {
+	path := filepath.Join(c.path, key+cacheFileExtension)
+	compressed, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	data, err := c.decoder.DecodeAll(compressed, nil)
+	if err == nil && len(data) < sha256.Size {
+		err = fmt.Errorf("entry is too short")
+	}
+	if err == nil {
+		checksum := sha256.Sum256(data[sha256.Size:])
+		if !bytes.Equal(checksum[:], data[:sha256.Size]) {
+			err = fmt.Errorf("checksum mismatch")
+		}
+	}
+	if err == nil {
+		err = json.Unmarshal(data[sha256.Size:], value)
+	}
+	if err != nil {
+		c.log.Warnf("WARNING: Beacon cache entry %s is corrupt (%s), removing it.", key, err.Error())
+		c.remove(path)
+		return false
+	}
+
+	// Mark the entry as recently used so it's pruned last
+	now := time.Now()
+	_ = os.Chtimes(path, now, now)
+	return true
+}
+
+// Save a response to the cache, pruning the oldest entries if the cache is over its size limit.
+// Failures only mean the response isn't cached, so they're logged instead of returned.
+func (c *CachingClient) save(key string, value interface{}) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		c.log.Warnf("WARNING: couldn't serialize Beacon cache entry %s: %s", key, err.Error())
+		return
+	}
+	checksum := sha256.Sum256(data)
+	compressed := c.encoder.EncodeAll(append(checksum[:], data...), nil)
+	if uint64(len(compressed)) > c.maxSize {
+		return
+	}
+
+	// Write to a temporary file first so a partial write never looks like a complete entry
+	path := filepath.Join(c.path, key+cacheFileExtension)
+	tempPath := path + ".tmp"
+	if err := os.WriteFile(tempPath, compressed, 0644); err != nil {
+		c.log.Warnf("WARNING: couldn't save Beacon cache entry %s: %s", key, err.Error())
+		return
+	}
+	if err := os.Rename(tempPath, path); err != nil {
+		c.log.Warnf("WARNING: couldn't save Beacon cache entry %s: %s", key, err.Error())
+		return
+	}
+
+	c.lock.Lock()
+	c.totalSize += uint64(len(compressed))
+	isFull := c.totalSize > c.maxSize
+	c.lock.Unlock()
+	if isFull {
+		if err := c.prune(); err != nil {
+			c.log.Warnf("WARNING: couldn't prune the Beacon cache: %s", err.Error())
+		}
+	}
+}
+
+// Remove the least recently used entries until the cache is back under its target size
+func (c *CachingClient) prune() error {
+	entries, err := c.getEntries()
+	if err != nil {
+		return err
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].ModTime().Before(entries[j].ModTime())
+	})
+	totalSize := uint64(0)
+	for _, entry := range entries {
+		totalSize += uint64(entry.Size())
+	}
+
+	target := uint64(float64(c.maxSize) * cachePruneTarget)
+	for _, entry := range entries {
+		if totalSize <= target {
+			break
+		}
+		if err := os.Remove(filepath.Join(c.path, entry.Name())); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("error removing Beacon cache entry %s: %w", entry.Name(), err)
+		}
+		totalSize -= uint64(entry.Size())
+	}
+
+	c.lock.Lock()
+	c.totalSize = totalSize
+	c.lock.Unlock()
+	return nil
+}
+
+// Remove a corrupt entry
+func (c *CachingClient) remove(path string) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return
+	}
+	if err := os.Remove(path); err != nil {
+		return
+	}
+	c.lock.Lock()
+	if c.totalSize >= uint64(info.Size()) {
+		c.totalSize -= uint64(info.Size())
+	}
+	c.lock.Unlock()
+}
+
+// Get the info of every entry in the cache
+func (c *CachingClient) getEntries() ([]os.FileInfo, error) {
+	dirEntries, err := os.ReadDir(c.path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading Beacon cache folder %s: %w", c.path, err)
+	}
+	entries := make([]os.FileInfo, 0, len(dirEntries))
+	for _, dirEntry := range dirEntries {
+		if dirEntry.IsDir() || !strings.HasSuffix(dirEntry.Name(), cacheFileExtension) {
+			continue
+		}
+		info, err := dirEntry.Info()
+		if err != nil {
+			continue
+		}
+		entries = append(entries, info)
+	}
+	return entries, nil
+}
@@ -1,7 +1,9 @@
 package client
 
 import (
+	"bufio"
 	"bytes"
+	"context"
 	"encoding/hex"
 	"fmt"
 	"io"
@@ -41,7 +43,9 @@ const (
 	RequestBeaconBlockPath                 = "/eth/v2/beacon/blocks/%s"
 	RequestValidatorSyncDuties             = "/eth/v1/validator/duties/sync/%s"
 	RequestValidatorProposerDuties         = "/eth/v1/validator/duties/proposer/%s"
+	RequestValidatorLiveness               = "/eth/v1/validator/liveness/%s"
 	RequestWithdrawalCredentialsChangePath = "/eth/v1/beacon/pool/bls_to_execution_changes"
+	RequestEventsPath                      = "/eth/v1/events?topics=%s"
 
 	MaxRequestValidatorsCount     = 600
 	threadLimit               int = 12
@@ -50,12 +54,24 @@ const (
 // Beacon client using the standard Beacon HTTP REST API (https://ethereum.github.io/beacon-APIs/)
 type StandardHttpClient struct {
 	providerAddress string
+	httpClient      *http.Client
+	eventClient     *http.Client
 }
 
 // Create a new client instance
 func NewStandardHttpClient(providerAddress string) *StandardHttpClient {
+	return NewStandardHttpClientWithHttpClient(providerAddress, http.DefaultClient)
+}
+
+// Create a new client instance that sends its requests with the provided HTTP client
+func NewStandardHttpClientWithHttpClient(providerAddress string, httpClient *http.Client) *StandardHttpClient {
 	return &StandardHttpClient{
 		providerAddress: providerAddress,
+		httpClient:      httpClient,
+		// Event streams stay open indefinitely, so they can't share a client that has a timeout
+		eventClient: &http.Client{
+			Transport: httpClient.Transport,
+		},
 	}
 }
 
@@ -333,6 +349,36 @@ func (c *StandardHttpClient) GetValidatorSyncDuties(indices []string, epoch uint
 	return validatorMap, nil
 }
 
+// Get whether or not validators were seen performing their duties (such as attesting) during an epoch
+func (c *StandardHttpClient) GetValidatorLiveness(indices []string, epoch uint64) (map[string]bool, error) {
+
+	// Perform the post request
+	responseBody, status, err := c.postRequest(fmt.Sprintf(RequestValidatorLiveness, strconv.FormatUint(epoch, 10)), indices)
+
+	if err != nil {
+		return nil, fmt.Errorf("Could not get validator liveness: %w", err)
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("Could not get validator liveness: HTTP status %d; response body: '%s'", status, string(responseBody))
+	}
+
+	var response LivenessResponse
+	if err := json.Unmarshal(responseBody, &response); err != nil {
+		return nil, fmt.Errorf("Could not decode validator liveness data: %w", err)
+	}
+
+	// Map the results
+	validatorMap := make(map[string]bool)
+	for _, index := range indices {
+		validatorMap[index] = false
+	}
+	for _, liveness := range response.Data {
+		validatorMap[liveness.Index] = liveness.IsLive
+	}
+
+	return validatorMap, nil
+}
+
 // Sums proposer duties per validators for a given epoch
 func (c *StandardHttpClient) GetValidatorProposerDuties(indices []string, epoch uint64) (map[string]uint64, error) {
 
@@ -367,6 +413,39 @@ func (c *StandardHttpClient) GetValidatorProposerDuties(indices []string, epoch
 	return proposerMap, nil
 }
 
+// Get the slots the provided validators are scheduled to propose in during an epoch, mapped to the proposer's index.
+// Beacon nodes may only have the duties for the current and next epochs.
+func (c *StandardHttpClient) GetValidatorProposerSlots(indices []string, epoch uint64) (map[uint64]string, error) {
+
+	// Perform the request
+	responseBody, status, err := c.getRequest(fmt.Sprintf(RequestValidatorProposerDuties, strconv.FormatUint(epoch, 10)))
+	if err != nil {
+		return nil, fmt.Errorf("Could not get validator proposer duties: %w", err)
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("Could not get validator proposer duties: HTTP status %d; response body: '%s'", status, string(responseBody))
+	}
+
+	var response ProposerDutiesResponse
+	if err := json.Unmarshal(responseBody, &response); err != nil {
+		return nil, fmt.Errorf("Could not decode validator proposer duties data: %w", err)
+	}
+
+	// Map the results
+	indexMap := make(map[string]bool, len(indices))
+	for _, index := range indices {
+		indexMap[index] = true
+	}
+	slots := make(map[uint64]string)
+	for _, duty := range response.Data {
+		if indexMap[duty.ValidatorIndex] {
+			slots[uint64(duty.Slot)] = duty.ValidatorIndex
+		}
+	}
+
+	return slots, nil
+}
+
 // Get a validator's index
 func (c *StandardHttpClient) GetValidatorIndex(pubkey types.ValidatorPubkey) (string, error) {
 
@@ -507,6 +586,7 @@ func (c *StandardHttpClient) GetBeaconBlock(blockId string) (beacon.BeaconBlock,
 		beaconBlock.HasExecutionPayload = true
 		beaconBlock.FeeRecipient = common.BytesToAddress(block.Data.Message.Body.ExecutionPayload.FeeRecipient)
 		beaconBlock.ExecutionBlockNumber = uint64(block.Data.Message.Body.ExecutionPayload.BlockNumber)
+		beaconBlock.ExecutionBlockHash = common.BytesToHash(block.Data.Message.Body.ExecutionPayload.BlockHash)
 	}
 
 	// Add attestation info
@@ -548,7 +628,112 @@ func (c *StandardHttpClient) ChangeWithdrawalCredentials(validatorIndex string,
 	})
 }
 
-// Get sync status
+// Subscribe to the Beacon node's event stream, sending each received event to the provided channel.
+// Only the head and finalized_checkpoint topics are supported.
+// Blocks until the stream is closed by the node, an error occurs, or the stop channel is closed.
+func (c *StandardHttpClient) SubscribeChainEvents(topics []beacon.ChainEventTopic, events chan<- beacon.ChainEvent, stop <-chan struct{}) error {
+
+	// Make sure every topic can be decoded
+	if len(topics) == 0 {
+		return fmt.Errorf("Could not subscribe to Beacon events: no topics were provided")
+	}
+	for _, topic := range topics {
+		switch topic {
+		case beacon.ChainEventTopic_Head, beacon.ChainEventTopic_FinalizedCheckpoint:
+		default:
+			return fmt.Errorf("Could not subscribe to Beacon events: unsupported topic [%s]", topic)
+		}
+	}
+
+	// Cancel the request when the caller stops the subscription
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-stop:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	// Open the stream
+	topicStrings := make([]string, len(topics))
+	for i, topic := range topics {
+		topicStrings[i] = string(topic)
+	}
+	requestPath := fmt.Sprintf(RequestEventsPath, strings.Join(topicStrings, ","))
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf(RequestUrlFormat, c.providerAddress, requestPath), nil)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Accept", "text/event-stream")
+	response, err := c.eventClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+	if response.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(response.Body)
+		return fmt.Errorf("Could not subscribe to Beacon events: HTTP status %d; response body: '%s'", response.StatusCode, string(body))
+	}
+
+	// Read events until the stream ends
+	scanner := bufio.NewScanner(response.Body)
+	var topic beacon.ChainEventTopic
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "event:") {
+			topic = beacon.ChainEventTopic(strings.TrimSpace(strings.TrimPrefix(line, "event:")))
+			continue
+		}
+		if !strings.HasPrefix(line, "data:") {
+			continue
+		}
+
+		data := []byte(strings.TrimSpace(strings.TrimPrefix(line, "data:")))
+		event := beacon.ChainEvent{
+			Topic: topic,
+		}
+		switch topic {
+		case beacon.ChainEventTopic_Head:
+			var head HeadEventData
+			if err := json.Unmarshal(data, &head); err != nil {
+				return fmt.Errorf("Could not decode head event: %w", err)
+			}
+			event.Slot = uint64(head.Slot)
+			event.EpochTransition = head.EpochTransition
+		case beacon.ChainEventTopic_FinalizedCheckpoint:
+			var checkpoint FinalizedCheckpointEventData
+			if err := json.Unmarshal(data, &checkpoint); err != nil {
+				return fmt.Errorf("Could not decode finalized checkpoint event: %w", err)
+			}
+			event.Epoch = uint64(checkpoint.Epoch)
+		default:
+			continue
+		}
+
+		select {
+		case events <- event:
+		case <-stop:
+			return nil
+		}
+	}
+
+	// Ignore the error caused by stopping the subscription
+	select {
+	case <-stop:
+		return nil
+	default:
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return fmt.Errorf("Beacon event stream was closed by the node")
+
+}
+
 func (c *StandardHttpClient) getSyncStatus() (SyncStatusResponse, error) {
 	responseBody, status, err := c.getRequest(RequestSyncStatusPath)
 	if err != nil {
@@ -868,7 +1053,7 @@ func (c *StandardHttpClient) postWithdrawalCredentialsChange(request BLSToExecut
 func (c *StandardHttpClient) getRequestReader(requestPath string) (io.ReadCloser, int, error) {
 
 	// Send request
-	response, err := http.Get(fmt.Sprintf(RequestUrlFormat, c.providerAddress, requestPath))
+	response, err := c.httpClient.Get(fmt.Sprintf(RequestUrlFormat, c.providerAddress, requestPath))
 	if err != nil {
 		return nil, 0, err
 	}
@@ -909,7 +1094,7 @@ func (c *StandardHttpClient) postRequest(requestPath string, requestBody interfa
 	requestBodyReader := bytes.NewReader(requestBodyBytes)
 
 	// Send request
-	response, err := http.Post(fmt.Sprintf(RequestUrlFormat, c.providerAddress, requestPath), RequestContentType, requestBodyReader)
+	response, err := c.httpClient.Post(fmt.Sprintf(RequestUrlFormat, c.providerAddress, requestPath), RequestContentType, requestBodyReader)
 	if err != nil {
 		return []byte{}, 0, err
 	}
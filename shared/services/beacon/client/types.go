@@ -95,6 +95,7 @@ type BeaconBlockResponse struct {
 				ExecutionPayload *struct {
 					FeeRecipient byteArray `json:"fee_recipient"`
 					BlockNumber  uinteger  `json:"block_number"`
+					BlockHash    byteArray `json:"block_hash"`
 				} `json:"execution_payload"`
 			} `json:"body"`
 		} `json:"message"`
@@ -126,11 +127,19 @@ type SyncDuty struct {
 	ValidatorIndex       string     `json:"validator_index"`
 	SyncCommitteeIndices []uinteger `json:"validator_sync_committee_indices"`
 }
+type LivenessResponse struct {
+	Data []ValidatorLiveness `json:"data"`
+}
+type ValidatorLiveness struct {
+	Index  string `json:"index"`
+	IsLive bool   `json:"is_live"`
+}
 type ProposerDutiesResponse struct {
 	Data []ProposerDuty `json:"data"`
 }
 type ProposerDuty struct {
-	ValidatorIndex string `json:"validator_index"`
+	ValidatorIndex string   `json:"validator_index"`
+	Slot           uinteger `json:"slot"`
 }
 
 type CommitteesResponse struct {
@@ -144,6 +153,13 @@ type Attestation struct {
 		Index uinteger `json:"index"`
 	} `json:"data"`
 }
+type HeadEventData struct {
+	Slot            uinteger `json:"slot"`
+	EpochTransition bool     `json:"epoch_transition"`
+}
+type FinalizedCheckpointEventData struct {
+	Epoch uinteger `json:"epoch"`
+}
 
 // Unsigned integer type
 type uinteger uint64
@@ -0,0 +1,267 @@
+package cmdqueue
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/services/progress"
+)
+
+// Settings
+const (
+	// How long a command waits for its turn before giving up
+	DefaultTimeout = 5 * time.Minute
+
+	lockFilename   string = "wallet.lock"
+	closedFilename string = "closed"
+	ticketSuffix   string = ".ticket"
+	tempSuffix     string = ".tmp"
+	pollInterval          = 250 * time.Millisecond
+	queueFileMode         = 0644
+)
+
+// The node daemon is shutting down and has closed the queue
+var ErrClosed = errors.New("the node daemon is shutting down, so commands that use the node wallet can't run right now; please try again once it's restarted")
+
+// Serializes the commands that use the node wallet to change something, such as sending transactions.
+// The commands run in separate processes (and in separate containers for the API server), so the queue lives in a folder they share:
+// the command holding the wallet has a lock on its lock file, and the ones waiting for it each hold a lock on a ticket named after the time they arrived.
+// Locks are released by the OS when a process dies, so a command that crashed never blocks the others.
+type Lock struct {
+	file *os.File
+}
+
+// The files this process holds locks on to keep queues closed; they're kept open until it exits
+var closedFiles []*os.File
+
+// Wait for this command's turn with the node wallet, in the order the commands arrived, and hold it until Release is called.
+// The reporter shows how many commands are ahead while it waits.
+func Acquire(dir string, timeout time.Duration, reporter *progress.Reporter) (*Lock, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("error creating command queue folder [%s]: %w", dir, err)
+	}
+	lockFile, err := os.OpenFile(filepath.Join(dir, lockFilename), os.O_CREATE|os.O_RDWR, queueFileMode)
+	if err != nil {
+		return nil, fmt.Errorf("error opening command queue lock: %w", err)
+	}
+
+	if isClosed(dir) {
+		lockFile.Close()
+		return nil, ErrClosed
+	}
+
+	// Skip the queue if nothing else is using the wallet or waiting for it
+	waiting, err := getPosition(dir, "")
+	if err != nil {
+		lockFile.Close()
+		return nil, err
+	}
+	if waiting == 0 {
+		err = syscall.Flock(int(lockFile.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+		if err == nil {
+			return &Lock{file: lockFile}, nil
+		}
+		if !errors.Is(err, syscall.EWOULDBLOCK) {
+			lockFile.Close()
+			return nil, fmt.Errorf("error locking command queue: %w", err)
+		}
+	}
+
+	ticket, ticketName, err := takeTicket(dir)
+	if err != nil {
+		lockFile.Close()
+		return nil, err
+	}
+	defer func() {
+		os.Remove(filepath.Join(dir, ticketName))
+		ticket.Close()
+	}()
+
+	deadline := time.Now().Add(timeout)
+	lastAhead := -1
+	for {
+		if isClosed(dir) {
+			lockFile.Close()
+			if lastAhead >= 0 {
+				reporter.Finish(ErrClosed)
+			}
+			return nil, ErrClosed
+		}
+
+		// Only the first command in line tries to take the wallet, so later ones can't cut in
+		position, err := getPosition(dir, ticketName)
+		if err != nil {
+			lockFile.Close()
+			return nil, err
+		}
+		if position == 0 {
+			err = syscall.Flock(int(lockFile.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+			if err == nil {
+				if lastAhead >= 0 {
+					reporter.Finish(nil)
+				}
+				return &Lock{file: lockFile}, nil
+			}
+			if !errors.Is(err, syscall.EWOULDBLOCK) {
+				lockFile.Close()
+				return nil, fmt.Errorf("error locking command queue: %w", err)
+			}
+		}
+
+		// The command holding the wallet is ahead of everything in line
+		ahead := position + 1
+		if time.Now().After(deadline) {
+			lockFile.Close()
+			err := fmt.Errorf("timed out after %s waiting for %d other command(s) using the node wallet to finish; please try again once they're done", timeout, ahead)
+			reporter.Finish(err)
+			return nil, err
+		}
+		if ahead != lastAhead {
+			reporter.SetPhase(fmt.Sprintf("Queued behind %d other command(s) using the node wallet", ahead), 0)
+			lastAhead = ahead
+		}
+		time.Sleep(pollInterval)
+	}
+}
+
+// Close the queue for good so no more commands can take the node wallet, and wait up to the timeout for the command using it to finish.
+// The commands waiting in line give up with ErrClosed. The queue stays closed until this process exits.
+func Close(dir string, timeout time.Duration) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("error creating command queue folder [%s]: %w", dir, err)
+	}
+
+	// Commands treat the marker as stale once nothing holds a lock on it, so a daemon that exited never leaves the queue closed
+	marker, err := os.OpenFile(filepath.Join(dir, closedFilename), os.O_CREATE|os.O_RDWR, queueFileMode)
+	if err != nil {
+		return fmt.Errorf("error creating command queue marker: %w", err)
+	}
+	if err := syscall.Flock(int(marker.Fd()), syscall.LOCK_EX); err != nil {
+		marker.Close()
+		return fmt.Errorf("error locking command queue marker: %w", err)
+	}
+	closedFiles = append(closedFiles, marker)
+
+	lockFile, err := os.OpenFile(filepath.Join(dir, lockFilename), os.O_CREATE|os.O_RDWR, queueFileMode)
+	if err != nil {
+		return fmt.Errorf("error opening command queue lock: %w", err)
+	}
+	deadline := time.Now().Add(timeout)
+	for {
+		err = syscall.Flock(int(lockFile.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+		if err == nil {
+			closedFiles = append(closedFiles, lockFile)
+			return nil
+		}
+		if !errors.Is(err, syscall.EWOULDBLOCK) {
+			lockFile.Close()
+			return fmt.Errorf("error locking command queue: %w", err)
+		}
+		if time.Now().After(deadline) {
+			lockFile.Close()
+			return fmt.Errorf("timed out after %s waiting for the command using the node wallet to finish", timeout)
+		}
+		time.Sleep(pollInterval)
+	}
+}
+
+// Release the node wallet for the next command
+func (l *Lock) Release() error {
+	if l == nil || l.file == nil {
+		return nil
+	}
+	err := syscall.Flock(int(l.file.Fd()), syscall.LOCK_UN)
+	l.file.Close()
+	l.file = nil
+	if err != nil {
+		return fmt.Errorf("error unlocking command queue: %w", err)
+	}
+	return nil
+}
+
+// Get in line, returning the locked ticket and its name.
+// The ticket is locked before it's given its real name, so the others never mistake it for one left behind by a crashed command.
+func takeTicket(dir string) (*os.File, string, error) {
+	name := fmt.Sprintf("%020d-%d%s", time.Now().UnixNano(), os.Getpid(), ticketSuffix)
+	tempPath := filepath.Join(dir, name+tempSuffix)
+	ticket, err := os.OpenFile(tempPath, os.O_CREATE|os.O_EXCL|os.O_RDWR, queueFileMode)
+	if err != nil {
+		return nil, "", fmt.Errorf("error creating command queue ticket: %w", err)
+	}
+	if err := syscall.Flock(int(ticket.Fd()), syscall.LOCK_EX); err != nil {
+		ticket.Close()
+		os.Remove(tempPath)
+		return nil, "", fmt.Errorf("error locking command queue ticket: %w", err)
+	}
+	if err := os.Rename(tempPath, filepath.Join(dir, name)); err != nil {
+		ticket.Close()
+		os.Remove(tempPath)
+		return nil, "", fmt.Errorf("error creating command queue ticket: %w", err)
+	}
+	return ticket, name, nil
+}
+
+// Get how many live tickets are ahead of the provided one (or all of them for an empty name), removing any that were left behind by commands that crashed
+func getPosition(dir string, ticketName string) (int, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, fmt.Errorf("error reading command queue folder [%s]: %w", dir, err)
+	}
+	names := []string{}
+	for _, entry := range entries {
+		if strings.HasSuffix(entry.Name(), ticketSuffix) {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+
+	position := 0
+	for _, name := range names {
+		if ticketName != "" && name >= ticketName {
+			break
+		}
+		if isAbandoned(filepath.Join(dir, name)) {
+			os.Remove(filepath.Join(dir, name))
+			continue
+		}
+		position++
+	}
+	return position, nil
+}
+
+// Check if the node daemon has closed the queue, which is the case while it holds a lock on the marker
+func isClosed(dir string) bool {
+	file, err := os.Open(filepath.Join(dir, closedFilename))
+	if err != nil {
+		return false
+	}
+	defer file.Close()
+	err = syscall.Flock(int(file.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+	if err != nil {
+		return true
+	}
+	syscall.Flock(int(file.Fd()), syscall.LOCK_UN)
+	return false
+}
+
+// Check if a ticket's command is gone, which is the case when nothing holds a lock on it anymore
+func isAbandoned(path string) bool {
+	file, err := os.Open(path)
+	if err != nil {
+		// It was removed by its command in the meantime
+		return false
+	}
+	defer file.Close()
+	err = syscall.Flock(int(file.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+	if err != nil {
+		return false
+	}
+	syscall.Flock(int(file.Fd()), syscall.LOCK_UN)
+	return true
+}
@@ -0,0 +1,201 @@
+package cmdqueue
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/services/progress"
+)
+
+// How long the tests wait for something that should happen right away
+const testWait = 5 * time.Second
+
+// Create a reporter that discards its output
+func newTestReporter() *progress.Reporter {
+	return progress.NewWriterReporter(io.Discard, "Waiting for the node wallet")
+}
+
+// Take the node wallet, failing the test if it can't
+func acquire(t *testing.T, dir string) *Lock {
+	t.Helper()
+	lock, err := Acquire(dir, testWait, newTestReporter())
+	if err != nil {
+		t.Fatalf("error acquiring the queue: %s", err.Error())
+	}
+	return lock
+}
+
+// Release the node wallet, failing the test if it can't
+func release(t *testing.T, lock *Lock) {
+	t.Helper()
+	if err := lock.Release(); err != nil {
+		t.Fatalf("error releasing the queue: %s", err.Error())
+	}
+}
+
+// Take the node wallet in the background, sending the lock (or the error) once it's this command's turn
+func acquireAsync(dir string, timeout time.Duration) (chan *Lock, chan error) {
+	locks := make(chan *Lock, 1)
+	errs := make(chan error, 1)
+	go func() {
+		lock, err := Acquire(dir, timeout, newTestReporter())
+		if err != nil {
+			errs <- err
+			return
+		}
+		locks <- lock
+	}()
+	return locks, errs
+}
+
+// Wait until the provided number of commands are waiting in line
+func waitForTickets(t *testing.T, dir string, count int) {
+	t.Helper()
+	deadline := time.Now().Add(testWait)
+	for time.Now().Before(deadline) {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tickets := 0
+		for _, entry := range entries {
+			if strings.HasSuffix(entry.Name(), ticketSuffix) {
+				tickets++
+			}
+		}
+		if tickets == count {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %d command(s) to get in line", count)
+}
+
+// Check that a command hasn't been given the node wallet yet
+func expectWaiting(t *testing.T, locks chan *Lock, errs chan error) {
+	t.Helper()
+	select {
+	case <-locks:
+		t.Fatal("expected the command to still be waiting for its turn")
+	case err := <-errs:
+		t.Fatalf("expected the command to still be waiting for its turn, got error: %s", err.Error())
+	case <-time.After(3 * pollInterval):
+	}
+}
+
+// Wait for a command to be given the node wallet
+func expectAcquired(t *testing.T, locks chan *Lock, errs chan error) *Lock {
+	t.Helper()
+	select {
+	case lock := <-locks:
+		return lock
+	case err := <-errs:
+		t.Fatalf("error acquiring the queue: %s", err.Error())
+	case <-time.After(testWait):
+		t.Fatal("timed out waiting for the command's turn")
+	}
+	return nil
+}
+
+func TestAcquireAndRelease(t *testing.T) {
+	dir := t.TempDir()
+	lock := acquire(t, dir)
+	release(t, lock)
+
+	// Releasing twice is harmless, and the wallet can be taken again
+	release(t, lock)
+	release(t, acquire(t, dir))
+}
+
+func TestCommandsTakeTurnsInOrder(t *testing.T) {
+	dir := t.TempDir()
+	holder := acquire(t, dir)
+
+	firstLocks, firstErrs := acquireAsync(dir, testWait)
+	waitForTickets(t, dir, 1)
+	secondLocks, secondErrs := acquireAsync(dir, testWait)
+	waitForTickets(t, dir, 2)
+	expectWaiting(t, firstLocks, firstErrs)
+
+	release(t, holder)
+	first := expectAcquired(t, firstLocks, firstErrs)
+	expectWaiting(t, secondLocks, secondErrs)
+
+	release(t, first)
+	release(t, expectAcquired(t, secondLocks, secondErrs))
+}
+
+func TestAcquireTimesOut(t *testing.T) {
+	dir := t.TempDir()
+	holder := acquire(t, dir)
+	defer release(t, holder)
+
+	_, err := Acquire(dir, 2*pollInterval, newTestReporter())
+	if err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Fatalf("expected a timeout, got %v", err)
+	}
+
+	// The command that gave up doesn't leave its ticket behind
+	waitForTickets(t, dir, 0)
+}
+
+func TestAbandonedTicketsAreSkipped(t *testing.T) {
+	dir := t.TempDir()
+
+	// A ticket that nothing holds a lock on was left behind by a command that crashed
+	abandoned := filepath.Join(dir, "00000000000000000001-1"+ticketSuffix)
+	if err := os.WriteFile(abandoned, []byte{}, queueFileMode); err != nil {
+		t.Fatal(err)
+	}
+
+	locks, errs := acquireAsync(dir, testWait)
+	release(t, expectAcquired(t, locks, errs))
+	if _, err := os.Stat(abandoned); !errors.Is(err, os.ErrNotExist) {
+		t.Fatal("expected the abandoned ticket to be removed")
+	}
+}
+
+func TestCloseTurnsAwayNewCommands(t *testing.T) {
+	dir := t.TempDir()
+	if err := Close(dir, testWait); err != nil {
+		t.Fatalf("error closing the queue: %s", err.Error())
+	}
+	if _, err := Acquire(dir, testWait, newTestReporter()); !errors.Is(err, ErrClosed) {
+		t.Fatalf("expected ErrClosed once the queue is closed, got %v", err)
+	}
+}
+
+func TestCloseWaitsForTheHolderAndTurnsAwayTheRest(t *testing.T) {
+	dir := t.TempDir()
+	holder := acquire(t, dir)
+	waitingLocks, waitingErrs := acquireAsync(dir, testWait)
+	waitForTickets(t, dir, 1)
+
+	// Closing can't finish while the wallet is held
+	if err := Close(dir, 2*pollInterval); err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Fatalf("expected closing to time out while the wallet is held, got %v", err)
+	}
+
+	// The waiting command gives up once the queue is closed
+	select {
+	case <-waitingLocks:
+		t.Fatal("expected the waiting command to be turned away")
+	case err := <-waitingErrs:
+		if !errors.Is(err, ErrClosed) {
+			t.Fatalf("expected ErrClosed, got %s", err.Error())
+		}
+	case <-time.After(testWait):
+		t.Fatal("timed out waiting for the waiting command to be turned away")
+	}
+
+	// The queue stays closed after the holder is done, even though closing timed out
+	release(t, holder)
+	if _, err := Acquire(dir, testWait, newTestReporter()); !errors.Is(err, ErrClosed) {
+		t.Fatalf("expected ErrClosed once the queue is closed, got %v", err)
+	}
+}
@@ -0,0 +1,88 @@
+package cmdqueue
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/services/progress"
+)
+
+// Settings
+const (
+	// The longest a transaction can hold the queue between getting its nonce and being broadcast, in case it's never sent because signing it failed
+	MaxSendWindow = time.Minute
+
+	// The folder in the queue for the commands that change the wallet's files, which take turns separately from transactions
+	WalletFilesFolder string = "wallet-files"
+)
+
+// The reporter that shows the commands waiting for their turn to send a transaction, if this process has one
+var (
+	waitReporter     *progress.Reporter
+	waitReporterLock sync.Mutex
+)
+
+// The queue a transaction holds from the moment its nonce is assigned until it's been broadcast, so concurrent senders can't get the same nonce
+type sendWindow struct {
+	lock  sync.Mutex
+	held  *Lock
+	timer *time.Timer
+}
+
+type sendWindowKey struct{}
+
+// Show how many commands are ahead when this process waits for its turn to send a transaction
+func SetWaitReporter(reporter *progress.Reporter) {
+	waitReporterLock.Lock()
+	defer waitReporterLock.Unlock()
+	waitReporter = reporter
+}
+
+// Mark a context as sending transactions from the node wallet, so the Execution client takes the queue when it assigns their nonces
+func WithSendWindow(ctx context.Context) context.Context {
+	return context.WithValue(ctx, sendWindowKey{}, &sendWindow{})
+}
+
+// Take the queue for a transaction that's being sent with the provided context, if it's marked as sending from the node wallet.
+// It's called when the transaction's nonce is assigned, and does nothing if the context already holds the queue.
+func OpenSendWindow(ctx context.Context, dir string) error {
+	window, ok := ctx.Value(sendWindowKey{}).(*sendWindow)
+	if !ok {
+		return nil
+	}
+	window.lock.Lock()
+	defer window.lock.Unlock()
+	if window.held != nil {
+		return nil
+	}
+
+	waitReporterLock.Lock()
+	reporter := waitReporter
+	waitReporterLock.Unlock()
+	held, err := Acquire(dir, DefaultTimeout, reporter)
+	if err != nil {
+		return err
+	}
+	window.held = held
+	window.timer = time.AfterFunc(MaxSendWindow, func() {
+		CloseSendWindow(ctx)
+	})
+	return nil
+}
+
+// Release the queue once the transaction being sent with the provided context has been broadcast, or has failed to be
+func CloseSendWindow(ctx context.Context) {
+	window, ok := ctx.Value(sendWindowKey{}).(*sendWindow)
+	if !ok {
+		return
+	}
+	window.lock.Lock()
+	defer window.lock.Unlock()
+	if window.timer != nil {
+		window.timer.Stop()
+		window.timer = nil
+	}
+	window.held.Release()
+	window.held = nil
+}
@@ -0,0 +1,414 @@
+package config
+
+import (
+	"github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Defaults
+const (
+	defaultAlertingCollateralThreshold float64 = 12
+	defaultAlertingCollateralLookback  uint64  = 30
+	defaultAlertingStuckTxTimeout      uint64  = 30
+	defaultAlertingMissedAttestations  uint64  = 3
+	defaultAlertingCooldown            uint64  = 60
+	defaultAlertingDiskSpaceThreshold  uint64  = 50
+	defaultAlertingDiskExhaustionDays  uint64  = 14
+	defaultAlertingMemoryThreshold     float64 = 90
+	defaultAlertingClockSkewThreshold  uint64  = 500
+	defaultAlertingNtpServer           string  = "pool.ntp.org"
+	defaultAlertingDepositPoolEth      float64 = 24
+	defaultAlertingQueueWaitChange     float64 = 50
+	defaultAlertingBalanceAnomaly      float64 = 0.5
+	defaultAlertingHeartbeatInterval   uint64  = 5
+)
+
+// Configuration for the daemon alerting system
+type AlertingConfig struct {
+	Title string `yaml:"-"`
+
+	// The collateral ratio (as a percentage of borrowed ETH) below which an alert is raised
+	CollateralThreshold config.Parameter `yaml:"collateralThreshold,omitempty"`
+
+	// How many days of submitted RPL prices are used to forecast the collateral ratio at the next rewards snapshot
+	CollateralLookback config.Parameter `yaml:"collateralLookback,omitempty"`
+
+	// The number of consecutive epochs a validator can miss before an alert is raised
+	MissedAttestations config.Parameter `yaml:"missedAttestations,omitempty"`
+
+	// How long a transaction can remain pending before it's considered stuck, in minutes
+	StuckTxTimeout config.Parameter `yaml:"stuckTxTimeout,omitempty"`
+
+	// The amount of free disk space (in GB) below which an alert is raised
+	DiskSpaceThreshold config.Parameter `yaml:"diskSpaceThreshold,omitempty"`
+
+	// How many days ahead of the disk filling up, at its current growth rate, an alert is raised
+	DiskExhaustionDays config.Parameter `yaml:"diskExhaustionDays,omitempty"`
+
+	// The percentage of RAM in use above which an alert is raised
+	MemoryThreshold config.Parameter `yaml:"memoryThreshold,omitempty"`
+
+	// How far the system clock can drift from the right time, in milliseconds, before an alert is raised
+	ClockSkewThreshold config.Parameter `yaml:"clockSkewThreshold,omitempty"`
+
+	// The NTP server the system clock is compared against
+	NtpServer config.Parameter `yaml:"ntpServer,omitempty"`
+
+	// The ETH in the deposit pool, beyond what the minipool queue needs, above which an alert is raised
+	DepositPoolThreshold config.Parameter `yaml:"depositPoolThreshold,omitempty"`
+
+	// The percentage a queued minipool's estimated wait has to change by for an alert to be raised
+	QueueWaitChange config.Parameter `yaml:"queueWaitChange,omitempty"`
+
+	// The smallest unexplained ETH outflow from the node wallet that can be treated as an anomaly
+	BalanceAnomalyThreshold config.Parameter `yaml:"balanceAnomalyThreshold,omitempty"`
+
+	// How long to wait before repeating an alert that is still active, in minutes
+	Cooldown config.Parameter `yaml:"cooldown,omitempty"`
+
+	// The Discord webhook URL to send alerts to
+	DiscordWebhookUrl config.Parameter `yaml:"discordWebhookUrl,omitempty"`
+
+	// The Telegram bot token used to send alerts
+	TelegramBotToken config.Parameter `yaml:"telegramBotToken,omitempty"`
+
+	// The Telegram chat ID to send alerts to
+	TelegramChatID config.Parameter `yaml:"telegramChatID,omitempty"`
+
+	// The Pushover application token used to send alerts
+	PushoverAppToken config.Parameter `yaml:"pushoverAppToken,omitempty"`
+
+	// The Pushover user key to send alerts to
+	PushoverUserKey config.Parameter `yaml:"pushoverUserKey,omitempty"`
+
+	// The PagerDuty Events API v2 routing key
+	PagerDutyRoutingKey config.Parameter `yaml:"pagerDutyRoutingKey,omitempty"`
+
+	// A generic webhook URL that will receive alerts as JSON
+	WebhookUrl config.Parameter `yaml:"webhookUrl,omitempty"`
+
+	// The URL the node's signed heartbeats are published to
+	HeartbeatUrl config.Parameter `yaml:"heartbeatUrl,omitempty"`
+
+	// The pub/sub topic on the heartbeat URL to publish heartbeats to
+	HeartbeatTopic config.Parameter `yaml:"heartbeatTopic,omitempty"`
+
+	// How often to publish a heartbeat, in minutes
+	HeartbeatInterval config.Parameter `yaml:"heartbeatInterval,omitempty"`
+}
+
+// Generates a new alerting config
+func NewAlertingConfig(cfg *RocketPoolConfig) *AlertingConfig {
+	return &AlertingConfig{
+		Title: "Alerting Settings",
+
+		CollateralThreshold: config.Parameter{
+			ID:                   "collateralThreshold",
+			Name:                 "Collateral Alert Threshold",
+			Description:          "An alert will be sent when your node's RPL collateral falls below this percentage of its borrowed ETH.\n\nThe minimum collateral required for RPL rewards is 10%.",
+			Type:                 config.ParameterType_Float,
+			Default:              map[config.Network]interface{}{config.Network_All: defaultAlertingCollateralThreshold},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		CollateralLookback: config.Parameter{
+			ID:                   "collateralLookback",
+			Name:                 "Collateral Forecast Lookback",
+			Description:          "The number of days of RPL prices submitted by the Oracle DAO used to measure how volatile the RPL price is. An alert will be sent when a plausible drop in the RPL price before the next rewards snapshot would take your node's RPL collateral below the minimum required for RPL rewards, so you have time to top it up.\n\nSet this to 0 to disable the alert.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: defaultAlertingCollateralLookback},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		MissedAttestations: config.Parameter{
+			ID:                   "missedAttestations",
+			Name:                 "Missed Attestation Threshold",
+			Description:          "An alert will be sent when one of your validators misses its attestations for this many epochs in a row.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: defaultAlertingMissedAttestations},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		StuckTxTimeout: config.Parameter{
+			ID:                   "stuckTxTimeout",
+			Name:                 "Stuck Transaction Timeout",
+			Description:          "The number of minutes a transaction from your node wallet can remain pending before an alert is sent about it being stuck.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: defaultAlertingStuckTxTimeout},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		DiskSpaceThreshold: config.Parameter{
+			ID:                   "diskSpaceThreshold",
+			Name:                 "Free Disk Space Threshold",
+			Description:          "An alert will be sent when the disk holding your chain data has less than this many GB of free space left.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: defaultAlertingDiskSpaceThreshold},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		DiskExhaustionDays: config.Parameter{
+			ID:                   "diskExhaustionDays",
+			Name:                 "Disk Exhaustion Warning",
+			Description:          "An alert will be sent when your disk is projected to fill up within this many days, based on how quickly its free space has been shrinking.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: defaultAlertingDiskExhaustionDays},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		MemoryThreshold: config.Parameter{
+			ID:                   "memoryThreshold",
+			Name:                 "Memory Usage Threshold",
+			Description:          "An alert will be sent when more than this percentage of your machine's RAM is in use.",
+			Type:                 config.ParameterType_Float,
+			Default:              map[config.Network]interface{}{config.Network_All: defaultAlertingMemoryThreshold},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		ClockSkewThreshold: config.Parameter{
+			ID:                   "clockSkewThreshold",
+			Name:                 "Clock Skew Threshold",
+			Description:          "An alert will be sent when your machine's clock is more than this many milliseconds off from the NTP server or the Beacon Chain's slot times. Validators with a skewed clock attest late or on the wrong data, which quietly costs rewards.\n\nSet this to 0 to disable the check.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: defaultAlertingClockSkewThreshold},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		NtpServer: config.Parameter{
+			ID:                   "ntpServer",
+			Name:                 "NTP Server",
+			Description:          "The NTP server your machine's clock is compared against. Leave this blank to only compare it against the Beacon Chain's slot times, which can only catch skew of a few seconds or more.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: defaultAlertingNtpServer},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		DepositPoolThreshold: config.Parameter{
+			ID:                   "depositPoolThreshold",
+			Name:                 "Deposit Pool Alert Threshold",
+			Description:          "An alert will be sent when the deposit pool has at least this much ETH left over after matching every minipool in the queue. 24 ETH is enough for a new 8 ETH minipool to be matched right away, without waiting in the queue.\n\nSet this to 0 to disable the alert.",
+			Type:                 config.ParameterType_Float,
+			Default:              map[config.Network]interface{}{config.Network_All: defaultAlertingDepositPoolEth},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		QueueWaitChange: config.Parameter{
+			ID:                   "queueWaitChange",
+			Name:                 "Queue Wait Change Threshold",
+			Description:          "An alert will be sent when the estimated wait of one of your minipools in the queue changes by more than this percentage since the last alert about it.\n\nSet this to 0 to disable the alert.",
+			Type:                 config.ParameterType_Float,
+			Default:              map[config.Network]interface{}{config.Network_All: defaultAlertingQueueWaitChange},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		BalanceAnomalyThreshold: config.Parameter{
+			ID:                   "balanceAnomalyThreshold",
+			Name:                 "Balance Anomaly Threshold",
+			Description:          "An alert will be sent when ETH or RPL leaves your node wallet faster than it usually does, based on your node's balance history, without a minipool deposit or RPL stake to explain it. This can be an early warning that your node wallet's key has been compromised or that one of your automations is misbehaving.\n\nETH outflows smaller than this many ETH never raise the alert, so gas spent by the Smartnode's own transactions doesn't. Any unexplained RPL transfer does.\n\nSet this to 0 to disable the alert.",
+			Type:                 config.ParameterType_Float,
+			Default:              map[config.Network]interface{}{config.Network_All: defaultAlertingBalanceAnomaly},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		Cooldown: config.Parameter{
+			ID:                   "cooldown",
+			Name:                 "Repeat Interval",
+			Description:          "The number of minutes to wait before repeating an alert that is still active.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: defaultAlertingCooldown},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		DiscordWebhookUrl: config.Parameter{
+			ID:                   "discordWebhookUrl",
+			Name:                 "Discord Webhook URL",
+			Description:          "The URL of a Discord webhook to send alerts to. Leave this blank to disable Discord alerts.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		TelegramBotToken: config.Parameter{
+			ID:                   "telegramBotToken",
+			Name:                 "Telegram Bot Token",
+			Description:          "The token of the Telegram bot that will send alerts. Leave this blank to disable Telegram alerts.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		TelegramChatID: config.Parameter{
+			ID:                   "telegramChatID",
+			Name:                 "Telegram Chat ID",
+			Description:          "The ID of the Telegram chat that the bot should send alerts to.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		PushoverAppToken: config.Parameter{
+			ID:                   "pushoverAppToken",
+			Name:                 "Pushover App Token",
+			Description:          "The API token of your Pushover application. Leave this blank to disable Pushover alerts.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		PushoverUserKey: config.Parameter{
+			ID:                   "pushoverUserKey",
+			Name:                 "Pushover User Key",
+			Description:          "The Pushover user or group key that alerts should be delivered to.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		PagerDutyRoutingKey: config.Parameter{
+			ID:                   "pagerDutyRoutingKey",
+			Name:                 "PagerDuty Routing Key",
+			Description:          "The integration (routing) key of a PagerDuty Events API v2 service. Leave this blank to disable PagerDuty alerts.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		WebhookUrl: config.Parameter{
+			ID:                   "webhookUrl",
+			Name:                 "Generic Webhook URL",
+			Description:          "A URL that will receive each alert as a JSON POST request. Leave this blank to disable it.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		HeartbeatUrl: config.Parameter{
+			ID:                   "heartbeatUrl",
+			Name:                 "Heartbeat URL",
+			Description:          "A URL that the node will regularly send a heartbeat to as a JSON POST request, so an external watchdog service can alert you if your node goes silent. Each heartbeat has your node address, the time, your client versions and their sync status, and is signed with your node wallet so the watchdog can check that it came from your node. Your node's API is never exposed.\n\nLeave this blank to disable heartbeats.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		HeartbeatTopic: config.Parameter{
+			ID:                   "heartbeatTopic",
+			Name:                 "Heartbeat Topic",
+			Description:          "The topic to publish heartbeats to, if the Heartbeat URL is a pub/sub service that accepts messages over HTTP (such as ntfy). The topic is added to the end of the URL.\n\nLeave this blank to send heartbeats to the Heartbeat URL itself.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		HeartbeatInterval: config.Parameter{
+			ID:                   "heartbeatInterval",
+			Name:                 "Heartbeat Interval",
+			Description:          "The number of minutes between heartbeats.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: defaultAlertingHeartbeatInterval},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+	}
+}
+
+// Get the parameters for this config
+func (cfg *AlertingConfig) GetParameters() []*config.Parameter {
+	return []*config.Parameter{
+		&cfg.CollateralThreshold,
+		&cfg.CollateralLookback,
+		&cfg.MissedAttestations,
+		&cfg.StuckTxTimeout,
+		&cfg.DiskSpaceThreshold,
+		&cfg.DiskExhaustionDays,
+		&cfg.MemoryThreshold,
+		&cfg.ClockSkewThreshold,
+		&cfg.NtpServer,
+		&cfg.DepositPoolThreshold,
+		&cfg.QueueWaitChange,
+		&cfg.BalanceAnomalyThreshold,
+		&cfg.Cooldown,
+		&cfg.DiscordWebhookUrl,
+		&cfg.TelegramBotToken,
+		&cfg.TelegramChatID,
+		&cfg.PushoverAppToken,
+		&cfg.PushoverUserKey,
+		&cfg.PagerDutyRoutingKey,
+		&cfg.WebhookUrl,
+		&cfg.HeartbeatUrl,
+		&cfg.HeartbeatTopic,
+		&cfg.HeartbeatInterval,
+	}
+}
+
+// The the title for the config
+func (cfg *AlertingConfig) GetConfigTitle() string {
+	return cfg.Title
+}
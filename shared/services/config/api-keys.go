@@ -0,0 +1,203 @@
+package config
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// API key settings
+const (
+	// The file in the data folder holding the keys other tools can use with the node daemon's API server
+	ApiKeysFilename string = "api-keys.json"
+
+	apiKeyLength   int = 32
+	apiKeyFileMode     = 0600
+)
+
+// What an API key is allowed to do
+type ApiRole string
+
+const (
+	// Can only run commands that don't change anything, for monitoring and dashboards
+	ApiRole_ReadOnly ApiRole = "read-only"
+
+	// Can also send the node's routine transactions, like staking RPL or claiming rewards
+	ApiRole_Operator ApiRole = "operator"
+
+	// Can do everything, including managing the wallet, moving funds out of the node and changing its withdrawal address
+	ApiRole_Admin ApiRole = "admin"
+)
+
+// A key for the node daemon's API server. Only a hash of the token is stored.
+type ApiKey struct {
+	Name      string    `json:"name"`
+	Role      ApiRole   `json:"role"`
+	TokenHash string    `json:"tokenHash"`
+	Created   time.Time `json:"created"`
+	Rotated   time.Time `json:"rotated,omitempty"`
+}
+
+// The keys for the node daemon's API server
+type ApiKeys struct {
+	Keys []ApiKey `json:"keys"`
+}
+
+// Get a role from its name
+func ParseApiRole(name string) (ApiRole, error) {
+	switch ApiRole(name) {
+	case ApiRole_ReadOnly, ApiRole_Operator, ApiRole_Admin:
+		return ApiRole(name), nil
+	}
+	return "", fmt.Errorf("invalid role '%s'; it must be '%s', '%s' or '%s'", name, ApiRole_ReadOnly, ApiRole_Operator, ApiRole_Admin)
+}
+
+// True if this role can do everything the other one can
+func (r ApiRole) Includes(other ApiRole) bool {
+	return r.rank() >= other.rank()
+}
+
+func (r ApiRole) rank() int {
+	switch r {
+	case ApiRole_ReadOnly:
+		return 1
+	case ApiRole_Operator:
+		return 2
+	case ApiRole_Admin:
+		return 3
+	}
+	return 0
+}
+
+// Get the key with the provided name
+func (k *ApiKeys) Get(name string) (*ApiKey, bool) {
+	for i := range k.Keys {
+		if k.Keys[i].Name == name {
+			return &k.Keys[i], true
+		}
+	}
+	return nil, false
+}
+
+// Add a new key and return its token; the token can't be recovered later
+func (k *ApiKeys) Add(name string, role ApiRole) (string, error) {
+	if name == "" {
+		return "", errors.New("the key needs a name")
+	}
+	if _, exists := k.Get(name); exists {
+		return "", fmt.Errorf("there is already a key named '%s'", name)
+	}
+	token, hash, err := newApiToken()
+	if err != nil {
+		return "", err
+	}
+	k.Keys = append(k.Keys, ApiKey{
+		Name:      name,
+		Role:      role,
+		TokenHash: hash,
+		Created:   time.Now().UTC(),
+	})
+	sort.Slice(k.Keys, func(i, j int) bool {
+		return k.Keys[i].Name < k.Keys[j].Name
+	})
+	return token, nil
+}
+
+// Replace a key's token with a new one and return it; the old token stops working
+func (k *ApiKeys) Rotate(name string) (string, error) {
+	key, exists := k.Get(name)
+	if !exists {
+		return "", fmt.Errorf("there is no key named '%s'", name)
+	}
+	token, hash, err := newApiToken()
+	if err != nil {
+		return "", err
+	}
+	key.TokenHash = hash
+	key.Rotated = time.Now().UTC()
+	return token, nil
+}
+
+// Remove a key
+func (k *ApiKeys) Remove(name string) error {
+	for i, key := range k.Keys {
+		if key.Name == name {
+			k.Keys = append(k.Keys[:i], k.Keys[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("there is no key named '%s'", name)
+}
+
+// Find the key a token belongs to
+func (k *ApiKeys) Find(token string) (*ApiKey, bool) {
+	hash := hashApiToken(token)
+	for i := range k.Keys {
+		if subtle.ConstantTimeCompare([]byte(hash), []byte(k.Keys[i].TokenHash)) == 1 {
+			return &k.Keys[i], true
+		}
+	}
+	return nil, false
+}
+
+// Save the keys to the provided path
+func (k *ApiKeys) Save(path string) error {
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return fmt.Errorf("error creating API keys directory: %w", err)
+	}
+	bytes, err := json.MarshalIndent(k, "", "    ")
+	if err != nil {
+		return fmt.Errorf("error serializing API keys: %w", err)
+	}
+	err = os.WriteFile(path, bytes, apiKeyFileMode)
+	if err != nil {
+		return fmt.Errorf("error writing API keys file [%s]: %w", path, err)
+	}
+	return nil
+}
+
+// Load the keys from the provided path. Returns no keys if none have been created yet.
+func LoadApiKeys(path string) (*ApiKeys, error) {
+	keys := &ApiKeys{
+		Keys: []ApiKey{},
+	}
+	bytes, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return keys, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading API keys file [%s]: %w", path, err)
+	}
+	err = json.Unmarshal(bytes, keys)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing API keys file [%s]: %w", path, err)
+	}
+	if keys.Keys == nil {
+		keys.Keys = []ApiKey{}
+	}
+	return keys, nil
+}
+
+// Generate a new token and its hash
+func newApiToken() (string, string, error) {
+	bytes := make([]byte, apiKeyLength)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", "", fmt.Errorf("error generating API key: %w", err)
+	}
+	token := hex.EncodeToString(bytes)
+	return token, hashApiToken(token), nil
+}
+
+func hashApiToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
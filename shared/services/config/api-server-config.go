@@ -0,0 +1,117 @@
+package config
+
+import (
+	"github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Node API server settings
+const (
+	// The file in the data folder holding the bearer token the node daemon's API server accepts
+	ApiServerTokenFilename string = "api-token.txt"
+
+	// The file in the data folder holding the responses to commands that were sent with an idempotency key
+	ApiIdempotencyFilename string = "api-idempotency.json"
+
+	defaultApiServerPort     uint16 = 8280
+	defaultApiServerOpenPort string = string(config.RPC_OpenLocalhost)
+)
+
+// Configuration for the node daemon's API server
+type ApiServerConfig struct {
+	Title string `yaml:"-"`
+
+	// Toggle for serving the API commands over HTTP from the node daemon
+	Enabled config.Parameter `yaml:"enabled,omitempty"`
+
+	// The port the API server listens on
+	Port config.Parameter `yaml:"port,omitempty"`
+
+	// Toggle for forwarding the API port outside of Docker
+	OpenPort config.Parameter `yaml:"openPort,omitempty"`
+
+	parent *RocketPoolConfig
+}
+
+// Generates a new API server config
+func NewApiServerConfig(cfg *RocketPoolConfig) *ApiServerConfig {
+	portModes := config.PortModes("Allow connections from external hosts. The API is served over plain HTTP, so its keys and the passphrases sent to it cross your network unencrypted, and anyone with an operator or admin key can send transactions from your node wallet. Only do this on a network you trust, or reach the API through a VPN or a reverse proxy that adds TLS.")
+	return &ApiServerConfig{
+		Title: "Node API Server Settings",
+
+		Enabled: config.Parameter{
+			ID:   "enabled",
+			Name: "Enable Node API Server",
+			Description: "Serve the Smartnode's API commands over HTTP from the node daemon, so the CLI and other tools can talk to it directly instead of starting a new process inside the API container for every command.\n\n" +
+				"Requests must carry the token the Smartnode generates in your data folder, or a key created with `rocketpool service api-keys`. Keys can be read-only, so dashboards can query your node without being able to send transactions.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{"ENABLE_NODE_API"},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		Port: config.Parameter{
+			ID:                   "port",
+			Name:                 "Node API Port",
+			Description:          "The port the node daemon's API server listens on.",
+			Type:                 config.ParameterType_Uint16,
+			Default:              map[config.Network]interface{}{config.Network_All: defaultApiServerPort},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{"NODE_API_PORT"},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		OpenPort: config.Parameter{
+			ID:                   "openPort",
+			Name:                 "Expose Node API Port",
+			Description:          "Expose the node daemon's API port to your machine, or to your local network so other machines can access it too. The CLI can only use the API server if this is at least open to localhost.\n\nThe API server doesn't support TLS; if you open it to external hosts, its keys, tokens and passphrases are sent over your network in cleartext.",
+			Type:                 config.ParameterType_Choice,
+			Default:              map[config.Network]interface{}{config.Network_All: defaultApiServerOpenPort},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{"NODE_API_OPEN_PORT"},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Options:              portModes,
+		},
+
+		parent: cfg,
+	}
+}
+
+// Get the parameters for this config
+func (cfg *ApiServerConfig) GetParameters() []*config.Parameter {
+	return []*config.Parameter{
+		&cfg.Enabled,
+		&cfg.Port,
+		&cfg.OpenPort,
+	}
+}
+
+// The the title for the config
+func (cfg *ApiServerConfig) GetConfigTitle() string {
+	return cfg.Title
+}
+
+// Get the address the API server should listen on.
+// In Docker mode it listens on every interface of the node container and Docker decides who can reach it.
+func (cfg *ApiServerConfig) GetListenAddress() string {
+	if !cfg.parent.IsNativeMode || cfg.IsOpenToExternalHosts() {
+		return "0.0.0.0"
+	}
+	return "127.0.0.1"
+}
+
+// True if the API server accepts connections from other machines, which reach it over plain HTTP
+func (cfg *ApiServerConfig) IsOpenToExternalHosts() bool {
+	return cfg.OpenPort.Value.(config.RPCMode) == config.RPC_OpenExternal
+}
+
+// True if the CLI on this machine can reach the API server
+func (cfg *ApiServerConfig) IsReachable() bool {
+	if cfg.Enabled.Value != true {
+		return false
+	}
+	return cfg.parent.IsNativeMode || cfg.OpenPort.Value.(config.RPCMode).Open()
+}
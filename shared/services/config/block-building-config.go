@@ -0,0 +1,115 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// The lowest gas limit a block can have
+const MinGasLimit uint64 = 5000
+
+// How one of the node's minipools builds its blocks, replacing the Validator client's defaults
+type BlockBuildingPreference struct {
+	// Whether to use MEV-Boost's builders or always build locally; the default follows the node's MEV-Boost setting
+	Mode config.BlockBuildingMode `json:"mode,omitempty"`
+
+	// The gas limit to register with the builders and to target for local blocks, or 0 for the Validator client's default
+	GasLimit uint64 `json:"gasLimit,omitempty"`
+}
+
+// The per-minipool block building settings, which are managed with `rocketpool node set-block-building` instead of the config UI
+type BlockBuildingSettings struct {
+	Minipools map[common.Address]BlockBuildingPreference `json:"minipools"`
+}
+
+// Check if a preference doesn't change anything, so the minipool uses the node's settings
+func (p BlockBuildingPreference) IsDefault() bool {
+	return p.Mode == config.BlockBuildingMode_Default && p.GasLimit == 0
+}
+
+// Check if a block building preference can be used with the node's settings
+func (cfg *RocketPoolConfig) ValidateBlockBuildingPreference(preference BlockBuildingPreference) error {
+	switch preference.Mode {
+	case config.BlockBuildingMode_Default, config.BlockBuildingMode_Local:
+	case config.BlockBuildingMode_Builder:
+		if cfg.EnableMevBoost.Value != true {
+			return fmt.Errorf("MEV-Boost is disabled, so minipools can't use its builders")
+		}
+	default:
+		return fmt.Errorf("unknown block building mode [%s]", preference.Mode)
+	}
+	if preference.GasLimit != 0 && preference.GasLimit < MinGasLimit {
+		return fmt.Errorf("the gas limit can't be lower than %d", MinGasLimit)
+	}
+	return nil
+}
+
+// Check if a minipool with the provided preference uses MEV-Boost's builders for its proposals
+func (cfg *RocketPoolConfig) UsesBuilder(preference BlockBuildingPreference) bool {
+	switch preference.Mode {
+	case config.BlockBuildingMode_Builder:
+		return true
+	case config.BlockBuildingMode_Local:
+		return false
+	default:
+		return cfg.EnableMevBoost.Value == true
+	}
+}
+
+// Check which block building preferences a validator client lets the Smartnode set for each validator over the Keymanager API
+func GetBlockBuildingSupport(cc config.ConsensusClient) (gasLimit bool, builder bool) {
+	switch cc {
+	case config.ConsensusClient_Lighthouse:
+		// Only Lighthouse has a Keymanager API extension for opting validators in or out of the builders
+		return true, true
+	case config.ConsensusClient_Lodestar, config.ConsensusClient_Nimbus, config.ConsensusClient_Prysm, config.ConsensusClient_Teku:
+		return true, false
+	default:
+		return false, false
+	}
+}
+
+// Load the per-minipool block building settings. Returns empty settings if the file doesn't exist yet.
+func LoadBlockBuildingSettings(path string) (*BlockBuildingSettings, error) {
+	settings := &BlockBuildingSettings{
+		Minipools: map[common.Address]BlockBuildingPreference{},
+	}
+	bytes, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return settings, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading block building settings file [%s]: %w", path, err)
+	}
+	err = json.Unmarshal(bytes, settings)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing block building settings file [%s]: %w", path, err)
+	}
+	if settings.Minipools == nil {
+		settings.Minipools = map[common.Address]BlockBuildingPreference{}
+	}
+	return settings, nil
+}
+
+// Save the per-minipool block building settings to the provided path
+func (s *BlockBuildingSettings) Save(path string) error {
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return fmt.Errorf("error creating block building settings directory: %w", err)
+	}
+	bytes, err := json.MarshalIndent(s, "", "    ")
+	if err != nil {
+		return fmt.Errorf("error serializing block building settings: %w", err)
+	}
+	err = os.WriteFile(path, bytes, 0644)
+	if err != nil {
+		return fmt.Errorf("error writing block building settings file [%s]: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/rocket-pool/smartnode/shared/services/config/migration"
+	"github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Settings in the root section of the settings file that aren't parameters
+var rootMetadataSettings = map[string]bool{
+	"rpDir":                 true,
+	"isNative":              true,
+	"version":               true,
+	migration.MigrationsKey: true,
+}
+
+// A port used by one of the enabled services
+type servicePort struct {
+	name  string
+	param *config.Parameter
+}
+
+// A port that one of the enabled services publishes on the host
+type HostPort struct {
+	Name      string
+	Port      uint16
+	Protocol  string
+	Container string
+
+	// True if the port should be reachable from the internet so the client can find peers
+	IsP2p bool
+}
+
+// Check a serialized settings file for sections and parameters that this version of the Smartnode doesn't know about.
+// These are silently ignored when the file is loaded, so they're usually typos or leftovers from a hand-edited file.
+func (cfg *RocketPoolConfig) GetUnknownSettings(masterMap map[string]map[string]string) []string {
+	sections := map[string][]*config.Parameter{
+		rootConfigName: cfg.GetParameters(),
+	}
+	for name, subconfig := range cfg.GetSubconfigs() {
+		sections[name] = subconfig.GetParameters()
+	}
+
+	problems := []string{}
+	for sectionName, settings := range masterMap {
+		params, exists := sections[sectionName]
+		if !exists {
+			problems = append(problems, fmt.Sprintf("Unknown section [%s] in the settings file.", sectionName))
+			continue
+		}
+		known := map[string]bool{}
+		for _, param := range params {
+			known[param.ID] = true
+		}
+		for id := range settings {
+			if !known[id] && !(sectionName == rootConfigName && rootMetadataSettings[id]) {
+				problems = append(problems, fmt.Sprintf("Unknown parameter [%s.%s] in the settings file.", sectionName, id))
+			}
+		}
+	}
+	sort.Strings(problems)
+	return problems
+}
+
+// Check for ports that are used by more than one of the enabled services
+func (cfg *RocketPoolConfig) GetPortConflicts() []string {
+	ports := []servicePort{}
+	if cfg.ExecutionClientMode.Value.(config.Mode) == config.Mode_Local {
+		ports = append(ports,
+			servicePort{"Execution client HTTP API", &cfg.ExecutionCommon.HttpPort},
+			servicePort{"Execution client Websocket API", &cfg.ExecutionCommon.WsPort},
+			servicePort{"Execution client Engine API", &cfg.ExecutionCommon.EnginePort},
+			servicePort{"Execution client P2P", &cfg.ExecutionCommon.P2pPort},
+		)
+	}
+	if cfg.ConsensusClientMode.Value.(config.Mode) == config.Mode_Local {
+		ports = append(ports,
+			servicePort{"Consensus client P2P", &cfg.ConsensusCommon.P2pPort},
+			servicePort{"Consensus client HTTP API", &cfg.ConsensusCommon.ApiPort},
+		)
+		if cfg.ConsensusClient.Value.(config.ConsensusClient) == config.ConsensusClient_Prysm {
+			ports = append(ports, servicePort{"Prysm RPC", &cfg.Prysm.RpcPort})
+		}
+	}
+	if cfg.Smartnode.EnableHealthCheck.Value == true {
+		ports = append(ports,
+			servicePort{"Node health check", &cfg.Smartnode.NodeHealthPort},
+			servicePort{"Watchtower health check", &cfg.Smartnode.WatchtowerHealthPort},
+		)
+	}
+	if cfg.EnableMetrics.Value == true {
+		ports = append(ports,
+			servicePort{"Node metrics", &cfg.NodeMetricsPort},
+			servicePort{"Watchtower metrics", &cfg.WatchtowerMetricsPort},
+			servicePort{"Validator client metrics", &cfg.VcMetricsPort},
+			servicePort{"Node Exporter metrics", &cfg.ExporterMetricsPort},
+			servicePort{"Prometheus", &cfg.Prometheus.Port},
+			servicePort{"Grafana", &cfg.Grafana.Port},
+		)
+		if cfg.ExecutionClientMode.Value.(config.Mode) == config.Mode_Local {
+			ports = append(ports, servicePort{"Execution client metrics", &cfg.EcMetricsPort})
+		}
+		if cfg.ConsensusClientMode.Value.(config.Mode) == config.Mode_Local {
+			ports = append(ports, servicePort{"Consensus client metrics", &cfg.BnMetricsPort})
+		}
+	}
+	if cfg.EnableMevBoost.Value == true && cfg.MevBoost.Mode.Value.(config.Mode) == config.Mode_Local {
+		ports = append(ports, servicePort{"MEV-Boost", &cfg.MevBoost.Port})
+	}
+
+	// Find the ports that are used more than once
+	users := map[uint16][]string{}
+	for _, port := range ports {
+		value, ok := port.param.Value.(uint16)
+		if !ok {
+			continue
+		}
+		users[value] = append(users[value], port.name)
+	}
+	problems := []string{}
+	for port, names := range users {
+		if len(names) > 1 {
+			problems = append(problems, fmt.Sprintf("Port %d is used by more than one service: %v.", port, names))
+		}
+	}
+	sort.Strings(problems)
+	return problems
+}
+
+// Get the ports that the enabled services publish on the host
+func (cfg *RocketPoolConfig) GetHostPorts() []HostPort {
+	ports := []HostPort{}
+	addOpen := func(name string, mode *config.Parameter, port *config.Parameter, container string) {
+		if mode.Value.(config.RPCMode).Open() {
+			ports = append(ports, HostPort{Name: name, Port: port.Value.(uint16), Protocol: "tcp", Container: container})
+		}
+	}
+	addP2p := func(name string, port *config.Parameter, container string) {
+		ports = append(ports,
+			HostPort{Name: name, Port: port.Value.(uint16), Protocol: "tcp", Container: container, IsP2p: true},
+			HostPort{Name: name, Port: port.Value.(uint16), Protocol: "udp", Container: container, IsP2p: true},
+		)
+	}
+
+	if cfg.ExecutionClientMode.Value.(config.Mode) == config.Mode_Local {
+		addP2p("Execution client P2P", &cfg.ExecutionCommon.P2pPort, Eth1ContainerName)
+		addOpen("Execution client HTTP API", &cfg.ExecutionCommon.OpenRpcPorts, &cfg.ExecutionCommon.HttpPort, Eth1ContainerName)
+		addOpen("Execution client Websocket API", &cfg.ExecutionCommon.OpenRpcPorts, &cfg.ExecutionCommon.WsPort, Eth1ContainerName)
+		if cfg.IsEngineApiPublished() {
+			ports = append(ports, HostPort{Name: "Execution client Engine API", Port: cfg.ExecutionCommon.EnginePort.Value.(uint16), Protocol: "tcp", Container: Eth1ContainerName})
+		}
+	}
+	if cfg.ConsensusClientMode.Value.(config.Mode) == config.Mode_Local {
+		addP2p("Consensus client P2P", &cfg.ConsensusCommon.P2pPort, Eth2ContainerName)
+		addOpen("Consensus client HTTP API", &cfg.ConsensusCommon.OpenApiPort, &cfg.ConsensusCommon.ApiPort, Eth2ContainerName)
+		if cfg.ConsensusClient.Value.(config.ConsensusClient) == config.ConsensusClient_Prysm {
+			addOpen("Prysm RPC", &cfg.Prysm.OpenRpcPort, &cfg.Prysm.RpcPort, Eth2ContainerName)
+		}
+	}
+	if cfg.EnableMetrics.Value == true {
+		ports = append(ports, HostPort{Name: "Grafana", Port: cfg.Grafana.Port.Value.(uint16), Protocol: "tcp", Container: GrafanaContainerName})
+		addOpen("Prometheus", &cfg.Prometheus.OpenPort, &cfg.Prometheus.Port, PrometheusContainerName)
+	}
+	if cfg.EnableMevBoost.Value == true && cfg.MevBoost.Mode.Value.(config.Mode) == config.Mode_Local {
+		addOpen("MEV-Boost", &cfg.MevBoost.OpenRpcPort, &cfg.MevBoost.Port, MevBoostContainerName)
+	}
+	if cfg.ApiServer.Enabled.Value == true {
+		addOpen("Node API", &cfg.ApiServer.OpenPort, &cfg.ApiServer.Port, NodeContainerName)
+	}
+	return ports
+}
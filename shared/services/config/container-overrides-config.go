@@ -0,0 +1,163 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+var (
+	// An environment variable name
+	envVarNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+	// A Docker image reference, e.g. sigp/lighthouse:v4.5.0-rc.0 or ghcr.io/org/image@sha256:...
+	containerTagRegex = regexp.MustCompile(`^[a-z0-9]+([._\-/:][a-z0-9]+)*(:[\w][\w.\-]{0,127})?(@sha256:[a-f0-9]{64})?$`)
+)
+
+// Configuration for extra environment variables passed to the client containers
+type ContainerOverridesConfig struct {
+	Title string `yaml:"-"`
+
+	// Extra environment variables for the Execution client
+	Eth1Env config.Parameter `yaml:"eth1Env,omitempty"`
+
+	// Extra environment variables for the Consensus client
+	Eth2Env config.Parameter `yaml:"eth2Env,omitempty"`
+
+	// Extra environment variables for the Validator client
+	ValidatorEnv config.Parameter `yaml:"validatorEnv,omitempty"`
+
+	// Extra environment variables for MEV-Boost
+	MevBoostEnv config.Parameter `yaml:"mevBoostEnv,omitempty"`
+}
+
+// Generates a new container overrides config
+func NewContainerOverridesConfig(cfg *RocketPoolConfig) *ContainerOverridesConfig {
+	return &ContainerOverridesConfig{
+		Title: "Container Override Settings",
+
+		Eth1Env:      newExtraEnvParameter("eth1Env", "Execution Client", config.ContainerID_Eth1),
+		Eth2Env:      newExtraEnvParameter("eth2Env", "Consensus Client", config.ContainerID_Eth2),
+		ValidatorEnv: newExtraEnvParameter("validatorEnv", "Validator Client", config.ContainerID_Validator),
+		MevBoostEnv:  newExtraEnvParameter("mevBoostEnv", "MEV-Boost", config.ContainerID_MevBoost),
+	}
+}
+
+// Creates an extra environment variable parameter for one of the containers
+func newExtraEnvParameter(id string, name string, container config.ContainerID) config.Parameter {
+	return config.Parameter{
+		ID:                   id,
+		Name:                 fmt.Sprintf("%s Environment Variables", name),
+		Description:          fmt.Sprintf("Extra environment variables to set in the %s container, as a comma-separated list of KEY=value pairs (for example `JAVA_OPTS=-Xmx8g,LOG_FORMAT=json`).\n\nThese are added to the generated Docker compose files every time the Smartnode starts, so use this instead of editing them by hand.", name),
+		Type:                 config.ParameterType_String,
+		Default:              map[config.Network]interface{}{config.Network_All: ""},
+		AffectsContainers:    []config.ContainerID{container},
+		EnvironmentVariables: []string{},
+		CanBeBlank:           true,
+		OverwriteOnUpgrade:   false,
+	}
+}
+
+// Get the parameters for this config
+func (cfg *ContainerOverridesConfig) GetParameters() []*config.Parameter {
+	return []*config.Parameter{
+		&cfg.Eth1Env,
+		&cfg.Eth2Env,
+		&cfg.ValidatorEnv,
+		&cfg.MevBoostEnv,
+	}
+}
+
+// The the title for the config
+func (cfg *ContainerOverridesConfig) GetConfigTitle() string {
+	return cfg.Title
+}
+
+// Get the extra environment variables for each container that has some, keyed by container name
+func (cfg *ContainerOverridesConfig) GetExtraEnvironment() (map[string]map[string]string, error) {
+	params := map[string]*config.Parameter{
+		Eth1ContainerName:      &cfg.Eth1Env,
+		Eth2ContainerName:      &cfg.Eth2Env,
+		ValidatorContainerName: &cfg.ValidatorEnv,
+		MevBoostContainerName:  &cfg.MevBoostEnv,
+	}
+
+	envs := map[string]map[string]string{}
+	for container, param := range params {
+		env, err := ParseExtraEnvironment(param.Value.(string))
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", param.Name, err)
+		}
+		if len(env) > 0 {
+			envs[container] = env
+		}
+	}
+	return envs, nil
+}
+
+// Parse a comma-separated list of KEY=value pairs
+func ParseExtraEnvironment(value string) (map[string]string, error) {
+	env := map[string]string{}
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, envValue, found := strings.Cut(entry, "=")
+		if !found {
+			return nil, fmt.Errorf("[%s] is not in the KEY=value format", entry)
+		}
+		name = strings.TrimSpace(name)
+		if !envVarNameRegex.MatchString(name) {
+			return nil, fmt.Errorf("[%s] is not a valid environment variable name", name)
+		}
+		if strings.ContainsAny(envValue, "\n\r") {
+			return nil, fmt.Errorf("the value of [%s] can't contain line breaks", name)
+		}
+		if _, exists := env[name]; exists {
+			return nil, fmt.Errorf("[%s] is set more than once", name)
+		}
+		env[name] = envValue
+	}
+	return env, nil
+}
+
+// Check the container tags, extra flags and extra environment variables that users can override
+func (cfg *RocketPoolConfig) GetContainerOverrideProblems() []string {
+	problems := []string{}
+	sections := cfg.GetSubconfigs()
+	names := make([]string, 0, len(sections))
+	for name := range sections {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		for _, param := range sections[name].GetParameters() {
+			value, ok := param.Value.(string)
+			if !ok || value == "" {
+				continue
+			}
+			switch {
+			case strings.HasSuffix(param.ID, "ontainerTag"):
+				if !containerTagRegex.MatchString(value) {
+					problems = append(problems, fmt.Sprintf("[%s - %s] is not a valid Docker image reference: %s", name, param.Name, value))
+				}
+			case strings.HasPrefix(param.ID, "additional") && strings.HasSuffix(param.ID, "Flags"):
+				if strings.ContainsAny(value, "\n\r") {
+					problems = append(problems, fmt.Sprintf("[%s - %s] can't contain line breaks.", name, param.Name))
+				}
+			}
+		}
+	}
+
+	for _, param := range cfg.ContainerOverrides.GetParameters() {
+		if _, err := ParseExtraEnvironment(param.Value.(string)); err != nil {
+			problems = append(problems, fmt.Sprintf("[%s] is invalid: %s.", param.Name, err.Error()))
+		}
+	}
+	return problems
+}
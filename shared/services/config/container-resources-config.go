@@ -0,0 +1,248 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// CPU shares and block I/O weights for each priority; Docker's defaults are 1024 and 500
+const (
+	lowPriorityCpuShares  uint16 = 256
+	lowPriorityIoWeight   uint16 = 100
+	highPriorityCpuShares uint16 = 4096
+	highPriorityIoWeight  uint16 = 1000
+)
+
+// A Docker memory size, e.g. 512m or 8g
+var memoryLimitRegex = regexp.MustCompile(`^[0-9]+(\.[0-9]+)?[bkmgBKMG]?$`)
+
+// Resource settings for one of the client containers
+type ContainerResources struct {
+	// The most CPU cores the container can use
+	CpuLimit config.Parameter `yaml:"cpuLimit,omitempty"`
+
+	// The most memory the container can use
+	MemoryLimit config.Parameter `yaml:"memoryLimit,omitempty"`
+
+	// The container's share of the CPU and disk when it's competing with the others
+	Priority config.Parameter `yaml:"priority,omitempty"`
+
+	// When Docker restarts the container
+	RestartPolicy config.Parameter `yaml:"restartPolicy,omitempty"`
+}
+
+// The resource limits that have been set for a container
+type ContainerResourceLimits struct {
+	CpuLimit      string
+	MemoryLimit   string
+	CpuShares     uint16
+	IoWeight      uint16
+	RestartPolicy config.RestartPolicy
+}
+
+// Configuration for the resource limits, priorities and restart policies of the client containers
+type ContainerResourcesConfig struct {
+	Title string `yaml:"-"`
+
+	// Execution client resources
+	Eth1 ContainerResources `yaml:"eth1,omitempty"`
+
+	// Consensus client resources
+	Eth2 ContainerResources `yaml:"eth2,omitempty"`
+
+	// Validator client resources
+	Validator ContainerResources `yaml:"validator,omitempty"`
+
+	// Node daemon resources
+	Node ContainerResources `yaml:"node,omitempty"`
+
+	// Watchtower daemon resources
+	Watchtower ContainerResources `yaml:"watchtower,omitempty"`
+
+	// MEV-Boost resources
+	MevBoost ContainerResources `yaml:"mevBoost,omitempty"`
+}
+
+// Generates a new container resources config
+func NewContainerResourcesConfig(cfg *RocketPoolConfig) *ContainerResourcesConfig {
+	return &ContainerResourcesConfig{
+		Title: "Container Resource Settings",
+
+		Eth1:       newContainerResources("eth1", "Execution Client", config.ContainerID_Eth1),
+		Eth2:       newContainerResources("eth2", "Consensus Client", config.ContainerID_Eth2),
+		Validator:  newContainerResources("validator", "Validator Client", config.ContainerID_Validator),
+		Node:       newContainerResources("node", "Node Daemon", config.ContainerID_Node),
+		Watchtower: newContainerResources("watchtower", "Watchtower", config.ContainerID_Watchtower),
+		MevBoost:   newContainerResources("mevBoost", "MEV-Boost", config.ContainerID_MevBoost),
+	}
+}
+
+// Creates the resource parameters for one of the containers
+func newContainerResources(id string, name string, container config.ContainerID) ContainerResources {
+	return ContainerResources{
+		CpuLimit: config.Parameter{
+			ID:                   id + "CpuLimit",
+			Name:                 fmt.Sprintf("%s CPU Limit", name),
+			Description:          fmt.Sprintf("The most CPU cores the %s container can use, such as `2` or `1.5`.\n\nLeave this blank to let it use as many as it needs.", name),
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{container},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		MemoryLimit: config.Parameter{
+			ID:                   id + "MemoryLimit",
+			Name:                 fmt.Sprintf("%s Memory Limit", name),
+			Description:          fmt.Sprintf("The most memory the %s container can use, such as `512m` or `8g`. If it tries to use more, it will be stopped and restarted, so leave plenty of room.\n\nLeave this blank to let it use as much as it needs.", name),
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{container},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		Priority: config.Parameter{
+			ID:                   id + "Priority",
+			Name:                 fmt.Sprintf("%s Priority", name),
+			Description:          fmt.Sprintf("How much CPU time and disk I/O the %s container gets when the machine is busy, like the `nice` level of a process. It has no effect when there is enough to go around.", name),
+			Type:                 config.ParameterType_Choice,
+			Default:              map[config.Network]interface{}{config.Network_All: config.ContainerPriority_Normal},
+			AffectsContainers:    []config.ContainerID{container},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Options: []config.ParameterOption{{
+				Name:        "Low",
+				Description: "Give way to the other containers, such as for an Execution client that is resyncing on the same machine as the Validator client.",
+				Value:       config.ContainerPriority_Low,
+			}, {
+				Name:        "Normal",
+				Description: "Share the machine equally with the other containers.",
+				Value:       config.ContainerPriority_Normal,
+			}, {
+				Name:        "High",
+				Description: "Get ahead of the other containers.",
+				Value:       config.ContainerPriority_High,
+			}},
+		},
+
+		RestartPolicy: config.Parameter{
+			ID:                   id + "RestartPolicy",
+			Name:                 fmt.Sprintf("%s Restart Policy", name),
+			Description:          fmt.Sprintf("When Docker should restart the %s container.", name),
+			Type:                 config.ParameterType_Choice,
+			Default:              map[config.Network]interface{}{config.Network_All: config.RestartPolicy_UnlessStopped},
+			AffectsContainers:    []config.ContainerID{container},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Options: []config.ParameterOption{{
+				Name:        "Unless Stopped",
+				Description: "Restart it whenever it stops, including after a reboot, unless you stopped it yourself.",
+				Value:       config.RestartPolicy_UnlessStopped,
+			}, {
+				Name:        "Always",
+				Description: "Restart it whenever it stops, even if you stopped it yourself (it will be restarted when Docker restarts).",
+				Value:       config.RestartPolicy_Always,
+			}, {
+				Name:        "On Failure",
+				Description: "Only restart it if it crashes; it won't be started again after a reboot.",
+				Value:       config.RestartPolicy_OnFailure,
+			}, {
+				Name:        "Never",
+				Description: "Never restart it automatically.",
+				Value:       config.RestartPolicy_No,
+			}},
+		},
+	}
+}
+
+// Get the parameters for this config
+func (cfg *ContainerResourcesConfig) GetParameters() []*config.Parameter {
+	params := []*config.Parameter{}
+	for _, resources := range []*ContainerResources{&cfg.Eth1, &cfg.Eth2, &cfg.Validator, &cfg.Node, &cfg.Watchtower, &cfg.MevBoost} {
+		params = append(params,
+			&resources.CpuLimit,
+			&resources.MemoryLimit,
+			&resources.Priority,
+			&resources.RestartPolicy,
+		)
+	}
+	return params
+}
+
+// The the title for the config
+func (cfg *ContainerResourcesConfig) GetConfigTitle() string {
+	return cfg.Title
+}
+
+// Get the resource limits for each container that has some, keyed by container name
+func (cfg *ContainerResourcesConfig) GetResourceLimits() (map[string]ContainerResourceLimits, error) {
+	containers := []struct {
+		name      string
+		resources *ContainerResources
+	}{
+		{Eth1ContainerName, &cfg.Eth1},
+		{Eth2ContainerName, &cfg.Eth2},
+		{ValidatorContainerName, &cfg.Validator},
+		{NodeContainerName, &cfg.Node},
+		{WatchtowerContainerName, &cfg.Watchtower},
+		{MevBoostContainerName, &cfg.MevBoost},
+	}
+
+	limits := map[string]ContainerResourceLimits{}
+	for _, container := range containers {
+		containerLimits, err := container.resources.getLimits()
+		if err != nil {
+			return nil, err
+		}
+		if containerLimits != (ContainerResourceLimits{}) {
+			limits[container.name] = containerLimits
+		}
+	}
+	return limits, nil
+}
+
+// Check the resource settings and convert them into Docker's values; settings left at Docker's defaults are left empty
+func (resources *ContainerResources) getLimits() (ContainerResourceLimits, error) {
+	limits := ContainerResourceLimits{}
+
+	cpuLimit := strings.TrimSpace(resources.CpuLimit.Value.(string))
+	if cpuLimit != "" {
+		cpus, err := strconv.ParseFloat(cpuLimit, 64)
+		if err != nil || cpus <= 0 {
+			return limits, fmt.Errorf("invalid %s: [%s] is not a positive number of CPU cores", resources.CpuLimit.Name, cpuLimit)
+		}
+		limits.CpuLimit = cpuLimit
+	}
+
+	memoryLimit := strings.TrimSpace(resources.MemoryLimit.Value.(string))
+	if memoryLimit != "" {
+		if !memoryLimitRegex.MatchString(memoryLimit) {
+			return limits, fmt.Errorf("invalid %s: [%s] is not a memory size like 512m or 8g", resources.MemoryLimit.Name, memoryLimit)
+		}
+		limits.MemoryLimit = strings.ToLower(memoryLimit)
+	}
+
+	switch resources.Priority.Value.(config.ContainerPriority) {
+	case config.ContainerPriority_Low:
+		limits.CpuShares = lowPriorityCpuShares
+		limits.IoWeight = lowPriorityIoWeight
+	case config.ContainerPriority_High:
+		limits.CpuShares = highPriorityCpuShares
+		limits.IoWeight = highPriorityIoWeight
+	}
+
+	restartPolicy := resources.RestartPolicy.Value.(config.RestartPolicy)
+	if restartPolicy != config.RestartPolicy_UnlessStopped {
+		limits.RestartPolicy = restartPolicy
+	}
+	return limits, nil
+}
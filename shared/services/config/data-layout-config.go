@@ -0,0 +1,247 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/mitchellh/go-homedir"
+
+	"github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// The pieces of the Smartnode's data that can be moved to their own folder
+type DataComponent string
+
+const (
+	DataComponent_ExecutionData DataComponent = "execution-data"
+	DataComponent_ConsensusData DataComponent = "consensus-data"
+	DataComponent_RewardsTrees  DataComponent = "rewards-trees"
+	DataComponent_Wallet        DataComponent = "wallet"
+	DataComponent_Logs          DataComponent = "logs"
+)
+
+// Where the relocated components are mounted in the containers
+const (
+	clientDataContainerPath   string = "/ethclient"
+	RelocatedWalletFolder     string = "/.rocketpool/wallet"
+	RelocatedLogsFolder       string = "/.rocketpool/logs"
+	WalletFilename            string = "wallet"
+	PasswordFilename          string = "password"
+	rewardsTreesContainerPath string = DaemonDataPath + "/" + RewardsTreesFolder
+)
+
+// A folder on the host that a relocated component is bind mounted from
+type DataMount struct {
+	Component     DataComponent
+	HostPath      string
+	ContainerPath string
+	Containers    []string
+}
+
+// Configuration for moving parts of the Smartnode's data to separate folders or volumes
+type DataLayoutConfig struct {
+	Title string `yaml:"-"`
+
+	// The folder for the Execution client's chain data
+	ExecutionDataPath config.Parameter `yaml:"executionDataPath,omitempty"`
+
+	// The folder for the Consensus client's chain data
+	ConsensusDataPath config.Parameter `yaml:"consensusDataPath,omitempty"`
+
+	// The folder for the rewards trees and minipool performance files
+	RewardsTreesPath config.Parameter `yaml:"rewardsTreesPath,omitempty"`
+
+	// The folder for the node wallet and its password
+	WalletPath config.Parameter `yaml:"walletPath,omitempty"`
+
+	// The folder for the audit log and crash reports
+	LogsPath config.Parameter `yaml:"logsPath,omitempty"`
+}
+
+// Generates a new data layout config
+func NewDataLayoutConfig(cfg *RocketPoolConfig) *DataLayoutConfig {
+	return &DataLayoutConfig{
+		Title: "Data Layout Settings",
+
+		ExecutionDataPath: config.Parameter{
+			ID:                   "executionDataPath",
+			Name:                 "Execution Chain Data Folder",
+			Description:          "The folder on this machine to keep your Execution client's chain data in, such as a mount point for a large, fast SSD. It's the largest part of the Smartnode's data by far.\n\nLeave this blank to keep it in its Docker volume.\n\nUse `rocketpool service migrate-data execution-data <folder>` to move existing chain data instead of changing this directly, or your client will have to sync again.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Eth1},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		ConsensusDataPath: config.Parameter{
+			ID:                   "consensusDataPath",
+			Name:                 "Consensus Chain Data Folder",
+			Description:          "The folder on this machine to keep your Consensus client's chain data in.\n\nLeave this blank to keep it in its Docker volume.\n\nUse `rocketpool service migrate-data consensus-data <folder>` to move existing chain data instead of changing this directly, or your client will have to sync again.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Eth2},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		RewardsTreesPath: config.Parameter{
+			ID:                   "rewardsTreesPath",
+			Name:                 "Rewards Trees Folder",
+			Description:          "The folder on this machine to keep the rewards trees and minipool performance files in. They grow by a file every rewards interval, and can be kept on slower, cheaper storage.\n\nLeave this blank to keep them in the Smartnode's data folder.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		WalletPath: config.Parameter{
+			ID:                   "walletPath",
+			Name:                 "Wallet Folder",
+			Description:          "The folder on this machine to keep the node wallet and its password in, such as an encrypted volume.\n\nLeave this blank to keep them in the Smartnode's data folder.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		LogsPath: config.Parameter{
+			ID:                   "logsPath",
+			Name:                 "Logs Folder",
+			Description:          "The folder on this machine to keep the audit log and the daemons' crash reports in.\n\nLeave this blank to keep them in the Smartnode's data folder.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+	}
+}
+
+// Get the parameters for this config
+func (cfg *DataLayoutConfig) GetParameters() []*config.Parameter {
+	return []*config.Parameter{
+		&cfg.ExecutionDataPath,
+		&cfg.ConsensusDataPath,
+		&cfg.RewardsTreesPath,
+		&cfg.WalletPath,
+		&cfg.LogsPath,
+	}
+}
+
+// The the title for the config
+func (cfg *DataLayoutConfig) GetConfigTitle() string {
+	return cfg.Title
+}
+
+// Get the components that can be relocated, in the order they're shown
+func GetDataComponents() []DataComponent {
+	return []DataComponent{
+		DataComponent_ExecutionData,
+		DataComponent_ConsensusData,
+		DataComponent_RewardsTrees,
+		DataComponent_Wallet,
+		DataComponent_Logs,
+	}
+}
+
+// Get the parameter holding a component's folder, or nil if it isn't a known component
+func (cfg *DataLayoutConfig) GetParameter(component DataComponent) *config.Parameter {
+	switch component {
+	case DataComponent_ExecutionData:
+		return &cfg.ExecutionDataPath
+	case DataComponent_ConsensusData:
+		return &cfg.ConsensusDataPath
+	case DataComponent_RewardsTrees:
+		return &cfg.RewardsTreesPath
+	case DataComponent_Wallet:
+		return &cfg.WalletPath
+	case DataComponent_Logs:
+		return &cfg.LogsPath
+	}
+	return nil
+}
+
+// Get the folder a component has been moved to on the host, or an empty string if it's in its default location
+func (cfg *DataLayoutConfig) GetPath(component DataComponent) string {
+	param := cfg.GetParameter(component)
+	if param == nil {
+		return ""
+	}
+	path := strings.TrimSpace(param.Value.(string))
+	if path == "" {
+		return ""
+	}
+	expanded, err := homedir.Expand(path)
+	if err != nil {
+		return path
+	}
+	return filepath.Clean(expanded)
+}
+
+// Get the bind mounts for the components that have been moved, given the containers being deployed
+func (cfg *DataLayoutConfig) GetMounts() []DataMount {
+	daemonContainers := []string{ApiContainerName, NodeContainerName, WatchtowerContainerName}
+	mounts := []DataMount{}
+	for _, component := range GetDataComponents() {
+		hostPath := cfg.GetPath(component)
+		if hostPath == "" {
+			continue
+		}
+		mount := DataMount{
+			Component: component,
+			HostPath:  hostPath,
+		}
+		switch component {
+		case DataComponent_ExecutionData:
+			mount.ContainerPath = clientDataContainerPath
+			mount.Containers = []string{Eth1ContainerName}
+		case DataComponent_ConsensusData:
+			mount.ContainerPath = clientDataContainerPath
+			mount.Containers = []string{Eth2ContainerName}
+		case DataComponent_RewardsTrees:
+			mount.ContainerPath = rewardsTreesContainerPath
+			mount.Containers = daemonContainers
+		case DataComponent_Wallet:
+			mount.ContainerPath = RelocatedWalletFolder
+			mount.Containers = daemonContainers
+		case DataComponent_Logs:
+			mount.ContainerPath = RelocatedLogsFolder
+			mount.Containers = daemonContainers
+		}
+		mounts = append(mounts, mount)
+	}
+	return mounts
+}
+
+// Check the folders for problems
+func (cfg *DataLayoutConfig) GetProblems() []string {
+	problems := []string{}
+	checked := []DataComponent{}
+	for _, component := range GetDataComponents() {
+		path := cfg.GetPath(component)
+		if path == "" {
+			continue
+		}
+		if !filepath.IsAbs(path) {
+			problems = append(problems, fmt.Sprintf("The %s [%s] must be an absolute path.", cfg.GetParameter(component).Name, path))
+			continue
+		}
+		for _, otherComponent := range checked {
+			otherPath := cfg.GetPath(otherComponent)
+			if path == otherPath || strings.HasPrefix(path, otherPath+string(filepath.Separator)) || strings.HasPrefix(otherPath, path+string(filepath.Separator)) {
+				problems = append(problems, fmt.Sprintf("The %s [%s] overlaps with the %s [%s]; each component needs a folder of its own.", cfg.GetParameter(component).Name, path, cfg.GetParameter(otherComponent).Name, otherPath))
+			}
+		}
+		checked = append(checked, component)
+	}
+	return problems
+}
@@ -0,0 +1,196 @@
+package config
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/rocket-pool/smartnode/shared/types/config"
+	"gopkg.in/yaml.v2"
+)
+
+// The version of the declarative config document schema
+const DeclarativeSchemaVersion uint64 = 1
+
+// The reserved top-level key holding the schema version of a declarative config document
+const declarativeVersionKey string = "schemaVersion"
+
+// The error returned when a declarative config document is invalid; it lists every problem that was found
+type DeclarativeConfigError struct {
+	Problems []string
+}
+
+func (e *DeclarativeConfigError) Error() string {
+	return fmt.Sprintf("the config document is invalid:\n\t%s", strings.Join(e.Problems, "\n\t"))
+}
+
+// Apply a declarative config document on top of this config.
+// The document is a YAML map with the same layout as the user settings file: a section per subconfig (plus "root" for the
+// top-level parameters), each mapping parameter IDs to values. Settings that aren't in the document are left unchanged.
+// Unknown sections or parameters, values of the wrong type and invalid choices are all reported as errors,
+// and nothing is applied unless the whole document is valid.
+func (cfg *RocketPoolConfig) ApplyDeclarativeConfig(document []byte) error {
+
+	// Parse the document
+	var doc map[string]interface{}
+	if err := yaml.UnmarshalStrict(document, &doc); err != nil {
+		return fmt.Errorf("error parsing config document: %w", err)
+	}
+
+	problems := []string{}
+	versionValue, exists := doc[declarativeVersionKey]
+	if !exists {
+		problems = append(problems, fmt.Sprintf("[%s] is missing; it must be set to %d", declarativeVersionKey, DeclarativeSchemaVersion))
+	} else if version, ok := versionValue.(int); !ok || uint64(version) != DeclarativeSchemaVersion {
+		problems = append(problems, fmt.Sprintf("[%s] is %v, but only version %d is supported", declarativeVersionKey, versionValue, DeclarativeSchemaVersion))
+	}
+
+	// Get the parameters of each section
+	sections := map[string][]*config.Parameter{
+		rootConfigName: cfg.GetParameters(),
+	}
+	for name, subconfig := range cfg.GetSubconfigs() {
+		sections[name] = subconfig.GetParameters()
+	}
+
+	// Validate every value before applying any of them
+	type update struct {
+		param *config.Parameter
+		value interface{}
+	}
+	updates := []update{}
+	var newNetwork interface{}
+	sectionNames := make([]string, 0, len(doc))
+	for name := range doc {
+		sectionNames = append(sectionNames, name)
+	}
+	sort.Strings(sectionNames)
+	for _, sectionName := range sectionNames {
+		if sectionName == declarativeVersionKey {
+			continue
+		}
+		params, exists := sections[sectionName]
+		if !exists {
+			problems = append(problems, fmt.Sprintf("unknown section [%s]", sectionName))
+			continue
+		}
+		values, ok := doc[sectionName].(map[interface{}]interface{})
+		if !ok {
+			problems = append(problems, fmt.Sprintf("section [%s] must be a map of parameter IDs to values", sectionName))
+			continue
+		}
+
+		paramMap := map[string]*config.Parameter{}
+		for _, param := range params {
+			paramMap[param.ID] = param
+		}
+		for key, rawValue := range values {
+			id := fmt.Sprint(key)
+			param, exists := paramMap[id]
+			if !exists {
+				problems = append(problems, fmt.Sprintf("unknown parameter [%s.%s]", sectionName, id))
+				continue
+			}
+			value, err := parseDeclarativeValue(param, rawValue)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("[%s.%s]: %s", sectionName, id, err.Error()))
+				continue
+			}
+			if param == &cfg.Smartnode.Network {
+				newNetwork = value
+				continue
+			}
+			updates = append(updates, update{param, value})
+		}
+	}
+	if len(problems) > 0 {
+		return &DeclarativeConfigError{Problems: problems}
+	}
+
+	// Apply the network first, since it changes the defaults of the other parameters
+	if newNetwork != nil {
+		cfg.ChangeNetwork(newNetwork.(config.Network))
+	}
+	for _, update := range updates {
+		update.param.Value = update.value
+	}
+
+	// Check the resulting configuration as a whole
+	if errors := cfg.Validate(); len(errors) > 0 {
+		return &DeclarativeConfigError{Problems: errors}
+	}
+	return nil
+
+}
+
+// Convert a value from a declarative config document into the type used by the parameter
+func parseDeclarativeValue(param *config.Parameter, rawValue interface{}) (interface{}, error) {
+	switch param.Type {
+	case config.ParameterType_Bool:
+		value, ok := rawValue.(bool)
+		if !ok {
+			return nil, fmt.Errorf("expected a bool but got [%v]", rawValue)
+		}
+		return value, nil
+
+	case config.ParameterType_Int:
+		value, ok := rawValue.(int)
+		if !ok {
+			return nil, fmt.Errorf("expected an integer but got [%v]", rawValue)
+		}
+		return int64(value), nil
+
+	case config.ParameterType_Uint, config.ParameterType_Uint16:
+		value, ok := rawValue.(int)
+		if !ok || value < 0 {
+			return nil, fmt.Errorf("expected a non-negative integer but got [%v]", rawValue)
+		}
+		if param.Type == config.ParameterType_Uint16 {
+			if value > math.MaxUint16 {
+				return nil, fmt.Errorf("[%d] is larger than the maximum of %d", value, math.MaxUint16)
+			}
+			return uint16(value), nil
+		}
+		return uint64(value), nil
+
+	case config.ParameterType_Float:
+		switch value := rawValue.(type) {
+		case float64:
+			return value, nil
+		case int:
+			return float64(value), nil
+		}
+		return nil, fmt.Errorf("expected a number but got [%v]", rawValue)
+
+	case config.ParameterType_String:
+		value, ok := rawValue.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected a string but got [%v]; quote it if it's meant to be a string", rawValue)
+		}
+		if param.MaxLength > 0 && len(value) > param.MaxLength {
+			return nil, fmt.Errorf("[%s] is longer than the max length of %d", value, param.MaxLength)
+		}
+		if !param.CanBeBlank && value == "" {
+			return nil, fmt.Errorf("cannot be blank")
+		}
+		if param.Regex != "" && value != "" && !regexp.MustCompile(param.Regex).MatchString(value) {
+			return nil, fmt.Errorf("[%s] did not match the expected format", value)
+		}
+		return value, nil
+
+	case config.ParameterType_Choice:
+		selection := fmt.Sprint(rawValue)
+		options := make([]string, 0, len(param.Options))
+		for _, option := range param.Options {
+			if fmt.Sprint(option.Value) == selection {
+				return option.Value, nil
+			}
+			options = append(options, fmt.Sprint(option.Value))
+		}
+		return nil, fmt.Errorf("[%s] is not one of the options (%s)", selection, strings.Join(options, ", "))
+	}
+
+	return nil, fmt.Errorf("unsupported parameter type [%s]", param.Type)
+}
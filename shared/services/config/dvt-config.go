@@ -0,0 +1,189 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Configuration for operating minipool validators with an external distributed validator cluster
+type DvtConfig struct {
+	Title string `yaml:"-"`
+
+	// Toggle for handing new minipools' validator keys off to the DVT cluster
+	Enabled config.Parameter `yaml:"enabled,omitempty"`
+
+	// The DVT technology the cluster runs
+	Provider config.Parameter `yaml:"provider,omitempty"`
+
+	// The URL of the DVT client's API, used to track the cluster's health
+	ApiUrl config.Parameter `yaml:"apiUrl,omitempty"`
+
+	parent *RocketPoolConfig
+}
+
+// A minipool whose validator key was handed off to the DVT cluster
+type DvtMinipool struct {
+	Pubkey      types.ValidatorPubkey `json:"pubkey"`
+	WalletIndex uint                  `json:"walletIndex"`
+	Provider    config.DvtProvider    `json:"provider"`
+	HandoffTime time.Time             `json:"handoffTime"`
+}
+
+// The minipools operated by the DVT cluster, which are recorded when they're created instead of in the config UI
+type DvtSettings struct {
+	Minipools map[common.Address]DvtMinipool `json:"minipools"`
+}
+
+// Generates a new DVT config
+func NewDvtConfig(cfg *RocketPoolConfig) *DvtConfig {
+	return &DvtConfig{
+		Title: "Distributed Validator Settings",
+
+		Enabled: config.Parameter{
+			ID:   "enabled",
+			Name: "Enable DVT Mode",
+			Description: "Enable this to have new minipools' validators operated by a distributed validator (DVT) cluster that you run outside of the Smartnode.\n\n" +
+				"Deposits and the minipool lifecycle stay in the Smartnode, but the new validator's key isn't loaded into your Validator client. " +
+				"Instead, it's written as an EIP-2335 keystore to the `dvt-handoff` folder in your data directory, so you can split it into key shares for your cluster.\n\n" +
+				"Minipools created before this was enabled are unaffected.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		Provider: config.Parameter{
+			ID:                   "provider",
+			Name:                 "DVT Provider",
+			Description:          "Select the distributed validator technology your cluster runs.",
+			Type:                 config.ParameterType_Choice,
+			Default:              map[config.Network]interface{}{config.Network_All: config.DvtProvider_Obol},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Options: []config.ParameterOption{{
+				Name:        "Obol",
+				Description: "Your cluster runs Obol's Charon middleware. Split the handed off keystore with `charon create cluster --split-existing-keys`.",
+				Value:       config.DvtProvider_Obol,
+			}, {
+				Name:        "SSV",
+				Description: "Your validator is operated by SSV Network operators. Split the handed off keystore with the SSV key splitting tool and register it with the SSV Network.",
+				Value:       config.DvtProvider_Ssv,
+			}},
+		},
+
+		ApiUrl: config.Parameter{
+			ID:   "apiUrl",
+			Name: "DVT Client API URL",
+			Description: "The URL of your DVT client's API, which the node daemon checks to track your cluster's health.\n\n" +
+				"For Obol, this is Charon's monitoring address (e.g. http://charon:3620). For SSV, this is your SSV node's API (e.g. http://ssv-node:16000).",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		parent: cfg,
+	}
+}
+
+// Get the parameters for this config
+func (cfg *DvtConfig) GetParameters() []*config.Parameter {
+	return []*config.Parameter{
+		&cfg.Enabled,
+		&cfg.Provider,
+		&cfg.ApiUrl,
+	}
+}
+
+// The the title for the config
+func (cfg *DvtConfig) GetConfigTitle() string {
+	return cfg.Title
+}
+
+// Get any problems with the DVT settings
+func (cfg *DvtConfig) GetProblems() []string {
+	if cfg.Enabled.Value != true {
+		return []string{}
+	}
+	apiUrl := cfg.ApiUrl.Value.(string)
+	if apiUrl == "" {
+		return []string{"DVT mode is enabled, but the DVT Client API URL is blank, so your cluster's health can't be tracked."}
+	}
+	if parsed, err := url.Parse(apiUrl); err != nil || parsed.Scheme == "" || parsed.Host == "" {
+		return []string{fmt.Sprintf("The DVT Client API URL [%s] isn't a valid URL.", apiUrl)}
+	}
+	return []string{}
+}
+
+// Get the pubkeys of the minipools operated by the DVT cluster
+func (s *DvtSettings) GetPubkeys() map[types.ValidatorPubkey]bool {
+	pubkeys := map[types.ValidatorPubkey]bool{}
+	for _, minipool := range s.Minipools {
+		pubkeys[minipool.Pubkey] = true
+	}
+	return pubkeys
+}
+
+// Get the DVT minipool with the provided pubkey, if there is one
+func (s *DvtSettings) GetMinipoolByPubkey(pubkey types.ValidatorPubkey) (common.Address, DvtMinipool, bool) {
+	for address, minipool := range s.Minipools {
+		if minipool.Pubkey == pubkey {
+			return address, minipool, true
+		}
+	}
+	return common.Address{}, DvtMinipool{}, false
+}
+
+// Load the DVT minipools from the provided path
+func LoadDvtSettings(path string) (*DvtSettings, error) {
+	settings := &DvtSettings{
+		Minipools: map[common.Address]DvtMinipool{},
+	}
+	bytes, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return settings, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading DVT settings file [%s]: %w", path, err)
+	}
+	err = json.Unmarshal(bytes, settings)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing DVT settings file [%s]: %w", path, err)
+	}
+	if settings.Minipools == nil {
+		settings.Minipools = map[common.Address]DvtMinipool{}
+	}
+	return settings, nil
+}
+
+// Save the DVT minipools to the provided path
+func (s *DvtSettings) Save(path string) error {
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return fmt.Errorf("error creating DVT settings directory: %w", err)
+	}
+	bytes, err := json.MarshalIndent(s, "", "    ")
+	if err != nil {
+		return fmt.Errorf("error serializing DVT settings: %w", err)
+	}
+	err = os.WriteFile(path, bytes, 0644)
+	if err != nil {
+		return fmt.Errorf("error writing DVT settings file [%s]: %w", path, err)
+	}
+	return nil
+}
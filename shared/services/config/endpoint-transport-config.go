@@ -0,0 +1,233 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Transport settings for one of the externally managed client endpoints
+type EndpointTransport struct {
+	// Basic authentication
+	Username config.Parameter `yaml:"username,omitempty"`
+	Password config.Parameter `yaml:"password,omitempty"`
+
+	// Bearer token authentication
+	BearerToken config.Parameter `yaml:"bearerToken,omitempty"`
+
+	// Client TLS certificate and key, and the CA that signed the endpoint's certificate
+	TlsCertPath config.Parameter `yaml:"tlsCertPath,omitempty"`
+	TlsKeyPath  config.Parameter `yaml:"tlsKeyPath,omitempty"`
+	TlsCaPath   config.Parameter `yaml:"tlsCaPath,omitempty"`
+
+	// HTTP(S) or SOCKS5 proxy
+	ProxyUrl config.Parameter `yaml:"proxyUrl,omitempty"`
+}
+
+// The transport settings for an endpoint, with the file paths resolved for wherever they're being used
+type EndpointTransportSettings struct {
+	Username    string
+	Password    string
+	BearerToken string
+	TlsCertPath string
+	TlsKeyPath  string
+	TlsCaPath   string
+	ProxyUrl    *url.URL
+}
+
+// Configuration for connecting to externally managed clients that need authentication, client certificates or a proxy
+type EndpointTransportConfig struct {
+	Title string `yaml:"-"`
+
+	// The externally managed Execution client
+	ExecutionClient EndpointTransport `yaml:"executionClient,omitempty"`
+
+	// The externally managed Consensus client
+	ConsensusClient EndpointTransport `yaml:"consensusClient,omitempty"`
+
+	// The fallback Execution client
+	FallbackExecutionClient EndpointTransport `yaml:"fallbackExecutionClient,omitempty"`
+
+	// The fallback Consensus client
+	FallbackConsensusClient EndpointTransport `yaml:"fallbackConsensusClient,omitempty"`
+
+	parent *RocketPoolConfig
+}
+
+// Generates a new endpoint transport config
+func NewEndpointTransportConfig(cfg *RocketPoolConfig) *EndpointTransportConfig {
+	return &EndpointTransportConfig{
+		Title: "External Endpoint Transport Settings",
+
+		ExecutionClient:         newEndpointTransport("ec", "External Execution Client"),
+		ConsensusClient:         newEndpointTransport("cc", "External Consensus Client"),
+		FallbackExecutionClient: newEndpointTransport("fallbackEc", "Fallback Execution Client"),
+		FallbackConsensusClient: newEndpointTransport("fallbackCc", "Fallback Consensus Client"),
+
+		parent: cfg,
+	}
+}
+
+// Creates the transport parameters for one of the endpoints
+func newEndpointTransport(id string, name string) EndpointTransport {
+	newParam := func(suffix string, paramName string, description string) config.Parameter {
+		return config.Parameter{
+			ID:                   id + suffix,
+			Name:                 fmt.Sprintf("%s %s", name, paramName),
+			Description:          description,
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		}
+	}
+	fileNote := "The file must be in your Smartnode data folder so the Smartnode's containers can read it."
+
+	return EndpointTransport{
+		Username:    newParam("Username", "Username", fmt.Sprintf("The username for the %s's HTTP basic authentication, if it uses it.", name)),
+		Password:    newParam("Password", "Password", fmt.Sprintf("The password for the %s's HTTP basic authentication, if it uses it.", name)),
+		BearerToken: newParam("BearerToken", "Bearer Token", fmt.Sprintf("The token to send in the Authorization header of every request to the %s, if it uses bearer token authentication.", name)),
+		TlsCertPath: newParam("TlsCertPath", "TLS Client Certificate", fmt.Sprintf("The path of the PEM-encoded client certificate to present to the %s, if it requires one.\n\n%s", name, fileNote)),
+		TlsKeyPath:  newParam("TlsKeyPath", "TLS Client Key", fmt.Sprintf("The path of the PEM-encoded private key for the client certificate.\n\n%s", fileNote)),
+		TlsCaPath:   newParam("TlsCaPath", "TLS CA Certificate", fmt.Sprintf("The path of the PEM-encoded CA certificate that signed the %s's certificate, if it isn't signed by a public CA.\n\n%s", name, fileNote)),
+		ProxyUrl:    newParam("ProxyUrl", "Proxy URL", fmt.Sprintf("The proxy to connect to the %s through, such as `http://proxy:3128` or `socks5://127.0.0.1:1080`.", name)),
+	}
+}
+
+// Get the parameters for this config
+func (cfg *EndpointTransportConfig) GetParameters() []*config.Parameter {
+	params := []*config.Parameter{}
+	for _, transport := range []*EndpointTransport{&cfg.ExecutionClient, &cfg.ConsensusClient, &cfg.FallbackExecutionClient, &cfg.FallbackConsensusClient} {
+		params = append(params,
+			&transport.Username,
+			&transport.Password,
+			&transport.BearerToken,
+			&transport.TlsCertPath,
+			&transport.TlsKeyPath,
+			&transport.TlsCaPath,
+			&transport.ProxyUrl,
+		)
+	}
+	return params
+}
+
+// The the title for the config
+func (cfg *EndpointTransportConfig) GetConfigTitle() string {
+	return cfg.Title
+}
+
+// Get the transport settings for the primary Execution client, or nil if it's locally managed
+func (cfg *EndpointTransportConfig) GetExecutionClientSettings() (*EndpointTransportSettings, error) {
+	if !cfg.parent.IsNativeMode && cfg.parent.ExecutionClientMode.Value.(config.Mode) == config.Mode_Local {
+		return nil, nil
+	}
+	return cfg.getSettings(&cfg.ExecutionClient)
+}
+
+// Get the transport settings for the primary Consensus client, or nil if it's locally managed
+func (cfg *EndpointTransportConfig) GetConsensusClientSettings() (*EndpointTransportSettings, error) {
+	if !cfg.parent.IsNativeMode && cfg.parent.ConsensusClientMode.Value.(config.Mode) == config.Mode_Local {
+		return nil, nil
+	}
+	return cfg.getSettings(&cfg.ConsensusClient)
+}
+
+// Get the transport settings for the fallback Execution client
+func (cfg *EndpointTransportConfig) GetFallbackExecutionClientSettings() (*EndpointTransportSettings, error) {
+	return cfg.getSettings(&cfg.FallbackExecutionClient)
+}
+
+// Get the transport settings for the fallback Consensus client
+func (cfg *EndpointTransportConfig) GetFallbackConsensusClientSettings() (*EndpointTransportSettings, error) {
+	return cfg.getSettings(&cfg.FallbackConsensusClient)
+}
+
+// Check the transport settings and resolve them, or return nil if none of them are set
+func (cfg *EndpointTransportConfig) getSettings(transport *EndpointTransport) (*EndpointTransportSettings, error) {
+	settings := EndpointTransportSettings{
+		Username:    transport.Username.Value.(string),
+		Password:    transport.Password.Value.(string),
+		BearerToken: strings.TrimSpace(transport.BearerToken.Value.(string)),
+	}
+
+	if settings.Password != "" && settings.Username == "" {
+		return nil, fmt.Errorf("%s is set but %s isn't", transport.Password.Name, transport.Username.Name)
+	}
+	if settings.Username != "" && settings.BearerToken != "" {
+		return nil, fmt.Errorf("%s and %s can't both be set; an endpoint can only use one kind of authentication", transport.Username.Name, transport.BearerToken.Name)
+	}
+
+	var err error
+	if settings.TlsCertPath, err = cfg.getDaemonFilePath(&transport.TlsCertPath); err != nil {
+		return nil, err
+	}
+	if settings.TlsKeyPath, err = cfg.getDaemonFilePath(&transport.TlsKeyPath); err != nil {
+		return nil, err
+	}
+	if settings.TlsCaPath, err = cfg.getDaemonFilePath(&transport.TlsCaPath); err != nil {
+		return nil, err
+	}
+	if (settings.TlsCertPath == "") != (settings.TlsKeyPath == "") {
+		return nil, fmt.Errorf("%s and %s have to be set together", transport.TlsCertPath.Name, transport.TlsKeyPath.Name)
+	}
+
+	proxyUrl := strings.TrimSpace(transport.ProxyUrl.Value.(string))
+	if proxyUrl != "" {
+		settings.ProxyUrl, err = url.Parse(proxyUrl)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", transport.ProxyUrl.Name, err)
+		}
+		switch settings.ProxyUrl.Scheme {
+		case "http", "https", "socks5":
+		default:
+			return nil, fmt.Errorf("invalid %s [%s]: only http, https and socks5 proxies are supported", transport.ProxyUrl.Name, proxyUrl)
+		}
+	}
+
+	if settings == (EndpointTransportSettings{}) {
+		return nil, nil
+	}
+	return &settings, nil
+}
+
+// Get the path that the daemons can read a file from; in Docker mode, it has to be in the data folder that's mounted into their containers
+func (cfg *EndpointTransportConfig) getDaemonFilePath(param *config.Parameter) (string, error) {
+	path := strings.TrimSpace(param.Value.(string))
+	if path == "" {
+		return "", nil
+	}
+	path, err := homedir.Expand(path)
+	if err != nil {
+		return "", fmt.Errorf("error expanding %s: %w", param.Name, err)
+	}
+	if cfg.parent.IsNativeMode || strings.HasPrefix(path, DaemonDataPath+"/") {
+		return path, nil
+	}
+
+	dataPath, err := homedir.Expand(cfg.parent.Smartnode.DataPath.Value.(string))
+	if err != nil {
+		return "", fmt.Errorf("error expanding data directory: %w", err)
+	}
+	relativePath, err := filepath.Rel(dataPath, path)
+	if err != nil || relativePath == ".." || strings.HasPrefix(relativePath, "../") {
+		return "", fmt.Errorf("%s [%s] isn't in your Smartnode data folder (%s)", param.Name, path, dataPath)
+	}
+	return filepath.Join(DaemonDataPath, relativePath), nil
+}
+
+// Check the transport settings for every endpoint
+func (cfg *EndpointTransportConfig) GetProblems() []string {
+	problems := []string{}
+	for _, transport := range []*EndpointTransport{&cfg.ExecutionClient, &cfg.ConsensusClient, &cfg.FallbackExecutionClient, &cfg.FallbackConsensusClient} {
+		if _, err := cfg.getSettings(transport); err != nil {
+			problems = append(problems, fmt.Sprintf("Your external endpoint transport settings are invalid: %s.", err.Error()))
+		}
+	}
+	return problems
+}
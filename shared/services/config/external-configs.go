@@ -13,6 +13,12 @@ type ExternalExecutionConfig struct {
 
 	// The URL of the websocket endpoint
 	WsUrl config.Parameter `yaml:"wsUrl,omitempty"`
+
+	// The URL of the Engine API endpoint, used by a locally managed Consensus client
+	EngineUrl config.Parameter `yaml:"engineUrl,omitempty"`
+
+	// The path of the JWT secret shared with the Execution client, used by a locally managed Consensus client
+	JwtSecretPath config.Parameter `yaml:"jwtSecretPath,omitempty"`
 }
 
 // Configuration for external Consensus clients
@@ -143,6 +149,30 @@ func NewExternalExecutionConfig(cfg *RocketPoolConfig) *ExternalExecutionConfig
 			CanBeBlank:           false,
 			OverwriteOnUpgrade:   false,
 		},
+
+		EngineUrl: config.Parameter{
+			ID:                   "engineUrl",
+			Name:                 "Engine API URL",
+			Description:          "The URL of the Engine API endpoint for your external Execution client, for example 'http://192.168.1.100:8551'.\nThis is only needed if your Consensus client is locally managed, since it has to drive the Execution client through this API.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Eth2},
+			EnvironmentVariables: []string{"EC_ENGINE_ENDPOINT"},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		JwtSecretPath: config.Parameter{
+			ID:                   "jwtSecretPath",
+			Name:                 "JWT Secret Path",
+			Description:          "The path on this machine of a copy of the JWT secret file your external Execution client uses to authenticate its Engine API.\nThis is only needed if your Consensus client is locally managed; the Smartnode will give it the secret when the service starts.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Eth2},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
 	}
 }
 
@@ -512,6 +542,8 @@ func (cfg *ExternalExecutionConfig) GetParameters() []*config.Parameter {
 	return []*config.Parameter{
 		&cfg.HttpUrl,
 		&cfg.WsUrl,
+		&cfg.EngineUrl,
+		&cfg.JwtSecretPath,
 	}
 }
 
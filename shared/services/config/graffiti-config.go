@@ -0,0 +1,348 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/smartnode/shared"
+	"github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Graffiti settings
+const (
+	// The most bytes a block's graffiti can hold
+	MaxGraffitiLength int = 32
+
+	// The separator between the entries of a graffiti rotation list
+	GraffitiRotationSeparator string = ";"
+
+	// The env var that tells the VC which file in the validators folder holds the graffiti the node daemon renders
+	GraffitiFileEnvVar string = "GRAFFITI_FILE"
+)
+
+// Graffiti template placeholders
+const (
+	GraffitiPlaceholderRp        string = "{rp}"
+	GraffitiPlaceholderVersion   string = "{version}"
+	GraffitiPlaceholderEc        string = "{ec}"
+	GraffitiPlaceholderCc        string = "{cc}"
+	GraffitiPlaceholderEcInitial string = "{ecInitial}"
+	GraffitiPlaceholderCcInitial string = "{ccInitial}"
+	GraffitiPlaceholderCustom    string = "{custom}"
+)
+
+// Matches anything that looks like a placeholder in a graffiti template
+var graffitiPlaceholderRegex = regexp.MustCompile(`\{[a-zA-Z]*\}`)
+
+// Configuration for the graffiti the validator client puts in proposed blocks
+type GraffitiConfig struct {
+	Title string `yaml:"-"`
+
+	// The node's graffiti template
+	Template config.Parameter `yaml:"template,omitempty"`
+
+	// Templates to cycle through instead of the node's template
+	Rotation config.Parameter `yaml:"rotation,omitempty"`
+
+	// How long to use each entry of the rotation for, in hours
+	RotationInterval config.Parameter `yaml:"rotationInterval,omitempty"`
+
+	parent *RocketPoolConfig
+}
+
+// The graffiti for one of the node's minipools, replacing the node's graffiti
+type GraffitiOverride struct {
+	Template string   `json:"template,omitempty"`
+	Rotation []string `json:"rotation,omitempty"`
+}
+
+// The per-minipool graffiti settings, which are managed with `rocketpool node set-graffiti` instead of the config UI
+type GraffitiSettings struct {
+	Minipools map[common.Address]GraffitiOverride `json:"minipools"`
+}
+
+// Generates a new graffiti config
+func NewGraffitiConfig(cfg *RocketPoolConfig) *GraffitiConfig {
+	placeholders := fmt.Sprintf("%s (the Rocket Pool version and client tag, e.g. `RP-GL v%s`), %s, %s, %s, %s, %s, and %s (your Custom Graffiti)",
+		GraffitiPlaceholderRp, shared.RocketPoolVersion, GraffitiPlaceholderVersion, GraffitiPlaceholderEc, GraffitiPlaceholderCc, GraffitiPlaceholderEcInitial, GraffitiPlaceholderCcInitial, GraffitiPlaceholderCustom)
+
+	return &GraffitiConfig{
+		Title: "Graffiti Settings",
+
+		Template: config.Parameter{
+			ID:   "template",
+			Name: "Graffiti Template",
+			Description: "The graffiti to put in the blocks your validators propose. It can use these placeholders:\n" + placeholders + ".\n\n" +
+				"Leave this blank to use the standard Rocket Pool graffiti, followed by your Custom Graffiti in brackets if you've set one.\n" +
+				"The rendered graffiti can't be longer than 32 bytes.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Validator, config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		Rotation: config.Parameter{
+			ID:   "rotation",
+			Name: "Graffiti Rotation",
+			Description: fmt.Sprintf("A list of graffiti templates separated by `%s` to cycle through instead of the Graffiti Template, switching every Rotation Interval.\n\n", GraffitiRotationSeparator) +
+				"Rotation only works with clients that read their graffiti from a file as they propose (Lighthouse, Prysm and Teku); the others always use the first entry.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Validator, config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		RotationInterval: config.Parameter{
+			ID:                   "rotationInterval",
+			Name:                 "Rotation Interval",
+			Description:          "How many hours to use each entry of the Graffiti Rotation for before switching to the next one.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(24)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		parent: cfg,
+	}
+}
+
+// Get the parameters for this config
+func (cfg *GraffitiConfig) GetParameters() []*config.Parameter {
+	return []*config.Parameter{
+		&cfg.Template,
+		&cfg.Rotation,
+		&cfg.RotationInterval,
+	}
+}
+
+// The the title for the config
+func (cfg *GraffitiConfig) GetConfigTitle() string {
+	return cfg.Title
+}
+
+// Get the node's graffiti rotation list, or nil if it doesn't have one
+func (cfg *GraffitiConfig) GetRotation() []string {
+	return ParseGraffitiRotation(cfg.Rotation.Value.(string))
+}
+
+// Split a graffiti rotation list into its entries
+func ParseGraffitiRotation(rotation string) []string {
+	var entries []string
+	for _, entry := range strings.Split(rotation, GraffitiRotationSeparator) {
+		entry = strings.TrimSpace(entry)
+		if entry != "" {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// Get the node's graffiti at the provided time
+func (cfg *GraffitiConfig) GetNodeGraffiti(t time.Time) (string, error) {
+	return cfg.RenderGraffiti(cfg.Template.Value.(string), cfg.GetRotation(), t)
+}
+
+// Get the graffiti for a minipool at the provided time, using the node's graffiti if it doesn't have an override
+func (cfg *GraffitiConfig) GetMinipoolGraffiti(override GraffitiOverride, t time.Time) (string, error) {
+	if strings.TrimSpace(override.Template) == "" && len(override.Rotation) == 0 {
+		return cfg.GetNodeGraffiti(t)
+	}
+	return cfg.RenderGraffiti(override.Template, override.Rotation, t)
+}
+
+// Render a graffiti template, or the entry of the rotation list that's active at the provided time if there is one
+func (cfg *GraffitiConfig) RenderGraffiti(template string, rotation []string, t time.Time) (string, error) {
+	if len(rotation) > 0 {
+		interval := cfg.RotationInterval.Value.(uint64)
+		if interval == 0 {
+			interval = 1
+		}
+		period := uint64(t.Unix()) / (interval * 3600)
+		template = rotation[period%uint64(len(rotation))]
+	}
+
+	values := cfg.getPlaceholderValues()
+	if strings.TrimSpace(template) == "" {
+		if values[GraffitiPlaceholderCustom] == "" {
+			return values[GraffitiPlaceholderRp], nil
+		}
+		return fmt.Sprintf("%s (%s)", values[GraffitiPlaceholderRp], values[GraffitiPlaceholderCustom]), nil
+	}
+
+	var unknownPlaceholder string
+	graffiti := graffitiPlaceholderRegex.ReplaceAllStringFunc(template, func(placeholder string) string {
+		value, exists := values[placeholder]
+		if !exists {
+			unknownPlaceholder = placeholder
+		}
+		return value
+	})
+	if unknownPlaceholder != "" {
+		return "", fmt.Errorf("graffiti template [%s] has an unknown placeholder %s", template, unknownPlaceholder)
+	}
+	if strings.ContainsAny(graffiti, "\r\n") {
+		return "", fmt.Errorf("graffiti template [%s] can't contain line breaks", template)
+	}
+	if len(graffiti) > MaxGraffitiLength {
+		return "", fmt.Errorf("graffiti template [%s] renders to [%s], which is longer than %d bytes", template, graffiti, MaxGraffitiLength)
+	}
+	return graffiti, nil
+}
+
+// Check every entry of a graffiti template or rotation list
+func (cfg *GraffitiConfig) ValidateGraffiti(template string, rotation []string) error {
+	if _, err := cfg.RenderGraffiti(template, nil, time.Time{}); err != nil {
+		return err
+	}
+	for _, entry := range rotation {
+		if _, err := cfg.RenderGraffiti(entry, nil, time.Time{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Get the value of each placeholder for the current client selection
+func (cfg *GraffitiConfig) getPlaceholderValues() map[string]string {
+	versionString := fmt.Sprintf("v%s", shared.RocketPoolVersion)
+
+	ecName := "external"
+	if !cfg.parent.IsNativeMode && cfg.parent.ExecutionClientMode.Value.(config.Mode) == config.Mode_Local {
+		ecName = fmt.Sprint(cfg.parent.ExecutionClient.Value)
+	}
+	ecInitial := "X" // X is for external / unknown
+	if ecName != "external" {
+		ecInitial = strings.ToUpper(ecName[:1])
+	}
+
+	cc := cfg.parent.GetGraffitiConsensusClient()
+	ccName := fmt.Sprint(cc)
+	var ccInitial string
+	switch cc {
+	case config.ConsensusClient_Unknown:
+		ccInitial = "X"
+	case config.ConsensusClient_Lodestar:
+		ccInitial = "S" // Lodestar is special because it conflicts with Lighthouse
+	default:
+		ccInitial = strings.ToUpper(ccName[:1])
+	}
+
+	// Long version strings don't leave room for the client tag
+	identifier := ""
+	if len(versionString) < 8 {
+		identifier = fmt.Sprintf("-%s%s", ecInitial, ccInitial)
+	}
+
+	return map[string]string{
+		GraffitiPlaceholderRp:        fmt.Sprintf("RP%s %s", identifier, versionString),
+		GraffitiPlaceholderVersion:   versionString,
+		GraffitiPlaceholderEc:        ecName,
+		GraffitiPlaceholderCc:        ccName,
+		GraffitiPlaceholderEcInitial: ecInitial,
+		GraffitiPlaceholderCcInitial: ccInitial,
+		GraffitiPlaceholderCustom:    cfg.parent.GetCustomGraffiti(),
+	}
+}
+
+// Check the node's graffiti settings
+func (cfg *GraffitiConfig) GetProblems() []string {
+	if err := cfg.ValidateGraffiti(cfg.Template.Value.(string), cfg.GetRotation()); err != nil {
+		return []string{fmt.Sprintf("Your graffiti settings are invalid: %s.", err.Error())}
+	}
+	return []string{}
+}
+
+// Get the Consensus client whose validator client proposes the node's blocks
+func (cfg *RocketPoolConfig) GetGraffitiConsensusClient() config.ConsensusClient {
+	if cfg.IsNativeMode {
+		return cfg.Native.ConsensusClient.Value.(config.ConsensusClient)
+	}
+	cc, _ := cfg.GetSelectedConsensusClient()
+	return cc
+}
+
+// Get the user's Custom Graffiti for the selected Consensus client mode
+func (cfg *RocketPoolConfig) GetCustomGraffiti() string {
+	if cfg.IsNativeMode || cfg.ConsensusClientMode.Value.(config.Mode) == config.Mode_Local {
+		return cfg.ConsensusCommon.Graffiti.Value.(string)
+	}
+	switch cfg.ExternalConsensusClient.Value.(config.ConsensusClient) {
+	case config.ConsensusClient_Lighthouse:
+		return cfg.ExternalLighthouse.Graffiti.Value.(string)
+	case config.ConsensusClient_Lodestar:
+		return cfg.ExternalLodestar.Graffiti.Value.(string)
+	case config.ConsensusClient_Nimbus:
+		return cfg.ExternalNimbus.Graffiti.Value.(string)
+	case config.ConsensusClient_Prysm:
+		return cfg.ExternalPrysm.Graffiti.Value.(string)
+	case config.ConsensusClient_Teku:
+		return cfg.ExternalTeku.Graffiti.Value.(string)
+	}
+	return ""
+}
+
+// Check if a validator client can read its graffiti from a file, and whether it rereads the file for every proposal
+func GetGraffitiFileSupport(cc config.ConsensusClient) (supported bool, rereadsFile bool, perValidator bool) {
+	switch cc {
+	case config.ConsensusClient_Lighthouse:
+		return true, true, true
+	case config.ConsensusClient_Prysm:
+		return true, false, true
+	case config.ConsensusClient_Teku:
+		return true, true, false
+	default:
+		return false, false, false
+	}
+}
+
+// Load the per-minipool graffiti settings. Returns empty settings if the file doesn't exist yet.
+func LoadGraffitiSettings(path string) (*GraffitiSettings, error) {
+	settings := &GraffitiSettings{
+		Minipools: map[common.Address]GraffitiOverride{},
+	}
+	bytes, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return settings, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading graffiti settings file [%s]: %w", path, err)
+	}
+	err = json.Unmarshal(bytes, settings)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing graffiti settings file [%s]: %w", path, err)
+	}
+	if settings.Minipools == nil {
+		settings.Minipools = map[common.Address]GraffitiOverride{}
+	}
+	return settings, nil
+}
+
+// Save the per-minipool graffiti settings to the provided path
+func (s *GraffitiSettings) Save(path string) error {
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return fmt.Errorf("error creating graffiti settings directory: %w", err)
+	}
+	bytes, err := json.MarshalIndent(s, "", "    ")
+	if err != nil {
+		return fmt.Errorf("error serializing graffiti settings: %w", err)
+	}
+	err = os.WriteFile(path, bytes, 0644)
+	if err != nil {
+		return fmt.Errorf("error writing graffiti settings file [%s]: %w", path, err)
+	}
+	return nil
+}
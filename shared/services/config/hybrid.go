@@ -0,0 +1,101 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Settings
+const (
+	JwtSecretFolder   string = "secrets"
+	JwtSecretFilename string = "jwtsecret"
+)
+
+// Get the path of the JWT secret shared by the locally managed clients
+func (cfg *RocketPoolConfig) GetJwtSecretPath() string {
+	return filepath.Join(cfg.RocketPoolDirectory, JwtSecretFolder, JwtSecretFilename)
+}
+
+// Check if the Execution client is locally managed but the Consensus client isn't, in which case the
+// Engine API has to be published so the external Consensus client can reach it
+func (cfg *RocketPoolConfig) IsEngineApiPublished() bool {
+	return !cfg.IsNativeMode &&
+		cfg.ExecutionClientMode.Value.(config.Mode) == config.Mode_Local &&
+		cfg.ConsensusClientMode.Value.(config.Mode) == config.Mode_External
+}
+
+// Check if the Consensus client is locally managed but the Execution client isn't, in which case the
+// Consensus client needs the external Execution client's Engine API and JWT secret
+func (cfg *RocketPoolConfig) UsesExternalEngineApi() bool {
+	return !cfg.IsNativeMode &&
+		cfg.ExecutionClientMode.Value.(config.Mode) == config.Mode_External &&
+		cfg.ConsensusClientMode.Value.(config.Mode) == config.Mode_Local
+}
+
+// Describe how each client is managed, for status reports
+func (cfg *RocketPoolConfig) GetClientModeDescriptions() (string, string) {
+	if cfg.IsNativeMode {
+		return "natively managed (by you, outside of Docker)", "natively managed (by you, outside of Docker)"
+	}
+
+	var ecDescription string
+	switch cfg.ExecutionClientMode.Value.(config.Mode) {
+	case config.Mode_Local:
+		ecDescription = fmt.Sprintf("locally managed (%s)", getOptionName(&cfg.ExecutionClient))
+	case config.Mode_External:
+		ecDescription = fmt.Sprintf("externally managed (%s)", cfg.ExternalExecution.HttpUrl.Value.(string))
+	default:
+		ecDescription = "not configured"
+	}
+
+	ccDescription := "not configured"
+	ccConfig, err := cfg.GetSelectedConsensusClientConfig()
+	if err == nil {
+		switch cfg.ConsensusClientMode.Value.(config.Mode) {
+		case config.Mode_Local:
+			ccDescription = fmt.Sprintf("locally managed (%s)", ccConfig.GetName())
+		case config.Mode_External:
+			ccDescription = fmt.Sprintf("externally managed (%s)", ccConfig.GetName())
+			if externalConfig, ok := ccConfig.(config.ExternalConsensusConfig); ok {
+				ccDescription = fmt.Sprintf("externally managed (%s at %s)", ccConfig.GetName(), externalConfig.GetApiUrl())
+			}
+		}
+	}
+
+	return ecDescription, ccDescription
+}
+
+// Check that mixed client modes have what they need to connect the clients to each other
+func (cfg *RocketPoolConfig) GetHybridModeProblems() []string {
+	problems := []string{}
+	if !cfg.UsesExternalEngineApi() {
+		return problems
+	}
+
+	if cfg.ExternalExecution.EngineUrl.Value.(string) == "" {
+		problems = append(problems, "Your Consensus client is locally managed but your Execution client isn't, so the Consensus client needs the external Execution client's Engine API. Please enter its URL in the External Execution Client settings.")
+	}
+	jwtSecretPath := cfg.ExternalExecution.JwtSecretPath.Value.(string)
+	if jwtSecretPath == "" {
+		problems = append(problems, "Your Consensus client is locally managed but your Execution client isn't, so the Consensus client needs the JWT secret the external Execution client uses for its Engine API. Please copy it to this machine and enter its path in the External Execution Client settings.")
+	} else if expandedPath, err := homedir.Expand(jwtSecretPath); err == nil {
+		if _, err := os.Stat(expandedPath); err != nil {
+			problems = append(problems, fmt.Sprintf("The external Execution client's JWT secret file (%s) can't be read: %s", jwtSecretPath, err.Error()))
+		}
+	}
+	return problems
+}
+
+// Get the name of a choice parameter's selected option
+func getOptionName(param *config.Parameter) string {
+	for _, option := range param.Options {
+		if option.Value == param.Value {
+			return option.Name
+		}
+	}
+	return fmt.Sprint(param.Value)
+}
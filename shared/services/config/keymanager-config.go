@@ -0,0 +1,81 @@
+package config
+
+import (
+	"github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Keymanager API settings
+const (
+	// The file in the validators folder holding the bearer token the Validator client's Keymanager API accepts
+	KeymanagerTokenFilename string = "keymanager-api-token.txt"
+
+	// The env var that tells the VC which file in the validators folder holds the Keymanager API token
+	KeymanagerTokenFileEnvVar string = "KEYMANAGER_TOKEN_FILE"
+)
+
+// Configuration for the Validator client's Keymanager API
+type KeymanagerConfig struct {
+	Title string `yaml:"-"`
+
+	// Toggle for managing the Validator client over its Keymanager API
+	Enabled config.Parameter `yaml:"enabled,omitempty"`
+
+	// The port the Keymanager API listens on
+	Port config.Parameter `yaml:"port,omitempty"`
+
+	parent *RocketPoolConfig
+}
+
+// Generates a new Keymanager API config
+func NewKeymanagerConfig(cfg *RocketPoolConfig) *KeymanagerConfig {
+	return &KeymanagerConfig{
+		Title: "Keymanager API Settings",
+
+		Enabled: config.Parameter{
+			ID:   "enabled",
+			Name: "Enable Keymanager API",
+			Description: "Enable your Validator client's standard Keymanager API, so the node daemon can load new validator keys and set fee recipients while it's running instead of restarting it.\n\n" +
+				"The API is only reachable from inside the Smartnode's Docker network, and requires a token that the Smartnode generates in your validators folder.\n" +
+				"If the API can't be reached, the node daemon falls back to restarting your Validator client.\n\n" +
+				"Validator clients remember fee recipients set over the API, so if you disable this later, make sure your validators aren't left with an old fee recipient.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Validator, config.ContainerID_Node},
+			EnvironmentVariables: []string{"ENABLE_KEYMANAGER"},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		Port: config.Parameter{
+			ID:                   "port",
+			Name:                 "Keymanager API Port",
+			Description:          "The port your Validator client's Keymanager API listens on inside the Smartnode's Docker network.",
+			Type:                 config.ParameterType_Uint16,
+			Default:              map[config.Network]interface{}{config.Network_All: uint16(5062)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Validator, config.ContainerID_Node},
+			EnvironmentVariables: []string{"KEYMANAGER_PORT"},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		parent: cfg,
+	}
+}
+
+// Get the parameters for this config
+func (cfg *KeymanagerConfig) GetParameters() []*config.Parameter {
+	return []*config.Parameter{
+		&cfg.Enabled,
+		&cfg.Port,
+	}
+}
+
+// The the title for the config
+func (cfg *KeymanagerConfig) GetConfigTitle() string {
+	return cfg.Title
+}
+
+// True if the node daemon should manage the Validator client over its Keymanager API; it's only available in Docker mode
+func (cfg *KeymanagerConfig) IsEnabled() bool {
+	return !cfg.parent.IsNativeMode && cfg.Enabled.Value == true
+}
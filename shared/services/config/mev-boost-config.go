@@ -68,6 +68,9 @@ type MevBoostConfig struct {
 	// Custom command line flags
 	AdditionalFlags config.Parameter `yaml:"additionalFlags,omitempty"`
 
+	// Toggle for checking the relays' health and validator registrations
+	EnableRelayMonitoring config.Parameter `yaml:"enableRelayMonitoring,omitempty"`
+
 	// The URL of an external MEV-Boost client
 	ExternalUrl config.Parameter `yaml:"externalUrl"`
 
@@ -199,6 +202,18 @@ func NewMevBoostConfig(cfg *RocketPoolConfig) *MevBoostConfig {
 			OverwriteOnUpgrade:   false,
 		},
 
+		EnableRelayMonitoring: config.Parameter{
+			ID:                   "enableRelayMonitoring",
+			Name:                 "Monitor Relays",
+			Description:          "Have the node daemon regularly check that each of your enabled relays is up, and which of your validators are registered with them. You can see the results with `rocketpool node mev-status`, and you'll be alerted if a relay goes down or a validator isn't registered with any of them.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: true},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
 		ExternalUrl: config.Parameter{
 			ID:                   "externalUrl",
 			Name:                 "External URL",
@@ -234,6 +249,7 @@ func (cfg *MevBoostConfig) GetParameters() []*config.Parameter {
 		&cfg.OpenRpcPort,
 		&cfg.ContainerTag,
 		&cfg.AdditionalFlags,
+		&cfg.EnableRelayMonitoring,
 		&cfg.ExternalUrl,
 	}
 }
@@ -7,11 +7,50 @@ import (
 	"github.com/hashicorp/go-version"
 )
 
+// The root setting that records the migrations that have been applied to a settings file
+const MigrationsKey string = "migrations"
+
+// A single upgrade step, which transforms a settings file created by the given version (or an older one)
+// into the layout used by the next release
 type ConfigUpgrader struct {
 	Version     *version.Version
+	Description string
 	UpgradeFunc func(serializedConfig map[string]map[string]string) error
 }
 
+// Get all of the upgrade steps, ordered from the oldest version to the newest
+func getUpgraders() ([]ConfigUpgrader, error) {
+	steps := []struct {
+		version     string
+		description string
+		upgradeFunc func(serializedConfig map[string]map[string]string) error
+	}{
+		{"1.3.1", "Move the common Execution client settings out of the Geth section", upgradeFromV131},
+		{"1.5.1", "Rename the Nimbus additional flags to additionalBnFlags", upgradeFromV151},
+		{"1.9.8", "Convert the open RPC port booleans into port modes", upgradeFromV198},
+	}
+
+	upgraders := make([]ConfigUpgrader, 0, len(steps))
+	for _, step := range steps {
+		stepVersion, err := parseVersion(step.version)
+		if err != nil {
+			return nil, err
+		}
+		if len(upgraders) > 0 && !upgraders[len(upgraders)-1].Version.LessThan(stepVersion) {
+			return nil, fmt.Errorf("config upgrade for v%s is out of order", step.version)
+		}
+		upgraders = append(upgraders, ConfigUpgrader{
+			Version:     stepVersion,
+			Description: step.description,
+			UpgradeFunc: step.upgradeFunc,
+		})
+	}
+	return upgraders, nil
+}
+
+// Upgrade a serialized settings file to the latest layout.
+// Every upgrade step for the file's version or newer is applied in order, so the result only depends on the file's version.
+// The steps that were applied are appended to the file's migration record.
 func UpdateConfig(serializedConfig map[string]map[string]string) error {
 
 	// Get the config's version
@@ -19,61 +58,40 @@ func UpdateConfig(serializedConfig map[string]map[string]string) error {
 	if err != nil {
 		return err
 	}
-
-	// Create versions
-	v131, err := parseVersion("1.3.1")
+	upgraders, err := getUpgraders()
 	if err != nil {
 		return err
 	}
-	v151, err := parseVersion("1.5.1")
-	if err != nil {
-		return err
-	}
-	v198, err := parseVersion("1.9.8")
-	if err != nil {
-		return err
-	}
-
-	// Create the collection of upgraders
-	upgraders := []ConfigUpgrader{
-		{
-			Version:     v131,
-			UpgradeFunc: upgradeFromV131,
-		}, {
-			Version:     v151,
-			UpgradeFunc: upgradeFromV151,
-		}, {
-			Version:     v198,
-			UpgradeFunc: upgradeFromV198,
-		},
-	}
 
-	// Find the index of the provided config's version
-	targetIndex := -1
-	for i, upgrader := range upgraders {
-		if configVersion.LessThanOrEqual(upgrader.Version) {
-			targetIndex = i
+	// Apply every upgrade that the config hasn't had yet
+	applied := GetAppliedMigrations(serializedConfig)
+	for _, upgrader := range upgraders {
+		if configVersion.GreaterThan(upgrader.Version) {
+			continue
 		}
-	}
-
-	// If there are no upgrades to apply, return
-	if targetIndex == -1 {
-		return nil
-	}
-
-	// If there are upgrades, start at the first applicable index and apply them all in series
-	for i := targetIndex; i < len(upgraders); i++ {
-		upgrader := upgraders[i]
 		err = upgrader.UpgradeFunc(serializedConfig)
 		if err != nil {
 			return fmt.Errorf("error applying upgrade for config version %s: %w", upgrader.Version.String(), err)
 		}
+		applied = append(applied, "v"+upgrader.Version.String())
+	}
+	if len(applied) > 0 {
+		serializedConfig["root"][MigrationsKey] = strings.Join(applied, ",")
 	}
 
 	return nil
 
 }
 
+// Get the upgrade steps that have been applied to a serialized settings file, from oldest to newest
+func GetAppliedMigrations(serializedConfig map[string]map[string]string) []string {
+	rootConfig, exists := serializedConfig["root"]
+	if !exists || rootConfig[MigrationsKey] == "" {
+		return []string{}
+	}
+	return strings.Split(rootConfig[MigrationsKey], ",")
+}
+
 // Get the Smartnode version that the given config was built with
 func getVersionFromConfig(serializedConfig map[string]map[string]string) (*version.Version, error) {
 	rootConfig, exists := serializedConfig["root"]
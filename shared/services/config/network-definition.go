@@ -0,0 +1,187 @@
+package config
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mitchellh/go-homedir"
+	"github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Settings
+const (
+	CustomNetworkFilename string = "custom-network.json"
+)
+
+// A user-supplied definition of a Rocket Pool deployment, such as a private devnet or a test deployment on a public testnet.
+// It replaces the built-in chain ID and contract addresses of the network it's based on while that network is selected.
+type NetworkDefinition struct {
+	// A name for the deployment, for display
+	Name string `json:"name"`
+
+	// The built-in network this deployment is based on, which still determines the client images and defaults
+	Network config.Network `json:"network"`
+
+	// The execution chain ID
+	ChainID uint `json:"chainId"`
+
+	// Contract addresses; only RocketStorage and multicall are required, the rest keep the base network's values if they're left out
+	RocketStorageAddress      string `json:"rocketStorageAddress"`
+	MulticallAddress          string `json:"multicallAddress"`
+	BalanceBatcherAddress     string `json:"balanceBatcherAddress,omitempty"`
+	RplTokenAddress           string `json:"rplTokenAddress,omitempty"`
+	RethAddress               string `json:"rethAddress,omitempty"`
+	RplFaucetAddress          string `json:"rplFaucetAddress,omitempty"`
+	OneInchOracleAddress      string `json:"oneInchOracleAddress,omitempty"`
+	RplTwapPoolAddress        string `json:"rplTwapPoolAddress,omitempty"`
+	SnapshotDelegationAddress string `json:"snapshotDelegationAddress,omitempty"`
+
+	// Block explorer URL for following transactions
+	TxWatchUrl string `json:"txWatchUrl,omitempty"`
+
+	// The Beacon Chain's genesis, used to make sure the Consensus client is on the right chain
+	Genesis NetworkGenesis `json:"genesis,omitempty"`
+}
+
+// The genesis parameters of a Beacon Chain
+type NetworkGenesis struct {
+	ForkVersion string `json:"forkVersion,omitempty"`
+	Time        uint64 `json:"time,omitempty"`
+}
+
+// Load a network definition from a JSON file and check that it's valid
+func LoadNetworkDefinition(path string) (*NetworkDefinition, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading network definition [%s]: %w", path, err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(contents))
+	decoder.DisallowUnknownFields()
+	var definition NetworkDefinition
+	if err := decoder.Decode(&definition); err != nil {
+		return nil, fmt.Errorf("error decoding network definition [%s]: %w", path, err)
+	}
+	if err := definition.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid network definition [%s]: %w", path, err)
+	}
+	return &definition, nil
+}
+
+// Check that the definition has everything the Smartnode needs and that its addresses are well-formed
+func (d *NetworkDefinition) Validate() error {
+	if d.Name == "" {
+		return fmt.Errorf("name is missing")
+	}
+	switch d.Network {
+	case config.Network_Mainnet:
+		return fmt.Errorf("custom networks can't be based on %s", d.Network)
+	case config.Network_Prater, config.Network_Devnet:
+	default:
+		return fmt.Errorf("unknown base network [%s]", d.Network)
+	}
+	if d.ChainID == 0 {
+		return fmt.Errorf("chainId is missing")
+	}
+
+	required := map[string]string{
+		"rocketStorageAddress": d.RocketStorageAddress,
+		"multicallAddress":     d.MulticallAddress,
+	}
+	optional := map[string]string{
+		"balanceBatcherAddress":     d.BalanceBatcherAddress,
+		"rplTokenAddress":           d.RplTokenAddress,
+		"rethAddress":               d.RethAddress,
+		"rplFaucetAddress":          d.RplFaucetAddress,
+		"oneInchOracleAddress":      d.OneInchOracleAddress,
+		"rplTwapPoolAddress":        d.RplTwapPoolAddress,
+		"snapshotDelegationAddress": d.SnapshotDelegationAddress,
+	}
+	for name, address := range required {
+		if address == "" {
+			return fmt.Errorf("%s is missing", name)
+		}
+		optional[name] = address
+	}
+	for name, address := range optional {
+		if address != "" && !common.IsHexAddress(address) {
+			return fmt.Errorf("%s [%s] is not a valid address", name, address)
+		}
+	}
+
+	if d.Genesis.ForkVersion != "" {
+		if _, err := d.GetGenesisForkVersion(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Get the Beacon Chain's genesis fork version, or nil if the definition doesn't include it
+func (d *NetworkDefinition) GetGenesisForkVersion() ([]byte, error) {
+	if d.Genesis.ForkVersion == "" {
+		return nil, nil
+	}
+	forkVersion, err := hex.DecodeString(strings.TrimPrefix(d.Genesis.ForkVersion, "0x"))
+	if err != nil || len(forkVersion) != 4 {
+		return nil, fmt.Errorf("genesis forkVersion [%s] is not a 4-byte hex value", d.Genesis.ForkVersion)
+	}
+	return forkVersion, nil
+}
+
+// Get the path of the custom network definition in the data folder
+func (cfg *SmartnodeConfig) GetCustomNetworkPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), CustomNetworkFilename)
+	}
+
+	return filepath.Join(DaemonDataPath, CustomNetworkFilename)
+}
+
+// Get the custom network definition in the data folder, or nil if there isn't one or it's for a different network than the selected one.
+// The daemons find it in the data folder mounted into their containers; the CLI finds it in the data folder on the host.
+func (cfg *SmartnodeConfig) GetNetworkDefinition() (*NetworkDefinition, error) {
+	if !cfg.networkDefinitionLoaded {
+		cfg.networkDefinition, cfg.networkDefinitionErr = cfg.loadNetworkDefinition()
+		cfg.networkDefinitionLoaded = true
+	}
+	if cfg.networkDefinitionErr != nil {
+		return nil, cfg.networkDefinitionErr
+	}
+	if cfg.networkDefinition == nil || cfg.networkDefinition.Network != cfg.Network.Value.(config.Network) {
+		return nil, nil
+	}
+	return cfg.networkDefinition, nil
+}
+
+// Find and load the custom network definition
+func (cfg *SmartnodeConfig) loadNetworkDefinition() (*NetworkDefinition, error) {
+	paths := []string{cfg.GetCustomNetworkPath()}
+	if !cfg.parent.IsNativeMode {
+		if hostDataPath, err := homedir.Expand(cfg.DataPath.Value.(string)); err == nil {
+			paths = append(paths, filepath.Join(hostDataPath, CustomNetworkFilename))
+		}
+	}
+	for _, path := range paths {
+		if _, err := os.Stat(path); err == nil {
+			return LoadNetworkDefinition(path)
+		}
+	}
+	return nil, nil
+}
+
+// Get a network-specific value, preferring the custom network definition's value if it has one
+func (cfg *SmartnodeConfig) getNetworkValue(values map[config.Network]string, getCustom func(*NetworkDefinition) string) string {
+	definition, err := cfg.GetNetworkDefinition()
+	if err == nil && definition != nil {
+		if value := getCustom(definition); value != "" {
+			return value
+		}
+	}
+	return values[cfg.Network.Value.(config.Network)]
+}
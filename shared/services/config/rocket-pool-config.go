@@ -8,8 +8,10 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/alessio/shellescape"
+	"github.com/mitchellh/go-homedir"
 	"github.com/pbnjay/memory"
 	"github.com/rocket-pool/smartnode/addons"
 	"github.com/rocket-pool/smartnode/shared"
@@ -53,6 +55,9 @@ type RocketPoolConfig struct {
 
 	Version string `yaml:"-"`
 
+	// The settings file upgrade steps that have been applied to this config, from oldest to newest
+	Migrations []string `yaml:"-"`
+
 	RocketPoolDirectory string `yaml:"-"`
 
 	IsNativeMode bool `yaml:"-"`
@@ -121,8 +126,45 @@ type RocketPoolConfig struct {
 	EnableMevBoost config.Parameter `yaml:"enableMevBoost,omitempty"`
 	MevBoost       *MevBoostConfig  `yaml:"mevBoost,omitempty"`
 
+	// Alerting
+	EnableAlerting config.Parameter `yaml:"enableAlerting,omitempty"`
+	Alerting       *AlertingConfig  `yaml:"alerting,omitempty"`
+
+	// Container overrides
+	ContainerOverrides *ContainerOverridesConfig `yaml:"containerOverrides,omitempty"`
+
+	// Container resources
+	ContainerResources *ContainerResourcesConfig `yaml:"containerResources,omitempty"`
+
+	// External endpoint transport
+	EndpointTransport *EndpointTransportConfig `yaml:"endpointTransport,omitempty"`
+
+	// Data layout
+	DataLayout *DataLayoutConfig `yaml:"dataLayout,omitempty"`
+
+	// Graffiti
+	Graffiti *GraffitiConfig `yaml:"graffiti,omitempty"`
+
+	// Distributed validators
+	Dvt *DvtConfig `yaml:"dvt,omitempty"`
+
+	// Validator client Keymanager API
+	Keymanager *KeymanagerConfig `yaml:"keymanager,omitempty"`
+
+	// Node daemon API server
+	ApiServer *ApiServerConfig `yaml:"apiServer,omitempty"`
+
+	// Secret storage
+	Secrets *SecretsConfig `yaml:"secrets,omitempty"`
+
 	// Addons
 	GraffitiWallWriter addontypes.SmartnodeAddon `yaml:"addon-gww,omitempty"`
+	RescueNode         addontypes.SmartnodeAddon `yaml:"addon-rescue-node,omitempty"`
+
+	// Community addons installed in the addons folder, and the errors for the ones that couldn't be loaded
+	ExternalAddons      []addontypes.SmartnodeAddon `yaml:"-"`
+	ExternalAddonErrors []error                     `yaml:"-"`
+	externalAddonsRoot  string
 }
 
 // Load configuration settings from a file
@@ -178,7 +220,7 @@ func NewRocketPoolConfig(rpDir string, isNativeMode bool) *RocketPoolConfig {
 		ExecutionClientMode: config.Parameter{
 			ID:                   "executionClientMode",
 			Name:                 "Execution Client Mode",
-			Description:          "Choose which mode to use for your Execution client - locally managed (Docker Mode), or externally managed (Hybrid Mode).",
+			Description:          "Choose which mode to use for your Execution client - locally managed (Docker Mode), or externally managed (Hybrid Mode).\nThis can be chosen independently of the Consensus client's mode.",
 			Type:                 config.ParameterType_Choice,
 			Default:              map[config.Network]interface{}{},
 			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Eth1, config.ContainerID_Eth2, config.ContainerID_Node, config.ContainerID_Watchtower},
@@ -240,7 +282,7 @@ func NewRocketPoolConfig(rpDir string, isNativeMode bool) *RocketPoolConfig {
 		ConsensusClientMode: config.Parameter{
 			ID:                   "consensusClientMode",
 			Name:                 "Consensus Client Mode",
-			Description:          "Choose which mode to use for your Consensus client - locally managed (Docker Mode), or externally managed (Hybrid Mode).",
+			Description:          "Choose which mode to use for your Consensus client - locally managed (Docker Mode), or externally managed (Hybrid Mode).\nThis can be chosen independently of the Execution client's mode.",
 			Type:                 config.ParameterType_Choice,
 			Default:              map[config.Network]interface{}{config.Network_All: config.Mode_Local},
 			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Eth2, config.ContainerID_Node, config.ContainerID_Prometheus, config.ContainerID_Validator, config.ContainerID_Watchtower},
@@ -435,6 +477,18 @@ func NewRocketPoolConfig(rpDir string, isNativeMode bool) *RocketPoolConfig {
 			CanBeBlank:           false,
 			OverwriteOnUpgrade:   true,
 		},
+
+		EnableAlerting: config.Parameter{
+			ID:                   "enableAlerting",
+			Name:                 "Enable Alerting",
+			Description:          "Enable the Smartnode's alerting system, which will notify you through the channels of your choice when something goes wrong with your node (such as a client going offline, a validator missing attestations, or your collateral falling too low).",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
 	}
 
 	// Set the defaults for choices
@@ -466,9 +520,21 @@ func NewRocketPoolConfig(rpDir string, isNativeMode bool) *RocketPoolConfig {
 	cfg.BitflyNodeMetrics = NewBitflyNodeMetricsConfig(cfg)
 	cfg.Native = NewNativeConfig(cfg)
 	cfg.MevBoost = NewMevBoostConfig(cfg)
+	cfg.Alerting = NewAlertingConfig(cfg)
+	cfg.ContainerOverrides = NewContainerOverridesConfig(cfg)
+	cfg.ContainerResources = NewContainerResourcesConfig(cfg)
+	cfg.EndpointTransport = NewEndpointTransportConfig(cfg)
+	cfg.DataLayout = NewDataLayoutConfig(cfg)
+	cfg.Graffiti = NewGraffitiConfig(cfg)
+	cfg.Dvt = NewDvtConfig(cfg)
+	cfg.Keymanager = NewKeymanagerConfig(cfg)
+	cfg.ApiServer = NewApiServerConfig(cfg)
+	cfg.Secrets = NewSecretsConfig(cfg)
 
 	// Addons
 	cfg.GraffitiWallWriter = addons.NewGraffitiWallWriter()
+	cfg.RescueNode = addons.NewRescueNode()
+	cfg.loadExternalAddons(rpDir)
 
 	// Apply the default values for mainnet
 	cfg.Smartnode.Network.Value = cfg.Smartnode.Network.Options[0].Value
@@ -495,11 +561,17 @@ func getAugmentedEcDescription(client config.ExecutionClient, originalDescriptio
 // Create a copy of this configuration.
 func (cfg *RocketPoolConfig) CreateCopy() *RocketPoolConfig {
 	newConfig := NewRocketPoolConfig(cfg.RocketPoolDirectory, cfg.IsNativeMode)
+	newConfig.Migrations = append([]string{}, cfg.Migrations...)
 
 	// Set the network
 	network := cfg.Smartnode.Network.Value.(config.Network)
 	newConfig.Smartnode.Network.Value = network
 
+	// Use the same community addons; the daemons load them from a different path than the one saved in the settings
+	if newConfig.externalAddonsRoot != cfg.externalAddonsRoot {
+		newConfig.loadExternalAddons(cfg.externalAddonsRoot)
+	}
+
 	newParams := newConfig.GetParameters()
 	for i, param := range cfg.GetParameters() {
 		newParams[i].Value = param.Value
@@ -508,7 +580,11 @@ func (cfg *RocketPoolConfig) CreateCopy() *RocketPoolConfig {
 
 	newSubconfigs := newConfig.GetSubconfigs()
 	for name, subConfig := range cfg.GetSubconfigs() {
-		newParams := newSubconfigs[name].GetParameters()
+		newSubconfig, exists := newSubconfigs[name]
+		if !exists {
+			continue
+		}
+		newParams := newSubconfig.GetParameters()
 		for i, param := range subConfig.GetParameters() {
 			newParams[i].Value = param.Value
 			newParams[i].UpdateDescription(network)
@@ -538,12 +614,13 @@ func (cfg *RocketPoolConfig) GetParameters() []*config.Parameter {
 		&cfg.ExporterMetricsPort,
 		&cfg.WatchtowerMetricsPort,
 		&cfg.EnableMevBoost,
+		&cfg.EnableAlerting,
 	}
 }
 
 // Get the subconfigurations for this config
 func (cfg *RocketPoolConfig) GetSubconfigs() map[string]config.Config {
-	return map[string]config.Config{
+	subconfigs := map[string]config.Config{
 		"smartnode":          cfg.Smartnode,
 		"executionCommon":    cfg.ExecutionCommon,
 		"geth":               cfg.Geth,
@@ -569,7 +646,65 @@ func (cfg *RocketPoolConfig) GetSubconfigs() map[string]config.Config {
 		"bitflyNodeMetrics":  cfg.BitflyNodeMetrics,
 		"native":             cfg.Native,
 		"mevBoost":           cfg.MevBoost,
-		"addons-gww":         cfg.GraffitiWallWriter.GetConfig(),
+		"alerting":           cfg.Alerting,
+		"containerOverrides": cfg.ContainerOverrides,
+		"containerResources": cfg.ContainerResources,
+		"endpointTransport":  cfg.EndpointTransport,
+		"dataLayout":         cfg.DataLayout,
+		"graffiti":           cfg.Graffiti,
+		"dvt":                cfg.Dvt,
+		"keymanager":         cfg.Keymanager,
+		"apiServer":          cfg.ApiServer,
+		"secrets":            cfg.Secrets,
+	}
+	for _, addon := range cfg.GetAddons() {
+		subconfigs[addontypes.GetConfigSectionName(addon)] = addon.GetConfig()
+	}
+	return subconfigs
+}
+
+// Get all of the addons, starting with the ones built into the Smartnode
+func (cfg *RocketPoolConfig) GetAddons() []addontypes.SmartnodeAddon {
+	return append([]addontypes.SmartnodeAddon{cfg.GraffitiWallWriter, cfg.RescueNode}, cfg.ExternalAddons...)
+}
+
+// Get the addon with the provided ID, or nil if it isn't installed
+func (cfg *RocketPoolConfig) GetAddon(id string) addontypes.SmartnodeAddon {
+	for _, addon := range cfg.GetAddons() {
+		if addon.GetID() == id {
+			return addon
+		}
+	}
+	return nil
+}
+
+// Load the community addons installed in the provided Smartnode directory
+func (cfg *RocketPoolConfig) loadExternalAddons(rpDir string) {
+	cfg.externalAddonsRoot = rpDir
+	if rpDir == "" {
+		cfg.ExternalAddons = []addontypes.SmartnodeAddon{}
+		cfg.ExternalAddonErrors = []error{}
+		return
+	}
+	expandedDir, err := homedir.Expand(rpDir)
+	if err != nil {
+		cfg.ExternalAddons = []addontypes.SmartnodeAddon{}
+		cfg.ExternalAddonErrors = []error{fmt.Errorf("error expanding Smartnode directory [%s]: %w", rpDir, err)}
+		return
+	}
+	cfg.ExternalAddons, cfg.ExternalAddonErrors = addons.NewExternalAddons(expandedDir)
+
+	// Start them with their defaults, since they're loaded after the rest of the config is set up
+	network, ok := cfg.Smartnode.Network.Value.(config.Network)
+	if !ok {
+		network = config.Network_Mainnet
+	}
+	for _, addon := range cfg.ExternalAddons {
+		for _, param := range addon.GetConfig().GetParameters() {
+			if err := param.SetToDefault(network); err != nil {
+				cfg.ExternalAddonErrors = append(cfg.ExternalAddonErrors, fmt.Errorf("error setting the defaults of addon [%s]: %w", addon.GetID(), err))
+			}
+		}
 	}
 }
 
@@ -745,6 +880,9 @@ func (cfg *RocketPoolConfig) Serialize() map[string]map[string]string {
 	masterMap[rootConfigName]["rpDir"] = cfg.RocketPoolDirectory
 	masterMap[rootConfigName]["isNative"] = fmt.Sprint(cfg.IsNativeMode)
 	masterMap[rootConfigName]["version"] = fmt.Sprintf("v%s", shared.RocketPoolVersion) // Update the version with the current Smartnode version
+	if len(cfg.Migrations) > 0 {
+		masterMap[rootConfigName][migration.MigrationsKey] = strings.Join(cfg.Migrations, ",")
+	}
 
 	// Serialize the subconfigs
 	for name, subconfig := range cfg.GetSubconfigs() {
@@ -798,6 +936,7 @@ func (cfg *RocketPoolConfig) Deserialize(masterMap map[string]map[string]string)
 		return fmt.Errorf("error parsing isNative: %w", err)
 	}
 	cfg.Version = masterMap[rootConfigName]["version"]
+	cfg.Migrations = migration.GetAppliedMigrations(masterMap)
 
 	// Deserialize the subconfigs
 	for name, subconfig := range cfg.GetSubconfigs() {
@@ -843,6 +982,12 @@ func (cfg *RocketPoolConfig) GenerateEnvironmentVariables() map[string]string {
 			envVars["EC_OPEN_API_PORTS"] = fmt.Sprintf(", \"%s\", \"%s\"", httpMapping, wsMapping)
 		}
 
+		// An external Consensus client has to reach the Engine API, which is protected by the JWT secret
+		if cfg.IsEngineApiPublished() {
+			engineMapping := config.RPC_OpenExternal.DockerPortMapping(cfg.ExecutionCommon.EnginePort.Value.(uint16))
+			envVars["EC_OPEN_API_PORTS"] += fmt.Sprintf(", \"%s\"", engineMapping)
+		}
+
 		// Common params
 		config.AddParametersToEnvVars(cfg.ExecutionCommon.GetParameters(), envVars)
 
@@ -927,32 +1072,28 @@ func (cfg *RocketPoolConfig) GenerateEnvironmentVariables() map[string]string {
 	envVars["CC_CLIENT"] = fmt.Sprint(consensusClient)
 
 	// Graffiti
-	identifier := ""
-	versionString := fmt.Sprintf("v%s", shared.RocketPoolVersion)
-	envVars["ROCKET_POOL_VERSION"] = versionString
-	if len(versionString) < 8 {
-		ecInitial := strings.ToUpper(string(envVars["EC_CLIENT"][0]))
-
-		var ccInitial string
-		switch consensusClient {
-		case config.ConsensusClient_Lodestar:
-			ccInitial = "S" // Lodestar is special because it conflicts with Lighthouse
-		default:
-			ccInitial = strings.ToUpper(string(envVars["CC_CLIENT"][0]))
-		}
-		identifier = fmt.Sprintf("-%s%s", ecInitial, ccInitial)
+	envVars["ROCKET_POOL_VERSION"] = fmt.Sprintf("v%s", shared.RocketPoolVersion)
+	graffitiValues := cfg.Graffiti.getPlaceholderValues()
+	envVars["GRAFFITI_PREFIX"] = graffitiValues[GraffitiPlaceholderRp]
+	graffiti, err := cfg.Graffiti.GetNodeGraffiti(time.Now())
+	if err != nil {
+		// Fall back to the standard graffiti; the template problem is reported by Validate
+		graffiti, _ = cfg.Graffiti.RenderGraffiti("", nil, time.Now())
+	}
+	envVars["GRAFFITI"] = graffiti
+	if supported, _, _ := GetGraffitiFileSupport(consensusClient); supported {
+		envVars[GraffitiFileEnvVar] = GraffitiFilename
 	}
 
-	graffitiPrefix := fmt.Sprintf("RP%s %s", identifier, versionString)
-	envVars["GRAFFITI_PREFIX"] = graffitiPrefix
-
-	customGraffiti := envVars[CustomGraffitiEnvVar]
-	if customGraffiti == "" {
-		envVars["GRAFFITI"] = graffitiPrefix
-	} else {
-		envVars["GRAFFITI"] = fmt.Sprintf("%s (%s)", graffitiPrefix, customGraffiti)
+	// Keymanager API
+	config.AddParametersToEnvVars(cfg.Keymanager.GetParameters(), envVars)
+	if cfg.Keymanager.IsEnabled() {
+		envVars[KeymanagerTokenFileEnvVar] = KeymanagerTokenFilename
 	}
 
+	// Node API server
+	config.AddParametersToEnvVars(cfg.ApiServer.GetParameters(), envVars)
+
 	// Get the hostname of the Consensus client, necessary for Prometheus to work in hybrid mode
 	ccUrl, err := url.Parse(envVars["CC_API_ENDPOINT"])
 	if err == nil && ccUrl != nil {
@@ -1016,7 +1157,9 @@ func (cfg *RocketPoolConfig) GenerateEnvironmentVariables() map[string]string {
 	}
 
 	// Addons
-	cfg.GraffitiWallWriter.UpdateEnvVars(envVars)
+	for _, addon := range cfg.GetAddons() {
+		addon.UpdateEnvVars(envVars)
+	}
 
 	return envVars
 
@@ -1114,12 +1257,8 @@ func (cfg *RocketPoolConfig) Validate() []string {
 		}
 	}
 
-	// Force all Docker or all Hybrid
-	if cfg.ExecutionClientMode.Value.(config.Mode) == config.Mode_Local && cfg.ConsensusClientMode.Value.(config.Mode) == config.Mode_External {
-		errors = append(errors, "You are using a locally-managed Execution client and an externally-managed Consensus client.\nThis configuration is not compatible with The Merge; please select either locally-managed or externally-managed for both the EC and CC.")
-	} else if cfg.ExecutionClientMode.Value.(config.Mode) == config.Mode_External && cfg.ConsensusClientMode.Value.(config.Mode) == config.Mode_Local {
-		errors = append(errors, "You are using an externally-managed Execution client and a locally-managed Consensus client.\nThis configuration is not compatible with The Merge; please select either locally-managed or externally-managed for both the EC and CC.")
-	}
+	// Make sure clients in different modes can reach each other
+	errors = append(errors, cfg.GetHybridModeProblems()...)
 
 	// Ensure there's a MEV-boost URL
 	if !cfg.IsNativeMode && cfg.EnableMevBoost.Value == true {
@@ -1131,8 +1270,8 @@ func (cfg *RocketPoolConfig) Validate() []string {
 				errors = append(errors, "You have MEV-boost enabled in local mode but don't have any profiles or relays enabled. Please select at least one profile or relay to use MEV-boost.")
 			}
 		case config.Mode_External:
-			// In external MEV-boost mode, the user has to have an external URL if they're running Docker mode
-			if cfg.ExecutionClientMode.Value.(config.Mode) == config.Mode_Local && cfg.MevBoost.ExternalUrl.Value.(string) == "" {
+			// In external MEV-boost mode, the user has to have an external URL if their Consensus client is locally managed
+			if cfg.ConsensusClientMode.Value.(config.Mode) == config.Mode_Local && cfg.MevBoost.ExternalUrl.Value.(string) == "" {
 				errors = append(errors, "You have MEV-boost enabled in external mode but don't have a URL set. Please enter the external MEV-boost server URL to use it.")
 			}
 		default:
@@ -1140,6 +1279,34 @@ func (cfg *RocketPoolConfig) Validate() []string {
 		}
 	}
 
+	// Check the user-provided container tags, flags and environment variables
+	errors = append(errors, cfg.GetContainerOverrideProblems()...)
+
+	// Check the container resource limits
+	if _, err := cfg.ContainerResources.GetResourceLimits(); err != nil {
+		errors = append(errors, fmt.Sprintf("Your container resource settings are invalid: %s.", err.Error()))
+	}
+
+	// Check the external endpoint transport settings
+	errors = append(errors, cfg.EndpointTransport.GetProblems()...)
+
+	// Check the relocated data folders
+	errors = append(errors, cfg.DataLayout.GetProblems()...)
+
+	// Check the graffiti templates
+	errors = append(errors, cfg.Graffiti.GetProblems()...)
+
+	// Check the DVT settings
+	errors = append(errors, cfg.Dvt.GetProblems()...)
+
+	// Check the secret storage settings
+	errors = append(errors, cfg.Secrets.GetProblems()...)
+
+	// Make sure the custom network definition can be used
+	if _, err := cfg.Smartnode.GetNetworkDefinition(); err != nil {
+		errors = append(errors, fmt.Sprintf("Your custom network definition can't be used: %s\nPlease fix it, or remove it with `rocketpool service custom-network remove`.", err.Error()))
+	}
+
 	return errors
 }
 
@@ -1176,7 +1343,12 @@ func getChangedSettingsMap(oldConfig *RocketPoolConfig, newConfig *RocketPoolCon
 	// Subconfig settings
 	oldSubconfigs := oldConfig.GetSubconfigs()
 	for name, subConfig := range newConfig.GetSubconfigs() {
-		oldParams := oldSubconfigs[name].GetParameters()
+		// Addons that were just installed didn't have any settings before
+		oldSubconfig, exists := oldSubconfigs[name]
+		if !exists {
+			continue
+		}
+		oldParams := oldSubconfig.GetParameters()
 		newParams := subConfig.GetParameters()
 		changedSettings[subConfig.GetConfigTitle()] = getChangedSettings(oldParams, newParams, newConfig)
 	}
@@ -0,0 +1,266 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+
+	"github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Where the node's secrets are kept
+type SecretBackend string
+
+const (
+	// Plaintext files in the data folder
+	SecretBackend_File SecretBackend = "file"
+
+	// The Linux Secret Service keyring (GNOME Keyring, KWallet), through secret-tool
+	SecretBackend_Keyring SecretBackend = "keyring"
+
+	// The macOS Keychain, through the security tool
+	SecretBackend_Keychain SecretBackend = "keychain"
+
+	// A HashiCorp Vault KV version 2 secrets engine
+	SecretBackend_Vault SecretBackend = "vault"
+
+	// AWS Secrets Manager, through the AWS CLI
+	SecretBackend_AwsSecretsManager SecretBackend = "aws-secrets-manager"
+)
+
+// Secret backend settings
+const (
+	// The env var the Vault token is read from if there's no token file
+	VaultTokenEnvVar string = "VAULT_TOKEN"
+
+	defaultVaultMount      string = "secret"
+	defaultSecretPrefix    string = "rocketpool"
+	defaultAwsSecretPrefix string = "rocketpool/"
+)
+
+// Configuration for where the node wallet password and API server token are kept
+type SecretsConfig struct {
+	Title string `yaml:"-"`
+
+	// The backend the secrets are kept in
+	Backend config.Parameter `yaml:"backend,omitempty"`
+
+	// The address of the Vault server
+	VaultAddress config.Parameter `yaml:"vaultAddress,omitempty"`
+
+	// The mount path of the KV version 2 secrets engine
+	VaultMount config.Parameter `yaml:"vaultMount,omitempty"`
+
+	// The path under the mount the secrets are kept in
+	VaultPath config.Parameter `yaml:"vaultPath,omitempty"`
+
+	// The file holding the Vault token
+	VaultTokenPath config.Parameter `yaml:"vaultTokenPath,omitempty"`
+
+	// The AWS region of the secrets
+	AwsRegion config.Parameter `yaml:"awsRegion,omitempty"`
+
+	// The prefix for the names of the secrets in AWS Secrets Manager
+	AwsSecretPrefix config.Parameter `yaml:"awsSecretPrefix,omitempty"`
+
+	// Whether to keep the validator keystore directory encrypted on disk
+	EncryptValidatorKeys config.Parameter `yaml:"encryptValidatorKeys,omitempty"`
+
+	parent *RocketPoolConfig
+}
+
+// Generates a new secrets config
+func NewSecretsConfig(cfg *RocketPoolConfig) *SecretsConfig {
+	return &SecretsConfig{
+		Title: "Secret Storage Settings",
+
+		Backend: config.Parameter{
+			ID:   "backend",
+			Name: "Secret Storage",
+			Description: "Where the node wallet's password and the node API server's token are kept. By default they're plaintext files in your data folder; the other options keep them in a secret manager instead.\n\n" +
+				"The Linux keyring, macOS Keychain and AWS Secrets Manager are only available in Native mode, since the Smartnode's containers can't reach them. HashiCorp Vault works in both modes.\n\n" +
+				"After changing this, run `rocketpool service migrate-secrets` to move the existing secrets out of your data folder.",
+			Type:                 config.ParameterType_Choice,
+			Default:              map[config.Network]interface{}{config.Network_All: SecretBackend_File},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Options: []config.ParameterOption{{
+				Name:        "Data Folder",
+				Description: "Keep the secrets in plaintext files in your data folder, readable only by the Smartnode's user.",
+				Value:       SecretBackend_File,
+			}, {
+				Name:        "Linux Keyring",
+				Description: "Keep the secrets in the Linux Secret Service keyring (such as GNOME Keyring or KWallet) using `secret-tool`. Native mode only.",
+				Value:       SecretBackend_Keyring,
+			}, {
+				Name:        "macOS Keychain",
+				Description: "Keep the secrets in the macOS Keychain using the `security` tool. Native mode only.",
+				Value:       SecretBackend_Keychain,
+			}, {
+				Name:        "HashiCorp Vault",
+				Description: "Keep the secrets in a HashiCorp Vault KV version 2 secrets engine.",
+				Value:       SecretBackend_Vault,
+			}, {
+				Name:        "AWS Secrets Manager",
+				Description: "Keep the secrets in AWS Secrets Manager using the AWS CLI and its usual credentials. Native mode only.",
+				Value:       SecretBackend_AwsSecretsManager,
+			}},
+		},
+
+		VaultAddress: config.Parameter{
+			ID:                   "vaultAddress",
+			Name:                 "Vault Address",
+			Description:          "The address of your Vault server, such as https://vault.example.com:8200. In Docker mode, it must be reachable from inside the Smartnode's containers.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		VaultMount: config.Parameter{
+			ID:                   "vaultMount",
+			Name:                 "Vault Mount",
+			Description:          "The mount path of the KV version 2 secrets engine the secrets are kept in.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: defaultVaultMount},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		VaultPath: config.Parameter{
+			ID:                   "vaultPath",
+			Name:                 "Vault Path",
+			Description:          "The path under the mount the secrets are kept in. Each secret is stored at its own path below this one.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: defaultSecretPrefix},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		VaultTokenPath: config.Parameter{
+			ID:   "vaultTokenPath",
+			Name: "Vault Token File",
+			Description: fmt.Sprintf("The name of the file in your data folder holding the token the Smartnode uses to authenticate with Vault, such as one written by Vault Agent.\n\n"+
+				"Leave this blank to read the token from the `%s` environment variable instead.", VaultTokenEnvVar),
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		AwsRegion: config.Parameter{
+			ID:                   "awsRegion",
+			Name:                 "AWS Region",
+			Description:          "The AWS region your secrets are kept in. Leave this blank to use the AWS CLI's configured region.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		AwsSecretPrefix: config.Parameter{
+			ID:                   "awsSecretPrefix",
+			Name:                 "AWS Secret Prefix",
+			Description:          "The prefix for the names of the Smartnode's secrets in AWS Secrets Manager.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: defaultAwsSecretPrefix},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		EncryptValidatorKeys: config.Parameter{
+			ID:   "encryptValidatorKeys",
+			Name: "Encrypt Validator Keys",
+			Description: "Keep your validator keystore directory encrypted on disk, with a key held in your secret storage, so the keys can't be read from a resold disk or a leaked backup. The node daemon decrypts the directory into memory-backed storage (tmpfs) for the Validator Client when it starts, and encrypts it again whenever it changes.\n\n" +
+				"This requires a secret storage other than the data folder. After enabling it, run `rocketpool service encrypt-validator-keys` to encrypt your existing keys. To turn it off, run `rocketpool service decrypt-validator-keys` before disabling this.\n\n" +
+				"Your Validator Client's slashing protection database isn't encrypted; it stays in the slashing-protection folder of your data folder so none of its history is lost if the node loses power.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		parent: cfg,
+	}
+}
+
+// Get the parameters for this config
+func (cfg *SecretsConfig) GetParameters() []*config.Parameter {
+	return []*config.Parameter{
+		&cfg.Backend,
+		&cfg.VaultAddress,
+		&cfg.VaultMount,
+		&cfg.VaultPath,
+		&cfg.VaultTokenPath,
+		&cfg.AwsRegion,
+		&cfg.AwsSecretPrefix,
+		&cfg.EncryptValidatorKeys,
+	}
+}
+
+// The the title for the config
+func (cfg *SecretsConfig) GetConfigTitle() string {
+	return cfg.Title
+}
+
+// Get the backend the secrets are kept in
+func (cfg *SecretsConfig) GetBackend() SecretBackend {
+	return cfg.Backend.Value.(SecretBackend)
+}
+
+// Get the path of the Vault token file, or an empty string if the token comes from the environment.
+// The file is in the data folder, so the daemons and the CLI see it at different paths in Docker mode.
+func (cfg *SecretsConfig) GetVaultTokenPath(daemon bool) string {
+	filename := cfg.VaultTokenPath.Value.(string)
+	if filename == "" {
+		return ""
+	}
+	if daemon && !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, filename)
+	}
+	return filepath.Join(cfg.parent.Smartnode.DataPath.Value.(string), filename)
+}
+
+// Check if the validator keystore directory is kept encrypted on disk
+func (cfg *SecretsConfig) IsValidatorKeyEncryptionEnabled() bool {
+	return cfg.EncryptValidatorKeys.Value == true
+}
+
+// Check the secret storage settings for problems
+func (cfg *SecretsConfig) GetProblems() []string {
+	problems := []string{}
+	backend := cfg.GetBackend()
+	switch backend {
+	case SecretBackend_Keyring, SecretBackend_Keychain, SecretBackend_AwsSecretsManager:
+		if !cfg.parent.IsNativeMode {
+			problems = append(problems, fmt.Sprintf("Secret storage is set to %s, which is only available in Native mode. Please use the data folder or HashiCorp Vault instead.", backend))
+		}
+	case SecretBackend_Vault:
+		address := cfg.VaultAddress.Value.(string)
+		if address == "" {
+			problems = append(problems, "Secret storage is set to HashiCorp Vault, but the Vault Address is blank.")
+		} else if parsed, err := url.Parse(address); err != nil || parsed.Scheme == "" || parsed.Host == "" {
+			problems = append(problems, fmt.Sprintf("The Vault Address [%s] isn't a valid URL.", address))
+		}
+	}
+	if cfg.IsValidatorKeyEncryptionEnabled() && backend == SecretBackend_File {
+		problems = append(problems, "Validator key encryption needs somewhere other than the data folder to keep its key. Please choose a different secret storage.")
+	}
+	return problems
+}
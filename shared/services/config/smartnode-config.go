@@ -21,24 +21,66 @@ const (
 	RewardsTreeFilenameFormat          string = "rp-rewards-%s-%d.json"
 	MinipoolPerformanceFilenameFormat  string = "rp-minipool-performance-%s-%d.json"
 	RewardsTreeIpfsExtension           string = ".zst"
+	LocalRewardsRootExtension          string = ".local-root"
 	RewardsTreesFolder                 string = "rewards-trees"
 	DaemonDataPath                     string = "/.rocketpool/data"
 	WatchtowerFolder                   string = "watchtower"
 	WatchtowerStateFile                string = "state.yml"
+	BeaconCacheFolder                  string = "beacon-cache"
 	RegenerateRewardsTreeRequestSuffix string = ".request"
 	RegenerateRewardsTreeRequestFormat string = "%d" + RegenerateRewardsTreeRequestSuffix
+	RewardsTreeProgressFilename        string = "rewards-tree-progress.json"
+	RplPriceApprovalFormat             string = "%d.price-approval"
+	CommandQueueFolder                 string = "command-queue"
+	ConfirmationsFilename              string = "confirmations.json"
+	AuditLogFilename                   string = "audit-log.jsonl"
+	WalletSessionFolder                string = "/dev/shm"
+	WalletSessionVolumePath            string = "/.rocketpool/session"
+	WalletSessionFilename              string = "rocketpool-wallet-session"
+	SealedValidatorsFilename           string = "validators.sealed"
+	UnsealedValidatorsFolder           string = "/dev/shm/rocketpool-validators"
+	DecryptedValidatorsFolder          string = "validators.decrypted"
+	SlashingProtectionFolder           string = "slashing-protection"
 	PrimaryRewardsFileUrl              string = "https://%s.ipfs.dweb.link/%s"
 	SecondaryRewardsFileUrl            string = "https://ipfs.io/ipfs/%s/%s"
 	GithubRewardsFileUrl               string = "https://github.com/rocket-pool/rewards-trees/raw/main/%s/%s"
 	FeeRecipientFilename               string = "rp-fee-recipient.txt"
 	NativeFeeRecipientFilename         string = "rp-fee-recipient-env.txt"
+	NodeHistoryFolder                  string = "history"
+	NodeHistoryFilenameFormat          string = "rp-node-history-%s.jsonl"
+	TaskStatusFolder                   string = "tasks"
+	TaskStatusFilenameFormat           string = "rp-task-status-%s.json"
+	RpcUsageFilenameFormat             string = "rp-rpc-usage-%s.json"
+	SystemStatusFilename               string = "rp-system-status.json"
+	UpdateStatusFilename               string = "rp-update-status.json"
+	MevRelayStatusFilename             string = "rp-mev-relay-status.json"
+	ProposalHistoryFilename            string = "rp-proposal-history.json"
+	GraffitiFilename                   string = "rp-graffiti.txt"
+	GraffitiSettingsFilename           string = "graffiti.json"
+	DvtSettingsFilename                string = "dvt.json"
+	DvtStatusFilename                  string = "rp-dvt-status.json"
+	DvtHandoffFolder                   string = "dvt-handoff"
+	AddonStatusFilename                string = "rp-addon-status.json"
+	AlertHistoryFilename               string = "rp-alert-history.json"
+	BlockBuildingSettingsFilename      string = "block-building.json"
+	ValidatorUptimeFilenameFormat      string = "rp-validator-uptime-%s.json"
+	UpgradeHistoryFilename             string = "rp-upgrade-history.json"
+	DelegateSightingsFilename          string = "rp-delegate-sightings.json"
+	FiatPriceCacheFilename             string = "fiat-prices.json"
+	ActivityDatabaseFilenameFormat     string = "rp-activity-%s.db"
+	ProfilingFolder                    string = "profiling"
+	ProfilingSocketFilenameFormat      string = "%s.sock"
+	ProfilesFolder                     string = "profiles"
+	CrashReportsFolder                 string = "crash-reports"
 )
 
 // Defaults
 const (
-	defaultProjectName       string = "rocketpool"
-	WatchtowerMaxFeeDefault  uint64 = 200
-	WatchtowerPrioFeeDefault uint64 = 3
+	defaultProjectName          string = "rocketpool"
+	WatchtowerMaxFeeDefault     uint64 = 200
+	WatchtowerPrioFeeDefault    uint64 = 3
+	defaultNodeHealthPort       uint16 = 9106
+	defaultWatchtowerHealthPort uint16 = 9107
 )
 
 // Configuration for the Smartnode
@@ -70,12 +112,29 @@ type SmartnodeConfig struct {
 	// Manual priority fee override
 	PriorityFee config.Parameter `yaml:"priorityFee,omitempty"`
 
+	// The gas price option to use when there's nobody to pick one
+	GasOption config.Parameter `yaml:"gasOption,omitempty"`
+
 	// Threshold for automatic transactions
 	AutoTxGasThreshold config.Parameter `yaml:"minipoolStakeGasThreshold,omitempty"`
 
+	// Staking prelaunch minipools as soon as their scrub check ends
+	StakeOnScrubEnd           config.Parameter `yaml:"stakeOnScrubEnd,omitempty"`
+	ScrubEndStakeGasThreshold config.Parameter `yaml:"scrubEndStakeGasThreshold,omitempty"`
+
 	// The amount of ETH in a minipool's balance before auto-distribute kicks in
 	DistributeThreshold config.Parameter `yaml:"distributeThreshold,omitempty"`
 
+	// Automatic minipool delegate upgrades
+	AutoUpgradeDelegates        config.Parameter `yaml:"autoUpgradeDelegates,omitempty"`
+	DelegateUpgradeDelay        config.Parameter `yaml:"delegateUpgradeDelay,omitempty"`
+	DelegateUpgradeGasThreshold config.Parameter `yaml:"delegateUpgradeGasThreshold,omitempty"`
+
+	// Extra safeguards for dangerous commands
+	ConfirmationPolicies config.Parameter `yaml:"confirmationPolicies,omitempty"`
+	ConfirmationDelay    config.Parameter `yaml:"confirmationDelay,omitempty"`
+	ConfirmationDevices  config.Parameter `yaml:"confirmationDevices,omitempty"`
+
 	// Mode for acquiring Merkle rewards trees
 	RewardsTreeMode config.Parameter `yaml:"rewardsTreeMode,omitempty"`
 
@@ -91,18 +150,79 @@ type SmartnodeConfig struct {
 	// Manual override for the watchtower's priority fee
 	WatchtowerPrioFeeOverride config.Parameter `yaml:"watchtowerPrioFeeOverride,omitempty"`
 
+	// The largest change in the rETH exchange rate the watchtower will submit balances for, as a percentage
+	BalancesMaxRateChange config.Parameter `yaml:"balancesMaxRateChange,omitempty"`
+
+	// The external sources the watchtower cross-checks its RPL price against
+	PriceReferenceSources config.Parameter `yaml:"priceReferenceSources,omitempty"`
+
+	// The largest deviation from the reference prices the watchtower will submit an RPL price with, as a percentage
+	PriceMaxDeviation config.Parameter `yaml:"priceMaxDeviation,omitempty"`
+
 	// The toggle for rolling records
 	UseRollingRecords config.Parameter `yaml:"useRollingRecords,omitempty"`
 
 	// The rolling record checkpoint interval
 	RecordCheckpointInterval config.Parameter `yaml:"recordCheckpointInterval,omitempty"`
 
+	// The number of epochs before the end of a rewards interval to start generating its tree
+	RewardsTreePregenerationEpochs config.Parameter `yaml:"rewardsTreePregenerationEpochs,omitempty"`
+
+	// The size limit of the disk cache for Beacon data used in rewards tree generation
+	BeaconCacheSize config.Parameter `yaml:"beaconCacheSize,omitempty"`
+
 	// The checkpoint retention limit
 	CheckpointRetentionLimit config.Parameter `yaml:"checkpointRetentionLimit,omitempty"`
 
 	// The path of the records folder where snapshots of rolling record info is stored during a rewards interval
 	RecordsPath config.Parameter `yaml:"recordsPath,omitempty"`
 
+	// The toggle for running the watchtower duties in shadow mode on non-Oracle DAO nodes
+	WatchtowerShadowMode config.Parameter `yaml:"watchtowerShadowMode,omitempty"`
+
+	// The number of blocks an event has to be buried under before the daemons act on it
+	ConfirmationDepth config.Parameter `yaml:"confirmationDepth,omitempty"`
+
+	// The output format of the daemon logs
+	LogFormat config.Parameter `yaml:"logFormat,omitempty"`
+
+	// The minimum severity of daemon log messages to print
+	LogLevel config.Parameter `yaml:"logLevel,omitempty"`
+
+	// Per-module overrides for the minimum log severity
+	LogModuleLevels config.Parameter `yaml:"logModuleLevels,omitempty"`
+
+	// The container orchestrator used to run the services
+	Orchestrator config.Parameter `yaml:"orchestrator,omitempty"`
+
+	// The Kubernetes namespace to deploy the services into
+	KubernetesNamespace config.Parameter `yaml:"kubernetesNamespace,omitempty"`
+
+	// Toggle for checking for new Smartnode and client releases
+	EnableUpdateChecks config.Parameter `yaml:"enableUpdateChecks,omitempty"`
+
+	// The number of days over which new releases are rolled out to nodes
+	UpdateRolloutDays config.Parameter `yaml:"updateRolloutDays,omitempty"`
+
+	// The CoinGecko-compatible API to get token prices from
+	PriceApiUrl config.Parameter `yaml:"priceApiUrl,omitempty"`
+
+	// The fiat currency to value income and balances in
+	FiatCurrency config.Parameter `yaml:"fiatCurrency,omitempty"`
+
+	// Toggle for showing approximate fiat values in the CLI's output
+	ShowFiatValues config.Parameter `yaml:"showFiatValues,omitempty"`
+
+	// Toggle for serving the daemons' runtime profiles
+	EnableProfiling config.Parameter `yaml:"enableProfiling,omitempty"`
+
+	// Toggle for serving the daemons' health check endpoints
+	EnableHealthCheck config.Parameter `yaml:"enableHealthCheck,omitempty"`
+
+	// The ports the daemons serve their health check endpoints on
+	NodeHealthPort       config.Parameter `yaml:"nodeHealthPort,omitempty"`
+	WatchtowerHealthPort config.Parameter `yaml:"watchtowerHealthPort,omitempty"`
+
 	///////////////////////////
 	// Non-editable settings //
 	///////////////////////////
@@ -191,6 +311,11 @@ type SmartnodeConfig struct {
 	// The BalanceChecker contract address
 	balancebatcherAddress map[config.Network]string `yaml:"-"`
 
+	// The custom network definition from the data folder, loaded on first use
+	networkDefinition       *NetworkDefinition `yaml:"-"`
+	networkDefinitionErr    error              `yaml:"-"`
+	networkDefinitionLoaded bool               `yaml:"-"`
+
 	// The FlashBots Protect RPC endpoint
 	flashbotsProtectUrl map[config.Network]string `yaml:"-"`
 }
@@ -275,6 +400,31 @@ func NewSmartnodeConfig(cfg *RocketPoolConfig) *SmartnodeConfig {
 			OverwriteOnUpgrade:   false,
 		},
 
+		GasOption: config.Parameter{
+			ID:                   "gasOption",
+			Name:                 "Default Gas Option",
+			Description:          "When you make a transaction, the Smartnode suggests low, medium and high gas prices based on the fees paid in recent blocks. This is the option used when you run a command with `-y` and aren't asked to choose, and the one selected by default when you are.\n\nThis doesn't apply if you've set a Manual Max Fee.",
+			Type:                 config.ParameterType_Choice,
+			Default:              map[config.Network]interface{}{config.Network_All: config.GasOption_Medium},
+			AffectsContainers:    []config.ContainerID{},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Options: []config.ParameterOption{{
+				Name:        "Low",
+				Description: "Pay about what the cheaper transactions in recent blocks paid. The transaction may take a while to be included, or get stuck if the base fee rises.",
+				Value:       config.GasOption_Low,
+			}, {
+				Name:        "Medium",
+				Description: "Pay about what a typical transaction in recent blocks paid, with room for the base fee to rise for a few blocks.",
+				Value:       config.GasOption_Medium,
+			}, {
+				Name:        "High",
+				Description: "Pay about what the more expensive transactions in recent blocks paid, with room for the base fee to double, so the transaction is included as soon as possible.",
+				Value:       config.GasOption_High,
+			}},
+		},
+
 		AutoTxGasThreshold: config.Parameter{
 			ID:   "minipoolStakeGasThreshold",
 			Name: "Automatic TX Gas Threshold",
@@ -289,6 +439,30 @@ func NewSmartnodeConfig(cfg *RocketPoolConfig) *SmartnodeConfig {
 			OverwriteOnUpgrade:   false,
 		},
 
+		StakeOnScrubEnd: config.Parameter{
+			ID:                   "stakeOnScrubEnd",
+			Name:                 "Stake When Scrub Check Ends",
+			Description:          "Enable this to have the Smartnode stake your prelaunch minipools at the moment their scrub check ends, instead of on its next regular check a few minutes later. This gets your validators into the Beacon Chain's activation queue as early as possible.\n\nIf the network's suggested fee is above the ceiling below at that moment, the minipool is staked by the regular checks instead.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		ScrubEndStakeGasThreshold: config.Parameter{
+			ID:                   "scrubEndStakeGasThreshold",
+			Name:                 "Scrub End Stake Gas Ceiling",
+			Description:          "The highest max fee (in gwei) the Smartnode will pay to stake a minipool as soon as its scrub check ends.\n\nSet this to 0 to use the Automatic TX Gas Threshold instead.",
+			Type:                 config.ParameterType_Float,
+			Default:              map[config.Network]interface{}{config.Network_All: float64(0)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
 		DistributeThreshold: config.Parameter{
 			ID:                   "distributeThreshold",
 			Name:                 "Auto-Distribute Threshold",
@@ -301,6 +475,78 @@ func NewSmartnodeConfig(cfg *RocketPoolConfig) *SmartnodeConfig {
 			OverwriteOnUpgrade:   false,
 		},
 
+		AutoUpgradeDelegates: config.Parameter{
+			ID:                   "autoUpgradeDelegates",
+			Name:                 "Auto-Upgrade Minipool Delegates",
+			Description:          "Enable this to have the Smartnode upgrade your minipools to the latest delegate contract automatically once a new one has been out for the waiting period below.\n\nMinipools with the `use-latest-delegate` flag set are already using the latest delegate and aren't affected.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		DelegateUpgradeDelay: config.Parameter{
+			ID:                   "delegateUpgradeDelay",
+			Name:                 "Delegate Upgrade Waiting Period",
+			Description:          "The number of days a new minipool delegate has to have been released before the Smartnode upgrades your minipools to it, which gives the community time to find problems with it first.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(14)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		DelegateUpgradeGasThreshold: config.Parameter{
+			ID:                   "delegateUpgradeGasThreshold",
+			Name:                 "Delegate Upgrade Gas Ceiling",
+			Description:          "The highest max fee (in gwei) the Smartnode will pay to upgrade your minipools' delegates automatically. Upgrades wait until the network's suggested fee is below this.\n\nSet this to 0 to use the Automatic TX Gas Threshold instead.",
+			Type:                 config.ParameterType_Float,
+			Default:              map[config.Network]interface{}{config.Network_All: float64(0)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		ConfirmationPolicies: config.Parameter{
+			ID:                   "confirmationPolicies",
+			Name:                 "Confirmation Policies",
+			Description:          "Extra safeguards the Smartnode enforces before running specific commands, to protect against things like exiting the wrong minipool or a mistyped withdrawal address.\n\nEnter a comma-separated list of `command=safeguard+safeguard` entries, where the command is the API route (for example, `node/set-withdrawal-address` or `minipool/exit`) and the safeguards are any of:\n- phrase: type a phrase generated for each request\n- passphrase: enter the confirmation passphrase set with `rocketpool service set-confirmation-passphrase`\n- delay: wait for the Confirmation Delay after requesting the command\n- device: approve the request from one of the Confirmation Devices\n\nFor example: `minipool/exit=phrase, node/set-withdrawal-address=phrase+passphrase+delay`",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		ConfirmationDelay: config.Parameter{
+			ID:                   "confirmationDelay",
+			Name:                 "Confirmation Delay",
+			Description:          "The number of hours commands with the `delay` safeguard have to wait after they're requested before they can run. Once the delay is over, the command has an hour to be run before it has to be requested again.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(24)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		ConfirmationDevices: config.Parameter{
+			ID:                   "confirmationDevices",
+			Name:                 "Confirmation Devices",
+			Description:          "A comma-separated list of the names of the API server keys that can approve requests for commands with the `device` safeguard, using `rocketpool service approve-command` from a CLI connected to this node's API server. The keys need the operator role.\n\nThe node's own CLI can never approve requests, so a second device is always needed.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
 		RewardsTreeMode: config.Parameter{
 			ID:                   "rewardsTreeMode",
 			Name:                 "Rewards Tree Mode",
@@ -370,6 +616,42 @@ func NewSmartnodeConfig(cfg *RocketPoolConfig) *SmartnodeConfig {
 			OverwriteOnUpgrade:   true,
 		},
 
+		BalancesMaxRateChange: config.Parameter{
+			ID:                   "balancesMaxRateChange",
+			Name:                 "Balances Max Rate Change",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]The largest change in the rETH exchange rate, as a percentage of the rate from the last balances submission, that the watchtower will submit network balances for. Balances that move the rate further than this are held and an alert is raised instead, so a calculation bug can be looked into before it's voted on-chain. Set this to 0 to disable the check.",
+			Type:                 config.ParameterType_Float,
+			Default:              map[config.Network]interface{}{config.Network_All: float64(1)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		PriceReferenceSources: config.Parameter{
+			ID:                   "priceReferenceSources",
+			Name:                 "RPL Price Reference Sources",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]A comma-separated list of sources for the watchtower to cross-check the RPL price it calculates against before submitting it. Each one can be `coingecko` or `binance` for the price those exchanges had at the price block's time, or the address of a Uniswap V3 RPL / ETH pool for its TWAP at the price block.\n\nThe divergence from each source is logged. Leave this blank to disable the cross-check.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		PriceMaxDeviation: config.Parameter{
+			ID:                   "priceMaxDeviation",
+			Name:                 "RPL Price Max Deviation",
+			Description:          "[orange]**For Oracle DAO members only.**\n\n[white]The largest deviation, as a percentage of the median of the reference prices, that the watchtower will submit its RPL price with. Prices that deviate further are held and an alert is raised until you approve them with `rocketpool odao approve-rpl-price`. Set this to 0 to only log the divergence.",
+			Type:                 config.ParameterType_Float,
+			Default:              map[config.Network]interface{}{config.Network_All: float64(5)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
 		UseRollingRecords: config.Parameter{
 			ID:                   "useRollingRecords",
 			Name:                 "Use Rolling Records",
@@ -394,6 +676,30 @@ func NewSmartnodeConfig(cfg *RocketPoolConfig) *SmartnodeConfig {
 			OverwriteOnUpgrade:   false,
 		},
 
+		RewardsTreePregenerationEpochs: config.Parameter{
+			ID:                   "rewardsTreePregenerationEpochs",
+			Name:                 "Rewards Tree Pre-generation",
+			Description:          "The number of epochs before the end of a rewards interval to start generating its tree speculatively from the rolling record. The watchtower saves a checkpoint and does a trial run of the tree generation at that point, then keeps the record up to date until the last epoch of the interval, so only that last chunk needs to be processed once the interval ends. Set this to 0 to disable it. Used if Rolling Records is enabled.\n\nOnly useful for the Oracle DAO, or if you generate your own rewards trees.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(0)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		BeaconCacheSize: config.Parameter{
+			ID:                   "beaconCacheSize",
+			Name:                 "Beacon Cache Size",
+			Description:          "The maximum size, in GB, of the disk cache for the finalized Beacon chain data used during rewards tree generation (committees, attestations, blocks and validator statuses). Retries and reruns of tree generation read from the cache instead of the Consensus client. The least recently used data is removed when the cache is full. Set this to 0 to disable the cache.\n\nOnly useful for the Oracle DAO, or if you generate your own rewards trees.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(0)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
 		CheckpointRetentionLimit: config.Parameter{
 			ID:                   "checkpointRetentionLimit",
 			Name:                 "Checkpoint Retention Limit",
@@ -418,6 +724,241 @@ func NewSmartnodeConfig(cfg *RocketPoolConfig) *SmartnodeConfig {
 			OverwriteOnUpgrade:   false,
 		},
 
+		WatchtowerShadowMode: config.Parameter{
+			ID:                   "watchtowerShadowMode",
+			Name:                 "Watchtower Shadow Mode",
+			Description:          "Enable this to have your watchtower independently calculate the network balances, RPL price, and rewards tree root that the Oracle DAO submits, and compare its results against the values that were actually submitted.\n\nAny divergence will be logged and exported in the watchtower's metrics. Nothing is ever submitted to the network in this mode.\n\nOnly useful if you are *not* an Oracle DAO member. Checking the rewards tree root requires the Rewards Tree Mode to be set to Generate.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		ConfirmationDepth: config.Parameter{
+			ID:                   "confirmationDepth",
+			Name:                 "Confirmation Depth",
+			Description:          "The number of blocks that have to be built on top of a block before the Smartnode acts on the Rocket Pool events in it, such as scrubbing a minipool, recording a contract upgrade, or adding an entry to the node's activity history. Events in newer blocks are picked up once they're buried deep enough.\n\nThe Smartnode also remembers the hashes of the blocks it has processed, and if one of them is reorged out, it discards what it found from that block onward and processes the new chain instead.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(64)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		LogFormat: config.Parameter{
+			ID:                   "logFormat",
+			Name:                 "Daemon Log Format",
+			Description:          "The format the node and watchtower daemons will use for their log output.",
+			Type:                 config.ParameterType_Choice,
+			Default:              map[config.Network]interface{}{config.Network_All: config.LogFormat_Color},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Options: []config.ParameterOption{{
+				Name:        "Color",
+				Description: "Human-readable output with colors for each task. This is what you'll see with `rocketpool service logs`.",
+				Value:       config.LogFormat_Color,
+			}, {
+				Name:        "JSON",
+				Description: "One JSON object per line, with a timestamp, level, module, and message. Use this if you ship your logs to a collector such as Loki or ELK.",
+				Value:       config.LogFormat_Json,
+			}, {
+				Name:        "Logfmt",
+				Description: "One `key=value` record per line, with a timestamp, level, module, and message.",
+				Value:       config.LogFormat_Logfmt,
+			}},
+		},
+
+		LogLevel: config.Parameter{
+			ID:                   "logLevel",
+			Name:                 "Daemon Log Level",
+			Description:          "The minimum severity of the messages the node and watchtower daemons will print.",
+			Type:                 config.ParameterType_Choice,
+			Default:              map[config.Network]interface{}{config.Network_All: config.LogLevel_Debug},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Options: []config.ParameterOption{{
+				Name:        "Debug",
+				Description: "Print everything.",
+				Value:       config.LogLevel_Debug,
+			}, {
+				Name:        "Info",
+				Description: "Print routine task progress, warnings, and errors.",
+				Value:       config.LogLevel_Info,
+			}, {
+				Name:        "Warning",
+				Description: "Only print warnings and errors.",
+				Value:       config.LogLevel_Warn,
+			}, {
+				Name:        "Error",
+				Description: "Only print errors.",
+				Value:       config.LogLevel_Error,
+			}},
+		},
+
+		LogModuleLevels: config.Parameter{
+			ID:                   "logModuleLevels",
+			Name:                 "Module Log Levels",
+			Description:          "Overrides for the log level of specific daemon modules, as a comma-separated list of `module=level` entries. Modules are named after the daemon and task, for example `watchtower.submit-rpl-price=debug,node=warn`.\n\nLeave this blank to use the Daemon Log Level for everything.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		Orchestrator: config.Parameter{
+			ID:                   "orchestrator",
+			Name:                 "Container Orchestrator",
+			Description:          "The tool the Smartnode uses to run its services. The `rocketpool service` commands (start, stop, status, logs, version and so on) work the same way with all of them.",
+			Type:                 config.ParameterType_Choice,
+			Default:              map[config.Network]interface{}{config.Network_All: config.Orchestrator_Docker},
+			AffectsContainers:    []config.ContainerID{},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Options: []config.ParameterOption{{
+				Name:        "Docker",
+				Description: "Run the services with Docker Compose.",
+				Value:       config.Orchestrator_Docker,
+			}, {
+				Name:        "Podman",
+				Description: "Run the services with `podman compose`, for machines where Docker isn't available or allowed. Podman's Docker-compatible socket must be enabled for the daemons that manage containers.",
+				Value:       config.Orchestrator_Podman,
+			}, {
+				Name:        "Kubernetes",
+				Description: "Convert the compose files into Kubernetes manifests with `kompose` and apply them to the cluster `kubectl` is configured for. Chain data and keys are mounted from the host, so the services must be scheduled on this machine.",
+				Value:       config.Orchestrator_Kubernetes,
+			}},
+		},
+
+		KubernetesNamespace: config.Parameter{
+			ID:                   "kubernetesNamespace",
+			Name:                 "Kubernetes Namespace",
+			Description:          "The Kubernetes namespace to deploy the Smartnode's services into when using the Kubernetes orchestrator. It must already exist.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: "rocketpool"},
+			AffectsContainers:    []config.ContainerID{},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		EnableUpdateChecks: config.Parameter{
+			ID:                   "enableUpdateChecks",
+			Name:                 "Check for Updates",
+			Description:          "Periodically check for new Smartnode and client releases, and let you know with `rocketpool service status` and your alerts when one is available. Nothing is installed until you run `rocketpool service update`.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: true},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		UpdateRolloutDays: config.Parameter{
+			ID:                   "updateRolloutDays",
+			Name:                 "Update Rollout Days",
+			Description:          "New releases are offered to nodes gradually over this many days after they're published, so problems with a release are found before every node has installed it. Your node's place in the rollout is fixed. Set this to 0 to be notified as soon as a release is published.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(7)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		PriceApiUrl: config.Parameter{
+			ID:                   "priceApiUrl",
+			Name:                 "Price API URL",
+			Description:          "The URL of a CoinGecko-compatible API to get the prices of ETH and RPL from, for valuing your node's income in `rocketpool node export-income` and showing fiat values in the CLI's output. If you have a CoinGecko API plan, you can put its URL here.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: "https://api.coingecko.com/api/v3"},
+			AffectsContainers:    []config.ContainerID{},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		FiatCurrency: config.Parameter{
+			ID:                   "fiatCurrency",
+			Name:                 "Fiat Currency",
+			Description:          "The currency to value your node's income in for `rocketpool node export-income`, and to show balances, rewards and gas costs in if Show Fiat Values is enabled, as a lowercase code like `usd`, `eur` or `gbp`.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: "usd"},
+			AffectsContainers:    []config.ContainerID{},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		ShowFiatValues: config.Parameter{
+			ID:                   "showFiatValues",
+			Name:                 "Show Fiat Values",
+			Description:          "Enable this to show the approximate value of balances, rewards and gas costs in your fiat currency next to their ETH and RPL amounts in the CLI's output.\n\nThe prices come from the Price API URL and are refreshed every few minutes, so they're only a rough guide.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:    []config.ContainerID{},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		EnableProfiling: config.Parameter{
+			ID:                   "enableProfiling",
+			Name:                 "Enable Profiling",
+			Description:          "Enable this to have the node and watchtower daemons serve Go's pprof profiling endpoint, so `rocketpool service profile` can collect CPU and memory profiles from them while they run. This is useful for diagnosing high CPU or memory usage, such as during rewards tree generation.\n\nThe endpoint is served on a Unix socket in your data folder rather than a network port, so it can only be reached from this machine.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		EnableHealthCheck: config.Parameter{
+			ID:                   "enableHealthCheck",
+			Name:                 "Enable Health Checks",
+			Description:          "Enable this to have the node and watchtower daemons serve `/healthz` and `/readyz` endpoints, so a supervisor like Kubernetes, systemd or an uptime checker can restart or alert on them.\n\n`/healthz` fails only if a daemon stops making progress; it stays up while your clients are offline or syncing and reports itself as degraded instead. `/readyz` fails whenever a client, the wallet or one of the daemon's duties isn't working.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{"ENABLE_HEALTH_CHECK"},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		NodeHealthPort: config.Parameter{
+			ID:                   "nodeHealthPort",
+			Name:                 "Node Health Check Port",
+			Description:          "The port your Node container should serve its health check endpoints on, if they're enabled.",
+			Type:                 config.ParameterType_Uint16,
+			Default:              map[config.Network]interface{}{config.Network_All: defaultNodeHealthPort},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{"NODE_HEALTH_PORT"},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		WatchtowerHealthPort: config.Parameter{
+			ID:                   "watchtowerHealthPort",
+			Name:                 "Watchtower Health Check Port",
+			Description:          "The port your Watchtower container should serve its health check endpoints on, if they're enabled.\nThis is only relevant for Oracle Nodes.",
+			Type:                 config.ParameterType_Uint16,
+			Default:              map[config.Network]interface{}{config.Network_All: defaultWatchtowerHealthPort},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{"WATCHTOWER_HEALTH_PORT"},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
 		txWatchUrl: map[config.Network]string{
 			config.Network_Mainnet: "https://etherscan.io/tx",
 			config.Network_Prater:  "https://goerli.etherscan.io/tx",
@@ -608,24 +1149,54 @@ func (cfg *SmartnodeConfig) GetParameters() []*config.Parameter {
 		&cfg.DataPath,
 		&cfg.ManualMaxFee,
 		&cfg.PriorityFee,
+		&cfg.GasOption,
 		&cfg.AutoTxGasThreshold,
+		&cfg.StakeOnScrubEnd,
+		&cfg.ScrubEndStakeGasThreshold,
 		&cfg.DistributeThreshold,
+		&cfg.AutoUpgradeDelegates,
+		&cfg.DelegateUpgradeDelay,
+		&cfg.DelegateUpgradeGasThreshold,
+		&cfg.ConfirmationPolicies,
+		&cfg.ConfirmationDelay,
+		&cfg.ConfirmationDevices,
 		&cfg.RewardsTreeMode,
 		&cfg.ArchiveECUrl,
 		&cfg.Web3StorageApiToken,
 		&cfg.WatchtowerMaxFeeOverride,
 		&cfg.WatchtowerPrioFeeOverride,
+		&cfg.BalancesMaxRateChange,
+		&cfg.PriceReferenceSources,
+		&cfg.PriceMaxDeviation,
 		&cfg.UseRollingRecords,
 		&cfg.RecordCheckpointInterval,
+		&cfg.RewardsTreePregenerationEpochs,
+		&cfg.BeaconCacheSize,
 		&cfg.CheckpointRetentionLimit,
 		&cfg.RecordsPath,
+		&cfg.WatchtowerShadowMode,
+		&cfg.ConfirmationDepth,
+		&cfg.LogFormat,
+		&cfg.LogLevel,
+		&cfg.LogModuleLevels,
+		&cfg.Orchestrator,
+		&cfg.KubernetesNamespace,
+		&cfg.EnableUpdateChecks,
+		&cfg.UpdateRolloutDays,
+		&cfg.PriceApiUrl,
+		&cfg.FiatCurrency,
+		&cfg.ShowFiatValues,
+		&cfg.EnableProfiling,
+		&cfg.EnableHealthCheck,
+		&cfg.NodeHealthPort,
+		&cfg.WatchtowerHealthPort,
 	}
 }
 
 // Getters for the non-editable parameters
 
 func (cfg *SmartnodeConfig) GetTxWatchUrl() string {
-	return cfg.txWatchUrl[cfg.Network.Value.(config.Network)]
+	return cfg.getNetworkValue(cfg.txWatchUrl, func(d *NetworkDefinition) string { return d.TxWatchUrl })
 }
 
 func (cfg *SmartnodeConfig) GetStakeUrl() string {
@@ -633,23 +1204,73 @@ func (cfg *SmartnodeConfig) GetStakeUrl() string {
 }
 
 func (cfg *SmartnodeConfig) GetChainID() uint {
+	if definition, err := cfg.GetNetworkDefinition(); err == nil && definition != nil {
+		return definition.ChainID
+	}
 	return cfg.chainID[cfg.Network.Value.(config.Network)]
 }
 
+func (cfg *SmartnodeConfig) GetDataFolderPath() string {
+	if cfg.parent.IsNativeMode {
+		return cfg.DataPath.Value.(string)
+	}
+
+	return DaemonDataPath
+}
+
 func (cfg *SmartnodeConfig) GetWalletPath() string {
+	if folder := cfg.getRelocatedFolder(DataComponent_Wallet, RelocatedWalletFolder); folder != "" {
+		return filepath.Join(folder, WalletFilename)
+	}
 	if cfg.parent.IsNativeMode {
-		return filepath.Join(cfg.DataPath.Value.(string), "wallet")
+		return filepath.Join(cfg.DataPath.Value.(string), WalletFilename)
 	}
 
-	return filepath.Join(DaemonDataPath, "wallet")
+	return filepath.Join(DaemonDataPath, WalletFilename)
 }
 
 func (cfg *SmartnodeConfig) GetPasswordPath() string {
+	if folder := cfg.getRelocatedFolder(DataComponent_Wallet, RelocatedWalletFolder); folder != "" {
+		return filepath.Join(folder, PasswordFilename)
+	}
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), PasswordFilename)
+	}
+
+	return filepath.Join(DaemonDataPath, PasswordFilename)
+}
+
+// Get the folder a relocated data component is in from the daemon's point of view: the host folder in Native Mode, or where it's mounted in the container otherwise.
+// Returns an empty string if the component hasn't been relocated.
+func (cfg *SmartnodeConfig) getRelocatedFolder(component DataComponent, containerFolder string) string {
+	if cfg.parent.DataLayout == nil {
+		return ""
+	}
+	hostFolder := cfg.parent.DataLayout.GetPath(component)
+	if hostFolder == "" {
+		return ""
+	}
 	if cfg.parent.IsNativeMode {
-		return filepath.Join(cfg.DataPath.Value.(string), "password")
+		return hostFolder
 	}
+	return containerFolder
+}
 
-	return filepath.Join(DaemonDataPath, "password")
+// Get the folder a relocated data component is in on the host, or an empty string if it hasn't been relocated
+func (cfg *SmartnodeConfig) getRelocatedHostFolder(component DataComponent) string {
+	if cfg.parent.DataLayout == nil {
+		return ""
+	}
+	return cfg.parent.DataLayout.GetPath(component)
+}
+
+// The wallet's unlock session is kept in memory-backed storage, so it's gone after a restart.
+// In Docker mode that's a tmpfs volume shared by the Smartnode containers, since each container has its own /dev/shm.
+func (cfg *SmartnodeConfig) GetWalletSessionPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(WalletSessionFolder, WalletSessionFilename)
+	}
+	return filepath.Join(WalletSessionVolumePath, WalletSessionFilename)
 }
 
 func (cfg *SmartnodeConfig) GetValidatorKeychainPath() string {
@@ -660,6 +1281,52 @@ func (cfg *SmartnodeConfig) GetValidatorKeychainPath() string {
 	return filepath.Join(DaemonDataPath, "validators")
 }
 
+// The encrypted copy of the validator keystore directory, used when validator key encryption is enabled
+func (cfg *SmartnodeConfig) GetSealedValidatorKeychainPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), SealedValidatorsFilename)
+	}
+
+	return filepath.Join(DaemonDataPath, SealedValidatorsFilename)
+}
+
+func (cfg *SmartnodeConfig) GetSealedValidatorKeychainPathInCLI() string {
+	return filepath.Join(cfg.DataPath.Value.(string), SealedValidatorsFilename)
+}
+
+// The memory-backed folder the encrypted validator keystore directory is decrypted into.
+// In Native mode the validators folder links to it; in Docker mode a tmpfs volume is mounted over the validators folder instead.
+func (cfg *SmartnodeConfig) GetUnsealedValidatorKeychainPath() string {
+	if cfg.parent.IsNativeMode {
+		return UnsealedValidatorsFolder
+	}
+
+	return cfg.GetValidatorKeychainPath()
+}
+
+// The folder the validator keystore directory is decrypted into in Docker mode, where the validators folder is covered by its tmpfs volume
+// until the service is restarted; the node daemon moves it into place when it starts
+func (cfg *SmartnodeConfig) GetDecryptedValidatorKeychainPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), DecryptedValidatorsFolder)
+	}
+
+	return filepath.Join(DaemonDataPath, DecryptedValidatorsFolder)
+}
+
+// The folder the Validator Clients' slashing protection databases are kept in while the validator keys are encrypted
+func (cfg *SmartnodeConfig) GetSlashingProtectionPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), SlashingProtectionFolder)
+	}
+
+	return filepath.Join(DaemonDataPath, SlashingProtectionFolder)
+}
+
+func (cfg *SmartnodeConfig) GetSlashingProtectionPathInCLI() string {
+	return filepath.Join(cfg.DataPath.Value.(string), SlashingProtectionFolder)
+}
+
 func (cfg *SmartnodeConfig) GetRecordsPath() string {
 	if cfg.parent.IsNativeMode {
 		return filepath.Join(cfg.DataPath.Value.(string), "records")
@@ -668,12 +1335,208 @@ func (cfg *SmartnodeConfig) GetRecordsPath() string {
 	return filepath.Join(DaemonDataPath, "records")
 }
 
+func (cfg *SmartnodeConfig) GetNodeHistoryPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), NodeHistoryFolder, fmt.Sprintf(NodeHistoryFilenameFormat, string(cfg.Network.Value.(config.Network))))
+	}
+
+	return filepath.Join(DaemonDataPath, NodeHistoryFolder, fmt.Sprintf(NodeHistoryFilenameFormat, string(cfg.Network.Value.(config.Network))))
+}
+
+func (cfg *SmartnodeConfig) GetTaskStatusPath(daemon string) string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), TaskStatusFolder, fmt.Sprintf(TaskStatusFilenameFormat, daemon))
+	}
+
+	return filepath.Join(DaemonDataPath, TaskStatusFolder, fmt.Sprintf(TaskStatusFilenameFormat, daemon))
+}
+
+func (cfg *SmartnodeConfig) GetRpcUsagePath(daemon string) string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), TaskStatusFolder, fmt.Sprintf(RpcUsageFilenameFormat, daemon))
+	}
+
+	return filepath.Join(DaemonDataPath, TaskStatusFolder, fmt.Sprintf(RpcUsageFilenameFormat, daemon))
+}
+
+// The folder the daemons save reports of panicking tasks to
+func (cfg *SmartnodeConfig) GetCrashReportsPath() string {
+	if folder := cfg.getRelocatedFolder(DataComponent_Logs, RelocatedLogsFolder); folder != "" {
+		return filepath.Join(folder, CrashReportsFolder)
+	}
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), CrashReportsFolder)
+	}
+
+	return filepath.Join(DaemonDataPath, CrashReportsFolder)
+}
+
+// The Unix socket a daemon serves its runtime profiles on when profiling is enabled
+func (cfg *SmartnodeConfig) GetProfilingSocketPath(daemon string) string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), ProfilingFolder, fmt.Sprintf(ProfilingSocketFilenameFormat, daemon))
+	}
+
+	return filepath.Join(DaemonDataPath, ProfilingFolder, fmt.Sprintf(ProfilingSocketFilenameFormat, daemon))
+}
+
+// The folder collected profiles are saved to
+func (cfg *SmartnodeConfig) GetProfilesPath(daemon bool) string {
+	if daemon && !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, ProfilesFolder)
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), ProfilesFolder)
+}
+
+func (cfg *SmartnodeConfig) GetSystemStatusPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), SystemStatusFilename)
+	}
+
+	return filepath.Join(DaemonDataPath, SystemStatusFilename)
+}
+
+func (cfg *SmartnodeConfig) GetUpdateStatusPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), UpdateStatusFilename)
+	}
+
+	return filepath.Join(DaemonDataPath, UpdateStatusFilename)
+}
+
+func (cfg *SmartnodeConfig) GetMevRelayStatusPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), MevRelayStatusFilename)
+	}
+
+	return filepath.Join(DaemonDataPath, MevRelayStatusFilename)
+}
+
+func (cfg *SmartnodeConfig) GetProposalHistoryPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), NodeHistoryFolder, ProposalHistoryFilename)
+	}
+
+	return filepath.Join(DaemonDataPath, NodeHistoryFolder, ProposalHistoryFilename)
+}
+
+func (cfg *SmartnodeConfig) GetGraffitiSettingsPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), GraffitiSettingsFilename)
+	}
+
+	return filepath.Join(DaemonDataPath, GraffitiSettingsFilename)
+}
+
+func (cfg *SmartnodeConfig) GetGraffitiFilePath() string {
+	if !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, "validators", GraffitiFilename)
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), "validators", GraffitiFilename)
+}
+
+func (cfg *SmartnodeConfig) GetDvtSettingsPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), DvtSettingsFilename)
+	}
+
+	return filepath.Join(DaemonDataPath, DvtSettingsFilename)
+}
+
+func (cfg *SmartnodeConfig) GetDvtStatusPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), DvtStatusFilename)
+	}
+
+	return filepath.Join(DaemonDataPath, DvtStatusFilename)
+}
+
+func (cfg *SmartnodeConfig) GetAddonStatusPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), AddonStatusFilename)
+	}
+
+	return filepath.Join(DaemonDataPath, AddonStatusFilename)
+}
+
+func (cfg *SmartnodeConfig) GetAlertHistoryPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), AlertHistoryFilename)
+	}
+
+	return filepath.Join(DaemonDataPath, AlertHistoryFilename)
+}
+
+func (cfg *SmartnodeConfig) GetUpgradeHistoryPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), UpgradeHistoryFilename)
+	}
+
+	return filepath.Join(DaemonDataPath, UpgradeHistoryFilename)
+}
+
+func (cfg *SmartnodeConfig) GetDelegateSightingsPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), DelegateSightingsFilename)
+	}
+
+	return filepath.Join(DaemonDataPath, DelegateSightingsFilename)
+}
+
+func (cfg *SmartnodeConfig) GetFiatPriceCachePath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), FiatPriceCacheFilename)
+	}
+
+	return filepath.Join(DaemonDataPath, FiatPriceCacheFilename)
+}
+
+func (cfg *SmartnodeConfig) GetActivityDatabasePath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), NodeHistoryFolder, fmt.Sprintf(ActivityDatabaseFilenameFormat, string(cfg.Network.Value.(config.Network))))
+	}
+
+	return filepath.Join(DaemonDataPath, NodeHistoryFolder, fmt.Sprintf(ActivityDatabaseFilenameFormat, string(cfg.Network.Value.(config.Network))))
+}
+
+func (cfg *SmartnodeConfig) GetDvtHandoffPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), DvtHandoffFolder)
+	}
+
+	return filepath.Join(DaemonDataPath, DvtHandoffFolder)
+}
+
+func (cfg *SmartnodeConfig) GetBlockBuildingSettingsPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), BlockBuildingSettingsFilename)
+	}
+
+	return filepath.Join(DaemonDataPath, BlockBuildingSettingsFilename)
+}
+
+func (cfg *SmartnodeConfig) GetValidatorUptimePath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), NodeHistoryFolder, fmt.Sprintf(ValidatorUptimeFilenameFormat, string(cfg.Network.Value.(config.Network))))
+	}
+
+	return filepath.Join(DaemonDataPath, NodeHistoryFolder, fmt.Sprintf(ValidatorUptimeFilenameFormat, string(cfg.Network.Value.(config.Network))))
+}
+
 func (cfg *SmartnodeConfig) GetWalletPathInCLI() string {
-	return filepath.Join(cfg.DataPath.Value.(string), "wallet")
+	if folder := cfg.getRelocatedHostFolder(DataComponent_Wallet); folder != "" {
+		return filepath.Join(folder, WalletFilename)
+	}
+	return filepath.Join(cfg.DataPath.Value.(string), WalletFilename)
 }
 
 func (cfg *SmartnodeConfig) GetPasswordPathInCLI() string {
-	return filepath.Join(cfg.DataPath.Value.(string), "password")
+	if folder := cfg.getRelocatedHostFolder(DataComponent_Wallet); folder != "" {
+		return filepath.Join(folder, PasswordFilename)
+	}
+	return filepath.Join(cfg.DataPath.Value.(string), PasswordFilename)
 }
 
 func (cfg *SmartnodeConfig) GetValidatorKeychainPathInCLI() string {
@@ -705,23 +1568,23 @@ func (cfg *SmartnodeConfig) GetCustomKeyPasswordFilePath() string {
 }
 
 func (cfg *SmartnodeConfig) GetStorageAddress() string {
-	return cfg.storageAddress[cfg.Network.Value.(config.Network)]
+	return cfg.getNetworkValue(cfg.storageAddress, func(d *NetworkDefinition) string { return d.RocketStorageAddress })
 }
 
 func (cfg *SmartnodeConfig) GetOneInchOracleAddress() string {
-	return cfg.oneInchOracleAddress[cfg.Network.Value.(config.Network)]
+	return cfg.getNetworkValue(cfg.oneInchOracleAddress, func(d *NetworkDefinition) string { return d.OneInchOracleAddress })
 }
 
 func (cfg *SmartnodeConfig) GetRplTokenAddress() string {
-	return cfg.rplTokenAddress[cfg.Network.Value.(config.Network)]
+	return cfg.getNetworkValue(cfg.rplTokenAddress, func(d *NetworkDefinition) string { return d.RplTokenAddress })
 }
 
 func (cfg *SmartnodeConfig) GetRplFaucetAddress() string {
-	return cfg.rplFaucetAddress[cfg.Network.Value.(config.Network)]
+	return cfg.getNetworkValue(cfg.rplFaucetAddress, func(d *NetworkDefinition) string { return d.RplFaucetAddress })
 }
 
 func (cfg *SmartnodeConfig) GetSnapshotDelegationAddress() string {
-	return cfg.snapshotDelegationAddress[cfg.Network.Value.(config.Network)]
+	return cfg.getNetworkValue(cfg.snapshotDelegationAddress, func(d *NetworkDefinition) string { return d.SnapshotDelegationAddress })
 }
 
 func (cfg *SmartnodeConfig) GetSmartnodeContainerTag() string {
@@ -758,7 +1621,7 @@ func (cfg *SmartnodeConfig) GetConfigTitle() string {
 }
 
 func (cfg *SmartnodeConfig) GetRethAddress() common.Address {
-	return common.HexToAddress(cfg.rethAddress[cfg.Network.Value.(config.Network)])
+	return common.HexToAddress(cfg.getNetworkValue(cfg.rethAddress, func(d *NetworkDefinition) string { return d.RethAddress }))
 }
 
 func getDefaultDataDir(config *RocketPoolConfig) string {
@@ -774,7 +1637,12 @@ func (cfg *SmartnodeConfig) GetRewardsTreePath(interval uint64, daemon bool) str
 		return filepath.Join(DaemonDataPath, RewardsTreesFolder, fmt.Sprintf(RewardsTreeFilenameFormat, string(cfg.Network.Value.(config.Network)), interval))
 	}
 
-	return filepath.Join(cfg.DataPath.Value.(string), RewardsTreesFolder, fmt.Sprintf(RewardsTreeFilenameFormat, string(cfg.Network.Value.(config.Network)), interval))
+	return filepath.Join(cfg.getRewardsTreesHostFolder(), fmt.Sprintf(RewardsTreeFilenameFormat, string(cfg.Network.Value.(config.Network)), interval))
+}
+
+// The Merkle root of a tree this node generated itself is kept next to the tree, since a downloaded tree can take the tree's place
+func (cfg *SmartnodeConfig) GetLocalRewardsRootPath(interval uint64, daemon bool) string {
+	return cfg.GetRewardsTreePath(interval, daemon) + LocalRewardsRootExtension
 }
 
 func (cfg *SmartnodeConfig) GetMinipoolPerformancePath(interval uint64, daemon bool) string {
@@ -782,7 +1650,15 @@ func (cfg *SmartnodeConfig) GetMinipoolPerformancePath(interval uint64, daemon b
 		return filepath.Join(DaemonDataPath, RewardsTreesFolder, fmt.Sprintf(MinipoolPerformanceFilenameFormat, string(cfg.Network.Value.(config.Network)), interval))
 	}
 
-	return filepath.Join(cfg.DataPath.Value.(string), RewardsTreesFolder, fmt.Sprintf(MinipoolPerformanceFilenameFormat, string(cfg.Network.Value.(config.Network)), interval))
+	return filepath.Join(cfg.getRewardsTreesHostFolder(), fmt.Sprintf(MinipoolPerformanceFilenameFormat, string(cfg.Network.Value.(config.Network)), interval))
+}
+
+// Get the folder the rewards trees are kept in on the host; the daemon containers always see them in the data folder, where the relocated folder is mounted
+func (cfg *SmartnodeConfig) getRewardsTreesHostFolder() string {
+	if folder := cfg.getRelocatedHostFolder(DataComponent_RewardsTrees); folder != "" {
+		return folder
+	}
+	return filepath.Join(cfg.DataPath.Value.(string), RewardsTreesFolder)
 }
 
 func (cfg *SmartnodeConfig) GetRegenerateRewardsTreeRequestPath(interval uint64, daemon bool) string {
@@ -793,6 +1669,18 @@ func (cfg *SmartnodeConfig) GetRegenerateRewardsTreeRequestPath(interval uint64,
 	return filepath.Join(cfg.DataPath.Value.(string), WatchtowerFolder, fmt.Sprintf(RegenerateRewardsTreeRequestFormat, interval))
 }
 
+func (cfg *SmartnodeConfig) GetRplPriceApprovalPath(blockNumber uint64, daemon bool) string {
+	return filepath.Join(cfg.GetWatchtowerFolder(daemon), fmt.Sprintf(RplPriceApprovalFormat, blockNumber))
+}
+
+func (cfg *SmartnodeConfig) GetRewardsTreeProgressPath(daemon bool) string {
+	if daemon && !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, WatchtowerFolder, RewardsTreeProgressFilename)
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), WatchtowerFolder, RewardsTreeProgressFilename)
+}
+
 func (cfg *SmartnodeConfig) GetWatchtowerFolder(daemon bool) string {
 	if daemon && !cfg.parent.IsNativeMode {
 		return filepath.Join(DaemonDataPath, WatchtowerFolder)
@@ -801,6 +1689,10 @@ func (cfg *SmartnodeConfig) GetWatchtowerFolder(daemon bool) string {
 	return filepath.Join(cfg.DataPath.Value.(string), WatchtowerFolder)
 }
 
+func (cfg *SmartnodeConfig) GetBeaconCachePath() string {
+	return filepath.Join(cfg.GetWatchtowerFolder(true), BeaconCacheFolder)
+}
+
 func (cfg *SmartnodeConfig) GetFeeRecipientFilePath() string {
 	if !cfg.parent.IsNativeMode {
 		return filepath.Join(DaemonDataPath, "validators", FeeRecipientFilename)
@@ -809,6 +1701,65 @@ func (cfg *SmartnodeConfig) GetFeeRecipientFilePath() string {
 	return filepath.Join(cfg.DataPath.Value.(string), "validators", NativeFeeRecipientFilename)
 }
 
+func (cfg *SmartnodeConfig) GetKeymanagerTokenPath() string {
+	if !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, "validators", KeymanagerTokenFilename)
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), "validators", KeymanagerTokenFilename)
+}
+
+func (cfg *SmartnodeConfig) GetApiServerTokenPath() string {
+	if !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, ApiServerTokenFilename)
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), ApiServerTokenFilename)
+}
+
+func (cfg *SmartnodeConfig) GetApiKeysPath() string {
+	if !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, ApiKeysFilename)
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), ApiKeysFilename)
+}
+
+func (cfg *SmartnodeConfig) GetCommandQueuePath() string {
+	if !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, CommandQueueFolder)
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), CommandQueueFolder)
+}
+
+func (cfg *SmartnodeConfig) GetConfirmationsPath() string {
+	if !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, ConfirmationsFilename)
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), ConfirmationsFilename)
+}
+
+func (cfg *SmartnodeConfig) GetAuditLogPath() string {
+	if folder := cfg.getRelocatedFolder(DataComponent_Logs, RelocatedLogsFolder); folder != "" {
+		return filepath.Join(folder, AuditLogFilename)
+	}
+	if !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, AuditLogFilename)
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), AuditLogFilename)
+}
+
+func (cfg *SmartnodeConfig) GetApiIdempotencyPath() string {
+	if !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, ApiIdempotencyFilename)
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), ApiIdempotencyFilename)
+}
+
 func (cfg *SmartnodeConfig) GetV100RewardsPoolAddress() common.Address {
 	return common.HexToAddress(cfg.v1_0_0_RewardsPoolAddress[cfg.Network.Value.(config.Network)])
 }
@@ -870,15 +1821,15 @@ func (cfg *SmartnodeConfig) GetBaseMessengerAddress() string {
 }
 
 func (cfg *SmartnodeConfig) GetRplTwapPoolAddress() string {
-	return cfg.rplTwapPoolAddress[cfg.Network.Value.(config.Network)]
+	return cfg.getNetworkValue(cfg.rplTwapPoolAddress, func(d *NetworkDefinition) string { return d.RplTwapPoolAddress })
 }
 
 func (cfg *SmartnodeConfig) GetMulticallAddress() string {
-	return cfg.multicallAddress[cfg.Network.Value.(config.Network)]
+	return cfg.getNetworkValue(cfg.multicallAddress, func(d *NetworkDefinition) string { return d.MulticallAddress })
 }
 
 func (cfg *SmartnodeConfig) GetBalanceBatcherAddress() string {
-	return cfg.balancebatcherAddress[cfg.Network.Value.(config.Network)]
+	return cfg.getNetworkValue(cfg.balancebatcherAddress, func(d *NetworkDefinition) string { return d.BalanceBatcherAddress })
 }
 
 func (cfg *SmartnodeConfig) GetFlashbotsProtectUrl() string {
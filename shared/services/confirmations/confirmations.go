@@ -0,0 +1,504 @@
+package confirmations
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// Settings
+const (
+	// How long a request can be used for once its waiting period is over; after this, the command has to be requested again
+	RequestLifetime = time.Hour
+
+	// The environment variables the confirmation passphrases are passed to the api commands in, so they don't show up in the process list
+	PassphraseEnvVar    string = "RP_CONFIRMATION_PASSPHRASE"
+	NewPassphraseEnvVar string = "RP_NEW_CONFIRMATION_PASSPHRASE"
+
+	// The environment variable the API server passes the name of the request's API key to the api commands in
+	ApiKeyNameEnvVar string = "RP_API_KEY_NAME"
+
+	fileMode          = 0600
+	idLength      int = 4
+	phraseLength  int = 3
+	saltSize      int = 16
+	keySize       int = 32
+	scryptN       int = 1 << 15
+	scryptR       int = 8
+	scryptP       int = 1
+	policySep         = ","
+	safeguardSep      = "+"
+	routeSep          = "="
+	anyDevice         = "*"
+	deviceNameSep     = ","
+	lockSuffix        = ".lock"
+)
+
+// Something an operator has to do before a protected command runs
+type Safeguard string
+
+const (
+	// Type a phrase that's generated for the request
+	Safeguard_Phrase Safeguard = "phrase"
+
+	// Enter the confirmation passphrase, which is separate from the node wallet's password
+	Safeguard_Passphrase Safeguard = "passphrase"
+
+	// Wait for the confirmation delay after requesting the command
+	Safeguard_Delay Safeguard = "delay"
+
+	// Have the request approved from one of the allow-listed devices, using its API server key
+	Safeguard_Device Safeguard = "device"
+)
+
+// The safeguards a command needs
+type Policy struct {
+	Route      string
+	Safeguards []Safeguard
+}
+
+// One run of a protected command that a request allows, identified by a fingerprint of its arguments
+type Invocation struct {
+	Fingerprint string `json:"fingerprint"`
+	Used        bool   `json:"used"`
+}
+
+// A request to run a protected command, and the safeguards it has satisfied so far.
+// It only allows the runs it was made for, each with the exact arguments it was requested with, and each of them only once.
+type Request struct {
+	ID                  string       `json:"id"`
+	Route               string       `json:"route"`
+	Phrase              string       `json:"phrase"`
+	Requested           time.Time    `json:"requested"`
+	UsableAt            time.Time    `json:"usableAt"`
+	Invocations         []Invocation `json:"invocations"`
+	PhraseConfirmed     bool         `json:"phraseConfirmed"`
+	PassphraseConfirmed bool         `json:"passphraseConfirmed"`
+	ApprovedBy          string       `json:"approvedBy,omitempty"`
+}
+
+// The pending confirmation requests and the hash of the confirmation passphrase, saved as a single JSON file
+type Store struct {
+	PassphraseSalt string    `json:"passphraseSalt,omitempty"`
+	PassphraseHash string    `json:"passphraseHash,omitempty"`
+	Requests       []Request `json:"requests"`
+
+	path string
+}
+
+// Get the policies from the Smartnode settings
+func GetPolicies(cfg *config.RocketPoolConfig) (map[string]Policy, error) {
+	return ParsePolicies(cfg.Smartnode.ConfirmationPolicies.Value.(string))
+}
+
+// Get the delay for the delay safeguard from the Smartnode settings
+func GetDelay(cfg *config.RocketPoolConfig) time.Duration {
+	return time.Duration(cfg.Smartnode.ConfirmationDelay.Value.(uint64)) * time.Hour
+}
+
+// Parse a list of policies, in the form `route=safeguard+safeguard, route=safeguard`
+func ParsePolicies(value string) (map[string]Policy, error) {
+	policies := map[string]Policy{}
+	for _, entry := range strings.Split(value, policySep) {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		route, safeguards, found := strings.Cut(entry, routeSep)
+		route = strings.Trim(strings.TrimSpace(route), "/")
+		if !found || route == "" {
+			return nil, fmt.Errorf("invalid confirmation policy '%s'; it should look like 'node/set-withdrawal-address=phrase+delay'", entry)
+		}
+		policy := Policy{Route: route}
+		for _, name := range strings.Split(safeguards, safeguardSep) {
+			safeguard := Safeguard(strings.ToLower(strings.TrimSpace(name)))
+			switch safeguard {
+			case Safeguard_Phrase, Safeguard_Passphrase, Safeguard_Delay, Safeguard_Device:
+				policy.Safeguards = append(policy.Safeguards, safeguard)
+			default:
+				return nil, fmt.Errorf("unknown safeguard '%s' in the confirmation policy for %s; it must be '%s', '%s', '%s' or '%s'", name, route, Safeguard_Phrase, Safeguard_Passphrase, Safeguard_Delay, Safeguard_Device)
+			}
+		}
+		policies[route] = policy
+	}
+	return policies, nil
+}
+
+// Check if the policy needs a safeguard
+func (p Policy) Requires(safeguard Safeguard) bool {
+	for _, s := range p.Safeguards {
+		if s == safeguard {
+			return true
+		}
+	}
+	return false
+}
+
+// Lock the store at the provided path until the returned function is called. Hold it from loading the store until it's saved,
+// so concurrent API commands can't both use the same confirmation or overwrite each other's changes.
+func LockStore(path string) (func(), error) {
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return nil, fmt.Errorf("error creating confirmations directory: %w", err)
+	}
+	lockPath := path + lockSuffix
+	file, err := os.OpenFile(lockPath, os.O_RDONLY|os.O_CREATE, fileMode)
+	if err != nil {
+		return nil, fmt.Errorf("error opening confirmations lock [%s]: %w", lockPath, err)
+	}
+	if err := syscall.Flock(int(file.Fd()), syscall.LOCK_EX); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("error locking confirmations [%s]: %w", lockPath, err)
+	}
+	return func() {
+		syscall.Flock(int(file.Fd()), syscall.LOCK_UN)
+		file.Close()
+	}, nil
+}
+
+// Load the store from the provided path, or create an empty one if it doesn't exist yet
+func LoadStore(path string) (*Store, error) {
+	store := &Store{
+		Requests: []Request{},
+		path:     path,
+	}
+	bytes, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading confirmations file [%s]: %w", path, err)
+	}
+	err = json.Unmarshal(bytes, store)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing confirmations file [%s]: %w", path, err)
+	}
+	if store.Requests == nil {
+		store.Requests = []Request{}
+	}
+	return store, nil
+}
+
+// Save the store
+func (s *Store) Save() error {
+	err := os.MkdirAll(filepath.Dir(s.path), 0755)
+	if err != nil {
+		return fmt.Errorf("error creating confirmations directory: %w", err)
+	}
+	bytes, err := json.MarshalIndent(s, "", "    ")
+	if err != nil {
+		return fmt.Errorf("error serializing confirmations: %w", err)
+	}
+	err = os.WriteFile(s.path, bytes, fileMode)
+	if err != nil {
+		return fmt.Errorf("error writing confirmations file [%s]: %w", s.path, err)
+	}
+	return nil
+}
+
+// Check if the confirmation passphrase has been set
+func (s *Store) HasPassphrase() bool {
+	return s.PassphraseHash != ""
+}
+
+// Set the confirmation passphrase; only a salted hash of it is kept
+func (s *Store) SetPassphrase(passphrase string) error {
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return err
+	}
+	hash, err := hashPassphrase(passphrase, salt)
+	if err != nil {
+		return err
+	}
+	s.PassphraseSalt = hex.EncodeToString(salt)
+	s.PassphraseHash = hash
+	return nil
+}
+
+// Get the pending request for a command, ignoring the ones that have expired
+func (s *Store) GetRequest(route string) (*Request, bool) {
+	s.prune()
+	for i := range s.Requests {
+		if s.Requests[i].Route == route {
+			return &s.Requests[i], true
+		}
+	}
+	return nil, false
+}
+
+// Get a pending request by its ID
+func (s *Store) FindRequest(id string) (*Request, bool) {
+	s.prune()
+	for i := range s.Requests {
+		if s.Requests[i].ID == id {
+			return &s.Requests[i], true
+		}
+	}
+	return nil, false
+}
+
+// Get the fingerprint of a run of a command with the provided arguments
+func GetFingerprint(route string, args []string) string {
+	hash := sha256.New()
+	hash.Write([]byte(route))
+	for _, arg := range args {
+		hash.Write([]byte{0})
+		hash.Write([]byte(arg))
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// Encode the arguments of a run of a command so they can be passed as a single argument to the confirmation commands
+func FormatInvocation(args []string) (string, error) {
+	if args == nil {
+		args = []string{}
+	}
+	bytes, err := json.Marshal(args)
+	if err != nil {
+		return "", fmt.Errorf("error serializing command arguments: %w", err)
+	}
+	return string(bytes), nil
+}
+
+// Decode the arguments of runs of a command that were encoded with FormatInvocation
+func ParseInvocations(values []string) ([][]string, error) {
+	invocations := make([][]string, len(values))
+	for i, value := range values {
+		if err := json.Unmarshal([]byte(value), &invocations[i]); err != nil {
+			return nil, fmt.Errorf("invalid command arguments '%s'; they should be a JSON array of strings", value)
+		}
+	}
+	return invocations, nil
+}
+
+// Request to run a command once for each of the provided argument lists, replacing any request for it that's already pending
+func (s *Store) NewRequest(policy Policy, delay time.Duration, invocations [][]string) (*Request, error) {
+	if len(invocations) == 0 {
+		return nil, fmt.Errorf("a confirmation request for [%s] needs the arguments of at least one run of the command", policy.Route)
+	}
+	s.prune()
+	requests := []Request{}
+	for _, request := range s.Requests {
+		if request.Route != policy.Route {
+			requests = append(requests, request)
+		}
+	}
+	id, err := randomHex(idLength)
+	if err != nil {
+		return nil, err
+	}
+	code, err := randomHex(phraseLength)
+	if err != nil {
+		return nil, err
+	}
+	parts := strings.Split(policy.Route, "/")
+	request := Request{
+		ID:        id,
+		Route:     policy.Route,
+		Phrase:    fmt.Sprintf("%s %s", parts[len(parts)-1], code),
+		Requested: time.Now().UTC(),
+	}
+	for _, args := range invocations {
+		request.Invocations = append(request.Invocations, Invocation{
+			Fingerprint: GetFingerprint(policy.Route, args),
+		})
+	}
+	request.UsableAt = request.Requested
+	if policy.Requires(Safeguard_Delay) {
+		request.UsableAt = request.Requested.Add(delay)
+	}
+	s.Requests = append(requests, request)
+	return &s.Requests[len(s.Requests)-1], nil
+}
+
+// Confirm a request with the typed phrase and the confirmation passphrase, whichever of them the policy needs
+func (s *Store) Confirm(policy Policy, request *Request, phrase string, passphrase string) error {
+	if policy.Requires(Safeguard_Phrase) && !request.PhraseConfirmed {
+		if strings.TrimSpace(phrase) != request.Phrase {
+			return fmt.Errorf("the phrase doesn't match; type '%s' exactly", request.Phrase)
+		}
+		request.PhraseConfirmed = true
+	}
+	if policy.Requires(Safeguard_Passphrase) && !request.PassphraseConfirmed {
+		if !s.HasPassphrase() {
+			return errors.New("the confirmation passphrase hasn't been set; set it with `rocketpool service set-confirmation-passphrase`")
+		}
+		if !s.CheckPassphrase(passphrase) {
+			return errors.New("the confirmation passphrase is incorrect")
+		}
+		request.PassphraseConfirmed = true
+	}
+	return nil
+}
+
+// Approve a request from a secondary device, identified by the name of its API server key
+func Approve(request *Request, keyName string, devices string) error {
+	if keyName == "" {
+		return errors.New("requests can only be approved over the API server, using the key of one of the allow-listed devices")
+	}
+	for _, device := range strings.Split(devices, deviceNameSep) {
+		device = strings.TrimSpace(device)
+		if device == keyName || device == anyDevice {
+			request.ApprovedBy = keyName
+			return nil
+		}
+	}
+	return fmt.Errorf("the API key '%s' isn't one of the devices allowed to approve confirmation requests", keyName)
+}
+
+// Get the time a request stops working
+func (r *Request) Expires() time.Time {
+	return r.UsableAt.Add(RequestLifetime)
+}
+
+// Check if a request allows the provided runs of its command that it hasn't been used for yet
+func (r *Request) Covers(invocations [][]string) bool {
+	available := map[string]int{}
+	for _, invocation := range r.Invocations {
+		if !invocation.Used {
+			available[invocation.Fingerprint]++
+		}
+	}
+	for _, args := range invocations {
+		fingerprint := GetFingerprint(r.Route, args)
+		if available[fingerprint] == 0 {
+			return false
+		}
+		available[fingerprint]--
+	}
+	return true
+}
+
+// Check if every run a request allows has been used
+func (r *Request) IsUsedUp() bool {
+	for _, invocation := range r.Invocations {
+		if !invocation.Used {
+			return false
+		}
+	}
+	return true
+}
+
+// Get the safeguards a request hasn't satisfied yet
+func (r *Request) Missing(policy Policy) []Safeguard {
+	missing := []Safeguard{}
+	for _, safeguard := range policy.Safeguards {
+		switch safeguard {
+		case Safeguard_Phrase:
+			if !r.PhraseConfirmed {
+				missing = append(missing, safeguard)
+			}
+		case Safeguard_Passphrase:
+			if !r.PassphraseConfirmed {
+				missing = append(missing, safeguard)
+			}
+		case Safeguard_Delay:
+			if time.Now().Before(r.UsableAt) {
+				missing = append(missing, safeguard)
+			}
+		case Safeguard_Device:
+			if r.ApprovedBy == "" {
+				missing = append(missing, safeguard)
+			}
+		}
+	}
+	return missing
+}
+
+// Use the confirmation for a run of a protected command with the provided arguments, so it can't be used for that run again.
+// Returns an error explaining what's missing if it can't run yet.
+func (s *Store) Use(policy Policy, args []string) error {
+	request, exists := s.GetRequest(policy.Route)
+	if !exists {
+		return fmt.Errorf("[%s] is protected by a confirmation policy. Run `rocketpool service confirm-command %s` with the command's arguments first, then run the command again", policy.Route, policy.Route)
+	}
+	fingerprint := GetFingerprint(policy.Route, args)
+	var invocation *Invocation
+	for i := range request.Invocations {
+		if request.Invocations[i].Fingerprint == fingerprint && !request.Invocations[i].Used {
+			invocation = &request.Invocations[i]
+			break
+		}
+	}
+	if invocation == nil {
+		return fmt.Errorf("[%s] is protected by a confirmation policy and request %s wasn't made for these arguments, or has already been used for them. Run `rocketpool service confirm-command %s` with the command's arguments to request it again", policy.Route, request.ID, policy.Route)
+	}
+	missing := request.Missing(policy)
+	if len(missing) > 0 {
+		names := make([]string, len(missing))
+		for i, safeguard := range missing {
+			names[i] = string(safeguard)
+		}
+		return fmt.Errorf("[%s] is protected by a confirmation policy and request %s is still missing: %s. Run `rocketpool service confirm-command %s` to finish it", policy.Route, request.ID, strings.Join(names, ", "), policy.Route)
+	}
+	invocation.Used = true
+	if request.IsUsedUp() {
+		s.remove(request.ID)
+	}
+	return nil
+}
+
+// Remove a request
+func (s *Store) remove(id string) {
+	requests := []Request{}
+	for _, request := range s.Requests {
+		if request.ID != id {
+			requests = append(requests, request)
+		}
+	}
+	s.Requests = requests
+}
+
+// Remove the requests that can't be used anymore
+func (s *Store) prune() {
+	requests := []Request{}
+	for _, request := range s.Requests {
+		if time.Now().Before(request.Expires()) {
+			requests = append(requests, request)
+		}
+	}
+	s.Requests = requests
+}
+
+// Check the confirmation passphrase
+func (s *Store) CheckPassphrase(passphrase string) bool {
+	salt, err := hex.DecodeString(s.PassphraseSalt)
+	if err != nil {
+		return false
+	}
+	hash, err := hashPassphrase(passphrase, salt)
+	if err != nil {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(hash), []byte(s.PassphraseHash)) == 1
+}
+
+func hashPassphrase(passphrase string, salt []byte) (string, error) {
+	key, err := scrypt.Key([]byte(passphrase), salt, scryptN, scryptR, scryptP, keySize)
+	if err != nil {
+		return "", fmt.Errorf("error hashing confirmation passphrase: %w", err)
+	}
+	return hex.EncodeToString(key), nil
+}
+
+func randomHex(length int) (string, error) {
+	bytes := make([]byte, length)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", fmt.Errorf("error generating confirmation request: %w", err)
+	}
+	return hex.EncodeToString(bytes), nil
+}
@@ -0,0 +1,286 @@
+package confirmations
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestParsePolicies(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected map[string]Policy
+		err      string
+	}{
+		{
+			name:     "empty",
+			value:    " ",
+			expected: map[string]Policy{},
+		},
+		{
+			name:  "single",
+			value: "node/send=phrase",
+			expected: map[string]Policy{
+				"node/send": {Route: "node/send", Safeguards: []Safeguard{Safeguard_Phrase}},
+			},
+		},
+		{
+			name:  "several with spacing, slashes and case",
+			value: " /node/send/ = Phrase + DELAY , node/set-withdrawal-address=device+passphrase,",
+			expected: map[string]Policy{
+				"node/send":                   {Route: "node/send", Safeguards: []Safeguard{Safeguard_Phrase, Safeguard_Delay}},
+				"node/set-withdrawal-address": {Route: "node/set-withdrawal-address", Safeguards: []Safeguard{Safeguard_Device, Safeguard_Passphrase}},
+			},
+		},
+		{
+			name:  "missing safeguards",
+			value: "node/send",
+			err:   "invalid confirmation policy",
+		},
+		{
+			name:  "missing route",
+			value: "=phrase",
+			err:   "invalid confirmation policy",
+		},
+		{
+			name:  "unknown safeguard",
+			value: "node/send=phrase+retina",
+			err:   "unknown safeguard",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			policies, err := ParsePolicies(test.value)
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Fatalf("expected an error containing \"%s\", got %v", test.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+			if !reflect.DeepEqual(policies, test.expected) {
+				t.Fatalf("expected %v, got %v", test.expected, policies)
+			}
+		})
+	}
+}
+
+func TestCovers(t *testing.T) {
+	route := "node/send"
+	request := Request{
+		Route: route,
+		Invocations: []Invocation{
+			{Fingerprint: GetFingerprint(route, []string{"1", "eth"})},
+			{Fingerprint: GetFingerprint(route, []string{"1", "eth"})},
+			{Fingerprint: GetFingerprint(route, []string{"2", "rpl"}), Used: true},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		invocations [][]string
+		expected    bool
+	}{
+		{name: "nothing", invocations: [][]string{}, expected: true},
+		{name: "one run", invocations: [][]string{{"1", "eth"}}, expected: true},
+		{name: "both runs", invocations: [][]string{{"1", "eth"}, {"1", "eth"}}, expected: true},
+		{name: "more runs than requested", invocations: [][]string{{"1", "eth"}, {"1", "eth"}, {"1", "eth"}}, expected: false},
+		{name: "used run", invocations: [][]string{{"2", "rpl"}}, expected: false},
+		{name: "different arguments", invocations: [][]string{{"1", "rpl"}}, expected: false},
+		{name: "arguments split differently", invocations: [][]string{{"1eth"}}, expected: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if covers := request.Covers(test.invocations); covers != test.expected {
+				t.Fatalf("expected %t, got %t", test.expected, covers)
+			}
+		})
+	}
+}
+
+func TestMissing(t *testing.T) {
+	policy := Policy{
+		Route:      "node/send",
+		Safeguards: []Safeguard{Safeguard_Phrase, Safeguard_Passphrase, Safeguard_Delay, Safeguard_Device},
+	}
+
+	tests := []struct {
+		name     string
+		request  Request
+		expected []Safeguard
+	}{
+		{
+			name:     "nothing done",
+			request:  Request{UsableAt: time.Now().Add(time.Hour)},
+			expected: []Safeguard{Safeguard_Phrase, Safeguard_Passphrase, Safeguard_Delay, Safeguard_Device},
+		},
+		{
+			name:     "delay passed",
+			request:  Request{UsableAt: time.Now().Add(-time.Minute)},
+			expected: []Safeguard{Safeguard_Phrase, Safeguard_Passphrase, Safeguard_Device},
+		},
+		{
+			name: "everything done",
+			request: Request{
+				UsableAt:            time.Now().Add(-time.Minute),
+				PhraseConfirmed:     true,
+				PassphraseConfirmed: true,
+				ApprovedBy:          "phone",
+			},
+			expected: []Safeguard{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if missing := test.request.Missing(policy); !reflect.DeepEqual(missing, test.expected) {
+				t.Fatalf("expected %v, got %v", test.expected, missing)
+			}
+		})
+	}
+
+	// Safeguards that aren't in the policy are never missing
+	request := Request{UsableAt: time.Now().Add(time.Hour)}
+	if missing := request.Missing(Policy{Route: "node/send", Safeguards: []Safeguard{Safeguard_Phrase}}); !reflect.DeepEqual(missing, []Safeguard{Safeguard_Phrase}) {
+		t.Fatalf("expected only the phrase to be missing, got %v", missing)
+	}
+}
+
+// Create a store with a request for the provided runs of a command that has satisfied its policy
+func newConfirmedStore(t *testing.T, path string, policy Policy, invocations [][]string) *Store {
+	t.Helper()
+	store, err := LoadStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	request, err := store.NewRequest(policy, 0, invocations)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Confirm(policy, request, request.Phrase, ""); err != nil {
+		t.Fatal(err)
+	}
+	return store
+}
+
+// Check that using a confirmation failed with the expected reason
+func expectUseError(t *testing.T, err error, contains string) {
+	t.Helper()
+	if err == nil || !strings.Contains(err.Error(), contains) {
+		t.Fatalf("expected an error containing \"%s\", got %v", contains, err)
+	}
+}
+
+func TestUse(t *testing.T) {
+	policy := Policy{Route: "node/send", Safeguards: []Safeguard{Safeguard_Phrase}}
+	args := []string{"1", "eth", "0x01"}
+
+	tests := []struct {
+		name string
+		run  func(t *testing.T, store *Store)
+	}{
+		{
+			name: "without a request",
+			run: func(t *testing.T, store *Store) {
+				store.Requests = []Request{}
+				expectUseError(t, store.Use(policy, args), "Run `rocketpool service confirm-command node/send`")
+			},
+		},
+		{
+			name: "with other arguments",
+			run: func(t *testing.T, store *Store) {
+				expectUseError(t, store.Use(policy, []string{"2", "eth", "0x01"}), "wasn't made for these arguments")
+			},
+		},
+		{
+			name: "before the phrase is confirmed",
+			run: func(t *testing.T, store *Store) {
+				store.Requests[0].PhraseConfirmed = false
+				expectUseError(t, store.Use(policy, args), "is still missing: phrase")
+			},
+		},
+		{
+			name: "once",
+			run: func(t *testing.T, store *Store) {
+				if err := store.Use(policy, args); err != nil {
+					t.Fatalf("unexpected error: %s", err.Error())
+				}
+				if len(store.Requests) != 0 {
+					t.Fatal("expected the used up request to be removed")
+				}
+				expectUseError(t, store.Use(policy, args), "Run `rocketpool service confirm-command node/send`")
+			},
+		},
+		{
+			name: "after it expired",
+			run: func(t *testing.T, store *Store) {
+				store.Requests[0].UsableAt = time.Now().Add(-RequestLifetime - time.Minute)
+				expectUseError(t, store.Use(policy, args), "Run `rocketpool service confirm-command node/send`")
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			store := newConfirmedStore(t, filepath.Join(t.TempDir(), "confirmations.json"), policy, [][]string{args})
+			test.run(t, store)
+		})
+	}
+}
+
+func TestUseIsSingleUseAcrossConcurrentCommands(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "confirmations.json")
+	policy := Policy{Route: "node/send", Safeguards: []Safeguard{Safeguard_Phrase}}
+	args := []string{"1", "eth", "0x01"}
+	store := newConfirmedStore(t, path, policy, [][]string{args})
+	if err := store.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Each command loads, uses and saves the store under its lock like the API commands do
+	commands := 16
+	results := make(chan error, commands)
+	wg := &sync.WaitGroup{}
+	for i := 0; i < commands; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			unlock, err := LockStore(path)
+			if err != nil {
+				results <- err
+				return
+			}
+			defer unlock()
+			store, err := LoadStore(path)
+			if err != nil {
+				results <- err
+				return
+			}
+			if err := store.Use(policy, args); err != nil {
+				results <- err
+				return
+			}
+			results <- store.Save()
+		}()
+	}
+	wg.Wait()
+	close(results)
+
+	successes := 0
+	for err := range results {
+		if err == nil {
+			successes++
+		}
+	}
+	if successes != 1 {
+		t.Fatalf("expected the confirmation to be used exactly once, got %d uses", successes)
+	}
+}
@@ -0,0 +1,260 @@
+package daopayload
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	strutils "github.com/rocket-pool/rocketpool-go/utils/strings"
+)
+
+// The proposal contracts of the DAOs
+const (
+	OracleDaoProposalsContractName   string = "rocketDAONodeTrustedProposals"
+	ProtocolDaoProposalsContractName string = "rocketDAOProtocolProposals"
+)
+
+// The types of the values in a protocol DAO multi-setting proposal, in the order of the contract's enum
+var multiSettingTypes = []abi.Type{
+	mustNewType("uint256"),
+	mustNewType("bool"),
+	mustNewType("address"),
+}
+
+// An argument of a proposal payload
+type Argument struct {
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// A proposal payload decoded into what it will do when it's executed
+type DecodedPayload struct {
+	// The contract method the proposal calls
+	Method string `json:"method"`
+
+	// The raw arguments of the call
+	Arguments []Argument `json:"arguments"`
+
+	// A human-readable description of the call, with setting values in their units
+	Description string `json:"description"`
+}
+
+// Get the proposals contract of a DAO from its short name
+func GetProposalsContractName(daoName string) (string, error) {
+	switch strings.ToLower(daoName) {
+	case "odao":
+		return OracleDaoProposalsContractName, nil
+	case "pdao":
+		return ProtocolDaoProposalsContractName, nil
+	}
+	return "", fmt.Errorf("unknown DAO [%s]; it must be 'odao' or 'pdao'", daoName)
+}
+
+// Decode the payload of a proposal made through the provided DAO proposals contract
+func Decode(rp *rocketpool.RocketPool, contractName string, payload []byte) (DecodedPayload, error) {
+	contractAbi, err := rp.GetABI(contractName, nil)
+	if err != nil {
+		return DecodedPayload{}, fmt.Errorf("error getting the %s ABI: %w", contractName, err)
+	}
+	return DecodeWithAbi(contractAbi, payload)
+}
+
+// Decode a proposal payload with the ABI of the proposals contract it calls
+func DecodeWithAbi(contractAbi *abi.ABI, payload []byte) (DecodedPayload, error) {
+	if len(payload) < 4 {
+		return DecodedPayload{}, fmt.Errorf("the payload is too short to call a method")
+	}
+	method, err := contractAbi.MethodById(payload)
+	if err != nil {
+		return DecodedPayload{}, fmt.Errorf("error getting the payload method: %w", err)
+	}
+	args, err := method.Inputs.UnpackValues(payload[4:])
+	if err != nil {
+		return DecodedPayload{}, fmt.Errorf("error unpacking the payload arguments: %w", err)
+	}
+
+	decoded := DecodedPayload{
+		Method:    method.RawName,
+		Arguments: make([]Argument, len(args)),
+	}
+	for i, arg := range args {
+		decoded.Arguments[i] = Argument{
+			Name:  strings.TrimPrefix(method.Inputs[i].Name, "_"),
+			Type:  method.Inputs[i].Type.String(),
+			Value: formatArgument(method.Inputs[i].Type, arg),
+		}
+	}
+	decoded.Description = strutils.Sanitize(describe(method.RawName, args, decoded.Arguments))
+	return decoded, nil
+}
+
+// Describe what a proposal call will do, falling back to the call itself for methods that aren't known
+func describe(method string, args []interface{}, formatted []Argument) string {
+	switch method {
+	case "proposalInvite":
+		if id, url, address, ok := getStringStringAddress(args); ok {
+			return fmt.Sprintf("Invite %s (%s) with node address %s to the Oracle DAO", id, url, address.Hex())
+		}
+	case "proposalLeave":
+		if len(args) == 1 {
+			if address, ok := args[0].(common.Address); ok {
+				return fmt.Sprintf("Let %s leave the Oracle DAO", address.Hex())
+			}
+		}
+	case "proposalKick":
+		if len(args) == 2 {
+			address, ok1 := args[0].(common.Address)
+			fine, ok2 := args[1].(*big.Int)
+			if ok1 && ok2 {
+				return fmt.Sprintf("Kick %s from the Oracle DAO with a fine of %s", address.Hex(), FormatValue(fine, Unit_Rpl))
+			}
+		}
+	case "proposalSettingUint":
+		if len(args) == 3 {
+			contract, ok1 := args[0].(string)
+			path, ok2 := args[1].(string)
+			value, ok3 := args[2].(*big.Int)
+			if ok1 && ok2 && ok3 {
+				return describeSetting(contract, path, FormatValue(value, GetSettingUnit(path)))
+			}
+		}
+	case "proposalSettingBool", "proposalSettingAddress":
+		if len(args) == 3 {
+			contract, ok1 := args[0].(string)
+			path, ok2 := args[1].(string)
+			if ok1 && ok2 {
+				return describeSetting(contract, path, formatted[2].Value)
+			}
+		}
+	case "proposalSettingMulti":
+		if description, ok := describeMultiSetting(args); ok {
+			return description
+		}
+	case "proposalSettingRewardsClaimer":
+		if len(args) == 2 {
+			contract, ok1 := args[0].(string)
+			percent, ok2 := args[1].(*big.Int)
+			if ok1 && ok2 {
+				return fmt.Sprintf("Set the share of the RPL rewards claimed by %s to %s", contract, FormatValue(percent, Unit_Percent))
+			}
+		}
+	case "proposalSpendTreasury":
+		if len(args) == 3 {
+			invoice, ok1 := args[0].(string)
+			recipient, ok2 := args[1].(common.Address)
+			amount, ok3 := args[2].(*big.Int)
+			if ok1 && ok2 && ok3 {
+				return fmt.Sprintf("Spend %s from the treasury, paid to %s for invoice %s", FormatValue(amount, Unit_Rpl), recipient.Hex(), invoice)
+			}
+		}
+	case "proposalUpgrade":
+		if len(args) == 4 {
+			upgradeType, ok1 := args[0].(string)
+			name, ok2 := args[1].(string)
+			address, ok3 := args[3].(common.Address)
+			if ok1 && ok2 && ok3 {
+				return fmt.Sprintf("Upgrade contract %s (%s) to %s", name, upgradeType, address.Hex())
+			}
+		}
+	}
+
+	argStrings := make([]string, len(formatted))
+	for i, arg := range formatted {
+		argStrings[i] = fmt.Sprintf("%s=%s", arg.Name, arg.Value)
+	}
+	return fmt.Sprintf("%s(%s)", method, strings.Join(argStrings, ", "))
+}
+
+// Describe a change to a setting
+func describeSetting(contract string, path string, value string) string {
+	return fmt.Sprintf("Set %s in %s to %s", path, contract, value)
+}
+
+// Describe each of the settings a protocol DAO multi-setting proposal changes
+func describeMultiSetting(args []interface{}) (string, bool) {
+	if len(args) != 4 {
+		return "", false
+	}
+	contracts, ok1 := args[0].([]string)
+	paths, ok2 := args[1].([]string)
+	types, ok3 := args[2].([]uint8)
+	data, ok4 := args[3].([][]byte)
+	if !ok1 || !ok2 || !ok3 || !ok4 || len(paths) != len(contracts) || len(types) != len(contracts) || len(data) != len(contracts) {
+		return "", false
+	}
+
+	descriptions := make([]string, len(contracts))
+	for i := range contracts {
+		if int(types[i]) >= len(multiSettingTypes) {
+			return "", false
+		}
+		settingType := multiSettingTypes[types[i]]
+		values, err := abi.Arguments{{Type: settingType}}.UnpackValues(data[i])
+		if err != nil || len(values) != 1 {
+			return "", false
+		}
+		value := formatArgument(settingType, values[0])
+		if number, ok := values[0].(*big.Int); ok {
+			value = FormatValue(number, GetSettingUnit(paths[i]))
+		}
+		descriptions[i] = describeSetting(contracts[i], paths[i], value)
+	}
+	return strings.Join(descriptions, "; "), true
+}
+
+// Get the arguments of a call that takes two strings and an address
+func getStringStringAddress(args []interface{}) (string, string, common.Address, bool) {
+	if len(args) != 3 {
+		return "", "", common.Address{}, false
+	}
+	first, ok1 := args[0].(string)
+	second, ok2 := args[1].(string)
+	address, ok3 := args[2].(common.Address)
+	return first, second, address, ok1 && ok2 && ok3
+}
+
+// Format a raw argument value as a string
+func formatArgument(argType abi.Type, arg interface{}) string {
+	switch argType.T {
+	case abi.AddressTy:
+		if address, ok := arg.(common.Address); ok {
+			return address.Hex()
+		}
+	case abi.HashTy:
+		if hash, ok := arg.(common.Hash); ok {
+			return hash.Hex()
+		}
+	case abi.BytesTy:
+		if bytes, ok := arg.([]byte); ok {
+			return hex.EncodeToString(bytes)
+		}
+	case abi.SliceTy:
+		if byteSlices, ok := arg.([][]byte); ok {
+			encoded := make([]string, len(byteSlices))
+			for i, bytes := range byteSlices {
+				encoded[i] = hex.EncodeToString(bytes)
+			}
+			return fmt.Sprintf("[%s]", strings.Join(encoded, " "))
+		}
+	case abi.StringTy:
+		if str, ok := arg.(string); ok && len(str) > 100 {
+			// Contract ABIs in upgrade proposals are too long to show
+			return fmt.Sprintf("%s... (%d characters)", str[:100], len(str))
+		}
+	}
+	return fmt.Sprintf("%v", arg)
+}
+
+// Create an ABI type that's known to be valid
+func mustNewType(name string) abi.Type {
+	abiType, err := abi.NewType(name, "", nil)
+	if err != nil {
+		panic(err)
+	}
+	return abiType
+}
@@ -0,0 +1,137 @@
+package daopayload
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+)
+
+// The unit a setting's raw value is stored in
+type Unit string
+
+const (
+	// A plain number
+	Unit_Count Unit = "count"
+
+	// An amount of ETH, in wei
+	Unit_Eth Unit = "eth"
+
+	// An amount of RPL, in wei
+	Unit_Rpl Unit = "rpl"
+
+	// A fraction, scaled by 1e18
+	Unit_Percent Unit = "percent"
+
+	// A ratio, scaled by 1e18
+	Unit_Ratio Unit = "ratio"
+
+	// A length of time, in seconds
+	Unit_Duration Unit = "duration"
+
+	// A Unix timestamp
+	Unit_Timestamp Unit = "timestamp"
+
+	// A number of blocks
+	Unit_Blocks Unit = "blocks"
+)
+
+// The units of the settings the DAOs can change, by their paths.
+// Settings that aren't listed are shown as their raw values.
+var settingUnits = map[string]Unit{
+	// Oracle DAO members
+	"members.quorum":                    Unit_Percent,
+	"members.rplbond":                   Unit_Rpl,
+	"members.minipool.unbonded.max":     Unit_Count,
+	"members.minipool.unbonded.min.fee": Unit_Percent,
+	"members.challenge.cooldown":        Unit_Duration,
+	"members.challenge.window":          Unit_Duration,
+	"members.challenge.cost":            Unit_Eth,
+
+	// Oracle DAO proposals
+	"proposal.cooldown.time":   Unit_Duration,
+	"proposal.vote.time":       Unit_Duration,
+	"proposal.vote.delay.time": Unit_Duration,
+	"proposal.execute.time":    Unit_Duration,
+	"proposal.action.time":     Unit_Duration,
+
+	// Oracle DAO minipools
+	"minipool.scrub.period":                 Unit_Duration,
+	"minipool.promotion.scrub.period":       Unit_Duration,
+	"minipool.bond.reduction.window.start":  Unit_Duration,
+	"minipool.bond.reduction.window.length": Unit_Duration,
+
+	// Protocol DAO auctions
+	"auction.lot.value.minimum": Unit_Eth,
+	"auction.lot.value.maximum": Unit_Eth,
+	"auction.lot.duration":      Unit_Blocks,
+	"auction.price.start":       Unit_Percent,
+	"auction.price.reserve":     Unit_Percent,
+
+	// Protocol DAO deposits
+	"deposit.minimum":        Unit_Eth,
+	"deposit.pool.maximum":   Unit_Eth,
+	"deposit.assign.maximum": Unit_Count,
+	"deposit.fee":            Unit_Percent,
+
+	// Protocol DAO inflation
+	"rpl.inflation.interval.rate":  Unit_Ratio,
+	"rpl.inflation.interval.start": Unit_Timestamp,
+
+	// Protocol DAO minipools
+	"minipool.launch.timeout": Unit_Duration,
+
+	// Protocol DAO network
+	"network.consensus.threshold":       Unit_Percent,
+	"network.submit.balances.frequency": Unit_Duration,
+	"network.submit.prices.frequency":   Unit_Duration,
+	"network.node.fee.minimum":          Unit_Percent,
+	"network.node.fee.target":           Unit_Percent,
+	"network.node.fee.maximum":          Unit_Percent,
+	"network.node.fee.demand.range":     Unit_Eth,
+	"network.reth.collateral.target":    Unit_Percent,
+
+	// Protocol DAO nodes
+	"node.per.minipool.stake.minimum": Unit_Percent,
+	"node.per.minipool.stake.maximum": Unit_Percent,
+
+	// Protocol DAO rewards
+	"rpl.rewards.claim.period.time": Unit_Duration,
+}
+
+// Get the unit of a setting, or an empty string if it isn't known
+func GetSettingUnit(path string) Unit {
+	return settingUnits[path]
+}
+
+// Format a raw setting value in its unit
+func FormatValue(value *big.Int, unit Unit) string {
+	switch unit {
+	case Unit_Count:
+		return value.String()
+	case Unit_Eth:
+		return fmt.Sprintf("%s ETH", formatScaled(value, 1))
+	case Unit_Rpl:
+		return fmt.Sprintf("%s RPL", formatScaled(value, 1))
+	case Unit_Percent:
+		return fmt.Sprintf("%s%%", formatScaled(value, 100))
+	case Unit_Ratio:
+		return formatScaled(value, 1)
+	case Unit_Duration:
+		return fmt.Sprintf("%s (%s seconds)", time.Duration(value.Int64())*time.Second, value.String())
+	case Unit_Timestamp:
+		return fmt.Sprintf("%s (%s)", time.Unix(value.Int64(), 0).UTC().Format(time.RFC1123), value.String())
+	case Unit_Blocks:
+		return fmt.Sprintf("%s blocks", value.String())
+	}
+	return value.String()
+}
+
+// Format a value scaled by 1e18 exactly, multiplied by the provided factor and without trailing zeros
+func formatScaled(value *big.Int, multiplier int64) string {
+	scaled := new(big.Rat).SetFrac(new(big.Int).Mul(value, big.NewInt(multiplier)), eth.EthToWei(1))
+	text := strings.TrimRight(scaled.FloatString(18), "0")
+	return strings.TrimSuffix(text, ".")
+}
@@ -0,0 +1,172 @@
+package dvt
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/types"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Settings
+const (
+	// Charon responds with 200 once it's connected to its peers and the Beacon node, and with the reason it isn't otherwise
+	obolReadyPath string = "/readyz"
+
+	// The SSV node reports each of its components as "good" or "bad"
+	ssvHealthPath   string = "/v1/node/health"
+	ssvHealthyValue string = "good"
+)
+
+// How long to wait for the DVT client to respond
+var clusterTimeout, _ = time.ParseDuration("10s")
+
+// The health of the DVT cluster, as recorded by the node daemon
+type ClusterStatus struct {
+	Time     time.Time            `json:"time"`
+	Provider cfgtypes.DvtProvider `json:"provider"`
+	Url      string               `json:"url"`
+
+	// True if the DVT client responded and reported that the cluster is ready
+	Healthy bool   `json:"healthy"`
+	Latency int64  `json:"latency"`
+	Error   string `json:"error,omitempty"`
+
+	// The state of each of the DVT client's components, if it reports them
+	Components map[string]string `json:"components,omitempty"`
+
+	// The validators operated by the cluster
+	Validators []types.ValidatorPubkey `json:"validators"`
+}
+
+// Check the health of the DVT cluster through its client's API
+func CheckCluster(cfg *config.RocketPoolConfig, pubkeys []types.ValidatorPubkey) *ClusterStatus {
+	status := &ClusterStatus{
+		Time:       time.Now(),
+		Provider:   cfg.Dvt.Provider.Value.(cfgtypes.DvtProvider),
+		Url:        cfg.Dvt.ApiUrl.Value.(string),
+		Validators: pubkeys,
+	}
+	httpClient := &http.Client{Timeout: clusterTimeout}
+
+	var err error
+	start := time.Now()
+	switch status.Provider {
+	case cfgtypes.DvtProvider_Obol:
+		err = checkObolCluster(httpClient, status)
+	case cfgtypes.DvtProvider_Ssv:
+		err = checkSsvCluster(httpClient, status)
+	default:
+		err = fmt.Errorf("unknown DVT provider [%s]", status.Provider)
+	}
+	status.Latency = time.Since(start).Milliseconds()
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+	status.Healthy = true
+	return status
+}
+
+// Check Charon's readiness
+func checkObolCluster(httpClient *http.Client, status *ClusterStatus) error {
+	response, err := httpClient.Get(strings.TrimSuffix(status.Url, "/") + obolReadyPath)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("error reading response: %w", err)
+	}
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("charon isn't ready (%s): %s", response.Status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+// Check the SSV node's components
+func checkSsvCluster(httpClient *http.Client, status *ClusterStatus) error {
+	response, err := httpClient.Get(strings.TrimSuffix(status.Url, "/") + ssvHealthPath)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("error reading response: %w", err)
+	}
+
+	// The node responds with its component states even when it's unhealthy
+	var health map[string]interface{}
+	if err := json.Unmarshal(body, &health); err != nil {
+		if response.StatusCode != http.StatusOK {
+			return fmt.Errorf("the SSV node responded with %s: %s", response.Status, strings.TrimSpace(string(body)))
+		}
+		return fmt.Errorf("error deserializing response: %w", err)
+	}
+	status.Components = map[string]string{}
+	unhealthy := []string{}
+	for component, value := range health {
+		state, isString := value.(string)
+		if !isString {
+			continue
+		}
+		status.Components[component] = state
+		if state != ssvHealthyValue {
+			unhealthy = append(unhealthy, fmt.Sprintf("%s is %s", component, state))
+		}
+	}
+	sort.Strings(unhealthy)
+	if len(unhealthy) > 0 {
+		return fmt.Errorf("the SSV node isn't healthy: %s", strings.Join(unhealthy, ", "))
+	}
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("the SSV node responded with %s", response.Status)
+	}
+	return nil
+}
+
+// Save the status to the provided path
+func (s *ClusterStatus) Save(path string) error {
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return fmt.Errorf("error creating DVT status directory: %w", err)
+	}
+	bytes, err := json.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("error serializing DVT status: %w", err)
+	}
+	err = os.WriteFile(path, bytes, 0644)
+	if err != nil {
+		return fmt.Errorf("error writing DVT status file [%s]: %w", path, err)
+	}
+	return nil
+}
+
+// Load the status from the provided path. Returns nil if the node daemon hasn't recorded one yet.
+func LoadClusterStatus(path string) (*ClusterStatus, error) {
+	bytes, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading DVT status file [%s]: %w", path, err)
+	}
+	var status ClusterStatus
+	err = json.Unmarshal(bytes, &status)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing DVT status file [%s]: %w", path, err)
+	}
+	return &status, nil
+}
@@ -0,0 +1,59 @@
+package dvt
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/rocket-pool/rocketpool-go/types"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+
+	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore"
+)
+
+// Handoff file settings
+const (
+	// Obol's and SSV's key splitting tools both read keystore-*.json files, with the password in a matching .txt file
+	handoffKeystoreFilenameFormat string = "keystore-%s.json"
+	handoffPasswordFilenameFormat string = "keystore-%s.txt"
+
+	handoffDirMode  = 0700
+	handoffFileMode = 0600
+)
+
+// Write a validator key to the handoff folder as an EIP-2335 keystore and password file, so it can be split into key shares for the DVT cluster.
+// Returns the keystore's filename.
+func WriteHandoffKeystore(handoffPath string, key *eth2types.BLSPrivateKey, derivationPath string) (string, error) {
+
+	// Encrypt key
+	handoffKeystore, password, err := keystore.CreateEip2335Keystore(key, derivationPath, "Rocket Pool minipool validator key for DVT key splitting")
+	if err != nil {
+		return "", err
+	}
+	pubkey := handoffKeystore.Pubkey
+	keystoreBytes, err := json.Marshal(handoffKeystore)
+	if err != nil {
+		return "", fmt.Errorf("error encoding validator keystore: %w", err)
+	}
+
+	// Write the keystore and its password
+	if err := os.MkdirAll(handoffPath, handoffDirMode); err != nil {
+		return "", fmt.Errorf("error creating DVT handoff folder: %w", err)
+	}
+	passwordFilename := fmt.Sprintf(handoffPasswordFilenameFormat, pubkey.Hex())
+	if err := os.WriteFile(filepath.Join(handoffPath, passwordFilename), []byte(password), handoffFileMode); err != nil {
+		return "", fmt.Errorf("error writing keystore password to disk: %w", err)
+	}
+	keystoreFilename := fmt.Sprintf(handoffKeystoreFilenameFormat, pubkey.Hex())
+	if err := os.WriteFile(filepath.Join(handoffPath, keystoreFilename), keystoreBytes, handoffFileMode); err != nil {
+		return "", fmt.Errorf("error writing validator keystore to disk: %w", err)
+	}
+	return keystoreFilename, nil
+
+}
+
+// Get the filename of a validator's handoff keystore
+func GetHandoffKeystoreFilename(pubkey types.ValidatorPubkey) string {
+	return fmt.Sprintf(handoffKeystoreFilenameFormat, pubkey.Hex())
+}
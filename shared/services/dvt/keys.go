@@ -0,0 +1,34 @@
+package dvt
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/rocket-pool/rocketpool-go/types"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+)
+
+// Get a minipool's validator key for signing deposits and exits.
+// DVT minipools' keys aren't in the Validator client's keystores, so they're derived from the wallet instead.
+func GetValidatorKey(cfg *config.RocketPoolConfig, w *wallet.Wallet, pubkey types.ValidatorPubkey) (*eth2types.BLSPrivateKey, error) {
+	settings, err := config.LoadDvtSettings(cfg.Smartnode.GetDvtSettingsPath())
+	if err != nil {
+		return nil, err
+	}
+	_, minipool, isDvt := settings.GetMinipoolByPubkey(pubkey)
+	if !isDvt {
+		return w.GetValidatorKeyByPubkey(pubkey)
+	}
+
+	key, err := w.GetValidatorKeyAt(minipool.WalletIndex)
+	if err != nil {
+		return nil, fmt.Errorf("error deriving the key for DVT validator %s: %w", pubkey.Hex(), err)
+	}
+	if !bytes.Equal(key.PublicKey().Marshal(), pubkey.Bytes()) {
+		return nil, fmt.Errorf("the wallet's key at index %d doesn't match DVT validator %s", minipool.WalletIndex, pubkey.Hex())
+	}
+	return key, nil
+}
@@ -2,18 +2,24 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"math"
 	"math/big"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/fatih/color"
+	"github.com/rocket-pool/smartnode/shared/services/cmdqueue"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/providers"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
@@ -25,12 +31,27 @@ type ExecutionClientManager struct {
 	fallbackEcUrl   string
 	primaryEc       *ethclient.Client
 	fallbackEc      *ethclient.Client
+	primaryRpc      *rpc.Client
+	fallbackRpc     *rpc.Client
 	logger          log.ColorLogger
 	primaryReady    bool
 	fallbackReady   bool
 	ignoreSyncCheck bool
+
+	// The chain ID the node is configured for, which every client and outgoing transaction must match
+	chainID *big.Int
+
+	// The command queue that transactions from the node wallet hold between getting their nonce and being broadcast
+	commandQueuePath string
+
+	// The hosted providers serving each client, if they're known ones; detected from their URLs, or from their errors behind a proxy
+	primaryProfile  *providers.Profile
+	fallbackProfile *providers.Profile
 }
 
+// The endpoint's provider doesn't serve the debug_ namespace
+var ErrDebugNamespaceUnsupported = errors.New("the Execution client's provider doesn't support the debug_ namespace")
+
 // This is a signature for a wrapped ethclient.Client function
 type ecFunction func(*ethclient.Client) (interface{}, error)
 
@@ -64,17 +85,30 @@ func NewExecutionClientManager(cfg *config.RocketPoolConfig) (*ExecutionClientMa
 		}
 	}
 
-	primaryEc, err := ethclient.Dial(primaryEcUrl)
+	// Get the transport settings for endpoints that need authentication, client certificates or a proxy
+	primaryTransport, err := cfg.EndpointTransport.GetExecutionClientSettings()
+	if err != nil {
+		return nil, fmt.Errorf("error getting primary EC transport settings: %w", err)
+	}
+	fallbackTransport, err := cfg.EndpointTransport.GetFallbackExecutionClientSettings()
+	if err != nil {
+		return nil, fmt.Errorf("error getting fallback EC transport settings: %w", err)
+	}
+
+	primaryRpc, err := dialExecutionClient("primary EC", primaryEcUrl, primaryTransport)
 	if err != nil {
 		return nil, fmt.Errorf("error connecting to primary EC at [%s]: %w", primaryEcUrl, err)
 	}
+	primaryEc := ethclient.NewClient(primaryRpc)
 
+	var fallbackRpc *rpc.Client
 	var fallbackEc *ethclient.Client
 	if fallbackEcUrl != "" {
-		fallbackEc, err = ethclient.Dial(fallbackEcUrl)
+		fallbackRpc, err = dialExecutionClient("fallback EC", fallbackEcUrl, fallbackTransport)
 		if err != nil {
 			return nil, fmt.Errorf("error connecting to fallback EC at [%s]: %w", fallbackEcUrl, err)
 		}
+		fallbackEc = ethclient.NewClient(fallbackRpc)
 	}
 
 	return &ExecutionClientManager{
@@ -82,9 +116,17 @@ func NewExecutionClientManager(cfg *config.RocketPoolConfig) (*ExecutionClientMa
 		fallbackEcUrl: fallbackEcUrl,
 		primaryEc:     primaryEc,
 		fallbackEc:    fallbackEc,
+		primaryRpc:    primaryRpc,
+		fallbackRpc:   fallbackRpc,
 		logger:        log.NewColorLogger(color.FgYellow),
 		primaryReady:  true,
 		fallbackReady: fallbackEc != nil,
+		chainID:       big.NewInt(int64(cfg.Smartnode.GetChainID())),
+
+		commandQueuePath: cfg.Smartnode.GetCommandQueuePath(),
+
+		primaryProfile:  providers.Detect(primaryEcUrl),
+		fallbackProfile: providers.Detect(fallbackEcUrl),
 	}, nil
 
 }
@@ -156,11 +198,16 @@ func (p *ExecutionClientManager) PendingCodeAt(ctx context.Context, account comm
 }
 
 // PendingNonceAt retrieves the current pending nonce associated with an account.
+// If it's assigning the nonce of a transaction from the node wallet, the command queue is held until the transaction is sent.
 func (p *ExecutionClientManager) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	if err := cmdqueue.OpenSendWindow(ctx, p.commandQueuePath); err != nil {
+		return 0, err
+	}
 	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
 		return client.PendingNonceAt(ctx, account)
 	})
 	if err != nil {
+		cmdqueue.CloseSendWindow(ctx)
 		return 0, err
 	}
 	return result.(uint64), err
@@ -190,6 +237,17 @@ func (p *ExecutionClientManager) SuggestGasTipCap(ctx context.Context) (*big.Int
 	return result.(*big.Int), err
 }
 
+// FeeHistory retrieves the fee market history.
+func (p *ExecutionClientManager) FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error) {
+	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+		return client.FeeHistory(ctx, blockCount, lastBlock, rewardPercentiles)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*ethereum.FeeHistory), err
+}
+
 // EstimateGas tries to estimate the gas needed to execute a specific
 // transaction based on the current pending state of the backend blockchain.
 // There is no guarantee that this is the true gas limit requirement as other
@@ -206,8 +264,21 @@ func (p *ExecutionClientManager) EstimateGas(ctx context.Context, call ethereum.
 }
 
 // SendTransaction injects the transaction into the pending pool for execution.
+// The transaction and the client it's sent through must both be on the chain the node is configured for, so a transaction
+// signed for one network is never broadcast to another.
 func (p *ExecutionClientManager) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	defer cmdqueue.CloseSendWindow(ctx)
+	if tx.ChainId().Cmp(p.chainID) != 0 {
+		return fmt.Errorf("refusing to send transaction %s: it was signed for chain ID %s, but the node is configured for %s (chain ID %s)", tx.Hash().Hex(), tx.ChainId().String(), getNetworkNameFromId(uint(p.chainID.Uint64())), p.chainID.String())
+	}
 	_, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+		chainID, err := client.ChainID(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if chainID.Cmp(p.chainID) != 0 {
+			return nil, fmt.Errorf("refusing to send transaction %s: the Execution client is on chain ID %s, but the node is configured for %s (chain ID %s)", tx.Hash().Hex(), chainID.String(), getNetworkNameFromId(uint(p.chainID.Uint64())), p.chainID.String())
+		}
 		return nil, client.SendTransaction(ctx, tx)
 	})
 	return err
@@ -328,6 +399,115 @@ func (p *ExecutionClientManager) SyncProgress(ctx context.Context) (*ethereum.Sy
 	return result.(*ethereum.SyncProgress), err
 }
 
+// ChainID retrieves the current chain ID for transaction replay protection.
+func (p *ExecutionClientManager) ChainID(ctx context.Context) (*big.Int, error) {
+	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+		return client.ChainID(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*big.Int), err
+}
+
+// Check that the primary and fallback clients are on the chain the node is configured for.
+// The chain ID is pinned from whichever of them can be reached, so the node can start on the fallback while the primary is down;
+// a client that can't be reached now is still checked before each transaction sent through it.
+// Returns an error if none of them can be reached or any of them is on a different chain, so the daemons can retry until they agree.
+func (p *ExecutionClientManager) PinChainID(ctx context.Context) error {
+	expectedName := getNetworkNameFromId(uint(p.chainID.Uint64()))
+
+	// Check the primary
+	pinned := false
+	primaryChainID, err := p.primaryEc.ChainID(ctx)
+	if err != nil {
+		p.logger.Warnf("WARNING: Couldn't get the chain ID of the primary Execution client at [%s]: %s", p.primaryEcUrl, err.Error())
+	} else if primaryChainID.Cmp(p.chainID) != 0 {
+		message := fmt.Sprintf("the primary Execution client at [%s] is on %s (chain ID %s), but the node is configured for %s (chain ID %s); refusing to run against the wrong network", p.primaryEcUrl, getNetworkNameFromId(uint(primaryChainID.Uint64())), primaryChainID.String(), expectedName, p.chainID.String())
+		if forkClient, err := p.GetForkClient(ctx); err == nil && forkClient != ForkClient_None {
+			message += fmt.Sprintf(". It's a local %s fork; start it with the chain ID of the network it forks (such as `--chain-id %s`)", forkClient, p.chainID.String())
+		}
+		return errors.New(message)
+	} else {
+		pinned = true
+	}
+
+	// Check the fallback
+	if p.fallbackEc != nil {
+		fallbackChainID, err := p.fallbackEc.ChainID(ctx)
+		if err != nil {
+			p.logger.Warnf("WARNING: Couldn't get the chain ID of the fallback Execution client at [%s]: %s", p.fallbackEcUrl, err.Error())
+		} else if fallbackChainID.Cmp(p.chainID) != 0 {
+			return fmt.Errorf("the fallback Execution client at [%s] is on %s (chain ID %s), but the node is configured for %s (chain ID %s); refusing to run against the wrong network", p.fallbackEcUrl, getNetworkNameFromId(uint(fallbackChainID.Uint64())), fallbackChainID.String(), expectedName, p.chainID.String())
+		} else {
+			pinned = true
+		}
+	}
+
+	if !pinned {
+		return errors.New("none of the Execution clients could be reached to check their chain ID")
+	}
+	return nil
+}
+
+/// ===================
+/// Raw RPC Functions
+/// ===================
+
+// CallContext performs a JSON-RPC call with the given arguments on the client that's in use.
+// Calls to the debug_ namespace fail with ErrDebugNamespaceUnsupported right away if the client's provider is known not to serve it.
+func (p *ExecutionClientManager) CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	return p.runRpcFunction(func(client *rpc.Client, profile *providers.Profile) error {
+		if profile != nil && !profile.HasDebugNamespace && strings.HasPrefix(method, "debug_") {
+			return fmt.Errorf("can't call %s on %s: %w", method, profile.Name, ErrDebugNamespaceUnsupported)
+		}
+		return client.CallContext(ctx, result, method, args...)
+	})
+}
+
+// BatchCallContext sends all of the given requests as JSON-RPC batches on the client that's in use.
+// The batch is split into several if the client's provider caps how many requests a batch can hold.
+func (p *ExecutionClientManager) BatchCallContext(ctx context.Context, batch []rpc.BatchElem) error {
+	return p.runRpcFunction(func(client *rpc.Client, profile *providers.Profile) error {
+		if profile != nil {
+			for _, elem := range batch {
+				if !profile.HasDebugNamespace && strings.HasPrefix(elem.Method, "debug_") {
+					return fmt.Errorf("can't call %s on %s: %w", elem.Method, profile.Name, ErrDebugNamespaceUnsupported)
+				}
+			}
+		}
+		size := len(batch)
+		if profile != nil && profile.MaxBatchSize > 0 && profile.MaxBatchSize < size {
+			size = profile.MaxBatchSize
+		}
+		for start := 0; start < len(batch); start += size {
+			end := start + size
+			if end > len(batch) {
+				end = len(batch)
+			}
+			if err := client.BatchCallContext(ctx, batch[start:end]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Get the most blocks an eth_getLogs query can cover on the client that's in use, or 0 if its provider doesn't have a known limit.
+// The log scanner starts its chunks at this size so it doesn't have to find the limit by failing.
+func (p *ExecutionClientManager) GetMaxLogRange() uint64 {
+	profile := p.getActiveProfile()
+	if profile == nil {
+		return 0
+	}
+	return profile.MaxLogRange
+}
+
+// True if the primary client is unavailable and requests are going to the fallback client instead
+func (p *ExecutionClientManager) IsUsingFallback() bool {
+	return !p.primaryReady && p.fallbackReady
+}
+
 /// ==================
 /// Internal functions
 /// ==================
@@ -350,16 +530,27 @@ func (p *ExecutionClientManager) CheckStatus(cfg *config.RocketPoolConfig) *api.
 	}
 
 	// Get the primary EC status
-	status.PrimaryClientStatus = checkEcStatus(p.primaryEc)
+	status.PrimaryClientStatus = checkEcStatus(p.primaryEc, p.primaryRpc)
+	if p.primaryProfile != nil {
+		status.PrimaryClientStatus.Provider = p.primaryProfile.Name
+	}
+	expectedChainID := cfg.Smartnode.GetChainID()
 
-	// Flag if primary client is ready
+	// Flag if primary client is ready, which it can't be if it's on a different chain
 	p.primaryReady = (status.PrimaryClientStatus.IsWorking && status.PrimaryClientStatus.IsSynced)
+	if status.PrimaryClientStatus.Error == "" && status.PrimaryClientStatus.NetworkId != expectedChainID {
+		p.primaryReady = false
+		status.PrimaryClientStatus.IsWorking = false
+		status.PrimaryClientStatus.Error = fmt.Sprintf("The primary client is using a different chain [%s, Chain ID %d] than what your node is configured for [%s, Chain ID %d]", getNetworkNameFromId(status.PrimaryClientStatus.NetworkId), status.PrimaryClientStatus.NetworkId, getNetworkNameFromId(expectedChainID), expectedChainID)
+	}
 
 	// Get the fallback EC status if applicable
 	if status.FallbackEnabled {
-		status.FallbackClientStatus = checkEcStatus(p.fallbackEc)
+		status.FallbackClientStatus = checkEcStatus(p.fallbackEc, p.fallbackRpc)
+		if p.fallbackProfile != nil {
+			status.FallbackClientStatus.Provider = p.fallbackProfile.Name
+		}
 		// Check if fallback is using the expected network
-		expectedChainID := cfg.Smartnode.GetChainID()
 		if status.FallbackClientStatus.Error == "" && status.FallbackClientStatus.NetworkId != expectedChainID {
 			p.fallbackReady = false
 			colorReset := "\033[0m"
@@ -380,6 +571,8 @@ func getNetworkNameFromId(networkId uint) string {
 		return "Ethereum Mainnet"
 	case 5:
 		return "Goerli Testnet"
+	case 17000:
+		return "Holesky Testnet"
 	default:
 		return "Unknown Network"
 	}
@@ -387,7 +580,7 @@ func getNetworkNameFromId(networkId uint) string {
 }
 
 // Check the client status
-func checkEcStatus(client *ethclient.Client) api.ClientStatus {
+func checkEcStatus(client *ethclient.Client, rpcClient *rpc.Client) api.ClientStatus {
 
 	status := api.ClientStatus{}
 
@@ -426,6 +619,12 @@ func checkEcStatus(client *ethclient.Client) api.ClientStatus {
 
 		status.IsWorking = true
 		if !isUpToDate {
+			// Local forks only mine when told to, so their last block can be old without them being out of sync
+			if forkClient, err := GetForkClient(context.Background(), rpcClient); err == nil && forkClient != ForkClient_None {
+				status.IsSynced = true
+				status.SyncProgress = 1
+				return status
+			}
 			status.Error = fmt.Sprintf("Client claims to have finished syncing, but its last block was from %s ago. It likely doesn't have enough peers", time.Since(blockTime))
 			status.IsSynced = false
 			status.SyncProgress = 0
@@ -465,12 +664,13 @@ func (p *ExecutionClientManager) runFunction(function ecFunction) (interface{},
 		if err != nil {
 			if p.isDisconnected(err) {
 				// If it's disconnected, log it and try the fallback
-				p.logger.Printlnf("WARNING: Primary Execution client disconnected (%s), using fallback...", err.Error())
+				p.logger.Warnf("WARNING: Primary Execution client disconnected (%s), using fallback...", err.Error())
 				p.primaryReady = false
 				return p.runFunction(function)
 			}
 
 			// If it's a different error, just return it
+			p.detectProfile(true, err)
 			return nil, err
 		}
 
@@ -484,12 +684,13 @@ func (p *ExecutionClientManager) runFunction(function ecFunction) (interface{},
 		if err != nil {
 			if p.isDisconnected(err) {
 				// If it's disconnected, log it and try the fallback
-				p.logger.Printlnf("WARNING: Fallback Execution client disconnected (%s)", err.Error())
+				p.logger.Warnf("WARNING: Fallback Execution client disconnected (%s)", err.Error())
 				p.fallbackReady = false
 				return nil, fmt.Errorf("all Execution clients failed")
 			}
 
 			// If it's a different error, just return it
+			p.detectProfile(false, err)
 			return nil, err
 		}
 
@@ -500,7 +701,59 @@ func (p *ExecutionClientManager) runFunction(function ecFunction) (interface{},
 	return nil, fmt.Errorf("no Execution clients were ready")
 }
 
-// Returns true if the error was a connection failure and a backup client is available
+// Attempts to run a raw RPC function progressively through each client until one succeeds or they all fail, passing it the client's provider profile
+func (p *ExecutionClientManager) runRpcFunction(function func(*rpc.Client, *providers.Profile) error) error {
+
+	if p.primaryReady {
+		err := function(p.primaryRpc, p.primaryProfile)
+		if err != nil {
+			if p.isDisconnected(err) {
+				p.logger.Warnf("WARNING: Primary Execution client disconnected (%s), using fallback...", err.Error())
+				p.primaryReady = false
+				return p.runRpcFunction(function)
+			}
+			p.detectProfile(true, err)
+			return err
+		}
+		return nil
+	}
+
+	if p.fallbackReady {
+		err := function(p.fallbackRpc, p.fallbackProfile)
+		if err != nil {
+			if p.isDisconnected(err) {
+				p.logger.Warnf("WARNING: Fallback Execution client disconnected (%s)", err.Error())
+				p.fallbackReady = false
+				return fmt.Errorf("all Execution clients failed")
+			}
+			p.detectProfile(false, err)
+			return err
+		}
+		return nil
+	}
+
+	return fmt.Errorf("no Execution clients were ready")
+}
+
+// Recognize the provider behind a client from an error only it returns, if it wasn't recognized from its URL
+func (p *ExecutionClientManager) detectProfile(isPrimary bool, err error) {
+	if isPrimary && p.primaryProfile == nil {
+		p.primaryProfile = providers.DetectFromError(err)
+	} else if !isPrimary && p.fallbackProfile == nil {
+		p.fallbackProfile = providers.DetectFromError(err)
+	}
+}
+
+// Get the provider profile of the client that's in use
+func (p *ExecutionClientManager) getActiveProfile() *providers.Profile {
+	if !p.primaryReady && p.fallbackReady {
+		return p.fallbackProfile
+	}
+	return p.primaryProfile
+}
+
+// Returns true if the error was a connection failure and a backup client is available.
+// A client that just went down can also drop the connections that were already open, which fail with EOF or a reset instead of a dial error.
 func (p *ExecutionClientManager) isDisconnected(err error) bool {
-	return strings.Contains(err.Error(), "dial tcp")
+	return strings.Contains(err.Error(), "dial tcp") || errors.Is(err, io.EOF) || errors.Is(err, syscall.ECONNRESET)
 }
@@ -0,0 +1,198 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/simulator"
+)
+
+// Start a simulated primary and fallback Execution client, and a manager that uses them
+func newSimulatedEcManager(t *testing.T) (*ExecutionClientManager, *config.RocketPoolConfig, *simulator.ExecutionServer, *simulator.ExecutionServer) {
+	t.Helper()
+
+	cfg := config.NewRocketPoolConfig(t.TempDir(), true)
+	chainID := uint64(cfg.Smartnode.GetChainID())
+	genesisTime := time.Now().Add(-time.Minute)
+
+	primary := simulator.NewExecutionServer("127.0.0.1:0", chainID, genesisTime)
+	fallback := simulator.NewExecutionServer("127.0.0.1:0", chainID, genesisTime)
+	for _, server := range []*simulator.ExecutionServer{primary, fallback} {
+		if err := server.Start(); err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func(server *simulator.ExecutionServer) func() {
+			return func() { server.Stop() }
+		}(server))
+	}
+
+	cfg.Native.EcHttpUrl.Value = primary.Url()
+	cfg.UseFallbackClients.Value = true
+	cfg.FallbackNormal.EcHttpUrl.Value = fallback.Url()
+
+	manager, err := NewExecutionClientManager(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return manager, cfg, primary, fallback
+}
+
+// Get the block number through the manager, failing the test if it can't
+func getBlockNumber(t *testing.T, manager *ExecutionClientManager) uint64 {
+	t.Helper()
+	blockNumber, err := manager.BlockNumber(context.Background())
+	if err != nil {
+		t.Fatalf("error getting block number: %s", err.Error())
+	}
+	return blockNumber
+}
+
+func TestEcManagerFailsOverWhenPrimaryGoesDown(t *testing.T) {
+	manager, _, primary, fallback := newSimulatedEcManager(t)
+
+	// Give the clients different heads so it's clear which one answered
+	primary.Mine(1)
+	fallback.Mine(2)
+
+	if blockNumber := getBlockNumber(t, manager); blockNumber != 1 {
+		t.Fatalf("expected the primary's block 1, got %d", blockNumber)
+	}
+	if manager.IsUsingFallback() {
+		t.Fatal("expected the primary to be in use")
+	}
+
+	if err := primary.Stop(); err != nil {
+		t.Fatal(err)
+	}
+	if blockNumber := getBlockNumber(t, manager); blockNumber != 2 {
+		t.Fatalf("expected the fallback's block 2 after the primary went down, got %d", blockNumber)
+	}
+	if !manager.IsUsingFallback() {
+		t.Fatal("expected the fallback to be in use after the primary went down")
+	}
+}
+
+func TestEcManagerReturnsToPrimaryOnceItRecovers(t *testing.T) {
+	manager, cfg, primary, fallback := newSimulatedEcManager(t)
+	primary.Mine(1)
+	fallback.Mine(2)
+
+	if err := primary.Stop(); err != nil {
+		t.Fatal(err)
+	}
+	getBlockNumber(t, manager)
+	if !manager.IsUsingFallback() {
+		t.Fatal("expected the fallback to be in use after the primary went down")
+	}
+
+	// The primary comes back on the same address, and the next status check picks it up again
+	if err := primary.Start(); err != nil {
+		t.Fatal(err)
+	}
+	status := manager.CheckStatus(cfg)
+	if !status.PrimaryClientStatus.IsWorking || !status.PrimaryClientStatus.IsSynced {
+		t.Fatalf("expected the recovered primary to be working and synced, got %+v", status.PrimaryClientStatus)
+	}
+	if blockNumber := getBlockNumber(t, manager); blockNumber != 1 {
+		t.Fatalf("expected the primary's block 1 after it recovered, got %d", blockNumber)
+	}
+}
+
+func TestEcManagerFailsWhenAllClientsAreDown(t *testing.T) {
+	manager, _, primary, fallback := newSimulatedEcManager(t)
+	primary.Stop()
+	fallback.Stop()
+
+	if _, err := manager.BlockNumber(context.Background()); err == nil {
+		t.Fatal("expected an error with every client down")
+	}
+	if _, err := manager.BlockNumber(context.Background()); err == nil {
+		t.Fatal("expected an error once no client is ready")
+	}
+}
+
+func TestEcManagerUsesFallbackWhilePrimarySyncs(t *testing.T) {
+	manager, cfg, primary, fallback := newSimulatedEcManager(t)
+	primary.Mine(1)
+	fallback.Mine(2)
+
+	primary.SetSyncing(&simulator.ExecutionSyncStatus{
+		StartingBlock: 0,
+		CurrentBlock:  25,
+		HighestBlock:  100,
+	})
+	status := manager.CheckStatus(cfg)
+	if !status.PrimaryClientStatus.IsWorking {
+		t.Fatalf("expected the syncing primary to be working, got %+v", status.PrimaryClientStatus)
+	}
+	if status.PrimaryClientStatus.IsSynced {
+		t.Fatal("expected the syncing primary not to be synced")
+	}
+	if status.PrimaryClientStatus.SyncProgress != 0.25 {
+		t.Fatalf("expected the primary's sync progress to be 0.25, got %f", status.PrimaryClientStatus.SyncProgress)
+	}
+	if !status.FallbackClientStatus.IsSynced {
+		t.Fatalf("expected the fallback to be synced, got %+v", status.FallbackClientStatus)
+	}
+	if blockNumber := getBlockNumber(t, manager); blockNumber != 2 {
+		t.Fatalf("expected the fallback's block 2 while the primary syncs, got %d", blockNumber)
+	}
+
+	// Once the primary finishes syncing, it's used again
+	primary.SetSyncing(nil)
+	status = manager.CheckStatus(cfg)
+	if !status.PrimaryClientStatus.IsSynced {
+		t.Fatalf("expected the primary to be synced, got %+v", status.PrimaryClientStatus)
+	}
+	if blockNumber := getBlockNumber(t, manager); blockNumber != 1 {
+		t.Fatalf("expected the primary's block 1 once it synced, got %d", blockNumber)
+	}
+}
+
+func TestEcManagerReportsStaleClientAsNotSynced(t *testing.T) {
+	cfg := config.NewRocketPoolConfig(t.TempDir(), true)
+	primary := simulator.NewExecutionServer("127.0.0.1:0", uint64(cfg.Smartnode.GetChainID()), time.Now().Add(-24*time.Hour))
+	if err := primary.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer primary.Stop()
+	cfg.Native.EcHttpUrl.Value = primary.Url()
+
+	manager, err := NewExecutionClientManager(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	status := manager.CheckStatus(cfg)
+	if !status.PrimaryClientStatus.IsWorking {
+		t.Fatalf("expected the stale primary to be working, got %+v", status.PrimaryClientStatus)
+	}
+	if status.PrimaryClientStatus.IsSynced {
+		t.Fatal("expected a client whose last block is a day old not to be synced")
+	}
+	if _, err := manager.BlockNumber(context.Background()); err == nil {
+		t.Fatal("expected an error with no synced client")
+	}
+}
+
+func TestEcManagerRejectsClientOnWrongChain(t *testing.T) {
+	manager, cfg, primary, fallback := newSimulatedEcManager(t)
+	primary.Mine(1)
+	fallback.Mine(2)
+
+	primary.SetChainID(uint64(cfg.Smartnode.GetChainID()) + 1)
+	status := manager.CheckStatus(cfg)
+	if status.PrimaryClientStatus.IsWorking {
+		t.Fatal("expected the primary on the wrong chain not to be working")
+	}
+	if status.PrimaryClientStatus.Error == "" {
+		t.Fatal("expected an error for the primary on the wrong chain")
+	}
+	if blockNumber := getBlockNumber(t, manager); blockNumber != 2 {
+		t.Fatalf("expected the fallback's block 2 while the primary is on the wrong chain, got %d", blockNumber)
+	}
+	if err := manager.PinChainID(context.Background()); err == nil {
+		t.Fatal("expected pinning the chain ID to fail with the primary on the wrong chain")
+	}
+}
@@ -0,0 +1,91 @@
+package services
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rpcusage"
+)
+
+// An HTTP round tripper that adds authentication to every request
+type authRoundTripper struct {
+	base     http.RoundTripper
+	settings *config.EndpointTransportSettings
+}
+
+func (t *authRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	if t.settings.Username == "" && t.settings.BearerToken == "" {
+		return t.base.RoundTrip(request)
+	}
+	request = request.Clone(request.Context())
+	if t.settings.Username != "" {
+		request.SetBasicAuth(t.settings.Username, t.settings.Password)
+	} else {
+		request.Header.Set("Authorization", "Bearer "+t.settings.BearerToken)
+	}
+	return t.base.RoundTrip(request)
+}
+
+// Create an HTTP client that connects to an endpoint with its transport settings, or the default transport if it doesn't have any.
+// The requests it sends are recorded as RPC usage of the endpoint.
+func newEndpointHttpClient(endpoint string, settings *config.EndpointTransportSettings) (*http.Client, error) {
+	if settings == nil {
+		return &http.Client{
+			Transport: rpcusage.NewRoundTripper(endpoint, http.DefaultTransport),
+		}, nil
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if settings.ProxyUrl != nil {
+		transport.Proxy = http.ProxyURL(settings.ProxyUrl)
+	}
+
+	if settings.TlsCertPath != "" || settings.TlsCaPath != "" {
+		tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+		if settings.TlsCertPath != "" {
+			certificate, err := tls.LoadX509KeyPair(settings.TlsCertPath, settings.TlsKeyPath)
+			if err != nil {
+				return nil, fmt.Errorf("error loading TLS client certificate: %w", err)
+			}
+			tlsConfig.Certificates = []tls.Certificate{certificate}
+		}
+		if settings.TlsCaPath != "" {
+			caBytes, err := os.ReadFile(settings.TlsCaPath)
+			if err != nil {
+				return nil, fmt.Errorf("error reading TLS CA certificate: %w", err)
+			}
+			pool := x509.NewCertPool()
+			if !pool.AppendCertsFromPEM(caBytes) {
+				return nil, fmt.Errorf("TLS CA certificate [%s] doesn't contain any PEM-encoded certificates", settings.TlsCaPath)
+			}
+			tlsConfig.RootCAs = pool
+		}
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	return &http.Client{
+		Transport: rpcusage.NewRoundTripper(endpoint, &authRoundTripper{
+			base:     transport,
+			settings: settings,
+		}),
+	}, nil
+}
+
+// Connect to an Execution client, using its transport settings if it has any.
+// Only HTTP connections are recorded as RPC usage; websocket and IPC connections are dialed as they are.
+func dialExecutionClient(endpoint string, url string, settings *config.EndpointTransportSettings) (*rpc.Client, error) {
+	if settings == nil && !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+		return rpc.Dial(url)
+	}
+	httpClient, err := newEndpointHttpClient(endpoint, settings)
+	if err != nil {
+		return nil, err
+	}
+	return rpc.DialHTTPWithClient(url, httpClient)
+}
@@ -0,0 +1,76 @@
+package ens
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	goens "github.com/wealdtech/go-ens/v3"
+)
+
+// Resolves ENS names to addresses and addresses back to their primary ENS names
+type Resolver struct {
+	client bind.ContractBackend
+
+	// Reverse lookups are cached, including the ones that didn't find a name, since status output formats the same addresses
+	// several times
+	names map[common.Address]string
+	lock  sync.Mutex
+}
+
+// Create a new resolver on top of an execution client
+func NewResolver(client bind.ContractBackend) *Resolver {
+	return &Resolver{
+		client: client,
+		names:  map[common.Address]string{},
+	}
+}
+
+// Get the address an ENS name resolves to
+func (r *Resolver) Resolve(name string) (common.Address, error) {
+	address, err := goens.Resolve(r.client, name)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("error resolving ENS name %s: %w", name, err)
+	}
+	return address, nil
+}
+
+// Get the primary ENS name of an address, or an empty string if it doesn't have one.
+// Anyone can set the reverse record of their own address to any name, so the name only counts if it resolves back to the address.
+func (r *Resolver) ReverseResolve(address common.Address) (string, error) {
+	r.lock.Lock()
+	name, exists := r.names[address]
+	r.lock.Unlock()
+	if exists {
+		return name, nil
+	}
+
+	name = ""
+	if address != (common.Address{}) {
+		reverseName, err := goens.ReverseResolve(r.client, address)
+		if err == nil {
+			forwardAddress, err := goens.Resolve(r.client, reverseName)
+			if err != nil {
+				return "", fmt.Errorf("error verifying ENS name %s of %s: %w", reverseName, address.Hex(), err)
+			}
+			if forwardAddress == address {
+				name = reverseName
+			}
+		}
+	}
+
+	r.lock.Lock()
+	r.names[address] = name
+	r.lock.Unlock()
+	return name, nil
+}
+
+// Format an address for display, with its primary ENS name in front of it if it has one
+func (r *Resolver) FormatAddress(address common.Address) string {
+	name, err := r.ReverseResolve(address)
+	if err != nil || name == "" {
+		return address.Hex()
+	}
+	return fmt.Sprintf("%s (%s)", name, address.Hex())
+}
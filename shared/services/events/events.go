@@ -0,0 +1,112 @@
+package events
+
+import (
+	"sync"
+	"time"
+)
+
+// Settings
+const (
+	// How many recent events are kept for subscribers that reconnect
+	historySize int = 100
+
+	// How many events can wait for a slow subscriber before it's dropped
+	subscriberBuffer int = 64
+)
+
+// The kinds of events the node daemon publishes
+type EventType string
+
+const (
+	EventType_MinipoolDetected     EventType = "minipool-detected"
+	EventType_TransactionConfirmed EventType = "transaction-confirmed"
+	EventType_TransactionFailed    EventType = "transaction-failed"
+	EventType_ClientFailover       EventType = "client-failover"
+	EventType_ClientRecovered      EventType = "client-recovered"
+	EventType_RewardsAvailable     EventType = "rewards-available"
+	EventType_AlertRaised          EventType = "alert-raised"
+	EventType_AlertResolved        EventType = "alert-resolved"
+)
+
+// Something that happened in the node daemon
+type Event struct {
+	ID   uint64      `json:"id"`
+	Type EventType   `json:"type"`
+	Time time.Time   `json:"time"`
+	Data interface{} `json:"data"`
+}
+
+// Hands the node daemon's events to everyone listening for them.
+// Subscribers that can't keep up are dropped instead of holding up the daemon; they can reconnect and catch up from the history.
+type Broker struct {
+	nextID      uint64
+	history     []Event
+	subscribers map[chan Event]bool
+	lock        *sync.Mutex
+}
+
+// Create a new event broker
+func NewBroker() *Broker {
+	return &Broker{
+		nextID:      1,
+		history:     []Event{},
+		subscribers: map[chan Event]bool{},
+		lock:        &sync.Mutex{},
+	}
+}
+
+// Publish an event to every subscriber
+func (b *Broker) Publish(eventType EventType, data interface{}) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	event := Event{
+		ID:   b.nextID,
+		Type: eventType,
+		Time: time.Now().UTC(),
+		Data: data,
+	}
+	b.nextID++
+	b.history = append(b.history, event)
+	if len(b.history) > historySize {
+		b.history = b.history[len(b.history)-historySize:]
+	}
+
+	for subscriber := range b.subscribers {
+		select {
+		case subscriber <- event:
+		default:
+			delete(b.subscribers, subscriber)
+			close(subscriber)
+		}
+	}
+}
+
+// Subscribe to new events, starting with the ones in the history after the provided ID.
+// The channel is closed if the subscriber falls too far behind.
+func (b *Broker) Subscribe(afterID uint64) chan Event {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	subscriber := make(chan Event, subscriberBuffer+historySize)
+	if afterID > 0 {
+		for _, event := range b.history {
+			if event.ID > afterID {
+				subscriber <- event
+			}
+		}
+	}
+	b.subscribers[subscriber] = true
+	return subscriber
+}
+
+// Stop sending events to a subscriber
+func (b *Broker) Unsubscribe(subscriber chan Event) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.subscribers[subscriber] {
+		delete(b.subscribers, subscriber)
+		close(subscriber)
+	}
+}
@@ -0,0 +1,98 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// A development client running a local fork of a live network, whose chain only advances when it's told to
+type ForkClient string
+
+const (
+	ForkClient_None    ForkClient = ""
+	ForkClient_Anvil   ForkClient = "anvil"
+	ForkClient_Hardhat ForkClient = "hardhat"
+)
+
+// Check if an Execution client is a development client running a local fork, from the version it reports
+func GetForkClient(ctx context.Context, client *rpc.Client) (ForkClient, error) {
+	var version string
+	if err := client.CallContext(ctx, &version, "web3_clientVersion"); err != nil {
+		return ForkClient_None, fmt.Errorf("error getting client version: %w", err)
+	}
+	version = strings.ToLower(version)
+	switch {
+	case strings.HasPrefix(version, "anvil/"):
+		return ForkClient_Anvil, nil
+	case strings.HasPrefix(version, "hardhatnetwork/"):
+		return ForkClient_Hardhat, nil
+	default:
+		return ForkClient_None, nil
+	}
+}
+
+// Get the development client the primary Execution client is, or ForkClient_None if it's a regular client
+func (p *ExecutionClientManager) GetForkClient(ctx context.Context) (ForkClient, error) {
+	return GetForkClient(ctx, p.primaryRpc)
+}
+
+// Move the fork's clock forward and mine a block at the new time, so time-based checks such as the scrub period and
+// rewards intervals can be passed without waiting
+func (p *ExecutionClientManager) IncreaseForkTime(ctx context.Context, seconds uint64) error {
+	if _, err := p.requireFork(ctx); err != nil {
+		return err
+	}
+	var offset interface{}
+	if err := p.primaryRpc.CallContext(ctx, &offset, "evm_increaseTime", seconds); err != nil {
+		return fmt.Errorf("error increasing the fork's time: %w", err)
+	}
+	var result interface{}
+	if err := p.primaryRpc.CallContext(ctx, &result, "evm_mine"); err != nil {
+		return fmt.Errorf("error mining a block at the new time: %w", err)
+	}
+	return nil
+}
+
+// Mine blocks on the fork, such as to confirm transactions on a fork that doesn't mine automatically
+func (p *ExecutionClientManager) MineForkBlocks(ctx context.Context, count uint64) error {
+	forkClient, err := p.requireFork(ctx)
+	if err != nil {
+		return err
+	}
+	var result interface{}
+	if err := p.primaryRpc.CallContext(ctx, &result, string(forkClient)+"_mine", hexutil.Uint64(count)); err != nil {
+		return fmt.Errorf("error mining blocks: %w", err)
+	}
+	return nil
+}
+
+// Set the ETH balance of an address on the fork, such as to fund the node wallet
+func (p *ExecutionClientManager) SetForkBalance(ctx context.Context, address common.Address, balance *big.Int) error {
+	forkClient, err := p.requireFork(ctx)
+	if err != nil {
+		return err
+	}
+	var result interface{}
+	if err := p.primaryRpc.CallContext(ctx, &result, string(forkClient)+"_setBalance", address, (*hexutil.Big)(balance)); err != nil {
+		return fmt.Errorf("error setting the balance of %s: %w", address.Hex(), err)
+	}
+	return nil
+}
+
+// Make sure the primary Execution client is a local fork before changing its chain
+func (p *ExecutionClientManager) requireFork(ctx context.Context) (ForkClient, error) {
+	forkClient, err := p.GetForkClient(ctx)
+	if err != nil {
+		return ForkClient_None, err
+	}
+	if forkClient == ForkClient_None {
+		return ForkClient_None, fmt.Errorf("the primary Execution client at [%s] isn't an Anvil or Hardhat fork", p.primaryEcUrl)
+	}
+	return forkClient, nil
+}
@@ -0,0 +1,200 @@
+package gas
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/math"
+)
+
+const secondsPerBlock float64 = 12
+
+// The lowest priority fee a suggestion uses, so transactions still tip something when recent blocks didn't need to
+const minSuggestedPriorityFeeGwei float64 = 0.01
+
+// The options suggested from the fee history, from cheapest to most expensive
+var gasOptions = []cfgtypes.GasOption{cfgtypes.GasOption_Low, cfgtypes.GasOption_Medium, cfgtypes.GasOption_High}
+
+// Which of the fee history percentiles each option's priority fee is based on
+var gasOptionPercentiles = map[cfgtypes.GasOption]int{
+	cfgtypes.GasOption_Low:    0,
+	cfgtypes.GasOption_Medium: 1,
+	cfgtypes.GasOption_High:   2,
+}
+
+// How far each option's max fee lets the base fee rise above the next block's before the transaction can't be included
+var gasOptionHeadroom = map[cfgtypes.GasOption]float64{
+	cfgtypes.GasOption_Low:    1,
+	cfgtypes.GasOption_Medium: 1.25,
+	cfgtypes.GasOption_High:   2,
+}
+
+// A gas price suggested from the recent fee history
+type gasSuggestion struct {
+	option          cfgtypes.GasOption
+	maxFeeGwei      float64
+	priorityFeeGwei float64
+
+	// The fraction of the recent blocks the transaction would have been included in
+	inclusionChance float64
+}
+
+// Suggest a low, medium and high gas price from the fee history. The priority fee of each comes from the fees paid in recent blocks,
+// unless one was requested.
+func getGasSuggestions(history api.GasFeeHistoryResponse, requestedPriorityFeeGwei float64) []gasSuggestion {
+	nextBaseFeeGwei := eth.WeiToGwei(history.NextBaseFee)
+	suggestions := []gasSuggestion{}
+	for _, option := range gasOptions {
+		priorityFeeGwei := requestedPriorityFeeGwei
+		if priorityFeeGwei == 0 {
+			priorityFeeGwei = math.RoundUp(getMedianPriorityFee(history, gasOptionPercentiles[option]), 2)
+			if priorityFeeGwei < minSuggestedPriorityFeeGwei {
+				priorityFeeGwei = minSuggestedPriorityFeeGwei
+			}
+		}
+		maxFeeGwei := math.RoundUp(nextBaseFeeGwei*gasOptionHeadroom[option]+priorityFeeGwei, 2)
+		suggestions = append(suggestions, gasSuggestion{
+			option:          option,
+			maxFeeGwei:      maxFeeGwei,
+			priorityFeeGwei: priorityFeeGwei,
+			inclusionChance: getInclusionChance(history, maxFeeGwei, priorityFeeGwei),
+		})
+	}
+	return suggestions
+}
+
+// Get the median priority fee (in gwei) at one of the fee history percentiles, ignoring empty blocks
+func getMedianPriorityFee(history api.GasFeeHistoryResponse, percentile int) float64 {
+	fees := []float64{}
+	for i, rewards := range history.PriorityFees {
+		if percentile >= len(rewards) || i >= len(history.GasUsedRatios) || history.GasUsedRatios[i] == 0 {
+			continue
+		}
+		fees = append(fees, eth.WeiToGwei(rewards[percentile]))
+	}
+	if len(fees) == 0 {
+		return 0
+	}
+	sort.Float64s(fees)
+	return fees[len(fees)/2]
+}
+
+// Get the fraction of the recent blocks that a transaction with the provided fees would have been included in.
+// A transaction is counted as included if it could pay the block's base fee and would have tipped at least as much as the
+// cheapest transactions in the block did.
+func getInclusionChance(history api.GasFeeHistoryResponse, maxFeeGwei float64, priorityFeeGwei float64) float64 {
+	if len(history.BaseFees) == 0 {
+		return 0
+	}
+	included := 0
+	for i, baseFee := range history.BaseFees {
+		baseFeeGwei := eth.WeiToGwei(baseFee)
+		if maxFeeGwei < baseFeeGwei {
+			continue
+		}
+		tipGwei := maxFeeGwei - baseFeeGwei
+		if priorityFeeGwei < tipGwei {
+			tipGwei = priorityFeeGwei
+		}
+		if i < len(history.PriorityFees) && len(history.PriorityFees[i]) > 0 && i < len(history.GasUsedRatios) && history.GasUsedRatios[i] > 0 {
+			if tipGwei < eth.WeiToGwei(history.PriorityFees[i][0]) {
+				continue
+			}
+		}
+		included++
+	}
+	return float64(included) / float64(len(history.BaseFees))
+}
+
+// Get the suggestion for the provided option, defaulting to the medium one if it's not a known option
+func getGasSuggestion(suggestions []gasSuggestion, option cfgtypes.GasOption) gasSuggestion {
+	for _, suggestion := range suggestions {
+		if suggestion.option == option {
+			return suggestion
+		}
+	}
+	return suggestions[1]
+}
+
+// Format how long a transaction is expected to wait to be included, from its chance of being included in each block
+func formatInclusionWait(inclusionChance float64) string {
+	if inclusionChance == 0 {
+		return "unknown"
+	}
+	wait := time.Duration(secondsPerBlock / inclusionChance * float64(time.Second))
+	return fmt.Sprintf("~%s", wait.Round(time.Second))
+}
+
+// Show the gas suggestions and let the user pick one or enter their own max fee. Returns the max fee and priority fee to use.
+func handleGasSuggestions(fiat *cliutils.FiatFormatter, suggestions []gasSuggestion, defaultOption cfgtypes.GasOption, history api.GasFeeHistoryResponse, gasInfo rocketpool.GasInfo, gasLimit uint64, priorityFee float64) (float64, float64) {
+
+	fmt.Printf("%s+==================================== Suggested Gas Prices ====================================+\n", colorBlue)
+	fmt.Println("| Option |   Max Fee   | Priority Fee | Per-Block Inclusion | Est. Wait |   Total Gas Cost   |")
+	for _, suggestion := range suggestions {
+		var lowCost float64
+		var highCost float64
+		if gasLimit == 0 {
+			lowCost = suggestion.maxFeeGwei / eth.WeiPerGwei * float64(gasInfo.EstGasLimit)
+			highCost = suggestion.maxFeeGwei / eth.WeiPerGwei * float64(gasInfo.SafeGasLimit)
+		} else {
+			lowCost = suggestion.maxFeeGwei / eth.WeiPerGwei * float64(gasLimit)
+			highCost = lowCost
+		}
+		name := string(suggestion.option)
+		if suggestion.option == defaultOption {
+			name += "*"
+		}
+		fmt.Printf("| %-6s | %-11s | %-12s | %-19s | %-9s | %.4f to %.4f ETH |\n",
+			name,
+			fmt.Sprintf("%.2f gwei", suggestion.maxFeeGwei),
+			fmt.Sprintf("%.2f gwei", suggestion.priorityFeeGwei),
+			fmt.Sprintf("%.0f%%", suggestion.inclusionChance*100),
+			formatInclusionWait(suggestion.inclusionChance),
+			lowCost, highCost)
+		if fiatCost := fiat.EthRange(lowCost, highCost); fiatCost != "" {
+			fmt.Printf("| %-6s | %-11s | %-12s | %-19s | %-9s | %-20s |\n", "", "", "", "", "", fiatCost)
+		}
+	}
+	fmt.Printf("+==============================================================================================+\n\n%s", colorReset)
+
+	fmt.Printf("The next block's base fee is %.2f gwei. These suggestions are based on the fees paid in the last %d blocks; the costs assume the full max fee is paid, though you'll usually pay less.\n", eth.WeiToGwei(history.NextBaseFee), len(history.BaseFees))
+	fmt.Printf("The option marked with * is the default, which you can change with the Default Gas Option setting in `rocketpool service config`.\n")
+
+	for {
+		desiredOption := cliutils.Prompt(
+			fmt.Sprintf("Please choose an option (low, medium or high), enter your own max fee (including the priority fee) in gwei, or leave blank for the default (%s):", defaultOption),
+			"(?i)^(?:low|medium|high|(?:[1-9]\\d*|0)?(?:\\.\\d+)?)$",
+			"Not a valid option or gas price, try again:")
+
+		if desiredOption == "" {
+			suggestion := getGasSuggestion(suggestions, defaultOption)
+			return suggestion.maxFeeGwei, suggestion.priorityFeeGwei
+		}
+		for _, suggestion := range suggestions {
+			if strings.EqualFold(desiredOption, string(suggestion.option)) {
+				return suggestion.maxFeeGwei, suggestion.priorityFeeGwei
+			}
+		}
+
+		desiredPriceFloat, err := strconv.ParseFloat(desiredOption, 64)
+		if err != nil {
+			fmt.Printf("Not a valid gas price (%s), try again.\n", err.Error())
+			continue
+		}
+		if desiredPriceFloat <= 0 {
+			fmt.Println("Max fee must be greater than zero.")
+			continue
+		}
+
+		return desiredPriceFloat, priorityFee
+	}
+
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/gas/etherchain"
 	"github.com/rocket-pool/smartnode/shared/services/gas/etherscan"
 	rpsvc "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 	"github.com/rocket-pool/smartnode/shared/utils/math"
 )
@@ -30,6 +31,7 @@ func AssignMaxFeeAndLimit(gasInfo rocketpool.GasInfo, rp *rpsvc.Client, headless
 
 	// Get the current settings from the CLI arguments
 	maxFeeGwei, maxPriorityFeeGwei, gasLimit := rp.GetGasSettings()
+	requestedPriorityFeeGwei := maxPriorityFeeGwei
 
 	// Get the max fee - prioritize the CLI arguments, default to the config file setting
 	if maxFeeGwei == 0 {
@@ -63,16 +65,35 @@ func AssignMaxFeeAndLimit(gasInfo rocketpool.GasInfo, rp *rpsvc.Client, headless
 			lowLimit = maxFeeGwei / eth.WeiPerGwei * float64(gasLimit)
 			highLimit = lowLimit
 		}
-		fmt.Printf("Total cost: %.4f to %.4f ETH%s\n", lowLimit, highLimit, colorReset)
+		fmt.Printf("Total cost: %.4f to %.4f ETH", lowLimit, highLimit)
+		if fiatCost := cliutils.NewFiatFormatter(rp).EthRange(lowLimit, highLimit); fiatCost != "" {
+			fmt.Printf(" (%s)", fiatCost)
+		}
+		fmt.Printf("%s\n", colorReset)
 
 	} else {
-		if headless {
+		// Suggest gas prices from the recent fee history, falling back to the gas price services if it isn't available
+		defaultOption := cfg.Smartnode.GasOption.Value.(cfgtypes.GasOption)
+		history, err := rp.GasFeeHistory()
+		if err == nil && len(history.BaseFees) > 0 {
+			suggestions := getGasSuggestions(history, requestedPriorityFeeGwei)
+			if headless {
+				suggestion := getGasSuggestion(suggestions, defaultOption)
+				maxFeeGwei, maxPriorityFeeGwei = suggestion.maxFeeGwei, suggestion.priorityFeeGwei
+			} else {
+				maxFeeGwei, maxPriorityFeeGwei = handleGasSuggestions(cliutils.NewFiatFormatter(rp), suggestions, defaultOption, history, gasInfo, gasLimit, maxPriorityFeeGwei)
+			}
+		} else if headless {
 			maxFeeWei, err := GetHeadlessMaxFeeWei()
 			if err != nil {
 				return err
 			}
 			maxFeeGwei = eth.WeiToGwei(maxFeeWei)
 		} else {
+			if err != nil {
+				fmt.Printf("%sWarning: couldn't get the recent fee history - %s\nFalling back to Etherchain%s\n", colorYellow, err.Error(), colorReset)
+			}
+
 			// Try to get the latest gas prices from Etherchain
 			etherchainData, err := etherchain.GetGasPrices()
 			if err == nil {
@@ -0,0 +1,284 @@
+package grafana
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// Layout of the provisioning folder, whose files are mounted into the Grafana container under /etc/grafana/provisioning
+const (
+	ProvisioningFolder string = "grafana-provisioning"
+
+	containerProvisioningPath string = "/etc/grafana/provisioning"
+	datasourcesFolder         string = "datasources"
+	dashboardsFolder          string = "dashboards"
+	alertingFolder            string = "alerting"
+
+	datasourceFile string = "rocketpool.yml"
+	providerFile   string = "rocketpool.yml"
+	dashboardFile  string = "rocketpool-node.json"
+	alertRulesFile string = "rocketpool.yml"
+
+	datasourceUid string = "rocketpool-prometheus"
+	dashboardUid  string = "rocketpool-node"
+	folderName    string = "Rocket Pool"
+)
+
+// A panel on the generated dashboard
+type panel struct {
+	title   string
+	unit    string
+	queries []string
+}
+
+// The panels on the generated dashboard, using the metric names exported by the node daemon
+var dashboardPanels = []panel{
+	{"Node APR", "percent", []string{`rocketpool_apr_node_apr`}},
+	{"rETH and Validator APR", "percent", []string{`rocketpool_apr_reth_apr`, `rocketpool_apr_validator_apr`}},
+	{"Collateral Ratio", "percentunit", []string{`rocketpool_node_borrowed_collateral_ratio`, `rocketpool_node_bonded_collateral_ratio`}},
+	{"Staked RPL", "none", []string{`rocketpool_node_total_staked_rpl`, `rocketpool_node_effective_staked_rpl`}},
+	{"Minipools", "none", []string{`rocketpool_node_minipool_count`}},
+	{"Beacon Balance", "none", []string{`rocketpool_node_beacon_balance`}},
+	{"Validator Effectiveness", "percentunit", []string{`rocketpool_liveness_effectiveness`}},
+	{"Consecutive Missed Epochs", "none", []string{`rocketpool_liveness_consecutive_misses`}},
+	{"Task Duration", "s", []string{`rocketpool_daemon_task_duration_seconds`}},
+	{"Task Errors", "none", []string{`increase(rocketpool_daemon_task_errors_total[1h])`}},
+	{"RPL Price", "none", []string{`rocketpool_rpl_rpl_price`}},
+	{"Fee Distributor Balance", "none", []string{`rocketpool_node_fee_distributor_balance`}},
+}
+
+// An alert rule provisioned into Grafana
+type alertRule struct {
+	uid       string
+	title     string
+	query     string
+	condition string
+	threshold float64
+	summary   string
+}
+
+// Generate the Grafana provisioning files (datasource, dashboard and alert rules) for the provided config.
+// Everything is derived from the config so the dashboards always match the selected ports and thresholds.
+func GenerateProvisioning(cfg *config.RocketPoolConfig, configPath string) error {
+	provisioningPath := filepath.Join(configPath, ProvisioningFolder)
+	for _, folder := range []string{datasourcesFolder, dashboardsFolder, alertingFolder} {
+		err := os.MkdirAll(filepath.Join(provisioningPath, folder), 0755)
+		if err != nil {
+			return fmt.Errorf("error creating Grafana provisioning folder: %w", err)
+		}
+	}
+
+	// Datasource
+	datasources := map[string]interface{}{
+		"apiVersion": 1,
+		"datasources": []map[string]interface{}{
+			{
+				"name":      "Prometheus",
+				"uid":       datasourceUid,
+				"type":      "prometheus",
+				"access":    "proxy",
+				"url":       fmt.Sprintf("http://%s:%d", config.PrometheusContainerName, cfg.Prometheus.Port.Value),
+				"isDefault": true,
+				"editable":  false,
+			},
+		},
+	}
+	if err := writeYaml(filepath.Join(provisioningPath, datasourcesFolder, datasourceFile), datasources); err != nil {
+		return err
+	}
+
+	// Dashboard provider
+	providers := map[string]interface{}{
+		"apiVersion": 1,
+		"providers": []map[string]interface{}{
+			{
+				"name":                  "Rocket Pool",
+				"folder":                folderName,
+				"type":                  "file",
+				"disableDeletion":       true,
+				"allowUiUpdates":        false,
+				"updateIntervalSeconds": 60,
+				"options": map[string]interface{}{
+					"path": fmt.Sprintf("%s/%s", containerProvisioningPath, dashboardsFolder),
+				},
+			},
+		},
+	}
+	if err := writeYaml(filepath.Join(provisioningPath, dashboardsFolder, providerFile), providers); err != nil {
+		return err
+	}
+
+	// Dashboard
+	dashboardBytes, err := json.MarshalIndent(createDashboard(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("error serializing Grafana dashboard: %w", err)
+	}
+	dashboardPath := filepath.Join(provisioningPath, dashboardsFolder, dashboardFile)
+	if err := os.WriteFile(dashboardPath, dashboardBytes, 0664); err != nil {
+		return fmt.Errorf("could not write Grafana dashboard to %s: %w", dashboardPath, err)
+	}
+
+	// Alert rules
+	if err := writeYaml(filepath.Join(provisioningPath, alertingFolder, alertRulesFile), createAlertRules(cfg)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Get the bind mounts that provide the generated files to the Grafana container.
+// The datasource is mounted as a single file so it sits alongside the datasource the container template provides.
+func GetProvisioningMounts(configPath string) []string {
+	provisioningPath := filepath.Join(configPath, ProvisioningFolder)
+	return []string{
+		fmt.Sprintf("%s:%s/%s/%s:ro", filepath.Join(provisioningPath, datasourcesFolder, datasourceFile), containerProvisioningPath, datasourcesFolder, datasourceFile),
+		fmt.Sprintf("%s:%s/%s:ro", filepath.Join(provisioningPath, dashboardsFolder), containerProvisioningPath, dashboardsFolder),
+		fmt.Sprintf("%s:%s/%s:ro", filepath.Join(provisioningPath, alertingFolder), containerProvisioningPath, alertingFolder),
+	}
+}
+
+// Create the node operator dashboard
+func createDashboard() map[string]interface{} {
+	panels := []map[string]interface{}{}
+	for i, p := range dashboardPanels {
+		targets := []map[string]interface{}{}
+		for j, query := range p.queries {
+			targets = append(targets, map[string]interface{}{
+				"refId":      string(rune('A' + j)),
+				"expr":       query,
+				"datasource": map[string]string{"type": "prometheus", "uid": datasourceUid},
+			})
+		}
+		panels = append(panels, map[string]interface{}{
+			"id":         i + 1,
+			"title":      p.title,
+			"type":       "timeseries",
+			"datasource": map[string]string{"type": "prometheus", "uid": datasourceUid},
+			"gridPos":    map[string]int{"h": 8, "w": 12, "x": (i % 2) * 12, "y": (i / 2) * 8},
+			"fieldConfig": map[string]interface{}{
+				"defaults":  map[string]string{"unit": p.unit},
+				"overrides": []interface{}{},
+			},
+			"targets": targets,
+		})
+	}
+
+	return map[string]interface{}{
+		"uid":           dashboardUid,
+		"title":         "Rocket Pool Node",
+		"tags":          []string{"rocketpool"},
+		"timezone":      "browser",
+		"schemaVersion": 38,
+		"refresh":       "1m",
+		"time":          map[string]string{"from": "now-7d", "to": "now"},
+		"panels":        panels,
+	}
+}
+
+// Create the alert rules, using the thresholds from the alerting config
+func createAlertRules(cfg *config.RocketPoolConfig) map[string]interface{} {
+	collateralThreshold := cfg.Alerting.CollateralThreshold.Value.(float64)
+	missedAttestations := cfg.Alerting.MissedAttestations.Value.(uint64)
+	rules := []alertRule{
+		{
+			uid:       "rp-low-collateral",
+			title:     "Low RPL collateral",
+			query:     `rocketpool_node_borrowed_collateral_ratio`,
+			condition: "lt",
+			threshold: collateralThreshold / 100,
+			summary:   fmt.Sprintf("The node's RPL collateral is below %.2f%% of its borrowed ETH.", collateralThreshold),
+		},
+		{
+			uid:       "rp-missed-attestations",
+			title:     "Validator missing attestations",
+			query:     `max(rocketpool_liveness_consecutive_misses)`,
+			condition: "gt",
+			threshold: float64(missedAttestations) - 1,
+			summary:   fmt.Sprintf("A validator has missed at least %d epochs in a row.", missedAttestations),
+		},
+		{
+			uid:       "rp-task-errors",
+			title:     "Node daemon task errors",
+			query:     `sum(increase(rocketpool_daemon_task_errors_total[1h]))`,
+			condition: "gt",
+			threshold: 0,
+			summary:   "One or more node daemon tasks have failed in the last hour.",
+		},
+	}
+
+	provisionedRules := []map[string]interface{}{}
+	for _, rule := range rules {
+		provisionedRules = append(provisionedRules, map[string]interface{}{
+			"uid":       rule.uid,
+			"title":     rule.title,
+			"condition": "B",
+			"for":       "5m",
+			"data": []map[string]interface{}{
+				{
+					"refId":             "A",
+					"datasourceUid":     datasourceUid,
+					"relativeTimeRange": map[string]int{"from": 600, "to": 0},
+					"model": map[string]interface{}{
+						"refId": "A",
+						"expr":  rule.query,
+					},
+				},
+				{
+					"refId":         "B",
+					"datasourceUid": "__expr__",
+					"model": map[string]interface{}{
+						"refId":      "B",
+						"type":       "threshold",
+						"expression": "A",
+						"conditions": []map[string]interface{}{
+							{
+								"evaluator": map[string]interface{}{
+									"type":   rule.condition,
+									"params": []float64{rule.threshold},
+								},
+							},
+						},
+					},
+				},
+			},
+			"annotations": map[string]string{
+				"summary": rule.summary,
+			},
+			"labels": map[string]string{
+				"source": "rocketpool",
+			},
+		})
+	}
+
+	return map[string]interface{}{
+		"apiVersion": 1,
+		"groups": []map[string]interface{}{
+			{
+				"orgId":    1,
+				"name":     "rocketpool",
+				"folder":   folderName,
+				"interval": "1m",
+				"rules":    provisionedRules,
+			},
+		},
+	}
+}
+
+// Serialize an object to YAML and write it to the provided path
+func writeYaml(path string, contents interface{}) error {
+	bytes, err := yaml.Marshal(contents)
+	if err != nil {
+		return fmt.Errorf("error serializing %s: %w", filepath.Base(path), err)
+	}
+	err = os.WriteFile(path, bytes, 0664)
+	if err != nil {
+		return fmt.Errorf("could not write Grafana provisioning file to %s: %w", path, err)
+	}
+	return nil
+}
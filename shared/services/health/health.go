@@ -0,0 +1,175 @@
+package health
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// The components of a daemon that are reported by the health check
+type Component string
+
+const (
+	Component_ExecutionClient Component = "executionClient"
+	Component_BeaconClient    Component = "beaconClient"
+	Component_Wallet          Component = "wallet"
+	Component_Duties          Component = "duties"
+)
+
+// The status of a single component
+type ComponentStatus struct {
+	Healthy     bool      `json:"healthy"`
+	Message     string    `json:"message,omitempty"`
+	LastUpdated time.Time `json:"lastUpdated"`
+}
+
+// The response body of the health check endpoints
+type HealthResponse struct {
+	Healthy         bool                          `json:"healthy"`
+	Degraded        bool                          `json:"degraded"`
+	LastDutySuccess time.Time                     `json:"lastDutySuccess"`
+	LastProgress    time.Time                     `json:"lastProgress"`
+	Components      map[Component]ComponentStatus `json:"components"`
+}
+
+// Tracks the status of a daemon's components and serves them over HTTP.
+// `/healthz` reports whether the daemon's task loop is still making progress, so a supervisor can restart it if it hangs.
+// It stays healthy while a component is down, since restarting the daemon won't bring a client back, and reports itself as degraded instead.
+// `/readyz` reports whether every component is currently healthy.
+type Checker struct {
+	components      map[Component]ComponentStatus
+	lastDutySuccess time.Time
+	lastProgress    time.Time
+	maxLoopAge      time.Duration
+	startTime       time.Time
+	lock            *sync.Mutex
+}
+
+// Create a new health checker. The daemon is considered stalled if its task loop doesn't make progress within maxLoopAge.
+func NewChecker(maxLoopAge time.Duration, components ...Component) *Checker {
+	checker := &Checker{
+		components: map[Component]ComponentStatus{},
+		maxLoopAge: maxLoopAge,
+		startTime:  time.Now(),
+		lock:       &sync.Mutex{},
+	}
+	for _, component := range components {
+		checker.components[component] = ComponentStatus{
+			Healthy: false,
+			Message: "not checked yet",
+		}
+	}
+	return checker
+}
+
+// Update the status of a component; a nil error means it's healthy
+func (c *Checker) SetStatus(component Component, err error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	status := ComponentStatus{
+		Healthy:     err == nil,
+		LastUpdated: time.Now(),
+	}
+	if err != nil {
+		status.Message = err.Error()
+	}
+	c.components[component] = status
+}
+
+// Record that the daemon's task loop finished a pass, even if it stopped early because a client was down
+func (c *Checker) RecordProgress() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.lastProgress = time.Now()
+}
+
+// Record the outcome of a pass through the daemon's duties, with the names of the ones that failed
+func (c *Checker) RecordDuties(failed []string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	now := time.Now()
+	status := ComponentStatus{
+		Healthy:     len(failed) == 0,
+		LastUpdated: now,
+	}
+	if len(failed) > 0 {
+		status.Message = fmt.Sprintf("failed: %s", strings.Join(failed, ", "))
+	} else {
+		c.lastDutySuccess = now
+	}
+	c.components[Component_Duties] = status
+	c.lastProgress = now
+}
+
+// Check if the daemon's task loop has stalled
+func (c *Checker) isLive() bool {
+	lastProgress := c.lastProgress
+	if lastProgress.IsZero() {
+		lastProgress = c.startTime
+	}
+	return time.Since(lastProgress) <= c.maxLoopAge
+}
+
+// Get the current status of the daemon and its components
+func (c *Checker) GetStatus() HealthResponse {
+	return c.getResponse(true)
+}
+
+// Get the current health response
+func (c *Checker) getResponse(requireComponents bool) HealthResponse {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	response := HealthResponse{
+		Healthy:         c.isLive(),
+		LastDutySuccess: c.lastDutySuccess,
+		LastProgress:    c.lastProgress,
+		Components:      map[Component]ComponentStatus{},
+	}
+	for component, status := range c.components {
+		response.Components[component] = status
+		if status.Healthy {
+			continue
+		}
+		if requireComponents {
+			response.Healthy = false
+		} else {
+			response.Degraded = true
+		}
+	}
+	return response
+}
+
+// Serve the health check endpoints. This blocks until the server stops.
+func (c *Checker) Serve(address string, port uint) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		writeResponse(w, c.getResponse(false))
+	})
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		writeResponse(w, c.getResponse(true))
+	})
+
+	err := http.ListenAndServe(fmt.Sprintf("%s:%d", address, port), mux)
+	if err != nil {
+		return fmt.Errorf("error running health check server: %w", err)
+	}
+	return nil
+}
+
+// Write a health response with the matching status code
+func writeResponse(w http.ResponseWriter, response HealthResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	if response.Healthy {
+		w.WriteHeader(http.StatusOK)
+	} else {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,208 @@
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Settings
+const (
+	// How long samples are kept
+	Retention = 365 * 24 * time.Hour
+
+	// How far past the retention period the oldest sample can get before the old samples are removed, so the file is only rewritten occasionally
+	pruneSlack = 30 * 24 * time.Hour
+
+	// How much of the end of the file is read to find the most recent sample
+	tailSize int64 = 64 * 1024
+
+	fileMode = 0644
+)
+
+// A snapshot of the node's key metrics at a point in time
+type NodeSample struct {
+	Time              time.Time `json:"time"`
+	ElBlock           uint64    `json:"elBlock"`
+	EthBalance        float64   `json:"ethBalance"`
+	RplBalance        float64   `json:"rplBalance"`
+	RethBalance       float64   `json:"rethBalance"`
+	RplStake          float64   `json:"rplStake"`
+	EffectiveRplStake float64   `json:"effectiveRplStake"`
+	CollateralRatio   float64   `json:"collateralRatio"`
+	BeaconBalance     float64   `json:"beaconBalance"`
+	ActiveMinipools   uint64    `json:"activeMinipools"`
+	RplPrice          float64   `json:"rplPrice"`
+	RethExchangeRate  float64   `json:"rethExchangeRate"`
+	RplApr            float64   `json:"rplApr"`
+	Effectiveness     float64   `json:"effectiveness"`
+}
+
+// An append-only store of node samples, saved as one JSON object per line
+type Store struct {
+	path string
+}
+
+// Create a new store backed by the file at the provided path
+func NewStore(path string) *Store {
+	return &Store{
+		path: path,
+	}
+}
+
+// Append a sample to the store, removing the samples older than the retention period once there are enough of them
+func (s *Store) Append(sample NodeSample) error {
+	err := os.MkdirAll(filepath.Dir(s.path), 0755)
+	if err != nil {
+		return fmt.Errorf("error creating history directory: %w", err)
+	}
+	if err := s.prune(sample.Time); err != nil {
+		return err
+	}
+
+	bytes, err := json.Marshal(sample)
+	if err != nil {
+		return fmt.Errorf("error serializing history sample: %w", err)
+	}
+
+	file, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, fileMode)
+	if err != nil {
+		return fmt.Errorf("error opening history file [%s]: %w", s.path, err)
+	}
+	defer file.Close()
+
+	_, err = file.Write(append(bytes, '\n'))
+	if err != nil {
+		return fmt.Errorf("error writing to history file [%s]: %w", s.path, err)
+	}
+	return nil
+}
+
+// Load every sample recorded at or after the provided time, in the order they were recorded.
+// Lines that can't be parsed (e.g. a partial write from an unclean shutdown) are skipped.
+func (s *Store) Load(since time.Time) ([]NodeSample, error) {
+	samples := []NodeSample{}
+
+	file, err := os.Open(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return samples, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error opening history file [%s]: %w", s.path, err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var sample NodeSample
+		if err := json.Unmarshal(scanner.Bytes(), &sample); err != nil {
+			continue
+		}
+		if sample.Time.Before(since) {
+			continue
+		}
+		samples = append(samples, sample)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading history file [%s]: %w", s.path, err)
+	}
+	return samples, nil
+}
+
+// Get the time of the most recent sample, or the zero time if there aren't any.
+// Only the end of the file is read, since the samples are in the order they were recorded.
+func (s *Store) GetLatestTime() (time.Time, error) {
+	file, err := os.Open(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("error opening history file [%s]: %w", s.path, err)
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("error checking history file [%s]: %w", s.path, err)
+	}
+
+	offset := info.Size() - tailSize
+	if offset < 0 {
+		offset = 0
+	}
+	tail := make([]byte, info.Size()-offset)
+	if _, err := file.ReadAt(tail, offset); err != nil {
+		return time.Time{}, fmt.Errorf("error reading history file [%s]: %w", s.path, err)
+	}
+
+	// Use the last line that can be parsed, skipping a partial write at the end
+	lines := strings.Split(string(tail), "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		var sample NodeSample
+		if err := json.Unmarshal([]byte(lines[i]), &sample); err == nil {
+			return sample.Time, nil
+		}
+	}
+	return time.Time{}, nil
+}
+
+// Remove the samples older than the retention period once the oldest one is past it by the slack, replacing the file atomically
+func (s *Store) prune(now time.Time) error {
+	oldest, err := s.getOldestTime()
+	if err != nil {
+		return err
+	}
+	cutoff := now.Add(-Retention)
+	if oldest.IsZero() || !oldest.Before(cutoff.Add(-pruneSlack)) {
+		return nil
+	}
+
+	samples, err := s.Load(cutoff)
+	if err != nil {
+		return err
+	}
+	lines := make([]byte, 0)
+	for _, sample := range samples {
+		bytes, err := json.Marshal(sample)
+		if err != nil {
+			return fmt.Errorf("error serializing history sample: %w", err)
+		}
+		lines = append(append(lines, bytes...), '\n')
+	}
+	tempPath := s.path + ".tmp"
+	if err := os.WriteFile(tempPath, lines, fileMode); err != nil {
+		return fmt.Errorf("error writing history file [%s]: %w", tempPath, err)
+	}
+	if err := os.Rename(tempPath, s.path); err != nil {
+		return fmt.Errorf("error replacing history file [%s]: %w", s.path, err)
+	}
+	return nil
+}
+
+// Get the time of the first sample that can be parsed, or the zero time if there aren't any
+func (s *Store) getOldestTime() (time.Time, error) {
+	file, err := os.Open(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("error opening history file [%s]: %w", s.path, err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var sample NodeSample
+		if err := json.Unmarshal(scanner.Bytes(), &sample); err == nil {
+			return sample.Time, nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return time.Time{}, fmt.Errorf("error reading history file [%s]: %w", s.path, err)
+	}
+	return time.Time{}, nil
+}
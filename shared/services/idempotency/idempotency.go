@@ -0,0 +1,176 @@
+package idempotency
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Settings
+const (
+	// How long a key's response is kept; retries after this run the command again
+	Retention = 24 * time.Hour
+
+	// The longest key that's accepted, so clients can't fill the store with huge ones
+	MaxKeyLength int = 128
+
+	fileMode = 0600
+)
+
+var (
+	// The key is already being used by a command that hasn't finished yet
+	ErrKeyInProgress = errors.New("a command with this idempotency key is still running")
+
+	// The key was already used for the same command with different arguments
+	ErrKeyMismatch = errors.New("this idempotency key was already used with different arguments")
+
+	// The key was used by a command that was interrupted by a daemon restart, so it may or may not have sent its transactions
+	ErrKeyInterrupted = errors.New("a command with this idempotency key was interrupted before it finished and may have sent its transactions; check the node's recent transactions before running it again with a new key")
+)
+
+// The response a command returned for an idempotency key, or a marker that it started if it's still running
+type Record struct {
+	Route       string          `json:"route"`
+	Fingerprint string          `json:"fingerprint"`
+	Running     bool            `json:"running,omitempty"`
+	Response    json.RawMessage `json:"response,omitempty"`
+	Time        time.Time       `json:"time"`
+}
+
+// A store of the responses to commands that were sent with an idempotency key, saved as a single JSON file.
+// Keys are scoped to the command, so a CLI command that sends several transactions can use one key for all of them.
+type Store struct {
+	path       string
+	records    map[string]Record
+	inProgress map[string]bool
+	lock       *sync.Mutex
+}
+
+// Load the store from the provided path, or create an empty one if it doesn't exist yet
+func LoadStore(path string) (*Store, error) {
+	store := &Store{
+		path:       path,
+		records:    map[string]Record{},
+		inProgress: map[string]bool{},
+		lock:       &sync.Mutex{},
+	}
+
+	bytes, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading idempotency file [%s]: %w", path, err)
+	}
+	err = json.Unmarshal(bytes, &store.records)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing idempotency file [%s]: %w", path, err)
+	}
+	return store, nil
+}
+
+// Start a command with an idempotency key.
+// Returns the original record if the key was already used for this command, in which case the command must not be run again.
+// Otherwise the key is marked as running on disk until Finish is called, so neither concurrent retries nor retries after a daemon restart
+// can run the command twice.
+func (s *Store) Begin(route string, key string, fingerprint string) (*Record, error) {
+	if len(key) > MaxKeyLength {
+		return nil, fmt.Errorf("idempotency keys can't be longer than %d characters", MaxKeyLength)
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	id := getRecordID(route, key)
+	if s.inProgress[id] {
+		return nil, ErrKeyInProgress
+	}
+	record, exists := s.records[id]
+	if exists && time.Since(record.Time) < Retention {
+		if record.Fingerprint != fingerprint {
+			return nil, ErrKeyMismatch
+		}
+		if record.Running {
+			return nil, ErrKeyInterrupted
+		}
+		return &record, nil
+	}
+	s.records[id] = Record{
+		Route:       route,
+		Fingerprint: fingerprint,
+		Running:     true,
+		Time:        time.Now(),
+	}
+	if err := s.save(); err != nil {
+		delete(s.records, id)
+		return nil, err
+	}
+	s.inProgress[id] = true
+	return nil, nil
+}
+
+// Release a key that was started with Begin without running its command, so it can be used again
+func (s *Store) Release(route string, key string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	id := getRecordID(route, key)
+	delete(s.inProgress, id)
+	delete(s.records, id)
+	return s.save()
+}
+
+// Finish a command that was started with Begin, recording its response for retries.
+// Every outcome is kept, including errors, since a command that failed may have already sent some of its transactions.
+func (s *Store) Finish(route string, key string, fingerprint string, response []byte) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	id := getRecordID(route, key)
+	delete(s.inProgress, id)
+	s.records[id] = Record{
+		Route:       route,
+		Fingerprint: fingerprint,
+		Response:    response,
+		Time:        time.Now(),
+	}
+
+	// Drop the keys that have expired
+	for id, record := range s.records {
+		if time.Since(record.Time) >= Retention {
+			delete(s.records, id)
+		}
+	}
+	return s.save()
+}
+
+// Save the store to disk, replacing the previous file atomically
+func (s *Store) save() error {
+	err := os.MkdirAll(filepath.Dir(s.path), 0755)
+	if err != nil {
+		return fmt.Errorf("error creating idempotency directory: %w", err)
+	}
+	bytes, err := json.Marshal(s.records)
+	if err != nil {
+		return fmt.Errorf("error serializing idempotency records: %w", err)
+	}
+	tempPath := s.path + ".tmp"
+	err = os.WriteFile(tempPath, bytes, fileMode)
+	if err != nil {
+		return fmt.Errorf("error writing idempotency file [%s]: %w", tempPath, err)
+	}
+	err = os.Rename(tempPath, s.path)
+	if err != nil {
+		return fmt.Errorf("error replacing idempotency file [%s]: %w", s.path, err)
+	}
+	return nil
+}
+
+// Get the ID of a key's record; the same key can be used with different commands
+func getRecordID(route string, key string) string {
+	return route + ":" + key
+}
@@ -0,0 +1,242 @@
+package idempotency
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	testRoute       string = "node/send"
+	testKey         string = "retry-me"
+	testFingerprint string = "fingerprint"
+)
+
+// Create a store backed by a file in a temporary folder
+func newTestStore(t *testing.T) (*Store, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "idempotency.json")
+	store, err := LoadStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return store, path
+}
+
+// Load the store from disk again, as the daemon does after a restart
+func reloadStore(t *testing.T, path string) *Store {
+	t.Helper()
+	store, err := LoadStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return store
+}
+
+// Begin a command with the test fingerprint, failing the test if it doesn't start
+func beginKey(t *testing.T, store *Store, route string, key string) {
+	t.Helper()
+	record, err := store.Begin(route, key, testFingerprint)
+	if err != nil {
+		t.Fatalf("error beginning key: %s", err.Error())
+	}
+	if record != nil {
+		t.Fatal("expected a new key")
+	}
+}
+
+// Finish a command with the test fingerprint and the provided response
+func finishKey(t *testing.T, store *Store, route string, key string, response string) {
+	t.Helper()
+	if err := store.Finish(route, key, testFingerprint, []byte(response)); err != nil {
+		t.Fatalf("error finishing key: %s", err.Error())
+	}
+}
+
+// Move a record's time back past the retention period
+func expireRecord(store *Store, route string, key string) {
+	id := getRecordID(route, key)
+	record := store.records[id]
+	record.Time = time.Now().Add(-Retention - time.Minute)
+	store.records[id] = record
+}
+
+func TestBegin(t *testing.T) {
+	tests := []struct {
+		name        string
+		setup       func(t *testing.T, store *Store, path string) *Store
+		route       string
+		key         string
+		fingerprint string
+		response    string
+		err         error
+	}{
+		{
+			name:        "new key",
+			setup:       func(t *testing.T, store *Store, path string) *Store { return store },
+			fingerprint: testFingerprint,
+		},
+		{
+			name: "key still running",
+			setup: func(t *testing.T, store *Store, path string) *Store {
+				beginKey(t, store, testRoute, testKey)
+				return store
+			},
+			fingerprint: testFingerprint,
+			err:         ErrKeyInProgress,
+		},
+		{
+			name: "key interrupted by a restart",
+			setup: func(t *testing.T, store *Store, path string) *Store {
+				beginKey(t, store, testRoute, testKey)
+				return reloadStore(t, path)
+			},
+			fingerprint: testFingerprint,
+			err:         ErrKeyInterrupted,
+		},
+		{
+			name: "finished key",
+			setup: func(t *testing.T, store *Store, path string) *Store {
+				beginKey(t, store, testRoute, testKey)
+				finishKey(t, store, testRoute, testKey, `{"status":"success"}`)
+				return store
+			},
+			fingerprint: testFingerprint,
+			response:    `{"status":"success"}`,
+		},
+		{
+			name: "finished key after a restart",
+			setup: func(t *testing.T, store *Store, path string) *Store {
+				beginKey(t, store, testRoute, testKey)
+				finishKey(t, store, testRoute, testKey, `{"status":"error"}`)
+				return reloadStore(t, path)
+			},
+			fingerprint: testFingerprint,
+			response:    `{"status":"error"}`,
+		},
+		{
+			name: "finished key with different arguments",
+			setup: func(t *testing.T, store *Store, path string) *Store {
+				beginKey(t, store, testRoute, testKey)
+				finishKey(t, store, testRoute, testKey, `{"status":"success"}`)
+				return store
+			},
+			fingerprint: "other-fingerprint",
+			err:         ErrKeyMismatch,
+		},
+		{
+			name: "running key with different arguments",
+			setup: func(t *testing.T, store *Store, path string) *Store {
+				beginKey(t, store, testRoute, testKey)
+				return reloadStore(t, path)
+			},
+			fingerprint: "other-fingerprint",
+			err:         ErrKeyMismatch,
+		},
+		{
+			name: "same key for another command",
+			setup: func(t *testing.T, store *Store, path string) *Store {
+				beginKey(t, store, "node/stake-rpl", testKey)
+				return store
+			},
+			fingerprint: testFingerprint,
+		},
+		{
+			name: "expired key",
+			setup: func(t *testing.T, store *Store, path string) *Store {
+				beginKey(t, store, testRoute, testKey)
+				finishKey(t, store, testRoute, testKey, `{"status":"success"}`)
+				expireRecord(store, testRoute, testKey)
+				return store
+			},
+			fingerprint: "other-fingerprint",
+		},
+		{
+			name:        "key too long",
+			setup:       func(t *testing.T, store *Store, path string) *Store { return store },
+			key:         strings.Repeat("a", MaxKeyLength+1),
+			fingerprint: testFingerprint,
+			err:         errors.New("idempotency keys can't be longer than 128 characters"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			store, path := newTestStore(t)
+			store = test.setup(t, store, path)
+			route := test.route
+			if route == "" {
+				route = testRoute
+			}
+			key := test.key
+			if key == "" {
+				key = testKey
+			}
+
+			record, err := store.Begin(route, key, test.fingerprint)
+			if test.err != nil {
+				if err == nil || (!errors.Is(err, test.err) && err.Error() != test.err.Error()) {
+					t.Fatalf("expected error \"%v\", got \"%v\"", test.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+			if test.response == "" {
+				if record != nil {
+					t.Fatalf("expected the command to run, got the stored response %s", string(record.Response))
+				}
+				return
+			}
+			if record == nil {
+				t.Fatal("expected the stored response, but the command would run again")
+			}
+			if string(record.Response) != test.response {
+				t.Fatalf("expected response %s, got %s", test.response, string(record.Response))
+			}
+		})
+	}
+}
+
+func TestReleaseAllowsTheKeyToBeUsedAgain(t *testing.T) {
+	store, path := newTestStore(t)
+	beginKey(t, store, testRoute, testKey)
+	if err := store.Release(testRoute, testKey); err != nil {
+		t.Fatal(err)
+	}
+
+	// The key is free in this process and after a restart, even with different arguments
+	for _, store := range []*Store{store, reloadStore(t, path)} {
+		record, err := store.Begin(testRoute, testKey, "other-fingerprint")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+		if record != nil {
+			t.Fatal("expected the released key to run the command")
+		}
+		if err := store.Release(testRoute, testKey); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestFinishPrunesExpiredRecords(t *testing.T) {
+	store, path := newTestStore(t)
+	beginKey(t, store, testRoute, "old")
+	finishKey(t, store, testRoute, "old", `{"status":"success"}`)
+	expireRecord(store, testRoute, "old")
+
+	beginKey(t, store, testRoute, testKey)
+	finishKey(t, store, testRoute, testKey, `{"status":"success"}`)
+
+	reloaded := reloadStore(t, path)
+	if _, exists := reloaded.records[getRecordID(testRoute, "old")]; exists {
+		t.Fatal("expected the expired record to be pruned")
+	}
+	if _, exists := reloaded.records[getRecordID(testRoute, testKey)]; !exists {
+		t.Fatal("expected the new record to be kept")
+	}
+}
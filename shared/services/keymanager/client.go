@@ -0,0 +1,305 @@
+package keymanager
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	hexutil "github.com/rocket-pool/smartnode/shared/utils/hex"
+)
+
+// Keymanager API routes
+const (
+	keystoresPath    string = "/eth/v1/keystores"
+	feeRecipientPath string = "/eth/v1/validator/%s/feerecipient"
+	gasLimitPath     string = "/eth/v1/validator/%s/gas_limit"
+
+	// Lighthouse's extension for changing a validator's settings
+	lighthouseValidatorPath string = "/lighthouse/validators/%s"
+)
+
+// Keystore import statuses
+type ImportStatus string
+
+const (
+	ImportStatus_Imported  ImportStatus = "imported"
+	ImportStatus_Duplicate ImportStatus = "duplicate"
+	ImportStatus_Error     ImportStatus = "error"
+)
+
+// Keystore deletion statuses
+type DeleteStatus string
+
+const (
+	DeleteStatus_Deleted   DeleteStatus = "deleted"
+	DeleteStatus_NotActive DeleteStatus = "not_active"
+	DeleteStatus_NotFound  DeleteStatus = "not_found"
+	DeleteStatus_Error     DeleteStatus = "error"
+)
+
+// How long to wait for the Validator client to respond
+var requestTimeout, _ = time.ParseDuration("30s")
+
+// A client for the standard Keymanager API of the Smartnode's Validator client
+type Client struct {
+	url        string
+	token      string
+	httpClient *http.Client
+}
+
+// A validator key loaded in the Validator client
+type Keystore struct {
+	Pubkey         types.ValidatorPubkey
+	DerivationPath string
+	ReadOnly       bool
+}
+
+// The result of importing or deleting one keystore
+type ImportResult struct {
+	Status  ImportStatus `json:"status"`
+	Message string       `json:"message"`
+}
+type DeleteResult struct {
+	Status  DeleteStatus `json:"status"`
+	Message string       `json:"message"`
+}
+
+// Request and response bodies
+type listKeystoresResponse struct {
+	Data []struct {
+		Pubkey         string `json:"validating_pubkey"`
+		DerivationPath string `json:"derivation_path"`
+		ReadOnly       bool   `json:"readonly"`
+	} `json:"data"`
+}
+type importKeystoresRequest struct {
+	Keystores          []string `json:"keystores"`
+	Passwords          []string `json:"passwords"`
+	SlashingProtection string   `json:"slashing_protection,omitempty"`
+}
+type importKeystoresResponse struct {
+	Data []ImportResult `json:"data"`
+}
+type deleteKeystoresRequest struct {
+	Pubkeys []string `json:"pubkeys"`
+}
+type deleteKeystoresResponse struct {
+	Data               []DeleteResult `json:"data"`
+	SlashingProtection string         `json:"slashing_protection"`
+}
+type feeRecipientRequest struct {
+	EthAddress common.Address `json:"ethaddress"`
+}
+type feeRecipientResponse struct {
+	Data struct {
+		EthAddress common.Address `json:"ethaddress"`
+	} `json:"data"`
+}
+type gasLimitRequest struct {
+	GasLimit string `json:"gas_limit"`
+}
+type gasLimitResponse struct {
+	Data struct {
+		GasLimit string `json:"gas_limit"`
+	} `json:"data"`
+}
+type lighthouseValidatorRequest struct {
+	BuilderProposals *bool `json:"builder_proposals,omitempty"`
+}
+type errorResponse struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Creates a client for the Validator client's Keymanager API, reading the token the Smartnode generated for it
+func NewClient(cfg *config.RocketPoolConfig) (*Client, error) {
+	if !cfg.Keymanager.IsEnabled() {
+		return nil, fmt.Errorf("the Keymanager API isn't enabled")
+	}
+	tokenPath := cfg.Smartnode.GetKeymanagerTokenPath()
+	token, err := os.ReadFile(tokenPath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading Keymanager API token [%s]: %w", tokenPath, err)
+	}
+	return &Client{
+		url:        fmt.Sprintf("http://%s:%d", config.ValidatorContainerName, cfg.Keymanager.Port.Value),
+		token:      strings.TrimSpace(string(token)),
+		httpClient: &http.Client{Timeout: requestTimeout},
+	}, nil
+}
+
+// Get the validator keys loaded in the Validator client
+func (c *Client) ListKeystores() ([]Keystore, error) {
+	var response listKeystoresResponse
+	if err := c.request(http.MethodGet, keystoresPath, nil, &response); err != nil {
+		return nil, fmt.Errorf("error listing keystores: %w", err)
+	}
+	keystores := make([]Keystore, len(response.Data))
+	for i, keystore := range response.Data {
+		pubkey, err := types.HexToValidatorPubkey(hexutil.RemovePrefix(keystore.Pubkey))
+		if err != nil {
+			return nil, fmt.Errorf("error listing keystores: invalid pubkey [%s]: %w", keystore.Pubkey, err)
+		}
+		keystores[i] = Keystore{
+			Pubkey:         pubkey,
+			DerivationPath: keystore.DerivationPath,
+			ReadOnly:       keystore.ReadOnly,
+		}
+	}
+	return keystores, nil
+}
+
+// Load EIP-2335 keystores into the Validator client, with an optional EIP-3076 slashing protection interchange.
+// The results are in the same order as the keystores.
+func (c *Client) ImportKeystores(keystores []string, passwords []string, slashingProtection string) ([]ImportResult, error) {
+	request := importKeystoresRequest{
+		Keystores:          keystores,
+		Passwords:          passwords,
+		SlashingProtection: slashingProtection,
+	}
+	var response importKeystoresResponse
+	if err := c.request(http.MethodPost, keystoresPath, request, &response); err != nil {
+		return nil, fmt.Errorf("error importing keystores: %w", err)
+	}
+	if len(response.Data) != len(keystores) {
+		return nil, fmt.Errorf("error importing keystores: expected %d results but got %d", len(keystores), len(response.Data))
+	}
+	return response.Data, nil
+}
+
+// Remove validator keys from the Validator client, returning their slashing protection interchange.
+// The results are in the same order as the pubkeys.
+func (c *Client) DeleteKeystores(pubkeys []types.ValidatorPubkey) ([]DeleteResult, string, error) {
+	request := deleteKeystoresRequest{
+		Pubkeys: make([]string, len(pubkeys)),
+	}
+	for i, pubkey := range pubkeys {
+		request.Pubkeys[i] = hexutil.AddPrefix(pubkey.Hex())
+	}
+	var response deleteKeystoresResponse
+	if err := c.request(http.MethodDelete, keystoresPath, request, &response); err != nil {
+		return nil, "", fmt.Errorf("error deleting keystores: %w", err)
+	}
+	if len(response.Data) != len(pubkeys) {
+		return nil, "", fmt.Errorf("error deleting keystores: expected %d results but got %d", len(pubkeys), len(response.Data))
+	}
+	return response.Data, response.SlashingProtection, nil
+}
+
+// Get the fee recipient the Validator client uses for a validator
+func (c *Client) GetFeeRecipient(pubkey types.ValidatorPubkey) (common.Address, error) {
+	var response feeRecipientResponse
+	if err := c.request(http.MethodGet, fmt.Sprintf(feeRecipientPath, hexutil.AddPrefix(pubkey.Hex())), nil, &response); err != nil {
+		return common.Address{}, fmt.Errorf("error getting fee recipient for validator %s: %w", pubkey.Hex(), err)
+	}
+	return response.Data.EthAddress, nil
+}
+
+// Set the fee recipient the Validator client uses for a validator
+func (c *Client) SetFeeRecipient(pubkey types.ValidatorPubkey, feeRecipient common.Address) error {
+	if err := c.request(http.MethodPost, fmt.Sprintf(feeRecipientPath, hexutil.AddPrefix(pubkey.Hex())), feeRecipientRequest{EthAddress: feeRecipient}, nil); err != nil {
+		return fmt.Errorf("error setting fee recipient for validator %s: %w", pubkey.Hex(), err)
+	}
+	return nil
+}
+
+// Remove a validator's fee recipient so the Validator client uses its default
+func (c *Client) DeleteFeeRecipient(pubkey types.ValidatorPubkey) error {
+	if err := c.request(http.MethodDelete, fmt.Sprintf(feeRecipientPath, hexutil.AddPrefix(pubkey.Hex())), nil, nil); err != nil {
+		return fmt.Errorf("error deleting fee recipient for validator %s: %w", pubkey.Hex(), err)
+	}
+	return nil
+}
+
+// Get the gas limit the Validator client registers for a validator
+func (c *Client) GetGasLimit(pubkey types.ValidatorPubkey) (uint64, error) {
+	var response gasLimitResponse
+	if err := c.request(http.MethodGet, fmt.Sprintf(gasLimitPath, hexutil.AddPrefix(pubkey.Hex())), nil, &response); err != nil {
+		return 0, fmt.Errorf("error getting gas limit for validator %s: %w", pubkey.Hex(), err)
+	}
+	gasLimit, err := strconv.ParseUint(response.Data.GasLimit, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid gas limit [%s] for validator %s: %w", response.Data.GasLimit, pubkey.Hex(), err)
+	}
+	return gasLimit, nil
+}
+
+// Set the gas limit the Validator client registers for a validator
+func (c *Client) SetGasLimit(pubkey types.ValidatorPubkey, gasLimit uint64) error {
+	if err := c.request(http.MethodPost, fmt.Sprintf(gasLimitPath, hexutil.AddPrefix(pubkey.Hex())), gasLimitRequest{GasLimit: strconv.FormatUint(gasLimit, 10)}, nil); err != nil {
+		return fmt.Errorf("error setting gas limit for validator %s: %w", pubkey.Hex(), err)
+	}
+	return nil
+}
+
+// Remove a validator's gas limit so the Validator client uses its default
+func (c *Client) DeleteGasLimit(pubkey types.ValidatorPubkey) error {
+	if err := c.request(http.MethodDelete, fmt.Sprintf(gasLimitPath, hexutil.AddPrefix(pubkey.Hex())), nil, nil); err != nil {
+		return fmt.Errorf("error deleting gas limit for validator %s: %w", pubkey.Hex(), err)
+	}
+	return nil
+}
+
+// Set whether Lighthouse uses MEV-Boost's builders for a validator's proposals instead of its --builder-proposals flag.
+// This is a Lighthouse extension, and Lighthouse doesn't report the current value.
+func (c *Client) SetLighthouseBuilderProposals(pubkey types.ValidatorPubkey, enabled bool) error {
+	if err := c.request(http.MethodPatch, fmt.Sprintf(lighthouseValidatorPath, hexutil.AddPrefix(pubkey.Hex())), lighthouseValidatorRequest{BuilderProposals: &enabled}, nil); err != nil {
+		return fmt.Errorf("error setting builder proposals for validator %s: %w", pubkey.Hex(), err)
+	}
+	return nil
+}
+
+// Send an authenticated request, decoding the response into the provided value if it isn't nil
+func (c *Client) request(method string, path string, body interface{}, result interface{}) error {
+	var requestBody io.Reader
+	if body != nil {
+		bodyBytes, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("error serializing request: %w", err)
+		}
+		requestBody = bytes.NewReader(bodyBytes)
+	}
+	request, err := http.NewRequest(method, c.url+path, requestBody)
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+	request.Header.Set("Authorization", "Bearer "+c.token)
+	request.Header.Set("Accept", "application/json")
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	responseBytes, err := io.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("error reading response: %w", err)
+	}
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		var apiError errorResponse
+		if json.Unmarshal(responseBytes, &apiError) == nil && apiError.Message != "" {
+			return fmt.Errorf("the Validator client responded with %s: %s", response.Status, apiError.Message)
+		}
+		return fmt.Errorf("the Validator client responded with %s", response.Status)
+	}
+	if result == nil || len(responseBytes) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(responseBytes, result); err != nil {
+		return fmt.Errorf("error deserializing response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package keymanager
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore"
+)
+
+// Settings
+const (
+	tokenLength   int = 32
+	tokenDirMode      = 0770
+	tokenFileMode     = 0600
+)
+
+// Create the Keymanager API token at the provided path if it doesn't exist yet
+func EnsureToken(path string) error {
+	_, err := os.Stat(path)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("error checking Keymanager API token [%s]: %w", path, err)
+	}
+
+	token := make([]byte, tokenLength)
+	if _, err := rand.Read(token); err != nil {
+		return fmt.Errorf("error generating Keymanager API token: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), tokenDirMode); err != nil {
+		return fmt.Errorf("error creating Keymanager API token folder: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(hex.EncodeToString(token)), tokenFileMode); err != nil {
+		return fmt.Errorf("error writing Keymanager API token [%s]: %w", path, err)
+	}
+	return nil
+}
+
+// Load validator keys into the Validator client. Keys it already has are left alone.
+func (c *Client) ImportValidatorKeys(keys []*eth2types.BLSPrivateKey) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	keystores := make([]string, len(keys))
+	passwords := make([]string, len(keys))
+	pubkeys := make([]types.ValidatorPubkey, len(keys))
+	for i, key := range keys {
+		validatorKeystore, password, err := keystore.CreateEip2335Keystore(key, "", "Rocket Pool minipool validator key")
+		if err != nil {
+			return err
+		}
+		keystoreBytes, err := json.Marshal(validatorKeystore)
+		if err != nil {
+			return fmt.Errorf("error encoding validator keystore: %w", err)
+		}
+		keystores[i] = string(keystoreBytes)
+		passwords[i] = password
+		pubkeys[i] = validatorKeystore.Pubkey
+	}
+
+	results, err := c.ImportKeystores(keystores, passwords, "")
+	if err != nil {
+		return err
+	}
+	failures := []string{}
+	for i, result := range results {
+		if result.Status != ImportStatus_Imported && result.Status != ImportStatus_Duplicate {
+			failures = append(failures, fmt.Sprintf("%s: %s", pubkeys[i].Hex(), result.Message))
+		}
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("the Validator client couldn't import %d keys: %s", len(failures), strings.Join(failures, "; "))
+	}
+	return nil
+}
+
+// Make sure every validator loaded in the Validator client uses the provided fee recipient, returning how many were changed
+func (c *Client) SetFeeRecipientForAll(feeRecipient common.Address) (int, error) {
+	keystores, err := c.ListKeystores()
+	if err != nil {
+		return 0, err
+	}
+	updated := 0
+	for _, keystore := range keystores {
+		current, err := c.GetFeeRecipient(keystore.Pubkey)
+		if err != nil {
+			return updated, err
+		}
+		if current == feeRecipient {
+			continue
+		}
+		if err := c.SetFeeRecipient(keystore.Pubkey, feeRecipient); err != nil {
+			return updated, err
+		}
+		updated++
+	}
+	return updated, nil
+}
+
+// Apply a minipool's block building preference to its validator, going back to the Validator client's defaults for anything it doesn't set.
+// Preferences the Validator client can't change over the API are skipped.
+func (c *Client) ApplyBlockBuildingPreference(cfg *config.RocketPoolConfig, pubkey types.ValidatorPubkey, preference config.BlockBuildingPreference) error {
+	cc, _ := cfg.GetSelectedConsensusClient()
+	gasLimitSupported, builderSupported := config.GetBlockBuildingSupport(cc)
+
+	if gasLimitSupported {
+		if preference.GasLimit == 0 {
+			if err := c.DeleteGasLimit(pubkey); err != nil {
+				return err
+			}
+		} else {
+			current, err := c.GetGasLimit(pubkey)
+			if err != nil {
+				return err
+			}
+			if current != preference.GasLimit {
+				if err := c.SetGasLimit(pubkey, preference.GasLimit); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	// The builder setting can't be read back, so it's always set
+	if builderSupported {
+		if err := c.SetLighthouseBuilderProposals(pubkey, cfg.UsesBuilder(preference)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
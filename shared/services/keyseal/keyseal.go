@@ -0,0 +1,580 @@
+package keyseal
+
+import (
+	"archive/tar"
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/secrets"
+)
+
+// The header at the start of a sealed directory, so other files aren't mistaken for one
+var sealedHeader = []byte("RPSEAL1\n")
+
+// The length of the envelope key, for AES-256
+const envelopeKeyLength = 32
+
+// The sealed file and the unsealed directory can only be read by the node's user
+const (
+	fileMode fs.FileMode = 0600
+	dirMode  fs.FileMode = 0700
+)
+
+// The entry in the validator keystore directory that leads to the slashing protection folder; in Native mode it's a link, and in Docker mode
+// the folder is mounted there
+const SlashingProtectionLinkName string = ".slashing-protection"
+
+// The folder a sealed directory is extracted into before it replaces the directory's contents
+const unsealTempFolder string = ".unseal.tmp"
+
+// A Validator Client's slashing protection database, relative to the validator keystore directory
+type slashingProtectionDb struct {
+	path  string
+	isDir bool
+}
+
+// The slashing protection databases of each Validator Client. They're kept on persistent storage and linked into the decrypted directory
+// instead of being sealed with it, so a crash can't roll them back to an older copy.
+var slashingProtectionDbs = []slashingProtectionDb{
+	{path: "lighthouse/validators/slashing_protection.sqlite"},
+	{path: "lodestar/validator-db", isDir: true},
+	{path: "nimbus/validators/slashing_protection.sqlite3"},
+	{path: "prysm-non-hd/direct/validator.db"},
+	{path: "teku/validator/slashprotection", isDir: true},
+}
+
+// The files SQLite keeps next to a database while it's being written to; they're moved along with it.
+// SQLite follows a link to the database, so it creates them next to the persistent copy.
+var sqliteSidecarSuffixes = []string{"-journal", "-wal", "-shm"}
+
+// Get the envelope key the validator keystore directory is sealed with, creating one if it doesn't exist yet and create is set
+func GetEnvelopeKey(store secrets.Store, create bool) ([]byte, error) {
+	if store == nil {
+		return nil, fmt.Errorf("validator key encryption needs a secret storage other than the data folder")
+	}
+	encodedKey, exists, err := store.Get(secrets.ValidatorKeysEnvelopeKey)
+	if err != nil {
+		return nil, fmt.Errorf("error getting the validator key envelope key from %s: %w", store.String(), err)
+	}
+	if exists {
+		key, err := hex.DecodeString(encodedKey)
+		if err != nil || len(key) != envelopeKeyLength {
+			return nil, fmt.Errorf("the validator key envelope key in %s is malformed", store.String())
+		}
+		return key, nil
+	}
+	if !create {
+		return nil, fmt.Errorf("%s does not have a validator key envelope key", store.String())
+	}
+
+	key := make([]byte, envelopeKeyLength)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("error generating validator key envelope key: %w", err)
+	}
+	if err := store.Set(secrets.ValidatorKeysEnvelopeKey, hex.EncodeToString(key)); err != nil {
+		return nil, fmt.Errorf("error saving the validator key envelope key to %s: %w", store.String(), err)
+	}
+	return key, nil
+}
+
+// Archive a directory and encrypt it to the sealed path with the envelope key.
+// Returns the digest of the directory's contents, for noticing when it changes.
+func Seal(dir string, sealedPath string, key []byte) (string, error) {
+
+	// Archive the directory
+	var archive bytes.Buffer
+	writer := tar.NewWriter(&archive)
+	digest := sha256.New()
+	err := walk(dir, func(name string, info fs.FileInfo, contents []byte) error {
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		header.Name = name
+		if err := writer.WriteHeader(header); err != nil {
+			return err
+		}
+		addToDigest(digest, name, info.Mode(), contents)
+		_, err = writer.Write(contents)
+		return err
+	})
+	if err != nil {
+		return "", fmt.Errorf("error archiving [%s]: %w", dir, err)
+	}
+	if err := writer.Close(); err != nil {
+		return "", fmt.Errorf("error archiving [%s]: %w", dir, err)
+	}
+
+	// Encrypt it
+	aead, err := newAead(key)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("error generating nonce: %w", err)
+	}
+	sealed := append([]byte{}, sealedHeader...)
+	sealed = append(sealed, nonce...)
+	sealed = aead.Seal(sealed, nonce, archive.Bytes(), sealedHeader)
+
+	// Replace the sealed file in one step so a crash can't leave a partial one
+	tempPath := sealedPath + ".tmp"
+	if err := os.WriteFile(tempPath, sealed, fileMode); err != nil {
+		return "", fmt.Errorf("error writing [%s]: %w", tempPath, err)
+	}
+	if err := os.Rename(tempPath, sealedPath); err != nil {
+		return "", fmt.Errorf("error replacing [%s]: %w", sealedPath, err)
+	}
+	return hex.EncodeToString(digest.Sum(nil)), nil
+
+}
+
+// Decrypt a sealed directory and extract it to the provided directory, replacing whatever is there other than the slashing protection folder.
+// The directory is replaced in place so it can be a mount point. Returns the digest of the directory's contents.
+func Unseal(sealedPath string, dir string, key []byte) (string, error) {
+
+	archive, err := open(sealedPath, key)
+	if err != nil {
+		return "", err
+	}
+
+	// Extract it into a folder inside the destination, so moving it into place never crosses filesystems
+	if err := os.MkdirAll(dir, dirMode); err != nil {
+		return "", fmt.Errorf("error creating [%s]: %w", dir, err)
+	}
+	tempDir := filepath.Join(dir, unsealTempFolder)
+	if err := os.RemoveAll(tempDir); err != nil {
+		return "", fmt.Errorf("error removing [%s]: %w", tempDir, err)
+	}
+	if err := os.Mkdir(tempDir, dirMode); err != nil {
+		return "", fmt.Errorf("error creating [%s]: %w", tempDir, err)
+	}
+	digest, err := readArchive(archive, func(name string, header *tar.Header, contents []byte) error {
+		path := filepath.Join(tempDir, filepath.FromSlash(name))
+		switch header.Typeflag {
+		case tar.TypeDir:
+			return os.MkdirAll(path, header.FileInfo().Mode().Perm()|0700)
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(path), dirMode); err != nil {
+				return err
+			}
+			return os.WriteFile(path, contents, header.FileInfo().Mode().Perm())
+		default:
+			return fmt.Errorf("unexpected entry type for [%s]", name)
+		}
+	})
+	if err != nil {
+		os.RemoveAll(tempDir)
+		return "", fmt.Errorf("error extracting [%s]: %w", sealedPath, err)
+	}
+
+	// Swap the old contents for the extracted ones
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", fmt.Errorf("error reading [%s]: %w", dir, err)
+	}
+	for _, entry := range entries {
+		if isReservedEntry(entry.Name()) {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		if err := os.RemoveAll(path); err != nil {
+			return "", fmt.Errorf("error removing [%s]: %w", path, err)
+		}
+	}
+	extracted, err := os.ReadDir(tempDir)
+	if err != nil {
+		return "", fmt.Errorf("error reading [%s]: %w", tempDir, err)
+	}
+	for _, entry := range extracted {
+		if err := os.Rename(filepath.Join(tempDir, entry.Name()), filepath.Join(dir, entry.Name())); err != nil {
+			return "", fmt.Errorf("error moving [%s] into [%s]: %w", entry.Name(), dir, err)
+		}
+	}
+	if err := os.Remove(tempDir); err != nil {
+		return "", fmt.Errorf("error removing [%s]: %w", tempDir, err)
+	}
+	return digest, nil
+
+}
+
+// Check if a directory is missing or has nothing in it but the slashing protection folder, such as a fresh tmpfs after a reboot
+func IsEmpty(dir string) (bool, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return true, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("error reading [%s]: %w", dir, err)
+	}
+	for _, entry := range entries {
+		if !isReservedEntry(entry.Name()) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// Remove everything in a directory other than the slashing protection folder
+func Clear(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("error reading [%s]: %w", dir, err)
+	}
+	for _, entry := range entries {
+		if entry.Name() == SlashingProtectionLinkName {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		if err := os.RemoveAll(path); err != nil {
+			return fmt.Errorf("error removing [%s]: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// Move the slashing protection databases in a validator keystore directory to the persistent folder and link them back into it.
+// The Validator Client must be stopped first.
+func DetachSlashingProtection(dir string, persistentDir string) error {
+	for _, db := range slashingProtectionDbs {
+		for _, name := range db.getPaths() {
+			path := filepath.Join(dir, filepath.FromSlash(name))
+			info, err := os.Lstat(path)
+			if os.IsNotExist(err) {
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("error checking [%s]: %w", path, err)
+			}
+			if info.Mode()&fs.ModeSymlink != 0 {
+				continue
+			}
+			target := filepath.Join(persistentDir, filepath.FromSlash(name))
+			if err := os.MkdirAll(filepath.Dir(target), dirMode); err != nil {
+				return fmt.Errorf("error creating [%s]: %w", filepath.Dir(target), err)
+			}
+			if err := os.RemoveAll(target); err != nil {
+				return fmt.Errorf("error removing [%s]: %w", target, err)
+			}
+			if err := move(path, target); err != nil {
+				return fmt.Errorf("error moving [%s] to [%s]: %w", path, target, err)
+			}
+		}
+	}
+	_, err := LinkSlashingProtection(dir, persistentDir)
+	return err
+}
+
+// Link the slashing protection databases of the Validator Clients that have keys in a validator keystore directory to their copies in the
+// persistent folder, creating the folders they'll go in if they don't exist yet. Returns the databases that are in the directory itself
+// instead, such as ones a Validator Client created before it was linked; those can only be moved while the Validator Client is stopped.
+func LinkSlashingProtection(dir string, persistentDir string) ([]string, error) {
+
+	// Link the persistent folder into the directory, unless it's mounted there
+	if err := os.MkdirAll(persistentDir, dirMode); err != nil {
+		return nil, fmt.Errorf("error creating [%s]: %w", persistentDir, err)
+	}
+	folderLink := filepath.Join(dir, SlashingProtectionLinkName)
+	if _, err := os.Lstat(folderLink); os.IsNotExist(err) {
+		if err := os.Symlink(persistentDir, folderLink); err != nil {
+			return nil, fmt.Errorf("error linking [%s] to [%s]: %w", folderLink, persistentDir, err)
+		}
+	} else if err != nil {
+		return nil, fmt.Errorf("error checking [%s]: %w", folderLink, err)
+	}
+
+	unlinked := []string{}
+	for _, db := range slashingProtectionDbs {
+		client := strings.SplitN(db.path, "/", 2)[0]
+		if _, err := os.Stat(filepath.Join(dir, client)); err != nil {
+			continue
+		}
+
+		// Make sure whatever the link points to can be created, then link it through the folder link with a relative path so it also resolves
+		// inside the Validator Client's container
+		target := filepath.Join(persistentDir, filepath.FromSlash(db.path))
+		targetFolder := filepath.Dir(target)
+		if db.isDir {
+			targetFolder = target
+		}
+		if err := os.MkdirAll(targetFolder, dirMode); err != nil {
+			return nil, fmt.Errorf("error creating [%s]: %w", targetFolder, err)
+		}
+		path := filepath.Join(dir, filepath.FromSlash(db.path))
+		info, err := os.Lstat(path)
+		if err == nil {
+			if info.Mode()&fs.ModeSymlink == 0 {
+				unlinked = append(unlinked, db.path)
+			}
+			continue
+		}
+		if !os.IsNotExist(err) {
+			return nil, fmt.Errorf("error checking [%s]: %w", path, err)
+		}
+		if err := os.MkdirAll(filepath.Dir(path), dirMode); err != nil {
+			return nil, fmt.Errorf("error creating [%s]: %w", filepath.Dir(path), err)
+		}
+		relativeTarget := strings.Repeat("../", strings.Count(db.path, "/")) + SlashingProtectionLinkName + "/" + db.path
+		if err := os.Symlink(filepath.FromSlash(relativeTarget), path); err != nil {
+			return nil, fmt.Errorf("error linking [%s] to [%s]: %w", path, target, err)
+		}
+	}
+	return unlinked, nil
+
+}
+
+// Replace the slashing protection links in a validator keystore directory with the databases they point to, moving them out of the persistent folder.
+// The Validator Client must be stopped first.
+func AttachSlashingProtection(dir string, persistentDir string) error {
+	for _, db := range slashingProtectionDbs {
+		for _, name := range db.getPaths() {
+			source := filepath.Join(persistentDir, filepath.FromSlash(name))
+			if _, err := os.Lstat(source); os.IsNotExist(err) {
+				continue
+			} else if err != nil {
+				return fmt.Errorf("error checking [%s]: %w", source, err)
+			}
+			path := filepath.Join(dir, filepath.FromSlash(name))
+			if info, err := os.Lstat(path); err == nil && info.Mode()&fs.ModeSymlink == 0 {
+				// The directory has its own copy, which a Validator Client wrote to before it was linked; keep it
+				continue
+			}
+			if err := os.MkdirAll(filepath.Dir(path), dirMode); err != nil {
+				return fmt.Errorf("error creating [%s]: %w", filepath.Dir(path), err)
+			}
+			if err := os.RemoveAll(path); err != nil {
+				return fmt.Errorf("error removing [%s]: %w", path, err)
+			}
+			if err := move(source, path); err != nil {
+				return fmt.Errorf("error moving [%s] to [%s]: %w", source, path, err)
+			}
+		}
+	}
+
+	// Remove the link to the persistent folder, and the links of any clients that didn't have a database yet
+	if err := os.RemoveAll(filepath.Join(dir, SlashingProtectionLinkName)); err != nil {
+		return fmt.Errorf("error removing the slashing protection link: %w", err)
+	}
+	for _, db := range slashingProtectionDbs {
+		path := filepath.Join(dir, filepath.FromSlash(db.path))
+		if info, err := os.Lstat(path); err == nil && info.Mode()&fs.ModeSymlink != 0 {
+			if err := os.Remove(path); err != nil {
+				return fmt.Errorf("error removing [%s]: %w", path, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Get the paths of a slashing protection database and the files that go with it
+func (db slashingProtectionDb) getPaths() []string {
+	if db.isDir {
+		return []string{db.path}
+	}
+	paths := []string{db.path}
+	for _, suffix := range sqliteSidecarSuffixes {
+		paths = append(paths, db.path+suffix)
+	}
+	return paths
+}
+
+// Move a file or folder, copying it if it's going to a different filesystem such as out of the tmpfs volume
+func move(source string, target string) error {
+	err := os.Rename(source, target)
+	if !errors.Is(err, syscall.EXDEV) {
+		return err
+	}
+	err = filepath.WalkDir(source, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		name, err := filepath.Rel(source, path)
+		if err != nil {
+			return err
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		destination := filepath.Join(target, name)
+		if info.IsDir() {
+			return os.MkdirAll(destination, info.Mode().Perm())
+		}
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(destination, contents, info.Mode().Perm())
+	})
+	if err != nil {
+		os.RemoveAll(target)
+		return err
+	}
+	return os.RemoveAll(source)
+}
+
+// Check if a top-level entry of the validator keystore directory is one that's never sealed
+func isReservedEntry(name string) bool {
+	return name == SlashingProtectionLinkName || name == unsealTempFolder
+}
+
+// Get the digest of a sealed directory's contents without extracting it
+func GetSealedDigest(sealedPath string, key []byte) (string, error) {
+	archive, err := open(sealedPath, key)
+	if err != nil {
+		return "", err
+	}
+	digest, err := readArchive(archive, func(string, *tar.Header, []byte) error {
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("error reading [%s]: %w", sealedPath, err)
+	}
+	return digest, nil
+}
+
+// Get the digest of a directory's contents, matching the one Seal returns for it
+func GetDigest(dir string) (string, error) {
+	digest := sha256.New()
+	err := walk(dir, func(name string, info fs.FileInfo, contents []byte) error {
+		addToDigest(digest, name, info.Mode(), contents)
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("error reading [%s]: %w", dir, err)
+	}
+	return hex.EncodeToString(digest.Sum(nil)), nil
+}
+
+// Read and decrypt a sealed directory
+func open(sealedPath string, key []byte) ([]byte, error) {
+	sealed, err := os.ReadFile(sealedPath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading [%s]: %w", sealedPath, err)
+	}
+	aead, err := newAead(key)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.HasPrefix(sealed, sealedHeader) || len(sealed) < len(sealedHeader)+aead.NonceSize() {
+		return nil, fmt.Errorf("[%s] is not a sealed validator keystore directory", sealedPath)
+	}
+	nonce := sealed[len(sealedHeader) : len(sealedHeader)+aead.NonceSize()]
+	archive, err := aead.Open(nil, nonce, sealed[len(sealedHeader)+aead.NonceSize():], sealedHeader)
+	if err != nil {
+		return nil, fmt.Errorf("error decrypting [%s]; it may have been sealed with a different key or been modified", sealedPath)
+	}
+	return archive, nil
+}
+
+// Create the cipher for an envelope key
+func newAead(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("error creating cipher: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("error creating cipher: %w", err)
+	}
+	return aead, nil
+}
+
+// Walk the folders and regular files in a directory in a stable order, with their slash-separated names relative to it.
+// Anything else, such as sockets left by a running client or the links to the slashing protection databases, is skipped.
+func walk(dir string, fn func(name string, info fs.FileInfo, contents []byte) error) error {
+	return filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == dir {
+			return nil
+		}
+		if filepath.Dir(path) == dir && isReservedEntry(entry.Name()) {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() && !info.Mode().IsRegular() {
+			return nil
+		}
+		name, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		var contents []byte
+		if info.Mode().IsRegular() {
+			contents, err = os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+		}
+		return fn(filepath.ToSlash(name), info, contents)
+	})
+}
+
+// Read the entries of an archive, checking that none of them point outside of it, and get the digest of its contents
+func readArchive(archive []byte, fn func(name string, header *tar.Header, contents []byte) error) (string, error) {
+	digest := sha256.New()
+	reader := tar.NewReader(bytes.NewReader(archive))
+	for {
+		header, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+		name := filepath.ToSlash(filepath.Clean(filepath.FromSlash(header.Name)))
+		if name != header.Name || filepath.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") {
+			return "", fmt.Errorf("archive entry [%s] is not a plain relative path", header.Name)
+		}
+		contents, err := io.ReadAll(reader)
+		if err != nil {
+			return "", err
+		}
+		addToDigest(digest, name, header.FileInfo().Mode(), contents)
+		if err := fn(name, header, contents); err != nil {
+			return "", err
+		}
+	}
+	return hex.EncodeToString(digest.Sum(nil)), nil
+}
+
+// Add an entry to a digest of a directory's contents
+func addToDigest(digest io.Writer, name string, mode fs.FileMode, contents []byte) {
+	entryHash := sha256.Sum256(contents)
+	fmt.Fprintf(digest, "%s\x00%d\x00%s\n", name, uint32(mode.Perm()|(mode&fs.ModeDir)), hex.EncodeToString(entryHash[:]))
+}
+
+// Check if the validator keystore directory has been encrypted. In Native mode the validators folder then links to its decrypted copy;
+// in Docker mode the service mounts a tmpfs volume over it whenever the encrypted copy exists.
+func IsSealed(cfg *config.RocketPoolConfig) bool {
+	if cfg.IsNativeMode {
+		info, err := os.Lstat(os.ExpandEnv(cfg.Smartnode.GetValidatorKeychainPath()))
+		if err != nil || info.Mode()&fs.ModeSymlink == 0 {
+			return false
+		}
+	}
+	_, err := os.Stat(os.ExpandEnv(cfg.Smartnode.GetSealedValidatorKeychainPath()))
+	return err == nil
+}
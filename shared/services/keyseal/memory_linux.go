@@ -0,0 +1,24 @@
+//go:build linux
+// +build linux
+
+package keyseal
+
+import (
+	"fmt"
+	"syscall"
+)
+
+// The filesystem types that only live in memory
+const (
+	tmpfsMagic int64 = 0x01021994
+	ramfsMagic int64 = 0x858458f6
+)
+
+// Check if a directory is on memory-backed storage, so the decrypted keys never reach a disk
+func IsMemoryBacked(dir string) (bool, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(dir, &stat); err != nil {
+		return false, fmt.Errorf("error checking the filesystem of [%s]: %w", dir, err)
+	}
+	return int64(stat.Type) == tmpfsMagic || int64(stat.Type) == ramfsMagic, nil
+}
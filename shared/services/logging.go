@@ -0,0 +1,38 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Apply the daemon log format and level settings from the Smartnode config
+func ConfigureLogging(cfg *config.RocketPoolConfig) error {
+
+	// Get the format
+	var format log.Format
+	switch cfg.Smartnode.LogFormat.Value.(cfgtypes.LogFormat) {
+	case cfgtypes.LogFormat_Json:
+		format = log.Format_Json
+	case cfgtypes.LogFormat_Logfmt:
+		format = log.Format_Logfmt
+	default:
+		format = log.Format_Color
+	}
+
+	// Get the levels
+	defaultLevel, err := log.ParseLevel(string(cfg.Smartnode.LogLevel.Value.(cfgtypes.LogLevel)))
+	if err != nil {
+		return fmt.Errorf("error parsing daemon log level: %w", err)
+	}
+	moduleLevels, err := log.ParseModuleLevels(cfg.Smartnode.LogModuleLevels.Value.(string))
+	if err != nil {
+		return fmt.Errorf("error parsing module log levels: %w", err)
+	}
+
+	log.Configure(format, defaultLevel, moduleLevels)
+	return nil
+
+}
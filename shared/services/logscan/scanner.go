@@ -0,0 +1,214 @@
+package logscan
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+)
+
+// Settings
+const (
+	// How many times a chunk is retried after an error that isn't caused by its size
+	maxRetries int = 3
+
+	// How many chunks in a row have to succeed before a split chunk size is doubled again
+	growthThreshold int = 4
+)
+
+// How long to wait before retrying a chunk
+var retryDelay, _ = time.ParseDuration("2s")
+
+// Parts of the errors clients and providers return when a query covers too many blocks or would return too many logs
+var limitErrors = []string{
+	"query returned more than",
+	"block range",
+	"range is too large",
+	"range too large",
+	"exceed maximum block range",
+	"limit exceeded",
+	"response size exceeded",
+	"too many results",
+	"query timeout exceeded",
+}
+
+// The part of the Execution client the scanner needs
+type Client interface {
+	FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error)
+	BlockNumber(ctx context.Context) (uint64, error)
+}
+
+// A client that knows the most blocks its endpoint allows in a single query, such as the Execution client manager for hosted providers
+type RangeLimiter interface {
+	// Get the most blocks a query can cover, or 0 if there's no known limit
+	GetMaxLogRange() uint64
+}
+
+// Scans event logs over a range of blocks in chunks. Chunks that exceed the client's range or result limits are
+// split in half until they succeed, and the chunk size grows back once the smaller chunks have been working.
+// Passing the Execution client manager as the client makes each chunk fail over to the fallback client, and keeps the chunks
+// within the known limits of the hosted provider serving it.
+type Scanner struct {
+	client    Client
+	chunkSize uint64
+}
+
+// Create a scanner that requests up to chunkSize blocks at a time
+func NewScanner(client Client, chunkSize uint64) *Scanner {
+	if chunkSize == 0 {
+		chunkSize = 1
+	}
+	return &Scanner{
+		client:    client,
+		chunkSize: chunkSize,
+	}
+}
+
+// Scan the logs matching the filters from fromBlock to toBlock (inclusive), passing them to the handler one chunk at a time
+// in block order. Scanning stops at the first error from the client or the handler.
+func (s *Scanner) Scan(ctx context.Context, addressFilter []common.Address, topicFilter [][]common.Hash, fromBlock uint64, toBlock uint64, handler func([]types.Log) error) error {
+	maxChunkSize := s.getMaxChunkSize()
+	chunkSize := maxChunkSize
+	successes := 0
+	for start := fromBlock; start <= toBlock; {
+		end := start + chunkSize - 1
+		if end > toBlock || end < start {
+			end = toBlock
+		}
+
+		logs, err := s.getChunk(ctx, addressFilter, topicFilter, start, end)
+		if err != nil {
+			if isLimitError(err) && end > start {
+				// Split the chunk and try again; the error may have revealed which provider the client is using, and with it a tighter limit
+				chunkSize = (end - start + 1) / 2
+				maxChunkSize = s.getMaxChunkSize()
+				if chunkSize > maxChunkSize {
+					chunkSize = maxChunkSize
+				}
+				successes = 0
+				continue
+			}
+			return fmt.Errorf("error getting logs for blocks %d to %d: %w", start, end, err)
+		}
+		if err := handler(logs); err != nil {
+			return err
+		}
+
+		// Grow the chunk size back after a run of successes
+		successes++
+		if chunkSize < maxChunkSize && successes >= growthThreshold {
+			chunkSize *= 2
+			if chunkSize > maxChunkSize {
+				chunkSize = maxChunkSize
+			}
+			successes = 0
+		}
+		if end == toBlock {
+			break
+		}
+		start = end + 1
+	}
+	return nil
+}
+
+// Get the largest chunk to request, which is capped by the client's range limit if it has one
+func (s *Scanner) getMaxChunkSize() uint64 {
+	limiter, isLimiter := s.client.(RangeLimiter)
+	if !isLimiter {
+		return s.chunkSize
+	}
+	limit := limiter.GetMaxLogRange()
+	if limit > 0 && limit < s.chunkSize {
+		return limit
+	}
+	return s.chunkSize
+}
+
+// Get all of the logs matching the filters from fromBlock to toBlock (inclusive). A nil toBlock scans up to the latest block.
+func (s *Scanner) GetLogs(addressFilter []common.Address, topicFilter [][]common.Hash, fromBlock *big.Int, toBlock *big.Int) ([]types.Log, error) {
+	ctx := context.Background()
+	var end uint64
+	if toBlock == nil {
+		latestBlock, err := s.client.BlockNumber(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("error getting latest block number: %w", err)
+		}
+		end = latestBlock
+	} else {
+		end = toBlock.Uint64()
+	}
+	start := uint64(0)
+	if fromBlock != nil {
+		start = fromBlock.Uint64()
+	}
+
+	logs := []types.Log{}
+	err := s.Scan(ctx, addressFilter, topicFilter, start, end, func(chunk []types.Log) error {
+		logs = append(logs, chunk...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return logs, nil
+}
+
+// Get the logs of a single chunk, retrying errors that aren't caused by the size of the chunk
+func (s *Scanner) getChunk(ctx context.Context, addressFilter []common.Address, topicFilter [][]common.Hash, start uint64, end uint64) ([]types.Log, error) {
+	query := ethereum.FilterQuery{
+		Addresses: addressFilter,
+		Topics:    topicFilter,
+		FromBlock: new(big.Int).SetUint64(start),
+		ToBlock:   new(big.Int).SetUint64(end),
+	}
+	var err error
+	for attempt := 0; attempt <= maxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(retryDelay):
+			}
+		}
+		var logs []types.Log
+		logs, err = s.client.FilterLogs(ctx, query)
+		if err == nil {
+			return logs, nil
+		}
+		if isLimitError(err) {
+			return nil, err
+		}
+	}
+	return nil, err
+}
+
+// Check if an error means the query was too large
+func isLimitError(err error) bool {
+	message := strings.ToLower(err.Error())
+	for _, limitError := range limitErrors {
+		if strings.Contains(message, limitError) {
+			return true
+		}
+	}
+	return false
+}
+
+// Get the logs matching the filters with a scanner for the Rocket Pool client, in chunks of intervalSize blocks.
+// A nil fromBlock starts from the block Rocket Pool was deployed on, and a nil toBlock scans up to the latest block.
+func GetLogs(rp *rocketpool.RocketPool, addressFilter []common.Address, topicFilter [][]common.Hash, intervalSize *big.Int, fromBlock *big.Int, toBlock *big.Int) ([]types.Log, error) {
+	if fromBlock == nil {
+		deployBlock, err := rp.RocketStorage.GetUint(nil, crypto.Keccak256Hash([]byte("deploy.block")))
+		if err != nil {
+			return nil, fmt.Errorf("error getting Rocket Pool deployment block: %w", err)
+		}
+		fromBlock = deployBlock
+	}
+	return NewScanner(rp.Client, intervalSize.Uint64()).GetLogs(addressFilter, topicFilter, fromBlock, toBlock)
+}
@@ -0,0 +1,209 @@
+package mevboost
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Settings
+const (
+	relayPayloadDeliveredPath string = "/relay/v1/data/bidtraces/proposer_payload_delivered?slot=%d"
+
+	// How many proposals to keep in the history
+	maxProposalHistory int = 500
+)
+
+// Where one of the node's proposals got its block from
+type ProposalSource string
+
+const (
+	ProposalSource_Relay  ProposalSource = "relay"
+	ProposalSource_Local  ProposalSource = "local"
+	ProposalSource_Missed ProposalSource = "missed"
+)
+
+// A block a relay delivered to a proposer
+type DeliveredPayload struct {
+	Relay         cfgtypes.MevRelay
+	BuilderPubkey string
+	Value         *big.Int
+}
+
+// One of the node's proposals, as recorded by the node daemon
+type Proposal struct {
+	Slot     uint64                `json:"slot"`
+	Time     time.Time             `json:"time"`
+	Pubkey   types.ValidatorPubkey `json:"pubkey"`
+	Minipool common.Address        `json:"minipool"`
+	Source   ProposalSource        `json:"source"`
+
+	// Whether the minipool was supposed to get its block from MEV-Boost's builders
+	ExpectedBuilder bool `json:"expectedBuilder"`
+
+	// The execution block, if the proposal wasn't missed
+	BlockNumber  uint64         `json:"blockNumber,omitempty"`
+	BlockHash    common.Hash    `json:"blockHash,omitempty"`
+	FeeRecipient common.Address `json:"feeRecipient,omitempty"`
+
+	// The relay that delivered the block and what its builder bid for it, for blocks from a relay
+	Relay    string   `json:"relay,omitempty"`
+	BidValue *big.Int `json:"bidValue,omitempty"`
+
+	// How much the fee recipient's balance went up in the block, or nil if it couldn't be found
+	Reward *big.Int `json:"reward,omitempty"`
+}
+
+// The node's recent proposals
+type ProposalHistory struct {
+	Proposals []Proposal `json:"proposals"`
+}
+
+// A relay's record of a block it delivered
+type bidTrace struct {
+	Slot          string `json:"slot"`
+	BlockHash     string `json:"block_hash"`
+	BuilderPubkey string `json:"builder_pubkey"`
+	Value         string `json:"value"`
+}
+
+// Ask the relays available on the node's network whether any of them delivered the block for a slot.
+// Returns nil if none of them did; this is only an error if none of them could be asked.
+func FindDeliveredPayload(cfg *config.RocketPoolConfig, slot uint64, blockHash common.Hash) (*DeliveredPayload, error) {
+	network := cfg.Smartnode.Network.Value.(cfgtypes.Network)
+	httpClient := &http.Client{Timeout: relayTimeout}
+
+	relays := cfg.MevBoost.GetAvailableRelays()
+	if len(relays) == 0 {
+		return nil, nil
+	}
+	payloads := make([]*DeliveredPayload, len(relays))
+	errs := make([]error, len(relays))
+	var wg sync.WaitGroup
+	for i, relay := range relays {
+		wg.Add(1)
+		go func(i int, relay cfgtypes.MevRelay) {
+			defer wg.Done()
+			payloads[i], errs[i] = getDeliveredPayload(httpClient, relay, relay.Urls[network], slot, blockHash)
+		}(i, relay)
+	}
+	wg.Wait()
+
+	failures := []string{}
+	for i, payload := range payloads {
+		if payload != nil {
+			return payload, nil
+		}
+		if errs[i] != nil {
+			failures = append(failures, fmt.Sprintf("%s: %s", relays[i].Name, errs[i].Error()))
+		}
+	}
+	if len(failures) == len(relays) {
+		return nil, fmt.Errorf("couldn't check any of the relays for slot %d: %s", slot, strings.Join(failures, "; "))
+	}
+	return nil, nil
+}
+
+// Ask a single relay whether it delivered the block for a slot
+func getDeliveredPayload(httpClient *http.Client, relay cfgtypes.MevRelay, relayUrl string, slot uint64, blockHash common.Hash) (*DeliveredPayload, error) {
+	baseUrl, err := GetRelayBaseUrl(relayUrl)
+	if err != nil {
+		return nil, err
+	}
+	statusCode, body, err := relayGet(httpClient, baseUrl+fmt.Sprintf(relayPayloadDeliveredPath, slot))
+	if err != nil {
+		return nil, err
+	}
+	if statusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP %d: %s", statusCode, string(body))
+	}
+	var traces []bidTrace
+	if err := json.Unmarshal(body, &traces); err != nil {
+		return nil, fmt.Errorf("error deserializing delivered payloads: %w", err)
+	}
+	for _, trace := range traces {
+		if common.HexToHash(trace.BlockHash) != blockHash {
+			continue
+		}
+		value, ok := big.NewInt(0).SetString(trace.Value, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid bid value [%s]", trace.Value)
+		}
+		return &DeliveredPayload{
+			Relay:         relay,
+			BuilderPubkey: trace.BuilderPubkey,
+			Value:         value,
+		}, nil
+	}
+	return nil, nil
+}
+
+// Add a proposal to the history, replacing any earlier record of the same slot and dropping the oldest ones once it's full
+func (h *ProposalHistory) Add(proposal Proposal) {
+	for i, existing := range h.Proposals {
+		if existing.Slot == proposal.Slot {
+			h.Proposals[i] = proposal
+			return
+		}
+	}
+	h.Proposals = append(h.Proposals, proposal)
+	sort.Slice(h.Proposals, func(i, j int) bool {
+		return h.Proposals[i].Slot < h.Proposals[j].Slot
+	})
+	if len(h.Proposals) > maxProposalHistory {
+		h.Proposals = h.Proposals[len(h.Proposals)-maxProposalHistory:]
+	}
+}
+
+// Save the history to the provided path
+func (h *ProposalHistory) Save(path string) error {
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return fmt.Errorf("error creating proposal history directory: %w", err)
+	}
+	bytes, err := json.Marshal(h)
+	if err != nil {
+		return fmt.Errorf("error serializing proposal history: %w", err)
+	}
+	err = os.WriteFile(path, bytes, 0644)
+	if err != nil {
+		return fmt.Errorf("error writing proposal history file [%s]: %w", path, err)
+	}
+	return nil
+}
+
+// Load the history from the provided path. Returns an empty history if the node daemon hasn't recorded any proposals yet.
+func LoadProposalHistory(path string) (*ProposalHistory, error) {
+	history := &ProposalHistory{
+		Proposals: []Proposal{},
+	}
+	bytes, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return history, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading proposal history file [%s]: %w", path, err)
+	}
+	err = json.Unmarshal(bytes, history)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing proposal history file [%s]: %w", path, err)
+	}
+	if history.Proposals == nil {
+		history.Proposals = []Proposal{}
+	}
+	return history, nil
+}
@@ -0,0 +1,260 @@
+package mevboost
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/types"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Settings
+const (
+	relayStatusPath       string = "/eth/v1/builder/status"
+	relayRegistrationPath string = "/relay/v1/data/validator_registration?pubkey=%s"
+
+	// How many registration lookups to run against a relay at the same time
+	registrationThreadLimit int = 8
+)
+
+// How long to wait for a relay to respond
+var relayTimeout, _ = time.ParseDuration("10s")
+
+// The health of one of the enabled relays, and which of the node's validators are registered with it
+type RelayStatus struct {
+	ID        cfgtypes.MevRelayID `json:"id"`
+	Name      string              `json:"name"`
+	Url       string              `json:"url"`
+	Regulated bool                `json:"regulated"`
+
+	// True if the relay's status endpoint responded successfully
+	Live    bool   `json:"live"`
+	Latency int64  `json:"latency"`
+	Error   string `json:"error,omitempty"`
+
+	// The validators that are and aren't registered with the relay; empty if the relay isn't live
+	RegisteredValidators   []types.ValidatorPubkey `json:"registeredValidators"`
+	UnregisteredValidators []types.ValidatorPubkey `json:"unregisteredValidators"`
+}
+
+// The results of the last relay check, as recorded by the node daemon
+type RelayReport struct {
+	Time       time.Time               `json:"time"`
+	Validators []types.ValidatorPubkey `json:"validators"`
+	Relays     []RelayStatus           `json:"relays"`
+}
+
+// Check the status of every enabled relay and the registrations of the provided validators with each of them
+func CheckRelays(cfg *config.RocketPoolConfig, pubkeys []types.ValidatorPubkey) *RelayReport {
+	report := &RelayReport{
+		Time:       time.Now(),
+		Validators: pubkeys,
+		Relays:     []RelayStatus{},
+	}
+	network := cfg.Smartnode.Network.Value.(cfgtypes.Network)
+	httpClient := &http.Client{Timeout: relayTimeout}
+
+	relays := cfg.MevBoost.GetEnabledMevRelays()
+	statuses := make([]RelayStatus, len(relays))
+	var wg sync.WaitGroup
+	for i, relay := range relays {
+		wg.Add(1)
+		go func(i int, relay cfgtypes.MevRelay) {
+			defer wg.Done()
+			statuses[i] = checkRelay(httpClient, relay, relay.Urls[network], pubkeys)
+		}(i, relay)
+	}
+	wg.Wait()
+
+	report.Relays = statuses
+	return report
+}
+
+// Check a single relay
+func checkRelay(httpClient *http.Client, relay cfgtypes.MevRelay, relayUrl string, pubkeys []types.ValidatorPubkey) RelayStatus {
+	status := RelayStatus{
+		ID:                     relay.ID,
+		Name:                   relay.Name,
+		Regulated:              relay.Regulated,
+		RegisteredValidators:   []types.ValidatorPubkey{},
+		UnregisteredValidators: []types.ValidatorPubkey{},
+	}
+	baseUrl, err := GetRelayBaseUrl(relayUrl)
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+	status.Url = baseUrl
+
+	// Check that it's up
+	start := time.Now()
+	statusCode, _, err := relayGet(httpClient, baseUrl+relayStatusPath)
+	status.Latency = time.Since(start).Milliseconds()
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+	if statusCode != http.StatusOK {
+		status.Error = fmt.Sprintf("status check returned HTTP %d", statusCode)
+		return status
+	}
+	status.Live = true
+
+	// Check each validator's registration
+	registered := make([]bool, len(pubkeys))
+	errs := make([]error, len(pubkeys))
+	semaphore := make(chan struct{}, registrationThreadLimit)
+	var wg sync.WaitGroup
+	for i, pubkey := range pubkeys {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(i int, pubkey types.ValidatorPubkey) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			registered[i], errs[i] = isValidatorRegistered(httpClient, baseUrl, pubkey)
+		}(i, pubkey)
+	}
+	wg.Wait()
+
+	for i, pubkey := range pubkeys {
+		if errs[i] != nil {
+			status.Error = fmt.Sprintf("error checking the registration of 0x%s: %s", pubkey.Hex(), errs[i].Error())
+			continue
+		}
+		if registered[i] {
+			status.RegisteredValidators = append(status.RegisteredValidators, pubkey)
+		} else {
+			status.UnregisteredValidators = append(status.UnregisteredValidators, pubkey)
+		}
+	}
+	return status
+}
+
+// Check if a validator has registered with a relay
+func isValidatorRegistered(httpClient *http.Client, baseUrl string, pubkey types.ValidatorPubkey) (bool, error) {
+	statusCode, body, err := relayGet(httpClient, baseUrl+fmt.Sprintf(relayRegistrationPath, "0x"+pubkey.Hex()))
+	if err != nil {
+		return false, err
+	}
+	switch statusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusBadRequest, http.StatusNotFound:
+		// Relays respond with 400 or 404 when they don't have a registration for the validator
+		return false, nil
+	default:
+		return false, fmt.Errorf("HTTP %d: %s", statusCode, string(body))
+	}
+}
+
+// Make a GET request to a relay
+func relayGet(httpClient *http.Client, requestUrl string) (int, []byte, error) {
+	response, err := httpClient.Get(requestUrl)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+	body, err := io.ReadAll(io.LimitReader(response.Body, 4096))
+	if err != nil {
+		return 0, nil, err
+	}
+	return response.StatusCode, body, nil
+}
+
+// Get the base URL of a relay from its MEV-Boost URL, removing the relay's public key and any query parameters
+func GetRelayBaseUrl(relayUrl string) (string, error) {
+	parsedUrl, err := url.Parse(relayUrl)
+	if err != nil {
+		return "", fmt.Errorf("invalid relay URL: %w", err)
+	}
+	parsedUrl.User = nil
+	parsedUrl.RawQuery = ""
+	parsedUrl.Fragment = ""
+	return parsedUrl.String(), nil
+}
+
+// Get the relays that are down
+func (r *RelayReport) GetDownRelays() []RelayStatus {
+	down := []RelayStatus{}
+	for _, relay := range r.Relays {
+		if !relay.Live {
+			down = append(down, relay)
+		}
+	}
+	return down
+}
+
+// Get the validators that aren't registered with any of the live relays
+func (r *RelayReport) GetUnregisteredValidators() []types.ValidatorPubkey {
+	registered := map[types.ValidatorPubkey]bool{}
+	anyLive := false
+	for _, relay := range r.Relays {
+		if !relay.Live {
+			continue
+		}
+		anyLive = true
+		for _, pubkey := range relay.RegisteredValidators {
+			registered[pubkey] = true
+		}
+	}
+	unregistered := []types.ValidatorPubkey{}
+	if !anyLive {
+		return unregistered
+	}
+	for _, pubkey := range r.Validators {
+		if !registered[pubkey] {
+			unregistered = append(unregistered, pubkey)
+		}
+	}
+	sort.Slice(unregistered, func(i, j int) bool {
+		return unregistered[i].Hex() < unregistered[j].Hex()
+	})
+	return unregistered
+}
+
+// Save the report to the provided path
+func (r *RelayReport) Save(path string) error {
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return fmt.Errorf("error creating relay status directory: %w", err)
+	}
+	bytes, err := json.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("error serializing relay status: %w", err)
+	}
+	err = os.WriteFile(path, bytes, 0644)
+	if err != nil {
+		return fmt.Errorf("error writing relay status file [%s]: %w", path, err)
+	}
+	return nil
+}
+
+// Load the report from the provided path. Returns nil if the node daemon hasn't recorded one yet.
+func LoadRelayReport(path string) (*RelayReport, error) {
+	bytes, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading relay status file [%s]: %w", path, err)
+	}
+	var report RelayReport
+	err = json.Unmarshal(bytes, &report)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing relay status file [%s]: %w", path, err)
+	}
+	return &report, nil
+}
@@ -0,0 +1,233 @@
+package netstats
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rocket-pool/rocketpool-go/deposit"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/network"
+	"github.com/rocket-pool/rocketpool-go/node"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/tokens"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"golang.org/x/sync/errgroup"
+)
+
+// The version of the snapshot schema. Increment this whenever a field is renamed, removed or changes meaning;
+// adding new fields is backwards compatible and doesn't require a new version.
+const SchemaVersion uint64 = 1
+
+// The number of minipools in each status
+type MinipoolCounts struct {
+	Initialized  uint64 `json:"initialized"`
+	Prelaunch    uint64 `json:"prelaunch"`
+	Staking      uint64 `json:"staking"`
+	Withdrawable uint64 `json:"withdrawable"`
+	Dissolved    uint64 `json:"dissolved"`
+	Finalized    uint64 `json:"finalized"`
+}
+
+// The sizes of the deposit pool and minipool queue
+type QueueSizes struct {
+	DepositPoolBalance    float64 `json:"depositPoolBalance"`
+	MinipoolQueueLength   uint64  `json:"minipoolQueueLength"`
+	MinipoolQueueCapacity float64 `json:"minipoolQueueCapacity"`
+}
+
+// A snapshot of network-wide, non-sensitive Rocket Pool statistics taken at a single block
+type Snapshot struct {
+	SchemaVersion      uint64         `json:"schemaVersion"`
+	Network            string         `json:"network"`
+	BlockNumber        uint64         `json:"blockNumber"`
+	Time               time.Time      `json:"time"`
+	NodeCount          uint64         `json:"nodeCount"`
+	SmoothingPoolNodes uint64         `json:"smoothingPoolNodes"`
+	Minipools          MinipoolCounts `json:"minipools"`
+	Queues             QueueSizes     `json:"queues"`
+	TotalRplStaked     float64        `json:"totalRplStaked"`
+	RplPrice           float64        `json:"rplPrice"`
+	RethExchangeRate   float64        `json:"rethExchangeRate"`
+	NodeFee            float64        `json:"nodeFee"`
+}
+
+// A snapshot along with the node wallet's signature of it.
+// The snapshot is kept as raw JSON so the exact signed bytes survive being re-serialized.
+type SignedSnapshot struct {
+	Snapshot  json.RawMessage `json:"snapshot"`
+	Signer    common.Address  `json:"signer,omitempty"`
+	Signature string          `json:"signature,omitempty"`
+}
+
+// Create a snapshot of the network's statistics at the provided block
+func CreateSnapshot(rp *rocketpool.RocketPool, networkName string, blockNumber uint64, blockTime time.Time) (*Snapshot, error) {
+	opts := &bind.CallOpts{
+		BlockNumber: big.NewInt(0).SetUint64(blockNumber),
+	}
+	snapshot := &Snapshot{
+		SchemaVersion: SchemaVersion,
+		Network:       networkName,
+		BlockNumber:   blockNumber,
+		Time:          blockTime.UTC(),
+	}
+
+	var wg errgroup.Group
+	wg.Go(func() error {
+		nodeCount, err := node.GetNodeCount(rp, opts)
+		if err != nil {
+			return fmt.Errorf("error getting node count: %w", err)
+		}
+		snapshot.NodeCount = nodeCount
+		return nil
+	})
+	wg.Go(func() error {
+		smoothingPoolNodes, err := node.GetSmoothingPoolRegisteredNodeCount(rp, opts)
+		if err != nil {
+			return fmt.Errorf("error getting smoothing pool node count: %w", err)
+		}
+		snapshot.SmoothingPoolNodes = smoothingPoolNodes
+		return nil
+	})
+	wg.Go(func() error {
+		minipoolCounts, err := minipool.GetMinipoolCountPerStatus(rp, opts)
+		if err != nil {
+			return fmt.Errorf("error getting minipool counts: %w", err)
+		}
+		finalizedCount, err := minipool.GetFinalisedMinipoolCount(rp, opts)
+		if err != nil {
+			return fmt.Errorf("error getting finalized minipool count: %w", err)
+		}
+		snapshot.Minipools = MinipoolCounts{
+			Initialized:  minipoolCounts.Initialized.Uint64(),
+			Prelaunch:    minipoolCounts.Prelaunch.Uint64(),
+			Staking:      minipoolCounts.Staking.Uint64(),
+			Withdrawable: minipoolCounts.Withdrawable.Uint64(),
+			Dissolved:    minipoolCounts.Dissolved.Uint64(),
+			Finalized:    finalizedCount,
+		}
+		return nil
+	})
+	wg.Go(func() error {
+		balance, err := deposit.GetBalance(rp, opts)
+		if err != nil {
+			return fmt.Errorf("error getting deposit pool balance: %w", err)
+		}
+		snapshot.Queues.DepositPoolBalance = eth.WeiToEth(balance)
+		return nil
+	})
+	wg.Go(func() error {
+		queueLength, err := minipool.GetQueueTotalLength(rp, opts)
+		if err != nil {
+			return fmt.Errorf("error getting minipool queue length: %w", err)
+		}
+		snapshot.Queues.MinipoolQueueLength = queueLength
+		return nil
+	})
+	wg.Go(func() error {
+		queueCapacity, err := minipool.GetQueueTotalCapacity(rp, opts)
+		if err != nil {
+			return fmt.Errorf("error getting minipool queue capacity: %w", err)
+		}
+		snapshot.Queues.MinipoolQueueCapacity = eth.WeiToEth(queueCapacity)
+		return nil
+	})
+	wg.Go(func() error {
+		totalStaked, err := node.GetTotalRPLStake(rp, opts)
+		if err != nil {
+			return fmt.Errorf("error getting total RPL stake: %w", err)
+		}
+		snapshot.TotalRplStaked = eth.WeiToEth(totalStaked)
+		return nil
+	})
+	wg.Go(func() error {
+		rplPrice, err := network.GetRPLPrice(rp, opts)
+		if err != nil {
+			return fmt.Errorf("error getting RPL price: %w", err)
+		}
+		snapshot.RplPrice = eth.WeiToEth(rplPrice)
+		return nil
+	})
+	wg.Go(func() error {
+		rethRate, err := tokens.GetRETHExchangeRate(rp, opts)
+		if err != nil {
+			return fmt.Errorf("error getting rETH exchange rate: %w", err)
+		}
+		snapshot.RethExchangeRate = rethRate
+		return nil
+	})
+	wg.Go(func() error {
+		nodeFee, err := network.GetNodeFee(rp, opts)
+		if err != nil {
+			return fmt.Errorf("error getting node fee: %w", err)
+		}
+		snapshot.NodeFee = nodeFee
+		return nil
+	})
+	if err := wg.Wait(); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// Serialize a snapshot and sign it with the provided function, which should produce an EIP-191 personal signature
+// (such as the node wallet's SignMessage). If sign is nil, the snapshot is left unsigned.
+func SignSnapshot(snapshot *Snapshot, signer common.Address, sign func(message string) ([]byte, error)) (*SignedSnapshot, error) {
+	bytes, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("error serializing snapshot: %w", err)
+	}
+	signed := &SignedSnapshot{
+		Snapshot: bytes,
+	}
+	if sign == nil {
+		return signed, nil
+	}
+
+	signature, err := sign(string(bytes))
+	if err != nil {
+		return nil, fmt.Errorf("error signing snapshot: %w", err)
+	}
+	signed.Signer = signer
+	signed.Signature = "0x" + hex.EncodeToString(signature)
+	return signed, nil
+}
+
+// Check that a signed snapshot was signed by its signer, and deserialize it
+func VerifySnapshot(signed *SignedSnapshot) (*Snapshot, error) {
+	if signed.Signature == "" {
+		return nil, fmt.Errorf("snapshot is not signed")
+	}
+	signature, err := hex.DecodeString(strings.TrimPrefix(signed.Signature, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("error decoding signature: %w", err)
+	}
+	if len(signature) != crypto.SignatureLength {
+		return nil, fmt.Errorf("invalid signature length %d", len(signature))
+	}
+	if signature[crypto.RecoveryIDOffset] >= 27 {
+		signature[crypto.RecoveryIDOffset] -= 27
+	}
+
+	publicKey, err := crypto.SigToPub(accounts.TextHash(signed.Snapshot), signature)
+	if err != nil {
+		return nil, fmt.Errorf("error recovering signer: %w", err)
+	}
+	recovered := crypto.PubkeyToAddress(*publicKey)
+	if recovered != signed.Signer {
+		return nil, fmt.Errorf("snapshot was signed by %s, not %s", recovered.Hex(), signed.Signer.Hex())
+	}
+
+	var snapshot Snapshot
+	if err := json.Unmarshal(signed.Snapshot, &snapshot); err != nil {
+		return nil, fmt.Errorf("error deserializing snapshot: %w", err)
+	}
+	return &snapshot, nil
+}
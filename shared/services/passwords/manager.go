@@ -1,31 +1,65 @@
 package passwords
 
 import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/services/secrets"
 )
 
 // Config
 const (
 	MinPasswordLength = 12
 	FileMode          = 0600
+
+	sessionSecretSize = 32
 )
 
 // Password manager
 type PasswordManager struct {
 	passwordPath string
+	sessionPath  string
+
+	// The secret store the password is kept in instead of the password file, if there is one
+	store secrets.Store
+}
+
+// A period in which the wallet can be used without its password being saved.
+// The session only holds a random secret that the wallet keeps a copy of its seed encrypted with, so the password itself is never stored.
+type session struct {
+	Secret  string    `json:"secret"`
+	Expires time.Time `json:"expires"`
 }
 
 // Create new password manager
-func NewPasswordManager(passwordPath string) *PasswordManager {
+func NewPasswordManager(passwordPath string, sessionPath string, store secrets.Store) *PasswordManager {
 	return &PasswordManager{
 		passwordPath: passwordPath,
+		sessionPath:  sessionPath,
+		store:        store,
 	}
 }
 
-// Check if the password has been set
+// Check if the password is available, either because it's saved to disk or because an unlock session is active
 func (pm *PasswordManager) IsPasswordSet() bool {
+	if pm.IsPasswordSaved() {
+		return true
+	}
+	_, active := pm.GetSessionExpiry()
+	return active
+}
+
+// Check if the password is saved to disk or to the secret store
+func (pm *PasswordManager) IsPasswordSaved() bool {
+	if pm.store != nil {
+		_, exists, err := pm.store.Get(secrets.WalletPassword)
+		return (err == nil && exists)
+	}
 	_, err := os.ReadFile(pm.passwordPath)
 	return (err == nil)
 }
@@ -33,6 +67,23 @@ func (pm *PasswordManager) IsPasswordSet() bool {
 // Get the password
 func (pm *PasswordManager) GetPassword() (string, error) {
 
+	// Unlock sessions don't keep the password
+	if !pm.IsPasswordSaved() {
+		if _, active := pm.GetSessionExpiry(); active {
+			return "", errors.New("The password is not saved; the wallet is only unlocked for a session, which doesn't keep it")
+		}
+		return "", errors.New("The password is not saved and the wallet is locked")
+	}
+
+	// Read from the secret store
+	if pm.store != nil {
+		password, _, err := pm.store.Get(secrets.WalletPassword)
+		if err != nil {
+			return "", fmt.Errorf("Could not read password from %s: %w", pm.store.String(), err)
+		}
+		return password, nil
+	}
+
 	// Read from disk
 	password, err := os.ReadFile(pm.passwordPath)
 	if err != nil {
@@ -44,11 +95,93 @@ func (pm *PasswordManager) GetPassword() (string, error) {
 
 }
 
+// Start a session that lasts until it expires, returning its secret. The session is kept in memory-backed storage,
+// so the secret is gone after a restart.
+func (pm *PasswordManager) StartSession(duration time.Duration) (string, error) {
+
+	// Check the duration
+	if duration <= 0 {
+		return "", errors.New("The session duration must be positive")
+	}
+
+	// Generate the secret
+	secret := make([]byte, sessionSecretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("Could not generate session secret: %w", err)
+	}
+
+	// Write the session
+	s := session{
+		Secret:  hex.EncodeToString(secret),
+		Expires: time.Now().Add(duration),
+	}
+	bytes, err := json.Marshal(s)
+	if err != nil {
+		return "", fmt.Errorf("Could not serialize session: %w", err)
+	}
+	if err := os.WriteFile(pm.sessionPath, bytes, FileMode); err != nil {
+		return "", fmt.Errorf("Could not write session: %w", err)
+	}
+
+	// Return
+	return s.Secret, nil
+
+}
+
+// Get the secret of the active session
+func (pm *PasswordManager) GetSessionSecret() (string, error) {
+	s, err := pm.loadSession()
+	if err != nil {
+		return "", err
+	}
+	if s == nil {
+		return "", errors.New("The password is not saved and the wallet is locked")
+	}
+	return s.Secret, nil
+}
+
+// Get when the active session expires, and whether there is one
+func (pm *PasswordManager) GetSessionExpiry() (time.Time, bool) {
+	s, err := pm.loadSession()
+	if err != nil || s == nil {
+		return time.Time{}, false
+	}
+	return s.Expires, true
+}
+
+// End the active session, if there is one
+func (pm *PasswordManager) EndSession() error {
+	err := os.Remove(pm.sessionPath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("Could not end session: %w", err)
+	}
+	return nil
+}
+
+// Load the active session, or nil if there isn't one; expired sessions are removed
+func (pm *PasswordManager) loadSession() (*session, error) {
+	bytes, err := os.ReadFile(pm.sessionPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Could not read session: %w", err)
+	}
+	s := new(session)
+	if err := json.Unmarshal(bytes, s); err != nil {
+		return nil, fmt.Errorf("Could not decode session: %w", err)
+	}
+	if !time.Now().Before(s.Expires) {
+		return nil, pm.EndSession()
+	}
+	return s, nil
+}
+
 // Set the password
 func (pm *PasswordManager) SetPassword(password string) error {
 
 	// Check password is not set
-	if pm.IsPasswordSet() {
+	if pm.IsPasswordSaved() {
 		return errors.New("Password is already set")
 	}
 
@@ -57,6 +190,14 @@ func (pm *PasswordManager) SetPassword(password string) error {
 		return fmt.Errorf("Password must be at least %d characters long", MinPasswordLength)
 	}
 
+	// Write to the secret store
+	if pm.store != nil {
+		if err := pm.store.Set(secrets.WalletPassword, password); err != nil {
+			return fmt.Errorf("Could not save password to %s: %w", pm.store.String(), err)
+		}
+		return nil
+	}
+
 	// Write to disk
 	if err := os.WriteFile(pm.passwordPath, []byte(password), FileMode); err != nil {
 		return fmt.Errorf("Could not write password to disk: %w", err)
@@ -70,6 +211,11 @@ func (pm *PasswordManager) SetPassword(password string) error {
 // Delete the password
 func (pm *PasswordManager) DeletePassword() error {
 
+	// Delete it from the secret store
+	if pm.store != nil {
+		return pm.store.Delete(secrets.WalletPassword)
+	}
+
 	// Check if it exists
 	_, err := os.Stat(pm.passwordPath)
 	if os.IsNotExist(err) {
@@ -0,0 +1,123 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// A check that must pass before a command can run; it returns false and the reason if it failed
+type preflightCheck struct {
+	name  string
+	check func() (bool, string, error)
+}
+
+// Runs the checks a command needs before sending its transaction concurrently, reporting every check that fails instead of stopping at the first one
+type PreflightRunner struct {
+	loads  []func() error
+	checks []preflightCheck
+}
+
+// Create a new pre-flight runner
+func NewPreflightRunner() *PreflightRunner {
+	return &PreflightRunner{
+		loads:  []func() error{},
+		checks: []preflightCheck{},
+	}
+}
+
+// Add a task that loads data for the checks or the response, such as a gas estimate. Loads run concurrently before any checks.
+func (r *PreflightRunner) Load(load func() error) {
+	r.loads = append(r.loads, load)
+}
+
+// Add a named check, with the message to show if it fails
+func (r *PreflightRunner) Check(name string, failure string, check func() (bool, error)) {
+	r.CheckWithReason(name, func() (bool, string, error) {
+		passed, err := check()
+		return passed, failure, err
+	})
+}
+
+// Add a named check that describes why it failed itself, for messages that include the values it checked
+func (r *PreflightRunner) CheckWithReason(name string, check func() (bool, string, error)) {
+	r.checks = append(r.checks, preflightCheck{
+		name:  name,
+		check: check,
+	})
+}
+
+// Run the loads and then the checks. Errors don't stop the other tasks; if any occur they're all returned together.
+func (r *PreflightRunner) Run() (api.PreflightResult, error) {
+	result := api.PreflightResult{
+		Passed: true,
+		Checks: make([]api.PreflightCheck, len(r.checks)),
+	}
+
+	// Run the loads
+	if err := runPreflightTasks(len(r.loads), func(i int) error {
+		return r.loads[i]()
+	}); err != nil {
+		return result, err
+	}
+
+	// Run the checks
+	err := runPreflightTasks(len(r.checks), func(i int) error {
+		check := r.checks[i]
+		passed, failure, err := check.check()
+		if err != nil {
+			return fmt.Errorf("error checking '%s': %w", check.name, err)
+		}
+		result.Checks[i] = api.PreflightCheck{
+			Name:   check.name,
+			Passed: passed,
+		}
+		if !passed {
+			result.Checks[i].Failure = failure
+		}
+		return nil
+	})
+	if err != nil {
+		return result, err
+	}
+
+	for _, check := range result.Checks {
+		result.Passed = result.Passed && check.Passed
+	}
+	return result, nil
+}
+
+// Run tasks concurrently, waiting for all of them and combining their errors
+func runPreflightTasks(count int, task func(i int) error) error {
+	var wg sync.WaitGroup
+	errs := make([]error, count)
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = task(i)
+		}(i)
+	}
+	wg.Wait()
+
+	messages := []string{}
+	var firstErr error
+	for _, err := range errs {
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			messages = append(messages, err.Error())
+		}
+	}
+	switch len(messages) {
+	case 0:
+		return nil
+	case 1:
+		return firstErr
+	default:
+		return fmt.Errorf("%d pre-flight checks failed to run: %s", len(messages), strings.Join(messages, "; "))
+	}
+}
@@ -0,0 +1,86 @@
+package prices
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"time"
+)
+
+const simplePricePath string = "%s/simple/price?ids=%s,%s&vs_currencies=%s"
+
+// The current prices of ETH and RPL in a fiat currency
+type CurrentPrices struct {
+	Currency string    `json:"currency"`
+	Eth      float64   `json:"eth"`
+	Rpl      float64   `json:"rpl"`
+	Time     time.Time `json:"time"`
+}
+
+// Get the current prices of ETH and RPL
+func (c *Client) GetCurrentPrices() (CurrentPrices, error) {
+	url := fmt.Sprintf(simplePricePath, c.apiUrl, CoinID_Eth, CoinID_Rpl, c.currency)
+	resp, err := httpClient.Get(url)
+	if err != nil {
+		return CurrentPrices{}, fmt.Errorf("error getting the current prices: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return CurrentPrices{}, fmt.Errorf("unexpected http status getting the current prices: %d", resp.StatusCode)
+	}
+
+	// The response maps each coin to its price in each of the requested currencies
+	var response map[string]map[string]float64
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return CurrentPrices{}, fmt.Errorf("error decoding the current prices: %w", err)
+	}
+	ethPrice, exists := response[CoinID_Eth][c.currency]
+	if !exists {
+		return CurrentPrices{}, fmt.Errorf("the price API doesn't have a current %s price for %s", c.currency, CoinID_Eth)
+	}
+	rplPrice, exists := response[CoinID_Rpl][c.currency]
+	if !exists {
+		return CurrentPrices{}, fmt.Errorf("the price API doesn't have a current %s price for %s", c.currency, CoinID_Rpl)
+	}
+	return CurrentPrices{
+		Currency: c.currency,
+		Eth:      ethPrice,
+		Rpl:      rplPrice,
+		Time:     time.Now(),
+	}, nil
+}
+
+// Get the current prices of ETH and RPL, reusing the ones saved in the cache file if they're in the same currency and
+// younger than the provided TTL. Commands that show prices run often, so this keeps them under the price API's rate limits.
+func (c *Client) GetCachedCurrentPrices(cachePath string, ttl time.Duration) (CurrentPrices, error) {
+
+	// Use the cached prices if they're recent enough
+	bytes, err := os.ReadFile(cachePath)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return CurrentPrices{}, fmt.Errorf("error reading price cache: %w", err)
+	}
+	if err == nil {
+		var cached CurrentPrices
+		if json.Unmarshal(bytes, &cached) == nil && cached.Currency == c.currency && time.Since(cached.Time) < ttl {
+			return cached, nil
+		}
+	}
+
+	// Refresh them
+	prices, err := c.GetCurrentPrices()
+	if err != nil {
+		return CurrentPrices{}, err
+	}
+	bytes, err = json.Marshal(prices)
+	if err != nil {
+		return CurrentPrices{}, fmt.Errorf("error serializing prices: %w", err)
+	}
+	if err := os.WriteFile(cachePath, bytes, 0644); err != nil {
+		return CurrentPrices{}, fmt.Errorf("error saving price cache: %w", err)
+	}
+	return prices, nil
+
+}
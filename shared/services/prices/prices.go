@@ -0,0 +1,94 @@
+package prices
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Settings
+const (
+	historyPath       string = "%s/coins/%s/history?date=%s&localization=false"
+	historyDateFormat string = "02-01-2006"
+	rateLimitRetries  int    = 3
+)
+
+// Rate limited requests are retried after this long; the free CoinGecko API allows a handful of requests per minute
+var rateLimitDelay, _ = time.ParseDuration("30s")
+
+// CoinGecko IDs of the tokens a node earns
+const (
+	CoinID_Eth string = "ethereum"
+	CoinID_Rpl string = "rocket-pool"
+)
+
+var httpClient = &http.Client{Timeout: time.Minute}
+
+// The part of CoinGecko's coin history response with the prices in
+type historyResponse struct {
+	MarketData struct {
+		CurrentPrice map[string]float64 `json:"current_price"`
+	} `json:"market_data"`
+}
+
+// Gets daily token prices from a CoinGecko-compatible API, remembering the ones it has already looked up
+type Client struct {
+	apiUrl   string
+	currency string
+	cache    map[string]float64
+}
+
+// Create a new price client for the API at the provided URL, pricing tokens in the provided fiat currency
+func NewClient(apiUrl string, currency string) *Client {
+	return &Client{
+		apiUrl:   strings.TrimSuffix(apiUrl, "/"),
+		currency: strings.ToLower(currency),
+		cache:    map[string]float64{},
+	}
+}
+
+// Get the currency prices are in
+func (c *Client) Currency() string {
+	return c.currency
+}
+
+// Get the price of a token on the day of the provided time (in UTC)
+func (c *Client) GetPrice(coinID string, date time.Time) (float64, error) {
+	day := date.UTC().Format(historyDateFormat)
+	key := coinID + "/" + day
+	if price, exists := c.cache[key]; exists {
+		return price, nil
+	}
+
+	url := fmt.Sprintf(historyPath, c.apiUrl, coinID, day)
+	var resp *http.Response
+	var err error
+	for attempt := 0; ; attempt++ {
+		resp, err = httpClient.Get(url)
+		if err != nil {
+			return 0, fmt.Errorf("error getting the price of %s on %s: %w", coinID, day, err)
+		}
+		if resp.StatusCode != http.StatusTooManyRequests || attempt == rateLimitRetries {
+			break
+		}
+		resp.Body.Close()
+		time.Sleep(rateLimitDelay)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("unexpected http status getting the price of %s on %s: %d", coinID, day, resp.StatusCode)
+	}
+
+	var history historyResponse
+	if err := json.NewDecoder(resp.Body).Decode(&history); err != nil {
+		return 0, fmt.Errorf("error decoding the price of %s on %s: %w", coinID, day, err)
+	}
+	price, exists := history.MarketData.CurrentPrice[c.currency]
+	if !exists {
+		return 0, fmt.Errorf("the price API doesn't have a %s price for %s on %s", c.currency, coinID, day)
+	}
+	c.cache[key] = price
+	return price, nil
+}
@@ -0,0 +1,115 @@
+package profiling
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// A kind of runtime profile
+type ProfileKind string
+
+const (
+	ProfileKind_Cpu       ProfileKind = "cpu"
+	ProfileKind_Heap      ProfileKind = "heap"
+	ProfileKind_Goroutine ProfileKind = "goroutine"
+)
+
+// How long to wait for a profile on top of the time it takes to record
+const collectionTimeout time.Duration = 30 * time.Second
+
+// Serve the pprof endpoints on a Unix socket, which is only reachable from this machine. This blocks until the server stops.
+func Serve(socketPath string) error {
+	if err := os.MkdirAll(filepath.Dir(socketPath), 0700); err != nil {
+		return fmt.Errorf("error creating profiling socket folder: %w", err)
+	}
+
+	// Remove the socket left behind by a previous run
+	if err := os.Remove(socketPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("error removing old profiling socket [%s]: %w", socketPath, err)
+	}
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return fmt.Errorf("error listening on profiling socket [%s]: %w", socketPath, err)
+	}
+	if err := os.Chmod(socketPath, 0600); err != nil {
+		listener.Close()
+		return fmt.Errorf("error setting permissions of profiling socket [%s]: %w", socketPath, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+
+	err = http.Serve(listener, mux)
+	if err != nil {
+		return fmt.Errorf("error running profiling server: %w", err)
+	}
+	return nil
+}
+
+// Collect a profile from the daemon serving the socket and save it to the output path. CPU profiles are recorded for the
+// provided duration; the others are snapshots.
+func Collect(socketPath string, kind ProfileKind, duration time.Duration, outputPath string) error {
+	var path string
+	switch kind {
+	case ProfileKind_Cpu:
+		path = fmt.Sprintf("/debug/pprof/profile?seconds=%d", int(duration.Seconds()))
+	case ProfileKind_Heap:
+		path = "/debug/pprof/heap?gc=1"
+	case ProfileKind_Goroutine:
+		path = "/debug/pprof/goroutine"
+	default:
+		return fmt.Errorf("unknown profile kind [%s]", kind)
+	}
+	if _, err := os.Stat(socketPath); errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("the daemon isn't serving profiles at [%s]; make sure profiling is enabled and the daemon has been restarted since", socketPath)
+	}
+
+	client := &http.Client{
+		Timeout: duration + collectionTimeout,
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var dialer net.Dialer
+				return dialer.DialContext(ctx, "unix", socketPath)
+			},
+		},
+	}
+	response, err := client.Get("http://localhost" + path)
+	if err != nil {
+		return fmt.Errorf("error collecting %s profile: %w", kind, err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(response.Body)
+		return fmt.Errorf("error collecting %s profile: HTTP status %d: %s", kind, response.StatusCode, string(body))
+	}
+
+	// Write the profile
+	if err := os.MkdirAll(filepath.Dir(outputPath), 0755); err != nil {
+		return fmt.Errorf("error creating profiles folder: %w", err)
+	}
+	file, err := os.OpenFile(outputPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("error creating profile [%s]: %w", outputPath, err)
+	}
+	if _, err := io.Copy(file, response.Body); err != nil {
+		file.Close()
+		os.Remove(outputPath)
+		return fmt.Errorf("error saving profile [%s]: %w", outputPath, err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("error saving profile [%s]: %w", outputPath, err)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package progress
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/term"
+)
+
+// Settings
+const (
+	barWidth     int    = 30
+	clearLine    string = "\r\033[K"
+	colorReset   string = "\033[0m"
+	colorGreen   string = "\033[32m"
+	colorSkyBlue string = "\033[36m"
+)
+
+// Draws a live progress bar for a task on stderr.
+// It stays hidden when stderr isn't a terminal or when it's disabled with --quiet, so scripts only ever see the command's normal output.
+type Bar struct {
+	out     io.Writer
+	enabled bool
+	drawn   bool
+}
+
+// Create a progress bar, which is only shown on terminals
+func NewBar(quiet bool) *Bar {
+	return &Bar{
+		out:     os.Stderr,
+		enabled: !quiet && term.IsTerminal(int(os.Stderr.Fd())),
+	}
+}
+
+// Check if the bar is shown
+func (b *Bar) Enabled() bool {
+	return b != nil && b.enabled
+}
+
+// Draw the latest progress in place of the previous one
+func (b *Bar) Update(update Update) {
+	if !b.Enabled() {
+		return
+	}
+
+	var line string
+	percent := update.Percent()
+	if percent < 0 {
+		// Phases without a known size just show how long they've been going
+		line = fmt.Sprintf("%s%s%s... (%s)", colorSkyBlue, update.Phase, colorReset, time.Since(update.Started).Round(time.Second))
+	} else {
+		filled := int(percent / 100 * float64(barWidth))
+		bar := strings.Repeat("#", filled) + strings.Repeat("-", barWidth-filled)
+		line = fmt.Sprintf("%s%s%s [%s%s%s] %5.1f%% (%d/%d)", colorSkyBlue, update.Phase, colorReset, colorGreen, bar, colorReset, percent, update.Current, update.Total)
+		if update.EtaSeconds > 0 {
+			line += fmt.Sprintf(" ETA %s", update.Eta())
+		}
+	}
+	fmt.Fprint(b.out, clearLine+line)
+	b.drawn = true
+}
+
+// Remove the bar so the command's own output starts on a clean line
+func (b *Bar) Clear() {
+	if !b.Enabled() || !b.drawn {
+		return
+	}
+	fmt.Fprint(b.out, clearLine)
+	b.drawn = false
+}
@@ -0,0 +1,274 @@
+package progress
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Settings
+const (
+	// Marks the progress lines api commands write to stderr, so they can be told apart from anything else there
+	LinePrefix string = "rocketpool-progress: "
+
+	// How often progress is written while a phase is running; phase changes and the end of the task are always written
+	reportInterval = 1 * time.Second
+
+	fileMode = 0644
+)
+
+// The progress of a long-running task
+type Update struct {
+	Task       string    `json:"task"`
+	Phase      string    `json:"phase"`
+	Current    uint64    `json:"current"`
+	Total      uint64    `json:"total"`
+	EtaSeconds uint64    `json:"etaSeconds,omitempty"`
+	Started    time.Time `json:"started"`
+	Time       time.Time `json:"time"`
+	Done       bool      `json:"done"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// Get how much of the current phase is done, from 0 to 100, or -1 if its size isn't known
+func (u Update) Percent() float64 {
+	if u.Total == 0 {
+		return -1
+	}
+	percent := float64(u.Current) / float64(u.Total) * 100
+	if percent > 100 {
+		percent = 100
+	}
+	return percent
+}
+
+// Get the estimated time until the current phase is done, or 0 if it isn't known
+func (u Update) Eta() time.Duration {
+	return time.Duration(u.EtaSeconds) * time.Second
+}
+
+// Reports the progress of a task as it runs.
+// A nil reporter ignores everything, so tasks can take one optionally.
+type Reporter struct {
+	update     Update
+	phaseStart time.Time
+	lastWrite  time.Time
+	write      func(Update) error
+	lock       *sync.Mutex
+}
+
+// Create a reporter that writes the task's progress to stderr, where the CLI picks it up from api commands
+func NewStderrReporter(task string) *Reporter {
+	return NewWriterReporter(os.Stderr, task)
+}
+
+// Create a reporter that writes the task's progress as lines to the provided writer
+func NewWriterReporter(w io.Writer, task string) *Reporter {
+	return newReporter(task, func(update Update) error {
+		line, err := FormatLine(update)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(line)
+		return err
+	})
+}
+
+// Create a reporter that keeps the task's latest progress in a file, where the CLI can follow it for tasks that run in the daemons
+func NewFileReporter(path string, task string) *Reporter {
+	return newReporter(task, func(update Update) error {
+		bytes, err := json.Marshal(update)
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+
+		// Write to a temporary file first so readers never see a partial update
+		tempPath := path + ".tmp"
+		if err := os.WriteFile(tempPath, bytes, fileMode); err != nil {
+			return err
+		}
+		return os.Rename(tempPath, path)
+	})
+}
+
+// Create a reporter with the provided output
+func newReporter(task string, write func(Update) error) *Reporter {
+	now := time.Now()
+	return &Reporter{
+		update: Update{
+			Task:    task,
+			Started: now,
+		},
+		phaseStart: now,
+		write:      write,
+		lock:       &sync.Mutex{},
+	}
+}
+
+// Start a new phase of the task, with the number of steps in it or 0 if that isn't known
+func (r *Reporter) SetPhase(phase string, total uint64) {
+	if r == nil {
+		return
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.update.Phase = phase
+	r.update.Current = 0
+	r.update.Total = total
+	r.update.EtaSeconds = 0
+	r.phaseStart = time.Now()
+	r.flush()
+}
+
+// Set how many steps of the current phase are done
+func (r *Reporter) SetProgress(current uint64) {
+	if r == nil {
+		return
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.update.Current = current
+
+	// Estimate the rest of the phase from how fast it's gone so far
+	r.update.EtaSeconds = 0
+	if current > 0 && current < r.update.Total {
+		elapsed := time.Since(r.phaseStart)
+		remaining := time.Duration(float64(elapsed) / float64(current) * float64(r.update.Total-current))
+		r.update.EtaSeconds = uint64(remaining.Seconds())
+	}
+	if time.Since(r.lastWrite) >= reportInterval {
+		r.flush()
+	}
+}
+
+// Mark the task as finished, with the error it failed with if there was one
+func (r *Reporter) Finish(err error) {
+	if r == nil {
+		return
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.update.Done = true
+	r.update.EtaSeconds = 0
+	if err != nil {
+		r.update.Error = err.Error()
+	} else {
+		r.update.Current = r.update.Total
+	}
+	r.flush()
+}
+
+// Write the latest progress; failures are ignored since progress is only informational
+func (r *Reporter) flush() {
+	r.update.Time = time.Now()
+	r.lastWrite = r.update.Time
+	_ = r.write(r.update)
+}
+
+// Format an update as a progress line, including its newline
+func FormatLine(update Update) ([]byte, error) {
+	bytes, err := json.Marshal(update)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(fmt.Sprintf("%s%s\n", LinePrefix, bytes)), nil
+}
+
+// Parse a progress line written by a stderr reporter
+func ParseLine(line string) (Update, bool) {
+	if !strings.HasPrefix(line, LinePrefix) {
+		return Update{}, false
+	}
+	var update Update
+	if err := json.Unmarshal([]byte(strings.TrimPrefix(line, LinePrefix)), &update); err != nil {
+		return Update{}, false
+	}
+	return update, true
+}
+
+// Load the progress written by a file reporter, or nil if there isn't any
+func Load(path string) (*Update, error) {
+	bytes, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading progress file [%s]: %w", path, err)
+	}
+	var update Update
+	if err := json.Unmarshal(bytes, &update); err != nil {
+		return nil, fmt.Errorf("error deserializing progress file [%s]: %w", path, err)
+	}
+	return &update, nil
+}
+
+// Picks the progress lines out of a command's stderr as it's written, keeping everything else
+type LineFilter struct {
+	onUpdate func(Update)
+	partial  []byte
+	other    []byte
+	lock     *sync.Mutex
+}
+
+// Create a filter that calls the provided function with each progress update
+func NewLineFilter(onUpdate func(Update)) *LineFilter {
+	return &LineFilter{
+		onUpdate: onUpdate,
+		lock:     &sync.Mutex{},
+	}
+}
+
+// Write some of the command's stderr
+func (f *LineFilter) Write(p []byte) (int, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.partial = append(f.partial, p...)
+	for {
+		index := bytes.IndexByte(f.partial, '\n')
+		if index < 0 {
+			break
+		}
+		f.handleLine(f.partial[:index+1])
+		f.partial = f.partial[index+1:]
+	}
+	return len(p), nil
+}
+
+// Get everything the command wrote to stderr other than its progress
+func (f *LineFilter) Other() []byte {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	// The last line may not have ended with a newline
+	if len(f.partial) > 0 {
+		f.handleLine(f.partial)
+		f.partial = nil
+	}
+	return f.other
+}
+
+// Handle a complete line of stderr
+func (f *LineFilter) handleLine(line []byte) {
+	update, isProgress := ParseLine(strings.TrimRight(string(line), "\r\n"))
+	if !isProgress {
+		f.other = append(f.other, line...)
+		return
+	}
+	if f.onUpdate != nil {
+		f.onUpdate(update)
+	}
+}
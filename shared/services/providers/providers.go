@@ -0,0 +1,98 @@
+package providers
+
+import (
+	"net/url"
+	"strings"
+)
+
+// The known quirks of a hosted RPC provider, and the limits the Smartnode works within to avoid them
+type Profile struct {
+	// The provider's name
+	Name string
+
+	// The domains the provider's endpoints are served from
+	Domains []string
+
+	// Parts of the errors only this provider returns, used to recognize it behind a custom domain or a proxy
+	ErrorSignatures []string
+
+	// The most blocks an eth_getLogs query can cover, or 0 if there's no fixed limit
+	MaxLogRange uint64
+
+	// The most requests a JSON-RPC batch can hold, or 0 if there's no fixed limit
+	MaxBatchSize int
+
+	// Whether the debug_ namespace is available
+	HasDebugNamespace bool
+}
+
+// The known providers
+var Profiles = []Profile{
+	{
+		// Results are capped at 10,000 logs and queries at 10 seconds; 10,000 blocks keeps the queries for Rocket Pool's events well under both
+		Name:    "Infura",
+		Domains: []string{"infura.io"},
+		ErrorSignatures: []string{
+			"query returned more than 10000 results",
+			"project id required in the url",
+		},
+		MaxLogRange:       10000,
+		MaxBatchSize:      100,
+		HasDebugNamespace: false,
+	},
+	{
+		// Unlimited ranges are only allowed if they return fewer than 10,000 logs, but 2,000 blocks always works
+		Name:    "Alchemy",
+		Domains: []string{"alchemy.com", "alchemyapi.io"},
+		ErrorSignatures: []string{
+			"log response size exceeded",
+			"you can make eth_getlogs requests with up to a",
+		},
+		MaxLogRange:       2000,
+		MaxBatchSize:      1000,
+		HasDebugNamespace: false,
+	},
+	{
+		Name:    "Ankr",
+		Domains: []string{"ankr.com"},
+		ErrorSignatures: []string{
+			"block range is too wide",
+		},
+		MaxLogRange:       1000,
+		MaxBatchSize:      100,
+		HasDebugNamespace: false,
+	},
+}
+
+// Get the profile of the provider serving the endpoint at the provided URL, or nil if it isn't a known provider
+func Detect(endpoint string) *Profile {
+	parsed, err := url.Parse(endpoint)
+	if err != nil {
+		return nil
+	}
+	host := strings.ToLower(parsed.Hostname())
+	for i, profile := range Profiles {
+		for _, domain := range profile.Domains {
+			if host == domain || strings.HasSuffix(host, "."+domain) {
+				return &Profiles[i]
+			}
+		}
+	}
+	return nil
+}
+
+// Get the profile of the provider that returned the provided error, or nil if it isn't one a known provider returns
+func DetectFromError(err error) *Profile {
+	if err == nil {
+		return nil
+	}
+	message := strings.ToLower(err.Error())
+	for i, profile := range Profiles {
+		for _, signature := range profile.ErrorSignatures {
+			if strings.Contains(message, signature) {
+				return &Profiles[i]
+			}
+		}
+	}
+	return nil
+}
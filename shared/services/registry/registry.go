@@ -0,0 +1,195 @@
+package registry
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+
+	"github.com/rocket-pool/smartnode/shared/services/progress"
+	"github.com/rocket-pool/smartnode/shared/services/upgrades"
+)
+
+// The Rocket Pool contracts the Smartnode resolves by name
+var ContractNames = []string{
+	"rocketAuctionManager",
+	"rocketClaimDAO",
+	"rocketDAONodeTrusted",
+	"rocketDAONodeTrustedActions",
+	"rocketDAONodeTrustedProposals",
+	"rocketDAONodeTrustedSettingsMembers",
+	"rocketDAONodeTrustedSettingsMinipool",
+	"rocketDAONodeTrustedSettingsProposals",
+	"rocketDAONodeTrustedSettingsRewards",
+	"rocketDAONodeTrustedUpgrade",
+	"rocketDAOProposal",
+	"rocketDAOProtocol",
+	"rocketDAOProtocolSettingsAuction",
+	"rocketDAOProtocolSettingsDeposit",
+	"rocketDAOProtocolSettingsInflation",
+	"rocketDAOProtocolSettingsMinipool",
+	"rocketDAOProtocolSettingsNetwork",
+	"rocketDAOProtocolSettingsNode",
+	"rocketDAOProtocolSettingsRewards",
+	"rocketDepositPool",
+	"rocketMerkleDistributorMainnet",
+	"rocketMinipoolBondReducer",
+	"rocketMinipoolDelegate",
+	"rocketMinipoolFactory",
+	"rocketMinipoolManager",
+	"rocketMinipoolPenalty",
+	"rocketMinipoolQueue",
+	"rocketMinipoolStatus",
+	"rocketNetworkBalances",
+	"rocketNetworkFees",
+	"rocketNetworkPenalties",
+	"rocketNetworkPrices",
+	"rocketNodeDeposit",
+	"rocketNodeDistributorDelegate",
+	"rocketNodeDistributorFactory",
+	"rocketNodeManager",
+	"rocketNodeStaking",
+	"rocketRewardsPool",
+	"rocketSmoothingPool",
+	"rocketTokenRETH",
+	"rocketTokenRPL",
+	"rocketTokenRPLFixedSupply",
+}
+
+// The current deployment of a Rocket Pool contract
+type ContractInfo struct {
+	Name              string           `json:"name"`
+	Address           common.Address   `json:"address"`
+	Version           uint8            `json:"version"`
+	PreviousAddresses []common.Address `json:"previousAddresses"`
+	UpgradedBlock     uint64           `json:"upgradedBlock"`
+}
+
+// Resolves the addresses and ABIs of the Rocket Pool contracts, and watches the upgrade contract so bindings
+// to contracts that have been upgraded are replaced instead of being used until the client's cache expires
+type Registry struct {
+	rp           *rocketpool.RocketPool
+	historyPath  string
+	intervalSize *big.Int
+	depth        uint64
+	contracts    map[string]ContractInfo
+	nameHashes   map[common.Hash]string
+	checkedBlock uint64
+	seen         map[upgrades.PreviousContract]bool
+	lock         sync.Mutex
+}
+
+// Create a new contract registry, keeping the contract upgrade history at the provided path.
+// Upgrades are only picked up once their block is confirmationDepth blocks deep.
+func NewRegistry(rp *rocketpool.RocketPool, historyPath string, intervalSize *big.Int, confirmationDepth uint64) *Registry {
+	nameHashes := map[common.Hash]string{}
+	for _, name := range ContractNames {
+		nameHashes[crypto.Keccak256Hash([]byte(name))] = name
+	}
+	return &Registry{
+		rp:           rp,
+		historyPath:  historyPath,
+		intervalSize: intervalSize,
+		depth:        confirmationDepth,
+		contracts:    map[string]ContractInfo{},
+		nameHashes:   nameHashes,
+		seen:         map[upgrades.PreviousContract]bool{},
+	}
+}
+
+// Get a binding for the named contract
+func (r *Registry) GetContract(contractName string) (*rocketpool.Contract, error) {
+	return r.rp.GetContract(contractName, nil)
+}
+
+// Scan for contract upgrades since the last refresh and reload any contract that was upgraded, returning their names.
+// The first refresh loads every contract.
+func (r *Registry) Refresh(reporter *progress.Reporter) ([]string, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	history, err := upgrades.UpdateHistory(r.rp, r.historyPath, r.intervalSize, r.depth, reporter)
+	if err != nil {
+		return nil, err
+	}
+
+	// Work out which contracts need to be reloaded; upgrades are compared against the ones already seen instead of by block,
+	// since the history rescans the blocks after a reorg and can find upgrades below the last checked block again
+	upgraded := []string{}
+	reload := ContractNames
+	if r.checkedBlock > 0 {
+		found := map[string]bool{}
+		for _, contract := range history.Contracts {
+			name, exists := r.nameHashes[contract.NameHash]
+			if exists && !r.seen[contract] && !found[name] {
+				found[name] = true
+				upgraded = append(upgraded, name)
+			}
+		}
+		reload = upgraded
+	}
+
+	// Passing call options makes the client skip its cache and replace the cached binding; addresses requested
+	// directly from the client without call options can still be stale until its cache expires
+	reporter.SetPhase("Loading contracts", uint64(len(reload)))
+	for i, name := range reload {
+		reporter.SetProgress(uint64(i + 1))
+
+		// Skip contracts that haven't been deployed on this network
+		address, err := r.rp.GetAddress(name, &bind.CallOpts{})
+		if err != nil {
+			return nil, err
+		}
+		if *address == (common.Address{}) {
+			continue
+		}
+		contract, err := r.rp.GetContract(name, &bind.CallOpts{})
+		if err != nil {
+			return nil, fmt.Errorf("error loading contract %s: %w", name, err)
+		}
+		info := ContractInfo{
+			Name:              name,
+			Address:           *contract.Address,
+			PreviousAddresses: history.GetPreviousAddresses(name),
+		}
+		nameHash := crypto.Keccak256Hash([]byte(name))
+		for _, previous := range history.Contracts {
+			if previous.NameHash == nameHash {
+				info.UpgradedBlock = previous.Block
+			}
+		}
+		if _, exists := contract.ABI.Methods["version"]; exists {
+			if err := contract.Call(nil, &info.Version, "version"); err != nil {
+				return nil, fmt.Errorf("error getting the version of contract %s: %w", name, err)
+			}
+		}
+		r.contracts[name] = info
+	}
+	r.checkedBlock = history.ScannedBlock
+	for _, contract := range history.Contracts {
+		r.seen[contract] = true
+	}
+
+	sort.Strings(upgraded)
+	return upgraded, nil
+}
+
+// Get the current deployment of every contract, in name order, as of the last refresh
+func (r *Registry) GetContracts() []ContractInfo {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	contracts := make([]ContractInfo, 0, len(r.contracts))
+	for _, contract := range r.contracts {
+		contracts = append(contracts, contract)
+	}
+	sort.Slice(contracts, func(i, j int) bool {
+		return contracts[i].Name < contracts[j].Name
+	})
+	return contracts
+}
@@ -0,0 +1,118 @@
+package reorg
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// How many checkpoints a tracker keeps; older ones are dropped as new blocks are processed
+const maxCheckpoints int = 32
+
+// The part of the Execution client the tracker needs
+type Client interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	BlockNumber(ctx context.Context) (uint64, error)
+}
+
+// A block that event processing has reached, identified by its hash so it can be checked against the canonical chain later
+type Checkpoint struct {
+	Number uint64      `json:"number"`
+	Hash   common.Hash `json:"hash"`
+}
+
+// Tracks the blocks event processing has reached, so logic driven by event logs can tell when a block it has already
+// acted on is reorged out and roll back to the last block that's still canonical
+type Tracker struct {
+	Checkpoints []Checkpoint `json:"checkpoints"`
+}
+
+// Get the latest block that is buried at least depth blocks deep. Returns false if the chain isn't that long yet.
+func GetConfirmedBlock(ctx context.Context, client Client, depth uint64) (uint64, bool, error) {
+	latestBlock, err := client.BlockNumber(ctx)
+	if err != nil {
+		return 0, false, fmt.Errorf("error getting the latest block: %w", err)
+	}
+	if latestBlock < depth {
+		return 0, false, nil
+	}
+	return latestBlock - depth, true, nil
+}
+
+// Get the checkpoint for a block
+func GetCheckpoint(ctx context.Context, client Client, number uint64) (Checkpoint, error) {
+	header, err := client.HeaderByNumber(ctx, new(big.Int).SetUint64(number))
+	if err != nil {
+		return Checkpoint{}, fmt.Errorf("error getting header for block %d: %w", number, err)
+	}
+	return Checkpoint{
+		Number: number,
+		Hash:   header.Hash(),
+	}, nil
+}
+
+// Record that processing has reached a block
+func (t *Tracker) Add(ctx context.Context, client Client, number uint64) error {
+	checkpoint, err := GetCheckpoint(ctx, client, number)
+	if err != nil {
+		return err
+	}
+	t.Checkpoints = append(t.Checkpoints, checkpoint)
+	if len(t.Checkpoints) > maxCheckpoints {
+		t.Checkpoints = t.Checkpoints[len(t.Checkpoints)-maxCheckpoints:]
+	}
+	return nil
+}
+
+// Get the newest checkpoint, or false if there aren't any yet
+func (t *Tracker) GetLatest() (Checkpoint, bool) {
+	if len(t.Checkpoints) == 0 {
+		return Checkpoint{}, false
+	}
+	return t.Checkpoints[len(t.Checkpoints)-1], true
+}
+
+// Check that the blocks the tracker has followed are still on the canonical chain. The block after the newest checkpoint
+// has to build on it; if it doesn't, the checkpoints are walked back until one is found that's still canonical.
+// Returns the block processing has to roll back to and true if a reorg was found. Rolling back to block 0 means none of
+// the checkpoints are canonical anymore, so everything the tracker has covered has to be processed again.
+func (t *Tracker) FindReorg(ctx context.Context, client Client) (uint64, bool, error) {
+	latest, exists := t.GetLatest()
+	if !exists {
+		return 0, false, nil
+	}
+
+	// Check the parent hash of the next block, or the newest checkpoint itself if it's the head
+	latestBlock, err := client.BlockNumber(ctx)
+	if err != nil {
+		return 0, false, fmt.Errorf("error getting the latest block: %w", err)
+	}
+	if latestBlock > latest.Number {
+		header, err := client.HeaderByNumber(ctx, new(big.Int).SetUint64(latest.Number+1))
+		if err != nil {
+			return 0, false, fmt.Errorf("error getting header for block %d: %w", latest.Number+1, err)
+		}
+		if header.ParentHash == latest.Hash {
+			return latest.Number, false, nil
+		}
+	}
+
+	// Walk back to the newest checkpoint that's still canonical
+	for i := len(t.Checkpoints) - 1; i >= 0; i-- {
+		checkpoint := t.Checkpoints[i]
+		canonical, err := GetCheckpoint(ctx, client, checkpoint.Number)
+		if err != nil {
+			return 0, false, err
+		}
+		if canonical.Hash == checkpoint.Hash {
+			reorged := i < len(t.Checkpoints)-1
+			t.Checkpoints = t.Checkpoints[:i+1]
+			return checkpoint.Number, reorged, nil
+		}
+	}
+	t.Checkpoints = []Checkpoint{}
+	return 0, true, nil
+}
@@ -0,0 +1,176 @@
+package report
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Settings
+const (
+	FilePrefix    string = "rocketpool-report-"
+	FileExtension string = ".tar.gz"
+	RedactedValue string = "[redacted]"
+
+	// Values shorter than this are never treated as secrets when redacting free text, so short common strings aren't mangled
+	minSecretLength int = 8
+)
+
+// Parts of a setting's name that mark it as a secret
+var secretParameterMarkers = []string{
+	"token",
+	"secret",
+	"key",
+	"password",
+	"webhook",
+}
+
+// Strips secrets out of the settings and anything else that goes into a report
+type Redactor struct {
+	secrets map[string]bool
+}
+
+// Create a redactor with no known secrets
+func NewRedactor() *Redactor {
+	return &Redactor{
+		secrets: map[string]bool{},
+	}
+}
+
+// Add a value that must never appear in the report
+func (r *Redactor) AddSecret(secret string) {
+	if len(secret) < minSecretLength {
+		return
+	}
+	r.secrets[secret] = true
+}
+
+// Replace every known secret in the text
+func (r *Redactor) Redact(text string) string {
+	// Replace the longest secrets first so a secret containing another one is removed whole
+	secrets := make([]string, 0, len(r.secrets))
+	for secret := range r.secrets {
+		secrets = append(secrets, secret)
+	}
+	sort.Slice(secrets, func(i, j int) bool {
+		return len(secrets[i]) > len(secrets[j])
+	})
+	for _, secret := range secrets {
+		text = strings.ReplaceAll(text, secret, RedactedValue)
+	}
+	return text
+}
+
+// Copy serialized settings with secret values removed, remembering them so they can be redacted from the rest of the report.
+// Credentials, paths and queries are stripped from URLs since providers often put API keys in them.
+func (r *Redactor) SanitizeSettings(settings map[string]map[string]string) map[string]map[string]string {
+	sanitized := map[string]map[string]string{}
+	for section, params := range settings {
+		sanitizedParams := map[string]string{}
+		for name, value := range params {
+			if value != "" && isSecretParameter(name) {
+				r.AddSecret(value)
+				sanitizedParams[name] = RedactedValue
+				continue
+			}
+
+			// Settings can hold a comma-separated list of URLs
+			items := strings.Split(value, ",")
+			for i, item := range items {
+				items[i] = r.sanitizeUrl(item)
+			}
+			sanitizedParams[name] = strings.Join(items, ",")
+		}
+		sanitized[section] = sanitizedParams
+	}
+	return sanitized
+}
+
+// Check if a setting holds a secret based on its name
+func isSecretParameter(name string) bool {
+	name = strings.ToLower(name)
+	for _, marker := range secretParameterMarkers {
+		if strings.Contains(name, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// Remove everything but the scheme, host and port from a URL; values that aren't URLs are returned as they are
+func (r *Redactor) sanitizeUrl(value string) string {
+	trimmed := strings.TrimSpace(value)
+	parsed, err := url.Parse(trimmed)
+	if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+		return value
+	}
+	if parsed.User == nil && (parsed.Path == "" || parsed.Path == "/") && parsed.RawQuery == "" && parsed.Fragment == "" {
+		return value
+	}
+
+	r.AddSecret(trimmed)
+	if parsed.User != nil {
+		r.AddSecret(parsed.User.String())
+	}
+	r.AddSecret(strings.Trim(parsed.Path, "/"))
+	r.AddSecret(parsed.RawQuery)
+	return fmt.Sprintf("%s://%s/%s", parsed.Scheme, parsed.Host, RedactedValue)
+}
+
+// A gzipped tarball of report files
+type Archive struct {
+	file *os.File
+	gzip *gzip.Writer
+	tar  *tar.Writer
+	time time.Time
+}
+
+// Create a new report archive at the provided path
+func NewArchive(path string) (*Archive, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("error creating report file [%s]: %w", path, err)
+	}
+	gzipWriter := gzip.NewWriter(file)
+	return &Archive{
+		file: file,
+		gzip: gzipWriter,
+		tar:  tar.NewWriter(gzipWriter),
+		time: time.Now(),
+	}, nil
+}
+
+// Add a file to the report
+func (a *Archive) AddFile(name string, contents []byte) error {
+	header := &tar.Header{
+		Name:    name,
+		Mode:    0644,
+		Size:    int64(len(contents)),
+		ModTime: a.time,
+	}
+	if err := a.tar.WriteHeader(header); err != nil {
+		return fmt.Errorf("error adding [%s] to the report: %w", name, err)
+	}
+	if _, err := a.tar.Write(contents); err != nil {
+		return fmt.Errorf("error adding [%s] to the report: %w", name, err)
+	}
+	return nil
+}
+
+// Finish writing the report
+func (a *Archive) Close() error {
+	if err := a.tar.Close(); err != nil {
+		a.file.Close()
+		return fmt.Errorf("error finishing report archive: %w", err)
+	}
+	if err := a.gzip.Close(); err != nil {
+		a.file.Close()
+		return fmt.Errorf("error compressing report archive: %w", err)
+	}
+	return a.file.Close()
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/rocket-pool/rocketpool-go/node"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/urfave/cli"
 )
 
@@ -27,6 +28,7 @@ var ethClientSyncPollInterval, _ = time.ParseDuration("5s")
 var beaconClientSyncPollInterval, _ = time.ParseDuration("5s")
 var ethClientRecentBlockThreshold, _ = time.ParseDuration("5m")
 var ethClientStatusRefreshInterval, _ = time.ParseDuration("60s")
+var checkChainIDInterval, _ = time.ParseDuration("15s")
 
 //
 // Service requirements
@@ -38,6 +40,13 @@ func RequireNodePassword(c *cli.Context) error {
 		return err
 	}
 	if !nodePasswordSet {
+		w, err := GetWallet(c)
+		if err != nil {
+			return err
+		}
+		if w.IsLocked() {
+			return wallet.ErrWalletLocked
+		}
 		return errors.New("The node password has not been set. Please run 'rocketpool wallet init' and try again.")
 	}
 	return nil
@@ -169,7 +178,11 @@ func WaitNodePassword(c *cli.Context, verbose bool) error {
 			return nil
 		}
 		if verbose {
-			log.Printf("The node password has not been set, retrying in %s...\n", checkNodePasswordInterval.String())
+			if w, err := GetWallet(c); err == nil && w.IsLocked() {
+				log.Printf("The node wallet is locked, retrying in %s...\n", checkNodePasswordInterval.String())
+			} else {
+				log.Printf("The node password has not been set, retrying in %s...\n", checkNodePasswordInterval.String())
+			}
 		}
 		time.Sleep(checkNodePasswordInterval)
 	}
@@ -204,6 +217,28 @@ func WaitBeaconClientSynced(c *cli.Context, verbose bool) error {
 	return err
 }
 
+// Wait until the Execution clients are synced and the ones that can be reached are on the network the node is configured for.
+// Mismatches are logged and retried rather than returned, so a client pointed at the wrong network can be fixed without the daemon restarting.
+func WaitChainIDPinned(c *cli.Context, verbose bool) error {
+	if err := WaitEthClientSynced(c, verbose); err != nil {
+		return err
+	}
+	ec, err := GetEthClient(c)
+	if err != nil {
+		return err
+	}
+	for {
+		err := ec.PinChainID(context.Background())
+		if err == nil {
+			return nil
+		}
+		if verbose {
+			log.Printf("Can't start until the Execution clients are on the right network: %s. Retrying in %s...\n", err.Error(), checkChainIDInterval.String())
+		}
+		time.Sleep(checkChainIDInterval)
+	}
+}
+
 func WaitRocketStorage(c *cli.Context, verbose bool) error {
 	if err := WaitEthClientSynced(c, verbose); err != nil {
 		return err
@@ -0,0 +1,173 @@
+package fixture
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	rpstate "github.com/rocket-pool/rocketpool-go/utils/state"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+const (
+	// The version of the fixture format
+	FixtureVersion uint64 = 2
+)
+
+// The client a recorded request was sent to
+type Client string
+
+const (
+	Client_Execution Client = "ec"
+	Client_Beacon    Client = "bc"
+)
+
+// A request consumed while generating a rewards tree, along with the response the client gave
+type Exchange struct {
+	Client Client `json:"client"`
+
+	// The normalized request; the JSON-RPC body with renumbered IDs for the Execution client, or the method, path and body
+	// for the Beacon node
+	Request string `json:"request"`
+
+	Status      int    `json:"status"`
+	ContentType string `json:"contentType,omitempty"`
+	Response    []byte `json:"response"`
+}
+
+// The inputs a rewards tree generator is created with
+type Interval struct {
+	Index            uint64        `json:"index"`
+	StartTime        time.Time     `json:"startTime"`
+	EndTime          time.Time     `json:"endTime"`
+	ConsensusBlock   uint64        `json:"consensusBlock"`
+	ElSnapshotHeader *types.Header `json:"elSnapshotHeader"`
+	IntervalsPassed  uint64        `json:"intervalsPassed"`
+}
+
+// The network state at the end of an interval, which the rewards tree is generated from
+type State struct {
+	ElBlockNumber          uint64                           `json:"elBlockNumber"`
+	BeaconSlotNumber       uint64                           `json:"beaconSlotNumber"`
+	BeaconConfig           beacon.Eth2Config                `json:"beaconConfig"`
+	NetworkDetails         *rpstate.NetworkDetails          `json:"networkDetails"`
+	NodeDetails            []rpstate.NativeNodeDetails      `json:"nodeDetails"`
+	MinipoolDetails        []rpstate.NativeMinipoolDetails  `json:"minipoolDetails"`
+	OracleDaoMemberDetails []rpstate.OracleDaoMemberDetails `json:"oracleDaoMemberDetails"`
+
+	// Keyed by the hex-encoded validator pubkey, since the pubkeys can't be JSON map keys themselves
+	ValidatorDetails map[string]beacon.ValidatorStatus `json:"validatorDetails"`
+}
+
+// Create a snapshot of a network state that can be saved to a fixture
+func NewState(networkState *state.NetworkState) *State {
+	validatorDetails := make(map[string]beacon.ValidatorStatus, len(networkState.ValidatorDetails))
+	for pubkey, status := range networkState.ValidatorDetails {
+		validatorDetails[pubkey.Hex()] = status
+	}
+	return &State{
+		ElBlockNumber:          networkState.ElBlockNumber,
+		BeaconSlotNumber:       networkState.BeaconSlotNumber,
+		BeaconConfig:           networkState.BeaconConfig,
+		NetworkDetails:         networkState.NetworkDetails,
+		NodeDetails:            networkState.NodeDetails,
+		MinipoolDetails:        networkState.MinipoolDetails,
+		OracleDaoMemberDetails: networkState.OracleDaoMemberDetails,
+		ValidatorDetails:       validatorDetails,
+	}
+}
+
+// Rebuild the network state from the snapshot
+func (s *State) GetNetworkState() (*state.NetworkState, error) {
+	validatorDetails := make(map[rptypes.ValidatorPubkey]beacon.ValidatorStatus, len(s.ValidatorDetails))
+	for pubkeyString, status := range s.ValidatorDetails {
+		pubkey, err := rptypes.HexToValidatorPubkey(pubkeyString)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding validator pubkey [%s]: %w", pubkeyString, err)
+		}
+		validatorDetails[pubkey] = status
+	}
+	return state.NewNetworkStateFromDetails(s.ElBlockNumber, s.BeaconSlotNumber, s.BeaconConfig, s.NetworkDetails, s.NodeDetails, s.MinipoolDetails, validatorDetails, s.OracleDaoMemberDetails), nil
+}
+
+// The network state and interval a rewards tree was generated from, every Execution and Beacon client response the generator
+// consumed, and the files it produced, so the tree can be regenerated without any clients and checked byte-for-byte
+type Fixture struct {
+	Version        uint64           `json:"version"`
+	Network        cfgtypes.Network `json:"network"`
+	Interval       Interval         `json:"interval"`
+	State          *State           `json:"state"`
+	RulesetVersion uint64           `json:"rulesetVersion"`
+	MerkleRoot     string           `json:"merkleRoot"`
+
+	// SHA-256 hashes of the serialized rewards file and minipool performance file
+	RewardsFileHash             string `json:"rewardsFileHash"`
+	MinipoolPerformanceFileHash string `json:"minipoolPerformanceFileHash"`
+
+	Exchanges []Exchange `json:"exchanges"`
+}
+
+// Load a fixture from a gzipped JSON file
+func Load(path string) (*Fixture, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("error opening fixture [%s]: %w", path, err)
+	}
+	defer file.Close()
+	reader, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing fixture [%s]: %w", path, err)
+	}
+	defer reader.Close()
+
+	var fixture Fixture
+	if err := json.NewDecoder(reader).Decode(&fixture); err != nil {
+		return nil, fmt.Errorf("error decoding fixture [%s]: %w", path, err)
+	}
+	if fixture.Version != FixtureVersion {
+		return nil, fmt.Errorf("fixture [%s] has version %d but only version %d is supported", path, fixture.Version, FixtureVersion)
+	}
+	return &fixture, nil
+}
+
+// Save the fixture to a gzipped JSON file. The exchanges are sorted first so recording the same interval twice produces
+// the same file.
+func (f *Fixture) Save(path string) error {
+	sort.Slice(f.Exchanges, func(i, j int) bool {
+		if f.Exchanges[i].Client != f.Exchanges[j].Client {
+			return f.Exchanges[i].Client < f.Exchanges[j].Client
+		}
+		return f.Exchanges[i].Request < f.Exchanges[j].Request
+	})
+
+	tempPath := filepath.Join(filepath.Dir(path), "."+filepath.Base(path)+".tmp")
+	file, err := os.OpenFile(tempPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("error creating fixture [%s]: %w", tempPath, err)
+	}
+	writer := gzip.NewWriter(file)
+	if err := json.NewEncoder(writer).Encode(f); err != nil {
+		writer.Close()
+		file.Close()
+		return fmt.Errorf("error encoding fixture: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		file.Close()
+		return fmt.Errorf("error compressing fixture: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("error writing fixture [%s]: %w", tempPath, err)
+	}
+	if err := os.Rename(tempPath, path); err != nil {
+		return fmt.Errorf("error moving fixture to [%s]: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,539 @@
+package fixture
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/fatih/color"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/rocket-pool/rocketpool-go/contracts"
+	"github.com/rocket-pool/rocketpool-go/rewards"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	rpstate "github.com/rocket-pool/rocketpool-go/utils/state"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/services/simulator"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+var update = flag.Bool("update", false, "rebuild the fixture and the expected files in testdata from the synthetic network on the simulated clients")
+
+// The synthetic interval the fixture is recorded from. Each EL block is produced in the slot with the same number.
+const (
+	testNetwork        cfgtypes.Network = cfgtypes.Network_Devnet
+	testIndex          uint64           = 3
+	testSecondsPerSlot uint64           = 12
+	testSlotsPerEpoch  uint64           = 4
+
+	// The last slot of the previous interval, and the EL block its snapshot event was emitted in
+	previousIntervalSlot       uint64 = 15
+	previousIntervalEventBlock uint64 = 16
+
+	// The last slot of the interval, and the last slot of the chain, which covers the epoch after the interval
+	intervalEndSlot uint64 = 47
+	lastSlot        uint64 = 51
+
+	// The number of validators on the Beacon chain; 10 and up belong to minipools
+	validatorCount uint64 = 15
+)
+
+var (
+	testGenesisTime = time.Unix(1700000000, 0).UTC()
+
+	// Slots without a block
+	missingSlots = map[uint64]bool{16: true, 30: true}
+
+	rewardsPoolAddress   = common.HexToAddress("0x000000000000000000000000000000000000a001")
+	smoothingPoolAddress = common.HexToAddress("0x000000000000000000000000000000000000a002")
+)
+
+// The parts of rocketRewardsPool the generator uses
+const rewardsPoolAbi string = `[
+	{"type": "function", "name": "getClaimIntervalExecutionBlock", "stateMutability": "view", "inputs": [{"name": "_interval", "type": "uint256"}], "outputs": [{"name": "", "type": "uint256"}]},
+	{"type": "event", "name": "RewardSnapshot", "anonymous": false, "inputs": [
+		{"indexed": true, "name": "rewardIndex", "type": "uint256"},
+		{"indexed": false, "name": "submission", "type": "tuple", "components": [
+			{"name": "rewardIndex", "type": "uint256"},
+			{"name": "executionBlock", "type": "uint256"},
+			{"name": "consensusBlock", "type": "uint256"},
+			{"name": "merkleRoot", "type": "bytes32"},
+			{"name": "merkleTreeCID", "type": "string"},
+			{"name": "intervalsPassed", "type": "uint256"},
+			{"name": "treasuryRPL", "type": "uint256"},
+			{"name": "trustedNodeRPL", "type": "uint256[]"},
+			{"name": "nodeRPL", "type": "uint256[]"},
+			{"name": "nodeETH", "type": "uint256[]"},
+			{"name": "userETH", "type": "uint256"}
+		]},
+		{"indexed": false, "name": "intervalStartTime", "type": "uint256"},
+		{"indexed": false, "name": "intervalEndTime", "type": "uint256"},
+		{"indexed": false, "name": "time", "type": "uint256"}
+	]}
+]`
+
+func TestRegeneratedTreeMatchesFixture(t *testing.T) {
+	if *update {
+		updateTestdata(t)
+	}
+
+	fixture, err := Load(getTestdataPath("rewards-%s-%d.fixture.gz"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger := log.NewColorLogger(color.FgHiCyan)
+	files, err := fixture.Regenerate(&logger, 0)
+	if err != nil {
+		t.Fatalf("error regenerating the tree: %s", err.Error())
+	}
+	for _, mismatch := range fixture.Compare(files) {
+		t.Error(mismatch)
+	}
+	compareToTestdata(t, config.RewardsTreeFilenameFormat, files.RewardsFile)
+	compareToTestdata(t, config.MinipoolPerformanceFilenameFormat, files.MinipoolPerformanceFile)
+}
+
+func TestReplayFailsOnUnrecordedRequest(t *testing.T) {
+	fixture, err := Load(getTestdataPath("rewards-%s-%d.fixture.gz"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Drop the committees so the generator asks for data the fixture doesn't have
+	exchanges := []Exchange{}
+	for _, exchange := range fixture.Exchanges {
+		if !strings.Contains(exchange.Request, "/committees") {
+			exchanges = append(exchanges, exchange)
+		}
+	}
+	fixture.Exchanges = exchanges
+
+	logger := log.NewColorLogger(color.FgHiCyan)
+	_, err = fixture.Regenerate(&logger, 0)
+	if err == nil {
+		t.Fatal("expected regenerating the tree to fail without the committees")
+	}
+	if !strings.Contains(err.Error(), "the fixture doesn't have a response") {
+		t.Fatalf("expected an error about the missing response, got: %s", err.Error())
+	}
+}
+
+// Get the path of a file in testdata for the test interval
+func getTestdataPath(filenameFormat string) string {
+	return filepath.Join("testdata", fmt.Sprintf(filenameFormat, string(testNetwork), testIndex))
+}
+
+// Check that a regenerated file matches the expected one in testdata byte-for-byte
+func compareToTestdata(t *testing.T, filenameFormat string, actual []byte) {
+	t.Helper()
+	path := getTestdataPath(filenameFormat)
+	expected, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(actual, expected) {
+		t.Errorf("the regenerated file doesn't match %s; run the tests with -update if the change is intended\nexpected: %s\nactual:   %s", path, expected, actual)
+	}
+}
+
+// Record the fixture from the synthetic network on the simulated clients and save it with the files it produced
+func updateTestdata(t *testing.T) {
+	t.Helper()
+
+	cfg := getConfig(testNetwork)
+	chainID := uint64(cfg.Smartnode.GetChainID())
+	ec := simulator.NewExecutionServer("127.0.0.1:0", chainID, testGenesisTime)
+	bc := simulator.NewBeaconServer("127.0.0.1:0", chainID, common.Address{}, testGenesisTime)
+	if err := ec.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer ec.Stop()
+	if err := bc.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer bc.Stop()
+
+	// Build the chains
+	rewardsPool, err := abi.JSON(strings.NewReader(rewardsPoolAbi))
+	if err != nil {
+		t.Fatal(err)
+	}
+	scriptContracts(t, ec, common.HexToAddress(cfg.Smartnode.GetStorageAddress()), &rewardsPool)
+	ec.Mine(int(previousIntervalEventBlock))
+	ec.AddLog(getPreviousIntervalEvent(t, &rewardsPool))
+	ec.Mine(int(lastSlot - previousIntervalEventBlock))
+	client, err := ethclient.Dial(ec.Url())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	scriptBeaconChain(t, bc, client)
+
+	// Record the interval
+	elSnapshotHeader, err := client.HeaderByNumber(context.Background(), big.NewInt(int64(intervalEndSlot)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	interval := Interval{
+		Index:            testIndex,
+		StartTime:        getSlotTime(previousIntervalSlot + 1),
+		EndTime:          getSlotTime(intervalEndSlot + 1),
+		ConsensusBlock:   intervalEndSlot,
+		ElSnapshotHeader: elSnapshotHeader,
+		IntervalsPassed:  1,
+	}
+	logger := log.NewColorLogger(color.FgHiCyan)
+	fixture, files, err := Record(&logger, testNetwork, interval, getNetworkState(), 0, ec.Url(), bc.Url())
+	if err != nil {
+		t.Fatalf("error recording the fixture: %s", err.Error())
+	}
+
+	// Save everything
+	if err := os.MkdirAll("testdata", 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := fixture.Save(getTestdataPath("rewards-%s-%d.fixture.gz")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(getTestdataPath(config.RewardsTreeFilenameFormat), files.RewardsFile, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(getTestdataPath(config.MinipoolPerformanceFilenameFormat), files.MinipoolPerformanceFile, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// Answer the RocketStorage and rocketRewardsPool calls the generator makes
+func scriptContracts(t *testing.T, ec *simulator.ExecutionServer, storageAddress common.Address, rewardsPool *abi.ABI) {
+	t.Helper()
+
+	storage, err := abi.JSON(strings.NewReader(contracts.RocketStorageABI))
+	if err != nil {
+		t.Fatal(err)
+	}
+	encodedRewardsPoolAbi, err := rocketpool.EncodeAbiStr(rewardsPoolAbi)
+	if err != nil {
+		t.Fatal(err)
+	}
+	addressKey := crypto.Keccak256Hash([]byte("contract.address"), []byte("rocketRewardsPool"))
+	abiKey := crypto.Keccak256Hash([]byte("contract.abi"), []byte("rocketRewardsPool"))
+
+	getAddress := storage.Methods["getAddress"]
+	ec.HandleCall(storageAddress, getAddress.ID, func(data []byte) ([]byte, error) {
+		if common.BytesToHash(data[4:]) != addressKey {
+			return nil, fmt.Errorf("unknown address key")
+		}
+		return getAddress.Outputs.Pack(rewardsPoolAddress)
+	})
+	getString := storage.Methods["getString"]
+	ec.HandleCall(storageAddress, getString.ID, func(data []byte) ([]byte, error) {
+		if common.BytesToHash(data[4:]) != abiKey {
+			return nil, fmt.Errorf("unknown string key")
+		}
+		return getString.Outputs.Pack(encodedRewardsPoolAbi)
+	})
+
+	getBlock := rewardsPool.Methods["getClaimIntervalExecutionBlock"]
+	ec.HandleCall(rewardsPoolAddress, getBlock.ID, func(data []byte) ([]byte, error) {
+		args, err := getBlock.Inputs.Unpack(data[4:])
+		if err != nil {
+			return nil, err
+		}
+		if args[0].(*big.Int).Uint64() != testIndex-1 {
+			return getBlock.Outputs.Pack(big.NewInt(0))
+		}
+		return getBlock.Outputs.Pack(big.NewInt(int64(previousIntervalEventBlock)))
+	})
+}
+
+// Get the snapshot event of the previous interval
+func getPreviousIntervalEvent(t *testing.T, rewardsPool *abi.ABI) types.Log {
+	t.Helper()
+
+	event := rewardsPool.Events["RewardSnapshot"]
+	submission := rewards.RewardSubmission{
+		RewardIndex:     big.NewInt(int64(testIndex - 1)),
+		ExecutionBlock:  big.NewInt(int64(previousIntervalSlot)),
+		ConsensusBlock:  big.NewInt(int64(previousIntervalSlot)),
+		MerkleRoot:      crypto.Keccak256Hash([]byte("previous interval")),
+		MerkleTreeCID:   "",
+		IntervalsPassed: big.NewInt(1),
+		TreasuryRPL:     big.NewInt(0),
+		TrustedNodeRPL:  []*big.Int{big.NewInt(0)},
+		NodeRPL:         []*big.Int{big.NewInt(0)},
+		NodeETH:         []*big.Int{big.NewInt(0)},
+		UserETH:         big.NewInt(0),
+	}
+	intervalEndTime := getSlotTime(previousIntervalSlot + 1)
+	data, err := event.Inputs.NonIndexed().Pack(
+		submission,
+		big.NewInt(intervalEndTime.Add(-time.Duration(32*testSecondsPerSlot)*time.Second).Unix()),
+		big.NewInt(intervalEndTime.Unix()),
+		big.NewInt(getSlotTime(previousIntervalEventBlock).Unix()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return types.Log{
+		Address: rewardsPoolAddress,
+		Topics:  []common.Hash{event.ID, common.BigToHash(submission.RewardIndex)},
+		Data:    data,
+	}
+}
+
+// Add the blocks and committees of the synthetic Beacon chain
+func scriptBeaconChain(t *testing.T, bc *simulator.BeaconServer, client *ethclient.Client) {
+	t.Helper()
+
+	for slot := previousIntervalSlot; slot <= lastSlot; slot++ {
+		if missingSlots[slot] {
+			continue
+		}
+		header, err := client.HeaderByNumber(context.Background(), big.NewInt(int64(slot)))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Include the attestations for every slot since the previous block
+		attestations := []map[string]interface{}{}
+		for attestedSlot := slot - 1; ; attestedSlot-- {
+			for index := uint64(0); index < 2; index++ {
+				attestations = append(attestations, getAttestation(attestedSlot, index))
+			}
+			if !missingSlots[attestedSlot] {
+				break
+			}
+		}
+
+		block, err := json.Marshal(map[string]interface{}{
+			"message": map[string]interface{}{
+				"slot":           strconv.FormatUint(slot, 10),
+				"proposer_index": "0",
+				"body": map[string]interface{}{
+					"eth1_data": map[string]string{
+						"deposit_root":  common.Hash{}.Hex(),
+						"deposit_count": "0",
+						"block_hash":    common.Hash{}.Hex(),
+					},
+					"attestations": attestations,
+					"execution_payload": map[string]string{
+						"fee_recipient": common.Address{}.Hex(),
+						"block_number":  strconv.FormatUint(slot, 10),
+						"block_hash":    header.Hash().Hex(),
+					},
+				},
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		bc.SetBlock(strconv.FormatUint(slot, 10), block)
+	}
+
+	bc.HandlePath(http.MethodGet, "/eth/v1/beacon/states/head/committees", func(w http.ResponseWriter, r *http.Request) {
+		epoch, err := strconv.ParseUint(r.URL.Query().Get("epoch"), 10, 64)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		committees := []map[string]interface{}{}
+		for slot := epoch * testSlotsPerEpoch; slot < (epoch+1)*testSlotsPerEpoch; slot++ {
+			for index := uint64(0); index < 2; index++ {
+				validators := []string{}
+				for _, validator := range getCommittee(slot, index) {
+					validators = append(validators, strconv.FormatUint(validator, 10))
+				}
+				committees = append(committees, map[string]interface{}{
+					"index":      strconv.FormatUint(index, 10),
+					"slot":       strconv.FormatUint(slot, 10),
+					"validators": validators,
+				})
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": committees})
+	})
+}
+
+// Get the validators in a committee. Each validator attests once per epoch, in the slot and committee its index maps to.
+func getCommittee(slot uint64, index uint64) []uint64 {
+	validators := []uint64{}
+	for validator := uint64(0); validator < validatorCount; validator++ {
+		if validator%testSlotsPerEpoch == slot%testSlotsPerEpoch && (validator/testSlotsPerEpoch)%2 == index {
+			validators = append(validators, validator)
+		}
+	}
+	return validators
+}
+
+// Get the aggregate attestation of a committee; validator 11 misses every attestation in epoch 6 and validator 12 misses slot 36
+func getAttestation(slot uint64, index uint64) map[string]interface{} {
+	committee := getCommittee(slot, index)
+	bits := bitfield.NewBitlist(uint64(len(committee)))
+	for position, validator := range committee {
+		missed := (validator == 11 && slot/testSlotsPerEpoch == 6) || (validator == 12 && slot == 36)
+		bits.SetBitAt(uint64(position), !missed)
+	}
+	return map[string]interface{}{
+		"aggregation_bits": hexutil.Encode(bits),
+		"data": map[string]string{
+			"slot":  strconv.FormatUint(slot, 10),
+			"index": strconv.FormatUint(index, 10),
+		},
+	}
+}
+
+// Get the time of a slot
+func getSlotTime(slot uint64) time.Time {
+	return testGenesisTime.Add(time.Duration(slot*testSecondsPerSlot) * time.Second)
+}
+
+// Get the network state at the end of the synthetic interval:
+//   - node 1 is in the Oracle DAO and has two minipools in the Smoothing Pool, one of which reduced its bond during the interval
+//   - node 2 registered, joined the Smoothing Pool and started its minipool during the interval
+//   - node 3 joined the Oracle DAO during the interval, left the Smoothing Pool before it and is over the maximum RPL stake
+//   - node 4 is in the Smoothing Pool but its minipool has been penalized too often, and it's under the minimum RPL stake
+func getNetworkState() *state.NetworkState {
+	longAgo := testGenesisTime.Add(-30 * 24 * time.Hour)
+	nodeDetails := []rpstate.NativeNodeDetails{
+		getNodeDetails(1, longAgo, true, testGenesisTime.Add(-10*24*time.Hour), 1000),
+		getNodeDetails(2, getSlotTime(20), true, getSlotTime(24), 500),
+		getNodeDetails(3, longAgo, false, testGenesisTime.Add(-5*24*time.Hour), 2000),
+		getNodeDetails(4, longAgo, true, testGenesisTime.Add(-10*24*time.Hour), 100),
+	}
+
+	reducedBond := getMinipoolDetails(11, 1, longAgo, 8, 0.14)
+	reducedBond.LastBondReductionTime = big.NewInt(getSlotTime(32).Unix())
+	reducedBond.LastBondReductionPrevValue = eth.EthToWei(16)
+	reducedBond.LastBondReductionPrevNodeFee = eth.EthToWei(0.15)
+	penalized := getMinipoolDetails(14, 4, longAgo, 8, 0.14)
+	penalized.PenaltyCount = big.NewInt(3)
+	minipoolDetails := []rpstate.NativeMinipoolDetails{
+		getMinipoolDetails(10, 1, longAgo, 8, 0.14),
+		reducedBond,
+		getMinipoolDetails(12, 2, getSlotTime(28), 16, 0.15),
+		getMinipoolDetails(13, 3, longAgo, 8, 0.14),
+		penalized,
+	}
+
+	validatorDetails := map[rptypes.ValidatorPubkey]beacon.ValidatorStatus{}
+	for _, details := range minipoolDetails {
+		activationEpoch := uint64(1)
+		if details.StatusTime.Int64() > testGenesisTime.Unix() {
+			activationEpoch = uint64(details.StatusTime.Int64()-testGenesisTime.Unix()) / (testSecondsPerSlot * testSlotsPerEpoch)
+		}
+		validatorDetails[details.Pubkey] = beacon.ValidatorStatus{
+			Pubkey:                     details.Pubkey,
+			Index:                      strconv.FormatUint(getValidatorIndex(details.Pubkey), 10),
+			Balance:                    32e9,
+			Status:                     beacon.ValidatorState_ActiveOngoing,
+			EffectiveBalance:           32e9,
+			ActivationEligibilityEpoch: 0,
+			ActivationEpoch:            activationEpoch,
+			ExitEpoch:                  rprewards.FarEpoch,
+			WithdrawableEpoch:          rprewards.FarEpoch,
+			Exists:                     true,
+		}
+	}
+
+	oracleDaoMemberDetails := []rpstate.OracleDaoMemberDetails{
+		{Address: getTestAddress(1), Exists: true, ID: "member-1", Url: "https://member-1.example", JoinedTime: longAgo, RPLBondAmount: eth.EthToWei(1750)},
+		{Address: getTestAddress(3), Exists: true, ID: "member-3", Url: "https://member-3.example", JoinedTime: getSlotTime(32), RPLBondAmount: eth.EthToWei(1750)},
+	}
+
+	networkDetails := &rpstate.NetworkDetails{
+		RplPrice:                          eth.EthToWei(0.01),
+		MinCollateralFraction:             eth.EthToWei(0.1),
+		MaxCollateralFraction:             eth.EthToWei(1.5),
+		IntervalDuration:                  getSlotTime(intervalEndSlot + 1).Sub(getSlotTime(previousIntervalSlot + 1)),
+		IntervalStart:                     getSlotTime(intervalEndSlot + 1),
+		NodeOperatorRewardsPercent:        eth.EthToWei(0.7),
+		TrustedNodeOperatorRewardsPercent: eth.EthToWei(0.05),
+		ProtocolDaoRewardsPercent:         eth.EthToWei(0.25),
+		PendingRPLRewards:                 eth.EthToWei(10000),
+		RewardIndex:                       testIndex,
+		SmoothingPoolAddress:              smoothingPoolAddress,
+		SmoothingPoolBalance:              eth.EthToWei(5),
+	}
+
+	beaconConfig := beacon.Eth2Config{
+		GenesisForkVersion:           []byte{0, 0, 0, 0},
+		GenesisValidatorsRoot:        common.Hash{}.Bytes(),
+		GenesisEpoch:                 0,
+		GenesisTime:                  uint64(testGenesisTime.Unix()),
+		SecondsPerSlot:               testSecondsPerSlot,
+		SlotsPerEpoch:                testSlotsPerEpoch,
+		SecondsPerEpoch:              testSecondsPerSlot * testSlotsPerEpoch,
+		EpochsPerSyncCommitteePeriod: 256,
+	}
+
+	return state.NewNetworkStateFromDetails(intervalEndSlot, intervalEndSlot, beaconConfig, networkDetails, nodeDetails, minipoolDetails, validatorDetails, oracleDaoMemberDetails)
+}
+
+// Get the details of a node on reward network 0
+func getNodeDetails(number int64, registrationTime time.Time, isOptedIn bool, optInChangeTime time.Time, rplStake float64) rpstate.NativeNodeDetails {
+	return rpstate.NativeNodeDetails{
+		Exists:                           true,
+		NodeAddress:                      getTestAddress(number),
+		RegistrationTime:                 big.NewInt(registrationTime.Unix()),
+		RewardNetwork:                    big.NewInt(0),
+		RplStake:                         eth.EthToWei(rplStake),
+		SmoothingPoolRegistrationState:   isOptedIn,
+		SmoothingPoolRegistrationChanged: big.NewInt(optInChangeTime.Unix()),
+	}
+}
+
+// Get the details of a staking minipool, with the validator it belongs to picked by index
+func getMinipoolDetails(validatorIndex uint64, nodeNumber int64, stakingTime time.Time, bond float64, fee float64) rpstate.NativeMinipoolDetails {
+	return rpstate.NativeMinipoolDetails{
+		Exists:                       true,
+		MinipoolAddress:              getTestAddress(int64(0x1000 + validatorIndex)),
+		Pubkey:                       rptypes.BytesToValidatorPubkey(bytes.Repeat([]byte{byte(validatorIndex)}, rptypes.ValidatorPubkeyLength)),
+		StatusRaw:                    uint8(rptypes.Staking),
+		Status:                       rptypes.Staking,
+		StatusTime:                   big.NewInt(stakingTime.Unix()),
+		DepositTypeRaw:               uint8(rptypes.Variable),
+		DepositType:                  rptypes.Variable,
+		NodeFee:                      eth.EthToWei(fee),
+		NodeDepositBalance:           eth.EthToWei(bond),
+		UserDepositBalance:           eth.EthToWei(32 - bond),
+		PenaltyCount:                 big.NewInt(0),
+		NodeAddress:                  getTestAddress(nodeNumber),
+		Version:                      3,
+		LastBondReductionTime:        big.NewInt(0),
+		LastBondReductionPrevValue:   big.NewInt(0),
+		LastBondReductionPrevNodeFee: big.NewInt(0),
+	}
+}
+
+// Get the index of the validator a synthetic pubkey was made for
+func getValidatorIndex(pubkey rptypes.ValidatorPubkey) uint64 {
+	return uint64(pubkey[0])
+}
+
+// Get a synthetic address
+func getTestAddress(number int64) common.Address {
+	return common.BigToAddress(big.NewInt(number))
+}
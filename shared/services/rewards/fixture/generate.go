@@ -0,0 +1,218 @@
+package fixture
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/beacon/client"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// The URLs the clients are given during a replay; nothing is ever sent to them
+const (
+	replayEcUrl string = "http://fixture-ec"
+	replayBcUrl string = "http://fixture-bc"
+)
+
+// The files generated for an interval
+type Files struct {
+	RulesetVersion          uint64
+	MerkleRoot              string
+	RewardsFile             []byte
+	MinipoolPerformanceFile []byte
+}
+
+// The Execution and Beacon clients a tree is generated with
+type clients struct {
+	rpcClient *rpc.Client
+	rp        *rocketpool.RocketPool
+	bc        beacon.Client
+}
+
+// Get the inputs for an interval from its snapshot event, along with the network state at its end
+func GetInterval(logger *log.ColorLogger, network cfgtypes.Network, index uint64, ecUrl string, bcUrl string) (Interval, *state.NetworkState, error) {
+
+	cfg := getConfig(network)
+	c, err := newClients(cfg, ecUrl, http.DefaultClient, bcUrl, http.DefaultClient)
+	if err != nil {
+		return Interval{}, nil, err
+	}
+	defer c.rpcClient.Close()
+
+	rewardsEvent, err := rprewards.GetRewardSnapshotEvent(c.rp, cfg, index, nil)
+	if err != nil {
+		return Interval{}, nil, fmt.Errorf("error getting event for interval %d: %w", index, err)
+	}
+	elBlockHeader, err := c.rp.Client.HeaderByNumber(context.Background(), rewardsEvent.ExecutionBlock)
+	if err != nil {
+		return Interval{}, nil, fmt.Errorf("error getting execution block %s: %w", rewardsEvent.ExecutionBlock.String(), err)
+	}
+	m, err := state.NewNetworkStateManager(c.rp, cfg, c.rp.Client, c.bc, logger)
+	if err != nil {
+		return Interval{}, nil, fmt.Errorf("error creating network state manager: %w", err)
+	}
+	networkState, err := m.GetStateForSlot(rewardsEvent.ConsensusBlock.Uint64())
+	if err != nil {
+		return Interval{}, nil, fmt.Errorf("error getting state for beacon slot %d: %w", rewardsEvent.ConsensusBlock.Uint64(), err)
+	}
+
+	interval := Interval{
+		Index:            index,
+		StartTime:        rewardsEvent.IntervalStartTime,
+		EndTime:          rewardsEvent.IntervalEndTime,
+		ConsensusBlock:   rewardsEvent.ConsensusBlock.Uint64(),
+		ElSnapshotHeader: elBlockHeader,
+		IntervalsPassed:  rewardsEvent.IntervalsPassed.Uint64(),
+	}
+	return interval, networkState, nil
+
+}
+
+// Generate the tree for an interval from its network state against live clients, and record every response the generator
+// consumed into a fixture. Leave the ruleset at 0 to use the interval's own.
+func Record(logger *log.ColorLogger, network cfgtypes.Network, interval Interval, networkState *state.NetworkState, ruleset uint64, ecUrl string, bcUrl string) (*Fixture, *Files, error) {
+
+	recorder := NewRecorder()
+	ecHttpClient := &http.Client{Transport: recorder.Transport(Client_Execution, http.DefaultTransport)}
+	bcHttpClient := &http.Client{Transport: recorder.Transport(Client_Beacon, http.DefaultTransport)}
+	files, err := generate(logger, network, interval, networkState, ruleset, ecUrl, ecHttpClient, bcUrl, bcHttpClient)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fixture := &Fixture{
+		Version:                     FixtureVersion,
+		Network:                     network,
+		Interval:                    interval,
+		State:                       NewState(networkState),
+		RulesetVersion:              files.RulesetVersion,
+		MerkleRoot:                  files.MerkleRoot,
+		RewardsFileHash:             HashFile(files.RewardsFile),
+		MinipoolPerformanceFileHash: HashFile(files.MinipoolPerformanceFile),
+		Exchanges:                   recorder.GetExchanges(),
+	}
+	return fixture, files, nil
+
+}
+
+// Regenerate the tree purely from the fixture, without any clients. Leave the ruleset at 0 to use the one the fixture was
+// recorded with.
+func (f *Fixture) Regenerate(logger *log.ColorLogger, ruleset uint64) (*Files, error) {
+
+	if ruleset == 0 {
+		ruleset = f.RulesetVersion
+	}
+	networkState, err := f.State.GetNetworkState()
+	if err != nil {
+		return nil, err
+	}
+
+	replayer := NewReplayer(f)
+	ecHttpClient := &http.Client{Transport: replayer.Transport(Client_Execution)}
+	bcHttpClient := &http.Client{Transport: replayer.Transport(Client_Beacon)}
+	return generate(logger, f.Network, f.Interval, networkState, ruleset, replayEcUrl, ecHttpClient, replayBcUrl, bcHttpClient)
+
+}
+
+// Compare regenerated files to the ones the fixture was recorded with, returning a description of each difference
+func (f *Fixture) Compare(files *Files) []string {
+	mismatches := []string{}
+	if files.MerkleRoot != f.MerkleRoot {
+		mismatches = append(mismatches, fmt.Sprintf("Merkle root %s doesn't match the recorded %s", files.MerkleRoot, f.MerkleRoot))
+	}
+	if hash := HashFile(files.RewardsFile); hash != f.RewardsFileHash {
+		mismatches = append(mismatches, fmt.Sprintf("rewards file hash %s doesn't match the recorded %s", hash, f.RewardsFileHash))
+	}
+	if hash := HashFile(files.MinipoolPerformanceFile); hash != f.MinipoolPerformanceFileHash {
+		mismatches = append(mismatches, fmt.Sprintf("minipool performance file hash %s doesn't match the recorded %s", hash, f.MinipoolPerformanceFileHash))
+	}
+	return mismatches
+}
+
+// Get the SHA-256 hash of a generated file
+func HashFile(bytes []byte) string {
+	hash := sha256.Sum256(bytes)
+	return hex.EncodeToString(hash[:])
+}
+
+// Generate the rewards tree for an interval with clients that send their requests through the provided HTTP clients
+func generate(logger *log.ColorLogger, network cfgtypes.Network, interval Interval, networkState *state.NetworkState, ruleset uint64, ecUrl string, ecHttpClient *http.Client, bcUrl string, bcHttpClient *http.Client) (*Files, error) {
+
+	cfg := getConfig(network)
+	c, err := newClients(cfg, ecUrl, ecHttpClient, bcUrl, bcHttpClient)
+	if err != nil {
+		return nil, err
+	}
+	defer c.rpcClient.Close()
+
+	// Generate the tree
+	generationPrefix := fmt.Sprintf("[Interval %d Tree]", interval.Index)
+	treegen, err := rprewards.NewTreeGenerator(logger, generationPrefix, c.rp, cfg, c.bc, interval.Index, interval.StartTime, interval.EndTime, interval.ConsensusBlock, interval.ElSnapshotHeader, interval.IntervalsPassed, networkState, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating Merkle tree generator: %w", err)
+	}
+	if ruleset == 0 {
+		ruleset = treegen.GetGeneratorRulesetVersion()
+	}
+	rewardsFile, err := treegen.GenerateTreeWithRuleset(ruleset)
+	if err != nil {
+		return nil, fmt.Errorf("error generating Merkle tree: %w", err)
+	}
+
+	// Serialize the files the same way the watchtower does
+	rewardsFile.SetMinipoolPerformanceFileCID("---")
+	minipoolPerformanceBytes, err := rewardsFile.GetMinipoolPerformanceFile().Serialize()
+	if err != nil {
+		return nil, fmt.Errorf("error serializing minipool performance file: %w", err)
+	}
+	rewardsBytes, err := rewardsFile.Serialize()
+	if err != nil {
+		return nil, fmt.Errorf("error serializing rewards file: %w", err)
+	}
+
+	return &Files{
+		RulesetVersion:          ruleset,
+		MerkleRoot:              common.BytesToHash(rewardsFile.GetHeader().MerkleTree.Root()).Hex(),
+		RewardsFile:             rewardsBytes,
+		MinipoolPerformanceFile: minipoolPerformanceBytes,
+	}, nil
+
+}
+
+// Get the config for a network
+func getConfig(network cfgtypes.Network) *config.RocketPoolConfig {
+	cfg := config.NewRocketPoolConfig("", true)
+	cfg.ChangeNetwork(network)
+	return cfg
+}
+
+// Create the clients, sending their requests through the provided HTTP clients
+func newClients(cfg *config.RocketPoolConfig, ecUrl string, ecHttpClient *http.Client, bcUrl string, bcHttpClient *http.Client) (*clients, error) {
+	rpcClient, err := rpc.DialHTTPWithClient(ecUrl, ecHttpClient)
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to Execution client at [%s]: %w", ecUrl, err)
+	}
+	rp, err := rocketpool.NewRocketPool(ethclient.NewClient(rpcClient), common.HexToAddress(cfg.Smartnode.GetStorageAddress()))
+	if err != nil {
+		rpcClient.Close()
+		return nil, fmt.Errorf("error creating Rocket Pool binding: %w", err)
+	}
+	return &clients{
+		rpcClient: rpcClient,
+		rp:        rp,
+		bc:        client.NewStandardHttpClientWithHttpClient(bcUrl, bcHttpClient),
+	}, nil
+}
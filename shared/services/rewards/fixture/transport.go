@@ -0,0 +1,286 @@
+package fixture
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+)
+
+// The longest part of a request to include in errors about it
+const maxRequestPreview int = 256
+
+// Records the requests sent to the clients and the responses they gave, to build a fixture from
+type Recorder struct {
+	lock      sync.Mutex
+	exchanges map[string]Exchange
+}
+
+// Create a new recorder
+func NewRecorder() *Recorder {
+	return &Recorder{
+		exchanges: map[string]Exchange{},
+	}
+}
+
+// Get an HTTP transport that sends requests to the client with the base transport and records them
+func (r *Recorder) Transport(client Client, base http.RoundTripper) http.RoundTripper {
+	return &recordingTransport{
+		recorder: r,
+		client:   client,
+		base:     base,
+	}
+}
+
+// Get the recorded exchanges. Repeated requests are only kept once, since the generator only asks for data at fixed
+// blocks and slots.
+func (r *Recorder) GetExchanges() []Exchange {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	exchanges := make([]Exchange, 0, len(r.exchanges))
+	for _, exchange := range r.exchanges {
+		exchanges = append(exchanges, exchange)
+	}
+	return exchanges
+}
+
+type recordingTransport struct {
+	recorder *Recorder
+	client   Client
+	base     http.RoundTripper
+}
+
+func (t *recordingTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	requestBody, err := readRequestBody(request)
+	if err != nil {
+		return nil, err
+	}
+	key, ids, err := normalizeRequest(t.client, request, requestBody)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := t.base.RoundTrip(request)
+	if err != nil {
+		return nil, err
+	}
+	responseBody, err := io.ReadAll(response.Body)
+	response.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("error reading response to [%s]: %w", preview(key), err)
+	}
+	response.Body = io.NopCloser(bytes.NewReader(responseBody))
+
+	// Only keep successful responses; the generator retries the others
+	if response.StatusCode < http.StatusInternalServerError && response.StatusCode != http.StatusTooManyRequests {
+		recorded := responseBody
+		if t.client == Client_Execution {
+			recorded = renumberResponse(responseBody, ids)
+		}
+		t.recorder.lock.Lock()
+		t.recorder.exchanges[string(t.client)+" "+key] = Exchange{
+			Client:      t.client,
+			Request:     key,
+			Status:      response.StatusCode,
+			ContentType: response.Header.Get("Content-Type"),
+			Response:    recorded,
+		}
+		t.recorder.lock.Unlock()
+	}
+	return response, nil
+}
+
+// Answers the requests sent to the clients with the responses recorded in a fixture, so a rewards tree can be regenerated
+// without any clients
+type Replayer struct {
+	exchanges map[string]Exchange
+}
+
+// Create a replayer for the provided fixture
+func NewReplayer(fixture *Fixture) *Replayer {
+	exchanges := make(map[string]Exchange, len(fixture.Exchanges))
+	for _, exchange := range fixture.Exchanges {
+		exchanges[string(exchange.Client)+" "+exchange.Request] = exchange
+	}
+	return &Replayer{
+		exchanges: exchanges,
+	}
+}
+
+// Get an HTTP transport that answers requests to the client from the fixture
+func (r *Replayer) Transport(client Client) http.RoundTripper {
+	return &replayingTransport{
+		replayer: r,
+		client:   client,
+	}
+}
+
+type replayingTransport struct {
+	replayer *Replayer
+	client   Client
+}
+
+func (t *replayingTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	requestBody, err := readRequestBody(request)
+	if err != nil {
+		return nil, err
+	}
+	key, ids, err := normalizeRequest(t.client, request, requestBody)
+	if err != nil {
+		return nil, err
+	}
+
+	exchange, exists := t.replayer.exchanges[string(t.client)+" "+key]
+	if !exists {
+		return nil, fmt.Errorf("the fixture doesn't have a response for the %s request [%s]; the generator asked for data it didn't ask for when the fixture was recorded", t.client, preview(key))
+	}
+	responseBody := exchange.Response
+	if t.client == Client_Execution {
+		responseBody, err = restoreResponse(responseBody, ids)
+		if err != nil {
+			return nil, fmt.Errorf("error restoring the response to [%s]: %w", preview(key), err)
+		}
+	}
+
+	header := http.Header{}
+	if exchange.ContentType != "" {
+		header.Set("Content-Type", exchange.ContentType)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", exchange.Status, http.StatusText(exchange.Status)),
+		StatusCode:    exchange.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(responseBody)),
+		ContentLength: int64(len(responseBody)),
+		Request:       request,
+	}, nil
+}
+
+// Read a request's body and put it back so it can still be sent
+func readRequestBody(request *http.Request) ([]byte, error) {
+	if request.Body == nil {
+		return nil, nil
+	}
+	body, err := io.ReadAll(request.Body)
+	request.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("error reading request body: %w", err)
+	}
+	request.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+// Get the key a request is recorded under. JSON-RPC requests have their IDs replaced by their position in the batch, since
+// the IDs count up with every request the client sends; the original IDs are returned so the response can be matched up.
+func normalizeRequest(client Client, request *http.Request, body []byte) (string, []json.RawMessage, error) {
+	if client == Client_Beacon {
+		key := request.Method + " " + request.URL.RequestURI()
+		if len(body) > 0 {
+			key += " " + string(body)
+		}
+		return key, nil, nil
+	}
+
+	messages, batch, err := parseRpcMessages(body)
+	if err != nil {
+		return "", nil, fmt.Errorf("error parsing JSON-RPC request: %w", err)
+	}
+	ids := make([]json.RawMessage, len(messages))
+	for i, message := range messages {
+		ids[i] = message["id"]
+		message["id"] = json.RawMessage(strconv.Itoa(i))
+	}
+	key, err := marshalRpcMessages(messages, batch)
+	if err != nil {
+		return "", nil, fmt.Errorf("error normalizing JSON-RPC request: %w", err)
+	}
+	return string(key), ids, nil
+}
+
+// Replace the IDs in a JSON-RPC response with the position of the request they answer. Responses that can't be parsed,
+// such as plain-text errors, are kept as they are.
+func renumberResponse(body []byte, ids []json.RawMessage) []byte {
+	messages, batch, err := parseRpcMessages(body)
+	if err != nil {
+		return body
+	}
+	for _, message := range messages {
+		for i, id := range ids {
+			if bytes.Equal(compactJson(message["id"]), compactJson(id)) {
+				message["id"] = json.RawMessage(strconv.Itoa(i))
+				break
+			}
+		}
+	}
+	renumbered, err := marshalRpcMessages(messages, batch)
+	if err != nil {
+		return body
+	}
+	return renumbered
+}
+
+// Put the IDs of the current request back into a recorded JSON-RPC response
+func restoreResponse(body []byte, ids []json.RawMessage) ([]byte, error) {
+	messages, batch, err := parseRpcMessages(body)
+	if err != nil {
+		return body, nil
+	}
+	for _, message := range messages {
+		index, err := strconv.Atoi(string(message["id"]))
+		if err != nil {
+			continue
+		}
+		if index < 0 || index >= len(ids) {
+			return nil, fmt.Errorf("response ID %d is outside of the request's %d messages", index, len(ids))
+		}
+		message["id"] = ids[index]
+	}
+	return marshalRpcMessages(messages, batch)
+}
+
+// Parse a single JSON-RPC message or a batch of them
+func parseRpcMessages(body []byte) ([]map[string]json.RawMessage, bool, error) {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		var messages []map[string]json.RawMessage
+		if err := json.Unmarshal(trimmed, &messages); err != nil {
+			return nil, false, err
+		}
+		return messages, true, nil
+	}
+	var message map[string]json.RawMessage
+	if err := json.Unmarshal(trimmed, &message); err != nil {
+		return nil, false, err
+	}
+	return []map[string]json.RawMessage{message}, false, nil
+}
+
+// Serialize JSON-RPC messages with sorted keys, so equal requests always produce the same bytes
+func marshalRpcMessages(messages []map[string]json.RawMessage, batch bool) ([]byte, error) {
+	if batch {
+		return json.Marshal(messages)
+	}
+	return json.Marshal(messages[0])
+}
+
+func compactJson(value json.RawMessage) []byte {
+	var buffer bytes.Buffer
+	if err := json.Compact(&buffer, value); err != nil {
+		return value
+	}
+	return buffer.Bytes()
+}
+
+// Shorten a request for an error message
+func preview(key string) string {
+	if len(key) <= maxRequestPreview {
+		return key
+	}
+	return key[:maxRequestPreview] + "..."
+}
@@ -607,7 +607,7 @@ func (r *treeGeneratorImpl_v7_rolling) calculateNodeRewards() (*big.Int, *big.In
 
 	// If there weren't any successful attestations, everything goes to the pool stakers
 	if totalScore.Cmp(common.Big0) == 0 || attestationCount == 0 {
-		r.log.Printlnf("WARNING: Total attestation score = %s, successful attestations = %d... sending the whole smoothing pool balance to the pool stakers.", totalScore.String(), attestationCount)
+		r.log.Warnf("WARNING: Total attestation score = %s, successful attestations = %d... sending the whole smoothing pool balance to the pool stakers.", totalScore.String(), attestationCount)
 		return r.smoothingPoolBalance, big.NewInt(0), nil
 	}
 
@@ -256,6 +256,29 @@ func GetELBlockHeaderForTime(targetTime time.Time, rp *rocketpool.RocketPool) (*
 	}
 }
 
+// Record the Merkle root of a rewards tree this node generated itself, so it can be told apart from a downloaded one
+func SaveLocalRewardsRoot(cfg *config.RocketPoolConfig, interval uint64, root common.Hash) error {
+	path := cfg.Smartnode.GetLocalRewardsRootPath(interval, true)
+	err := os.WriteFile(path, []byte(root.Hex()), 0644)
+	if err != nil {
+		return fmt.Errorf("error saving local rewards root to %s: %w", path, err)
+	}
+	return nil
+}
+
+// Get the Merkle root of the rewards tree this node generated itself for an interval. Returns false if it hasn't generated one.
+func LoadLocalRewardsRoot(cfg *config.RocketPoolConfig, interval uint64) (common.Hash, bool, error) {
+	path := cfg.Smartnode.GetLocalRewardsRootPath(interval, true)
+	bytes, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return common.Hash{}, false, nil
+	}
+	if err != nil {
+		return common.Hash{}, false, fmt.Errorf("error reading local rewards root from %s: %w", path, err)
+	}
+	return common.HexToHash(strings.TrimSpace(string(bytes))), true, nil
+}
+
 // Downloads a single rewards file
 func DownloadRewardsFile(cfg *config.RocketPoolConfig, interval uint64, cid string, isDaemon bool) error {
 
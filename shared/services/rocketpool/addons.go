@@ -0,0 +1,133 @@
+package rocketpool
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/mitchellh/go-homedir"
+
+	"github.com/rocket-pool/smartnode/addons"
+	"github.com/rocket-pool/smartnode/addons/external"
+)
+
+// Get the folder community addons are installed in
+func (c *Client) GetAddonsPath() (string, error) {
+	expandedPath, err := homedir.Expand(c.configPath)
+	if err != nil {
+		return "", fmt.Errorf("error expanding config path: %w", err)
+	}
+	return filepath.Join(expandedPath, addons.ExternalAddonsFolder), nil
+}
+
+// Install the community addon in the provided folder by copying it into the addons folder, replacing an older version if there is one
+func (c *Client) InstallAddon(sourceDir string) (*external.Manifest, error) {
+	sourceDir, err := homedir.Expand(sourceDir)
+	if err != nil {
+		return nil, fmt.Errorf("error expanding addon path: %w", err)
+	}
+	manifest, err := external.LoadManifest(sourceDir)
+	if err != nil {
+		return nil, err
+	}
+	if addons.IsBuiltinAddon(manifest.ID) {
+		return nil, fmt.Errorf("addon [%s] has the same ID as a built-in addon", manifest.ID)
+	}
+
+	// Make sure the addon can actually be set up before replacing anything
+	addon, err := external.NewAddon(manifest, sourceDir)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(addon.GetTemplatePath()); err != nil {
+		return nil, fmt.Errorf("error checking the container template of addon [%s]: %w", manifest.ID, err)
+	}
+
+	addonsPath, err := c.GetAddonsPath()
+	if err != nil {
+		return nil, err
+	}
+	targetDir := filepath.Join(addonsPath, manifest.ID)
+	tempDir := targetDir + ".tmp"
+	if err := os.RemoveAll(tempDir); err != nil {
+		return nil, fmt.Errorf("error removing leftover addon folder [%s]: %w", tempDir, err)
+	}
+	if err := copyFolder(sourceDir, tempDir); err != nil {
+		os.RemoveAll(tempDir)
+		return nil, err
+	}
+	if err := os.RemoveAll(targetDir); err != nil {
+		return nil, fmt.Errorf("error removing the previous version of addon [%s]: %w", manifest.ID, err)
+	}
+	if err := os.Rename(tempDir, targetDir); err != nil {
+		return nil, fmt.Errorf("error installing addon [%s]: %w", manifest.ID, err)
+	}
+	return manifest, nil
+}
+
+// Remove an installed community addon
+func (c *Client) UninstallAddon(id string) error {
+	if addons.IsBuiltinAddon(id) {
+		return fmt.Errorf("addon [%s] is built into the Smartnode and can't be uninstalled; you can disable it instead", id)
+	}
+	addonsPath, err := c.GetAddonsPath()
+	if err != nil {
+		return err
+	}
+	addonDir := filepath.Join(addonsPath, id)
+	if _, err := os.Stat(filepath.Join(addonDir, external.ManifestFilename)); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("addon [%s] isn't installed", id)
+		}
+		return fmt.Errorf("error checking addon [%s]: %w", id, err)
+	}
+	if err := os.RemoveAll(addonDir); err != nil {
+		return fmt.Errorf("error removing addon [%s]: %w", id, err)
+	}
+	return nil
+}
+
+// Copy a folder and everything in it
+func copyFolder(sourceDir string, targetDir string) error {
+	return filepath.WalkDir(sourceDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return fmt.Errorf("error reading [%s]: %w", path, err)
+		}
+		relativePath, err := filepath.Rel(sourceDir, path)
+		if err != nil {
+			return err
+		}
+		targetPath := filepath.Join(targetDir, relativePath)
+		info, err := entry.Info()
+		if err != nil {
+			return fmt.Errorf("error reading [%s]: %w", path, err)
+		}
+		if entry.IsDir() {
+			if err := os.MkdirAll(targetPath, info.Mode().Perm()|0700); err != nil {
+				return fmt.Errorf("error creating folder [%s]: %w", targetPath, err)
+			}
+			return nil
+		}
+		if !info.Mode().IsRegular() {
+			return fmt.Errorf("[%s] isn't a regular file; addons can only contain files and folders", path)
+		}
+
+		source, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("error opening [%s]: %w", path, err)
+		}
+		defer source.Close()
+		target, err := os.OpenFile(targetPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, info.Mode().Perm())
+		if err != nil {
+			return fmt.Errorf("error creating [%s]: %w", targetPath, err)
+		}
+		defer target.Close()
+		if _, err := io.Copy(target, source); err != nil {
+			return fmt.Errorf("error copying [%s]: %w", path, err)
+		}
+		return nil
+	})
+}
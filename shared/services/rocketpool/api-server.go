@@ -0,0 +1,341 @@
+package rocketpool
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/mitchellh/go-homedir"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/events"
+	"github.com/rocket-pool/smartnode/shared/services/progress"
+	"github.com/rocket-pool/smartnode/shared/services/secrets"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Settings
+const (
+	apiServerRoutePrefix   string = "/api/v1/"
+	apiServerTokenLength   int    = 32
+	apiServerTokenFileMode        = 0600
+)
+
+// Creates the token for the node daemon's API server in the data folder or the secret store if it's enabled and doesn't have one yet
+func (c *Client) EnsureApiServerToken(cfg *config.RocketPoolConfig) error {
+	if cfg.ApiServer.Enabled.Value != true {
+		return nil
+	}
+
+	// Use the secret store if there is one
+	store, err := secrets.NewStore(cfg, false)
+	if err != nil {
+		return err
+	}
+	if store != nil {
+		_, exists, err := store.Get(secrets.ApiServerToken)
+		if err != nil || exists {
+			return err
+		}
+		token, err := newApiServerToken()
+		if err != nil {
+			return err
+		}
+		return store.Set(secrets.ApiServerToken, token)
+	}
+
+	path, err := getApiServerTokenPath(cfg)
+	if err != nil {
+		return err
+	}
+	_, err = os.Stat(path)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("error checking API server token [%s]: %w", path, err)
+	}
+
+	token, err := newApiServerToken()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("error creating API server token folder: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(token), apiServerTokenFileMode); err != nil {
+		return fmt.Errorf("error writing API server token [%s]: %w", path, err)
+	}
+	return nil
+}
+
+// Generate a new token for the node daemon's API server
+func newApiServerToken() (string, error) {
+	token := make([]byte, apiServerTokenLength)
+	if _, err := rand.Read(token); err != nil {
+		return "", fmt.Errorf("error generating API server token: %w", err)
+	}
+	return hex.EncodeToString(token), nil
+}
+
+// Load the keys other tools can use with the node daemon's API server
+func (c *Client) LoadApiKeys(cfg *config.RocketPoolConfig) (*config.ApiKeys, error) {
+	path, err := getApiKeysPath(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return config.LoadApiKeys(path)
+}
+
+// Save the keys other tools can use with the node daemon's API server; the daemon picks up changes right away
+func (c *Client) SaveApiKeys(cfg *config.RocketPoolConfig, keys *config.ApiKeys) error {
+	path, err := getApiKeysPath(cfg)
+	if err != nil {
+		return err
+	}
+	return keys.Save(path)
+}
+
+// Get the path of the API server token on this machine
+func getApiServerTokenPath(cfg *config.RocketPoolConfig) (string, error) {
+	if cfg.IsNativeMode {
+		return cfg.Smartnode.GetApiServerTokenPath(), nil
+	}
+	return getHostDataFilePath(cfg, config.ApiServerTokenFilename)
+}
+
+// Get the path of the API keys file on this machine
+func getApiKeysPath(cfg *config.RocketPoolConfig) (string, error) {
+	if cfg.IsNativeMode {
+		return cfg.Smartnode.GetApiKeysPath(), nil
+	}
+	return getHostDataFilePath(cfg, config.ApiKeysFilename)
+}
+
+// Get the path of a file in the data folder on the host, which the daemons see under their own data path
+func getHostDataFilePath(cfg *config.RocketPoolConfig, filename string) (string, error) {
+	dataPath, err := homedir.Expand(cfg.Smartnode.DataPath.Value.(string))
+	if err != nil {
+		return "", fmt.Errorf("error expanding data directory: %w", err)
+	}
+	return filepath.Join(dataPath, filename), nil
+}
+
+// Create a client that runs every API command on a node daemon's API server instead of through Docker, for tools that don't run on the node itself.
+// The URL is the server's base address (such as http://127.0.0.1:8280) and the token is an API key, or the CLI's own token.
+// Progress bars are left to the tool, so they're never drawn on its terminal.
+func NewApiServerClient(url string, token string) *Client {
+	return &Client{
+		apiServerUrl:   strings.TrimSuffix(url, "/"),
+		apiServerToken: token,
+		quiet:          true,
+	}
+}
+
+// Get the API server's routes and the role each one needs, along with the role of the client's key
+func (c *Client) ApiServerRoutes() (api.ServerRoutesResponse, error) {
+	baseUrl, token, err := c.getApiServerCredentials()
+	if err != nil {
+		return api.ServerRoutesResponse{}, err
+	}
+	request, err := http.NewRequest(http.MethodGet, baseUrl+apiServerRoutePrefix+"routes", nil)
+	if err != nil {
+		return api.ServerRoutesResponse{}, fmt.Errorf("Could not get API server routes: %w", err)
+	}
+	request.Header.Set("Authorization", "Bearer "+token)
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return api.ServerRoutesResponse{}, fmt.Errorf("Could not get API server routes: %w", err)
+	}
+	defer response.Body.Close()
+	var routes api.ServerRoutesResponse
+	if err := json.NewDecoder(response.Body).Decode(&routes); err != nil {
+		return api.ServerRoutesResponse{}, fmt.Errorf("Could not decode API server routes response: %w", err)
+	}
+	if routes.Error != "" {
+		return api.ServerRoutesResponse{}, fmt.Errorf("Could not get API server routes: %s", routes.Error)
+	}
+	return routes, nil
+}
+
+// Stream the node daemon's events from its API server, calling the handler with each one until the context is cancelled or the stream ends.
+// Pass the ID of the last event that was handled to catch up on the ones that were missed while disconnected, or 0 for only new events.
+func (c *Client) StreamEvents(ctx context.Context, lastEventID uint64, handler func(events.Event)) error {
+	baseUrl, token, err := c.getApiServerCredentials()
+	if err != nil {
+		return err
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, baseUrl+apiServerRoutePrefix+"events", nil)
+	if err != nil {
+		return fmt.Errorf("Could not stream events: %w", err)
+	}
+	request.Header.Set("Authorization", "Bearer "+token)
+	request.Header.Set("Accept", "text/event-stream")
+	if lastEventID > 0 {
+		request.Header.Set("Last-Event-ID", strconv.FormatUint(lastEventID, 10))
+	}
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("Could not stream events: %w", err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(response.Body)
+		return fmt.Errorf("Could not stream events: HTTP %d: %s", response.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	// Every event's data line holds the whole event; the other fields and keepalive comments can be skipped
+	scanner := bufio.NewScanner(response.Body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "data: ") {
+			continue
+		}
+		var event events.Event
+		if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &event); err != nil {
+			return fmt.Errorf("Could not decode event: %w", err)
+		}
+		handler(event)
+	}
+	if err := scanner.Err(); err != nil && ctx.Err() == nil {
+		return fmt.Errorf("Could not stream events: %w", err)
+	}
+	return nil
+}
+
+// Get the address of the API server and the token to use with it
+func (c *Client) getApiServerCredentials() (string, string, error) {
+	if c.apiServerUrl != "" {
+		return c.apiServerUrl, c.apiServerToken, nil
+	}
+	cfg, _, err := c.LoadConfig()
+	if err != nil {
+		return "", "", err
+	}
+	if !cfg.ApiServer.IsReachable() {
+		return "", "", fmt.Errorf("The node API server isn't enabled or isn't reachable from this machine.")
+	}
+	url := fmt.Sprintf("http://127.0.0.1:%d", cfg.ApiServer.Port.Value.(uint16))
+
+	// Read the token from the secret store if there is one
+	store, err := secrets.NewStore(cfg, false)
+	if err != nil {
+		return "", "", err
+	}
+	if store != nil {
+		token, exists, err := store.Get(secrets.ApiServerToken)
+		if err != nil {
+			return "", "", err
+		}
+		if !exists {
+			return "", "", fmt.Errorf("The API server token isn't in %s; restart the Smartnode service to create it.", store.String())
+		}
+		return url, token, nil
+	}
+
+	path, err := getApiServerTokenPath(cfg)
+	if err != nil {
+		return "", "", err
+	}
+	token, err := os.ReadFile(path)
+	if err != nil {
+		return "", "", fmt.Errorf("error reading API server token [%s]: %w", path, err)
+	}
+	return url, strings.TrimSpace(string(token)), nil
+}
+
+// Run an API command on the node daemon's API server.
+// Returns false with the error if the server couldn't be reached; on this machine it isn't running until the node has been registered, so the command can be run the old way instead.
+func (c *Client) callApiServer(baseUrl string, token string, envVars map[string]string, args string, otherArgs ...string) ([]byte, bool, error) {
+
+	// Every api command belongs to a group except for wait, so the route is made of the first one or two words
+	words := strings.Fields(args)
+	if len(words) == 0 {
+		return nil, true, fmt.Errorf("no API command provided")
+	}
+	routeLength := 2
+	if words[0] == "wait" || len(words) < 2 {
+		routeLength = 1
+	}
+	request := api.ServerRequest{
+		Args:            append(words[routeLength:], otherArgs...),
+		MaxFee:          c.maxFee,
+		MaxPrioFee:      c.maxPrioFee,
+		GasLimit:        c.gasLimit,
+		IgnoreSyncCheck: c.ignoreSyncCheck,
+		ForceFallbacks:  c.forceFallbacks,
+		IdempotencyKey:  c.idempotencyKey,
+		EnvVars:         envVars,
+	}
+	bar := progress.NewBar(c.quiet)
+	defer bar.Clear()
+	request.Progress = bar.Enabled()
+	if c.customNonce != nil {
+		request.Nonce = c.customNonce.String()
+	}
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, true, fmt.Errorf("error serializing API server request: %w", err)
+	}
+
+	url := baseUrl + apiServerRoutePrefix + strings.Join(words[:routeLength], "/")
+	if c.debugPrint {
+		fmt.Println("To API server:")
+		fmt.Println(url)
+	}
+	httpRequest, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, true, fmt.Errorf("error creating API server request: %w", err)
+	}
+	httpRequest.Header.Set("Content-Type", "application/json")
+	httpRequest.Header.Set("Authorization", "Bearer "+token)
+	response, err := http.DefaultClient.Do(httpRequest)
+	if err != nil {
+		if c.debugPrint {
+			fmt.Printf("API server unavailable: %s\n", err.Error())
+		}
+		return nil, false, fmt.Errorf("error connecting to API server: %w", err)
+	}
+	defer response.Body.Close()
+
+	// Progress streamed ahead of the command's response goes to the bar
+	filter := progress.NewLineFilter(bar.Update)
+	if _, err := io.Copy(filter, response.Body); err != nil {
+		return nil, true, fmt.Errorf("error reading API server response: %w", err)
+	}
+	output := filter.Other()
+	if c.debugPrint {
+		fmt.Println("API Out:")
+		fmt.Println(string(output))
+	}
+
+	// Reset the gas settings after the call
+	c.maxFee = c.originalMaxFee
+	c.maxPrioFee = c.originalMaxPrioFee
+	c.gasLimit = c.originalGasLimit
+
+	if response.Header.Get("Idempotent-Replayed") == "true" && !c.quiet {
+		fmt.Fprintf(os.Stderr, "This command was already run with idempotency key '%s', so its original result is shown instead of sending it again.\n", c.idempotencyKey)
+	}
+
+	// Error responses from the server itself carry the same status and error fields as command responses
+	if response.StatusCode != http.StatusOK {
+		var errorResponse api.APIResponse
+		if err := json.Unmarshal(output, &errorResponse); err == nil && errorResponse.Error != "" {
+			return nil, true, fmt.Errorf("API server error (HTTP %d): %s", response.StatusCode, errorResponse.Error)
+		}
+		return nil, true, fmt.Errorf("API server error (HTTP %d): %s", response.StatusCode, string(output))
+	}
+	return output, true, nil
+}
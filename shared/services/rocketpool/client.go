@@ -26,8 +26,10 @@ import (
 	"github.com/blang/semver/v4"
 	externalip "github.com/glendc/go-external-ip"
 	"github.com/mitchellh/go-homedir"
-	"github.com/rocket-pool/smartnode/addons/graffiti_wall_writer"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/grafana"
+	"github.com/rocket-pool/smartnode/shared/services/progress"
+	addontypes "github.com/rocket-pool/smartnode/shared/types/addons"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 	"github.com/rocket-pool/smartnode/shared/utils/rp"
@@ -42,6 +44,7 @@ const (
 	BackupSettingsFile       string = "user-settings-backup.yml"
 	PrometheusConfigTemplate string = "prometheus.tmpl"
 	PrometheusFile           string = "prometheus.yml"
+	AddressBookFile          string = "address-book.json"
 
 	APIContainerSuffix string = "_api"
 	APIBinPath         string = "/go/bin/rocketpool"
@@ -71,7 +74,7 @@ func SyncRatioToPercent(in float64) float64 {
 // Get the external IP address. Try finding an IPv4 address first to:
 // * Improve peer discovery and node performance
 // * Avoid unnecessary container restarts caused by switching between IPv4 and IPv6
-func getExternalIP() (net.IP, error) {
+func GetExternalIP() (net.IP, error) {
 	// Try IPv4 first
 	ip4Consensus := externalip.DefaultConsensus(nil, nil)
 	ip4Consensus.UseIPProtocol(4)
@@ -100,6 +103,10 @@ type Client struct {
 	debugPrint         bool
 	ignoreSyncCheck    bool
 	forceFallbacks     bool
+	quiet              bool
+	idempotencyKey     string
+	apiServerUrl       string
+	apiServerToken     string
 }
 
 func getClientStatusString(clientStatus api.ClientStatus) string {
@@ -174,6 +181,8 @@ func NewClientFromCtx(c *cli.Context) *Client {
 		originalMaxPrioFee: c.GlobalFloat64("maxPrioFee"),
 		originalGasLimit:   c.GlobalUint64("gasLimit"),
 		debugPrint:         c.GlobalBool("debug"),
+		quiet:              c.GlobalBool("quiet"),
+		idempotencyKey:     c.GlobalString("idempotency-key"),
 		forceFallbacks:     false,
 		ignoreSyncCheck:    false,
 	}
@@ -247,6 +256,11 @@ func (c *Client) LoadConfig() (*config.RocketPoolConfig, bool, error) {
 	return cfg, isNew, nil
 }
 
+// Get the path of the address book file
+func (c *Client) GetAddressBookPath() (string, error) {
+	return homedir.Expand(filepath.Join(c.configPath, AddressBookFile))
+}
+
 // Load the backup config
 func (c *Client) LoadBackupConfig() (*config.RocketPoolConfig, error) {
 	settingsFilePath := filepath.Join(c.configPath, BackupSettingsFile)
@@ -329,8 +343,22 @@ func (c *Client) UpdatePrometheusConfiguration(settings map[string]string) error
 	return nil
 }
 
-// Install the Rocket Pool service
-func (c *Client) InstallService(verbose, noDeps bool, network, version, path string, dataPath string) error {
+// Regenerate the provisioned Grafana datasource, dashboards and alert rules from the config
+func (c *Client) UpdateGrafanaProvisioning(cfg *config.RocketPoolConfig) error {
+	configPath, err := homedir.Expand(c.configPath)
+	if err != nil {
+		return fmt.Errorf("Error expanding config path: %w", err)
+	}
+	err = grafana.GenerateProvisioning(cfg, configPath)
+	if err != nil {
+		return fmt.Errorf("Error updating Grafana provisioning: %w", err)
+	}
+	return nil
+}
+
+// Install the Rocket Pool service. If an installer path is provided, that copy of the installation script is run instead of
+// downloading it.
+func (c *Client) InstallService(verbose, noDeps bool, network, version, path string, dataPath string, installerPath string) error {
 
 	// Get installation script flags
 	flags := []string{
@@ -347,24 +375,19 @@ func (c *Client) InstallService(verbose, noDeps bool, network, version, path str
 		flags = append(flags, fmt.Sprintf("-u %s", dataPath))
 	}
 
-	// Download the installation script
-	resp, err := http.Get(fmt.Sprintf(InstallerURL, version))
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("unexpected http status downloading installation script: %d", resp.StatusCode)
-	}
-
-	// Sanity check that the script octet length matches content-length
-	script, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return err
-	}
-
-	if fmt.Sprint(len(script)) != resp.Header.Get("content-length") {
-		return fmt.Errorf("downloaded script length %d did not match content-length header %s", len(script), resp.Header.Get("content-length"))
+	// Get the installation script
+	var script []byte
+	var err error
+	if installerPath != "" {
+		script, err = os.ReadFile(installerPath)
+		if err != nil {
+			return fmt.Errorf("error reading installation script [%s]: %w", installerPath, err)
+		}
+	} else {
+		script, err = downloadInstaller(version)
+		if err != nil {
+			return err
+		}
 	}
 
 	// Initialize installation command
@@ -419,6 +442,28 @@ func (c *Client) InstallService(verbose, noDeps bool, network, version, path str
 
 }
 
+// Download the installation script for the provided version
+func downloadInstaller(version string) ([]byte, error) {
+	resp, err := http.Get(fmt.Sprintf(InstallerURL, version))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected http status downloading installation script: %d", resp.StatusCode)
+	}
+
+	// Sanity check that the script octet length matches content-length
+	script, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if fmt.Sprint(len(script)) != resp.Header.Get("content-length") {
+		return nil, fmt.Errorf("downloaded script length %d did not match content-length header %s", len(script), resp.Header.Get("content-length"))
+	}
+	return script, nil
+}
+
 // Install the update tracker
 func (c *Client) InstallUpdateTracker(verbose bool, version string) error {
 
@@ -516,29 +561,29 @@ func (c *Client) StartService(composeFiles []string) error {
 		}
 	*/
 	// Start all of the containers
-	cmd, err := c.compose(composeFiles, "up -d --remove-orphans --quiet-pull")
+	d, orchestrator, err := c.deployment(composeFiles)
 	if err != nil {
 		return err
 	}
-	return c.printOutput(cmd)
+	return c.printOutput(orchestrator.Up(d))
 }
 
 // Pause the Rocket Pool service
 func (c *Client) PauseService(composeFiles []string) error {
-	cmd, err := c.compose(composeFiles, "stop")
+	d, orchestrator, err := c.deployment(composeFiles)
 	if err != nil {
 		return err
 	}
-	return c.printOutput(cmd)
+	return c.printOutput(orchestrator.Pause(d))
 }
 
 // Stop the Rocket Pool service
 func (c *Client) StopService(composeFiles []string) error {
-	cmd, err := c.compose(composeFiles, "down -v")
+	d, orchestrator, err := c.deployment(composeFiles)
 	if err != nil {
 		return err
 	}
-	return c.printOutput(cmd)
+	return c.printOutput(orchestrator.Down(d))
 }
 
 // Stop the Rocket Pool service and remove the config folder
@@ -550,11 +595,11 @@ func (c *Client) TerminateService(composeFiles []string, configPath string) erro
 	}
 
 	// Terminate the Docker containers
-	cmd, err := c.compose(composeFiles, "down -v")
+	d, orchestrator, err := c.deployment(composeFiles)
 	if err != nil {
 		return fmt.Errorf("error creating Docker artifact removal command: %w", err)
 	}
-	err = c.printOutput(cmd)
+	err = c.printOutput(orchestrator.Down(d))
 	if err != nil {
 		return fmt.Errorf("error removing Docker artifacts: %w", err)
 	}
@@ -565,7 +610,7 @@ func (c *Client) TerminateService(composeFiles []string, configPath string) erro
 		return fmt.Errorf("error loading Rocket Pool directory: %w", err)
 	}
 	fmt.Printf("Deleting Rocket Pool directory (%s)...\n", path)
-	cmd = fmt.Sprintf("%s rm -rf %s", rootCmd, path)
+	cmd := fmt.Sprintf("%s rm -rf %s", rootCmd, path)
 	_, err = c.readOutput(cmd)
 	if err != nil {
 		return fmt.Errorf("error deleting Rocket Pool directory: %w", err)
@@ -578,52 +623,47 @@ func (c *Client) TerminateService(composeFiles []string, configPath string) erro
 
 // Print the Rocket Pool service status
 func (c *Client) PrintServiceStatus(composeFiles []string) error {
-	cmd, err := c.compose(composeFiles, "ps")
+	d, orchestrator, err := c.deployment(composeFiles)
 	if err != nil {
 		return err
 	}
-	return c.printOutput(cmd)
+	return c.printOutput(orchestrator.Status(d))
 }
 
 // Print the Rocket Pool service logs
 func (c *Client) PrintServiceLogs(composeFiles []string, tail string, serviceNames ...string) error {
-	sanitizedStrings := make([]string, len(serviceNames))
-	for i, serviceName := range serviceNames {
-		sanitizedStrings[i] = fmt.Sprintf("%s", shellescape.Quote(serviceName))
-	}
-	cmd, err := c.compose(composeFiles, fmt.Sprintf("logs -f --tail %s %s", shellescape.Quote(tail), strings.Join(sanitizedStrings, " ")))
+	d, orchestrator, err := c.deployment(composeFiles)
 	if err != nil {
 		return err
 	}
-	return c.printOutput(cmd)
+	return c.printOutput(orchestrator.Logs(d, tail, serviceNames))
 }
 
-// Print the Rocket Pool service stats
-func (c *Client) PrintServiceStats(composeFiles []string) error {
-
-	// Get service container IDs
-	cmd, err := c.compose(composeFiles, "ps -q")
+// Get the most recent Rocket Pool service logs
+func (c *Client) GetServiceLogs(composeFiles []string, tail string, serviceNames ...string) ([]byte, error) {
+	d, orchestrator, err := c.deployment(composeFiles)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	containers, err := c.readOutput(cmd)
+	return c.readOutput(orchestrator.RecentLogs(d, tail, serviceNames))
+}
+
+// Print the Rocket Pool service stats
+func (c *Client) PrintServiceStats(composeFiles []string) error {
+	d, orchestrator, err := c.deployment(composeFiles)
 	if err != nil {
 		return err
 	}
-	containerIds := strings.Split(strings.TrimSpace(string(containers)), "\n")
-
-	// Print stats
-	return c.printOutput(fmt.Sprintf("docker stats %s", strings.Join(containerIds, " ")))
-
+	return c.printOutput(orchestrator.Stats(d))
 }
 
 // Print the Rocket Pool service compose config
 func (c *Client) PrintServiceCompose(composeFiles []string) error {
-	cmd, err := c.compose(composeFiles, "config")
+	d, orchestrator, err := c.deployment(composeFiles)
 	if err != nil {
 		return err
 	}
-	return c.printOutput(cmd)
+	return c.printOutput(orchestrator.Config(d))
 }
 
 // Get the Rocket Pool service version
@@ -632,11 +672,11 @@ func (c *Client) GetServiceVersion() (string, error) {
 	// Get service container version output
 	var cmd string
 	if c.daemonPath == "" {
-		containerName, err := c.getAPIContainerName()
+		var err error
+		cmd, err = c.getAPIExecCommand(nil, fmt.Sprintf("%s --version", shellescape.Quote(APIBinPath)))
 		if err != nil {
 			return "", err
 		}
-		cmd = fmt.Sprintf("docker exec %s %s --version", shellescape.Quote(containerName), shellescape.Quote(APIBinPath))
 	} else {
 		cmd = fmt.Sprintf("%s --version", shellescape.Quote(c.daemonPath))
 	}
@@ -955,6 +995,12 @@ func (c *Client) AssignGasSettings(maxFee float64, maxPrioFee float64, gasLimit
 	c.gasLimit = gasLimit
 }
 
+// Set the key sent with every transaction the client submits until it's changed; use an empty key to stop sending one.
+// Submitting the same command with the same key again returns the original response instead of sending a new transaction.
+func (c *Client) SetIdempotencyKey(key string) {
+	c.idempotencyKey = key
+}
+
 // Set the flags for ignoring EC and CC sync checks and forcing fallbacks to prevent unnecessary duplication of effort by the API during CLI commands
 func (c *Client) SetClientStatusFlags(ignoreSyncCheck bool, forceFallbacks bool) {
 	c.ignoreSyncCheck = ignoreSyncCheck
@@ -1008,45 +1054,77 @@ func (c *Client) checkIfCommandExists(command string) (bool, error) {
 	}
 }
 
-// Build a docker compose command
-func (c *Client) compose(composeFiles []string, args string) (string, error) {
+// Provision the docker compose files for the services and get the orchestrator that runs them
+func (c *Client) deployment(composeFiles []string) (*Deployment, Orchestrator, error) {
 
 	// Cancel if running in non-docker mode
 	if c.daemonPath != "" {
-		return "", errors.New("command unavailable in Native Mode (with '--daemon-path' option specified)")
+		return nil, nil, errors.New("command unavailable in Native Mode (with '--daemon-path' option specified)")
 	}
 
 	// Get the expanded config path
 	expandedConfigPath, err := homedir.Expand(c.configPath)
 	if err != nil {
-		return "", err
+		return nil, nil, err
 	}
 
 	// Load config
 	cfg, isNew, err := c.LoadConfig()
 	if err != nil {
-		return "", err
+		return nil, nil, err
+	}
+	orchestrator, err := NewOrchestrator(cfg)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	if isNew {
-		return "", fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode before starting it.")
+		return nil, nil, fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode before starting it.")
 	}
 
 	// Check config
 	if cfg.ExecutionClientMode.Value.(cfgtypes.Mode) == cfgtypes.Mode_Unknown {
-		return "", fmt.Errorf("You haven't selected local or external mode for your Execution (ETH1) client.\nPlease run 'rocketpool service config' before running this command.")
+		return nil, nil, fmt.Errorf("You haven't selected local or external mode for your Execution (ETH1) client.\nPlease run 'rocketpool service config' before running this command.")
 	} else if cfg.ExecutionClientMode.Value.(cfgtypes.Mode) == cfgtypes.Mode_Local && cfg.ExecutionClient.Value.(cfgtypes.ExecutionClient) == cfgtypes.ExecutionClient_Unknown {
-		return "", errors.New("No Execution (ETH1) client selected. Please run 'rocketpool service config' before running this command.")
+		return nil, nil, errors.New("No Execution (ETH1) client selected. Please run 'rocketpool service config' before running this command.")
 	}
 	if cfg.ConsensusClientMode.Value.(cfgtypes.Mode) == cfgtypes.Mode_Unknown {
-		return "", fmt.Errorf("You haven't selected local or external mode for your Consensus (ETH2) client.\nPlease run 'rocketpool service config' before running this command.")
+		return nil, nil, fmt.Errorf("You haven't selected local or external mode for your Consensus (ETH2) client.\nPlease run 'rocketpool service config' before running this command.")
 	} else if cfg.ConsensusClientMode.Value.(cfgtypes.Mode) == cfgtypes.Mode_Local && cfg.ConsensusClient.Value.(cfgtypes.ConsensusClient) == cfgtypes.ConsensusClient_Unknown {
-		return "", errors.New("No Consensus (ETH2) client selected. Please run 'rocketpool service config' before running this command.")
+		return nil, nil, errors.New("No Consensus (ETH2) client selected. Please run 'rocketpool service config' before running this command.")
+	}
+
+	// Set up environment variables and deploy the template config files
+	settings := getComposeSettings(cfg)
+
+	// Deploy the templates and run environment variable substitution on them
+	deployedContainers, err := c.deployTemplates(cfg, expandedConfigPath, settings)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error deploying Docker templates: %w", err)
+	}
+
+	// Set up all of the environment variables to pass to the run command
+	env := []string{}
+	for key, value := range settings {
+		env = append(env, fmt.Sprintf("%s=%s", key, shellescape.Quote(value)))
+	}
+
+	// Include all of the relevant docker compose definition files
+	deployment := &Deployment{
+		ProjectDir:   expandedConfigPath,
+		Env:          env,
+		ComposeFiles: append(deployedContainers, composeFiles...),
 	}
+	return deployment, orchestrator, nil
+
+}
+
+// Get the environment variables used to provision the docker compose templates
+func getComposeSettings(cfg *config.RocketPoolConfig) map[string]string {
 
 	// Get the external IP address
 	var externalIP string
-	ip, err := getExternalIP()
+	ip, err := GetExternalIP()
 	if err != nil {
 		fmt.Println("Warning: couldn't get external IP address; if you're using Nimbus or Besu, it may have trouble finding peers:")
 		fmt.Println(err.Error())
@@ -1057,43 +1135,73 @@ func (c *Client) compose(composeFiles []string, args string) (string, error) {
 		externalIP = ip.String()
 	}
 
-	// Set up environment variables and deploy the template config files
 	settings := cfg.GenerateEnvironmentVariables()
 	if externalIP != "" {
 		settings["EXTERNAL_IP"] = shellescape.Quote(externalIP)
 	}
+	return settings
 
-	// Deploy the templates and run environment variable substitution on them
-	deployedContainers, err := c.deployTemplates(cfg, expandedConfigPath, settings)
+}
+
+// Deploys all of the appropriate docker compose template files and provisions them based on the provided configuration
+func (c *Client) deployTemplates(cfg *config.RocketPoolConfig, rocketpoolDir string, settings map[string]string) ([]string, error) {
+	runtimeFolder := filepath.Join(rocketpoolDir, runtimeDir)
+	deployedContainers, err := c.deployTemplatesTo(cfg, rocketpoolDir, runtimeFolder, settings)
 	if err != nil {
-		return "", fmt.Errorf("error deploying Docker templates: %w", err)
+		return nil, err
 	}
 
-	// Set up all of the environment variables to pass to the run command
-	env := []string{}
-	for key, value := range settings {
-		env = append(env, fmt.Sprintf("%s=%s", key, shellescape.Quote(value)))
+	// Regenerate the Grafana provisioning files so the mounted dashboards and alert rules match the config
+	if cfg.EnableMetrics.Value == true {
+		err = grafana.GenerateProvisioning(cfg, rocketpoolDir)
+		if err != nil {
+			return nil, fmt.Errorf("error updating Grafana provisioning: %w", err)
+		}
 	}
 
-	// Include all of the relevant docker compose definition files
-	composeFileFlags := []string{}
-	for _, container := range deployedContainers {
-		composeFileFlags = append(composeFileFlags, fmt.Sprintf("-f %s", shellescape.Quote(container)))
-	}
-	for _, container := range composeFiles {
-		composeFileFlags = append(composeFileFlags, fmt.Sprintf("-f %s", shellescape.Quote(container)))
+	// Give a locally managed Consensus client the external Execution client's JWT secret
+	if cfg.UsesExternalEngineApi() {
+		err = installExternalJwtSecret(cfg, rocketpoolDir)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	// Return command
-	return fmt.Sprintf("%s docker compose --project-directory %s %s %s", strings.Join(env, " "), shellescape.Quote(expandedConfigPath), strings.Join(composeFileFlags, " "), args), nil
+	// Record the environment variables so later config changes can be compared against what was deployed
+	err = saveDeployedSettings(runtimeFolder, settings)
+	if err != nil {
+		return nil, err
+	}
+	return deployedContainers, nil
+}
 
+// Copy the external Execution client's JWT secret to where the locally managed Consensus client reads it from
+func installExternalJwtSecret(cfg *config.RocketPoolConfig, rocketpoolDir string) error {
+	sourcePath, err := homedir.Expand(cfg.ExternalExecution.JwtSecretPath.Value.(string))
+	if err != nil {
+		return fmt.Errorf("error expanding JWT secret path: %w", err)
+	}
+	secret, err := os.ReadFile(sourcePath)
+	if err != nil {
+		return fmt.Errorf("error reading the external Execution client's JWT secret: %w", err)
+	}
+	secretFolder := filepath.Join(rocketpoolDir, config.JwtSecretFolder)
+	err = os.MkdirAll(secretFolder, 0755)
+	if err != nil {
+		return fmt.Errorf("error creating secrets folder [%s]: %w", secretFolder, err)
+	}
+	secretPath := filepath.Join(secretFolder, config.JwtSecretFilename)
+	err = os.WriteFile(secretPath, secret, 0644)
+	if err != nil {
+		return fmt.Errorf("error writing JWT secret to %s: %w", secretPath, err)
+	}
+	return nil
 }
 
-// Deploys all of the appropriate docker compose template files and provisions them based on the provided configuration
-func (c *Client) deployTemplates(cfg *config.RocketPoolConfig, rocketpoolDir string, settings map[string]string) ([]string, error) {
+// Provisions the docker compose template files into the provided runtime folder
+func (c *Client) deployTemplatesTo(cfg *config.RocketPoolConfig, rocketpoolDir string, runtimeFolder string, settings map[string]string) ([]string, error) {
 
 	// Check for the folders
-	runtimeFolder := filepath.Join(rocketpoolDir, runtimeDir)
 	templatesFolder := filepath.Join(rocketpoolDir, templatesDir)
 	_, err := os.Stat(templatesFolder)
 	if os.IsNotExist(err) {
@@ -1270,6 +1378,42 @@ func (c *Client) deployTemplates(cfg *config.RocketPoolConfig, rocketpoolDir str
 		deployedContainers = append(deployedContainers, filepath.Join(overrideFolder, config.MevBoostContainerName+composeFileSuffix))
 	}
 
+	// Add the extra environment variables
+	deployedContainers, err = writeEnvOverrides(cfg, runtimeFolder, deployedContainers)
+	if err != nil {
+		return []string{}, fmt.Errorf("error provisioning container environment overrides: %w", err)
+	}
+
+	// Add the resource limits
+	deployedContainers, err = writeResourceOverrides(cfg, runtimeFolder, deployedContainers)
+	if err != nil {
+		return []string{}, fmt.Errorf("error provisioning container resource limits: %w", err)
+	}
+
+	// Mount the data folders that have been moved out of their default locations
+	deployedContainers, err = writeDataLayoutOverrides(cfg, runtimeFolder, deployedContainers)
+	if err != nil {
+		return []string{}, fmt.Errorf("error provisioning data folders: %w", err)
+	}
+
+	// Mount the tmpfs volume for the encrypted validator keys
+	deployedContainers, err = writeValidatorKeyVolumeOverrides(cfg, runtimeFolder, deployedContainers)
+	if err != nil {
+		return []string{}, fmt.Errorf("error provisioning the validator key volume: %w", err)
+	}
+
+	// Mount the tmpfs volume for the wallet's unlock session
+	deployedContainers, err = writeWalletSessionVolumeOverrides(cfg, runtimeFolder, deployedContainers)
+	if err != nil {
+		return []string{}, fmt.Errorf("error provisioning the wallet session volume: %w", err)
+	}
+
+	// Mount the generated Grafana provisioning files
+	deployedContainers, err = writeGrafanaProvisioningOverride(rocketpoolDir, runtimeFolder, deployedContainers)
+	if err != nil {
+		return []string{}, fmt.Errorf("error provisioning the Grafana dashboards: %w", err)
+	}
+
 	// Create the custom keys dir
 	customKeyDir, err := homedir.Expand(filepath.Join(cfg.Smartnode.DataPath.Value.(string), "custom-keys"))
 	if err != nil {
@@ -1293,18 +1437,28 @@ func (c *Client) deployTemplates(cfg *config.RocketPoolConfig, rocketpoolDir str
 		fmt.Printf("%sWARNING: Couldn't create the rewards tree file directory (%s). You will not be able to view or claim your rewards until you create the folder [%s] manually.%s\n", colorYellow, err.Error(), rewardsFileDir, colorReset)
 	}
 
-	return c.composeAddons(cfg, rocketpoolDir, settings, deployedContainers)
+	return c.composeAddons(cfg, rocketpoolDir, runtimeFolder, settings, deployedContainers)
 
 }
 
 // Handle composing for addons
-func (c *Client) composeAddons(cfg *config.RocketPoolConfig, rocketpoolDir string, settings map[string]string, deployedContainers []string) ([]string, error) {
+func (c *Client) composeAddons(cfg *config.RocketPoolConfig, rocketpoolDir string, runtimeRoot string, settings map[string]string, deployedContainers []string) ([]string, error) {
 
-	// GWW
-	if cfg.GraffitiWallWriter.GetEnabledParameter().Value == true {
-		runtimeFolder := filepath.Join(rocketpoolDir, runtimeDir, "addons", "gww")
-		templatesFolder := filepath.Join(rocketpoolDir, templatesDir, "addons", "gww")
-		overrideFolder := filepath.Join(rocketpoolDir, overrideDir, "addons", "gww")
+	for _, addon := range cfg.GetAddons() {
+		// Addons without a container only change the environment of the Smartnode's own containers
+		if addon.GetEnabledParameter().Value != true || addon.GetContainerName() == "" {
+			continue
+		}
+		id := addon.GetID()
+		composeName := addontypes.GetComposeName(addon)
+		runtimeFolder := filepath.Join(runtimeRoot, "addons", id)
+		overrideFolder := filepath.Join(rocketpoolDir, overrideDir, "addons", id)
+
+		// Community addons ship their own template, the built-in ones come with the Smartnode's templates
+		templatePath := filepath.Join(rocketpoolDir, templatesDir, "addons", id, composeName+templateSuffix)
+		if templateAddon, ok := addon.(addontypes.TemplateAddon); ok {
+			templatePath = templateAddon.GetTemplatePath()
+		}
 
 		// Make the addon folder
 		err := os.MkdirAll(runtimeFolder, 0775)
@@ -1312,36 +1466,86 @@ func (c *Client) composeAddons(cfg *config.RocketPoolConfig, rocketpoolDir strin
 			return []string{}, fmt.Errorf("error creating addon runtime folder (%s): %w", runtimeFolder, err)
 		}
 
-		contents, err := envsubst.ReadFile(filepath.Join(templatesFolder, graffiti_wall_writer.GraffitiWallWriterContainerName+templateSuffix))
+		contents, err := envsubst.ReadFile(templatePath)
 		if err != nil {
-			return []string{}, fmt.Errorf("error reading and substituting GWW addon container template: %w", err)
+			return []string{}, fmt.Errorf("error reading and substituting %s addon container template: %w", addon.GetName(), err)
 		}
-		composePath := filepath.Join(runtimeFolder, graffiti_wall_writer.GraffitiWallWriterContainerName+composeFileSuffix)
+		composePath := filepath.Join(runtimeFolder, composeName+composeFileSuffix)
 		err = os.WriteFile(composePath, contents, 0664)
 		if err != nil {
-			return []string{}, fmt.Errorf("could not write GWW addon container file to %s: %w", composePath, err)
+			return []string{}, fmt.Errorf("could not write %s addon container file to %s: %w", addon.GetName(), composePath, err)
+		}
+
+		// The Smartnode's installer only provides override files for the built-in addons
+		overridePath := filepath.Join(overrideFolder, composeName+composeFileSuffix)
+		err = createAddonOverride(addon, overridePath)
+		if err != nil {
+			return []string{}, err
 		}
 		deployedContainers = append(deployedContainers, composePath)
-		deployedContainers = append(deployedContainers, filepath.Join(overrideFolder, graffiti_wall_writer.GraffitiWallWriterContainerName+composeFileSuffix))
+		deployedContainers = append(deployedContainers, overridePath)
 	}
 
 	return deployedContainers, nil
 
 }
 
+// Create an empty override file for an addon if it doesn't have one yet
+func createAddonOverride(addon addontypes.SmartnodeAddon, overridePath string) error {
+	_, err := os.Stat(overridePath)
+	if err == nil {
+		return nil
+	}
+	if !os.IsNotExist(err) {
+		return fmt.Errorf("error checking %s addon override file %s: %w", addon.GetName(), overridePath, err)
+	}
+	err = os.MkdirAll(filepath.Dir(overridePath), 0775)
+	if err != nil {
+		return fmt.Errorf("error creating addon override folder (%s): %w", filepath.Dir(overridePath), err)
+	}
+	contents := fmt.Sprintf("# Enter your own customizations for the %s addon here. These changes will persist after upgrades, so you only need to do them once.\n#\n# See https://docs.docker.com/compose/extends/#adding-and-overriding-configuration\n# for more information on overriding specific parameters of docker-compose files.\n\nservices:\n  %s:\n    x-rp-comment: Add your customizations below this line\n", addon.GetName(), addontypes.GetComposeName(addon))
+	err = os.WriteFile(overridePath, []byte(contents), 0664)
+	if err != nil {
+		return fmt.Errorf("could not write %s addon override file to %s: %w", addon.GetName(), overridePath, err)
+	}
+	return nil
+}
+
 // Call the Rocket Pool API
 func (c *Client) callAPI(args string, otherArgs ...string) ([]byte, error) {
+	// Clients made for a remote API server can only use that
+	if c.apiServerUrl != "" {
+		output, _, err := c.callApiServer(c.apiServerUrl, c.apiServerToken, nil, args, otherArgs...)
+		return output, err
+	}
+
+	// Use the node daemon's API server if it's enabled and running
+	if c.client == nil {
+		baseUrl, token, err := c.getApiServerCredentials()
+		if err == nil {
+			output, reached, err := c.callApiServer(baseUrl, token, nil, args, otherArgs...)
+			if reached {
+				return output, err
+			}
+		}
+	}
+
+	// Only the API server remembers idempotency keys, so running the command without it could send the transactions twice
+	if c.idempotencyKey != "" {
+		return []byte{}, errors.New("Idempotency keys need the node API server, but it isn't enabled or isn't running. Enable it in the Smartnode section of `rocketpool service config`, or run the command without --idempotency-key.")
+	}
+
 	// Sanitize and parse the args
 	ignoreSyncCheckFlag, forceFallbackECFlag, args := c.getApiCallArgs(args, otherArgs...)
 
 	// Create the command to run
 	var cmd string
 	if c.daemonPath == "" {
-		containerName, err := c.getAPIContainerName()
+		var err error
+		cmd, err = c.getAPIExecCommand(nil, fmt.Sprintf("%s %s %s %s %s api %s", shellescape.Quote(APIBinPath), ignoreSyncCheckFlag, forceFallbackECFlag, c.getGasOpts(), c.getCustomNonce(), args))
 		if err != nil {
 			return []byte{}, err
 		}
-		cmd = fmt.Sprintf("docker exec %s %s %s %s %s %s api %s", shellescape.Quote(containerName), shellescape.Quote(APIBinPath), ignoreSyncCheckFlag, forceFallbackECFlag, c.getGasOpts(), c.getCustomNonce(), args)
 	} else {
 		cmd = fmt.Sprintf("%s --settings %s %s %s %s %s api %s",
 			c.daemonPath,
@@ -1359,22 +1563,28 @@ func (c *Client) callAPI(args string, otherArgs ...string) ([]byte, error) {
 
 // Call the Rocket Pool API with some custom environment variables
 func (c *Client) callAPIWithEnvVars(envVars map[string]string, args string, otherArgs ...string) ([]byte, error) {
+	// Remote API servers get the variables in the request body, since they're secrets that shouldn't show up in the URL
+	if c.apiServerUrl != "" {
+		output, _, err := c.callApiServer(c.apiServerUrl, c.apiServerToken, envVars, args, otherArgs...)
+		return output, err
+	}
+
 	// Sanitize and parse the args
 	ignoreSyncCheckFlag, forceFallbackECFlag, args := c.getApiCallArgs(args, otherArgs...)
 
 	// Create the command to run
 	var cmd string
 	if c.daemonPath == "" {
-		envArgs := ""
+		envNames := []string{}
 		for key, value := range envVars {
 			os.Setenv(key, shellescape.Quote(value))
-			envArgs += fmt.Sprintf("-e %s ", key)
+			envNames = append(envNames, key)
 		}
-		containerName, err := c.getAPIContainerName()
+		var err error
+		cmd, err = c.getAPIExecCommand(envNames, fmt.Sprintf("%s %s %s %s %s api %s", shellescape.Quote(APIBinPath), ignoreSyncCheckFlag, forceFallbackECFlag, c.getGasOpts(), c.getCustomNonce(), args))
 		if err != nil {
 			return []byte{}, err
 		}
-		cmd = fmt.Sprintf("docker exec %s %s %s %s %s %s %s api %s", envArgs, shellescape.Quote(containerName), shellescape.Quote(APIBinPath), ignoreSyncCheckFlag, forceFallbackECFlag, c.getGasOpts(), c.getCustomNonce(), args)
 	} else {
 		envArgs := ""
 		for key, value := range envVars {
@@ -1428,7 +1638,7 @@ func (c *Client) runApiCall(cmd string) ([]byte, error) {
 		fmt.Println(cmd)
 	}
 
-	output, err := c.readOutput(cmd)
+	output, err := c.readApiOutput(cmd)
 
 	if c.debugPrint {
 		if output != nil {
@@ -1449,8 +1659,8 @@ func (c *Client) runApiCall(cmd string) ([]byte, error) {
 	return output, err
 }
 
-// Get the API container name
-func (c *Client) getAPIContainerName() (string, error) {
+// Get the command that runs the provided command inside the API container with the configured orchestrator
+func (c *Client) getAPIExecCommand(env []string, command string) (string, error) {
 	cfg, _, err := c.LoadConfig()
 	if err != nil {
 		return "", err
@@ -1458,7 +1668,12 @@ func (c *Client) getAPIContainerName() (string, error) {
 	if cfg.Smartnode.ProjectName.Value == "" {
 		return "", errors.New("Rocket Pool docker project name not set")
 	}
-	return cfg.Smartnode.ProjectName.Value.(string) + APIContainerSuffix, nil
+	orchestrator, err := NewOrchestrator(cfg)
+	if err != nil {
+		return "", err
+	}
+	containerName := cfg.Smartnode.ProjectName.Value.(string) + APIContainerSuffix
+	return orchestrator.Exec(containerName, config.ApiContainerName, env, command), nil
 }
 
 // Get gas price & limit flags
@@ -1518,3 +1733,33 @@ func (c *Client) readOutput(cmdText string) ([]byte, error) {
 	return cmd.Output()
 
 }
+
+// Run an api command and return its output, showing the progress it reports on stderr as a live bar
+func (c *Client) readApiOutput(cmdText string) ([]byte, error) {
+
+	// Initialize command
+	cmd, err := c.newCommand(cmdText)
+	if err != nil {
+		return []byte{}, err
+	}
+	defer func() {
+		_ = cmd.Close()
+	}()
+
+	// Pick the progress out of stderr, keeping the rest for errors
+	bar := progress.NewBar(c.quiet)
+	defer bar.Clear()
+	filter := progress.NewLineFilter(bar.Update)
+	var stdout bytes.Buffer
+	cmd.SetStdout(&stdout)
+	cmd.SetStderr(filter)
+
+	// Run command and return output
+	err = cmd.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		exitErr.Stderr = filter.Other()
+	}
+	return stdout.Bytes(), err
+
+}
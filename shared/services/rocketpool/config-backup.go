@@ -0,0 +1,94 @@
+package rocketpool
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/mitchellh/go-homedir"
+	"gopkg.in/yaml.v2"
+
+	"github.com/rocket-pool/smartnode/shared"
+)
+
+// Back up the settings file before it's upgraded to the current Smartnode version, so the upgrade can be rolled back.
+// Settings files that are already on the current version aren't backed up, so repeated runs keep the pre-upgrade copy.
+func (c *Client) BackupConfig() error {
+	settingsFilePath, err := homedir.Expand(filepath.Join(c.configPath, SettingsFile))
+	if err != nil {
+		return fmt.Errorf("error expanding settings file path: %w", err)
+	}
+	backupFilePath, err := homedir.Expand(filepath.Join(c.configPath, BackupSettingsFile))
+	if err != nil {
+		return fmt.Errorf("error expanding backup settings file path: %w", err)
+	}
+
+	configBytes, err := os.ReadFile(settingsFilePath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error reading settings file: %w", err)
+	}
+	settingsVersion, err := getSettingsVersion(configBytes)
+	if err != nil {
+		return err
+	}
+	if settingsVersion == fmt.Sprintf("v%s", shared.RocketPoolVersion) {
+		return nil
+	}
+
+	err = os.WriteFile(backupFilePath, configBytes, 0664)
+	if err != nil {
+		return fmt.Errorf("error writing backup settings file: %w", err)
+	}
+	return nil
+}
+
+// Swap the settings file with the backup taken before the last upgrade, returning the Smartnode version of the restored settings.
+// Running it again undoes the rollback.
+func (c *Client) RollbackConfig() (string, error) {
+	settingsFilePath, err := homedir.Expand(filepath.Join(c.configPath, SettingsFile))
+	if err != nil {
+		return "", fmt.Errorf("error expanding settings file path: %w", err)
+	}
+	backupFilePath, err := homedir.Expand(filepath.Join(c.configPath, BackupSettingsFile))
+	if err != nil {
+		return "", fmt.Errorf("error expanding backup settings file path: %w", err)
+	}
+
+	backupBytes, err := os.ReadFile(backupFilePath)
+	if os.IsNotExist(err) {
+		return "", fmt.Errorf("there is no backup of your settings from a previous Smartnode version to roll back to")
+	}
+	if err != nil {
+		return "", fmt.Errorf("error reading backup settings file: %w", err)
+	}
+	backupVersion, err := getSettingsVersion(backupBytes)
+	if err != nil {
+		return "", err
+	}
+	currentBytes, err := os.ReadFile(settingsFilePath)
+	if err != nil {
+		return "", fmt.Errorf("error reading settings file: %w", err)
+	}
+
+	err = os.WriteFile(settingsFilePath, backupBytes, 0664)
+	if err != nil {
+		return "", fmt.Errorf("error restoring backup settings file: %w", err)
+	}
+	err = os.WriteFile(backupFilePath, currentBytes, 0664)
+	if err != nil {
+		return "", fmt.Errorf("error backing up the replaced settings file: %w", err)
+	}
+	return backupVersion, nil
+}
+
+// Get the Smartnode version that wrote a serialized settings file
+func getSettingsVersion(configBytes []byte) (string, error) {
+	var settings map[string]map[string]string
+	if err := yaml.Unmarshal(configBytes, &settings); err != nil {
+		return "", fmt.Errorf("could not parse settings file: %w", err)
+	}
+	return settings["root"]["version"], nil
+}
@@ -0,0 +1,205 @@
+package rocketpool
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/mitchellh/go-homedir"
+	"gopkg.in/yaml.v2"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/config/migration"
+)
+
+// The file in the runtime folder that records the environment variables used for the last deployment
+const deployedSettingsFile string = "deployed-settings.json"
+
+// A container environment variable that will change
+type EnvChange struct {
+	Name     string
+	OldValue string
+	NewValue string
+}
+
+// The differences between the deployed docker compose files and the ones the current config would produce
+type ComposeDiff struct {
+	// False if the environment variables of the last deployment weren't recorded (e.g. it was done by an older Smartnode)
+	HasDeployedSettings bool
+
+	EnvChanges   []EnvChange
+	AddedFiles   []string
+	RemovedFiles []string
+	ChangedFiles []string
+}
+
+// Load the raw settings file, upgraded to the current version, without deserializing it into a config
+func (c *Client) LoadConfigSettings() (map[string]map[string]string, error) {
+	settingsFilePath, err := homedir.Expand(filepath.Join(c.configPath, SettingsFile))
+	if err != nil {
+		return nil, fmt.Errorf("error expanding settings file path: %w", err)
+	}
+	configBytes, err := os.ReadFile(settingsFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("could not read Rocket Pool settings file at %s: %w", settingsFilePath, err)
+	}
+	var settings map[string]map[string]string
+	if err := yaml.Unmarshal(configBytes, &settings); err != nil {
+		return nil, fmt.Errorf("could not parse settings file: %w", err)
+	}
+	if err := migration.UpdateConfig(settings); err != nil {
+		return nil, fmt.Errorf("error upgrading settings file: %w", err)
+	}
+	return settings, nil
+}
+
+// Compare the currently deployed docker compose files and environment variables with the ones the provided config would produce,
+// without changing anything
+func (c *Client) GetComposeDiff(cfg *config.RocketPoolConfig) (*ComposeDiff, error) {
+
+	// Cancel if running in non-docker mode
+	if c.daemonPath != "" {
+		return nil, errors.New("command unavailable in Native Mode (with '--daemon-path' option specified)")
+	}
+	rocketpoolDir, err := homedir.Expand(c.configPath)
+	if err != nil {
+		return nil, err
+	}
+	runtimeFolder := filepath.Join(rocketpoolDir, runtimeDir)
+
+	// Provision the templates into a scratch folder
+	previewFolder, err := os.MkdirTemp("", "rocketpool-runtime-")
+	if err != nil {
+		return nil, fmt.Errorf("error creating temporary runtime folder: %w", err)
+	}
+	defer os.RemoveAll(previewFolder)
+	settings := getComposeSettings(cfg)
+	_, err = c.deployTemplatesTo(cfg, rocketpoolDir, previewFolder, settings)
+	if err != nil {
+		return nil, fmt.Errorf("error provisioning Docker templates: %w", err)
+	}
+
+	// Compare the compose files
+	diff := &ComposeDiff{
+		EnvChanges:   []EnvChange{},
+		AddedFiles:   []string{},
+		RemovedFiles: []string{},
+		ChangedFiles: []string{},
+	}
+	newFiles, err := readComposeFiles(previewFolder)
+	if err != nil {
+		return nil, err
+	}
+	oldFiles, err := readComposeFiles(runtimeFolder)
+	if err != nil {
+		return nil, err
+	}
+	for name, contents := range newFiles {
+		oldContents, exists := oldFiles[name]
+		if !exists {
+			diff.AddedFiles = append(diff.AddedFiles, name)
+		} else if !bytes.Equal(contents, oldContents) {
+			diff.ChangedFiles = append(diff.ChangedFiles, name)
+		}
+	}
+	for name := range oldFiles {
+		if _, exists := newFiles[name]; !exists {
+			diff.RemovedFiles = append(diff.RemovedFiles, name)
+		}
+	}
+	sort.Strings(diff.AddedFiles)
+	sort.Strings(diff.RemovedFiles)
+	sort.Strings(diff.ChangedFiles)
+
+	// Compare the environment variables
+	oldSettings, err := loadDeployedSettings(runtimeFolder)
+	if err != nil {
+		return nil, err
+	}
+	if oldSettings == nil {
+		return diff, nil
+	}
+	diff.HasDeployedSettings = true
+	for name, value := range settings {
+		oldValue, exists := oldSettings[name]
+		if !exists || oldValue != value {
+			diff.EnvChanges = append(diff.EnvChanges, EnvChange{name, oldValue, value})
+		}
+	}
+	for name, oldValue := range oldSettings {
+		if _, exists := settings[name]; !exists {
+			diff.EnvChanges = append(diff.EnvChanges, EnvChange{name, oldValue, ""})
+		}
+	}
+	sort.Slice(diff.EnvChanges, func(i, j int) bool {
+		return diff.EnvChanges[i].Name < diff.EnvChanges[j].Name
+	})
+	return diff, nil
+
+}
+
+// Read all of the compose files in a runtime folder, keyed by their path relative to it
+func readComposeFiles(runtimeFolder string) (map[string][]byte, error) {
+	files := map[string][]byte{}
+	err := filepath.WalkDir(runtimeFolder, func(path string, entry fs.DirEntry, err error) error {
+		if errors.Is(err, fs.ErrNotExist) && path == runtimeFolder {
+			return filepath.SkipDir
+		}
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || filepath.Ext(path) != composeFileSuffix {
+			return nil
+		}
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(runtimeFolder, path)
+		if err != nil {
+			return err
+		}
+		files[relPath] = contents
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error reading compose files in %s: %w", runtimeFolder, err)
+	}
+	return files, nil
+}
+
+// Record the environment variables used for a deployment; they can include credentials, so only the owner can read them
+func saveDeployedSettings(runtimeFolder string, settings map[string]string) error {
+	bytes, err := json.Marshal(settings)
+	if err != nil {
+		return fmt.Errorf("error serializing deployed settings: %w", err)
+	}
+	path := filepath.Join(runtimeFolder, deployedSettingsFile)
+	err = os.WriteFile(path, bytes, 0600)
+	if err != nil {
+		return fmt.Errorf("error writing deployed settings to %s: %w", path, err)
+	}
+	return nil
+}
+
+// Load the environment variables used for the last deployment, or nil if they weren't recorded
+func loadDeployedSettings(runtimeFolder string) (map[string]string, error) {
+	path := filepath.Join(runtimeFolder, deployedSettingsFile)
+	bytes, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading deployed settings from %s: %w", path, err)
+	}
+	settings := map[string]string{}
+	if err := json.Unmarshal(bytes, &settings); err != nil {
+		return nil, fmt.Errorf("error deserializing deployed settings from %s: %w", path, err)
+	}
+	return settings, nil
+}
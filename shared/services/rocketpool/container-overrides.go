@@ -0,0 +1,70 @@
+package rocketpool
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// The suffix of the compose files in the runtime folder that add the user's extra environment variables to a container
+const envOverrideSuffix string = ".env" + composeFileSuffix
+
+// A compose file that only adds environment variables to a service
+type envOverrideFile struct {
+	Services map[string]envOverrideService `yaml:"services"`
+}
+type envOverrideService struct {
+	Environment map[string]string `yaml:"environment"`
+}
+
+// Write compose files that add the user's extra environment variables to each of the deployed containers that has some,
+// and add them to the list of deployed compose files
+func writeEnvOverrides(cfg *config.RocketPoolConfig, runtimeFolder string, deployedContainers []string) ([]string, error) {
+	envs, err := cfg.ContainerOverrides.GetExtraEnvironment()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, container := range []string{config.Eth1ContainerName, config.Eth2ContainerName, config.ValidatorContainerName, config.MevBoostContainerName} {
+		env, exists := envs[container]
+		if !exists || !containsString(deployedContainers, filepath.Join(runtimeFolder, container+composeFileSuffix)) {
+			continue
+		}
+
+		// Escape the values so compose doesn't try to substitute variables in them
+		escaped := make(map[string]string, len(env))
+		for name, value := range env {
+			escaped[name] = strings.ReplaceAll(value, "$", "$$")
+		}
+		contents, err := yaml.Marshal(envOverrideFile{
+			Services: map[string]envOverrideService{
+				container: {Environment: escaped},
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error serializing the %s environment overrides: %w", container, err)
+		}
+		path := filepath.Join(runtimeFolder, container+envOverrideSuffix)
+		err = os.WriteFile(path, contents, 0664)
+		if err != nil {
+			return nil, fmt.Errorf("could not write the %s environment overrides to %s: %w", container, path, err)
+		}
+		deployedContainers = append(deployedContainers, path)
+	}
+	return deployedContainers, nil
+}
+
+// Check if a list of strings contains a value
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,87 @@
+package rocketpool
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// The suffix of the compose files in the runtime folder that set a container's resource limits
+const resourcesOverrideSuffix string = ".resources" + composeFileSuffix
+
+// A compose file that only sets the resource limits, priority and restart policy of a service
+type resourcesOverrideFile struct {
+	Services map[string]resourcesOverrideService `yaml:"services"`
+}
+type resourcesOverrideService struct {
+	Restart     string                `yaml:"restart,omitempty"`
+	CpuShares   uint16                `yaml:"cpu_shares,omitempty"`
+	BlkioConfig *resourcesBlkioConfig `yaml:"blkio_config,omitempty"`
+	Deploy      *resourcesDeploy      `yaml:"deploy,omitempty"`
+}
+type resourcesBlkioConfig struct {
+	Weight uint16 `yaml:"weight"`
+}
+type resourcesDeploy struct {
+	Resources resourcesDeployResources `yaml:"resources"`
+}
+type resourcesDeployResources struct {
+	Limits resourcesDeployLimits `yaml:"limits"`
+}
+type resourcesDeployLimits struct {
+	Cpus   string `yaml:"cpus,omitempty"`
+	Memory string `yaml:"memory,omitempty"`
+}
+
+// Write compose files that set the user's resource limits, priorities and restart policies for each of the deployed containers that has some,
+// and add them to the list of deployed compose files
+func writeResourceOverrides(cfg *config.RocketPoolConfig, runtimeFolder string, deployedContainers []string) ([]string, error) {
+	limits, err := cfg.ContainerResources.GetResourceLimits()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, container := range []string{config.Eth1ContainerName, config.Eth2ContainerName, config.ValidatorContainerName, config.NodeContainerName, config.WatchtowerContainerName, config.MevBoostContainerName} {
+		containerLimits, exists := limits[container]
+		if !exists || !containsString(deployedContainers, filepath.Join(runtimeFolder, container+composeFileSuffix)) {
+			continue
+		}
+
+		service := resourcesOverrideService{
+			Restart:   string(containerLimits.RestartPolicy),
+			CpuShares: containerLimits.CpuShares,
+		}
+		if containerLimits.IoWeight != 0 {
+			service.BlkioConfig = &resourcesBlkioConfig{Weight: containerLimits.IoWeight}
+		}
+		if containerLimits.CpuLimit != "" || containerLimits.MemoryLimit != "" {
+			service.Deploy = &resourcesDeploy{
+				Resources: resourcesDeployResources{
+					Limits: resourcesDeployLimits{
+						Cpus:   containerLimits.CpuLimit,
+						Memory: containerLimits.MemoryLimit,
+					},
+				},
+			}
+		}
+		contents, err := yaml.Marshal(resourcesOverrideFile{
+			Services: map[string]resourcesOverrideService{
+				container: service,
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error serializing the %s resource limits: %w", container, err)
+		}
+		path := filepath.Join(runtimeFolder, container+resourcesOverrideSuffix)
+		err = os.WriteFile(path, contents, 0664)
+		if err != nil {
+			return nil, fmt.Errorf("could not write the %s resource limits to %s: %w", container, path, err)
+		}
+		deployedContainers = append(deployedContainers, path)
+	}
+	return deployedContainers, nil
+}
@@ -0,0 +1,65 @@
+package rocketpool
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// The suffix of the compose files in the runtime folder that mount a container's relocated data folders
+const dataLayoutOverrideSuffix string = ".data" + composeFileSuffix
+
+// A compose file that only adds bind mounts to a service; compose replaces the template's mount for the same container path
+type dataLayoutOverrideFile struct {
+	Services map[string]dataLayoutOverrideService `yaml:"services"`
+}
+type dataLayoutOverrideService struct {
+	Volumes []string `yaml:"volumes"`
+}
+
+// Write compose files that mount the folders the user has moved parts of the data to into each of the deployed containers that uses them,
+// and add them to the list of deployed compose files
+func writeDataLayoutOverrides(cfg *config.RocketPoolConfig, runtimeFolder string, deployedContainers []string) ([]string, error) {
+	volumes := map[string][]string{}
+	for _, mount := range cfg.DataLayout.GetMounts() {
+		// Docker would create a missing folder owned by root, so make it first
+		mode := os.FileMode(0755)
+		if mount.Component == config.DataComponent_Wallet {
+			mode = 0700
+		}
+		err := os.MkdirAll(mount.HostPath, mode)
+		if err != nil {
+			return nil, fmt.Errorf("could not create the %s folder [%s]: %w", mount.Component, mount.HostPath, err)
+		}
+		for _, container := range mount.Containers {
+			volumes[container] = append(volumes[container], fmt.Sprintf("%s:%s", mount.HostPath, mount.ContainerPath))
+		}
+	}
+
+	for _, container := range []string{config.Eth1ContainerName, config.Eth2ContainerName, config.ApiContainerName, config.NodeContainerName, config.WatchtowerContainerName} {
+		containerVolumes, exists := volumes[container]
+		if !exists || !containsString(deployedContainers, filepath.Join(runtimeFolder, container+composeFileSuffix)) {
+			continue
+		}
+
+		contents, err := yaml.Marshal(dataLayoutOverrideFile{
+			Services: map[string]dataLayoutOverrideService{
+				container: {Volumes: containerVolumes},
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error serializing the %s data folders: %w", container, err)
+		}
+		path := filepath.Join(runtimeFolder, container+dataLayoutOverrideSuffix)
+		err = os.WriteFile(path, contents, 0664)
+		if err != nil {
+			return nil, fmt.Errorf("could not write the %s data folders to %s: %w", container, path, err)
+		}
+		deployedContainers = append(deployedContainers, path)
+	}
+	return deployedContainers, nil
+}
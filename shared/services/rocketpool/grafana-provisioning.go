@@ -0,0 +1,38 @@
+package rocketpool
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/grafana"
+)
+
+// The suffix of the compose file in the runtime folder that mounts the generated Grafana provisioning files
+const grafanaProvisioningOverrideSuffix string = ".provisioning" + composeFileSuffix
+
+// Write a compose file that mounts the generated Grafana datasource, dashboards and alert rules into the Grafana container if it's deployed,
+// and add it to the list of deployed compose files
+func writeGrafanaProvisioningOverride(rocketpoolDir string, runtimeFolder string, deployedContainers []string) ([]string, error) {
+	if !containsString(deployedContainers, filepath.Join(runtimeFolder, config.GrafanaContainerName+composeFileSuffix)) {
+		return deployedContainers, nil
+	}
+
+	contents, err := yaml.Marshal(dataLayoutOverrideFile{
+		Services: map[string]dataLayoutOverrideService{
+			config.GrafanaContainerName: {Volumes: grafana.GetProvisioningMounts(rocketpoolDir)},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error serializing the Grafana provisioning mounts: %w", err)
+	}
+	path := filepath.Join(runtimeFolder, config.GrafanaContainerName+grafanaProvisioningOverrideSuffix)
+	err = os.WriteFile(path, contents, 0664)
+	if err != nil {
+		return nil, fmt.Errorf("could not write the Grafana provisioning mounts to %s: %w", path, err)
+	}
+	return append(deployedContainers, path), nil
+}
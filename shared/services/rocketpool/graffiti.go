@@ -0,0 +1,95 @@
+package rocketpool
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/rocket-pool/rocketpool-go/types"
+	"gopkg.in/yaml.v2"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Prysm's graffiti file format
+type prysmGraffitiFile struct {
+	Default  string            `yaml:"default"`
+	Specific map[string]string `yaml:"specific,omitempty"`
+}
+
+// Gets the contents of the graffiti file for the validator client, in the format it reads.
+// Validator graffiti that's the same as the default is left out; clients that can't set graffiti per validator only get the default.
+func GetGraffitiFileContents(cfg *config.RocketPoolConfig, defaultGraffiti string, validatorGraffiti map[types.ValidatorPubkey]string) (string, error) {
+
+	// Sort the validators so the file is stable between runs
+	specific := map[string]string{}
+	pubkeys := []string{}
+	for pubkey, graffiti := range validatorGraffiti {
+		if graffiti == defaultGraffiti {
+			continue
+		}
+		pubkeyString := "0x" + pubkey.Hex()
+		specific[pubkeyString] = graffiti
+		pubkeys = append(pubkeys, pubkeyString)
+	}
+	sort.Strings(pubkeys)
+
+	cc := cfg.GetGraffitiConsensusClient()
+	switch cc {
+	case cfgtypes.ConsensusClient_Lighthouse:
+		var builder strings.Builder
+		builder.WriteString(fmt.Sprintf("default: %s\n", defaultGraffiti))
+		for _, pubkey := range pubkeys {
+			builder.WriteString(fmt.Sprintf("%s: %s\n", pubkey, specific[pubkey]))
+		}
+		return builder.String(), nil
+
+	case cfgtypes.ConsensusClient_Prysm:
+		file := prysmGraffitiFile{
+			Default: defaultGraffiti,
+		}
+		if len(specific) > 0 {
+			file.Specific = specific
+		}
+		bytes, err := yaml.Marshal(file)
+		if err != nil {
+			return "", fmt.Errorf("error serializing Prysm graffiti file: %w", err)
+		}
+		return string(bytes), nil
+
+	case cfgtypes.ConsensusClient_Teku:
+		return defaultGraffiti, nil
+
+	default:
+		return "", fmt.Errorf("the %s validator client can't read its graffiti from a file", cc)
+	}
+}
+
+// Checks if the graffiti file exists and has the expected contents.
+// The first return value is for file existence, the second is for whether the contents match.
+func CheckGraffitiFile(expectedContents string, cfg *config.RocketPoolConfig) (bool, bool, error) {
+	bytes, err := os.ReadFile(cfg.Smartnode.GetGraffitiFilePath())
+	if os.IsNotExist(err) {
+		return false, false, nil
+	} else if err != nil {
+		return false, false, fmt.Errorf("error reading graffiti file: %w", err)
+	}
+	return true, string(bytes) == expectedContents, nil
+}
+
+// Writes the graffiti file. Validator clients that don't reread it for each proposal have to be restarted to pick up the new file.
+func UpdateGraffitiFile(contents string, cfg *config.RocketPoolConfig) error {
+	path := cfg.Smartnode.GetGraffitiFilePath()
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return fmt.Errorf("error creating validators directory: %w", err)
+	}
+	err = os.WriteFile(path, []byte(contents), FileMode)
+	if err != nil {
+		return fmt.Errorf("error writing graffiti file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+package rocketpool
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/mitchellh/go-homedir"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/keymanager"
+)
+
+// Creates the token for the Validator client's Keymanager API in the validators folder if it's enabled and doesn't have one yet
+func (c *Client) EnsureKeymanagerToken(cfg *config.RocketPoolConfig) error {
+	if !cfg.Keymanager.IsEnabled() {
+		return nil
+	}
+	dataPath, err := homedir.Expand(cfg.Smartnode.DataPath.Value.(string))
+	if err != nil {
+		return fmt.Errorf("error expanding data directory: %w", err)
+	}
+	return keymanager.EnsureToken(filepath.Join(dataPath, "validators", config.KeymanagerTokenFilename))
+}
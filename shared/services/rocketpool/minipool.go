@@ -280,6 +280,22 @@ func (c *Client) DelegateUpgradeMinipool(address common.Address) (api.DelegateUp
 	return response, nil
 }
 
+// Get the delegate contracts and versions of the node's minipools
+func (c *Client) MinipoolDelegateStatus() (api.MinipoolDelegateStatusResponse, error) {
+	responseBytes, err := c.callAPI("minipool get-delegate-status")
+	if err != nil {
+		return api.MinipoolDelegateStatusResponse{}, fmt.Errorf("Could not get minipool delegate status: %w", err)
+	}
+	var response api.MinipoolDelegateStatusResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.MinipoolDelegateStatusResponse{}, fmt.Errorf("Could not decode minipool delegate status response: %w", err)
+	}
+	if response.Error != "" {
+		return api.MinipoolDelegateStatusResponse{}, fmt.Errorf("Could not get minipool delegate status: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Check whether a minipool can have its delegate rolled back
 func (c *Client) CanDelegateRollbackMinipool(address common.Address) (api.CanDelegateRollbackResponse, error) {
 	responseBytes, err := c.callAPI(fmt.Sprintf("minipool can-delegate-rollback %s", address.Hex()))
@@ -328,6 +344,22 @@ func (c *Client) CanSetUseLatestDelegateMinipool(address common.Address, setting
 	return response, nil
 }
 
+// Check which of the node's minipools can have their use-latest-delegate setting changed
+func (c *Client) CanSetUseLatestDelegateMinipools(setting bool) (api.CanSetUseLatestDelegatesResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("minipool can-set-use-latest-delegates %t", setting))
+	if err != nil {
+		return api.CanSetUseLatestDelegatesResponse{}, fmt.Errorf("Could not get can set use latest delegates status: %w", err)
+	}
+	var response api.CanSetUseLatestDelegatesResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.CanSetUseLatestDelegatesResponse{}, fmt.Errorf("Could not decode can set use latest delegates response: %w", err)
+	}
+	if response.Error != "" {
+		return api.CanSetUseLatestDelegatesResponse{}, fmt.Errorf("Could not get can set use latest delegates status: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Change a minipool's auto-upgrade setting
 func (c *Client) SetUseLatestDelegateMinipool(address common.Address, setting bool) (api.SetUseLatestDelegateResponse, error) {
 	responseBytes, err := c.callAPI(fmt.Sprintf("minipool set-use-latest-delegate %s %t", address.Hex(), setting))
@@ -535,3 +567,19 @@ func (c *Client) RescueDissolvedMinipool(address common.Address, amount *big.Int
 	}
 	return response, nil
 }
+
+// Cross-check the node's minipools' deposits and withdrawal credentials against the deposit contract and the Beacon Chain
+func (c *Client) AuditMinipools() (api.MinipoolAuditResponse, error) {
+	responseBytes, err := c.callAPI("minipool audit")
+	if err != nil {
+		return api.MinipoolAuditResponse{}, fmt.Errorf("Could not audit minipools: %w", err)
+	}
+	var response api.MinipoolAuditResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.MinipoolAuditResponse{}, fmt.Errorf("Could not decode minipool audit response: %w", err)
+	}
+	if response.Error != "" {
+		return api.MinipoolAuditResponse{}, fmt.Errorf("Could not audit minipools: %s", response.Error)
+	}
+	return response, nil
+}
@@ -1,11 +1,13 @@
 package rocketpool
 
 import (
+	"encoding/hex"
 	"fmt"
 	"math/big"
 
 	"github.com/goccy/go-json"
 	"github.com/rocket-pool/smartnode/shared/types/api"
+	utils "github.com/rocket-pool/smartnode/shared/utils/api"
 )
 
 // Get network node fee
@@ -24,6 +26,55 @@ func (c *Client) NodeFee() (api.NodeFeeResponse, error) {
 	return response, nil
 }
 
+// Get the node fee curve and the fees locked in by minipools created over the last few days
+func (c *Client) NodeFeeHistory(days uint64) (api.NodeFeeHistoryResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("network get-node-fee-history %d", days))
+	if err != nil {
+		return api.NodeFeeHistoryResponse{}, fmt.Errorf("Could not get network node fee history: %w", err)
+	}
+	var response api.NodeFeeHistoryResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeFeeHistoryResponse{}, fmt.Errorf("Could not decode network node fee history response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeFeeHistoryResponse{}, fmt.Errorf("Could not get network node fee history: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the base fees and priority fees paid in the latest blocks
+func (c *Client) GasFeeHistory() (api.GasFeeHistoryResponse, error) {
+	responseBytes, err := c.callAPI("network get-gas-fee-history")
+	if err != nil {
+		return api.GasFeeHistoryResponse{}, fmt.Errorf("Could not get gas fee history: %w", err)
+	}
+	var response api.GasFeeHistoryResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.GasFeeHistoryResponse{}, fmt.Errorf("Could not decode gas fee history response: %w", err)
+	}
+	if response.Error != "" {
+		return api.GasFeeHistoryResponse{}, fmt.Errorf("Could not get gas fee history: %s", response.Error)
+	}
+	utils.ZeroIfNil(&response.NextBaseFee)
+	return response, nil
+}
+
+// Get the current prices of ETH and RPL in the configured fiat currency
+func (c *Client) FiatPrices() (api.FiatPricesResponse, error) {
+	responseBytes, err := c.callAPI("network get-fiat-prices")
+	if err != nil {
+		return api.FiatPricesResponse{}, fmt.Errorf("Could not get fiat prices: %w", err)
+	}
+	var response api.FiatPricesResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.FiatPricesResponse{}, fmt.Errorf("Could not decode fiat prices response: %w", err)
+	}
+	if response.Error != "" {
+		return api.FiatPricesResponse{}, fmt.Errorf("Could not get fiat prices: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Get network RPL price
 func (c *Client) RplPrice() (api.RplPriceResponse, error) {
 	responseBytes, err := c.callAPI("network rpl-price")
@@ -71,6 +122,38 @@ func (c *Client) NetworkStats() (api.NetworkStatsResponse, error) {
 	return response, nil
 }
 
+// Get the trailing rETH APR and an estimate of the node's APR
+func (c *Client) NetworkApr() (api.NetworkAprResponse, error) {
+	responseBytes, err := c.callAPI("network apr")
+	if err != nil {
+		return api.NetworkAprResponse{}, fmt.Errorf("Could not get network APR: %w", err)
+	}
+	var response api.NetworkAprResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NetworkAprResponse{}, fmt.Errorf("Could not decode network APR response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NetworkAprResponse{}, fmt.Errorf("Could not get network APR: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get a signed snapshot of network-wide stats
+func (c *Client) NetworkStatsSnapshot() (api.NetworkStatsSnapshotResponse, error) {
+	responseBytes, err := c.callAPI("network stats-snapshot")
+	if err != nil {
+		return api.NetworkStatsSnapshotResponse{}, fmt.Errorf("Could not get network stats snapshot: %w", err)
+	}
+	var response api.NetworkStatsSnapshotResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NetworkStatsSnapshotResponse{}, fmt.Errorf("Could not decode network stats snapshot response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NetworkStatsSnapshotResponse{}, fmt.Errorf("Could not get network stats snapshot: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Get the timezone map
 func (c *Client) TimezoneMap() (api.NetworkTimezonesResponse, error) {
 	responseBytes, err := c.callAPI("network timezone-map")
@@ -119,6 +202,22 @@ func (c *Client) GenerateRewardsTree(index uint64) (api.NetworkGenerateRewardsTr
 	return response, nil
 }
 
+// Get the progress of the rewards tree the watchtower is generating, or the last one it generated
+func (c *Client) GetRewardsTreeProgress() (api.NetworkRewardsTreeProgressResponse, error) {
+	responseBytes, err := c.callAPI("network get-rewards-tree-progress")
+	if err != nil {
+		return api.NetworkRewardsTreeProgressResponse{}, fmt.Errorf("Could not get rewards tree generation progress: %w", err)
+	}
+	var response api.NetworkRewardsTreeProgressResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NetworkRewardsTreeProgressResponse{}, fmt.Errorf("Could not decode rewards tree generation progress response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NetworkRewardsTreeProgressResponse{}, fmt.Errorf("Could not get rewards tree generation progress: %s", response.Error)
+	}
+	return response, nil
+}
+
 // GetActiveDAOProposals fetches information about active DAO proposals
 func (c *Client) GetActiveDAOProposals() (api.NetworkDAOProposalsResponse, error) {
 	responseBytes, err := c.callAPI("network dao-proposals")
@@ -182,3 +281,35 @@ func (c *Client) GetLatestDelegate() (api.GetLatestDelegateResponse, error) {
 	}
 	return response, nil
 }
+
+// Get the current address and version of each Rocket Pool contract
+func (c *Client) NetworkContracts() (api.NetworkContractsResponse, error) {
+	responseBytes, err := c.callAPI("network contracts")
+	if err != nil {
+		return api.NetworkContractsResponse{}, fmt.Errorf("Could not get network contracts: %w", err)
+	}
+	var response api.NetworkContractsResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NetworkContractsResponse{}, fmt.Errorf("Could not decode network contracts response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NetworkContractsResponse{}, fmt.Errorf("Could not get network contracts: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Decode the payload of an oracle DAO or protocol DAO proposal
+func (c *Client) DecodeProposalPayload(daoName string, payload []byte) (api.DecodeProposalPayloadResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("network decode-proposal-payload %s %s", daoName, hex.EncodeToString(payload)))
+	if err != nil {
+		return api.DecodeProposalPayloadResponse{}, fmt.Errorf("Could not decode proposal payload: %w", err)
+	}
+	var response api.DecodeProposalPayloadResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.DecodeProposalPayloadResponse{}, fmt.Errorf("Could not decode proposal payload response: %w", err)
+	}
+	if response.Error != "" {
+		return api.DecodeProposalPayloadResponse{}, fmt.Errorf("Could not decode proposal payload: %s", response.Error)
+	}
+	return response, nil
+}
@@ -11,7 +11,10 @@ import (
 	"github.com/goccy/go-json"
 
 	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/smartnode/shared/services/activity"
+	"github.com/rocket-pool/smartnode/shared/services/upgrades"
 	"github.com/rocket-pool/smartnode/shared/types/api"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 	utils "github.com/rocket-pool/smartnode/shared/utils/api"
 )
 
@@ -547,6 +550,38 @@ func (c *Client) NodeSync() (api.NodeSyncProgressResponse, error) {
 	return response, nil
 }
 
+// Get the number of transactions from the node wallet that haven't been mined yet
+func (c *Client) NodePendingTransactions() (api.NodePendingTransactionsResponse, error) {
+	responseBytes, err := c.callAPI("node get-pending-transactions")
+	if err != nil {
+		return api.NodePendingTransactionsResponse{}, fmt.Errorf("Could not get pending transactions: %w", err)
+	}
+	var response api.NodePendingTransactionsResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodePendingTransactionsResponse{}, fmt.Errorf("Could not decode pending transactions response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodePendingTransactionsResponse{}, fmt.Errorf("Could not get pending transactions: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the most recent alerts sent by the node daemon, newest first
+func (c *Client) NodeRecentAlerts(count uint64) (api.NodeRecentAlertsResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("node get-recent-alerts %d", count))
+	if err != nil {
+		return api.NodeRecentAlertsResponse{}, fmt.Errorf("Could not get recent alerts: %w", err)
+	}
+	var response api.NodeRecentAlertsResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeRecentAlertsResponse{}, fmt.Errorf("Could not decode recent alerts response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeRecentAlertsResponse{}, fmt.Errorf("Could not get recent alerts: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Check whether the node has RPL rewards available to claim
 func (c *Client) CanNodeClaimRpl() (api.CanNodeClaimRplResponse, error) {
 	responseBytes, err := c.callAPI("node can-claim-rpl-rewards")
@@ -947,6 +982,40 @@ func (c *Client) SignMessage(message string) (api.NodeSignResponse, error) {
 	return response, nil
 }
 
+// Get the node's recorded metric history over the provided number of hours
+func (c *Client) NodeHistory(hours uint64) (api.NodeHistoryResponse, error) {
+	// Ignore sync status since the history is read from disk
+	c.ignoreSyncCheck = true
+	responseBytes, err := c.callAPI(fmt.Sprintf("node history %d", hours))
+	if err != nil {
+		return api.NodeHistoryResponse{}, fmt.Errorf("Could not get node history: %w", err)
+	}
+	var response api.NodeHistoryResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeHistoryResponse{}, fmt.Errorf("Could not decode node history response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeHistoryResponse{}, fmt.Errorf("Could not get node history: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the monthly uptime report for the node's validators
+func (c *Client) NodeUptime(months uint64) (api.NodeUptimeResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("node uptime %d", months))
+	if err != nil {
+		return api.NodeUptimeResponse{}, fmt.Errorf("Could not get node uptime: %w", err)
+	}
+	var response api.NodeUptimeResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeUptimeResponse{}, fmt.Errorf("Could not decode node uptime response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeUptimeResponse{}, fmt.Errorf("Could not get node uptime: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Check whether a vacant minipool can be created for solo staker migration
 func (c *Client) CanCreateVacantMinipool(amountWei *big.Int, minFee float64, salt *big.Int, pubkey types.ValidatorPubkey) (api.CanCreateVacantMinipoolResponse, error) {
 	responseBytes, err := c.callAPI(fmt.Sprintf("node can-create-vacant-minipool %s %f %s %s", amountWei.String(), minFee, salt.String(), pubkey.Hex()))
@@ -1042,3 +1111,263 @@ func (c *Client) SendMessage(address common.Address, message []byte) (api.NodeSe
 	}
 	return response, nil
 }
+
+// Get the MEV relay health and validator registrations found by the node daemon
+func (c *Client) MevStatus() (api.NodeMevStatusResponse, error) {
+	responseBytes, err := c.callAPI("node mev-status")
+	if err != nil {
+		return api.NodeMevStatusResponse{}, fmt.Errorf("Could not get MEV status: %w", err)
+	}
+	var response api.NodeMevStatusResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeMevStatusResponse{}, fmt.Errorf("Could not decode MEV status response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeMevStatusResponse{}, fmt.Errorf("Could not get MEV status: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the node's recent proposals recorded by the node daemon
+func (c *Client) Proposals() (api.NodeProposalsResponse, error) {
+	responseBytes, err := c.callAPI("node proposals")
+	if err != nil {
+		return api.NodeProposalsResponse{}, fmt.Errorf("Could not get proposals: %w", err)
+	}
+	var response api.NodeProposalsResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeProposalsResponse{}, fmt.Errorf("Could not decode proposals response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeProposalsResponse{}, fmt.Errorf("Could not get proposals: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the node's DVT minipools and the DVT cluster health found by the node daemon
+func (c *Client) DvtStatus() (api.NodeDvtStatusResponse, error) {
+	responseBytes, err := c.callAPI("node dvt-status")
+	if err != nil {
+		return api.NodeDvtStatusResponse{}, fmt.Errorf("Could not get DVT status: %w", err)
+	}
+	var response api.NodeDvtStatusResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeDvtStatusResponse{}, fmt.Errorf("Could not decode DVT status response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeDvtStatusResponse{}, fmt.Errorf("Could not get DVT status: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the node's graffiti and the graffiti of each minipool that has its own
+func (c *Client) GetGraffiti() (api.NodeGetGraffitiResponse, error) {
+	responseBytes, err := c.callAPI("node get-graffiti")
+	if err != nil {
+		return api.NodeGetGraffitiResponse{}, fmt.Errorf("Could not get graffiti: %w", err)
+	}
+	var response api.NodeGetGraffitiResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeGetGraffitiResponse{}, fmt.Errorf("Could not decode get graffiti response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeGetGraffitiResponse{}, fmt.Errorf("Could not get graffiti: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Set the graffiti template and rotation list of one of the node's minipools, or clear them if both are blank
+func (c *Client) SetMinipoolGraffiti(minipoolAddress common.Address, template string, rotation string) (api.SetMinipoolGraffitiResponse, error) {
+	responseBytes, err := c.callAPI("node set-minipool-graffiti", minipoolAddress.Hex(), template, rotation)
+	if err != nil {
+		return api.SetMinipoolGraffitiResponse{}, fmt.Errorf("Could not set minipool graffiti: %w", err)
+	}
+	var response api.SetMinipoolGraffitiResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.SetMinipoolGraffitiResponse{}, fmt.Errorf("Could not decode set minipool graffiti response: %w", err)
+	}
+	if response.Error != "" {
+		return api.SetMinipoolGraffitiResponse{}, fmt.Errorf("Could not set minipool graffiti: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the block building preferences of each minipool that has its own
+func (c *Client) GetBlockBuilding() (api.NodeGetBlockBuildingResponse, error) {
+	responseBytes, err := c.callAPI("node get-block-building")
+	if err != nil {
+		return api.NodeGetBlockBuildingResponse{}, fmt.Errorf("Could not get block building preferences: %w", err)
+	}
+	var response api.NodeGetBlockBuildingResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeGetBlockBuildingResponse{}, fmt.Errorf("Could not decode get block building preferences response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeGetBlockBuildingResponse{}, fmt.Errorf("Could not get block building preferences: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Set the block building mode and gas limit of one of the node's minipools, or clear them if the mode is blank and the gas limit is 0
+func (c *Client) SetMinipoolBlockBuilding(minipoolAddress common.Address, mode cfgtypes.BlockBuildingMode, gasLimit uint64) (api.SetMinipoolBlockBuildingResponse, error) {
+	responseBytes, err := c.callAPI("node set-minipool-block-building", minipoolAddress.Hex(), string(mode), strconv.FormatUint(gasLimit, 10))
+	if err != nil {
+		return api.SetMinipoolBlockBuildingResponse{}, fmt.Errorf("Could not set minipool block building preference: %w", err)
+	}
+	var response api.SetMinipoolBlockBuildingResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.SetMinipoolBlockBuildingResponse{}, fmt.Errorf("Could not decode set minipool block building preference response: %w", err)
+	}
+	if response.Error != "" {
+		return api.SetMinipoolBlockBuildingResponse{}, fmt.Errorf("Could not set minipool block building preference: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the node's token allowances for current and previous Rocket Pool contracts
+func (c *Client) NodeApprovals() (api.NodeApprovalsResponse, error) {
+	responseBytes, err := c.callAPI("node get-approvals")
+	if err != nil {
+		return api.NodeApprovalsResponse{}, fmt.Errorf("Could not get node approvals: %w", err)
+	}
+	var response api.NodeApprovalsResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeApprovalsResponse{}, fmt.Errorf("Could not decode node approvals response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeApprovalsResponse{}, fmt.Errorf("Could not get node approvals: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Check whether the node can set its allowance of a token for a Rocket Pool contract
+func (c *Client) CanNodeSetApproval(token string, spender common.Address, amountWei *big.Int) (api.CanNodeSetApprovalResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("node can-set-approval %s %s %s", token, spender.Hex(), amountWei.String()))
+	if err != nil {
+		return api.CanNodeSetApprovalResponse{}, fmt.Errorf("Could not get can node set approval status: %w", err)
+	}
+	var response api.CanNodeSetApprovalResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.CanNodeSetApprovalResponse{}, fmt.Errorf("Could not decode can node set approval response: %w", err)
+	}
+	if response.Error != "" {
+		return api.CanNodeSetApprovalResponse{}, fmt.Errorf("Could not get can node set approval status: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Set the node's allowance of a token for a Rocket Pool contract to an exact amount
+func (c *Client) NodeSetApproval(token string, spender common.Address, amountWei *big.Int) (api.NodeSetApprovalResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("node set-approval %s %s %s", token, spender.Hex(), amountWei.String()))
+	if err != nil {
+		return api.NodeSetApprovalResponse{}, fmt.Errorf("Could not set node approval: %w", err)
+	}
+	var response api.NodeSetApprovalResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeSetApprovalResponse{}, fmt.Errorf("Could not decode set node approval response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeSetApprovalResponse{}, fmt.Errorf("Could not set node approval: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the node's assets held by previous versions of Rocket Pool's contracts
+func (c *Client) NodeStrandedAssets() (api.NodeStrandedAssetsResponse, error) {
+	responseBytes, err := c.callAPI("node get-stranded-assets")
+	if err != nil {
+		return api.NodeStrandedAssetsResponse{}, fmt.Errorf("Could not get node stranded assets: %w", err)
+	}
+	var response api.NodeStrandedAssetsResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeStrandedAssetsResponse{}, fmt.Errorf("Could not decode node stranded assets response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeStrandedAssetsResponse{}, fmt.Errorf("Could not get node stranded assets: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Check whether the node can claim an asset held by a previous version of a Rocket Pool contract
+func (c *Client) CanNodeClaimStrandedAsset(assetType upgrades.AssetType, address common.Address) (api.CanNodeClaimStrandedAssetResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("node can-claim-stranded-asset %s %s", assetType, address.Hex()))
+	if err != nil {
+		return api.CanNodeClaimStrandedAssetResponse{}, fmt.Errorf("Could not get can node claim stranded asset status: %w", err)
+	}
+	var response api.CanNodeClaimStrandedAssetResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.CanNodeClaimStrandedAssetResponse{}, fmt.Errorf("Could not decode can node claim stranded asset response: %w", err)
+	}
+	if response.Error != "" {
+		return api.CanNodeClaimStrandedAssetResponse{}, fmt.Errorf("Could not get can node claim stranded asset status: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Claim an asset held by a previous version of a Rocket Pool contract
+func (c *Client) NodeClaimStrandedAsset(assetType upgrades.AssetType, address common.Address) (api.NodeClaimStrandedAssetResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("node claim-stranded-asset %s %s", assetType, address.Hex()))
+	if err != nil {
+		return api.NodeClaimStrandedAssetResponse{}, fmt.Errorf("Could not claim node stranded asset: %w", err)
+	}
+	var response api.NodeClaimStrandedAssetResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeClaimStrandedAssetResponse{}, fmt.Errorf("Could not decode claim node stranded asset response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeClaimStrandedAssetResponse{}, fmt.Errorf("Could not claim node stranded asset: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the node's Rocket Pool activity, newest first
+func (c *Client) NodeActivity(category activity.Category, limit uint64) (api.NodeActivityResponse, error) {
+	categoryArg := string(category)
+	if categoryArg == "" {
+		categoryArg = "all"
+	}
+	responseBytes, err := c.callAPI(fmt.Sprintf("node get-activity %s %d", categoryArg, limit))
+	if err != nil {
+		return api.NodeActivityResponse{}, fmt.Errorf("Could not get node activity: %w", err)
+	}
+	var response api.NodeActivityResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeActivityResponse{}, fmt.Errorf("Could not decode node activity response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeActivityResponse{}, fmt.Errorf("Could not get node activity: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the node's income in a calendar year
+func (c *Client) NodeIncome(year int) (api.NodeIncomeResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("node get-income %d", year))
+	if err != nil {
+		return api.NodeIncomeResponse{}, fmt.Errorf("Could not get node income: %w", err)
+	}
+	var response api.NodeIncomeResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeIncomeResponse{}, fmt.Errorf("Could not decode node income response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeIncomeResponse{}, fmt.Errorf("Could not get node income: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Estimate the returns of a new minipool with each bond size
+func (c *Client) EstimateNodeDeposit() (api.NodeEstimateDepositResponse, error) {
+	responseBytes, err := c.callAPI("node estimate-deposit")
+	if err != nil {
+		return api.NodeEstimateDepositResponse{}, fmt.Errorf("Could not estimate node deposit: %w", err)
+	}
+	var response api.NodeEstimateDepositResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeEstimateDepositResponse{}, fmt.Errorf("Could not decode estimate node deposit response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeEstimateDepositResponse{}, fmt.Errorf("Could not estimate node deposit: %s", response.Error)
+	}
+	return response, nil
+}
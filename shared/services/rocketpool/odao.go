@@ -66,7 +66,7 @@ func (c *Client) TNDAOProposals() (api.TNDAOProposalsResponse, error) {
 
 // Get a single oracle DAO proposal
 func (c *Client) TNDAOProposal(id uint64) (api.TNDAOProposalResponse, error) {
-	responseBytes, err := c.callAPI("odao proposal-details", string(id))
+	responseBytes, err := c.callAPI(fmt.Sprintf("odao proposal-details %d", id))
 	if err != nil {
 		return api.TNDAOProposalResponse{}, fmt.Errorf("Could not get oracle DAO proposal: %w", err)
 	}
@@ -851,3 +851,35 @@ func (c *Client) GetTNDAOMinipoolSettings() (api.GetTNDAOMinipoolSettingsRespons
 	}
 	return response, nil
 }
+
+// Set a marker for the watchtower to submit the RPL price for the given block even though it diverges from the reference prices
+func (c *Client) ApproveTNDAORplPrice(blockNumber uint64) (api.ApproveTNDAORplPriceResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("odao approve-rpl-price %d", blockNumber))
+	if err != nil {
+		return api.ApproveTNDAORplPriceResponse{}, fmt.Errorf("Could not approve the RPL price: %w", err)
+	}
+	var response api.ApproveTNDAORplPriceResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ApproveTNDAORplPriceResponse{}, fmt.Errorf("Could not decode approve RPL price response: %w", err)
+	}
+	if response.Error != "" {
+		return api.ApproveTNDAORplPriceResponse{}, fmt.Errorf("Could not approve the RPL price: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the latest Oracle DAO submissions of a type
+func (c *Client) TNDAOSubmissions(submissionType api.TNDAOSubmissionType, last uint64) (api.TNDAOSubmissionsResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("odao submissions %s %d", submissionType, last))
+	if err != nil {
+		return api.TNDAOSubmissionsResponse{}, fmt.Errorf("Could not get oracle DAO submissions: %w", err)
+	}
+	var response api.TNDAOSubmissionsResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.TNDAOSubmissionsResponse{}, fmt.Errorf("Could not decode oracle DAO submissions response: %w", err)
+	}
+	if response.Error != "" {
+		return api.TNDAOSubmissionsResponse{}, fmt.Errorf("Could not get oracle DAO submissions: %s", response.Error)
+	}
+	return response, nil
+}
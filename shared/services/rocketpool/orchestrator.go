@@ -0,0 +1,202 @@
+package rocketpool
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/alessio/shellescape"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/shutdown"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// The provisioned compose files and environment for the Smartnode's services
+type Deployment struct {
+	ProjectDir   string
+	Env          []string
+	ComposeFiles []string
+}
+
+// A container orchestrator that runs the Smartnode's services from the provisioned compose files.
+// Each method returns the shell command that performs the action.
+type Orchestrator interface {
+	// Create or update all of the services and start them
+	Up(d *Deployment) string
+
+	// Stop the services without removing them
+	Pause(d *Deployment) string
+
+	// Stop the services and remove them along with their volumes
+	Down(d *Deployment) string
+
+	// Print the status of the services
+	Status(d *Deployment) string
+
+	// Follow the logs of the given services, or all of them if none are provided
+	Logs(d *Deployment, tail string, services []string) string
+
+	// Print the most recent logs of the given services without following them
+	RecentLogs(d *Deployment, tail string, services []string) string
+
+	// Print the live resource usage of the services
+	Stats(d *Deployment) string
+
+	// Print the fully-resolved service definitions
+	Config(d *Deployment) string
+
+	// Run a command inside a service's container. Environment variables are passed by name and must already be set in
+	// the CLI's environment.
+	Exec(container string, service string, env []string, command string) string
+}
+
+// Get the orchestrator selected in the config
+func NewOrchestrator(cfg *config.RocketPoolConfig) (Orchestrator, error) {
+	switch cfg.Smartnode.Orchestrator.Value.(cfgtypes.Orchestrator) {
+	case cfgtypes.Orchestrator_Docker:
+		return &composeOrchestrator{binary: "docker"}, nil
+	case cfgtypes.Orchestrator_Podman:
+		return &composeOrchestrator{binary: "podman"}, nil
+	case cfgtypes.Orchestrator_Kubernetes:
+		return &kubernetesOrchestrator{namespace: cfg.Smartnode.KubernetesNamespace.Value.(string)}, nil
+	}
+	return nil, fmt.Errorf("unknown orchestrator [%v]", cfg.Smartnode.Orchestrator.Value)
+}
+
+// Get the compose file flags for a deployment
+func getComposeFileFlags(d *Deployment) string {
+	flags := make([]string, 0, len(d.ComposeFiles))
+	for _, file := range d.ComposeFiles {
+		flags = append(flags, fmt.Sprintf("-f %s", shellescape.Quote(file)))
+	}
+	return strings.Join(flags, " ")
+}
+
+// Get how many seconds the services are given to stop before they're killed, so the daemons can finish the transactions they're sending
+func getStopTimeout() int {
+	return int(shutdown.StopTimeout.Seconds())
+}
+
+// Quote a list of names for the shell
+func quoteAll(names []string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = shellescape.Quote(name)
+	}
+	return strings.Join(quoted, " ")
+}
+
+// Runs the services with Docker Compose or Podman's compatible `podman compose`
+type composeOrchestrator struct {
+	binary string
+}
+
+func (o *composeOrchestrator) compose(d *Deployment, args string) string {
+	return fmt.Sprintf("%s %s compose --project-directory %s %s %s", strings.Join(d.Env, " "), o.binary, shellescape.Quote(d.ProjectDir), getComposeFileFlags(d), args)
+}
+
+func (o *composeOrchestrator) Up(d *Deployment) string {
+	return o.compose(d, "up -d --remove-orphans --quiet-pull")
+}
+
+func (o *composeOrchestrator) Pause(d *Deployment) string {
+	return o.compose(d, fmt.Sprintf("stop -t %d", getStopTimeout()))
+}
+
+func (o *composeOrchestrator) Down(d *Deployment) string {
+	return o.compose(d, fmt.Sprintf("down -v -t %d", getStopTimeout()))
+}
+
+func (o *composeOrchestrator) Status(d *Deployment) string {
+	return o.compose(d, "ps")
+}
+
+func (o *composeOrchestrator) Logs(d *Deployment, tail string, services []string) string {
+	return o.compose(d, fmt.Sprintf("logs -f --tail %s %s", shellescape.Quote(tail), quoteAll(services)))
+}
+
+func (o *composeOrchestrator) RecentLogs(d *Deployment, tail string, services []string) string {
+	return o.compose(d, fmt.Sprintf("logs --no-color --tail %s %s", shellescape.Quote(tail), quoteAll(services)))
+}
+
+func (o *composeOrchestrator) Stats(d *Deployment) string {
+	return fmt.Sprintf("%s stats $(%s)", o.binary, o.compose(d, "ps -q"))
+}
+
+func (o *composeOrchestrator) Config(d *Deployment) string {
+	return o.compose(d, "config")
+}
+
+func (o *composeOrchestrator) Exec(container string, service string, env []string, command string) string {
+	envArgs := ""
+	for _, name := range env {
+		envArgs += fmt.Sprintf("-e %s ", name)
+	}
+	return fmt.Sprintf("%s exec %s%s %s", o.binary, envArgs, shellescape.Quote(container), command)
+}
+
+// Converts the compose files into Kubernetes manifests with kompose and applies them with kubectl
+type kubernetesOrchestrator struct {
+	namespace string
+}
+
+// Get the command that prints the Kubernetes manifests for the deployment
+func (o *kubernetesOrchestrator) manifests(d *Deployment) string {
+	return fmt.Sprintf("%s kompose convert --stdout --volumes hostPath %s", strings.Join(d.Env, " "), getComposeFileFlags(d))
+}
+
+func (o *kubernetesOrchestrator) kubectl(args string) string {
+	return fmt.Sprintf("kubectl --namespace %s %s", shellescape.Quote(o.namespace), args)
+}
+
+func (o *kubernetesOrchestrator) Up(d *Deployment) string {
+	return fmt.Sprintf("%s | %s", o.manifests(d), o.kubectl("apply -f -"))
+}
+
+func (o *kubernetesOrchestrator) Pause(d *Deployment) string {
+	return o.kubectl("scale deployment --all --replicas=0")
+}
+
+func (o *kubernetesOrchestrator) Down(d *Deployment) string {
+	return fmt.Sprintf("%s | %s", o.manifests(d), o.kubectl(fmt.Sprintf("delete --ignore-not-found --grace-period=%d -f -", getStopTimeout())))
+}
+
+func (o *kubernetesOrchestrator) Status(d *Deployment) string {
+	return o.kubectl("get deployments,pods")
+}
+
+func (o *kubernetesOrchestrator) Logs(d *Deployment, tail string, services []string) string {
+	selector := "io.kompose.service"
+	if len(services) > 0 {
+		selector = fmt.Sprintf("io.kompose.service in (%s)", strings.Join(services, ","))
+	}
+	return o.kubectl(fmt.Sprintf("logs -f --prefix --max-log-requests 20 --tail %s -l %s", shellescape.Quote(tail), shellescape.Quote(selector)))
+}
+
+func (o *kubernetesOrchestrator) RecentLogs(d *Deployment, tail string, services []string) string {
+	selector := "io.kompose.service"
+	if len(services) > 0 {
+		selector = fmt.Sprintf("io.kompose.service in (%s)", strings.Join(services, ","))
+	}
+	return o.kubectl(fmt.Sprintf("logs --prefix --max-log-requests 20 --tail %s -l %s", shellescape.Quote(tail), shellescape.Quote(selector)))
+}
+
+func (o *kubernetesOrchestrator) Stats(d *Deployment) string {
+	return o.kubectl("top pods")
+}
+
+func (o *kubernetesOrchestrator) Config(d *Deployment) string {
+	return o.manifests(d)
+}
+
+func (o *kubernetesOrchestrator) Exec(container string, service string, env []string, command string) string {
+	// kubectl exec can't pass environment variables through, so set them with env inside the container
+	envArgs := ""
+	for _, name := range env {
+		envArgs += fmt.Sprintf("%s=\"$%s\" ", name, name)
+	}
+	if envArgs != "" {
+		envArgs = "env " + envArgs
+	}
+	return o.kubectl(fmt.Sprintf("exec deploy/%s -- %s%s", shellescape.Quote(service), envArgs, command))
+}
@@ -0,0 +1,152 @@
+package rocketpool
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/mitchellh/go-homedir"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+// Settings
+const (
+	ProfilesFolder    string = "profiles"
+	activeProfileFile string = "active-profile"
+	profileExtension  string = ".yml"
+)
+
+// Profile names are also used in Docker project names, so they're limited to what Docker allows
+var profileNameRegex = regexp.MustCompile("^[a-z0-9][a-z0-9_-]*$")
+
+// Check that a profile name is valid
+func ValidateProfileName(name string) error {
+	if !profileNameRegex.MatchString(name) {
+		return fmt.Errorf("invalid profile name [%s]; profile names can only contain lowercase letters, numbers, dashes and underscores, and must start with a letter or number", name)
+	}
+	return nil
+}
+
+// Get the names of the saved network profiles
+func (c *Client) GetProfiles() ([]string, error) {
+	profilesFolder, err := c.getProfilesFolder()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(profilesFolder)
+	if os.IsNotExist(err) {
+		return []string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading profiles folder: %w", err)
+	}
+	names := []string{}
+	for _, entry := range entries {
+		if entry.Type().IsRegular() && strings.HasSuffix(entry.Name(), profileExtension) {
+			names = append(names, strings.TrimSuffix(entry.Name(), profileExtension))
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// Load a saved network profile, or nil if it doesn't exist
+func (c *Client) LoadProfile(name string) (*config.RocketPoolConfig, error) {
+	path, err := c.getProfilePath(name)
+	if err != nil {
+		return nil, err
+	}
+	return rp.LoadConfigFromFile(path)
+}
+
+// Save the settings as a network profile
+func (c *Client) SaveProfile(name string, cfg *config.RocketPoolConfig) error {
+	path, err := c.getProfilePath(name)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(filepath.Dir(path), 0775)
+	if err != nil {
+		return fmt.Errorf("error creating profiles folder: %w", err)
+	}
+	return rp.SaveConfig(cfg, path)
+}
+
+// Delete a saved network profile
+func (c *Client) DeleteProfile(name string) error {
+	path, err := c.getProfilePath(name)
+	if err != nil {
+		return err
+	}
+	err = os.Remove(path)
+	if os.IsNotExist(err) {
+		return fmt.Errorf("there is no profile named [%s]", name)
+	}
+	return err
+}
+
+// Get the name of the profile the current settings belong to, or an empty string if they haven't been saved as a profile
+func (c *Client) GetActiveProfile() (string, error) {
+	profilesFolder, err := c.getProfilesFolder()
+	if err != nil {
+		return "", err
+	}
+	bytes, err := os.ReadFile(filepath.Join(profilesFolder, activeProfileFile))
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("error reading active profile: %w", err)
+	}
+	return strings.TrimSpace(string(bytes)), nil
+}
+
+// Record the profile the current settings belong to
+func (c *Client) SetActiveProfile(name string) error {
+	if err := ValidateProfileName(name); err != nil {
+		return err
+	}
+	profilesFolder, err := c.getProfilesFolder()
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(profilesFolder, 0775)
+	if err != nil {
+		return fmt.Errorf("error creating profiles folder: %w", err)
+	}
+	err = os.WriteFile(filepath.Join(profilesFolder, activeProfileFile), []byte(name+"\n"), 0664)
+	if err != nil {
+		return fmt.Errorf("error saving active profile: %w", err)
+	}
+	return nil
+}
+
+// Get the path of a profile's settings file
+func (c *Client) getProfilePath(name string) (string, error) {
+	if err := ValidateProfileName(name); err != nil {
+		return "", err
+	}
+	profilesFolder, err := c.getProfilesFolder()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(profilesFolder, name+profileExtension), nil
+}
+
+// Get the folder the profiles are stored in
+func (c *Client) getProfilesFolder() (string, error) {
+	if c.configPath == "" {
+		return "", errors.New("the Rocket Pool config path is not set")
+	}
+	path, err := homedir.Expand(filepath.Join(c.configPath, ProfilesFolder))
+	if err != nil {
+		return "", fmt.Errorf("error expanding profiles folder path: %w", err)
+	}
+	return path, nil
+}
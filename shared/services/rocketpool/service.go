@@ -1,10 +1,15 @@
 package rocketpool
 
 import (
+	"encoding/hex"
 	"fmt"
+	"math/big"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/goccy/go-json"
 
+	"github.com/rocket-pool/smartnode/shared/services/backup"
+	"github.com/rocket-pool/smartnode/shared/services/confirmations"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 )
 
@@ -56,3 +61,427 @@ func (c *Client) RestartVc() (api.RestartVcResponse, error) {
 	}
 	return response, nil
 }
+
+// Gets the recent task runs recorded by the node and watchtower daemons
+func (c *Client) GetTaskStatus() (api.TaskStatusResponse, error) {
+	responseBytes, err := c.callAPI("service task-status")
+	if err != nil {
+		return api.TaskStatusResponse{}, fmt.Errorf("Could not get task status: %w", err)
+	}
+	var response api.TaskStatusResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.TaskStatusResponse{}, fmt.Errorf("Could not decode task status response: %w", err)
+	}
+	if response.Error != "" {
+		return api.TaskStatusResponse{}, fmt.Errorf("Could not get task status: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Gets the RPC requests the node and watchtower daemons have made to their clients
+func (c *Client) GetRpcUsage() (api.RpcUsageResponse, error) {
+	responseBytes, err := c.callAPI("service rpc-usage")
+	if err != nil {
+		return api.RpcUsageResponse{}, fmt.Errorf("Could not get RPC usage: %w", err)
+	}
+	var response api.RpcUsageResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.RpcUsageResponse{}, fmt.Errorf("Could not decode RPC usage response: %w", err)
+	}
+	if response.Error != "" {
+		return api.RpcUsageResponse{}, fmt.Errorf("Could not get RPC usage: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Gets the status of the enabled addons recorded by the node daemon
+func (c *Client) GetAddonStatus() (api.AddonStatusResponse, error) {
+	responseBytes, err := c.callAPI("service get-addon-status")
+	if err != nil {
+		return api.AddonStatusResponse{}, fmt.Errorf("Could not get addon status: %w", err)
+	}
+	var response api.AddonStatusResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.AddonStatusResponse{}, fmt.Errorf("Could not decode addon status response: %w", err)
+	}
+	if response.Error != "" {
+		return api.AddonStatusResponse{}, fmt.Errorf("Could not get addon status: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Gets the latest disk, chain data and memory usage recorded by the node daemon
+func (c *Client) GetSystemStatus() (api.SystemStatusResponse, error) {
+	responseBytes, err := c.callAPI("service system-status")
+	if err != nil {
+		return api.SystemStatusResponse{}, fmt.Errorf("Could not get system status: %w", err)
+	}
+	var response api.SystemStatusResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.SystemStatusResponse{}, fmt.Errorf("Could not decode system status response: %w", err)
+	}
+	if response.Error != "" {
+		return api.SystemStatusResponse{}, fmt.Errorf("Could not get system status: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Gets the new Smartnode and client releases found by the node daemon
+func (c *Client) GetUpdateStatus() (api.UpdateStatusResponse, error) {
+	responseBytes, err := c.callAPI("service update-status")
+	if err != nil {
+		return api.UpdateStatusResponse{}, fmt.Errorf("Could not get update status: %w", err)
+	}
+	var response api.UpdateStatusResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.UpdateStatusResponse{}, fmt.Errorf("Could not decode update status response: %w", err)
+	}
+	if response.Error != "" {
+		return api.UpdateStatusResponse{}, fmt.Errorf("Could not get update status: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Writes an encrypted backup of the settings and the data folder into the data folder
+func (c *Client) CreateBackup(passphrase string) (api.CreateBackupResponse, error) {
+	responseBytes, err := c.callAPIWithEnvVars(backupEnvVars(passphrase), "service create-backup")
+	if err != nil {
+		return api.CreateBackupResponse{}, fmt.Errorf("Could not create backup: %w", err)
+	}
+	var response api.CreateBackupResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.CreateBackupResponse{}, fmt.Errorf("Could not decode backup response: %w", err)
+	}
+	if response.Error != "" {
+		return api.CreateBackupResponse{}, fmt.Errorf("Could not create backup: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Decrypts and reads an entire backup in the data folder to check it before restoring it
+func (c *Client) CheckBackup(filename string, passphrase string) (api.CheckBackupResponse, error) {
+	responseBytes, err := c.callAPIWithEnvVars(backupEnvVars(passphrase), fmt.Sprintf("service check-backup %s", filename))
+	if err != nil {
+		return api.CheckBackupResponse{}, fmt.Errorf("Could not check backup: %w", err)
+	}
+	var response api.CheckBackupResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.CheckBackupResponse{}, fmt.Errorf("Could not decode backup response: %w", err)
+	}
+	if response.Error != "" {
+		return api.CheckBackupResponse{}, fmt.Errorf("Could not check backup: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Restores the settings and the data folder from a backup in the data folder
+func (c *Client) RestoreBackup(filename string, passphrase string) (api.RestoreBackupResponse, error) {
+	responseBytes, err := c.callAPIWithEnvVars(backupEnvVars(passphrase), fmt.Sprintf("service restore-backup %s", filename))
+	if err != nil {
+		return api.RestoreBackupResponse{}, fmt.Errorf("Could not restore backup: %w", err)
+	}
+	var response api.RestoreBackupResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.RestoreBackupResponse{}, fmt.Errorf("Could not decode backup response: %w", err)
+	}
+	if response.Error != "" {
+		return api.RestoreBackupResponse{}, fmt.Errorf("Could not restore backup: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the environment that passes a backup passphrase to the API
+func backupEnvVars(passphrase string) map[string]string {
+	return map[string]string{
+		backup.PassphraseEnvVar: hex.EncodeToString([]byte(passphrase)),
+	}
+}
+
+// Checks that a slashing protection interchange file in the validators folder is for this chain and covers all of the node's validators
+func (c *Client) CheckSlashingProtection(filename string) (api.CheckSlashingProtectionResponse, error) {
+	responseBytes, err := c.callAPI("service check-slashing-protection", filename)
+	if err != nil {
+		return api.CheckSlashingProtectionResponse{}, fmt.Errorf("Could not check slashing protection file: %w", err)
+	}
+	var response api.CheckSlashingProtectionResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.CheckSlashingProtectionResponse{}, fmt.Errorf("Could not decode slashing protection check response: %w", err)
+	}
+	if response.Error != "" {
+		return api.CheckSlashingProtectionResponse{}, fmt.Errorf("Could not check slashing protection file: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the confirmation policy of a command and its pending confirmation request, if it allows all of the provided runs of the command
+func (c *Client) GetConfirmationStatus(route string, invocations [][]string) (api.ConfirmationStatusResponse, error) {
+	args, err := formatInvocations(route, invocations)
+	if err != nil {
+		return api.ConfirmationStatusResponse{}, err
+	}
+	responseBytes, err := c.callAPI("service get-confirmation-status", args...)
+	if err != nil {
+		return api.ConfirmationStatusResponse{}, fmt.Errorf("Could not get confirmation status: %w", err)
+	}
+	var response api.ConfirmationStatusResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ConfirmationStatusResponse{}, fmt.Errorf("Could not decode confirmation status response: %w", err)
+	}
+	if response.Error != "" {
+		return api.ConfirmationStatusResponse{}, fmt.Errorf("Could not get confirmation status: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Request to run a command protected by a confirmation policy once with each of the provided arguments
+func (c *Client) RequestConfirmation(route string, invocations [][]string) (api.ConfirmationStatusResponse, error) {
+	args, err := formatInvocations(route, invocations)
+	if err != nil {
+		return api.ConfirmationStatusResponse{}, err
+	}
+	responseBytes, err := c.callAPI("service request-confirmation", args...)
+	if err != nil {
+		return api.ConfirmationStatusResponse{}, fmt.Errorf("Could not request confirmation: %w", err)
+	}
+	var response api.ConfirmationStatusResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ConfirmationStatusResponse{}, fmt.Errorf("Could not decode confirmation request response: %w", err)
+	}
+	if response.Error != "" {
+		return api.ConfirmationStatusResponse{}, fmt.Errorf("Could not request confirmation: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Confirm a request to run a protected command with its phrase and the confirmation passphrase
+func (c *Client) ConfirmCommand(id string, phrase string, passphrase string) (api.ConfirmationStatusResponse, error) {
+	responseBytes, err := c.callAPIWithEnvVars(confirmationEnvVars(passphrase, ""), fmt.Sprintf("service confirm-command %s", id), phrase)
+	if err != nil {
+		return api.ConfirmationStatusResponse{}, fmt.Errorf("Could not confirm command: %w", err)
+	}
+	var response api.ConfirmationStatusResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ConfirmationStatusResponse{}, fmt.Errorf("Could not decode confirm command response: %w", err)
+	}
+	if response.Error != "" {
+		return api.ConfirmationStatusResponse{}, fmt.Errorf("Could not confirm command: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Approve a request to run a protected command; this only works over the API server, with the key of an allow-listed device
+func (c *Client) ApproveCommand(id string) (api.ConfirmationStatusResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("service approve-command %s", id))
+	if err != nil {
+		return api.ConfirmationStatusResponse{}, fmt.Errorf("Could not approve command: %w", err)
+	}
+	var response api.ConfirmationStatusResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ConfirmationStatusResponse{}, fmt.Errorf("Could not decode approve command response: %w", err)
+	}
+	if response.Error != "" {
+		return api.ConfirmationStatusResponse{}, fmt.Errorf("Could not approve command: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the pending requests to run protected commands
+func (c *Client) GetConfirmationRequests() (api.ConfirmationRequestsResponse, error) {
+	responseBytes, err := c.callAPI("service get-confirmation-requests")
+	if err != nil {
+		return api.ConfirmationRequestsResponse{}, fmt.Errorf("Could not get confirmation requests: %w", err)
+	}
+	var response api.ConfirmationRequestsResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ConfirmationRequestsResponse{}, fmt.Errorf("Could not decode confirmation requests response: %w", err)
+	}
+	if response.Error != "" {
+		return api.ConfirmationRequestsResponse{}, fmt.Errorf("Could not get confirmation requests: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Set the passphrase that confirms requests to run protected commands
+func (c *Client) SetConfirmationPassphrase(currentPassphrase string, newPassphrase string) (api.SetConfirmationPassphraseResponse, error) {
+	responseBytes, err := c.callAPIWithEnvVars(confirmationEnvVars(currentPassphrase, newPassphrase), "service set-confirmation-passphrase")
+	if err != nil {
+		return api.SetConfirmationPassphraseResponse{}, fmt.Errorf("Could not set confirmation passphrase: %w", err)
+	}
+	var response api.SetConfirmationPassphraseResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.SetConfirmationPassphraseResponse{}, fmt.Errorf("Could not decode set confirmation passphrase response: %w", err)
+	}
+	if response.Error != "" {
+		return api.SetConfirmationPassphraseResponse{}, fmt.Errorf("Could not set confirmation passphrase: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the arguments that pass a protected command's route and the runs of it a confirmation is for to the API
+func formatInvocations(route string, invocations [][]string) ([]string, error) {
+	args := []string{route}
+	for _, invocation := range invocations {
+		arg, err := confirmations.FormatInvocation(invocation)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+	}
+	return args, nil
+}
+
+// Get the environment that passes the confirmation passphrases to the API, leaving out the ones that weren't provided
+func confirmationEnvVars(passphrase string, newPassphrase string) map[string]string {
+	envVars := map[string]string{}
+	if passphrase != "" {
+		envVars[confirmations.PassphraseEnvVar] = hex.EncodeToString([]byte(passphrase))
+	}
+	if newPassphrase != "" {
+		envVars[confirmations.NewPassphraseEnvVar] = hex.EncodeToString([]byte(newPassphrase))
+	}
+	return envVars
+}
+
+// Get the most recent entries in the audit log of state-changing commands
+func (c *Client) GetAuditLog(limit uint64) (api.GetAuditLogResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("service get-audit-log %d", limit))
+	if err != nil {
+		return api.GetAuditLogResponse{}, fmt.Errorf("Could not get audit log: %w", err)
+	}
+	var response api.GetAuditLogResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.GetAuditLogResponse{}, fmt.Errorf("Could not decode audit log response: %w", err)
+	}
+	if response.Error != "" {
+		return api.GetAuditLogResponse{}, fmt.Errorf("Could not get audit log: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Move the wallet password and API server token out of the data folder and into the configured secret store
+func (c *Client) MigrateSecrets() (api.MigrateSecretsResponse, error) {
+	responseBytes, err := c.callAPI("service migrate-secrets")
+	if err != nil {
+		return api.MigrateSecretsResponse{}, fmt.Errorf("Could not migrate secrets: %w", err)
+	}
+	var response api.MigrateSecretsResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.MigrateSecretsResponse{}, fmt.Errorf("Could not decode migrate secrets response: %w", err)
+	}
+	if response.Error != "" {
+		return api.MigrateSecretsResponse{}, fmt.Errorf("Could not migrate secrets: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Encrypt the validator keystore directory
+func (c *Client) EncryptValidatorKeys() (api.EncryptValidatorKeysResponse, error) {
+	responseBytes, err := c.callAPI("service encrypt-validator-keys")
+	if err != nil {
+		return api.EncryptValidatorKeysResponse{}, fmt.Errorf("Could not encrypt validator keys: %w", err)
+	}
+	var response api.EncryptValidatorKeysResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.EncryptValidatorKeysResponse{}, fmt.Errorf("Could not decode encrypt validator keys response: %w", err)
+	}
+	if response.Error != "" {
+		return api.EncryptValidatorKeysResponse{}, fmt.Errorf("Could not encrypt validator keys: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Decrypt the validator keystore directory back into the data folder
+func (c *Client) DecryptValidatorKeys() (api.DecryptValidatorKeysResponse, error) {
+	responseBytes, err := c.callAPI("service decrypt-validator-keys")
+	if err != nil {
+		return api.DecryptValidatorKeysResponse{}, fmt.Errorf("Could not decrypt validator keys: %w", err)
+	}
+	var response api.DecryptValidatorKeysResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.DecryptValidatorKeysResponse{}, fmt.Errorf("Could not decode decrypt validator keys response: %w", err)
+	}
+	if response.Error != "" {
+		return api.DecryptValidatorKeysResponse{}, fmt.Errorf("Could not decrypt validator keys: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Check if the primary Execution client is a local Anvil or Hardhat fork
+func (c *Client) GetForkStatus() (api.ForkStatusResponse, error) {
+	responseBytes, err := c.callAPI("service fork-status")
+	if err != nil {
+		return api.ForkStatusResponse{}, fmt.Errorf("Could not get fork status: %w", err)
+	}
+	var response api.ForkStatusResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ForkStatusResponse{}, fmt.Errorf("Could not decode fork status response: %w", err)
+	}
+	if response.Error != "" {
+		return api.ForkStatusResponse{}, fmt.Errorf("Could not get fork status: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Move a local fork's clock forward
+func (c *Client) ForkWarp(seconds uint64) (api.ForkWarpResponse, error) {
+	responseBytes, err := c.callAPI("service fork-warp", fmt.Sprint(seconds))
+	if err != nil {
+		return api.ForkWarpResponse{}, fmt.Errorf("Could not warp fork time: %w", err)
+	}
+	var response api.ForkWarpResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ForkWarpResponse{}, fmt.Errorf("Could not decode fork warp response: %w", err)
+	}
+	if response.Error != "" {
+		return api.ForkWarpResponse{}, fmt.Errorf("Could not warp fork time: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Mine blocks on a local fork
+func (c *Client) ForkMine(blocks uint64) (api.ForkMineResponse, error) {
+	responseBytes, err := c.callAPI("service fork-mine", fmt.Sprint(blocks))
+	if err != nil {
+		return api.ForkMineResponse{}, fmt.Errorf("Could not mine fork blocks: %w", err)
+	}
+	var response api.ForkMineResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ForkMineResponse{}, fmt.Errorf("Could not decode fork mine response: %w", err)
+	}
+	if response.Error != "" {
+		return api.ForkMineResponse{}, fmt.Errorf("Could not mine fork blocks: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Set the ETH balance of an address on a local fork
+func (c *Client) ForkSetBalance(address common.Address, balance *big.Int) (api.ForkSetBalanceResponse, error) {
+	responseBytes, err := c.callAPI("service fork-set-balance", address.Hex(), balance.String())
+	if err != nil {
+		return api.ForkSetBalanceResponse{}, fmt.Errorf("Could not set fork balance: %w", err)
+	}
+	var response api.ForkSetBalanceResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ForkSetBalanceResponse{}, fmt.Errorf("Could not decode fork set balance response: %w", err)
+	}
+	if response.Error != "" {
+		return api.ForkSetBalanceResponse{}, fmt.Errorf("Could not set fork balance: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Collect a runtime profile from the node or watchtower daemon
+func (c *Client) CollectProfile(daemon string, kind string, seconds uint64) (api.CollectProfileResponse, error) {
+	responseBytes, err := c.callAPI("service profile", daemon, kind, fmt.Sprint(seconds))
+	if err != nil {
+		return api.CollectProfileResponse{}, fmt.Errorf("Could not collect profile: %w", err)
+	}
+	var response api.CollectProfileResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.CollectProfileResponse{}, fmt.Errorf("Could not decode collect profile response: %w", err)
+	}
+	if response.Error != "" {
+		return api.CollectProfileResponse{}, fmt.Errorf("Could not collect profile: %s", response.Error)
+	}
+	return response, nil
+}
@@ -0,0 +1,125 @@
+package rocketpool
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/alessio/shellescape"
+	"github.com/mitchellh/go-homedir"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Settings
+const (
+	slashingProtectionContainerSuffix string = "_slashing_protection"
+	validatorsMountPath               string = "/validators"
+)
+
+// Slashing protection operations
+type SlashingProtectionOperation string
+
+const (
+	SlashingProtectionOperation_Export SlashingProtectionOperation = "export"
+	SlashingProtectionOperation_Import SlashingProtectionOperation = "import"
+)
+
+// Exports the validator client's slashing protection database to an EIP-3076 interchange file in the validators folder.
+// The validator client must be stopped first.
+func (c *Client) ExportSlashingProtection(cfg *config.RocketPoolConfig, filename string) error {
+	return c.runSlashingProtectionCommand(cfg, SlashingProtectionOperation_Export, filename)
+}
+
+// Imports an EIP-3076 interchange file in the validators folder into the validator client's slashing protection database.
+// The validator client must be stopped first.
+func (c *Client) ImportSlashingProtection(cfg *config.RocketPoolConfig, filename string) error {
+	return c.runSlashingProtectionCommand(cfg, SlashingProtectionOperation_Import, filename)
+}
+
+// Runs the validator client's slashing protection tool in a temporary container with the validators folder mounted
+func (c *Client) runSlashingProtectionCommand(cfg *config.RocketPoolConfig, operation SlashingProtectionOperation, filename string) error {
+	if cfg.IsNativeMode {
+		return fmt.Errorf("the Smartnode doesn't manage your validator client in native mode; please use its own slashing protection commands")
+	}
+	ccConfig, err := cfg.GetSelectedConsensusClientConfig()
+	if err != nil {
+		return err
+	}
+	entrypoint, args, err := getSlashingProtectionArgs(cfg, operation, filepath.Join(validatorsMountPath, filename))
+	if err != nil {
+		return err
+	}
+	dataPath, err := homedir.Expand(cfg.Smartnode.DataPath.Value.(string))
+	if err != nil {
+		return fmt.Errorf("error expanding data directory: %w", err)
+	}
+
+	// Use the tmpfs volume and the slashing protection folder if the validator keys are encrypted
+	mounts := fmt.Sprintf("-v %s:%s", shellescape.Quote(filepath.Join(dataPath, "validators")), validatorsMountPath)
+	sealed, err := isValidatorKeystoreSealed(cfg)
+	if err != nil {
+		return err
+	}
+	if sealed {
+		mounts = fmt.Sprintf("-v %s:%s -v %s:%s",
+			shellescape.Quote(getValidatorKeyVolumeName(cfg)),
+			validatorsMountPath,
+			shellescape.Quote(filepath.Join(dataPath, config.SlashingProtectionFolder)),
+			filepath.Join(validatorsMountPath, slashingProtectionMountName),
+		)
+	}
+
+	projectName := cfg.Smartnode.ProjectName.Value.(string)
+	cmd := fmt.Sprintf("docker run --rm --name %s --network %s %s --entrypoint %s %s %s",
+		shellescape.Quote(projectName+slashingProtectionContainerSuffix),
+		shellescape.Quote(projectName+"_net"),
+		mounts,
+		shellescape.Quote(entrypoint),
+		shellescape.Quote(ccConfig.GetValidatorImage()),
+		args,
+	)
+	return c.printOutput(cmd)
+}
+
+// Get the entrypoint and arguments of the selected validator client's slashing protection tool
+func getSlashingProtectionArgs(cfg *config.RocketPoolConfig, operation SlashingProtectionOperation, file string) (string, string, error) {
+	network := "mainnet"
+	if cfg.Smartnode.Network.Value.(cfgtypes.Network) != cfgtypes.Network_Mainnet {
+		network = "prater"
+	}
+	file = shellescape.Quote(file)
+
+	cc, mode := cfg.GetSelectedConsensusClient()
+	switch cc {
+	case cfgtypes.ConsensusClient_Lighthouse:
+		return "lighthouse", fmt.Sprintf("account validator slashing-protection %s %s --datadir /validators/lighthouse --network %s", operation, file, network), nil
+
+	case cfgtypes.ConsensusClient_Lodestar:
+		// Lodestar gets the genesis validators root from the Beacon node
+		beaconNode := fmt.Sprintf("http://%s:%d", config.Eth2ContainerName, cfg.ConsensusCommon.ApiPort.Value)
+		if mode == cfgtypes.Mode_External {
+			beaconNode = cfg.ExternalLodestar.HttpUrl.Value.(string)
+		}
+		return "node", fmt.Sprintf("/usr/app/packages/cli/bin/lodestar validator slashing-protection %s --file %s --dataDir /validators/lodestar --network %s --beaconNodes %s", operation, file, network, shellescape.Quote(beaconNode)), nil
+
+	case cfgtypes.ConsensusClient_Prysm:
+		if operation == SlashingProtectionOperation_Export {
+			// Prysm always writes the export to slashing_protection.json in the provided folder
+			if filepath.Base(file) != "slashing_protection.json" {
+				return "", "", fmt.Errorf("Prysm can only export slashing protection to a file named slashing_protection.json")
+			}
+			return "/app/cmd/validator/validator", fmt.Sprintf("slashing-protection-history export --datadir /validators/prysm-non-hd/direct --slashing-protection-export-dir %s --accept-terms-of-use", shellescape.Quote(filepath.Dir(file))), nil
+		}
+		return "/app/cmd/validator/validator", fmt.Sprintf("slashing-protection-history import --datadir /validators/prysm-non-hd/direct --slashing-protection-json-file %s --accept-terms-of-use", file), nil
+
+	case cfgtypes.ConsensusClient_Teku:
+		if operation == SlashingProtectionOperation_Export {
+			return "/opt/teku/bin/teku", fmt.Sprintf("slashing-protection export --data-path /validators/teku --to %s", file), nil
+		}
+		return "/opt/teku/bin/teku", fmt.Sprintf("slashing-protection import --data-path /validators/teku --from %s", file), nil
+
+	default:
+		return "", "", fmt.Errorf("the Smartnode can't %s slashing protection for the %s validator client; please use the client's own slashing protection commands", operation, cc)
+	}
+}
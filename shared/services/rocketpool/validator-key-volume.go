@@ -0,0 +1,122 @@
+package rocketpool
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/mitchellh/go-homedir"
+	"gopkg.in/yaml.v2"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Settings
+const (
+	// The suffix of the compose files in the runtime folder that mount the tmpfs volume the validator keys are decrypted into
+	validatorKeyVolumeOverrideSuffix string = ".validator-keys" + composeFileSuffix
+
+	// The name of the tmpfs volume, after the project name
+	validatorKeyVolumeSuffix string = "_validator-keys"
+
+	// The name of the link to the slashing protection folder in the validators folder; this must match keyseal.SlashingProtectionLinkName
+	slashingProtectionMountName string = ".slashing-protection"
+)
+
+// A compose file that mounts a tmpfs volume, like the validator key volume, into a service
+type validatorKeyVolumeOverrideFile struct {
+	Services map[string]dataLayoutOverrideService `yaml:"services"`
+	Volumes  map[string]validatorKeyVolume        `yaml:"volumes"`
+}
+type validatorKeyVolume struct {
+	Name       string            `yaml:"name"`
+	DriverOpts map[string]string `yaml:"driver_opts"`
+}
+
+// Check if the validator keystore directory has been encrypted, so it needs the tmpfs volume
+func isValidatorKeystoreSealed(cfg *config.RocketPoolConfig) (bool, error) {
+	sealedPath, err := homedir.Expand(cfg.Smartnode.GetSealedValidatorKeychainPathInCLI())
+	if err != nil {
+		return false, fmt.Errorf("error expanding the encrypted validator keys path: %w", err)
+	}
+	_, err = os.Stat(sealedPath)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("error checking for the encrypted validator keys: %w", err)
+	}
+	return true, nil
+}
+
+// Get the name of the tmpfs volume the validator keys are decrypted into
+func getValidatorKeyVolumeName(cfg *config.RocketPoolConfig) string {
+	return cfg.Smartnode.ProjectName.Value.(string) + validatorKeyVolumeSuffix
+}
+
+// If the validator keystore directory has been encrypted, write compose files that mount a tmpfs volume over the validators folder of every container
+// that uses it, with the slashing protection folder mounted inside it so the Validator Client's database stays on disk, and add them to the list of
+// deployed compose files. The node daemon decrypts the keys into the volume when it starts.
+func writeValidatorKeyVolumeOverrides(cfg *config.RocketPoolConfig, runtimeFolder string, deployedContainers []string) ([]string, error) {
+	sealed, err := isValidatorKeystoreSealed(cfg)
+	if err != nil || !sealed {
+		return deployedContainers, err
+	}
+	slashingProtectionPath, err := homedir.Expand(cfg.Smartnode.GetSlashingProtectionPathInCLI())
+	if err != nil {
+		return nil, fmt.Errorf("error expanding the slashing protection path: %w", err)
+	}
+	err = os.MkdirAll(slashingProtectionPath, 0700)
+	if err != nil {
+		return nil, fmt.Errorf("could not create the slashing protection folder [%s]: %w", slashingProtectionPath, err)
+	}
+
+	// The Smartnode containers see it under the data folder, and the Validator Client sees it on its own
+	mountPaths := map[string]string{
+		config.ApiContainerName:       filepath.Join(config.DaemonDataPath, "validators"),
+		config.NodeContainerName:      filepath.Join(config.DaemonDataPath, "validators"),
+		config.ValidatorContainerName: validatorsMountPath,
+	}
+	if cc, mode := cfg.GetSelectedConsensusClient(); cc == cfgtypes.ConsensusClient_Nimbus && mode == cfgtypes.Mode_Local {
+		// Nimbus runs its validators in the Beacon Node
+		mountPaths[config.Eth2ContainerName] = validatorsMountPath
+	}
+
+	volumeName := getValidatorKeyVolumeName(cfg)
+	for _, container := range []string{config.ApiContainerName, config.NodeContainerName, config.ValidatorContainerName, config.Eth2ContainerName} {
+		mountPath, exists := mountPaths[container]
+		if !exists || !containsString(deployedContainers, filepath.Join(runtimeFolder, container+composeFileSuffix)) {
+			continue
+		}
+
+		contents, err := yaml.Marshal(validatorKeyVolumeOverrideFile{
+			Services: map[string]dataLayoutOverrideService{
+				container: {Volumes: []string{
+					fmt.Sprintf("%s:%s", volumeName, mountPath),
+					fmt.Sprintf("%s:%s", slashingProtectionPath, filepath.Join(mountPath, slashingProtectionMountName)),
+				}},
+			},
+			Volumes: map[string]validatorKeyVolume{
+				volumeName: {
+					Name: volumeName,
+					DriverOpts: map[string]string{
+						"type":   "tmpfs",
+						"device": "tmpfs",
+						"o":      "mode=0700",
+					},
+				},
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error serializing the %s validator key volume: %w", container, err)
+		}
+		path := filepath.Join(runtimeFolder, container+validatorKeyVolumeOverrideSuffix)
+		err = os.WriteFile(path, contents, 0664)
+		if err != nil {
+			return nil, fmt.Errorf("could not write the %s validator key volume to %s: %w", container, path, err)
+		}
+		deployedContainers = append(deployedContainers, path)
+	}
+	return deployedContainers, nil
+}
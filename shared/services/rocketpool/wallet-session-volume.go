@@ -0,0 +1,59 @@
+package rocketpool
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// Settings
+const (
+	// The suffix of the compose files in the runtime folder that mount the tmpfs volume the wallet's unlock session is kept in
+	walletSessionVolumeOverrideSuffix string = ".wallet-session" + composeFileSuffix
+
+	// The name of the tmpfs volume, after the project name
+	walletSessionVolumeSuffix string = "_wallet-session"
+)
+
+// Write compose files that mount a tmpfs volume for the wallet's unlock session into every Smartnode container, so a wallet unlocked
+// through the API can be used by the node and watchtower daemons too, and add them to the list of deployed compose files
+func writeWalletSessionVolumeOverrides(cfg *config.RocketPoolConfig, runtimeFolder string, deployedContainers []string) ([]string, error) {
+	volumeName := cfg.Smartnode.ProjectName.Value.(string) + walletSessionVolumeSuffix
+	for _, container := range []string{config.ApiContainerName, config.NodeContainerName, config.WatchtowerContainerName} {
+		if !containsString(deployedContainers, filepath.Join(runtimeFolder, container+composeFileSuffix)) {
+			continue
+		}
+
+		contents, err := yaml.Marshal(validatorKeyVolumeOverrideFile{
+			Services: map[string]dataLayoutOverrideService{
+				container: {Volumes: []string{
+					fmt.Sprintf("%s:%s", volumeName, config.WalletSessionVolumePath),
+				}},
+			},
+			Volumes: map[string]validatorKeyVolume{
+				volumeName: {
+					Name: volumeName,
+					DriverOpts: map[string]string{
+						"type":   "tmpfs",
+						"device": "tmpfs",
+						"o":      "mode=0700",
+					},
+				},
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error serializing the %s wallet session volume: %w", container, err)
+		}
+		path := filepath.Join(runtimeFolder, container+walletSessionVolumeOverrideSuffix)
+		err = os.WriteFile(path, contents, 0664)
+		if err != nil {
+			return nil, fmt.Errorf("could not write the %s wallet session volume to %s: %w", container, path, err)
+		}
+		deployedContainers = append(deployedContainers, path)
+	}
+	return deployedContainers, nil
+}
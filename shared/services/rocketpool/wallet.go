@@ -2,6 +2,7 @@ package rocketpool
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/goccy/go-json"
@@ -41,6 +42,38 @@ func (c *Client) SetPassword(password string) (api.SetPasswordResponse, error) {
 	return response, nil
 }
 
+// Unlock the wallet for a limited time without saving its password
+func (c *Client) UnlockWallet(password string, duration time.Duration) (api.UnlockWalletResponse, error) {
+	responseBytes, err := c.callAPI("wallet unlock", password, fmt.Sprint(uint64(duration.Seconds())))
+	if err != nil {
+		return api.UnlockWalletResponse{}, fmt.Errorf("Could not unlock wallet: %w", err)
+	}
+	var response api.UnlockWalletResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.UnlockWalletResponse{}, fmt.Errorf("Could not decode unlock wallet response: %w", err)
+	}
+	if response.Error != "" {
+		return api.UnlockWalletResponse{}, fmt.Errorf("Could not unlock wallet: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Lock the wallet, deleting its saved password and ending any unlock session
+func (c *Client) LockWallet(password string) (api.LockWalletResponse, error) {
+	responseBytes, err := c.callAPI("wallet lock", password)
+	if err != nil {
+		return api.LockWalletResponse{}, fmt.Errorf("Could not lock wallet: %w", err)
+	}
+	var response api.LockWalletResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.LockWalletResponse{}, fmt.Errorf("Could not decode lock wallet response: %w", err)
+	}
+	if response.Error != "" {
+		return api.LockWalletResponse{}, fmt.Errorf("Could not lock wallet: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Initialize wallet
 func (c *Client) InitWallet(derivationPath string) (api.InitWalletResponse, error) {
 	responseBytes, err := c.callAPI("wallet init --derivation-path", derivationPath)
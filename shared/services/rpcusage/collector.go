@@ -0,0 +1,66 @@
+package rpcusage
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Represents the collector for a daemon's RPC usage
+type UsageCollector struct {
+	// The number of requests each task has made to each endpoint since the daemon started
+	requests *prometheus.Desc
+
+	// The number of those requests that failed
+	errors *prometheus.Desc
+
+	// The number of bytes each task has sent to each endpoint since the daemon started
+	requestBytes *prometheus.Desc
+
+	// The number of bytes each task has received from each endpoint since the daemon started
+	responseBytes *prometheus.Desc
+}
+
+// Create a new UsageCollector instance
+func NewUsageCollector() *UsageCollector {
+	namespace := "rocketpool"
+	subsystem := "rpc"
+	return &UsageCollector{
+		requests: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "requests_total"),
+			"The number of requests each task has made to each endpoint since the daemon started",
+			[]string{"endpoint", "task"}, nil,
+		),
+		errors: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "errors_total"),
+			"The number of requests each task has made to each endpoint that failed",
+			[]string{"endpoint", "task"}, nil,
+		),
+		requestBytes: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "request_bytes_total"),
+			"The number of bytes each task has sent to each endpoint since the daemon started",
+			[]string{"endpoint", "task"}, nil,
+		),
+		responseBytes: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "response_bytes_total"),
+			"The number of bytes each task has received from each endpoint since the daemon started",
+			[]string{"endpoint", "task"}, nil,
+		),
+	}
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *UsageCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.requests
+	channel <- collector.errors
+	channel <- collector.requestBytes
+	channel <- collector.responseBytes
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *UsageCollector) Collect(channel chan<- prometheus.Metric) {
+	for _, usage := range tracker.GetUsage() {
+		channel <- prometheus.MustNewConstMetric(
+			collector.requests, prometheus.CounterValue, float64(usage.Requests), usage.Endpoint, usage.Task)
+		channel <- prometheus.MustNewConstMetric(
+			collector.errors, prometheus.CounterValue, float64(usage.Errors), usage.Endpoint, usage.Task)
+		channel <- prometheus.MustNewConstMetric(
+			collector.requestBytes, prometheus.CounterValue, float64(usage.RequestBytes), usage.Endpoint, usage.Task)
+		channel <- prometheus.MustNewConstMetric(
+			collector.responseBytes, prometheus.CounterValue, float64(usage.ResponseBytes), usage.Endpoint, usage.Task)
+	}
+}
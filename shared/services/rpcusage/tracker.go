@@ -0,0 +1,174 @@
+package rpcusage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// The task usage is attributed to when no task is running, such as calls made for the API server
+const OtherTask string = "other"
+
+// The requests a daemon task made to one endpoint, and the bytes they moved
+type Usage struct {
+	Endpoint      string `json:"endpoint"`
+	Task          string `json:"task"`
+	Requests      uint64 `json:"requests"`
+	Errors        uint64 `json:"errors"`
+	RequestBytes  uint64 `json:"requestBytes"`
+	ResponseBytes uint64 `json:"responseBytes"`
+}
+
+// The RPC usage of a daemon since it started, as saved to disk
+type DaemonUsage struct {
+	Daemon  string    `json:"daemon"`
+	Since   time.Time `json:"since"`
+	Updated time.Time `json:"updated"`
+	Usage   []Usage   `json:"usage"`
+}
+
+// The key the usage is tracked under
+type usageKey struct {
+	endpoint string
+	task     string
+}
+
+// Counts the requests a daemon makes to its clients and the bytes they move, broken down by endpoint and by the task that made them.
+// Tasks mark themselves as running for as long as they do work, including the work they leave running in the background after
+// they return (such as the watchtower's rewards trees, balances and prices). Tasks can run concurrently, so requests are attributed
+// to the task that started most recently among the ones still running.
+type Tracker struct {
+	since  time.Time
+	usage  map[usageKey]*Usage
+	active []string
+	lock   *sync.Mutex
+}
+
+// The tracker for this process
+var tracker = &Tracker{
+	since: time.Now(),
+	usage: map[usageKey]*Usage{},
+	lock:  &sync.Mutex{},
+}
+
+// Get the tracker for this process, which the client managers record their requests into
+func GetTracker() *Tracker {
+	return tracker
+}
+
+// Mark a task as running, so the requests made until the returned function is called are attributed to it
+func (t *Tracker) StartTask(task string) func() {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.active = append(t.active, task)
+
+	return func() {
+		t.lock.Lock()
+		defer t.lock.Unlock()
+		for i := len(t.active) - 1; i >= 0; i-- {
+			if t.active[i] == task {
+				t.active = append(t.active[:i], t.active[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+// Record a request made to an endpoint
+func (t *Tracker) Record(endpoint string, requestBytes uint64, responseBytes uint64, failed bool) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	task := OtherTask
+	if len(t.active) > 0 {
+		task = t.active[len(t.active)-1]
+	}
+	key := usageKey{endpoint: endpoint, task: task}
+	usage, exists := t.usage[key]
+	if !exists {
+		usage = &Usage{
+			Endpoint: endpoint,
+			Task:     task,
+		}
+		t.usage[key] = usage
+	}
+	usage.Requests++
+	if failed {
+		usage.Errors++
+	}
+	usage.RequestBytes += requestBytes
+	usage.ResponseBytes += responseBytes
+}
+
+// Get a copy of the usage, sorted by endpoint and then by task
+func (t *Tracker) GetUsage() []Usage {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	usage := make([]Usage, 0, len(t.usage))
+	for _, entry := range t.usage {
+		usage = append(usage, *entry)
+	}
+	sort.Slice(usage, func(i, j int) bool {
+		if usage[i].Endpoint != usage[j].Endpoint {
+			return usage[i].Endpoint < usage[j].Endpoint
+		}
+		return usage[i].Task < usage[j].Task
+	})
+	return usage
+}
+
+// Save the usage for the provided daemon to disk, replacing the previous file atomically
+func (t *Tracker) Save(daemon string, path string) error {
+	if path == "" {
+		return nil
+	}
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return fmt.Errorf("error creating RPC usage directory: %w", err)
+	}
+
+	bytes, err := json.Marshal(DaemonUsage{
+		Daemon:  daemon,
+		Since:   t.since,
+		Updated: time.Now(),
+		Usage:   t.GetUsage(),
+	})
+	if err != nil {
+		return fmt.Errorf("error serializing RPC usage: %w", err)
+	}
+
+	tempPath := path + ".tmp"
+	err = os.WriteFile(tempPath, bytes, 0644)
+	if err != nil {
+		return fmt.Errorf("error writing RPC usage file [%s]: %w", tempPath, err)
+	}
+	err = os.Rename(tempPath, path)
+	if err != nil {
+		return fmt.Errorf("error replacing RPC usage file [%s]: %w", path, err)
+	}
+	return nil
+}
+
+// Load the usage a daemon saved to the provided path. Returns nil if the daemon hasn't saved any yet.
+func LoadDaemonUsage(path string) (*DaemonUsage, error) {
+	bytes, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading RPC usage file [%s]: %w", path, err)
+	}
+
+	var usage DaemonUsage
+	err = json.Unmarshal(bytes, &usage)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing RPC usage file [%s]: %w", path, err)
+	}
+	return &usage, nil
+}
@@ -0,0 +1,65 @@
+package rpcusage
+
+import (
+	"io"
+	"net/http"
+	"sync"
+)
+
+// An HTTP round tripper that records every request it sends into the tracker
+type roundTripper struct {
+	endpoint string
+	base     http.RoundTripper
+}
+
+// Wrap an HTTP round tripper so the requests it sends are recorded as usage of the provided endpoint.
+// The endpoint should be a label like "primary EC" rather than the URL, which can hold a provider's API key.
+func NewRoundTripper(endpoint string, base http.RoundTripper) http.RoundTripper {
+	return &roundTripper{
+		endpoint: endpoint,
+		base:     base,
+	}
+}
+
+func (t *roundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	requestBytes := uint64(0)
+	if request.ContentLength > 0 {
+		requestBytes = uint64(request.ContentLength)
+	}
+
+	response, err := t.base.RoundTrip(request)
+	if err != nil {
+		tracker.Record(t.endpoint, requestBytes, 0, true)
+		return nil, err
+	}
+
+	// The response is only counted once its body has been read, since that's when its size is known
+	response.Body = &countingBody{
+		body: response.Body,
+		onClose: func(responseBytes uint64) {
+			tracker.Record(t.endpoint, requestBytes, responseBytes, response.StatusCode >= 400)
+		},
+	}
+	return response, nil
+}
+
+// A response body that counts the bytes read from it, and reports them when it's closed
+type countingBody struct {
+	body    io.ReadCloser
+	count   uint64
+	onClose func(uint64)
+	once    sync.Once
+}
+
+func (b *countingBody) Read(p []byte) (int, error) {
+	n, err := b.body.Read(p)
+	b.count += uint64(n)
+	return n, err
+}
+
+func (b *countingBody) Close() error {
+	b.once.Do(func() {
+		b.onClose(b.count)
+	})
+	return b.body.Close()
+}
@@ -0,0 +1,88 @@
+package schedule
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Picks how long a daemon loop should wait before its next run.
+// The loop polls at its idle interval most of the time, but some moments matter more than others (a rewards interval ending,
+// a minipool's scrub window closing, one of the node's proposals); around those the loop polls at the active interval instead,
+// so it reacts quickly when it matters without paying for that responsiveness the rest of the time.
+type Scheduler struct {
+	active    time.Duration
+	window    time.Duration
+	lock      *sync.Mutex
+	deadlines map[string]time.Time
+}
+
+// Create a new scheduler that polls at the active interval from the window before each deadline until the window after it
+func NewScheduler(active time.Duration, window time.Duration) *Scheduler {
+	return &Scheduler{
+		active:    active,
+		window:    window,
+		lock:      &sync.Mutex{},
+		deadlines: map[string]time.Time{},
+	}
+}
+
+// Set the time of a deadline, replacing the previous time if the deadline was already set.
+// Deadlines are dropped once their window has passed.
+func (s *Scheduler) SetDeadline(name string, deadline time.Time) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.deadlines[name] = deadline
+}
+
+// Remove a deadline that no longer applies
+func (s *Scheduler) ClearDeadline(name string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.deadlines, name)
+}
+
+// Get how long to wait before the next run given the loop's idle interval, and the name of the deadline that shortened it (if any).
+// The wait is never shorter than the active interval.
+func (s *Scheduler) GetInterval(idle time.Duration) (time.Duration, string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	now := time.Now()
+	interval := idle
+	reason := ""
+	for _, name := range s.getNames() {
+		deadline := s.deadlines[name]
+		windowStart := deadline.Add(-s.window)
+		windowEnd := deadline.Add(s.window)
+		if now.After(windowEnd) {
+			delete(s.deadlines, name)
+			continue
+		}
+
+		// Inside the window, so poll as fast as allowed; otherwise wake up when the window opens
+		var wait time.Duration
+		if now.Before(windowStart) {
+			wait = windowStart.Sub(now)
+		}
+		if wait < interval {
+			interval = wait
+			reason = name
+		}
+	}
+
+	if interval < s.active {
+		interval = s.active
+	}
+	return interval, reason
+}
+
+// Get the names of the deadlines in order, so ties are broken the same way every time
+func (s *Scheduler) getNames() []string {
+	names := make([]string, 0, len(s.deadlines))
+	for name := range s.deadlines {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
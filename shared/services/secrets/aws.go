@@ -0,0 +1,88 @@
+package secrets
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// The error the AWS CLI reports when a secret doesn't exist
+const awsNotFoundError string = "ResourceNotFoundException"
+
+// Keeps secrets in AWS Secrets Manager through the AWS CLI, so its usual credential chain (profiles, instance roles) applies
+type awsStore struct {
+	region string
+	prefix string
+}
+
+func newAwsStore(cfg *config.RocketPoolConfig) *awsStore {
+	return &awsStore{
+		region: cfg.Secrets.AwsRegion.Value.(string),
+		prefix: cfg.Secrets.AwsSecretPrefix.Value.(string),
+	}
+}
+
+func (s *awsStore) Get(name string) (string, bool, error) {
+	value, _, err := s.run("", "get-secret-value", "--secret-id", s.prefix+name, "--query", "SecretString", "--output", "text")
+	if err != nil {
+		if strings.Contains(err.Error(), awsNotFoundError) {
+			return "", false, nil
+		}
+		return "", false, fmt.Errorf("error reading %s from AWS Secrets Manager: %w", name, err)
+	}
+	return value, true, nil
+}
+
+func (s *awsStore) Set(name string, value string) error {
+	// The secret is passed as JSON input on stdin, which keeps it out of the process list
+	input, err := json.Marshal(map[string]string{
+		"SecretId":     s.prefix + name,
+		"SecretString": value,
+	})
+	if err != nil {
+		return fmt.Errorf("error encoding %s for AWS Secrets Manager: %w", name, err)
+	}
+	_, _, err = s.run(string(input), "put-secret-value", "--cli-input-json", "file:///dev/stdin")
+	if err == nil {
+		return nil
+	}
+	if !strings.Contains(err.Error(), awsNotFoundError) {
+		return fmt.Errorf("error saving %s to AWS Secrets Manager: %w", name, err)
+	}
+
+	// Create the secret if it doesn't exist yet
+	input, err = json.Marshal(map[string]string{
+		"Name":         s.prefix + name,
+		"SecretString": value,
+	})
+	if err != nil {
+		return fmt.Errorf("error encoding %s for AWS Secrets Manager: %w", name, err)
+	}
+	if _, _, err := s.run(string(input), "create-secret", "--cli-input-json", "file:///dev/stdin"); err != nil {
+		return fmt.Errorf("error creating %s in AWS Secrets Manager: %w", name, err)
+	}
+	return nil
+}
+
+func (s *awsStore) Delete(name string) error {
+	_, _, err := s.run("", "delete-secret", "--secret-id", s.prefix+name, "--force-delete-without-recovery")
+	if err != nil && !strings.Contains(err.Error(), awsNotFoundError) {
+		return fmt.Errorf("error deleting %s from AWS Secrets Manager: %w", name, err)
+	}
+	return nil
+}
+
+func (s *awsStore) String() string {
+	return "AWS Secrets Manager"
+}
+
+// Run an AWS CLI Secrets Manager command in the configured region
+func (s *awsStore) run(input string, command string, args ...string) (string, int, error) {
+	args = append([]string{"secretsmanager", command}, args...)
+	if s.region != "" {
+		args = append(args, "--region", s.region)
+	}
+	return runTool(input, "aws", args...)
+}
@@ -0,0 +1,49 @@
+package secrets
+
+import (
+	"fmt"
+
+	"github.com/alessio/shellescape"
+)
+
+// The exit code of the security tool when an item can't be found
+const keychainNotFoundExitCode int = 44
+
+// Keeps secrets in the macOS Keychain through the security tool
+type keychainStore struct{}
+
+func newKeychainStore() *keychainStore {
+	return &keychainStore{}
+}
+
+func (s *keychainStore) Get(name string) (string, bool, error) {
+	value, exitCode, err := runTool("", "security", "find-generic-password", "-s", keychainService, "-a", name, "-w")
+	if exitCode == keychainNotFoundExitCode {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("error reading %s from the Keychain: %w", name, err)
+	}
+	return value, true, nil
+}
+
+func (s *keychainStore) Set(name string, value string) error {
+	// The interactive mode reads the command from stdin, which keeps the secret out of the process list
+	command := fmt.Sprintf("add-generic-password -U -s %s -a %s -w %s\n", shellescape.Quote(keychainService), shellescape.Quote(name), shellescape.Quote(value))
+	if _, _, err := runTool(command, "security", "-i"); err != nil {
+		return fmt.Errorf("error saving %s to the Keychain: %w", name, err)
+	}
+	return nil
+}
+
+func (s *keychainStore) Delete(name string) error {
+	_, exitCode, err := runTool("", "security", "delete-generic-password", "-s", keychainService, "-a", name)
+	if err != nil && exitCode != keychainNotFoundExitCode {
+		return fmt.Errorf("error deleting %s from the Keychain: %w", name, err)
+	}
+	return nil
+}
+
+func (s *keychainStore) String() string {
+	return "the macOS Keychain"
+}
@@ -0,0 +1,44 @@
+package secrets
+
+import (
+	"fmt"
+)
+
+// Keeps secrets in the Linux Secret Service keyring through secret-tool
+type keyringStore struct{}
+
+func newKeyringStore() *keyringStore {
+	return &keyringStore{}
+}
+
+func (s *keyringStore) Get(name string) (string, bool, error) {
+	value, exitCode, err := runTool("", "secret-tool", "lookup", "service", keychainService, "name", name)
+	if exitCode == 1 && value == "" {
+		// secret-tool exits with 1 and prints nothing when the secret doesn't exist
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("error reading %s from the keyring: %w", name, err)
+	}
+	return value, true, nil
+}
+
+func (s *keyringStore) Set(name string, value string) error {
+	_, _, err := runTool(value, "secret-tool", "store", "--label", fmt.Sprintf("Rocket Pool %s", name), "service", keychainService, "name", name)
+	if err != nil {
+		return fmt.Errorf("error saving %s to the keyring: %w", name, err)
+	}
+	return nil
+}
+
+func (s *keyringStore) Delete(name string) error {
+	_, exitCode, err := runTool("", "secret-tool", "clear", "service", keychainService, "name", name)
+	if err != nil && exitCode != 1 {
+		return fmt.Errorf("error deleting %s from the keyring: %w", name, err)
+	}
+	return nil
+}
+
+func (s *keyringStore) String() string {
+	return "the Linux keyring"
+}
@@ -0,0 +1,76 @@
+package secrets
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// The names of the secrets the Smartnode keeps
+const (
+	WalletPassword string = "wallet-password"
+	ApiServerToken string = "api-server-token"
+
+	// The envelope key the validator keystore directory is encrypted with
+	ValidatorKeysEnvelopeKey string = "validator-keys-envelope-key"
+)
+
+// The service the secrets are filed under in the OS keychains
+const keychainService string = "rocketpool"
+
+// A place to keep secrets other than plaintext files in the data folder
+type Store interface {
+	// Get a secret, and whether it exists
+	Get(name string) (string, bool, error)
+
+	// Create or replace a secret
+	Set(name string, value string) error
+
+	// Delete a secret; deleting one that doesn't exist isn't an error
+	Delete(name string) error
+
+	// Describe where the secrets are kept, for messages
+	String() string
+}
+
+// Create the store for the configured backend, or nil if the secrets are kept in the data folder.
+// Daemons see the data folder at a different path than the CLI in Docker mode.
+func NewStore(cfg *config.RocketPoolConfig, daemon bool) (Store, error) {
+	switch backend := cfg.Secrets.GetBackend(); backend {
+	case config.SecretBackend_File:
+		return nil, nil
+	case config.SecretBackend_Keyring:
+		return newKeyringStore(), nil
+	case config.SecretBackend_Keychain:
+		return newKeychainStore(), nil
+	case config.SecretBackend_Vault:
+		return newVaultStore(cfg, daemon)
+	case config.SecretBackend_AwsSecretsManager:
+		return newAwsStore(cfg), nil
+	default:
+		return nil, fmt.Errorf("unknown secret backend '%s'", backend)
+	}
+}
+
+// Run a secret manager's command line tool, passing the input on stdin so secrets never appear in the process list.
+// Returns the trimmed output, and the exit code if the tool ran but failed.
+func runTool(input string, name string, args ...string) (string, int, error) {
+	cmd := exec.Command(name, args...)
+	cmd.Stdin = strings.NewReader(input)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return strings.TrimSpace(stderr.String()), exitErr.ExitCode(), fmt.Errorf("%s failed: %s", name, strings.TrimSpace(stderr.String()))
+	}
+	if err != nil {
+		return "", 0, fmt.Errorf("error running %s: %w", name, err)
+	}
+	return strings.TrimRight(stdout.String(), "\r\n"), 0, nil
+}
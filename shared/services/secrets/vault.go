@@ -0,0 +1,136 @@
+package secrets
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// How long to wait for Vault to respond
+var vaultTimeout, _ = time.ParseDuration("10s")
+
+// Keeps secrets in a HashiCorp Vault KV version 2 secrets engine, with each secret's value in a "value" field at its own path
+type vaultStore struct {
+	address   string
+	mount     string
+	path      string
+	tokenPath string
+	client    *http.Client
+}
+
+// The data of a KV version 2 secret
+type vaultSecret struct {
+	Data struct {
+		Data map[string]string `json:"data"`
+	} `json:"data"`
+}
+
+func newVaultStore(cfg *config.RocketPoolConfig, daemon bool) (*vaultStore, error) {
+	address := strings.TrimRight(cfg.Secrets.VaultAddress.Value.(string), "/")
+	if address == "" {
+		return nil, errors.New("the Vault address hasn't been set")
+	}
+	return &vaultStore{
+		address:   address,
+		mount:     strings.Trim(cfg.Secrets.VaultMount.Value.(string), "/"),
+		path:      strings.Trim(cfg.Secrets.VaultPath.Value.(string), "/"),
+		tokenPath: cfg.Secrets.GetVaultTokenPath(daemon),
+		client:    &http.Client{Timeout: vaultTimeout},
+	}, nil
+}
+
+func (s *vaultStore) Get(name string) (string, bool, error) {
+	body, status, err := s.request(http.MethodGet, "data", name, nil)
+	if err != nil {
+		return "", false, err
+	}
+	if status == http.StatusNotFound {
+		return "", false, nil
+	}
+	var secret vaultSecret
+	if err := json.Unmarshal(body, &secret); err != nil {
+		return "", false, fmt.Errorf("error decoding %s from Vault: %w", name, err)
+	}
+	value, exists := secret.Data.Data["value"]
+	return value, exists, nil
+}
+
+func (s *vaultStore) Set(name string, value string) error {
+	payload, err := json.Marshal(map[string]interface{}{
+		"data": map[string]string{
+			"value": value,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("error encoding %s for Vault: %w", name, err)
+	}
+	_, _, err = s.request(http.MethodPost, "data", name, payload)
+	return err
+}
+
+func (s *vaultStore) Delete(name string) error {
+	// Deleting the metadata removes every version, so old versions of the secret don't linger
+	_, _, err := s.request(http.MethodDelete, "metadata", name, nil)
+	return err
+}
+
+func (s *vaultStore) String() string {
+	return fmt.Sprintf("Vault at %s", s.address)
+}
+
+// Send a request for a secret to Vault. Returns the response body and status; missing secrets aren't errors.
+func (s *vaultStore) request(method string, kind string, name string, payload []byte) ([]byte, int, error) {
+	token, err := s.getToken()
+	if err != nil {
+		return nil, 0, err
+	}
+	url := fmt.Sprintf("%s/v1/%s/%s/%s/%s", s.address, s.mount, kind, s.path, name)
+	request, err := http.NewRequest(method, url, bytes.NewReader(payload))
+	if err != nil {
+		return nil, 0, fmt.Errorf("error creating Vault request: %w", err)
+	}
+	request.Header.Set("X-Vault-Token", token)
+	if payload != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	response, err := s.client.Do(request)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error contacting Vault at %s: %w", s.address, err)
+	}
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error reading Vault response: %w", err)
+	}
+	if response.StatusCode == http.StatusNotFound {
+		return body, response.StatusCode, nil
+	}
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return nil, response.StatusCode, fmt.Errorf("Vault returned %s for %s: %s", response.Status, name, strings.TrimSpace(string(body)))
+	}
+	return body, response.StatusCode, nil
+}
+
+// Get the token to authenticate with; it's read on every request so tokens renewed by Vault Agent are picked up
+func (s *vaultStore) getToken() (string, error) {
+	if s.tokenPath == "" {
+		token := os.Getenv(config.VaultTokenEnvVar)
+		if token == "" {
+			return "", fmt.Errorf("there's no Vault token file configured and %s isn't set", config.VaultTokenEnvVar)
+		}
+		return token, nil
+	}
+	token, err := os.ReadFile(s.tokenPath)
+	if err != nil {
+		return "", fmt.Errorf("error reading Vault token [%s]: %w", s.tokenPath, err)
+	}
+	return strings.TrimSpace(string(token)), nil
+}
@@ -9,20 +9,27 @@ import (
 	"github.com/docker/docker/client"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/fatih/color"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	bcclient "github.com/rocket-pool/smartnode/shared/services/beacon/client"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/services/contracts"
+	"github.com/rocket-pool/smartnode/shared/services/ens"
 	"github.com/rocket-pool/smartnode/shared/services/passwords"
+	"github.com/rocket-pool/smartnode/shared/services/registry"
+	"github.com/rocket-pool/smartnode/shared/services/secrets"
+	"github.com/rocket-pool/smartnode/shared/services/state"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	lhkeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/lighthouse"
 	lokeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/lodestar"
 	nmkeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/nimbus"
 	prkeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/prysm"
 	tkkeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/teku"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
 	"github.com/rocket-pool/smartnode/shared/utils/rp"
 )
 
@@ -37,26 +44,38 @@ const (
 // Service instances & initializers
 var (
 	cfg                *config.RocketPoolConfig
+	secretStore        secrets.Store
+	secretStoreErr     error
 	passwordManager    *passwords.PasswordManager
 	nodeWallet         *wallet.Wallet
 	ecManager          *ExecutionClientManager
 	bcManager          *BeaconClientManager
 	rocketPool         *rocketpool.RocketPool
+	contractRegistry   *registry.Registry
 	rplFaucet          *contracts.RPLFaucet
 	snapshotDelegation *contracts.SnapshotDelegation
+	ensResolver        *ens.Resolver
 	beaconClient       beacon.Client
+	cachingBcClient    beacon.Client
+	stateManager       *state.NetworkStateManager
+	stateManagerErr    error
 	docker             *client.Client
 
 	initCfg                sync.Once
+	initSecretStore        sync.Once
 	initPasswordManager    sync.Once
 	initNodeWallet         sync.Once
 	initECManager          sync.Once
 	initBCManager          sync.Once
 	initRocketPool         sync.Once
+	initContractRegistry   sync.Once
 	initOneInchOracle      sync.Once
 	initRplFaucet          sync.Once
 	initSnapshotDelegation sync.Once
+	initEnsResolver        sync.Once
 	initBeaconClient       sync.Once
+	initCachingBcClient    sync.Once
+	initStateManager       sync.Once
 	initDocker             sync.Once
 )
 
@@ -68,12 +87,20 @@ func GetConfig(c *cli.Context) (*config.RocketPoolConfig, error) {
 	return getConfig(c)
 }
 
+func GetSecretStore(c *cli.Context) (secrets.Store, error) {
+	cfg, err := getConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	return getSecretStore(cfg)
+}
+
 func GetPasswordManager(c *cli.Context) (*passwords.PasswordManager, error) {
 	cfg, err := getConfig(c)
 	if err != nil {
 		return nil, err
 	}
-	return getPasswordManager(cfg), nil
+	return getPasswordManager(cfg)
 }
 
 func GetWallet(c *cli.Context) (*wallet.Wallet, error) {
@@ -81,7 +108,10 @@ func GetWallet(c *cli.Context) (*wallet.Wallet, error) {
 	if err != nil {
 		return nil, err
 	}
-	pm := getPasswordManager(cfg)
+	pm, err := getPasswordManager(cfg)
+	if err != nil {
+		return nil, err
+	}
 	return getWallet(c, cfg, pm)
 }
 
@@ -116,6 +146,18 @@ func GetRocketPool(c *cli.Context) (*rocketpool.RocketPool, error) {
 	return getRocketPool(cfg, ec)
 }
 
+func GetContractRegistry(c *cli.Context) (*registry.Registry, error) {
+	cfg, err := getConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	return getContractRegistry(cfg, rp)
+}
+
 func GetRplFaucet(c *cli.Context) (*contracts.RPLFaucet, error) {
 	cfg, err := getConfig(c)
 	if err != nil {
@@ -140,6 +182,18 @@ func GetSnapshotDelegation(c *cli.Context) (*contracts.SnapshotDelegation, error
 	return getSnapshotDelegation(cfg, ec)
 }
 
+func GetEnsResolver(c *cli.Context) (*ens.Resolver, error) {
+	cfg, err := getConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := getEthClient(c, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return getEnsResolver(ec), nil
+}
+
 func GetBeaconClient(c *cli.Context) (*BeaconClientManager, error) {
 	cfg, err := getConfig(c)
 	if err != nil {
@@ -148,6 +202,35 @@ func GetBeaconClient(c *cli.Context) (*BeaconClientManager, error) {
 	return getBeaconClient(c, cfg)
 }
 
+// Get the Beacon client for rewards tree generation, which keeps finalized data in a disk cache if one is configured
+func GetRewardsBeaconClient(c *cli.Context) (beacon.Client, error) {
+	cfg, err := getConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := getBeaconClient(c, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return getCachingBeaconClient(cfg, bc)
+}
+
+func GetNetworkStateManager(c *cli.Context) (*state.NetworkStateManager, error) {
+	cfg, err := getConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := getBeaconClient(c, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return getStateManager(cfg, rp, bc)
+}
+
 func GetDocker(c *cli.Context) (*client.Client, error) {
 	return getDocker()
 }
@@ -168,11 +251,23 @@ func getConfig(c *cli.Context) (*config.RocketPoolConfig, error) {
 	return cfg, err
 }
 
-func getPasswordManager(cfg *config.RocketPoolConfig) *passwords.PasswordManager {
+func getSecretStore(cfg *config.RocketPoolConfig) (secrets.Store, error) {
+	// The error is kept so a misconfigured store never quietly falls back to the password file
+	initSecretStore.Do(func() {
+		secretStore, secretStoreErr = secrets.NewStore(cfg, true)
+	})
+	return secretStore, secretStoreErr
+}
+
+func getPasswordManager(cfg *config.RocketPoolConfig) (*passwords.PasswordManager, error) {
+	store, err := getSecretStore(cfg)
+	if err != nil {
+		return nil, err
+	}
 	initPasswordManager.Do(func() {
-		passwordManager = passwords.NewPasswordManager(os.ExpandEnv(cfg.Smartnode.GetPasswordPath()))
+		passwordManager = passwords.NewPasswordManager(os.ExpandEnv(cfg.Smartnode.GetPasswordPath()), cfg.Smartnode.GetWalletSessionPath(), store)
 	})
-	return passwordManager
+	return passwordManager, nil
 }
 
 func getWallet(c *cli.Context, cfg *config.RocketPoolConfig, pm *passwords.PasswordManager) (*wallet.Wallet, error) {
@@ -244,6 +339,19 @@ func getRocketPool(cfg *config.RocketPoolConfig, client rocketpool.ExecutionClie
 	return rocketPool, err
 }
 
+func getContractRegistry(cfg *config.RocketPoolConfig, rp *rocketpool.RocketPool) (*registry.Registry, error) {
+	var err error
+	initContractRegistry.Do(func() {
+		var eventLogInterval int
+		eventLogInterval, err = cfg.GetEventLogInterval()
+		if err != nil {
+			return
+		}
+		contractRegistry = registry.NewRegistry(rp, cfg.Smartnode.GetUpgradeHistoryPath(), big.NewInt(int64(eventLogInterval)), cfg.Smartnode.ConfirmationDepth.Value.(uint64))
+	})
+	return contractRegistry, err
+}
+
 func getRplFaucet(cfg *config.RocketPoolConfig, client rocketpool.ExecutionClient) (*contracts.RPLFaucet, error) {
 	var err error
 	initRplFaucet.Do(func() {
@@ -263,6 +371,13 @@ func getSnapshotDelegation(cfg *config.RocketPoolConfig, client rocketpool.Execu
 	return snapshotDelegation, err
 }
 
+func getEnsResolver(client rocketpool.ExecutionClient) *ens.Resolver {
+	initEnsResolver.Do(func() {
+		ensResolver = ens.NewResolver(client)
+	})
+	return ensResolver
+}
+
 func getBeaconClient(c *cli.Context, cfg *config.RocketPoolConfig) (*BeaconClientManager, error) {
 	var err error
 	initBCManager.Do(func() {
@@ -281,6 +396,28 @@ func getBeaconClient(c *cli.Context, cfg *config.RocketPoolConfig) (*BeaconClien
 	return bcManager, err
 }
 
+func getCachingBeaconClient(cfg *config.RocketPoolConfig, bc *BeaconClientManager) (beacon.Client, error) {
+	var err error
+	initCachingBcClient.Do(func() {
+		cacheSize := cfg.Smartnode.BeaconCacheSize.Value.(uint64)
+		if cacheSize == 0 {
+			cachingBcClient = bc
+			return
+		}
+		logger := log.NewColorLogger(color.FgHiBlue)
+		cachingBcClient, err = bcclient.NewCachingClient(bc, bc.GetPrimaryProvider(), cfg.Smartnode.GetBeaconCachePath(), cacheSize*1024*1024*1024, &logger)
+	})
+	return cachingBcClient, err
+}
+
+func getStateManager(cfg *config.RocketPoolConfig, rp *rocketpool.RocketPool, bc beacon.Client) (*state.NetworkStateManager, error) {
+	// The error is kept so later callers don't get a nil manager if creating it failed
+	initStateManager.Do(func() {
+		stateManager, stateManagerErr = state.NewNetworkStateManager(rp, cfg, rp.Client, bc, nil)
+	})
+	return stateManager, stateManagerErr
+}
+
 func getDocker() (*client.Client, error) {
 	var err error
 	initDocker.Do(func() {
@@ -0,0 +1,179 @@
+package shutdown
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+	"sort"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Settings
+const (
+	// How long a daemon waits for its in-flight work to finish before it exits anyway
+	DefaultTimeout = 60 * time.Second
+
+	// How long the container runtime should give a daemon to exit before killing it, which leaves room to flush its state after draining
+	StopTimeout = DefaultTimeout + 15*time.Second
+
+	pollInterval = 100 * time.Millisecond
+)
+
+// The daemon is shutting down and isn't starting new work
+var ErrShuttingDown = errors.New("the daemon is shutting down")
+
+// Coordinates a daemon's shutdown so it doesn't exit halfway through sending a transaction.
+// Once a shutdown starts, no new operations are allowed to begin; the daemon waits (for a bounded time) for the ones in flight to finish,
+// runs its drains so the servers and queues it hosts stop taking work, flushes its state stores, and only then exits.
+type Coordinator struct {
+	daemon   string
+	log      log.ColorLogger
+	lock     *sync.Mutex
+	stopping bool
+	inFlight map[string]int
+	drains   []step
+	flushers []step
+}
+
+// A named step of the shutdown
+type step struct {
+	name string
+	run  func(ctx context.Context) error
+}
+
+// Create a new shutdown coordinator for the provided daemon
+func NewCoordinator(daemon string, logger log.ColorLogger) *Coordinator {
+	return &Coordinator{
+		daemon:   daemon,
+		log:      logger,
+		lock:     &sync.Mutex{},
+		inFlight: map[string]int{},
+	}
+}
+
+// Add something to stop taking new work and wait for the work it has in flight, such as a server, when the daemon shuts down.
+// The context expires when the daemon stops waiting.
+func (c *Coordinator) AddDrain(name string, drain func(ctx context.Context) error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.drains = append(c.drains, step{name: name, run: drain})
+}
+
+// Add a state store to save once the daemon has drained, right before it exits
+func (c *Coordinator) AddFlusher(name string, flush func() error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.flushers = append(c.flushers, step{name: name, run: func(context.Context) error { return flush() }})
+}
+
+// Check if the daemon has started shutting down
+func (c *Coordinator) IsStopping() bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.stopping
+}
+
+// Run an operation that shouldn't be interrupted, such as a task that may send a transaction.
+// Returns ErrShuttingDown without running it if the daemon has started shutting down.
+func (c *Coordinator) Run(operation string, run func() error) error {
+	c.lock.Lock()
+	if c.stopping {
+		c.lock.Unlock()
+		return ErrShuttingDown
+	}
+	c.inFlight[operation]++
+	c.lock.Unlock()
+
+	defer func() {
+		c.lock.Lock()
+		c.inFlight[operation]--
+		if c.inFlight[operation] <= 0 {
+			delete(c.inFlight, operation)
+		}
+		c.lock.Unlock()
+	}()
+	return run()
+}
+
+// Shut down when the daemon gets SIGINT or SIGTERM, then exit. A second signal exits right away without waiting.
+func (c *Coordinator) HandleSignals(timeout time.Duration) {
+	signals := make(chan os.Signal, 2)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		received := <-signals
+		c.log.Printlnf("Received %s, shutting down the %s daemon (send it again to exit immediately).", received, c.daemon)
+		go func() {
+			<-signals
+			c.log.Println("Received a second signal, exiting without waiting for in-flight work.")
+			os.Exit(1)
+		}()
+		c.Shutdown(timeout)
+		os.Exit(0)
+	}()
+}
+
+// Stop starting new operations, wait up to the timeout for the ones in flight and the drains, then flush the state stores
+func (c *Coordinator) Shutdown(timeout time.Duration) {
+	c.lock.Lock()
+	c.stopping = true
+	drains := c.drains
+	flushers := c.flushers
+	c.lock.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	// Stop the servers and queues from taking new work while the tasks finish, and wait for the work they already took
+	var wg sync.WaitGroup
+	for _, drain := range drains {
+		wg.Add(1)
+		go func(drain step) {
+			defer wg.Done()
+			if err := drain.run(ctx); err != nil {
+				c.log.Warnf("WARNING: Error draining %s: %s", drain.name, err.Error())
+			}
+		}(drain)
+	}
+
+	// Wait for the operations in flight
+	operations := c.getInFlight()
+	if len(operations) > 0 {
+		c.log.Printlnf("Waiting up to %s for %v to finish...", timeout, operations)
+	}
+	for len(operations) > 0 {
+		select {
+		case <-ctx.Done():
+			c.log.Warnf("WARNING: Timed out waiting for %v to finish; any transactions they were sending may not have been recorded.", operations)
+			operations = nil
+			continue
+		case <-time.After(pollInterval):
+		}
+		operations = c.getInFlight()
+	}
+	wg.Wait()
+
+	// Save the state stores now that nothing is changing them
+	for _, flusher := range flushers {
+		if err := flusher.run(ctx); err != nil {
+			c.log.Warnf("WARNING: Error saving %s: %s", flusher.name, err.Error())
+		}
+	}
+	c.log.Printlnf("The %s daemon has shut down.", c.daemon)
+}
+
+// Get the names of the operations in flight
+func (c *Coordinator) getInFlight() []string {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	operations := make([]string, 0, len(c.inFlight))
+	for operation := range c.inFlight {
+		operations = append(operations, operation)
+	}
+	sort.Strings(operations)
+	return operations
+}
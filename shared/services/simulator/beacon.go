@@ -0,0 +1,374 @@
+package simulator
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/rocket-pool/rocketpool-go/types"
+)
+
+// The Beacon chain parameters of simulated networks
+const (
+	secondsPerSlot               uint64 = 12
+	slotsPerEpoch                uint64 = 32
+	epochsPerSyncCommitteePeriod uint64 = 256
+
+	// The epoch used for events that haven't happened, such as the exit of an active validator
+	FarFutureEpoch uint64 = 18446744073709551615
+)
+
+// A validator on the simulated Beacon chain
+type BeaconValidator struct {
+	Index                 uint64
+	Pubkey                types.ValidatorPubkey
+	WithdrawalCredentials common.Hash
+	Status                string
+	BalanceGwei           uint64
+	EffectiveBalanceGwei  uint64
+	Slashed               bool
+	ActivationEpoch       uint64
+	ExitEpoch             uint64
+	WithdrawableEpoch     uint64
+}
+
+// A proposal duty on the simulated Beacon chain
+type ProposerDuty struct {
+	ValidatorIndex uint64
+	Slot           uint64
+}
+
+// A simulated Beacon node serving the parts of the Beacon API the Smartnode uses, with a head slot that only advances when told to.
+// Any other path can be scripted, and messages the Smartnode submits are recorded so tests can check them.
+type BeaconServer struct {
+	*server
+	lock sync.Mutex
+
+	genesisTime     time.Time
+	chainID         uint64
+	depositContract common.Address
+	forkVersion     []byte
+
+	headSlot       uint64
+	finalizedEpoch uint64
+	syncDistance   uint64
+	syncing        bool
+
+	validators     []BeaconValidator
+	proposerDuties map[uint64][]ProposerDuty
+	liveness       map[uint64]bool
+	blocks         map[string]json.RawMessage
+	handlers       map[string]http.HandlerFunc
+	submissions    map[string][]json.RawMessage
+}
+
+// Create a simulated Beacon node for the provided chain, with its genesis at the provided time.
+// The address can use port 0 to pick a free port; call Start to begin serving.
+func NewBeaconServer(address string, chainID uint64, depositContract common.Address, genesisTime time.Time) *BeaconServer {
+	s := &BeaconServer{
+		genesisTime:     genesisTime,
+		chainID:         chainID,
+		depositContract: depositContract,
+		forkVersion:     []byte{0, 0, 0, 0},
+		validators:      []BeaconValidator{},
+		proposerDuties:  map[uint64][]ProposerDuty{},
+		liveness:        map[uint64]bool{},
+		blocks:          map[string]json.RawMessage{},
+		handlers:        map[string]http.HandlerFunc{},
+		submissions:     map[string][]json.RawMessage{},
+	}
+	s.server = newServer(address, http.HandlerFunc(s.handle))
+	return s
+}
+
+// Move the head to a slot; the chain finalizes two epochs behind it unless SetFinalizedEpoch says otherwise
+func (s *BeaconServer) SetHeadSlot(slot uint64) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.headSlot = slot
+	headEpoch := slot / slotsPerEpoch
+	if headEpoch >= 2 && s.finalizedEpoch < headEpoch-2 {
+		s.finalizedEpoch = headEpoch - 2
+	}
+}
+
+// Set the latest finalized epoch, such as to simulate a loss of finality
+func (s *BeaconServer) SetFinalizedEpoch(epoch uint64) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.finalizedEpoch = epoch
+}
+
+// Report that the node is syncing and how far behind the head it is, or pass false to report that it's synced
+func (s *BeaconServer) SetSyncing(syncing bool, syncDistance uint64) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.syncing = syncing
+	s.syncDistance = syncDistance
+}
+
+// Add a validator, or replace the one with the same index
+func (s *BeaconServer) SetValidator(validator BeaconValidator) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for i, existing := range s.validators {
+		if existing.Index == validator.Index {
+			s.validators[i] = validator
+			return
+		}
+	}
+	s.validators = append(s.validators, validator)
+}
+
+// Set the proposal duties for an epoch
+func (s *BeaconServer) SetProposerDuties(epoch uint64, duties []ProposerDuty) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.proposerDuties[epoch] = duties
+}
+
+// Set whether a validator was seen attesting
+func (s *BeaconServer) SetLiveness(index uint64, isLive bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.liveness[index] = isLive
+}
+
+// Set the block returned for a block ID, such as a slot number; blocks that aren't set are reported missing
+func (s *BeaconServer) SetBlock(blockID string, block json.RawMessage) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.blocks[blockID] = block
+}
+
+// Answer requests to a path with a handler instead of the built-in behavior
+func (s *BeaconServer) HandlePath(method string, path string, handler http.HandlerFunc) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.handlers[method+" "+path] = handler
+}
+
+// Get the messages submitted to a pool path, such as /eth/v1/beacon/pool/voluntary_exits
+func (s *BeaconServer) GetSubmissions(path string) []json.RawMessage {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return append([]json.RawMessage{}, s.submissions[path]...)
+}
+
+// Serve a Beacon API request
+func (s *BeaconServer) handle(w http.ResponseWriter, r *http.Request) {
+	path := r.URL.Path
+	s.lock.Lock()
+	handler, exists := s.handlers[r.Method+" "+path]
+	s.lock.Unlock()
+	if exists {
+		s.record(r.Method+" "+path, http.StatusOK)
+		handler(w, r)
+		return
+	}
+
+	status, response := s.route(r, path)
+	s.record(r.Method+" "+path, status)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// Answer a request with a status and a response body
+func (s *BeaconServer) route(r *http.Request, path string) (int, interface{}) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	// Pool submissions
+	if r.Method == http.MethodPost && strings.HasPrefix(path, "/eth/v1/beacon/pool/") {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			return getBeaconError(http.StatusBadRequest, err.Error())
+		}
+		s.submissions[path] = append(s.submissions[path], json.RawMessage(body))
+		return http.StatusOK, map[string]interface{}{}
+	}
+
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	switch {
+	case path == "/eth/v1/node/syncing":
+		return http.StatusOK, getData(map[string]interface{}{
+			"is_syncing":    s.syncing,
+			"head_slot":     formatUint(s.headSlot),
+			"sync_distance": formatUint(s.syncDistance),
+		})
+
+	case path == "/eth/v1/node/version":
+		return http.StatusOK, getData(map[string]string{"version": simulatorVersion})
+
+	case path == "/eth/v1/config/spec":
+		return http.StatusOK, getData(map[string]string{
+			"SECONDS_PER_SLOT":                 formatUint(secondsPerSlot),
+			"SLOTS_PER_EPOCH":                  formatUint(slotsPerEpoch),
+			"EPOCHS_PER_SYNC_COMMITTEE_PERIOD": formatUint(epochsPerSyncCommitteePeriod),
+		})
+
+	case path == "/eth/v1/config/deposit_contract":
+		return http.StatusOK, getData(map[string]interface{}{
+			"chain_id": formatUint(s.chainID),
+			"address":  s.depositContract,
+		})
+
+	case path == "/eth/v1/beacon/genesis":
+		return http.StatusOK, getData(map[string]string{
+			"genesis_time":            formatUint(uint64(s.genesisTime.Unix())),
+			"genesis_fork_version":    hexutil.Encode(s.forkVersion),
+			"genesis_validators_root": common.Hash{}.Hex(),
+		})
+
+	case len(parts) == 6 && parts[2] == "beacon" && parts[3] == "states" && parts[5] == "finality_checkpoints":
+		return http.StatusOK, getData(map[string]interface{}{
+			"previous_justified": getCheckpoint(s.finalizedEpoch),
+			"current_justified":  getCheckpoint(s.finalizedEpoch + 1),
+			"finalized":          getCheckpoint(s.finalizedEpoch),
+		})
+
+	case len(parts) == 6 && parts[2] == "beacon" && parts[3] == "states" && parts[5] == "fork":
+		return http.StatusOK, getData(map[string]string{
+			"previous_version": hexutil.Encode(s.forkVersion),
+			"current_version":  hexutil.Encode(s.forkVersion),
+			"epoch":            "0",
+		})
+
+	case len(parts) == 6 && parts[2] == "beacon" && parts[3] == "states" && parts[5] == "validators":
+		return http.StatusOK, getData(s.getValidators(r.URL.Query().Get("id")))
+
+	case len(parts) == 5 && parts[1] == "v2" && parts[2] == "beacon" && parts[3] == "blocks":
+		blockID := parts[4]
+		if blockID == "head" {
+			blockID = formatUint(s.headSlot)
+		}
+		block, exists := s.blocks[blockID]
+		if !exists {
+			return getBeaconError(http.StatusNotFound, "Could not find requested block")
+		}
+		return http.StatusOK, getData(block)
+
+	case len(parts) == 6 && parts[1] == "v1" && parts[2] == "beacon" && parts[3] == "blocks" && parts[5] == "attestations":
+		block, exists := s.blocks[parts[4]]
+		if !exists {
+			return getBeaconError(http.StatusNotFound, "Could not find requested block")
+		}
+		var contents struct {
+			Message struct {
+				Body struct {
+					Attestations []json.RawMessage `json:"attestations"`
+				} `json:"body"`
+			} `json:"message"`
+		}
+		if err := json.Unmarshal(block, &contents); err != nil {
+			return getBeaconError(http.StatusInternalServerError, fmt.Sprintf("invalid block [%s]: %s", parts[4], err.Error()))
+		}
+		attestations := contents.Message.Body.Attestations
+		if attestations == nil {
+			attestations = []json.RawMessage{}
+		}
+		return http.StatusOK, getData(attestations)
+
+	case len(parts) == 6 && parts[2] == "validator" && parts[3] == "duties" && parts[4] == "proposer":
+		epoch, err := strconv.ParseUint(parts[5], 10, 64)
+		if err != nil {
+			return getBeaconError(http.StatusBadRequest, fmt.Sprintf("invalid epoch [%s]", parts[5]))
+		}
+		duties := []map[string]string{}
+		for _, duty := range s.proposerDuties[epoch] {
+			duties = append(duties, map[string]string{
+				"validator_index": formatUint(duty.ValidatorIndex),
+				"slot":            formatUint(duty.Slot),
+			})
+		}
+		return http.StatusOK, getData(duties)
+
+	case len(parts) == 5 && parts[2] == "validator" && parts[3] == "liveness":
+		var indices []string
+		if err := json.NewDecoder(r.Body).Decode(&indices); err != nil {
+			return getBeaconError(http.StatusBadRequest, err.Error())
+		}
+		liveness := []map[string]interface{}{}
+		for _, index := range indices {
+			parsed, _ := strconv.ParseUint(index, 10, 64)
+			liveness = append(liveness, map[string]interface{}{
+				"index":   index,
+				"is_live": s.liveness[parsed],
+			})
+		}
+		return http.StatusOK, getData(liveness)
+
+	default:
+		return getBeaconError(http.StatusNotFound, fmt.Sprintf("the simulator doesn't serve %s %s", r.Method, path))
+	}
+}
+
+// Get the validators matching a comma-separated list of pubkeys and indices, or all of them if it's empty; the lock must be held
+func (s *BeaconServer) getValidators(ids string) []interface{} {
+	wanted := map[string]bool{}
+	for _, id := range strings.Split(ids, ",") {
+		if id != "" {
+			wanted[strings.ToLower(id)] = true
+		}
+	}
+	validators := []interface{}{}
+	for _, validator := range s.validators {
+		index := formatUint(validator.Index)
+		pubkey := hexutil.Encode(validator.Pubkey[:])
+		if len(wanted) > 0 && !wanted[index] && !wanted[pubkey] {
+			continue
+		}
+		validators = append(validators, map[string]interface{}{
+			"index":   index,
+			"balance": formatUint(validator.BalanceGwei),
+			"status":  validator.Status,
+			"validator": map[string]interface{}{
+				"pubkey":                       pubkey,
+				"withdrawal_credentials":       validator.WithdrawalCredentials.Hex(),
+				"effective_balance":            formatUint(validator.EffectiveBalanceGwei),
+				"slashed":                      validator.Slashed,
+				"activation_eligibility_epoch": formatUint(validator.ActivationEpoch),
+				"activation_epoch":             formatUint(validator.ActivationEpoch),
+				"exit_epoch":                   formatUint(validator.ExitEpoch),
+				"withdrawable_epoch":           formatUint(validator.WithdrawableEpoch),
+			},
+		})
+	}
+	return validators
+}
+
+// Wrap a response in the data field, like every Beacon API response
+func getData(data interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"data": data,
+	}
+}
+
+// Get a Beacon API error response
+func getBeaconError(status int, message string) (int, interface{}) {
+	return status, map[string]interface{}{
+		"code":    status,
+		"message": message,
+	}
+}
+
+// Get a checkpoint for an epoch
+func getCheckpoint(epoch uint64) map[string]string {
+	return map[string]string{
+		"epoch": formatUint(epoch),
+		"root":  common.Hash{}.Hex(),
+	}
+}
+
+// Format an integer the way the Beacon API does
+func formatUint(value uint64) string {
+	return strconv.FormatUint(value, 10)
+}
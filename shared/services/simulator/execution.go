@@ -0,0 +1,731 @@
+package simulator
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// The time between simulated blocks
+const blockTime uint64 = 12
+
+// The gas limit and base fee of simulated blocks
+const (
+	blockGasLimit uint64 = 30000000
+	baseFeeGwei   int64  = 10
+)
+
+// The client version the simulated clients report
+const simulatorVersion string = "Simulator/v1.0.0"
+
+// Answers a JSON-RPC method with its result, or an error to send back to the caller
+type MethodHandler func(params []json.RawMessage) (interface{}, error)
+
+// Answers an eth_call to a contract function with its return data
+type CallHandler func(data []byte) ([]byte, error)
+
+// The sync status the simulated Execution client reports while syncing
+type ExecutionSyncStatus struct {
+	StartingBlock uint64
+	CurrentBlock  uint64
+	HighestBlock  uint64
+}
+
+// A JSON-RPC error, sent back as the error object of the response
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string {
+	return e.Message
+}
+
+// A JSON-RPC request and response
+type rpcRequest struct {
+	Version string            `json:"jsonrpc"`
+	ID      json.RawMessage   `json:"id"`
+	Method  string            `json:"method"`
+	Params  []json.RawMessage `json:"params"`
+}
+type rpcResponse struct {
+	Version string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+// A transaction that was sent to the simulated Execution client, and the block it was included in if it's been mined
+type sentTransaction struct {
+	tx          *types.Transaction
+	from        common.Address
+	blockNumber *uint64
+}
+
+// A simulated Execution client serving the JSON-RPC API over HTTP, with a chain that only advances when told to.
+// Contract calls and any other method can be scripted, so tests can drive the Smartnode without a synced node.
+type ExecutionServer struct {
+	*server
+	lock sync.Mutex
+
+	chainID  uint64
+	blocks   []*types.Header
+	syncing  *ExecutionSyncStatus
+	reorgs   uint64
+	balances map[common.Address]*big.Int
+	nonces   map[common.Address]uint64
+	code     map[common.Address][]byte
+	calls    map[string]CallHandler
+	methods  map[string]MethodHandler
+	logs     []types.Log
+	txs      map[common.Hash]*sentTransaction
+	txOrder  []common.Hash
+}
+
+// Create a simulated Execution client for the provided chain, with its genesis block at the provided time.
+// The address can use port 0 to pick a free port; call Start to begin serving.
+func NewExecutionServer(address string, chainID uint64, genesisTime time.Time) *ExecutionServer {
+	s := &ExecutionServer{
+		chainID:  chainID,
+		balances: map[common.Address]*big.Int{},
+		nonces:   map[common.Address]uint64{},
+		code:     map[common.Address][]byte{},
+		calls:    map[string]CallHandler{},
+		methods:  map[string]MethodHandler{},
+		txs:      map[common.Hash]*sentTransaction{},
+	}
+	s.blocks = []*types.Header{s.newHeader(nil, uint64(genesisTime.Unix()))}
+	s.server = newServer(address, http.HandlerFunc(s.handle))
+	return s
+}
+
+// Change the chain ID the client reports, such as to simulate pointing the node at the wrong network
+func (s *ExecutionServer) SetChainID(chainID uint64) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.chainID = chainID
+}
+
+// Report that the client is syncing, or pass nil to report that it's synced
+func (s *ExecutionServer) SetSyncing(status *ExecutionSyncStatus) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.syncing = status
+}
+
+// Set the ETH balance of an address, in wei
+func (s *ExecutionServer) SetBalance(address common.Address, balance *big.Int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.balances[address] = new(big.Int).Set(balance)
+}
+
+// Set the code deployed at an address
+func (s *ExecutionServer) SetCode(address common.Address, code []byte) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.code[address] = code
+}
+
+// Answer eth_calls to a contract function, identified by its 4-byte selector. Calls nothing handles revert.
+func (s *ExecutionServer) HandleCall(contract common.Address, selector []byte, handler CallHandler) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.calls[getCallKey(contract, selector)] = handler
+}
+
+// Answer a JSON-RPC method with a handler, replacing the built-in one if there is one
+func (s *ExecutionServer) HandleMethod(method string, handler MethodHandler) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.methods[method] = handler
+}
+
+// Add an event log to the head block
+func (s *ExecutionServer) AddLog(log types.Log) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	head := s.blocks[len(s.blocks)-1]
+	log.BlockNumber = head.Number.Uint64()
+	log.BlockHash = head.Hash()
+	log.Index = uint(len(s.logs))
+	s.logs = append(s.logs, log)
+}
+
+// Get the number of the head block
+func (s *ExecutionServer) GetHeadNumber() uint64 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return uint64(len(s.blocks) - 1)
+}
+
+// Get the transactions sent to the client, in the order they arrived
+func (s *ExecutionServer) GetSentTransactions() []*types.Transaction {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	txs := make([]*types.Transaction, len(s.txOrder))
+	for i, hash := range s.txOrder {
+		txs[i] = s.txs[hash].tx
+	}
+	return txs
+}
+
+// Add blocks to the chain. Pending transactions are included in the first one.
+func (s *ExecutionServer) Mine(count int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for i := 0; i < count; i++ {
+		s.mineBlock()
+	}
+}
+
+// Replace the last blocks with a different branch one block longer, dropping their logs and returning their transactions to
+// the pending pool, like a reorg on a real chain
+func (s *ExecutionServer) Reorg(depth int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if depth >= len(s.blocks) {
+		depth = len(s.blocks) - 1
+	}
+	forkBlock := uint64(len(s.blocks) - 1 - depth)
+	s.blocks = s.blocks[:forkBlock+1]
+	logs := []types.Log{}
+	for _, log := range s.logs {
+		if log.BlockNumber <= forkBlock {
+			logs = append(logs, log)
+		}
+	}
+	s.logs = logs
+	for _, sent := range s.txs {
+		if sent.blockNumber != nil && *sent.blockNumber > forkBlock {
+			sent.blockNumber = nil
+		}
+	}
+	s.reorgs++
+	for i := 0; i <= depth; i++ {
+		s.mineBlock()
+	}
+}
+
+// Add a block to the chain; the lock must be held
+func (s *ExecutionServer) mineBlock() {
+	parent := s.blocks[len(s.blocks)-1]
+	header := s.newHeader(parent, parent.Time+blockTime)
+	s.blocks = append(s.blocks, header)
+	number := header.Number.Uint64()
+	for _, hash := range s.txOrder {
+		sent := s.txs[hash]
+		if sent.blockNumber == nil {
+			sent.blockNumber = &number
+		}
+	}
+}
+
+// Create a block header; the reorg count goes in the extra data so branches get different hashes
+func (s *ExecutionServer) newHeader(parent *types.Header, timestamp uint64) *types.Header {
+	header := &types.Header{
+		Difficulty: big.NewInt(0),
+		Number:     big.NewInt(0),
+		GasLimit:   blockGasLimit,
+		Time:       timestamp,
+		Extra:      []byte(fmt.Sprintf("simulator-%d", s.reorgs)),
+		BaseFee:    big.NewInt(baseFeeGwei * 1e9),
+	}
+	if parent != nil {
+		header.ParentHash = parent.Hash()
+		header.Number = new(big.Int).Add(parent.Number, big.NewInt(1))
+	}
+	return header
+}
+
+// Serve a JSON-RPC request, or a batch of them
+func (s *ExecutionServer) handle(w http.ResponseWriter, r *http.Request) {
+	var body json.RawMessage
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var response interface{}
+	if len(body) > 0 && body[0] == '[' {
+		var requests []rpcRequest
+		if err := json.Unmarshal(body, &requests); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		responses := make([]rpcResponse, len(requests))
+		for i, request := range requests {
+			responses[i] = s.call(request)
+		}
+		response = responses
+	} else {
+		var request rpcRequest
+		if err := json.Unmarshal(body, &request); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		response = s.call(request)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// Run a single JSON-RPC request
+func (s *ExecutionServer) call(request rpcRequest) rpcResponse {
+	response := rpcResponse{
+		Version: "2.0",
+		ID:      request.ID,
+	}
+	result, err := s.runMethod(request.Method, request.Params)
+	if err != nil {
+		rpcErr, ok := err.(*rpcError)
+		if !ok {
+			rpcErr = &rpcError{Code: -32000, Message: err.Error()}
+		}
+		response.Error = rpcErr
+	} else if result == nil {
+		response.Result = json.RawMessage("null")
+	} else {
+		response.Result = result
+	}
+	s.record(request.Method, http.StatusOK)
+	return response
+}
+
+// Answer a JSON-RPC method
+func (s *ExecutionServer) runMethod(method string, params []json.RawMessage) (interface{}, error) {
+	s.lock.Lock()
+	handler, exists := s.methods[method]
+	s.lock.Unlock()
+	if exists {
+		return handler(params)
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	switch method {
+	case "web3_clientVersion":
+		return simulatorVersion, nil
+
+	case "eth_chainId":
+		return hexutil.Uint64(s.chainID), nil
+
+	case "net_version":
+		return strconv.FormatUint(s.chainID, 10), nil
+
+	case "eth_syncing":
+		if s.syncing == nil {
+			return false, nil
+		}
+		return map[string]hexutil.Uint64{
+			"startingBlock": hexutil.Uint64(s.syncing.StartingBlock),
+			"currentBlock":  hexutil.Uint64(s.syncing.CurrentBlock),
+			"highestBlock":  hexutil.Uint64(s.syncing.HighestBlock),
+		}, nil
+
+	case "eth_blockNumber":
+		return hexutil.Uint64(len(s.blocks) - 1), nil
+
+	case "eth_getBlockByNumber":
+		var tag string
+		if err := getParam(params, 0, &tag); err != nil {
+			return nil, err
+		}
+		number, err := s.getBlockNumber(tag)
+		if err != nil {
+			return nil, err
+		}
+		if number >= uint64(len(s.blocks)) {
+			return nil, nil
+		}
+		return s.getBlock(s.blocks[number])
+
+	case "eth_getBlockByHash":
+		var hash common.Hash
+		if err := getParam(params, 0, &hash); err != nil {
+			return nil, err
+		}
+		for _, header := range s.blocks {
+			if header.Hash() == hash {
+				return s.getBlock(header)
+			}
+		}
+		return nil, nil
+
+	case "eth_getBalance":
+		var address common.Address
+		if err := getParam(params, 0, &address); err != nil {
+			return nil, err
+		}
+		balance, exists := s.balances[address]
+		if !exists {
+			balance = big.NewInt(0)
+		}
+		return (*hexutil.Big)(balance), nil
+
+	case "eth_getCode":
+		var address common.Address
+		if err := getParam(params, 0, &address); err != nil {
+			return nil, err
+		}
+		return hexutil.Bytes(s.code[address]), nil
+
+	case "eth_getTransactionCount":
+		var address common.Address
+		if err := getParam(params, 0, &address); err != nil {
+			return nil, err
+		}
+		return hexutil.Uint64(s.nonces[address]), nil
+
+	case "eth_gasPrice":
+		return (*hexutil.Big)(big.NewInt(baseFeeGwei*1e9 + 1e9)), nil
+
+	case "eth_maxPriorityFeePerGas":
+		return (*hexutil.Big)(big.NewInt(1e9)), nil
+
+	case "eth_feeHistory":
+		return s.getFeeHistory(params)
+
+	case "eth_estimateGas":
+		return hexutil.Uint64(100000), nil
+
+	case "eth_call":
+		var call struct {
+			To    *common.Address `json:"to"`
+			Data  hexutil.Bytes   `json:"data"`
+			Input hexutil.Bytes   `json:"input"`
+		}
+		if err := getParam(params, 0, &call); err != nil {
+			return nil, err
+		}
+		data := call.Input
+		if len(data) == 0 {
+			data = call.Data
+		}
+		if call.To == nil || len(data) < 4 {
+			return nil, &rpcError{Code: 3, Message: "execution reverted"}
+		}
+		handler, exists := s.calls[getCallKey(*call.To, data[:4])]
+		if !exists {
+			return nil, &rpcError{Code: 3, Message: "execution reverted"}
+		}
+		s.lock.Unlock()
+		result, err := handler(data)
+		s.lock.Lock()
+		if err != nil {
+			return nil, &rpcError{Code: 3, Message: fmt.Sprintf("execution reverted: %s", err.Error())}
+		}
+		return hexutil.Bytes(result), nil
+
+	case "eth_sendRawTransaction":
+		var raw hexutil.Bytes
+		if err := getParam(params, 0, &raw); err != nil {
+			return nil, err
+		}
+		return s.sendTransaction(raw)
+
+	case "eth_getTransactionByHash":
+		var hash common.Hash
+		if err := getParam(params, 0, &hash); err != nil {
+			return nil, err
+		}
+		sent, exists := s.txs[hash]
+		if !exists {
+			return nil, nil
+		}
+		return s.getTransaction(sent)
+
+	case "eth_getTransactionReceipt":
+		var hash common.Hash
+		if err := getParam(params, 0, &hash); err != nil {
+			return nil, err
+		}
+		sent, exists := s.txs[hash]
+		if !exists || sent.blockNumber == nil {
+			return nil, nil
+		}
+		header := s.blocks[*sent.blockNumber]
+		receipt := &types.Receipt{
+			Type:              sent.tx.Type(),
+			Status:            types.ReceiptStatusSuccessful,
+			CumulativeGasUsed: sent.tx.Gas(),
+			Logs:              []*types.Log{},
+			TxHash:            sent.tx.Hash(),
+			GasUsed:           sent.tx.Gas(),
+			BlockHash:         header.Hash(),
+			BlockNumber:       header.Number,
+		}
+		return receipt, nil
+
+	case "eth_getLogs":
+		return s.getLogs(params)
+
+	default:
+		return nil, &rpcError{Code: -32601, Message: fmt.Sprintf("the method %s does not exist/is not available", method)}
+	}
+}
+
+// Accept a signed transaction into the pending pool; the lock must be held
+func (s *ExecutionServer) sendTransaction(raw []byte) (interface{}, error) {
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(raw); err != nil {
+		return nil, &rpcError{Code: -32000, Message: fmt.Sprintf("invalid transaction: %s", err.Error())}
+	}
+	if tx.ChainId().Uint64() != s.chainID {
+		return nil, &rpcError{Code: -32000, Message: fmt.Sprintf("invalid chain id: have %d, want %d", tx.ChainId().Uint64(), s.chainID)}
+	}
+	from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return nil, &rpcError{Code: -32000, Message: fmt.Sprintf("invalid sender: %s", err.Error())}
+	}
+	if tx.Nonce() != s.nonces[from] {
+		return nil, &rpcError{Code: -32000, Message: fmt.Sprintf("nonce too low: have %d, want %d", tx.Nonce(), s.nonces[from])}
+	}
+	if _, exists := s.txs[tx.Hash()]; exists {
+		return nil, &rpcError{Code: -32000, Message: "already known"}
+	}
+	s.nonces[from]++
+	s.txs[tx.Hash()] = &sentTransaction{
+		tx:   tx,
+		from: from,
+	}
+	s.txOrder = append(s.txOrder, tx.Hash())
+	return tx.Hash(), nil
+}
+
+// Get a transaction as eth_getTransactionByHash returns it; the lock must be held
+func (s *ExecutionServer) getTransaction(sent *sentTransaction) (interface{}, error) {
+	bytes, err := sent.tx.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]interface{}{}
+	if err := json.Unmarshal(bytes, &fields); err != nil {
+		return nil, err
+	}
+	fields["from"] = sent.from
+	if sent.blockNumber != nil {
+		header := s.blocks[*sent.blockNumber]
+		fields["blockNumber"] = (*hexutil.Big)(header.Number)
+		fields["blockHash"] = header.Hash()
+		fields["transactionIndex"] = hexutil.Uint64(0)
+	}
+	return fields, nil
+}
+
+// Get a block as eth_getBlockByNumber returns it, with the hashes of its transactions; the lock must be held
+func (s *ExecutionServer) getBlock(header *types.Header) (interface{}, error) {
+	bytes, err := json.Marshal(header)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]interface{}{}
+	if err := json.Unmarshal(bytes, &fields); err != nil {
+		return nil, err
+	}
+	number := header.Number.Uint64()
+	txHashes := []common.Hash{}
+	for _, hash := range s.txOrder {
+		sent := s.txs[hash]
+		if sent.blockNumber != nil && *sent.blockNumber == number {
+			txHashes = append(txHashes, hash)
+		}
+	}
+	fields["transactions"] = txHashes
+	fields["uncles"] = []common.Hash{}
+	fields["size"] = hexutil.Uint64(len(bytes))
+	return fields, nil
+}
+
+// Get the fee history for the requested blocks, with a flat base fee and priority fee; the lock must be held
+func (s *ExecutionServer) getFeeHistory(params []json.RawMessage) (interface{}, error) {
+	var count hexutil.Uint64
+	if err := getParam(params, 0, &count); err != nil {
+		return nil, err
+	}
+	var percentiles []float64
+	_ = getParam(params, 2, &percentiles)
+	head := uint64(len(s.blocks) - 1)
+	if uint64(count) > head+1 {
+		count = hexutil.Uint64(head + 1)
+	}
+	baseFees := make([]*hexutil.Big, count+1)
+	ratios := make([]float64, count)
+	rewards := make([][]*hexutil.Big, count)
+	for i := range baseFees {
+		baseFees[i] = (*hexutil.Big)(big.NewInt(baseFeeGwei * 1e9))
+	}
+	for i := range rewards {
+		ratios[i] = 0.5
+		rewards[i] = make([]*hexutil.Big, len(percentiles))
+		for j := range percentiles {
+			rewards[i][j] = (*hexutil.Big)(big.NewInt(1e9))
+		}
+	}
+	return map[string]interface{}{
+		"oldestBlock":   hexutil.Uint64(head + 1 - uint64(count)),
+		"baseFeePerGas": baseFees,
+		"gasUsedRatio":  ratios,
+		"reward":        rewards,
+	}, nil
+}
+
+// Get the logs matching a filter; the lock must be held
+func (s *ExecutionServer) getLogs(params []json.RawMessage) (interface{}, error) {
+	var filter struct {
+		FromBlock string            `json:"fromBlock"`
+		ToBlock   string            `json:"toBlock"`
+		BlockHash *common.Hash      `json:"blockHash"`
+		Address   json.RawMessage   `json:"address"`
+		Topics    []json.RawMessage `json:"topics"`
+	}
+	if err := getParam(params, 0, &filter); err != nil {
+		return nil, err
+	}
+
+	// Get the range and addresses
+	fromBlock, err := s.getBlockNumber(filter.FromBlock)
+	if err != nil {
+		return nil, err
+	}
+	toBlock, err := s.getBlockNumber(filter.ToBlock)
+	if err != nil {
+		return nil, err
+	}
+	addresses, err := getAddresses(filter.Address)
+	if err != nil {
+		return nil, err
+	}
+	topics := make([][]common.Hash, len(filter.Topics))
+	for i, topic := range filter.Topics {
+		topics[i], err = getTopics(topic)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Filter the logs
+	logs := []types.Log{}
+	for _, log := range s.logs {
+		if filter.BlockHash != nil {
+			if log.BlockHash != *filter.BlockHash {
+				continue
+			}
+		} else if log.BlockNumber < fromBlock || log.BlockNumber > toBlock {
+			continue
+		}
+		if len(addresses) > 0 && !containsAddress(addresses, log.Address) {
+			continue
+		}
+		if !matchesTopics(topics, log.Topics) {
+			continue
+		}
+		logs = append(logs, log)
+	}
+	return logs, nil
+}
+
+// Get the block number for a block tag such as latest or a hex number; the lock must be held
+func (s *ExecutionServer) getBlockNumber(tag string) (uint64, error) {
+	switch tag {
+	case "", "latest", "pending", "safe", "finalized":
+		return uint64(len(s.blocks) - 1), nil
+	case "earliest":
+		return 0, nil
+	}
+	number, err := hexutil.DecodeUint64(tag)
+	if err != nil {
+		return 0, &rpcError{Code: -32602, Message: fmt.Sprintf("invalid block number [%s]", tag)}
+	}
+	return number, nil
+}
+
+// Decode a positional parameter
+func getParam(params []json.RawMessage, index int, value interface{}) error {
+	if index >= len(params) {
+		return &rpcError{Code: -32602, Message: fmt.Sprintf("missing value for required argument %d", index)}
+	}
+	if err := json.Unmarshal(params[index], value); err != nil {
+		return &rpcError{Code: -32602, Message: fmt.Sprintf("invalid argument %d: %s", index, err.Error())}
+	}
+	return nil
+}
+
+// Decode the address of a log filter, which can be one address or a list of them
+func getAddresses(raw json.RawMessage) ([]common.Address, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil
+	}
+	var addresses []common.Address
+	if err := json.Unmarshal(raw, &addresses); err == nil {
+		return addresses, nil
+	}
+	var address common.Address
+	if err := json.Unmarshal(raw, &address); err != nil {
+		return nil, &rpcError{Code: -32602, Message: fmt.Sprintf("invalid address filter: %s", err.Error())}
+	}
+	return []common.Address{address}, nil
+}
+
+// Decode one position of a log filter's topics, which can be empty, one topic or a list of them
+func getTopics(raw json.RawMessage) ([]common.Hash, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil
+	}
+	var topics []common.Hash
+	if err := json.Unmarshal(raw, &topics); err == nil {
+		return topics, nil
+	}
+	var topic common.Hash
+	if err := json.Unmarshal(raw, &topic); err != nil {
+		return nil, &rpcError{Code: -32602, Message: fmt.Sprintf("invalid topic filter: %s", err.Error())}
+	}
+	return []common.Hash{topic}, nil
+}
+
+// Check if a list of addresses contains one
+func containsAddress(addresses []common.Address, address common.Address) bool {
+	for _, candidate := range addresses {
+		if candidate == address {
+			return true
+		}
+	}
+	return false
+}
+
+// Check if a log's topics match a filter; an empty position matches anything
+func matchesTopics(filter [][]common.Hash, topics []common.Hash) bool {
+	if len(filter) > len(topics) {
+		return false
+	}
+	for i, options := range filter {
+		if len(options) == 0 {
+			continue
+		}
+		matched := false
+		for _, option := range options {
+			if option == topics[i] {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// Get the key of a scripted contract call
+func getCallKey(contract common.Address, selector []byte) string {
+	return contract.Hex() + hexutil.Encode(selector)
+}
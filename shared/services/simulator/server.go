@@ -0,0 +1,155 @@
+package simulator
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// A request the simulated backend received
+type Request struct {
+	Time time.Time
+
+	// The JSON-RPC method for the Execution client, or the HTTP method and path for the Beacon node
+	Method string
+
+	// The HTTP status it was answered with
+	Status int
+}
+
+// The HTTP server behind a simulated backend, which can be taken down, rate limited and made slow to test how the Smartnode
+// handles a misbehaving client
+type server struct {
+	address  string
+	handler  http.Handler
+	listener net.Listener
+	http     *http.Server
+	lock     sync.Mutex
+
+	// Rate limiting; zero means unlimited
+	rateLimit    int
+	rateWindow   time.Duration
+	windowStart  time.Time
+	windowCount  int
+	responseTime time.Duration
+
+	requests []Request
+}
+
+// Create a server that listens on the provided address, such as 127.0.0.1:0 for a random port
+func newServer(address string, handler http.Handler) *server {
+	return &server{
+		address: address,
+		handler: handler,
+	}
+}
+
+// Start listening. The address is kept between restarts, so clients configured with it reconnect once it's back up.
+func (s *server) Start() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.listener != nil {
+		return nil
+	}
+	listener, err := net.Listen("tcp", s.address)
+	if err != nil {
+		return fmt.Errorf("error listening on %s: %w", s.address, err)
+	}
+	s.listener = listener
+	s.address = listener.Addr().String()
+	s.http = &http.Server{
+		Handler: http.HandlerFunc(s.serveHTTP),
+	}
+	go func(httpServer *http.Server, listener net.Listener) {
+		_ = httpServer.Serve(listener)
+	}(s.http, listener)
+	return nil
+}
+
+// Stop listening, so clients get connection refused errors like they would from a crashed client
+func (s *server) Stop() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.listener == nil {
+		return nil
+	}
+	err := s.http.Close()
+	s.listener = nil
+	s.http = nil
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// Get the URL of the server
+func (s *server) Url() string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return "http://" + s.address
+}
+
+// Answer with HTTP 429 once more than the provided number of requests arrive within the window; 0 removes the limit
+func (s *server) SetRateLimit(requests int, window time.Duration) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.rateLimit = requests
+	s.rateWindow = window
+	s.windowStart = time.Time{}
+	s.windowCount = 0
+}
+
+// Wait before answering each request, to simulate a slow or overloaded client
+func (s *server) SetResponseTime(responseTime time.Duration) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.responseTime = responseTime
+}
+
+// Get the requests received so far
+func (s *server) GetRequests() []Request {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return append([]Request{}, s.requests...)
+}
+
+// Record a request that was answered
+func (s *server) record(method string, status int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.requests = append(s.requests, Request{
+		Time:   time.Now(),
+		Method: method,
+		Status: status,
+	})
+}
+
+// Apply the rate limit and response time before handing the request to the backend
+func (s *server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	limited := false
+	if s.rateLimit > 0 {
+		now := time.Now()
+		if now.Sub(s.windowStart) >= s.rateWindow {
+			s.windowStart = now
+			s.windowCount = 0
+		}
+		s.windowCount++
+		limited = s.windowCount > s.rateLimit
+	}
+	responseTime := s.responseTime
+	s.lock.Unlock()
+
+	if responseTime > 0 {
+		time.Sleep(responseTime)
+	}
+	if limited {
+		s.record(r.Method+" "+r.URL.Path, http.StatusTooManyRequests)
+		http.Error(w, "rate limit exceeded", http.StatusTooManyRequests)
+		return
+	}
+	s.handler.ServeHTTP(w, r)
+}
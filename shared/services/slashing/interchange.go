@@ -0,0 +1,101 @@
+package slashing
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/rocket-pool/rocketpool-go/types"
+)
+
+// The EIP-3076 interchange format version that the validator clients read and write
+const InterchangeFormatVersion string = "5"
+
+// An EIP-3076 slashing protection interchange file
+type Interchange struct {
+	Metadata InterchangeMetadata    `json:"metadata"`
+	Data     []InterchangeValidator `json:"data"`
+}
+type InterchangeMetadata struct {
+	InterchangeFormatVersion string `json:"interchange_format_version"`
+	GenesisValidatorsRoot    string `json:"genesis_validators_root"`
+}
+type InterchangeValidator struct {
+	Pubkey             string                   `json:"pubkey"`
+	SignedBlocks       []InterchangeBlock       `json:"signed_blocks"`
+	SignedAttestations []InterchangeAttestation `json:"signed_attestations"`
+}
+type InterchangeBlock struct {
+	Slot        string `json:"slot"`
+	SigningRoot string `json:"signing_root,omitempty"`
+}
+type InterchangeAttestation struct {
+	SourceEpoch string `json:"source_epoch"`
+	TargetEpoch string `json:"target_epoch"`
+	SigningRoot string `json:"signing_root,omitempty"`
+}
+
+// Load an interchange file
+func LoadInterchange(path string) (*Interchange, error) {
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading slashing protection file [%s]: %w", path, err)
+	}
+	var interchange Interchange
+	err = json.Unmarshal(bytes, &interchange)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing slashing protection file [%s]: %w", path, err)
+	}
+	return &interchange, nil
+}
+
+// Check that the interchange is in a supported format and belongs to the chain with the provided genesis validators root
+func (i *Interchange) Validate(genesisValidatorsRoot []byte) error {
+	if i.Metadata.InterchangeFormatVersion != InterchangeFormatVersion {
+		return fmt.Errorf("unsupported interchange format version [%s], expected %s", i.Metadata.InterchangeFormatVersion, InterchangeFormatVersion)
+	}
+	root, err := hex.DecodeString(strings.TrimPrefix(i.Metadata.GenesisValidatorsRoot, "0x"))
+	if err != nil {
+		return fmt.Errorf("invalid genesis validators root [%s]: %w", i.Metadata.GenesisValidatorsRoot, err)
+	}
+	if !bytes.Equal(root, genesisValidatorsRoot) {
+		return fmt.Errorf("the file is for the chain with genesis validators root 0x%s, but your node is on the chain with root 0x%s", hex.EncodeToString(root), hex.EncodeToString(genesisValidatorsRoot))
+	}
+	for _, validator := range i.Data {
+		if _, err := types.HexToValidatorPubkey(strings.TrimPrefix(validator.Pubkey, "0x")); err != nil {
+			return fmt.Errorf("invalid validator pubkey [%s]: %w", validator.Pubkey, err)
+		}
+	}
+	return nil
+}
+
+// Get the validators that the interchange has records for
+func (i *Interchange) GetPubkeys() map[types.ValidatorPubkey]bool {
+	pubkeys := map[types.ValidatorPubkey]bool{}
+	for _, validator := range i.Data {
+		pubkey, err := types.HexToValidatorPubkey(strings.TrimPrefix(validator.Pubkey, "0x"))
+		if err == nil {
+			pubkeys[pubkey] = true
+		}
+	}
+	return pubkeys
+}
+
+// Get the provided validators that the interchange doesn't have records for
+func (i *Interchange) GetMissingPubkeys(pubkeys []types.ValidatorPubkey) []types.ValidatorPubkey {
+	included := i.GetPubkeys()
+	missing := []types.ValidatorPubkey{}
+	for _, pubkey := range pubkeys {
+		if !included[pubkey] {
+			missing = append(missing, pubkey)
+		}
+	}
+	sort.Slice(missing, func(a, b int) bool {
+		return missing[a].Hex() < missing[b].Hex()
+	})
+	return missing
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"math/big"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -24,6 +25,23 @@ type NetworkStateManager struct {
 	Network      cfgtypes.Network
 	ChainID      uint
 	BeaconConfig beacon.Eth2Config
+
+	// Shared with every copy of the manager made by WithLogger
+	cache *stateCache
+}
+
+// The states taken at the latest slot, so everything asking for the same slot shares one snapshot
+type stateCache struct {
+	lock       sync.Mutex
+	slot       uint64
+	fullState  *NetworkState
+	nodeStates map[common.Address]cachedNodeState
+}
+
+// A node's state and the network's total effective RPL stake, if it was calculated, at the cached slot
+type cachedNodeState struct {
+	state               *NetworkState
+	totalEffectiveStake *big.Int
 }
 
 // Create a new manager for the network state
@@ -39,6 +57,9 @@ func NewNetworkStateManager(rp *rocketpool.RocketPool, cfg *config.RocketPoolCon
 		Config:  cfg,
 		Network: cfg.Smartnode.Network.Value.(cfgtypes.Network),
 		ChainID: cfg.Smartnode.GetChainID(),
+		cache: &stateCache{
+			nodeStates: map[common.Address]cachedNodeState{},
+		},
 	}
 
 	// Get the Beacon config info
@@ -52,6 +73,23 @@ func NewNetworkStateManager(rp *rocketpool.RocketPool, cfg *config.RocketPoolCon
 
 }
 
+// Get a copy of the manager that logs to the provided logger, sharing this manager's cached states
+func (m *NetworkStateManager) WithLogger(log *log.ColorLogger) *NetworkStateManager {
+	logged := *m
+	logged.log = log
+	return &logged
+}
+
+// Get a copy of the manager that uses the provided clients and logger, sharing this manager's cached states.
+// The clients must be on the same network, such as an archive EC for states the primary EC has pruned.
+func (m *NetworkStateManager) WithClients(rp *rocketpool.RocketPool, bc beacon.Client, log *log.ColorLogger) *NetworkStateManager {
+	copied := m.WithLogger(log)
+	copied.rp = rp
+	copied.ec = rp.Client
+	copied.bc = bc
+	return copied
+}
+
 // Get the state of the network using the latest Execution layer block
 func (m *NetworkStateManager) GetHeadState() (*NetworkState, error) {
 	targetSlot, err := m.GetHeadSlot()
@@ -129,27 +167,66 @@ func (m *NetworkStateManager) GetLatestProposedBeaconBlock(targetSlot uint64) (b
 	}
 }
 
-// Get the state of the network at the provided Beacon slot
+// Get the state of the network at the provided Beacon slot.
+// States are shared with every caller asking for the same slot, so they must not be modified.
 func (m *NetworkStateManager) getState(slotNumber uint64) (*NetworkState, error) {
+	m.cache.lock.Lock()
+	defer m.cache.lock.Unlock()
+
+	if slotNumber == m.cache.slot && m.cache.fullState != nil {
+		return m.cache.fullState, nil
+	}
 	state, err := CreateNetworkState(m.cfg, m.rp, m.ec, m.bc, m.log, slotNumber, m.BeaconConfig)
 	if err != nil {
 		return nil, err
 	}
+	if m.resetCache(slotNumber) {
+		m.cache.fullState = state
+	}
 	return state, nil
 }
 
-// Get the state of the network for a specific node only at the provided Beacon slot
+// Get the state of the network for a specific node only at the provided Beacon slot.
+// States are shared with every caller asking for the same node and slot, so they must not be modified.
 func (m *NetworkStateManager) getStateForNode(nodeAddress common.Address, slotNumber uint64, calculateTotalEffectiveStake bool) (*NetworkState, *big.Int, error) {
+	m.cache.lock.Lock()
+	defer m.cache.lock.Unlock()
+
+	if slotNumber == m.cache.slot {
+		cached, exists := m.cache.nodeStates[nodeAddress]
+		if exists && (cached.totalEffectiveStake != nil || !calculateTotalEffectiveStake) {
+			return cached.state, cached.totalEffectiveStake, nil
+		}
+	}
 	state, totalEffectiveStake, err := CreateNetworkStateForNode(m.cfg, m.rp, m.ec, m.bc, m.log, slotNumber, m.BeaconConfig, nodeAddress, calculateTotalEffectiveStake)
 	if err != nil {
 		return nil, nil, err
 	}
+	if m.resetCache(slotNumber) {
+		m.cache.nodeStates[nodeAddress] = cachedNodeState{
+			state:               state,
+			totalEffectiveStake: totalEffectiveStake,
+		}
+	}
 	return state, totalEffectiveStake, nil
 }
 
+// Drop the cached states if they're older than the provided slot, returning false if the slot is older than the cached one and shouldn't replace it
+func (m *NetworkStateManager) resetCache(slotNumber uint64) bool {
+	if slotNumber < m.cache.slot {
+		return false
+	}
+	if slotNumber > m.cache.slot {
+		m.cache.slot = slotNumber
+		m.cache.fullState = nil
+		m.cache.nodeStates = map[common.Address]cachedNodeState{}
+	}
+	return true
+}
+
 // Logs a line if the logger is specified
 func (m *NetworkStateManager) logLine(format string, v ...interface{}) {
 	if m.log != nil {
-		m.log.Printlnf(format, v)
+		m.log.Printlnf(format, v...)
 	}
 }
@@ -316,6 +316,36 @@ func CreateNetworkStateForNode(cfg *config.RocketPoolConfig, rp *rocketpool.Rock
 	return state, totalEffectiveStake, nil
 }
 
+// Creates a network state from details that were already retrieved, such as ones loaded from a file, and builds its lookups
+func NewNetworkStateFromDetails(elBlockNumber uint64, slotNumber uint64, beaconConfig beacon.Eth2Config, networkDetails *rpstate.NetworkDetails, nodeDetails []rpstate.NativeNodeDetails, minipoolDetails []rpstate.NativeMinipoolDetails, validatorDetails map[types.ValidatorPubkey]beacon.ValidatorStatus, oracleDaoMemberDetails []rpstate.OracleDaoMemberDetails) *NetworkState {
+	state := &NetworkState{
+		ElBlockNumber:            elBlockNumber,
+		BeaconSlotNumber:         slotNumber,
+		BeaconConfig:             beaconConfig,
+		NetworkDetails:           networkDetails,
+		NodeDetails:              nodeDetails,
+		NodeDetailsByAddress:     map[common.Address]*rpstate.NativeNodeDetails{},
+		MinipoolDetails:          minipoolDetails,
+		MinipoolDetailsByAddress: map[common.Address]*rpstate.NativeMinipoolDetails{},
+		MinipoolDetailsByNode:    map[common.Address][]*rpstate.NativeMinipoolDetails{},
+		ValidatorDetails:         validatorDetails,
+		OracleDaoMemberDetails:   oracleDaoMemberDetails,
+	}
+
+	// Create the node lookup
+	for i, details := range state.NodeDetails {
+		state.NodeDetailsByAddress[details.NodeAddress] = &state.NodeDetails[i]
+	}
+
+	// Create the minipool lookups
+	for i, details := range state.MinipoolDetails {
+		state.MinipoolDetailsByAddress[details.MinipoolAddress] = &state.MinipoolDetails[i]
+		state.MinipoolDetailsByNode[details.NodeAddress] = append(state.MinipoolDetailsByNode[details.NodeAddress], &state.MinipoolDetails[i])
+	}
+
+	return state
+}
+
 // Calculate the true effective stakes of all nodes in the state, using the validator status
 // on Beacon as a reference for minipool eligibility instead of the EL-based minipool status
 func (s *NetworkState) CalculateTrueEffectiveStakes(scaleByParticipation bool, allowRplForUnstartedValidators bool) (map[common.Address]*big.Int, *big.Int, error) {
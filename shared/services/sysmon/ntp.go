@@ -0,0 +1,91 @@
+package sysmon
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"time"
+)
+
+// Settings
+const (
+	ntpPort       string = "123"
+	ntpPacketSize int    = 48
+
+	// The first byte of a client request: no leap second warning, version 4, client mode
+	ntpClientHeader byte = 0x23
+	ntpServerMode   byte = 4
+
+	// The seconds between the NTP epoch (1900) and the Unix epoch (1970)
+	ntpEpochOffset uint64 = 2208988800
+)
+
+// Query an NTP server with SNTP and get how far ahead of it the system clock is (negative if it's behind).
+// The server can include a port; the standard NTP port is used if it doesn't.
+func GetNtpOffset(server string, timeout time.Duration) (time.Duration, error) {
+	address := server
+	if _, _, err := net.SplitHostPort(server); err != nil {
+		address = net.JoinHostPort(server, ntpPort)
+	}
+	conn, err := net.DialTimeout("udp", address, timeout)
+	if err != nil {
+		return 0, fmt.Errorf("error connecting to NTP server [%s]: %w", server, err)
+	}
+	defer conn.Close()
+	if err := conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		return 0, fmt.Errorf("error setting NTP request deadline: %w", err)
+	}
+
+	// The transmit time is sent back as the origin time, which ties the response to this request
+	request := make([]byte, ntpPacketSize)
+	request[0] = ntpClientHeader
+	sent := time.Now()
+	binary.BigEndian.PutUint64(request[40:], toNtpTime(sent))
+	if _, err := conn.Write(request); err != nil {
+		return 0, fmt.Errorf("error sending NTP request to [%s]: %w", server, err)
+	}
+	response := make([]byte, ntpPacketSize)
+	read, err := conn.Read(response)
+	received := time.Now()
+	if err != nil {
+		return 0, fmt.Errorf("error reading NTP response from [%s]: %w", server, err)
+	}
+
+	// Check the response
+	if read < ntpPacketSize {
+		return 0, fmt.Errorf("NTP server [%s] sent a short response (%d bytes)", server, read)
+	}
+	if response[0]&0x07 != ntpServerMode {
+		return 0, fmt.Errorf("NTP server [%s] sent a response in mode %d instead of server mode", server, response[0]&0x07)
+	}
+	if response[1] == 0 {
+		return 0, fmt.Errorf("NTP server [%s] refused the request (kiss code %s)", server, string(response[12:16]))
+	}
+	if response[0]>>6 == 3 {
+		return 0, fmt.Errorf("NTP server [%s] isn't synchronized", server)
+	}
+	if binary.BigEndian.Uint64(response[24:]) != binary.BigEndian.Uint64(request[40:]) {
+		return 0, fmt.Errorf("NTP server [%s] sent a response to a different request", server)
+	}
+
+	// The server's receive and transmit times bracket its processing; averaging both legs cancels out the network delay when it's symmetric
+	serverReceived := fromNtpTime(binary.BigEndian.Uint64(response[32:]))
+	serverSent := fromNtpTime(binary.BigEndian.Uint64(response[40:]))
+	offset := (serverReceived.Sub(sent) + serverSent.Sub(received)) / 2
+	return -offset, nil
+}
+
+// Convert a time to an NTP timestamp, which has the seconds since 1900 in the upper 32 bits and the fraction in the lower 32
+func toNtpTime(t time.Time) uint64 {
+	nanos := uint64(t.UnixNano())
+	seconds := nanos/1e9 + ntpEpochOffset
+	fraction := (nanos % 1e9) << 32 / 1e9
+	return seconds<<32 | fraction
+}
+
+// Convert an NTP timestamp to a time
+func fromNtpTime(timestamp uint64) time.Time {
+	seconds := timestamp>>32 - ntpEpochOffset
+	nanos := (timestamp & 0xffffffff) * 1e9 >> 32
+	return time.Unix(int64(seconds), int64(nanos))
+}
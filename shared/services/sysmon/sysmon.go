@@ -0,0 +1,130 @@
+package sysmon
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// The disk usage of a client's chain data
+type ChainDataUsage struct {
+	Client string `json:"client"`
+	Volume string `json:"volume"`
+	Size   uint64 `json:"size"`
+}
+
+// A snapshot of the machine's resource usage, as recorded by the node daemon
+type SystemStatus struct {
+	Time time.Time `json:"time"`
+
+	// The disk holding the Smartnode's data
+	DiskPath  string `json:"diskPath"`
+	DiskTotal uint64 `json:"diskTotal"`
+	DiskFree  uint64 `json:"diskFree"`
+
+	// How quickly the free space has been shrinking, in bytes per day (negative if it's growing)
+	DiskUsagePerDay float64 `json:"diskUsagePerDay"`
+
+	// The number of days until the disk is full at its current growth rate, or -1 if it isn't shrinking
+	DaysUntilFull float64 `json:"daysUntilFull"`
+
+	// The size of each locally-managed client's chain data
+	ChainData []ChainDataUsage `json:"chainData"`
+
+	// The machine's RAM
+	MemoryTotal       uint64  `json:"memoryTotal"`
+	MemoryAvailable   uint64  `json:"memoryAvailable"`
+	MemoryUsedPercent float64 `json:"memoryUsedPercent"`
+
+	// How far ahead of the NTP server the system clock is (negative if it's behind), if it could be checked
+	NtpServer string         `json:"ntpServer,omitempty"`
+	NtpOffset *time.Duration `json:"ntpOffset,omitempty"`
+
+	// How far ahead of the Beacon Chain's slot times the system clock is at least (negative if it's behind), if it could be checked.
+	// It's estimated from when recent head blocks were seen, so it only catches skew larger than a slot's normal propagation delay.
+	BeaconOffset *time.Duration `json:"beaconOffset,omitempty"`
+
+	// Any thresholds that have been crossed
+	Warnings []string `json:"warnings"`
+
+	// The suggested way to free up space, if the disk is running low
+	PruneAdvice string `json:"pruneAdvice,omitempty"`
+}
+
+// Save the status to the provided path
+func (s *SystemStatus) Save(path string) error {
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return fmt.Errorf("error creating system status directory: %w", err)
+	}
+	bytes, err := json.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("error serializing system status: %w", err)
+	}
+	err = os.WriteFile(path, bytes, 0644)
+	if err != nil {
+		return fmt.Errorf("error writing system status file [%s]: %w", path, err)
+	}
+	return nil
+}
+
+// Load the status from the provided path. Returns nil if the node daemon hasn't recorded one yet.
+func LoadSystemStatus(path string) (*SystemStatus, error) {
+	bytes, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading system status file [%s]: %w", path, err)
+	}
+	var status SystemStatus
+	err = json.Unmarshal(bytes, &status)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing system status file [%s]: %w", path, err)
+	}
+	return &status, nil
+}
+
+// Check if the Smartnode can prune the configured Execution client with `rocketpool service prune-eth1`
+func CanPruneExecutionClient(cfg *config.RocketPoolConfig) bool {
+	if cfg.IsNativeMode || cfg.ExecutionClientMode.Value.(cfgtypes.Mode) != cfgtypes.Mode_Local {
+		return false
+	}
+	switch cfg.ExecutionClient.Value.(cfgtypes.ExecutionClient) {
+	case cfgtypes.ExecutionClient_Geth, cfgtypes.ExecutionClient_Nethermind:
+		return true
+	default:
+		return false
+	}
+}
+
+// Get the suggested way to free up disk space for the configured Execution client
+func GetPruneAdvice(cfg *config.RocketPoolConfig) string {
+	if cfg.IsNativeMode {
+		return "You are using Native Mode; please follow your Execution client's documentation to prune its database."
+	}
+	if cfg.ExecutionClientMode.Value.(cfgtypes.Mode) != cfgtypes.Mode_Local {
+		return "Your Execution client is externally managed; the Smartnode cannot prune it for you. Consider removing old logs, Docker images (`docker image prune`) or other files from this disk."
+	}
+
+	switch cfg.ExecutionClient.Value.(cfgtypes.ExecutionClient) {
+	case cfgtypes.ExecutionClient_Geth:
+		advice := "Run `rocketpool service prune-eth1` to prune Geth's database."
+		if cfg.UseFallbackClients.Value == false {
+			advice += " Geth will be offline while it prunes, so consider configuring a fallback client first."
+		}
+		return advice
+	case cfgtypes.ExecutionClient_Nethermind:
+		return "Run `rocketpool service prune-eth1` to start a full prune of Nethermind's database; it stays online while it prunes."
+	case cfgtypes.ExecutionClient_Besu:
+		return "Besu prunes its database automatically. If it is still too large, consider resyncing it with `rocketpool service resync-eth1` or moving to a larger disk."
+	default:
+		return "Consider resyncing your Execution client with `rocketpool service resync-eth1` to shrink its database, or moving to a larger disk."
+	}
+}
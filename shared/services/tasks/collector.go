@@ -0,0 +1,89 @@
+package tasks
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Represents the collector for a daemon's task loop
+type TaskCollector struct {
+	// How long the latest run of each task took, in seconds
+	taskDuration *prometheus.Desc
+
+	// The time each task was last run
+	taskLastRun *prometheus.Desc
+
+	// The time each task last completed without an error
+	taskLastSuccess *prometheus.Desc
+
+	// The number of times each task has failed since the daemon started
+	taskErrors *prometheus.Desc
+
+	// The fraction of each task's recent runs that failed
+	taskFailureRate *prometheus.Desc
+
+	// The recorder holding the task runs
+	recorder *Recorder
+}
+
+// Create a new TaskCollector instance
+func NewTaskCollector(recorder *Recorder) *TaskCollector {
+	namespace := "rocketpool"
+	subsystem := "daemon"
+	return &TaskCollector{
+		taskDuration: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "task_duration_seconds"),
+			"How long the latest run of each task took, in seconds",
+			[]string{"task"}, nil,
+		),
+		taskLastRun: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "task_last_run_time"),
+			"The time each task was last run, as a Unix timestamp",
+			[]string{"task"}, nil,
+		),
+		taskLastSuccess: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "task_last_success_time"),
+			"The time each task last completed without an error, as a Unix timestamp",
+			[]string{"task"}, nil,
+		),
+		taskErrors: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "task_errors_total"),
+			"The number of times each task has failed since the daemon started",
+			[]string{"task"}, nil,
+		),
+		taskFailureRate: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "task_failure_rate"),
+			"The fraction of each task's recent runs that failed",
+			[]string{"task"}, nil,
+		),
+		recorder: recorder,
+	}
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *TaskCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.taskDuration
+	channel <- collector.taskLastRun
+	channel <- collector.taskLastSuccess
+	channel <- collector.taskErrors
+	channel <- collector.taskFailureRate
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *TaskCollector) Collect(channel chan<- prometheus.Metric) {
+	for _, status := range collector.recorder.GetStatuses() {
+		if len(status.Runs) == 0 {
+			continue
+		}
+		latestRun := status.Runs[len(status.Runs)-1]
+		lastSuccess := float64(0)
+		if !status.LastSuccess.IsZero() {
+			lastSuccess = float64(status.LastSuccess.Unix())
+		}
+
+		channel <- prometheus.MustNewConstMetric(
+			collector.taskDuration, prometheus.GaugeValue, latestRun.Duration.Seconds(), status.Task)
+		channel <- prometheus.MustNewConstMetric(
+			collector.taskLastRun, prometheus.GaugeValue, float64(latestRun.Start.Add(latestRun.Duration).Unix()), status.Task)
+		channel <- prometheus.MustNewConstMetric(
+			collector.taskLastSuccess, prometheus.GaugeValue, lastSuccess, status.Task)
+		channel <- prometheus.MustNewConstMetric(
+			collector.taskErrors, prometheus.CounterValue, float64(status.TotalErrors), status.Task)
+		channel <- prometheus.MustNewConstMetric(
+			collector.taskFailureRate, prometheus.GaugeValue, status.GetFailureRate(), status.Task)
+	}
+}
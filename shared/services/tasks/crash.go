@@ -0,0 +1,170 @@
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared"
+	"github.com/rocket-pool/smartnode/shared/services/rpcusage"
+)
+
+// The number of crash reports kept in the crash folder
+const MaxCrashReports int = 50
+
+// A report of a task that panicked, saved so the cause can be found after the daemon carries on
+type CrashReport struct {
+	Daemon  string    `json:"daemon"`
+	Task    string    `json:"task"`
+	Time    time.Time `json:"time"`
+	Version string    `json:"version"`
+	Panic   string    `json:"panic"`
+	Stack   string    `json:"stack"`
+
+	// What the daemon's task loop was working on when the task panicked, such as the slot of the network state
+	Inputs map[string]string `json:"inputs,omitempty"`
+
+	// The task's runs before the one that panicked
+	RecentRuns []TaskRun `json:"recentRuns,omitempty"`
+}
+
+// Recovers from panics in a daemon's tasks so one broken task can't take the whole daemon down. Each panic is saved as a
+// crash report, passed to the listeners and turned into an error for the task's run.
+type CrashGuard struct {
+	daemon    string
+	dir       string
+	recorder  *Recorder
+	inputs    map[string]string
+	listeners []func(CrashReport, string)
+	lock      *sync.Mutex
+}
+
+// Create a new crash guard for the provided daemon, saving reports into the provided folder. The recorder is used to include
+// the task's recent runs in its reports, and can be nil.
+func NewCrashGuard(daemon string, dir string, recorder *Recorder) *CrashGuard {
+	return &CrashGuard{
+		daemon:   daemon,
+		dir:      dir,
+		recorder: recorder,
+		inputs:   map[string]string{},
+		lock:     &sync.Mutex{},
+	}
+}
+
+// Set what the task loop is currently working on, to be included in any crash reports
+func (g *CrashGuard) SetInputs(inputs map[string]string) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.inputs = inputs
+}
+
+// Call a function with every crash report and the path it was saved to (blank if it couldn't be saved)
+func (g *CrashGuard) AddListener(listener func(CrashReport, string)) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.listeners = append(g.listeners, listener)
+}
+
+// Run a task, turning a panic into an error after saving a crash report for it.
+// The RPC requests made while it runs are attributed to it.
+func (g *CrashGuard) Run(task string, run func() error) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = g.handlePanic(task, recovered, debug.Stack())
+		}
+	}()
+	defer rpcusage.GetTracker().StartTask(task)()
+	return run()
+}
+
+// Run the part of a task that carries on in the background after the task returns. A panic is turned into an error after
+// saving a crash report for it, like Run, and passed to handleError so the task can clean up after it.
+// The RPC requests made in the background are attributed to the task too.
+func (g *CrashGuard) Go(task string, run func(), handleError func(error)) {
+	go func() {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				handleError(g.handlePanic(task, recovered, debug.Stack()))
+			}
+		}()
+		defer rpcusage.GetTracker().StartTask(task)()
+		run()
+	}()
+}
+
+// Save the crash report for a panic and notify the listeners
+func (g *CrashGuard) handlePanic(task string, recovered interface{}, stack []byte) error {
+	g.lock.Lock()
+	inputs := make(map[string]string, len(g.inputs))
+	for key, value := range g.inputs {
+		inputs[key] = value
+	}
+	listeners := g.listeners
+	g.lock.Unlock()
+
+	report := CrashReport{
+		Daemon:  g.daemon,
+		Task:    task,
+		Time:    time.Now().UTC(),
+		Version: shared.RocketPoolVersion,
+		Panic:   fmt.Sprint(recovered),
+		Stack:   string(stack),
+		Inputs:  inputs,
+	}
+	if g.recorder != nil {
+		for _, status := range g.recorder.GetStatuses() {
+			if status.Task == task {
+				report.RecentRuns = status.Runs
+				break
+			}
+		}
+	}
+
+	path, saveErr := g.save(report)
+	for _, listener := range listeners {
+		listener(report, path)
+	}
+	if saveErr != nil {
+		return fmt.Errorf("task %s panicked: %s (the crash report couldn't be saved: %s)", task, report.Panic, saveErr.Error())
+	}
+	return fmt.Errorf("task %s panicked: %s (crash report saved to %s)", task, report.Panic, path)
+}
+
+// Save a crash report and remove the oldest ones past the limit
+func (g *CrashGuard) save(report CrashReport) (string, error) {
+	if err := os.MkdirAll(g.dir, 0755); err != nil {
+		return "", fmt.Errorf("error creating crash report folder [%s]: %w", g.dir, err)
+	}
+	bytes, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("error serializing crash report: %w", err)
+	}
+	path := filepath.Join(g.dir, fmt.Sprintf("%s-%s-%s.json", g.daemon, report.Time.Format("20060102-150405.000000000"), report.Task))
+	if err := os.WriteFile(path, bytes, 0644); err != nil {
+		return "", fmt.Errorf("error saving crash report [%s]: %w", path, err)
+	}
+
+	// Prune the oldest reports of this daemon
+	entries, err := os.ReadDir(g.dir)
+	if err != nil {
+		return path, nil
+	}
+	reports := []string{}
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasPrefix(entry.Name(), g.daemon+"-") && strings.HasSuffix(entry.Name(), ".json") {
+			reports = append(reports, entry.Name())
+		}
+	}
+	sort.Strings(reports)
+	for len(reports) > MaxCrashReports {
+		_ = os.Remove(filepath.Join(g.dir, reports[0]))
+		reports = reports[1:]
+	}
+	return path, nil
+}
@@ -0,0 +1,202 @@
+package tasks
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// The default number of runs kept for each task
+const DefaultRunHistorySize int = 20
+
+// The fraction of recent runs a task can fail before it's considered to be flapping
+const DefaultFailureBudget float64 = 0.2
+
+// A single run of a daemon task
+type TaskRun struct {
+	Start    time.Time     `json:"start"`
+	Duration time.Duration `json:"duration"`
+	Error    string        `json:"error,omitempty"`
+}
+
+// The recent runs of a daemon task, along with its lifetime totals
+type TaskStatus struct {
+	Task        string    `json:"task"`
+	Runs        []TaskRun `json:"runs"`
+	TotalRuns   uint64    `json:"totalRuns"`
+	TotalErrors uint64    `json:"totalErrors"`
+	LastSuccess time.Time `json:"lastSuccess"`
+}
+
+// The task statuses of a daemon, as saved to disk
+type DaemonTaskStatus struct {
+	Daemon  string       `json:"daemon"`
+	Updated time.Time    `json:"updated"`
+	Tasks   []TaskStatus `json:"tasks"`
+}
+
+// Records every run of a daemon's tasks into a ring buffer per task, and saves it to disk so the CLI can read it
+type Recorder struct {
+	daemon string
+	path   string
+	size   int
+	tasks  map[string]*TaskStatus
+	order  []string
+	lock   *sync.Mutex
+}
+
+// Create a new recorder for the provided daemon, saving to the file at the provided path
+func NewRecorder(daemon string, path string, size int) *Recorder {
+	return &Recorder{
+		daemon: daemon,
+		path:   path,
+		size:   size,
+		tasks:  map[string]*TaskStatus{},
+		order:  []string{},
+		lock:   &sync.Mutex{},
+	}
+}
+
+// Record the outcome of a task run and save the updated statuses
+func (r *Recorder) Record(task string, start time.Time, err error) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	status, exists := r.tasks[task]
+	if !exists {
+		status = &TaskStatus{
+			Task: task,
+			Runs: []TaskRun{},
+		}
+		r.tasks[task] = status
+		r.order = append(r.order, task)
+	}
+
+	now := time.Now()
+	run := TaskRun{
+		Start:    start,
+		Duration: now.Sub(start),
+	}
+	status.TotalRuns++
+	if err != nil {
+		run.Error = err.Error()
+		status.TotalErrors++
+	} else {
+		status.LastSuccess = now
+	}
+	status.Runs = append(status.Runs, run)
+	if len(status.Runs) > r.size {
+		status.Runs = status.Runs[len(status.Runs)-r.size:]
+	}
+
+	return r.save()
+}
+
+// Get a copy of the current task statuses, in the order the tasks first ran
+func (r *Recorder) GetStatuses() []TaskStatus {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.getStatuses()
+}
+
+// Get the tasks whose latest run started at or after the provided time and failed, in the order the tasks first ran
+func (r *Recorder) GetFailuresSince(since time.Time) []string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	failures := []string{}
+	for _, task := range r.order {
+		runs := r.tasks[task].Runs
+		if len(runs) == 0 {
+			continue
+		}
+		latest := runs[len(runs)-1]
+		if !latest.Start.Before(since) && latest.Error != "" {
+			failures = append(failures, task)
+		}
+	}
+	return failures
+}
+
+// Get a copy of the current task statuses without locking
+func (r *Recorder) getStatuses() []TaskStatus {
+	statuses := make([]TaskStatus, 0, len(r.order))
+	for _, task := range r.order {
+		status := *r.tasks[task]
+		status.Runs = append([]TaskRun{}, status.Runs...)
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+// Save the task statuses to disk, replacing the previous file atomically
+func (r *Recorder) save() error {
+	if r.path == "" {
+		return nil
+	}
+	err := os.MkdirAll(filepath.Dir(r.path), 0755)
+	if err != nil {
+		return fmt.Errorf("error creating task status directory: %w", err)
+	}
+
+	bytes, err := json.Marshal(DaemonTaskStatus{
+		Daemon:  r.daemon,
+		Updated: time.Now(),
+		Tasks:   r.getStatuses(),
+	})
+	if err != nil {
+		return fmt.Errorf("error serializing task status: %w", err)
+	}
+
+	tempPath := r.path + ".tmp"
+	err = os.WriteFile(tempPath, bytes, 0644)
+	if err != nil {
+		return fmt.Errorf("error writing task status file [%s]: %w", tempPath, err)
+	}
+	err = os.Rename(tempPath, r.path)
+	if err != nil {
+		return fmt.Errorf("error replacing task status file [%s]: %w", r.path, err)
+	}
+	return nil
+}
+
+// Load the task statuses a daemon saved to the provided path. Returns nil if the daemon hasn't saved any yet.
+func LoadDaemonTaskStatus(path string) (*DaemonTaskStatus, error) {
+	bytes, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading task status file [%s]: %w", path, err)
+	}
+
+	var status DaemonTaskStatus
+	err = json.Unmarshal(bytes, &status)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing task status file [%s]: %w", path, err)
+	}
+	return &status, nil
+}
+
+// Get the fraction of the task's recent runs that failed
+func (s TaskStatus) GetFailureRate() float64 {
+	if len(s.Runs) == 0 {
+		return 0
+	}
+	failures := 0
+	for _, run := range s.Runs {
+		if run.Error != "" {
+			failures++
+		}
+	}
+	return float64(failures) / float64(len(s.Runs))
+}
+
+// Check if the task's recent failure rate is over the provided budget
+func (s TaskStatus) IsOverBudget(budget float64) bool {
+	return s.GetFailureRate() > budget
+}
@@ -0,0 +1,169 @@
+package updates
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/openpgp"
+)
+
+// Settings
+const (
+	githubReleasesUrl string = "https://api.github.com/repos/%s/releases?per_page=20"
+	githubReleaseUrl  string = "https://api.github.com/repos/%s/releases/tags/%s"
+	signatureSuffix   string = ".sig"
+	digestPrefix      string = "sha256:"
+)
+
+var httpClient = &http.Client{Timeout: 5 * time.Minute}
+
+// A release published on GitHub
+type GithubRelease struct {
+	TagName     string        `json:"tag_name"`
+	HtmlUrl     string        `json:"html_url"`
+	Draft       bool          `json:"draft"`
+	Prerelease  bool          `json:"prerelease"`
+	PublishedAt time.Time     `json:"published_at"`
+	Assets      []GithubAsset `json:"assets"`
+}
+
+// A file attached to a GitHub release
+type GithubAsset struct {
+	Name string `json:"name"`
+	Url  string `json:"browser_download_url"`
+
+	// The SHA256 checksum of the file, in the form sha256:<hex>
+	Digest string `json:"digest"`
+}
+
+// Get the latest full release of a GitHub repository, ignoring drafts and prereleases
+func GetLatestRelease(repo string) (*GithubRelease, error) {
+	resp, err := httpClient.Get(fmt.Sprintf(githubReleasesUrl, repo))
+	if err != nil {
+		return nil, fmt.Errorf("error getting releases for %s: %w", repo, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected http status getting releases for %s: %d", repo, resp.StatusCode)
+	}
+
+	var releases []GithubRelease
+	if err := json.NewDecoder(resp.Body).Decode(&releases); err != nil {
+		return nil, fmt.Errorf("error decoding releases for %s: %w", repo, err)
+	}
+	for i := range releases {
+		if !releases[i].Draft && !releases[i].Prerelease {
+			return &releases[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%s has no published releases", repo)
+}
+
+// Get the release of a GitHub repository with the provided tag
+func GetRelease(repo string, tag string) (*GithubRelease, error) {
+	resp, err := httpClient.Get(fmt.Sprintf(githubReleaseUrl, repo, tag))
+	if err != nil {
+		return nil, fmt.Errorf("error getting release %s of %s: %w", tag, repo, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected http status getting release %s of %s: %d", tag, repo, resp.StatusCode)
+	}
+
+	var release GithubRelease
+	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+		return nil, fmt.Errorf("error decoding release %s of %s: %w", tag, repo, err)
+	}
+	return &release, nil
+}
+
+// Get an asset of the release by name
+func (r *GithubRelease) GetAsset(name string) (*GithubAsset, bool) {
+	for i := range r.Assets {
+		if r.Assets[i].Name == name {
+			return &r.Assets[i], true
+		}
+	}
+	return nil, false
+}
+
+// Download an asset of the release and verify it against the checksum published with it. If a release signing key is
+// provided, the asset's detached signature is verified too. Returns the asset's contents and whether it was signed.
+func (r *GithubRelease) DownloadVerifiedAsset(name string, keyPath string) ([]byte, bool, error) {
+	asset, exists := r.GetAsset(name)
+	if !exists {
+		return nil, false, fmt.Errorf("release %s doesn't have a %s file", r.TagName, name)
+	}
+	if !strings.HasPrefix(asset.Digest, digestPrefix) {
+		return nil, false, fmt.Errorf("release %s doesn't publish a checksum for %s", r.TagName, name)
+	}
+	contents, err := download(asset.Url)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// Verify the checksum
+	checksum := sha256.Sum256(contents)
+	if hex.EncodeToString(checksum[:]) != strings.TrimPrefix(asset.Digest, digestPrefix) {
+		return nil, false, fmt.Errorf("the checksum of %s from release %s doesn't match the published checksum", name, r.TagName)
+	}
+
+	// Verify the signature if there's a key to check it with
+	if keyPath == "" {
+		return contents, false, nil
+	}
+	keyBytes, err := os.ReadFile(keyPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return contents, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("error reading release signing key [%s]: %w", keyPath, err)
+	}
+	keyring, err := openpgp.ReadArmoredKeyRing(bytes.NewReader(keyBytes))
+	if err != nil {
+		return nil, false, fmt.Errorf("error parsing release signing key [%s]: %w", keyPath, err)
+	}
+	sigAsset, exists := r.GetAsset(name + signatureSuffix)
+	if !exists {
+		return nil, false, fmt.Errorf("release %s doesn't have a signature for %s", r.TagName, name)
+	}
+	signature, err := download(sigAsset.Url)
+	if err != nil {
+		return nil, false, err
+	}
+	if bytes.HasPrefix(signature, []byte("-----BEGIN")) {
+		_, err = openpgp.CheckArmoredDetachedSignature(keyring, bytes.NewReader(contents), bytes.NewReader(signature))
+	} else {
+		_, err = openpgp.CheckDetachedSignature(keyring, bytes.NewReader(contents), bytes.NewReader(signature))
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("the signature of %s from release %s is invalid: %w", name, r.TagName, err)
+	}
+	return contents, true, nil
+}
+
+// Download a file
+func download(url string) ([]byte, error) {
+	resp, err := httpClient.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("error downloading %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected http status downloading %s: %d", url, resp.StatusCode)
+	}
+	contents, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", url, err)
+	}
+	return contents, nil
+}
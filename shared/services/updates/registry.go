@@ -0,0 +1,69 @@
+package updates
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"runtime"
+	"strings"
+)
+
+// Settings
+const (
+	dockerHubTagUrl string = "https://hub.docker.com/v2/repositories/%s/tags/%s"
+)
+
+// A tag of an image on Docker Hub
+type dockerHubTag struct {
+	Name   string           `json:"name"`
+	Digest string           `json:"digest"`
+	Images []dockerHubImage `json:"images"`
+}
+
+// The image a Docker Hub tag points to for one platform
+type dockerHubImage struct {
+	Architecture string `json:"architecture"`
+	Os           string `json:"os"`
+	Digest       string `json:"digest"`
+}
+
+// Check that a container tag has been published on Docker Hub with an image for this node's platform, and get its digest
+func GetImageDigest(tag string) (string, error) {
+	repo, tagName := splitTag(tag)
+	if tagName == "" {
+		return "", fmt.Errorf("%s doesn't have a tag", tag)
+	}
+	if strings.Contains(strings.Split(repo, "/")[0], ".") {
+		return "", fmt.Errorf("%s isn't hosted on Docker Hub, so it can't be checked", tag)
+	}
+	if !strings.Contains(repo, "/") {
+		// Official images live in the library namespace
+		repo = "library/" + repo
+	}
+
+	resp, err := httpClient.Get(fmt.Sprintf(dockerHubTagUrl, repo, tagName))
+	if err != nil {
+		return "", fmt.Errorf("error getting %s from Docker Hub: %w", tag, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return "", fmt.Errorf("%s hasn't been published on Docker Hub", tag)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected http status getting %s from Docker Hub: %d", tag, resp.StatusCode)
+	}
+
+	var published dockerHubTag
+	if err := json.NewDecoder(resp.Body).Decode(&published); err != nil {
+		return "", fmt.Errorf("error decoding %s from Docker Hub: %w", tag, err)
+	}
+	if !strings.HasPrefix(published.Digest, digestPrefix) {
+		return "", fmt.Errorf("Docker Hub doesn't have a digest for %s", tag)
+	}
+	for _, image := range published.Images {
+		if image.Os == runtime.GOOS && image.Architecture == runtime.GOARCH {
+			return published.Digest, nil
+		}
+	}
+	return "", fmt.Errorf("%s doesn't have an image for %s/%s", tag, runtime.GOOS, runtime.GOARCH)
+}
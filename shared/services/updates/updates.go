@@ -0,0 +1,370 @@
+package updates
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-version"
+
+	"github.com/rocket-pool/smartnode/shared"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Settings
+const (
+	SmartnodeName      string = "smartnode"
+	SmartnodeRepo      string = "rocket-pool/smartnode"
+	InstallerRepo      string = "rocket-pool/smartnode-install"
+	InstallerAssetName string = "install.sh"
+
+	// The armored PGP key used to verify release signatures, in the Smartnode's config directory
+	ReleaseKeyFile string = "release-signing-key.asc"
+)
+
+// The version number in a container tag, such as the v4.5.0 in sigp/lighthouse:v4.5.0 or statusim/nimbus-eth2:multiarch-v23.10.0
+var tagVersionRegex = regexp.MustCompile(`v?\d+\.\d+(\.\d+)*`)
+
+// The GitHub repository that publishes each client's releases
+var clientRepos = map[string]string{
+	string(cfgtypes.ExecutionClient_Geth):       "ethereum/go-ethereum",
+	string(cfgtypes.ExecutionClient_Nethermind): "NethermindEth/nethermind",
+	string(cfgtypes.ExecutionClient_Besu):       "hyperledger/besu",
+	string(cfgtypes.ConsensusClient_Lighthouse): "sigp/lighthouse",
+	string(cfgtypes.ConsensusClient_Lodestar):   "ChainSafe/lodestar",
+	string(cfgtypes.ConsensusClient_Nimbus):     "status-im/nimbus-eth2",
+	string(cfgtypes.ConsensusClient_Prysm):      "prysmaticlabs/prysm",
+	string(cfgtypes.ConsensusClient_Teku):       "Consensys/teku",
+	config.MevBoostContainerName:                "flashbots/mev-boost",
+}
+
+// A newer release of the Smartnode or one of its clients
+type Release struct {
+	Name           string    `json:"name"`
+	Repo           string    `json:"repo"`
+	CurrentVersion string    `json:"currentVersion"`
+	LatestVersion  string    `json:"latestVersion"`
+	Url            string    `json:"url"`
+	PublishedAt    time.Time `json:"publishedAt"`
+
+	// When the release is offered to this node as part of the staged rollout
+	RolloutTime time.Time `json:"rolloutTime"`
+
+	// True if the release's installer matched its published checksum (and signature, if a release signing key is installed),
+	// or for clients, if the release's container images have been published for this node's platform
+	Verified          bool   `json:"verified"`
+	Signed            bool   `json:"signed"`
+	VerificationError string `json:"verificationError,omitempty"`
+
+	// The digests of a client release's container images, by tag
+	Images map[string]string `json:"images,omitempty"`
+}
+
+// The new releases found by the last update check, as recorded by the node daemon
+type UpdateStatus struct {
+	Time      time.Time `json:"time"`
+	Smartnode *Release  `json:"smartnode,omitempty"`
+	Clients   []Release `json:"clients"`
+
+	// Any releases that couldn't be checked
+	Errors []string `json:"errors"`
+}
+
+// A container image of one of the clients, and the parameter that holds its tag
+type ClientImage struct {
+	Name string
+	Repo string
+	Tag  *cfgtypes.Parameter
+}
+
+// Check if the release has been rolled out to this node yet
+func (r Release) IsAvailable(now time.Time) bool {
+	return !now.Before(r.RolloutTime)
+}
+
+// Get the releases that have been rolled out to this node
+func (s *UpdateStatus) GetAvailableUpdates(now time.Time) []Release {
+	available := []Release{}
+	if s.Smartnode != nil && s.Smartnode.IsAvailable(now) {
+		available = append(available, *s.Smartnode)
+	}
+	for _, release := range s.Clients {
+		if release.IsAvailable(now) {
+			available = append(available, release)
+		}
+	}
+	return available
+}
+
+// Save the status to the provided path
+func (s *UpdateStatus) Save(path string) error {
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return fmt.Errorf("error creating update status directory: %w", err)
+	}
+	bytes, err := json.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("error serializing update status: %w", err)
+	}
+	err = os.WriteFile(path, bytes, 0644)
+	if err != nil {
+		return fmt.Errorf("error writing update status file [%s]: %w", path, err)
+	}
+	return nil
+}
+
+// Load the status from the provided path. Returns nil if the node daemon hasn't recorded one yet.
+func LoadUpdateStatus(path string) (*UpdateStatus, error) {
+	bytes, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading update status file [%s]: %w", path, err)
+	}
+	var status UpdateStatus
+	err = json.Unmarshal(bytes, &status)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing update status file [%s]: %w", path, err)
+	}
+	return &status, nil
+}
+
+// Get the images of the clients the Smartnode manages
+func GetClientImages(cfg *config.RocketPoolConfig) []ClientImage {
+	images := []ClientImage{}
+	add := func(name string, tags ...*cfgtypes.Parameter) {
+		for _, tag := range tags {
+			images = append(images, ClientImage{Name: name, Repo: clientRepos[name], Tag: tag})
+		}
+	}
+
+	if cfg.ExecutionClientMode.Value.(cfgtypes.Mode) == cfgtypes.Mode_Local {
+		ec := cfg.ExecutionClient.Value.(cfgtypes.ExecutionClient)
+		switch ec {
+		case cfgtypes.ExecutionClient_Geth:
+			add(string(ec), &cfg.Geth.ContainerTag)
+		case cfgtypes.ExecutionClient_Nethermind:
+			add(string(ec), &cfg.Nethermind.ContainerTag)
+		case cfgtypes.ExecutionClient_Besu:
+			add(string(ec), &cfg.Besu.ContainerTag)
+		}
+	}
+
+	if cfg.ConsensusClientMode.Value.(cfgtypes.Mode) == cfgtypes.Mode_Local {
+		cc := cfg.ConsensusClient.Value.(cfgtypes.ConsensusClient)
+		switch cc {
+		case cfgtypes.ConsensusClient_Lighthouse:
+			add(string(cc), &cfg.Lighthouse.ContainerTag)
+		case cfgtypes.ConsensusClient_Lodestar:
+			add(string(cc), &cfg.Lodestar.ContainerTag)
+		case cfgtypes.ConsensusClient_Nimbus:
+			add(string(cc), &cfg.Nimbus.BnContainerTag, &cfg.Nimbus.VcContainerTag)
+		case cfgtypes.ConsensusClient_Prysm:
+			add(string(cc), &cfg.Prysm.BnContainerTag, &cfg.Prysm.VcContainerTag)
+		case cfgtypes.ConsensusClient_Teku:
+			add(string(cc), &cfg.Teku.ContainerTag)
+		}
+	} else {
+		// Only the validator client runs locally in hybrid mode
+		cc := cfg.ExternalConsensusClient.Value.(cfgtypes.ConsensusClient)
+		switch cc {
+		case cfgtypes.ConsensusClient_Lighthouse:
+			add(string(cc), &cfg.ExternalLighthouse.ContainerTag)
+		case cfgtypes.ConsensusClient_Lodestar:
+			add(string(cc), &cfg.ExternalLodestar.ContainerTag)
+		case cfgtypes.ConsensusClient_Nimbus:
+			add(string(cc), &cfg.ExternalNimbus.ContainerTag)
+		case cfgtypes.ConsensusClient_Prysm:
+			add(string(cc), &cfg.ExternalPrysm.ContainerTag)
+		case cfgtypes.ConsensusClient_Teku:
+			add(string(cc), &cfg.ExternalTeku.ContainerTag)
+		}
+	}
+
+	if cfg.EnableMevBoost.Value == true && cfg.MevBoost.Mode.Value.(cfgtypes.Mode) == cfgtypes.Mode_Local {
+		add(config.MevBoostContainerName, &cfg.MevBoost.ContainerTag)
+	}
+	return images
+}
+
+// Check for new releases of the Smartnode and the clients it manages. The seed fixes this node's place in the staged
+// rollout; if it's nil, releases are offered as soon as they're published.
+func CheckForUpdates(cfg *config.RocketPoolConfig, seed []byte) *UpdateStatus {
+	status := &UpdateStatus{
+		Time:    time.Now(),
+		Clients: []Release{},
+		Errors:  []string{},
+	}
+	rolloutDays := cfg.Smartnode.UpdateRolloutDays.Value.(uint64)
+
+	// Check the Smartnode
+	latest, err := GetLatestRelease(SmartnodeRepo)
+	if err != nil {
+		status.Errors = append(status.Errors, err.Error())
+	} else if isNewer(shared.RocketPoolVersion, latest.TagName) {
+		release := newRelease(SmartnodeName, SmartnodeRepo, "v"+shared.RocketPoolVersion, latest, seed, rolloutDays)
+		_, release.Signed, err = DownloadVerifiedInstaller(latest.TagName, filepath.Join(cfg.RocketPoolDirectory, ReleaseKeyFile))
+		if err != nil {
+			release.VerificationError = err.Error()
+		} else {
+			release.Verified = true
+		}
+		status.Smartnode = &release
+	}
+
+	// Check the clients
+	clientReleases := map[string]int{}
+	for _, image := range GetClientImages(cfg) {
+		index, checked := clientReleases[image.Name]
+		if !checked {
+			index = -1
+			clientReleases[image.Name] = index
+			currentVersion := GetTagVersion(image.Tag.Value.(string))
+			if currentVersion == "" {
+				// Custom tags like `latest` can't be compared
+				continue
+			}
+			latest, err := GetLatestRelease(image.Repo)
+			if err != nil {
+				status.Errors = append(status.Errors, err.Error())
+				continue
+			}
+			if !isNewer(currentVersion, latest.TagName) {
+				continue
+			}
+			status.Clients = append(status.Clients, newRelease(image.Name, image.Repo, currentVersion, latest, seed, rolloutDays))
+			index = len(status.Clients) - 1
+			clientReleases[image.Name] = index
+		}
+		if index >= 0 {
+			verifyClientImage(&status.Clients[index], image.Tag.Value.(string))
+		}
+	}
+
+	return status
+}
+
+// Download the installer of a Smartnode release and verify it against its published checksum, and its signature if a
+// release signing key is provided. Returns the installer and whether it was signed.
+func DownloadVerifiedInstaller(version string, keyPath string) ([]byte, bool, error) {
+	release, err := GetRelease(InstallerRepo, version)
+	if err != nil {
+		return nil, false, err
+	}
+	return release.DownloadVerifiedAsset(InstallerAssetName, keyPath)
+}
+
+// Check that the image a client release would switch the provided tag to has been published, recording its digest.
+// A release is only verified if all of its images are.
+func verifyClientImage(release *Release, oldTag string) {
+	newTag := GetUpdatedTag(oldTag, release.LatestVersion)
+	if newTag == oldTag || release.VerificationError != "" {
+		return
+	}
+	digest, err := GetImageDigest(newTag)
+	if err != nil {
+		release.Verified = false
+		release.VerificationError = err.Error()
+		return
+	}
+	if release.Images == nil {
+		release.Images = map[string]string{}
+	}
+	release.Images[newTag] = digest
+	release.Verified = true
+}
+
+// Set the container tags of the clients to their new releases, returning the tags that were changed
+func UpdateClientTags(cfg *config.RocketPoolConfig, releases []Release) map[*cfgtypes.Parameter]string {
+	latestVersions := map[string]string{}
+	for _, release := range releases {
+		latestVersions[release.Name] = release.LatestVersion
+	}
+
+	oldTags := map[*cfgtypes.Parameter]string{}
+	for _, image := range GetClientImages(cfg) {
+		latestVersion, exists := latestVersions[image.Name]
+		if !exists {
+			continue
+		}
+		oldTag := image.Tag.Value.(string)
+		newTag := GetUpdatedTag(oldTag, latestVersion)
+		if newTag != oldTag {
+			oldTags[image.Tag] = oldTag
+			image.Tag.Value = newTag
+		}
+	}
+	return oldTags
+}
+
+// Get the version number in a container tag, or an empty string if it doesn't have one
+func GetTagVersion(tag string) string {
+	_, tagName := splitTag(tag)
+	return tagVersionRegex.FindString(tagName)
+}
+
+// Replace the version number in a container tag, keeping the tag's style
+func GetUpdatedTag(tag string, newVersion string) string {
+	image, tagName := splitTag(tag)
+	oldVersion := tagVersionRegex.FindString(tagName)
+	if oldVersion == "" {
+		return tag
+	}
+	newVersion = strings.TrimPrefix(newVersion, "v")
+	if strings.HasPrefix(oldVersion, "v") {
+		newVersion = "v" + newVersion
+	}
+	return fmt.Sprintf("%s:%s", image, strings.Replace(tagName, oldVersion, newVersion, 1))
+}
+
+// Split a container tag into the image name and the tag name
+func splitTag(tag string) (string, string) {
+	index := strings.LastIndex(tag, ":")
+	if index < 0 || strings.Contains(tag[index:], "/") {
+		return tag, ""
+	}
+	return tag[:index], tag[index+1:]
+}
+
+// Check if a release is newer than the current version
+func isNewer(currentVersion string, latestVersion string) bool {
+	current, err := version.NewVersion(strings.TrimPrefix(currentVersion, "v"))
+	if err != nil {
+		return false
+	}
+	latest, err := version.NewVersion(strings.TrimPrefix(latestVersion, "v"))
+	if err != nil {
+		return false
+	}
+	return latest.GreaterThan(current)
+}
+
+// Create a release record, placing this node in the release's rollout window
+func newRelease(name string, repo string, currentVersion string, latest *GithubRelease, seed []byte, rolloutDays uint64) Release {
+	rolloutTime := latest.PublishedAt
+	if seed != nil {
+		// Each release gets a new, but fixed, position in the window so the same nodes aren't always first
+		hash := sha256.Sum256([]byte(fmt.Sprintf("%x/%s/%s", seed, name, latest.TagName)))
+		fraction := float64(binary.BigEndian.Uint64(hash[:8])) / math.MaxUint64
+		rolloutTime = rolloutTime.Add(time.Duration(fraction * float64(rolloutDays) * float64(24*time.Hour)))
+	}
+	return Release{
+		Name:           name,
+		Repo:           repo,
+		CurrentVersion: currentVersion,
+		LatestVersion:  latest.TagName,
+		Url:            latest.HtmlUrl,
+		PublishedAt:    latest.PublishedAt,
+		RolloutTime:    rolloutTime,
+	}
+}
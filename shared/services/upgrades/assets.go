@@ -0,0 +1,168 @@
+package upgrades
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/legacy/v1.0.0/rewards"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// The kinds of assets that can be left behind in contracts replaced by protocol upgrades
+type AssetType string
+
+const (
+	AssetType_RplClaim        AssetType = "rpl-claim"
+	AssetType_TrustedRplClaim AssetType = "trusted-rpl-claim"
+	AssetType_FeeDistributor  AssetType = "fee-distributor"
+)
+
+// Something belonging to the node that's held by a previous version of a Rocket Pool contract
+type StrandedAsset struct {
+	Type AssetType `json:"type"`
+
+	// The previous contract the asset was found through, and the address holding it
+	Contract common.Address `json:"contract"`
+	Address  common.Address `json:"address"`
+
+	// The token the asset is in ("rpl" or "eth") and how much of it there is
+	Token  string   `json:"token"`
+	Amount *big.Int `json:"amount"`
+}
+
+// Get a description of the asset for logs and alerts
+func (a StrandedAsset) Description() string {
+	switch a.Type {
+	case AssetType_RplClaim:
+		return fmt.Sprintf("unclaimed RPL rewards in the legacy node claim contract %s", a.Contract.Hex())
+	case AssetType_TrustedRplClaim:
+		return fmt.Sprintf("unclaimed RPL rewards in the legacy oDAO claim contract %s", a.Contract.Hex())
+	case AssetType_FeeDistributor:
+		return fmt.Sprintf("an undistributed balance in the fee distributor %s created by the previous factory %s", a.Address.Hex(), a.Contract.Hex())
+	default:
+		return fmt.Sprintf("an unknown asset at %s", a.Address.Hex())
+	}
+}
+
+// Find the node's assets that are held by previous versions of Rocket Pool's contracts.
+// Previous versions are discovered from the upgrade history; contracts replaced by upgrade contracts in the protocol's
+// early releases didn't emit upgrade events, so the legacy addresses from the config are checked too.
+func FindStrandedAssets(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, history *History, nodeAddress common.Address) ([]StrandedAsset, error) {
+	assets := []StrandedAsset{}
+
+	// Legacy RPL claims; these contracts aren't registered anymore so they may not have any code left, which just means there's nothing to claim
+	claimNodes := withLegacyAddress(history.GetPreviousAddresses("rocketClaimNode"), cfg.Smartnode.GetV100ClaimNodeAddress())
+	for _, address := range claimNodes {
+		address := address
+		possible, err := rewards.GetNodeClaimPossible(rp, nodeAddress, nil, &address)
+		if err != nil || !possible {
+			continue
+		}
+		amount, err := rewards.GetNodeClaimRewardsAmount(rp, nodeAddress, nil, &address)
+		if err != nil {
+			return nil, fmt.Errorf("error getting the RPL rewards available in legacy claim contract %s: %w", address.Hex(), err)
+		}
+		if amount.Sign() > 0 {
+			assets = append(assets, StrandedAsset{
+				Type:     AssetType_RplClaim,
+				Contract: address,
+				Address:  address,
+				Token:    "rpl",
+				Amount:   amount,
+			})
+		}
+	}
+	claimTrustedNodes := withLegacyAddress(history.GetPreviousAddresses("rocketClaimTrustedNode"), cfg.Smartnode.GetV100ClaimTrustedNodeAddress())
+	for _, address := range claimTrustedNodes {
+		address := address
+		possible, err := rewards.GetTrustedNodeClaimPossible(rp, nodeAddress, nil, &address)
+		if err != nil || !possible {
+			continue
+		}
+		amount, err := rewards.GetTrustedNodeClaimRewardsAmount(rp, nodeAddress, nil, &address)
+		if err != nil {
+			return nil, fmt.Errorf("error getting the RPL rewards available in legacy oDAO claim contract %s: %w", address.Hex(), err)
+		}
+		if amount.Sign() > 0 {
+			assets = append(assets, StrandedAsset{
+				Type:     AssetType_TrustedRplClaim,
+				Contract: address,
+				Address:  address,
+				Token:    "rpl",
+				Amount:   amount,
+			})
+		}
+	}
+
+	// Fee distributors created by previous factories live at different addresses than the current one.
+	// They forward to the current distributor delegate, so their balances can still be distributed.
+	currentDistributor, err := getDistributorAddress(rp, nil, nodeAddress)
+	if err != nil {
+		return nil, err
+	}
+	for _, factoryAddress := range history.GetPreviousAddresses("rocketNodeDistributorFactory") {
+		factoryAddress := factoryAddress
+		distributorAddress, err := getDistributorAddress(rp, &factoryAddress, nodeAddress)
+		if err != nil || distributorAddress == currentDistributor {
+			continue
+		}
+		code, err := rp.Client.CodeAt(context.Background(), distributorAddress, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error checking if fee distributor %s was deployed: %w", distributorAddress.Hex(), err)
+		}
+		if len(code) == 0 {
+			continue
+		}
+		balance, err := rp.Client.BalanceAt(context.Background(), distributorAddress, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error getting the balance of fee distributor %s: %w", distributorAddress.Hex(), err)
+		}
+		if balance.Sign() > 0 {
+			assets = append(assets, StrandedAsset{
+				Type:     AssetType_FeeDistributor,
+				Contract: factoryAddress,
+				Address:  distributorAddress,
+				Token:    "eth",
+				Amount:   balance,
+			})
+		}
+	}
+
+	return assets, nil
+}
+
+// Add a legacy address from the config to a contract's previous addresses if it isn't already there
+func withLegacyAddress(addresses []common.Address, legacyAddress common.Address) []common.Address {
+	if legacyAddress == (common.Address{}) {
+		return addresses
+	}
+	for _, address := range addresses {
+		if address == legacyAddress {
+			return addresses
+		}
+	}
+	return append(addresses, legacyAddress)
+}
+
+// Get the node's fee distributor address from the current distributor factory, or from a previous one if an address is provided
+func getDistributorAddress(rp *rocketpool.RocketPool, factoryAddress *common.Address, nodeAddress common.Address) (common.Address, error) {
+	var factory *rocketpool.Contract
+	var err error
+	if factoryAddress == nil {
+		factory, err = rp.GetContract("rocketNodeDistributorFactory", nil)
+	} else {
+		factory, err = rp.MakeContract("rocketNodeDistributorFactory", *factoryAddress, nil)
+	}
+	if err != nil {
+		return common.Address{}, fmt.Errorf("error getting the fee distributor factory: %w", err)
+	}
+	var address common.Address
+	if err := factory.Call(nil, &address, "getProxyAddress", nodeAddress); err != nil {
+		return common.Address{}, fmt.Errorf("error getting the fee distributor address: %w", err)
+	}
+	return address, nil
+}
@@ -0,0 +1,214 @@
+package upgrades
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+
+	"github.com/rocket-pool/smartnode/shared/services/progress"
+	"github.com/rocket-pool/smartnode/shared/services/reorg"
+)
+
+// A contract address that was replaced by a protocol upgrade
+type PreviousContract struct {
+	NameHash   common.Hash    `json:"nameHash"`
+	Address    common.Address `json:"address"`
+	ReplacedBy common.Address `json:"replacedBy"`
+	Block      uint64         `json:"block"`
+}
+
+// Every contract upgrade found in the upgrade contract's events, so they don't need to be scanned for again
+type History struct {
+	ScannedBlock uint64             `json:"scannedBlock"`
+	Contracts    []PreviousContract `json:"contracts"`
+	UpdatedTime  time.Time          `json:"updatedTime"`
+	Blocks       reorg.Tracker      `json:"blocks"`
+}
+
+// Load the upgrade history from the provided path. Returns an empty history if it hasn't been saved yet.
+func LoadHistory(path string) (*History, error) {
+	bytes, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &History{
+			Contracts: []PreviousContract{},
+		}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading contract upgrade history file [%s]: %w", path, err)
+	}
+	var history History
+	err = json.Unmarshal(bytes, &history)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing contract upgrade history file [%s]: %w", path, err)
+	}
+	return &history, nil
+}
+
+// Save the upgrade history to the provided path
+func (h *History) Save(path string) error {
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return fmt.Errorf("error creating contract upgrade history directory: %w", err)
+	}
+	bytes, err := json.Marshal(h)
+	if err != nil {
+		return fmt.Errorf("error serializing contract upgrade history: %w", err)
+	}
+
+	// The daemon and the API both update the history, so it's replaced atomically
+	tempPath := path + ".tmp"
+	err = os.WriteFile(tempPath, bytes, 0644)
+	if err != nil {
+		return fmt.Errorf("error writing contract upgrade history file [%s]: %w", tempPath, err)
+	}
+	err = os.Rename(tempPath, path)
+	if err != nil {
+		return fmt.Errorf("error replacing contract upgrade history file [%s]: %w", path, err)
+	}
+	return nil
+}
+
+// Scan the blocks since the last update for contract upgrades, reporting the progress if a reporter is provided.
+// Only blocks at least confirmationDepth blocks deep are scanned, and upgrades from blocks that have been reorged out are removed.
+func (h *History) Update(rp *rocketpool.RocketPool, intervalSize *big.Int, confirmationDepth uint64, reporter *progress.Reporter) error {
+
+	// Get the upgrade contract
+	upgradeContract, err := rp.GetContract("rocketDAONodeTrustedUpgrade", nil)
+	if err != nil {
+		return fmt.Errorf("error getting the upgrade contract: %w", err)
+	}
+	upgradeEvent, exists := upgradeContract.ABI.Events["ContractUpgraded"]
+	if !exists {
+		return fmt.Errorf("the upgrade contract doesn't have a ContractUpgraded event")
+	}
+
+	// Roll back to the last scanned block that's still canonical
+	if h.ScannedBlock > 0 {
+		safeBlock, reorged, err := h.Blocks.FindReorg(context.Background(), rp.Client)
+		if err != nil {
+			return fmt.Errorf("error checking the contract upgrade history for reorgs: %w", err)
+		}
+		if reorged {
+			h.rollBack(safeBlock)
+		}
+	}
+
+	// Get the range of blocks to scan, starting from Rocket Pool's deployment on the first scan
+	fromBlock := h.ScannedBlock + 1
+	if h.ScannedBlock == 0 {
+		deployBlock, err := rp.RocketStorage.GetUint(nil, crypto.Keccak256Hash([]byte("deploy.block")))
+		if err != nil {
+			return fmt.Errorf("error getting Rocket Pool's deployment block: %w", err)
+		}
+		fromBlock = deployBlock.Uint64()
+	}
+	latestBlock, confirmed, err := reorg.GetConfirmedBlock(context.Background(), rp.Client, confirmationDepth)
+	if err != nil {
+		return err
+	}
+	if !confirmed || fromBlock > latestBlock {
+		return nil
+	}
+	interval := latestBlock - fromBlock + 1
+	if intervalSize != nil && intervalSize.Sign() > 0 {
+		interval = intervalSize.Uint64()
+	}
+	reporter.SetPhase("Scanning for contract upgrades", (latestBlock-fromBlock)/interval+1)
+
+	// Scan in batches so the client's log limits are respected and progress can be reported
+	addressFilter := []common.Address{*upgradeContract.Address}
+	topicFilter := [][]common.Hash{{upgradeEvent.ID}}
+	batches := uint64(0)
+	for start := fromBlock; start <= latestBlock; start += interval {
+		end := start + interval - 1
+		if end > latestBlock {
+			end = latestBlock
+		}
+		logs, err := eth.GetLogs(rp, addressFilter, topicFilter, nil, new(big.Int).SetUint64(start), new(big.Int).SetUint64(end), nil)
+		if err != nil {
+			return fmt.Errorf("error getting contract upgrade events between blocks %d and %d: %w", start, end, err)
+		}
+		for _, log := range logs {
+			if len(log.Topics) < 4 {
+				continue
+			}
+			h.Contracts = append(h.Contracts, PreviousContract{
+				NameHash:   log.Topics[1],
+				Address:    common.BytesToAddress(log.Topics[2].Bytes()),
+				ReplacedBy: common.BytesToAddress(log.Topics[3].Bytes()),
+				Block:      log.BlockNumber,
+			})
+		}
+		if err := h.Blocks.Add(context.Background(), rp.Client, end); err != nil {
+			return err
+		}
+		h.ScannedBlock = end
+		batches++
+		reporter.SetProgress(batches)
+	}
+
+	h.UpdatedTime = time.Now()
+	return nil
+
+}
+
+// Remove the upgrades found after the provided block so the blocks after it are scanned again
+func (h *History) rollBack(block uint64) {
+	contracts := []PreviousContract{}
+	for _, contract := range h.Contracts {
+		if contract.Block <= block {
+			contracts = append(contracts, contract)
+		}
+	}
+	h.Contracts = contracts
+	h.ScannedBlock = block
+}
+
+// Get every address the named contract was deployed at before its current one, oldest first
+func (h *History) GetPreviousAddresses(contractName string) []common.Address {
+	nameHash := crypto.Keccak256Hash([]byte(contractName))
+	addresses := []common.Address{}
+	for _, contract := range h.Contracts {
+		if contract.NameHash == nameHash {
+			addresses = append(addresses, contract.Address)
+		}
+	}
+	return addresses
+}
+
+// Get the block the named contract was upgraded to the provided address in. Returns false if the address isn't one an
+// upgrade has put in place, such as a contract that has been there since Rocket Pool was deployed.
+func (h *History) GetUpgradeBlock(contractName string, address common.Address) (uint64, bool) {
+	nameHash := crypto.Keccak256Hash([]byte(contractName))
+	for _, contract := range h.Contracts {
+		if contract.NameHash == nameHash && contract.ReplacedBy == address {
+			return contract.Block, true
+		}
+	}
+	return 0, false
+}
+
+// Load the upgrade history, bring it up to date and save it
+func UpdateHistory(rp *rocketpool.RocketPool, path string, intervalSize *big.Int, confirmationDepth uint64, reporter *progress.Reporter) (*History, error) {
+	history, err := LoadHistory(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := history.Update(rp, intervalSize, confirmationDepth, reporter); err != nil {
+		return nil, err
+	}
+	if err := history.Save(path); err != nil {
+		return nil, err
+	}
+	return history, nil
+}
@@ -0,0 +1,72 @@
+package upgrades
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// The time the Smartnode first saw each minipool delegate as the latest one, for delegates it can't find in the upgrade
+// history, such as ones from blocks that haven't been scanned yet or ones set without a ContractUpgraded event
+type DelegateSightings struct {
+	FirstSeen map[common.Address]time.Time `json:"firstSeen"`
+}
+
+// Load the delegate sightings from the provided path. Returns an empty set if they haven't been saved yet.
+func LoadDelegateSightings(path string) (*DelegateSightings, error) {
+	bytes, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &DelegateSightings{
+			FirstSeen: map[common.Address]time.Time{},
+		}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading delegate sightings file [%s]: %w", path, err)
+	}
+	var sightings DelegateSightings
+	err = json.Unmarshal(bytes, &sightings)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing delegate sightings file [%s]: %w", path, err)
+	}
+	if sightings.FirstSeen == nil {
+		sightings.FirstSeen = map[common.Address]time.Time{}
+	}
+	return &sightings, nil
+}
+
+// Save the delegate sightings to the provided path
+func (s *DelegateSightings) Save(path string) error {
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return fmt.Errorf("error creating delegate sightings directory: %w", err)
+	}
+	bytes, err := json.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("error serializing delegate sightings: %w", err)
+	}
+	tempPath := path + ".tmp"
+	err = os.WriteFile(tempPath, bytes, 0644)
+	if err != nil {
+		return fmt.Errorf("error writing delegate sightings file [%s]: %w", tempPath, err)
+	}
+	err = os.Rename(tempPath, path)
+	if err != nil {
+		return fmt.Errorf("error replacing delegate sightings file [%s]: %w", path, err)
+	}
+	return nil
+}
+
+// Get the time the delegate was first seen, recording the provided time if it hasn't been seen before.
+// Returns true if it's a new sighting that needs to be saved.
+func (s *DelegateSightings) Record(delegate common.Address, now time.Time) (time.Time, bool) {
+	if firstSeen, exists := s.FirstSeen[delegate]; exists {
+		return firstSeen, false
+	}
+	s.FirstSeen[delegate] = now
+	return now, true
+}
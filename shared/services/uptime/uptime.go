@@ -0,0 +1,214 @@
+package uptime
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// A run of consecutive epochs in which a validator was either online or offline
+type Span struct {
+	Online     bool      `json:"online"`
+	StartEpoch uint64    `json:"startEpoch"`
+	EndEpoch   uint64    `json:"endEpoch"`
+	StartTime  time.Time `json:"startTime"`
+	EndTime    time.Time `json:"endTime"`
+}
+
+// The online and offline spans of a single validator
+type ValidatorUptime struct {
+	Index  string `json:"index"`
+	Pubkey string `json:"pubkey"`
+	Spans  []Span `json:"spans"`
+}
+
+// The uptime of a single validator within a calendar month
+type ValidatorMonth struct {
+	Index        string        `json:"index"`
+	Pubkey       string        `json:"pubkey"`
+	TrackedTime  time.Duration `json:"trackedTime"`
+	Downtime     time.Duration `json:"downtime"`
+	MissedEpochs uint64        `json:"missedEpochs"`
+
+	// The estimated ETH the validator missed out on while offline, including inactivity penalties
+	EstimatedEthLost float64 `json:"estimatedEthLost"`
+}
+
+// The uptime of every validator within a calendar month
+type MonthReport struct {
+	Month      string           `json:"month"`
+	Validators []ValidatorMonth `json:"validators"`
+}
+
+// A store of validator uptime spans, saved as a single JSON file
+type Store struct {
+	path       string
+	validators map[string]*ValidatorUptime
+	lock       *sync.Mutex
+}
+
+// Load the store from the provided path, or create an empty one if it doesn't exist yet
+func LoadStore(path string) (*Store, error) {
+	store := &Store{
+		path:       path,
+		validators: map[string]*ValidatorUptime{},
+		lock:       &sync.Mutex{},
+	}
+
+	bytes, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading uptime file [%s]: %w", path, err)
+	}
+	err = json.Unmarshal(bytes, &store.validators)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing uptime file [%s]: %w", path, err)
+	}
+	return store, nil
+}
+
+// Record whether a validator was online in an epoch. Consecutive epochs with the same status are merged into one span;
+// epochs that were never checked (e.g. while the daemon was down) are left out rather than being counted either way.
+func (s *Store) RecordEpoch(index string, pubkey string, epoch uint64, startTime time.Time, endTime time.Time, online bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	validator, exists := s.validators[index]
+	if !exists {
+		validator = &ValidatorUptime{
+			Index:  index,
+			Pubkey: pubkey,
+			Spans:  []Span{},
+		}
+		s.validators[index] = validator
+	}
+
+	if len(validator.Spans) > 0 {
+		last := &validator.Spans[len(validator.Spans)-1]
+		if epoch <= last.EndEpoch {
+			return
+		}
+		if last.Online == online && last.EndEpoch+1 == epoch {
+			last.EndEpoch = epoch
+			last.EndTime = endTime
+			return
+		}
+	}
+	validator.Spans = append(validator.Spans, Span{
+		Online:     online,
+		StartEpoch: epoch,
+		EndEpoch:   epoch,
+		StartTime:  startTime,
+		EndTime:    endTime,
+	})
+}
+
+// Save the store to disk, replacing the previous file atomically
+func (s *Store) Save() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	err := os.MkdirAll(filepath.Dir(s.path), 0755)
+	if err != nil {
+		return fmt.Errorf("error creating uptime directory: %w", err)
+	}
+	bytes, err := json.Marshal(s.validators)
+	if err != nil {
+		return fmt.Errorf("error serializing uptime: %w", err)
+	}
+	tempPath := s.path + ".tmp"
+	err = os.WriteFile(tempPath, bytes, 0644)
+	if err != nil {
+		return fmt.Errorf("error writing uptime file [%s]: %w", tempPath, err)
+	}
+	err = os.Rename(tempPath, s.path)
+	if err != nil {
+		return fmt.Errorf("error replacing uptime file [%s]: %w", s.path, err)
+	}
+	return nil
+}
+
+// Get the cumulative downtime of each validator per calendar month (in UTC), for every month since the provided time.
+// Spans that cross a month boundary are split proportionally.
+func (s *Store) GetMonthlyReport(since time.Time) []MonthReport {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	months := map[string]map[string]*ValidatorMonth{}
+	for index, validator := range s.validators {
+		for _, span := range validator.Spans {
+			if span.EndTime.Before(since) {
+				continue
+			}
+			epochs := span.EndEpoch - span.StartEpoch + 1
+			spanDuration := span.EndTime.Sub(span.StartTime)
+
+			// Split the span across the months it covers
+			start := span.StartTime.UTC()
+			for start.Before(span.EndTime) {
+				monthStart := time.Date(start.Year(), start.Month(), 1, 0, 0, 0, 0, time.UTC)
+				monthEnd := monthStart.AddDate(0, 1, 0)
+				end := span.EndTime.UTC()
+				if end.After(monthEnd) {
+					end = monthEnd
+				}
+				overlap := end.Sub(start)
+
+				key := monthStart.Format("2006-01")
+				validators, exists := months[key]
+				if !exists {
+					validators = map[string]*ValidatorMonth{}
+					months[key] = validators
+				}
+				month, exists := validators[index]
+				if !exists {
+					month = &ValidatorMonth{
+						Index:  index,
+						Pubkey: validator.Pubkey,
+					}
+					validators[index] = month
+				}
+				month.TrackedTime += overlap
+				if !span.Online {
+					month.Downtime += overlap
+					if spanDuration > 0 {
+						month.MissedEpochs += uint64(float64(epochs) * overlap.Seconds() / spanDuration.Seconds())
+					}
+				}
+				start = end
+			}
+		}
+	}
+
+	// Sort the report by month, then by validator index
+	keys := make([]string, 0, len(months))
+	for key := range months {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	report := make([]MonthReport, 0, len(keys))
+	for _, key := range keys {
+		validators := make([]ValidatorMonth, 0, len(months[key]))
+		for _, month := range months[key] {
+			validators = append(validators, *month)
+		}
+		sort.Slice(validators, func(i, j int) bool {
+			if len(validators[i].Index) != len(validators[j].Index) {
+				return len(validators[i].Index) < len(validators[j].Index)
+			}
+			return validators[i].Index < validators[j].Index
+		})
+		report = append(report, MonthReport{
+			Month:      key,
+			Validators: validators,
+		})
+	}
+	return report
+}
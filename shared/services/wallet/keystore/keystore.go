@@ -1,9 +1,13 @@
 package keystore
 
 import (
+	"fmt"
+
+	"github.com/google/uuid"
 	"github.com/rocket-pool/rocketpool-go/types"
 	"github.com/sethvargo/go-password/password"
 	eth2types "github.com/wealdtech/go-eth2-types/v2"
+	eth2ks "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
 )
 
 // Generates a random password
@@ -24,3 +28,40 @@ type Keystore interface {
 	LoadValidatorKey(pubkey types.ValidatorPubkey) (*eth2types.BLSPrivateKey, error)
 	GetKeystoreDir() string
 }
+
+// A standalone EIP-2335 keystore, as read by the Keymanager API and key splitting tools
+type Eip2335Keystore struct {
+	Crypto      map[string]interface{} `json:"crypto"`
+	Description string                 `json:"description"`
+	Pubkey      types.ValidatorPubkey  `json:"pubkey"`
+	Path        string                 `json:"path"`
+	UUID        uuid.UUID              `json:"uuid"`
+	Version     uint                   `json:"version"`
+}
+
+// Encrypts a validator key into an EIP-2335 keystore with a new random password
+func CreateEip2335Keystore(key *eth2types.BLSPrivateKey, derivationPath string, description string) (*Eip2335Keystore, string, error) {
+
+	// Create a new password
+	password, err := GenerateRandomPassword()
+	if err != nil {
+		return nil, "", fmt.Errorf("Could not generate random password: %w", err)
+	}
+
+	// Encrypt key
+	encryptor := eth2ks.New()
+	encryptedKey, err := encryptor.Encrypt(key.Marshal(), password)
+	if err != nil {
+		return nil, "", fmt.Errorf("Could not encrypt validator key: %w", err)
+	}
+
+	return &Eip2335Keystore{
+		Crypto:      encryptedKey,
+		Description: description,
+		Pubkey:      types.BytesToValidatorPubkey(key.PublicKey().Marshal()),
+		Path:        derivationPath,
+		UUID:        uuid.New(),
+		Version:     encryptor.Version(),
+	}, password, nil
+
+}
@@ -11,12 +11,17 @@ import (
 	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/rocket-pool/smartnode/shared/services/cmdqueue"
 )
 
 // Get the node account
 func (w *Wallet) GetNodeAccount() (accounts.Account, error) {
 
-	// Check wallet is initialized
+	// Check wallet is unlocked and initialized
+	if w.IsLocked() {
+		return accounts.Account{}, ErrWalletLocked
+	}
 	if !w.IsInitialized() {
 		return accounts.Account{}, errors.New("Wallet is not initialized")
 	}
@@ -48,7 +53,10 @@ func (w *Wallet) GetNodeAccount() (accounts.Account, error) {
 // Get a transactor for the node account
 func (w *Wallet) GetNodeAccountTransactor() (*bind.TransactOpts, error) {
 
-	// Check wallet is initialized
+	// Check wallet is unlocked and initialized
+	if w.IsLocked() {
+		return nil, ErrWalletLocked
+	}
 	if !w.IsInitialized() {
 		return nil, errors.New("Wallet is not initialized")
 	}
@@ -64,7 +72,7 @@ func (w *Wallet) GetNodeAccountTransactor() (*bind.TransactOpts, error) {
 	transactor.GasFeeCap = w.maxFee
 	transactor.GasTipCap = w.maxPriorityFee
 	transactor.GasLimit = w.gasLimit
-	transactor.Context = context.Background()
+	transactor.Context = cmdqueue.WithSendWindow(context.Background())
 	return transactor, err
 
 }
@@ -72,7 +80,10 @@ func (w *Wallet) GetNodeAccountTransactor() (*bind.TransactOpts, error) {
 // Get the node account private key bytes
 func (w *Wallet) GetNodePrivateKeyBytes() ([]byte, error) {
 
-	// Check wallet is initialized
+	// Check wallet is unlocked and initialized
+	if w.IsLocked() {
+		return nil, ErrWalletLocked
+	}
 	if !w.IsInitialized() {
 		return nil, errors.New("Wallet is not initialized")
 	}
@@ -91,6 +102,11 @@ func (w *Wallet) GetNodePrivateKeyBytes() ([]byte, error) {
 // Get the node private key
 func (w *Wallet) getNodePrivateKey() (*ecdsa.PrivateKey, string, error) {
 
+	// Check the wallet hasn't been locked since it was loaded
+	if !w.isPasswordAvailable() {
+		return nil, "", ErrWalletLocked
+	}
+
 	// Check for cached node key
 	if w.nodeKey != nil {
 		return w.nodeKey, w.nodeKeyPath, nil
@@ -75,12 +75,8 @@ func (w *Wallet) CreateValidatorKey() (*eth2types.BLSPrivateKey, error) {
 		return nil, errors.New("Wallet is not initialized")
 	}
 
-	// Get & increment account index
-	index := w.ws.NextAccount
-	w.ws.NextAccount++
-
 	// Get validator key
-	key, path, err := w.getValidatorPrivateKey(index)
+	key, path, _, err := w.ReserveValidatorKey()
 	if err != nil {
 		return nil, err
 	}
@@ -96,6 +92,36 @@ func (w *Wallet) CreateValidatorKey() (*eth2types.BLSPrivateKey, error) {
 
 }
 
+// Create a new validator key without storing it in the wallet's keystores, returning its derivation path and wallet index
+func (w *Wallet) ReserveValidatorKey() (*eth2types.BLSPrivateKey, string, uint, error) {
+
+	// Check wallet is initialized
+	if !w.IsInitialized() {
+		return nil, "", 0, errors.New("Wallet is not initialized")
+	}
+
+	// Get & increment account index
+	index := w.ws.NextAccount
+	w.ws.NextAccount++
+
+	// Get validator key
+	key, path, err := w.getValidatorPrivateKey(index)
+	if err != nil {
+		return nil, "", 0, err
+	}
+
+	// Return validator key
+	return key, path, index, nil
+
+}
+
+// Make sure the wallet doesn't create a new validator key at or below the provided index, for keys that are in use but aren't stored in its keystores
+func (w *Wallet) SkipValidatorKeyIndex(index uint) {
+	if index >= w.ws.NextAccount {
+		w.ws.NextAccount = index + 1
+	}
+}
+
 // Stores a validator key into all of the wallet's keystores
 func (w *Wallet) StoreValidatorKey(key *eth2types.BLSPrivateKey, path string) error {
 
@@ -300,6 +326,11 @@ func (w *Wallet) TestRecoverValidatorKey(pubkey rptypes.ValidatorPubkey, startIn
 // Get a validator private key by index
 func (w *Wallet) getValidatorPrivateKey(index uint) (*eth2types.BLSPrivateKey, string, error) {
 
+	// Check the wallet hasn't been locked since it was loaded
+	if !w.isPasswordAvailable() {
+		return nil, "", ErrWalletLocked
+	}
+
 	// Get derivation path
 	derivationPath := fmt.Sprintf(validator.ValidatorKeyPath, index)
 
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math/big"
 	"os"
+	"time"
 
 	"github.com/btcsuite/btcd/btcutil/hdkeychain"
 	"github.com/btcsuite/btcd/chaincfg"
@@ -31,6 +32,12 @@ const (
 	MyEtherWalletNodeKeyPath = "m/44'/60'/0'/%d"
 )
 
+// The suffix of the file next to the wallet that holds its seed encrypted with the secret of the active unlock session
+const sessionKeystoreSuffix string = ".session"
+
+// The error returned when key material is needed while the wallet is locked
+var ErrWalletLocked = errors.New("The node wallet is locked. Please run 'rocketpool wallet unlock' and try again.")
+
 // Wallet
 type Wallet struct {
 
@@ -116,6 +123,145 @@ func (w *Wallet) IsInitialized() bool {
 	return (w.ws != nil && w.seed != nil && w.mk != nil)
 }
 
+// Check if the wallet exists but its password isn't available, so its keys can't be used
+func (w *Wallet) IsLocked() bool {
+	return (w.ws != nil && w.ws.Crypto != nil && !w.isPasswordAvailable())
+}
+
+// Check if the wallet's password is available; wallets without a password manager only hold keys in memory
+func (w *Wallet) isPasswordAvailable() bool {
+	return (w.pm == nil || w.pm.IsPasswordSet())
+}
+
+// Lock the wallet, dropping the decrypted seed and every key derived from it.
+// The wallet can be used again once it's reloaded with its password available.
+func (w *Wallet) Lock() {
+	for i := range w.seed {
+		w.seed[i] = 0
+	}
+	if w.mk != nil {
+		w.mk.Zero()
+	}
+	if w.nodeKey != nil {
+		w.nodeKey.D.SetInt64(0)
+	}
+	w.seed = nil
+	w.mk = nil
+	w.nodeKey = nil
+	w.nodeKeyPath = ""
+	w.validatorKeys = map[uint]*eth2types.BLSPrivateKey{}
+}
+
+// Check that a password decrypts the wallet saved on disk
+func (w *Wallet) VerifyPassword(password string) error {
+
+	// Read wallet store from disk
+	wsBytes, err := os.ReadFile(w.walletPath)
+	if err != nil {
+		return fmt.Errorf("Could not read wallet from disk: %w", err)
+	}
+	ws := new(walletStore)
+	if err = json.Unmarshal(wsBytes, ws); err != nil {
+		return fmt.Errorf("Could not decode wallet: %w", err)
+	}
+
+	// Try to decrypt it
+	if _, err := w.encryptor.Decrypt(ws.Crypto, password); err != nil {
+		return errors.New("The password is not correct for the node wallet")
+	}
+
+	// Return
+	return nil
+
+}
+
+// Start an unlock session that lets the wallet be used without its password being saved, returning when it expires.
+// A copy of the seed is encrypted with the session's secret and saved next to the wallet; the secret itself is only kept in memory-backed storage,
+// so the copy is useless once the session ends or the machine restarts.
+func (w *Wallet) StartSession(password string, duration time.Duration) (time.Time, error) {
+
+	// Check the password by decrypting the seed with it
+	wsBytes, err := os.ReadFile(w.walletPath)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("Could not read wallet from disk: %w", err)
+	}
+	ws := new(walletStore)
+	if err = json.Unmarshal(wsBytes, ws); err != nil {
+		return time.Time{}, fmt.Errorf("Could not decode wallet: %w", err)
+	}
+	seed, err := w.encryptor.Decrypt(ws.Crypto, password)
+	if err != nil {
+		return time.Time{}, errors.New("The password is not correct for the node wallet")
+	}
+	defer func() {
+		for i := range seed {
+			seed[i] = 0
+		}
+	}()
+
+	// Encrypt the seed with the session's secret
+	secret, err := w.pm.StartSession(duration)
+	if err != nil {
+		return time.Time{}, err
+	}
+	sessionCrypto, err := w.encryptor.Encrypt(seed, secret)
+	if err != nil {
+		w.pm.EndSession()
+		return time.Time{}, fmt.Errorf("Could not encrypt wallet seed for the session: %w", err)
+	}
+	sessionBytes, err := json.Marshal(sessionCrypto)
+	if err != nil {
+		w.pm.EndSession()
+		return time.Time{}, fmt.Errorf("Could not encode wallet session: %w", err)
+	}
+	if err := os.WriteFile(w.walletPath+sessionKeystoreSuffix, sessionBytes, FileMode); err != nil {
+		w.pm.EndSession()
+		return time.Time{}, fmt.Errorf("Could not write wallet session to disk: %w", err)
+	}
+
+	// Return
+	expiry, _ := w.pm.GetSessionExpiry()
+	return expiry, nil
+
+}
+
+// End the active unlock session, if there is one, and delete the copy of the seed it was using
+func (w *Wallet) EndSession() error {
+	if err := w.pm.EndSession(); err != nil {
+		return err
+	}
+	err := os.Remove(w.walletPath + sessionKeystoreSuffix)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("Could not delete wallet session: %w", err)
+	}
+	return nil
+}
+
+// Decrypt the seed with the saved password, or with the copy saved for the active unlock session if the password isn't saved
+func (w *Wallet) decryptSeed() ([]byte, error) {
+	if w.pm.IsPasswordSaved() {
+		password, err := w.pm.GetPassword()
+		if err != nil {
+			return nil, fmt.Errorf("Could not get wallet password: %w", err)
+		}
+		return w.encryptor.Decrypt(w.ws.Crypto, password)
+	}
+
+	secret, err := w.pm.GetSessionSecret()
+	if err != nil {
+		return nil, err
+	}
+	sessionBytes, err := os.ReadFile(w.walletPath + sessionKeystoreSuffix)
+	if err != nil {
+		return nil, fmt.Errorf("Could not read wallet session from disk: %w", err)
+	}
+	sessionCrypto := map[string]interface{}{}
+	if err := json.Unmarshal(sessionBytes, &sessionCrypto); err != nil {
+		return nil, fmt.Errorf("Could not decode wallet session: %w", err)
+	}
+	return w.encryptor.Decrypt(sessionCrypto, secret)
+}
+
 // Attempt to initialize the wallet if not initialized and return status
 func (w *Wallet) GetInitialized() (bool, error) {
 	if w.IsInitialized() {
@@ -343,14 +489,14 @@ func (w *Wallet) loadStore() (bool, error) {
 		w.ws.DerivationPath = DefaultNodeKeyPath
 	}
 
-	// Get wallet password
-	password, err := w.pm.GetPassword()
-	if err != nil {
-		return false, fmt.Errorf("Could not get wallet password: %w", err)
+	// Leave the wallet locked if its password isn't available
+	if !w.isPasswordAvailable() {
+		w.Lock()
+		return false, nil
 	}
 
 	// Decrypt seed
-	w.seed, err = w.encryptor.Decrypt(w.ws.Crypto, password)
+	w.seed, err = w.decryptSeed()
 	if err != nil {
 		return false, fmt.Errorf("Could not decrypt wallet seed: %w", err)
 	}
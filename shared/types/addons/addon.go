@@ -6,6 +6,8 @@ import (
 
 // Interface for Smartnode addons
 type SmartnodeAddon interface {
+	// The addon's unique ID, which names its config section ("addons-<id>"), its folders and its container ("addon_<id>")
+	GetID() string
 	GetName() string
 	GetDescription() string
 	GetConfig() cfgtypes.Config
@@ -14,3 +16,50 @@ type SmartnodeAddon interface {
 	GetEnabledParameter() *cfgtypes.Parameter
 	UpdateEnvVars(envVars map[string]string) error
 }
+
+// Interface for addons that ship their own container template instead of using one from the Smartnode's templates folder
+type TemplateAddon interface {
+	GetTemplatePath() string
+}
+
+// Interface for addons that run a task in every loop of the node daemon
+type TaskAddon interface {
+	RunTask(context TaskContext) error
+}
+
+// Interface for addons that contribute to the node's status
+type StatusAddon interface {
+	GetStatus() (Status, error)
+}
+
+// Information about the node that's passed to addon tasks
+type TaskContext struct {
+	Network     string `json:"network"`
+	NodeAddress string `json:"nodeAddress"`
+	BeaconSlot  uint64 `json:"beaconSlot"`
+}
+
+// The health of an addon
+type StatusLevel string
+
+const (
+	StatusLevel_Ok      StatusLevel = "ok"
+	StatusLevel_Warning StatusLevel = "warning"
+	StatusLevel_Error   StatusLevel = "error"
+)
+
+// An addon's contribution to the node's status
+type Status struct {
+	Level   StatusLevel `json:"level"`
+	Message string      `json:"message"`
+}
+
+// Get the name of an addon's compose file and service
+func GetComposeName(addon SmartnodeAddon) string {
+	return "addon_" + addon.GetID()
+}
+
+// Get the name of an addon's section in the settings file
+func GetConfigSectionName(addon SmartnodeAddon) string {
+	return "addons-" + addon.GetID()
+}
@@ -4,3 +4,56 @@ type APIResponse struct {
 	Status string `json:"status"`
 	Error  string `json:"error"`
 }
+
+// A request to the node daemon's API server; the route names the command and this carries its arguments and the global flags
+type ServerRequest struct {
+	Args            []string `json:"args"`
+	MaxFee          float64  `json:"maxFee,omitempty"`
+	MaxPrioFee      float64  `json:"maxPrioFee,omitempty"`
+	GasLimit        uint64   `json:"gasLimit,omitempty"`
+	Nonce           string   `json:"nonce,omitempty"`
+	IgnoreSyncCheck bool     `json:"ignoreSyncCheck,omitempty"`
+	ForceFallbacks  bool     `json:"forceFallbacks,omitempty"`
+	Progress        bool     `json:"progress,omitempty"`
+	IdempotencyKey  string   `json:"idempotencyKey,omitempty"`
+
+	// Secrets like passphrases that the command reads from its environment; the server only passes on the ones it expects
+	EnvVars map[string]string `json:"envVars,omitempty"`
+}
+
+type ServerRoute struct {
+	Path string `json:"path"`
+	Role string `json:"role"`
+}
+
+type ServerRoutesResponse struct {
+	Status  string        `json:"status"`
+	Error   string        `json:"error"`
+	Version string        `json:"version"`
+	Role    string        `json:"role"`
+	Routes  []ServerRoute `json:"routes"`
+}
+
+// The outcome of one of the checks run before a command's transaction is sent
+type PreflightCheck struct {
+	Name    string `json:"name"`
+	Passed  bool   `json:"passed"`
+	Failure string `json:"failure,omitempty"`
+}
+
+// The outcome of every check run before a command's transaction is sent, in the order they were added
+type PreflightResult struct {
+	Passed bool             `json:"passed"`
+	Checks []PreflightCheck `json:"checks"`
+}
+
+// Get the checks that didn't pass
+func (r PreflightResult) Failures() []PreflightCheck {
+	failures := []PreflightCheck{}
+	for _, check := range r.Checks {
+		if !check.Passed {
+			failures = append(failures, check)
+		}
+	}
+	return failures
+}
@@ -234,6 +234,19 @@ type CanSetUseLatestDelegateResponse struct {
 	Error   string             `json:"error"`
 	GasInfo rocketpool.GasInfo `json:"gasInfo"`
 }
+type CanSetUseLatestDelegatesResponse struct {
+	Status    string                             `json:"status"`
+	Error     string                             `json:"error"`
+	Setting   bool                               `json:"setting"`
+	Minipools []MinipoolUseLatestDelegateDetails `json:"minipools"`
+}
+type MinipoolUseLatestDelegateDetails struct {
+	Address           common.Address     `json:"address"`
+	UseLatestDelegate bool               `json:"useLatestDelegate"`
+	CanChange         bool               `json:"canChange"`
+	Reason            string             `json:"reason"`
+	GasInfo           rocketpool.GasInfo `json:"gasInfo"`
+}
 type SetUseLatestDelegateResponse struct {
 	Status string      `json:"status"`
 	Error  string      `json:"error"`
@@ -288,6 +301,25 @@ type GetEffectiveDelegateResponse struct {
 	Address common.Address `json:"address"`
 }
 
+type MinipoolDelegateStatusResponse struct {
+	Status                string                    `json:"status"`
+	Error                 string                    `json:"error"`
+	LatestDelegate        common.Address            `json:"latestDelegate"`
+	LatestDelegateVersion uint8                     `json:"latestDelegateVersion"`
+	Minipools             []MinipoolDelegateDetails `json:"minipools"`
+}
+type MinipoolDelegateDetails struct {
+	Address                  common.Address `json:"address"`
+	Finalised                bool           `json:"finalised"`
+	UseLatestDelegate        bool           `json:"useLatestDelegate"`
+	Delegate                 common.Address `json:"delegate"`
+	DelegateVersion          uint8          `json:"delegateVersion"`
+	PreviousDelegate         common.Address `json:"previousDelegate"`
+	PreviousDelegateVersion  uint8          `json:"previousDelegateVersion"`
+	EffectiveDelegate        common.Address `json:"effectiveDelegate"`
+	EffectiveDelegateVersion uint8          `json:"effectiveDelegateVersion"`
+}
+
 type GetVanityArtifactsResponse struct {
 	Status                 string         `json:"status"`
 	Error                  string         `json:"error"`
@@ -307,6 +339,7 @@ type CanBeginReduceBondAmountResponse struct {
 	BeaconState           beacon.ValidatorState `json:"beaconState"`
 	InvalidBeaconState    bool                  `json:"invalidBeaconState"`
 	CanReduce             bool                  `json:"canReduce"`
+	Preflight             PreflightResult       `json:"preflight"`
 	GasInfo               rocketpool.GasInfo    `json:"gasInfo"`
 }
 type BeginReduceBondAmountResponse struct {
@@ -349,3 +382,28 @@ type RescueDissolvedMinipoolResponse struct {
 	Error  string      `json:"error"`
 	TxHash common.Hash `json:"txHash"`
 }
+
+// A problem found by a minipool audit
+type MinipoolAuditIssue struct {
+	Severity string `json:"severity"`
+	Type     string `json:"type"`
+	Message  string `json:"message"`
+}
+type MinipoolAuditDetails struct {
+	Address                       common.Address        `json:"address"`
+	ValidatorPubkey               types.ValidatorPubkey `json:"validatorPubkey"`
+	MinipoolStatus                types.MinipoolStatus  `json:"minipoolStatus"`
+	Vacant                        bool                  `json:"vacant"`
+	ExpectedWithdrawalCredentials common.Hash           `json:"expectedWithdrawalCredentials"`
+	ValidatorExists               bool                  `json:"validatorExists"`
+	BeaconWithdrawalCredentials   common.Hash           `json:"beaconWithdrawalCredentials"`
+	DepositCount                  int                   `json:"depositCount"`
+	DepositedGwei                 uint64                `json:"depositedGwei"`
+	Issues                        []MinipoolAuditIssue  `json:"issues"`
+}
+type MinipoolAuditResponse struct {
+	Status     string                 `json:"status"`
+	Error      string                 `json:"error"`
+	StartBlock uint64                 `json:"startBlock"`
+	Minipools  []MinipoolAuditDetails `json:"minipools"`
+}
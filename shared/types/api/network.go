@@ -2,8 +2,14 @@ package api
 
 import (
 	"math/big"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/rocket-pool/smartnode/shared/services/daopayload"
+	"github.com/rocket-pool/smartnode/shared/services/netstats"
+	"github.com/rocket-pool/smartnode/shared/services/progress"
+	"github.com/rocket-pool/smartnode/shared/services/registry"
 )
 
 type NodeFeeResponse struct {
@@ -15,6 +21,53 @@ type NodeFeeResponse struct {
 	MaxNodeFee    float64 `json:"maxNodeFee"`
 }
 
+type NodeFeeHistoryResponse struct {
+	Status        string              `json:"status"`
+	Error         string              `json:"error"`
+	NodeFee       float64             `json:"nodeFee"`
+	MinNodeFee    float64             `json:"minNodeFee"`
+	TargetNodeFee float64             `json:"targetNodeFee"`
+	MaxNodeFee    float64             `json:"maxNodeFee"`
+	NodeDemand    *big.Int            `json:"nodeDemand"`
+	DemandRange   *big.Int            `json:"demandRange"`
+	Curve         []NodeFeeCurvePoint `json:"curve"`
+	Days          uint64              `json:"days"`
+	History       []NodeFeeHistoryDay `json:"history"`
+}
+type NodeFeeCurvePoint struct {
+	Demand *big.Int `json:"demand"`
+	Fee    float64  `json:"fee"`
+}
+type NodeFeeHistoryDay struct {
+	Date       time.Time `json:"date"`
+	Minipools  int       `json:"minipools"`
+	MinFee     float64   `json:"minFee"`
+	AverageFee float64   `json:"averageFee"`
+	MaxFee     float64   `json:"maxFee"`
+}
+
+// The priority fee percentiles the fee history reports for each block, from the cheapest transactions to the most expensive ones
+var GasFeeHistoryPercentiles = []float64{10, 50, 90}
+
+type GasFeeHistoryResponse struct {
+	Status        string       `json:"status"`
+	Error         string       `json:"error"`
+	Percentiles   []float64    `json:"percentiles"`
+	BaseFees      []*big.Int   `json:"baseFees"`
+	NextBaseFee   *big.Int     `json:"nextBaseFee"`
+	PriorityFees  [][]*big.Int `json:"priorityFees"`
+	GasUsedRatios []float64    `json:"gasUsedRatios"`
+}
+
+type FiatPricesResponse struct {
+	Status    string    `json:"status"`
+	Error     string    `json:"error"`
+	Currency  string    `json:"currency"`
+	EthPrice  float64   `json:"ethPrice"`
+	RplPrice  float64   `json:"rplPrice"`
+	PriceTime time.Time `json:"priceTime"`
+}
+
 type RplPriceResponse struct {
 	Status                      string   `json:"status"`
 	Error                       string   `json:"error"`
@@ -50,6 +103,23 @@ type NetworkStatsResponse struct {
 	SmoothingPoolBalance      float64        `json:"smoothingPoolBalance"`
 }
 
+type NetworkAprResponse struct {
+	Status           string  `json:"status"`
+	Error            string  `json:"error"`
+	RethApr          float64 `json:"rethApr"`
+	ValidatorApr     float64 `json:"validatorApr"`
+	NodeAprAvailable bool    `json:"nodeAprAvailable"`
+	NodeEthApr       float64 `json:"nodeEthApr"`
+	NodeRplApr       float64 `json:"nodeRplApr"`
+	NodeTotalApr     float64 `json:"nodeTotalApr"`
+}
+
+type NetworkStatsSnapshotResponse struct {
+	Status   string                   `json:"status"`
+	Error    string                   `json:"error"`
+	Snapshot *netstats.SignedSnapshot `json:"snapshot"`
+}
+
 type NetworkTimezonesResponse struct {
 	Status         string            `json:"status"`
 	Error          string            `json:"error"`
@@ -70,6 +140,12 @@ type NetworkGenerateRewardsTreeResponse struct {
 	Error  string `json:"error"`
 }
 
+type NetworkRewardsTreeProgressResponse struct {
+	Status   string           `json:"status"`
+	Error    string           `json:"error"`
+	Progress *progress.Update `json:"progress"`
+}
+
 type NetworkDAOProposalsResponse struct {
 	Status                  string                 `json:"status"`
 	Error                   string                 `json:"error"`
@@ -95,3 +171,15 @@ type GetLatestDelegateResponse struct {
 	Error   string         `json:"error"`
 	Address common.Address `json:"address"`
 }
+
+type NetworkContractsResponse struct {
+	Status    string                  `json:"status"`
+	Error     string                  `json:"error"`
+	Contracts []registry.ContractInfo `json:"contracts"`
+}
+
+type DecodeProposalPayloadResponse struct {
+	Status  string                    `json:"status"`
+	Error   string                    `json:"error"`
+	Payload daopayload.DecodedPayload `json:"payload"`
+}
@@ -9,7 +9,15 @@ import (
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/rocketpool-go/tokens"
 	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/smartnode/shared/services/activity"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/dvt"
+	"github.com/rocket-pool/smartnode/shared/services/history"
+	"github.com/rocket-pool/smartnode/shared/services/mevboost"
 	"github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/services/upgrades"
+	"github.com/rocket-pool/smartnode/shared/services/uptime"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 	"github.com/rocket-pool/smartnode/shared/utils/rp"
 )
 
@@ -38,6 +46,7 @@ type NodeStatusResponse struct {
 	PendingMaximumRplStake            *big.Int        `json:"pendingMaximumRplStake"`
 	PendingBorrowedCollateralRatio    float64         `json:"pendingBorrowedCollateralRatio"`
 	PendingBondedCollateralRatio      float64         `json:"pendingBondedCollateralRatio"`
+	EthOnlyPermitted                  bool            `json:"ethOnlyPermitted"`
 	VotingDelegate                    common.Address  `json:"votingDelegate"`
 	VotingDelegateFormatted           string          `json:"votingDelegateFormatted"`
 	MinipoolLimit                     uint64          `json:"minipoolLimit"`
@@ -74,6 +83,7 @@ type CanRegisterNodeResponse struct {
 	CanRegister          bool               `json:"canRegister"`
 	AlreadyRegistered    bool               `json:"alreadyRegistered"`
 	RegistrationDisabled bool               `json:"registrationDisabled"`
+	Preflight            PreflightResult    `json:"preflight"`
 	GasInfo              rocketpool.GasInfo `json:"gasInfo"`
 }
 type RegisterNodeResponse struct {
@@ -164,6 +174,7 @@ type CanNodeStakeRplResponse struct {
 	CanStake            bool               `json:"canStake"`
 	InsufficientBalance bool               `json:"insufficientBalance"`
 	InConsensus         bool               `json:"inConsensus"`
+	Preflight           PreflightResult    `json:"preflight"`
 	GasInfo             rocketpool.GasInfo `json:"gasInfo"`
 }
 type NodeStakeRplApproveGasResponse struct {
@@ -207,6 +218,7 @@ type CanNodeWithdrawRplResponse struct {
 	MinipoolsUndercollateralized bool               `json:"minipoolsUndercollateralized"`
 	WithdrawalDelayActive        bool               `json:"withdrawalDelayActive"`
 	InConsensus                  bool               `json:"inConsensus"`
+	Preflight                    PreflightResult    `json:"preflight"`
 	GasInfo                      rocketpool.GasInfo `json:"gasInfo"`
 }
 type NodeWithdrawRplResponse struct {
@@ -226,11 +238,13 @@ type CanNodeDepositResponse struct {
 	InsufficientBalance              bool               `json:"insufficientBalance"`
 	InsufficientBalanceWithoutCredit bool               `json:"insufficientBalanceWithoutCredit"`
 	InsufficientRplStake             bool               `json:"insufficientRplStake"`
+	EthOnly                          bool               `json:"ethOnly"`
 	InvalidAmount                    bool               `json:"invalidAmount"`
 	UnbondedMinipoolsAtMax           bool               `json:"unbondedMinipoolsAtMax"`
 	DepositDisabled                  bool               `json:"depositDisabled"`
 	InConsensus                      bool               `json:"inConsensus"`
 	MinipoolAddress                  common.Address     `json:"minipoolAddress"`
+	Preflight                        PreflightResult    `json:"preflight"`
 	GasInfo                          rocketpool.GasInfo `json:"gasInfo"`
 }
 type NodeDepositResponse struct {
@@ -240,6 +254,9 @@ type NodeDepositResponse struct {
 	MinipoolAddress common.Address          `json:"minipoolAddress"`
 	ValidatorPubkey rptypes.ValidatorPubkey `json:"validatorPubkey"`
 	ScrubPeriod     time.Duration           `json:"scrubPeriod"`
+
+	// The keystore written to the DVT handoff folder, if the minipool is operated by a DVT cluster
+	DvtHandoffKeystore string `json:"dvtHandoffKeystore,omitempty"`
 }
 
 type CanCreateVacantMinipoolResponse struct {
@@ -247,9 +264,11 @@ type CanCreateVacantMinipoolResponse struct {
 	Error                string             `json:"error"`
 	CanDeposit           bool               `json:"canDeposit"`
 	InsufficientRplStake bool               `json:"insufficientRplStake"`
+	EthOnly              bool               `json:"ethOnly"`
 	InvalidAmount        bool               `json:"invalidAmount"`
 	DepositDisabled      bool               `json:"depositDisabled"`
 	MinipoolAddress      common.Address     `json:"minipoolAddress"`
+	Preflight            PreflightResult    `json:"preflight"`
 	GasInfo              rocketpool.GasInfo `json:"gasInfo"`
 }
 type CreateVacantMinipoolResponse struct {
@@ -294,6 +313,7 @@ type CanNodeBurnResponse struct {
 	CanBurn                bool               `json:"canBurn"`
 	InsufficientBalance    bool               `json:"insufficientBalance"`
 	InsufficientCollateral bool               `json:"insufficientCollateral"`
+	Preflight              PreflightResult    `json:"preflight"`
 	GasInfo                rocketpool.GasInfo `json:"gasInfo"`
 }
 type NodeBurnResponse struct {
@@ -302,6 +322,100 @@ type NodeBurnResponse struct {
 	TxHash common.Hash `json:"txHash"`
 }
 
+// An allowance the node has granted a Rocket Pool contract to spend one of its tokens
+type TokenApproval struct {
+	Token          string         `json:"token"`
+	TokenAddress   common.Address `json:"tokenAddress"`
+	Spender        string         `json:"spender"`
+	SpenderAddress common.Address `json:"spenderAddress"`
+	Current        bool           `json:"current"`
+	Allowance      *big.Int       `json:"allowance"`
+}
+type NodeApprovalsResponse struct {
+	Status    string          `json:"status"`
+	Error     string          `json:"error"`
+	Approvals []TokenApproval `json:"approvals"`
+}
+type CanNodeSetApprovalResponse struct {
+	Status         string             `json:"status"`
+	Error          string             `json:"error"`
+	CanSet         bool               `json:"canSet"`
+	UnknownSpender bool               `json:"unknownSpender"`
+	GasInfo        rocketpool.GasInfo `json:"gasInfo"`
+}
+type NodeSetApprovalResponse struct {
+	Status string      `json:"status"`
+	Error  string      `json:"error"`
+	TxHash common.Hash `json:"txHash"`
+}
+
+type NodeStrandedAssetsResponse struct {
+	Status string                   `json:"status"`
+	Error  string                   `json:"error"`
+	Assets []upgrades.StrandedAsset `json:"assets"`
+}
+type CanNodeClaimStrandedAssetResponse struct {
+	Status   string             `json:"status"`
+	Error    string             `json:"error"`
+	CanClaim bool               `json:"canClaim"`
+	NotFound bool               `json:"notFound"`
+	GasInfo  rocketpool.GasInfo `json:"gasInfo"`
+}
+type NodeClaimStrandedAssetResponse struct {
+	Status string      `json:"status"`
+	Error  string      `json:"error"`
+	TxHash common.Hash `json:"txHash"`
+}
+
+type NodeActivityResponse struct {
+	Status       string           `json:"status"`
+	Error        string           `json:"error"`
+	ScannedBlock uint64           `json:"scannedBlock"`
+	Events       []activity.Event `json:"events"`
+}
+
+type NodeIncomeEntry struct {
+	Time        time.Time   `json:"time"`
+	Type        string      `json:"type"`
+	Token       string      `json:"token"`
+	Amount      *big.Int    `json:"amount"`
+	FiatPrice   float64     `json:"fiatPrice"`
+	FiatValue   float64     `json:"fiatValue"`
+	TxHash      common.Hash `json:"txHash"`
+	Description string      `json:"description"`
+}
+type NodeIncomeResponse struct {
+	Status             string            `json:"status"`
+	Error              string            `json:"error"`
+	Year               int               `json:"year"`
+	Currency           string            `json:"currency"`
+	ScannedBlock       uint64            `json:"scannedBlock"`
+	Entries            []NodeIncomeEntry `json:"entries"`
+	SkippedWithdrawals []common.Hash     `json:"skippedWithdrawals"`
+}
+
+type DepositEstimate struct {
+	BondAmount       float64 `json:"bondAmount"`
+	BorrowedAmount   float64 `json:"borrowedAmount"`
+	MinimumRplStake  float64 `json:"minimumRplStake"`
+	MaximumRplStake  float64 `json:"maximumRplStake"`
+	YearlyEthRewards float64 `json:"yearlyEthRewards"`
+	WaitsInQueue     bool    `json:"waitsInQueue"`
+}
+type NodeEstimateDepositResponse struct {
+	Status                 string            `json:"status"`
+	Error                  string            `json:"error"`
+	Commission             float64           `json:"commission"`
+	RethApr                float64           `json:"rethApr"`
+	ValidatorApr           float64           `json:"validatorApr"`
+	RplPrice               float64           `json:"rplPrice"`
+	YearlyRplRewardsPerRpl float64           `json:"yearlyRplRewardsPerRpl"`
+	DepositPoolBalance     float64           `json:"depositPoolBalance"`
+	QueueLength            uint64            `json:"queueLength"`
+	QueueEthRequired       float64           `json:"queueEthRequired"`
+	Estimates              []DepositEstimate `json:"estimates"`
+}
+
 type NodeSyncProgressResponse struct {
 	Status   string              `json:"status"`
 	Error    string              `json:"error"`
@@ -309,6 +423,20 @@ type NodeSyncProgressResponse struct {
 	BcStatus ClientManagerStatus `json:"bcStatus"`
 }
 
+type NodePendingTransactionsResponse struct {
+	Status       string `json:"status"`
+	Error        string `json:"error"`
+	LatestNonce  uint64 `json:"latestNonce"`
+	PendingNonce uint64 `json:"pendingNonce"`
+	PendingCount uint64 `json:"pendingCount"`
+}
+
+type NodeRecentAlertsResponse struct {
+	Status string                  `json:"status"`
+	Error  string                  `json:"error"`
+	Alerts []alerting.HistoryEntry `json:"alerts"`
+}
+
 type CanNodeClaimRplResponse struct {
 	Status    string             `json:"status"`
 	Error     string             `json:"error"`
@@ -331,6 +459,7 @@ type NodeRewardsResponse struct {
 	Registered                  bool          `json:"registered"`
 	EffectiveRplStake           float64       `json:"effectiveRplStake"`
 	TotalRplStake               float64       `json:"totalRplStake"`
+	EthOnlyPermitted            bool          `json:"ethOnlyPermitted"`
 	TrustedRplBond              float64       `json:"trustedRplBond"`
 	EstimatedRewards            float64       `json:"estimatedRewards"`
 	CumulativeRplRewards        float64       `json:"cumulativeRplRewards"`
@@ -354,6 +483,19 @@ type DepositContractInfoResponse struct {
 	SufficientSync        bool           `json:"sufficientSync"`
 }
 
+type NodeHistoryResponse struct {
+	Status  string               `json:"status"`
+	Error   string               `json:"error"`
+	Samples []history.NodeSample `json:"samples"`
+}
+
+type NodeUptimeResponse struct {
+	Status       string               `json:"status"`
+	Error        string               `json:"error"`
+	ValidatorApr float64              `json:"validatorApr"`
+	Months       []uptime.MonthReport `json:"months"`
+}
+
 type NodeSignResponse struct {
 	Status     string `json:"status"`
 	Error      string `json:"error"`
@@ -541,3 +683,80 @@ type NodeEthBalanceResponse struct {
 	Error   string   `json:"error"`
 	Balance *big.Int `json:"balance"`
 }
+
+type NodeMevStatusResponse struct {
+	Status      string                `json:"status"`
+	Error       string                `json:"error"`
+	RelayReport *mevboost.RelayReport `json:"relayReport"`
+}
+
+type NodeProposalsResponse struct {
+	Status    string              `json:"status"`
+	Error     string              `json:"error"`
+	Proposals []mevboost.Proposal `json:"proposals"`
+}
+
+type MinipoolGraffiti struct {
+	Address  common.Address `json:"address"`
+	Template string         `json:"template"`
+	Rotation []string       `json:"rotation"`
+	Graffiti string         `json:"graffiti"`
+	Error    string         `json:"error,omitempty"`
+}
+type NodeGetGraffitiResponse struct {
+	Status              string             `json:"status"`
+	Error               string             `json:"error"`
+	NodeGraffiti        string             `json:"nodeGraffiti"`
+	Minipools           []MinipoolGraffiti `json:"minipools"`
+	FileSupported       bool               `json:"fileSupported"`
+	PerValidatorSupport bool               `json:"perValidatorSupport"`
+}
+
+type SetMinipoolGraffitiResponse struct {
+	Status   string `json:"status"`
+	Error    string `json:"error"`
+	Graffiti string `json:"graffiti"`
+}
+
+type MinipoolBlockBuilding struct {
+	Address     common.Address             `json:"address"`
+	Mode        cfgtypes.BlockBuildingMode `json:"mode"`
+	GasLimit    uint64                     `json:"gasLimit"`
+	UsesBuilder bool                       `json:"usesBuilder"`
+}
+type NodeGetBlockBuildingResponse struct {
+	Status            string                  `json:"status"`
+	Error             string                  `json:"error"`
+	MevBoostEnabled   bool                    `json:"mevBoostEnabled"`
+	KeymanagerEnabled bool                    `json:"keymanagerEnabled"`
+	GasLimitSupported bool                    `json:"gasLimitSupported"`
+	BuilderSupported  bool                    `json:"builderSupported"`
+	Minipools         []MinipoolBlockBuilding `json:"minipools"`
+}
+
+type SetMinipoolBlockBuildingResponse struct {
+	Status      string `json:"status"`
+	Error       string `json:"error"`
+	UsesBuilder bool   `json:"usesBuilder"`
+
+	// Whether the preference was pushed to the Validator client straight away, and why not if it wasn't
+	Applied    bool   `json:"applied"`
+	ApplyError string `json:"applyError,omitempty"`
+}
+
+type DvtMinipool struct {
+	Address     common.Address          `json:"address"`
+	Pubkey      rptypes.ValidatorPubkey `json:"pubkey"`
+	Provider    cfgtypes.DvtProvider    `json:"provider"`
+	HandoffTime time.Time               `json:"handoffTime"`
+
+	// The keystore in the handoff folder, if it hasn't been removed since being split
+	HandoffKeystore string `json:"handoffKeystore,omitempty"`
+}
+type NodeDvtStatusResponse struct {
+	Status        string             `json:"status"`
+	Error         string             `json:"error"`
+	Enabled       bool               `json:"enabled"`
+	Minipools     []DvtMinipool      `json:"minipools"`
+	ClusterStatus *dvt.ClusterStatus `json:"clusterStatus"`
+}
@@ -2,6 +2,7 @@ package api
 
 import (
 	"math/big"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/rocketpool-go/dao"
@@ -36,15 +37,17 @@ type TNDAOMembersResponse struct {
 }
 
 type TNDAOProposalsResponse struct {
-	Status    string                `json:"status"`
-	Error     string                `json:"error"`
-	Proposals []dao.ProposalDetails `json:"proposals"`
+	Status              string                `json:"status"`
+	Error               string                `json:"error"`
+	Proposals           []dao.ProposalDetails `json:"proposals"`
+	PayloadDescriptions map[uint64]string     `json:"payloadDescriptions"`
 }
 
 type TNDAOProposalResponse struct {
-	Status    string              `json:"status"`
-	Error     string              `json:"error"`
-	Proposals dao.ProposalDetails `json:"proposal"`
+	Status             string              `json:"status"`
+	Error              string              `json:"error"`
+	Proposals          dao.ProposalDetails `json:"proposal"`
+	PayloadDescription string              `json:"payloadDescription"`
 }
 
 type CanProposeTNDAOInviteResponse struct {
@@ -314,3 +317,49 @@ type GetTNDAOMinipoolSettingsResponse struct {
 	BondReductionWindowStart  uint64 `json:"bondReductionWindowStart"`
 	BondReductionWindowLength uint64 `json:"bondReductionWindowLength"`
 }
+
+type ApproveTNDAORplPriceResponse struct {
+	Status      string `json:"status"`
+	Error       string `json:"error"`
+	PricesBlock uint64 `json:"pricesBlock"`
+}
+
+// The kinds of Oracle DAO submissions that are voted on
+type TNDAOSubmissionType string
+
+const (
+	TNDAOSubmissionType_Prices   TNDAOSubmissionType = "prices"
+	TNDAOSubmissionType_Balances TNDAOSubmissionType = "balances"
+	TNDAOSubmissionType_Rewards  TNDAOSubmissionType = "rewards"
+)
+
+type TNDAOSubmissionsResponse struct {
+	Status      string                 `json:"status"`
+	Error       string                 `json:"error"`
+	Type        TNDAOSubmissionType    `json:"type"`
+	MemberCount uint64                 `json:"memberCount"`
+	Rounds      []TNDAOSubmissionRound `json:"rounds"`
+}
+
+// The submissions the Oracle DAO made for one prices block, balances block or rewards interval
+type TNDAOSubmissionRound struct {
+	// The block the prices or balances were for, or the rewards interval
+	Key uint64 `json:"key"`
+
+	// The execution block the values were calculated at
+	ReferenceBlock uint64 `json:"referenceBlock"`
+
+	ConsensusReached bool                  `json:"consensusReached"`
+	ConsensusBlock   uint64                `json:"consensusBlock"`
+	ConsensusTime    time.Time             `json:"consensusTime"`
+	ConsensusValue   string                `json:"consensusValue"`
+	Votes            []TNDAOSubmissionVote `json:"votes"`
+}
+type TNDAOSubmissionVote struct {
+	Member           common.Address `json:"member"`
+	MemberID         string         `json:"memberId"`
+	Block            uint64         `json:"block"`
+	Time             time.Time      `json:"time"`
+	Value            string         `json:"value"`
+	MatchesConsensus bool           `json:"matchesConsensus"`
+}
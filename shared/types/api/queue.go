@@ -2,17 +2,29 @@ package api
 
 import (
 	"math/big"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 )
 
 type QueueStatusResponse struct {
-	Status                string   `json:"status"`
-	Error                 string   `json:"error"`
-	DepositPoolBalance    *big.Int `json:"depositPoolBalance"`
-	MinipoolQueueLength   uint64   `json:"minipoolQueueLength"`
-	MinipoolQueueCapacity *big.Int `json:"minipoolQueueCapacity"`
+	Status                string           `json:"status"`
+	Error                 string           `json:"error"`
+	DepositPoolBalance    *big.Int         `json:"depositPoolBalance"`
+	MinipoolQueueLength   uint64           `json:"minipoolQueueLength"`
+	MinipoolQueueCapacity *big.Int         `json:"minipoolQueueCapacity"`
+	DepositRate           float64          `json:"depositRate"`
+	DepositRateDays       uint64           `json:"depositRateDays"`
+	NodeMinipools         []QueuedMinipool `json:"nodeMinipools"`
+}
+
+// One of the node's minipools waiting in the queue
+type QueuedMinipool struct {
+	Address       common.Address `json:"address"`
+	Position      uint64         `json:"position"`
+	EstimatedWait time.Duration  `json:"estimatedWait"`
+	WaitKnown     bool           `json:"waitKnown"`
 }
 
 type CanProcessQueueResponse struct {
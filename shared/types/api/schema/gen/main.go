@@ -0,0 +1,175 @@
+// Generates the table of API routes and their response types from the API client, which is the one place that pairs the two.
+// Run it with `go generate ./shared/types/api/schema` after adding or changing an API command.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	clientDir  string = "../../../services/rocketpool"
+	outputFile string = "routes.go"
+)
+
+func main() {
+	routes, err := findRoutes(clientDir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	names := make([]string, 0, len(routes))
+	for route := range routes {
+		names = append(names, route)
+	}
+	sort.Strings(names)
+
+	var source bytes.Buffer
+	source.WriteString("// Code generated by gen/main.go; DO NOT EDIT.\n\n")
+	source.WriteString("package schema\n\n")
+	source.WriteString("import \"github.com/rocket-pool/smartnode/shared/types/api\"\n\n")
+	source.WriteString("// The response type of each API route\n")
+	source.WriteString("var routeResponses = map[string]interface{}{\n")
+	for _, route := range names {
+		source.WriteString(fmt.Sprintf("\t%q: api.%s{},\n", route, routes[route]))
+	}
+	source.WriteString("}\n")
+
+	formatted, err := format.Source(source.Bytes())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error formatting generated source: %s\n", err.Error())
+		os.Exit(1)
+	}
+	if err := os.WriteFile(outputFile, formatted, 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "error writing %s: %s\n", outputFile, err.Error())
+		os.Exit(1)
+	}
+}
+
+// Find the route each API client method calls and the response type it decodes
+func findRoutes(dir string) (map[string]string, error) {
+	fset := token.NewFileSet()
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	routes := map[string]string{}
+	for _, path := range files {
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing %s: %w", path, err)
+		}
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv == nil || fn.Body == nil {
+				continue
+			}
+			route, responseType := inspectMethod(fn)
+			if route == "" || responseType == "" {
+				continue
+			}
+			if _, exists := routes[route]; !exists {
+				routes[route] = responseType
+			}
+		}
+	}
+	return routes, nil
+}
+
+// Get the route and response type of an API client method, if it has them
+func inspectMethod(fn *ast.FuncDecl) (string, string) {
+	literals := map[string]string{}
+	route := ""
+	responseType := ""
+	ast.Inspect(fn.Body, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.AssignStmt:
+			// Remember the commands built in a variable before the call
+			if len(n.Lhs) == 1 && len(n.Rhs) == 1 && n.Tok == token.DEFINE {
+				if ident, ok := n.Lhs[0].(*ast.Ident); ok {
+					if value, ok := stringLiteral(n.Rhs[0]); ok {
+						literals[ident.Name] = value
+					}
+				}
+			}
+		case *ast.CallExpr:
+			selector, ok := n.Fun.(*ast.SelectorExpr)
+			if !ok || route != "" || len(n.Args) == 0 {
+				return true
+			}
+			if selector.Sel.Name != "callAPI" && selector.Sel.Name != "callAPIWithEnvVars" {
+				return true
+			}
+			arg := n.Args[0]
+			if selector.Sel.Name == "callAPIWithEnvVars" && len(n.Args) > 1 {
+				arg = n.Args[1]
+			}
+			route = getRoute(arg, literals)
+		case *ast.ValueSpec:
+			// The response is decoded into a `var response api.X`
+			if responseType != "" || n.Type == nil {
+				return true
+			}
+			if selector, ok := n.Type.(*ast.SelectorExpr); ok {
+				if pkg, ok := selector.X.(*ast.Ident); ok && pkg.Name == "api" {
+					responseType = selector.Sel.Name
+				}
+			}
+		}
+		return true
+	})
+	return route, responseType
+}
+
+// Get the route from the command passed to callAPI
+func getRoute(arg ast.Expr, literals map[string]string) string {
+	command := ""
+	switch a := arg.(type) {
+	case *ast.BasicLit:
+		command, _ = stringLiteral(a)
+	case *ast.Ident:
+		command = literals[a.Name]
+	case *ast.CallExpr:
+		// fmt.Sprintf("group command %s", ...)
+		if len(a.Args) > 0 {
+			command, _ = stringLiteral(a.Args[0])
+		}
+	}
+	words := []string{}
+	for _, word := range strings.Fields(command) {
+		if strings.Contains(word, "%") || strings.HasPrefix(word, "-") {
+			break
+		}
+		words = append(words, word)
+	}
+	if len(words) == 0 {
+		return ""
+	}
+	if words[0] == "wait" || len(words) == 1 {
+		return words[0]
+	}
+	return words[0] + "/" + words[1]
+}
+
+// Get the value of a string literal
+func stringLiteral(expr ast.Expr) (string, bool) {
+	literal, ok := expr.(*ast.BasicLit)
+	if !ok || literal.Kind != token.STRING {
+		return "", false
+	}
+	value, err := strconv.Unquote(literal.Value)
+	if err != nil {
+		return "", false
+	}
+	return value, true
+}
@@ -0,0 +1,257 @@
+// Code generated by gen/main.go; DO NOT EDIT.
+
+package schema
+
+import "github.com/rocket-pool/smartnode/shared/types/api"
+
+// The response type of each API route
+var routeResponses = map[string]interface{}{
+	"auction/bid-lot":                                api.BidOnLotResponse{},
+	"auction/can-bid-lot":                            api.CanBidOnLotResponse{},
+	"auction/can-claim-lot":                          api.CanClaimFromLotResponse{},
+	"auction/can-create-lot":                         api.CanCreateLotResponse{},
+	"auction/can-recover-lot":                        api.CanRecoverRPLFromLotResponse{},
+	"auction/claim-lot":                              api.ClaimFromLotResponse{},
+	"auction/create-lot":                             api.CreateLotResponse{},
+	"auction/lots":                                   api.AuctionLotsResponse{},
+	"auction/recover-lot":                            api.RecoverRPLFromLotResponse{},
+	"auction/status":                                 api.AuctionStatusResponse{},
+	"faucet/can-withdraw-rpl":                        api.CanFaucetWithdrawRplResponse{},
+	"faucet/status":                                  api.FaucetStatusResponse{},
+	"faucet/withdraw-rpl":                            api.FaucetWithdrawRplResponse{},
+	"minipool/audit":                                 api.MinipoolAuditResponse{},
+	"minipool/begin-reduce-bond-amount":              api.BeginReduceBondAmountResponse{},
+	"minipool/can-begin-reduce-bond-amount":          api.CanBeginReduceBondAmountResponse{},
+	"minipool/can-change-withdrawal-creds":           api.CanChangeWithdrawalCredentialsResponse{},
+	"minipool/can-delegate-rollback":                 api.CanDelegateRollbackResponse{},
+	"minipool/can-delegate-upgrade":                  api.CanDelegateUpgradeResponse{},
+	"minipool/can-dissolve":                          api.CanDissolveMinipoolResponse{},
+	"minipool/can-exit":                              api.CanExitMinipoolResponse{},
+	"minipool/can-promote":                           api.CanPromoteMinipoolResponse{},
+	"minipool/can-reduce-bond-amount":                api.CanReduceBondAmountResponse{},
+	"minipool/can-refund":                            api.CanRefundMinipoolResponse{},
+	"minipool/can-set-use-latest-delegate":           api.CanSetUseLatestDelegateResponse{},
+	"minipool/can-set-use-latest-delegates":          api.CanSetUseLatestDelegatesResponse{},
+	"minipool/can-stake":                             api.CanStakeMinipoolResponse{},
+	"minipool/change-withdrawal-creds":               api.ChangeWithdrawalCredentialsResponse{},
+	"minipool/close":                                 api.CloseMinipoolResponse{},
+	"minipool/delegate-rollback":                     api.DelegateRollbackResponse{},
+	"minipool/delegate-upgrade":                      api.DelegateUpgradeResponse{},
+	"minipool/dissolve":                              api.DissolveMinipoolResponse{},
+	"minipool/distribute-balance":                    api.DistributeBalanceResponse{},
+	"minipool/exit":                                  api.ExitMinipoolResponse{},
+	"minipool/get-delegate-status":                   api.MinipoolDelegateStatusResponse{},
+	"minipool/get-distribute-balance-details":        api.GetDistributeBalanceDetailsResponse{},
+	"minipool/get-minipool-close-details-for-node":   api.GetMinipoolCloseDetailsForNodeResponse{},
+	"minipool/get-rescue-dissolved-details-for-node": api.GetMinipoolRescueDissolvedDetailsForNodeResponse{},
+	"minipool/get-vanity-artifacts":                  api.GetVanityArtifactsResponse{},
+	"minipool/import-key":                            api.ChangeWithdrawalCredentialsResponse{},
+	"minipool/promote":                               api.PromoteMinipoolResponse{},
+	"minipool/reduce-bond-amount":                    api.ReduceBondAmountResponse{},
+	"minipool/refund":                                api.RefundMinipoolResponse{},
+	"minipool/rescue-dissolved":                      api.RescueDissolvedMinipoolResponse{},
+	"minipool/set-use-latest-delegate":               api.SetUseLatestDelegateResponse{},
+	"minipool/stake":                                 api.StakeMinipoolResponse{},
+	"minipool/status":                                api.MinipoolStatusResponse{},
+	"network/apr":                                    api.NetworkAprResponse{},
+	"network/can-generate-rewards-tree":              api.CanNetworkGenerateRewardsTreeResponse{},
+	"network/contracts":                              api.NetworkContractsResponse{},
+	"network/dao-proposals":                          api.NetworkDAOProposalsResponse{},
+	"network/decode-proposal-payload":                api.DecodeProposalPayloadResponse{},
+	"network/download-rewards-file":                  api.DownloadRewardsFileResponse{},
+	"network/generate-rewards-tree":                  api.NetworkGenerateRewardsTreeResponse{},
+	"network/get-fiat-prices":                        api.FiatPricesResponse{},
+	"network/get-gas-fee-history":                    api.GasFeeHistoryResponse{},
+	"network/get-node-fee-history":                   api.NodeFeeHistoryResponse{},
+	"network/get-rewards-tree-progress":              api.NetworkRewardsTreeProgressResponse{},
+	"network/is-atlas-deployed":                      api.IsAtlasDeployedResponse{},
+	"network/latest-delegate":                        api.GetLatestDelegateResponse{},
+	"network/node-fee":                               api.NodeFeeResponse{},
+	"network/rpl-price":                              api.RplPriceResponse{},
+	"network/stats":                                  api.NetworkStatsResponse{},
+	"network/stats-snapshot":                         api.NetworkStatsSnapshotResponse{},
+	"network/timezone-map":                           api.NetworkTimezonesResponse{},
+	"node/burn":                                      api.NodeBurnResponse{},
+	"node/can-burn":                                  api.CanNodeBurnResponse{},
+	"node/can-claim-and-stake-rewards":               api.CanNodeClaimAndStakeRewardsResponse{},
+	"node/can-claim-rewards":                         api.CanNodeClaimRewardsResponse{},
+	"node/can-claim-rpl-rewards":                     api.CanNodeClaimRplResponse{},
+	"node/can-claim-stranded-asset":                  api.CanNodeClaimStrandedAssetResponse{},
+	"node/can-confirm-withdrawal-address":            api.CanSetNodeWithdrawalAddressResponse{},
+	"node/can-create-vacant-minipool":                api.CanCreateVacantMinipoolResponse{},
+	"node/can-deposit":                               api.CanNodeDepositResponse{},
+	"node/can-distribute":                            api.NodeCanDistributeResponse{},
+	"node/can-register":                              api.CanRegisterNodeResponse{},
+	"node/can-send":                                  api.CanNodeSendResponse{},
+	"node/can-send-message":                          api.CanNodeSendMessageResponse{},
+	"node/can-set-approval":                          api.CanNodeSetApprovalResponse{},
+	"node/can-set-smoothing-pool-status":             api.CanSetSmoothingPoolRegistrationStatusResponse{},
+	"node/can-set-stake-rpl-for-allowed":             api.CanSetStakeRplForAllowedResponse{},
+	"node/can-set-timezone":                          api.CanSetNodeTimezoneResponse{},
+	"node/can-set-withdrawal-address":                api.CanSetNodeWithdrawalAddressResponse{},
+	"node/can-stake-rpl":                             api.CanNodeStakeRplResponse{},
+	"node/can-swap-rpl":                              api.CanNodeSwapRplResponse{},
+	"node/can-withdraw-rpl":                          api.CanNodeWithdrawRplResponse{},
+	"node/check-collateral":                          api.CheckCollateralResponse{},
+	"node/claim-and-stake-rewards":                   api.NodeClaimAndStakeRewardsResponse{},
+	"node/claim-rewards":                             api.NodeClaimRewardsResponse{},
+	"node/claim-rpl-rewards":                         api.NodeClaimRplResponse{},
+	"node/claim-stranded-asset":                      api.NodeClaimStrandedAssetResponse{},
+	"node/clear-snapshot-delegate":                   api.ClearSnapshotDelegateResponse{},
+	"node/confirm-withdrawal-address":                api.SetNodeWithdrawalAddressResponse{},
+	"node/create-vacant-minipool":                    api.CreateVacantMinipoolResponse{},
+	"node/deposit":                                   api.NodeDepositResponse{},
+	"node/deposit-contract-info":                     api.DepositContractInfoResponse{},
+	"node/distribute":                                api.NodeDistributeResponse{},
+	"node/dvt-status":                                api.NodeDvtStatusResponse{},
+	"node/estimate-clear-snapshot-delegate-gas":      api.EstimateClearSnapshotDelegateGasResponse{},
+	"node/estimate-deposit":                          api.NodeEstimateDepositResponse{},
+	"node/estimate-set-snapshot-delegate-gas":        api.EstimateSetSnapshotDelegateGasResponse{},
+	"node/get-activity":                              api.NodeActivityResponse{},
+	"node/get-approvals":                             api.NodeApprovalsResponse{},
+	"node/get-block-building":                        api.NodeGetBlockBuildingResponse{},
+	"node/get-eth-balance":                           api.NodeEthBalanceResponse{},
+	"node/get-graffiti":                              api.NodeGetGraffitiResponse{},
+	"node/get-income":                                api.NodeIncomeResponse{},
+	"node/get-initialize-fee-distributor-gas":        api.NodeInitializeFeeDistributorGasResponse{},
+	"node/get-pending-transactions":                  api.NodePendingTransactionsResponse{},
+	"node/get-recent-alerts":                         api.NodeRecentAlertsResponse{},
+	"node/get-rewards-info":                          api.NodeGetRewardsInfoResponse{},
+	"node/get-smoothing-pool-registration-status":    api.GetSmoothingPoolRegistrationStatusResponse{},
+	"node/get-stake-rpl-approval-gas":                api.NodeStakeRplApproveGasResponse{},
+	"node/get-stranded-assets":                       api.NodeStrandedAssetsResponse{},
+	"node/get-swap-rpl-approval-gas":                 api.NodeSwapRplApproveGasResponse{},
+	"node/history":                                   api.NodeHistoryResponse{},
+	"node/initialize-fee-distributor":                api.NodeInitializeFeeDistributorResponse{},
+	"node/is-fee-distributor-initialized":            api.NodeIsFeeDistributorInitializedResponse{},
+	"node/mev-status":                                api.NodeMevStatusResponse{},
+	"node/proposals":                                 api.NodeProposalsResponse{},
+	"node/register":                                  api.RegisterNodeResponse{},
+	"node/resolve-ens-name":                          api.ResolveEnsNameResponse{},
+	"node/reverse-resolve-ens-name":                  api.ResolveEnsNameResponse{},
+	"node/rewards":                                   api.NodeRewardsResponse{},
+	"node/send":                                      api.NodeSendResponse{},
+	"node/send-message":                              api.NodeSendMessageResponse{},
+	"node/set-approval":                              api.NodeSetApprovalResponse{},
+	"node/set-minipool-block-building":               api.SetMinipoolBlockBuildingResponse{},
+	"node/set-minipool-graffiti":                     api.SetMinipoolGraffitiResponse{},
+	"node/set-smoothing-pool-status":                 api.SetSmoothingPoolRegistrationStatusResponse{},
+	"node/set-snapshot-delegate":                     api.SetSnapshotDelegateResponse{},
+	"node/set-stake-rpl-for-allowed":                 api.SetStakeRplForAllowedResponse{},
+	"node/set-timezone":                              api.SetNodeTimezoneResponse{},
+	"node/set-withdrawal-address":                    api.SetNodeWithdrawalAddressResponse{},
+	"node/sign-message":                              api.NodeSignResponse{},
+	"node/stake-rpl":                                 api.NodeStakeRplStakeResponse{},
+	"node/stake-rpl-allowance":                       api.NodeStakeRplAllowanceResponse{},
+	"node/stake-rpl-approve-rpl":                     api.NodeStakeRplApproveResponse{},
+	"node/status":                                    api.NodeStatusResponse{},
+	"node/swap-rpl":                                  api.NodeSwapRplSwapResponse{},
+	"node/swap-rpl-allowance":                        api.NodeSwapRplAllowanceResponse{},
+	"node/swap-rpl-approve-rpl":                      api.NodeSwapRplApproveResponse{},
+	"node/sync":                                      api.NodeSyncProgressResponse{},
+	"node/uptime":                                    api.NodeUptimeResponse{},
+	"node/wait-and-stake-rpl":                        api.NodeStakeRplStakeResponse{},
+	"node/wait-and-swap-rpl":                         api.NodeSwapRplSwapResponse{},
+	"node/withdraw-rpl":                              api.NodeWithdrawRplResponse{},
+	"odao/approve-rpl-price":                         api.ApproveTNDAORplPriceResponse{},
+	"odao/can-cancel-proposal":                       api.CanCancelTNDAOProposalResponse{},
+	"odao/can-execute-proposal":                      api.CanExecuteTNDAOProposalResponse{},
+	"odao/can-join":                                  api.CanJoinTNDAOResponse{},
+	"odao/can-leave":                                 api.CanLeaveTNDAOResponse{},
+	"odao/can-propose-bond-reduction-window-length":  api.CanProposeTNDAOSettingResponse{},
+	"odao/can-propose-bond-reduction-window-start":   api.CanProposeTNDAOSettingResponse{},
+	"odao/can-propose-invite":                        api.CanProposeTNDAOInviteResponse{},
+	"odao/can-propose-kick":                          api.CanProposeTNDAOKickResponse{},
+	"odao/can-propose-leave":                         api.CanProposeTNDAOLeaveResponse{},
+	"odao/can-propose-members-minipool-unbonded-max": api.CanProposeTNDAOSettingResponse{},
+	"odao/can-propose-members-quorum":                api.CanProposeTNDAOSettingResponse{},
+	"odao/can-propose-members-rplbond":               api.CanProposeTNDAOSettingResponse{},
+	"odao/can-propose-promotion-scrub-period":        api.CanProposeTNDAOSettingResponse{},
+	"odao/can-propose-proposal-action-timespan":      api.CanProposeTNDAOSettingResponse{},
+	"odao/can-propose-proposal-cooldown":             api.CanProposeTNDAOSettingResponse{},
+	"odao/can-propose-proposal-execute-timespan":     api.CanProposeTNDAOSettingResponse{},
+	"odao/can-propose-proposal-vote-delay-timespan":  api.CanProposeTNDAOSettingResponse{},
+	"odao/can-propose-proposal-vote-timespan":        api.CanProposeTNDAOSettingResponse{},
+	"odao/can-propose-replace":                       api.CanProposeTNDAOReplaceResponse{},
+	"odao/can-propose-scrub-penalty-enabled":         api.CanProposeTNDAOSettingResponse{},
+	"odao/can-propose-scrub-period":                  api.CanProposeTNDAOSettingResponse{},
+	"odao/can-propose-setting":                       api.CanProposeTNDAOSettingResponse{},
+	"odao/can-replace":                               api.CanReplaceTNDAOPositionResponse{},
+	"odao/can-vote-proposal":                         api.CanVoteOnTNDAOProposalResponse{},
+	"odao/cancel-proposal":                           api.CancelTNDAOProposalResponse{},
+	"odao/execute-proposal":                          api.ExecuteTNDAOProposalResponse{},
+	"odao/get-member-settings":                       api.GetTNDAOMemberSettingsResponse{},
+	"odao/get-minipool-settings":                     api.GetTNDAOMinipoolSettingsResponse{},
+	"odao/get-proposal-settings":                     api.GetTNDAOProposalSettingsResponse{},
+	"odao/join":                                      api.JoinTNDAOJoinResponse{},
+	"odao/join-approve-rpl":                          api.JoinTNDAOApproveResponse{},
+	"odao/leave":                                     api.LeaveTNDAOResponse{},
+	"odao/members":                                   api.TNDAOMembersResponse{},
+	"odao/proposal-details":                          api.TNDAOProposalResponse{},
+	"odao/proposals":                                 api.TNDAOProposalsResponse{},
+	"odao/propose-bond-reduction-window-length":      api.ProposeTNDAOSettingBondReductionWindowLengthResponse{},
+	"odao/propose-bond-reduction-window-start":       api.ProposeTNDAOSettingBondReductionWindowStartResponse{},
+	"odao/propose-invite":                            api.ProposeTNDAOInviteResponse{},
+	"odao/propose-kick":                              api.ProposeTNDAOKickResponse{},
+	"odao/propose-leave":                             api.ProposeTNDAOLeaveResponse{},
+	"odao/propose-members-minipool-unbonded-max":     api.ProposeTNDAOSettingMinipoolUnbondedMaxResponse{},
+	"odao/propose-members-quorum":                    api.ProposeTNDAOSettingMembersQuorumResponse{},
+	"odao/propose-members-rplbond":                   api.ProposeTNDAOSettingMembersRplBondResponse{},
+	"odao/propose-promotion-scrub-period":            api.ProposeTNDAOSettingPromotionScrubPeriodResponse{},
+	"odao/propose-proposal-action-timespan":          api.ProposeTNDAOSettingProposalActionTimespanResponse{},
+	"odao/propose-proposal-cooldown":                 api.ProposeTNDAOSettingProposalCooldownResponse{},
+	"odao/propose-proposal-execute-timespan":         api.ProposeTNDAOSettingProposalExecuteTimespanResponse{},
+	"odao/propose-proposal-vote-delay-timespan":      api.ProposeTNDAOSettingProposalVoteDelayTimespanResponse{},
+	"odao/propose-proposal-vote-timespan":            api.ProposeTNDAOSettingProposalVoteTimespanResponse{},
+	"odao/propose-replace":                           api.ProposeTNDAOReplaceResponse{},
+	"odao/propose-scrub-penalty-enabled":             api.ProposeTNDAOSettingScrubPenaltyEnabledResponse{},
+	"odao/propose-scrub-period":                      api.ProposeTNDAOSettingScrubPeriodResponse{},
+	"odao/replace":                                   api.ReplaceTNDAOPositionResponse{},
+	"odao/status":                                    api.TNDAOStatusResponse{},
+	"odao/submissions":                               api.TNDAOSubmissionsResponse{},
+	"odao/vote-proposal":                             api.VoteOnTNDAOProposalResponse{},
+	"queue/can-process":                              api.CanProcessQueueResponse{},
+	"queue/process":                                  api.ProcessQueueResponse{},
+	"queue/status":                                   api.QueueStatusResponse{},
+	"service/approve-command":                        api.ConfirmationStatusResponse{},
+	"service/check-backup":                           api.CheckBackupResponse{},
+	"service/check-slashing-protection":              api.CheckSlashingProtectionResponse{},
+	"service/confirm-command":                        api.ConfirmationStatusResponse{},
+	"service/create-backup":                          api.CreateBackupResponse{},
+	"service/decrypt-validator-keys":                 api.DecryptValidatorKeysResponse{},
+	"service/encrypt-validator-keys":                 api.EncryptValidatorKeysResponse{},
+	"service/fork-mine":                              api.ForkMineResponse{},
+	"service/fork-set-balance":                       api.ForkSetBalanceResponse{},
+	"service/fork-status":                            api.ForkStatusResponse{},
+	"service/fork-warp":                              api.ForkWarpResponse{},
+	"service/get-addon-status":                       api.AddonStatusResponse{},
+	"service/get-audit-log":                          api.GetAuditLogResponse{},
+	"service/get-client-status":                      api.ClientStatusResponse{},
+	"service/get-confirmation-requests":              api.ConfirmationRequestsResponse{},
+	"service/get-confirmation-status":                api.ConfirmationStatusResponse{},
+	"service/migrate-secrets":                        api.MigrateSecretsResponse{},
+	"service/profile":                                api.CollectProfileResponse{},
+	"service/request-confirmation":                   api.ConfirmationStatusResponse{},
+	"service/restart-vc":                             api.RestartVcResponse{},
+	"service/restore-backup":                         api.RestoreBackupResponse{},
+	"service/rpc-usage":                              api.RpcUsageResponse{},
+	"service/set-confirmation-passphrase":            api.SetConfirmationPassphraseResponse{},
+	"service/system-status":                          api.SystemStatusResponse{},
+	"service/task-status":                            api.TaskStatusResponse{},
+	"service/terminate-data-folder":                  api.TerminateDataFolderResponse{},
+	"service/update-status":                          api.UpdateStatusResponse{},
+	"wait":                                           api.APIResponse{},
+	"wallet/estimate-gas-set-ens-name":               api.SetEnsNameResponse{},
+	"wallet/export":                                  api.ExportWalletResponse{},
+	"wallet/init":                                    api.InitWalletResponse{},
+	"wallet/lock":                                    api.LockWalletResponse{},
+	"wallet/rebuild":                                 api.RebuildWalletResponse{},
+	"wallet/recover":                                 api.RecoverWalletResponse{},
+	"wallet/search-and-recover":                      api.SearchAndRecoverWalletResponse{},
+	"wallet/set-ens-name":                            api.SetEnsNameResponse{},
+	"wallet/set-password":                            api.SetPasswordResponse{},
+	"wallet/status":                                  api.WalletStatusResponse{},
+	"wallet/test-recovery":                           api.RecoverWalletResponse{},
+	"wallet/test-search-and-recover":                 api.SearchAndRecoverWalletResponse{},
+	"wallet/unlock":                                  api.UnlockWalletResponse{},
+}
@@ -0,0 +1,244 @@
+package schema
+
+//go:generate go run ./gen
+
+import (
+	"encoding"
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Settings
+const openApiVersion string = "3.0.3"
+
+// A route for the OpenAPI document
+type Route struct {
+	// The route's path on the server
+	Path string
+
+	// The api command it runs, such as node/status, or empty for the server's own routes
+	Command string
+
+	// The HTTP method it takes
+	Method string
+
+	// The role a key needs to use it
+	Role string
+
+	// A short description of the route
+	Summary string
+
+	// True if the route takes an idempotency key
+	Idempotent bool
+}
+
+// Builds the OpenAPI document for the node daemon's API server from the response types of its commands
+type builder struct {
+	schemas map[string]interface{}
+}
+
+// Build the OpenAPI document for the provided routes
+func Build(routes []Route, version string) ([]byte, error) {
+	b := &builder{
+		schemas: map[string]interface{}{},
+	}
+	errorSchema := b.schemaFor(reflect.TypeOf(api.APIResponse{}))
+	requestSchema := b.schemaFor(reflect.TypeOf(api.ServerRequest{}))
+	errorResponse := func(description string) map[string]interface{} {
+		return map[string]interface{}{
+			"description": description,
+			"content": map[string]interface{}{
+				"application/json": map[string]interface{}{"schema": errorSchema},
+			},
+		}
+	}
+
+	paths := map[string]interface{}{}
+	for _, route := range routes {
+		var responseSchema interface{}
+		if route.Command != "" {
+			responseType, exists := routeResponses[route.Command]
+			if !exists {
+				// Commands the API client doesn't use yet only have the standard fields
+				responseType = api.APIResponse{}
+			}
+			responseSchema = b.schemaFor(reflect.TypeOf(responseType))
+		}
+
+		operation := map[string]interface{}{
+			"operationId": strings.ReplaceAll(strings.Trim(route.Path, "/"), "/", "_"),
+			"summary":     route.Summary,
+			"x-role":      route.Role,
+			"responses": map[string]interface{}{
+				"401": errorResponse("The API key is missing or invalid"),
+				"403": errorResponse("The API key's role doesn't allow this route"),
+				"500": errorResponse("The command couldn't be run"),
+			},
+		}
+		responses := operation["responses"].(map[string]interface{})
+		if responseSchema != nil {
+			responses["200"] = map[string]interface{}{
+				"description": "The command's response; its status is 'error' if the command failed",
+				"content": map[string]interface{}{
+					"application/json": map[string]interface{}{"schema": responseSchema},
+				},
+			}
+		} else {
+			responses["200"] = map[string]interface{}{"description": "Success"}
+		}
+		if route.Idempotent {
+			operation["parameters"] = []interface{}{
+				map[string]interface{}{
+					"name":        "Idempotency-Key",
+					"in":          "header",
+					"required":    false,
+					"description": "A unique key for this submission; repeating it returns the original response instead of running the command again. It can also be sent in the request's idempotencyKey.",
+					"schema":      map[string]interface{}{"type": "string"},
+				},
+			}
+			responses["409"] = errorResponse("A command with the same idempotency key is still running")
+			responses["422"] = errorResponse("The idempotency key was already used with different arguments")
+		}
+		if route.Method == "POST" {
+			operation["requestBody"] = map[string]interface{}{
+				"required": true,
+				"content": map[string]interface{}{
+					"application/json": map[string]interface{}{"schema": requestSchema},
+				},
+			}
+		}
+		paths[route.Path] = map[string]interface{}{
+			strings.ToLower(route.Method): operation,
+		}
+	}
+
+	document := map[string]interface{}{
+		"openapi": openApiVersion,
+		"info": map[string]interface{}{
+			"title":       "Rocket Pool Smartnode API",
+			"version":     version,
+			"description": "The node daemon's API server. Each command route runs the matching `rocketpool api` command with the positional arguments in the request's args.",
+		},
+		"security": []interface{}{
+			map[string]interface{}{"bearer": []interface{}{}},
+		},
+		"paths": paths,
+		"components": map[string]interface{}{
+			"securitySchemes": map[string]interface{}{
+				"bearer": map[string]interface{}{"type": "http", "scheme": "bearer"},
+			},
+			"schemas": b.schemas,
+		},
+	}
+	return json.MarshalIndent(document, "", "    ")
+}
+
+var (
+	bigIntType        = reflect.TypeOf(big.Int{})
+	timeType          = reflect.TypeOf(time.Time{})
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+// Get the schema of a type, adding named structs to the components so they're only described once
+func (b *builder) schemaFor(t reflect.Type) interface{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	// Types with their own JSON encoding
+	switch {
+	case t == bigIntType:
+		return map[string]interface{}{"type": "integer"}
+	case t == timeType:
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	case t.Implements(textMarshalerType) || reflect.PtrTo(t).Implements(textMarshalerType):
+		return map[string]interface{}{"type": "string"}
+	case t.Implements(jsonMarshalerType) || reflect.PtrTo(t).Implements(jsonMarshalerType):
+		return map[string]interface{}{}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			// Byte slices are base64 strings, fixed-size byte arrays are arrays of numbers
+			if t.Kind() == reflect.Slice {
+				return map[string]interface{}{"type": "string", "format": "byte"}
+			}
+		}
+		return map[string]interface{}{"type": "array", "items": b.schemaFor(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": b.schemaFor(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return b.structSchema(t)
+		}
+		name := componentName(t)
+		if _, exists := b.schemas[name]; !exists {
+			// Reserve the name first so self-referencing structs don't recurse forever
+			b.schemas[name] = map[string]interface{}{}
+			b.schemas[name] = b.structSchema(t)
+		}
+		return map[string]interface{}{"$ref": "#/components/schemas/" + name}
+	}
+	return map[string]interface{}{}
+}
+
+// Get the schema of a struct's JSON fields
+func (b *builder) structSchema(t reflect.Type) map[string]interface{} {
+	properties := map[string]interface{}{}
+	b.addFields(t, properties)
+	return map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+	}
+}
+
+// Add a struct's JSON fields to a set of properties, including the fields of embedded structs
+func (b *builder) addFields(t reflect.Type, properties map[string]interface{}) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			for embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				b.addFields(embedded, properties)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		properties[name] = b.schemaFor(field.Type)
+	}
+}
+
+// Get the name of a struct in the components, qualified by its package so names from different packages don't collide
+func componentName(t reflect.Type) string {
+	path := strings.Split(t.PkgPath(), "/")
+	return path[len(path)-1] + "." + t.Name()
+}
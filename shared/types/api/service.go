@@ -1,6 +1,19 @@
 package api
 
-import "github.com/ethereum/go-ethereum/common"
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
+
+	"github.com/rocket-pool/smartnode/addons"
+	"github.com/rocket-pool/smartnode/shared/services/auditlog"
+	"github.com/rocket-pool/smartnode/shared/services/backup"
+	"github.com/rocket-pool/smartnode/shared/services/rpcusage"
+	"github.com/rocket-pool/smartnode/shared/services/sysmon"
+	"github.com/rocket-pool/smartnode/shared/services/tasks"
+	"github.com/rocket-pool/smartnode/shared/services/updates"
+)
 
 type TerminateDataFolderResponse struct {
 	Status        string `json:"status"`
@@ -21,6 +34,9 @@ type ClientStatus struct {
 	SyncProgress float64 `json:"syncProgress"`
 	NetworkId    uint    `json:"networkId"`
 	Error        string  `json:"error"`
+
+	// The hosted provider serving the client, if it's a known one whose limits are worked around
+	Provider string `json:"provider,omitempty"`
 }
 
 // This is a wrapper for the manager's overall status report
@@ -41,3 +57,167 @@ type RestartVcResponse struct {
 	Status string `json:"status"`
 	Error  string `json:"error"`
 }
+
+type TaskStatusResponse struct {
+	Status  string                   `json:"status"`
+	Error   string                   `json:"error"`
+	Daemons []tasks.DaemonTaskStatus `json:"daemons"`
+}
+
+type RpcUsageResponse struct {
+	Status  string                 `json:"status"`
+	Error   string                 `json:"error"`
+	Daemons []rpcusage.DaemonUsage `json:"daemons"`
+}
+
+type AddonStatusResponse struct {
+	Status string               `json:"status"`
+	Error  string               `json:"error"`
+	Report *addons.StatusReport `json:"report"`
+}
+
+type SystemStatusResponse struct {
+	Status       string               `json:"status"`
+	Error        string               `json:"error"`
+	SystemStatus *sysmon.SystemStatus `json:"systemStatus"`
+	CanPrune     bool                 `json:"canPrune"`
+}
+
+type UpdateStatusResponse struct {
+	Status       string                `json:"status"`
+	Error        string                `json:"error"`
+	UpdateStatus *updates.UpdateStatus `json:"updateStatus"`
+}
+
+type CreateBackupResponse struct {
+	Status   string           `json:"status"`
+	Error    string           `json:"error"`
+	Filename string           `json:"filename"`
+	Manifest *backup.Manifest `json:"manifest"`
+}
+
+type CheckBackupResponse struct {
+	Status        string           `json:"status"`
+	Error         string           `json:"error"`
+	Manifest      *backup.Manifest `json:"manifest"`
+	WalletExists  bool             `json:"walletExists"`
+	WalletAddress string           `json:"walletAddress"`
+}
+
+type RestoreBackupResponse struct {
+	Status   string           `json:"status"`
+	Error    string           `json:"error"`
+	Manifest *backup.Manifest `json:"manifest"`
+}
+
+type CheckSlashingProtectionResponse struct {
+	Status         string                  `json:"status"`
+	Error          string                  `json:"error"`
+	ValidatorCount int                     `json:"validatorCount"`
+	NodeValidators []types.ValidatorPubkey `json:"nodeValidators"`
+	MissingPubkeys []types.ValidatorPubkey `json:"missingPubkeys"`
+	CoversAllKeys  bool                    `json:"coversAllKeys"`
+}
+
+// A request to run a command protected by a confirmation policy
+type ConfirmationRequest struct {
+	ID         string    `json:"id"`
+	Route      string    `json:"route"`
+	Phrase     string    `json:"phrase"`
+	Requested  time.Time `json:"requested"`
+	UsableAt   time.Time `json:"usableAt"`
+	Expires    time.Time `json:"expires"`
+	ApprovedBy string    `json:"approvedBy"`
+	Missing    []string  `json:"missing"`
+}
+
+type ConfirmationStatusResponse struct {
+	Status        string               `json:"status"`
+	Error         string               `json:"error"`
+	Route         string               `json:"route"`
+	Protected     bool                 `json:"protected"`
+	Safeguards    []string             `json:"safeguards"`
+	PassphraseSet bool                 `json:"passphraseSet"`
+	Request       *ConfirmationRequest `json:"request"`
+}
+
+type ConfirmationRequestsResponse struct {
+	Status   string                `json:"status"`
+	Error    string                `json:"error"`
+	Requests []ConfirmationRequest `json:"requests"`
+}
+
+type SetConfirmationPassphraseResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+}
+
+type GetAuditLogResponse struct {
+	Status       string           `json:"status"`
+	Error        string           `json:"error"`
+	Entries      []auditlog.Entry `json:"entries"`
+	TotalEntries int              `json:"totalEntries"`
+	Problem      string           `json:"problem"`
+}
+
+type MigrateSecretsResponse struct {
+	Status   string   `json:"status"`
+	Error    string   `json:"error"`
+	Store    string   `json:"store"`
+	Migrated []string `json:"migrated"`
+}
+
+type EncryptValidatorKeysResponse struct {
+	Status                 string `json:"status"`
+	Error                  string `json:"error"`
+	Store                  string `json:"store"`
+	SealedPath             string `json:"sealedPath"`
+	UnsealedPath           string `json:"unsealedPath"`
+	SlashingProtectionPath string `json:"slashingProtectionPath"`
+	RestartRequired        bool   `json:"restartRequired"`
+}
+
+type DecryptValidatorKeysResponse struct {
+	Status          string `json:"status"`
+	Error           string `json:"error"`
+	ValidatorsPath  string `json:"validatorsPath"`
+	RestartRequired bool   `json:"restartRequired"`
+}
+
+type ForkStatusResponse struct {
+	Status      string `json:"status"`
+	Error       string `json:"error"`
+	IsFork      bool   `json:"isFork"`
+	ForkClient  string `json:"forkClient"`
+	ChainID     uint64 `json:"chainId"`
+	BlockNumber uint64 `json:"blockNumber"`
+	BlockTime   uint64 `json:"blockTime"`
+}
+
+type ForkWarpResponse struct {
+	Status      string `json:"status"`
+	Error       string `json:"error"`
+	BlockNumber uint64 `json:"blockNumber"`
+	BlockTime   uint64 `json:"blockTime"`
+}
+
+type ForkMineResponse struct {
+	Status      string `json:"status"`
+	Error       string `json:"error"`
+	BlockNumber uint64 `json:"blockNumber"`
+	BlockTime   uint64 `json:"blockTime"`
+}
+
+type ForkSetBalanceResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+}
+
+type CollectProfileResponse struct {
+	Status   string `json:"status"`
+	Error    string `json:"error"`
+	Daemon   string `json:"daemon"`
+	Kind     string `json:"kind"`
+	Filename string `json:"filename"`
+	Size     uint64 `json:"size"`
+}
@@ -1,6 +1,8 @@
 package api
 
 import (
+	"time"
+
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/google/uuid"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
@@ -21,7 +23,11 @@ type WalletStatusResponse struct {
 	Status            string         `json:"status"`
 	Error             string         `json:"error"`
 	PasswordSet       bool           `json:"passwordSet"`
+	PasswordSaved     bool           `json:"passwordSaved"`
 	WalletInitialized bool           `json:"walletInitialized"`
+	WalletLocked      bool           `json:"walletLocked"`
+	SessionActive     bool           `json:"sessionActive"`
+	SessionExpires    time.Time      `json:"sessionExpires"`
 	AccountAddress    common.Address `json:"accountAddress"`
 }
 
@@ -30,6 +36,17 @@ type SetPasswordResponse struct {
 	Error  string `json:"error"`
 }
 
+type UnlockWalletResponse struct {
+	Status         string    `json:"status"`
+	Error          string    `json:"error"`
+	SessionExpires time.Time `json:"sessionExpires"`
+}
+
+type LockWalletResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+}
+
 type InitWalletResponse struct {
 	Status         string         `json:"status"`
 	Error          string         `json:"error"`
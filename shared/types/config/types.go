@@ -10,6 +10,14 @@ type RewardsMode string
 type MevRelayID string
 type MevSelectionMode string
 type NimbusPruningMode string
+type LogFormat string
+type LogLevel string
+type Orchestrator string
+type RestartPolicy string
+type ContainerPriority string
+type DvtProvider string
+type BlockBuildingMode string
+type GasOption string
 
 // Enum to describe which container(s) a parameter impacts, so the Smartnode knows which
 // ones to restart upon a settings change
@@ -108,6 +116,63 @@ const (
 	NimbusPruningMode_Prune   NimbusPruningMode = "prune"
 )
 
+// Enum to describe the daemon log output formats
+const (
+	LogFormat_Color  LogFormat = "color"
+	LogFormat_Json   LogFormat = "json"
+	LogFormat_Logfmt LogFormat = "logfmt"
+)
+
+// Enum to describe the minimum severity of daemon log messages
+const (
+	LogLevel_Debug LogLevel = "debug"
+	LogLevel_Info  LogLevel = "info"
+	LogLevel_Warn  LogLevel = "warn"
+	LogLevel_Error LogLevel = "error"
+)
+
+// Enum to describe the container orchestrators that can run the Smartnode's services
+const (
+	Orchestrator_Docker     Orchestrator = "docker"
+	Orchestrator_Podman     Orchestrator = "podman"
+	Orchestrator_Kubernetes Orchestrator = "kubernetes"
+)
+
+// Enum to describe when Docker restarts a container
+const (
+	RestartPolicy_UnlessStopped RestartPolicy = "unless-stopped"
+	RestartPolicy_Always        RestartPolicy = "always"
+	RestartPolicy_OnFailure     RestartPolicy = "on-failure"
+	RestartPolicy_No            RestartPolicy = "no"
+)
+
+// Enum to describe how much CPU time and disk I/O a container gets when it's competing with the others
+const (
+	ContainerPriority_Low    ContainerPriority = "low"
+	ContainerPriority_Normal ContainerPriority = "normal"
+	ContainerPriority_High   ContainerPriority = "high"
+)
+
+// Enum to describe which distributed validator technology runs the cluster that operates DVT minipools
+const (
+	DvtProvider_Obol DvtProvider = "obol"
+	DvtProvider_Ssv  DvtProvider = "ssv"
+)
+
+// Enum to describe how a minipool's blocks are built
+const (
+	BlockBuildingMode_Default BlockBuildingMode = ""
+	BlockBuildingMode_Builder BlockBuildingMode = "builder"
+	BlockBuildingMode_Local   BlockBuildingMode = "local"
+)
+
+// Enum to describe the gas price options suggested from the recent fee history
+const (
+	GasOption_Low    GasOption = "low"
+	GasOption_Medium GasOption = "medium"
+	GasOption_High   GasOption = "high"
+)
+
 type Config interface {
 	GetConfigTitle() string
 	GetParameters() []*Parameter
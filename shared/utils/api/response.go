@@ -17,15 +17,28 @@ func ZeroIfNil(in **big.Int) {
 	}
 }
 
+// A function that's called with each response before it's printed, if one has been set
+var responseListener func(response interface{})
+
+// Set a function to call with each response before it's printed
+func SetResponseListener(listener func(response interface{})) {
+	responseListener = listener
+}
+
 // Print an API response
 // response must be a pointer to a struct type with Error and Status string fields
 func PrintResponse(response interface{}, responseError error) {
+	fmt.Println(string(MarshalResponse(response, responseError)))
+}
+
+// Get an API response as it's printed
+// response must be a pointer to a struct type with Error and Status string fields
+func MarshalResponse(response interface{}, responseError error) []byte {
 
 	// Check response type
 	r := reflect.ValueOf(response)
 	if !(r.Kind() == reflect.Ptr && r.Type().Elem().Kind() == reflect.Struct) {
-		PrintErrorResponse(errors.New("Invalid API response"))
-		return
+		return MarshalResponse(&api.APIResponse{}, errors.New("Invalid API response"))
 	}
 
 	// Create zero response value if nil
@@ -38,8 +51,7 @@ func PrintResponse(response interface{}, responseError error) {
 	sf := r.Elem().FieldByName("Status")
 	ef := r.Elem().FieldByName("Error")
 	if !(sf.IsValid() && sf.CanSet() && sf.Kind() == reflect.String && ef.IsValid() && ef.CanSet() && ef.Kind() == reflect.String) {
-		PrintErrorResponse(errors.New("Invalid API response"))
-		return
+		return MarshalResponse(&api.APIResponse{}, errors.New("Invalid API response"))
 	}
 
 	// Populate error
@@ -53,16 +65,16 @@ func PrintResponse(response interface{}, responseError error) {
 	} else {
 		sf.SetString("error")
 	}
+	if responseListener != nil {
+		responseListener(response)
+	}
 
 	// Encode
 	responseBytes, err := json.Marshal(response)
 	if err != nil {
-		PrintErrorResponse(fmt.Errorf("Could not encode API response: %w", err))
-		return
+		return MarshalResponse(&api.APIResponse{}, fmt.Errorf("Could not encode API response: %w", err))
 	}
-
-	// Print
-	fmt.Println(string(responseBytes))
+	return responseBytes
 
 }
 
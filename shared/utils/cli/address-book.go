@@ -0,0 +1,27 @@
+package cli
+
+import (
+	"github.com/rocket-pool/smartnode/shared/services/addressbook"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+// Load the address book, prompting for its passphrase if it's encrypted
+func LoadAddressBook(rp *rocketpool.Client) (*addressbook.AddressBook, string, error) {
+	path, err := rp.GetAddressBookPath()
+	if err != nil {
+		return nil, "", err
+	}
+	encrypted, err := addressbook.IsEncrypted(path)
+	if err != nil {
+		return nil, "", err
+	}
+	passphrase := ""
+	if encrypted {
+		passphrase = PromptPassword("Please enter the address book's passphrase:", "^.+$", "")
+	}
+	book, err := addressbook.Load(path, passphrase)
+	if err != nil {
+		return nil, "", err
+	}
+	return book, path, nil
+}
@@ -0,0 +1,39 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/rocket-pool/smartnode/shared/services/addressbook"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+// Get the address from an argument that can be an address, an ENS name or the name of an address book entry, along with
+// a description of it for display
+func ResolveAddress(rp *rocketpool.Client, name string, addressOrName string) (common.Address, string, error) {
+	if strings.Contains(addressOrName, ".") {
+		response, err := rp.ResolveEnsName(addressOrName)
+		if err != nil {
+			return common.Address{}, "", err
+		}
+		return response.Address, fmt.Sprintf("%s (%s)", addressOrName, response.Address.Hex()), nil
+	}
+	if addressbook.IsValidName(addressOrName) {
+		book, _, err := LoadAddressBook(rp)
+		if err != nil {
+			return common.Address{}, "", err
+		}
+		address, exists := book.Get(addressOrName)
+		if !exists {
+			return common.Address{}, "", fmt.Errorf("Invalid %s '%s': it isn't an address, an ENS name or the name of an address book entry", name, addressOrName)
+		}
+		return address, fmt.Sprintf("%s (%s)", addressOrName, address.Hex()), nil
+	}
+	address, err := ValidateAddress(name, addressOrName)
+	if err != nil {
+		return common.Address{}, "", err
+	}
+	return address, address.Hex(), nil
+}
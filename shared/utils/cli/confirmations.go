@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rocket-pool/smartnode/shared/services/confirmations"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Walk the user through the confirmation policy of a protected command, requesting it and confirming its phrase and passphrase
+// as needed. The request only allows the provided runs of the command, each with the exact arguments the API is called with.
+// Returns true if the command can run now, or false after explaining what it's still waiting for.
+func ConfirmProtectedCommand(rp *rocketpool.Client, route string, invocations ...[]string) (bool, error) {
+
+	// Check if the command is protected and already has a request for these runs
+	status, err := rp.GetConfirmationStatus(route, invocations)
+	if err != nil {
+		return false, err
+	}
+	if !status.Protected {
+		return true, nil
+	}
+
+	// Request it if there isn't a pending request yet
+	if status.Request == nil {
+		fmt.Printf("%s[%s] is protected by a confirmation policy with these safeguards: %s.%s\n\n", colorYellow, route, strings.Join(status.Safeguards, ", "), colorReset)
+		status, err = rp.RequestConfirmation(route, invocations)
+		if err != nil {
+			return false, err
+		}
+	}
+	request := status.Request
+
+	// Confirm the phrase and passphrase
+	needsPhrase := hasSafeguard(request.Missing, confirmations.Safeguard_Phrase)
+	needsPassphrase := hasSafeguard(request.Missing, confirmations.Safeguard_Passphrase)
+	if needsPhrase || needsPassphrase {
+		phrase := ""
+		if needsPhrase {
+			phrase = Prompt(fmt.Sprintf("Please type '%s' to confirm you want to run this command:", request.Phrase), "^.+$", "Please type the phrase:")
+		}
+		passphrase := ""
+		if needsPassphrase {
+			if !status.PassphraseSet {
+				return false, fmt.Errorf("The confirmation passphrase hasn't been set. Please set it with `rocketpool service set-confirmation-passphrase` first.")
+			}
+			passphrase = PromptPassword("Please enter the confirmation passphrase:", "^.+$", "")
+		}
+		status, err = rp.ConfirmCommand(request.ID, phrase, passphrase)
+		if err != nil {
+			return false, err
+		}
+		request = status.Request
+	}
+
+	// Explain what the request is still waiting for
+	if len(request.Missing) == 0 {
+		return true, nil
+	}
+	fmt.Printf("Confirmation request %s for [%s] isn't ready yet:\n", request.ID, route)
+	if hasSafeguard(request.Missing, confirmations.Safeguard_Delay) {
+		fmt.Printf("\t- It has to wait for the confirmation delay, until %s.\n", request.UsableAt.Local().Format("2006-01-02 15:04:05"))
+	}
+	if hasSafeguard(request.Missing, confirmations.Safeguard_Device) {
+		fmt.Printf("\t- It has to be approved from one of the confirmation devices with `rocketpool service approve-command %s`.\n", request.ID)
+	}
+	fmt.Printf("Once it's ready, run this command again with the same arguments before %s.\n", request.Expires.Local().Format("2006-01-02 15:04:05"))
+	return false, nil
+
+}
+
+// Print a pending confirmation request
+func PrintConfirmationRequest(request api.ConfirmationRequest) {
+	fmt.Printf("%s%s%s\n", colorGreen, request.ID, colorReset)
+	fmt.Printf("    Command:   %s\n", request.Route)
+	fmt.Printf("    Requested: %s\n", request.Requested.Local().Format("2006-01-02 15:04:05"))
+	fmt.Printf("    Usable at: %s\n", request.UsableAt.Local().Format("2006-01-02 15:04:05"))
+	fmt.Printf("    Expires:   %s\n", request.Expires.Local().Format("2006-01-02 15:04:05"))
+	if request.ApprovedBy != "" {
+		fmt.Printf("    Approved:  by %s\n", request.ApprovedBy)
+	}
+	if len(request.Missing) > 0 {
+		fmt.Printf("    Missing:   %s\n", strings.Join(request.Missing, ", "))
+	}
+}
+
+// Check if a list of safeguards includes the provided one
+func hasSafeguard(safeguards []string, safeguard confirmations.Safeguard) bool {
+	for _, s := range safeguards {
+		if s == string(safeguard) {
+			return true
+		}
+	}
+	return false
+}
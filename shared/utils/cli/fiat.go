@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+// Annotates ETH and RPL amounts with their approximate value in the node operator's fiat currency
+type FiatFormatter struct {
+	enabled  bool
+	currency string
+	ethPrice float64
+	rplPrice float64
+}
+
+// Get a formatter with the current fiat prices. Fiat values are a nice-to-have, so if they're disabled or the prices aren't
+// available, the formatter just doesn't add anything to the amounts.
+func NewFiatFormatter(rp *rocketpool.Client) *FiatFormatter {
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil || isNew || cfg.Smartnode.ShowFiatValues.Value != true {
+		return &FiatFormatter{}
+	}
+	response, err := rp.FiatPrices()
+	if err != nil {
+		fmt.Printf("%sNOTE: couldn't get fiat prices, so fiat values won't be shown (%s)%s\n\n", colorYellow, err.Error(), colorReset)
+		return &FiatFormatter{}
+	}
+	return &FiatFormatter{
+		enabled:  true,
+		currency: strings.ToUpper(response.Currency),
+		ethPrice: response.EthPrice,
+		rplPrice: response.RplPrice,
+	}
+}
+
+// Get the approximate fiat value of an amount of ETH as a suffix for it, or an empty string if fiat values aren't shown
+func (f *FiatFormatter) Eth(amount float64) string {
+	if !f.enabled {
+		return ""
+	}
+	return fmt.Sprintf(" (~%.2f %s)", amount*f.ethPrice, f.currency)
+}
+
+// Get the approximate fiat value of an amount of RPL as a suffix for it, or an empty string if fiat values aren't shown
+func (f *FiatFormatter) Rpl(amount float64) string {
+	if !f.enabled {
+		return ""
+	}
+	return fmt.Sprintf(" (~%.2f %s)", amount*f.rplPrice, f.currency)
+}
+
+// Get the approximate fiat value of a range of ETH amounts, or an empty string if fiat values aren't shown
+func (f *FiatFormatter) EthRange(low float64, high float64) string {
+	if !f.enabled {
+		return ""
+	}
+	return fmt.Sprintf("~%.2f to %.2f %s", low*f.ethPrice, high*f.ethPrice, f.currency)
+}
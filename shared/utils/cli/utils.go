@@ -7,6 +7,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
 	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 )
 
@@ -111,6 +112,18 @@ func PrettyPrintError(err error) {
 	fmt.Println(prettyErr)
 }
 
+// Prints the pre-flight checks a command ran as a checklist, so every reason it can't run is shown at once
+func PrintPreflightResult(header string, result api.PreflightResult) {
+	fmt.Println(header)
+	for _, check := range result.Checks {
+		if check.Passed {
+			fmt.Printf("\t%s[✓]%s %s\n", colorGreen, colorReset, check.Name)
+		} else {
+			fmt.Printf("\t%s[✗]%s %s: %s\n", colorRed, colorReset, check.Name, check.Failure)
+		}
+	}
+}
+
 // Prints an error message when the Beacon client is not using the deposit contract address that Rocket Pool expects
 func PrintDepositMismatchError(rpNetwork, beaconNetwork uint64, rpDepositAddress, beaconDepositAddress common.Address) {
 	fmt.Printf("%s***ALERT***\n", colorRed)
@@ -139,6 +152,15 @@ func PrintNetwork(rp *rocketpool.Client) error {
 	}
 
 	currentNetwork := cfg.Smartnode.Network.Value.(cfgtypes.Network)
+	definition, err := cfg.Smartnode.GetNetworkDefinition()
+	if err != nil {
+		return fmt.Errorf("Error loading custom network definition: %w", err)
+	}
+	if definition != nil {
+		fmt.Printf("Your Smartnode is currently using the %scustom network [%s]%s (chain ID %d, based on %v).\n\n", colorYellow, definition.Name, colorReset, definition.ChainID, currentNetwork)
+		return nil
+	}
+
 	switch currentNetwork {
 	case cfgtypes.Network_Mainnet:
 		fmt.Printf("Your Smartnode is currently using the %sEthereum Mainnet.%s\n\n", colorGreen, colorReset)
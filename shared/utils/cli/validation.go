@@ -13,7 +13,10 @@ import (
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/smartnode/shared/services/activity"
 	"github.com/rocket-pool/smartnode/shared/services/passwords"
+	"github.com/rocket-pool/smartnode/shared/services/upgrades"
+	"github.com/rocket-pool/smartnode/shared/types/api"
 	hexutils "github.com/rocket-pool/smartnode/shared/utils/hex"
 )
 
@@ -34,6 +37,14 @@ func ValidateArgCount(c *cli.Context, count int) error {
 	return nil
 }
 
+// Validate the minimum argument count of a command that takes a variable number of arguments
+func ValidateMinArgCount(c *cli.Context, count int) error {
+	if len(c.Args()) < count {
+		return fmt.Errorf("Incorrect argument count; usage: %s", c.Command.UsageText)
+	}
+	return nil
+}
+
 // Validate a big int
 func ValidateBigInt(name, value string) (*big.Int, error) {
 	val, success := big.NewInt(0).SetString(value, 0)
@@ -209,6 +220,44 @@ func ValidateBurnableTokenType(name, value string) (string, error) {
 	return val, nil
 }
 
+// Validate a token type that allowances can be granted for
+func ValidateApprovalTokenType(name, value string) (string, error) {
+	val := strings.ToLower(value)
+	if !(val == "rpl" || val == "fsrpl" || val == "reth") {
+		return "", fmt.Errorf("Invalid %s '%s' - valid types are 'RPL', 'fsRPL', and 'rETH'", name, value)
+	}
+	return val, nil
+}
+
+// Validate the type of an asset left in a previous contract version
+func ValidateStrandedAssetType(name, value string) (upgrades.AssetType, error) {
+	val := upgrades.AssetType(strings.ToLower(value))
+	switch val {
+	case upgrades.AssetType_RplClaim, upgrades.AssetType_TrustedRplClaim, upgrades.AssetType_FeeDistributor:
+		return val, nil
+	}
+	return "", fmt.Errorf("Invalid %s '%s' - valid types are '%s', '%s', and '%s'", name, value, upgrades.AssetType_RplClaim, upgrades.AssetType_TrustedRplClaim, upgrades.AssetType_FeeDistributor)
+}
+
+// Validate a category of node activity
+func ValidateActivityCategory(name, value string) (activity.Category, error) {
+	val := strings.ToLower(value)
+	if !activity.IsValidCategory(val) {
+		return "", fmt.Errorf("Invalid %s '%s' - valid categories are %v", name, value, activity.Categories)
+	}
+	return activity.Category(val), nil
+}
+
+// Validate the type of an Oracle DAO submission
+func ValidateTNDAOSubmissionType(name, value string) (api.TNDAOSubmissionType, error) {
+	val := api.TNDAOSubmissionType(strings.ToLower(value))
+	switch val {
+	case api.TNDAOSubmissionType_Prices, api.TNDAOSubmissionType_Balances, api.TNDAOSubmissionType_Rewards:
+		return val, nil
+	}
+	return "", fmt.Errorf("Invalid %s '%s' - valid types are '%s', '%s', and '%s'", name, value, api.TNDAOSubmissionType_Prices, api.TNDAOSubmissionType_Balances, api.TNDAOSubmissionType_Rewards)
+}
+
 // Validate a node password
 func ValidateNodePassword(name, value string) (string, error) {
 	if len(value) < passwords.MinPasswordLength {
@@ -1,22 +1,33 @@
 package log
 
 import (
+	"fmt"
 	"log"
+	"strings"
 
 	"github.com/fatih/color"
 )
 
-// Logger with ANSI color output
+// Logger with ANSI color output, or structured output if a structured format has been configured
 type ColorLogger struct {
 	Color       color.Attribute
+	Module      string
+	Level       Level
 	sprintFunc  func(a ...interface{}) string
 	sprintfFunc func(format string, a ...interface{}) string
 }
 
 // Create new color logger
 func NewColorLogger(colorAttr color.Attribute) ColorLogger {
+	return NewModuleLogger("", LevelInfo, colorAttr)
+}
+
+// Create a new logger for a specific module that writes its messages at the provided level
+func NewModuleLogger(module string, level Level, colorAttr color.Attribute) ColorLogger {
 	return ColorLogger{
 		Color:       colorAttr,
+		Module:      module,
+		Level:       level,
 		sprintFunc:  color.New(colorAttr).SprintFunc(),
 		sprintfFunc: color.New(colorAttr).SprintfFunc(),
 	}
@@ -24,20 +35,68 @@ func NewColorLogger(colorAttr color.Attribute) ColorLogger {
 
 // Print values
 func (l *ColorLogger) Print(v ...interface{}) {
-	log.Print(l.sprintFunc(v...))
+	if !isEnabled(l.Module, l.Level) {
+		return
+	}
+	if getFormat() == Format_Color {
+		log.Print(l.sprintFunc(v...))
+		return
+	}
+	writeRecord(l.Module, l.Level, fmt.Sprint(v...))
 }
 
 // Print values with a newline
 func (l *ColorLogger) Println(v ...interface{}) {
-	log.Println(l.sprintFunc(v...))
+	if !isEnabled(l.Module, l.Level) {
+		return
+	}
+	if getFormat() == Format_Color {
+		log.Println(l.sprintFunc(v...))
+		return
+	}
+	writeRecord(l.Module, l.Level, strings.TrimSuffix(fmt.Sprintln(v...), "\n"))
 }
 
 // Print a formatted string
 func (l *ColorLogger) Printf(format string, v ...interface{}) {
-	log.Print(l.sprintfFunc(format, v...))
+	if !isEnabled(l.Module, l.Level) {
+		return
+	}
+	if getFormat() == Format_Color {
+		log.Print(l.sprintfFunc(format, v...))
+		return
+	}
+	writeRecord(l.Module, l.Level, fmt.Sprintf(format, v...))
 }
 
 // Print a formatted string with a newline
 func (l *ColorLogger) Printlnf(format string, v ...interface{}) {
-	log.Println(l.sprintfFunc(format, v...))
+	l.printlnfAt(l.Level, format, v...)
+}
+
+// Print a formatted debug message with a newline, whatever level the logger was created with
+func (l *ColorLogger) Debugf(format string, v ...interface{}) {
+	l.printlnfAt(LevelDebug, format, v...)
+}
+
+// Print a formatted warning with a newline, whatever level the logger was created with
+func (l *ColorLogger) Warnf(format string, v ...interface{}) {
+	l.printlnfAt(LevelWarn, format, v...)
+}
+
+// Print a formatted error with a newline, whatever level the logger was created with
+func (l *ColorLogger) Errorf(format string, v ...interface{}) {
+	l.printlnfAt(LevelError, format, v...)
+}
+
+// Print a formatted string with a newline at the provided level
+func (l *ColorLogger) printlnfAt(level Level, format string, v ...interface{}) {
+	if !isEnabled(l.Module, level) {
+		return
+	}
+	if getFormat() == Format_Color {
+		log.Println(l.sprintfFunc(format, v...))
+		return
+	}
+	writeRecord(l.Module, level, fmt.Sprintf(format, v...))
 }
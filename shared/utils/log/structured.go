@@ -0,0 +1,163 @@
+package log
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Output formats for log messages
+type Format string
+
+const (
+	Format_Color  Format = "color"
+	Format_Json   Format = "json"
+	Format_Logfmt Format = "logfmt"
+)
+
+// Severity levels for log messages
+type Level int
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+// Global logging settings, shared by every logger in the process
+var (
+	settingsLock sync.RWMutex
+	format       Format = Format_Color
+	defaultLevel Level  = LevelDebug
+	moduleLevels map[string]Level
+	writeLock    sync.Mutex
+)
+
+// A single structured log record
+type record struct {
+	Time    string `json:"time"`
+	Level   string `json:"level"`
+	Module  string `json:"module,omitempty"`
+	Message string `json:"msg"`
+}
+
+// Get the name of a level
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "debug"
+	case LevelInfo:
+		return "info"
+	case LevelWarn:
+		return "warn"
+	case LevelError:
+		return "error"
+	default:
+		return "unknown"
+	}
+}
+
+// Parse a level from its name
+func ParseLevel(name string) (Level, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "debug":
+		return LevelDebug, nil
+	case "info":
+		return LevelInfo, nil
+	case "warn", "warning":
+		return LevelWarn, nil
+	case "error":
+		return LevelError, nil
+	default:
+		return LevelInfo, fmt.Errorf("unknown log level '%s'", name)
+	}
+}
+
+// Parse a list of per-module level overrides in the form `module=level,module.submodule=level`
+func ParseModuleLevels(overrides string) (map[string]Level, error) {
+	levels := map[string]Level{}
+	for _, entry := range strings.Split(overrides, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		module, levelName, found := strings.Cut(entry, "=")
+		if !found {
+			return nil, fmt.Errorf("invalid module log level '%s', expected the format 'module=level'", entry)
+		}
+		level, err := ParseLevel(levelName)
+		if err != nil {
+			return nil, fmt.Errorf("invalid module log level '%s': %w", entry, err)
+		}
+		levels[strings.TrimSpace(module)] = level
+	}
+	return levels, nil
+}
+
+// Set the output format, the minimum level to print, and the per-module minimum level overrides for all loggers
+func Configure(newFormat Format, newDefaultLevel Level, newModuleLevels map[string]Level) {
+	settingsLock.Lock()
+	defer settingsLock.Unlock()
+	format = newFormat
+	defaultLevel = newDefaultLevel
+	moduleLevels = newModuleLevels
+}
+
+// Get the configured output format
+func getFormat() Format {
+	settingsLock.RLock()
+	defer settingsLock.RUnlock()
+	return format
+}
+
+// Check if a message at the given level should be printed for a module.
+// Module names are hierarchical and separated by periods, so `watchtower.submit-rpl-price` falls back to the `watchtower` setting.
+func isEnabled(module string, level Level) bool {
+	settingsLock.RLock()
+	defer settingsLock.RUnlock()
+	for name := module; name != ""; {
+		if minLevel, exists := moduleLevels[name]; exists {
+			return level >= minLevel
+		}
+		lastDot := strings.LastIndex(name, ".")
+		if lastDot == -1 {
+			break
+		}
+		name = name[:lastDot]
+	}
+	return level >= defaultLevel
+}
+
+// Write a structured record to the log output
+func writeRecord(module string, level Level, message string) {
+	entry := record{
+		Time:    time.Now().UTC().Format(time.RFC3339Nano),
+		Level:   level.String(),
+		Module:  module,
+		Message: message,
+	}
+
+	var line string
+	if getFormat() == Format_Json {
+		bytes, err := json.Marshal(entry)
+		if err != nil {
+			return
+		}
+		line = string(bytes)
+	} else {
+		line = fmt.Sprintf("time=%s level=%s", entry.Time, entry.Level)
+		if entry.Module != "" {
+			line += " module=" + entry.Module
+		}
+		line += " msg=" + strconv.Quote(entry.Message)
+	}
+
+	writeLock.Lock()
+	defer writeLock.Unlock()
+	fmt.Fprintln(log.Writer(), line)
+}
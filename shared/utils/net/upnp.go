@@ -0,0 +1,241 @@
+package net
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Settings
+const (
+	ssdpAddress       string = "239.255.255.250:1900"
+	igdSearchTarget   string = "urn:schemas-upnp-org:device:InternetGatewayDevice:1"
+	upnpNoSuchEntry   string = "714"
+	soapEnvelopeStart string = `<?xml version="1.0"?><s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/" s:encodingStyle="http://schemas.xmlsoap.org/soap/encoding/"><s:Body>`
+	soapEnvelopeEnd   string = `</s:Body></s:Envelope>`
+)
+
+// The WAN connection services that can manage port mappings
+var wanServiceTypes = []string{
+	"urn:schemas-upnp-org:service:WANIPConnection:2",
+	"urn:schemas-upnp-org:service:WANIPConnection:1",
+	"urn:schemas-upnp-org:service:WANPPPConnection:1",
+}
+
+// A UPnP Internet Gateway Device (usually the router) found on the local network
+type Gateway struct {
+	ControlUrl  string
+	ServiceType string
+
+	// This machine's address on the gateway's network
+	LocalIP net.IP
+}
+
+// A port mapping on a gateway
+type PortMapping struct {
+	InternalClient string
+	InternalPort   string
+	Enabled        bool
+}
+
+// The parts of a UPnP device description needed to find the WAN connection service
+type upnpRoot struct {
+	UrlBase string     `xml:"URLBase"`
+	Device  upnpDevice `xml:"device"`
+}
+type upnpDevice struct {
+	Services []upnpService `xml:"serviceList>service"`
+	Devices  []upnpDevice  `xml:"deviceList>device"`
+}
+type upnpService struct {
+	ServiceType string `xml:"serviceType"`
+	ControlUrl  string `xml:"controlURL"`
+}
+
+// Search the local network for a UPnP Internet Gateway Device. Returns nil if there isn't one or it doesn't respond in time.
+func DiscoverGateway(timeout time.Duration) (*Gateway, error) {
+
+	// Send the SSDP search
+	conn, err := net.ListenPacket("udp4", ":0")
+	if err != nil {
+		return nil, fmt.Errorf("error opening SSDP socket: %w", err)
+	}
+	defer conn.Close()
+	ssdpAddr, err := net.ResolveUDPAddr("udp4", ssdpAddress)
+	if err != nil {
+		return nil, err
+	}
+	search := fmt.Sprintf("M-SEARCH * HTTP/1.1\r\nHOST: %s\r\nST: %s\r\nMAN: \"ssdp:discover\"\r\nMX: 2\r\n\r\n", ssdpAddress, igdSearchTarget)
+	if _, err := conn.WriteTo([]byte(search), ssdpAddr); err != nil {
+		return nil, fmt.Errorf("error sending SSDP search: %w", err)
+	}
+
+	// Use the first gateway that has a WAN connection service
+	deadline := time.Now().Add(timeout)
+	buffer := make([]byte, 2048)
+	for {
+		if err := conn.SetReadDeadline(deadline); err != nil {
+			return nil, err
+		}
+		n, from, err := conn.ReadFrom(buffer)
+		if err != nil {
+			if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("error reading SSDP response: %w", err)
+		}
+		response, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(buffer[:n])), nil)
+		if err != nil {
+			continue
+		}
+		location := response.Header.Get("Location")
+		if location == "" {
+			continue
+		}
+		gateway, err := getGateway(location)
+		if err != nil || gateway == nil {
+			continue
+		}
+		gateway.LocalIP, err = getLocalIP(from)
+		if err != nil {
+			return nil, err
+		}
+		return gateway, nil
+	}
+
+}
+
+// Get the port mapping for an external port on the gateway, or nil if the port isn't mapped
+func (g *Gateway) GetPortMapping(port uint16, protocol string) (*PortMapping, error) {
+	action := "GetSpecificPortMappingEntry"
+	body := fmt.Sprintf("%s<u:%s xmlns:u=\"%s\"><NewRemoteHost></NewRemoteHost><NewExternalPort>%d</NewExternalPort><NewProtocol>%s</NewProtocol></u:%s>%s",
+		soapEnvelopeStart, action, g.ServiceType, port, strings.ToUpper(protocol), action, soapEnvelopeEnd)
+	request, err := http.NewRequest(http.MethodPost, g.ControlUrl, strings.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Content-Type", `text/xml; charset="utf-8"`)
+	request.Header.Set("SOAPAction", fmt.Sprintf(`"%s#%s"`, g.ServiceType, action))
+
+	client := http.Client{Timeout: 5 * time.Second}
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("error querying the gateway's port mappings: %w", err)
+	}
+	defer response.Body.Close()
+	contents, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading the gateway's port mappings: %w", err)
+	}
+
+	// Unmapped ports are reported as a SOAP fault
+	if response.StatusCode != http.StatusOK {
+		var fault struct {
+			ErrorCode string `xml:"Body>Fault>detail>UPnPError>errorCode"`
+		}
+		if err := xml.Unmarshal(contents, &fault); err == nil && fault.ErrorCode == upnpNoSuchEntry {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unexpected http status querying the gateway's port mappings: %d", response.StatusCode)
+	}
+
+	var result struct {
+		InternalPort   string `xml:"Body>GetSpecificPortMappingEntryResponse>NewInternalPort"`
+		InternalClient string `xml:"Body>GetSpecificPortMappingEntryResponse>NewInternalClient"`
+		Enabled        string `xml:"Body>GetSpecificPortMappingEntryResponse>NewEnabled"`
+	}
+	if err := xml.Unmarshal(contents, &result); err != nil {
+		return nil, fmt.Errorf("error decoding the gateway's port mappings: %w", err)
+	}
+	return &PortMapping{
+		InternalClient: result.InternalClient,
+		InternalPort:   result.InternalPort,
+		Enabled:        result.Enabled == "1",
+	}, nil
+}
+
+// Read a gateway's device description and find its WAN connection service. Returns nil if it doesn't have one.
+func getGateway(location string) (*Gateway, error) {
+	client := http.Client{Timeout: 5 * time.Second}
+	response, err := client.Get(location)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	var root upnpRoot
+	if err := xml.NewDecoder(response.Body).Decode(&root); err != nil {
+		return nil, err
+	}
+
+	base, err := url.Parse(location)
+	if err != nil {
+		return nil, err
+	}
+	if root.UrlBase != "" {
+		base, err = url.Parse(root.UrlBase)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, serviceType := range wanServiceTypes {
+		service := findService(root.Device, serviceType)
+		if service == nil {
+			continue
+		}
+		controlUrl, err := base.Parse(service.ControlUrl)
+		if err != nil {
+			return nil, err
+		}
+		return &Gateway{
+			ControlUrl:  controlUrl.String(),
+			ServiceType: serviceType,
+		}, nil
+	}
+	return nil, nil
+}
+
+// Find a service in a device or any of its embedded devices
+func findService(device upnpDevice, serviceType string) *upnpService {
+	for i := range device.Services {
+		if device.Services[i].ServiceType == serviceType {
+			return &device.Services[i]
+		}
+	}
+	for _, child := range device.Devices {
+		if service := findService(child, serviceType); service != nil {
+			return service
+		}
+	}
+	return nil
+}
+
+// Get the address this machine uses to reach a host
+func getLocalIP(remote net.Addr) (net.IP, error) {
+	conn, err := net.Dial("udp4", remote.String())
+	if err != nil {
+		return nil, fmt.Errorf("error finding local address for %s: %w", remote.String(), err)
+	}
+	defer conn.Close()
+	return conn.LocalAddr().(*net.UDPAddr).IP, nil
+}
+
+// Check if an address belongs to one of this machine's network interfaces
+func IsLocalAddress(ip net.IP) (bool, error) {
+	addresses, err := net.InterfaceAddrs()
+	if err != nil {
+		return false, fmt.Errorf("error getting network interface addresses: %w", err)
+	}
+	for _, address := range addresses {
+		if ipNet, ok := address.(*net.IPNet); ok && ipNet.IP.Equal(ip) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
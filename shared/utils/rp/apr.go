@@ -0,0 +1,177 @@
+package rp
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	rpstate "github.com/rocket-pool/rocketpool-go/utils/state"
+
+	"github.com/rocket-pool/smartnode/shared/services/logscan"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+)
+
+// The number of execution layer blocks per day, used to pick the starting block for the trailing APR window
+const blocksPerDay uint64 = 7200
+
+// A point in the rETH exchange rate history, taken from a network balances update
+type RethRateSample struct {
+	Time         time.Time
+	ExchangeRate float64
+}
+
+// A breakdown of the estimated APR for a node
+type NodeApr struct {
+	// The estimated yearly ETH rewards from the node's minipools, including commission on borrowed ETH
+	EthRewards float64 `json:"ethRewards"`
+
+	// The estimated yearly RPL rewards, in RPL
+	RplRewards float64 `json:"rplRewards"`
+
+	// The node's capital (bonded ETH plus the ETH value of its RPL stake)
+	Capital float64 `json:"capital"`
+
+	// The APR of the node's bonded ETH
+	EthApr float64 `json:"ethApr"`
+
+	// The APR of the node's staked RPL
+	RplApr float64 `json:"rplApr"`
+
+	// The APR of the node's total capital
+	TotalApr float64 `json:"totalApr"`
+}
+
+// Get the trailing rETH APR over the provided number of days, based on the exchange rate reported by each network balances update
+func GetTrailingRethApr(rp *rocketpool.RocketPool, days uint64, intervalSize *big.Int) (float64, []RethRateSample, error) {
+
+	// Get the balances contract
+	rocketNetworkBalances, err := rp.GetContract("rocketNetworkBalances", nil)
+	if err != nil {
+		return 0, nil, err
+	}
+	balancesUpdated, exists := rocketNetworkBalances.ABI.Events["BalancesUpdated"]
+	if !exists {
+		return 0, nil, fmt.Errorf("rocketNetworkBalances does not have a BalancesUpdated event")
+	}
+
+	// Get the logs for the window
+	currentBlock, err := rp.Client.BlockNumber(context.Background())
+	if err != nil {
+		return 0, nil, fmt.Errorf("error getting latest block number: %w", err)
+	}
+	fromBlock := uint64(0)
+	if currentBlock > days*blocksPerDay {
+		fromBlock = currentBlock - days*blocksPerDay
+	}
+	logs, err := logscan.GetLogs(rp, []common.Address{*rocketNetworkBalances.Address}, [][]common.Hash{{balancesUpdated.ID}}, intervalSize, big.NewInt(0).SetUint64(fromBlock), nil)
+	if err != nil {
+		return 0, nil, fmt.Errorf("error getting network balances updates: %w", err)
+	}
+
+	// Get the exchange rate from each update
+	samples := []RethRateSample{}
+	for _, log := range logs {
+		values := make(map[string]interface{})
+		if err := balancesUpdated.Inputs.UnpackIntoMap(values, log.Data); err != nil {
+			return 0, nil, fmt.Errorf("error unpacking network balances update in block %d: %w", log.BlockNumber, err)
+		}
+		totalEth, _ := values["totalEth"].(*big.Int)
+		rethSupply, _ := values["rethSupply"].(*big.Int)
+		timestamp, exists := values["blockTimestamp"].(*big.Int)
+		if !exists {
+			// Older versions of the contract call it "time"
+			timestamp, _ = values["time"].(*big.Int)
+		}
+		if totalEth == nil || rethSupply == nil || timestamp == nil || rethSupply.Sign() == 0 {
+			continue
+		}
+		samples = append(samples, RethRateSample{
+			Time:         time.Unix(timestamp.Int64(), 0),
+			ExchangeRate: eth.WeiToEth(totalEth) / eth.WeiToEth(rethSupply),
+		})
+	}
+	if len(samples) < 2 {
+		return 0, samples, nil
+	}
+
+	// Annualize the change between the first and last updates
+	first := samples[0]
+	last := samples[len(samples)-1]
+	elapsed := last.Time.Sub(first.Time)
+	if elapsed <= 0 || first.ExchangeRate == 0 {
+		return 0, samples, nil
+	}
+	growth := last.ExchangeRate/first.ExchangeRate - 1
+	apr := growth * (365 * 24 * time.Hour).Hours() / elapsed.Hours() * 100
+	return apr, samples, nil
+
+}
+
+// Get the APR of a solo validator implied by the rETH APR.
+// rETH holders earn the validator APR on the staking portion of the protocol's ETH, minus the node operator commission.
+func GetValidatorAprFromRethApr(rethApr float64, utilization float64, commission float64) float64 {
+	if utilization == 0 || commission >= 1 {
+		return 0
+	}
+	return rethApr / utilization / (1 - commission)
+}
+
+// Estimate the APR of a node from its minipools and RPL stake, given the validator APR (in percent)
+func EstimateNodeApr(networkState *state.NetworkState, nodeAddress common.Address, totalEffectiveStake *big.Int, validatorApr float64) (NodeApr, error) {
+	nodeApr := NodeApr{}
+	nd, exists := networkState.NodeDetailsByAddress[nodeAddress]
+	if !exists {
+		return nodeApr, fmt.Errorf("node %s was not found in the network state", nodeAddress.Hex())
+	}
+
+	// Get the ETH rewards from each staking minipool: the full APR on the bond, plus the commission on the borrowed ETH
+	bondedEth := float64(0)
+	for _, mpd := range networkState.MinipoolDetailsByNode[nodeAddress] {
+		if mpd.Finalised || mpd.Status != types.Staking {
+			continue
+		}
+		bond := eth.WeiToEth(mpd.NodeDepositBalance)
+		borrowed := 32 - bond
+		commission := eth.WeiToEth(mpd.NodeFee)
+		bondedEth += bond
+		nodeApr.EthRewards += (bond + borrowed*commission) * validatorApr / 100
+	}
+
+	// Get the RPL rewards for the node's effective stake
+	rplStake := eth.WeiToEth(nd.RplStake)
+	nodeApr.RplRewards = eth.WeiToEth(nd.EffectiveRPLStake) * GetYearlyRplRewardsPerRpl(networkState.NetworkDetails, totalEffectiveStake)
+
+	// Get the APRs
+	rplPrice := eth.WeiToEth(networkState.NetworkDetails.RplPrice)
+	nodeApr.Capital = bondedEth + rplStake*rplPrice
+	if bondedEth > 0 {
+		nodeApr.EthApr = nodeApr.EthRewards / bondedEth * 100
+	}
+	if rplStake > 0 {
+		nodeApr.RplApr = nodeApr.RplRewards / rplStake * 100
+	}
+	if nodeApr.Capital > 0 {
+		nodeApr.TotalApr = (nodeApr.EthRewards + nodeApr.RplRewards*rplPrice) / nodeApr.Capital * 100
+	}
+	return nodeApr, nil
+}
+
+// Get the yearly RPL rewards earned by each RPL of effective stake, at the current inflation rate and total effective stake
+func GetYearlyRplRewardsPerRpl(details *rpstate.NetworkDetails, totalEffectiveStake *big.Int) float64 {
+	if totalEffectiveStake == nil || totalEffectiveStake.Sign() == 0 {
+		return 0
+	}
+	intervalDays := details.IntervalDuration.Hours() / 24
+	inflationPerDay := eth.WeiToEth(details.RPLInflationIntervalRate)
+	newRplPerInterval := (math.Pow(inflationPerDay, intervalDays) - 1) * eth.WeiToEth(details.RPLTotalSupply)
+	if newRplPerInterval <= 0 || intervalDays <= 0 {
+		return 0
+	}
+	return newRplPerInterval * eth.WeiToEth(details.NodeOperatorRewardsPercent) * 365 / intervalDays / eth.WeiToEth(totalEffectiveStake)
+}
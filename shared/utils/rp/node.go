@@ -9,9 +9,11 @@ import (
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/rocket-pool/rocketpool-go/minipool"
 	"github.com/rocket-pool/rocketpool-go/node"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/settings/protocol"
 	tnsettings "github.com/rocket-pool/rocketpool-go/settings/trustednode"
 	"github.com/rocket-pool/rocketpool-go/types"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
@@ -63,6 +65,41 @@ func GetNodeValidatorIndices(rp *rocketpool.RocketPool, ec rocketpool.ExecutionC
 	return validatorIndices, nil
 }
 
+// Checks if the protocol lets nodes make minipools without staking any RPL, which it does when the minimum RPL stake per minipool is zero
+func IsEthOnlyStakingPermitted(rp *rocketpool.RocketPool, opts *bind.CallOpts) (bool, error) {
+	minStakeFraction, err := protocol.GetMinimumPerMinipoolStakeRaw(rp, opts)
+	if err != nil {
+		return false, fmt.Errorf("error getting minimum RPL stake per minipool: %w", err)
+	}
+	return minStakeFraction.Sign() == 0, nil
+}
+
+// Checks if the node is staking without any RPL, which the protocol only allows when it doesn't require RPL collateral
+func IsEthOnlyNode(rp *rocketpool.RocketPool, nodeAddress common.Address, opts *bind.CallOpts) (bool, error) {
+	permitted, err := IsEthOnlyStakingPermitted(rp, opts)
+	if err != nil || !permitted {
+		return false, err
+	}
+	rplStake, err := node.GetNodeRPLStake(rp, nodeAddress, opts)
+	if err != nil {
+		return false, fmt.Errorf("error getting RPL stake for node %s: %w", nodeAddress.Hex(), err)
+	}
+	return rplStake.Sign() == 0, nil
+}
+
+// Gets how much ETH the node can borrow from the staking pool. The limit comes from the node's RPL stake, so it doesn't apply
+// when the protocol doesn't require any.
+func GetNodeEthMatchedLimit(rp *rocketpool.RocketPool, nodeAddress common.Address, opts *bind.CallOpts) (*big.Int, error) {
+	ethOnly, err := IsEthOnlyStakingPermitted(rp, opts)
+	if err != nil {
+		return nil, err
+	}
+	if ethOnly {
+		return new(big.Int).Set(math.MaxBig256), nil
+	}
+	return node.GetNodeEthMatchedLimit(rp, nodeAddress, opts)
+}
+
 // Checks the given node's current matched ETH, its limit on matched ETH, and how much ETH is preparing to be matched by pending bond reductions
 func CheckCollateral(rp *rocketpool.RocketPool, nodeAddress common.Address, opts *bind.CallOpts) (ethMatched *big.Int, ethMatchedLimit *big.Int, pendingMatchAmount *big.Int, err error) {
 	// Get the node's minipool addresses
@@ -116,7 +153,7 @@ func CheckCollateral(rp *rocketpool.RocketPool, nodeAddress common.Address, opts
 	})
 	wg.Go(func() error {
 		var err error
-		ethMatchedLimit, err = node.GetNodeEthMatchedLimit(rp, nodeAddress, opts)
+		ethMatchedLimit, err = GetNodeEthMatchedLimit(rp, nodeAddress, opts)
 		if err != nil {
 			return fmt.Errorf("error getting how much ETH the node is able to borrow: %w", err)
 		}
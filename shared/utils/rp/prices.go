@@ -0,0 +1,77 @@
+package rp
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+
+	"github.com/rocket-pool/smartnode/shared/services/logscan"
+)
+
+// Get the RPL prices (in ETH) the Oracle DAO has submitted over the last few days, oldest first
+func GetSubmittedRplPrices(rp *rocketpool.RocketPool, days uint64, intervalSize *big.Int) ([]float64, error) {
+
+	// Get the network prices contract
+	rocketNetworkPrices, err := rp.GetContract("rocketNetworkPrices", nil)
+	if err != nil {
+		return nil, err
+	}
+	pricesUpdated, exists := rocketNetworkPrices.ABI.Events["PricesUpdated"]
+	if !exists {
+		return nil, fmt.Errorf("rocketNetworkPrices does not have a PricesUpdated event")
+	}
+
+	// Get the logs for the window
+	currentBlock, err := rp.Client.BlockNumber(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("error getting latest block number: %w", err)
+	}
+	fromBlock := uint64(0)
+	if currentBlock > days*blocksPerDay {
+		fromBlock = currentBlock - days*blocksPerDay
+	}
+	logs, err := logscan.GetLogs(rp, []common.Address{*rocketNetworkPrices.Address}, [][]common.Hash{{pricesUpdated.ID}}, intervalSize, big.NewInt(0).SetUint64(fromBlock), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting submitted RPL prices: %w", err)
+	}
+
+	// Get the prices
+	prices := []float64{}
+	for _, log := range logs {
+		values := make(map[string]interface{})
+		if err := pricesUpdated.Inputs.UnpackIntoMap(values, log.Data); err != nil {
+			return nil, fmt.Errorf("error unpacking price update in block %d: %w", log.BlockNumber, err)
+		}
+		if price, ok := values["rplPrice"].(*big.Int); ok && price.Sign() > 0 {
+			prices = append(prices, eth.WeiToEth(price))
+		}
+	}
+	return prices, nil
+
+}
+
+// Get the volatility of the RPL price between consecutive price updates, as the standard deviation of the log returns.
+// Returns 0 if there aren't enough prices to measure it.
+func GetRplPriceVolatility(prices []float64) float64 {
+	if len(prices) < 3 {
+		return 0
+	}
+	returns := make([]float64, len(prices)-1)
+	mean := 0.0
+	for i := 1; i < len(prices); i++ {
+		returns[i-1] = math.Log(prices[i] / prices[i-1])
+		mean += returns[i-1]
+	}
+	mean /= float64(len(returns))
+	variance := 0.0
+	for _, r := range returns {
+		variance += (r - mean) * (r - mean)
+	}
+	variance /= float64(len(returns) - 1)
+	return math.Sqrt(variance)
+}
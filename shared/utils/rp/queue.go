@@ -0,0 +1,73 @@
+package rp
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+
+	"github.com/rocket-pool/smartnode/shared/services/logscan"
+)
+
+// Get the average amount of ETH staked into the deposit pool per day over the last few days
+func GetTrailingDepositRate(rp *rocketpool.RocketPool, days uint64, intervalSize *big.Int) (float64, error) {
+
+	// Get the deposit pool contract
+	rocketDepositPool, err := rp.GetContract("rocketDepositPool", nil)
+	if err != nil {
+		return 0, err
+	}
+	depositReceived, exists := rocketDepositPool.ABI.Events["DepositReceived"]
+	if !exists {
+		return 0, fmt.Errorf("rocketDepositPool does not have a DepositReceived event")
+	}
+
+	// Get the logs for the window
+	currentBlock, err := rp.Client.BlockNumber(context.Background())
+	if err != nil {
+		return 0, fmt.Errorf("error getting latest block number: %w", err)
+	}
+	fromBlock := uint64(0)
+	if currentBlock > days*blocksPerDay {
+		fromBlock = currentBlock - days*blocksPerDay
+	}
+	logs, err := logscan.GetLogs(rp, []common.Address{*rocketDepositPool.Address}, [][]common.Hash{{depositReceived.ID}}, intervalSize, big.NewInt(0).SetUint64(fromBlock), nil)
+	if err != nil {
+		return 0, fmt.Errorf("error getting deposit pool deposits: %w", err)
+	}
+
+	// Add up the deposits
+	total := big.NewInt(0)
+	for _, log := range logs {
+		values := make(map[string]interface{})
+		if err := depositReceived.Inputs.UnpackIntoMap(values, log.Data); err != nil {
+			return 0, fmt.Errorf("error unpacking deposit in block %d: %w", log.BlockNumber, err)
+		}
+		if amount, ok := values["amount"].(*big.Int); ok {
+			total.Add(total, amount)
+		}
+	}
+	return eth.WeiToEth(total) / float64(days), nil
+
+}
+
+// Estimate how long a minipool at the provided position in the queue (starting from 1) will wait to be assigned.
+// The ETH needed by the minipools ahead of it is estimated from the queue's average capacity per minipool.
+// Returns false if the deposit rate is too low to make an estimate.
+func EstimateQueueWait(position uint64, queueLength uint64, queueCapacity *big.Int, depositPoolBalance *big.Int, depositRate float64) (time.Duration, bool) {
+	if queueLength == 0 || position == 0 {
+		return 0, true
+	}
+	ethNeeded := eth.WeiToEth(queueCapacity)*float64(position)/float64(queueLength) - eth.WeiToEth(depositPoolBalance)
+	if ethNeeded <= 0 {
+		return 0, true
+	}
+	if depositRate <= 0 {
+		return 0, false
+	}
+	return time.Duration(ethNeeded / depositRate * float64(24*time.Hour)), true
+}
@@ -0,0 +1,86 @@
+package validator
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/types"
+
+	"github.com/rocket-pool/smartnode/shared/services/contracts"
+	"github.com/rocket-pool/smartnode/shared/services/logscan"
+)
+
+// How many recent blocks to check for deposits the Beacon Chain may not have processed yet (about a week). Older deposits
+// have been processed, so the validator they created shows up on the Beacon Chain.
+const pendingDepositLookback uint64 = 50400
+
+// The topic of the Beacon deposit contract's DepositEvent
+var depositEventID = common.HexToHash("0x649bbc62d0e31342afea4e5cd82d4049e7e1ee912fc0889aa790803be39038c5")
+
+// A deposit made to the Beacon deposit contract
+type BeaconDeposit struct {
+	BlockNumber           uint64
+	TxHash                common.Hash
+	WithdrawalCredentials common.Hash
+	AmountGwei            uint64
+}
+
+// Get the deposits made for a validator pubkey in the recent blocks, which the Beacon Chain may not have processed yet
+func GetPendingDeposits(rp *rocketpool.RocketPool, pubkey types.ValidatorPubkey, intervalSize *big.Int) ([]BeaconDeposit, error) {
+
+	// Get the deposit contract
+	casperAddress, err := rp.GetAddress("casperDeposit", nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting Beacon deposit contract address: %w", err)
+	}
+	depositContract, err := contracts.NewBeaconDeposit(*casperAddress, rp.Client)
+	if err != nil {
+		return nil, fmt.Errorf("error creating Beacon deposit contract binding: %w", err)
+	}
+
+	// Get the range to scan
+	latestBlock, err := rp.Client.BlockNumber(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("error getting latest block number: %w", err)
+	}
+	fromBlock := uint64(0)
+	if latestBlock > pendingDepositLookback {
+		fromBlock = latestBlock - pendingDepositLookback
+	}
+
+	// Find the deposits for the pubkey; it isn't indexed, so every deposit in the range has to be checked
+	deposits := []BeaconDeposit{}
+	scanner := logscan.NewScanner(rp.Client, intervalSize.Uint64())
+	err = scanner.Scan(context.Background(), []common.Address{*casperAddress}, [][]common.Hash{{depositEventID}}, fromBlock, latestBlock, func(logs []ethtypes.Log) error {
+		for _, log := range logs {
+			event, err := depositContract.ParseDepositEvent(log)
+			if err != nil {
+				return fmt.Errorf("error decoding deposit in block %d: %w", log.BlockNumber, err)
+			}
+			if !bytes.Equal(event.Pubkey, pubkey[:]) {
+				continue
+			}
+			deposit := BeaconDeposit{
+				BlockNumber:           log.BlockNumber,
+				TxHash:                log.TxHash,
+				WithdrawalCredentials: common.BytesToHash(event.WithdrawalCredentials),
+			}
+			if len(event.Amount) == 8 {
+				deposit.AmountGwei = binary.LittleEndian.Uint64(event.Amount)
+			}
+			deposits = append(deposits, deposit)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error scanning Beacon deposits: %w", err)
+	}
+	return deposits, nil
+
+}
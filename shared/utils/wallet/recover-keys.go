@@ -14,6 +14,7 @@ import (
 	"github.com/rocket-pool/rocketpool-go/types"
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/progress"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	hexutils "github.com/rocket-pool/smartnode/shared/utils/hex"
@@ -28,7 +29,7 @@ const (
 	bucketLimit uint = 2000
 )
 
-func RecoverMinipoolKeys(c *cli.Context, rp *rocketpool.RocketPool, address common.Address, w *wallet.Wallet, testOnly bool) ([]types.ValidatorPubkey, error) {
+func RecoverMinipoolKeys(c *cli.Context, rp *rocketpool.RocketPool, address common.Address, w *wallet.Wallet, testOnly bool, reporter *progress.Reporter) ([]types.ValidatorPubkey, error) {
 
 	cfg, err := services.GetConfig(c)
 	if err != nil {
@@ -51,9 +52,23 @@ func RecoverMinipoolKeys(c *cli.Context, rp *rocketpool.RocketPool, address comm
 	}
 	pubkeys = filteredPubkeys
 
+	// DVT minipools are operated by the cluster, so their keys are left out of the Validator client
+	dvtSettings, err := config.LoadDvtSettings(cfg.Smartnode.GetDvtSettingsPath())
+	if err != nil {
+		return nil, err
+	}
+	dvtPubkeys := dvtSettings.GetPubkeys()
+	if !testOnly {
+		for _, dvtMinipool := range dvtSettings.Minipools {
+			w.SkipValidatorKeyIndex(dvtMinipool.WalletIndex)
+		}
+	}
+
 	pubkeyMap := map[types.ValidatorPubkey]bool{}
 	for _, pubkey := range pubkeys {
-		pubkeyMap[pubkey] = true
+		if !dvtPubkeys[pubkey] {
+			pubkeyMap[pubkey] = true
+		}
 	}
 
 	pubkeyMap, err = CheckForAndRecoverCustomMinipoolKeys(cfg, pubkeyMap, w, testOnly)
@@ -62,6 +77,8 @@ func RecoverMinipoolKeys(c *cli.Context, rp *rocketpool.RocketPool, address comm
 	}
 
 	// Recover conventionally generated keys
+	reporter.SetPhase("Searching for validator keys", uint64(len(pubkeyMap)))
+	keysToFind := len(pubkeyMap)
 	bucketStart := uint(0)
 	for {
 		if bucketStart >= bucketLimit {
@@ -91,6 +108,7 @@ func RecoverMinipoolKeys(c *cli.Context, rp *rocketpool.RocketPool, address comm
 			}
 		}
 
+		reporter.SetProgress(uint64(keysToFind - len(pubkeyMap)))
 		if len(pubkeyMap) == 0 {
 			// All keys recovered!
 			break